@@ -17,6 +17,7 @@ func newAppsCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			defer ac.Close()
 			s, err := core.LoadSessions(ac.ProjectRoot)
 			if err != nil {
 				return err
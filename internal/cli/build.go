@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/xcbolt/xcbolt/internal/core"
@@ -16,38 +17,73 @@ func newBuildCmd() *cobra.Command {
 	var target string
 	var targetType string
 	var companionTarget string
+	var destination string
+	var force bool
+	var buildTarget string
 
 	cmd := &cobra.Command{
 		Use:   "build",
 		Short: "Build the configured scheme",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if buildTarget != "" && scheme != "" {
+				return fmt.Errorf("use either --build-target or --scheme, not both")
+			}
 			ac, err := NewAppContext(flags)
 			if err != nil {
 				return err
 			}
+			defer ac.Close()
+			ac.Config.TargetOverride = buildTarget
 			if err := applyOverrides(&ac.Config, scheme, configuration, platform, target, targetType, companionTarget); err != nil {
 				return err
 			}
+			applyForce(&ac.Config, force)
 
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
 
-			_, cfg2, err := core.Build(ctx, ac.ProjectRoot, ac.Config, ac.Emitter)
+			if err := applyQueryOverrides(ctx, &ac, configuration, target, destination); err != nil {
+				return err
+			}
+
+			done := trackStatus(&ac, "build")
+			res, cfg2, err := core.Build(ctx, ac.ProjectRoot, ac.Config, ac.Emitter)
+			recordMetrics(ac, cfg2, done(err))
 			persistConfigIfChanged(ac, cfg2)
+			if buildTarget != "" {
+				return err
+			}
+			rememberSchemeDestination(ac, cfg2)
+			if err == nil && !cfg2.Xcodebuild.DryRun {
+				recordBuild(ac, cfg2, res)
+			}
 			return err
 		},
 	}
 
 	cmd.Flags().StringVar(&scheme, "scheme", "", "Override scheme")
-	cmd.Flags().StringVar(&configuration, "configuration", "", "Override configuration (Debug/Release/...)")
+	cmd.Flags().StringVar(&configuration, "configuration", "", "Override configuration (Debug/Release/..., case-insensitive prefix)")
 	cmd.Flags().StringVar(&platform, "platform", "", "Destination platform family (ios|ipados|tvos|visionos|watchos|macos|catalyst)")
 	cmd.Flags().StringVar(&target, "target", "", "Destination ID or exact name")
+	cmd.Flags().StringVar(&destination, "destination", "", "Destination by fuzzy name (e.g. \"15 pro max\"), or \"last\" for the one last used with the scheme")
 	cmd.Flags().StringVar(&targetType, "target-type", "", "Destination target type (simulator|device|local)")
 	cmd.Flags().StringVar(&companionTarget, "companion-target", "", "Companion destination ID/name (watchOS physical runs)")
+	cmd.Flags().BoolVar(&force, "force", false, "Skip the check that the scheme supports the destination's platform")
+	cmd.Flags().StringVar(&buildTarget, "build-target", "", "Build this target (xcodebuild -target) instead of the scheme, e.g. for a quick typecheck")
 
 	return cmd
 }
 
+// recordBuild adds the build to the user state's build history and warns
+// when it looks like an unexpected full rebuild
+func recordBuild(ac AppContext, cfg core.Config, res core.BuildResult) {
+	st, _ := core.LoadState()
+	if check, rebuilt := st.RecordBuild(ac.ProjectRoot, core.NewBuildRecord(cfg, res.CompiledFiles, time.Now())); rebuilt {
+		ac.Emitter.Emit(core.Warn("build", check.Message()))
+	}
+	_ = core.SaveState(st)
+}
+
 func applyOverrides(cfg *core.Config, scheme, configuration, platform, target, targetType, companionTarget string) error {
 	if scheme != "" {
 		cfg.Scheme = scheme
@@ -103,3 +139,78 @@ func applyOverrides(cfg *core.Config, scheme, configuration, platform, target, t
 	}
 	return nil
 }
+
+// applyForce skips the destination compatibility check for this
+// invocation only
+func applyForce(cfg *core.Config, force bool) {
+	if force {
+		cfg.Xcodebuild.SkipDestinationCheck = true
+		cfg.SetFlagOrigin("xcodebuild.skipDestinationCheck")
+	}
+}
+
+// applyQueryOverrides resolves a --configuration prefix against the project's
+// configurations and a fuzzy --destination against the available targets.
+func applyQueryOverrides(ctx context.Context, ac *AppContext, configuration, target, destination string) error {
+	if configuration != "" {
+		resolved, err := core.ResolveConfigurationByQuery(ac.ProjectRoot, ac.Config, configuration)
+		if err != nil {
+			return err
+		}
+		ac.Config.Configuration = resolved
+	}
+	if destination == "" {
+		return nil
+	}
+	if target != "" {
+		return fmt.Errorf("use either --target or --destination, not both")
+	}
+	if strings.EqualFold(strings.TrimSpace(destination), core.DestinationLastToken) {
+		return applyLastDestination(ctx, ac)
+	}
+	cfg, err := core.ResolveDestinationByQuery(ctx, ac.Config, destination, ac.Emitter)
+	if err != nil {
+		return err
+	}
+	ac.Config = cfg
+	return nil
+}
+
+// applyLastDestination points the config at the destination last used with
+// the scheme (--destination last). When there is none or it is gone, the
+// destination is auto-picked instead.
+func applyLastDestination(ctx context.Context, ac *AppContext) error {
+	st, _ := core.LoadState()
+	remembered, ok := st.SchemeDestination(ac.ProjectRoot, ac.Config.Scheme)
+	if !ok {
+		ac.Emitter.Emit(core.Warn("config", fmt.Sprintf("No destination used with scheme %q yet; auto-picking one", ac.Config.Scheme)))
+		ac.Config.Destination = core.AutoDestination(ac.Config.Destination.PlatformFamily)
+		return nil
+	}
+	candidates, err := core.ListDestinationCandidates(ctx, ac.Emitter, ac.Config.Xcodebuild.PreserveLocale)
+	if err != nil {
+		return err
+	}
+	cfg, err := core.ResolveRememberedDestination(ac.Config, candidates, remembered)
+	if err != nil {
+		ac.Emitter.Emit(core.Warn("config", err.Error()+"; auto-picking a destination"))
+		ac.Config.Destination = core.AutoDestination(remembered.PlatformFamily)
+		return nil
+	}
+	ac.Config = cfg
+	return nil
+}
+
+// rememberSchemeDestination records the destination an operation resolved
+// for its scheme, for --destination last and the TUI.
+func rememberSchemeDestination(ac AppContext, cfg core.Config) {
+	if cfg.Scheme == "" || !cfg.Destination.IsResolved() || cfg.Xcodebuild.DryRun {
+		return
+	}
+	st, _ := core.LoadState()
+	if prev, ok := st.SchemeDestination(ac.ProjectRoot, cfg.Scheme); ok && prev == cfg.Destination {
+		return
+	}
+	st.SetSchemeDestination(ac.ProjectRoot, cfg.Scheme, cfg.Destination)
+	_ = core.SaveState(st)
+}
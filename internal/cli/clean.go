@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/xcbolt/xcbolt/internal/core"
 	"github.com/xcbolt/xcbolt/internal/util"
 )
 
@@ -15,6 +17,7 @@ func newCleanCmd() *cobra.Command {
 	var results bool
 	var sessions bool
 	var spmCache bool
+	var allDestinations bool
 
 	cmd := &cobra.Command{
 		Use:   "clean",
@@ -24,6 +27,7 @@ func newCleanCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			defer ac.Close()
 
 			dd := derived || all || (!derived && !results && !sessions && !spmCache && !all)
 			rb := results || all || (!derived && !results && !sessions && !spmCache && !all)
@@ -31,9 +35,19 @@ func newCleanCmd() *cobra.Command {
 			spm := spmCache || all
 
 			if dd {
-				path := filepath.Join(ac.ProjectRoot, ".xcbolt", "DerivedData")
-				_ = util.RemoveAllIfExists(path)
-				fmt.Fprintln(cmd.OutOrStdout(), "Removed", path)
+				base := filepath.Join(ac.ProjectRoot, ".xcbolt", "DerivedData")
+				removed, err := core.CleanDerivedData(base, ac.Config, allDestinations || all)
+				if err != nil {
+					return err
+				}
+				for _, path := range removed {
+					fmt.Fprintln(cmd.OutOrStdout(), "Removed", path)
+				}
+				if len(removed) > 0 {
+					st, _ := core.LoadState()
+					st.RecordClean(ac.ProjectRoot, time.Now())
+					_ = core.SaveState(st)
+				}
 			}
 			if rb {
 				path := filepath.Join(ac.ProjectRoot, ".xcbolt", "Results")
@@ -61,6 +75,7 @@ func newCleanCmd() *cobra.Command {
 
 	cmd.Flags().BoolVar(&all, "all", false, "Remove everything under .xcbolt")
 	cmd.Flags().BoolVar(&derived, "derived-data", false, "Remove .xcbolt/DerivedData")
+	cmd.Flags().BoolVar(&allDestinations, "all-destinations", false, "With splitDerivedDataByDestination, remove every destination's DerivedData instead of only the active one")
 	cmd.Flags().BoolVar(&results, "results", false, "Remove .xcbolt/Results")
 	cmd.Flags().BoolVar(&sessions, "sessions", false, "Remove .xcbolt/sessions.json")
 	cmd.Flags().BoolVar(&spmCache, "spm-cache", false, "Remove SwiftPM caches (~/Library/Caches/org.swift.swiftpm, ~/Library/Developer/Xcode/SourcePackages)")
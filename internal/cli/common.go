@@ -2,7 +2,10 @@ package cli
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/xcbolt/xcbolt/internal/core"
 	"github.com/xcbolt/xcbolt/internal/util"
@@ -10,6 +13,7 @@ import (
 
 type GlobalFlags struct {
 	JSON              bool
+	Output            string
 	EventVersion      int
 	Config            string
 	Project           string
@@ -17,6 +21,10 @@ type GlobalFlags struct {
 	LogFormat         string
 	LogFormatArgs     []string
 	UseXcodebuildList bool
+	ListTestPlans     bool
+	StatusPort        int
+	StatusBind        string
+	SummaryPath       string
 }
 
 func resolveProjectRoot(projectFlag string) (string, error) {
@@ -37,6 +45,17 @@ type AppContext struct {
 	Config      core.Config
 	Emitter     core.Emitter
 	Flags       GlobalFlags
+	// closer releases the emitter (the compact output's log file)
+	closer io.Closer
+}
+
+// Close releases the emitter; commands defer it once NewAppContext
+// succeeds.
+func (ac AppContext) Close() error {
+	if ac.closer == nil {
+		return nil
+	}
+	return ac.closer.Close()
 }
 
 func NewAppContext(flags GlobalFlags) (AppContext, error) {
@@ -50,30 +69,59 @@ func NewAppContext(flags GlobalFlags) (AppContext, error) {
 	}
 	if flags.LogFormat != "" {
 		cfg.Xcodebuild.LogFormat = flags.LogFormat
+		cfg.SetFlagOrigin("xcodebuild.logFormat")
 	}
 	if len(flags.LogFormatArgs) > 0 {
 		cfg.Xcodebuild.LogFormatArgs = flags.LogFormatArgs
+		cfg.SetFlagOrigin("xcodebuild.logFormatArgs")
 	}
 	emit := core.Emitter(core.NewTextEmitter(os.Stdout))
+	var closer io.Closer
+	switch flags.Output {
+	case "", core.OutputText:
+	case core.OutputCompact:
+		logPath := filepath.Join(cfg.ResultBundlesPath, time.Now().Format("20060102-150405")+core.OutputLogSuffix)
+		compact := core.NewCompactEmitter(os.Stdout, logPath, isTerminal(os.Stdout))
+		emit, closer = compact, compact
+	default:
+		return AppContext{}, fmt.Errorf("unknown --output %q (text|compact)", flags.Output)
+	}
 	if flags.JSON {
 		if flags.EventVersion != core.EventSchemaVersion {
 			return AppContext{}, fmt.Errorf("unsupported --event-version %d (supported: %d)", flags.EventVersion, core.EventSchemaVersion)
 		}
 		emit = core.NewNDJSONEmitter(os.Stdout, flags.EventVersion)
 	}
+	redactor, redactErrs := core.NewRedactor(cfg.Redact)
+	emit = core.RedactEmitter(emit, redactor)
+	for _, err := range redactErrs {
+		emit.Emit(core.Warn("config", err.Error()))
+	}
 	cfgPath := flags.Config
 	if cfgPath == "" {
 		cfgPath = core.ConfigPath(root)
 	}
+	if problems, err := core.CheckConfig(root, cfgPath); err == nil {
+		for _, p := range problems {
+			emit.Emit(core.Warn("config", p.String()))
+		}
+	}
 	return AppContext{
 		ProjectRoot: root,
 		ConfigPath:  cfgPath,
 		Config:      cfg,
 		Emitter:     emit,
 		Flags:       flags,
+		closer:      closer,
 	}, nil
 }
 
+// isTerminal reports whether f is a terminal rather than a pipe or file
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
 func PrintFatal(err error) {
 	if ee, ok := err.(ExitError); ok {
 		fmt.Fprintln(os.Stderr, ee.Error())
@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// completionTimeout bounds dynamic completion; the shell gets nothing
+// rather than a hang
+const completionTimeout = 200 * time.Millisecond
+
+func newCompletionsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "completions bash|zsh|fish",
+		Short: "Print a shell completion script",
+		Long: `Print a completion script for bash, zsh, or fish. Besides commands and flags,
+it completes --scheme, --configuration, --destination, and --target with the
+project's values, read from the context cached by the last discovery.
+
+  xcbolt completions zsh > "${fpath[1]}/_xcbolt"
+  xcbolt completions bash > /usr/local/etc/bash_completion.d/xcbolt
+  xcbolt completions fish > ~/.config/fish/completions/xcbolt.fish`,
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: []string{"bash", "zsh", "fish"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := cmd.OutOrStdout()
+			switch args[0] {
+			case "bash":
+				return cmd.Root().GenBashCompletionV2(out, true)
+			case "zsh":
+				return cmd.Root().GenZshCompletion(out)
+			case "fish":
+				return cmd.Root().GenFishCompletion(out, true)
+			}
+			return fmt.Errorf("unsupported shell %q (bash, zsh, or fish)", args[0])
+		},
+	}
+}
+
+// Values completed for flags, by kind
+const (
+	completeSchemes        = "schemes"
+	completeConfigurations = "configurations"
+	completeDestinations   = "destinations"
+	completeTargets        = "targets"
+)
+
+// valueCompletionFlags maps the flags completed with project values to the
+// kind of value
+var valueCompletionFlags = map[string]string{
+	"scheme":           completeSchemes,
+	"configuration":    completeConfigurations,
+	"destination":      completeDestinations,
+	"target":           completeTargets,
+	"companion-target": completeTargets,
+}
+
+// registerCompletions adds flag value completion to cmd and its
+// subcommands: project values for the flags in valueCompletionFlags, and
+// the fixed choices of --platform and --target-type.
+func registerCompletions(cmd *cobra.Command) {
+	for name, kind := range valueCompletionFlags {
+		if cmd.Flags().Lookup(name) == nil {
+			continue
+		}
+		_ = cmd.RegisterFlagCompletionFunc(name, func(*cobra.Command, []string, string) ([]cobra.Completion, cobra.ShellCompDirective) {
+			return completionValues(flags.Project, kind), cobra.ShellCompDirectiveNoFileComp
+		})
+	}
+	if cmd.Flags().Lookup("platform") != nil {
+		_ = cmd.RegisterFlagCompletionFunc("platform", cobra.FixedCompletions(
+			[]cobra.Completion{"ios", "ipados", "tvos", "visionos", "watchos", "macos", "catalyst"}, cobra.ShellCompDirectiveNoFileComp))
+	}
+	if cmd.Flags().Lookup("target-type") != nil {
+		_ = cmd.RegisterFlagCompletionFunc("target-type", cobra.FixedCompletions(
+			[]cobra.Completion{"simulator", "device", "local"}, cobra.ShellCompDirectiveNoFileComp))
+	}
+	for _, sub := range cmd.Commands() {
+		registerCompletions(sub)
+	}
+}
+
+// completionValues returns the project's values of a kind within
+// completionTimeout, or none
+func completionValues(projectFlag, kind string) []cobra.Completion {
+	done := make(chan []cobra.Completion, 1)
+	go func() {
+		root, err := resolveProjectRoot(projectFlag)
+		if err != nil {
+			done <- nil
+			return
+		}
+		done <- lookupCompletionValues(root, kind)
+	}()
+	select {
+	case values := <-done:
+		return values
+	case <-time.After(completionTimeout):
+		return nil
+	}
+}
+
+// lookupCompletionValues reads values from the context cached within
+// core.ContextCacheTTL. Without one, schemes and configurations fall back
+// to the configured ones, and destinations to "last"; nothing shells out.
+func lookupCompletionValues(projectRoot, kind string) []cobra.Completion {
+	info, ok := core.ReadContextCache(projectRoot, core.ContextCacheTTL)
+	if !ok {
+		cfg, err := core.LoadConfig(projectRoot, flags.Config)
+		if err != nil {
+			return nil
+		}
+		info = core.ContextInfo{}
+		if cfg.Scheme != "" {
+			info.Schemes = []string{cfg.Scheme}
+		}
+		if cfg.Configuration != "" {
+			info.Configurations = []string{cfg.Configuration}
+		}
+	}
+
+	var values []cobra.Completion
+	switch kind {
+	case completeSchemes:
+		values = append(values, info.Schemes...)
+	case completeConfigurations:
+		values = append(values, info.Configurations...)
+	case completeDestinations:
+		values = append(values, "last")
+		for _, sim := range info.Simulators {
+			if sim.Available && !slices.Contains(values, sim.Name) {
+				values = append(values, sim.Name)
+			}
+		}
+		for _, dev := range info.Devices {
+			if !slices.Contains(values, dev.Name) {
+				values = append(values, dev.Name)
+			}
+		}
+	case completeTargets:
+		for _, sim := range info.Simulators {
+			if sim.Available {
+				values = append(values, cobra.CompletionWithDesc(sim.UDID, sim.Name+" ("+sim.OSVersion+")"))
+			}
+		}
+		for _, dev := range info.Devices {
+			values = append(values, cobra.CompletionWithDesc(dev.Identifier, dev.Name+" ("+dev.OSVersion+")"))
+		}
+	}
+	return values
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func TestLookupCompletionValuesFromCache(t *testing.T) {
+	root := t.TempDir()
+	err := core.WriteContextCache(root, core.ContextInfo{
+		Schemes:        []string{"App", "AppTests"},
+		Configurations: []string{"Debug", "Release"},
+		Simulators: []core.Simulator{
+			{Name: "iPhone 15", UDID: "SIM-A", OSVersion: "17.5", Available: true},
+			{Name: "iPhone 15", UDID: "SIM-B", OSVersion: "18.0", Available: true},
+			{Name: "Old Phone", UDID: "SIM-C", OSVersion: "12.0"},
+		},
+		Devices: []core.Device{{Name: "Max's iPhone", Identifier: "DEV-1", OSVersion: "18.1"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := lookupCompletionValues(root, completeSchemes); !reflect.DeepEqual(got, []string{"App", "AppTests"}) {
+		t.Fatalf("schemes = %q", got)
+	}
+	if got := lookupCompletionValues(root, completeDestinations); !reflect.DeepEqual(got, []string{"last", "iPhone 15", "Max's iPhone"}) {
+		t.Fatalf("destinations = %q", got)
+	}
+	want := []string{"SIM-A\tiPhone 15 (17.5)", "SIM-B\tiPhone 15 (18.0)", "DEV-1\tMax's iPhone (18.1)"}
+	if got := lookupCompletionValues(root, completeTargets); !reflect.DeepEqual(got, want) {
+		t.Fatalf("targets = %q", got)
+	}
+}
+
+func TestLookupCompletionValuesWithoutCache(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	root := t.TempDir()
+	if got := lookupCompletionValues(root, completeSchemes); len(got) != 0 {
+		t.Fatalf("schemes without cache or config = %q", got)
+	}
+
+	cfg := core.DefaultConfig(root)
+	cfg.Scheme = "App"
+	if err := core.SaveConfig(root, "", cfg); err != nil {
+		t.Fatal(err)
+	}
+	// A stale cache is ignored in favor of the config
+	stale := `{"savedAt": "2020-01-01T00:00:00Z", "context": {"schemes": ["Old"]}}`
+	if err := os.WriteFile(core.ContextCachePath(root), []byte(stale), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := lookupCompletionValues(root, completeSchemes); !reflect.DeepEqual(got, []string{"App"}) {
+		t.Fatalf("schemes = %q, want the configured one", got)
+	}
+	if got := lookupCompletionValues(root, completeTargets); len(got) != 0 {
+		t.Fatalf("targets without cache = %q", got)
+	}
+}
+
+func TestCompletionsScriptsCallComplete(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		cmd := newCompletionsCmd()
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetArgs([]string{shell})
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("%s: %v", shell, err)
+		}
+		if !strings.Contains(out.String(), "__complete") {
+			t.Fatalf("%s script does not request dynamic completions", shell)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 
@@ -46,6 +47,7 @@ func newConfigCmd() *cobra.Command {
 					Flags:       flags,
 				}
 			}
+			defer ac.Close()
 
 			if migrate {
 				res, err := core.MigrateConfig(ac.ProjectRoot, ac.ConfigPath)
@@ -87,5 +89,98 @@ func newConfigCmd() *cobra.Command {
 
 	cmd.Flags().BoolVar(&edit, "edit", false, "Open config in $EDITOR")
 	cmd.Flags().BoolVar(&migrate, "migrate", false, "Migrate config to the latest schema version")
+	cmd.AddCommand(newConfigValidateCmd())
+	cmd.AddCommand(newConfigShowCmd())
 	return cmd
 }
+
+func newConfigShowCmd() *cobra.Command {
+	var origin bool
+
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Print the effective config (global, project, and flags merged)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ac, err := NewAppContext(flags)
+			if err != nil {
+				return err
+			}
+			defer ac.Close()
+			if !origin {
+				b, _ := json.MarshalIndent(ac.Config, "", "  ")
+				fmt.Fprintln(cmd.OutOrStdout(), string(b))
+				return nil
+			}
+			settings, err := core.ConfigOrigins(ac.Config)
+			if err != nil {
+				return err
+			}
+			if ac.Flags.JSON {
+				ac.Emitter.Emit(core.Event{Cmd: "config", Type: "config_origins", Data: settings})
+				return nil
+			}
+			writeConfigOrigins(cmd.OutOrStdout(), settings)
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&origin, "origin", false, "Show where each value comes from (default, global, project, or flag)")
+	return cmd
+}
+
+// writeConfigOrigins prints one aligned "key value origin" line per setting
+func writeConfigOrigins(w io.Writer, settings []core.ConfigSetting) {
+	keyWidth, valueWidth := 0, 0
+	for _, s := range settings {
+		keyWidth = max(keyWidth, len(s.Key))
+		valueWidth = max(valueWidth, min(len(s.Value), 48))
+	}
+	for _, s := range settings {
+		origin := s.Origin
+		if s.Path != "" {
+			origin += " (" + s.Path + ")"
+		}
+		fmt.Fprintf(w, "%-*s  %-*s  %s\n", keyWidth, s.Key, valueWidth, s.Value, origin)
+	}
+}
+
+func newConfigValidateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate",
+		Short: "Report unknown and deprecated config keys",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Not NewAppContext: it would warn about the same problems first
+			root, err := resolveProjectRoot(flags.Project)
+			if err != nil {
+				return err
+			}
+			cfgPath := flags.Config
+			if cfgPath == "" {
+				cfgPath = core.ConfigPath(root)
+			}
+			problems, err := core.CheckConfig(root, cfgPath)
+			if err != nil {
+				return err
+			}
+			if flags.JSON {
+				if flags.EventVersion != core.EventSchemaVersion {
+					return fmt.Errorf("unsupported --event-version %d (supported: %d)", flags.EventVersion, core.EventSchemaVersion)
+				}
+				emit := core.NewNDJSONEmitter(cmd.OutOrStdout(), flags.EventVersion)
+				if problems == nil {
+					problems = []core.ConfigProblem{}
+				}
+				emit.Emit(core.Event{Cmd: "config", Type: "config_validation", Data: map[string]any{"path": cfgPath, "problems": problems}})
+			} else if len(problems) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "Config OK:", cfgPath)
+			} else {
+				for _, p := range problems {
+					fmt.Fprintf(cmd.OutOrStdout(), "%s:%d: %s\n", cfgPath, p.Line, p.Message())
+				}
+			}
+			if len(problems) > 0 {
+				return ExitError{Code: 1, Err: fmt.Errorf("%d config problem(s) found", len(problems))}
+			}
+			return nil
+		},
+	}
+}
@@ -19,11 +19,13 @@ func newContextCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			defer ac.Close()
 			ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 			defer cancel()
 
 			info, cfg, err := core.DiscoverContext(ctx, ac.ProjectRoot, ac.Config, ac.Emitter, core.ContextOptions{
 				UseXcodebuildList:     ac.Flags.UseXcodebuildList,
+				ListTestPlans:         ac.Flags.ListTestPlans,
 				AllowXcodebuildList:   true,
 				XcodebuildListTimeout: 5 * time.Second,
 			})
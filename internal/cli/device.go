@@ -24,10 +24,11 @@ func newDeviceCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			defer ac.Close()
 			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 			defer cancel()
 
-			devs, err := core.DevicectlList(ctx, ac.Emitter)
+			devs, err := core.DevicectlList(ctx, ac.Emitter, ac.Config.Xcodebuild.PreserveLocale)
 			if err != nil {
 				return err
 			}
@@ -50,9 +51,10 @@ func newDeviceCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			defer ac.Close()
 			ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
 			defer cancel()
-			return core.DevicectlInstallApp(ctx, args[0], args[1], ac.Emitter)
+			return core.DevicectlInstallApp(ctx, args[0], args[1], ac.Emitter, ac.Config.Xcodebuild.PreserveLocale)
 		},
 	})
 
@@ -67,10 +69,11 @@ func newDeviceCmd() *cobra.Command {
 				if err != nil {
 					return err
 				}
+				defer ac.Close()
 				ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 				defer cancel()
 				info := core.AppBundleInfo{BundleID: args[1]}
-				_, err = core.DevicectlLaunchApp(ctx, args[0], args[1], console, nil, info, false, ac.Emitter)
+				_, err = core.DevicectlLaunchApp(ctx, args[0], args[1], console, nil, info, false, false, ac.Emitter, ac.Config.Xcodebuild.PreserveLocale)
 				return err
 			},
 		}
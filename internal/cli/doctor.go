@@ -13,16 +13,17 @@ import (
 func newDoctorCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "doctor",
-		Short: "Diagnose common environment problems (Xcode, simctl, devicectl, xcresulttool)",
+		Short: "Diagnose environment and project problems (Xcode, simulators, scheme, destination, build dirs)",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ac, err := NewAppContext(flags)
 			if err != nil {
 				return err
 			}
+			defer ac.Close()
 			ctx, cancel := context.WithTimeout(context.Background(), 45*time.Second)
 			defer cancel()
 
-			rep, err := core.Doctor(ctx, ac.ProjectRoot, ac.Emitter)
+			rep, err := core.Doctor(ctx, ac.ProjectRoot, ac.Config, ac.Emitter)
 			if err != nil {
 				return err
 			}
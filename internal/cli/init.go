@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"path/filepath"
 	"strings"
 	"time"
 
@@ -15,6 +14,14 @@ import (
 
 func newInitCmd() *cobra.Command {
 	var nonInteractive bool
+	var scheme string
+	var configuration string
+	var platform string
+	var targetType string
+	var target string
+	var destination string
+	var workspace string
+	var project string
 
 	cmd := &cobra.Command{
 		Use:   "init",
@@ -60,12 +67,22 @@ func newInitCmd() *cobra.Command {
 					return err
 				}
 			}
+			defer ac.Close()
+			if workspace != "" && project != "" {
+				return fmt.Errorf("use either --workspace or --xcodeproj, not both")
+			}
+			if workspace != "" {
+				core.ContainerCandidate{Path: workspace, Workspace: true}.Apply(&ac.Config)
+			} else if project != "" {
+				core.ContainerCandidate{Path: project}.Apply(&ac.Config)
+			}
 			ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 			defer cancel()
 
 			ac.Emitter.Emit(core.Status("init", "Loading project context…", nil))
 			info, cfg, err := core.DiscoverContext(ctx, ac.ProjectRoot, ac.Config, ac.Emitter, core.ContextOptions{
 				UseXcodebuildList:     ac.Flags.UseXcodebuildList,
+				ListTestPlans:         ac.Flags.ListTestPlans,
 				AllowXcodebuildList:   true,
 				XcodebuildListTimeout: 5 * time.Second,
 			})
@@ -73,25 +90,17 @@ func newInitCmd() *cobra.Command {
 				return err
 			}
 
-			if nonInteractive || ac.Flags.JSON {
-				// Non-interactive best-effort defaults.
-				cfg = pickInitDefaults(info, cfg)
-				if nonInteractive {
-					if cfg.Workspace == "" && cfg.Project == "" {
-						return ExitError{Code: 2, Err: fmt.Errorf("no workspace/project detected")}
-					}
-					if cfg.Scheme == "" {
-						return ExitError{Code: 3, Err: fmt.Errorf("no scheme detected")}
-					}
-					if cfg.Configuration == "" {
-						return ExitError{Code: 4, Err: fmt.Errorf("no build configuration detected")}
-					}
-				}
-				if err := core.SaveConfig(ac.ProjectRoot, ac.ConfigPath, cfg); err != nil {
-					return err
-				}
-				ac.Emitter.Emit(core.Result("init", true, map[string]any{"config": ac.ConfigPath}))
-				return nil
+			headless := nonInteractive || ac.Flags.JSON || workspace != "" || project != "" || scheme != "" || configuration != "" ||
+				platform != "" || targetType != "" || target != "" || destination != ""
+			if headless {
+				return runHeadlessInit(ctx, cmd, ac, info, cfg, initSelection{
+					scheme:        scheme,
+					configuration: configuration,
+					platform:      platform,
+					targetType:    targetType,
+					target:        target,
+					destination:   destination,
+				})
 			}
 
 			cfg, err = runInitWizard(info, cfg)
@@ -108,17 +117,108 @@ func newInitCmd() *cobra.Command {
 		},
 	}
 	cmd.Flags().BoolVar(&nonInteractive, "non-interactive", false, "Use defaults without prompts (CI-friendly)")
+	cmd.Flags().StringVar(&workspace, "workspace", "", "Workspace to save, relative to the project root (skips the wizard)")
+	cmd.Flags().StringVar(&project, "xcodeproj", "", "Project to save, relative to the project root (skips the wizard)")
+	cmd.Flags().StringVar(&scheme, "scheme", "", "Scheme to save (skips the wizard)")
+	cmd.Flags().StringVar(&configuration, "configuration", "", "Configuration to save (case-insensitive prefix)")
+	cmd.Flags().StringVar(&platform, "platform", "", "Destination platform family (ios|ipados|tvos|visionos|watchos|macos|catalyst)")
+	cmd.Flags().StringVar(&targetType, "target-type", "", "Destination target type (simulator|device|local)")
+	cmd.Flags().StringVar(&target, "target", "", "Destination ID or exact name")
+	cmd.Flags().StringVar(&destination, "destination", "", "Destination by fuzzy name (e.g. \"15 pro max\")")
 	return cmd
 }
 
-func pickInitDefaults(info core.ContextInfo, cfg core.Config) core.Config {
-	// workspace > project
-	if cfg.Workspace == "" && len(info.Workspaces) > 0 {
-		cfg.Workspace = filepath.Base(info.Workspaces[0])
+// initSelection holds the init flags that pin config values.
+type initSelection struct {
+	scheme        string
+	configuration string
+	platform      string
+	targetType    string
+	target        string
+	destination   string
+}
+
+// initExitCodes maps validation error codes to process exit codes.
+var initExitCodes = map[string]int{
+	"PROJECT_REQUIRED":        2,
+	"PROJECT_NOT_FOUND":       2,
+	"PROJECT_AMBIGUOUS":       2,
+	"SCHEME_REQUIRED":         3,
+	"SCHEME_NOT_FOUND":        3,
+	"CONFIGURATION_REQUIRED":  4,
+	"CONFIGURATION_NOT_FOUND": 4,
+	"DESTINATION_NOT_FOUND":   5,
+}
+
+// runHeadlessInit applies flag selections on top of detected defaults,
+// validates them the same way the TUI wizard does and writes the config.
+func runHeadlessInit(ctx context.Context, cmd *cobra.Command, ac AppContext, info core.ContextInfo, cfg core.Config, sel initSelection) error {
+	fail := func(code string, err error) error {
+		obj := core.ErrorObject{Code: code, Message: err.Error()}
+		var ierr *core.InitError
+		if errors.As(err, &ierr) {
+			obj = ierr.Obj
+		}
+		ac.Emitter.Emit(core.Err("init", obj))
+		exit := initExitCodes[obj.Code]
+		if exit == 0 {
+			exit = 1
+		}
+		return ExitError{Code: exit, Err: err}
+	}
+
+	if sel.target != "" && sel.destination != "" {
+		return fail("INVALID_FLAGS", fmt.Errorf("use either --target or --destination, not both"))
+	}
+	if sel.platform != "" || sel.targetType != "" || sel.target != "" || sel.destination != "" {
+		// New destination flags replace any previously pinned destination.
+		cfg.Destination = core.Destination{Kind: core.DestAuto, TargetType: core.TargetAuto}
+	}
+	if err := applyOverrides(&cfg, sel.scheme, sel.configuration, sel.platform, sel.target, sel.targetType, ""); err != nil {
+		return fail("INVALID_FLAGS", err)
+	}
+	cfg = pickInitDefaults(info, cfg)
+
+	candidates := core.InitDestinationCandidates(info)
+	if sel.destination != "" {
+		resolved, err := core.ResolveDestinationQuery(cfg, candidates, sel.destination)
+		if err != nil {
+			return fail("DESTINATION_NOT_FOUND", err)
+		}
+		cfg = resolved
+	}
+
+	cfg, err := core.ValidateInitConfig(info, cfg, candidates)
+	if err != nil {
+		return fail("", err)
 	}
-	if cfg.Project == "" && cfg.Workspace == "" && len(info.Projects) > 0 {
-		cfg.Project = filepath.Base(info.Projects[0])
+	if err := core.SaveConfig(ac.ProjectRoot, ac.ConfigPath, cfg); err != nil {
+		return err
 	}
+
+	if ac.Flags.JSON {
+		ac.Emitter.Emit(core.Result("init", true, map[string]any{
+			"config":        ac.ConfigPath,
+			"workspace":     cfg.Workspace,
+			"project":       cfg.Project,
+			"scheme":        cfg.Scheme,
+			"configuration": cfg.Configuration,
+			"destination":   cfg.Destination,
+		}))
+		return nil
+	}
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Wrote %s\n", ac.ConfigPath)
+	for _, line := range core.InitSummary(cfg) {
+		fmt.Fprintf(out, "  %s\n", line)
+	}
+	return nil
+}
+
+// pickInitDefaults fills in the first scheme and configuration. Discovery
+// already picked the workspace or project unless that was ambiguous, which
+// then takes --workspace or --xcodeproj.
+func pickInitDefaults(info core.ContextInfo, cfg core.Config) core.Config {
 	if cfg.Scheme == "" && len(info.Schemes) > 0 {
 		cfg.Scheme = info.Schemes[0]
 	}
@@ -145,11 +245,13 @@ func runInitWizard(info core.ContextInfo, cfg core.Config) (core.Config, error)
 	}
 
 	projOpts := []huh.Option[string]{}
-	for _, w := range info.Workspaces {
-		projOpts = append(projOpts, huh.NewOption("Workspace: "+w, "workspace:"+w))
-	}
-	for _, p := range info.Projects {
-		projOpts = append(projOpts, huh.NewOption("Project: "+p, "project:"+p))
+	// Best first, so Pods and sample apps end up at the bottom
+	for _, c := range core.RankContainers(info) {
+		label := "Project: "
+		if c.Workspace {
+			label = "Workspace: "
+		}
+		projOpts = append(projOpts, huh.NewOption(label+c.Path, c.Kind()+":"+c.Path))
 	}
 	if len(projOpts) == 0 {
 		projOpts = append(projOpts, huh.NewOption("(No workspace/project detected)", ""))
@@ -22,6 +22,7 @@ func newLogsCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			defer ac.Close()
 			if err := applyOverrides(&ac.Config, "", "", platform, target, targetType, ""); err != nil {
 				return err
 			}
@@ -42,8 +43,9 @@ func newLogsCmd() *cobra.Command {
 					args = append(args, "--predicate", predicate)
 				}
 				_, err := core.RunStreaming(ctx, core.CmdSpec{
-					Path: "xcrun",
-					Args: args,
+					Path:           "xcrun",
+					Args:           args,
+					PreserveLocale: ac.Config.Xcodebuild.PreserveLocale,
 					StdoutLine: func(s string) {
 						if ac.Flags.JSON {
 							ac.Emitter.Emit(core.Log("logs", s))
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func newMetricsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "metrics",
+		Short: "Work with the opt-in build metrics log (metrics.enabled)",
+	}
+	cmd.AddCommand(newMetricsExportCmd())
+	return cmd
+}
+
+func newMetricsExportCmd() *cobra.Command {
+	var since string
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Print metrics log entries as NDJSON",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, err := resolveProjectRoot(flags.Project)
+			if err != nil {
+				return err
+			}
+			cfg, err := core.LoadConfig(root, flags.Config)
+			if err != nil {
+				return err
+			}
+			var from time.Time
+			if since != "" {
+				if from, err = core.ParseSince(since, time.Now()); err != nil {
+					return err
+				}
+			}
+			entries, err := core.ReadMetrics(core.MetricsPath(root, cfg), from)
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			for _, e := range entries {
+				b, err := json.Marshal(e)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(out, string(b))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&since, "since", "", "Only entries from this window back, e.g. 7d or 12h")
+	return cmd
+}
+
+// recordMetrics appends a finished op to the metrics log, best effort
+func recordMetrics(ac AppContext, cfg core.Config, run core.LastRun) {
+	if cfg.Scheme == "" {
+		cfg = ac.Config
+	}
+	_ = core.RecordMetrics(ac.ProjectRoot, cfg, run)
+}
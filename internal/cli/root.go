@@ -21,14 +21,8 @@ var (
 			if err != nil {
 				return err
 			}
-			overrides := tui.ConfigOverrides{
-				LogFormat:         flags.LogFormat,
-				LogFormatArgs:     flags.LogFormatArgs,
-				HasLogFormat:      flags.LogFormat != "",
-				HasLogFormatArgs:  len(flags.LogFormatArgs) > 0,
-				UseXcodebuildList: flags.UseXcodebuildList,
-			}
-			return tui.Run(ac.ProjectRoot, ac.ConfigPath, overrides)
+			defer ac.Close()
+			return tui.Run(ac.ProjectRoot, ac.ConfigPath, tuiOverrides(flags))
 		},
 	}
 )
@@ -38,13 +32,18 @@ func Execute() {
 	rootCmd.SetErr(os.Stderr)
 
 	rootCmd.PersistentFlags().BoolVar(&flags.JSON, "json", false, "Emit NDJSON event stream to stdout")
+	rootCmd.PersistentFlags().StringVar(&flags.Output, "output", core.OutputText, "Human output: text, or compact (one status line, errors and warnings, and a summary; full log in .xcbolt/Results)")
 	rootCmd.PersistentFlags().IntVar(&flags.EventVersion, "event-version", core.EventSchemaVersion, "NDJSON event schema version")
 	rootCmd.PersistentFlags().StringVar(&flags.Config, "config", "", "Path to config file (default: .xcbolt/config.json)")
 	rootCmd.PersistentFlags().StringVar(&flags.Project, "project", "", "Project directory (default: auto-detected)")
 	rootCmd.PersistentFlags().BoolVar(&flags.Verbose, "verbose", false, "Verbose output")
-	rootCmd.PersistentFlags().StringVar(&flags.LogFormat, "log-format", "", "Log formatter for xcodebuild output (auto|xcpretty|xcbeautify|raw)")
+	rootCmd.PersistentFlags().StringVar(&flags.LogFormat, "log-format", "", "Log formatter for xcodebuild output (auto|xcpretty|xcbeautify|raw|<command>)")
 	rootCmd.PersistentFlags().StringArrayVar(&flags.LogFormatArgs, "log-format-arg", nil, "Additional args for the log formatter (repeatable)")
 	rootCmd.PersistentFlags().BoolVar(&flags.UseXcodebuildList, "xcodebuild-list", false, "Use xcodebuild -list to discover schemes/configurations (may be slow)")
+	rootCmd.PersistentFlags().BoolVar(&flags.ListTestPlans, "test-plans", false, "Discover the scheme's test plans with xcodebuild -showTestPlans (may be slow)")
+	rootCmd.PersistentFlags().IntVar(&flags.StatusPort, "status-port", 0, "Serve TUI status over HTTP on this port (GET /status, GET /events)")
+	rootCmd.PersistentFlags().StringVar(&flags.StatusBind, "status-bind", "127.0.0.1", "Address the status server binds to")
+	rootCmd.PersistentFlags().StringVar(&flags.SummaryPath, "summary-path", "", "Where build, run, and test write their JSON summary (default: .xcbolt/last-run.json)")
 
 	rootCmd.AddCommand(newTUICmd())
 	rootCmd.AddCommand(newInitCmd())
@@ -57,9 +56,18 @@ func Execute() {
 	rootCmd.AddCommand(newCleanCmd())
 	rootCmd.AddCommand(newAppsCmd())
 	rootCmd.AddCommand(newStopCmd())
+	rootCmd.AddCommand(newStatusCmd())
 	rootCmd.AddCommand(newLogsCmd())
 	rootCmd.AddCommand(newSimulatorCmd())
 	rootCmd.AddCommand(newDeviceCmd())
+	rootCmd.AddCommand(newMetricsCmd())
+	rootCmd.AddCommand(newCompletionsCmd())
+
+	// `completions` replaces Cobra's `completion`; its scripts call the
+	// hidden `__complete` command, which completes flag values from the
+	// cached context
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+	registerCompletions(rootCmd)
 
 	if err := rootCmd.Execute(); err != nil {
 		PrintFatal(err)
@@ -75,15 +83,22 @@ func newTUICmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			overrides := tui.ConfigOverrides{
-				LogFormat:         flags.LogFormat,
-				LogFormatArgs:     flags.LogFormatArgs,
-				HasLogFormat:      flags.LogFormat != "",
-				HasLogFormatArgs:  len(flags.LogFormatArgs) > 0,
-				UseXcodebuildList: flags.UseXcodebuildList,
-			}
-			return tui.Run(ac.ProjectRoot, ac.ConfigPath, overrides)
+			defer ac.Close()
+			return tui.Run(ac.ProjectRoot, ac.ConfigPath, tuiOverrides(flags))
 		},
 	}
 	return cmd
 }
+
+func tuiOverrides(flags GlobalFlags) tui.ConfigOverrides {
+	return tui.ConfigOverrides{
+		LogFormat:         flags.LogFormat,
+		LogFormatArgs:     flags.LogFormatArgs,
+		HasLogFormat:      flags.LogFormat != "",
+		HasLogFormatArgs:  len(flags.LogFormatArgs) > 0,
+		UseXcodebuildList: flags.UseXcodebuildList,
+		ListTestPlans:     flags.ListTestPlans,
+		StatusPort:        flags.StatusPort,
+		StatusBind:        flags.StatusBind,
+	}
+}
@@ -2,6 +2,8 @@ package cli
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/xcbolt/xcbolt/internal/core"
@@ -14,7 +16,13 @@ func newRunCmd() *cobra.Command {
 	var target string
 	var targetType string
 	var companionTarget string
+	var destination string
+	var force bool
 	var console bool
+	var product string
+	var preset string
+	var launchArgs []string
+	var launchEnv []string
 
 	cmd := &cobra.Command{
 		Use:   "run",
@@ -24,27 +32,68 @@ func newRunCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			defer ac.Close()
 			if err := applyOverrides(&ac.Config, scheme, configuration, platform, target, targetType, companionTarget); err != nil {
 				return err
 			}
+			applyForce(&ac.Config, force)
 
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
 
-			_, cfg2, err := core.Run(ctx, ac.ProjectRoot, ac.Config, console, ac.Emitter)
+			if err := applyQueryOverrides(ctx, &ac, configuration, target, destination); err != nil {
+				return err
+			}
+
+			if product != "" {
+				ac.Config.Launch.Product = product
+			}
+			env, err := parseLaunchEnv(launchEnv)
+			if err != nil {
+				return err
+			}
+			opts := core.RunOptions{Console: console, Preset: preset, LaunchArgs: launchArgs, LaunchEnv: env}
+
+			done := trackStatus(&ac, "run")
+			emit := core.FilterConsoleLevels(ac.Emitter, ac.Config.Launch.ConsoleLogLevels)
+			_, cfg2, err := core.RunWithOptions(ctx, ac.ProjectRoot, ac.Config, opts, emit)
+			recordMetrics(ac, cfg2, done(err))
 			// Persist auto-selected scheme/config for future runs.
 			persistConfigIfChanged(ac, cfg2)
+			rememberSchemeDestination(ac, cfg2)
 			return err
 		},
 	}
 
 	cmd.Flags().StringVar(&scheme, "scheme", "", "Override scheme")
-	cmd.Flags().StringVar(&configuration, "configuration", "", "Override configuration")
+	cmd.Flags().StringVar(&configuration, "configuration", "", "Override configuration (case-insensitive prefix)")
 	cmd.Flags().StringVar(&platform, "platform", "", "Destination platform family (ios|ipados|tvos|visionos|watchos|macos|catalyst)")
 	cmd.Flags().StringVar(&target, "target", "", "Destination ID or exact name")
+	cmd.Flags().StringVar(&destination, "destination", "", "Destination by fuzzy name (e.g. \"15 pro max\"), or \"last\" for the one last used with the scheme")
 	cmd.Flags().StringVar(&targetType, "target-type", "", "Destination target type (simulator|device|local)")
 	cmd.Flags().StringVar(&companionTarget, "companion-target", "", "Companion destination ID/name (watchOS physical runs)")
+	cmd.Flags().BoolVar(&force, "force", false, "Skip the check that the scheme supports the destination's platform")
+	cmd.Flags().StringVar(&product, "product", "", "Bundle id of the app or app extension to run (overrides launch.product)")
 	cmd.Flags().BoolVar(&console, "console", false, "Attempt to stream app output (simctl --console / devicectl --console)")
+	cmd.Flags().StringVar(&preset, "preset", "", "Launch preset from launch.presets for this run (overrides launch.preset; \"none\" for none)")
+	cmd.Flags().StringArrayVar(&launchArgs, "launch-arg", nil, "Launch argument added after the preset's for this run (repeatable)")
+	cmd.Flags().StringArrayVar(&launchEnv, "launch-env", nil, "KEY=VALUE set over the preset's environment for this run (repeatable)")
 
 	return cmd
 }
+
+// parseLaunchEnv reads --launch-env KEY=VALUE pairs
+func parseLaunchEnv(pairs []string) (map[string]string, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	env := make(map[string]string, len(pairs))
+	for _, p := range pairs {
+		k, v, ok := strings.Cut(p, "=")
+		if !ok || k == "" {
+			return nil, fmt.Errorf("--launch-env %q: want KEY=VALUE", p)
+		}
+		env[k] = v
+	}
+	return env, nil
+}
@@ -25,10 +25,11 @@ func newSimulatorCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			defer ac.Close()
 			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 			defer cancel()
 
-			list, err := core.SimctlList(ctx, ac.Emitter)
+			list, err := core.SimctlList(ctx, ac.Emitter, ac.Config.Xcodebuild.PreserveLocale)
 			if err != nil {
 				return err
 			}
@@ -48,11 +49,14 @@ func newSimulatorCmd() *cobra.Command {
 		Short: "Boot a simulator",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ac, err := NewAppContext(flags)
+			if err != nil {
+				return err
+			}
+			defer ac.Close()
 			ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 			defer cancel()
-			udid := args[0]
-			_ = core.SimctlBoot(ctx, udid)
-			return core.SimctlBootStatus(ctx, udid)
+			return core.BootSimulator(ctx, ac.Config, "simulator", args[0], ac.Emitter)
 		},
 	})
 
@@ -63,7 +67,7 @@ func newSimulatorCmd() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 			defer cancel()
-			return core.SimctlShutdown(ctx, args[0])
+			return core.SimctlShutdown(ctx, args[0], false)
 		},
 	})
 
@@ -74,7 +78,7 @@ func newSimulatorCmd() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
 			defer cancel()
-			return core.SimctlErase(ctx, args[0])
+			return core.SimctlErase(ctx, args[0], false)
 		},
 	})
 
@@ -95,7 +99,7 @@ func newSimulatorCmd() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 			defer cancel()
-			return core.SimctlOpenURL(ctx, args[0], args[1])
+			return core.SimctlOpenURL(ctx, args[0], args[1], false)
 		},
 	})
 
@@ -115,10 +119,11 @@ func newSimulatorCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			defer ac.Close()
 			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 			defer cancel()
 			out = filepath.Join(ac.ProjectRoot, out)
-			return core.SimctlScreenshot(ctx, udid, out)
+			return core.SimctlScreenshot(ctx, udid, out, ac.Config.Xcodebuild.PreserveLocale)
 		},
 	})
 
@@ -129,7 +134,7 @@ func newSimulatorCmd() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 			defer cancel()
-			udid, err := core.SimctlCreate(ctx, args[0], args[1], args[2])
+			udid, err := core.SimctlCreate(ctx, args[0], args[1], args[2], false)
 			if err != nil {
 				return err
 			}
@@ -145,7 +150,7 @@ func newSimulatorCmd() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 			defer cancel()
-			return core.SimctlDelete(ctx, args[0])
+			return core.SimctlDelete(ctx, args[0], false)
 		},
 	})
 
@@ -155,7 +160,7 @@ func newSimulatorCmd() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 			defer cancel()
-			return core.SimctlPrune(ctx)
+			return core.SimctlPrune(ctx, false)
 		},
 	})
 
@@ -0,0 +1,212 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func newStatusCmd() *cobra.Command {
+	var follow bool
+	var interval time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Print a one-line status of the project's current or last operation",
+		Long: "Print the status bar of the TUI or CLI working in this project (scheme, destination,\n" +
+			"running stage or last result, error and warning counts) as one line, read from\n" +
+			".xcbolt/status.json. --follow keeps the line updated, e.g. in a tmux pane.\n" +
+			"Colors are off when NO_COLOR is set.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, err := resolveProjectRoot(flags.Project)
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			if flags.JSON {
+				snap, err := readStatus(root)
+				if err != nil {
+					return err
+				}
+				b, _ := json.Marshal(snap)
+				fmt.Fprintln(out, string(b))
+				return nil
+			}
+			color := os.Getenv("NO_COLOR") == ""
+			if !follow {
+				snap, err := readStatus(root)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(out, core.FormatStatusLine(snap, color))
+				return nil
+			}
+			return followStatus(root, out, color, interval)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "Keep the line updated as the status changes")
+	cmd.Flags().DurationVar(&interval, "interval", 500*time.Millisecond, "How often --follow checks for changes")
+	return cmd
+}
+
+// readStatus reads the status file; without one (nothing ran yet) the
+// configured scheme and destination are shown as idle
+func readStatus(root string) (core.StatusSnapshot, error) {
+	snap, err := core.ReadStatusFile(root)
+	if err == nil {
+		return snap, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return snap, err
+	}
+	snap = core.StatusSnapshot{Project: root}
+	if cfg, err := core.LoadConfig(root, flags.Config); err == nil {
+		snap.Scheme, snap.Destination = cfg.Scheme, cfg.Destination.Name
+	}
+	return snap, nil
+}
+
+// followStatus redraws the status line in place whenever it changes.
+// A file that can't be read (e.g. mid-replace) keeps the previous line.
+func followStatus(root string, out io.Writer, color bool, interval time.Duration) error {
+	if interval <= 0 {
+		interval = 500 * time.Millisecond
+	}
+	last := ""
+	for {
+		if snap, err := readStatus(root); err == nil {
+			if line := core.FormatStatusLine(snap, color); line != last {
+				fmt.Fprint(out, "\r\x1b[K"+line)
+				last = line
+			}
+		}
+		time.Sleep(interval)
+	}
+}
+
+// statusEmitter keeps .xcbolt/status.json current for a CLI op, so
+// `xcbolt status` follows CLI runs as well as the TUI, and records the
+// op's .xcbolt/last-run.json summary
+type statusEmitter struct {
+	next    core.Emitter
+	writer  *core.StatusFileWriter
+	lastRun *core.LastRunRecorder
+
+	mu       sync.Mutex
+	snap     core.StatusSnapshot
+	finished bool
+}
+
+// trackStatus starts recording op in the status file by wrapping
+// ac.Emitter; call the returned func with the op's error when it returns.
+// It also writes the last-run summary (--summary-path) and returns it.
+func trackStatus(ac *AppContext, op string) func(error) core.LastRun {
+	s := &statusEmitter{
+		next:    ac.Emitter,
+		writer:  core.NewStatusFileWriter(ac.ProjectRoot),
+		lastRun: core.NewLastRunRecorder(op),
+		snap: core.StatusSnapshot{
+			Project:     ac.ProjectRoot,
+			Scheme:      ac.Config.Scheme,
+			Destination: ac.Config.Destination.Name,
+			Running:     true,
+			Op:          op,
+		},
+	}
+	s.writer.Publish(s.snap)
+	ac.Emitter = s
+	started := time.Now()
+	return func(err error) core.LastRun {
+		s.mu.Lock()
+		if !s.finished {
+			s.finish(err == nil, time.Since(started))
+		}
+		s.mu.Unlock()
+		s.writer.Close()
+
+		path := ac.Flags.SummaryPath
+		if path == "" {
+			path = core.LastRunPath(ac.ProjectRoot)
+		}
+		run := s.lastRun.Finish(err)
+		if werr := core.WriteLastRun(path, run); werr != nil {
+			s.next.Emit(core.Warn(op, "Could not write the run summary: "+werr.Error()))
+		}
+		return run
+	}
+}
+
+func (s *statusEmitter) Emit(ev core.Event) {
+	s.next.Emit(ev)
+	s.lastRun.Observe(ev)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.finished {
+		return
+	}
+	switch ev.Type {
+	case "status":
+		if ev.Code == core.LaunchPresetCode {
+			data, _ := ev.Data.(map[string]any)
+			s.snap.Preset, _ = data["preset"].(string)
+		} else if ev.Cmd == s.snap.Op {
+			s.snap.Stage = ev.Msg
+		}
+	case "progress":
+		data, _ := ev.Data.(map[string]any)
+		if stage, _ := data["stage"].(string); stage != "" {
+			s.snap.Stage = stage
+		}
+		s.snap.Progress.Current, _ = data["current"].(int)
+		s.snap.Progress.Total, _ = data["total"].(int)
+	case "error":
+		s.snap.ErrorCount++
+	case "warning":
+		s.snap.WarningCount++
+	case "log", "log_raw":
+		switch {
+		case strings.Contains(ev.Msg, ": error: "):
+			s.snap.ErrorCount++
+		case strings.Contains(ev.Msg, ": warning: "):
+			s.snap.WarningCount++
+		}
+	case "result":
+		if ev.Cmd != s.snap.Op {
+			break
+		}
+		ok := false
+		var duration time.Duration
+		if data, _ := ev.Data.(map[string]any); data != nil {
+			ok = data["status"] == "success"
+			if inner, _ := data["data"].(map[string]any); inner != nil {
+				if ms, isInt := inner["durationMs"].(int64); isInt {
+					duration = time.Duration(ms) * time.Millisecond
+				}
+			}
+		}
+		s.finish(ok, duration)
+		return
+	}
+	s.writer.Publish(s.snap)
+}
+
+// finish records the op's result; callers hold s.mu
+func (s *statusEmitter) finish(ok bool, duration time.Duration) {
+	s.finished = true
+	s.snap.Running, s.snap.Stage, s.snap.Preset = false, "", ""
+	s.snap.LastResult = &core.StatusLastResult{Op: s.snap.Op, Success: ok}
+	if duration > 0 {
+		s.snap.LastResult.Duration = duration.Round(100 * time.Millisecond).String()
+	}
+	s.writer.Publish(s.snap)
+}
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+type discardEmitter struct{ events int }
+
+func (d *discardEmitter) Emit(core.Event) { d.events++ }
+
+func TestTrackStatusRecordsCLIOp(t *testing.T) {
+	root := t.TempDir()
+	next := &discardEmitter{}
+	ac := AppContext{ProjectRoot: root, Emitter: next, Config: core.Config{Scheme: "App"}}
+	done := trackStatus(&ac, "build")
+
+	ac.Emitter.Emit(core.Status("build", "Compiling", nil))
+	ac.Emitter.Emit(core.Log("build", "/src/App.swift:3:1: warning: unused variable 'x'"))
+	ac.Emitter.Emit(core.Result("build", true, map[string]any{"durationMs": int64(4200)}))
+	done(nil)
+
+	if next.events != 3 {
+		t.Fatalf("events should pass through, got %d", next.events)
+	}
+	snap, err := core.ReadStatusFile(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snap.Running || snap.WarningCount != 1 || snap.LastResult == nil || !snap.LastResult.Success || snap.LastResult.Duration != "4.2s" {
+		t.Fatalf("unexpected status %+v (%+v)", snap, snap.LastResult)
+	}
+}
+
+func TestTrackStatusFailsWithoutResult(t *testing.T) {
+	root := t.TempDir()
+	ac := AppContext{ProjectRoot: root, Emitter: &discardEmitter{}}
+	done := trackStatus(&ac, "test")
+	time.Sleep(10 * time.Millisecond)
+	done(errors.New("no scheme"))
+
+	snap, err := core.ReadStatusFile(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snap.Running || snap.LastResult == nil || snap.LastResult.Success || snap.LastResult.Op != "test" {
+		t.Fatalf("unexpected status %+v", snap)
+	}
+}
+
+func TestTrackStatusFollowsProgress(t *testing.T) {
+	root := t.TempDir()
+	ac := AppContext{ProjectRoot: root, Emitter: &discardEmitter{}}
+	done := trackStatus(&ac, "build")
+	ac.Emitter.Emit(core.Progress("build", "Compile", 3, 12, "/src/App.swift"))
+
+	s := ac.Emitter.(*statusEmitter)
+	s.mu.Lock()
+	snap := s.snap
+	s.mu.Unlock()
+	if snap.Stage != "Compile" || snap.Progress.Current != 3 || snap.Progress.Total != 12 {
+		t.Fatalf("unexpected status %+v", snap)
+	}
+	done(nil)
+}
+
+func TestTrackStatusWritesLastRun(t *testing.T) {
+	root := t.TempDir()
+	ac := AppContext{ProjectRoot: root, Emitter: &discardEmitter{}}
+	done := trackStatus(&ac, "build")
+	ac.Emitter.Emit(core.Err("build", core.ErrorObject{Code: "XCODEBUILD_FAILED", Message: "xcodebuild failed"}))
+	ac.Emitter.Emit(core.Result("build", false, map[string]any{"exitCode": 65, "durationMs": int64(1500)}))
+	done(errors.New("exit status 65"))
+
+	run, err := core.ReadLastRun(core.LastRunPath(root))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if run.Op != "build" || run.Success || run.ExitCode != 65 || run.DurationMs != 1500 || run.ErrorCode != "XCODEBUILD_FAILED" {
+		t.Fatalf("unexpected summary %+v", run)
+	}
+
+	// --summary-path moves it
+	path := filepath.Join(t.TempDir(), "summary.json")
+	ac = AppContext{ProjectRoot: root, Emitter: &discardEmitter{}, Flags: GlobalFlags{SummaryPath: path}}
+	trackStatus(&ac, "test")(nil)
+	if run, err := core.ReadLastRun(path); err != nil || run.Op != "test" || !run.Success {
+		t.Fatalf("summary at --summary-path: %+v, %v", run, err)
+	}
+}
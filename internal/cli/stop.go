@@ -20,6 +20,7 @@ func newStopCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			defer ac.Close()
 			id := args[0]
 			s, err := core.LoadSessions(ac.ProjectRoot)
 			if err != nil {
@@ -45,8 +46,9 @@ func newStopCmd() *cobra.Command {
 					return errors.New("session missing simulator udid")
 				}
 				_, err := core.RunStreaming(ctx, core.CmdSpec{
-					Path: "xcrun",
-					Args: []string{"simctl", "terminate", sess.UDID, sess.BundleID},
+					Path:           "xcrun",
+					Args:           []string{"simctl", "terminate", sess.UDID, sess.BundleID},
+					PreserveLocale: ac.Config.Xcodebuild.PreserveLocale,
 				})
 				if err != nil {
 					return err
@@ -55,11 +57,11 @@ func newStopCmd() *cobra.Command {
 				if sess.UDID == "" {
 					return errors.New("session missing device udid")
 				}
-				if err := core.DevicectlStop(ctx, sess.UDID, sess.PID, sess.BundleID, ac.Emitter); err != nil {
+				if err := core.DevicectlStop(ctx, sess.UDID, sess.PID, sess.BundleID, ac.Emitter, ac.Config.Xcodebuild.PreserveLocale); err != nil {
 					return err
 				}
 				if sess.CompanionTargetID != "" && sess.CompanionBundleID != "" {
-					if err := core.DevicectlStop(ctx, sess.CompanionTargetID, 0, sess.CompanionBundleID, ac.Emitter); err != nil {
+					if err := core.DevicectlStop(ctx, sess.CompanionTargetID, 0, sess.CompanionBundleID, ac.Emitter, ac.Config.Xcodebuild.PreserveLocale); err != nil {
 						ac.Emitter.Emit(core.Warn("stop", "failed to stop companion app on paired iPhone: "+err.Error()))
 					}
 				}
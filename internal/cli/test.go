@@ -16,9 +16,14 @@ func newTestCmd() *cobra.Command {
 	var target string
 	var targetType string
 	var companionTarget string
+	var destination string
+	var force bool
 	var list bool
 	var only []string
 	var skip []string
+	var testPlan string
+	var repeat int
+	var untilFailure bool
 
 	cmd := &cobra.Command{
 		Use:   "test",
@@ -28,13 +33,23 @@ func newTestCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			defer ac.Close()
 			if err := applyOverrides(&ac.Config, scheme, configuration, platform, target, targetType, companionTarget); err != nil {
 				return err
 			}
+			applyForce(&ac.Config, force)
 
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
 
+			if err := applyQueryOverrides(ctx, &ac, configuration, target, destination); err != nil {
+				return err
+			}
+
+			if cmd.Flags().Changed("test-plan") {
+				ac.Config.Test.Plan = testPlan
+			}
+
 			if list {
 				et, err := core.XcodebuildEnumerateTests(ctx, ac.ProjectRoot, ac.Config)
 				if err != nil {
@@ -49,8 +64,23 @@ func newTestCmd() *cobra.Command {
 				return nil
 			}
 
+			if untilFailure && repeat < 1 {
+				return fmt.Errorf("--until-failure needs --repeat N")
+			}
+			if repeat > 0 {
+				done := trackStatus(&ac, "test")
+				_, cfg2, err := core.StressTest(ctx, ac.ProjectRoot, ac.Config, only, skip, core.StressOptions{Iterations: repeat, UntilFailure: untilFailure}, ac.Emitter)
+				recordMetrics(ac, cfg2, done(err))
+				persistConfigIfChanged(ac, cfg2)
+				rememberSchemeDestination(ac, cfg2)
+				return err
+			}
+
+			done := trackStatus(&ac, "test")
 			_, cfg2, err := core.Test(ctx, ac.ProjectRoot, ac.Config, only, skip, ac.Emitter)
+			recordMetrics(ac, cfg2, done(err))
 			persistConfigIfChanged(ac, cfg2)
+			rememberSchemeDestination(ac, cfg2)
 			return err
 		},
 	}
@@ -58,12 +88,17 @@ func newTestCmd() *cobra.Command {
 	cmd.Flags().BoolVar(&list, "list", false, "List tests (xcodebuild -enumerate-tests)")
 	cmd.Flags().StringArrayVar(&only, "only", []string{}, "Run only these tests (repeatable); value format: <Target>/<Class>/<testMethod>")
 	cmd.Flags().StringArrayVar(&skip, "skip", []string{}, "Skip these tests (repeatable)")
+	cmd.Flags().IntVar(&repeat, "repeat", 0, "Stress mode: run the tests N times to catch flaky ones (retries off); combine with --only")
+	cmd.Flags().BoolVar(&untilFailure, "until-failure", false, "With --repeat, stop at the first failing iteration")
+	cmd.Flags().StringVar(&testPlan, "test-plan", "", "Test plan to run (-testPlan); \"\" uses the scheme default")
 	cmd.Flags().StringVar(&scheme, "scheme", "", "Override scheme")
-	cmd.Flags().StringVar(&configuration, "configuration", "", "Override configuration")
+	cmd.Flags().StringVar(&configuration, "configuration", "", "Override configuration (case-insensitive prefix)")
 	cmd.Flags().StringVar(&platform, "platform", "", "Destination platform family (ios|ipados|tvos|visionos|watchos|macos|catalyst)")
 	cmd.Flags().StringVar(&target, "target", "", "Destination ID or exact name")
+	cmd.Flags().StringVar(&destination, "destination", "", "Destination by fuzzy name (e.g. \"15 pro max\"), or \"last\" for the one last used with the scheme")
 	cmd.Flags().StringVar(&targetType, "target-type", "", "Destination target type (simulator|device|local)")
 	cmd.Flags().StringVar(&companionTarget, "companion-target", "", "Companion destination ID/name (watchOS physical runs)")
+	cmd.Flags().BoolVar(&force, "force", false, "Skip the check that the scheme supports the destination's platform")
 
 	return cmd
 }
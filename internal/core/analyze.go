@@ -0,0 +1,131 @@
+package core
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+type AnalyzeResult struct {
+	ResultBundle string        `json:"resultBundle"`
+	ExitCode     int           `json:"exitCode"`
+	Duration     time.Duration `json:"duration"`
+	// ReportDir receives the analyzer's plist and HTML output.
+	ReportDir string `json:"reportDir"`
+	// Reports lists the HTML reports, one per analyzer finding.
+	Reports []string `json:"reports,omitempty"`
+	// Command is the xcodebuild invocation, with env values redacted.
+	Command string `json:"command,omitempty"`
+}
+
+// Analyze runs xcodebuild analyze (the clang static analyzer). Reports are
+// written next to the result bundle so each run keeps its own. Result
+// bundles beyond results.retention are pruned afterwards.
+func Analyze(ctx context.Context, projectRoot string, cfg Config, emit Emitter) (AnalyzeResult, Config, error) {
+	finish := resultsOp(ctx, cfg, "analyze", emit)
+	res, cfg, err := analyze(ctx, projectRoot, cfg, emit)
+	finish(cfg)
+	return res, cfg, err
+}
+
+func analyze(ctx context.Context, projectRoot string, cfg Config, emit Emitter) (AnalyzeResult, Config, error) {
+	cfg, err := maybeGenerateProject(ctx, projectRoot, cfg, "analyze", emit)
+	if err != nil {
+		return AnalyzeResult{}, cfg, err
+	}
+	if cfg2, err := ensureSchemeAndConfigFromFS(projectRoot, cfg, emit); err == nil {
+		cfg = cfg2
+	} else {
+		emitMaybe(emit, Err("analyze", schemeRequiredError(err)))
+		return AnalyzeResult{}, cfg, err
+	}
+
+	cfg, _ = ResolveDestinationIfNeeded(ctx, projectRoot, cfg, emit)
+	cfg.Destination = normalizeDestination(cfg.Destination)
+
+	if err := EnsureBuildDirs(cfg); err != nil {
+		return AnalyzeResult{}, cfg, err
+	}
+
+	bundlePath := resultBundlePath(cfg, "analyze", time.Now(), emit)
+	reportDir := strings.TrimSuffix(bundlePath, ".xcresult") + "-analyzer"
+	args := baseXcodebuildArgs(projectRoot, cfg)
+	args = append(args,
+		"-derivedDataPath", DerivedDataDir(cfg),
+		"-resultBundlePath", bundlePath,
+		"analyze",
+		"CLANG_ANALYZER_OUTPUT=plist-html",
+		"CLANG_ANALYZER_OUTPUT_DIR="+reportDir,
+	)
+	args = append(args, cfg.Xcodebuild.Options...)
+
+	command := xcodebuildCommandLine(cfg, args)
+	emitMaybe(emit, Status("analyze", "Analyze started", map[string]any{"resultBundle": bundlePath, "reportDir": reportDir}))
+	emitMaybe(emit, XcodebuildCommand("analyze", command))
+	if cfg.Xcodebuild.DryRun {
+		cmdLine := formatCmd("xcodebuild", xcodebuildArgs(cfg, args))
+		emitMaybe(emit, Log("analyze", "Dry run: "+cmdLine))
+		emitMaybe(emit, Result("analyze", true, map[string]any{"exitCode": 0, "resultBundle": bundlePath, "reportDir": reportDir, "dryRun": true}))
+		cfg.LastResultBundle = bundlePath
+		return AnalyzeResult{ResultBundle: bundlePath, ReportDir: reportDir, Command: command}, cfg, nil
+	}
+	sink := newXcodebuildLogSink(ctx, "analyze", cfg, emit)
+	res, err := RunStreaming(ctx, CmdSpec{
+		Path:           "xcrun",
+		Args:           append([]string{"xcodebuild"}, args...),
+		PreserveLocale: cfg.Xcodebuild.PreserveLocale,
+		Dir:            projectRoot,
+		Env:            cfg.Xcodebuild.Env,
+		StdoutLine:     sink.HandleLine,
+		StderrLine:     sink.HandleStderrLine,
+	})
+	sink.Finalize(err, res.ExitCode)
+
+	cfg.LastResultBundle = bundlePath
+	reports := AnalyzerReports(reportDir)
+	ar := AnalyzeResult{ResultBundle: bundlePath, ExitCode: res.ExitCode, Duration: res.Duration, ReportDir: reportDir, Reports: reports, Command: command}
+	data := map[string]any{
+		"exitCode":     res.ExitCode,
+		"resultBundle": bundlePath,
+		"durationMs":   res.Duration.Milliseconds(),
+		"reportDir":    reportDir,
+		"reports":      len(reports),
+	}
+	if err != nil {
+		if status := CancelStatus(err); status != "" {
+			emitMaybe(emit, Err("analyze", cancelErrorObject("Analyze", err, "")))
+			data["status"] = status
+		} else {
+			emitMaybe(emit, Err("analyze", ErrorObject{
+				Code:       "XCODEBUILD_FAILED",
+				Message:    "xcodebuild analyze failed",
+				Detail:     err.Error(),
+				Suggestion: "Fix the build errors first; the analyzer only runs on code that compiles.",
+			}))
+		}
+		emitMaybe(emit, Result("analyze", false, data))
+		return ar, cfg, err
+	}
+	emitMaybe(emit, Result("analyze", true, data))
+	return ar, cfg, nil
+}
+
+// AnalyzerReports lists the HTML reports under dir, sorted by path (nil when
+// there are none or dir doesn't exist)
+func AnalyzerReports(dir string) []string {
+	var reports []string
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if !d.IsDir() && strings.EqualFold(filepath.Ext(path), ".html") {
+			reports = append(reports, path)
+		}
+		return nil
+	})
+	sort.Strings(reports)
+	return reports
+}
@@ -0,0 +1,56 @@
+package core
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAnalyzeDryRunRequestsHTMLReports(t *testing.T) {
+	results := t.TempDir()
+	cfg := Config{
+		Project:           "App.xcodeproj",
+		Scheme:            "App",
+		Configuration:     "Debug",
+		ResultBundlesPath: results,
+		Destination:       Destination{Kind: DestSimulator, UDID: "SIM-1", Platform: "iOS Simulator"},
+		Xcodebuild:        XcodebuildConfig{DryRun: true},
+	}
+
+	res, _, err := Analyze(context.Background(), t.TempDir(), cfg, &recordingEmitter{})
+	if err != nil {
+		t.Fatalf("dry-run analyze: %v", err)
+	}
+	if !strings.HasPrefix(res.ReportDir, results) || !strings.HasSuffix(res.ReportDir, "-analyzer") {
+		t.Fatalf("unexpected report dir %q", res.ReportDir)
+	}
+	for _, want := range []string{" analyze ", "CLANG_ANALYZER_OUTPUT=plist-html", "CLANG_ANALYZER_OUTPUT_DIR=" + res.ReportDir} {
+		if !strings.Contains(res.Command+" ", want) {
+			t.Fatalf("command %q is missing %q", res.Command, want)
+		}
+	}
+}
+
+func TestAnalyzerReports(t *testing.T) {
+	dir := t.TempDir()
+	nested := filepath.Join(dir, "App", "normal", "arm64")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{filepath.Join(dir, "report-b.html"), filepath.Join(nested, "report-a.html"), filepath.Join(nested, "Foo.plist")} {
+		if err := os.WriteFile(name, []byte("x"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := []string{filepath.Join(nested, "report-a.html"), filepath.Join(dir, "report-b.html")}
+	if got := AnalyzerReports(dir); !reflect.DeepEqual(got, want) {
+		t.Fatalf("AnalyzerReports = %v, want %v", got, want)
+	}
+	if got := AnalyzerReports(filepath.Join(dir, "missing")); got != nil {
+		t.Fatalf("missing dir should have no reports, got %v", got)
+	}
+}
@@ -17,6 +17,8 @@ type AppBundleInfo struct {
 	BuildVersion      string
 	IsWatchApp        bool
 	CompanionBundleID string
+	// ExtensionPoint is set for app extensions, e.g. "com.apple.widgetkit-extension"
+	ExtensionPoint string
 }
 
 func ReadAppBundleInfo(appPath string) (AppBundleInfo, error) {
@@ -46,6 +48,18 @@ func ReadAppBundleInfo(appPath string) (AppBundleInfo, error) {
 		}
 		return false
 	}
+	extensionPoint := func() string {
+		for _, key := range []string{"NSExtension", "EXAppExtensionAttributes"} {
+			if d, ok := m[key].(map[string]any); ok {
+				for _, id := range []string{"NSExtensionPointIdentifier", "EXExtensionPointIdentifier"} {
+					if s, ok := d[id].(string); ok {
+						return s
+					}
+				}
+			}
+		}
+		return ""
+	}
 	return AppBundleInfo{
 		BundleID:          get("CFBundleIdentifier"),
 		DisplayName:       get("CFBundleDisplayName"),
@@ -55,5 +69,6 @@ func ReadAppBundleInfo(appPath string) (AppBundleInfo, error) {
 		BuildVersion:      get("CFBundleVersion"),
 		IsWatchApp:        getBool("WKWatchKitApp"),
 		CompanionBundleID: get("WKCompanionAppBundleIdentifier"),
+		ExtensionPoint:    extensionPoint(),
 	}, nil
 }
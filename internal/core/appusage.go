@@ -0,0 +1,86 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// AppLaunchedCode marks the run status event sent once the launched app's
+// process is known, before a console run streams its output. Data has
+// pid, bundleId, target ("simulator", "device", "macos", or "catalyst"),
+// and for simulators udid.
+const AppLaunchedCode = "APP_LAUNCHED"
+
+// ErrAppExited is returned by SampleAppUsage when the process is gone.
+var ErrAppExited = errors.New("app is no longer running")
+
+// AppUsage is a launched app's CPU and memory footprint.
+type AppUsage struct {
+	// CPU is the process's share of one core, in percent, as ps reports it.
+	CPU float64
+	// RSSKB is the resident memory in KiB.
+	RSSKB int64
+}
+
+// MemoryMB returns the resident memory in MiB.
+func (u AppUsage) MemoryMB() float64 {
+	return float64(u.RSSKB) / 1024
+}
+
+// AppUsageSupported reports whether SampleAppUsage can watch an app on
+// target. Simulator apps are processes of the Mac, so ps sees them like
+// macOS apps; device apps are out of reach.
+func AppUsageSupported(target string) bool {
+	switch target {
+	case "simulator", string(DestMacOS), string(DestCatalyst):
+		return true
+	}
+	return false
+}
+
+// SampleAppUsage reads the CPU and memory of process pid with one ps run.
+func SampleAppUsage(ctx context.Context, pid int) (AppUsage, error) {
+	if pid <= 0 {
+		return AppUsage{}, ErrAppExited
+	}
+	out, err := captureCmd(ctx, "ps", "-o", "%cpu=,rss=", "-p", strconv.Itoa(pid))
+	if ctx.Err() != nil {
+		return AppUsage{}, ctx.Err()
+	}
+	if err != nil && strings.TrimSpace(out) == "" {
+		// ps exits 1 without output when no process matches
+		return AppUsage{}, ErrAppExited
+	}
+	return parseAppUsage(out)
+}
+
+// parseAppUsage reads `ps -o %cpu=,rss=` output, e.g. "12.3 188416"
+func parseAppUsage(out string) (AppUsage, error) {
+	fields := strings.Fields(out)
+	if len(fields) < 2 {
+		return AppUsage{}, ErrAppExited
+	}
+	cpu, err := strconv.ParseFloat(strings.Replace(fields[0], ",", ".", 1), 64)
+	if err != nil {
+		return AppUsage{}, fmt.Errorf("unrecognized ps output %q", out)
+	}
+	rss, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return AppUsage{}, fmt.Errorf("unrecognized ps output %q", out)
+	}
+	return AppUsage{CPU: cpu, RSSKB: rss}, nil
+}
+
+// emitAppLaunched reports the launched app's process (AppLaunchedCode)
+func emitAppLaunched(emit Emitter, pid int, bundleID, target, udid string) {
+	data := map[string]any{"pid": pid, "bundleId": bundleID, "target": target}
+	if udid != "" {
+		data["udid"] = udid
+	}
+	ev := Status("run", "App launched", data)
+	ev.Code = AppLaunchedCode
+	emitMaybe(emit, ev)
+}
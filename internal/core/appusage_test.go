@@ -0,0 +1,37 @@
+package core
+
+import "testing"
+
+func TestParseAppUsage(t *testing.T) {
+	u, err := parseAppUsage("  12.5 188416\n")
+	if err != nil || u.CPU != 12.5 || u.RSSKB != 188416 || u.MemoryMB() != 184 {
+		t.Fatalf("usage = %+v, %v", u, err)
+	}
+	// Locales with a decimal comma
+	if u, err := parseAppUsage("3,0 1024"); err != nil || u.CPU != 3 {
+		t.Fatalf("usage = %+v, %v", u, err)
+	}
+	if _, err := parseAppUsage(""); err != ErrAppExited {
+		t.Fatalf("empty output: %v", err)
+	}
+	if _, err := parseAppUsage("abc 12"); err == nil || err == ErrAppExited {
+		t.Fatalf("garbage output: %v", err)
+	}
+}
+
+func TestSimctlLaunchLinePID(t *testing.T) {
+	if pid := simctlLaunchLinePID("com.example.App: 4242", "com.example.App"); pid != 4242 {
+		t.Fatalf("pid = %d", pid)
+	}
+	if pid := simctlLaunchLinePID("Hello from the app", "com.example.App"); pid != 0 {
+		t.Fatalf("pid = %d for an app line", pid)
+	}
+}
+
+func TestAppUsageSupported(t *testing.T) {
+	for target, want := range map[string]bool{"simulator": true, "macos": true, "catalyst": true, "device": false, "": false} {
+		if got := AppUsageSupported(target); got != want {
+			t.Errorf("AppUsageSupported(%q) = %v", target, got)
+		}
+	}
+}
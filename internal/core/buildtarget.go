@@ -0,0 +1,136 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// targetSettingsTimeout bounds the build settings lookup that picks a
+// target build's destination
+const targetSettingsTimeout = 30 * time.Second
+
+// ProjectTarget is a target of one of the project's Xcode projects
+type ProjectTarget struct {
+	Name string `json:"name"`
+	// Project contains the target, relative to the project root
+	Project string `json:"project"`
+}
+
+// ListProjectTargets lists the targets of the configured project, or of
+// every project in the configured workspace, via xcodebuild -list -json.
+func ListProjectTargets(ctx context.Context, projectRoot string, cfg Config, emit Emitter) ([]ProjectTarget, error) {
+	var projects []string
+	switch {
+	case cfg.Project != "":
+		projects = []string{cfg.Project}
+	case cfg.Workspace != "":
+		for _, p := range workspaceProjectPaths(projectRoot, cfg.Workspace) {
+			if rel, err := filepath.Rel(projectRoot, p); err == nil && !strings.HasPrefix(rel, "..") {
+				p = rel
+			}
+			projects = append(projects, p)
+		}
+	}
+	if len(projects) == 0 {
+		return nil, errors.New("no Xcode project configured (target builds don't work with Swift packages)")
+	}
+	var targets []ProjectTarget
+	var firstErr error
+	for _, p := range projects {
+		list, err := XcodebuildList(ctx, projectRoot, Config{Project: p, Xcodebuild: cfg.Xcodebuild}, emit)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		for _, name := range list.Targets {
+			targets = append(targets, ProjectTarget{Name: name, Project: p})
+		}
+	}
+	if len(targets) == 0 && firstErr != nil {
+		return nil, firstErr
+	}
+	return targets, nil
+}
+
+// targetBuildError reports an invalid target build as TARGET_BUILD_INVALID
+func targetBuildError(emit Emitter, err error) error {
+	emitMaybe(emit, Err("build", ErrorObject{
+		Code:       "TARGET_BUILD_INVALID",
+		Message:    "Cannot build target",
+		Detail:     err.Error(),
+		Suggestion: "Target builds need an Xcode project; pick a target from `xcodebuild -list` or build the scheme instead.",
+	}))
+	return err
+}
+
+// resolveTargetBuild prepares cfg.TargetOverride for xcodebuild -target: it
+// finds the project containing the target when only a workspace is
+// configured (-target doesn't work with -workspace) and picks the
+// destination.
+func resolveTargetBuild(ctx context.Context, projectRoot string, cfg Config, emit Emitter) (Config, error) {
+	cfg.TargetOverride = strings.TrimSpace(cfg.TargetOverride)
+	if cfg.TargetProject == "" {
+		cfg.TargetProject = cfg.Project
+	}
+	if cfg.TargetProject == "" {
+		targets, err := ListProjectTargets(ctx, projectRoot, cfg, emit)
+		if err != nil {
+			return cfg, targetBuildError(emit, err)
+		}
+		i := slices.IndexFunc(targets, func(t ProjectTarget) bool { return t.Name == cfg.TargetOverride })
+		if i < 0 {
+			return cfg, targetBuildError(emit, fmt.Errorf("no target %q in the workspace's projects", cfg.TargetOverride))
+		}
+		cfg.TargetProject = targets[i].Project
+	}
+
+	cfg.targetDestination = BuildDestinationString(cfg)
+	if cfg.Xcodebuild.DryRun {
+		return cfg, nil
+	}
+	lookup := cfg
+	lookup.targetDestination = ""
+	settingsCtx, cancel := context.WithTimeout(ctx, targetSettingsTimeout)
+	defer cancel()
+	if settings, err := ShowBuildSettings(settingsCtx, projectRoot, lookup); err == nil {
+		cfg.targetDestination = TargetDestination(cfg, PlatformFamiliesFromSupportedPlatforms(settings["SUPPORTED_PLATFORMS"]))
+	}
+	return cfg, nil
+}
+
+// TargetDestination is the -destination of a target build: the configured
+// destination when the target supports its platform, else a generic one for
+// the target's first platform (simulator SDKs for iOS-like platforms, so
+// nothing needs signing). Targets that report no platforms keep the
+// configured destination.
+func TargetDestination(cfg Config, families []PlatformFamily) string {
+	dest := BuildDestinationString(cfg)
+	if len(families) == 0 || (dest != "" && FamilySupported(families, cfg.Destination.PlatformFamily)) {
+		return dest
+	}
+	tt := TargetSimulator
+	if families[0] == PlatformMacOS {
+		tt = TargetLocal
+	}
+	return "generic/platform=" + PlatformStringForDestination(families[0], tt)
+}
+
+// targetBuildArgs replace the workspace and scheme of baseXcodebuildArgs
+// with -project and -target
+func targetBuildArgs(projectRoot string, cfg Config) []string {
+	args := []string{"-project", absJoin(projectRoot, cfg.TargetProject), "-target", cfg.TargetOverride}
+	if cfg.Configuration != "" {
+		args = append(args, "-configuration", cfg.Configuration)
+	}
+	if cfg.targetDestination != "" {
+		args = append(args, "-destination", cfg.targetDestination)
+	}
+	return args
+}
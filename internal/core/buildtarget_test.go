@@ -0,0 +1,99 @@
+package core
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTargetDestination(t *testing.T) {
+	sim := Config{Destination: Destination{Kind: DestSimulator, ID: "SIM-1", PlatformFamily: PlatformIOS, TargetType: TargetSimulator}}
+	mac := Config{Destination: Destination{Kind: DestMacOS, PlatformFamily: PlatformMacOS, TargetType: TargetLocal}}
+	tests := []struct {
+		name     string
+		cfg      Config
+		families []PlatformFamily
+		want     string
+	}{
+		{"supported destination", sim, []PlatformFamily{PlatformIOS}, "platform=iOS Simulator,id=SIM-1"},
+		{"unsupported destination", mac, []PlatformFamily{PlatformIOS, PlatformTvOS}, "generic/platform=iOS Simulator"},
+		{"no destination", Config{}, []PlatformFamily{PlatformMacOS}, "generic/platform=macOS"},
+		{"unknown platforms", sim, nil, "platform=iOS Simulator,id=SIM-1"},
+	}
+	for _, tc := range tests {
+		if got := TargetDestination(tc.cfg, tc.families); got != tc.want {
+			t.Errorf("%s: TargetDestination = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestBuildTargetInWorkspace(t *testing.T) {
+	root := t.TempDir()
+	ws := filepath.Join(root, "App.xcworkspace")
+	if err := os.MkdirAll(ws, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	data := `<Workspace version="1.0"><FileRef location="group:App.xcodeproj"></FileRef><FileRef location="group:Net/Net.xcodeproj"></FileRef></Workspace>`
+	if err := os.WriteFile(filepath.Join(ws, "contents.xcworkspacedata"), []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	bin := t.TempDir()
+	argsLog := filepath.Join(t.TempDir(), "args")
+	script := `echo "$*" >> ` + argsLog + `
+case "$*" in
+*-list*Net.xcodeproj*) echo '{"project":{"name":"Net","targets":["Networking","NetworkingTests"]}}' ;;
+*-list*) echo '{"project":{"name":"App","targets":["App"]}}' ;;
+*-showBuildSettings*) echo "    SUPPORTED_PLATFORMS = iphoneos iphonesimulator" ;;
+esac`
+	if err := os.WriteFile(filepath.Join(bin, "xcrun"), []byte("#!/bin/sh\n"+script+"\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	cfg := Config{
+		Workspace:         "App.xcworkspace",
+		Scheme:            "App",
+		Configuration:     "Debug",
+		DerivedDataPath:   t.TempDir(),
+		ResultBundlesPath: t.TempDir(),
+		Destination:       Destination{Kind: DestMacOS, PlatformFamily: PlatformMacOS, TargetType: TargetLocal},
+		TargetOverride:    "Networking",
+	}
+	rec := &recordingEmitter{}
+	_, cfg2, err := Build(context.Background(), root, cfg, rec)
+	if err != nil {
+		t.Fatalf("Build: %v (%s)", err, errorCodes(rec.events))
+	}
+	if cfg2.TargetOverride != "" || cfg2.TargetProject != "" {
+		t.Fatalf("returned config still builds a target: %+v", cfg2)
+	}
+
+	raw, err := os.ReadFile(argsLog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buildLine string
+	for _, line := range strings.Split(string(raw), "\n") {
+		if strings.Contains(line, "-resultBundlePath") {
+			buildLine = line
+		}
+	}
+	want := "-project " + filepath.Join(root, "Net", "Net.xcodeproj") + " -target Networking -configuration Debug -destination generic/platform=iOS Simulator"
+	if !strings.Contains(buildLine, want) {
+		t.Fatalf("build args = %q, want %q", buildLine, want)
+	}
+	if strings.Contains(buildLine, "-scheme") || strings.Contains(buildLine, "-workspace") {
+		t.Fatalf("target build kept the scheme: %q", buildLine)
+	}
+}
+
+func TestBuildTargetWithoutProject(t *testing.T) {
+	rec := &recordingEmitter{}
+	_, _, err := Build(context.Background(), t.TempDir(), Config{TargetOverride: "Networking", ResultBundlesPath: t.TempDir()}, rec)
+	if err == nil || errorCodes(rec.events) != "TARGET_BUILD_INVALID" {
+		t.Fatalf("err = %v, codes = %s", err, errorCodes(rec.events))
+	}
+}
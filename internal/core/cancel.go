@@ -0,0 +1,54 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Values of the "status" field on result events of interrupted operations.
+const (
+	CancelStatusCanceled = "canceled"
+	CancelStatusTimedOut = "timed_out"
+)
+
+// CancelStatus classifies err as a user cancel or a timeout ("" otherwise).
+func CancelStatus(err error) string {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return CancelStatusTimedOut
+	case errors.Is(err, context.Canceled):
+		return CancelStatusCanceled
+	}
+	return ""
+}
+
+// cancelErrorObject describes an interrupted operation; what is e.g. "Build".
+func cancelErrorObject(what string, err error, detail string) ErrorObject {
+	if CancelStatus(err) == CancelStatusTimedOut {
+		return ErrorObject{
+			Code:       "TIMED_OUT",
+			Message:    what + " timed out",
+			Detail:     detail,
+			Suggestion: "Raise the timeout, or check for a hung simulator or test.",
+		}
+	}
+	return ErrorObject{Code: "CANCELED", Message: what + " canceled", Detail: detail}
+}
+
+// partialTestSummary reads whatever an interrupted test run wrote to its
+// result bundle. The op context is already done, so it uses its own timeout,
+// and a half-written bundle must never take the caller down with it.
+func partialTestSummary(bundlePath string) (summary TestSummary, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			summary, err = TestSummary{}, fmt.Errorf("reading partial result bundle: %v", r)
+		}
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	summary, err = XcresultTestSummary(ctx, bundlePath)
+	summary.Partial = err == nil
+	return summary, err
+}
@@ -0,0 +1,140 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// CleanSizeTimeout bounds how long MeasureCleanTargets walks each target.
+const CleanSizeTimeout = 3 * time.Second
+
+// CleanTarget is one path a clean operation removes.
+type CleanTarget struct {
+	Path  string `json:"path"`
+	Bytes int64  `json:"bytes"`
+	// Global marks caches shared by every project on the machine.
+	Global bool `json:"global,omitempty"`
+	// Partial means the size walk timed out and Bytes is a lower bound.
+	Partial bool `json:"partial,omitempty"`
+}
+
+// CleanTargets lists the existing paths removed by a clean op: "clean"
+// (DerivedData of the active destination and Results), "clean-derived"
+// (every destination's DerivedData), "clean-results", "clean-sessions", or
+// "clean-spm-cache" (the global SwiftPM caches).
+func CleanTargets(projectRoot string, cfg Config, op string) []CleanTarget {
+	xcbolt := filepath.Join(projectRoot, ".xcbolt")
+	derived := filepath.Join(xcbolt, "DerivedData")
+	var paths []string
+	global := false
+	switch op {
+	case "clean":
+		paths = []string{derivedDataCleanPath(derived, cfg, false), filepath.Join(xcbolt, "Results")}
+	case "clean-derived":
+		paths = []string{derived}
+	case "clean-results":
+		paths = []string{filepath.Join(xcbolt, "Results")}
+	case "clean-sessions":
+		paths = []string{filepath.Join(xcbolt, "sessions.json")}
+	case "clean-spm-cache":
+		paths = SwiftPMCachePaths()
+		global = true
+	}
+	var targets []CleanTarget
+	for _, p := range paths {
+		if _, err := os.Lstat(p); err == nil {
+			targets = append(targets, CleanTarget{Path: p, Global: global})
+		}
+	}
+	return targets
+}
+
+// SwiftPMCachePaths returns the machine-wide SwiftPM caches.
+func SwiftPMCachePaths() []string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+	return []string{
+		filepath.Join(home, "Library", "Caches", "org.swift.swiftpm"),
+		filepath.Join(home, "Library", "Developer", "Xcode", "SourcePackages"),
+	}
+}
+
+// derivedDataCleanPath is the directory CleanDerivedData removes.
+func derivedDataCleanPath(base string, cfg Config, all bool) string {
+	sub := DerivedDataSubdir(normalizeDestination(cfg.Destination))
+	if cfg.Xcodebuild.SplitDerivedDataByDestination && !all && sub != "" {
+		return filepath.Join(base, sub)
+	}
+	return base
+}
+
+// MeasureCleanTargets fills in each target's size, giving up on a target
+// after CleanSizeTimeout.
+func MeasureCleanTargets(ctx context.Context, targets []CleanTarget) []CleanTarget {
+	out := make([]CleanTarget, len(targets))
+	for i, t := range targets {
+		walkCtx, cancel := context.WithTimeout(ctx, CleanSizeTimeout)
+		t.Bytes, t.Partial = pathSize(walkCtx, t.Path)
+		cancel()
+		out[i] = t
+	}
+	return out
+}
+
+// pathSize sums regular file sizes under path. partial is set when ctx ended
+// before the walk finished. Unreadable entries are skipped.
+func pathSize(ctx context.Context, path string) (total int64, partial bool) {
+	err := filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if d != nil && d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if d.Type().IsRegular() {
+			if info, err := d.Info(); err == nil {
+				total += info.Size()
+			}
+		}
+		return nil
+	})
+	return total, err != nil
+}
+
+// RemoveCleanTargets deletes targets and emits a result listing the bytes
+// freed per path. The first removal error is returned after trying them all.
+func RemoveCleanTargets(op string, targets []CleanTarget, emit Emitter) ([]CleanTarget, error) {
+	var removed []CleanTarget
+	var firstErr error
+	var freed int64
+	for _, t := range targets {
+		if err := os.RemoveAll(t.Path); err != nil {
+			emitMaybe(emit, Warn(op, fmt.Sprintf("Failed to remove %s: %v", t.Path, err)))
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		size := FormatBytes(t.Bytes)
+		if t.Partial {
+			size = "≥" + size
+		}
+		emitMaybe(emit, Log(op, fmt.Sprintf("Removed %s (%s)", t.Path, size)))
+		removed = append(removed, t)
+		freed += t.Bytes
+	}
+	emitMaybe(emit, Result(op, firstErr == nil, map[string]any{
+		"removed":    removed,
+		"bytesFreed": freed,
+	}))
+	return removed, firstErr
+}
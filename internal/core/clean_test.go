@@ -0,0 +1,54 @@
+package core
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCleanTargetsMeasureAndRemove(t *testing.T) {
+	root := t.TempDir()
+	results := filepath.Join(root, ".xcbolt", "Results")
+	if err := os.MkdirAll(filepath.Join(results, "a.xcresult"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, size := range map[string]int{"a.xcresult/data": 1000, "b.log": 24} {
+		if err := os.WriteFile(filepath.Join(results, name), make([]byte, size), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// DerivedData does not exist, so only Results is listed.
+	targets := MeasureCleanTargets(context.Background(), CleanTargets(root, Config{}, "clean"))
+	if len(targets) != 1 || targets[0].Path != results || targets[0].Bytes != 1024 || targets[0].Partial || targets[0].Global {
+		t.Fatalf("unexpected targets %+v", targets)
+	}
+
+	rec := &recordingEmitter{}
+	removed, err := RemoveCleanTargets("clean", targets, rec)
+	if err != nil || len(removed) != 1 {
+		t.Fatalf("remove: %v (%+v)", err, removed)
+	}
+	if _, err := os.Stat(results); !os.IsNotExist(err) {
+		t.Fatalf("results should be gone: %v", err)
+	}
+	last := rec.events[len(rec.events)-1]
+	data := last.Data.(map[string]any)["data"].(map[string]any)
+	if last.Type != "result" || data["bytesFreed"] != int64(1024) {
+		t.Fatalf("unexpected result event %+v", last)
+	}
+}
+
+func TestCleanTargetsSwiftPMCacheIsGlobal(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	cache := filepath.Join(home, "Library", "Caches", "org.swift.swiftpm")
+	if err := os.MkdirAll(cache, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	targets := CleanTargets(t.TempDir(), Config{}, "clean-spm-cache")
+	if len(targets) != 1 || targets[0].Path != cache || !targets[0].Global {
+		t.Fatalf("unexpected targets %+v", targets)
+	}
+}
@@ -0,0 +1,367 @@
+package core
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Output modes of the CLI (--output).
+const (
+	OutputText    = "text"
+	OutputCompact = "compact"
+)
+
+// OutputLogSuffix ends the raw logs of compact output in resultBundlesPath,
+// e.g. 20260102-150405-output.log.
+const OutputLogSuffix = "-output.log"
+
+const (
+	// compactRedrawInterval is the least time between two redraws of the
+	// status line caused by events
+	compactRedrawInterval = 100 * time.Millisecond
+	// compactTick redraws the status line so the elapsed time keeps going
+	compactTick = time.Second
+	// CompactPlainInterval is how often a progress line is printed when
+	// output isn't a terminal.
+	CompactPlainInterval = 10 * time.Second
+	// compactStageWidth caps the stage shown in the status line, so the line
+	// fits and \r can redraw it
+	compactStageWidth = 40
+)
+
+// CompactEmitter is the compact CLI output: a single status line (operation,
+// stage, elapsed time, error and warning counts), errors and warnings as
+// they occur, and a summary per result. Raw xcodebuild output goes to a log
+// file instead. On a terminal the status line redraws in place with \r;
+// otherwise a progress line is printed every CompactPlainInterval.
+type CompactEmitter struct {
+	w       io.Writer
+	tty     bool
+	logPath string
+	now     func() time.Time
+
+	mu       sync.Mutex
+	log      *os.File
+	logErr   bool
+	lastRaw  string
+	started  time.Time
+	opStarts map[string]time.Time
+	op       string
+	stage    string
+	current  int
+	total    int
+	errors   int
+	warnings int
+	active   bool
+	drawn    bool
+	lastDraw time.Time
+	stop     chan struct{}
+}
+
+// NewCompactEmitter writes compact output to w and raw logs to logPath,
+// created when the first line arrives. tty says whether w is a terminal.
+func NewCompactEmitter(w io.Writer, logPath string, tty bool) *CompactEmitter {
+	return &CompactEmitter{w: w, tty: tty, logPath: logPath, now: time.Now, opStarts: map[string]time.Time{}}
+}
+
+// LogPath returns the raw log file, or "" before anything was written to it.
+func (e *CompactEmitter) LogPath() string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.log == nil {
+		return ""
+	}
+	return e.logPath
+}
+
+func (e *CompactEmitter) Emit(ev Event) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	now := e.now()
+	if e.started.IsZero() {
+		e.started = now
+	}
+	if ev.Cmd != "" {
+		if _, ok := e.opStarts[ev.Cmd]; !ok {
+			e.opStarts[ev.Cmd] = now
+		}
+	}
+
+	switch ev.Type {
+	case "log", "log_raw":
+		if data, _ := ev.Data.(map[string]any); data != nil && data["pretty"] == true {
+			// Formatter output repeats the raw lines
+			return
+		}
+		// With a log formatter, errors come both as log_raw and log
+		if ev.Msg == e.lastRaw {
+			return
+		}
+		e.lastRaw = ev.Msg
+		e.writeLog(ev.Msg)
+		switch ClassifyDiagnostic(ev.Msg).Severity {
+		case DiagnosticError:
+			e.errors++
+			e.printLine(ev.Msg)
+		case DiagnosticWarning:
+			e.warnings++
+			e.printLine(ev.Msg)
+		}
+	case "progress":
+		data, _ := ev.Data.(map[string]any)
+		if stage, _ := data["stage"].(string); stage != "" {
+			e.stage = stage
+		}
+		e.current, _ = data["current"].(int)
+		e.total, _ = data["total"].(int)
+		e.op = ev.Cmd
+	case "status":
+		if ev.Code == XcodebuildCommandCode {
+			e.writeLog(ev.Msg)
+			return
+		}
+		e.op = ev.Cmd
+		e.stage = ev.Msg
+		e.current, e.total = 0, 0
+	case "warning":
+		e.writeLog(ev.Msg)
+		e.printLine("[warn] " + ev.Msg)
+	case "error":
+		if ev.Err == nil {
+			e.printLine("error: " + ev.Msg)
+			return
+		}
+		e.writeLog(ev.Err.Message)
+		line := fmt.Sprintf("error[%s]: %s", ev.Err.Code, ev.Err.Message)
+		if ev.Err.Suggestion != "" {
+			line += "\n  hint: " + ev.Err.Suggestion
+		}
+		e.printLine(line)
+		return
+	case "result":
+		e.summary(ev, now)
+		return
+	default:
+		return
+	}
+
+	if !e.active {
+		e.active = true
+		e.startTicker()
+	}
+	if e.tty && now.Sub(e.lastDraw) >= compactRedrawInterval {
+		e.draw(now)
+	}
+}
+
+// statusText is the status line, e.g. "● build · Compile 12/40 · 42s · ✗ 2 ⚠ 5"
+func (e *CompactEmitter) statusText(now time.Time) string {
+	parts := []string{"● " + e.op}
+	if stage := e.stage; stage != "" {
+		if r := []rune(stage); len(r) > compactStageWidth {
+			stage = string(r[:compactStageWidth-1]) + "…"
+		}
+		if e.total > 0 {
+			stage += fmt.Sprintf(" %d/%d", e.current, e.total)
+		}
+		parts = append(parts, stage)
+	}
+	parts = append(parts, now.Sub(e.started).Round(time.Second).String())
+	var counts []string
+	if e.errors > 0 {
+		counts = append(counts, fmt.Sprintf("✗ %d", e.errors))
+	}
+	if e.warnings > 0 {
+		counts = append(counts, fmt.Sprintf("⚠ %d", e.warnings))
+	}
+	if len(counts) > 0 {
+		parts = append(parts, strings.Join(counts, " "))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// draw redraws the status line in place; callers hold e.mu
+func (e *CompactEmitter) draw(now time.Time) {
+	fmt.Fprint(e.w, "\r\x1b[K"+e.statusText(now))
+	e.drawn = true
+	e.lastDraw = now
+}
+
+// clear removes the status line so a line can be printed; callers hold e.mu
+func (e *CompactEmitter) clear() {
+	if e.drawn {
+		fmt.Fprint(e.w, "\r\x1b[K")
+		e.drawn = false
+	}
+}
+
+// printLine prints s above the status line; callers hold e.mu
+func (e *CompactEmitter) printLine(s string) {
+	e.clear()
+	fmt.Fprintln(e.w, s)
+	if e.tty && e.active {
+		e.draw(e.now())
+	}
+}
+
+// tick redraws the status line, or prints it as a progress line when
+// output isn't a terminal
+func (e *CompactEmitter) tick() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !e.active {
+		return
+	}
+	now := e.now()
+	if e.tty {
+		e.draw(now)
+		return
+	}
+	fmt.Fprintln(e.w, e.statusText(now))
+}
+
+// startTicker ticks until the next result; callers hold e.mu
+func (e *CompactEmitter) startTicker() {
+	stop := make(chan struct{})
+	e.stop = stop
+	interval := CompactPlainInterval
+	if e.tty {
+		interval = compactTick
+	}
+	go func() {
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-t.C:
+				e.tick()
+			}
+		}
+	}()
+}
+
+// summary prints an operation's result line, like the TUI's, with the
+// issue counts and the log file; callers hold e.mu
+func (e *CompactEmitter) summary(ev Event, now time.Time) {
+	e.clear()
+	e.active = false
+	if e.stop != nil {
+		close(e.stop)
+		e.stop = nil
+	}
+
+	success := false
+	cancelReason := ""
+	var duration time.Duration
+	if data, _ := ev.Data.(map[string]any); data != nil {
+		success = data["status"] == "success"
+		if inner, _ := data["data"].(map[string]any); inner != nil {
+			switch inner["status"] {
+			case CancelStatusCanceled:
+				cancelReason = "canceled"
+			case CancelStatusTimedOut:
+				cancelReason = "timed out"
+			}
+			if ms, ok := inner["durationMs"].(int64); ok {
+				duration = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+	if duration == 0 {
+		if start, ok := e.opStarts[ev.Cmd]; ok {
+			duration = now.Sub(start)
+		}
+	}
+
+	icon := "✓"
+	switch {
+	case cancelReason == "timed out":
+		icon = "⚠"
+	case cancelReason != "":
+		icon = "◉"
+	case !success:
+		icon = "✗"
+	}
+	fmt.Fprintln(e.w, icon+" "+ResultHeadline(ev.Cmd, success, cancelReason, duration))
+	if e.errors > 0 || e.warnings > 0 {
+		fmt.Fprintf(e.w, "  %s · %s\n", countNoun(e.errors, "error"), countNoun(e.warnings, "warning"))
+	}
+	if e.log != nil {
+		fmt.Fprintln(e.w, "  Log: "+e.logPath)
+	}
+}
+
+// countNoun returns e.g. "1 error" or "2 errors"
+func countNoun(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// writeLog appends a line to the raw log file; callers hold e.mu
+func (e *CompactEmitter) writeLog(line string) {
+	if e.logPath == "" || e.logErr {
+		return
+	}
+	if e.log == nil {
+		var f *os.File
+		err := os.MkdirAll(filepath.Dir(e.logPath), 0o755)
+		if err == nil {
+			f, err = os.Create(e.logPath)
+		}
+		if err != nil {
+			e.logErr = true
+			e.printLine("[warn] Could not write the log file: " + err.Error())
+			return
+		}
+		e.log = f
+	}
+	fmt.Fprintln(e.log, line)
+}
+
+// Close stops the status line and closes the log file.
+func (e *CompactEmitter) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.clear()
+	e.active = false
+	if e.stop != nil {
+		close(e.stop)
+		e.stop = nil
+	}
+	if e.log == nil {
+		return nil
+	}
+	return e.log.Close()
+}
+
+// ResultHeadline is the text of an operation's result line, e.g. "Build
+// Succeeded · 42.3s"; cancelReason is "canceled", "timed out", or "" when
+// the operation ran to completion.
+func ResultHeadline(op string, success bool, cancelReason string, duration time.Duration) string {
+	verb := "Succeeded"
+	switch {
+	case cancelReason == "timed out":
+		verb = "Timed Out"
+	case cancelReason != "":
+		verb = "Canceled"
+	case !success:
+		verb = "Failed"
+	}
+	text := verb
+	if op != "" {
+		text = strings.ToUpper(op[:1]) + op[1:] + " " + verb
+	}
+	if duration > 0 {
+		text += " · " + duration.Round(100*time.Millisecond).String()
+	}
+	return text
+}
@@ -0,0 +1,112 @@
+package core
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// compactBuild feeds e a short failing build with one error and one warning
+func compactBuild(e *CompactEmitter, clock *time.Time) {
+	e.Emit(Status("build", "Build started", nil))
+	e.Emit(XcodebuildCommand("build", "xcodebuild -scheme App build"))
+	e.Emit(Progress("build", "Compile", 3, 0, "App.swift"))
+	e.Emit(Log("build", "CompileSwift normal arm64 /src/App.swift"))
+	e.Emit(Log("build", "/src/App.swift:3:1: warning: var never mutated"))
+	e.Emit(LogRaw("build", "/src/App.swift:9:5: error: cannot find 'x' in scope"))
+	// The same error again as a log event, and formatter output
+	e.Emit(Log("build", "/src/App.swift:9:5: error: cannot find 'x' in scope"))
+	e.Emit(LogPretty("build", "❌ /src/App.swift:9:5: cannot find 'x' in scope"))
+	*clock = clock.Add(12 * time.Second)
+	e.tick()
+	e.Emit(Result("build", false, map[string]any{"exitCode": 65}))
+}
+
+func newTestCompactEmitter(t *testing.T, tty bool) (*CompactEmitter, *bytes.Buffer, *time.Time, string) {
+	t.Helper()
+	var out bytes.Buffer
+	logPath := filepath.Join(t.TempDir(), "Results", "build.log")
+	e := NewCompactEmitter(&out, logPath, tty)
+	clock := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+	e.now = func() time.Time { return clock }
+	t.Cleanup(func() { e.Close() })
+	return e, &out, &clock, logPath
+}
+
+func TestCompactEmitterPlain(t *testing.T) {
+	e, out, clock, logPath := newTestCompactEmitter(t, false)
+	compactBuild(e, clock)
+
+	got := out.String()
+	if strings.Contains(got, "\r") {
+		t.Fatalf("carriage return without a terminal:\n%q", got)
+	}
+	want := strings.Join([]string{
+		"/src/App.swift:3:1: warning: var never mutated",
+		"/src/App.swift:9:5: error: cannot find 'x' in scope",
+		"● build · Compile · 12s · ✗ 1 ⚠ 1",
+		"✗ Build Failed · 12s",
+		"  1 error · 1 warning",
+		"  Log: " + logPath,
+		"",
+	}, "\n")
+	if got != want {
+		t.Fatalf("output:\n%s\nwant:\n%s", got, want)
+	}
+
+	raw, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantLog := strings.Join([]string{
+		"$ xcodebuild -scheme App build",
+		"CompileSwift normal arm64 /src/App.swift",
+		"/src/App.swift:3:1: warning: var never mutated",
+		"/src/App.swift:9:5: error: cannot find 'x' in scope",
+		"",
+	}, "\n")
+	if string(raw) != wantLog {
+		t.Fatalf("log:\n%s\nwant:\n%s", raw, wantLog)
+	}
+
+	// Nothing more is printed once the op is done
+	out.Reset()
+	e.tick()
+	if out.Len() != 0 {
+		t.Fatalf("tick after the result printed %q", out.String())
+	}
+}
+
+func TestCompactEmitterTerminal(t *testing.T) {
+	e, out, clock, _ := newTestCompactEmitter(t, true)
+	compactBuild(e, clock)
+
+	got := out.String()
+	if !strings.Contains(got, "\r\x1b[K● build · Build started · 0s") {
+		t.Fatalf("no status line drawn:\n%q", got)
+	}
+	// Diagnostics clear the status line, print, and redraw it
+	if !strings.Contains(got, "\r\x1b[K/src/App.swift:9:5: error: cannot find 'x' in scope\n\r\x1b[K● build") {
+		t.Fatalf("error not printed above the status line:\n%q", got)
+	}
+	if !strings.Contains(got, "\r\x1b[K● build · Compile · 12s · ✗ 1 ⚠ 1") {
+		t.Fatalf("tick didn't redraw the status line:\n%q", got)
+	}
+	// The summary replaces the status line
+	if !strings.HasSuffix(got, "\r\x1b[K✗ Build Failed · 12s\n  1 error · 1 warning\n  Log: "+e.LogPath()+"\n") {
+		t.Fatalf("summary:\n%q", got)
+	}
+}
+
+func TestCompactEmitterResult(t *testing.T) {
+	e, out, _, _ := newTestCompactEmitter(t, false)
+	e.Emit(Result("build", true, map[string]any{"durationMs": int64(42300)}))
+	e.Emit(Result("test", false, map[string]any{"status": CancelStatusTimedOut}))
+	want := "✓ Build Succeeded · 42.3s\n⚠ Test Timed Out\n"
+	if got := out.String(); got != want {
+		t.Fatalf("output %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,139 @@
+package core
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SlowestFilesLimit is how many compile units the build result lists as
+// slowest.
+const SlowestFilesLimit = 10
+
+// CompileTime is how long one Swift frontend job took: a single file, or a
+// whole module (or batch) compiled by one job.
+type CompileTime struct {
+	File string `json:"file"` // Source path, or the module name for a multi-file unit
+	// Files is the number of sources of a multi-file unit (0 for one file).
+	Files    int           `json:"files,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// timingReportArgs are the build arguments for xcodebuild.timingReport:
+// the build timing summary and per-job Swift driver timings.
+func timingReportArgs(cfg Config) []string {
+	if !cfg.Xcodebuild.TimingReport {
+		return nil
+	}
+	return []string{"-showBuildTimingSummary", "OTHER_SWIFT_FLAGS=$(inherited) -driver-time-compilation"}
+}
+
+// A row of the -driver-time-compilation table: up to four "secs ( pct%)"
+// columns (user, system, user+system, wall) and the job name.
+var driverTimingRowRE = regexp.MustCompile(`^\s*((?:\d+\.\d+\s+\(\s*\d+\.\d+%\)\s+)+)(\S.*?)\s*$`)
+
+var driverTimingSecsRE = regexp.MustCompile(`(\d+\.\d+)\s+\(`)
+
+// parseDriverTimingRow reads a compile job's inputs and wall time from a
+// -driver-time-compilation row. Both driver spellings are understood:
+// "{compile: App.o <= A.swift B.swift}" and "compile /path/A.swift".
+func parseDriverTimingRow(line string) (CompileTime, bool) {
+	m := driverTimingRowRE.FindStringSubmatch(line)
+	if m == nil {
+		return CompileTime{}, false
+	}
+	secs := driverTimingSecsRE.FindAllStringSubmatch(m[1], -1)
+	wall, err := strconv.ParseFloat(secs[len(secs)-1][1], 64)
+	if err != nil {
+		return CompileTime{}, false
+	}
+
+	name := m[2]
+	var output string
+	switch {
+	case strings.HasPrefix(name, "{compile:"):
+		name = strings.TrimSuffix(strings.TrimPrefix(name, "{compile:"), "}")
+		if out, in, ok := strings.Cut(name, "<="); ok {
+			output, name = strings.TrimSpace(out), in
+		}
+	case strings.HasPrefix(name, "compile "):
+		name = strings.TrimPrefix(name, "compile ")
+	default:
+		return CompileTime{}, false
+	}
+
+	var sources []string
+	for _, field := range strings.Fields(name) {
+		if strings.HasSuffix(field, ".swift") {
+			sources = append(sources, field)
+		}
+	}
+	t := CompileTime{Duration: time.Duration(wall * float64(time.Second))}
+	switch {
+	case len(sources) == 1:
+		t.File = sources[0]
+	case len(sources) > 1:
+		t.Files = len(sources)
+		t.File = strings.TrimSuffix(filepath.Base(output), filepath.Ext(output))
+		if output == "" {
+			t.File = filepath.Base(sources[0])
+		}
+	default:
+		// Whole-module job named after its module, e.g. "compile App"
+		fields := strings.Fields(name)
+		if len(fields) != 1 {
+			return CompileTime{}, false
+		}
+		t.File = strings.TrimSuffix(fields[0], filepath.Ext(fields[0]))
+	}
+	return t, true
+}
+
+// compileTimer collects compile times from xcodebuild output. A unit that
+// shows up more than once (e.g. one job per architecture) keeps its slowest
+// time.
+type compileTimer struct {
+	times map[string]CompileTime
+}
+
+func (c *compileTimer) observe(line string) {
+	t, ok := parseDriverTimingRow(line)
+	if !ok {
+		return
+	}
+	if c.times == nil {
+		c.times = make(map[string]CompileTime)
+	}
+	if prev, seen := c.times[t.File]; !seen || t.Duration > prev.Duration {
+		c.times[t.File] = t
+	}
+}
+
+// Times returns the collected compile times, slowest first
+func (c *compileTimer) Times() []CompileTime {
+	if len(c.times) == 0 {
+		return nil
+	}
+	out := make([]CompileTime, 0, len(c.times))
+	for _, t := range c.times {
+		out = append(out, t)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Duration != out[j].Duration {
+			return out[i].Duration > out[j].Duration
+		}
+		return out[i].File < out[j].File
+	})
+	return out
+}
+
+// SlowestFiles returns the first n of times (sorted slowest first)
+func SlowestFiles(times []CompileTime, n int) []CompileTime {
+	if len(times) > n {
+		return times[:n]
+	}
+	return times
+}
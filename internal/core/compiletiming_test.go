@@ -0,0 +1,87 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDriverTimingRow(t *testing.T) {
+	tests := []struct {
+		line string
+		want CompileTime
+	}{
+		// Batch/incremental driver: one job per file
+		{"   1.2000 ( 40.0%)   0.1000 ( 10.0%)   1.3000 ( 38.0%)   1.4500 ( 39.2%)  compile /src/App/FeedView.swift",
+			CompileTime{File: "/src/App/FeedView.swift", Duration: 1450 * time.Millisecond}},
+		// Legacy driver spelling
+		{"   0.5000 ( 20.0%)   0.0500 (  5.0%)   0.5500 ( 19.0%)   0.6000 ( 18.1%)  {compile: Model.o <= /src/App/Model.swift}",
+			CompileTime{File: "/src/App/Model.swift", Duration: 600 * time.Millisecond}},
+		// Whole-module job over several files
+		{"   8.0000 ( 90.0%)   0.5000 ( 80.0%)   8.5000 ( 89.0%)   9.2500 ( 88.0%)  {compile: App.o <= A.swift B.swift C.swift}",
+			CompileTime{File: "App", Files: 3, Duration: 9250 * time.Millisecond}},
+		{"   3.0000 ( 90.0%)  compile App",
+			CompileTime{File: "App", Duration: 3 * time.Second}},
+	}
+	for _, tt := range tests {
+		got, ok := parseDriverTimingRow(tt.line)
+		if !ok || got != tt.want {
+			t.Fatalf("parseDriverTimingRow(%q) = %+v, %v; want %+v", tt.line, got, ok, tt.want)
+		}
+	}
+
+	for _, line := range []string{
+		"   0.0100 (  1.0%)   0.0100 (  1.0%)   0.0200 (  1.0%)   0.0300 (  1.0%)  {link: App <= App.o}",
+		"   0.0100 (  1.0%)  merge-module App",
+		"===-------------------------------------------------------------------------===",
+		"CompileSwift normal arm64 /src/App/FeedView.swift (in target 'App' from project 'App')",
+	} {
+		if got, ok := parseDriverTimingRow(line); ok {
+			t.Fatalf("parseDriverTimingRow(%q) = %+v, want no match", line, got)
+		}
+	}
+}
+
+func TestCompileTimerKeepsSlowestSortedFirst(t *testing.T) {
+	var c compileTimer
+	if c.Times() != nil {
+		t.Fatalf("times without rows should be nil")
+	}
+	c.observe("   0.5000 ( 10.0%)  compile /src/B.swift")
+	c.observe("   2.0000 ( 40.0%)  compile /src/A.swift")
+	c.observe("   0.9000 ( 20.0%)  compile /src/B.swift") // Second architecture
+	c.observe("   0.9000 ( 20.0%)  compile /src/C.swift")
+	c.observe("   0.1000 (  2.0%)  compile /src/A.swift")
+	c.observe("note: Building targets in dependency order")
+
+	times := c.Times()
+	want := []CompileTime{
+		{File: "/src/A.swift", Duration: 2 * time.Second},
+		{File: "/src/B.swift", Duration: 900 * time.Millisecond},
+		{File: "/src/C.swift", Duration: 900 * time.Millisecond},
+	}
+	if len(times) != len(want) {
+		t.Fatalf("times = %+v, want %+v", times, want)
+	}
+	for i := range want {
+		if times[i] != want[i] {
+			t.Fatalf("times[%d] = %+v, want %+v", i, times[i], want[i])
+		}
+	}
+	if got := SlowestFiles(times, 2); len(got) != 2 || got[1].File != "/src/B.swift" {
+		t.Fatalf("SlowestFiles(2) = %+v", got)
+	}
+	if got := SlowestFiles(times, SlowestFilesLimit); len(got) != 3 {
+		t.Fatalf("SlowestFiles(limit) = %+v", got)
+	}
+}
+
+func TestTimingReportArgs(t *testing.T) {
+	if args := timingReportArgs(Config{}); args != nil {
+		t.Fatalf("args without timingReport = %v", args)
+	}
+	cfg := Config{Xcodebuild: XcodebuildConfig{TimingReport: true}}
+	args := timingReportArgs(cfg)
+	if len(args) != 2 || args[0] != "-showBuildTimingSummary" || args[1] != "OTHER_SWIFT_FLAGS=$(inherited) -driver-time-compilation" {
+		t.Fatalf("args = %v", args)
+	}
+}
@@ -1,7 +1,6 @@
 package core
 
 import (
-	"bufio"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -53,6 +52,23 @@ type XcodebuildConfig struct {
 	LogFormat     string            `json:"logFormat,omitempty"`
 	LogFormatArgs []string          `json:"logFormatArgs,omitempty"`
 	DryRun        bool              `json:"dryRun,omitempty"`
+	// SuppressToolNoise lists regexes for output lines to drop entirely.
+	SuppressToolNoise []string `json:"suppressToolNoise,omitempty"`
+	// TimingReport makes builds print the build timing summary and Swift
+	// driver timings, and reports the slowest files.
+	TimingReport bool `json:"timingReport,omitempty"`
+	// SplitDerivedDataByDestination keeps one DerivedData per
+	// <platformFamily>-<targetType> under derivedDataPath.
+	SplitDerivedDataByDestination bool `json:"splitDerivedDataByDestination,omitempty"`
+	// PreserveLocale runs xcodebuild, simctl, and devicectl in the user's
+	// locale instead of forcing English (log parsing expects English).
+	PreserveLocale bool `json:"preserveLocale,omitempty"`
+	// SkipDestinationCheck builds even when the destination's platform is
+	// not among the scheme's SUPPORTED_PLATFORMS.
+	SkipDestinationCheck bool `json:"skipDestinationCheck,omitempty"`
+	// AutoCleanOnXcodeChange cleans DerivedData without asking when it was
+	// built by another Xcode.
+	AutoCleanOnXcodeChange bool `json:"autoCleanOnXcodeChange,omitempty"`
 }
 
 type LaunchConfig struct {
@@ -61,10 +77,204 @@ type LaunchConfig struct {
 	StreamUnifiedLogs *bool             `json:"streamUnifiedLogs,omitempty"`
 	StreamSystemLogs  *bool             `json:"streamSystemLogs,omitempty"`
 	ConsoleLogLevels  map[string]bool   `json:"consoleLogLevels,omitempty"`
+	// Product is the bundle id of the app or app extension to run when the
+	// scheme builds several ("" runs the scheme's main app).
+	Product string `json:"product,omitempty"`
+	// DeviceLogTool streams unified logs on physical devices: "devicectl"
+	// (default) or "idevicesyslog" (libimobiledevice).
+	DeviceLogTool string `json:"deviceLogTool,omitempty"`
+	// Presets are named argument and environment sets merged over Options
+	// and Env for a run.
+	Presets []LaunchPreset `json:"presets,omitempty"`
+	// Preset is the preset every run uses unless one is picked for the
+	// run ("" uses none).
+	Preset string `json:"preset,omitempty"`
 }
 
 type TUIConfig struct {
 	ShowAllLogs bool `json:"showAllLogs,omitempty"`
+	// IssueContextLines is how many lines around each error/warning the
+	// errors-only view keeps (default 2).
+	IssueContextLines *int `json:"issueContextLines,omitempty"`
+	// ConfirmClean asks before clean operations delete anything (default
+	// true).
+	ConfirmClean *bool `json:"confirmClean,omitempty"`
+	// PerSchemeDestinations switches to the destination last used with a
+	// scheme whenever the scheme changes (default true). When false, it
+	// does so only if the current destination doesn't support the scheme.
+	PerSchemeDestinations *bool `json:"perSchemeDestinations,omitempty"`
+	// Hyperlinks turns file locations in logs and issues into OSC 8 links.
+	// Unset means on for terminals known to support them.
+	Hyperlinks *bool `json:"hyperlinks,omitempty"`
+	// HyperlinkTemplate is the link URL with {path}, {line}, and {col}
+	// placeholders, e.g. "vscode://file{path}:{line}:{col}" (default
+	// DefaultHyperlinkTemplate).
+	HyperlinkTemplate string `json:"hyperlinkTemplate,omitempty"`
+	// Notify announces finished operations: "none" (default), "bell", or
+	// "system" (macOS notification).
+	Notify string `json:"notify,omitempty"`
+	// NotifyMinDuration is the shortest op that notifies, as a Go duration
+	// (default "10s").
+	NotifyMinDuration string `json:"notifyMinDuration,omitempty"`
+	// AutoSwitchTab picks tabs for you: "issues-on-failure" (default) shows
+	// Issues after a failed op with errors, "logs-on-start" shows Logs when
+	// an op starts, and "never" leaves the tab alone.
+	AutoSwitchTab string `json:"autoSwitchTab,omitempty"`
+	// RunSplitRatio is the build pane's share of the run mode split, in
+	// percent (default 60).
+	RunSplitRatio int `json:"runSplitRatio,omitempty"`
+	// RunSplitVertical puts the console next to the build pane on wide
+	// terminals.
+	RunSplitVertical bool `json:"runSplitVertical,omitempty"`
+	// DevicePollInterval is how often the idle TUI looks for devices and
+	// simulators that came or went, as a Go duration (default "15s"; "0"
+	// turns polling off).
+	DevicePollInterval string `json:"devicePollInterval,omitempty"`
+	// LegacyProgress reads the stage and progress from log text instead of
+	// progress events, as xcbolt did before it had them.
+	LegacyProgress bool `json:"legacyProgress,omitempty"`
+	// MutedPhases lists phases whose output is collapsed in Logs and whose
+	// errors and warnings aren't counted or listed in Issues, e.g.
+	// ["Running Script"]. Each entry matches a detected phase name
+	// ("Running Script", "Compiling Swift") or part of a phase header line.
+	MutedPhases []string `json:"mutedPhases,omitempty"`
+	// AppCPUWarn is the CPU usage, in percent of one core, above which the
+	// run console's readout of the app is highlighted (default 80).
+	AppCPUWarn int `json:"appCpuWarn,omitempty"`
+	// AppMemoryWarnMB is the resident memory, in MB, above which the run
+	// console's readout of the app is highlighted (default 1024).
+	AppMemoryWarnMB int `json:"appMemoryWarnMb,omitempty"`
+}
+
+// Limits for tui.runSplitRatio.
+const (
+	DefaultRunSplitRatio = 60
+	MinRunSplitRatio     = 20
+	MaxRunSplitRatio     = 80
+)
+
+// SplitRatio returns the build pane's share of the run mode split, clamped
+// to MinRunSplitRatio..MaxRunSplitRatio.
+func (c TUIConfig) SplitRatio() int {
+	switch {
+	case c.RunSplitRatio == 0:
+		return DefaultRunSplitRatio
+	case c.RunSplitRatio < MinRunSplitRatio:
+		return MinRunSplitRatio
+	case c.RunSplitRatio > MaxRunSplitRatio:
+		return MaxRunSplitRatio
+	}
+	return c.RunSplitRatio
+}
+
+// Modes for tui.autoSwitchTab.
+const (
+	AutoSwitchNever           = "never"
+	AutoSwitchIssuesOnFailure = "issues-on-failure"
+	AutoSwitchLogsOnStart     = "logs-on-start"
+)
+
+// AutoSwitchMode returns the normalized tab auto-switch mode.
+func (c TUIConfig) AutoSwitchMode() string {
+	switch strings.ToLower(strings.TrimSpace(c.AutoSwitchTab)) {
+	case AutoSwitchNever:
+		return AutoSwitchNever
+	case AutoSwitchLogsOnStart:
+		return AutoSwitchLogsOnStart
+	}
+	return AutoSwitchIssuesOnFailure
+}
+
+// Notification modes for tui.notify.
+const (
+	NotifyNone   = "none"
+	NotifyBell   = "bell"
+	NotifySystem = "system"
+)
+
+// DefaultNotifyMinDuration is used when tui.notifyMinDuration is unset or
+// invalid.
+const DefaultNotifyMinDuration = 10 * time.Second
+
+// NotifyMode returns the normalized notification mode.
+func (c TUIConfig) NotifyMode() string {
+	switch strings.ToLower(strings.TrimSpace(c.Notify)) {
+	case NotifyBell:
+		return NotifyBell
+	case NotifySystem:
+		return NotifySystem
+	}
+	return NotifyNone
+}
+
+// NotifyThreshold returns the minimum op duration that notifies.
+func (c TUIConfig) NotifyThreshold() time.Duration {
+	d, err := time.ParseDuration(strings.TrimSpace(c.NotifyMinDuration))
+	if err != nil || d < 0 {
+		return DefaultNotifyMinDuration
+	}
+	return d
+}
+
+// DefaultDevicePollInterval is used when tui.devicePollInterval is unset or
+// invalid.
+const DefaultDevicePollInterval = 15 * time.Second
+
+// DevicePollEvery returns how often the TUI polls for destinations; 0 means
+// never.
+func (c TUIConfig) DevicePollEvery() time.Duration {
+	d, err := time.ParseDuration(strings.TrimSpace(c.DevicePollInterval))
+	if err != nil || d < 0 {
+		return DefaultDevicePollInterval
+	}
+	return d
+}
+
+// Defaults for tui.appCpuWarn and tui.appMemoryWarnMb.
+const (
+	DefaultAppCPUWarn      = 80
+	DefaultAppMemoryWarnMB = 1024
+)
+
+// CPUWarn returns the app CPU percentage that is highlighted.
+func (c TUIConfig) CPUWarn() int {
+	if c.AppCPUWarn <= 0 {
+		return DefaultAppCPUWarn
+	}
+	return c.AppCPUWarn
+}
+
+// MemoryWarnMB returns the app memory, in MB, that is highlighted.
+func (c TUIConfig) MemoryWarnMB() int {
+	if c.AppMemoryWarnMB <= 0 {
+		return DefaultAppMemoryWarnMB
+	}
+	return c.AppMemoryWarnMB
+}
+
+// DefaultHyperlinkTemplate opens files with the system handler.
+const DefaultHyperlinkTemplate = "file://{path}"
+
+// ShouldConfirmClean reports whether clean operations need confirmation.
+func (c TUIConfig) ShouldConfirmClean() bool {
+	return c.ConfirmClean == nil || *c.ConfirmClean
+}
+
+// AlwaysRestoreSchemeDestination reports whether changing the scheme always
+// switches to the destination last used with it.
+func (c TUIConfig) AlwaysRestoreSchemeDestination() bool {
+	return c.PerSchemeDestinations == nil || *c.PerSchemeDestinations
+}
+
+// DefaultIssueContextLines is used when tui.issueContextLines is unset.
+const DefaultIssueContextLines = 2
+
+// IssueContext returns the errors-only context window size.
+func (c TUIConfig) IssueContext() int {
+	if c.IssueContextLines == nil || *c.IssueContextLines < 0 {
+		return DefaultIssueContextLines
+	}
+	return *c.IssueContextLines
 }
 
 type Config struct {
@@ -75,6 +285,9 @@ type Config struct {
 	Scheme    string `json:"scheme,omitempty"`
 
 	Configuration string `json:"configuration,omitempty"`
+	// RequiredXcode is an optional version constraint for the active Xcode,
+	// e.g. ">=15.3" or "^15".
+	RequiredXcode string `json:"requiredXcode,omitempty"`
 
 	Destination Destination `json:"destination"`
 
@@ -83,9 +296,120 @@ type Config struct {
 	LastResultBundle   string `json:"-"`
 	LastBuiltAppBundle string `json:"-"`
 
+	// TargetOverride makes Build build this target (xcodebuild -target)
+	// instead of the scheme; TargetProject is the project containing it
+	// (default: project). Neither is saved.
+	TargetOverride string `json:"-"`
+	TargetProject  string `json:"-"`
+	// targetDestination is the -destination resolveTargetBuild picked
+	targetDestination string
+
 	Xcodebuild XcodebuildConfig `json:"xcodebuild,omitempty"`
 	Launch     LaunchConfig     `json:"launch,omitempty"`
 	TUI        TUIConfig        `json:"tui,omitempty"`
+	Hooks      HooksConfig      `json:"hooks,omitempty"`
+	Test       TestConfig       `json:"test,omitempty"`
+	Generate   GenerateConfig   `json:"generate,omitempty"`
+	Simulator  SimulatorConfig  `json:"simulator,omitempty"`
+	Issues     IssuesConfig     `json:"issues,omitempty"`
+	Redact     RedactConfig     `json:"redact,omitempty"`
+	Repro      ReproConfig      `json:"repro,omitempty"`
+	Results    ResultsConfig    `json:"results,omitempty"`
+	Metrics    MetricsConfig    `json:"metrics,omitempty"`
+
+	// Commands are project commands added to the TUI command palette.
+	Commands []UserCommand `json:"commands,omitempty"`
+
+	// DiskSpaceWarnGB warns when the project's volume has less free space
+	// (GB) than this (0 means DefaultDiskSpaceWarnGB, negative turns the
+	// warning off).
+	DiskSpaceWarnGB int `json:"diskSpaceWarnGB,omitempty"`
+
+	// unknown holds keys xcbolt doesn't know (by dotted path), written back
+	// by SaveConfig so a newer version's settings survive
+	unknown map[string]json.RawMessage
+	// layers records where LoadConfig found each value
+	layers *configLayers
+}
+
+// HooksConfig holds shell commands run around build, run, and test.
+// Commands run via /bin/sh -c in the project root.
+type HooksConfig struct {
+	PreBuild  string `json:"preBuild,omitempty"`
+	PostBuild string `json:"postBuild,omitempty"`
+	PreRun    string `json:"preRun,omitempty"`
+	PostRun   string `json:"postRun,omitempty"`
+	PreTest   string `json:"preTest,omitempty"`
+	PostTest  string `json:"postTest,omitempty"`
+}
+
+// SimulatorConfig holds simulator options.
+type SimulatorConfig struct {
+	// AutoRecover restarts CoreSimulator and retries once when a boot fails
+	// because the simulator runtime is broken (default true).
+	AutoRecover *bool `json:"autoRecover,omitempty"`
+}
+
+// ShouldAutoRecover reports whether broken simulator boots are recovered.
+func (c SimulatorConfig) ShouldAutoRecover() bool {
+	return c.AutoRecover == nil || *c.AutoRecover
+}
+
+// GenerateConfig regenerates a tuist or XcodeGen project before operations.
+type GenerateConfig struct {
+	// Tool is "tuist", "xcodegen", or "custom" ("" disables generation).
+	Tool string `json:"tool,omitempty"`
+	// Command overrides the generator command (required for "custom").
+	// It runs via /bin/sh -c in the project root.
+	Command string `json:"command,omitempty"`
+	// Auto regenerates before build, run, and test whenever the manifest is
+	// newer than the generated project.
+	Auto bool `json:"auto,omitempty"`
+}
+
+// TestConfig holds options for xcbolt test.
+type TestConfig struct {
+	// RetryOnFailure retries each failing test up to this many times. Xcode 13+
+	// retries in place; older versions re-run the failed tests once.
+	RetryOnFailure int `json:"retryOnFailure,omitempty"`
+	// Plan is the scheme test plan passed as -testPlan ("" uses the default).
+	Plan string `json:"plan,omitempty"`
+	// SimulatorPrep sets up the simulator before tests on a simulator
+	// destination.
+	SimulatorPrep SimulatorPrepConfig `json:"simulatorPrep,omitempty"`
+	// CodeCoverage passes -enableCodeCoverage YES and reports line coverage
+	// from the result bundle.
+	CodeCoverage bool `json:"codeCoverage,omitempty"`
+	// CoverageWarn and CoverageFail are the coverage levels, in percent,
+	// below which coverage shows as a warning or a failure (default 80 and
+	// 50).
+	CoverageWarn float64 `json:"coverageWarn,omitempty"`
+	CoverageFail float64 `json:"coverageFail,omitempty"`
+	// SourcePaths are the directories, relative to the project root,
+	// searched for a failing test's source when it was reported without a
+	// file (default the whole project).
+	SourcePaths []string `json:"sourcePaths,omitempty"`
+}
+
+// SimulatorPrepConfig is the simulator state UI tests expect. Appearance
+// and the status bar are restored after the tests.
+type SimulatorPrepConfig struct {
+	// Appearance is "light" or "dark" ("" leaves it alone).
+	Appearance string `json:"appearance,omitempty"`
+	// Locale is the region locale, e.g. "de_DE".
+	Locale string `json:"locale,omitempty"`
+	// Language is the app language, e.g. "de".
+	Language string `json:"language,omitempty"`
+	// StatusBarOverrides shows a clean status bar (9:41, full battery and
+	// signal) for screenshots.
+	StatusBarOverrides bool `json:"statusBarOverrides,omitempty"`
+	// Required fails the tests when the prep fails (default: warn and go on).
+	Required bool `json:"required,omitempty"`
+}
+
+// Enabled reports whether any prep is configured.
+func (c SimulatorPrepConfig) Enabled() bool {
+	return c.Appearance != "" || c.Locale != "" || c.Language != "" || c.StatusBarOverrides
 }
 
 func DefaultConfig(projectRoot string) Config {
@@ -122,49 +446,16 @@ func EnsureProjectDirs(projectRoot string) error {
 	return ensureXcboltGitignore(dir)
 }
 
+// ensureXcboltGitignore keeps .xcbolt/.gitignore ignoring everything xcbolt
+// generates, so only config.json is committed.
 func ensureXcboltGitignore(xcboltDir string) error {
-	path := filepath.Join(xcboltDir, ".gitignore")
-	entries := []string{
-		"DerivedData/",
-		"Results/",
-	}
-
-	b, err := os.ReadFile(path)
-	if err != nil {
-		if errors.Is(err, os.ErrNotExist) {
-			content := strings.Join(entries, "\n") + "\n"
-			return os.WriteFile(path, []byte(content), 0o644)
-		}
-		return err
-	}
-
-	existing := string(b)
-	missing := []string{}
-	for _, entry := range entries {
-		if !hasGitignoreLine(existing, entry) {
-			missing = append(missing, entry)
-		}
-	}
-	if len(missing) == 0 {
-		return nil
-	}
-	if existing != "" && !strings.HasSuffix(existing, "\n") {
-		existing += "\n"
-	}
-	existing += strings.Join(missing, "\n") + "\n"
-	return os.WriteFile(path, []byte(existing), 0o644)
-}
-
-func hasGitignoreLine(content string, line string) bool {
-	scanner := bufio.NewScanner(strings.NewReader(content))
-	for scanner.Scan() {
-		if strings.TrimSpace(scanner.Text()) == line {
-			return true
-		}
-	}
-	return false
+	_, err := appendGitignoreLines(filepath.Join(xcboltDir, ".gitignore"), xcboltGeneratedEntries, "")
+	return err
 }
 
+// LoadConfig reads the project config over the global config (see
+// GlobalConfigPath) and the defaults. Maps such as env merge key by key;
+// other values in the project config replace the global ones.
 func LoadConfig(projectRoot string, overridePath string) (Config, error) {
 	cfg := DefaultConfig(projectRoot)
 
@@ -172,17 +463,29 @@ func LoadConfig(projectRoot string, overridePath string) (Config, error) {
 	if path == "" {
 		path = ConfigPath(projectRoot)
 	}
+	layers := &configLayers{projectPath: path, project: map[string]configLeaf{}, origins: map[string]string{}, inherited: map[string]configLeaf{}}
+	cfg.layers = layers
+	if err := loadGlobalConfig(&cfg, layers); err != nil {
+		return cfg, err
+	}
 
 	b, err := os.ReadFile(path)
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
-			return cfg, nil
+			syncDestinationLegacy(&cfg.Destination)
+			if err := ValidateCommands(cfg.Commands, nil); err != nil {
+				return cfg, err
+			}
+			return cfg, ValidateResultNameTemplate(cfg.Results.NameTemplate)
 		}
 		return cfg, err
 	}
-	if err := json.Unmarshal(b, &cfg); err != nil {
+	leaves, unknown, err := decodeConfigLayer(b, &cfg)
+	if err != nil {
 		return cfg, fmt.Errorf("failed to parse config %s: %w", path, err)
 	}
+	cfg.unknown = unknown
+	layers.apply(ConfigOriginProject, leaves)
 	if cfg.Version != ConfigVersion {
 		return cfg, ConfigVersionError{Path: path, Got: cfg.Version, Want: ConfigVersion}
 	}
@@ -203,9 +506,18 @@ func LoadConfig(projectRoot string, overridePath string) (Config, error) {
 		cfg.Launch.Env = map[string]string{}
 	}
 	syncDestinationLegacy(&cfg.Destination)
+	if err := ValidateCommands(cfg.Commands, nil); err != nil {
+		return cfg, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+	if err := ValidateResultNameTemplate(cfg.Results.NameTemplate); err != nil {
+		return cfg, fmt.Errorf("invalid config %s: %w", path, err)
+	}
 	return cfg, nil
 }
 
+// SaveConfig writes cfg to the project config. Values cfg inherited from
+// the global config or flags are not written unless they were changed. The
+// file is replaced atomically under a lock shared with other instances.
 func SaveConfig(projectRoot string, overridePath string, cfg Config) error {
 	if err := EnsureProjectDirs(projectRoot); err != nil {
 		return err
@@ -216,12 +528,17 @@ func SaveConfig(projectRoot string, overridePath string, cfg Config) error {
 	}
 	syncDestinationLegacy(&cfg.Destination)
 	cfg.Version = ConfigVersion
-	b, err := json.MarshalIndent(cfg, "", "  ")
+	b, err := marshalConfig(cfg)
 	if err != nil {
 		return err
 	}
+	if b, err = projectOnlyConfig(b, cfg.layers); err != nil {
+		return err
+	}
 	b = append(b, '\n')
-	return os.WriteFile(path, b, 0o644)
+	return withFileLock(path, func() error {
+		return writeFileAtomic(path, b, 0o644)
+	})
 }
 
 type ConfigMigrationResult struct {
@@ -260,9 +577,11 @@ func MigrateConfig(projectRoot string, overridePath string) (ConfigMigrationResu
 	fromVersion := extractConfigVersion(b)
 
 	cfg := DefaultConfig(projectRoot)
-	if err := json.Unmarshal(b, &cfg); err != nil {
+	renamed, unknown := tolerateConfigKeys(b)
+	if err := json.Unmarshal(renamed, &cfg); err != nil {
 		return ConfigMigrationResult{}, fmt.Errorf("failed to parse config %s: %w", path, err)
 	}
+	cfg.unknown = unknown
 	// Preserve legacy configs that omitted version or used older versions.
 	if cfg.DerivedDataPath == "" {
 		cfg.DerivedDataPath = filepath.Join(projectRoot, ".xcbolt", "DerivedData")
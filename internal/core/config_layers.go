@@ -0,0 +1,276 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Config origins, from lowest to highest precedence.
+const (
+	ConfigOriginDefault = "default"
+	ConfigOriginGlobal  = "global"
+	ConfigOriginProject = "project"
+	ConfigOriginFlag    = "flag"
+)
+
+// GlobalConfigPath is the machine-wide config merged under every project's
+// config: $XDG_CONFIG_HOME/xcbolt/config.json, or
+// ~/.config/xcbolt/config.json. It is "" when there is no home directory.
+func GlobalConfigPath() string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "xcbolt", "config.json")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return ""
+	}
+	return filepath.Join(home, ".config", "xcbolt", "config.json")
+}
+
+// ConfigSetting is one effective config value and where it came from.
+type ConfigSetting struct {
+	Key    string `json:"key"`   // Dotted path, e.g. "xcodebuild.env.FOO"
+	Value  string `json:"value"` // JSON encoded
+	Origin string `json:"origin"`
+	// Path is the file the value was read from (global and project only).
+	Path string `json:"path,omitempty"`
+}
+
+// configLeaf is a scalar or array value of a config object. Env and other
+// maps are objects, so each of their keys is a leaf of its own.
+type configLeaf struct {
+	path  []string
+	value string // Canonical JSON
+}
+
+// configLayers records how LoadConfig built a Config, so SaveConfig can
+// leave out values that came from the global config or from flags.
+type configLayers struct {
+	globalPath  string
+	projectPath string
+	// project holds the leaves of the project file.
+	project map[string]configLeaf
+	// origins is the layer each leaf was last set by.
+	origins map[string]string
+	// inherited holds the value of each leaf set by a layer other than the
+	// project, as that layer set it.
+	inherited map[string]configLeaf
+}
+
+func (l *configLayers) clone() *configLayers {
+	c := &configLayers{
+		globalPath:  l.globalPath,
+		projectPath: l.projectPath,
+		project:     make(map[string]configLeaf, len(l.project)),
+		origins:     make(map[string]string, len(l.origins)),
+		inherited:   make(map[string]configLeaf, len(l.inherited)),
+	}
+	for k, v := range l.project {
+		c.project[k] = v
+	}
+	for k, v := range l.origins {
+		c.origins[k] = v
+	}
+	for k, v := range l.inherited {
+		c.inherited[k] = v
+	}
+	return c
+}
+
+// apply records the leaves of a layer that was decoded over the config.
+func (l *configLayers) apply(origin string, leaves map[string]configLeaf) {
+	for k, leaf := range leaves {
+		l.origins[k] = origin
+		if origin == ConfigOriginProject {
+			l.project[k] = leaf
+			delete(l.inherited, k)
+		} else {
+			l.inherited[k] = leaf
+		}
+	}
+}
+
+// decodeConfigLayer decodes config JSON b over cfg and returns the leaves it
+// set. Maps merge key by key; everything else is replaced.
+func decodeConfigLayer(b []byte, cfg *Config) (map[string]configLeaf, map[string]json.RawMessage, error) {
+	b, unknown := tolerateConfigKeys(b)
+	if err := json.Unmarshal(b, cfg); err != nil {
+		return nil, nil, err
+	}
+	var root map[string]any
+	if err := json.Unmarshal(b, &root); err != nil {
+		return nil, nil, err
+	}
+	delete(root, "version")
+	leaves := flattenConfig(root)
+	for k, leaf := range leaves {
+		if known, _ := lookupConfigKey(leaf.path); !known {
+			delete(leaves, k)
+		}
+	}
+	return leaves, unknown, nil
+}
+
+// loadGlobalConfig decodes the global config over cfg. A missing file is
+// not an error.
+func loadGlobalConfig(cfg *Config, layers *configLayers) error {
+	path := GlobalConfigPath()
+	if path == "" {
+		return nil
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	leaves, _, err := decodeConfigLayer(b, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to parse global config %s: %w", path, err)
+	}
+	// The version belongs to the project file.
+	cfg.Version = ConfigVersion
+	layers.globalPath = path
+	layers.apply(ConfigOriginGlobal, leaves)
+	return nil
+}
+
+// SetFlagOrigin records the given keys (dotted paths) as set by command-line
+// flags or other per-invocation overrides. Call it after changing cfg;
+// SaveConfig then keeps the project's own values for them.
+func (c *Config) SetFlagOrigin(keys ...string) {
+	if c.layers == nil {
+		c.layers = &configLayers{project: map[string]configLeaf{}, origins: map[string]string{}, inherited: map[string]configLeaf{}}
+	} else {
+		c.layers = c.layers.clone()
+	}
+	current, err := configLeaves(*c)
+	if err != nil {
+		return
+	}
+	for _, key := range keys {
+		set := map[string]configLeaf{}
+		for k, leaf := range current {
+			if k == key || strings.HasPrefix(k, key+".") {
+				set[k] = leaf
+			}
+		}
+		c.layers.apply(ConfigOriginFlag, set)
+	}
+}
+
+// ConfigOrigins lists the effective values of cfg with the layer each came
+// from, sorted by key. Values changed since loading count as project
+// values, since saving writes them to the project config.
+func ConfigOrigins(cfg Config) ([]ConfigSetting, error) {
+	leaves, err := configLeaves(cfg)
+	if err != nil {
+		return nil, err
+	}
+	layers := cfg.layers
+	if layers == nil {
+		layers = &configLayers{}
+	}
+	settings := make([]ConfigSetting, 0, len(leaves))
+	for k, leaf := range leaves {
+		if k == "version" {
+			continue
+		}
+		s := ConfigSetting{Key: k, Value: leaf.value, Origin: ConfigOriginDefault}
+		if origin, ok := layers.origins[k]; ok {
+			s.Origin = origin
+		}
+		if prev, ok := layers.inherited[k]; ok && prev.value != leaf.value {
+			s.Origin = ConfigOriginProject
+		}
+		switch s.Origin {
+		case ConfigOriginGlobal:
+			s.Path = layers.globalPath
+		case ConfigOriginProject:
+			s.Path = layers.projectPath
+		}
+		settings = append(settings, s)
+	}
+	sort.Slice(settings, func(i, j int) bool { return settings[i].Key < settings[j].Key })
+	return settings, nil
+}
+
+// projectOnlyConfig rewrites the encoded config b so values inherited from
+// the global config or flags, and unchanged since loading, are not written
+// to the project file: the project's own value is kept, or the key is left
+// out.
+func projectOnlyConfig(b []byte, layers *configLayers) ([]byte, error) {
+	if layers == nil || len(layers.inherited) == 0 {
+		return b, nil
+	}
+	var root map[string]any
+	if err := json.Unmarshal(b, &root); err != nil {
+		return nil, err
+	}
+	current := flattenConfig(root)
+	changed := false
+	for k, prev := range layers.inherited {
+		cur, ok := current[k]
+		if !ok || cur.value != prev.value {
+			continue
+		}
+		parent, name := configParent(root, cur.path, false)
+		if parent == nil {
+			continue
+		}
+		if own, ok := layers.project[k]; ok {
+			var v any
+			if err := json.Unmarshal([]byte(own.value), &v); err != nil {
+				continue
+			}
+			parent[name] = v
+		} else {
+			delete(parent, name)
+		}
+		changed = true
+	}
+	if !changed {
+		return b, nil
+	}
+	return json.MarshalIndent(root, "", "  ")
+}
+
+// configLeaves flattens the encoded cfg.
+func configLeaves(cfg Config) (map[string]configLeaf, error) {
+	b, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var root map[string]any
+	if err := json.Unmarshal(b, &root); err != nil {
+		return nil, err
+	}
+	return flattenConfig(root), nil
+}
+
+// flattenConfig maps the dotted path of every leaf under root to the leaf.
+func flattenConfig(root map[string]any) map[string]configLeaf {
+	out := map[string]configLeaf{}
+	var walk func(path []string, v any)
+	walk = func(path []string, v any) {
+		if obj, ok := v.(map[string]any); ok {
+			for k, child := range obj {
+				walk(append(append([]string{}, path...), k), child)
+			}
+			return
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return
+		}
+		out[strings.Join(path, ".")] = configLeaf{path: path, value: string(b)}
+	}
+	walk(nil, root)
+	return out
+}
@@ -0,0 +1,149 @@
+package core
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeLayeredConfigs(t *testing.T, global, project string) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", home)
+	if err := os.MkdirAll(filepath.Join(home, "xcbolt"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(home, "xcbolt", "config.json"), []byte(global), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	root := t.TempDir()
+	if err := EnsureProjectDirs(root); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(ConfigPath(root), []byte(project), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+const (
+	globalLayerFixture = `{
+  "xcodebuild": {"logFormat": "xcbeautify", "env": {"A": "global", "B": "global"}},
+  "launch": {"consoleLogLevels": {"D": false}},
+  "tui": {"notify": "bell", "autoSwitchTab": "never"}
+}`
+	projectLayerFixture = `{
+  "version": 3,
+  "scheme": "App",
+  "xcodebuild": {"env": {"B": "project"}},
+  "tui": {"notify": "system"}
+}`
+)
+
+func TestLoadConfigMergesGlobalUnderProject(t *testing.T) {
+	root := writeLayeredConfigs(t, globalLayerFixture, projectLayerFixture)
+	cfg, err := LoadConfig(root, "")
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if cfg.Xcodebuild.LogFormat != "xcbeautify" || cfg.TUI.Notify != "system" || cfg.TUI.AutoSwitchTab != "never" {
+		t.Fatalf("scalars: logFormat=%q notify=%q autoSwitchTab=%q", cfg.Xcodebuild.LogFormat, cfg.TUI.Notify, cfg.TUI.AutoSwitchTab)
+	}
+	if want := map[string]string{"A": "global", "B": "project"}; !reflect.DeepEqual(cfg.Xcodebuild.Env, want) {
+		t.Fatalf("env = %v, want %v", cfg.Xcodebuild.Env, want)
+	}
+	if cfg.Launch.ConsoleLogLevels["D"] || !cfg.Launch.ConsoleLogLevels["E"] {
+		t.Fatalf("console levels should merge over the defaults: %v", cfg.Launch.ConsoleLogLevels)
+	}
+
+	cfg.Xcodebuild.LogFormatArgs = []string{"--quiet"}
+	cfg.SetFlagOrigin("xcodebuild.logFormatArgs")
+	settings, err := ConfigOrigins(cfg)
+	if err != nil {
+		t.Fatalf("ConfigOrigins: %v", err)
+	}
+	origins := map[string]string{}
+	for _, s := range settings {
+		origins[s.Key] = s.Origin
+	}
+	want := map[string]string{
+		"scheme":                    ConfigOriginProject,
+		"configuration":             ConfigOriginDefault,
+		"xcodebuild.logFormat":      ConfigOriginGlobal,
+		"xcodebuild.logFormatArgs":  ConfigOriginFlag,
+		"xcodebuild.env.A":          ConfigOriginGlobal,
+		"xcodebuild.env.B":          ConfigOriginProject,
+		"launch.consoleLogLevels.D": ConfigOriginGlobal,
+		"launch.consoleLogLevels.E": ConfigOriginDefault,
+		"tui.notify":                ConfigOriginProject,
+		"tui.autoSwitchTab":         ConfigOriginGlobal,
+	}
+	for key, origin := range want {
+		if origins[key] != origin {
+			t.Errorf("origin of %s = %q, want %q", key, origins[key], origin)
+		}
+	}
+}
+
+func TestSaveConfigWritesOnlyProjectValues(t *testing.T) {
+	root := writeLayeredConfigs(t, globalLayerFixture, `{"version": 3, "scheme": "App", "xcodebuild": {"logFormat": "xcpretty"}}`)
+	cfg, err := LoadConfig(root, "")
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	cfg.Xcodebuild.LogFormat = "raw"
+	cfg.SetFlagOrigin("xcodebuild.logFormat")
+	cfg.TUI.AutoSwitchTab = "logs-on-start" // Changed in the TUI
+	cfg.Configuration = "Release"
+	if err := SaveConfig(root, "", cfg); err != nil {
+		t.Fatalf("SaveConfig: %v", err)
+	}
+
+	b, err := os.ReadFile(ConfigPath(root))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var saved struct {
+		Configuration string `json:"configuration"`
+		Xcodebuild    struct {
+			LogFormat string            `json:"logFormat"`
+			Env       map[string]string `json:"env"`
+		} `json:"xcodebuild"`
+		Launch struct {
+			ConsoleLogLevels map[string]bool `json:"consoleLogLevels"`
+		} `json:"launch"`
+		TUI map[string]any `json:"tui"`
+	}
+	if err := json.Unmarshal(b, &saved); err != nil {
+		t.Fatalf("saved config: %v\n%s", err, b)
+	}
+	if saved.Configuration != "Release" || saved.Xcodebuild.LogFormat != "xcpretty" {
+		t.Fatalf("configuration=%q logFormat=%q\n%s", saved.Configuration, saved.Xcodebuild.LogFormat, b)
+	}
+	if len(saved.Xcodebuild.Env) != 0 {
+		t.Fatalf("global env leaked into the project config: %v", saved.Xcodebuild.Env)
+	}
+	if _, ok := saved.Launch.ConsoleLogLevels["D"]; ok {
+		t.Fatalf("global console level leaked into the project config: %v", saved.Launch.ConsoleLogLevels)
+	}
+	if want := map[string]any{"autoSwitchTab": "logs-on-start", "showAllLogs": true}; !reflect.DeepEqual(saved.TUI, want) {
+		t.Fatalf("tui = %v, want %v", saved.TUI, want)
+	}
+
+	reloaded, err := LoadConfig(root, "")
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if reloaded.TUI.Notify != "bell" || reloaded.Xcodebuild.Env["A"] != "global" {
+		t.Fatalf("global values should still apply: notify=%q env=%v", reloaded.TUI.Notify, reloaded.Xcodebuild.Env)
+	}
+}
+
+func TestLoadConfigReportsInvalidGlobalConfig(t *testing.T) {
+	root := writeLayeredConfigs(t, `{"tui": `, projectLayerFixture)
+	if _, err := LoadConfig(root, ""); err == nil {
+		t.Fatal("expected an error for invalid global config")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestEnsureProjectDirsCreatesGitignore(t *testing.T) {
@@ -176,3 +177,42 @@ func TestMigrateConfigFromV2CreatesBackup(t *testing.T) {
 		t.Fatalf("destination id = %q", loaded.Destination.ID)
 	}
 }
+
+func TestTUINotifySettings(t *testing.T) {
+	var c TUIConfig
+	if c.NotifyMode() != NotifyNone || c.NotifyThreshold() != DefaultNotifyMinDuration {
+		t.Fatalf("unexpected defaults: %q %v", c.NotifyMode(), c.NotifyThreshold())
+	}
+	c = TUIConfig{Notify: " System ", NotifyMinDuration: "90s"}
+	if c.NotifyMode() != NotifySystem || c.NotifyThreshold() != 90*time.Second {
+		t.Fatalf("unexpected settings: %q %v", c.NotifyMode(), c.NotifyThreshold())
+	}
+	c = TUIConfig{Notify: "loud", NotifyMinDuration: "soon"}
+	if c.NotifyMode() != NotifyNone || c.NotifyThreshold() != DefaultNotifyMinDuration {
+		t.Fatalf("invalid values should fall back: %q %v", c.NotifyMode(), c.NotifyThreshold())
+	}
+}
+
+func TestTUIAutoSwitchMode(t *testing.T) {
+	tests := map[string]string{
+		"":                  AutoSwitchIssuesOnFailure,
+		"never":             AutoSwitchNever,
+		" Logs-On-Start ":   AutoSwitchLogsOnStart,
+		"issues-on-failure": AutoSwitchIssuesOnFailure,
+		"always":            AutoSwitchIssuesOnFailure,
+	}
+	for in, want := range tests {
+		if got := (TUIConfig{AutoSwitchTab: in}).AutoSwitchMode(); got != want {
+			t.Fatalf("AutoSwitchMode(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestTUISplitRatio(t *testing.T) {
+	tests := map[int]int{0: DefaultRunSplitRatio, 45: 45, 5: MinRunSplitRatio, 95: MaxRunSplitRatio}
+	for in, want := range tests {
+		if got := (TUIConfig{RunSplitRatio: in}).SplitRatio(); got != want {
+			t.Fatalf("SplitRatio(%d) = %d, want %d", in, got, want)
+		}
+	}
+}
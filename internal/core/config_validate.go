@@ -0,0 +1,397 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// configKeyRenames maps old spellings of config keys (dotted paths) to the
+// current key when a key is renamed. LoadConfig still honors them, and
+// validation reports them as deprecated. No key has been renamed yet.
+var configKeyRenames = map[string]string{}
+
+const (
+	ConfigProblemUnknown    = "unknown"
+	ConfigProblemDeprecated = "deprecated"
+	ConfigProblemInvalid    = "invalid"
+)
+
+// ConfigProblem is an unknown, deprecated, or invalid key in a config file.
+type ConfigProblem struct {
+	Key  string `json:"key"` // Dotted path, e.g. "tui.notfy"
+	Line int    `json:"line"`
+	Kind string `json:"kind"` // ConfigProblemUnknown, ConfigProblemDeprecated, or ConfigProblemInvalid
+	// Suggestion is the key to use instead: the closest valid key for
+	// unknown keys ("" when none is close), the new name for deprecated ones.
+	Suggestion string `json:"suggestion,omitempty"`
+	// Detail says what is wrong with an invalid value
+	Detail string `json:"detail,omitempty"`
+}
+
+// Message describes the problem without its line
+func (p ConfigProblem) Message() string {
+	switch p.Kind {
+	case ConfigProblemDeprecated:
+		return fmt.Sprintf("%q is deprecated; use %q", p.Key, p.Suggestion)
+	case ConfigProblemInvalid:
+		return p.Detail
+	}
+	msg := fmt.Sprintf("unknown key %q", p.Key)
+	if p.Suggestion != "" {
+		msg += fmt.Sprintf(" (did you mean %q?)", p.Suggestion)
+	}
+	return msg
+}
+
+func (p ConfigProblem) String() string {
+	if p.Line > 0 {
+		return fmt.Sprintf("line %d: %s", p.Line, p.Message())
+	}
+	return p.Message()
+}
+
+// CheckConfig reports the unknown and deprecated keys of the config file
+// (nil when it doesn't exist). Unknown keys are kept when xcbolt saves the
+// config; deprecated keys are read under their new name.
+func CheckConfig(projectRoot, overridePath string) ([]ConfigProblem, error) {
+	path := overridePath
+	if path == "" {
+		path = ConfigPath(projectRoot)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	problems, err := ValidateConfig(b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	return problems, nil
+}
+
+// ValidateConfig checks config JSON against the keys Config understands
+func ValidateConfig(b []byte) ([]ConfigProblem, error) {
+	keys, err := scanConfigKeys(b)
+	if err != nil {
+		return nil, err
+	}
+	var problems []ConfigProblem
+	var skip string // Unknown object whose children need no report
+	for _, k := range keys {
+		dotted := strings.Join(k.path, ".")
+		if skip != "" && strings.HasPrefix(dotted, skip+".") {
+			continue
+		}
+		if to, ok := configKeyRenames[dotted]; ok {
+			problems = append(problems, ConfigProblem{Key: dotted, Line: k.line, Kind: ConfigProblemDeprecated, Suggestion: to})
+			skip = dotted
+			continue
+		}
+		known, siblings := lookupConfigKey(k.path)
+		if known {
+			continue
+		}
+		p := ConfigProblem{Key: dotted, Line: k.line, Kind: ConfigProblemUnknown}
+		if best := closestKey(k.path[len(k.path)-1], siblings); best != "" {
+			p.Suggestion = strings.Join(append(append([]string{}, k.path[:len(k.path)-1]...), best), ".")
+		}
+		problems = append(problems, p)
+		skip = dotted
+	}
+	return append(problems, invalidConfigValues(b, keys)...), nil
+}
+
+// invalidConfigValues checks the values LoadConfig would reject, except
+// commands (see ValidateCommands)
+func invalidConfigValues(b []byte, keys []configKey) []ConfigProblem {
+	var values struct {
+		Results struct {
+			NameTemplate string `json:"nameTemplate"`
+		} `json:"results"`
+	}
+	if json.Unmarshal(b, &values) != nil {
+		return nil
+	}
+	var problems []ConfigProblem
+	if err := ValidateResultNameTemplate(values.Results.NameTemplate); err != nil {
+		problems = append(problems, ConfigProblem{Key: "results.nameTemplate", Line: configKeyLine(keys, "results.nameTemplate"), Kind: ConfigProblemInvalid, Detail: err.Error()})
+	}
+	return problems
+}
+
+// configKeyLine is the line of the dotted key in keys (0 if absent)
+func configKeyLine(keys []configKey, dotted string) int {
+	for _, k := range keys {
+		if strings.Join(k.path, ".") == dotted {
+			return k.line
+		}
+	}
+	return 0
+}
+
+// configKey is an object key of a config file with the line it's on
+type configKey struct {
+	path []string
+	line int
+}
+
+// scanConfigKeys lists every object key in b, in file order. Keys inside
+// arrays are skipped (no config array holds objects).
+func scanConfigKeys(b []byte) ([]configKey, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	var keys []configKey
+	lineAt := func(offset int64) int {
+		return bytes.Count(b[:offset], []byte("\n")) + 1
+	}
+	var walk func(path []string, record bool) error
+	walk = func(path []string, record bool) error {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		delim, ok := tok.(json.Delim)
+		if !ok {
+			return nil
+		}
+		switch delim {
+		case '{':
+			for dec.More() {
+				keyTok, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				key, _ := keyTok.(string)
+				child := append(append([]string{}, path...), key)
+				if record {
+					keys = append(keys, configKey{path: child, line: lineAt(dec.InputOffset())})
+				}
+				if err := walk(child, record); err != nil {
+					return err
+				}
+			}
+		case '[':
+			for dec.More() {
+				if err := walk(path, false); err != nil {
+					return err
+				}
+			}
+		}
+		_, err = dec.Token() // Closing delimiter
+		return err
+	}
+	if err := walk(nil, true); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// lookupConfigKey reports whether path names a Config field. siblings are
+// the valid keys of path's parent, for suggestions.
+func lookupConfigKey(path []string) (bool, []string) {
+	t := reflect.TypeOf(Config{})
+	for i, key := range path {
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		switch t.Kind() {
+		case reflect.Map:
+			return true, nil // Free-form keys (env, consoleLogLevels)
+		case reflect.Struct:
+		default:
+			return true, nil // Not an object; decoding reports type errors
+		}
+		fields := jsonFields(t)
+		ft, ok := fields[key]
+		if !ok {
+			// encoding/json matches field names case-insensitively
+			for name, t := range fields {
+				if strings.EqualFold(name, key) {
+					ft, ok = t, true
+					break
+				}
+			}
+		}
+		if !ok {
+			if i < len(path)-1 {
+				return false, nil
+			}
+			names := make([]string, 0, len(fields))
+			for name := range fields {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			return false, names
+		}
+		t = ft
+	}
+	return true, nil
+}
+
+// jsonFields maps the JSON names of a struct's fields to their types
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
+
+// closestKey returns the candidate nearest to key by edit distance
+// (case-insensitive), or "" when none is close enough to be a typo
+func closestKey(key string, candidates []string) string {
+	best, bestDist := "", -1
+	for _, c := range candidates {
+		d := levenshtein(strings.ToLower(key), strings.ToLower(c))
+		if bestDist < 0 || d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	if bestDist < 0 || bestDist > max(2, len(key)/3) {
+		return ""
+	}
+	return best
+}
+
+// levenshtein is the edit distance between a and b
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+// =============================================================================
+// Round-trip of tolerated keys
+// =============================================================================
+
+// tolerateConfigKeys renames deprecated keys in b and collects its unknown
+// keys. Invalid JSON is returned as-is for the caller's decode to report.
+func tolerateConfigKeys(b []byte) ([]byte, map[string]json.RawMessage) {
+	problems, err := ValidateConfig(b)
+	if err != nil {
+		return b, nil
+	}
+	out, unknown, err := applyConfigRenames(b, problems)
+	if err != nil {
+		return b, nil
+	}
+	return out, unknown
+}
+
+// applyConfigRenames rewrites deprecated keys of a config object to their
+// new names (an explicit new key wins), returning the unknown keys to keep
+// on save, by dotted path.
+func applyConfigRenames(b []byte, problems []ConfigProblem) ([]byte, map[string]json.RawMessage, error) {
+	if len(problems) == 0 {
+		return b, nil, nil
+	}
+	var root map[string]any
+	if err := json.Unmarshal(b, &root); err != nil {
+		return b, nil, err
+	}
+	unknown := map[string]json.RawMessage{}
+	for _, p := range problems {
+		parent, key := configParent(root, strings.Split(p.Key, "."), false)
+		if parent == nil {
+			continue
+		}
+		v, ok := parent[key]
+		if !ok {
+			continue
+		}
+		switch p.Kind {
+		case ConfigProblemDeprecated:
+			delete(parent, key)
+			dst, newKey := configParent(root, strings.Split(p.Suggestion, "."), true)
+			if _, exists := dst[newKey]; !exists {
+				dst[newKey] = v
+			}
+		case ConfigProblemUnknown:
+			raw, err := json.Marshal(v)
+			if err == nil {
+				unknown[p.Key] = raw
+			}
+		}
+	}
+	out, err := json.Marshal(root)
+	if err != nil {
+		return b, nil, err
+	}
+	return out, unknown, nil
+}
+
+// configParent returns the object holding the last key of path, creating
+// missing objects along the way when create is set
+func configParent(root map[string]any, path []string, create bool) (map[string]any, string) {
+	obj := root
+	for _, key := range path[:len(path)-1] {
+		next, ok := obj[key].(map[string]any)
+		if !ok {
+			if !create {
+				return nil, ""
+			}
+			next = map[string]any{}
+			obj[key] = next
+		}
+		obj = next
+	}
+	return obj, path[len(path)-1]
+}
+
+// marshalConfig encodes cfg for saving, writing back the unknown keys it
+// was loaded with
+func marshalConfig(cfg Config) ([]byte, error) {
+	b, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil || len(cfg.unknown) == 0 {
+		return b, err
+	}
+	var root map[string]any
+	if err := json.Unmarshal(b, &root); err != nil {
+		return nil, err
+	}
+	for key, raw := range cfg.unknown {
+		var v any
+		if err := json.Unmarshal(raw, &v); err != nil {
+			continue
+		}
+		parent, name := configParent(root, strings.Split(key, "."), true)
+		if _, exists := parent[name]; !exists {
+			parent[name] = v
+		}
+	}
+	return json.MarshalIndent(root, "", "  ")
+}
@@ -0,0 +1,155 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateConfigSuggestsClosestKeyWithLine(t *testing.T) {
+	b := []byte(`{
+  "version": 1,
+  "scheme": "App",
+  "configuratoin": "Release",
+  "tui": {
+    "notfy": "bell"
+  }
+}`)
+	problems, err := ValidateConfig(b)
+	if err != nil {
+		t.Fatalf("ValidateConfig: %v", err)
+	}
+	if len(problems) != 2 {
+		t.Fatalf("expected 2 problems, got %+v", problems)
+	}
+	want := []ConfigProblem{
+		{Key: "configuratoin", Line: 4, Kind: ConfigProblemUnknown, Suggestion: "configuration"},
+		{Key: "tui.notfy", Line: 6, Kind: ConfigProblemUnknown, Suggestion: "tui.notify"},
+	}
+	for i, w := range want {
+		if problems[i] != w {
+			t.Fatalf("problem %d = %+v, want %+v", i, problems[i], w)
+		}
+	}
+	if got := problems[0].String(); got != `line 4: unknown key "configuratoin" (did you mean "configuration"?)` {
+		t.Fatalf("unexpected message %q", got)
+	}
+}
+
+func TestValidateConfigIgnoresFreeFormMapsAndCase(t *testing.T) {
+	b := []byte(`{
+  "Scheme": "App",
+  "xcodebuild": {"env": {"MY_FLAG": "1"}},
+  "launch": {"consoleLogLevels": {"debug": false}},
+  "totallyUnrelatedSetting": {"nested": true}
+}`)
+	problems, err := ValidateConfig(b)
+	if err != nil {
+		t.Fatalf("ValidateConfig: %v", err)
+	}
+	if len(problems) != 1 {
+		t.Fatalf("expected only the unrelated key, got %+v", problems)
+	}
+	if p := problems[0]; p.Key != "totallyUnrelatedSetting" || p.Suggestion != "" {
+		t.Fatalf("unexpected problem %+v", p)
+	}
+}
+
+func TestConfigKeyRenamesTargetKnownKeys(t *testing.T) {
+	for from, to := range configKeyRenames {
+		if known, _ := lookupConfigKey(strings.Split(to, ".")); !known {
+			t.Fatalf("rename %s -> %s targets an unknown key", from, to)
+		}
+	}
+}
+
+func TestLoadConfigHonorsDeprecatedKeys(t *testing.T) {
+	saved := configKeyRenames
+	configKeyRenames = map[string]string{"test.planName": "test.plan", "launch.environment": "launch.env"}
+	t.Cleanup(func() { configKeyRenames = saved })
+
+	root := t.TempDir()
+	writeTestConfig(t, root, fmt.Sprintf(`{
+  "version": %d,
+  "scheme": "App",
+  "test": {"planName": "Smoke"},
+  "launch": {"environment": {"A": "1"}}
+}`, ConfigVersion))
+	cfg, err := LoadConfig(root, "")
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if cfg.Test.Plan != "Smoke" {
+		t.Fatalf("deprecated test key not honored: %+v", cfg.Test)
+	}
+	if cfg.Launch.Env["A"] != "1" {
+		t.Fatalf("deprecated launch key not honored: %+v", cfg.Launch.Env)
+	}
+
+	problems, err := CheckConfig(root, "")
+	if err != nil {
+		t.Fatalf("CheckConfig: %v", err)
+	}
+	if len(problems) != 2 || problems[0].Kind != ConfigProblemDeprecated || problems[0].Suggestion != "test.plan" {
+		t.Fatalf("unexpected problems %+v", problems)
+	}
+}
+
+func TestSaveConfigPreservesUnknownKeys(t *testing.T) {
+	root := t.TempDir()
+	writeTestConfig(t, root, fmt.Sprintf(`{
+  "version": %d,
+  "scheme": "App",
+  "futureSetting": {"level": 3},
+  "tui": {"futureToggle": true}
+}`, ConfigVersion))
+	cfg, err := LoadConfig(root, "")
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	cfg.Scheme = "Other"
+	if err := SaveConfig(root, "", cfg); err != nil {
+		t.Fatalf("SaveConfig: %v", err)
+	}
+
+	b, err := os.ReadFile(ConfigPath(root))
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	var saved map[string]any
+	if err := json.Unmarshal(b, &saved); err != nil {
+		t.Fatalf("saved config is not JSON: %v", err)
+	}
+	if saved["scheme"] != "Other" {
+		t.Fatalf("scheme not saved: %v", saved["scheme"])
+	}
+	future, _ := saved["futureSetting"].(map[string]any)
+	if future["level"] != float64(3) {
+		t.Fatalf("futureSetting dropped: %s", b)
+	}
+	tui, _ := saved["tui"].(map[string]any)
+	if tui["futureToggle"] != true {
+		t.Fatalf("tui.futureToggle dropped: %s", b)
+	}
+}
+
+func TestCheckConfigMissingFile(t *testing.T) {
+	problems, err := CheckConfig(t.TempDir(), "")
+	if err != nil || problems != nil {
+		t.Fatalf("expected no problems for a missing config, got %+v, %v", problems, err)
+	}
+}
+
+func writeTestConfig(t *testing.T, root, content string) {
+	t.Helper()
+	path := ConfigPath(root)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+}
@@ -0,0 +1,54 @@
+package core
+
+import "strings"
+
+// ConsoleLineLevel returns the level letter (D/I/W/E/F) of a formatted console
+// line ("15:04:05.000 W App[123:0]\nmessage"), or "" when it has none.
+func ConsoleLineLevel(line string) string {
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return ""
+	}
+	r := []rune(strings.TrimSpace(fields[1]))
+	if len(r) == 0 {
+		return ""
+	}
+	return strings.ToUpper(string(r[0]))
+}
+
+// ConsoleLevelEnabled reports whether lines of level pass Launch.ConsoleLogLevels.
+// Unknown and missing levels are shown.
+func ConsoleLevelEnabled(levels map[string]bool, level string) bool {
+	if level == "" {
+		return true
+	}
+	if v, ok := levels[level]; ok {
+		return v
+	}
+	return true
+}
+
+// FilterConsoleLevels drops app and unified console lines whose level is
+// disabled in levels, so CLI output honors the same setting as the TUI.
+func FilterConsoleLevels(emit Emitter, levels map[string]bool) Emitter {
+	if emit == nil {
+		return nil
+	}
+	return consoleLevelEmitter{next: emit, levels: levels}
+}
+
+type consoleLevelEmitter struct {
+	next   Emitter
+	levels map[string]bool
+}
+
+func (e consoleLevelEmitter) Emit(ev Event) {
+	if mm, ok := ev.Data.(map[string]any); ok && ev.Type == "log" {
+		if s, _ := mm["stream"].(string); s == "app" || s == "unified" {
+			if !ConsoleLevelEnabled(e.levels, ConsoleLineLevel(ev.Msg)) {
+				return
+			}
+		}
+	}
+	e.next.Emit(ev)
+}
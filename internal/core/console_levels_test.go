@@ -0,0 +1,20 @@
+package core
+
+import "testing"
+
+func TestFilterConsoleLevels(t *testing.T) {
+	rec := &recordingEmitter{}
+	emit := FilterConsoleLevels(rec, map[string]bool{"D": false, "I": false, "W": true, "E": true, "F": true})
+
+	emit.Emit(LogStream("run", "10:00:00.000 I App[1:0]\nhello", "app"))
+	emit.Emit(LogStream("run", "10:00:01.000 W App[1:0]\nlow memory", "unified"))
+	emit.Emit(LogStream("run", "Filtering the log data using ...", "system"))
+	emit.Emit(Log("run", "Installing app"))
+
+	if len(rec.events) != 3 {
+		t.Fatalf("expected info console line dropped, got %+v", rec.events)
+	}
+	if rec.events[0].Msg != "10:00:01.000 W App[1:0]\nlow memory" {
+		t.Fatalf("unexpected first event %q", rec.events[0].Msg)
+	}
+}
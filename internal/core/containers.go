@@ -0,0 +1,248 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// containerScanDepth is how many directories below the project root
+// discovery looks for workspaces and projects.
+const containerScanDepth = 2
+
+// deprioritizedDirs hold dependencies, build output, or sample apps, whose
+// workspaces and projects are rarely the one to build; the value is how
+// much a container under one loses in ScoreContainer.
+var deprioritizedDirs = map[string]int{
+	"Pods":     100,
+	"Carthage": 100,
+	".build":   100,
+	"Examples": 50,
+	"Example":  50,
+}
+
+// ContainerCandidate is a discovered workspace or project.
+type ContainerCandidate struct {
+	// Path is relative to the project root, as saved in the config.
+	Path      string `json:"path"`
+	Workspace bool   `json:"workspace"`
+	// Schemes counts the shared and user schemes found on disk.
+	Schemes int `json:"schemes"`
+	Score   int `json:"score"`
+}
+
+// Dir is the candidate's directory relative to the project root ("." for
+// the root itself).
+func (c ContainerCandidate) Dir() string {
+	return filepath.Dir(c.Path)
+}
+
+// Kind is "workspace" or "project".
+func (c ContainerCandidate) Kind() string {
+	if c.Workspace {
+		return "workspace"
+	}
+	return "project"
+}
+
+// Deprioritized reports whether the candidate is under Pods/, Carthage/,
+// .build/, or Examples/.
+func (c ContainerCandidate) Deprioritized() bool {
+	return containerDeprioritized(c.Path)
+}
+
+// Apply selects the candidate in cfg.
+func (c ContainerCandidate) Apply(cfg *Config) {
+	cfg.Workspace, cfg.Project = "", ""
+	if c.Workspace {
+		cfg.Workspace = c.Path
+	} else {
+		cfg.Project = c.Path
+	}
+}
+
+// ScoreContainer ranks a workspace or project path relative to the project
+// root: workspaces beat projects, shallower paths beat deeper ones, and
+// paths under Pods/, Carthage/, .build/, or Examples/ sink to the bottom.
+func ScoreContainer(path string, workspace bool) int {
+	score := 0
+	if workspace {
+		score += 10
+	}
+	for _, dir := range containerDirs(path) {
+		score -= 5 + deprioritizedDirs[dir]
+	}
+	return score
+}
+
+// containerDeprioritized reports whether path is under one of the
+// deprioritizedDirs
+func containerDeprioritized(path string) bool {
+	for _, dir := range containerDirs(path) {
+		if deprioritizedDirs[dir] > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// containerDirs splits the directories of a relative container path
+func containerDirs(path string) []string {
+	dir := filepath.ToSlash(filepath.Dir(path))
+	if dir == "." || dir == "" {
+		return nil
+	}
+	return strings.Split(dir, "/")
+}
+
+// RankContainers lists the discovered workspaces and projects of info, best
+// first.
+func RankContainers(info ContextInfo) []ContainerCandidate {
+	return rankContainers(info.ProjectRoot, info.Workspaces, info.Projects)
+}
+
+func rankContainers(projectRoot string, workspaces, projects []string) []ContainerCandidate {
+	cands := make([]ContainerCandidate, 0, len(workspaces)+len(projects))
+	for _, ws := range workspaces {
+		cands = append(cands, ContainerCandidate{
+			Path:      ws,
+			Workspace: true,
+			Schemes:   len(listSchemesFromFS(projectRoot, Config{Workspace: ws}, nil)),
+			Score:     ScoreContainer(ws, true),
+		})
+	}
+	for _, p := range projects {
+		cands = append(cands, ContainerCandidate{
+			Path:    p,
+			Schemes: len(listSchemesFromFS(projectRoot, Config{Project: p}, nil)),
+			Score:   ScoreContainer(p, false),
+		})
+	}
+	sort.SliceStable(cands, func(i, j int) bool { return cands[i].Score > cands[j].Score })
+	return cands
+}
+
+// PickContainer returns the container to use when the config names none,
+// or false when there is none or it is ambiguous and the user should
+// choose. Containers under Pods/, Carthage/, .build/, or Examples/ are never
+// picked, projects that a remaining workspace includes don't compete with
+// it, and only the shallowest of the rest compete, so a root App.xcodeproj
+// beats Tools/Gen.xcodeproj.
+func PickContainer(projectRoot string, ranked []ContainerCandidate) (ContainerCandidate, bool) {
+	var plausible []ContainerCandidate
+	for _, c := range ranked {
+		if !containerDeprioritized(c.Path) {
+			plausible = append(plausible, c)
+		}
+	}
+	included := map[string]bool{}
+	for _, c := range plausible {
+		if c.Workspace {
+			for _, p := range workspaceProjectPaths(projectRoot, c.Path) {
+				included[p] = true
+			}
+		}
+	}
+	var picks []ContainerCandidate
+	shallowest := -1
+	for _, c := range plausible {
+		if !c.Workspace && included[filepath.Clean(absJoin(projectRoot, c.Path))] {
+			continue
+		}
+		depth := len(containerDirs(c.Path))
+		if shallowest >= 0 && depth > shallowest {
+			continue
+		}
+		if depth < shallowest {
+			picks = nil
+		}
+		shallowest = depth
+		picks = append(picks, c)
+	}
+	if len(picks) != 1 {
+		return ContainerCandidate{}, false
+	}
+	return picks[0], true
+}
+
+// HasProject reports whether projectRoot has something to build: a
+// configured workspace or project, a Package.swift, or a workspace or
+// project that discovery finds (like ios/App.xcodeproj). In the home
+// directory only the root counts, so projects a level down aren't taken
+// for one.
+func HasProject(projectRoot string, cfg Config) bool {
+	if cfg.Workspace != "" || cfg.Project != "" {
+		return true
+	}
+	if _, err := os.Stat(filepath.Join(projectRoot, "Package.swift")); err == nil {
+		return true
+	}
+	scan := findContainers
+	if isHomeDir(projectRoot) {
+		scan = scanProjectEntries
+	}
+	workspaces, projects, err := scan(projectRoot)
+	return err == nil && len(workspaces)+len(projects) > 0
+}
+
+// isHomeDir reports whether dir is the user's home directory
+func isHomeDir(dir string) bool {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return false
+	}
+	return filepath.Clean(home) == filepath.Clean(dir)
+}
+
+// ContainerChoices formats ranked candidates for messages, e.g.
+// "Examples/Demo/Demo.xcworkspace (workspace, 3 schemes)".
+func ContainerChoices(ranked []ContainerCandidate) []string {
+	lines := make([]string, len(ranked))
+	for i, c := range ranked {
+		lines[i] = c.Path + " (" + c.Kind() + ", " + countNoun(c.Schemes, "scheme") + ")"
+	}
+	return lines
+}
+
+// findContainers lists the workspaces and projects at most
+// containerScanDepth directories below projectRoot, root first, as
+// relative paths. Hidden directories, node_modules, DerivedData, and the
+// insides of workspaces and projects are skipped.
+func findContainers(projectRoot string) (workspaces, projects []string, err error) {
+	workspaces, projects = []string{}, []string{}
+	var walk func(rel string, depth int) error
+	walk = func(rel string, depth int) error {
+		entries, err := os.ReadDir(filepath.Join(projectRoot, rel))
+		if err != nil {
+			return err
+		}
+		var subdirs []string
+		for _, e := range entries {
+			name := e.Name()
+			if !e.IsDir() || strings.HasPrefix(name, ".") {
+				continue
+			}
+			path := filepath.Join(rel, name)
+			switch {
+			case strings.HasSuffix(name, ".xcworkspace"):
+				workspaces = append(workspaces, path)
+			case strings.HasSuffix(name, ".xcodeproj"):
+				projects = append(projects, path)
+			case name == "node_modules" || name == "DerivedData":
+			default:
+				subdirs = append(subdirs, path)
+			}
+		}
+		if depth == containerScanDepth {
+			return nil
+		}
+		for _, dir := range subdirs {
+			// Unreadable subdirectories don't fail discovery
+			_ = walk(dir, depth+1)
+		}
+		return nil
+	}
+	err = walk("", 0)
+	return workspaces, projects, err
+}
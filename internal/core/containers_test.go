@@ -0,0 +1,205 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// makeContainers creates the given workspaces and projects under root; a
+// workspace's value lists the projects it includes
+func makeContainers(t *testing.T, root string, containers map[string][]string) {
+	t.Helper()
+	for path, refs := range containers {
+		dir := filepath.Join(root, path)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if len(refs) == 0 {
+			continue
+		}
+		data := `<?xml version="1.0" encoding="UTF-8"?>` + "\n<Workspace version=\"1.0\">\n"
+		for _, ref := range refs {
+			data += `  <FileRef location="group:` + ref + `"></FileRef>` + "\n"
+		}
+		data += "</Workspace>\n"
+		if err := os.WriteFile(filepath.Join(dir, "contents.xcworkspacedata"), []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestScoreContainer(t *testing.T) {
+	ordered := []struct {
+		path      string
+		workspace bool
+	}{
+		{"App.xcworkspace", true},
+		{"App.xcodeproj", false},
+		{"Apps/Admin/Admin.xcodeproj", false},
+		{"Examples/Demo/Demo.xcworkspace", true},
+		{"Pods/Pods.xcodeproj", false},
+		{".build/checkouts/Lib/Lib.xcodeproj", false},
+	}
+	for i := 1; i < len(ordered); i++ {
+		a, b := ordered[i-1], ordered[i]
+		if ScoreContainer(a.path, a.workspace) <= ScoreContainer(b.path, b.workspace) {
+			t.Errorf("%s should rank above %s", a.path, b.path)
+		}
+	}
+	for path, want := range map[string]bool{"Pods/Pods.xcodeproj": true, "Carthage/Checkouts/X/X.xcodeproj": true, "Examples/Demo.xcodeproj": true, "Tools/Gen.xcodeproj": false, "App.xcodeproj": false} {
+		if got := containerDeprioritized(path); got != want {
+			t.Errorf("containerDeprioritized(%q) = %v", path, got)
+		}
+	}
+}
+
+func TestFindContainers(t *testing.T) {
+	root := t.TempDir()
+	makeContainers(t, root, map[string][]string{
+		"App.xcworkspace":                   {"App.xcodeproj", "Pods/Pods.xcodeproj"},
+		"App.xcodeproj":                     nil,
+		"Pods/Pods.xcodeproj":               nil,
+		"Examples/Demo/Demo.xcodeproj":      nil,
+		"Examples/Demo/Deep/Deep.xcodeproj": nil,
+		".build/Lib.xcodeproj":              nil,
+		"App.xcodeproj/project.xcworkspace": nil,
+	})
+	workspaces, projects, err := findContainers(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(workspaces, []string{"App.xcworkspace"}) {
+		t.Fatalf("workspaces = %v", workspaces)
+	}
+	if want := []string{"App.xcodeproj", filepath.Join("Examples", "Demo", "Demo.xcodeproj"), filepath.Join("Pods", "Pods.xcodeproj")}; !reflect.DeepEqual(projects, want) {
+		t.Fatalf("projects = %v, want %v", projects, want)
+	}
+}
+
+func TestPickContainer(t *testing.T) {
+	cases := []struct {
+		name       string
+		containers map[string][]string
+		want       string
+	}{
+		{"CocoaPods workspace", map[string][]string{
+			"App.xcworkspace":     {"App.xcodeproj", "Pods/Pods.xcodeproj"},
+			"App.xcodeproj":       nil,
+			"Pods/Pods.xcodeproj": nil,
+		}, "App.xcworkspace"},
+		{"project beside examples", map[string][]string{
+			"App.xcodeproj":                  nil,
+			"Examples/Demo/Demo.xcworkspace": {"Demo.xcodeproj"},
+			"Examples/Demo/Demo.xcodeproj":   nil,
+		}, "App.xcodeproj"},
+		{"workspace and unrelated project", map[string][]string{
+			"App.xcworkspace": {"App.xcodeproj"},
+			"App.xcodeproj":   nil,
+			"Tool.xcodeproj":  nil,
+		}, ""},
+		{"two workspaces", map[string][]string{
+			"App.xcworkspace":   {"App.xcodeproj"},
+			"Admin.xcworkspace": {"App.xcodeproj"},
+			"App.xcodeproj":     nil,
+		}, ""},
+		{"root project plus nested project", map[string][]string{
+			"App.xcodeproj":              nil,
+			"Tools/Gen.xcodeproj":        nil,
+			"External/Lib/Lib.xcodeproj": nil,
+		}, "App.xcodeproj"},
+		{"nested projects side by side", map[string][]string{
+			"ios/App.xcodeproj":   nil,
+			"tools/Gen.xcodeproj": nil,
+		}, ""},
+		{"only examples", map[string][]string{
+			"Examples/Demo.xcodeproj": nil,
+		}, ""},
+	}
+	for _, tc := range cases {
+		root := t.TempDir()
+		makeContainers(t, root, tc.containers)
+		workspaces, projects, err := findContainers(root)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ranked := rankContainers(root, workspaces, projects)
+		got, ok := PickContainer(root, ranked)
+		if ok != (tc.want != "") || got.Path != tc.want {
+			t.Errorf("%s: picked %q (%v), want %q", tc.name, got.Path, ok, tc.want)
+		}
+	}
+}
+
+func TestContainerChoices(t *testing.T) {
+	got := ContainerChoices([]ContainerCandidate{
+		{Path: "App.xcworkspace", Workspace: true, Schemes: 3},
+		{Path: "Tool.xcodeproj", Schemes: 1},
+	})
+	want := []string{"App.xcworkspace (workspace, 3 schemes)", "Tool.xcodeproj (project, 1 scheme)"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("choices = %v", got)
+	}
+}
+
+func TestHasProject(t *testing.T) {
+	root := t.TempDir()
+	if HasProject(root, Config{}) {
+		t.Fatal("empty directory has a project")
+	}
+	if !HasProject(root, Config{Project: "Elsewhere/App.xcodeproj"}) {
+		t.Fatal("configured project ignored")
+	}
+	makeContainers(t, root, map[string][]string{"ios/App.xcodeproj": nil})
+	if !HasProject(root, Config{}) {
+		t.Fatal("nested project not found")
+	}
+
+	// In the home directory only the root counts
+	t.Setenv("HOME", root)
+	if HasProject(root, Config{}) {
+		t.Fatal("nested project in the home directory counted as a project")
+	}
+	makeContainers(t, root, map[string][]string{"App.xcodeproj": nil})
+	if !HasProject(root, Config{}) {
+		t.Fatal("root project not found")
+	}
+	pkg := t.TempDir()
+	if err := os.WriteFile(filepath.Join(pkg, "Package.swift"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if !HasProject(pkg, Config{}) {
+		t.Fatal("Swift package not found")
+	}
+}
+
+func TestEnsureSchemeAndConfigFromFSPicksContainer(t *testing.T) {
+	root := t.TempDir()
+	makeContainers(t, root, map[string][]string{
+		"App.xcworkspace":            {"App.xcodeproj", "Pods/Pods.xcodeproj"},
+		"App.xcodeproj":              nil,
+		"Pods/Pods.xcodeproj":        nil,
+		"Admin.xcodeproj":            nil,
+		"Apps/Tools/Tools.xcodeproj": nil,
+	})
+	_, err := ensureSchemeAndConfigFromFS(root, Config{Scheme: "App"}, nil)
+	var ierr *InitError
+	if !errors.As(err, &ierr) || ierr.Obj.Code != "PROJECT_AMBIGUOUS" || !strings.Contains(ierr.Obj.Detail, "Admin.xcodeproj") {
+		t.Fatalf("expected PROJECT_AMBIGUOUS, got %v", err)
+	}
+	if obj := schemeRequiredError(err); obj.Code != "PROJECT_AMBIGUOUS" {
+		t.Fatalf("error object = %+v", obj)
+	}
+
+	// A nested project doesn't compete with the root's
+	if err := os.RemoveAll(filepath.Join(root, "Admin.xcodeproj")); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := ensureSchemeAndConfigFromFS(root, Config{Scheme: "App"}, nil)
+	if err != nil || cfg.Workspace != "App.xcworkspace" || cfg.Project != "" {
+		t.Fatalf("cfg = %+v, err = %v", cfg, err)
+	}
+}
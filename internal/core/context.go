@@ -2,60 +2,79 @@ package core
 
 import (
 	"context"
-	"os"
 	"path/filepath"
-	"strings"
 	"time"
 )
 
 type ContextInfo struct {
-	ProjectRoot    string      `json:"projectRoot"`
-	Workspaces     []string    `json:"workspaces"`
-	Projects       []string    `json:"projects"`
-	Schemes        []string    `json:"schemes"`
-	Configurations []string    `json:"configurations"`
-	Simulators     []Simulator `json:"simulators"`
-	Devices        []Device    `json:"devices"`
+	ProjectRoot    string       `json:"projectRoot"`
+	Workspaces     []string     `json:"workspaces"`
+	Projects       []string     `json:"projects"`
+	Schemes        []string     `json:"schemes"`
+	Configurations []string     `json:"configurations"`
+	Simulators     []Simulator  `json:"simulators"`
+	Devices        []Device     `json:"devices"`
+	Xcode          XcodeVersion `json:"xcode"`
+	// TestPlans lists the scheme's test plans (only with ListTestPlans).
+	TestPlans []string `json:"testPlans,omitempty"`
+	// Package names the Swift package when the root has a Package.swift and
+	// no workspace or project; schemes are then its products and targets.
+	Package string `json:"package,omitempty"`
+	// Targets are the project's targets, for target builds (only when
+	// xcodebuild -list ran for a project; see ListProjectTargets).
+	Targets []ProjectTarget `json:"targets,omitempty"`
 }
 
 type ContextOptions struct {
 	UseXcodebuildList     bool
 	AllowXcodebuildList   bool
 	XcodebuildListTimeout time.Duration
+	// ListTestPlans runs xcodebuild -showTestPlans for the scheme (slow).
+	ListTestPlans bool
 }
 
 func DiscoverContext(ctx context.Context, projectRoot string, cfg Config, emit Emitter, opts ContextOptions) (ContextInfo, Config, error) {
 	emitMaybe(emit, Status("context", "Scanning project root", map[string]any{"path": projectRoot}))
-	entries, err := os.ReadDir(projectRoot)
+	workspaces, projects, err := findContainers(projectRoot)
 	if err != nil {
 		return ContextInfo{}, cfg, err
 	}
-	workspaces := []string{}
-	projects := []string{}
-	for _, e := range entries {
-		name := e.Name()
-		if e.IsDir() && strings.HasSuffix(name, ".xcworkspace") {
-			workspaces = append(workspaces, name)
-		}
-		if e.IsDir() && strings.HasSuffix(name, ".xcodeproj") {
-			projects = append(projects, name)
-		}
-	}
 
-	// Auto-pick workspace/project if unset.
-	if cfg.Workspace == "" && len(workspaces) == 1 {
-		cfg.Workspace = workspaces[0]
+	// Auto-pick workspace/project if unset and unambiguous.
+	if cfg.Workspace == "" && cfg.Project == "" {
+		if c, ok := PickContainer(projectRoot, rankContainers(projectRoot, workspaces, projects)); ok {
+			c.Apply(&cfg)
+		}
 	}
-	if cfg.Project == "" && cfg.Workspace == "" && len(projects) == 1 {
-		cfg.Project = projects[0]
+	// Pods and sample projects don't contribute schemes and configurations
+	// while none is selected
+	var plausibleProjects []string
+	for _, p := range projects {
+		if !containerDeprioritized(p) {
+			plausibleProjects = append(plausibleProjects, p)
+		}
 	}
 
 	// Schemes/configurations via filesystem (fast, no xcodebuild).
 	emitMaybe(emit, Status("context", "Reading schemes/configurations from filesystem", nil))
-	schemes := listSchemesFromFS(projectRoot, cfg, projects)
-	configurations := listConfigurationsFromPBXProj(projectRoot, cfg, projects)
+	schemes := listSchemesFromFS(projectRoot, cfg, plausibleProjects)
+	configurations := listConfigurationsFromPBXProj(projectRoot, cfg, plausibleProjects)
+
+	packageName := ""
+	if isSwiftPackageRoot(projectRoot, cfg) {
+		pkg, pkgCfg, err := swiftPackageContext(ctx, projectRoot, cfg, emit)
+		if err == nil {
+			cfg = pkgCfg
+			packageName = pkg.Name
+			schemes = pkg.Schemes()
+			configurations = append([]string{}, SwiftPackageConfigurations...)
+		} else {
+			emitMaybe(emit, Warn("context", "Could not read Swift package: "+err.Error()))
+		}
+	}
 
 	// Optionally use xcodebuild -list -json (slow) when requested or as fallback.
+	var targets []ProjectTarget
 	useXcodebuild := opts.UseXcodebuildList || (opts.AllowXcodebuildList && (len(schemes) == 0 || len(configurations) == 0))
 	if useXcodebuild && (cfg.Workspace != "" || cfg.Project != "") {
 		listCtx := ctx
@@ -83,27 +102,57 @@ func DiscoverContext(ctx context.Context, projectRoot string, cfg Config, emit E
 			if cfg.Configuration == "" && len(list.Configurations) == 1 {
 				cfg.Configuration = list.Configurations[0]
 			}
+			if cfg.Workspace == "" {
+				for _, name := range list.Targets {
+					targets = append(targets, ProjectTarget{Name: name, Project: cfg.Project})
+				}
+			}
 		} else {
 			emitMaybe(emit, Warn("context", "Could not list schemes/configurations via xcodebuild: "+err.Error()))
 		}
 	}
 
+	dests := listDestinations(ctx, emit, cfg.Xcodebuild.PreserveLocale, 0)
 	simulators := []Simulator{}
-	if list, err := SimctlList(ctx, emit); err == nil {
-		simulators = FlattenSimulators(list)
-	} else {
-		emitMaybe(emit, Warn("context", "Could not list simulators: "+err.Error()))
+	if dests.SimulatorsErr != nil {
+		emitMaybe(emit, Warn("context", "Could not list simulators: "+dests.SimulatorsErr.Error()))
+	} else if dests.Simulators != nil {
+		simulators = dests.Simulators
 	}
 
 	devices := []Device{}
-	if DevicectlAvailable(ctx) {
-		if devs, err := DevicectlList(ctx, emit); err == nil {
-			devices = devs
-		} else {
-			emitMaybe(emit, Warn("context", "Could not list devices: "+err.Error()))
+	switch {
+	case dests.DevicesErr == nil:
+		if dests.Devices != nil {
+			devices = dests.Devices
 		}
-	} else {
+	case !DevicectlAvailable(ctx, cfg.Xcodebuild.PreserveLocale):
 		emitMaybe(emit, Warn("context", "devicectl not available (install Xcode Command Line Tools / select Xcode)"))
+	default:
+		emitMaybe(emit, Warn("context", "Could not list devices: "+dests.DevicesErr.Error()))
+	}
+
+	xcode, err := XcodeInfo(ctx)
+	if err != nil {
+		emitMaybe(emit, Warn("context", "Could not read Xcode version: "+err.Error()))
+	} else if xcode.CommandLineTools {
+		emitMaybe(emit, Warn("context", "No full Xcode selected ("+xcode.DeveloperDir+")"))
+	}
+	if rerr := CheckRequiredXcode(xcode, cfg.RequiredXcode); rerr != nil {
+		emitMaybe(emit, Warn("context", rerr.Error()))
+	}
+
+	var testPlans []string
+	if opts.ListTestPlans && cfg.Scheme != "" && packageName == "" {
+		emitMaybe(emit, Status("context", "Running xcodebuild -showTestPlans", map[string]any{"scheme": cfg.Scheme}))
+		planCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		plans, err := XcodebuildTestPlans(planCtx, projectRoot, cfg)
+		cancel()
+		if err == nil {
+			testPlans = plans
+		} else {
+			emitMaybe(emit, Warn("context", "Could not list test plans: "+err.Error()))
+		}
 	}
 
 	info := ContextInfo{
@@ -114,6 +163,14 @@ func DiscoverContext(ctx context.Context, projectRoot string, cfg Config, emit E
 		Configurations: configurations,
 		Simulators:     simulators,
 		Devices:        devices,
+		Xcode:          xcode,
+		TestPlans:      testPlans,
+		Package:        packageName,
+		Targets:        targets,
+	}
+	// Keep a copy for shell completion, which can't wait for discovery
+	if len(workspaces) > 0 || len(projects) > 0 || packageName != "" {
+		_ = WriteContextCache(projectRoot, info)
 	}
 	return info, cfg, nil
 }
@@ -66,10 +66,10 @@ func workspaceProjectPaths(projectRoot, workspaceRel string) []string {
 		switch kind {
 		case "absolute":
 			fullPath = path
-		case "group", "container":
-			fullPath = filepath.Join(workspacePath, path)
 		default:
-			fullPath = filepath.Join(workspacePath, path)
+			// group: and container: paths of a top-level entry are relative
+			// to the directory holding the workspace
+			fullPath = filepath.Join(filepath.Dir(workspacePath), path)
 		}
 		fullPath = filepath.Clean(fullPath)
 		paths = append(paths, fullPath)
@@ -228,22 +228,34 @@ func scanProjectEntries(projectRoot string) ([]string, []string, error) {
 	return workspaces, projects, nil
 }
 
+// ensureSchemeAndConfigFromFS fills in the container, scheme, and
+// configuration an op needs from the file system. The container is picked
+// like DiscoverContext does; when several remain it fails with a
+// PROJECT_AMBIGUOUS *InitError instead of guessing.
 func ensureSchemeAndConfigFromFS(projectRoot string, cfg Config, emit Emitter) (Config, error) {
-	workspaces, projects, err := scanProjectEntries(projectRoot)
+	workspaces, projects, err := findContainers(projectRoot)
 	if err != nil {
 		return cfg, err
 	}
 
-	// Auto-pick workspace/project if unset.
-	if cfg.Workspace == "" && len(workspaces) == 1 {
-		cfg.Workspace = workspaces[0]
+	// Auto-pick workspace/project if unset and unambiguous.
+	if cfg.Workspace == "" && cfg.Project == "" {
+		ranked := rankContainers(projectRoot, workspaces, projects)
+		if c, ok := PickContainer(projectRoot, ranked); ok {
+			c.Apply(&cfg)
+		} else if len(ranked) > 1 {
+			return cfg, initError("PROJECT_AMBIGUOUS", "Several workspaces and projects found", strings.Join(ContainerChoices(ranked), "; "), "Set workspace or project in .xcbolt/config.json, or run `xcbolt init --workspace` or `--xcodeproj` with one of these paths.")
+		}
 	}
-	if cfg.Project == "" && cfg.Workspace == "" && len(projects) == 1 {
-		cfg.Project = projects[0]
+	var plausibleProjects []string
+	for _, p := range projects {
+		if !containerDeprioritized(p) {
+			plausibleProjects = append(plausibleProjects, p)
+		}
 	}
 
-	schemes := listSchemesFromFS(projectRoot, cfg, projects)
-	configurations := listConfigurationsFromPBXProj(projectRoot, cfg, projects)
+	schemes := listSchemesFromFS(projectRoot, cfg, plausibleProjects)
+	configurations := listConfigurationsFromPBXProj(projectRoot, cfg, plausibleProjects)
 
 	if len(schemes) > 0 {
 		if cfg.Scheme == "" || !stringInSlice(cfg.Scheme, schemes) {
@@ -263,6 +275,22 @@ func ensureSchemeAndConfigFromFS(projectRoot string, cfg Config, emit Emitter) (
 	return cfg, nil
 }
 
+// schemeRequiredError is the error object of a failed
+// ensureSchemeAndConfigFromFS: PROJECT_AMBIGUOUS when no container could be
+// picked, else SCHEME_REQUIRED.
+func schemeRequiredError(err error) ErrorObject {
+	var ierr *InitError
+	if errors.As(err, &ierr) {
+		return ierr.Obj
+	}
+	return ErrorObject{
+		Code:       "SCHEME_REQUIRED",
+		Message:    "No scheme configured",
+		Detail:     err.Error(),
+		Suggestion: "Run `xcbolt init` or pass --scheme.",
+	}
+}
+
 func stringInSlice(needle string, haystack []string) bool {
 	for _, v := range haystack {
 		if v == needle {
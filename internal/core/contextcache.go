@@ -0,0 +1,53 @@
+package core
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ContextCacheTTL is how long .xcbolt/context.json counts as recent for
+// shell completion.
+const ContextCacheTTL = 10 * time.Minute
+
+// contextCacheFile is the last discovered context with its time.
+type contextCacheFile struct {
+	SavedAt string      `json:"savedAt"`
+	Context ContextInfo `json:"context"`
+}
+
+func ContextCachePath(projectRoot string) string {
+	return filepath.Join(projectRoot, ".xcbolt", "context.json")
+}
+
+// WriteContextCache stores info as the project's last discovered context.
+func WriteContextCache(projectRoot string, info ContextInfo) error {
+	b, err := json.Marshal(contextCacheFile{SavedAt: time.Now().UTC().Format(time.RFC3339), Context: info})
+	if err != nil {
+		return err
+	}
+	if err := EnsureProjectDirs(projectRoot); err != nil {
+		return err
+	}
+	return writeFileAtomic(ContextCachePath(projectRoot), b, 0o644)
+}
+
+// ReadContextCache returns the project's last discovered context if it was
+// saved within maxAge. ok is false when there is none, or it is older or
+// unreadable.
+func ReadContextCache(projectRoot string, maxAge time.Duration) (ContextInfo, bool) {
+	b, err := os.ReadFile(ContextCachePath(projectRoot))
+	if err != nil {
+		return ContextInfo{}, false
+	}
+	var cache contextCacheFile
+	if err := json.Unmarshal(b, &cache); err != nil {
+		return ContextInfo{}, false
+	}
+	saved, err := time.Parse(time.RFC3339, cache.SavedAt)
+	if err != nil || time.Since(saved) > maxAge {
+		return ContextInfo{}, false
+	}
+	return cache.Context, true
+}
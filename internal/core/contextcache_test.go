@@ -0,0 +1,24 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWriteContextCache(t *testing.T) {
+	root := t.TempDir()
+	info := ContextInfo{Schemes: []string{"App"}, Configurations: []string{"Debug"}}
+	if err := WriteContextCache(root, info); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(root, ".xcbolt", ".gitignore")); err != nil {
+		t.Fatalf("context cache written without .xcbolt/.gitignore: %v", err)
+	}
+	got, ok := ReadContextCache(root, time.Minute)
+	if !ok || !reflect.DeepEqual(got.Schemes, info.Schemes) || !reflect.DeepEqual(got.Configurations, info.Configurations) {
+		t.Fatalf("cached context = %+v, %v", got, ok)
+	}
+}
@@ -0,0 +1,112 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Default coverage thresholds in percent (test.coverageWarn/coverageFail).
+const (
+	DefaultCoverageWarn = 80
+	DefaultCoverageFail = 50
+)
+
+// CoverageSummary is the line coverage of a test run.
+type CoverageSummary struct {
+	// LineCoverage is the covered share of executable lines (0..1).
+	LineCoverage float64          `json:"lineCoverage"`
+	Targets      []TargetCoverage `json:"targets,omitempty"`
+}
+
+// TargetCoverage is the line coverage of one built target (e.g. App.app).
+type TargetCoverage struct {
+	Name            string  `json:"name"`
+	LineCoverage    float64 `json:"lineCoverage"`
+	CoveredLines    int     `json:"coveredLines"`
+	ExecutableLines int     `json:"executableLines"`
+}
+
+// Percent is LineCoverage in percent.
+func (c CoverageSummary) Percent() float64 {
+	return c.LineCoverage * 100
+}
+
+// CoverageThresholds returns the warn and fail coverage levels in percent.
+func (c TestConfig) CoverageThresholds() (warn, fail float64) {
+	warn, fail = DefaultCoverageWarn, DefaultCoverageFail
+	if c.CoverageWarn > 0 {
+		warn = c.CoverageWarn
+	}
+	if c.CoverageFail > 0 {
+		fail = c.CoverageFail
+	}
+	return warn, fail
+}
+
+// XcresultCoverage reads the coverage report of a result bundle with
+// `xccov view --report --json`.
+func XcresultCoverage(ctx context.Context, resultBundlePath string) (CoverageSummary, error) {
+	if resultBundlePath == "" {
+		return CoverageSummary{}, errors.New("missing result bundle path")
+	}
+	var out, stderr strings.Builder
+	_, err := RunStreaming(ctx, CmdSpec{
+		Path: "xcrun",
+		Args: []string{"xccov", "view", "--report", "--json", resultBundlePath},
+		StdoutLine: func(s string) {
+			out.WriteString(s)
+			out.WriteString("\n")
+		},
+		StderrLine: func(s string) {
+			if stderr.Len() == 0 {
+				stderr.WriteString(strings.TrimSpace(s))
+			}
+		},
+	})
+	if err != nil {
+		if stderr.Len() > 0 {
+			return CoverageSummary{}, fmt.Errorf("%w: %s", err, stderr.String())
+		}
+		return CoverageSummary{}, err
+	}
+	return ParseCoverageReport([]byte(out.String()))
+}
+
+// ParseCoverageReport parses `xccov view --report --json` output. Targets
+// are sorted by coverage, lowest first.
+func ParseCoverageReport(b []byte) (CoverageSummary, error) {
+	var report struct {
+		LineCoverage    *float64         `json:"lineCoverage"`
+		CoveredLines    int              `json:"coveredLines"`
+		ExecutableLines int              `json:"executableLines"`
+		Targets         []TargetCoverage `json:"targets"`
+	}
+	trim := extractJSONObject(string(b))
+	if trim == "" {
+		trim = string(b)
+	}
+	if err := json.Unmarshal([]byte(trim), &report); err != nil {
+		return CoverageSummary{}, fmt.Errorf("xccov json parse: %w", err)
+	}
+	if report.LineCoverage == nil {
+		return CoverageSummary{}, errors.New("xccov report has no lineCoverage")
+	}
+	sum := CoverageSummary{LineCoverage: *report.LineCoverage, Targets: report.Targets}
+	sort.SliceStable(sum.Targets, func(i, j int) bool {
+		return sum.Targets[i].LineCoverage < sum.Targets[j].LineCoverage
+	})
+	return sum, nil
+}
+
+// withCoverage adds the coverage summary to a test result payload.
+func withCoverage(data map[string]any, cov *CoverageSummary) map[string]any {
+	if cov == nil {
+		return data
+	}
+	data["coverage"] = cov
+	return data
+}
@@ -0,0 +1,118 @@
+package core
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseCoverageReport(t *testing.T) {
+	b, err := os.ReadFile(filepath.Join("testdata", "xccov_report.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cov, err := ParseCoverageReport(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cov.Percent() != 62 || len(cov.Targets) != 2 {
+		t.Fatalf("coverage = %+v", cov)
+	}
+	if cov.Targets[0].Name != "Networking.framework" || cov.Targets[1].CoveredLines != 560 {
+		t.Fatalf("targets should be sorted lowest first: %+v", cov.Targets)
+	}
+
+	if _, err := ParseCoverageReport([]byte(`{"targets": []}`)); err == nil {
+		t.Fatal("expected an error for a report without lineCoverage")
+	}
+	if _, err := ParseCoverageReport([]byte("error: no coverage data")); err == nil {
+		t.Fatal("expected an error for non-JSON output")
+	}
+}
+
+func TestCoverageThresholds(t *testing.T) {
+	if warn, fail := (TestConfig{}).CoverageThresholds(); warn != DefaultCoverageWarn || fail != DefaultCoverageFail {
+		t.Fatalf("defaults = %v, %v", warn, fail)
+	}
+	if warn, fail := (TestConfig{CoverageWarn: 90, CoverageFail: 70}).CoverageThresholds(); warn != 90 || fail != 70 {
+		t.Fatalf("configured = %v, %v", warn, fail)
+	}
+}
+
+// fakeCoverageXcrun puts an xcrun on PATH whose xcodebuild succeeds and
+// whose xccov prints report (or fails when it is empty)
+func fakeCoverageXcrun(t *testing.T, report string) {
+	t.Helper()
+	bin := t.TempDir()
+	xccov := "exit 1"
+	if report != "" {
+		xccov = "cat " + report
+	}
+	script := "#!/bin/sh\ncase \"$1\" in\nxccov) " + xccov + " ;;\nxcresulttool) exit 1 ;;\nesac\nexit 0\n"
+	if err := os.WriteFile(filepath.Join(bin, "xcrun"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func coverageTestConfig(t *testing.T) Config {
+	return Config{
+		Project:           "App.xcodeproj",
+		Scheme:            "App",
+		Configuration:     "Debug",
+		DerivedDataPath:   t.TempDir(),
+		ResultBundlesPath: t.TempDir(),
+		Destination:       Destination{Kind: DestMacOS, PlatformFamily: PlatformMacOS, TargetType: TargetLocal},
+		Test:              TestConfig{CodeCoverage: true},
+	}
+}
+
+func TestTestCollectsCoverage(t *testing.T) {
+	report, err := filepath.Abs(filepath.Join("testdata", "xccov_report.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fakeCoverageXcrun(t, report)
+	rec := &recordingEmitter{}
+	tr, _, err := Test(context.Background(), t.TempDir(), coverageTestConfig(t), nil, nil, rec)
+	if err != nil {
+		t.Fatalf("test: %v", err)
+	}
+	if !strings.Contains(tr.Command, "-enableCodeCoverage YES") {
+		t.Fatalf("command = %q", tr.Command)
+	}
+	if tr.Coverage == nil || tr.Coverage.Percent() != 62 {
+		t.Fatalf("coverage = %+v", tr.Coverage)
+	}
+	var inResult bool
+	for _, ev := range rec.events {
+		if ev.Type == "result" {
+			data, _ := ev.Data.(map[string]any)["data"].(map[string]any)
+			_, inResult = data["coverage"]
+		}
+	}
+	if !inResult {
+		t.Fatal("result event has no coverage")
+	}
+}
+
+func TestTestCoverageFailureOnlyWarns(t *testing.T) {
+	fakeCoverageXcrun(t, "")
+	rec := &recordingEmitter{}
+	tr, _, err := Test(context.Background(), t.TempDir(), coverageTestConfig(t), nil, nil, rec)
+	if err != nil {
+		t.Fatalf("coverage failure failed the tests: %v", err)
+	}
+	if tr.Coverage != nil {
+		t.Fatalf("coverage = %+v", tr.Coverage)
+	}
+	var warned bool
+	for _, ev := range rec.events {
+		warned = warned || (ev.Type == "warning" && strings.Contains(ev.Msg, "code coverage"))
+	}
+	if !warned {
+		t.Fatalf("no coverage warning in %+v", rec.events)
+	}
+}
@@ -0,0 +1,185 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DerivedDataDir returns the -derivedDataPath for cfg. With
+// Xcodebuild.SplitDerivedDataByDestination set, each destination gets its own
+// <derivedDataPath>/<platformFamily>-<targetType> directory so switching
+// between e.g. an iOS simulator and macOS does not invalidate the other.
+func DerivedDataDir(cfg Config) string {
+	if !cfg.Xcodebuild.SplitDerivedDataByDestination || cfg.DerivedDataPath == "" {
+		return cfg.DerivedDataPath
+	}
+	sub := DerivedDataSubdir(normalizeDestination(cfg.Destination))
+	if sub == "" {
+		return cfg.DerivedDataPath
+	}
+	return filepath.Join(cfg.DerivedDataPath, sub)
+}
+
+// DerivedDataSubdir names the per-destination directory, or "" while the
+// destination is still unresolved.
+func DerivedDataSubdir(dst Destination) string {
+	if dst.PlatformFamily == PlatformUnknown || dst.TargetType == "" || dst.TargetType == TargetAuto {
+		return ""
+	}
+	return string(dst.PlatformFamily) + "-" + string(dst.TargetType)
+}
+
+// ListDerivedDataDirs returns the per-destination directories found under
+// base, sorted by name.
+func ListDerivedDataDirs(base string) ([]string, error) {
+	entries, err := os.ReadDir(base)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var dirs []string
+	for _, e := range entries {
+		if e.IsDir() && isDerivedDataSubdir(e.Name()) {
+			dirs = append(dirs, filepath.Join(base, e.Name()))
+		}
+	}
+	sort.Strings(dirs)
+	return dirs, nil
+}
+
+func isDerivedDataSubdir(name string) bool {
+	family, target, ok := strings.Cut(name, "-")
+	if !ok {
+		return false
+	}
+	if NormalizePlatformFamily(family) != PlatformFamily(family) || family == "" {
+		return false
+	}
+	switch TargetType(target) {
+	case TargetSimulator, TargetDevice, TargetLocal:
+		return true
+	}
+	return false
+}
+
+// CleanDerivedData removes DerivedData under base and returns the removed
+// paths. With per-destination directories enabled it removes only the active
+// destination's directory unless all is set; with it disabled (or the
+// destination unresolved) base is removed entirely.
+func CleanDerivedData(base string, cfg Config, all bool) ([]string, error) {
+	if dir := derivedDataCleanPath(base, cfg, all); dir != base {
+		if _, err := os.Stat(dir); err != nil {
+			return nil, nil
+		}
+		return []string{dir}, os.RemoveAll(dir)
+	}
+
+	var removed []string
+	if cfg.Xcodebuild.SplitDerivedDataByDestination {
+		dirs, err := ListDerivedDataDirs(base)
+		if err != nil {
+			return nil, err
+		}
+		removed = append(removed, dirs...)
+	}
+	if _, err := os.Stat(base); err != nil {
+		return removed, nil
+	}
+	return append(removed, base), os.RemoveAll(base)
+}
+
+// derivedDataXcodeName records the Xcode that last built a DerivedData
+// directory successfully
+const derivedDataXcodeName = ".xcbolt-xcode.json"
+
+// DerivedDataStaleCode marks the warning that DerivedData was built by
+// another Xcode (data.builtWith, data.active).
+const DerivedDataStaleCode = "DERIVED_DATA_STALE"
+
+// DerivedDataXcode returns the Xcode recorded for the DerivedData directory
+// dir; ok is false when none is.
+func DerivedDataXcode(dir string) (x XcodeVersion, ok bool) {
+	b, err := os.ReadFile(filepath.Join(dir, derivedDataXcodeName))
+	if err != nil || json.Unmarshal(b, &x) != nil || (x.Build == "" && x.Version == "") {
+		return XcodeVersion{}, false
+	}
+	return x, true
+}
+
+// recordDerivedDataXcode notes that x built dir successfully
+func recordDerivedDataXcode(dir string, x XcodeVersion) {
+	if dir == "" || (x.Build == "" && x.Version == "") {
+		return
+	}
+	b, err := json.Marshal(XcodeVersion{Version: x.Version, Build: x.Build})
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(filepath.Join(dir, derivedDataXcodeName), b, 0o644)
+}
+
+// StaleDerivedData reports whether the DerivedData directory dir was built
+// by an Xcode other than active, returning that Xcode. Directories without
+// a record aren't stale.
+func StaleDerivedData(dir string, active XcodeVersion) (XcodeVersion, bool) {
+	built, ok := DerivedDataXcode(dir)
+	if !ok || active.CommandLineTools {
+		return XcodeVersion{}, false
+	}
+	if built.Build != "" && active.Build != "" {
+		return built, built.Build != active.Build
+	}
+	return built, active.Version != "" && built.Version != active.Version
+}
+
+// StaleModuleCacheError reports whether a build error is a typical symptom
+// of module caches built by another compiler.
+func StaleModuleCacheError(msg string) bool {
+	lower := strings.ToLower(msg)
+	switch {
+	case strings.Contains(lower, "pch file built from a different branch"),
+		strings.Contains(lower, "was built from a different branch"),
+		strings.Contains(lower, "has been modified since the precompiled header"),
+		strings.Contains(lower, "module file was built by a different compiler"):
+		return true
+	case strings.Contains(lower, "module compiled with swift") && strings.Contains(lower, "cannot be imported"):
+		return true
+	}
+	return false
+}
+
+// checkDerivedDataXcode compares the active Xcode with the one that built
+// cfg's DerivedData before an xcodebuild run. Stale DerivedData is cleaned
+// with xcodebuild.autoCleanOnXcodeChange and warned about otherwise. The
+// active Xcode is returned for recordDerivedDataXcode.
+func checkDerivedDataXcode(ctx context.Context, cfg Config, cmd string, emit Emitter) XcodeVersion {
+	active, err := XcodeInfo(ctx)
+	if err != nil || active.CommandLineTools {
+		return XcodeVersion{}
+	}
+	dir := DerivedDataDir(cfg)
+	built, stale := StaleDerivedData(dir, active)
+	if !stale {
+		return active
+	}
+	if cfg.Xcodebuild.AutoCleanOnXcodeChange {
+		if _, err := CleanDerivedData(cfg.DerivedDataPath, cfg, false); err != nil {
+			emitMaybe(emit, Warn(cmd, "Could not clean DerivedData: "+err.Error()))
+		} else {
+			emitMaybe(emit, Log(cmd, fmt.Sprintf("Cleaned DerivedData built with %s for %s (xcodebuild.autoCleanOnXcodeChange)", built.Label(), active.Label())))
+		}
+		return active
+	}
+	ev := Warn(cmd, fmt.Sprintf("DerivedData was built with %s but %s is active; stale module caches can cause odd build errors. Run `xcbolt clean --derived-data`, or set xcodebuild.autoCleanOnXcodeChange.", built.Label(), active.Label()))
+	ev.Code = DerivedDataStaleCode
+	ev.Data = map[string]any{"builtWith": built, "active": active}
+	emitMaybe(emit, ev)
+	return active
+}
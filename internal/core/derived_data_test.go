@@ -0,0 +1,158 @@
+package core
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDerivedDataDir(t *testing.T) {
+	cfg := Config{
+		DerivedDataPath: "/p/.xcbolt/DerivedData",
+		Destination:     Destination{PlatformFamily: PlatformIOS, TargetType: TargetSimulator},
+	}
+	if got := DerivedDataDir(cfg); got != cfg.DerivedDataPath {
+		t.Fatalf("flag off should keep the single directory, got %s", got)
+	}
+
+	cfg.Xcodebuild.SplitDerivedDataByDestination = true
+	if got := DerivedDataDir(cfg); got != "/p/.xcbolt/DerivedData/ios-simulator" {
+		t.Fatalf("unexpected split dir %s", got)
+	}
+
+	cfg.Destination = Destination{Kind: DestAuto, TargetType: TargetAuto}
+	if got := DerivedDataDir(cfg); got != cfg.DerivedDataPath {
+		t.Fatalf("unresolved destination should fall back to the base, got %s", got)
+	}
+}
+
+func TestCleanDerivedDataPerDestination(t *testing.T) {
+	base := filepath.Join(t.TempDir(), "DerivedData")
+	for _, sub := range []string{"ios-simulator", "macos-local", "ModuleCache.noindex"} {
+		if err := os.MkdirAll(filepath.Join(base, sub), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dirs, err := ListDerivedDataDirs(base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{filepath.Join(base, "ios-simulator"), filepath.Join(base, "macos-local")}
+	if !reflect.DeepEqual(dirs, want) {
+		t.Fatalf("ListDerivedDataDirs = %v, want %v", dirs, want)
+	}
+
+	cfg := Config{
+		Destination: Destination{PlatformFamily: PlatformMacOS, TargetType: TargetLocal},
+		Xcodebuild:  XcodebuildConfig{SplitDerivedDataByDestination: true},
+	}
+	removed, err := CleanDerivedData(base, cfg, false)
+	if err != nil || !reflect.DeepEqual(removed, want[1:]) {
+		t.Fatalf("active clean removed %v (%v)", removed, err)
+	}
+	if _, err := os.Stat(want[0]); err != nil {
+		t.Fatalf("other destination should survive: %v", err)
+	}
+
+	removed, err = CleanDerivedData(base, cfg, true)
+	if err != nil || len(removed) != 2 || removed[len(removed)-1] != base {
+		t.Fatalf("clean all removed %v (%v)", removed, err)
+	}
+	if _, err := os.Stat(base); !os.IsNotExist(err) {
+		t.Fatalf("base should be gone, got %v", err)
+	}
+}
+
+// fakeXcode puts xcode-select and xcrun on PATH reporting Xcode version
+// (build)
+func fakeXcode(t *testing.T, version, build string) {
+	t.Helper()
+	bin := t.TempDir()
+	scripts := map[string]string{
+		"xcode-select": "echo /Applications/Xcode.app/Contents/Developer",
+		"xcrun":        "echo 'Xcode " + version + "'\necho 'Build version " + build + "'",
+	}
+	for name, script := range scripts {
+		if err := os.WriteFile(filepath.Join(bin, name), []byte("#!/bin/sh\n"+script+"\n"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	resetXcodeVersions(t)
+}
+
+// resetXcodeVersions empties XcodeInfo's cache now and after the test
+func resetXcodeVersions(t *testing.T) {
+	reset := func() {
+		xcodeVersions.Lock()
+		xcodeVersions.byDir = nil
+		xcodeVersions.Unlock()
+	}
+	reset()
+	t.Cleanup(reset)
+}
+
+func TestStaleDerivedData(t *testing.T) {
+	dir := t.TempDir()
+	active := XcodeVersion{Version: "16.0", Build: "16A242d"}
+	if _, stale := StaleDerivedData(dir, active); stale {
+		t.Fatal("DerivedData without a record should not be stale")
+	}
+	recordDerivedDataXcode(dir, XcodeVersion{Version: "15.4", Build: "15F31d", DeveloperDir: "/x"})
+	built, stale := StaleDerivedData(dir, active)
+	if !stale || built.Build != "15F31d" || built.DeveloperDir != "" {
+		t.Fatalf("built = %+v, stale = %v", built, stale)
+	}
+	if _, stale := StaleDerivedData(dir, XcodeVersion{Version: "15.4", Build: "15F31d"}); stale {
+		t.Fatal("same build should not be stale")
+	}
+}
+
+func TestCheckDerivedDataXcode(t *testing.T) {
+	fakeXcode(t, "16.0", "16A242d")
+	cfg := Config{DerivedDataPath: filepath.Join(t.TempDir(), "DerivedData")}
+	if err := os.MkdirAll(filepath.Join(cfg.DerivedDataPath, "ModuleCache.noindex"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	recordDerivedDataXcode(cfg.DerivedDataPath, XcodeVersion{Version: "15.4", Build: "15F31d"})
+
+	rec := &recordingEmitter{}
+	active := checkDerivedDataXcode(context.Background(), cfg, "build", rec)
+	if active.Build != "16A242d" {
+		t.Fatalf("active = %+v", active)
+	}
+	if len(rec.events) != 1 || rec.events[0].Code != DerivedDataStaleCode || !strings.Contains(rec.events[0].Msg, "Xcode 15.4 (15F31d)") {
+		t.Fatalf("events = %+v", rec.events)
+	}
+	if _, err := os.Stat(cfg.DerivedDataPath); err != nil {
+		t.Fatal("warning only: DerivedData should be kept")
+	}
+
+	cfg.Xcodebuild.AutoCleanOnXcodeChange = true
+	rec = &recordingEmitter{}
+	checkDerivedDataXcode(context.Background(), cfg, "build", rec)
+	if _, err := os.Stat(cfg.DerivedDataPath); !os.IsNotExist(err) {
+		t.Fatalf("DerivedData should be cleaned, stat err = %v", err)
+	}
+	if len(rec.events) != 1 || rec.events[0].Type != "log" {
+		t.Fatalf("events = %+v", rec.events)
+	}
+}
+
+func TestStaleModuleCacheError(t *testing.T) {
+	for _, msg := range []string{
+		"PCH file built from a different branch ((clang-1500.3.9.4)) than the compiler ((clang-1600.0.26.3))",
+		"module compiled with Swift 5.10 cannot be imported by the Swift 6.0 compiler: /DerivedData/Foo.swiftmodule",
+	} {
+		if !StaleModuleCacheError(msg) {
+			t.Errorf("%q should be a stale module cache error", msg)
+		}
+	}
+	if StaleModuleCacheError("cannot find 'x' in scope") {
+		t.Error("ordinary errors are not stale module cache errors")
+	}
+}
@@ -0,0 +1,174 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// destinationCheckTimeout bounds the build settings lookup of
+// CheckDestinationCompatible.
+const destinationCheckTimeout = 30 * time.Second
+
+// SchemePlatforms caches the platforms a scheme supports. Stamp is the
+// modification time of the project files it was read from.
+type SchemePlatforms struct {
+	Families []PlatformFamily `json:"families"`
+	Stamp    string           `json:"stamp"`
+}
+
+// FamilySupported reports whether a scheme supporting families builds for
+// family. iPadOS and Mac Catalyst destinations count as iOS, and unknown
+// families are given the benefit of the doubt.
+func FamilySupported(families []PlatformFamily, family PlatformFamily) bool {
+	switch family {
+	case PlatformUnknown:
+		return true
+	case PlatformIPadOS, PlatformCatalyst:
+		family = PlatformIOS
+	}
+	for _, f := range families {
+		if f == family || (f == PlatformIPadOS && family == PlatformIOS) {
+			return true
+		}
+	}
+	return false
+}
+
+// schemePlatformsFile caches SchemePlatforms per scheme and configuration
+// in the project's .xcbolt directory, apart from the user state the TUI
+// keeps in memory and saves whole.
+const schemePlatformsFile = "scheme-platforms.json"
+
+// schemePlatformsKey keys the scheme platforms cache
+func schemePlatformsKey(cfg Config) string {
+	return cfg.Scheme + "|" + cfg.Configuration
+}
+
+// loadSchemePlatforms reads the project's scheme platforms cache; a missing
+// or unreadable cache is empty.
+func loadSchemePlatforms(projectRoot string) map[string]SchemePlatforms {
+	cache := map[string]SchemePlatforms{}
+	if b, err := os.ReadFile(filepath.Join(projectRoot, ".xcbolt", schemePlatformsFile)); err == nil {
+		_ = json.Unmarshal(b, &cache)
+	}
+	return cache
+}
+
+// saveSchemePlatforms writes the project's scheme platforms cache
+func saveSchemePlatforms(projectRoot string, cache map[string]SchemePlatforms) error {
+	b, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := EnsureProjectDirs(projectRoot); err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(projectRoot, ".xcbolt", schemePlatformsFile), b, 0o644)
+}
+
+// projectStamp is the newest modification time of the workspace and
+// project files behind cfg ("" if there are none).
+func projectStamp(projectRoot string, cfg Config) string {
+	var paths []string
+	switch {
+	case cfg.Workspace != "":
+		ws := filepath.Join(projectRoot, cfg.Workspace)
+		paths = append(paths, filepath.Join(ws, "contents.xcworkspacedata"))
+		projects, _ := filepath.Glob(filepath.Join(filepath.Dir(ws), "*.xcodeproj", "project.pbxproj"))
+		paths = append(paths, projects...)
+	case cfg.Project != "":
+		paths = append(paths, filepath.Join(projectRoot, cfg.Project, "project.pbxproj"))
+	}
+	var newest time.Time
+	for _, p := range paths {
+		if info, err := os.Stat(p); err == nil && info.ModTime().After(newest) {
+			newest = info.ModTime()
+		}
+	}
+	if newest.IsZero() {
+		return ""
+	}
+	return newest.UTC().Format(time.RFC3339Nano)
+}
+
+// CachedSchemePlatformFamilies is SchemePlatformFamilies, cached in
+// .xcbolt/scheme-platforms.json per scheme and configuration until the
+// project files change.
+func CachedSchemePlatformFamilies(ctx context.Context, projectRoot string, cfg Config) ([]PlatformFamily, error) {
+	key, stamp := schemePlatformsKey(cfg), projectStamp(projectRoot, cfg)
+	if stamp != "" {
+		if cached, ok := loadSchemePlatforms(projectRoot)[key]; ok && cached.Stamp == stamp {
+			return cached.Families, nil
+		}
+	}
+	families, err := SchemePlatformFamilies(ctx, projectRoot, cfg)
+	if err != nil || stamp == "" {
+		return families, err
+	}
+	cache := loadSchemePlatforms(projectRoot)
+	cache[key] = SchemePlatforms{Families: families, Stamp: stamp}
+	_ = saveSchemePlatforms(projectRoot, cache)
+	return families, nil
+}
+
+// DestinationIncompatibleError is returned when the destination's platform
+// is not one the scheme supports.
+type DestinationIncompatibleError struct {
+	Scheme      string
+	Destination Destination
+	Supported   []PlatformFamily
+}
+
+func (e *DestinationIncompatibleError) Error() string {
+	return fmt.Sprintf("scheme %s does not support %s (supported: %s)",
+		e.Scheme, destinationLabel(e.Destination), platformFamilyList(e.Supported))
+}
+
+// destinationLabel names a destination for messages, e.g. "Apple TV (tvOS)"
+func destinationLabel(dst Destination) string {
+	family := PlatformFamilyLabel(dst.PlatformFamily)
+	if dst.Name == "" {
+		return family
+	}
+	return dst.Name + " (" + family + ")"
+}
+
+func platformFamilyList(families []PlatformFamily) string {
+	labels := make([]string, len(families))
+	for i, f := range families {
+		labels[i] = PlatformFamilyLabel(f)
+	}
+	return strings.Join(labels, ", ")
+}
+
+// CheckDestinationCompatible fails fast when the resolved destination's
+// platform is not among the scheme's SUPPORTED_PLATFORMS, instead of letting
+// xcodebuild fail later with "Unable to find a destination". It emits a
+// DESTINATION_INCOMPATIBLE error and returns a *DestinationIncompatibleError.
+// Dry runs, xcodebuild.skipDestinationCheck, destinations of unknown family,
+// and failed lookups pass.
+func CheckDestinationCompatible(ctx context.Context, projectRoot string, cfg Config, op string, emit Emitter) error {
+	family := cfg.Destination.PlatformFamily
+	if cfg.Xcodebuild.SkipDestinationCheck || cfg.Xcodebuild.DryRun || cfg.Scheme == "" || family == PlatformUnknown {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, destinationCheckTimeout)
+	defer cancel()
+	families, err := CachedSchemePlatformFamilies(ctx, projectRoot, cfg)
+	if err != nil || len(families) == 0 || FamilySupported(families, family) {
+		return nil
+	}
+	incompatible := &DestinationIncompatibleError{Scheme: cfg.Scheme, Destination: cfg.Destination, Supported: families}
+	emitMaybe(emit, Err(op, ErrorObject{
+		Code:       "DESTINATION_INCOMPATIBLE",
+		Message:    fmt.Sprintf("Scheme %s does not support %s", cfg.Scheme, destinationLabel(cfg.Destination)),
+		Detail:     "Supported platforms: " + platformFamilyList(families),
+		Suggestion: "Pick a compatible destination in the TUI (d) or pass --platform/--destination. Pass --force or set xcodebuild.skipDestinationCheck to build anyway.",
+	}))
+	return incompatible
+}
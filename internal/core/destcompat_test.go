@@ -0,0 +1,129 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFamilySupported(t *testing.T) {
+	cases := []struct {
+		families []PlatformFamily
+		family   PlatformFamily
+		want     bool
+	}{
+		{[]PlatformFamily{PlatformIOS}, PlatformIOS, true},
+		{[]PlatformFamily{PlatformIOS}, PlatformIPadOS, true},
+		{[]PlatformFamily{PlatformIOS}, PlatformCatalyst, true},
+		{[]PlatformFamily{PlatformIPadOS}, PlatformIOS, true},
+		{[]PlatformFamily{PlatformIOS}, PlatformTvOS, false},
+		{[]PlatformFamily{PlatformIOS}, PlatformMacOS, false},
+		{[]PlatformFamily{PlatformIOS, PlatformMacOS}, PlatformMacOS, true},
+		{[]PlatformFamily{PlatformTvOS}, PlatformUnknown, true},
+	}
+	for _, c := range cases {
+		if got := FamilySupported(c.families, c.family); got != c.want {
+			t.Errorf("FamilySupported(%v, %q) = %v, want %v", c.families, c.family, got, c.want)
+		}
+	}
+}
+
+// fakeBuildSettings puts an xcrun on PATH that prints an iOS-only scheme's
+// build settings and logs each invocation to the returned file
+func fakeBuildSettings(t *testing.T) string {
+	t.Helper()
+	bin := t.TempDir()
+	calls := filepath.Join(bin, "calls")
+	script := "#!/bin/sh\necho \"$@\" >> " + calls + "\n" +
+		"case \"$*\" in *-showBuildSettings*)\n" +
+		"  echo '    SUPPORTED_PLATFORMS = iphoneos iphonesimulator'\n" +
+		"  echo '    TARGETED_DEVICE_FAMILY = 1,2'\n" +
+		"  exit 0;;\nesac\nexit 65\n"
+	if err := os.WriteFile(filepath.Join(bin, "xcrun"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	return calls
+}
+
+// xcodebuildCalls counts the xcodebuild invocations in a calls file
+func xcodebuildCalls(t *testing.T, path string) int {
+	t.Helper()
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	return strings.Count(string(b), "xcodebuild ")
+}
+
+func TestBuildRejectsIncompatibleDestination(t *testing.T) {
+	calls := fakeBuildSettings(t)
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "App.xcodeproj"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "App.xcodeproj", "project.pbxproj"), []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := Config{
+		Project:           "App.xcodeproj",
+		Scheme:            "App",
+		Configuration:     "Debug",
+		ResultBundlesPath: t.TempDir(),
+		Destination:       Destination{Kind: DestSimulator, UDID: "TV-1", Name: "Apple TV", PlatformFamily: PlatformTvOS},
+	}
+
+	rec := &recordingEmitter{}
+	_, _, err := Build(context.Background(), root, cfg, rec)
+	var incompatible *DestinationIncompatibleError
+	if !errors.As(err, &incompatible) {
+		t.Fatalf("err = %v, want a DestinationIncompatibleError", err)
+	}
+	if !reflect.DeepEqual(incompatible.Supported, []PlatformFamily{PlatformIOS}) {
+		t.Fatalf("supported = %v", incompatible.Supported)
+	}
+	var ev *Event
+	for i := range rec.events {
+		if rec.events[i].Err != nil && rec.events[i].Err.Code == "DESTINATION_INCOMPATIBLE" {
+			ev = &rec.events[i]
+		}
+	}
+	if ev == nil {
+		t.Fatalf("no DESTINATION_INCOMPATIBLE error in %+v", rec.events)
+	}
+	if ev.Err.Detail != "Supported platforms: iOS" || !strings.Contains(ev.Err.Message, "Apple TV (tvOS)") {
+		t.Fatalf("unexpected error %+v", ev.Err)
+	}
+	if n := xcodebuildCalls(t, calls); n != 1 {
+		t.Fatalf("xcodebuild ran %d times, want only the build settings lookup", n)
+	}
+
+	// The scheme's platforms are cached until the project changes, apart
+	// from the user state a TUI saves whole from memory
+	if err := SaveState(defaultState()); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := Build(context.Background(), root, cfg, nil); !errors.As(err, &incompatible) {
+		t.Fatalf("second build: %v", err)
+	}
+	if n := xcodebuildCalls(t, calls); n != 1 {
+		t.Fatalf("xcodebuild ran %d times, want the cached platforms", n)
+	}
+
+	forced := cfg
+	forced.Xcodebuild.SkipDestinationCheck = true
+	if err := CheckDestinationCompatible(context.Background(), root, forced, "build", nil); err != nil {
+		t.Fatalf("skipDestinationCheck: %v", err)
+	}
+	ios := cfg
+	ios.Destination = Destination{Kind: DestSimulator, UDID: "SIM-1", PlatformFamily: PlatformIOS}
+	if err := CheckDestinationCompatible(context.Background(), root, ios, "build", nil); err != nil {
+		t.Fatalf("iOS destination: %v", err)
+	}
+}
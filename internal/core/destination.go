@@ -231,69 +231,160 @@ func normalizeDestination(dst Destination) Destination {
 	return dst
 }
 
-func ListDestinationCandidates(ctx context.Context, emit Emitter) ([]DestinationCandidate, error) {
+func ListDestinationCandidates(ctx context.Context, emit Emitter, preserveLocale bool) ([]DestinationCandidate, error) {
 	out := []DestinationCandidate{}
 
-	list, err := SimctlList(ctx, emit)
+	list, err := SimctlList(ctx, emit, preserveLocale)
 	if err == nil {
-		sims := FlattenSimulators(list)
-		for _, s := range sims {
-			family := s.PlatformFamily
-			if family == "" {
-				family = InferPlatformFamilyFromRuntime(s.RuntimeID, s.RuntimeName, s.Name)
-			}
-			plat := PlatformStringForDestination(family, TargetSimulator)
-			if family == "" || plat == "" {
-				continue
-			}
-			out = append(out, DestinationCandidate{
-				ID:             s.UDID,
-				Name:           s.Name,
-				PlatformFamily: family,
-				TargetType:     TargetSimulator,
-				Platform:       plat,
-				OSVersion:      s.OSVersion,
-				RuntimeName:    s.RuntimeName,
-				RuntimeID:      s.RuntimeID,
-				State:          s.State,
-				Available:      s.Available,
-			})
-		}
-	}
-
-	if DevicectlAvailable(ctx) {
-		if devs, derr := DevicectlList(ctx, emit); derr == nil {
-			for _, d := range devs {
-				family := d.PlatformFamily
-				if family == "" {
-					family = InferPlatformFamilyFromDevice(d.Platform, d.Model, d.Name)
-				}
-				plat := PlatformStringForDestination(family, TargetDevice)
-				if family == "" || plat == "" {
-					continue
-				}
-				out = append(out, DestinationCandidate{
-					ID:             d.Identifier,
-					Name:           d.Name,
-					PlatformFamily: family,
-					TargetType:     TargetDevice,
-					Platform:       plat,
-					OSVersion:      d.OSVersion,
-					Available:      true,
-				})
-			}
+		out = append(out, simulatorCandidates(FlattenSimulators(list))...)
+	}
+
+	if DevicectlAvailable(ctx, preserveLocale) {
+		if devs, derr := DevicectlList(ctx, emit, preserveLocale); derr == nil {
+			out = append(out, deviceCandidates(devs)...)
 		}
 	}
 
 	// Always expose local Mac targets.
-	out = append(out,
-		DestinationCandidate{ID: "macos", Name: "My Mac", PlatformFamily: PlatformMacOS, TargetType: TargetLocal, Platform: "macOS", Available: true},
-		DestinationCandidate{ID: "catalyst", Name: "My Mac (Catalyst)", PlatformFamily: PlatformCatalyst, TargetType: TargetLocal, Platform: "macOS", Available: true},
-	)
+	out = append(out, localDestinationCandidates()...)
 
 	return out, nil
 }
 
+func localDestinationCandidates() []DestinationCandidate {
+	return []DestinationCandidate{
+		{ID: "macos", Name: "My Mac", PlatformFamily: PlatformMacOS, TargetType: TargetLocal, Platform: "macOS", Available: true},
+		{ID: "catalyst", Name: "My Mac (Catalyst)", PlatformFamily: PlatformCatalyst, TargetType: TargetLocal, Platform: "macOS", Available: true},
+	}
+}
+
+func simulatorCandidates(sims []Simulator) []DestinationCandidate {
+	out := []DestinationCandidate{}
+	for _, s := range sims {
+		family := s.PlatformFamily
+		if family == "" {
+			family = InferPlatformFamilyFromRuntime(s.RuntimeID, s.RuntimeName, s.Name)
+		}
+		plat := PlatformStringForDestination(family, TargetSimulator)
+		if family == "" || plat == "" {
+			continue
+		}
+		out = append(out, DestinationCandidate{
+			ID:             s.UDID,
+			Name:           s.Name,
+			PlatformFamily: family,
+			TargetType:     TargetSimulator,
+			Platform:       plat,
+			OSVersion:      s.OSVersion,
+			RuntimeName:    s.RuntimeName,
+			RuntimeID:      s.RuntimeID,
+			State:          s.State,
+			Available:      s.Available,
+		})
+	}
+	return out
+}
+
+func deviceCandidates(devs []Device) []DestinationCandidate {
+	out := []DestinationCandidate{}
+	for _, d := range devs {
+		family := d.PlatformFamily
+		if family == "" {
+			family = InferPlatformFamilyFromDevice(d.Platform, d.Model, d.Name)
+		}
+		plat := PlatformStringForDestination(family, TargetDevice)
+		if family == "" || plat == "" {
+			continue
+		}
+		out = append(out, DestinationCandidate{
+			ID:             d.Identifier,
+			Name:           d.Name,
+			PlatformFamily: family,
+			TargetType:     TargetDevice,
+			Platform:       plat,
+			OSVersion:      d.OSVersion,
+			Available:      true,
+		})
+	}
+	return out
+}
+
+// DestinationCandidatesFromContext converts already-discovered simulators and
+// devices into candidates without shelling out again.
+func DestinationCandidatesFromContext(info ContextInfo) []DestinationCandidate {
+	out := simulatorCandidates(info.Simulators)
+	return append(out, deviceCandidates(info.Devices)...)
+}
+
+// SortDestinationCandidates orders candidates best-first: booted/available
+// simulators, then devices, with newer OS versions ahead within a score.
+func SortDestinationCandidates(candidates []DestinationCandidate) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		si := candidateScore(candidates[i])
+		sj := candidateScore(candidates[j])
+		if si == sj {
+			if c := CompareOSVersions(candidates[i].OSVersion, candidates[j].OSVersion); c != 0 {
+				return c > 0
+			}
+			return candidates[i].Name < candidates[j].Name
+		}
+		return si > sj
+	})
+}
+
+// PlatformFamiliesFromSupportedPlatforms maps a SUPPORTED_PLATFORMS build
+// setting (e.g. "iphoneos iphonesimulator") to platform families, ordered by
+// priority.
+func PlatformFamiliesFromSupportedPlatforms(v string) []PlatformFamily {
+	seen := map[PlatformFamily]bool{}
+	out := []PlatformFamily{}
+	for _, p := range strings.Fields(strings.ToLower(v)) {
+		var f PlatformFamily
+		switch p {
+		case "iphoneos", "iphonesimulator":
+			f = PlatformIOS
+		case "appletvos", "appletvsimulator":
+			f = PlatformTvOS
+		case "watchos", "watchsimulator":
+			f = PlatformWatchOS
+		case "xros", "xrsimulator":
+			f = PlatformVisionOS
+		case "macosx":
+			f = PlatformMacOS
+		default:
+			continue
+		}
+		if !seen[f] {
+			seen[f] = true
+			out = append(out, f)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return familyPriority(out[i]) < familyPriority(out[j]) })
+	return out
+}
+
+// PlatformFamilyLabel is the display name of a platform family, e.g. "iPadOS".
+func PlatformFamilyLabel(f PlatformFamily) string {
+	switch f {
+	case PlatformIOS:
+		return "iOS"
+	case PlatformIPadOS:
+		return "iPadOS"
+	case PlatformTvOS:
+		return "tvOS"
+	case PlatformWatchOS:
+		return "watchOS"
+	case PlatformVisionOS:
+		return "visionOS"
+	case PlatformMacOS:
+		return "macOS"
+	case PlatformCatalyst:
+		return "Mac Catalyst"
+	default:
+		return string(f)
+	}
+}
+
 func familyPriority(f PlatformFamily) int {
 	switch f {
 	case PlatformIOS:
@@ -354,6 +445,32 @@ func resolveCandidateByTarget(candidates []DestinationCandidate, target string)
 	return exactID, exactName
 }
 
+// narrowByRuntime picks, among same-named candidates, the ones on the
+// destination's saved runtime (or OS version, when no runtime is saved), so
+// several simulators called "iPhone 15 Pro" resolve to the one chosen.
+func narrowByRuntime(candidates []DestinationCandidate, dst Destination) []DestinationCandidate {
+	if len(candidates) < 2 {
+		return candidates
+	}
+	var out []DestinationCandidate
+	for _, c := range candidates {
+		switch {
+		case dst.RuntimeID != "":
+			if c.RuntimeID == dst.RuntimeID {
+				out = append(out, c)
+			}
+		case dst.OS != "":
+			if CompareOSVersions(c.OSVersion, dst.OS) == 0 {
+				out = append(out, c)
+			}
+		}
+	}
+	if len(out) == 0 {
+		return candidates
+	}
+	return out
+}
+
 func destinationFromCandidate(dst *Destination, c DestinationCandidate) {
 	dst.TargetType = c.TargetType
 	dst.PlatformFamily = c.PlatformFamily
@@ -445,6 +562,9 @@ func resolveDestination(cfg Config, candidates []DestinationCandidate) (Config,
 			cfg.Destination = dst
 			return cfg, nil
 		}
+		if len(ids) == 0 {
+			names = narrowByRuntime(names, dst)
+		}
 		if len(names) == 1 {
 			destinationFromCandidate(&dst, names[0])
 			cfg.Destination = dst
@@ -461,17 +581,7 @@ func resolveDestination(cfg Config, candidates []DestinationCandidate) (Config,
 		if len(filtered) == 0 {
 			return cfg, errors.New("no destinations available for the selected platform/target type")
 		}
-		sort.SliceStable(filtered, func(i, j int) bool {
-			si := candidateScore(filtered[i])
-			sj := candidateScore(filtered[j])
-			if si == sj {
-				if filtered[i].OSVersion == filtered[j].OSVersion {
-					return filtered[i].Name < filtered[j].Name
-				}
-				return filtered[i].OSVersion > filtered[j].OSVersion
-			}
-			return si > sj
-		})
+		SortDestinationCandidates(filtered)
 		destinationFromCandidate(&dst, filtered[0])
 		cfg.Destination = dst
 		return cfg, nil
@@ -483,7 +593,7 @@ func resolveDestination(cfg Config, candidates []DestinationCandidate) (Config,
 // ResolveDestinationIfNeeded resolves and normalizes config destination for build/test/run.
 func ResolveDestinationIfNeeded(ctx context.Context, projectRoot string, cfg Config, emit Emitter) (Config, error) {
 	_ = projectRoot
-	candidates, err := ListDestinationCandidates(ctx, emit)
+	candidates, err := ListDestinationCandidates(ctx, emit, cfg.Xcodebuild.PreserveLocale)
 	if err != nil {
 		return cfg, err
 	}
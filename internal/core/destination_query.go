@@ -0,0 +1,232 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// destinationQueryScore rates how well a candidate matches a free-form query
+// (higher = better, 0 = no match). It mirrors the TUI selector scoring, with
+// an extra tier for exact names so "iPhone 15" beats "iPhone 15 Pro".
+func destinationQueryScore(c DestinationCandidate, query string) int {
+	q := strings.ToLower(strings.TrimSpace(query))
+	if q == "" {
+		return 0
+	}
+	name := strings.ToLower(c.Name)
+	desc := strings.ToLower(strings.Join([]string{c.Platform, c.OSVersion, c.RuntimeName}, " "))
+
+	switch {
+	case name == q || strings.EqualFold(c.ID, q):
+		return 120
+	case strings.HasPrefix(name, q):
+		return 100
+	case strings.Contains(name, q):
+		return 80
+	case strings.Contains(name+" "+desc, q):
+		return 60
+	case subsequenceMatch(name, q):
+		return 40
+	}
+	return 0
+}
+
+// subsequenceMatch reports whether all characters of needle appear in
+// haystack in order.
+func subsequenceMatch(haystack, needle string) bool {
+	h := []rune(haystack)
+	i := 0
+	for _, r := range needle {
+		for i < len(h) && h[i] != r {
+			i++
+		}
+		if i == len(h) {
+			return false
+		}
+		i++
+	}
+	return true
+}
+
+// MatchDestinationQuery picks the candidate best matching query. Candidates
+// sharing the top score are ambiguous unless they all have the same name
+// (e.g. one simulator per runtime), in which case the usual auto-pick order
+// (booted, newest OS) breaks the tie.
+func MatchDestinationQuery(candidates []DestinationCandidate, query string) (DestinationCandidate, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return DestinationCandidate{}, fmt.Errorf("empty destination query")
+	}
+
+	type scored struct {
+		c     DestinationCandidate
+		score int
+	}
+	matches := []scored{}
+	for _, c := range candidates {
+		if s := destinationQueryScore(c, query); s > 0 {
+			matches = append(matches, scored{c, s})
+		}
+	}
+	if len(matches) == 0 {
+		return DestinationCandidate{}, fmt.Errorf("no destination matches %q (see `xcbolt simulator list` / `xcbolt device list`)", query)
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+
+	top := []DestinationCandidate{}
+	for _, m := range matches {
+		if m.score == matches[0].score {
+			top = append(top, m.c)
+		}
+	}
+	SortDestinationCandidates(top)
+	for _, c := range top[1:] {
+		if !strings.EqualFold(c.Name, top[0].Name) {
+			return DestinationCandidate{}, ambiguousDestinationError(query, top)
+		}
+	}
+	return top[0], nil
+}
+
+func ambiguousDestinationError(query string, top []DestinationCandidate) error {
+	lines := []string{}
+	for i, c := range top {
+		if i == 3 {
+			lines = append(lines, fmt.Sprintf("  … and %d more", len(top)-3))
+			break
+		}
+		label := c.Name
+		if c.OSVersion != "" {
+			label += " (" + c.Platform + " " + c.OSVersion + ")"
+		} else if c.Platform != "" {
+			label += " (" + c.Platform + ")"
+		}
+		lines = append(lines, "  "+label+"  "+c.ID)
+	}
+	return fmt.Errorf("destination %q is ambiguous; candidates:\n%s", query, strings.Join(lines, "\n"))
+}
+
+// ResolveDestinationByQuery lists destinations and points cfg at the one
+// best matching query. Platform/target-type constraints already set on cfg
+// narrow the candidates.
+func ResolveDestinationByQuery(ctx context.Context, cfg Config, query string, emit Emitter) (Config, error) {
+	candidates, err := ListDestinationCandidates(ctx, emit, cfg.Xcodebuild.PreserveLocale)
+	if err != nil {
+		return cfg, err
+	}
+	return ResolveDestinationQuery(cfg, candidates, query)
+}
+
+// ResolveDestinationQuery is ResolveDestinationByQuery over an already
+// discovered candidate list.
+func ResolveDestinationQuery(cfg Config, candidates []DestinationCandidate, query string) (Config, error) {
+	dst := normalizeDestination(cfg.Destination)
+	filtered := make([]DestinationCandidate, 0, len(candidates))
+	for _, c := range candidates {
+		if c.TargetType != TargetLocal && !c.Available {
+			continue
+		}
+		if dst.PlatformFamily != "" && c.PlatformFamily != dst.PlatformFamily {
+			continue
+		}
+		if dst.TargetType != "" && dst.TargetType != TargetAuto && c.TargetType != dst.TargetType {
+			continue
+		}
+		filtered = append(filtered, c)
+	}
+	c, err := MatchDestinationQuery(filtered, query)
+	if err != nil {
+		return cfg, err
+	}
+	destinationFromCandidate(&dst, c)
+	cfg.Destination = dst
+	return cfg, nil
+}
+
+// MatchConfigurationQuery resolves a case-insensitive configuration name or
+// unique prefix against the discovered configurations.
+func MatchConfigurationQuery(configurations []string, query string) (string, error) {
+	q := strings.ToLower(strings.TrimSpace(query))
+	prefixed := []string{}
+	for _, c := range configurations {
+		if strings.ToLower(c) == q {
+			return c, nil
+		}
+		if strings.HasPrefix(strings.ToLower(c), q) {
+			prefixed = append(prefixed, c)
+		}
+	}
+	switch len(prefixed) {
+	case 1:
+		return prefixed[0], nil
+	case 0:
+		return "", fmt.Errorf("no configuration matches %q (available: %s)", query, strings.Join(configurations, ", "))
+	}
+	return "", fmt.Errorf("configuration %q is ambiguous (matches: %s)", query, strings.Join(prefixed, ", "))
+}
+
+// ResolveConfigurationByQuery matches query against the configurations found
+// in the project files. When none can be discovered the query is returned
+// unchanged and left for xcodebuild to validate.
+func ResolveConfigurationByQuery(projectRoot string, cfg Config, query string) (string, error) {
+	_, projects, err := scanProjectEntries(projectRoot)
+	if err != nil {
+		return "", err
+	}
+	configurations := listConfigurationsFromPBXProj(projectRoot, cfg, projects)
+	if len(configurations) == 0 {
+		return strings.TrimSpace(query), nil
+	}
+	return MatchConfigurationQuery(configurations, query)
+}
+
+// DestinationLastToken is the --destination value for the destination last
+// used with the scheme.
+const DestinationLastToken = "last"
+
+// IsResolved reports whether d names a concrete destination (a Mac or a
+// simulator/device ID) rather than one to auto-pick.
+func (d Destination) IsResolved() bool {
+	switch d.Kind {
+	case DestMacOS, DestCatalyst:
+		return true
+	case DestSimulator, DestDevice:
+		return d.ID != "" || d.UDID != ""
+	}
+	return false
+}
+
+// AutoDestination is a destination auto-picked among family's simulators
+// and devices at build time.
+func AutoDestination(family PlatformFamily) Destination {
+	return Destination{Kind: DestAuto, TargetType: TargetAuto, PlatformFamily: family}
+}
+
+// ResolveRememberedDestination points cfg at remembered (e.g. the
+// destination last used with the scheme) when it is still available.
+func ResolveRememberedDestination(cfg Config, candidates []DestinationCandidate, remembered Destination) (Config, error) {
+	switch remembered.Kind {
+	case DestMacOS, DestCatalyst:
+		cfg.Destination = remembered
+		return cfg, nil
+	}
+	id := remembered.ID
+	if id == "" {
+		id = remembered.UDID
+	}
+	for _, c := range candidates {
+		if id != "" && strings.EqualFold(c.ID, id) {
+			dst := remembered
+			destinationFromCandidate(&dst, c)
+			cfg.Destination = dst
+			return cfg, nil
+		}
+	}
+	name := remembered.Name
+	if name == "" {
+		name = id
+	}
+	return cfg, fmt.Errorf("destination %q is no longer available", name)
+}
@@ -0,0 +1,119 @@
+package core
+
+import (
+	"strings"
+	"testing"
+)
+
+func iphoneCandidates() []DestinationCandidate {
+	sim := func(id, name, os string) DestinationCandidate {
+		return DestinationCandidate{ID: id, Name: name, PlatformFamily: PlatformIOS, TargetType: TargetSimulator, Platform: "iOS Simulator", OSVersion: os, Available: true}
+	}
+	return []DestinationCandidate{
+		sim("A", "iPhone 15", "17.5"),
+		sim("B", "iPhone 15 Pro", "17.5"),
+		sim("C", "iPhone 15 Pro Max", "17.5"),
+		sim("D", "iPhone 15 Pro", "18.0"),
+		{ID: "T", Name: "Apple TV 4K", PlatformFamily: PlatformTvOS, TargetType: TargetSimulator, Platform: "tvOS Simulator", OSVersion: "17.5", Available: true},
+	}
+}
+
+func TestMatchDestinationQueryOverlappingNames(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"iPhone 15", "A"},
+		{"iphone 15 pro", "D"}, // exact name on two runtimes: newest OS wins
+		{"iPhone 15 Pro Max", "C"},
+		{"iphone 15 pro m", "C"},
+		{"pro max", "C"},
+		{"apple", "T"},
+		{"C", "C"},
+	}
+	for _, tc := range tests {
+		got, err := MatchDestinationQuery(iphoneCandidates(), tc.query)
+		if err != nil {
+			t.Fatalf("MatchDestinationQuery(%q): %v", tc.query, err)
+		}
+		if got.ID != tc.want {
+			t.Fatalf("MatchDestinationQuery(%q) = %s (%s), want %s", tc.query, got.ID, got.Name, tc.want)
+		}
+	}
+}
+
+func TestMatchDestinationQueryAmbiguous(t *testing.T) {
+	_, err := MatchDestinationQuery(iphoneCandidates(), "15 pro")
+	if err == nil {
+		t.Fatal("expected ambiguity error")
+	}
+	msg := err.Error()
+	if !strings.Contains(msg, "ambiguous") || !strings.Contains(msg, "iPhone 15 Pro Max") || !strings.Contains(msg, "iPhone 15 Pro (iOS Simulator 18.0)") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := strings.Count(msg, "\n  "); n > 4 {
+		t.Fatalf("expected at most 3 candidates plus overflow line, got %d lines: %s", n, msg)
+	}
+
+	if _, err := MatchDestinationQuery(iphoneCandidates(), "pixel"); err == nil || !strings.Contains(err.Error(), "no destination matches") {
+		t.Fatalf("expected no-match error, got %v", err)
+	}
+}
+
+func TestResolveDestinationQueryRespectsPlatform(t *testing.T) {
+	cfg := Config{Destination: Destination{PlatformFamily: PlatformTvOS}}
+	cfg, err := ResolveDestinationQuery(cfg, iphoneCandidates(), "4k")
+	if err != nil {
+		t.Fatalf("resolveDestinationQuery: %v", err)
+	}
+	if cfg.Destination.ID != "T" || cfg.Destination.TargetType != TargetSimulator {
+		t.Fatalf("unexpected destination: %+v", cfg.Destination)
+	}
+
+	cfg = Config{Destination: Destination{PlatformFamily: PlatformTvOS}}
+	if _, err := ResolveDestinationQuery(cfg, iphoneCandidates(), "iPhone 15"); err == nil {
+		t.Fatal("expected iPhone query to miss when limited to tvOS")
+	}
+}
+
+func TestMatchConfigurationQuery(t *testing.T) {
+	configs := []string{"Debug", "Release", "Release-Staging"}
+	tests := map[string]string{
+		"debug":    "Debug",
+		"d":        "Debug",
+		"RELEASE":  "Release",
+		"release-": "Release-Staging",
+	}
+	for q, want := range tests {
+		got, err := MatchConfigurationQuery(configs, q)
+		if err != nil || got != want {
+			t.Fatalf("MatchConfigurationQuery(%q) = %q, %v; want %q", q, got, err, want)
+		}
+	}
+	if _, err := MatchConfigurationQuery(configs, "rel"); err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Fatalf("expected ambiguity error, got %v", err)
+	}
+	if _, err := MatchConfigurationQuery(configs, "prod"); err == nil {
+		t.Fatal("expected no-match error")
+	}
+}
+
+func TestResolveRememberedDestination(t *testing.T) {
+	cfg := Config{Scheme: "App"}
+	got, err := ResolveRememberedDestination(cfg, iphoneCandidates(), Destination{Kind: DestSimulator, ID: "D", Name: "iPhone 15 Pro", OS: "17.0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Destination.ID != "D" || got.Destination.OS != "18.0" || got.Destination.PlatformFamily != PlatformIOS {
+		t.Fatalf("destination = %+v", got.Destination)
+	}
+
+	if got, err := ResolveRememberedDestination(cfg, nil, Destination{Kind: DestMacOS, Name: "My Mac"}); err != nil || got.Destination.Kind != DestMacOS {
+		t.Fatalf("macOS: %+v %v", got.Destination, err)
+	}
+
+	_, err = ResolveRememberedDestination(cfg, iphoneCandidates(), Destination{Kind: DestSimulator, ID: "GONE", Name: "iPhone 12"})
+	if err == nil || !strings.Contains(err.Error(), "iPhone 12") {
+		t.Fatalf("err = %v", err)
+	}
+}
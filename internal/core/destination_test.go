@@ -193,6 +193,32 @@ func TestResolveDestinationAmbiguousName(t *testing.T) {
 	}
 }
 
+func TestResolveDestinationNameUsesRuntime(t *testing.T) {
+	candidates := []DestinationCandidate{
+		{ID: "sim-17", Name: "iPhone 15 Pro", PlatformFamily: PlatformIOS, TargetType: TargetSimulator, Platform: "iOS Simulator", OSVersion: "17.5", RuntimeID: "com.apple.CoreSimulator.SimRuntime.iOS-17-5", Available: true},
+		{ID: "sim-18", Name: "iPhone 15 Pro", PlatformFamily: PlatformIOS, TargetType: TargetSimulator, Platform: "iOS Simulator", OSVersion: "18.0", RuntimeID: "com.apple.CoreSimulator.SimRuntime.iOS-18-0", Available: true},
+	}
+	cfg := Config{Destination: Destination{PlatformFamily: PlatformIOS, TargetType: TargetSimulator, Name: "iPhone 15 Pro", RuntimeID: "com.apple.CoreSimulator.SimRuntime.iOS-17-5"}}
+	out, err := resolveDestination(cfg, candidates)
+	if err != nil {
+		t.Fatalf("resolveDestination: %v", err)
+	}
+	if out.Destination.ID != "sim-17" {
+		t.Fatalf("id = %q, want sim-17", out.Destination.ID)
+	}
+
+	// Without a runtime, the saved OS version decides
+	cfg.Destination.RuntimeID = ""
+	cfg.Destination.OS = "18.0"
+	out, err = resolveDestination(cfg, candidates)
+	if err != nil {
+		t.Fatalf("resolveDestination: %v", err)
+	}
+	if out.Destination.ID != "sim-18" {
+		t.Fatalf("id = %q, want sim-18", out.Destination.ID)
+	}
+}
+
 func TestResolveDestinationTargetNotFound(t *testing.T) {
 	cfg := Config{Destination: Destination{PlatformFamily: PlatformIOS, TargetType: TargetSimulator, ID: "missing-id"}}
 	candidates := []DestinationCandidate{
@@ -241,3 +267,45 @@ func TestBuildDestinationStringMatrix(t *testing.T) {
 		})
 	}
 }
+
+func TestPlatformFamiliesFromSupportedPlatforms(t *testing.T) {
+	got := PlatformFamiliesFromSupportedPlatforms("macosx iphonesimulator iphoneos xros")
+	want := []PlatformFamily{PlatformIOS, PlatformVisionOS, PlatformMacOS}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+	if got := PlatformFamiliesFromSupportedPlatforms(""); len(got) != 0 {
+		t.Fatalf("expected no families, got %v", got)
+	}
+}
+
+func TestSortDestinationCandidatesSemanticVersions(t *testing.T) {
+	candidates := []DestinationCandidate{
+		{ID: "17.5", Name: "iPhone 15", PlatformFamily: PlatformIOS, TargetType: TargetSimulator, OSVersion: "17.5", Available: true},
+		{ID: "17.10", Name: "iPhone 15", PlatformFamily: PlatformIOS, TargetType: TargetSimulator, OSVersion: "17.10", Available: true},
+		{ID: "9.0", Name: "iPhone 15", PlatformFamily: PlatformIOS, TargetType: TargetSimulator, OSVersion: "9.0", Available: true},
+	}
+	SortDestinationCandidates(candidates)
+	order := []string{candidates[0].ID, candidates[1].ID, candidates[2].ID}
+	if strings.Join(order, ",") != "17.10,17.5,9.0" {
+		t.Fatalf("order = %v", order)
+	}
+}
+
+func TestSortDestinationCandidatesBootedFirst(t *testing.T) {
+	candidates := []DestinationCandidate{
+		{ID: "old", Name: "iPhone 15", PlatformFamily: PlatformIOS, TargetType: TargetSimulator, OSVersion: "17.5", State: "Shutdown", Available: true},
+		{ID: "new", Name: "iPhone 16", PlatformFamily: PlatformIOS, TargetType: TargetSimulator, OSVersion: "18.2", State: "Shutdown", Available: true},
+		{ID: "booted", Name: "iPhone SE", PlatformFamily: PlatformIOS, TargetType: TargetSimulator, OSVersion: "17.0", State: "Booted", Available: true},
+	}
+	SortDestinationCandidates(candidates)
+	order := []string{candidates[0].ID, candidates[1].ID, candidates[2].ID}
+	if strings.Join(order, ",") != "booted,new,old" {
+		t.Fatalf("order = %v", order)
+	}
+}
@@ -21,14 +21,15 @@ type Device struct {
 	CompanionAppID string         `json:"companionAppId,omitempty"`
 }
 
-func DevicectlList(ctx context.Context, emit Emitter) ([]Device, error) {
+func DevicectlList(ctx context.Context, emit Emitter, preserveLocale bool) ([]Device, error) {
 	tmpDir := os.TempDir()
 	outPath := filepath.Join(tmpDir, fmt.Sprintf("xcbolt-devices-%d.json", os.Getpid()))
 
 	args := []string{"devicectl", "list", "devices", "--json-output", outPath}
 	_, err := RunStreaming(ctx, CmdSpec{
-		Path: "xcrun",
-		Args: args,
+		Path:           "xcrun",
+		Args:           args,
+		PreserveLocale: preserveLocale,
 		StdoutLine: func(s string) {
 			if emit != nil {
 				emit.Emit(Log("device", s))
@@ -57,28 +58,37 @@ func DevicectlList(ctx context.Context, emit Emitter) ([]Device, error) {
 	return devs, nil
 }
 
-func DevicectlInstallApp(ctx context.Context, deviceID string, appPath string, emit Emitter) error {
+func DevicectlInstallApp(ctx context.Context, deviceID string, appPath string, emit Emitter, preserveLocale bool) error {
 	if deviceID == "" {
 		return errors.New("missing --device udid")
 	}
 	if appPath == "" {
 		return errors.New("missing app path")
 	}
-	args := []string{"devicectl", "device", "install", "app", "--device", deviceID, appPath}
+	var out strings.Builder
+	jsonPath := filepath.Join(os.TempDir(), fmt.Sprintf("xcbolt-install-%d.json", os.Getpid()))
+	args := []string{"devicectl", "device", "install", "app", "--device", deviceID, appPath, "--json-output", jsonPath}
 	_, err := RunStreaming(ctx, CmdSpec{
-		Path: "xcrun",
-		Args: args,
+		Path:           "xcrun",
+		Args:           args,
+		PreserveLocale: preserveLocale,
 		StdoutLine: func(s string) {
+			out.WriteString(s + "\n")
 			if emit != nil {
 				emit.Emit(Log("device", s))
 			}
 		},
 		StderrLine: func(s string) {
+			out.WriteString(s + "\n")
 			if emit != nil {
 				emit.Emit(Log("device", s))
 			}
 		},
 	})
+	if err != nil && ctx.Err() == nil {
+		return devicectlFailure("install", err, jsonPath, out.String())
+	}
+	_ = os.Remove(jsonPath)
 	return err
 }
 
@@ -86,7 +96,10 @@ type LaunchResult struct {
 	PID int
 }
 
-func DevicectlLaunchApp(ctx context.Context, deviceID string, bundleID string, console bool, env map[string]string, info AppBundleInfo, filterSystem bool, emit Emitter) (LaunchResult, error) {
+// DevicectlLaunchApp launches an installed app. With console it stays
+// attached to the app's output; dedupeUnified drops the unified log lines
+// devicectl mirrors there when a device log stream already shows them.
+func DevicectlLaunchApp(ctx context.Context, deviceID string, bundleID string, console bool, env map[string]string, info AppBundleInfo, filterSystem bool, dedupeUnified bool, emit Emitter, preserveLocale bool) (LaunchResult, error) {
 	if deviceID == "" || bundleID == "" {
 		return LaunchResult{}, fmt.Errorf("deviceID and bundleID are required")
 	}
@@ -120,11 +133,16 @@ func DevicectlLaunchApp(ctx context.Context, deviceID string, bundleID string, c
 
 	var lastErr error
 	for _, args := range tries {
-		pid, err := runDevicectlLaunchCandidate(ctx, args, console, info, filterSystem, emit)
+		pid, err := runDevicectlLaunchCandidate(ctx, args, console, info, filterSystem, dedupeUnified, emit, preserveLocale)
 		if err == nil {
 			return LaunchResult{PID: pid}, nil
 		}
 		lastErr = err
+		// Other command shapes won't help a locked or untrusted device
+		var derr *DevicectlError
+		if ctx.Err() != nil || (errors.As(err, &derr) && derr.Category != "") {
+			break
+		}
 	}
 	if lastErr == nil {
 		lastErr = errors.New("failed to launch app via devicectl")
@@ -132,20 +150,21 @@ func DevicectlLaunchApp(ctx context.Context, deviceID string, bundleID string, c
 	return LaunchResult{}, lastErr
 }
 
-func runDevicectlLaunchCandidate(ctx context.Context, args []string, console bool, info AppBundleInfo, filterSystem bool, emit Emitter) (int, error) {
-	var out strings.Builder
+func runDevicectlLaunchCandidate(ctx context.Context, args []string, console bool, info AppBundleInfo, filterSystem bool, dedupeUnified bool, emit Emitter, preserveLocale bool) (int, error) {
+	var out, errOut strings.Builder
 	tmpDir := os.TempDir()
 	jsonPath := filepath.Join(tmpDir, fmt.Sprintf("xcbolt-launch-%d.json", os.Getpid()))
 	args = append(args, "--json-output", jsonPath)
 	_, err := RunStreaming(ctx, CmdSpec{
-		Path: "xcrun",
-		Args: args,
+		Path:           "xcrun",
+		Args:           args,
+		PreserveLocale: preserveLocale,
 		StdoutLine: func(s string) {
 			out.WriteString(s)
 			out.WriteString("\n")
 			if emit != nil {
 				if console {
-					if msg, ok := formatAppConsoleLine(info, 0, false, s, filterSystem, false); ok {
+					if msg, ok := formatAppConsoleLine(info, 0, false, s, filterSystem, dedupeUnified); ok {
 						emit.Emit(LogStream("run", msg, "app"))
 					}
 				} else {
@@ -154,9 +173,11 @@ func runDevicectlLaunchCandidate(ctx context.Context, args []string, console boo
 			}
 		},
 		StderrLine: func(s string) {
+			errOut.WriteString(s)
+			errOut.WriteString("\n")
 			if emit != nil {
 				if console {
-					if msg, ok := formatAppConsoleLine(info, 0, true, s, filterSystem, false); ok {
+					if msg, ok := formatAppConsoleLine(info, 0, true, s, filterSystem, dedupeUnified); ok {
 						emit.Emit(LogStream("run", msg, "app"))
 					}
 				} else {
@@ -166,7 +187,16 @@ func runDevicectlLaunchCandidate(ctx context.Context, args []string, console boo
 		},
 	})
 	if err != nil {
-		return 0, err
+		if ctx.Err() != nil {
+			_ = os.Remove(jsonPath)
+			return 0, err
+		}
+		// With console the output is the app's; only devicectl's JSON counts
+		output := out.String() + errOut.String()
+		if console {
+			output = ""
+		}
+		return 0, devicectlFailure("launch", err, jsonPath, output)
 	}
 	pid := parseLaunchPID(jsonPath)
 	_ = os.Remove(jsonPath)
@@ -315,12 +345,12 @@ func firstString(m map[string]any, keys []string) string {
 	return ""
 }
 
-func DevicectlAvailable(ctx context.Context) bool {
-	_, err := RunStreaming(ctx, CmdSpec{Path: "xcrun", Args: []string{"devicectl", "help"}})
+func DevicectlAvailable(ctx context.Context, preserveLocale bool) bool {
+	_, err := RunStreaming(ctx, CmdSpec{Path: "xcrun", Args: []string{"devicectl", "help"}, PreserveLocale: preserveLocale})
 	return err == nil
 }
 
-func DevicectlStop(ctx context.Context, deviceID string, pid int, bundleID string, emit Emitter) error {
+func DevicectlStop(ctx context.Context, deviceID string, pid int, bundleID string, emit Emitter, preserveLocale bool) error {
 	// Best-effort: try process terminate first, then app terminate.
 	candidates := [][]string{}
 	if pid > 0 {
@@ -335,8 +365,9 @@ func DevicectlStop(ctx context.Context, deviceID string, pid int, bundleID strin
 	var lastErr error
 	for _, args := range candidates {
 		_, err := RunStreaming(ctx, CmdSpec{
-			Path: "xcrun",
-			Args: args,
+			Path:           "xcrun",
+			Args:           args,
+			PreserveLocale: preserveLocale,
 			StdoutLine: func(s string) {
 				if emit != nil {
 					emit.Emit(Log("device", s))
@@ -0,0 +1,239 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Known reasons a devicectl install or launch fails, see DevicectlError.
+const (
+	DeviceFailureLocked        = "locked"
+	DeviceFailurePasscode      = "passcode"
+	DeviceFailureDeveloperMode = "developer_mode"
+	DeviceFailureUntrusted     = "untrusted"
+	DeviceFailureStorage       = "storage"
+)
+
+// devicectlErrorRawLimit caps DevicectlError.Raw
+const devicectlErrorRawLimit = 16 << 10
+
+// devicectlDescriptionKeys are the userInfo keys describing a devicectl error
+var devicectlDescriptionKeys = []string{"NSLocalizedDescription", "NSLocalizedFailureReason", "NSLocalizedRecoverySuggestion"}
+
+// deviceRemedy is what xcbolt reports for a known devicectl failure
+type deviceRemedy struct {
+	code    string
+	message string
+	steps   []string
+	// needles are matched against devicectl's lowercased errors and output
+	needles []string
+}
+
+// deviceRemedies are checked in order; passcode comes before locked since
+// passcode errors often say the device is locked too
+var deviceRemedies = []struct {
+	category string
+	remedy   deviceRemedy
+}{
+	{DeviceFailureDeveloperMode, deviceRemedy{
+		code:    "DEVICE_DEVELOPER_MODE_DISABLED",
+		message: "Developer Mode is off on the device",
+		steps: []string{
+			"On the device, open Settings → Privacy & Security → Developer Mode.",
+			"Turn Developer Mode on and let the device restart.",
+			"Unlock the device and confirm Turn On when asked.",
+		},
+		needles: []string{"developer mode", "developermode"},
+	}},
+	{DeviceFailurePasscode, deviceRemedy{
+		code:    "DEVICE_PASSCODE_REQUIRED",
+		message: "The device needs its passcode entered",
+		steps: []string{
+			"Unlock the device with its passcode (Face ID or Touch ID isn't enough after a restart).",
+			"Keep it unlocked until the app has launched.",
+		},
+		needles: []string{"passcode", "password protected", "passwordprotected"},
+	}},
+	{DeviceFailureLocked, deviceRemedy{
+		code:    "DEVICE_LOCKED",
+		message: "The device is locked",
+		steps: []string{
+			"Unlock the device.",
+			"Keep it unlocked, screen on, until the app has launched.",
+		},
+		needles: []string{"device is locked", "device was locked", "devicelocked", "unlock the device", "is locked"},
+	}},
+	{DeviceFailureUntrusted, deviceRemedy{
+		code:    "DEVICE_UNTRUSTED",
+		message: "The device doesn't trust this Mac",
+		steps: []string{
+			"Connect the device with a cable and unlock it.",
+			"Tap Trust on the \"Trust This Computer?\" prompt and enter the passcode.",
+			"If there's no prompt, open Xcode → Window → Devices and Simulators and pair the device.",
+		},
+		needles: []string{"not paired", "pairing", "trust this computer", "untrusted", "invalidpairrecord", "not trusted"},
+	}},
+	{DeviceFailureStorage, deviceRemedy{
+		code:    "DEVICE_STORAGE_FULL",
+		message: "The device is out of storage",
+		steps: []string{
+			"Free up space on the device (Settings → General → Storage).",
+			"Delete old builds of the app if several are installed.",
+		},
+		needles: []string{"insufficient storage", "not enough space", "not enough storage", "insufficient space", "no space", "nospace", "disk full", "storage is full"},
+	}},
+}
+
+// DevicectlError is a failed devicectl install or launch. Category is one of
+// the DeviceFailure constants, or "" when devicectl's error isn't a known
+// one; Raw then carries its JSON error output for bug reports.
+type DevicectlError struct {
+	// Step is "install" or "launch"
+	Step        string
+	Category    string
+	Description string
+	Raw         string
+	Err         error
+}
+
+func (e *DevicectlError) Error() string {
+	if e.Description != "" {
+		return fmt.Sprintf("devicectl %s failed: %s", e.Step, e.Description)
+	}
+	return fmt.Sprintf("devicectl %s failed: %v", e.Step, e.Err)
+}
+
+func (e *DevicectlError) Unwrap() error { return e.Err }
+
+// Steps are the numbered remediation steps for a known failure
+func (e *DevicectlError) Steps() []string {
+	if r, ok := deviceRemedyFor(e.Category); ok {
+		return r.steps
+	}
+	return nil
+}
+
+// ErrorObject is the error event for the failure: a specific code with
+// step-by-step suggestions when the category is known, else fallback with
+// devicectl's JSON error in the detail.
+func (e *DevicectlError) ErrorObject(fallback ErrorObject) ErrorObject {
+	r, ok := deviceRemedyFor(e.Category)
+	if !ok {
+		fallback.Detail = e.Error()
+		if e.Raw != "" {
+			fallback.Detail += "\n" + e.Raw
+		}
+		return fallback
+	}
+	steps := make([]string, 0, len(r.steps)+1)
+	for i, step := range append(r.steps, "Retry the "+e.Step+".") {
+		steps = append(steps, fmt.Sprintf("%d. %s", i+1, step))
+	}
+	return ErrorObject{
+		Code:       r.code,
+		Message:    r.message,
+		Detail:     e.Error(),
+		Suggestion: strings.Join(steps, " "),
+	}
+}
+
+func deviceRemedyFor(category string) (deviceRemedy, bool) {
+	for _, r := range deviceRemedies {
+		if r.category == category {
+			return r.remedy, true
+		}
+	}
+	return deviceRemedy{}, false
+}
+
+// devicectlFailure wraps err from a devicectl step with what its JSON
+// output (jsonPath) and console output say went wrong
+func devicectlFailure(step string, err error, jsonPath string, output string) *DevicectlError {
+	raw, _ := os.ReadFile(jsonPath)
+	_ = os.Remove(jsonPath)
+	return classifyDevicectlError(step, err, raw, output)
+}
+
+func classifyDevicectlError(step string, err error, raw []byte, output string) *DevicectlError {
+	derr := &DevicectlError{Step: step, Err: err}
+	var texts []string
+	var doc map[string]any
+	if json.Unmarshal(raw, &doc) == nil {
+		if errObj, ok := doc["error"]; ok {
+			texts = devicectlErrorTexts(errObj, texts)
+			if b, mErr := json.MarshalIndent(errObj, "", "  "); mErr == nil {
+				derr.Raw = string(b)
+			}
+		}
+	} else if s := strings.TrimSpace(string(raw)); s != "" {
+		derr.Raw = s
+	}
+	if len(derr.Raw) > devicectlErrorRawLimit {
+		derr.Raw = derr.Raw[:devicectlErrorRawLimit] + "\n…"
+	}
+	if len(texts) > 0 {
+		derr.Description = texts[0]
+	}
+
+	haystack := strings.ToLower(strings.Join(texts, "\n") + "\n" + output)
+	for _, r := range deviceRemedies {
+		for _, needle := range r.remedy.needles {
+			if strings.Contains(haystack, needle) {
+				derr.Category = r.category
+				return derr
+			}
+		}
+	}
+	return derr
+}
+
+// devicectlErrorTexts collects the descriptions and domains of a devicectl
+// error and its underlying errors, outermost first
+func devicectlErrorTexts(v any, texts []string) []string {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return texts
+	}
+	userInfo, _ := m["userInfo"].(map[string]any)
+	for _, key := range devicectlDescriptionKeys {
+		if s := devicectlString(userInfo[key]); s != "" {
+			texts = append(texts, s)
+		}
+	}
+	if domain, _ := m["domain"].(string); domain != "" {
+		texts = append(texts, domain)
+	}
+	if underlying, ok := userInfo["NSUnderlyingError"].(map[string]any); ok {
+		if inner, ok := underlying["error"]; ok {
+			return devicectlErrorTexts(inner, texts)
+		}
+		return devicectlErrorTexts(underlying, texts)
+	}
+	return texts
+}
+
+// devicectlString reads a userInfo value, which devicectl writes either as
+// a plain string or as {"string": "..."}
+func devicectlString(v any) string {
+	switch x := v.(type) {
+	case string:
+		return x
+	case map[string]any:
+		s, _ := x["string"].(string)
+		return s
+	}
+	return ""
+}
+
+// deviceErrorObject is fallback, or the specific error of a known devicectl
+// failure
+func deviceErrorObject(err error, fallback ErrorObject) ErrorObject {
+	var derr *DevicectlError
+	if errors.As(err, &derr) {
+		return derr.ErrorObject(fallback)
+	}
+	return fallback
+}
@@ -0,0 +1,79 @@
+package core
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const lockedInstallJSON = `{
+  "error" : {
+    "code" : 1,
+    "domain" : "com.apple.dt.CoreDeviceError",
+    "userInfo" : {
+      "NSLocalizedDescription" : { "string" : "Failed to install the app on the device." },
+      "NSUnderlyingError" : {
+        "error" : {
+          "code" : -402653158,
+          "domain" : "com.apple.dt.MobileDeviceErrorDomain",
+          "userInfo" : {
+            "NSLocalizedDescription" : { "string" : "The device is passcode protected." },
+            "NSLocalizedRecoverySuggestion" : { "string" : "Unlock the device and try again." }
+          }
+        }
+      }
+    }
+  },
+  "info" : { "commandType" : "devicectl.device.install.app", "outcome" : "failed" }
+}`
+
+func TestClassifyDevicectlError(t *testing.T) {
+	exit := errors.New("exit status 1")
+	tests := []struct {
+		name   string
+		raw    string
+		output string
+		want   string
+	}{
+		{"passcode from json", lockedInstallJSON, "", DeviceFailurePasscode},
+		{"locked from output", "", "ERROR: The device is locked. (com.apple.dt.CoreDeviceError error 4000.)", DeviceFailureLocked},
+		{"developer mode", `{"error":{"domain":"com.apple.dt.CoreDeviceError","userInfo":{"NSLocalizedDescription":"Developer Mode is disabled on this device."}}}`, "", DeviceFailureDeveloperMode},
+		{"untrusted", `{"error":{"userInfo":{"NSLocalizedDescription":{"string":"The device is not paired with this Mac."}}}}`, "", DeviceFailureUntrusted},
+		{"storage", "", "ERROR: Not enough space on the device to install the app", DeviceFailureStorage},
+		{"unknown", `{"error":{"code":3,"domain":"com.apple.dt.CoreDeviceError","userInfo":{"NSLocalizedDescription":{"string":"Something else broke."}}}}`, "", ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := classifyDevicectlError("install", exit, []byte(tc.raw), tc.output)
+			if got.Category != tc.want {
+				t.Fatalf("Category = %q, want %q", got.Category, tc.want)
+			}
+			if !errors.Is(got, exit) {
+				t.Fatal("lost the wrapped error")
+			}
+		})
+	}
+}
+
+func TestDevicectlErrorObject(t *testing.T) {
+	fallback := ErrorObject{Code: "DEVICE_INSTALL_FAILED", Message: "Failed to install app on device"}
+
+	known := classifyDevicectlError("install", errors.New("exit status 1"), []byte(lockedInstallJSON), "")
+	eo := deviceErrorObject(known, fallback)
+	if eo.Code != "DEVICE_PASSCODE_REQUIRED" || !strings.HasPrefix(eo.Suggestion, "1. Unlock the device") || !strings.HasSuffix(eo.Suggestion, "3. Retry the install.") {
+		t.Fatalf("known error = %+v", eo)
+	}
+	if eo.Detail != "devicectl install failed: Failed to install the app on the device." {
+		t.Fatalf("Detail = %q", eo.Detail)
+	}
+
+	unknown := classifyDevicectlError("launch", errors.New("exit status 1"), []byte(`{"error":{"code":3,"domain":"com.apple.dt.CoreDeviceError","userInfo":{}}}`), "")
+	eo = deviceErrorObject(unknown, fallback)
+	if eo.Code != "DEVICE_INSTALL_FAILED" || !strings.Contains(eo.Detail, `"domain": "com.apple.dt.CoreDeviceError"`) {
+		t.Fatalf("unknown error = %+v", eo)
+	}
+
+	if eo := deviceErrorObject(errors.New("other"), fallback); eo != fallback {
+		t.Fatalf("non-devicectl error = %+v", eo)
+	}
+}
@@ -0,0 +1,200 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// Tools for launch.deviceLogTool
+const (
+	DeviceLogToolDevicectl     = "devicectl"
+	DeviceLogToolIdevicesyslog = "idevicesyslog"
+)
+
+// deviceLogTool returns the configured device log streamer (devicectl by
+// default)
+func deviceLogTool(cfg Config) string {
+	if strings.EqualFold(strings.TrimSpace(cfg.Launch.DeviceLogTool), DeviceLogToolIdevicesyslog) {
+		return DeviceLogToolIdevicesyslog
+	}
+	return DeviceLogToolDevicectl
+}
+
+// startDeviceLogStream follows the app's unified logs on a physical device
+// until the returned stop func is called. It reports false, after a Warn,
+// when the configured tool isn't installed.
+func startDeviceLogStream(ctx context.Context, cfg Config, udid string, info AppBundleInfo, emit Emitter) (func(), bool) {
+	tool := deviceLogTool(cfg)
+	bin := "xcrun"
+	if tool == DeviceLogToolIdevicesyslog {
+		bin = DeviceLogToolIdevicesyslog
+	}
+	if _, err := exec.LookPath(bin); err != nil {
+		msg := "xcrun not found; device unified logs are off"
+		if tool == DeviceLogToolIdevicesyslog {
+			msg = "idevicesyslog not found (brew install libimobiledevice); device unified logs are off"
+		}
+		emitMaybe(emit, Warn("run", msg))
+		return func() {}, false
+	}
+	predicate := unifiedLogPredicate(cfg, info)
+	if tool == DeviceLogToolDevicectl && predicate == "" {
+		return func() {}, false
+	}
+
+	logCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		var err error
+		if tool == DeviceLogToolIdevicesyslog {
+			err = IdevicesyslogStream(logCtx, udid, info, !shouldStreamSystemLogs(cfg), emit)
+		} else {
+			err = DevicectlLogStream(logCtx, udid, predicate, emit, cfg.Xcodebuild.PreserveLocale)
+		}
+		if err != nil && logCtx.Err() == nil && !errors.Is(err, context.Canceled) {
+			emitMaybe(emit, Warn("run", deviceLogStreamFailure(tool, err)))
+		}
+	}()
+	return func() {
+		cancel()
+		<-done
+	}, true
+}
+
+// deviceLogStreamFailure explains a device log stream that stopped on its own
+func deviceLogStreamFailure(tool string, err error) string {
+	if tool == DeviceLogToolIdevicesyslog {
+		return "idevicesyslog failed: " + err.Error() + " (is the device paired and trusted? try idevicepair pair)"
+	}
+	return "devicectl log stream failed: " + err.Error() + " (unlock and trust the device, or set launch.deviceLogTool to \"idevicesyslog\")"
+}
+
+// DevicectlLogStream streams unified logs from a physical device.
+func DevicectlLogStream(ctx context.Context, udid string, predicate string, emit Emitter, preserveLocale bool) error {
+	if udid == "" {
+		return errors.New("missing device udid")
+	}
+	args := []string{"devicectl", "device", "log", "stream", "--device", udid, "--style", "compact", "--level", "debug"}
+	if predicate != "" {
+		args = append(args, "--predicate", predicate)
+	}
+	_, err := RunStreaming(ctx, CmdSpec{
+		Path:           "xcrun",
+		Args:           args,
+		PreserveLocale: preserveLocale,
+		StdoutLine: func(s string) {
+			if emit != nil {
+				if isSimctlLogHeader(s) {
+					emit.Emit(LogStream("run", s, "system"))
+				} else {
+					emit.Emit(LogStream("run", formatSimctlLogLine(s), "unified"))
+				}
+			}
+		},
+		StderrLine: func(s string) {
+			if emit != nil {
+				emit.Emit(Log("device", s))
+			}
+		},
+	})
+	return err
+}
+
+// IdevicesyslogStream streams a device's syslog with libimobiledevice. It
+// has no predicates: lines are limited to the app's process and, with
+// appOnly, to messages logged by the app binary itself.
+func IdevicesyslogStream(ctx context.Context, udid string, info AppBundleInfo, appOnly bool, emit Emitter) error {
+	if udid == "" {
+		return errors.New("missing device udid")
+	}
+	args := []string{"-u", udid, "--no-colors"}
+	if info.Executable != "" {
+		args = append(args, "-p", info.Executable)
+	}
+	_, err := RunStreaming(ctx, CmdSpec{
+		Path: DeviceLogToolIdevicesyslog,
+		Args: args,
+		StdoutLine: func(s string) {
+			if emit == nil {
+				return
+			}
+			line, ok := parseIdevicesyslogLine(s)
+			switch {
+			case ok:
+				if appOnly && info.Executable != "" && line.Image != "" && line.Image != info.Executable {
+					return
+				}
+				emit.Emit(LogStream("run", line.String(), "unified"))
+			case strings.HasPrefix(s, "[connected") || strings.HasPrefix(s, "[disconnected"):
+				emit.Emit(Log("device", s))
+			case strings.TrimSpace(s) != "":
+				// Continuation of a multi-line message
+				emit.Emit(LogStream("run", strings.TrimSpace(s), "unified"))
+			}
+		},
+		StderrLine: func(s string) {
+			if emit != nil {
+				emit.Emit(Log("device", s))
+			}
+		},
+	})
+	return err
+}
+
+// e.g. "Oct 14 12:34:56.789012 iPhone App(Foundation)[123] <Notice>: message"
+var idevicesyslogLineRE = regexp.MustCompile(`^\w{3}\s+\d+\s+(\d{2}:\d{2}:\d{2}(?:\.\d+)?)\s+\S+\s+([^\s(\[]+)(?:\(([^)]*)\))?\[(\d+)\]\s+<(\w+)>:\s?(.*)$`)
+
+// idevicesyslogLine is one parsed idevicesyslog line
+type idevicesyslogLine struct {
+	Time    string
+	Process string
+	Image   string // Binary that logged the message ("" when not shown)
+	PID     string
+	Level   string // Compact log style level (Db, I, Df, W, E, F)
+	Message string
+}
+
+// String formats the line like compact unified log lines in the console pane
+func (l idevicesyslogLine) String() string {
+	return fmt.Sprintf("%s %s %s[%s]\n%s", l.Time, l.Level, l.Process, l.PID, l.Message)
+}
+
+func parseIdevicesyslogLine(s string) (idevicesyslogLine, bool) {
+	m := idevicesyslogLineRE.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return idevicesyslogLine{}, false
+	}
+	return idevicesyslogLine{
+		Time:    m[1],
+		Process: m[2],
+		Image:   m[3],
+		PID:     m[4],
+		Level:   syslogLevel(m[5]),
+		Message: m[6],
+	}, true
+}
+
+// syslogLevel maps a syslog priority to the compact log style level letters
+// the console level filter reads
+func syslogLevel(priority string) string {
+	switch strings.ToLower(priority) {
+	case "debug":
+		return "Db"
+	case "info":
+		return "I"
+	case "notice":
+		return "Df"
+	case "warning":
+		return "W"
+	case "error":
+		return "E"
+	case "critical", "alert", "emergency":
+		return "F"
+	}
+	return priority
+}
@@ -0,0 +1,79 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestParseIdevicesyslogLine(t *testing.T) {
+	line, ok := parseIdevicesyslogLine("Oct 14 12:34:56.789012 iPhone Demo(Foundation)[123] <Error>: request failed")
+	if !ok {
+		t.Fatalf("line not parsed")
+	}
+	want := idevicesyslogLine{Time: "12:34:56.789012", Process: "Demo", Image: "Foundation", PID: "123", Level: "E", Message: "request failed"}
+	if line != want {
+		t.Fatalf("parsed %+v, want %+v", line, want)
+	}
+	if got := ConsoleLineLevel(line.String()); got != "E" {
+		t.Fatalf("console level = %q, want E", got)
+	}
+
+	line, ok = parseIdevicesyslogLine("Oct  4 09:00:01 iPhone Demo[7] <Notice>: started")
+	if !ok || line.Image != "" || line.Level != "Df" || line.Message != "started" {
+		t.Fatalf("line without image parsed as %+v, %v", line, ok)
+	}
+
+	if _, ok := parseIdevicesyslogLine("[connected:00008110-001]"); ok {
+		t.Fatalf("status line parsed as a log line")
+	}
+}
+
+func TestDeviceLogToolDefaultsToDevicectl(t *testing.T) {
+	tests := map[string]string{
+		"":              DeviceLogToolDevicectl,
+		"devicectl":     DeviceLogToolDevicectl,
+		"IDeviceSyslog": DeviceLogToolIdevicesyslog,
+		"bogus":         DeviceLogToolDevicectl,
+	}
+	for in, want := range tests {
+		cfg := Config{Launch: LaunchConfig{DeviceLogTool: in}}
+		if got := deviceLogTool(cfg); got != want {
+			t.Fatalf("deviceLogTool(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestUnifiedLogPredicate(t *testing.T) {
+	info := AppBundleInfo{BundleID: "com.example.demo", Executable: "Demo"}
+	on, off := true, false
+
+	cfg := Config{Launch: LaunchConfig{StreamSystemLogs: &off}}
+	if got, want := unifiedLogPredicate(cfg, info), `process == "Demo" AND (subsystem == "com.example.demo" OR subsystem BEGINSWITH "com.example.demo.")`; got != want {
+		t.Fatalf("predicate = %s, want %s", got, want)
+	}
+	cfg.Launch.StreamSystemLogs = &on
+	if got, want := unifiedLogPredicate(cfg, info), `process == "Demo"`; got != want {
+		t.Fatalf("predicate with system logs = %s, want %s", got, want)
+	}
+	if got := unifiedLogPredicate(cfg, AppBundleInfo{}); got != "" {
+		t.Fatalf("predicate without app info = %q, want empty", got)
+	}
+}
+
+func TestStartDeviceLogStreamWarnsWhenToolMissing(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	info := AppBundleInfo{BundleID: "com.example.demo", Executable: "Demo"}
+	for _, tool := range []string{DeviceLogToolDevicectl, DeviceLogToolIdevicesyslog} {
+		rec := &recordingEmitter{}
+		cfg := Config{Launch: LaunchConfig{DeviceLogTool: tool}}
+		stop, ok := startDeviceLogStream(context.Background(), cfg, "UDID", info, rec)
+		stop()
+		if ok {
+			t.Fatalf("%s: stream started without the tool", tool)
+		}
+		if len(rec.events) != 1 || rec.events[0].Type != "warning" || !strings.Contains(rec.events[0].Msg, "not found") {
+			t.Fatalf("%s: events = %+v, want one not-found warning", tool, rec.events)
+		}
+	}
+}
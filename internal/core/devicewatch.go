@@ -0,0 +1,172 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DestinationListTimeout bounds each simctl and devicectl listing of a
+// destination poll.
+const DestinationListTimeout = 5 * time.Second
+
+// Destinations is the device/simulator part of ContextInfo. A list whose
+// tool failed keeps its error, and callers should keep their previous list.
+type Destinations struct {
+	Simulators    []Simulator
+	Devices       []Device
+	SimulatorsErr error
+	DevicesErr    error
+}
+
+// ListDestinations lists simulators and connected devices, giving each tool
+// DestinationListTimeout.
+func ListDestinations(ctx context.Context, emit Emitter, preserveLocale bool) Destinations {
+	return listDestinations(ctx, emit, preserveLocale, DestinationListTimeout)
+}
+
+// listDestinations gives each tool timeout, or only ctx's deadline when
+// timeout is 0 (context discovery)
+func listDestinations(ctx context.Context, emit Emitter, preserveLocale bool, timeout time.Duration) Destinations {
+	toolCtx := func() (context.Context, context.CancelFunc) {
+		if timeout <= 0 {
+			return context.WithCancel(ctx)
+		}
+		return context.WithTimeout(ctx, timeout)
+	}
+	var d Destinations
+	simCtx, cancel := toolCtx()
+	if list, err := SimctlList(simCtx, emit, preserveLocale); err == nil {
+		d.Simulators = FlattenSimulators(list)
+	} else {
+		d.SimulatorsErr = err
+	}
+	cancel()
+
+	devCtx, cancel := toolCtx()
+	d.Devices, d.DevicesErr = DevicectlList(devCtx, emit, preserveLocale)
+	cancel()
+	return d
+}
+
+// DestinationChanges lists the names of devices and simulators that came
+// or went between two listings.
+type DestinationChanges struct {
+	Connected    []string
+	Disconnected []string
+}
+
+func (c DestinationChanges) Any() bool {
+	return len(c.Connected) > 0 || len(c.Disconnected) > 0
+}
+
+// Line summarizes the changes, e.g. "iPhone von Max connected" or
+// "2 devices connected, iPad disconnected".
+func (c DestinationChanges) Line() string {
+	describe := func(names []string, verb string) string {
+		switch len(names) {
+		case 0:
+			return ""
+		case 1:
+			return names[0] + " " + verb
+		}
+		return fmt.Sprintf("%d devices %s", len(names), verb)
+	}
+	var parts []string
+	for _, part := range []string{describe(c.Connected, "connected"), describe(c.Disconnected, "disconnected")} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// DiffDestinations compares the devices and simulators of two listings.
+// Simulators count by UDID, so booting one isn't a change; lists that
+// failed in next are left out.
+func DiffDestinations(prev ContextInfo, next Destinations) DestinationChanges {
+	var c DestinationChanges
+	if next.DevicesErr == nil {
+		prevIDs, nextIDs := map[string]bool{}, map[string]bool{}
+		for _, d := range prev.Devices {
+			prevIDs[d.Identifier] = true
+		}
+		for _, d := range next.Devices {
+			nextIDs[d.Identifier] = true
+			if !prevIDs[d.Identifier] {
+				c.Connected = append(c.Connected, d.Name)
+			}
+		}
+		for _, d := range prev.Devices {
+			if !nextIDs[d.Identifier] {
+				c.Disconnected = append(c.Disconnected, d.Name)
+			}
+		}
+	}
+	if next.SimulatorsErr == nil {
+		prevIDs, nextIDs := map[string]bool{}, map[string]bool{}
+		for _, s := range prev.Simulators {
+			prevIDs[s.UDID] = true
+		}
+		for _, s := range next.Simulators {
+			nextIDs[s.UDID] = true
+			if !prevIDs[s.UDID] {
+				c.Connected = append(c.Connected, s.Name)
+			}
+		}
+		for _, s := range prev.Simulators {
+			if !nextIDs[s.UDID] {
+				c.Disconnected = append(c.Disconnected, s.Name)
+			}
+		}
+	}
+	sort.Strings(c.Connected)
+	sort.Strings(c.Disconnected)
+	return c
+}
+
+// Apply replaces info's device and simulator lists with those that were
+// listed successfully.
+func (d Destinations) Apply(info *ContextInfo) {
+	if d.SimulatorsErr == nil {
+		info.Simulators = d.Simulators
+	}
+	if d.DevicesErr == nil {
+		info.Devices = d.Devices
+	}
+}
+
+// DestinationAvailable reports whether dest is among info's devices or
+// simulators. The Mac, auto destinations, and destinations without a UDID
+// always count as available, as do simulators when none are listed
+// (simctl failed).
+func DestinationAvailable(dest Destination, info ContextInfo) bool {
+	id := dest.UDID
+	if id == "" {
+		id = dest.ID
+	}
+	switch {
+	case id == "":
+		return true
+	case dest.Kind == DestDevice:
+		for _, d := range info.Devices {
+			if d.Identifier == id {
+				return true
+			}
+		}
+		return false
+	case dest.Kind == DestSimulator:
+		if len(info.Simulators) == 0 {
+			return true
+		}
+		for _, s := range info.Simulators {
+			if s.UDID == id {
+				return s.Available
+			}
+		}
+		return false
+	}
+	return true
+}
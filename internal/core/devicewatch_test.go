@@ -0,0 +1,61 @@
+package core
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDiffDestinations(t *testing.T) {
+	prev := ContextInfo{
+		Devices:    []Device{{Name: "iPad", Identifier: "IPAD"}},
+		Simulators: []Simulator{{Name: "iPhone 16", UDID: "SIM-1", State: "Shutdown", Available: true}},
+	}
+	next := Destinations{
+		Devices:    []Device{{Name: "iPhone von Max", Identifier: "PHONE"}},
+		Simulators: []Simulator{{Name: "iPhone 16", UDID: "SIM-1", State: "Booted", Available: true}},
+	}
+	c := DiffDestinations(prev, next)
+	if got, want := c.Line(), "iPhone von Max connected, iPad disconnected"; got != want {
+		t.Fatalf("line = %q, want %q", got, want)
+	}
+
+	// A failed listing is not everything disappearing
+	next.DevicesErr = errors.New("devicectl timed out")
+	if c := DiffDestinations(prev, next); c.Any() {
+		t.Fatalf("failed device list should be ignored, got %+v", c)
+	}
+	info := prev
+	next.Apply(&info)
+	if len(info.Devices) != 1 || info.Devices[0].Identifier != "IPAD" || info.Simulators[0].State != "Booted" {
+		t.Fatalf("unexpected info after apply: %+v", info)
+	}
+
+	many := DestinationChanges{Connected: []string{"a", "b"}}
+	if got := many.Line(); got != "2 devices connected" {
+		t.Fatalf("line = %q", got)
+	}
+}
+
+func TestDestinationAvailable(t *testing.T) {
+	info := ContextInfo{
+		Devices:    []Device{{Name: "iPhone", Identifier: "PHONE"}},
+		Simulators: []Simulator{{Name: "iPhone 16", UDID: "SIM-1", Available: true}, {Name: "Old", UDID: "SIM-2"}},
+	}
+	cases := []struct {
+		dest Destination
+		want bool
+	}{
+		{Destination{Kind: DestDevice, UDID: "PHONE"}, true},
+		{Destination{Kind: DestDevice, UDID: "IPAD"}, false},
+		{Destination{Kind: DestSimulator, UDID: "SIM-1"}, true},
+		{Destination{Kind: DestSimulator, UDID: "SIM-2"}, false},
+		{Destination{Kind: DestSimulator, UDID: "GONE"}, false},
+		{Destination{Kind: DestCatalyst}, true},
+		{Destination{Kind: DestDevice}, true},
+	}
+	for _, tc := range cases {
+		if got := DestinationAvailable(tc.dest, info); got != tc.want {
+			t.Errorf("%+v: got %v, want %v", tc.dest, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package core
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DiagnosticSeverity is how bad a classified log line is.
+type DiagnosticSeverity int
+
+const (
+	DiagnosticNone DiagnosticSeverity = iota
+	DiagnosticError
+	DiagnosticWarning
+	DiagnosticNote // note: and remark:
+)
+
+// Diagnostic is a build or test log line as ClassifyDiagnostic reads it.
+type Diagnostic struct {
+	Severity DiagnosticSeverity
+	// File, Line, and Col locate the diagnostic ("" and 0 when the line has
+	// no location; Line and Col are 0 when only a file is given).
+	File string
+	Line int
+	Col  int
+	// Message is the text after the location and severity, or the whole
+	// trimmed line when there is neither.
+	Message string
+	// IsTest marks XCTest and Swift Testing output: failed assertions and
+	// test result lines.
+	IsTest bool
+	// Test names the test of a result line or assertion failure, e.g.
+	// "-[AppTests.LoginTests testSSO]" or "testSSO()".
+	Test string
+	// TestOutcome is "passed", "failed", or "skipped" for test result lines.
+	// Result lines are not diagnostics: their Severity is DiagnosticNone.
+	TestOutcome string
+	// IsPretty marks log formatter output (xcbeautify, xcpretty), which
+	// repeats diagnostics already present in the raw log.
+	IsPretty bool
+}
+
+// Located reports whether the diagnostic points at a source line.
+func (d Diagnostic) Located() bool {
+	return d.File != "" && d.Line > 0
+}
+
+var (
+	// path:line[:col]: severity: message. The path may contain spaces and
+	// colons; the shortest prefix followed by a location and a severity wins.
+	diagLocatedRE = regexp.MustCompile(`^\s*(\S.*?):(\d+)(?::(\d+))?:\s*(fatal error|error|warning|note|remark):\s?(.*)$`)
+	// /path/to/App.xcodeproj: error: message (a file without a line)
+	diagFileRE = regexp.MustCompile(`^\s*(/.*?):\s*(fatal error|error|warning|note|remark):\s?(.*)$`)
+	// [tool: ]severity: message, e.g. "clang: error:", "ld: warning:",
+	// "xcodebuild: error:", or a bare "error:"
+	diagToolRE = regexp.MustCompile(`^\s*(?:[\w.+-]+: )?(fatal error|error|warning|note|remark):\s?(.*)$`)
+	// Location at the start of formatter output: "/path/App.swift:12:5: msg"
+	diagPrettyLocationRE = regexp.MustCompile(`^(\S.*?):(\d+)(?::(\d+))?:\s?(.*)$`)
+
+	xctestResultRE       = regexp.MustCompile(`^\s*Test Case '([^']+)' (passed|failed|skipped)`)
+	swiftTestingResultRE = regexp.MustCompile(`^\s*\S+ Test (.+?) (passed|failed|skipped)\b`)
+	swiftTestingIssueRE  = regexp.MustCompile(`^\s*\S+ Test (.+?) recorded an issue at (\S.*?):(\d+):(\d+):\s?(.*)$`)
+	xctestAssertionRE    = regexp.MustCompile(`^(-\[\S+ \S+\]) : `)
+)
+
+// diagFatalPhrases mark tool failures that carry no severity prefix
+// (matched case-insensitively anywhere in the line).
+var diagFatalPhrases = []string{
+	"fatal error",
+	"linker command failed",
+	"undefined symbols for architecture",
+	"symbol(s) not found",
+	"framework not found",
+	"library not found for",
+	"no such module",
+	"command swiftcompile failed",
+	"command compilec failed",
+	"command ld failed",
+	"failed with a nonzero exit code",
+	"failed with exit code",
+	"codesign error",
+	"code signing error",
+}
+
+// diagPrettyMarkers are the leading markers of formatter output, longest
+// first ("⚠️" is "⚠" plus a variation selector).
+var diagPrettyMarkers = []struct {
+	marker   string
+	severity DiagnosticSeverity
+	test     bool
+}{
+	{"❌", DiagnosticError, false},
+	{"⚠️", DiagnosticWarning, false},
+	{"⚠", DiagnosticWarning, false},
+	{"✖", DiagnosticError, true},
+	{"✗", DiagnosticError, true},
+}
+
+// ClassifyDiagnostic reads one line of build or test output. It is the only
+// place deciding whether a line is an error, warning, or note, so that log
+// views, the Issues tab, and every count agree.
+//
+// Severities are only taken from where compilers and tools put them: after a
+// file:line:col location, at the start of the line ("clang: error:",
+// "warning:"), or from a formatter's leading marker. A path or test name
+// that merely contains "error:" is not a diagnostic.
+func ClassifyDiagnostic(line string) Diagnostic {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" {
+		return Diagnostic{}
+	}
+
+	if m := swiftTestingIssueRE.FindStringSubmatch(line); m != nil {
+		d := Diagnostic{Severity: DiagnosticError, File: m[2], Message: m[5], IsTest: true, Test: m[1]}
+		d.Line, _ = strconv.Atoi(m[3])
+		d.Col, _ = strconv.Atoi(m[4])
+		return d
+	}
+	if m := xctestResultRE.FindStringSubmatch(line); m != nil {
+		return Diagnostic{Message: trimmed, IsTest: true, Test: m[1], TestOutcome: m[2]}
+	}
+	if m := swiftTestingResultRE.FindStringSubmatch(line); m != nil {
+		return Diagnostic{Message: trimmed, IsTest: true, Test: m[1], TestOutcome: m[2]}
+	}
+
+	if m := diagLocatedRE.FindStringSubmatch(line); m != nil {
+		d := Diagnostic{Severity: diagSeverity(m[4]), File: m[1], Message: strings.TrimSpace(m[5])}
+		d.Line, _ = strconv.Atoi(m[2])
+		d.Col, _ = strconv.Atoi(m[3])
+		if a := xctestAssertionRE.FindStringSubmatch(d.Message); a != nil {
+			d.IsTest, d.Test = true, a[1]
+		}
+		return d
+	}
+
+	for _, p := range diagPrettyMarkers {
+		if rest, ok := strings.CutPrefix(trimmed, p.marker); ok {
+			return classifyPretty(strings.TrimSpace(rest), p.severity, p.test)
+		}
+	}
+
+	if m := diagFileRE.FindStringSubmatch(line); m != nil {
+		return Diagnostic{Severity: diagSeverity(m[2]), File: m[1], Message: strings.TrimSpace(m[3])}
+	}
+	if m := diagToolRE.FindStringSubmatch(line); m != nil {
+		return Diagnostic{Severity: diagSeverity(m[1]), Message: trimmed}
+	}
+
+	lower := strings.ToLower(trimmed)
+	for _, phrase := range diagFatalPhrases {
+		if strings.Contains(lower, phrase) {
+			return Diagnostic{Severity: DiagnosticError, Message: trimmed}
+		}
+	}
+	return Diagnostic{Message: trimmed}
+}
+
+// classifyPretty reads formatter output after its marker, e.g.
+// "/path/App.swift:12:5: cannot find 'x' in scope" or "testSSO, failed"
+func classifyPretty(rest string, severity DiagnosticSeverity, test bool) Diagnostic {
+	d := Diagnostic{Severity: severity, Message: rest, IsPretty: true, IsTest: test}
+	if m := diagPrettyLocationRE.FindStringSubmatch(rest); m != nil && !strings.Contains(m[1], ", ") {
+		d.File = m[1]
+		d.Line, _ = strconv.Atoi(m[2])
+		d.Col, _ = strconv.Atoi(m[3])
+		d.Message = m[4]
+	}
+	for _, prefix := range []string{"error: ", "warning: "} {
+		d.Message = strings.TrimPrefix(d.Message, prefix)
+	}
+	if test {
+		if name, _, ok := strings.Cut(d.Message, ","); ok {
+			d.Test = strings.TrimSpace(name)
+		}
+	}
+	return d
+}
+
+func diagSeverity(s string) DiagnosticSeverity {
+	switch s {
+	case "fatal error", "error":
+		return DiagnosticError
+	case "warning":
+		return DiagnosticWarning
+	case "note", "remark":
+		return DiagnosticNote
+	}
+	return DiagnosticNone
+}
@@ -0,0 +1,114 @@
+package core
+
+import "testing"
+
+func TestClassifyDiagnostic(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want Diagnostic
+	}{
+		// swiftc
+		{"swiftc error", "/src/App/ContentView.swift:12:9: error: cannot find 'foo' in scope",
+			Diagnostic{Severity: DiagnosticError, File: "/src/App/ContentView.swift", Line: 12, Col: 9, Message: "cannot find 'foo' in scope"}},
+		{"swiftc warning", "/src/App/ContentView.swift:20:13: warning: variable 'x' was never used",
+			Diagnostic{Severity: DiagnosticWarning, File: "/src/App/ContentView.swift", Line: 20, Col: 13, Message: "variable 'x' was never used"}},
+		{"swiftc note", "/src/App/Model.swift:3:7: note: 'Model' declared here",
+			Diagnostic{Severity: DiagnosticNote, File: "/src/App/Model.swift", Line: 3, Col: 7, Message: "'Model' declared here"}},
+		{"swiftc relative path", "Sources/App.swift:12:5: error: no such module 'Foo'",
+			Diagnostic{Severity: DiagnosticError, File: "Sources/App.swift", Line: 12, Col: 5, Message: "no such module 'Foo'"}},
+		{"indented", "    /src/A.swift:1:2: warning: w",
+			Diagnostic{Severity: DiagnosticWarning, File: "/src/A.swift", Line: 1, Col: 2, Message: "w"}},
+		{"path with spaces", "/Users/me/My App/App.swift:1:1: error: expected expression",
+			Diagnostic{Severity: DiagnosticError, File: "/Users/me/My App/App.swift", Line: 1, Col: 1, Message: "expected expression"}},
+		{"unknown location", "<unknown>:0: error: unable to load standard library for target 'arm64-apple-ios17.0'",
+			Diagnostic{Severity: DiagnosticError, File: "<unknown>", Message: "unable to load standard library for target 'arm64-apple-ios17.0'"}},
+
+		// clang
+		{"clang fatal error", "/src/Bridge.m:3:9: fatal error: 'Foo/Foo.h' file not found",
+			Diagnostic{Severity: DiagnosticError, File: "/src/Bridge.m", Line: 3, Col: 9, Message: "'Foo/Foo.h' file not found"}},
+		{"clang remark", "/src/Bridge.m:8:1: remark: loop vectorized",
+			Diagnostic{Severity: DiagnosticNote, File: "/src/Bridge.m", Line: 8, Col: 1, Message: "loop vectorized"}},
+		{"clang driver error", "clang: error: linker command failed with exit code 1 (use -v to see invocation)",
+			Diagnostic{Severity: DiagnosticError, Message: "clang: error: linker command failed with exit code 1 (use -v to see invocation)"}},
+		{"plist without column", "/src/Info.plist:3: note: using default",
+			Diagnostic{Severity: DiagnosticNote, File: "/src/Info.plist", Line: 3, Message: "using default"}},
+
+		// ld
+		{"ld warning", "ld: warning: ignoring duplicate libraries: '-lc++'",
+			Diagnostic{Severity: DiagnosticWarning, Message: "ld: warning: ignoring duplicate libraries: '-lc++'"}},
+		{"ld symbols", "ld: symbol(s) not found for architecture arm64",
+			Diagnostic{Severity: DiagnosticError, Message: "ld: symbol(s) not found for architecture arm64"}},
+		{"undefined symbols", "Undefined symbols for architecture arm64:",
+			Diagnostic{Severity: DiagnosticError, Message: "Undefined symbols for architecture arm64:"}},
+		{"library not found", "ld: library not found for -lPods",
+			Diagnostic{Severity: DiagnosticError, Message: "ld: library not found for -lPods"}},
+
+		// xcodebuild
+		{"xcodebuild error", "xcodebuild: error: Unable to find a destination matching the provided destination specifier",
+			Diagnostic{Severity: DiagnosticError, Message: "xcodebuild: error: Unable to find a destination matching the provided destination specifier"}},
+		{"project error without line", "/Users/me/App/App.xcodeproj: error: Signing for \"App\" requires a development team.",
+			Diagnostic{Severity: DiagnosticError, File: "/Users/me/App/App.xcodeproj", Message: "Signing for \"App\" requires a development team."}},
+		{"bare warning", "warning: unable to find a platform",
+			Diagnostic{Severity: DiagnosticWarning, Message: "warning: unable to find a platform"}},
+		{"bare note", "note: Using new build system",
+			Diagnostic{Severity: DiagnosticNote, Message: "note: Using new build system"}},
+		{"script phase failure", "Command PhaseScriptExecution failed with a nonzero exit code",
+			Diagnostic{Severity: DiagnosticError, Message: "Command PhaseScriptExecution failed with a nonzero exit code"}},
+		{"uppercase tool warning", "--- xcodebuild: WARNING: Using the first of multiple matching destinations:",
+			Diagnostic{Message: "--- xcodebuild: WARNING: Using the first of multiple matching destinations:"}},
+		{"nslog warning", "2026-01-02 10:00:00.000 xcodebuild[123:456] warning: IDETestOperationsObserver",
+			Diagnostic{Message: "2026-01-02 10:00:00.000 xcodebuild[123:456] warning: IDETestOperationsObserver"}},
+		{"build failed banner", "** BUILD FAILED **", Diagnostic{Message: "** BUILD FAILED **"}},
+		{"compile step", "CompileSwift normal arm64 /src/App.swift (in target 'App' from project 'App')",
+			Diagnostic{Message: "CompileSwift normal arm64 /src/App.swift (in target 'App' from project 'App')"}},
+
+		// XCTest and Swift Testing
+		{"xctest assertion", "/src/AppTests/LoginTests.swift:42: error: -[AppTests.LoginTests testSSO] : XCTAssertEqual failed: (\"1\") is not equal to (\"2\")",
+			Diagnostic{Severity: DiagnosticError, File: "/src/AppTests/LoginTests.swift", Line: 42, Message: "-[AppTests.LoginTests testSSO] : XCTAssertEqual failed: (\"1\") is not equal to (\"2\")", IsTest: true, Test: "-[AppTests.LoginTests testSSO]"}},
+		{"xctest passed", "Test Case '-[AppTests.LoginTests testErrorHandling]' passed (0.001 seconds).",
+			Diagnostic{Message: "Test Case '-[AppTests.LoginTests testErrorHandling]' passed (0.001 seconds).", IsTest: true, Test: "-[AppTests.LoginTests testErrorHandling]", TestOutcome: "passed"}},
+		{"xctest failed", "Test Case '-[AppTests.LoginTests testSSO]' failed (0.002 seconds).",
+			Diagnostic{Message: "Test Case '-[AppTests.LoginTests testSSO]' failed (0.002 seconds).", IsTest: true, Test: "-[AppTests.LoginTests testSSO]", TestOutcome: "failed"}},
+		{"xctest started", "Test Case '-[AppTests.ErrorTests testErrorHandling]' started.",
+			Diagnostic{Message: "Test Case '-[AppTests.ErrorTests testErrorHandling]' started."}},
+		{"swift testing passed", "✔ Test parsesWarnings() passed after 0.001 seconds.",
+			Diagnostic{Message: "✔ Test parsesWarnings() passed after 0.001 seconds.", IsTest: true, Test: "parsesWarnings()", TestOutcome: "passed"}},
+		{"swift testing failed", "✘ Test errorHandling() failed after 0.002 seconds with 1 issue.",
+			Diagnostic{Message: "✘ Test errorHandling() failed after 0.002 seconds with 1 issue.", IsTest: true, Test: "errorHandling()", TestOutcome: "failed"}},
+		{"swift testing issue", "✘ Test errorHandling() recorded an issue at ErrorTests.swift:12:5: Expectation failed: (value → 1) == 2",
+			Diagnostic{Severity: DiagnosticError, File: "ErrorTests.swift", Line: 12, Col: 5, Message: "Expectation failed: (value → 1) == 2", IsTest: true, Test: "errorHandling()"}},
+
+		// xcbeautify / xcpretty
+		{"pretty error", "❌ /src/App/ContentView.swift:12:9: cannot find 'foo' in scope",
+			Diagnostic{Severity: DiagnosticError, File: "/src/App/ContentView.swift", Line: 12, Col: 9, Message: "cannot find 'foo' in scope", IsPretty: true}},
+		{"pretty warning with selector", "⚠️  /src/App/ContentView.swift:20:13: variable 'x' was never used",
+			Diagnostic{Severity: DiagnosticWarning, File: "/src/App/ContentView.swift", Line: 20, Col: 13, Message: "variable 'x' was never used", IsPretty: true}},
+		{"pretty unlocated error", "❌ error: Signing for \"App\" requires a development team.",
+			Diagnostic{Severity: DiagnosticError, Message: "Signing for \"App\" requires a development team.", IsPretty: true}},
+		{"pretty ld error", "❌ ld: symbol(s) not found for architecture arm64",
+			Diagnostic{Severity: DiagnosticError, Message: "ld: symbol(s) not found for architecture arm64", IsPretty: true}},
+		{"pretty test failure", "    ✖ testSSO, XCTAssertEqual failed: (\"1\") is not equal to (\"2\")",
+			Diagnostic{Severity: DiagnosticError, Message: "testSSO, XCTAssertEqual failed: (\"1\") is not equal to (\"2\")", IsPretty: true, IsTest: true, Test: "testSSO"}},
+		{"pretty test passed", "    ✔ testSSO (0.001 seconds)", Diagnostic{Message: "✔ testSSO (0.001 seconds)"}},
+
+		// Pathological
+		{"path containing warning:", "/tmp/warning: dir/App.swift:3:1: error: boom",
+			Diagnostic{Severity: DiagnosticError, File: "/tmp/warning: dir/App.swift", Line: 3, Col: 1, Message: "boom"}},
+		{"path containing error:", "/tmp/error: handling/App.swift:3:1: warning: unused",
+			Diagnostic{Severity: DiagnosticWarning, File: "/tmp/error: handling/App.swift", Line: 3, Col: 1, Message: "unused"}},
+		{"error: mid-line", "Running script 'Lint' with args error: none",
+			Diagnostic{Message: "Running script 'Lint' with args error: none"}},
+		{"test named testErrorHandling", "    testErrorHandling, 0.12s", Diagnostic{Message: "testErrorHandling, 0.12s"}},
+		{"warning in a file name", "CompileSwift normal arm64 /src/warning:Parser.swift", Diagnostic{Message: "CompileSwift normal arm64 /src/warning:Parser.swift"}},
+		{"marker mid-line", "Build log ❌ copied", Diagnostic{Message: "Build log ❌ copied"}},
+		{"empty", "   ", Diagnostic{}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ClassifyDiagnostic(tc.line); got != tc.want {
+				t.Fatalf("ClassifyDiagnostic(%q)\n got  %+v\n want %+v", tc.line, got, tc.want)
+			}
+		})
+	}
+}
@@ -2,69 +2,404 @@ package core
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
 )
 
+// DoctorStatus is the outcome of a single doctor check.
+type DoctorStatus string
+
+const (
+	DoctorPass DoctorStatus = "pass"
+	DoctorWarn DoctorStatus = "warn"
+	DoctorFail DoctorStatus = "fail"
+)
+
+// Doctor check identifiers (stable; used by the TUI to re-run or fix a check).
+const (
+	CheckXcodeSelect   = "xcode-select"
+	CheckXcodeLicense  = "xcode-license"
+	CheckXcodeVersion  = "xcode-version"
+	CheckSimctl        = "simctl"
+	CheckDevicectl     = "devicectl"
+	CheckXcresulttool  = "xcresulttool"
+	CheckLogFormatter  = "log-formatter"
+	CheckProjectConfig = "project-config"
+	CheckScheme        = "scheme"
+	CheckDestination   = "destination"
+	CheckDerivedData   = "derived-data"
+	CheckResultsSize   = "results-size"
+)
+
+// resultsSizeWarnBytes is the result bundle directory size that triggers a warning.
+const resultsSizeWarnBytes = 5 << 30
+
 type DoctorCheck struct {
-	Name   string `json:"name"`
-	OK     bool   `json:"ok"`
-	Detail string `json:"detail,omitempty"`
-	Hint   string `json:"hint,omitempty"`
+	ID      string       `json:"id"`
+	Name    string       `json:"name"`
+	Status  DoctorStatus `json:"status"`
+	OK      bool         `json:"ok"`
+	Detail  string       `json:"detail,omitempty"`
+	FixHint string       `json:"fixHint,omitempty"`
+	Fixable bool         `json:"fixable,omitempty"`
 }
 
 type DoctorReport struct {
 	Checks []DoctorCheck `json:"checks"`
 }
 
-func Doctor(ctx context.Context, projectRoot string, emit Emitter) (DoctorReport, error) {
+// Failed reports whether any check failed (warnings don't count).
+func (r DoctorReport) Failed() bool {
+	for _, c := range r.Checks {
+		if c.Status == DoctorFail {
+			return true
+		}
+	}
+	return false
+}
+
+type doctorSpec struct {
+	id   string
+	name string
+	run  func(ctx context.Context, projectRoot string, cfg Config) DoctorCheck
+	fix  func(ctx context.Context, projectRoot string, cfg Config) error
+}
+
+// doctorSpecs is the shared check list used by both the CLI and the TUI.
+func doctorSpecs() []doctorSpec {
+	return []doctorSpec{
+		{id: CheckXcodeSelect, name: "xcode-select path", run: checkXcodeSelect},
+		{id: CheckXcodeVersion, name: "required Xcode version", run: checkXcodeVersion},
+		{id: CheckXcodeLicense, name: "Xcode license accepted", run: checkXcodeLicense},
+		{id: CheckSimctl, name: "simctl reachable", run: checkSimctl},
+		{id: CheckDevicectl, name: "devicectl available", run: checkDevicectl},
+		{id: CheckXcresulttool, name: "xcresulttool available", run: checkXcresulttool},
+		{id: CheckLogFormatter, name: "log formatter", run: checkLogFormatter},
+		{id: CheckProjectConfig, name: "project config", run: checkProjectConfig},
+		{id: CheckScheme, name: "configured scheme", run: checkScheme},
+		{id: CheckDestination, name: "configured destination", run: checkDestination, fix: fixDestination},
+		{id: CheckDerivedData, name: "derived data writable", run: checkDerivedData, fix: fixBuildDirs},
+		{id: CheckResultsSize, name: "result bundles size", run: checkResultsSize},
+	}
+}
+
+func findDoctorSpec(id string) (doctorSpec, bool) {
+	for _, s := range doctorSpecs() {
+		if s.id == id {
+			return s, true
+		}
+	}
+	return doctorSpec{}, false
+}
+
+// Doctor runs the full check list against the environment and project config.
+func Doctor(ctx context.Context, projectRoot string, cfg Config, emit Emitter) (DoctorReport, error) {
 	rep := DoctorReport{Checks: []DoctorCheck{}}
+	for _, s := range doctorSpecs() {
+		emitMaybe(emit, Status("doctor", s.name, map[string]any{"id": s.id}))
+		c := runDoctorSpec(ctx, projectRoot, cfg, s)
+		if c.Status != DoctorPass {
+			emitMaybe(emit, Warn("doctor", fmt.Sprintf("%s: %s", c.Name, c.Detail)))
+		}
+		rep.Checks = append(rep.Checks, c)
+	}
+	return rep, nil
+}
+
+// RunDoctorCheck runs a single check by ID.
+func RunDoctorCheck(ctx context.Context, projectRoot string, cfg Config, id string) (DoctorCheck, error) {
+	s, ok := findDoctorSpec(id)
+	if !ok {
+		return DoctorCheck{}, fmt.Errorf("unknown doctor check %q", id)
+	}
+	return runDoctorSpec(ctx, projectRoot, cfg, s), nil
+}
+
+// FixDoctorCheck applies the automatic fix for a check and re-runs it.
+func FixDoctorCheck(ctx context.Context, projectRoot string, cfg Config, id string, emit Emitter) (DoctorCheck, error) {
+	s, ok := findDoctorSpec(id)
+	if !ok {
+		return DoctorCheck{}, fmt.Errorf("unknown doctor check %q", id)
+	}
+	if s.fix == nil {
+		return runDoctorSpec(ctx, projectRoot, cfg, s), fmt.Errorf("%s has no automatic fix", s.name)
+	}
+	emitMaybe(emit, Status("doctor", "Fixing "+s.name, map[string]any{"id": s.id}))
+	if err := s.fix(ctx, projectRoot, cfg); err != nil {
+		return runDoctorSpec(ctx, projectRoot, cfg, s), err
+	}
+	return runDoctorSpec(ctx, projectRoot, cfg, s), nil
+}
+
+func runDoctorSpec(ctx context.Context, projectRoot string, cfg Config, s doctorSpec) DoctorCheck {
+	c := s.run(ctx, projectRoot, cfg)
+	c.ID = s.id
+	c.Name = s.name
+	if c.Status == "" {
+		c.Status = DoctorPass
+	}
+	c.OK = c.Status != DoctorFail
+	if c.Status == DoctorPass {
+		c.FixHint = ""
+		c.Fixable = false
+	} else if s.fix == nil {
+		c.Fixable = false
+	}
+	return c
+}
+
+func doctorPass(detail string) DoctorCheck {
+	return DoctorCheck{Status: DoctorPass, Detail: detail}
+}
+
+func doctorIssue(status DoctorStatus, detail, hint string) DoctorCheck {
+	return DoctorCheck{Status: status, Detail: detail, FixHint: hint}
+}
+
+func captureCmd(ctx context.Context, path string, args ...string) (string, error) {
+	var b strings.Builder
+	_, err := RunStreaming(ctx, CmdSpec{
+		Path:       path,
+		Args:       args,
+		StdoutLine: func(s string) { b.WriteString(s + "\n") },
+		StderrLine: func(s string) { b.WriteString(s + "\n") },
+	})
+	return strings.TrimSpace(b.String()), err
+}
+
+// =============================================================================
+// Toolchain checks
+// =============================================================================
+
+func checkXcodeSelect(ctx context.Context, _ string, _ Config) DoctorCheck {
+	hint := "Run `sudo xcode-select -s /Applications/Xcode.app/Contents/Developer`."
+	x, err := XcodeInfo(ctx)
+	if x.DeveloperDir == "" {
+		if err == nil {
+			err = errors.New("xcode-select returned an empty path")
+		}
+		return doctorIssue(DoctorFail, err.Error(), hint)
+	}
+	if _, serr := os.Stat(x.DeveloperDir); serr != nil {
+		return doctorIssue(DoctorFail, fmt.Sprintf("%s does not exist", x.DeveloperDir), hint)
+	}
+	if x.CommandLineTools {
+		return doctorIssue(DoctorFail, x.DeveloperDir+" (Command Line Tools only; xcodebuild needs full Xcode)", hint)
+	}
+	if label := x.Label(); label != "" {
+		return doctorPass(x.DeveloperDir + " · " + label)
+	}
+	return doctorPass(x.DeveloperDir)
+}
+
+func checkXcodeVersion(ctx context.Context, _ string, cfg Config) DoctorCheck {
+	if strings.TrimSpace(cfg.RequiredXcode) == "" {
+		return doctorPass("no requiredXcode set")
+	}
+	x, err := XcodeInfo(ctx)
+	if err != nil {
+		return doctorIssue(DoctorWarn, err.Error(), "Could not determine the active Xcode version.")
+	}
+	if err := CheckRequiredXcode(x, cfg.RequiredXcode); err != nil {
+		return doctorIssue(DoctorWarn, err.Error(), "Select a matching Xcode with `sudo xcode-select -s` or update requiredXcode.")
+	}
+	return doctorPass(x.Label() + " satisfies " + cfg.RequiredXcode)
+}
+
+func checkXcodeLicense(ctx context.Context, _ string, _ Config) DoctorCheck {
+	if _, err := captureCmd(ctx, "xcrun", "xcodebuild", "-license", "check"); err != nil {
+		return doctorIssue(DoctorFail, "license not accepted or xcodebuild unavailable", "Run `sudo xcodebuild -license accept`.")
+	}
+	return doctorPass("accepted")
+}
 
-	check := func(name string, fn func() (string, error), hint string) {
-		emitMaybe(emit, Status("doctor", name, nil))
-		out, err := fn()
+func checkSimctl(ctx context.Context, _ string, _ Config) DoctorCheck {
+	if _, err := captureCmd(ctx, "xcrun", "simctl", "list", "--json", "runtimes"); err != nil {
+		return doctorIssue(DoctorFail, err.Error(), "Install Xcode and ensure simulators are available.")
+	}
+	return doctorPass("ok")
+}
+
+func checkDevicectl(ctx context.Context, _ string, _ Config) DoctorCheck {
+	if _, err := captureCmd(ctx, "xcrun", "devicectl", "help"); err != nil {
+		return doctorIssue(DoctorWarn, err.Error(), "Xcode 15+ is required for devicectl (physical devices).")
+	}
+	return doctorPass("ok")
+}
+
+func checkXcresulttool(ctx context.Context, _ string, _ Config) DoctorCheck {
+	if _, err := captureCmd(ctx, "xcrun", "xcresulttool", "help"); err != nil {
+		return doctorIssue(DoctorWarn, err.Error(), "xcresulttool is part of Xcode; test summaries need it.")
+	}
+	return doctorPass("ok")
+}
+
+func checkLogFormatter(_ context.Context, _ string, cfg Config) DoctorCheck {
+	detail, found := LogFormatterStatus(cfg)
+	if !found {
+		return doctorIssue(DoctorWarn, detail, "Install it (e.g. `brew install xcbeautify`) or set xcodebuild.logFormat to auto or raw.")
+	}
+	return doctorPass(detail)
+}
+
+// =============================================================================
+// Project checks
+// =============================================================================
+
+func checkProjectConfig(_ context.Context, projectRoot string, _ Config) DoctorCheck {
+	path := filepath.Join(projectRoot, ".xcbolt", "config.json")
+	if _, err := os.Stat(path); err != nil {
+		return doctorIssue(DoctorWarn, "missing "+path, "Run `xcbolt init` to create .xcbolt/config.json.")
+	}
+	return doctorPass(path)
+}
+
+func checkScheme(_ context.Context, projectRoot string, cfg Config) DoctorCheck {
+	_, projects, err := scanProjectEntries(projectRoot)
+	if err != nil {
+		return doctorIssue(DoctorFail, err.Error(), "Run xcbolt from the project root.")
+	}
+	schemes := listSchemesFromFS(projectRoot, cfg, projects)
+	if cfg.Scheme == "" {
+		if len(schemes) == 0 {
+			return doctorIssue(DoctorWarn, "no scheme configured or found", "Run `xcbolt init` or pass --scheme.")
+		}
+		return doctorIssue(DoctorWarn, fmt.Sprintf("no scheme configured (will use %s)", schemes[0]), "Run `xcbolt init` to pin a scheme.")
+	}
+	if len(schemes) > 0 && !stringInSlice(cfg.Scheme, schemes) {
+		return doctorIssue(DoctorFail,
+			fmt.Sprintf("scheme %q not found (available: %s)", cfg.Scheme, strings.Join(schemes, ", ")),
+			"Pick a scheme with `xcbolt init` or mark it as Shared in Xcode.")
+	}
+	return doctorPass(cfg.Scheme)
+}
+
+func checkDestination(ctx context.Context, _ string, cfg Config) DoctorCheck {
+	candidates, err := ListDestinationCandidates(ctx, nil, cfg.Xcodebuild.PreserveLocale)
+	if err != nil {
+		return doctorIssue(DoctorFail, err.Error(), "Check that simctl works and Xcode is selected.")
+	}
+	return destinationCheckFromCandidates(cfg, candidates)
+}
+
+func destinationCheckFromCandidates(cfg Config, candidates []DestinationCandidate) DoctorCheck {
+	resolved, err := resolveDestination(cfg, candidates)
+	if err != nil {
+		return doctorIssue(DoctorFail, err.Error(), "Pick a destination with `xcbolt init` or pass --target.")
+	}
+	dst := resolved.Destination
+	label := dst.Name
+	if dst.OS != "" {
+		label += " (" + dst.OS + ")"
+	}
+	if dst.TargetType != TargetSimulator {
+		return doctorPass(label)
+	}
+	for _, c := range candidates {
+		if c.ID == dst.ID && c.State != "" && c.State != "Booted" {
+			c := doctorIssue(DoctorWarn, label+" is "+strings.ToLower(c.State), "Boot the simulator (enter in the TUI) or `xcbolt simulator boot`.")
+			c.Fixable = true
+			return c
+		}
+	}
+	return doctorPass(label)
+}
+
+func fixDestination(ctx context.Context, _ string, cfg Config) error {
+	candidates, err := ListDestinationCandidates(ctx, nil, cfg.Xcodebuild.PreserveLocale)
+	if err != nil {
+		return err
+	}
+	resolved, err := resolveDestination(cfg, candidates)
+	if err != nil {
+		return err
+	}
+	if resolved.Destination.TargetType != TargetSimulator || resolved.Destination.ID == "" {
+		return errors.New("configured destination is not a simulator")
+	}
+	return BootSimulator(ctx, cfg, "doctor", resolved.Destination.ID, nil)
+}
+
+func checkDerivedData(_ context.Context, _ string, cfg Config) DoctorCheck {
+	dir := DerivedDataDir(cfg)
+	if dir == "" {
+		return doctorPass("xcodebuild default")
+	}
+	info, err := os.Stat(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		c := doctorIssue(DoctorWarn, dir+" does not exist yet", "Create it (enter in the TUI) or run a build.")
+		c.Fixable = true
+		return c
+	}
+	if err != nil {
+		return doctorIssue(DoctorFail, err.Error(), "Check permissions on "+dir+".")
+	}
+	if !info.IsDir() {
+		return doctorIssue(DoctorFail, dir+" is not a directory", "Remove it or change derivedDataPath.")
+	}
+	f, err := os.CreateTemp(dir, ".xcbolt-doctor-*")
+	if err != nil {
+		return doctorIssue(DoctorFail, "not writable: "+err.Error(), "Check permissions on "+dir+".")
+	}
+	name := f.Name()
+	_ = f.Close()
+	_ = os.Remove(name)
+	return doctorPass(dir)
+}
+
+func fixBuildDirs(_ context.Context, _ string, cfg Config) error {
+	return EnsureBuildDirs(cfg)
+}
+
+func checkResultsSize(_ context.Context, _ string, cfg Config) DoctorCheck {
+	dir := cfg.ResultBundlesPath
+	if dir == "" {
+		return doctorPass("not configured")
+	}
+	size, err := dirSize(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return doctorPass("empty")
+	}
+	if err != nil {
+		return doctorIssue(DoctorWarn, err.Error(), "")
+	}
+	detail := FormatBytes(size)
+	if size >= resultsSizeWarnBytes {
+		return doctorIssue(DoctorWarn, detail+" of result bundles", "Run `xcbolt clean --results` to reclaim space.")
+	}
+	return doctorPass(detail)
+}
+
+func dirSize(root string) (int64, error) {
+	var total int64
+	err := filepath.WalkDir(root, func(_ string, d fs.DirEntry, err error) error {
 		if err != nil {
-			rep.Checks = append(rep.Checks, DoctorCheck{Name: name, OK: false, Detail: err.Error(), Hint: hint})
-			emitMaybe(emit, Warn("doctor", fmt.Sprintf("%s: %v", name, err)))
-			return
+			return err
 		}
-		rep.Checks = append(rep.Checks, DoctorCheck{Name: name, OK: true, Detail: out})
-	}
-
-	check("xcodebuild available", func() (string, error) {
-		var b strings.Builder
-		_, err := RunStreaming(ctx, CmdSpec{
-			Path:       "xcrun",
-			Args:       []string{"xcodebuild", "-version"},
-			StdoutLine: func(s string) { b.WriteString(s + "\n") },
-		})
-		return strings.TrimSpace(b.String()), err
-	}, "Install Xcode and ensure xcode-select points at it.")
-
-	check("simctl available", func() (string, error) {
-		_, err := RunStreaming(ctx, CmdSpec{Path: "xcrun", Args: []string{"simctl", "list", "--json"}})
-		return "ok", err
-	}, "Install Xcode and ensure simulators are available.")
-
-	check("devicectl available", func() (string, error) {
-		_, err := RunStreaming(ctx, CmdSpec{Path: "xcrun", Args: []string{"devicectl", "help"}})
-		return "ok", err
-	}, "Xcode 15+ is required for devicectl.")
-
-	check("xcresulttool available", func() (string, error) {
-		_, err := RunStreaming(ctx, CmdSpec{Path: "xcrun", Args: []string{"xcresulttool", "help"}})
-		return "ok", err
-	}, "xcresulttool is part of Xcode.")
-
-	check("project config", func() (string, error) {
-		path := filepath.Join(projectRoot, ".xcbolt", "config.json")
-		if _, err := os.Stat(path); err != nil {
-			return "", err
+		if d.Type().IsRegular() {
+			if info, err := d.Info(); err == nil {
+				total += info.Size()
+			}
 		}
-		return path, nil
-	}, "Run `xcbolt init` to create .xcbolt/config.json.")
+		return nil
+	})
+	return total, err
+}
 
-	return rep, nil
+// FormatBytes renders n in binary units ("1.5 GB").
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
 }
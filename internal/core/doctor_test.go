@@ -0,0 +1,103 @@
+package core
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDoctorDerivedDataMissingIsFixable(t *testing.T) {
+	root := t.TempDir()
+	cfg := Config{
+		DerivedDataPath:   filepath.Join(root, ".xcbolt", "DerivedData"),
+		ResultBundlesPath: filepath.Join(root, ".xcbolt", "Results"),
+	}
+
+	c, err := RunDoctorCheck(context.Background(), root, cfg, CheckDerivedData)
+	if err != nil {
+		t.Fatalf("RunDoctorCheck: %v", err)
+	}
+	if c.Status != DoctorWarn || !c.Fixable {
+		t.Fatalf("expected fixable warning, got %+v", c)
+	}
+
+	c, err = FixDoctorCheck(context.Background(), root, cfg, CheckDerivedData, nil)
+	if err != nil {
+		t.Fatalf("FixDoctorCheck: %v", err)
+	}
+	if c.Status != DoctorPass || c.Fixable || c.FixHint != "" {
+		t.Fatalf("expected pass after fix, got %+v", c)
+	}
+	if _, err := os.Stat(cfg.ResultBundlesPath); err != nil {
+		t.Fatalf("results dir not created: %v", err)
+	}
+}
+
+func TestDoctorFixWithoutFixer(t *testing.T) {
+	root := t.TempDir()
+	if _, err := FixDoctorCheck(context.Background(), root, Config{}, CheckProjectConfig, nil); err == nil {
+		t.Fatalf("expected error for check without a fix")
+	}
+	if _, err := RunDoctorCheck(context.Background(), root, Config{}, "nope"); err == nil {
+		t.Fatalf("expected error for unknown check")
+	}
+}
+
+func TestDoctorResultsSize(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "Results", "a.xcresult")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "Info.plist"), make([]byte, 2048), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	c := checkResultsSize(context.Background(), root, Config{ResultBundlesPath: filepath.Join(root, "Results")})
+	if c.Status != DoctorPass || c.Detail != "2.0 KB" {
+		t.Fatalf("unexpected results check: %+v", c)
+	}
+}
+
+func TestDoctorSchemeMissing(t *testing.T) {
+	root := t.TempDir()
+	schemes := filepath.Join(root, "App.xcodeproj", "xcshareddata", "xcschemes")
+	if err := os.MkdirAll(schemes, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(schemes, "App.xcscheme"), []byte("<Scheme/>"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	c := checkScheme(context.Background(), root, Config{Project: "App.xcodeproj", Scheme: "Other"})
+	if c.Status != DoctorFail {
+		t.Fatalf("expected fail for unknown scheme, got %+v", c)
+	}
+	c = checkScheme(context.Background(), root, Config{Project: "App.xcodeproj", Scheme: "App"})
+	if c.Status != DoctorPass {
+		t.Fatalf("expected pass, got %+v", c)
+	}
+}
+
+func TestDoctorDestinationShutdownSimulatorIsFixable(t *testing.T) {
+	cfg := Config{Destination: Destination{ID: "SIM-1", PlatformFamily: PlatformIOS, TargetType: TargetSimulator}}
+	candidates := []DestinationCandidate{{
+		ID:             "SIM-1",
+		Name:           "iPhone 16",
+		PlatformFamily: PlatformIOS,
+		TargetType:     TargetSimulator,
+		State:          "Shutdown",
+		Available:      true,
+	}}
+
+	c := destinationCheckFromCandidates(cfg, candidates)
+	if c.Status != DoctorWarn || !c.Fixable {
+		t.Fatalf("expected fixable warning, got %+v", c)
+	}
+
+	candidates[0].State = "Booted"
+	if c := destinationCheckFromCandidates(cfg, candidates); c.Status != DoctorPass {
+		t.Fatalf("expected pass for booted simulator, got %+v", c)
+	}
+}
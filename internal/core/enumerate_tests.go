@@ -31,9 +31,10 @@ func XcodebuildEnumerateTests(ctx context.Context, projectRoot string, cfg Confi
 
 	var out strings.Builder
 	_, err := RunStreaming(ctx, CmdSpec{
-		Path: "xcrun",
-		Args: args,
-		Dir:  projectRoot,
+		Path:           "xcrun",
+		Args:           args,
+		PreserveLocale: cfg.Xcodebuild.PreserveLocale,
+		Dir:            projectRoot,
 		StdoutLine: func(s string) {
 			out.WriteString(s)
 			out.WriteString("\n")
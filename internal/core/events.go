@@ -30,6 +30,19 @@ type Event struct {
 
 func NowTS() string { return time.Now().UTC().Format(time.RFC3339Nano) }
 
+// Time returns when the event was created. ok is false when TS is missing or
+// unparseable (events from older emitters).
+func (ev Event) Time() (t time.Time, ok bool) {
+	if ev.TS == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339Nano, ev.TS)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
 type Emitter interface {
 	Emit(ev Event)
 }
@@ -94,6 +107,17 @@ func Status(cmd, msg string, data any) Event {
 	return Event{V: EventSchemaVersion, TS: NowTS(), Cmd: cmd, Type: "status", Level: "info", Msg: msg, Data: data}
 }
 
+// XcodebuildCommandCode marks the event carrying an operation's xcodebuild
+// invocation.
+const XcodebuildCommandCode = "XCODEBUILD_COMMAND"
+
+// XcodebuildCommand reports the command line about to run (Data.command).
+func XcodebuildCommand(cmd, command string) Event {
+	ev := Status(cmd, "$ "+command, map[string]any{"command": command})
+	ev.Code = XcodebuildCommandCode
+	return ev
+}
+
 func Log(cmd, msg string) Event {
 	return Event{V: EventSchemaVersion, TS: NowTS(), Cmd: cmd, Type: "log", Level: "info", Msg: msg}
 }
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"testing"
+	"time"
 )
 
 func TestEventJSONUsesV2FieldNames(t *testing.T) {
@@ -43,3 +44,20 @@ func TestNDJSONEmitterUsesConfiguredVersion(t *testing.T) {
 		t.Fatalf("version = %v, want %d", got["version"], EventSchemaVersion)
 	}
 }
+
+func TestEventTimeParsesEmitTimestamp(t *testing.T) {
+	ev := Log("build", "line")
+	ts, ok := ev.Time()
+	if !ok {
+		t.Fatalf("expected constructor timestamp to parse: %q", ev.TS)
+	}
+	if ts.Format(time.RFC3339Nano) != ev.TS {
+		t.Fatalf("round trip mismatch: %s vs %s", ts.Format(time.RFC3339Nano), ev.TS)
+	}
+	if _, ok := (Event{Msg: "legacy"}).Time(); ok {
+		t.Fatalf("expected missing timestamp to report !ok")
+	}
+	if _, ok := (Event{TS: "yesterday"}).Time(); ok {
+		t.Fatalf("expected unparseable timestamp to report !ok")
+	}
+}
@@ -0,0 +1,169 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"syscall"
+)
+
+// XcodebuildFailure says how xcodebuild failed, from its exit status.
+type XcodebuildFailure string
+
+const (
+	// FailureBuild is a build or test failure (exit 65, or any other exit
+	// code xcodebuild chose)
+	FailureBuild XcodebuildFailure = "failed"
+	// FailureCrash is an internal xcodebuild error (exit 70, EX_SOFTWARE):
+	// not the project's fault, and often gone on retry
+	FailureCrash XcodebuildFailure = "crashed"
+	// FailureSignal is a termination by a signal xcbolt didn't send, e.g.
+	// SIGKILL from the out-of-memory killer
+	FailureSignal XcodebuildFailure = "signal"
+)
+
+// xcodebuildExitSoftware is xcodebuild's exit status for an internal error
+// (EX_SOFTWARE in sysexits.h); failed builds and tests exit 65.
+const xcodebuildExitSoftware = 70
+
+// XcodebuildExitError is returned when xcodebuild failed on its own, not
+// because the operation was canceled. Err is the underlying error.
+type XcodebuildExitError struct {
+	Failure  XcodebuildFailure
+	ExitCode int
+	// Signal is set for FailureSignal, e.g. "SIGKILL".
+	Signal string
+	Err    error
+}
+
+func (e *XcodebuildExitError) Error() string { return e.Err.Error() }
+
+func (e *XcodebuildExitError) Unwrap() error { return e.Err }
+
+// ClassifyXcodebuildExit returns how a finished xcodebuild run failed, or ""
+// when it succeeded.
+func ClassifyXcodebuildExit(res CmdResult) XcodebuildFailure {
+	switch {
+	case res.Signal != "":
+		return FailureSignal
+	case res.ExitCode == 0:
+		return ""
+	case res.ExitCode == xcodebuildExitSoftware:
+		return FailureCrash
+	}
+	return FailureBuild
+}
+
+// xcodebuildExitError wraps err, a failure of the xcodebuild run res, with
+// its classification. Cancellations are returned as they are.
+func xcodebuildExitError(res CmdResult, err error) error {
+	if err == nil || CancelStatus(err) != "" {
+		return err
+	}
+	failure := ClassifyXcodebuildExit(res)
+	if failure == "" {
+		failure = FailureBuild
+	}
+	return &XcodebuildExitError{Failure: failure, ExitCode: res.ExitCode, Signal: res.Signal, Err: err}
+}
+
+// xcodebuildFailureObject describes a crashed or killed xcodebuild for
+// what (e.g. "Build"), or returns fallback for an ordinary failure.
+func xcodebuildFailureObject(what string, err error, fallback ErrorObject) ErrorObject {
+	var xe *XcodebuildExitError
+	if !errors.As(err, &xe) {
+		return fallback
+	}
+	switch xe.Failure {
+	case FailureCrash:
+		return ErrorObject{
+			Code:       "XCODEBUILD_INTERNAL_ERROR",
+			Message:    "xcodebuild crashed (exit 70)",
+			Detail:     err.Error(),
+			Suggestion: "This is an internal xcodebuild error, not a problem in the project: retry, and if it keeps happening run `sudo xcodebuild -runFirstLaunch`.",
+		}
+	case FailureSignal:
+		eo := ErrorObject{
+			Code:       "XCODEBUILD_TERMINATED",
+			Message:    fmt.Sprintf("%s terminated (%s)", what, xe.Signal),
+			Detail:     err.Error(),
+			Suggestion: "Something outside xcbolt stopped xcodebuild; retry.",
+		}
+		if xe.Signal == "SIGKILL" {
+			eo.Message = fmt.Sprintf("%s terminated (SIGKILL — possible out-of-memory)", what)
+			eo.Suggestion = "SIGKILL usually means macOS ran out of memory: close memory-hungry apps, lower parallelism (-jobs), and retry."
+		}
+		return eo
+	}
+	return fallback
+}
+
+// signalNames are the signals xcodebuild is likely to die of
+var signalNames = map[syscall.Signal]string{
+	syscall.SIGHUP:  "SIGHUP",
+	syscall.SIGINT:  "SIGINT",
+	syscall.SIGQUIT: "SIGQUIT",
+	syscall.SIGILL:  "SIGILL",
+	syscall.SIGTRAP: "SIGTRAP",
+	syscall.SIGABRT: "SIGABRT",
+	syscall.SIGBUS:  "SIGBUS",
+	syscall.SIGFPE:  "SIGFPE",
+	syscall.SIGKILL: "SIGKILL",
+	syscall.SIGSEGV: "SIGSEGV",
+	syscall.SIGPIPE: "SIGPIPE",
+	syscall.SIGTERM: "SIGTERM",
+}
+
+// SignalName returns the conventional name of sig, e.g. "SIGKILL".
+func SignalName(sig syscall.Signal) string {
+	if name, ok := signalNames[sig]; ok {
+		return name
+	}
+	return fmt.Sprintf("signal %d", int(sig))
+}
+
+// memoryPressureMarkers are log fragments of a machine running out of
+// memory: jetsam, the compiler's allocation failures, and "Killed: 9" from a
+// driver whose child was killed.
+var memoryPressureMarkers = []string{
+	"memory pressure",
+	"memorystatus: kill",
+	"memorystatus_kill",
+	"jetsam",
+	"out of memory",
+	"cannot allocate memory",
+	"mach_vm_map",
+	"killed: 9",
+	"low on memory",
+}
+
+// MemoryPressureLine reports whether a log line hints that memory ran out.
+func MemoryPressureLine(line string) bool {
+	lower := strings.ToLower(line)
+	for _, m := range memoryPressureMarkers {
+		if strings.Contains(lower, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// xcodebuildFailure returns the classification of an XcodebuildExitError
+func xcodebuildFailure(err error) (XcodebuildFailure, string) {
+	var xe *XcodebuildExitError
+	if errors.As(err, &xe) {
+		return xe.Failure, xe.Signal
+	}
+	return "", ""
+}
+
+// withFailure adds the failure classification to a result payload.
+func withFailure(data map[string]any, failure XcodebuildFailure, signal string) map[string]any {
+	if failure != "" {
+		data["failure"] = string(failure)
+	}
+	if signal != "" {
+		data["signal"] = signal
+	}
+	return data
+}
@@ -0,0 +1,120 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestClassifyXcodebuildExit(t *testing.T) {
+	tests := []struct {
+		res  CmdResult
+		want XcodebuildFailure
+	}{
+		{CmdResult{ExitCode: 0}, ""},
+		{CmdResult{ExitCode: 65}, FailureBuild},
+		{CmdResult{ExitCode: 1}, FailureBuild},
+		{CmdResult{ExitCode: 70}, FailureCrash},
+		{CmdResult{ExitCode: -1, Signal: "SIGKILL"}, FailureSignal},
+	}
+	for _, tc := range tests {
+		if got := ClassifyXcodebuildExit(tc.res); got != tc.want {
+			t.Errorf("ClassifyXcodebuildExit(%+v) = %q, want %q", tc.res, got, tc.want)
+		}
+	}
+}
+
+// buildWithFakeXcodebuild runs Build with an xcrun running script
+func buildWithFakeXcodebuild(t *testing.T, script string) (BuildResult, *recordingEmitter, error) {
+	t.Helper()
+	bin := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bin, "xcrun"), []byte("#!/bin/sh\n"+script+"\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	cfg := Config{
+		Project:           "App.xcodeproj",
+		Scheme:            "App",
+		Configuration:     "Debug",
+		DerivedDataPath:   t.TempDir(),
+		ResultBundlesPath: t.TempDir(),
+		Destination:       Destination{Kind: DestMacOS, PlatformFamily: PlatformMacOS, TargetType: TargetLocal},
+	}
+	rec := &recordingEmitter{}
+	res, _, err := Build(context.Background(), t.TempDir(), cfg, rec)
+	return res, rec, err
+}
+
+func errorCodes(events []Event) string {
+	var codes []string
+	for _, ev := range events {
+		if ev.Err != nil {
+			codes = append(codes, ev.Err.Code)
+		}
+	}
+	return strings.Join(codes, ",")
+}
+
+func TestBuildReportsHowXcodebuildFailed(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  string
+		failure XcodebuildFailure
+		signal  string
+		code    string
+	}{
+		{"build failure", "echo 'error: no such module' >&2\nexit 65", FailureBuild, "", "XCODEBUILD_FAILED"},
+		{"internal error", "echo 'xcodebuild: error: internal error' >&2\nexit 70", FailureCrash, "", "XCODEBUILD_INTERNAL_ERROR"},
+		{"killed", "kill -9 $$", FailureSignal, "SIGKILL", "XCODEBUILD_TERMINATED"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res, rec, err := buildWithFakeXcodebuild(t, tc.script)
+			var xe *XcodebuildExitError
+			if !errors.As(err, &xe) || xe.Failure != tc.failure || xe.Signal != tc.signal {
+				t.Fatalf("err = %#v", err)
+			}
+			if res.Failure != tc.failure || res.Signal != tc.signal {
+				t.Fatalf("result failure = %q signal = %q", res.Failure, res.Signal)
+			}
+			if got := errorCodes(rec.events); got != tc.code {
+				t.Fatalf("error codes = %s, want %s", got, tc.code)
+			}
+		})
+	}
+}
+
+func TestKilledBuildSuggestsMemory(t *testing.T) {
+	err := &XcodebuildExitError{Failure: FailureSignal, Signal: "SIGKILL", Err: errors.New("signal: killed")}
+	eo := xcodebuildFailureObject("Build", err, ErrorObject{})
+	if eo.Message != "Build terminated (SIGKILL — possible out-of-memory)" || !strings.Contains(eo.Suggestion, "memory") {
+		t.Fatalf("error object = %+v", eo)
+	}
+	eo = xcodebuildFailureObject("Build", &XcodebuildExitError{Failure: FailureCrash, ExitCode: 70, Err: err}, ErrorObject{})
+	if !strings.Contains(eo.Suggestion, "-runFirstLaunch") {
+		t.Fatalf("crash suggestion = %q", eo.Suggestion)
+	}
+}
+
+func TestMemoryPressureLine(t *testing.T) {
+	for _, line := range []string{
+		"clang: error: unable to execute command: Killed: 9",
+		"LLVM ERROR: out of memory",
+		"kernel: memorystatus: killing_specific_process pid 123 [swift-frontend] (per-process-limit)",
+	} {
+		if !MemoryPressureLine(line) {
+			t.Errorf("%q not recognized", line)
+		}
+	}
+	for _, line := range []string{
+		"CompileSwift normal arm64 /src/MemoryCache.swift",
+		"CompileSwift normal arm64 /src/MemoryStatusView.swift",
+	} {
+		if MemoryPressureLine(line) {
+			t.Errorf("%q matched", line)
+		}
+	}
+}
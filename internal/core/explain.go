@@ -0,0 +1,132 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// DefaultExplainTimeout is used when issues.explainTimeout is unset.
+const DefaultExplainTimeout = 30 * time.Second
+
+// ExplainDeadline returns how long issues.explainCommand may run.
+func (c IssuesConfig) ExplainDeadline() time.Duration {
+	d, err := time.ParseDuration(strings.TrimSpace(c.ExplainTimeout))
+	if err != nil || d <= 0 {
+		return DefaultExplainTimeout
+	}
+	return d
+}
+
+// ExplainRequest is the issue handed to issues.explainCommand.
+type ExplainRequest struct {
+	Type    string // "error", "warning", ...
+	Message string // Full, possibly multi-line message
+	File    string
+	Line    int
+	Column  int
+	// Context holds the log lines around the issue
+	Context []string
+}
+
+// Stdin is what the explain command reads: the issue, then the log lines
+// around it.
+func (r ExplainRequest) Stdin() string {
+	var b strings.Builder
+	loc := r.File
+	if loc != "" && r.Line > 0 {
+		loc += ":" + strconv.Itoa(r.Line)
+		if r.Column > 0 {
+			loc += ":" + strconv.Itoa(r.Column)
+		}
+	}
+	if loc != "" {
+		b.WriteString(loc + ": ")
+	}
+	if r.Type != "" {
+		b.WriteString(r.Type + ": ")
+	}
+	b.WriteString(strings.TrimSpace(r.Message) + "\n")
+	if len(r.Context) > 0 {
+		b.WriteString("\nLog context:\n")
+		for _, line := range r.Context {
+			b.WriteString(line + "\n")
+		}
+	}
+	return b.String()
+}
+
+// ExpandExplainCommand fills the {message}, {file}, {line}, {col}, and
+// {type} placeholders of an explain command with shell-quoted values.
+func ExpandExplainCommand(tmpl string, r ExplainRequest) string {
+	line, col := "", ""
+	if r.Line > 0 {
+		line = strconv.Itoa(r.Line)
+	}
+	if r.Column > 0 {
+		col = strconv.Itoa(r.Column)
+	}
+	return strings.NewReplacer(
+		"{message}", shellQuote(strings.TrimSpace(r.Message)),
+		"{file}", shellQuote(r.File),
+		"{line}", shellQuote(line),
+		"{col}", shellQuote(col),
+		"{type}", shellQuote(r.Type),
+	).Replace(tmpl)
+}
+
+// ExplainResult is the output of an explain command.
+type ExplainResult struct {
+	Command  string
+	Stdout   string
+	Stderr   string
+	ExitCode int
+	Duration time.Duration
+	TimedOut bool
+}
+
+// RunExplainCommand runs issues.explainCommand for an issue with /bin/sh in
+// the project root. A timeout or non-zero exit returns an error along with
+// the output so far.
+func RunExplainCommand(ctx context.Context, projectRoot string, cfg IssuesConfig, r ExplainRequest) (ExplainResult, error) {
+	res := ExplainResult{Command: ExpandExplainCommand(cfg.ExplainCommand, r)}
+	if strings.TrimSpace(cfg.ExplainCommand) == "" {
+		return res, errors.New("issues.explainCommand is not set")
+	}
+	timeout := cfg.ExplainDeadline()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "/bin/sh", "-c", res.Command)
+	cmd.Dir = projectRoot
+	cmd.Stdin = strings.NewReader(r.Stdin())
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// Kill the shell's children too, so they don't hold the pipes open
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error { return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL) }
+	cmd.WaitDelay = 2 * time.Second
+
+	start := time.Now()
+	err := cmd.Run()
+	res.Duration = time.Since(start)
+	res.Stdout, res.Stderr = stdout.String(), stderr.String()
+	if cmd.ProcessState != nil {
+		res.ExitCode = cmd.ProcessState.ExitCode()
+	}
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		res.TimedOut = true
+		return res, fmt.Errorf("explain command timed out after %s", timeout)
+	case err != nil:
+		return res, fmt.Errorf("explain command failed: %w", err)
+	}
+	return res, nil
+}
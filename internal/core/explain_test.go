@@ -0,0 +1,68 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testExplainRequest = ExplainRequest{
+	Type:    "error",
+	Message: "cannot convert value of type 'Int' to 'String'",
+	File:    "/src/My App/View.swift",
+	Line:    42,
+	Column:  7,
+	Context: []string{"CompileSwift normal arm64", "** BUILD FAILED **"},
+}
+
+func TestExpandExplainCommand(t *testing.T) {
+	got := ExpandExplainCommand("explain --file {file} --line {line}:{col} {type} {message}", testExplainRequest)
+	want := `explain --file '/src/My App/View.swift' --line 42:7 error 'cannot convert value of type '\''Int'\'' to '\''String'\'''`
+	if got != want {
+		t.Fatalf("got  %s\nwant %s", got, want)
+	}
+	if got := ExpandExplainCommand("explain {line}", ExplainRequest{Message: "x"}); got != "explain ''" {
+		t.Fatalf("missing line should expand to an empty argument, got %s", got)
+	}
+}
+
+func TestRunExplainCommandReadsStdin(t *testing.T) {
+	res, err := RunExplainCommand(context.Background(), t.TempDir(), IssuesConfig{ExplainCommand: "cat"}, testExplainRequest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "/src/My App/View.swift:42:7: error: cannot convert value of type 'Int' to 'String'\n\nLog context:\nCompileSwift normal arm64\n** BUILD FAILED **\n"
+	if res.Stdout != want || res.ExitCode != 0 {
+		t.Fatalf("stdout = %q (exit %d)", res.Stdout, res.ExitCode)
+	}
+}
+
+func TestRunExplainCommandFailures(t *testing.T) {
+	res, err := RunExplainCommand(context.Background(), t.TempDir(), IssuesConfig{ExplainCommand: "echo bad key >&2; exit 2"}, testExplainRequest)
+	if err == nil || res.ExitCode != 2 || res.Stderr != "bad key\n" || res.TimedOut {
+		t.Fatalf("unexpected result %+v (%v)", res, err)
+	}
+
+	start := time.Now()
+	res, err = RunExplainCommand(context.Background(), t.TempDir(), IssuesConfig{ExplainCommand: "sleep 5", ExplainTimeout: "100ms"}, testExplainRequest)
+	if err == nil || !res.TimedOut || !strings.Contains(err.Error(), "timed out after 100ms") {
+		t.Fatalf("expected a timeout, got %+v (%v)", res, err)
+	}
+	if time.Since(start) > 3*time.Second {
+		t.Fatalf("timeout took %s", time.Since(start))
+	}
+
+	if _, err := RunExplainCommand(context.Background(), t.TempDir(), IssuesConfig{}, testExplainRequest); err == nil {
+		t.Fatal("expected an error without issues.explainCommand")
+	}
+}
+
+func TestExplainDeadline(t *testing.T) {
+	if d := (IssuesConfig{}).ExplainDeadline(); d != DefaultExplainTimeout {
+		t.Fatalf("default = %s", d)
+	}
+	if d := (IssuesConfig{ExplainTimeout: "2m"}).ExplainDeadline(); d != 2*time.Minute {
+		t.Fatalf("2m = %s", d)
+	}
+}
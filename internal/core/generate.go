@@ -0,0 +1,207 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ProjectGenerateCode marks the status events of the Generate phase.
+const ProjectGenerateCode = "PROJECT_GENERATE"
+
+const (
+	GenerateTuist    = "tuist"
+	GenerateXcodeGen = "xcodegen"
+	GenerateCustom   = "custom"
+)
+
+// generatorManifests are the inputs whose changes make the generated project
+// stale. Custom generators are checked against both tools' manifests.
+func generatorManifests(tool string) []string {
+	tuist := []string{"Project.swift", "Workspace.swift", "Tuist.swift", "Tuist/Config.swift", "Tuist/Package.swift", "Tuist/ProjectDescriptionHelpers/*.swift"}
+	xcodegen := []string{"project.yml", "project.yaml", "project.json"}
+	switch tool {
+	case GenerateTuist:
+		return tuist
+	case GenerateXcodeGen:
+		return xcodegen
+	case GenerateCustom:
+		return append(xcodegen, tuist...)
+	}
+	return nil
+}
+
+// generatorCommand returns the shell command for g.
+func generatorCommand(g GenerateConfig) (string, error) {
+	if c := strings.TrimSpace(g.Command); c != "" {
+		return c, nil
+	}
+	switch g.Tool {
+	case GenerateTuist:
+		return "tuist generate --no-open", nil
+	case GenerateXcodeGen:
+		return "xcodegen generate", nil
+	case GenerateCustom:
+		return "", fmt.Errorf("generate.tool is %q but generate.command is empty", g.Tool)
+	}
+	return "", fmt.Errorf("unknown generate.tool %q (want tuist, xcodegen, or custom)", g.Tool)
+}
+
+// ProjectNeedsGeneration reports whether the generated project is missing or
+// older than its manifest, with a short reason.
+func ProjectNeedsGeneration(projectRoot string, cfg Config) (bool, string) {
+	var newest time.Time
+	manifest := ""
+	for _, pattern := range generatorManifests(cfg.Generate.Tool) {
+		matches, _ := filepath.Glob(filepath.Join(projectRoot, pattern))
+		for _, p := range matches {
+			if fi, err := os.Stat(p); err == nil && fi.ModTime().After(newest) {
+				newest = fi.ModTime()
+				manifest = p
+			}
+		}
+	}
+
+	outputs := generatedProjectFiles(projectRoot, cfg)
+	if len(outputs) == 0 {
+		return true, "no generated project yet"
+	}
+	if manifest == "" {
+		return false, ""
+	}
+	for _, out := range outputs {
+		fi, err := os.Stat(out)
+		if err != nil {
+			return true, relativeToRoot(projectRoot, out) + " is missing"
+		}
+		if newest.After(fi.ModTime()) {
+			return true, fmt.Sprintf("%s is newer than %s", relativeToRoot(projectRoot, manifest), relativeToRoot(projectRoot, filepath.Dir(out)))
+		}
+	}
+	return false, ""
+}
+
+// generatedProjectFiles lists the files a generator rewrites: the configured
+// workspace/project, or every .xcodeproj in the root.
+func generatedProjectFiles(projectRoot string, cfg Config) []string {
+	var files []string
+	if cfg.Workspace != "" {
+		files = append(files, filepath.Join(projectRoot, cfg.Workspace, "contents.xcworkspacedata"))
+	}
+	if cfg.Project != "" {
+		files = append(files, filepath.Join(projectRoot, cfg.Project, "project.pbxproj"))
+	}
+	if len(files) > 0 {
+		return files
+	}
+	_, projects, err := scanProjectEntries(projectRoot)
+	if err != nil {
+		return nil
+	}
+	for _, p := range projects {
+		files = append(files, filepath.Join(projectRoot, p, "project.pbxproj"))
+	}
+	return files
+}
+
+func relativeToRoot(projectRoot, path string) string {
+	if rel, err := filepath.Rel(projectRoot, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+	return path
+}
+
+// maybeGenerateProject runs the generator before op when generate.auto is on
+// and the project is stale.
+func maybeGenerateProject(ctx context.Context, projectRoot string, cfg Config, op string, emit Emitter) (Config, error) {
+	if cfg.Generate.Tool == "" || !cfg.Generate.Auto {
+		return cfg, nil
+	}
+	stale, reason := ProjectNeedsGeneration(projectRoot, cfg)
+	if !stale {
+		return cfg, nil
+	}
+	_, cfg, err := GenerateProject(ctx, projectRoot, cfg, op, reason, emit)
+	return cfg, err
+}
+
+// GenerateProject runs the configured generator with streamed output, then
+// rediscovers the project context since schemes may have changed (the
+// returned ContextInfo is empty when that fails or in a dry run). A failure
+// emits a GENERATE_FAILED error; the caller should abort.
+func GenerateProject(ctx context.Context, projectRoot string, cfg Config, op string, reason string, emit Emitter) (ContextInfo, Config, error) {
+	command, err := generatorCommand(cfg.Generate)
+	if err != nil {
+		emitMaybe(emit, Err(op, ErrorObject{
+			Code:       "GENERATE_CONFIG_INVALID",
+			Message:    "Project generation is misconfigured",
+			Detail:     err.Error(),
+			Suggestion: "Set generate.tool to tuist or xcodegen, or set generate.command, in .xcbolt/config.json.",
+		}))
+		return ContextInfo{}, cfg, err
+	}
+
+	data := map[string]any{"phase": "Generate", "tool": cfg.Generate.Tool, "command": command}
+	if reason != "" {
+		data["reason"] = reason
+	}
+	msg := "Generating project with " + cfg.Generate.Tool
+	if reason != "" {
+		msg += " (" + reason + ")"
+	}
+	ev := Status(op, msg, data)
+	ev.Code = ProjectGenerateCode
+	emitMaybe(emit, ev)
+	emitMaybe(emit, Log(op, "$ "+command))
+	if cfg.Xcodebuild.DryRun {
+		emitMaybe(emit, Log(op, "Dry run: generator not executed"))
+		return ContextInfo{}, cfg, nil
+	}
+
+	res, err := RunStreaming(ctx, CmdSpec{
+		Path:       "/bin/sh",
+		Args:       []string{"-c", command},
+		Dir:        projectRoot,
+		StdoutLine: func(s string) { emitMaybe(emit, Log(op, s)) },
+		StderrLine: func(s string) { emitMaybe(emit, Log(op, s)) },
+	})
+	if err != nil {
+		if status := CancelStatus(err); status != "" {
+			emitMaybe(emit, Err(op, cancelErrorObject("Project generation", err, "")))
+			return ContextInfo{}, cfg, err
+		}
+		detail := err.Error()
+		if res.ExitCode != 0 {
+			detail = fmt.Sprintf("%s exited with status %d", command, res.ExitCode)
+		}
+		suggestion := "Fix the manifest errors above, or set generate.auto to false in .xcbolt/config.json."
+		if res.ExitCode == 127 {
+			suggestion = fmt.Sprintf("Install %s or point generate.command at it in .xcbolt/config.json.", cfg.Generate.Tool)
+		}
+		emitMaybe(emit, Err(op, ErrorObject{
+			Code:       "GENERATE_FAILED",
+			Message:    fmt.Sprintf("Project generation failed; %s aborted", op),
+			Detail:     detail,
+			Suggestion: suggestion,
+		}))
+		return ContextInfo{}, cfg, fmt.Errorf("project generation failed: %s", detail)
+	}
+
+	// Schemes may have been added, renamed, or removed.
+	info, cfg2, err := DiscoverContext(ctx, projectRoot, cfg, nil, ContextOptions{})
+	if err != nil {
+		info = ContextInfo{}
+	} else {
+		cfg = cfg2
+		if cfg.Scheme != "" && len(info.Schemes) > 0 && !stringInSlice(cfg.Scheme, info.Schemes) {
+			emitMaybe(emit, Warn(op, fmt.Sprintf("Scheme %q no longer exists after generation (found: %s)", cfg.Scheme, strings.Join(info.Schemes, ", "))))
+		}
+	}
+	done := Status(op, "Project generated", map[string]any{"phase": "Generate", "tool": cfg.Generate.Tool, "durationMs": res.Duration.Milliseconds(), "schemes": info.Schemes})
+	done.Code = ProjectGenerateCode
+	emitMaybe(emit, done)
+	return info, cfg, nil
+}
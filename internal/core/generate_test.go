@@ -0,0 +1,109 @@
+package core
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeFileAt(t *testing.T, path string, mtime time.Time) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProjectNeedsGeneration(t *testing.T) {
+	root := t.TempDir()
+	cfg := Config{Generate: GenerateConfig{Tool: GenerateXcodeGen}}
+	old := time.Now().Add(-time.Hour)
+
+	writeFileAt(t, filepath.Join(root, "project.yml"), old)
+	if stale, reason := ProjectNeedsGeneration(root, cfg); !stale || reason != "no generated project yet" {
+		t.Fatalf("missing project should be stale, got %v %q", stale, reason)
+	}
+
+	writeFileAt(t, filepath.Join(root, "App.xcodeproj", "project.pbxproj"), old.Add(time.Minute))
+	if stale, reason := ProjectNeedsGeneration(root, cfg); stale {
+		t.Fatalf("fresh project reported stale: %q", reason)
+	}
+
+	writeFileAt(t, filepath.Join(root, "project.yml"), time.Now())
+	stale, reason := ProjectNeedsGeneration(root, cfg)
+	if !stale || reason != "project.yml is newer than App.xcodeproj" {
+		t.Fatalf("edited manifest should be stale, got %v %q", stale, reason)
+	}
+
+	// Tuist ignores XcodeGen manifests.
+	cfg.Generate.Tool = GenerateTuist
+	if stale, _ := ProjectNeedsGeneration(root, cfg); stale {
+		t.Fatalf("tuist should not look at project.yml")
+	}
+	writeFileAt(t, filepath.Join(root, "Tuist", "ProjectDescriptionHelpers", "Targets.swift"), time.Now())
+	if stale, _ := ProjectNeedsGeneration(root, cfg); !stale {
+		t.Fatalf("edited tuist helper should be stale")
+	}
+}
+
+func TestGenerateProjectFailureAborts(t *testing.T) {
+	root := t.TempDir()
+	rec := &recordingEmitter{}
+	cfg := Config{Generate: GenerateConfig{Tool: GenerateCustom, Command: "echo generating; exit 3", Auto: true}}
+
+	_, err := maybeGenerateProject(context.Background(), root, cfg, "build", rec)
+	if err == nil {
+		t.Fatalf("expected a generation error")
+	}
+	var sawOutput, sawPhase bool
+	var failure *ErrorObject
+	for _, ev := range rec.events {
+		switch {
+		case ev.Code == ProjectGenerateCode:
+			sawPhase = true
+		case ev.Type == "log" && ev.Msg == "generating":
+			sawOutput = true
+		case ev.Type == "error":
+			failure = ev.Err
+		}
+	}
+	if !sawPhase || !sawOutput {
+		t.Fatalf("expected the Generate phase and streamed output, got %+v", rec.events)
+	}
+	if failure == nil || failure.Code != "GENERATE_FAILED" || !strings.Contains(failure.Detail, "status 3") {
+		t.Fatalf("unexpected error object: %+v", failure)
+	}
+
+	cfg.Generate.Command = ""
+	if _, _, err := GenerateProject(context.Background(), root, cfg, "generate", "", nil); err == nil {
+		t.Fatalf("custom tool without a command should fail")
+	}
+}
+
+func TestMaybeGenerateProjectSkipsWithoutAuto(t *testing.T) {
+	rec := &recordingEmitter{}
+	cfg := Config{Generate: GenerateConfig{Tool: GenerateCustom, Command: "exit 1"}}
+	if _, err := maybeGenerateProject(context.Background(), t.TempDir(), cfg, "build", rec); err != nil || len(rec.events) != 0 {
+		t.Fatalf("generate.auto=false should not run the generator: %v %+v", err, rec.events)
+	}
+}
+
+func TestGenerateProjectReturnsRediscoveredContext(t *testing.T) {
+	root := t.TempDir()
+	cfg := Config{Generate: GenerateConfig{Tool: GenerateCustom, Command: "mkdir -p App.xcodeproj/xcshareddata/xcschemes && touch App.xcodeproj/project.pbxproj App.xcodeproj/xcshareddata/xcschemes/App.xcscheme"}}
+	info, _, err := GenerateProject(context.Background(), root, cfg, "generate", "", nil)
+	if err != nil {
+		t.Fatalf("GenerateProject: %v", err)
+	}
+	if info.ProjectRoot != root || strings.Join(info.Schemes, ",") != "App" {
+		t.Fatalf("info = %+v", info)
+	}
+}
@@ -0,0 +1,190 @@
+package core
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// xcboltGeneratedEntries is everything xcbolt writes under .xcbolt except
+// config.json, relative to .xcbolt.
+var xcboltGeneratedEntries = []string{
+	"DerivedData/",
+	"Results/",
+	"Logs/",
+	"Captures/",
+	"screenshots/",
+	"repro/",
+	"backups/",
+	"context.json",
+	"sessions.json",
+	"status.json",
+	"last-run.json",
+	"metrics.ndjson",
+	"scheme-platforms.json",
+}
+
+// GitignoreMode is what AddProjectGitignore adds to the project's .gitignore.
+type GitignoreMode string
+
+const (
+	// GitignoreDir ignores the whole .xcbolt directory, config included.
+	GitignoreDir GitignoreMode = "dir"
+	// GitignoreSelective ignores what xcbolt generates and keeps
+	// .xcbolt/config.json tracked.
+	GitignoreSelective GitignoreMode = "selective"
+)
+
+// gitignoreHeader precedes the lines xcbolt appends to a project .gitignore.
+const gitignoreHeader = "# xcbolt"
+
+// xcboltDirPatterns already ignore the whole .xcbolt directory.
+var xcboltDirPatterns = []string{".xcbolt", ".xcbolt/", "/.xcbolt", "/.xcbolt/", ".xcbolt/*", "/.xcbolt/*"}
+
+// ProjectGitignorePath is the project's top-level .gitignore.
+func ProjectGitignorePath(projectRoot string) string {
+	return filepath.Join(projectRoot, ".gitignore")
+}
+
+// ProjectGitignoreEntries returns the .gitignore lines of a mode.
+func ProjectGitignoreEntries(mode GitignoreMode) []string {
+	if mode == GitignoreDir {
+		return []string{".xcbolt/"}
+	}
+	entries := make([]string, len(xcboltGeneratedEntries))
+	for i, e := range xcboltGeneratedEntries {
+		entries[i] = ".xcbolt/" + e
+	}
+	return entries
+}
+
+// GitignorePlan is what AddProjectGitignore would change, for a preview.
+type GitignorePlan struct {
+	Path   string
+	Exists bool
+	// Add lists the lines to append, header included; empty when the
+	// .gitignore already covers the mode.
+	Add []string
+	// NeedsNewline is set when the file lacks a trailing newline, which is
+	// added before the new lines.
+	NeedsNewline bool
+}
+
+// Preview renders the plan as diff-style lines.
+func (p GitignorePlan) Preview() []string {
+	name := filepath.Base(p.Path)
+	out := []string{}
+	switch {
+	case !p.Exists:
+		out = append(out, "create "+name)
+	case p.NeedsNewline:
+		out = append(out, "append to "+name+" (adding the missing final newline)")
+	default:
+		out = append(out, "append to "+name)
+	}
+	for _, line := range p.Add {
+		out = append(out, "+ "+line)
+	}
+	return out
+}
+
+// PlanProjectGitignore works out which lines of mode the project's .gitignore
+// lacks. Nothing is written.
+func PlanProjectGitignore(projectRoot string, mode GitignoreMode) (GitignorePlan, error) {
+	plan := GitignorePlan{Path: ProjectGitignorePath(projectRoot)}
+	b, err := os.ReadFile(plan.Path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return plan, err
+	}
+	content := string(b)
+	plan.Exists = err == nil
+	plan.NeedsNewline = content != "" && !strings.HasSuffix(content, "\n")
+	if IgnoresXcboltDir(content) {
+		return plan, nil
+	}
+	missing := missingGitignoreLines(content, ProjectGitignoreEntries(mode))
+	if len(missing) > 0 && !hasGitignoreLine(content, gitignoreHeader) {
+		missing = append([]string{gitignoreHeader}, missing...)
+	}
+	if len(missing) > 0 {
+		plan.Add = missing
+	}
+	return plan, nil
+}
+
+// AddProjectGitignore appends the lines of mode the project's .gitignore
+// lacks, creating the file if needed. It re-reads the file, so edits made
+// since the preview are kept. Callers confirm with the user first.
+func AddProjectGitignore(projectRoot string, mode GitignoreMode) ([]string, error) {
+	b, err := os.ReadFile(ProjectGitignorePath(projectRoot))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if IgnoresXcboltDir(string(b)) {
+		return nil, nil
+	}
+	return appendGitignoreLines(ProjectGitignorePath(projectRoot), ProjectGitignoreEntries(mode), gitignoreHeader)
+}
+
+// IgnoresXcboltDir reports whether .gitignore content ignores all of .xcbolt.
+func IgnoresXcboltDir(content string) bool {
+	for _, p := range xcboltDirPatterns {
+		if hasGitignoreLine(content, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// appendGitignoreLines appends the entries missing from the .gitignore at
+// path, preceded by header when it is not "", creating the file if needed
+// and adding a missing trailing newline first. It returns what it appended.
+func appendGitignoreLines(path string, entries []string, header string) ([]string, error) {
+	perm := os.FileMode(0o644)
+	b, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if info, statErr := os.Stat(path); statErr == nil {
+		perm = info.Mode().Perm()
+	}
+
+	existing := string(b)
+	missing := missingGitignoreLines(existing, entries)
+	if len(missing) == 0 {
+		return nil, nil
+	}
+	if header != "" && !hasGitignoreLine(existing, header) {
+		missing = append([]string{header}, missing...)
+	}
+	if existing != "" && !strings.HasSuffix(existing, "\n") {
+		existing += "\n"
+	}
+	existing += strings.Join(missing, "\n") + "\n"
+	if err := os.WriteFile(path, []byte(existing), perm); err != nil {
+		return nil, err
+	}
+	return missing, nil
+}
+
+func missingGitignoreLines(content string, entries []string) []string {
+	missing := []string{}
+	for _, entry := range entries {
+		if !hasGitignoreLine(content, entry) {
+			missing = append(missing, entry)
+		}
+	}
+	return missing
+}
+
+func hasGitignoreLine(content string, line string) bool {
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		if strings.TrimSpace(scanner.Text()) == line {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEnsureProjectDirsIgnoresEverythingButConfig(t *testing.T) {
+	root := t.TempDir()
+	if err := EnsureProjectDirs(root); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(filepath.Join(root, ".xcbolt", ".gitignore"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range []string{"DerivedData/", "Results/", "Logs/", "screenshots/", "sessions.json", "status.json"} {
+		if !hasGitignoreLine(string(b), entry) {
+			t.Errorf("missing %s in %q", entry, b)
+		}
+	}
+	if strings.Contains(string(b), "config.json") {
+		t.Errorf("config.json ignored: %q", b)
+	}
+}
+
+func TestPlanProjectGitignoreWritesNothing(t *testing.T) {
+	root := t.TempDir()
+	plan, err := PlanProjectGitignore(root, GitignoreDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.Exists || !reflect.DeepEqual(plan.Add, []string{"# xcbolt", ".xcbolt/"}) {
+		t.Fatalf("plan = %+v", plan)
+	}
+	if got := plan.Preview(); got[0] != "create .gitignore" || got[2] != "+ .xcbolt/" {
+		t.Fatalf("preview = %q", got)
+	}
+	if _, err := os.Stat(ProjectGitignorePath(root)); !os.IsNotExist(err) {
+		t.Fatalf("plan wrote .gitignore: %v", err)
+	}
+}
+
+func TestAddProjectGitignoreCreatesFile(t *testing.T) {
+	root := t.TempDir()
+	if _, err := AddProjectGitignore(root, GitignoreDir); err != nil {
+		t.Fatal(err)
+	}
+	b, _ := os.ReadFile(ProjectGitignorePath(root))
+	if string(b) != "# xcbolt\n.xcbolt/\n" {
+		t.Fatalf(".gitignore = %q", b)
+	}
+}
+
+func TestAddProjectGitignoreAddsMissingNewline(t *testing.T) {
+	root := t.TempDir()
+	path := ProjectGitignorePath(root)
+	if err := os.WriteFile(path, []byte("*.xcuserstate\n.xcbolt/Results/"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	plan, err := PlanProjectGitignore(root, GitignoreSelective)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !plan.NeedsNewline || len(plan.Add) != len(xcboltGeneratedEntries) {
+		t.Fatalf("plan = %+v", plan)
+	}
+
+	added, err := AddProjectGitignore(root, GitignoreSelective)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(added, plan.Add) {
+		t.Fatalf("added %q, planned %q", added, plan.Add)
+	}
+	b, _ := os.ReadFile(path)
+	content := string(b)
+	if !strings.HasPrefix(content, "*.xcuserstate\n.xcbolt/Results/\n# xcbolt\n.xcbolt/DerivedData/\n") {
+		t.Fatalf(".gitignore = %q", content)
+	}
+	if strings.Count(content, ".xcbolt/Results/") != 1 || strings.Contains(content, "config.json") {
+		t.Fatalf(".gitignore = %q", content)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0o600 {
+		t.Fatalf("mode = %v", info.Mode())
+	}
+
+	// Running again changes nothing
+	if added, err := AddProjectGitignore(root, GitignoreSelective); err != nil || len(added) != 0 {
+		t.Fatalf("second add = %q, %v", added, err)
+	}
+}
+
+func TestPlanProjectGitignoreRespectsIgnoredDir(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(ProjectGitignorePath(root), []byte("/.xcbolt\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, mode := range []GitignoreMode{GitignoreDir, GitignoreSelective} {
+		plan, err := PlanProjectGitignore(root, mode)
+		if err != nil || len(plan.Add) != 0 {
+			t.Fatalf("%s: plan = %+v, %v", mode, plan, err)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package core
+
+import (
+	"strings"
+	"time"
+)
+
+// MaxHistoryEntries caps duration history per HistoryKey.
+const MaxHistoryEntries = 50
+
+// DurationEntry records how long a finished operation took
+type DurationEntry struct {
+	Op              string `json:"op"`
+	Scheme          string `json:"scheme,omitempty"`
+	Configuration   string `json:"configuration,omitempty"`
+	DestinationKind string `json:"destinationKind,omitempty"`
+	DurationMs      int64  `json:"durationMs"`
+	Timestamp       string `json:"timestamp"`
+	Success         bool   `json:"success"`
+}
+
+// Duration returns the entry duration.
+func (e DurationEntry) Duration() time.Duration {
+	return time.Duration(e.DurationMs) * time.Millisecond
+}
+
+// HistoryKey groups entries by project, op, scheme, configuration, and destination kind.
+func HistoryKey(projectRoot string, e DurationEntry) string {
+	return strings.Join([]string{projectRoot, e.Op, e.Scheme, e.Configuration, e.DestinationKind}, "|")
+}
+
+// AddDuration appends an entry, keeping the newest MaxHistoryEntries per key
+func (st *State) AddDuration(projectRoot string, e DurationEntry) {
+	if st.History == nil {
+		st.History = make(map[string][]DurationEntry)
+	}
+	key := HistoryKey(projectRoot, e)
+	entries := append(st.History[key], e)
+	if len(entries) > MaxHistoryEntries {
+		entries = entries[len(entries)-MaxHistoryEntries:]
+	}
+	st.History[key] = entries
+}
+
+// GetDurations returns entries for the key, oldest first
+func (st *State) GetDurations(projectRoot string, e DurationEntry) []DurationEntry {
+	if st.History == nil {
+		return nil
+	}
+	return st.History[HistoryKey(projectRoot, e)]
+}
+
+// ClearHistory drops all duration history for a project
+func (st *State) ClearHistory(projectRoot string) {
+	prefix := projectRoot + "|"
+	for key := range st.History {
+		if strings.HasPrefix(key, prefix) {
+			delete(st.History, key)
+		}
+	}
+}
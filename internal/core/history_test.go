@@ -0,0 +1,46 @@
+package core
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestAddDurationCapsPerKey(t *testing.T) {
+	var st State
+	for i := 0; i < MaxHistoryEntries+5; i++ {
+		st.AddDuration("/proj", DurationEntry{Op: "build", Scheme: "App", DurationMs: int64(i)})
+	}
+	st.AddDuration("/proj", DurationEntry{Op: "test", Scheme: "App", DurationMs: 1})
+
+	builds := st.GetDurations("/proj", DurationEntry{Op: "build", Scheme: "App"})
+	if len(builds) != MaxHistoryEntries {
+		t.Fatalf("expected %d entries, got %d", MaxHistoryEntries, len(builds))
+	}
+	if builds[0].DurationMs != 5 || builds[len(builds)-1].DurationMs != int64(MaxHistoryEntries+4) {
+		t.Fatalf("expected newest entries kept, got first=%d last=%d", builds[0].DurationMs, builds[len(builds)-1].DurationMs)
+	}
+	if got := st.GetDurations("/proj", DurationEntry{Op: "test", Scheme: "App"}); len(got) != 1 {
+		t.Fatalf("expected separate key for test, got %d", len(got))
+	}
+
+	st.AddDuration("/other", DurationEntry{Op: "build", Scheme: "App", DurationMs: 1})
+	st.ClearHistory("/proj")
+	if len(st.GetDurations("/proj", DurationEntry{Op: "build", Scheme: "App"})) != 0 {
+		t.Fatalf("expected project history cleared")
+	}
+	if len(st.GetDurations("/other", DurationEntry{Op: "build", Scheme: "App"})) != 1 {
+		t.Fatalf("clearing one project should keep others")
+	}
+}
+
+func TestMigrateStateFromV2(t *testing.T) {
+	v2 := []byte(`{"version":2,"recent":[{"root":"/proj","updatedAt":"2025-01-01T00:00:00Z"}]}`)
+	var st State
+	if err := json.Unmarshal(v2, &st); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	migrateState(&st)
+	if st.Version != StateVersion || st.History == nil || len(st.Recent) != 1 {
+		t.Fatalf("unexpected migrated state: %+v", st)
+	}
+}
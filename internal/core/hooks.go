@@ -0,0 +1,97 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// hookOutcome feeds the XCBOLT_* variables of post hooks.
+type hookOutcome struct {
+	success      bool
+	resultBundle string
+	appPath      string
+}
+
+func (h HooksConfig) command(op string, post bool) (name, command string) {
+	switch op {
+	case "build":
+		if post {
+			return "postBuild", h.PostBuild
+		}
+		return "preBuild", h.PreBuild
+	case "run":
+		if post {
+			return "postRun", h.PostRun
+		}
+		return "preRun", h.PreRun
+	case "test":
+		if post {
+			return "postTest", h.PostTest
+		}
+		return "preTest", h.PreTest
+	}
+	return "", ""
+}
+
+// runPreHook runs the pre hook for op. A non-zero exit aborts the operation.
+func runPreHook(ctx context.Context, projectRoot string, cfg Config, op string, emit Emitter) error {
+	name, command := cfg.Hooks.command(op, false)
+	err := runHook(ctx, projectRoot, cfg, op, name, command, map[string]string{"XCBOLT_OP": op}, emit)
+	if err != nil {
+		emitMaybe(emit, Err(op, ErrorObject{
+			Code:       "HOOK_FAILED",
+			Message:    fmt.Sprintf("%s hook failed; %s aborted", name, op),
+			Detail:     err.Error(),
+			Suggestion: fmt.Sprintf("Fix or remove hooks.%s in .xcbolt/config.json.", name),
+		}))
+	}
+	return err
+}
+
+// runPostHook runs the post hook for op, including after failures. Errors are
+// reported as warnings since the operation already finished.
+func runPostHook(ctx context.Context, projectRoot string, cfg Config, op string, out hookOutcome, emit Emitter) {
+	name, command := cfg.Hooks.command(op, true)
+	env := map[string]string{
+		"XCBOLT_OP":      op,
+		"XCBOLT_SUCCESS": strconv.FormatBool(out.success),
+	}
+	if out.resultBundle != "" {
+		env["XCBOLT_RESULT_BUNDLE"] = out.resultBundle
+	}
+	if out.appPath != "" {
+		env["XCBOLT_APP_PATH"] = out.appPath
+	}
+	if err := runHook(ctx, projectRoot, cfg, op, name, command, env, emit); err != nil {
+		emitMaybe(emit, Warn(op, fmt.Sprintf("%s hook failed: %v", name, err)))
+	}
+}
+
+func runHook(ctx context.Context, projectRoot string, cfg Config, op, name, command string, env map[string]string, emit Emitter) error {
+	command = strings.TrimSpace(command)
+	if command == "" {
+		return nil
+	}
+	emitMaybe(emit, Log(op, fmt.Sprintf("Hook %s: %s", name, command)))
+	if cfg.Xcodebuild.DryRun {
+		emitMaybe(emit, Log(op, fmt.Sprintf("Dry run: hook %s not executed", name)))
+		return nil
+	}
+	res, err := RunStreaming(ctx, CmdSpec{
+		Path:       "/bin/sh",
+		Args:       []string{"-c", command},
+		Dir:        projectRoot,
+		Env:        env,
+		StdoutLine: func(s string) { emitMaybe(emit, Log(op, s)) },
+		StderrLine: func(s string) { emitMaybe(emit, Log(op, s)) },
+	})
+	if err != nil {
+		if res.ExitCode != 0 {
+			return fmt.Errorf("exit status %d", res.ExitCode)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package core
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPreHookFailureAbortsBuild(t *testing.T) {
+	dir := t.TempDir()
+	rec := &recordingEmitter{}
+	cfg := Config{Hooks: HooksConfig{PreBuild: "echo generating; exit 3"}}
+
+	_, _, err := Build(context.Background(), dir, cfg, rec)
+	if err == nil {
+		t.Fatalf("expected pre-hook failure to abort the build")
+	}
+	var sawOutput, sawErr bool
+	for _, ev := range rec.events {
+		if ev.Type == "log" && ev.Msg == "generating" {
+			sawOutput = true
+		}
+		if ev.Type == "error" {
+			sawErr = true
+			if ev.Err == nil || ev.Err.Code != "HOOK_FAILED" || !strings.Contains(ev.Err.Detail, "exit status 3") {
+				t.Fatalf("unexpected error event: %+v", ev.Err)
+			}
+		}
+	}
+	if !sawOutput || !sawErr {
+		t.Fatalf("expected hook output and an error event, got %+v", rec.events)
+	}
+}
+
+func TestPostHookReceivesOutcome(t *testing.T) {
+	dir := t.TempDir()
+	cfg := Config{Hooks: HooksConfig{PostTest: `printf '%s %s %s' "$XCBOLT_OP" "$XCBOLT_SUCCESS" "$XCBOLT_RESULT_BUNDLE" > out.txt`}}
+
+	runPostHook(context.Background(), dir, cfg, "test", hookOutcome{success: false, resultBundle: "/tmp/r.xcresult"}, nil)
+
+	got, err := os.ReadFile(filepath.Join(dir, "out.txt"))
+	if err != nil {
+		t.Fatalf("hook did not run in the project root: %v", err)
+	}
+	if string(got) != "test false /tmp/r.xcresult" {
+		t.Fatalf("unexpected hook env: %q", got)
+	}
+}
+
+func TestDryRunPrintsHooksWithoutRunning(t *testing.T) {
+	dir := t.TempDir()
+	rec := &recordingEmitter{}
+	cfg := Config{
+		Xcodebuild: XcodebuildConfig{DryRun: true},
+		Hooks:      HooksConfig{PreRun: "touch ran.txt"},
+	}
+
+	if err := runPreHook(context.Background(), dir, cfg, "run", rec); err != nil {
+		t.Fatalf("runPreHook: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "ran.txt")); err == nil {
+		t.Fatalf("dry run executed the hook")
+	}
+	if len(rec.events) == 0 || !strings.Contains(rec.events[0].Msg, "touch ran.txt") {
+		t.Fatalf("expected the hook command to be printed, got %+v", rec.events)
+	}
+}
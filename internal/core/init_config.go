@@ -0,0 +1,119 @@
+package core
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// InitError is a validation failure for an init selection. Obj.Code is one
+// of PROJECT_REQUIRED, PROJECT_AMBIGUOUS, PROJECT_NOT_FOUND,
+// SCHEME_REQUIRED, SCHEME_NOT_FOUND, CONFIGURATION_REQUIRED,
+// CONFIGURATION_NOT_FOUND or DESTINATION_NOT_FOUND.
+type InitError struct {
+	Obj ErrorObject
+}
+
+func (e *InitError) Error() string {
+	if e.Obj.Detail != "" {
+		return e.Obj.Message + ": " + e.Obj.Detail
+	}
+	return e.Obj.Message
+}
+
+func initError(code, message, detail, suggestion string) *InitError {
+	return &InitError{Obj: ErrorObject{Code: code, Message: message, Detail: detail, Suggestion: suggestion}}
+}
+
+// InitDestinationCandidates returns the destinations an init selection may
+// target: discovered simulators and devices plus the local Mac.
+func InitDestinationCandidates(info ContextInfo) []DestinationCandidate {
+	return append(DestinationCandidatesFromContext(info), localDestinationCandidates()...)
+}
+
+// ValidateInitConfig checks the workspace/project, scheme, configuration and
+// destination of cfg against the discovered context. It is shared by
+// `xcbolt init` and the TUI wizard so both accept the same selections.
+// An explicit destination is resolved in place; an auto destination stays
+// auto but must have at least one match.
+func ValidateInitConfig(info ContextInfo, cfg Config, candidates []DestinationCandidate) (Config, error) {
+	if cfg.Workspace == "" && cfg.Project == "" && info.Package == "" {
+		if ranked := RankContainers(info); len(ranked) > 1 {
+			return cfg, initError("PROJECT_AMBIGUOUS", "Several workspaces and projects found", strings.Join(ContainerChoices(ranked), "; "), "Pass --workspace or --xcodeproj with one of these paths.")
+		}
+		return cfg, initError("PROJECT_REQUIRED", "No workspace or project selected", "", "Run init from the directory containing the .xcworkspace/.xcodeproj.")
+	}
+	if cfg.Workspace != "" && len(info.Workspaces) > 0 && !initContains(info.Workspaces, cfg.Workspace) {
+		return cfg, initError("PROJECT_NOT_FOUND", "Workspace not found", cfg.Workspace, "Available: "+strings.Join(info.Workspaces, ", "))
+	}
+	if cfg.Workspace == "" && cfg.Project != "" && len(info.Projects) > 0 && !initContains(info.Projects, cfg.Project) {
+		return cfg, initError("PROJECT_NOT_FOUND", "Project not found", cfg.Project, "Available: "+strings.Join(info.Projects, ", "))
+	}
+
+	cfg.Scheme = strings.TrimSpace(cfg.Scheme)
+	if cfg.Scheme == "" {
+		return cfg, initError("SCHEME_REQUIRED", "No scheme configured", "", "Pass --scheme or share a scheme in Xcode.")
+	}
+	if len(info.Schemes) > 0 && !stringInSlice(cfg.Scheme, info.Schemes) {
+		return cfg, initError("SCHEME_NOT_FOUND", "Scheme not found", cfg.Scheme, "Available: "+strings.Join(info.Schemes, ", "))
+	}
+
+	cfg.Configuration = strings.TrimSpace(cfg.Configuration)
+	if cfg.Configuration == "" {
+		return cfg, initError("CONFIGURATION_REQUIRED", "No build configuration selected", "", "Pass --configuration (Debug/Release/...).")
+	}
+	if len(info.Configurations) > 0 {
+		resolved, err := MatchConfigurationQuery(info.Configurations, cfg.Configuration)
+		if err != nil {
+			return cfg, initError("CONFIGURATION_NOT_FOUND", "Configuration not found", err.Error(), "")
+		}
+		cfg.Configuration = resolved
+	}
+
+	dst := normalizeDestination(cfg.Destination)
+	explicit := dst.ID != "" || dst.Name != "" || dst.TargetType == TargetLocal
+	constrained := dst.PlatformFamily != "" || (dst.TargetType != "" && dst.TargetType != TargetAuto)
+	if explicit || constrained {
+		resolved, err := resolveDestination(cfg, candidates)
+		if err != nil {
+			return cfg, initError("DESTINATION_NOT_FOUND", "Destination not available", err.Error(), "Check `xcbolt simulator list` / `xcbolt device list`.")
+		}
+		if explicit {
+			cfg = resolved
+		}
+	}
+	return cfg, nil
+}
+
+// initContains matches discovered entries by path or base name.
+func initContains(list []string, v string) bool {
+	for _, item := range list {
+		if item == v || filepath.Base(item) == filepath.Base(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// InitSummary lists the saved selection for display.
+func InitSummary(cfg Config) []string {
+	project := cfg.Workspace
+	if project == "" {
+		project = cfg.Project
+	}
+	dst := cfg.Destination.Name
+	if dst == "" {
+		dst = "auto"
+		if cfg.Destination.PlatformFamily != "" {
+			dst = fmt.Sprintf("auto (%s)", cfg.Destination.PlatformFamily)
+		}
+	} else if cfg.Destination.OS != "" {
+		dst += " (" + cfg.Destination.OS + ")"
+	}
+	return []string{
+		"Project:       " + project,
+		"Scheme:        " + cfg.Scheme,
+		"Configuration: " + cfg.Configuration,
+		"Destination:   " + dst,
+	}
+}
@@ -0,0 +1,125 @@
+package core
+
+import (
+	"errors"
+	"testing"
+)
+
+func initTestInfo() ContextInfo {
+	return ContextInfo{
+		Projects:       []string{"App.xcodeproj"},
+		Schemes:        []string{"App", "AppTests"},
+		Configurations: []string{"Debug", "Release"},
+		Simulators: []Simulator{
+			{UDID: "SIM-15", Name: "iPhone 15", PlatformFamily: PlatformIOS, OSVersion: "17.5", Available: true},
+			{UDID: "SIM-15P", Name: "iPhone 15 Pro", PlatformFamily: PlatformIOS, OSVersion: "17.5", Available: true},
+		},
+	}
+}
+
+func initTestConfig() Config {
+	cfg := DefaultConfig(".")
+	cfg.Project = "App.xcodeproj"
+	cfg.Scheme = "App"
+	cfg.Configuration = "Debug"
+	return cfg
+}
+
+func TestValidateInitConfigErrorCodes(t *testing.T) {
+	info := initTestInfo()
+	candidates := InitDestinationCandidates(info)
+
+	cases := []struct {
+		name   string
+		mutate func(*Config)
+		code   string
+	}{
+		{"no project", func(c *Config) { c.Project = "" }, "PROJECT_REQUIRED"},
+		{"unknown project", func(c *Config) { c.Project = "Other.xcodeproj" }, "PROJECT_NOT_FOUND"},
+		{"no scheme", func(c *Config) { c.Scheme = "" }, "SCHEME_REQUIRED"},
+		{"unknown scheme", func(c *Config) { c.Scheme = "Nope" }, "SCHEME_NOT_FOUND"},
+		{"no configuration", func(c *Config) { c.Configuration = "" }, "CONFIGURATION_REQUIRED"},
+		{"unknown configuration", func(c *Config) { c.Configuration = "Staging" }, "CONFIGURATION_NOT_FOUND"},
+		{"unknown destination", func(c *Config) {
+			c.Destination.TargetType = TargetSimulator
+			c.Destination.Name = "iPad Air"
+		}, "DESTINATION_NOT_FOUND"},
+		{"no device", func(c *Config) { c.Destination.TargetType = TargetDevice }, "DESTINATION_NOT_FOUND"},
+	}
+	for _, tc := range cases {
+		cfg := initTestConfig()
+		tc.mutate(&cfg)
+		_, err := ValidateInitConfig(info, cfg, candidates)
+		var ierr *InitError
+		if !errors.As(err, &ierr) {
+			t.Fatalf("%s: expected InitError, got %v", tc.name, err)
+		}
+		if ierr.Obj.Code != tc.code {
+			t.Fatalf("%s: expected %s, got %s", tc.name, tc.code, ierr.Obj.Code)
+		}
+	}
+}
+
+func TestValidateInitConfigResolvesSelection(t *testing.T) {
+	info := initTestInfo()
+	cfg := initTestConfig()
+	cfg.Configuration = "rel"
+	cfg.Destination.TargetType = TargetSimulator
+	cfg.Destination.Name = "iPhone 15"
+
+	got, err := ValidateInitConfig(info, cfg, InitDestinationCandidates(info))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Configuration != "Release" {
+		t.Fatalf("expected Release, got %q", got.Configuration)
+	}
+	if got.Destination.ID != "SIM-15" || got.Destination.OS != "17.5" {
+		t.Fatalf("expected iPhone 15 pinned, got %+v", got.Destination)
+	}
+}
+
+func TestValidateInitConfigKeepsAutoDestination(t *testing.T) {
+	info := initTestInfo()
+	cfg := initTestConfig()
+	cfg.Destination.PlatformFamily = PlatformIOS
+
+	got, err := ValidateInitConfig(info, cfg, InitDestinationCandidates(info))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Destination.ID != "" || got.Destination.Name != "" {
+		t.Fatalf("expected auto destination to stay unpinned, got %+v", got.Destination)
+	}
+
+	local := initTestConfig()
+	local.Destination.TargetType = TargetLocal
+	got, err = ValidateInitConfig(info, local, nil)
+	if err != nil {
+		t.Fatalf("unexpected error for local: %v", err)
+	}
+	if got.Destination.Name != "My Mac" {
+		t.Fatalf("expected My Mac, got %q", got.Destination.Name)
+	}
+}
+
+func TestValidateInitConfigAmbiguousProject(t *testing.T) {
+	root := t.TempDir()
+	makeContainers(t, root, map[string][]string{"App.xcworkspace": {"App.xcodeproj"}, "App.xcodeproj": nil, "Tool.xcodeproj": nil})
+	info := initTestInfo()
+	info.ProjectRoot = root
+	info.Workspaces = []string{"App.xcworkspace"}
+	info.Projects = []string{"App.xcodeproj", "Tool.xcodeproj"}
+	cfg := initTestConfig()
+	cfg.Project = ""
+
+	_, err := ValidateInitConfig(info, cfg, InitDestinationCandidates(info))
+	var ierr *InitError
+	if !errors.As(err, &ierr) || ierr.Obj.Code != "PROJECT_AMBIGUOUS" {
+		t.Fatalf("expected PROJECT_AMBIGUOUS, got %v", err)
+	}
+	want := "App.xcworkspace (workspace, 0 schemes); App.xcodeproj (project, 0 schemes); Tool.xcodeproj (project, 0 schemes)"
+	if ierr.Obj.Detail != want {
+		t.Fatalf("detail = %q", ierr.Obj.Detail)
+	}
+}
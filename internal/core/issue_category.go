@@ -0,0 +1,132 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Warning categories, in the order the TUI cycles through them.
+const (
+	WarningCategoryDeprecation   = "deprecation"
+	WarningCategoryUnused        = "unused"
+	WarningCategoryConcurrency   = "concurrency"
+	WarningCategoryUnhandled     = "unhandled-files"
+	WarningCategoryDocumentation = "documentation"
+	WarningCategoryOther         = "other"
+)
+
+// defaultWarningPatterns classify warnings by message (case-insensitive).
+var defaultWarningPatterns = []struct {
+	category string
+	patterns []string
+}{
+	{WarningCategoryDeprecation, []string{`is deprecated`, `was deprecated`, `deprecated:`}},
+	{WarningCategoryUnused, []string{`never used`, `never mutated`, `unused variable`, `unused parameter`, `unused function`, `result of call to .* is unused`}},
+	{WarningCategoryConcurrency, []string{`sendable`, `actor-isolated`, `main actor`, `data race`, `concurrency`}},
+	{WarningCategoryUnhandled, []string{`unhandled files?`}},
+	{WarningCategoryDocumentation, []string{`documentation`}},
+}
+
+// IssuesConfig holds Issues tab options.
+type IssuesConfig struct {
+	// CategoryPatterns adds regexes (case-insensitive) per warning category,
+	// e.g. {"concurrency": ["isolated to"], "swiftlint": ["\\(\\w+_rule\\)"]}.
+	// New category names are allowed. They are checked before the built-in
+	// patterns.
+	CategoryPatterns map[string][]string `json:"categoryPatterns,omitempty"`
+	// ExplainCommand is a shell command that explains the selected issue
+	// (x on the Issues tab). It gets the issue and surrounding log lines on
+	// stdin; {message}, {file}, {line}, {col}, and {type} are replaced by
+	// shell-quoted values. Unset, x stops the app as everywhere else.
+	ExplainCommand string `json:"explainCommand,omitempty"`
+	// ExplainTimeout is how long ExplainCommand may run, as a Go duration
+	// (default "30s").
+	ExplainTimeout string `json:"explainTimeout,omitempty"`
+}
+
+type warningRule struct {
+	category string
+	re       *regexp.Regexp
+}
+
+// WarningClassifier assigns warnings to categories.
+type WarningClassifier struct {
+	rules      []warningRule
+	categories []string
+}
+
+// NewWarningClassifier builds a classifier from the built-in patterns and
+// cfg.CategoryPatterns. Invalid patterns are skipped and returned as errors.
+func NewWarningClassifier(cfg IssuesConfig) (*WarningClassifier, []error) {
+	c := &WarningClassifier{}
+	var errs []error
+	seen := map[string]bool{}
+	addCategory := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			c.categories = append(c.categories, name)
+		}
+	}
+	for _, d := range defaultWarningPatterns {
+		addCategory(d.category)
+	}
+	names := make([]string, 0, len(cfg.CategoryPatterns))
+	for name := range cfg.CategoryPatterns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, p := range cfg.CategoryPatterns[name] {
+			re, err := regexp.Compile(`(?i)` + p)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("ignoring invalid issues.categoryPatterns pattern %q: %v", p, err))
+				continue
+			}
+			c.rules = append(c.rules, warningRule{category: name, re: re})
+			addCategory(name)
+		}
+	}
+	for _, d := range defaultWarningPatterns {
+		for _, p := range d.patterns {
+			c.rules = append(c.rules, warningRule{category: d.category, re: regexp.MustCompile(`(?i)` + p)})
+		}
+	}
+	addCategory(WarningCategoryOther)
+	return c, errs
+}
+
+// Classify returns the category of a warning message ("other" when no
+// pattern matches).
+func (c *WarningClassifier) Classify(message string) string {
+	if c == nil {
+		return WarningCategoryOther
+	}
+	for _, r := range c.rules {
+		if r.re.MatchString(message) {
+			return r.category
+		}
+	}
+	return WarningCategoryOther
+}
+
+// Categories lists the categories in display order: the built-in ones,
+// then custom ones by name, then "other".
+func (c *WarningClassifier) Categories() []string {
+	if c == nil {
+		return nil
+	}
+	return c.categories
+}
+
+// WarningCategoryLabel is the short header label of a category.
+func WarningCategoryLabel(category string) string {
+	switch category {
+	case WarningCategoryDeprecation:
+		return "deprecated"
+	case WarningCategoryUnhandled:
+		return "unhandled files"
+	}
+	return strings.ReplaceAll(category, "-", " ")
+}
@@ -0,0 +1,54 @@
+package core
+
+import "testing"
+
+func TestWarningClassifier(t *testing.T) {
+	c, errs := NewWarningClassifier(IssuesConfig{CategoryPatterns: map[string][]string{
+		"swiftlint":   {`\(\w+_rule\)`},
+		"concurrency": {`isolated to`},
+		"broken":      {`(`},
+	}})
+	if len(errs) != 1 {
+		t.Fatalf("errs = %v, want the invalid pattern", errs)
+	}
+	cases := map[string]string{
+		"'UIApplication.keyWindow' was deprecated in iOS 13.0":                      WarningCategoryDeprecation,
+		"'foo()' is deprecated: use bar()":                                          WarningCategoryDeprecation,
+		"initialization of immutable value 'x' was never used":                      WarningCategoryUnused,
+		"variable 'y' was never mutated; consider changing to 'let' constant":       WarningCategoryUnused,
+		"capture of 'self' with non-sendable type 'Model' in a `@Sendable` closure": WarningCategoryConcurrency,
+		"call to method 'load' is isolated to global actor":                         WarningCategoryConcurrency,
+		"found 2 file(s) which are unhandled; explicitly declare them as resources": WarningCategoryOther,
+		"Unhandled files: README.md":                                                WarningCategoryUnhandled,
+		"empty paragraph passed to '@param' command [-Wdocumentation]":              WarningCategoryDocumentation,
+		"Line should be 120 characters or less (line_length_rule)":                  "swiftlint",
+		"Run script build phase 'Lint' will be run during every build":              WarningCategoryOther,
+	}
+	for msg, want := range cases {
+		if got := c.Classify(msg); got != want {
+			t.Errorf("Classify(%q) = %q, want %q", msg, got, want)
+		}
+	}
+	want := []string{WarningCategoryDeprecation, WarningCategoryUnused, WarningCategoryConcurrency, WarningCategoryUnhandled, WarningCategoryDocumentation, "swiftlint", WarningCategoryOther}
+	if got := c.Categories(); len(got) != len(want) {
+		t.Fatalf("Categories() = %v, want %v", got, want)
+	} else {
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("Categories() = %v, want %v", got, want)
+			}
+		}
+	}
+}
+
+func TestIssueFilterState(t *testing.T) {
+	var st State
+	st.SetIssueFilter("/p", WarningCategoryConcurrency)
+	if got := st.IssueFilter("/p"); got != WarningCategoryConcurrency {
+		t.Fatalf("IssueFilter = %q", got)
+	}
+	st.SetIssueFilter("/p", "")
+	if got := st.IssueFilter("/p"); got != "" || len(st.IssueFilters) != 0 {
+		t.Fatalf("cleared filter = %q (%v)", got, st.IssueFilters)
+	}
+}
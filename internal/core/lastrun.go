@@ -0,0 +1,205 @@
+package core
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// LastRunVersion is the schema version of the last-run summary. Fields are
+// only ever added; a change to an existing field bumps it.
+const LastRunVersion = 1
+
+// LastRun is the machine-readable summary of a finished build, run, or test
+// that the CLI and the TUI write to .xcbolt/last-run.json for scripts.
+// Every field is always present.
+type LastRun struct {
+	Version int    `json:"version"`
+	Op      string `json:"op"`
+	Success bool   `json:"success"`
+	// Status is "success", "failure", or CancelStatusCanceled /
+	// CancelStatusTimedOut for an interrupted op.
+	Status string `json:"status"`
+	// ExitCode is xcodebuild's exit status, or 0/1 for success/failure when
+	// it didn't report one
+	ExitCode     int           `json:"exitCode"`
+	DurationMs   int64         `json:"durationMs"`
+	ResultBundle string        `json:"resultBundle"`
+	AppPath      string        `json:"appPath"`
+	BundleID     string        `json:"bundleId"`
+	ErrorCode    string        `json:"errorCode"`
+	ErrorMessage string        `json:"errorMessage"`
+	Counts       LastRunCounts `json:"counts"`
+	FinishedAt   string        `json:"finishedAt"`
+}
+
+// LastRunCounts are the op's compiler diagnostics, test outcomes, and
+// the compile steps of its build
+type LastRunCounts struct {
+	Errors        int `json:"errors"`
+	Warnings      int `json:"warnings"`
+	TestsPassed   int `json:"testsPassed"`
+	TestsFailed   int `json:"testsFailed"`
+	CompiledFiles int `json:"compiledFiles"`
+}
+
+// LastRunPath is the default location of the last-run summary.
+func LastRunPath(projectRoot string) string {
+	return filepath.Join(projectRoot, ".xcbolt", "last-run.json")
+}
+
+// LastRunRecorder builds a LastRun from an op's events. It is safe for
+// concurrent use and nil-safe, so emitters can feed it unconditionally.
+type LastRunRecorder struct {
+	mu        sync.Mutex
+	run       LastRun
+	hasResult bool
+	exitCode  bool
+	started   time.Time
+	lastRaw   string
+	now       func() time.Time
+}
+
+// NewLastRunRecorder starts recording op.
+func NewLastRunRecorder(op string) *LastRunRecorder {
+	return &LastRunRecorder{run: LastRun{Version: LastRunVersion, Op: op}, started: time.Now(), now: time.Now}
+}
+
+// Observe records what ev says about the op: diagnostics, the first error
+// object, and the result. Results of other commands (the build of a run)
+// contribute their result bundle and app.
+func (r *LastRunRecorder) Observe(ev Event) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	switch ev.Type {
+	case "log", "log_raw":
+		if data, _ := ev.Data.(map[string]any); data != nil && data["pretty"] == true {
+			return
+		}
+		// With a log formatter, errors come both as log_raw and log
+		if ev.Msg == r.lastRaw {
+			return
+		}
+		r.lastRaw = ev.Msg
+		switch ClassifyDiagnostic(ev.Msg).Severity {
+		case DiagnosticError:
+			r.run.Counts.Errors++
+		case DiagnosticWarning:
+			r.run.Counts.Warnings++
+		}
+	case "error":
+		if ev.Err != nil && r.run.ErrorCode == "" {
+			r.run.ErrorCode = ev.Err.Code
+			r.run.ErrorMessage = ev.Err.Message
+		}
+	case "result":
+		r.observeResult(ev)
+	}
+}
+
+// observeResult reads a result event; callers hold r.mu
+func (r *LastRunRecorder) observeResult(ev Event) {
+	outer, _ := ev.Data.(map[string]any)
+	data, _ := outer["data"].(map[string]any)
+	for key, field := range map[string]*string{"resultBundle": &r.run.ResultBundle, "appPath": &r.run.AppPath, "bundleId": &r.run.BundleID} {
+		if s, _ := data[key].(string); s != "" {
+			*field = s
+		}
+	}
+	if n, ok := eventInt(data["compiledFiles"]); ok {
+		r.run.Counts.CompiledFiles = int(n)
+	}
+	if ev.Cmd != r.run.Op {
+		return
+	}
+	r.hasResult = true
+	r.run.Success = outer["status"] == "success"
+	r.run.Status, _ = outer["status"].(string)
+	if status, _ := data["status"].(string); status == CancelStatusCanceled || status == CancelStatusTimedOut {
+		r.run.Status = status
+	}
+	if code, ok := eventInt(data["exitCode"]); ok {
+		r.run.ExitCode, r.exitCode = int(code), true
+	}
+	if ms, ok := eventInt(data["durationMs"]); ok {
+		r.run.DurationMs = ms
+	}
+	if n, ok := eventInt(data["passed"]); ok {
+		r.run.Counts.TestsPassed = int(n)
+	}
+	if n, ok := eventInt(data["failed"]); ok {
+		r.run.Counts.TestsFailed = int(n)
+	}
+}
+
+// Finish completes the summary with the op's returned error, for ops that
+// ended before emitting a result.
+func (r *LastRunRecorder) Finish(err error) LastRun {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	run := r.run
+	now := r.now()
+	if !r.hasResult {
+		run.Success = err == nil
+		run.Status = "success"
+		if err != nil {
+			run.Status = "failure"
+		}
+	}
+	if status := CancelStatus(err); status != "" {
+		run.Success, run.Status = false, status
+	}
+	if !run.Success && run.ErrorMessage == "" && err != nil {
+		run.ErrorMessage = err.Error()
+	}
+	if !r.exitCode && !run.Success {
+		run.ExitCode = 1
+	}
+	if run.DurationMs == 0 {
+		run.DurationMs = now.Sub(r.started).Milliseconds()
+	}
+	run.FinishedAt = now.UTC().Format(time.RFC3339)
+	return run
+}
+
+// eventInt reads a number from event data: an int or int64 in process, or
+// a float64 after a JSON round trip
+func eventInt(v any) (int64, bool) {
+	switch n := v.(type) {
+	case int:
+		return int64(n), true
+	case int64:
+		return n, true
+	case float64:
+		return int64(n), true
+	}
+	return 0, false
+}
+
+// WriteLastRun replaces the summary at path atomically.
+func WriteLastRun(path string, run LastRun) error {
+	b, err := json.MarshalIndent(run, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return writeFileAtomic(path, append(b, '\n'), 0o644)
+}
+
+// ReadLastRun reads the summary at path.
+func ReadLastRun(path string) (LastRun, error) {
+	var run LastRun
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return run, err
+	}
+	err = json.Unmarshal(b, &run)
+	return run, err
+}
@@ -0,0 +1,81 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestLastRunRecorder(op string) *LastRunRecorder {
+	r := NewLastRunRecorder(op)
+	clock := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+	r.started = clock
+	r.now = func() time.Time { return clock.Add(3 * time.Second) }
+	return r
+}
+
+func TestLastRunSuccess(t *testing.T) {
+	r := newTestLastRunRecorder("test")
+	r.Observe(Log("test", "/src/App.swift:3:1: warning: var never mutated"))
+	r.Observe(LogPretty("test", "⚠️ /src/App.swift:3:1: var never mutated"))
+	r.Observe(Result("test", true, map[string]any{"resultBundle": "/r/test.xcresult", "exitCode": 0, "durationMs": int64(42300), "passed": 12, "failed": 0}))
+	run := r.Finish(nil)
+
+	want := LastRun{
+		Version: LastRunVersion, Op: "test", Success: true, Status: "success", DurationMs: 42300,
+		ResultBundle: "/r/test.xcresult", Counts: LastRunCounts{Warnings: 1, TestsPassed: 12},
+		FinishedAt: "2026-01-02T15:04:08Z",
+	}
+	if run != want {
+		t.Fatalf("run = %+v\nwant %+v", run, want)
+	}
+
+	path := filepath.Join(t.TempDir(), "out", "last-run.json")
+	if err := WriteLastRun(path, run); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadLastRun(path)
+	if err != nil || got != run {
+		t.Fatalf("read back %+v, %v", got, err)
+	}
+}
+
+func TestLastRunFailure(t *testing.T) {
+	r := newTestLastRunRecorder("run")
+	r.Observe(LogRaw("build", "/src/App.swift:9:5: error: cannot find 'x' in scope"))
+	r.Observe(Log("build", "/src/App.swift:9:5: error: cannot find 'x' in scope"))
+	r.Observe(Err("build", ErrorObject{Code: "XCODEBUILD_FAILED", Message: "xcodebuild failed"}))
+	r.Observe(Result("build", false, map[string]any{"resultBundle": "/r/build.xcresult", "exitCode": 65}))
+	run := r.Finish(errors.New("exit status 65"))
+
+	if run.Success || run.Status != "failure" || run.ExitCode != 1 || run.DurationMs != 3000 {
+		t.Fatalf("run = %+v", run)
+	}
+	if run.ErrorCode != "XCODEBUILD_FAILED" || run.ErrorMessage != "xcodebuild failed" || run.ResultBundle != "/r/build.xcresult" || run.Counts.Errors != 1 {
+		t.Fatalf("run = %+v", run)
+	}
+
+	// Without an error event the returned error is the message
+	r = newTestLastRunRecorder("build")
+	if run := r.Finish(errors.New("no scheme")); run.ErrorMessage != "no scheme" || run.ExitCode != 1 {
+		t.Fatalf("run = %+v", run)
+	}
+}
+
+func TestLastRunCanceled(t *testing.T) {
+	r := newTestLastRunRecorder("test")
+	r.Observe(Err("test", cancelErrorObject("Test", context.DeadlineExceeded, "")))
+	r.Observe(Result("test", false, map[string]any{"status": CancelStatusTimedOut, "passed": float64(3), "failed": float64(1)}))
+	run := r.Finish(fmt.Errorf("test: %w", context.DeadlineExceeded))
+	if run.Success || run.Status != CancelStatusTimedOut || run.ErrorCode != "TIMED_OUT" || run.Counts.TestsPassed != 3 || run.Counts.TestsFailed != 1 {
+		t.Fatalf("run = %+v", run)
+	}
+
+	r = newTestLastRunRecorder("build")
+	if run := r.Finish(context.Canceled); run.Status != CancelStatusCanceled || run.Success {
+		t.Fatalf("run = %+v", run)
+	}
+}
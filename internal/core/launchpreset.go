@@ -0,0 +1,106 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// LaunchPreset is a named set of launch arguments and environment
+// ("scenario"), e.g. {"name": "Staging", "args": ["-useStagingAPI"]}.
+type LaunchPreset struct {
+	Name string            `json:"name"`
+	Args []string          `json:"args,omitempty"`
+	Env  map[string]string `json:"env,omitempty"`
+}
+
+// LaunchPresetNone picks no preset for one run, even when launch.preset
+// sets a default.
+const LaunchPresetNone = "none"
+
+// LaunchPresetCode marks the status event Run emits with the active launch
+// preset in data.preset.
+const LaunchPresetCode = "LAUNCH_PRESET"
+
+// FindLaunchPreset returns the preset of cfg.Launch.Presets named name.
+func FindLaunchPreset(cfg Config, name string) (LaunchPreset, bool) {
+	for _, p := range cfg.Launch.Presets {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return LaunchPreset{}, false
+}
+
+// ActiveLaunchPreset returns the name of the preset a run uses: override
+// when set (LaunchPresetNone for none), else launch.preset. "" means none.
+func ActiveLaunchPreset(cfg Config, override string) string {
+	name := cfg.Launch.Preset
+	if override != "" {
+		name = override
+	}
+	if name == LaunchPresetNone {
+		return ""
+	}
+	return name
+}
+
+// MergeLaunch layers a preset and then one run's arguments and environment
+// over base. Arguments are appended in that order, so for "-key value"
+// defaults the later one wins; environment values are replaced. base is
+// not modified.
+func MergeLaunch(base LaunchConfig, preset LaunchPreset, args []string, env map[string]string) LaunchConfig {
+	out := base
+	out.Options = make([]string, 0, len(base.Options)+len(preset.Args)+len(args))
+	out.Options = append(out.Options, base.Options...)
+	out.Options = append(out.Options, preset.Args...)
+	out.Options = append(out.Options, args...)
+	out.Env = make(map[string]string, len(base.Env)+len(preset.Env)+len(env))
+	for _, m := range []map[string]string{base.Env, preset.Env, env} {
+		for k, v := range m {
+			out.Env[k] = v
+		}
+	}
+	return out
+}
+
+// withLaunchPreset applies the run's launch preset and opts' arguments and
+// environment to cfg.Launch, returning the preset's name ("" for none)
+func withLaunchPreset(cfg Config, opts RunOptions, emit Emitter) (Config, string, error) {
+	name := ActiveLaunchPreset(cfg, opts.Preset)
+	var preset LaunchPreset
+	if name != "" {
+		p, ok := FindLaunchPreset(cfg, name)
+		if !ok {
+			err := fmt.Errorf("unknown launch preset %q", name)
+			emitMaybe(emit, Err("run", ErrorObject{
+				Code:       "LAUNCH_PRESET_UNKNOWN",
+				Message:    "Launch preset not found",
+				Detail:     err.Error(),
+				Suggestion: "Add it to launch.presets or pick one of: " + launchPresetNames(cfg),
+			}))
+			return cfg, "", err
+		}
+		preset = p
+		ev := Status("run", "Launch preset: "+name, map[string]any{"preset": name})
+		ev.Code = LaunchPresetCode
+		emitMaybe(emit, ev)
+	}
+	if name == "" && len(opts.LaunchArgs) == 0 && len(opts.LaunchEnv) == 0 {
+		return cfg, "", nil
+	}
+	cfg.Launch = MergeLaunch(cfg.Launch, preset, opts.LaunchArgs, opts.LaunchEnv)
+	return cfg, name, nil
+}
+
+func launchPresetNames(cfg Config) string {
+	if len(cfg.Launch.Presets) == 0 {
+		return "(none configured)"
+	}
+	names := make([]string, len(cfg.Launch.Presets))
+	for i, p := range cfg.Launch.Presets {
+		names[i] = p.Name
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,72 @@
+package core
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMergeLaunchPrecedence(t *testing.T) {
+	base := LaunchConfig{
+		Options: []string{"-verbose"},
+		Env:     map[string]string{"API": "prod", "LOG": "info"},
+	}
+	preset := LaunchPreset{
+		Name: "Staging",
+		Args: []string{"-useStagingAPI", "YES"},
+		Env:  map[string]string{"API": "staging", "SEED": "1"},
+	}
+	got := MergeLaunch(base, preset, []string{"-useStagingAPI", "NO"}, map[string]string{"SEED": "2"})
+
+	if want := []string{"-verbose", "-useStagingAPI", "YES", "-useStagingAPI", "NO"}; !reflect.DeepEqual(got.Options, want) {
+		t.Fatalf("options = %v, want %v", got.Options, want)
+	}
+	// Preset over base, this run's values over the preset
+	if want := map[string]string{"API": "staging", "LOG": "info", "SEED": "2"}; !reflect.DeepEqual(got.Env, want) {
+		t.Fatalf("env = %v, want %v", got.Env, want)
+	}
+	if len(base.Options) != 1 || base.Env["API"] != "prod" || base.Env["SEED"] != "" {
+		t.Fatalf("base modified: %+v", base)
+	}
+}
+
+func TestActiveLaunchPreset(t *testing.T) {
+	cfg := Config{Launch: LaunchConfig{Preset: "Staging"}}
+	if got := ActiveLaunchPreset(cfg, ""); got != "Staging" {
+		t.Fatalf("default = %q", got)
+	}
+	if got := ActiveLaunchPreset(cfg, "Onboarding"); got != "Onboarding" {
+		t.Fatalf("override = %q", got)
+	}
+	if got := ActiveLaunchPreset(cfg, LaunchPresetNone); got != "" {
+		t.Fatalf("none = %q", got)
+	}
+}
+
+func TestWithLaunchPreset(t *testing.T) {
+	cfg := Config{Launch: LaunchConfig{
+		Options: []string{"-base"},
+		Presets: []LaunchPreset{{Name: "UITest", Args: []string{"-uiTestMode"}}},
+		Preset:  "UITest",
+	}}
+	rec := &recordingEmitter{}
+	got, name, err := withLaunchPreset(cfg, RunOptions{LaunchArgs: []string{"-extra"}}, rec)
+	if err != nil || name != "UITest" {
+		t.Fatalf("name = %q, err = %v", name, err)
+	}
+	if strings.Join(got.Launch.Options, " ") != "-base -uiTestMode -extra" {
+		t.Fatalf("options = %v", got.Launch.Options)
+	}
+	if len(rec.events) != 1 || rec.events[0].Code != LaunchPresetCode {
+		t.Fatalf("events = %+v", rec.events)
+	}
+
+	got, name, _ = withLaunchPreset(cfg, RunOptions{Preset: LaunchPresetNone}, nil)
+	if name != "" || strings.Join(got.Launch.Options, " ") != "-base" {
+		t.Fatalf("none: name = %q, options = %v", name, got.Launch.Options)
+	}
+
+	if _, _, err := withLaunchPreset(cfg, RunOptions{Preset: "Missing"}, rec); err == nil || !strings.Contains(err.Error(), "Missing") {
+		t.Fatalf("unknown preset err = %v", err)
+	}
+}
@@ -0,0 +1,78 @@
+package core
+
+import "path/filepath"
+
+// englishLocaleEnv makes Apple tools print English. Log parsing keys on
+// English text ("** BUILD SUCCEEDED **", "error:", "Test Case … passed"),
+// which a localized system would otherwise translate.
+var englishLocaleEnv = map[string]string{
+	"LANG":        "en_US.UTF-8",
+	"LC_ALL":      "en_US.UTF-8",
+	"LC_MESSAGES": "en_US.UTF-8",
+}
+
+// englishLocaleDefaults are xcodebuild user defaults (-userdefault=value)
+// for Foundation's localization, which follows the AppleLanguages
+// preference rather than LANG.
+var englishLocaleDefaults = []string{"-AppleLanguages=(en)", "-AppleLocale=en_US"}
+
+// parsedTools are the tools whose output xcbolt parses.
+var parsedTools = map[string]bool{
+	"xcodebuild": true,
+	"simctl":     true,
+	"devicectl":  true,
+}
+
+// isParsedTool reports whether path/args runs one of parsedTools, directly
+// or through xcrun.
+func isParsedTool(path string, args []string) bool {
+	return parsedTools[toolName(path, args)]
+}
+
+// toolName is the tool path/args runs, looking through xcrun
+func toolName(path string, args []string) string {
+	name := filepath.Base(path)
+	if name == "xcrun" && len(args) > 0 {
+		name = args[0]
+	}
+	return name
+}
+
+// forceEnglish reports whether spec runs a parsed tool in English
+func forceEnglish(spec CmdSpec) bool {
+	return !spec.PreserveLocale && isParsedTool(spec.Path, spec.Args)
+}
+
+// commandEnv is the extra environment for spec: the English locale for
+// parsed tools (unless the locale is preserved), overridden by spec.Env.
+func commandEnv(spec CmdSpec) map[string]string {
+	if !forceEnglish(spec) {
+		return spec.Env
+	}
+	return withEnv(englishLocaleEnv, spec.Env)
+}
+
+// commandArgs are spec's arguments with englishLocaleDefaults appended for
+// xcodebuild, unless spec.Env sets a locale of its own.
+func commandArgs(spec CmdSpec) []string {
+	if toolName(spec.Path, spec.Args) != "xcodebuild" || !forceEnglish(spec) {
+		return spec.Args
+	}
+	for k := range englishLocaleEnv {
+		if _, ok := spec.Env[k]; ok {
+			return spec.Args
+		}
+	}
+	return append(append([]string{}, spec.Args...), englishLocaleDefaults...)
+}
+
+// xcodebuildArgs are args as RunStreaming passes them to xcodebuild for cfg,
+// so displayed and repro commands match what ran.
+func xcodebuildArgs(cfg Config, args []string) []string {
+	return commandArgs(CmdSpec{
+		Path:           "xcodebuild",
+		Args:           args,
+		Env:            cfg.Xcodebuild.Env,
+		PreserveLocale: cfg.Xcodebuild.PreserveLocale,
+	})
+}
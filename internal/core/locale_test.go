@@ -0,0 +1,140 @@
+package core
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCommandEnvForcesEnglishForParsedTools(t *testing.T) {
+	cases := []struct {
+		spec CmdSpec
+		want map[string]string
+	}{
+		{CmdSpec{Path: "xcrun", Args: []string{"xcodebuild", "build"}}, englishLocaleEnv},
+		{CmdSpec{Path: "xcrun", Args: []string{"simctl", "list"}}, englishLocaleEnv},
+		{CmdSpec{Path: "/usr/bin/xcrun", Args: []string{"devicectl", "list", "devices"}}, englishLocaleEnv},
+		{CmdSpec{Path: "xcrun", Args: []string{"xcodebuild"}, PreserveLocale: true}, nil},
+		{CmdSpec{Path: "/tmp/MyTool", Env: map[string]string{"A": "1"}}, map[string]string{"A": "1"}},
+		{
+			CmdSpec{Path: "xcrun", Args: []string{"xcodebuild"}, Env: map[string]string{"LANG": "de_DE.UTF-8"}},
+			map[string]string{"LANG": "de_DE.UTF-8", "LC_ALL": "en_US.UTF-8", "LC_MESSAGES": "en_US.UTF-8"},
+		},
+	}
+	for _, c := range cases {
+		if got := commandEnv(c.spec); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("commandEnv(%s %v) = %v, want %v", c.spec.Path, c.spec.Args, got, c.want)
+		}
+	}
+}
+
+func TestCommandArgsOverrideXcodebuildLanguage(t *testing.T) {
+	build := []string{"xcodebuild", "build"}
+	if got, want := commandArgs(CmdSpec{Path: "xcrun", Args: build}), append(build, englishLocaleDefaults...); !reflect.DeepEqual(got, want) {
+		t.Fatalf("commandArgs = %v, want %v", got, want)
+	}
+	for _, spec := range []CmdSpec{
+		{Path: "xcrun", Args: build, PreserveLocale: true},
+		{Path: "xcrun", Args: build, Env: map[string]string{"LC_ALL": "de_DE.UTF-8"}},
+		{Path: "xcrun", Args: []string{"simctl", "list"}},
+	} {
+		if got := commandArgs(spec); !reflect.DeepEqual(got, spec.Args) {
+			t.Errorf("commandArgs(%v) = %v, want the args unchanged", spec.Args, got)
+		}
+	}
+}
+
+func TestXcodebuildCommandLineShowsForcedLocale(t *testing.T) {
+	args := []string{"-scheme", "App", "build"}
+	var cfg Config
+	if got, want := xcodebuildCommandLine(cfg, args), "xcrun xcodebuild -scheme App build \"-AppleLanguages=(en)\" -AppleLocale=en_US"; got != want {
+		t.Fatalf("command line = %q, want %q", got, want)
+	}
+	cfg.Xcodebuild.PreserveLocale = true
+	if got, want := xcodebuildCommandLine(cfg, args), "xcrun xcodebuild -scheme App build"; got != want {
+		t.Fatalf("preserved command line = %q, want %q", got, want)
+	}
+	cfg = Config{Xcodebuild: XcodebuildConfig{Env: map[string]string{"LANG": "de_DE.UTF-8"}}}
+	if got, want := xcodebuildCommandLine(cfg, args), "LANG=*** xcrun xcodebuild -scheme App build"; got != want {
+		t.Fatalf("command line with a locale env = %q, want %q", got, want)
+	}
+}
+
+// The simctl helpers take the locale setting from their caller instead of
+// whichever config was loaded last.
+func TestSimctlHelpersHonorPreserveLocale(t *testing.T) {
+	dir := t.TempDir()
+	script := "#!/bin/sh\necho \"$LC_ALL\"\n"
+	if err := os.WriteFile(filepath.Join(dir, "xcrun"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir)
+	t.Setenv("LC_ALL", "de_DE.UTF-8")
+
+	for _, c := range []struct {
+		preserve bool
+		want     string
+	}{{false, "en_US.UTF-8"}, {true, "de_DE.UTF-8"}} {
+		out, err := simctlOutput(context.Background(), c.preserve, "list")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.TrimSpace(out); got != c.want {
+			t.Errorf("preserveLocale=%v: LC_ALL = %q, want %q", c.preserve, got, c.want)
+		}
+	}
+}
+
+// A stand-in xcodebuild prints the captured German log unless run in an
+// English locale. Forcing the locale must bring diagnostics back.
+func TestGermanLocaleLogRestoredByForcedLocale(t *testing.T) {
+	dir := t.TempDir()
+	fake := filepath.Join(dir, "xcodebuild")
+	script := "#!/bin/sh\ncase \"$LC_ALL\" in en_*) cat \"$XCB_EN_LOG\" ;; *) cat \"$XCB_DE_LOG\" ;; esac\n"
+	if err := os.WriteFile(fake, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	wd, _ := os.Getwd()
+	t.Setenv("XCB_EN_LOG", filepath.Join(wd, "testdata", "xcodebuild-en.log"))
+	t.Setenv("XCB_DE_LOG", filepath.Join(wd, "testdata", "xcodebuild-de.log"))
+	t.Setenv("LANG", "de_DE.UTF-8")
+	t.Setenv("LC_ALL", "de_DE.UTF-8")
+
+	classify := func(preserve bool) (diagnostics, errors, compiles int) {
+		_, err := RunStreaming(context.Background(), CmdSpec{
+			Path:           fake,
+			PreserveLocale: preserve,
+			StdoutLine: func(line string) {
+				if classifyXcodebuildLine(line, StreamStdout, nil) == lineDiagnostic {
+					diagnostics++
+				}
+				if ClassifyDiagnostic(line).Severity == DiagnosticError {
+					errors++
+				}
+				if isCompileStep(line) {
+					compiles++
+				}
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return diagnostics, errors, compiles
+	}
+
+	diags, errs, compiles := classify(true)
+	if diags != 0 || errs != 0 {
+		t.Fatalf("German log: %d diagnostics, %d errors; the classifier should not recognize localized output", diags, errs)
+	}
+	if compiles != 2 {
+		t.Fatalf("German log: %d compile steps, want 2 (raw step names are not localized)", compiles)
+	}
+
+	diags, errs, compiles = classify(false)
+	if diags != 2 || errs != 1 || compiles != 2 {
+		t.Fatalf("forced English: %d diagnostics, %d errors, %d compiles; want 2, 1, 2", diags, errs, compiles)
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"net/url"
 	"os/exec"
 	"path"
+	"path/filepath"
+	"regexp"
 	"strings"
 	"sync"
 )
@@ -100,10 +102,12 @@ func (f *logFormatter) Close() error {
 	f.closed = true
 	f.mu.Unlock()
 
+	// Drain the formatter's output before Wait, which closes the pipes and
+	// would drop lines still in flight.
 	_ = f.stdin.Close()
-	err := f.cmd.Wait()
 	<-f.outDone
 	<-f.errDone
+	err := f.cmd.Wait()
 	if f.writeErr != nil {
 		return f.writeErr
 	}
@@ -118,82 +122,175 @@ type logSink struct {
 	rawBuf        []logLine
 	prettyLines   int
 	switchedToRaw bool
+	noise         []*regexp.Regexp
 	mu            sync.Mutex
 
 	spmAction string
 	spmNames  []string
 	spmSeen   map[string]struct{}
+	packages  packageTracker
+	compiled  int
+	timer     compileTimer
+	targets   targetTracker
+	progress  progressTracker
+	// swiftBuild marks swift build/test output (see normalizeSwiftBuildLine).
+	swiftBuild bool
 }
 
 func newXcodebuildLogSink(ctx context.Context, cmd string, cfg Config, emit Emitter) *logSink {
 	sink := &logSink{cmd: cmd, emit: emit, bufferSize: 200}
-	format := normalizeLogFormat(cfg.Xcodebuild.LogFormat)
-	forceRaw := isNDJSONEmitter(emit)
-	if forceRaw {
-		format = LogFormatRaw
+	for _, expr := range cfg.Xcodebuild.SuppressToolNoise {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			emitMaybe(emit, Warn(cmd, fmt.Sprintf("ignoring invalid suppressToolNoise pattern %q: %v", expr, err)))
+			continue
+		}
+		sink.noise = append(sink.noise, re)
+	}
+	// NDJSON consumers get the raw xcodebuild stream (and no formatter noise).
+	if isNDJSONEmitter(emit) {
+		return sink
 	}
 
-	args := cfg.Xcodebuild.LogFormatArgs
-	start := func(name string) (*logFormatter, error) {
-		if _, err := exec.LookPath(name); err != nil {
+	start := func(fc logFormatterCmd) (*logFormatter, error) {
+		if _, err := exec.LookPath(fc.Name); err != nil {
 			return nil, err
 		}
-		return startLogFormatter(ctx, name, args, func(line string) {
+		return startLogFormatter(ctx, fc.Name, fc.Args, func(line string) {
 			sink.handlePrettyLine(line)
 		}, func(line string) {
 			if strings.TrimSpace(line) == "" {
 				return
 			}
-			emitMaybe(emit, Warn(cmd, fmt.Sprintf("%s: %s", name, line)))
+			emitMaybe(emit, Warn(cmd, fmt.Sprintf("%s: %s", filepath.Base(fc.Name), line)))
 		})
 	}
 
-	switch format {
-	case LogFormatRaw:
-		return sink
-	case LogFormatAuto:
-		if f, err := start(string(LogFormatXcpretty)); err == nil {
+	chain, explicit := logFormatterChain(cfg)
+	var firstErr error
+	for _, fc := range chain {
+		f, err := start(fc)
+		if err == nil {
+			if explicit && fc.Name != chain[0].Name {
+				warnFormatterOnce(emit, cmd, chain[0].Name, fmt.Sprintf("log formatter %s not available (%v); using %s", chain[0].Name, firstErr, fc.Name))
+			}
 			sink.formatter = f
 			return sink
 		}
-		if f, err := start(string(LogFormatXcbeautify)); err == nil {
-			sink.formatter = f
-			return sink
+		if firstErr == nil {
+			firstErr = err
 		}
+	}
+	if explicit && firstErr != nil {
+		warnFormatterOnce(emit, cmd, chain[0].Name, fmt.Sprintf("log formatter %s not available (%v); falling back to raw output", chain[0].Name, firstErr))
+	}
+	return sink
+}
+
+// logFormatterCmd is an external process that reads raw xcodebuild output on
+// stdin and writes formatted lines to stdout.
+type logFormatterCmd struct {
+	Name string
+	Args []string
+}
+
+// logFormatterChain returns the formatters to try, in order, for
+// cfg.Xcodebuild.LogFormat. Any value other than the built-in names is run as
+// a command ("xcsift", "/path/to/fmt --flag"), with LogFormatArgs appended.
+// explicit reports whether the user asked for a specific formatter, so that
+// not finding it is worth a warning.
+func logFormatterChain(cfg Config) (chain []logFormatterCmd, explicit bool) {
+	args := cfg.Xcodebuild.LogFormatArgs
+	switch format := normalizeLogFormat(cfg.Xcodebuild.LogFormat); format {
+	case LogFormatRaw:
+		return nil, false
+	case LogFormatAuto:
+		return []logFormatterCmd{{string(LogFormatXcpretty), args}, {string(LogFormatXcbeautify), args}}, false
 	case LogFormatXcpretty:
-		if f, err := start(string(LogFormatXcpretty)); err == nil {
-			sink.formatter = f
-			return sink
-		} else if !forceRaw {
-			emitMaybe(emit, Warn(cmd, fmt.Sprintf("xcpretty not available (%v); falling back to xcbeautify/raw", err)))
-		}
-		if f, err := start(string(LogFormatXcbeautify)); err == nil {
-			sink.formatter = f
-			return sink
-		}
+		return []logFormatterCmd{{string(LogFormatXcpretty), args}, {string(LogFormatXcbeautify), args}}, true
 	case LogFormatXcbeautify:
-		if f, err := start(string(LogFormatXcbeautify)); err == nil {
-			sink.formatter = f
-			return sink
-		} else if !forceRaw {
-			emitMaybe(emit, Warn(cmd, fmt.Sprintf("xcbeautify not available (%v); falling back to raw", err)))
-		}
+		return []logFormatterCmd{{string(LogFormatXcbeautify), args}}, true
 	default:
-		if !forceRaw {
-			emitMaybe(emit, Warn(cmd, fmt.Sprintf("unknown log format %q; falling back to raw", cfg.Xcodebuild.LogFormat)))
+		fields := strings.Fields(string(format))
+		if len(fields) == 0 {
+			return nil, false
 		}
+		custom := append(append([]string{}, fields[1:]...), args...)
+		return []logFormatterCmd{{fields[0], custom}}, true
 	}
+}
 
-	return sink
+// formatterWarned remembers formatters already reported missing, so repeated
+// builds in one session warn only once.
+var formatterWarned sync.Map
+
+func warnFormatterOnce(emit Emitter, cmd, name, msg string) {
+	if _, seen := formatterWarned.LoadOrStore(name, true); seen {
+		return
+	}
+	emitMaybe(emit, Warn(cmd, msg))
+}
+
+// LogFormatterStatus describes which formatter cfg resolves to on this
+// machine. found is false when a requested formatter is not installed.
+func LogFormatterStatus(cfg Config) (detail string, found bool) {
+	chain, explicit := logFormatterChain(cfg)
+	if len(chain) == 0 {
+		return "raw (no formatter)", true
+	}
+	for _, fc := range chain {
+		if p, err := exec.LookPath(fc.Name); err == nil {
+			return strings.TrimSpace(fc.Name + " " + strings.Join(fc.Args, " ") + " (" + p + ")"), true
+		}
+	}
+	if !explicit {
+		return "auto: no xcpretty or xcbeautify found; using raw output", true
+	}
+	return chain[0].Name + " not found in PATH; builds fall back to raw output", false
 }
 
+// HandleLine handles a line from xcodebuild stdout.
 func (s *logSink) HandleLine(line string) {
+	s.handleLine(line, StreamStdout)
+}
+
+// HandleStderrLine handles a line from xcodebuild stderr.
+func (s *logSink) HandleStderrLine(line string) {
+	s.handleLine(line, StreamStderr)
+}
+
+func (s *logSink) handleLine(line string, stream string) {
 	if s == nil {
 		return
 	}
 	if strings.TrimSpace(line) == "" {
 		return
 	}
+	s.mu.Lock()
+	stepProgress, progressDue := s.progress.observe(s.cmd, line)
+	s.mu.Unlock()
+	if progressDue {
+		defer emitMaybe(s.emit, stepProgress)
+	}
+	if s.swiftBuild {
+		line = normalizeSwiftBuildLine(line)
+	}
+	switch classifyXcodebuildLine(line, stream, s.noise) {
+	case lineSuppressed:
+		return
+	case lineToolNoise:
+		s.mu.Lock()
+		s.appendRaw(line, true)
+		s.mu.Unlock()
+		emitMaybe(s.emit, stderrLog(s.cmd, line, true))
+		return
+	}
+	s.mu.Lock()
+	progress, ok := s.packages.observe(s.cmd, line)
+	s.mu.Unlock()
+	if ok {
+		emitMaybe(s.emit, progress)
+	}
 	if s.handleSwiftPM(line) {
 		return
 	}
@@ -207,6 +304,13 @@ func (s *logSink) HandleLine(line string) {
 
 	s.mu.Lock()
 	s.appendRaw(line, false)
+	if isCompileStep(line) || (s.swiftBuild && isSwiftBuildCompileStep(line)) {
+		s.compiled++
+	}
+	s.timer.observe(line)
+	if !s.swiftBuild {
+		s.targets.observe(line)
+	}
 
 	if s.formatter != nil && s.formatter.Failed() && !s.switchedToRaw {
 		s.switchedToRaw = true
@@ -219,7 +323,7 @@ func (s *logSink) HandleLine(line string) {
 	} else {
 		emitLogRaw = true
 		useFormatter = true
-		if isXcodebuildErrorLine(line) {
+		if ClassifyDiagnostic(line).Severity == DiagnosticError {
 			emitRaw = true
 			s.markLastEmitted()
 		}
@@ -230,16 +334,70 @@ func (s *logSink) HandleLine(line string) {
 		emitMaybe(s.emit, Warn(s.cmd, emitWarning))
 	}
 	if emitLogRaw {
-		emitMaybe(s.emit, LogRaw(s.cmd, line))
+		ev := LogRaw(s.cmd, line)
+		if stream == StreamStderr {
+			ev.Data = map[string]any{"stream": StreamStderr}
+		}
+		emitMaybe(s.emit, ev)
 	}
 	if emitRaw {
-		emitMaybe(s.emit, Log(s.cmd, line))
+		if stream == StreamStderr {
+			emitMaybe(s.emit, stderrLog(s.cmd, line, false))
+		} else {
+			emitMaybe(s.emit, Log(s.cmd, line))
+		}
 	}
 	if useFormatter {
 		s.formatter.WriteLine(line)
 	}
 }
 
+// Output streams of the xcodebuild process.
+const (
+	StreamStdout = "stdout"
+	StreamStderr = "stderr"
+)
+
+type xcodebuildLineClass int
+
+const (
+	lineOutput     xcodebuildLineClass = iota // regular build output
+	lineDiagnostic                            // compiler/tool diagnostic worth counting
+	lineToolNoise                             // stderr chatter without a source location
+	lineSuppressed                            // matched cfg.Xcodebuild.SuppressToolNoise
+)
+
+// classifyXcodebuildLine decides how a line from the given stream is surfaced.
+// Stderr lines without a file:line:col location are tool noise unless they
+// look like a hard failure (e.g. "xcodebuild: error: ...").
+func classifyXcodebuildLine(line string, stream string, suppress []*regexp.Regexp) xcodebuildLineClass {
+	for _, re := range suppress {
+		if re.MatchString(line) {
+			return lineSuppressed
+		}
+	}
+	d := ClassifyDiagnostic(line)
+	if d.Located() && d.Severity != DiagnosticNone {
+		return lineDiagnostic
+	}
+	if stream != StreamStderr {
+		return lineOutput
+	}
+	if d.Severity == DiagnosticError {
+		return lineDiagnostic
+	}
+	return lineToolNoise
+}
+
+// stderrLog builds a log event tagged with the stderr stream.
+func stderrLog(cmd, line string, toolNoise bool) Event {
+	ev := LogStream(cmd, line, StreamStderr)
+	if toolNoise {
+		ev.Data.(map[string]any)["toolNoise"] = true
+	}
+	return ev
+}
+
 func (s *logSink) handleSwiftPM(line string) bool {
 	action, name, immediate := parseSwiftPMLine(line)
 	if immediate {
@@ -367,7 +525,57 @@ func repoNameFromURL(raw string) string {
 	return path.Base(raw)
 }
 
+// ResolvedPackages returns the packages listed by xcodebuild after resolution.
+func (s *logSink) ResolvedPackages() []ResolvedPackage {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.packages.ResolvedPackages()
+}
+
+// CompiledFiles returns how many compile steps xcodebuild has started.
+func (s *logSink) CompiledFiles() int {
+	if s == nil {
+		return 0
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.compiled
+}
+
+// CompileTimes returns the per-file compile times found in the output
+// (xcodebuild.timingReport), slowest first.
+func (s *logSink) CompileTimes() []CompileTime {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.timer.Times()
+}
+
+// Targets returns what each target did in the build, the ones that
+// compiled first.
+func (s *logSink) Targets() []TargetBuildInfo {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.targets.Targets()
+}
+
 func (s *logSink) Finalize(runErr error, exitCode int) {
+	if s != nil {
+		s.mu.Lock()
+		ev, ok := s.progress.flush(s.cmd)
+		s.mu.Unlock()
+		if ok {
+			emitMaybe(s.emit, ev)
+		}
+	}
 	if s == nil || s.formatter == nil {
 		if s != nil {
 			if msg := s.flushSPMBatch(); msg != "" {
@@ -418,7 +626,7 @@ func normalizeLogFormat(v string) LogFormat {
 	case string(LogFormatXcbeautify):
 		return LogFormatXcbeautify
 	default:
-		return LogFormat(v)
+		return LogFormat(strings.TrimSpace(v))
 	}
 }
 
@@ -475,32 +683,3 @@ func (s *logSink) handlePrettyLine(line string) {
 	s.mu.Unlock()
 	emitMaybe(s.emit, LogPretty(s.cmd, line))
 }
-
-func isXcodebuildErrorLine(line string) bool {
-	lower := strings.ToLower(line)
-	if strings.Contains(lower, "error:") {
-		return true
-	}
-	if strings.Contains(lower, "fatal error:") {
-		return true
-	}
-	if strings.Contains(lower, "clang: error:") {
-		return true
-	}
-	if strings.Contains(lower, "ld: error") || strings.Contains(lower, "linker command failed") {
-		return true
-	}
-	if strings.Contains(lower, "command swiftcompile failed") || strings.Contains(lower, "command compilec failed") {
-		return true
-	}
-	if strings.Contains(lower, "codesign error") || strings.Contains(lower, "provisioning profile") {
-		return true
-	}
-	if strings.Contains(lower, "no such module") {
-		return true
-	}
-	if strings.Contains(lower, "failed with exit code") {
-		return true
-	}
-	return false
-}
@@ -0,0 +1,205 @@
+package core
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClassifyXcodebuildLine(t *testing.T) {
+	suppress := []*regexp.Regexp{regexp.MustCompile(`^objc\[\d+\]: Class .* is implemented in both`)}
+
+	tests := []struct {
+		name   string
+		line   string
+		stream string
+		want   xcodebuildLineClass
+	}{
+		{"stdout output", "CompileSwift normal arm64 /src/App.swift", StreamStdout, lineOutput},
+		{"stdout diagnostic", "/src/App.swift:12:5: error: cannot find 'foo' in scope", StreamStdout, lineDiagnostic},
+		{"stdout warning without location", "warning: unable to find a platform", StreamStdout, lineOutput},
+		{"stderr diagnostic", "/src/App.swift:12:5: warning: variable 'x' was never used", StreamStderr, lineDiagnostic},
+		{"stderr diagnostic without column", "/src/Info.plist:3: note: using default", StreamStderr, lineDiagnostic},
+		{"stderr path with spaces", "/Users/me/My App/App.swift:1:1: error: expected expression", StreamStderr, lineDiagnostic},
+		{"stderr tool warning", "2026-01-02 10:00:00.000 xcodebuild[123:456] warning: IDETestOperationsObserver", StreamStderr, lineToolNoise},
+		{"stderr plain warning", "--- xcodebuild: WARNING: Using the first of multiple matching destinations:", StreamStderr, lineToolNoise},
+		{"stderr fatal tool error", "xcodebuild: error: Unable to find a destination matching the provided destination specifier", StreamStderr, lineDiagnostic},
+		{"suppressed stderr", "objc[123]: Class AMSupportURLSession is implemented in both /a and /b", StreamStderr, lineSuppressed},
+		{"suppressed wins over diagnostic", "objc[9]: Class X is implemented in both /a:1:1: warning: dup", StreamStdout, lineSuppressed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyXcodebuildLine(tt.line, tt.stream, suppress); got != tt.want {
+				t.Fatalf("classifyXcodebuildLine(%q, %q) = %d, want %d", tt.line, tt.stream, got, tt.want)
+			}
+		})
+	}
+}
+
+type recordingEmitter struct {
+	events []Event
+}
+
+func (r *recordingEmitter) Emit(ev Event) { r.events = append(r.events, ev) }
+
+func TestLogSinkTagsStderrToolNoise(t *testing.T) {
+	rec := &recordingEmitter{}
+	cfg := Config{Xcodebuild: XcodebuildConfig{
+		LogFormat:         "raw",
+		SuppressToolNoise: []string{`DVTPlugInManager`, `(`},
+	}}
+	sink := newXcodebuildLogSink(context.Background(), "build", cfg, rec)
+	if len(rec.events) != 1 || rec.events[0].Type != "warning" {
+		t.Fatalf("expected one warning for the invalid pattern, got %+v", rec.events)
+	}
+	rec.events = nil
+
+	sink.HandleStderrLine("xcodebuild[1:2] DVTPlugInManager: skipping plug-in")
+	sink.HandleStderrLine("--- xcodebuild: WARNING: Using the first of multiple matching destinations:")
+	sink.HandleStderrLine("/src/App.swift:1:2: warning: unused")
+	sink.HandleLine("Build Succeeded")
+
+	if len(rec.events) != 3 {
+		t.Fatalf("expected 3 events, got %+v", rec.events)
+	}
+	noise, _ := rec.events[0].Data.(map[string]any)
+	if noise["stream"] != StreamStderr || noise["toolNoise"] != true {
+		t.Fatalf("expected tool noise tag, got %+v", rec.events[0])
+	}
+	diag, _ := rec.events[1].Data.(map[string]any)
+	if diag["stream"] != StreamStderr || diag["toolNoise"] != nil {
+		t.Fatalf("expected stderr diagnostic without noise tag, got %+v", rec.events[1])
+	}
+	if rec.events[2].Data != nil {
+		t.Fatalf("stdout line should not be tagged, got %+v", rec.events[2])
+	}
+}
+
+// lockedEmitter records events from the formatter's output goroutines too.
+type lockedEmitter struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (r *lockedEmitter) Emit(ev Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, ev)
+}
+
+func writeFormatterScript(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "upper-fmt")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+body+"\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLogSinkPipesThroughCustomFormatter(t *testing.T) {
+	script := writeFormatterScript(t, `echo "formatter ready" >&2
+prefix="$1"
+while IFS= read -r line; do echo "$prefix$line" | tr '[:lower:]' '[:upper:]'; done`)
+	rec := &lockedEmitter{}
+	cfg := Config{Xcodebuild: XcodebuildConfig{LogFormat: script, LogFormatArgs: []string{"fmt: "}}}
+	sink := newXcodebuildLogSink(context.Background(), "build", cfg, rec)
+	if sink.formatter == nil {
+		t.Fatalf("expected custom formatter to start")
+	}
+	sink.HandleLine("CompileSwift normal arm64 /src/App.swift")
+	sink.HandleLine("Ld /build/App normal")
+	sink.Finalize(nil, 0)
+
+	var pretty, raw, warns []string
+	for _, ev := range rec.events {
+		switch {
+		case ev.Type == "log_raw":
+			raw = append(raw, ev.Msg)
+		case ev.Type == "log":
+			if d, _ := ev.Data.(map[string]any); d["pretty"] == true {
+				pretty = append(pretty, ev.Msg)
+			}
+		case ev.Type == "warning":
+			warns = append(warns, ev.Msg)
+		}
+	}
+	wantPretty := []string{"FMT: COMPILESWIFT NORMAL ARM64 /SRC/APP.SWIFT", "FMT: LD /BUILD/APP NORMAL"}
+	if strings.Join(pretty, "\n") != strings.Join(wantPretty, "\n") {
+		t.Fatalf("pretty = %q, want %q", pretty, wantPretty)
+	}
+	if len(raw) != 2 || raw[0] != "CompileSwift normal arm64 /src/App.swift" {
+		t.Fatalf("raw lines should be kept for phase structure, got %q", raw)
+	}
+	if len(warns) != 1 || warns[0] != "upper-fmt: formatter ready" {
+		t.Fatalf("expected formatter stderr as a warning, got %q", warns)
+	}
+}
+
+func TestLogSinkFallsBackWhenFormatterMissing(t *testing.T) {
+	cfg := Config{Xcodebuild: XcodebuildConfig{LogFormat: "xcbolt-test-missing-formatter --pretty"}}
+	for run := 0; run < 2; run++ {
+		rec := &lockedEmitter{}
+		sink := newXcodebuildLogSink(context.Background(), "build", cfg, rec)
+		sink.HandleLine("Build Succeeded")
+		sink.Finalize(nil, 0)
+
+		warnings := 0
+		for _, ev := range rec.events {
+			if ev.Type == "warning" {
+				warnings++
+				if !strings.Contains(ev.Msg, "xcbolt-test-missing-formatter not available") {
+					t.Fatalf("unexpected warning %q", ev.Msg)
+				}
+			}
+		}
+		if want := map[int]int{0: 1, 1: 0}[run]; warnings != want {
+			t.Fatalf("run %d: %d warnings, want %d", run, warnings, want)
+		}
+		last := rec.events[len(rec.events)-1]
+		if last.Type != "log" || last.Msg != "Build Succeeded" {
+			t.Fatalf("run %d: expected raw output, got %+v", run, last)
+		}
+	}
+}
+
+func TestLogFormatterStopsWithOperation(t *testing.T) {
+	script := writeFormatterScript(t, "exec sleep 30")
+	ctx, cancel := context.WithCancel(context.Background())
+	sink := newXcodebuildLogSink(ctx, "build", Config{Xcodebuild: XcodebuildConfig{LogFormat: script}}, &lockedEmitter{})
+	if sink.formatter == nil {
+		t.Fatalf("expected formatter to start")
+	}
+	cancel()
+	done := make(chan struct{})
+	go func() {
+		sink.Finalize(context.Canceled, 0)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("formatter outlived the canceled operation")
+	}
+}
+
+func TestDoctorLogFormatter(t *testing.T) {
+	script := writeFormatterScript(t, "cat")
+	c, err := RunDoctorCheck(context.Background(), t.TempDir(), Config{Xcodebuild: XcodebuildConfig{LogFormat: script}}, CheckLogFormatter)
+	if err != nil || c.Status != DoctorPass || !strings.Contains(c.Detail, script) {
+		t.Fatalf("expected pass for installed formatter, got %+v %v", c, err)
+	}
+	c, _ = RunDoctorCheck(context.Background(), t.TempDir(), Config{Xcodebuild: XcodebuildConfig{LogFormat: "xcbolt-test-no-such-fmt"}}, CheckLogFormatter)
+	if c.Status != DoctorWarn || c.FixHint == "" {
+		t.Fatalf("expected warning for missing formatter, got %+v", c)
+	}
+	c, _ = RunDoctorCheck(context.Background(), t.TempDir(), Config{Xcodebuild: XcodebuildConfig{LogFormat: "raw"}}, CheckLogFormatter)
+	if c.Status != DoctorPass {
+		t.Fatalf("raw should always pass, got %+v", c)
+	}
+}
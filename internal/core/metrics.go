@@ -0,0 +1,233 @@
+package core
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MetricsConfig holds the opt-in build metrics log: one JSON line per
+// finished build, run, or test for team tooling, without file names,
+// messages, or usernames.
+type MetricsConfig struct {
+	// Enabled turns the log on (default off).
+	Enabled bool `json:"enabled,omitempty"`
+	// Path is the log file, relative to the project root (default
+	// .xcbolt/metrics.ndjson).
+	Path string `json:"path,omitempty"`
+	// Salt is mixed into the project and scheme hashes. Share one across
+	// a team to group a project's entries from every developer; unset, a
+	// random salt is kept per user.
+	Salt string `json:"salt,omitempty"`
+}
+
+// MetricsVersion is the schema version of a metrics line.
+const MetricsVersion = 1
+
+// MetricsEntry is one line of the metrics log. Paths and names are only
+// recorded as salted hashes. CompiledFiles counts the compile steps that
+// started, so a failed build records how far it got.
+type MetricsEntry struct {
+	Version         int    `json:"version"`
+	ProjectHash     string `json:"projectHash"`
+	Op              string `json:"op"`
+	SchemeHash      string `json:"schemeHash,omitempty"`
+	Configuration   string `json:"configuration,omitempty"`
+	DestinationKind string `json:"destinationKind,omitempty"`
+	PlatformFamily  string `json:"platformFamily,omitempty"`
+	DurationMs      int64  `json:"durationMs"`
+	Success         bool   `json:"success"`
+	Errors          int    `json:"errors"`
+	CompiledFiles   int    `json:"compiledFiles"`
+	Timestamp       string `json:"timestamp"`
+}
+
+// MetricsPath is where the metrics log of projectRoot is written.
+func MetricsPath(projectRoot string, cfg Config) string {
+	if cfg.Metrics.Path == "" {
+		return filepath.Join(projectRoot, ".xcbolt", "metrics.ndjson")
+	}
+	return absJoin(projectRoot, cfg.Metrics.Path)
+}
+
+// NewMetricsEntry describes a finished op from its last-run summary.
+// projectID names the project the same way on every machine (see
+// metricsProjectID).
+func NewMetricsEntry(projectID string, cfg Config, run LastRun, salt string) MetricsEntry {
+	e := MetricsEntry{
+		Version:         MetricsVersion,
+		ProjectHash:     saltedHash(salt, projectID),
+		Op:              run.Op,
+		Configuration:   cfg.Configuration,
+		DestinationKind: string(cfg.Destination.Kind),
+		PlatformFamily:  string(cfg.Destination.PlatformFamily),
+		DurationMs:      run.DurationMs,
+		Success:         run.Success,
+		Errors:          run.Counts.Errors,
+		CompiledFiles:   run.Counts.CompiledFiles,
+		Timestamp:       run.FinishedAt,
+	}
+	if cfg.Scheme != "" {
+		e.SchemeHash = saltedHash(salt, cfg.Scheme)
+	}
+	return e
+}
+
+// metricsProjectID identifies projectRoot without machine-specific paths:
+// the checkout's origin URL and the root's path inside the checkout, or
+// the checkout's directory name when there is no origin. Outside git it is
+// the root's directory name.
+func metricsProjectID(projectRoot string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if resolved, err := filepath.EvalSymlinks(projectRoot); err == nil {
+		projectRoot = resolved
+	}
+	top, err := captureCmd(ctx, "git", "-C", projectRoot, "rev-parse", "--show-toplevel")
+	if err != nil || top == "" {
+		return filepath.Base(projectRoot)
+	}
+	rel, err := filepath.Rel(top, projectRoot)
+	if err != nil {
+		return filepath.Base(projectRoot)
+	}
+	repo := filepath.Base(top)
+	if remote, err := captureCmd(ctx, "git", "-C", projectRoot, "config", "--get", "remote.origin.url"); err == nil && remote != "" {
+		repo = normalizeRemoteURL(remote)
+	}
+	if rel == "." {
+		return repo
+	}
+	return repo + "/" + filepath.ToSlash(rel)
+}
+
+// normalizeRemoteURL reduces a git remote to host/path, so the SSH and
+// HTTPS forms of one repository match
+func normalizeRemoteURL(remote string) string {
+	u := strings.TrimSuffix(strings.TrimSpace(remote), "/")
+	u = strings.TrimSuffix(u, ".git")
+	if i := strings.Index(u, "://"); i >= 0 {
+		u = u[i+3:]
+	} else if host, path, ok := strings.Cut(u, ":"); ok {
+		u = host + "/" + path
+	}
+	if _, rest, ok := strings.Cut(u, "@"); ok {
+		u = rest
+	}
+	return u
+}
+
+// saltedHash is a short, non-reversible id for value
+func saltedHash(salt, value string) string {
+	sum := sha256.Sum256([]byte(salt + "\x00" + value))
+	return hex.EncodeToString(sum[:8])
+}
+
+// RecordMetrics appends run to the metrics log when metrics.enabled is
+// set. Canceled ops are left out.
+func RecordMetrics(projectRoot string, cfg Config, run LastRun) error {
+	if !cfg.Metrics.Enabled || run.Status == CancelStatusCanceled || run.Status == CancelStatusTimedOut {
+		return nil
+	}
+	salt, err := metricsSalt(cfg)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(NewMetricsEntry(metricsProjectID(projectRoot), cfg, run, salt))
+	if err != nil {
+		return err
+	}
+	path := MetricsPath(projectRoot, cfg)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// metricsSalt returns metrics.salt, or the user's random salt, created on
+// first use next to the user state
+func metricsSalt(cfg Config) (string, error) {
+	if cfg.Metrics.Salt != "" {
+		return cfg.Metrics.Salt, nil
+	}
+	statePath, err := UserStatePath()
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(filepath.Dir(statePath), "metrics-salt")
+	if b, err := os.ReadFile(path); err == nil && len(strings.TrimSpace(string(b))) > 0 {
+		return strings.TrimSpace(string(b)), nil
+	}
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	salt := hex.EncodeToString(buf)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+	return salt, os.WriteFile(path, []byte(salt+"\n"), 0o600)
+}
+
+// ReadMetrics reads the metrics log, oldest first, keeping entries at or
+// after since (zero keeps all). Malformed lines are skipped.
+func ReadMetrics(path string, since time.Time) ([]MetricsEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+	var entries []MetricsEntry
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var e MetricsEntry
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil || e.Op == "" {
+			continue
+		}
+		if !since.IsZero() {
+			if t, err := time.Parse(time.RFC3339, e.Timestamp); err != nil || t.Before(since) {
+				continue
+			}
+		}
+		entries = append(entries, e)
+	}
+	return entries, sc.Err()
+}
+
+// ParseSince reads a look-back window such as "7d", "12h", or "90m" and
+// returns its start before now.
+func ParseSince(s string, now time.Time) (time.Time, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return time.Time{}, fmt.Errorf("invalid --since %q (e.g. 7d, 12h)", s)
+		}
+		return now.AddDate(0, 0, -n), nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return time.Time{}, fmt.Errorf("invalid --since %q (e.g. 7d, 12h)", s)
+	}
+	return now.Add(-d), nil
+}
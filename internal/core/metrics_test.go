@@ -0,0 +1,156 @@
+package core
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecordMetrics(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	root := filepath.Join(t.TempDir(), "alice-secret-app")
+	cfg := Config{Scheme: "SecretApp", Configuration: "Debug", Destination: Destination{Kind: DestSimulator, PlatformFamily: PlatformIOS}}
+
+	r := newTestLastRunRecorder("build")
+	r.Observe(Log("build", "/Users/alice/SecretApp/App.swift:3:1: error: cannot find 'x' in scope"))
+	r.Observe(Result("build", false, map[string]any{"compiledFiles": 7}))
+	run := r.Finish(nil)
+
+	if err := RecordMetrics(root, cfg, run); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(MetricsPath(root, cfg)); !os.IsNotExist(err) {
+		t.Fatalf("metrics written while disabled: %v", err)
+	}
+
+	cfg.Metrics.Enabled = true
+	if err := RecordMetrics(root, cfg, run); err != nil {
+		t.Fatal(err)
+	}
+	canceled := run
+	canceled.Status = CancelStatusCanceled
+	if err := RecordMetrics(root, cfg, canceled); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(root, ".xcbolt", "metrics.ndjson")
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, leak := range []string{"alice", "SecretApp", "App.swift", "cannot find"} {
+		if strings.Contains(string(raw), leak) {
+			t.Fatalf("metrics line contains %q: %s", leak, raw)
+		}
+	}
+	entries, err := ReadMetrics(path, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("entries = %+v, want the failed build only", entries)
+	}
+	e := entries[0]
+	if e.Op != "build" || e.Success || e.Errors != 1 || e.CompiledFiles != 7 || e.Configuration != "Debug" ||
+		e.DestinationKind != "simulator" || e.PlatformFamily != "ios" || e.DurationMs != 3000 || e.Timestamp != "2026-01-02T15:04:08Z" {
+		t.Fatalf("entry = %+v", e)
+	}
+	if e.ProjectHash == "" || e.SchemeHash == "" {
+		t.Fatalf("missing hashes: %+v", e)
+	}
+
+	// The per-user salt is kept, so a project keeps its hash.
+	if err := RecordMetrics(root, cfg, run); err != nil {
+		t.Fatal(err)
+	}
+	entries, _ = ReadMetrics(path, time.Time{})
+	if len(entries) != 2 || entries[1].ProjectHash != e.ProjectHash {
+		t.Fatalf("entries = %+v", entries)
+	}
+}
+
+func TestMetricsSaltChangesHashes(t *testing.T) {
+	run := LastRun{Op: "test"}
+	a := NewMetricsEntry("/p", Config{Scheme: "App"}, run, "one")
+	b := NewMetricsEntry("/p", Config{Scheme: "App"}, run, "two")
+	if a.ProjectHash == b.ProjectHash || a.SchemeHash == b.SchemeHash {
+		t.Fatalf("hashes ignore the salt: %+v %+v", a, b)
+	}
+	if c := NewMetricsEntry("/p", Config{Scheme: "App"}, run, "one"); c != a {
+		t.Fatalf("hashes not stable: %+v %+v", a, c)
+	}
+}
+
+func TestMetricsProjectIDIsMachineIndependent(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	clone := func(remote string) string {
+		dir := filepath.Join(t.TempDir(), "checkout")
+		for _, args := range [][]string{{"init", "-q", dir}, {"-C", dir, "remote", "add", "origin", remote}} {
+			if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+				t.Fatalf("git %v: %v\n%s", args, err, out)
+			}
+		}
+		if err := os.MkdirAll(filepath.Join(dir, "ios"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		return filepath.Join(dir, "ios")
+	}
+	a := metricsProjectID(clone("git@github.com:acme/app.git"))
+	b := metricsProjectID(clone("https://github.com/acme/app"))
+	if a != "github.com/acme/app/ios" || b != a {
+		t.Fatalf("project ids = %q, %q; want github.com/acme/app/ios for both", a, b)
+	}
+	if id := metricsProjectID(filepath.Join(t.TempDir(), "Plain")); id != "Plain" {
+		t.Fatalf("project id outside git = %q", id)
+	}
+}
+
+func TestReadMetricsSince(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.ndjson")
+	lines := []string{
+		`{"version":1,"op":"build","timestamp":"2026-01-01T10:00:00Z"}`,
+		`not json`,
+		`{"version":1,"op":"test","timestamp":"2026-01-08T10:00:00Z"}`,
+	}
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	since, err := ParseSince("7d", time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, err := ReadMetrics(path, since)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Op != "test" {
+		t.Fatalf("entries = %+v", entries)
+	}
+	if entries, _ := ReadMetrics(filepath.Join(t.TempDir(), "none.ndjson"), since); entries != nil {
+		t.Fatalf("missing log = %+v", entries)
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	for in, want := range map[string]time.Time{
+		"7d":  time.Date(2026, 1, 3, 12, 0, 0, 0, time.UTC),
+		"12h": time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC),
+		"90m": time.Date(2026, 1, 10, 10, 30, 0, 0, time.UTC),
+	} {
+		got, err := ParseSince(in, now)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("ParseSince(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "d", "-1d", "week"} {
+		if _, err := ParseSince(in, now); err == nil {
+			t.Errorf("ParseSince(%q) accepted", in)
+		}
+	}
+}
@@ -9,7 +9,10 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -19,6 +22,27 @@ type BuildResult struct {
 	Duration     time.Duration `json:"duration"`
 	AppPath      string        `json:"appPath,omitempty"`
 	BundleID     string        `json:"bundleId,omitempty"`
+	// Command is the xcodebuild invocation, with env values redacted.
+	Command string `json:"command,omitempty"`
+	// Packages lists the SwiftPM dependencies resolved for the build.
+	Packages []ResolvedPackage `json:"packages,omitempty"`
+	// Products lists the apps and app extensions in TARGET_BUILD_DIR.
+	Products []ProductInfo `json:"products,omitempty"`
+	// CompiledFiles counts the compile steps xcodebuild ran (0 for a fully
+	// up-to-date build), including those of a failed build.
+	CompiledFiles int `json:"compiledFiles"`
+	// CompileTimes lists each compile unit's time, slowest first, when
+	// xcodebuild.timingReport is on.
+	CompileTimes []CompileTime `json:"compileTimes,omitempty"`
+	// Targets lists what each target did, the ones that compiled first and
+	// cached (up-to-date) ones after.
+	Targets []TargetBuildInfo `json:"targets,omitempty"`
+	// MetaPath is the build's environment record (see ResultMeta).
+	MetaPath string `json:"metaPath,omitempty"`
+	// Failure and Signal say how a failed xcodebuild ended (see
+	// XcodebuildExitError).
+	Failure XcodebuildFailure `json:"failure,omitempty"`
+	Signal  string            `json:"signal,omitempty"`
 }
 
 type RunResult struct {
@@ -28,6 +52,17 @@ type RunResult struct {
 	PID          int    `json:"pid,omitempty"`
 	Target       string `json:"target"`
 	UDID         string `json:"udid,omitempty"`
+	// Phase is the step the run ended in; a canceled run stops at the step
+	// it interrupted.
+	Phase RunPhase `json:"phase,omitempty"`
+	// Timings is how long each step of a successful run took.
+	Timings []RunStepTiming `json:"phases,omitempty"`
+	// Preset is the launch preset the app was launched with.
+	Preset string `json:"preset,omitempty"`
+	// TerminationReason is how the app of a simulator console run ended (a
+	// Termination constant, "" while it runs), and ExitCode its exit status.
+	TerminationReason string `json:"terminationReason,omitempty"`
+	ExitCode          int    `json:"exitCode,omitempty"`
 }
 
 type TestResult struct {
@@ -35,11 +70,29 @@ type TestResult struct {
 	ExitCode     int           `json:"exitCode"`
 	Duration     time.Duration `json:"duration"`
 	Summary      TestSummary   `json:"summary"`
+	// Command is the xcodebuild invocation, with env values redacted.
+	Command string `json:"command,omitempty"`
+	// Flaky lists tests that failed and then passed on retry.
+	Flaky []string `json:"flaky,omitempty"`
+	// RetryResultBundle is set when failed tests were re-run separately.
+	RetryResultBundle string `json:"retryResultBundle,omitempty"`
+	// Packages lists the SwiftPM dependencies resolved for the test build.
+	Packages []ResolvedPackage `json:"packages,omitempty"`
+	// MetaPath is the test run's environment record (see ResultMeta).
+	MetaPath string `json:"metaPath,omitempty"`
+	// Coverage is set when test.codeCoverage is on and the report parsed.
+	Coverage *CoverageSummary `json:"coverage,omitempty"`
+	// Changes compares the failing tests with the scheme's previous run.
+	Changes *TestChanges `json:"changes,omitempty"`
+	// Failure and Signal say how a failed xcodebuild ended (see
+	// XcodebuildExitError).
+	Failure XcodebuildFailure `json:"failure,omitempty"`
+	Signal  string            `json:"signal,omitempty"`
 }
 
 func EnsureBuildDirs(cfg Config) error {
-	if cfg.DerivedDataPath != "" {
-		if err := os.MkdirAll(cfg.DerivedDataPath, 0o755); err != nil {
+	if dir := DerivedDataDir(cfg); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
 			return err
 		}
 	}
@@ -51,68 +104,135 @@ func EnsureBuildDirs(cfg Config) error {
 	return nil
 }
 
+// Build runs xcodebuild build, wrapped by the preBuild and postBuild hooks.
+// A stale generated project is regenerated first when generate.auto is set.
+// Result bundles beyond results.retention are pruned afterwards. With
+// cfg.TargetOverride, the target is built instead of the scheme; the
+// returned config no longer carries it.
 func Build(ctx context.Context, projectRoot string, cfg Config, emit Emitter) (BuildResult, Config, error) {
-	if cfg2, err := ensureSchemeAndConfigFromFS(projectRoot, cfg, emit); err == nil {
-		cfg = cfg2
-	} else {
-		emitMaybe(emit, Err("build", ErrorObject{
-			Code:       "SCHEME_REQUIRED",
-			Message:    "No scheme configured",
-			Detail:     err.Error(),
-			Suggestion: "Run `xcbolt init` or pass --scheme.",
-		}))
+	finish := resultsOp(ctx, cfg, "build", emit)
+	res, cfg, err := buildWithHooks(ctx, projectRoot, cfg, emit)
+	finish(cfg)
+	cfg.TargetOverride, cfg.TargetProject, cfg.targetDestination = "", "", ""
+	return res, cfg, err
+}
+
+func buildWithHooks(ctx context.Context, projectRoot string, cfg Config, emit Emitter) (BuildResult, Config, error) {
+	cfg, err := maybeGenerateProject(ctx, projectRoot, cfg, "build", emit)
+	if err != nil {
 		return BuildResult{}, cfg, err
 	}
+	if err := runPreHook(ctx, projectRoot, cfg, "build", emit); err != nil {
+		return BuildResult{}, cfg, err
+	}
+	res, cfg, err := build(ctx, projectRoot, cfg, emit)
+	runPostHook(ctx, projectRoot, cfg, "build", hookOutcome{success: err == nil, resultBundle: res.ResultBundle, appPath: res.AppPath}, emit)
+	return res, cfg, err
+}
 
-	cfg, _ = ResolveDestinationIfNeeded(ctx, projectRoot, cfg, emit)
-	cfg.Destination = normalizeDestination(cfg.Destination)
+func build(ctx context.Context, projectRoot string, cfg Config, emit Emitter) (BuildResult, Config, error) {
+	started := time.Now()
+	if cfg.TargetOverride != "" {
+		var err error
+		cfg.Destination = normalizeDestination(cfg.Destination)
+		if cfg, err = resolveTargetBuild(ctx, projectRoot, cfg, emit); err != nil {
+			return BuildResult{}, cfg, err
+		}
+	} else {
+		if pkg, ok, err := swiftPackageFor(ctx, projectRoot, cfg); ok {
+			if err != nil {
+				emitMaybe(emit, Err("build", swiftPackageErrorObject(err)))
+				return BuildResult{}, cfg, err
+			}
+			return swiftPackageBuild(ctx, projectRoot, cfg, pkg, emit)
+		}
+		if cfg2, err := ensureSchemeAndConfigFromFS(projectRoot, cfg, emit); err == nil {
+			cfg = cfg2
+		} else {
+			emitMaybe(emit, Err("build", schemeRequiredError(err)))
+			return BuildResult{}, cfg, err
+		}
+
+		cfg, _ = ResolveDestinationIfNeeded(ctx, projectRoot, cfg, emit)
+		cfg.Destination = normalizeDestination(cfg.Destination)
+		if err := CheckDestinationCompatible(ctx, projectRoot, cfg, "build", emit); err != nil {
+			return BuildResult{}, cfg, err
+		}
+	}
 
+	var xcode XcodeVersion
+	if !cfg.Xcodebuild.DryRun {
+		xcode = checkDerivedDataXcode(ctx, cfg, "build", emit)
+	}
 	if err := EnsureBuildDirs(cfg); err != nil {
 		return BuildResult{}, cfg, err
 	}
 
-	bundlePath := filepath.Join(cfg.ResultBundlesPath, time.Now().Format("20060102-150405")+".xcresult")
+	bundlePath := resultBundlePath(cfg, "build", time.Now(), emit)
 	args := baseXcodebuildArgs(projectRoot, cfg)
 	args = append(args,
-		"-derivedDataPath", cfg.DerivedDataPath,
+		"-derivedDataPath", DerivedDataDir(cfg),
 		"-resultBundlePath", bundlePath,
 		"build",
 	)
+	args = append(args, timingReportArgs(cfg)...)
 	args = append(args, cfg.Xcodebuild.Options...)
 
-	emitMaybe(emit, Status("build", "Build started", map[string]any{"resultBundle": bundlePath}))
+	command := xcodebuildCommandLine(cfg, args)
+	if cfg.TargetOverride != "" {
+		emitMaybe(emit, Status("build", "Build started (target "+cfg.TargetOverride+")", map[string]any{"resultBundle": bundlePath, "target": cfg.TargetOverride}))
+	} else {
+		emitMaybe(emit, Status("build", "Build started", map[string]any{"resultBundle": bundlePath}))
+	}
+	emitMaybe(emit, XcodebuildCommand("build", command))
 	if cfg.Xcodebuild.DryRun {
-		cmdLine := formatCmd("xcodebuild", args)
+		cmdLine := formatCmd("xcodebuild", xcodebuildArgs(cfg, args))
 		emitMaybe(emit, Log("build", "Dry run: "+cmdLine))
 		emitMaybe(emit, Result("build", true, map[string]any{"exitCode": 0, "resultBundle": bundlePath, "dryRun": true}))
 		cfg.LastResultBundle = bundlePath
-		return BuildResult{ResultBundle: bundlePath, ExitCode: 0, Duration: 0}, cfg, nil
+		return BuildResult{ResultBundle: bundlePath, ExitCode: 0, Duration: 0, Command: command}, cfg, nil
 	}
 	sink := newXcodebuildLogSink(ctx, "build", cfg, emit)
 	res, err := RunStreaming(ctx, CmdSpec{
-		Path:       "xcrun",
-		Args:       append([]string{"xcodebuild"}, args...),
-		Dir:        projectRoot,
-		Env:        cfg.Xcodebuild.Env,
-		StdoutLine: sink.HandleLine,
-		StderrLine: sink.HandleLine,
+		Path:           "xcrun",
+		Args:           append([]string{"xcodebuild"}, args...),
+		PreserveLocale: cfg.Xcodebuild.PreserveLocale,
+		Dir:            projectRoot,
+		Env:            cfg.Xcodebuild.Env,
+		StdoutLine:     sink.HandleLine,
+		StderrLine:     sink.HandleStderrLine,
 	})
 	sink.Finalize(err, res.ExitCode)
+	err = xcodebuildExitError(res, err)
+	packages := resolvedPackagesFor(projectRoot, cfg, sink)
 
 	cfg.LastResultBundle = bundlePath
+	metaPath := writeResultMeta(projectRoot, cfg, resultRun{op: "build", bundle: bundlePath, started: started, duration: res.Duration, success: err == nil}, emit)
 	if err != nil {
-		emitMaybe(emit, Err("build", ErrorObject{
-			Code:       "XCODEBUILD_FAILED",
-			Message:    "xcodebuild failed",
-			Detail:     err.Error(),
-			Suggestion: "Run with --json to capture structured logs, or open the .xcresult bundle for details.",
-		}))
-		emitMaybe(emit, Result("build", false, map[string]any{"exitCode": res.ExitCode, "resultBundle": bundlePath}))
-		return BuildResult{ResultBundle: bundlePath, ExitCode: res.ExitCode, Duration: res.Duration}, cfg, err
+		data := map[string]any{"exitCode": res.ExitCode, "resultBundle": bundlePath, "compiledFiles": sink.CompiledFiles()}
+		if metaPath != "" {
+			data["meta"] = metaPath
+		}
+		failure, signal := xcodebuildFailure(err)
+		if status := CancelStatus(err); status != "" {
+			emitMaybe(emit, Err("build", cancelErrorObject("Build", err, "")))
+			data["status"] = status
+		} else {
+			emitMaybe(emit, Err("build", xcodebuildFailureObject("Build", err, ErrorObject{
+				Code:       "XCODEBUILD_FAILED",
+				Message:    "xcodebuild failed",
+				Detail:     err.Error(),
+				Suggestion: "Run with --json to capture structured logs, or open the .xcresult bundle for details.",
+			})))
+			data = withFailure(data, failure, signal)
+		}
+		emitMaybe(emit, Result("build", false, withPackages(data, packages)))
+		return BuildResult{ResultBundle: bundlePath, ExitCode: res.ExitCode, Duration: res.Duration, Command: command, Packages: packages, CompiledFiles: sink.CompiledFiles(), MetaPath: metaPath, Failure: failure, Signal: signal}, cfg, err
 	}
 
 	var appPath string
 	var bundleID string
+	var products []ProductInfo
 	if settings, err := ShowBuildSettings(ctx, projectRoot, cfg); err == nil {
 		if settings["PRODUCT_BUNDLE_IDENTIFIER"] != "" {
 			bundleID = settings["PRODUCT_BUNDLE_IDENTIFIER"]
@@ -120,108 +240,407 @@ func Build(ctx context.Context, projectRoot string, cfg Config, emit Emitter) (B
 		if p, err := guessAppBundlePath(settings); err == nil {
 			appPath = p
 		}
+		products = DiscoverProducts(productsDir(settings))
 	}
 	if appPath != "" {
 		cfg.LastBuiltAppBundle = appPath
 	}
 
-	emitMaybe(emit, Result("build", true, map[string]any{
-		"exitCode":     0,
-		"resultBundle": bundlePath,
-		"durationMs":   res.Duration.Milliseconds(),
-		"bundleId":     bundleID,
-		"appPath":      appPath,
-	}))
-	return BuildResult{ResultBundle: bundlePath, ExitCode: 0, Duration: res.Duration, AppPath: appPath, BundleID: bundleID}, cfg, nil
+	recordDerivedDataXcode(DerivedDataDir(cfg), xcode)
+	compiled := sink.CompiledFiles()
+	times := sink.CompileTimes()
+	targets := sink.Targets()
+	data := withPackages(map[string]any{
+		"exitCode":      0,
+		"resultBundle":  bundlePath,
+		"durationMs":    res.Duration.Milliseconds(),
+		"bundleId":      bundleID,
+		"appPath":       appPath,
+		"compiledFiles": compiled,
+	}, packages)
+	if len(products) > 0 {
+		data["products"] = products
+	}
+	if len(times) > 0 {
+		data["slowestFiles"] = SlowestFiles(times, SlowestFilesLimit)
+	}
+	if len(targets) > 0 {
+		data["targets"] = targets
+	}
+	if metaPath != "" {
+		data["meta"] = metaPath
+	}
+	emitMaybe(emit, Result("build", true, data))
+	return BuildResult{ResultBundle: bundlePath, ExitCode: 0, Duration: res.Duration, AppPath: appPath, BundleID: bundleID, Command: command, Packages: packages, Products: products, CompiledFiles: compiled, CompileTimes: times, Targets: targets, MetaPath: metaPath}, cfg, nil
 }
 
+// withPackages adds the resolved SwiftPM packages to a result payload.
+func withPackages(data map[string]any, packages []ResolvedPackage) map[string]any {
+	if len(packages) > 0 {
+		data["packages"] = packages
+	}
+	return data
+}
+
+// Test runs xcodebuild test, wrapped by the preTest and postTest hooks.
+// Result bundles beyond results.retention are pruned afterwards.
 func Test(ctx context.Context, projectRoot string, cfg Config, onlyTesting []string, skipTesting []string, emit Emitter) (TestResult, Config, error) {
+	finish := resultsOp(ctx, cfg, "test", emit)
+	res, cfg, err := testWithHooks(ctx, projectRoot, cfg, onlyTesting, skipTesting, emit)
+	finish(cfg)
+	return res, cfg, err
+}
+
+func testWithHooks(ctx context.Context, projectRoot string, cfg Config, onlyTesting []string, skipTesting []string, emit Emitter) (TestResult, Config, error) {
+	cfg, err := maybeGenerateProject(ctx, projectRoot, cfg, "test", emit)
+	if err != nil {
+		return TestResult{}, cfg, err
+	}
+	if err := runPreHook(ctx, projectRoot, cfg, "test", emit); err != nil {
+		return TestResult{}, cfg, err
+	}
+	res, cfg, err := test(ctx, projectRoot, cfg, onlyTesting, skipTesting, testIterations{}, emit)
+	runPostHook(ctx, projectRoot, cfg, "test", hookOutcome{success: err == nil, resultBundle: res.ResultBundle}, emit)
+	return res, cfg, err
+}
+
+func test(ctx context.Context, projectRoot string, cfg Config, onlyTesting []string, skipTesting []string, iterations testIterations, emit Emitter) (TestResult, Config, error) {
+	started := time.Now()
+	if pkg, ok, err := swiftPackageFor(ctx, projectRoot, cfg); ok {
+		if err != nil {
+			emitMaybe(emit, Err("test", swiftPackageErrorObject(err)))
+			return TestResult{}, cfg, err
+		}
+		return swiftPackageTest(ctx, projectRoot, cfg, pkg, onlyTesting, skipTesting, emit)
+	}
 	if cfg2, err := ensureSchemeAndConfigFromFS(projectRoot, cfg, emit); err == nil {
 		cfg = cfg2
 	} else {
-		emitMaybe(emit, Err("test", ErrorObject{
-			Code:       "SCHEME_REQUIRED",
-			Message:    "No scheme configured",
-			Detail:     err.Error(),
-			Suggestion: "Run `xcbolt init` or pass --scheme.",
-		}))
+		emitMaybe(emit, Err("test", schemeRequiredError(err)))
 		return TestResult{}, cfg, err
 	}
 
 	cfg, _ = ResolveDestinationIfNeeded(ctx, projectRoot, cfg, emit)
 	cfg.Destination = normalizeDestination(cfg.Destination)
+	if err := CheckDestinationCompatible(ctx, projectRoot, cfg, "test", emit); err != nil {
+		return TestResult{}, cfg, err
+	}
 
+	var xcode XcodeVersion
+	if !cfg.Xcodebuild.DryRun {
+		xcode = checkDerivedDataXcode(ctx, cfg, "test", emit)
+	}
 	if err := EnsureBuildDirs(cfg); err != nil {
 		return TestResult{}, cfg, err
 	}
 
-	bundlePath := filepath.Join(cfg.ResultBundlesPath, time.Now().Format("20060102-150405")+".xcresult")
-	args := baseXcodebuildArgs(projectRoot, cfg)
-	args = append(args,
-		"-derivedDataPath", cfg.DerivedDataPath,
-		"-resultBundlePath", bundlePath,
-	)
-	for _, o := range onlyTesting {
-		args = append(args, "-only-testing:"+o)
+	// Retries happen inside xcodebuild on Xcode 13+; older versions re-run
+	// the failed tests once afterwards.
+	retries := cfg.Test.RetryOnFailure
+	if iterations.count > 0 {
+		retries = 0
 	}
-	for _, s := range skipTesting {
-		args = append(args, "-skip-testing:"+s)
+	inPlaceRetry := retries > 0 && (cfg.Xcodebuild.DryRun || xcodeSupportsTestRetry(ctx))
+
+	testArgs := func(bundle string, only []string) []string {
+		args := baseXcodebuildArgs(projectRoot, cfg)
+		args = append(args,
+			"-derivedDataPath", DerivedDataDir(cfg),
+			"-resultBundlePath", bundle,
+		)
+		for _, o := range only {
+			args = append(args, "-only-testing:"+o)
+		}
+		if cfg.Test.Plan != "" {
+			args = append(args, "-testPlan", cfg.Test.Plan)
+		}
+		for _, s := range skipTesting {
+			args = append(args, "-skip-testing:"+s)
+		}
+		if inPlaceRetry {
+			args = append(args, testRetryArgs(retries)...)
+		}
+		args = append(args, iterations.args()...)
+		args = append(args, simulatorPrepTestArgs(cfg.Test.SimulatorPrep)...)
+		if cfg.Test.CodeCoverage {
+			args = append(args, "-enableCodeCoverage", "YES")
+		}
+		args = append(args, "test")
+		return append(args, cfg.Xcodebuild.Options...)
+	}
+	var packages []ResolvedPackage
+	runTests := func(bundle string, only []string) (CmdResult, error) {
+		args := testArgs(bundle, only)
+		emitMaybe(emit, XcodebuildCommand("test", xcodebuildCommandLine(cfg, args)))
+		sink := newXcodebuildLogSink(ctx, "test", cfg, emit)
+		res, err := RunStreaming(ctx, CmdSpec{
+			Path:           "xcrun",
+			Args:           append([]string{"xcodebuild"}, args...),
+			PreserveLocale: cfg.Xcodebuild.PreserveLocale,
+			Dir:            projectRoot,
+			Env:            withEnv(cfg.Xcodebuild.Env, simulatorPrepEnv(cfg.Test.SimulatorPrep)),
+			StdoutLine:     sink.HandleLine,
+			StderrLine:     sink.HandleStderrLine,
+		})
+		sink.Finalize(err, res.ExitCode)
+		if packages == nil {
+			packages = resolvedPackagesFor(projectRoot, cfg, sink)
+		}
+		return res, xcodebuildExitError(res, err)
 	}
-	args = append(args, "test")
-	args = append(args, cfg.Xcodebuild.Options...)
 
+	bundlePath := resultBundlePath(cfg, "test", time.Now(), emit)
+	command := xcodebuildCommandLine(cfg, testArgs(bundlePath, onlyTesting))
 	emitMaybe(emit, Status("test", "Tests started", map[string]any{"resultBundle": bundlePath}))
 	if cfg.Xcodebuild.DryRun {
-		cmdLine := formatCmd("xcodebuild", args)
+		emitMaybe(emit, XcodebuildCommand("test", command))
+		cmdLine := formatCmd("xcodebuild", xcodebuildArgs(cfg, testArgs(bundlePath, onlyTesting)))
 		emitMaybe(emit, Log("test", "Dry run: "+cmdLine))
 		emitMaybe(emit, Result("test", true, map[string]any{"exitCode": 0, "resultBundle": bundlePath, "dryRun": true}))
 		cfg.LastResultBundle = bundlePath
-		return TestResult{ResultBundle: bundlePath, ExitCode: 0, Duration: 0}, cfg, nil
+		return TestResult{ResultBundle: bundlePath, ExitCode: 0, Duration: 0, Command: command}, cfg, nil
 	}
-	sink := newXcodebuildLogSink(ctx, "test", cfg, emit)
-	res, err := RunStreaming(ctx, CmdSpec{
-		Path:       "xcrun",
-		Args:       append([]string{"xcodebuild"}, args...),
-		Dir:        projectRoot,
-		Env:        cfg.Xcodebuild.Env,
-		StdoutLine: sink.HandleLine,
-		StderrLine: sink.HandleLine,
-	})
-	sink.Finalize(err, res.ExitCode)
+	restoreSimulator, err := prepareSimulator(ctx, cfg, emit)
+	if errors.Is(err, ErrSimRuntimeBroken) {
+		return TestResult{ResultBundle: bundlePath, Command: command}, cfg, err
+	} else if err != nil {
+		emitMaybe(emit, Err("test", ErrorObject{
+			Code:       "SIMULATOR_PREP_FAILED",
+			Message:    "Simulator prep failed",
+			Detail:     err.Error(),
+			Suggestion: "Check test.simulatorPrep, or unset test.simulatorPrep.required to only warn.",
+		}))
+		return TestResult{ResultBundle: bundlePath, Command: command}, cfg, err
+	}
+	defer restoreSimulator()
+	res, err := runTests(bundlePath, onlyTesting)
 
 	cfg.LastResultBundle = bundlePath
 
-	summary, sumErr := XcresultTestSummary(ctx, bundlePath)
+	var summary TestSummary
+	var sumErr error
+	if CancelStatus(err) != "" {
+		// Interrupted: keep the results of the tests that already ran.
+		summary, sumErr = partialTestSummary(bundlePath)
+	} else {
+		summary, sumErr = XcresultTestSummary(ctx, bundlePath)
+	}
 	if sumErr != nil {
 		emitMaybe(emit, Warn("test", "Could not parse xcresult test summary: "+sumErr.Error()))
 	}
-	tr := TestResult{ResultBundle: bundlePath, ExitCode: res.ExitCode, Duration: res.Duration, Summary: summary}
+	tr := TestResult{ResultBundle: bundlePath, ExitCode: res.ExitCode, Duration: res.Duration, Summary: summary, Command: command, Flaky: summary.FlakyTests}
+
+	if err != nil && retries > 0 && !inPlaceRetry && len(summary.FailedTests) > 0 && ctx.Err() == nil {
+		failed := summary.FailedTests
+		emitMaybe(emit, Status("test", fmt.Sprintf("Retrying %d failed tests", len(failed)), map[string]any{"tests": failed}))
+		retryBundle := strings.TrimSuffix(bundlePath, ".xcresult") + "-retry.xcresult"
+		rres, rerr := runTests(retryBundle, failed)
+		tr.RetryResultBundle = retryBundle
+		tr.Duration += rres.Duration
+
+		stillFailing := map[string]bool{}
+		if rerr != nil {
+			rsum, _ := XcresultTestSummary(ctx, retryBundle)
+			for _, t := range rsum.FailedTests {
+				stillFailing[t] = true
+			}
+			if len(rsum.FailedTests) == 0 {
+				// Couldn't tell which ones failed again; claim nothing as flaky.
+				for _, t := range failed {
+					stillFailing[t] = true
+				}
+			}
+		}
+		for _, t := range failed {
+			if !stillFailing[t] {
+				tr.Flaky = append(tr.Flaky, t)
+			}
+		}
+		tr.Summary.Failed = max(0, tr.Summary.Failed-len(tr.Flaky))
+		tr.Summary.Passed += len(tr.Flaky)
+		if rerr == nil {
+			err = nil
+			res.ExitCode = 0
+			tr.ExitCode = 0
+		}
+	}
+	if err == nil {
+		recordDerivedDataXcode(DerivedDataDir(cfg), xcode)
+	}
+	summary = tr.Summary
+	summary.FlakyTests = tr.Flaky
+	tr.Summary = summary
+	tr.Packages = packages
+	if cfg.Test.CodeCoverage && CancelStatus(err) == "" {
+		if cov, covErr := XcresultCoverage(ctx, bundlePath); covErr != nil {
+			emitMaybe(emit, Warn("test", "Could not read code coverage: "+covErr.Error()))
+		} else {
+			tr.Coverage = &cov
+			emitMaybe(emit, Status("test", fmt.Sprintf("Coverage: %.1f%%", cov.Percent()), nil))
+		}
+	}
+	tr.Changes = recordTestChanges(projectRoot, cfg, onlyTesting, skipTesting, summary, err)
+	tr.MetaPath = writeResultMeta(projectRoot, cfg, resultRun{op: "test", bundle: bundlePath, started: started, duration: tr.Duration, success: err == nil}, emit)
+
+	if summary.HasCounts() {
+		emitMaybe(emit, Status("test", "Tests: "+summary.CountsLine(), nil))
+	}
+	if tr.Changes != nil {
+		emitMaybe(emit, Status("test", "Changes: "+tr.Changes.Line(), nil))
+	}
+	if len(tr.Flaky) > 0 {
+		emitMaybe(emit, Warn("test", fmt.Sprintf("Flaky tests (passed only after retry): %s", strings.Join(tr.Flaky, ", "))))
+	}
 
 	if err != nil {
-		emitMaybe(emit, Err("test", ErrorObject{
+		// No tests ran: a stale test plan is the likely cause.
+		errObj := xcodebuildFailureObject("Tests", err, ErrorObject{
 			Code:       "XCODEBUILD_TEST_FAILED",
 			Message:    "xcodebuild test failed",
 			Detail:     err.Error(),
 			Suggestion: "Inspect the .xcresult bundle for structured failures.",
-		}))
-		emitMaybe(emit, Result("test", false, map[string]any{"exitCode": res.ExitCode, "resultBundle": bundlePath, "durationMs": res.Duration.Milliseconds()}))
+		})
+		tr.Failure, tr.Signal = xcodebuildFailure(err)
+		data := withFailure(map[string]any{"exitCode": res.ExitCode, "resultBundle": bundlePath, "durationMs": tr.Duration.Milliseconds()}, tr.Failure, tr.Signal)
+		if tr.MetaPath != "" {
+			data["meta"] = tr.MetaPath
+		}
+		if status := CancelStatus(err); status != "" {
+			detail := "No tests finished"
+			if summary.HasCounts() {
+				detail = fmt.Sprintf("%d tests ran, %d failures so far", summary.Passed+summary.Failed+summary.Skipped+summary.ExpectedFailures, summary.Failed)
+			}
+			errObj = cancelErrorObject("Tests", err, detail)
+			data["status"] = status
+			data["partial"] = true
+		} else if cfg.Test.Plan != "" && !summary.HasCounts() {
+			if missing := missingTestPlanError(ctx, projectRoot, cfg); missing != nil {
+				errObj = *missing
+				err = fmt.Errorf("test plan %q not found in scheme %q", cfg.Test.Plan, cfg.Scheme)
+			}
+		}
+		emitMaybe(emit, Err("test", errObj))
+		emitMaybe(emit, Result("test", false, withTestChanges(withCoverage(withPackages(withTestCounts(data, summary), packages), tr.Coverage), tr.Changes)))
 		return tr, cfg, err
 	}
 	// Note: tests can fail while xcodebuild exits non-zero; if err is nil, exit code is 0.
-	emitMaybe(emit, Result("test", true, map[string]any{"exitCode": 0, "resultBundle": bundlePath, "durationMs": res.Duration.Milliseconds()}))
+	data := map[string]any{"exitCode": 0, "resultBundle": bundlePath, "durationMs": tr.Duration.Milliseconds()}
+	if tr.MetaPath != "" {
+		data["meta"] = tr.MetaPath
+	}
+	emitMaybe(emit, Result("test", true, withTestChanges(withCoverage(withPackages(withTestCounts(data, summary), packages), tr.Coverage), tr.Changes)))
 	return tr, cfg, nil
 }
 
+// runBeforeLaunch calls opts.BeforeLaunch, reporting its failure.
+func runBeforeLaunch(ctx context.Context, opts RunOptions, emit Emitter) error {
+	if opts.BeforeLaunch == nil {
+		return nil
+	}
+	if err := opts.BeforeLaunch(ctx); err != nil {
+		emitMaybe(emit, Err("run", ErrorObject{
+			Code:       "PRE_LAUNCH_FAILED",
+			Message:    "Failed to prepare the launch",
+			Detail:     err.Error(),
+			Suggestion: "Stop the running app (x) and run again.",
+		}))
+		return err
+	}
+	return nil
+}
+
+// withTestCounts adds the parsed xcresult counts to a test result payload.
+func withTestCounts(data map[string]any, summary TestSummary) map[string]any {
+	if !summary.HasCounts() {
+		return data
+	}
+	data["total"] = summary.Total
+	data["passed"] = summary.Passed
+	data["failed"] = summary.Failed
+	data["skipped"] = summary.Skipped
+	data["expectedFailures"] = summary.ExpectedFailures
+	if len(summary.SkippedTests) > 0 {
+		data["skippedTests"] = summary.SkippedTests
+	}
+	if len(summary.FlakyTests) > 0 {
+		data["flaky"] = summary.FlakyTests
+	}
+	return data
+}
+
+// RunOptions tunes RunWithOptions.
+type RunOptions struct {
+	// Console keeps the run attached to the app's output until it exits.
+	Console bool
+	// BeforeLaunch is called once the build succeeded, before the app is
+	// installed and launched, e.g. to stop a previous instance. An error
+	// aborts the run. A failed build never calls it.
+	BeforeLaunch func(ctx context.Context) error
+	// AppPath installs and launches this app bundle instead of building,
+	// e.g. Config.LastBuiltAppBundle after a canceled launch.
+	AppPath string
+	// SkipInstall launches the app already on a device without installing
+	// it again, e.g. to retry a launch that failed. Simulators and Macs
+	// ignore it.
+	SkipInstall bool
+	// Preset is the launch preset for this run only: "" uses launch.preset
+	// and LaunchPresetNone none.
+	Preset string
+	// LaunchArgs and LaunchEnv are added over the preset for this run only.
+	LaunchArgs []string
+	LaunchEnv  map[string]string
+}
+
+// Run builds, installs, and launches the app, wrapped by the preRun and
+// postRun hooks. The build step also fires the build hooks.
 func Run(ctx context.Context, projectRoot string, cfg Config, console bool, emit Emitter) (RunResult, Config, error) {
+	return RunWithOptions(ctx, projectRoot, cfg, RunOptions{Console: console}, emit)
+}
+
+// RunWithOptions is Run with the options of opts. The launch preset only
+// applies to this run: the returned config keeps the configured launch
+// options. Result bundles beyond results.retention are pruned afterwards.
+func RunWithOptions(ctx context.Context, projectRoot string, cfg Config, opts RunOptions, emit Emitter) (RunResult, Config, error) {
+	launch := cfg.Launch
+	cfg, preset, err := withLaunchPreset(cfg, opts, emit)
+	if err != nil {
+		return RunResult{}, cfg, err
+	}
+	finish := resultsOp(ctx, cfg, "run", emit)
+	res, cfg, err := runWithHooks(ctx, projectRoot, cfg, opts, emit)
+	res.Preset = preset
+	cfg.Launch = launch
+	finish(cfg)
+	return res, cfg, err
+}
+
+func runWithHooks(ctx context.Context, projectRoot string, cfg Config, opts RunOptions, emit Emitter) (RunResult, Config, error) {
+	if opts.AppPath == "" {
+		var err error
+		if cfg, err = maybeGenerateProject(ctx, projectRoot, cfg, "run", emit); err != nil {
+			return RunResult{}, cfg, err
+		}
+	}
+	if err := runPreHook(ctx, projectRoot, cfg, "run", emit); err != nil {
+		return RunResult{}, cfg, err
+	}
+	res, cfg, err := run(ctx, projectRoot, cfg, opts, emit)
+	runPostHook(ctx, projectRoot, cfg, "run", hookOutcome{success: err == nil, resultBundle: res.ResultBundle, appPath: res.AppPath}, emit)
+	return res, cfg, err
+}
+
+func run(ctx context.Context, projectRoot string, cfg Config, opts RunOptions, emit Emitter) (RunResult, Config, error) {
+	if pkg, ok, err := swiftPackageFor(ctx, projectRoot, cfg); ok {
+		if err != nil {
+			emitMaybe(emit, Err("run", swiftPackageErrorObject(err)))
+			return RunResult{}, cfg, err
+		}
+		return swiftPackageRun(ctx, projectRoot, cfg, pkg, opts, emit)
+	}
 	if cfg2, err := ensureSchemeAndConfigFromFS(projectRoot, cfg, emit); err == nil {
 		cfg = cfg2
 	} else {
-		emitMaybe(emit, Err("run", ErrorObject{
-			Code:       "SCHEME_REQUIRED",
-			Message:    "No scheme configured",
-			Detail:     err.Error(),
-			Suggestion: "Run `xcbolt init` or pass --scheme.",
-		}))
+		emitMaybe(emit, Err("run", schemeRequiredError(err)))
 		return RunResult{}, cfg, err
 	}
 
@@ -248,32 +667,93 @@ func Run(ctx context.Context, projectRoot string, cfg Config, console bool, emit
 		return RunResult{}, cfg, err
 	}
 	emitMaybe(emit, Status("run", "Resolved destination", destinationMetadata(cfg.Destination)))
+	if err := CheckDestinationCompatible(ctx, projectRoot, cfg, "run", emit); err != nil {
+		return RunResult{}, cfg, err
+	}
 	if cfg.Xcodebuild.DryRun {
 		args := baseXcodebuildArgs(projectRoot, cfg)
 		args = append(args,
-			"-derivedDataPath", cfg.DerivedDataPath,
-			"-resultBundlePath", filepath.Join(cfg.ResultBundlesPath, time.Now().Format("20060102-150405")+".xcresult"),
+			"-derivedDataPath", DerivedDataDir(cfg),
+			"-resultBundlePath", resultBundlePath(cfg, "run", time.Now(), emit),
 			"build",
 		)
 		args = append(args, cfg.Xcodebuild.Options...)
-		cmdLine := formatCmd("xcodebuild", args)
+		emitMaybe(emit, XcodebuildCommand("run", xcodebuildCommandLine(cfg, args)))
+		cmdLine := formatCmd("xcodebuild", xcodebuildArgs(cfg, args))
 		emitMaybe(emit, Status("run", "Dry run enabled; skipping build/install/launch", nil))
 		emitMaybe(emit, Log("run", "Dry run: "+cmdLine))
 		return RunResult{Target: string(cfg.Destination.Kind), UDID: cfg.Destination.UDID}, cfg, nil
 	}
 
-	// Always build first (run implies build).
-	buildRes, cfg, err := Build(ctx, projectRoot, cfg, emit)
-	if err != nil {
-		return RunResult{}, cfg, err
+	phases := &runPhases{emit: emit}
+	var appPath string
+	if opts.AppPath != "" {
+		if _, statErr := os.Stat(opts.AppPath); statErr != nil {
+			emitMaybe(emit, Err("run", ErrorObject{
+				Code:       "APP_BUNDLE_MISSING",
+				Message:    "Built app bundle is missing",
+				Detail:     statErr.Error(),
+				Suggestion: "Run again to rebuild the app.",
+			}))
+			return RunResult{}, cfg, statErr
+		}
+		appPath = opts.AppPath
+		emitMaybe(emit, Status("run", "Skipping build; reusing the built app", map[string]any{"app": appPath}))
+	} else {
+		// Run implies build
+		phases.enter(RunPhaseBuild)
+		buildDone := phases.time("build")
+		buildRes, cfg2, err := Build(ctx, projectRoot, cfg, emit)
+		cfg = cfg2
+		if err != nil {
+			return RunResult{}, cfg, err
+		}
+		if appPath, err = builtAppPath(ctx, projectRoot, cfg, buildRes, emit); err != nil {
+			return RunResult{}, cfg, err
+		}
+		buildDone()
 	}
+	cfg.LastBuiltAppBundle = appPath
+	return launchBuilt(ctx, projectRoot, cfg, appPath, opts, phases, emit)
+}
 
+// builtAppPath finds the app a build produced: launch.product's app or host
+// app when set, else the build's app or the one its settings point at.
+func builtAppPath(ctx context.Context, projectRoot string, cfg Config, buildRes BuildResult, emit Emitter) (string, error) {
 	appPath := buildRes.AppPath
 	if appPath != "" {
 		if _, statErr := os.Stat(appPath); statErr != nil {
 			appPath = ""
 		}
 	}
+	if id := cfg.Launch.Product; id != "" {
+		product, err := selectProduct(buildRes.Products, id)
+		if err != nil {
+			emitMaybe(emit, Err("run", ErrorObject{
+				Code:       "PRODUCT_NOT_FOUND",
+				Message:    "Selected product was not built",
+				Detail:     err.Error(),
+				Suggestion: "Pick another product (TUI palette: Run: Choose Product) or clear launch.product.",
+			}))
+			return "", err
+		}
+		switch {
+		case product.Kind == ProductApp:
+			appPath = product.Path
+		case product.HostApp != "":
+			emitMaybe(emit, Warn("run", extensionLaunchNote(product)))
+			appPath = product.HostApp
+		default:
+			err := fmt.Errorf("%s is not embedded in an app", product.Name())
+			emitMaybe(emit, Err("run", ErrorObject{
+				Code:       "EXTENSION_NOT_EMBEDDED",
+				Message:    "App extension has no host app",
+				Detail:     err.Error(),
+				Suggestion: "Embed the extension in an app target (Embed Foundation Extensions build phase) and run that.",
+			}))
+			return "", err
+		}
+	}
 	if appPath == "" {
 		settings, err := ShowBuildSettings(ctx, projectRoot, cfg)
 		if err != nil {
@@ -283,7 +763,7 @@ func Run(ctx context.Context, projectRoot string, cfg Config, console bool, emit
 				Detail:     err.Error(),
 				Suggestion: "Check scheme/configuration and destination.",
 			}))
-			return RunResult{}, cfg, err
+			return "", err
 		}
 
 		appPath, err = guessAppBundlePath(settings)
@@ -294,7 +774,7 @@ func Run(ctx context.Context, projectRoot string, cfg Config, console bool, emit
 				Detail:     err.Error(),
 				Suggestion: "Ensure the scheme builds an app target.",
 			}))
-			return RunResult{}, cfg, err
+			return "", err
 		}
 	}
 	if _, statErr := os.Stat(appPath); statErr != nil {
@@ -304,10 +784,38 @@ func Run(ctx context.Context, projectRoot string, cfg Config, console bool, emit
 			Detail:     statErr.Error(),
 			Suggestion: "Clean and rebuild, or verify the scheme produces an .app.",
 		}))
-		return RunResult{}, cfg, statErr
+		return "", statErr
 	}
-	cfg.LastBuiltAppBundle = appPath
+	return appPath, nil
+}
+
+// launchBuilt installs and launches the app at appPath. A cancel from here
+// on leaves the build standing: the result still names the app, and the
+// error is a *RunPhaseCanceledError with the interrupted phase.
+func launchBuilt(ctx context.Context, projectRoot string, cfg Config, appPath string, opts RunOptions, phases *runPhases, emit Emitter) (RunResult, Config, error) {
+	res, cfg, err := installAndLaunch(ctx, projectRoot, cfg, appPath, opts, phases, emit)
+	phase := phases.current()
+	if err != nil && ctx.Err() != nil {
+		res = RunResult{ResultBundle: cfg.LastResultBundle, AppPath: appPath, Target: string(cfg.Destination.Kind), UDID: cfg.Destination.UDID, Phase: phase}
+		if info, infoErr := ReadAppBundleInfo(appPath); infoErr == nil {
+			res.BundleID = info.BundleID
+		}
+		emitMaybe(emit, Status("run", runPhaseLabel(phase)+" canceled; the app is built", map[string]any{"phase": string(phase), "app": appPath}))
+		return res, cfg, &RunPhaseCanceledError{Phase: phase, Err: ctx.Err()}
+	}
+	if err == nil {
+		res.Phase = phase
+		phases.reportTimings()
+		res.Timings = phases.stepTimings()
+	}
+	return res, cfg, err
+}
 
+// installAndLaunch installs the app at appPath on the destination and
+// launches it, entering the install, launch, and console phases.
+func installAndLaunch(ctx context.Context, projectRoot string, cfg Config, appPath string, opts RunOptions, phases *runPhases, emit Emitter) (RunResult, Config, error) {
+	console := opts.Console
+	phases.enter(RunPhaseInstall)
 	appInfo, err := ReadAppBundleInfo(appPath)
 	if err != nil {
 		emitMaybe(emit, Err("run", ErrorObject{
@@ -341,6 +849,9 @@ func Run(ctx context.Context, projectRoot string, cfg Config, console bool, emit
 		}))
 		return RunResult{}, cfg, err
 	}
+	if err := runBeforeLaunch(ctx, opts, emit); err != nil {
+		return RunResult{}, cfg, err
+	}
 
 	switch cfg.Destination.Kind {
 	case DestSimulator:
@@ -349,17 +860,23 @@ func Run(ctx context.Context, projectRoot string, cfg Config, console bool, emit
 			return RunResult{}, cfg, errors.New("missing simulator udid")
 		}
 		emitMaybe(emit, Status("run", "Booting simulator", map[string]any{"udid": udid}))
-		_ = SimctlBoot(ctx, udid)
+		bootDone := phases.time("boot")
 		_ = SimctlOpenSimulatorApp(ctx)
-		if err := SimctlBootStatus(ctx, udid); err != nil {
+		booted, err := bootSimulator(ctx, cfg, "run", udid, emit)
+		if err != nil {
 			return RunResult{}, cfg, err
 		}
+		if booted {
+			bootDone()
+		}
 		emitMaybe(emit, Status("run", "Installing app", map[string]any{"app": appPath}))
+		installDone := phases.time("install")
 		if _, err := RunStreaming(ctx, CmdSpec{
-			Path:       "xcrun",
-			Args:       []string{"simctl", "install", udid, appPath},
-			StdoutLine: func(s string) { emitMaybe(emit, Log("run", s)) },
-			StderrLine: func(s string) { emitMaybe(emit, Log("run", s)) },
+			Path:           "xcrun",
+			Args:           []string{"simctl", "install", udid, appPath},
+			PreserveLocale: cfg.Xcodebuild.PreserveLocale,
+			StdoutLine:     func(s string) { emitMaybe(emit, Log("run", s)) },
+			StderrLine:     func(s string) { emitMaybe(emit, Log("run", s)) },
 		}); err != nil {
 			emitMaybe(emit, Err("run", ErrorObject{
 				Code:       "SIM_INSTALL_FAILED",
@@ -369,18 +886,33 @@ func Run(ctx context.Context, projectRoot string, cfg Config, console bool, emit
 			}))
 			return RunResult{}, cfg, err
 		}
+		installDone()
 
 		var logCancel context.CancelFunc
+		termination := &terminationWatcher{}
 		if console && shouldStreamUnifiedLogs(cfg) {
-			predicate := simLogPredicate(cfg, appInfo)
+			predicate := unifiedLogPredicate(cfg, appInfo)
 			if predicate != "" {
+				logsDone := phases.time("logs")
 				logCtx, cancel := context.WithCancel(ctx)
 				logCancel = cancel
+				started := make(chan struct{})
+				var startOnce sync.Once
+				markStarted := func() { startOnce.Do(func() { close(started) }) }
 				go func() {
-					if err := SimctlLogStream(logCtx, udid, predicate, emit); err != nil && !errors.Is(err, context.Canceled) {
+					defer markStarted()
+					if err := SimctlLogStream(logCtx, udid, predicate, startedEmitter{next: termination.emitter(emit), started: markStarted}, cfg.Xcodebuild.PreserveLocale); err != nil && !errors.Is(err, context.Canceled) {
 						emitMaybe(emit, Warn("run", "simctl log stream failed: "+err.Error()))
 					}
 				}()
+				// Launch once the stream is up, so the app's first log lines
+				// aren't missed
+				select {
+				case <-started:
+				case <-time.After(logStreamSetupTimeout):
+				case <-ctx.Done():
+				}
+				logsDone()
 			}
 		}
 
@@ -392,16 +924,33 @@ func Run(ctx context.Context, projectRoot string, cfg Config, console bool, emit
 		// Remaining arguments are passed to the app.
 		launchArgs = append(launchArgs, cfg.Launch.Options...)
 
+		phases.enter(RunPhaseLaunch)
 		emitMaybe(emit, Status("run", "Launching app", map[string]any{"bundleId": appInfo.BundleID}))
+		launchDone := phases.time("launch")
 		var out strings.Builder
 		var errOut strings.Builder
+		firstLine := true
 		res, err := RunStreaming(ctx, CmdSpec{
-			Path: "xcrun",
-			Args: launchArgs,
-			Env:  simctlChildEnv(launchEnv),
+			Path:           "xcrun",
+			Args:           launchArgs,
+			PreserveLocale: cfg.Xcodebuild.PreserveLocale,
+			Env:            simctlChildEnv(launchEnv),
 			StdoutLine: func(s string) {
+				// simctl reports the pid first; the rest is the app's output
+				if firstLine {
+					firstLine = false
+					if pid := simctlLaunchLinePID(s, appInfo.BundleID); pid > 0 {
+						emitAppLaunched(emit, pid, appInfo.BundleID, "simulator", udid)
+					}
+				}
+				if console {
+					launchDone()
+					phases.enter(RunPhaseConsole)
+					phases.reportTimings()
+				}
 				out.WriteString(s)
 				out.WriteString("\n")
+				termination.observe(s)
 				if msg, ok := formatAppConsoleLine(appInfo, 0, false, s, !shouldStreamSystemLogs(cfg), shouldStreamUnifiedLogs(cfg)); ok {
 					emitMaybe(emit, LogStream("run", msg, "app"))
 				}
@@ -409,6 +958,7 @@ func Run(ctx context.Context, projectRoot string, cfg Config, console bool, emit
 			StderrLine: func(s string) {
 				errOut.WriteString(s)
 				errOut.WriteString("\n")
+				termination.observe(s)
 				if msg, ok := formatAppConsoleLine(appInfo, 0, true, s, !shouldStreamSystemLogs(cfg), shouldStreamUnifiedLogs(cfg)); ok {
 					emitMaybe(emit, LogStream("run", msg, "app"))
 				}
@@ -417,16 +967,16 @@ func Run(ctx context.Context, projectRoot string, cfg Config, console bool, emit
 		if logCancel != nil {
 			logCancel()
 		}
+		launchDone()
 		pid := parseSimctlLaunchPID(out.String())
 		if err != nil {
 			if errors.Is(err, context.Canceled) {
-				emitMaybe(emit, Status("run", "Run canceled", map[string]any{"bundleId": appInfo.BundleID}))
 				return RunResult{}, cfg, err
 			}
 			if pid > 0 {
-				emitMaybe(emit, Status("run", "App exited", map[string]any{"pid": pid, "bundleId": appInfo.BundleID, "exitCode": res.ExitCode}))
-				emitMaybe(emit, Result("run", true, map[string]any{"pid": pid, "bundleId": appInfo.BundleID}))
-				return RunResult{ResultBundle: cfg.LastResultBundle, AppPath: appPath, BundleID: appInfo.BundleID, PID: pid, Target: "simulator", UDID: udid}, cfg, nil
+				reason := emitAppTerminated(emit, termination, res, pid, appInfo.BundleID)
+				emitMaybe(emit, Result("run", true, map[string]any{"pid": pid, "bundleId": appInfo.BundleID, "exitCode": res.ExitCode, "terminationReason": reason}))
+				return RunResult{ResultBundle: cfg.LastResultBundle, AppPath: appPath, BundleID: appInfo.BundleID, PID: pid, Target: "simulator", UDID: udid, ExitCode: res.ExitCode, TerminationReason: reason}, cfg, nil
 			}
 			emitMaybe(emit, Err("run", ErrorObject{
 				Code:       "SIM_LAUNCH_FAILED",
@@ -442,7 +992,13 @@ func Run(ctx context.Context, projectRoot string, cfg Config, console bool, emit
 		dst := cfg.Destination
 		dst.ID = udid
 		dst.UDID = udid
-		_, _ = AddSessionWithDestination(projectRoot, appInfo.BundleID, pid, dst)
+		_, _ = addSession(projectRoot, appInfo.BundleID, pid, dst, emit)
+		if console {
+			// simctl launch --console returns once the app has ended
+			reason := emitAppTerminated(emit, termination, res, pid, appInfo.BundleID)
+			emitMaybe(emit, Result("run", true, map[string]any{"pid": pid, "bundleId": appInfo.BundleID, "exitCode": res.ExitCode, "terminationReason": reason}))
+			return RunResult{ResultBundle: cfg.LastResultBundle, AppPath: appPath, BundleID: appInfo.BundleID, PID: pid, Target: "simulator", UDID: udid, ExitCode: res.ExitCode, TerminationReason: reason}, cfg, nil
+		}
 		emitMaybe(emit, Status("run", "Running", map[string]any{"pid": pid, "bundleId": appInfo.BundleID}))
 		emitMaybe(emit, Result("run", true, map[string]any{"pid": pid, "bundleId": appInfo.BundleID}))
 		return RunResult{ResultBundle: cfg.LastResultBundle, AppPath: appPath, BundleID: appInfo.BundleID, PID: pid, Target: "simulator", UDID: udid}, cfg, nil
@@ -453,7 +1009,7 @@ func Run(ctx context.Context, projectRoot string, cfg Config, console bool, emit
 			return RunResult{}, cfg, errors.New("missing device udid")
 		}
 		if cfg.Destination.PlatformFamily == PlatformWatchOS {
-			watchDeploy, err := resolveWatchDeviceDeployment(ctx, cfg.Destination, appPath, appInfo, emit)
+			watchDeploy, err := resolveWatchDeviceDeployment(ctx, cfg.Destination, appPath, appInfo, emit, cfg.Xcodebuild.PreserveLocale)
 			if err != nil {
 				emitMaybe(emit, Err("run", ErrorObject{
 					Code:       "WATCH_DEPLOYMENT_FAILED",
@@ -464,41 +1020,54 @@ func Run(ctx context.Context, projectRoot string, cfg Config, console bool, emit
 				return RunResult{}, cfg, err
 			}
 			cfg.Destination.CompanionTargetID = watchDeploy.CompanionDeviceID
-
-			emitMaybe(emit, Status("run", "Installing companion app on paired iPhone", map[string]any{
-				"companionDevice": watchDeploy.CompanionDeviceID,
-				"app":             watchDeploy.CompanionAppPath,
-			}))
-			if err := DevicectlInstallApp(ctx, watchDeploy.CompanionDeviceID, watchDeploy.CompanionAppPath, emit); err != nil {
-				emitMaybe(emit, Err("run", ErrorObject{
-					Code:       "WATCH_COMPANION_INSTALL_FAILED",
-					Message:    "Failed to install companion app on paired iPhone",
-					Detail:     err.Error(),
-					Suggestion: "Ensure paired iPhone is connected/unlocked and signing is valid.",
+			if !opts.SkipInstall {
+				installDone := phases.time("install")
+				emitMaybe(emit, Status("run", "Installing companion app on paired iPhone", map[string]any{
+					"companionDevice": watchDeploy.CompanionDeviceID,
+					"app":             watchDeploy.CompanionAppPath,
 				}))
-				return RunResult{}, cfg, err
-			}
+				if err := DevicectlInstallApp(ctx, watchDeploy.CompanionDeviceID, watchDeploy.CompanionAppPath, emit, cfg.Xcodebuild.PreserveLocale); err != nil {
+					emitMaybe(emit, Err("run", deviceErrorObject(err, ErrorObject{
+						Code:       "WATCH_COMPANION_INSTALL_FAILED",
+						Message:    "Failed to install companion app on paired iPhone",
+						Detail:     err.Error(),
+						Suggestion: "Ensure paired iPhone is connected/unlocked and signing is valid.",
+					})))
+					return RunResult{}, cfg, err
+				}
 
-			emitMaybe(emit, Status("run", "Installing watch app on device", map[string]any{"udid": udid, "app": watchDeploy.WatchAppPath}))
-			if err := DevicectlInstallApp(ctx, udid, watchDeploy.WatchAppPath, emit); err != nil {
-				emitMaybe(emit, Err("run", ErrorObject{
-					Code:       "WATCH_INSTALL_FAILED",
-					Message:    "Failed to install watch app on watch device",
-					Detail:     err.Error(),
-					Suggestion: "Verify the watch target bundle is built and the watch is paired/unlocked.",
-				}))
-				return RunResult{}, cfg, err
+				emitMaybe(emit, Status("run", "Installing watch app on device", map[string]any{"udid": udid, "app": watchDeploy.WatchAppPath}))
+				if err := DevicectlInstallApp(ctx, udid, watchDeploy.WatchAppPath, emit, cfg.Xcodebuild.PreserveLocale); err != nil {
+					emitMaybe(emit, Err("run", deviceErrorObject(err, ErrorObject{
+						Code:       "WATCH_INSTALL_FAILED",
+						Message:    "Failed to install watch app on watch device",
+						Detail:     err.Error(),
+						Suggestion: "Verify the watch target bundle is built and the watch is paired/unlocked.",
+					})))
+					return RunResult{}, cfg, err
+				}
+				installDone()
 			}
 
+			phases.enter(RunPhaseLaunch)
 			emitMaybe(emit, Status("run", "Launching watch app on device", map[string]any{"bundleId": watchDeploy.WatchInfo.BundleID, "console": console}))
-			lr, err := DevicectlLaunchApp(ctx, udid, watchDeploy.WatchInfo.BundleID, console, launchEnv, watchDeploy.WatchInfo, !shouldStreamSystemLogs(cfg), emit)
+			stopLogs, streaming := func() {}, false
+			if console && shouldStreamUnifiedLogs(cfg) {
+				logsDone := phases.time("logs")
+				stopLogs, streaming = startDeviceLogStream(ctx, cfg, udid, watchDeploy.WatchInfo, emit)
+				logsDone()
+			}
+			launchDone := phases.time("launch")
+			lr, err := DevicectlLaunchApp(ctx, udid, watchDeploy.WatchInfo.BundleID, console, launchEnv, watchDeploy.WatchInfo, !shouldStreamSystemLogs(cfg), streaming, phases.launchedEmitter(emit, launchDone), cfg.Xcodebuild.PreserveLocale)
+			launchDone()
+			stopLogs()
 			if err != nil {
-				emitMaybe(emit, Err("run", ErrorObject{
+				emitMaybe(emit, Err("run", deviceErrorObject(err, ErrorObject{
 					Code:       "WATCH_LAUNCH_FAILED",
 					Message:    "Failed to launch watch app on device",
 					Detail:     err.Error(),
 					Suggestion: "Check watch device connectivity and companion installation state.",
-				}))
+				})))
 				return RunResult{}, cfg, err
 			}
 			dst := cfg.Destination
@@ -506,36 +1075,52 @@ func Run(ctx context.Context, projectRoot string, cfg Config, console bool, emit
 			dst.UDID = udid
 			dst.CompanionTargetID = watchDeploy.CompanionDeviceID
 			dst.CompanionBundleID = watchDeploy.CompanionInfo.BundleID
-			_, _ = AddSessionWithDestination(projectRoot, watchDeploy.WatchInfo.BundleID, lr.PID, dst)
+			_, _ = addSession(projectRoot, watchDeploy.WatchInfo.BundleID, lr.PID, dst, emit)
 			emitMaybe(emit, Status("run", "Running", map[string]any{"pid": lr.PID, "bundleId": watchDeploy.WatchInfo.BundleID}))
 			emitMaybe(emit, Result("run", true, map[string]any{"pid": lr.PID, "bundleId": watchDeploy.WatchInfo.BundleID, "companionTargetId": watchDeploy.CompanionDeviceID}))
 			return RunResult{ResultBundle: cfg.LastResultBundle, AppPath: watchDeploy.WatchAppPath, BundleID: watchDeploy.WatchInfo.BundleID, PID: lr.PID, Target: "device", UDID: udid}, cfg, nil
 		}
-		emitMaybe(emit, Status("run", "Installing app on device", map[string]any{"udid": udid}))
-		if err := DevicectlInstallApp(ctx, udid, appPath, emit); err != nil {
-			emitMaybe(emit, Err("run", ErrorObject{
-				Code:       "DEVICE_INSTALL_FAILED",
-				Message:    "Failed to install app on device",
-				Detail:     err.Error(),
-				Suggestion: "Ensure device is trusted/unlocked and provisioning is valid.",
-			}))
-			return RunResult{}, cfg, err
+		if !opts.SkipInstall {
+			emitMaybe(emit, Status("run", "Installing app on device", map[string]any{"udid": udid}))
+			installDone := phases.time("install")
+			if err := DevicectlInstallApp(ctx, udid, appPath, emit, cfg.Xcodebuild.PreserveLocale); err != nil {
+				emitMaybe(emit, Err("run", deviceErrorObject(err, ErrorObject{
+					Code:       "DEVICE_INSTALL_FAILED",
+					Message:    "Failed to install app on device",
+					Detail:     err.Error(),
+					Suggestion: "Ensure device is trusted/unlocked and provisioning is valid.",
+				})))
+				return RunResult{}, cfg, err
+			}
+			installDone()
 		}
+		phases.enter(RunPhaseLaunch)
 		emitMaybe(emit, Status("run", "Launching app on device", map[string]any{"bundleId": appInfo.BundleID, "console": console}))
-		lr, err := DevicectlLaunchApp(ctx, udid, appInfo.BundleID, console, launchEnv, appInfo, !shouldStreamSystemLogs(cfg), emit)
+		// With --console devicectl stays attached until the app exits, so
+		// the log stream lives as long as the run.
+		stopLogs, streaming := func() {}, false
+		if console && shouldStreamUnifiedLogs(cfg) {
+			logsDone := phases.time("logs")
+			stopLogs, streaming = startDeviceLogStream(ctx, cfg, udid, appInfo, emit)
+			logsDone()
+		}
+		launchDone := phases.time("launch")
+		lr, err := DevicectlLaunchApp(ctx, udid, appInfo.BundleID, console, launchEnv, appInfo, !shouldStreamSystemLogs(cfg), streaming, phases.launchedEmitter(emit, launchDone), cfg.Xcodebuild.PreserveLocale)
+		launchDone()
+		stopLogs()
 		if err != nil {
-			emitMaybe(emit, Err("run", ErrorObject{
+			emitMaybe(emit, Err("run", deviceErrorObject(err, ErrorObject{
 				Code:       "DEVICE_LAUNCH_FAILED",
 				Message:    "Failed to launch app on device",
 				Detail:     err.Error(),
 				Suggestion: "Check device logs and app signing.",
-			}))
+			})))
 			return RunResult{}, cfg, err
 		}
 		dst := cfg.Destination
 		dst.ID = udid
 		dst.UDID = udid
-		_, _ = AddSessionWithDestination(projectRoot, appInfo.BundleID, lr.PID, dst)
+		_, _ = addSession(projectRoot, appInfo.BundleID, lr.PID, dst, emit)
 		emitMaybe(emit, Status("run", "Running", map[string]any{"pid": lr.PID, "bundleId": appInfo.BundleID}))
 		emitMaybe(emit, Result("run", true, map[string]any{"pid": lr.PID, "bundleId": appInfo.BundleID}))
 		return RunResult{ResultBundle: cfg.LastResultBundle, AppPath: appPath, BundleID: appInfo.BundleID, PID: lr.PID, Target: "device", UDID: udid}, cfg, nil
@@ -562,10 +1147,14 @@ func Run(ctx context.Context, projectRoot string, cfg Config, console bool, emit
 			return RunResult{}, cfg, statErr
 		}
 
+		phases.enter(RunPhaseLaunch)
 		emitMaybe(emit, Status("run", "Launching app on Mac", map[string]any{"app": appPath}))
+		launchDone := phases.time("launch")
 		cmd := exec.Command(execPath, cfg.Launch.Options...)
 		cmd.Env = mergeEnv(os.Environ(), launchEnv)
-		if err := cmd.Start(); err != nil {
+		err := cmd.Start()
+		launchDone()
+		if err != nil {
 			emitMaybe(emit, Err("run", ErrorObject{
 				Code:       "MAC_LAUNCH_FAILED",
 				Message:    "Failed to launch app on Mac",
@@ -574,12 +1163,19 @@ func Run(ctx context.Context, projectRoot string, cfg Config, console bool, emit
 			}))
 			return RunResult{}, cfg, err
 		}
+		// Canceled while launching (e.g. the TUI quit): don't leave the app behind.
+		if ctx.Err() != nil {
+			_ = cmd.Process.Kill()
+			_, _ = cmd.Process.Wait()
+			return RunResult{}, cfg, ctx.Err()
+		}
 		pid := 0
 		if cmd.Process != nil {
 			pid = cmd.Process.Pid
 			_ = cmd.Process.Release()
 		}
-		_, _ = AddSessionWithDestination(projectRoot, appInfo.BundleID, pid, cfg.Destination)
+		_, _ = addSession(projectRoot, appInfo.BundleID, pid, cfg.Destination, emit)
+		emitAppLaunched(emit, pid, appInfo.BundleID, string(cfg.Destination.Kind), "")
 		emitMaybe(emit, Status("run", "Running", map[string]any{"pid": pid, "bundleId": appInfo.BundleID}))
 		emitMaybe(emit, Result("run", true, map[string]any{"pid": pid, "bundleId": appInfo.BundleID}))
 		return RunResult{ResultBundle: cfg.LastResultBundle, AppPath: appPath, BundleID: appInfo.BundleID, PID: pid, Target: string(cfg.Destination.Kind)}, cfg, nil
@@ -622,7 +1218,9 @@ func shouldStreamSystemLogs(cfg Config) bool {
 	return *cfg.Launch.StreamSystemLogs
 }
 
-func simLogPredicate(cfg Config, info AppBundleInfo) string {
+// unifiedLogPredicate matches the app's unified logs (all of its process's
+// logs when system logs are on), for simulator and device log streams
+func unifiedLogPredicate(cfg Config, info AppBundleInfo) string {
 	exec := info.Executable
 	bundle := info.BundleID
 	systemLogs := shouldStreamSystemLogs(cfg)
@@ -681,6 +1279,23 @@ func parseMirroredUnifiedLog(line string) (mirroredUnifiedLine, bool) {
 	}, true
 }
 
+// simctlLaunchLinePID reads the pid from simctl launch's first line,
+// "<bundle id>: <pid>" (or a line naming the pid)
+func simctlLaunchLinePID(line, bundleID string) int {
+	if pid := parseSimctlLaunchPID(line); pid > 0 {
+		return pid
+	}
+	rest, ok := strings.CutPrefix(strings.TrimSpace(line), bundleID+":")
+	if !ok {
+		return 0
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(rest))
+	if err != nil {
+		return 0
+	}
+	return pid
+}
+
 func parseSimctlLaunchPID(output string) int {
 	for _, line := range strings.Split(output, "\n") {
 		if !strings.Contains(strings.ToLower(line), "pid") {
@@ -786,6 +1401,9 @@ func appDisplayName(info AppBundleInfo) string {
 }
 
 func baseXcodebuildArgs(projectRoot string, cfg Config) []string {
+	if cfg.TargetOverride != "" {
+		return targetBuildArgs(projectRoot, cfg)
+	}
 	args := []string{}
 	if cfg.Workspace != "" {
 		args = append(args, "-workspace", filepath.Join(projectRoot, cfg.Workspace))
@@ -808,7 +1426,7 @@ func formatCmd(path string, args []string) string {
 	parts := make([]string, 0, len(args)+1)
 	parts = append(parts, path)
 	for _, a := range args {
-		if strings.ContainsAny(a, " \t\"") {
+		if strings.ContainsAny(a, " \t\"()") {
 			a = strings.ReplaceAll(a, "\"", "\\\"")
 			parts = append(parts, "\""+a+"\"")
 		} else {
@@ -818,6 +1436,22 @@ func formatCmd(path string, args []string) string {
 	return strings.Join(parts, " ")
 }
 
+// xcodebuildCommandLine renders the invocation as RunStreaming executes it.
+// Env values are redacted (KEY=***) since they often carry secrets.
+func xcodebuildCommandLine(cfg Config, args []string) string {
+	keys := make([]string, 0, len(cfg.Xcodebuild.Env))
+	for k := range cfg.Xcodebuild.Env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys)+1)
+	for _, k := range keys {
+		parts = append(parts, k+"=***")
+	}
+	parts = append(parts, formatCmd("xcrun", append([]string{"xcodebuild"}, xcodebuildArgs(cfg, args)...)))
+	return strings.Join(parts, " ")
+}
+
 func emitMaybe(e Emitter, ev Event) {
 	if e != nil {
 		e.Emit(ev)
@@ -858,12 +1492,12 @@ type watchDeviceDeployment struct {
 
 var resolveCompanionDeviceIDForWatch = resolveCompanionDeviceID
 
-func resolveWatchDeviceDeployment(ctx context.Context, dst Destination, builtAppPath string, builtInfo AppBundleInfo, emit Emitter) (watchDeviceDeployment, error) {
+func resolveWatchDeviceDeployment(ctx context.Context, dst Destination, builtAppPath string, builtInfo AppBundleInfo, emit Emitter, preserveLocale bool) (watchDeviceDeployment, error) {
 	companionTarget := strings.TrimSpace(dst.CompanionTargetID)
 	if companionTarget == "" {
 		return watchDeviceDeployment{}, errors.New("missing companion target")
 	}
-	companionDeviceID, err := resolveCompanionDeviceIDForWatch(ctx, companionTarget, emit)
+	companionDeviceID, err := resolveCompanionDeviceIDForWatch(ctx, companionTarget, emit, preserveLocale)
 	if err != nil {
 		return watchDeviceDeployment{}, err
 	}
@@ -908,8 +1542,8 @@ func resolveWatchDeviceDeployment(ctx context.Context, dst Destination, builtApp
 	}, nil
 }
 
-func resolveCompanionDeviceID(ctx context.Context, target string, emit Emitter) (string, error) {
-	candidates, err := ListDestinationCandidates(ctx, emit)
+func resolveCompanionDeviceID(ctx context.Context, target string, emit Emitter, preserveLocale bool) (string, error) {
+	candidates, err := ListDestinationCandidates(ctx, emit, preserveLocale)
 	if err != nil {
 		return "", err
 	}
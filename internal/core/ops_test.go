@@ -0,0 +1,309 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBuildRecordsRedactedCommand(t *testing.T) {
+	root := t.TempDir()
+	rec := &recordingEmitter{}
+	cfg := Config{
+		Project:           "App.xcodeproj",
+		Scheme:            "App",
+		Configuration:     "Debug",
+		ResultBundlesPath: t.TempDir(),
+		Destination:       Destination{Kind: DestSimulator, UDID: "SIM-1", Platform: "iOS Simulator"},
+		Xcodebuild: XcodebuildConfig{
+			DryRun: true,
+			Env:    map[string]string{"API_TOKEN": "s3cret", "CI": "1"},
+		},
+	}
+
+	res, _, err := Build(context.Background(), root, cfg, rec)
+	if err != nil {
+		t.Fatalf("dry-run build: %v", err)
+	}
+	if !strings.HasPrefix(res.Command, "API_TOKEN=*** CI=*** xcrun xcodebuild ") || strings.Contains(res.Command, "s3cret") {
+		t.Fatalf("unexpected command: %q", res.Command)
+	}
+	if !strings.Contains(res.Command, "-scheme App") || !strings.HasSuffix(res.Command, " build \"-AppleLanguages=(en)\" -AppleLocale=en_US") {
+		t.Fatalf("command is missing xcodebuild args: %q", res.Command)
+	}
+
+	var found bool
+	for _, ev := range rec.events {
+		if ev.Code != XcodebuildCommandCode {
+			continue
+		}
+		found = true
+		if got := ev.Data.(map[string]any)["command"]; got != res.Command {
+			t.Fatalf("event command %q, result command %q", got, res.Command)
+		}
+	}
+	if !found {
+		t.Fatalf("no %s event in %+v", XcodebuildCommandCode, rec.events)
+	}
+}
+
+func TestFailedBuildCountsCompiledFiles(t *testing.T) {
+	bin := t.TempDir()
+	script := "#!/bin/sh\necho 'SwiftCompile normal arm64 /src/A.swift'\necho 'SwiftCompile normal arm64 /src/B.swift'\necho '/src/B.swift:1:1: error: no such module' >&2\nexit 65\n"
+	if err := os.WriteFile(filepath.Join(bin, "xcrun"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	cfg := Config{
+		Project:           "App.xcodeproj",
+		Scheme:            "App",
+		DerivedDataPath:   t.TempDir(),
+		ResultBundlesPath: t.TempDir(),
+		Destination:       Destination{Kind: DestMacOS, PlatformFamily: PlatformMacOS, TargetType: TargetLocal},
+	}
+	rec := &recordingEmitter{}
+	res, _, err := Build(context.Background(), t.TempDir(), cfg, rec)
+	if err == nil {
+		t.Fatal("expected the build to fail")
+	}
+	if res.CompiledFiles != 2 {
+		t.Fatalf("CompiledFiles = %d, want 2", res.CompiledFiles)
+	}
+	r := newTestLastRunRecorder("build")
+	for _, ev := range rec.events {
+		r.Observe(ev)
+	}
+	if run := r.Finish(err); run.Counts.CompiledFiles != 2 {
+		t.Fatalf("last run counts %+v, want 2 compiled files", run.Counts)
+	}
+}
+
+func TestRunSkipsBeforeLaunchWhenBuildFails(t *testing.T) {
+	bin := t.TempDir()
+	script := "#!/bin/sh\necho 'error: no such module' >&2\nexit 65\n"
+	if err := os.WriteFile(filepath.Join(bin, "xcrun"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	root := t.TempDir()
+	cfg := Config{
+		Project:           "App.xcodeproj",
+		Scheme:            "App",
+		Configuration:     "Debug",
+		DerivedDataPath:   t.TempDir(),
+		ResultBundlesPath: t.TempDir(),
+		Destination:       Destination{Kind: DestMacOS, PlatformFamily: PlatformMacOS, TargetType: TargetLocal},
+	}
+	called := false
+	opts := RunOptions{Console: true, BeforeLaunch: func(context.Context) error {
+		called = true
+		return nil
+	}}
+	rec := &recordingEmitter{}
+	if _, _, err := RunWithOptions(context.Background(), root, cfg, opts, rec); err == nil {
+		t.Fatal("expected the failed build to fail the run")
+	}
+	if called {
+		t.Fatal("BeforeLaunch ran after a failed build")
+	}
+	failed := false
+	for _, ev := range rec.events {
+		failed = failed || (ev.Err != nil && ev.Err.Code == "XCODEBUILD_FAILED")
+	}
+	if !failed {
+		t.Fatalf("run did not fail in the build: %+v", rec.events)
+	}
+}
+
+func TestRunBeforeLaunchErrorIsReported(t *testing.T) {
+	rec := &recordingEmitter{}
+	opts := RunOptions{BeforeLaunch: func(context.Context) error { return errors.New("terminate failed") }}
+	if err := runBeforeLaunch(context.Background(), opts, rec); err == nil {
+		t.Fatal("expected the error")
+	}
+	if len(rec.events) != 1 || rec.events[0].Err == nil || rec.events[0].Err.Code != "PRE_LAUNCH_FAILED" {
+		t.Fatalf("events = %+v", rec.events)
+	}
+	if err := runBeforeLaunch(context.Background(), RunOptions{}, rec); err != nil {
+		t.Fatalf("nil BeforeLaunch: %v", err)
+	}
+}
+
+// fakeLaunchingSimulator puts an xcrun on PATH whose simctl launch reports a
+// pid, prints a line of app output, and stays attached like --console
+func fakeLaunchingSimulator(t *testing.T) {
+	t.Helper()
+	fakeSimulatorWithBoot(t, "")
+}
+
+// fakeSimulatorWithBoot is fakeLaunchingSimulator whose simctl boot prints
+// bootOutput and fails when it is not ""
+func fakeSimulatorWithBoot(t *testing.T, bootOutput string) {
+	t.Helper()
+	bin := t.TempDir()
+	boot := ""
+	if bootOutput != "" {
+		boot = "*\"simctl boot \"*)\n  echo '" + bootOutput + "' >&2\n  exit 149;;\n"
+	}
+	script := "#!/bin/sh\ncase \"$*\" in\n" + boot +
+		"*\"simctl list\"*)\n" +
+		"  echo '{\"devices\": {\"com.apple.CoreSimulator.SimRuntime.iOS-17-5\": [{\"name\": \"iPhone 15\", \"udid\": \"SIM-1\", \"state\": \"Booted\", \"isAvailable\": true}]}}';;\n" +
+		"*\"simctl launch\"*)\n" +
+		"  echo 'com.example.app: 4242'\n  echo 'hello from the app'\n  exec sleep 30;;\nesac\nexit 0\n"
+	for name, body := range map[string]string{"xcrun": script, "open": "#!/bin/sh\nexit 0\n"} {
+		if err := os.WriteFile(filepath.Join(bin, name), []byte(body), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+}
+
+func writeTestApp(t *testing.T) string {
+	t.Helper()
+	app := filepath.Join(t.TempDir(), "App.app")
+	if err := os.MkdirAll(app, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	plist := `<?xml version="1.0" encoding="UTF-8"?>
+<plist version="1.0"><dict>
+<key>CFBundleIdentifier</key><string>com.example.app</string>
+<key>CFBundleExecutable</key><string>App</string>
+</dict></plist>`
+	if err := os.WriteFile(filepath.Join(app, "Info.plist"), []byte(plist), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return app
+}
+
+func TestRunCanceledAfterBuildKeepsTheApp(t *testing.T) {
+	fakeLaunchingSimulator(t)
+	app := writeTestApp(t)
+	cfg := Config{
+		Project:           "App.xcodeproj",
+		Scheme:            "App",
+		Configuration:     "Debug",
+		ResultBundlesPath: t.TempDir(),
+		LastResultBundle:  "/results/last.xcresult",
+		Destination:       Destination{Kind: DestSimulator, UDID: "SIM-1", Platform: "iOS Simulator", PlatformFamily: PlatformIOS},
+		Xcodebuild:        XcodebuildConfig{SkipDestinationCheck: true},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var phases []string
+	emit := emitterFunc(func(ev Event) {
+		if ev.Code == RunPhaseCode {
+			phase := ev.Data.(map[string]any)["phase"].(string)
+			phases = append(phases, phase)
+			if RunPhase(phase) == RunPhaseConsole {
+				cancel()
+			}
+		}
+	})
+	res, cfg2, err := RunWithOptions(ctx, t.TempDir(), cfg, RunOptions{Console: true, AppPath: app}, emit)
+	var phaseErr *RunPhaseCanceledError
+	if !errors.As(err, &phaseErr) || phaseErr.Phase != RunPhaseConsole {
+		t.Fatalf("err = %v, want a console RunPhaseCanceledError", err)
+	}
+	if !errors.Is(err, context.Canceled) || err.Error() != "console canceled" {
+		t.Fatalf("err = %q, want it to wrap context.Canceled", err)
+	}
+	if strings.Join(phases, ",") != "install,launch,console" {
+		t.Fatalf("phases = %v", phases)
+	}
+	if res.AppPath != app || res.BundleID != "com.example.app" || res.ResultBundle != "/results/last.xcresult" || res.Phase != RunPhaseConsole {
+		t.Fatalf("res = %+v", res)
+	}
+	if cfg2.LastBuiltAppBundle != app {
+		t.Fatalf("LastBuiltAppBundle = %q", cfg2.LastBuiltAppBundle)
+	}
+}
+
+func TestRunReportsStepTimings(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		bootOutput string
+		want       string
+	}{
+		{"boots the simulator", "", "boot,install,logs,launch"},
+		{"already booted", "Unable to boot device in current state: Booted", "install,logs,launch"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeSimulatorWithBoot(t, tc.bootOutput)
+			cfg := Config{
+				Project:           "App.xcodeproj",
+				Scheme:            "App",
+				Configuration:     "Debug",
+				ResultBundlesPath: t.TempDir(),
+				Destination:       Destination{Kind: DestSimulator, UDID: "SIM-1", Platform: "iOS Simulator", PlatformFamily: PlatformIOS},
+				Xcodebuild:        XcodebuildConfig{SkipDestinationCheck: true},
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			var timings []RunStepTiming
+			var msg string
+			emit := emitterFunc(func(ev Event) {
+				if ev.Code == RunTimingCode {
+					timings = ev.Data.(map[string]any)["phases"].([]RunStepTiming)
+					msg = ev.Msg
+					cancel()
+				}
+			})
+			_, _, _ = RunWithOptions(ctx, t.TempDir(), cfg, RunOptions{Console: true, AppPath: writeTestApp(t)}, emit)
+
+			var names []string
+			for _, step := range timings {
+				names = append(names, step.Name)
+			}
+			if strings.Join(names, ",") != tc.want {
+				t.Fatalf("steps = %v, want %s", names, tc.want)
+			}
+			if !strings.HasPrefix(msg, "Run timing: ") || !strings.Contains(msg, " · launch ") {
+				t.Fatalf("msg = %q", msg)
+			}
+		})
+	}
+}
+
+func TestFormatRunTimings(t *testing.T) {
+	got := FormatRunTimings([]RunStepTiming{{"build", 42300}, {"boot", 3000}, {"install", 2140}, {"logs", 12}, {"launch", 800}, {"build", 95000}})
+	if want := "build 42s · boot 3s · install 2.1s · logs 12ms · launch 0.8s · build 1m35s"; got != want {
+		t.Fatalf("got  %q\nwant %q", got, want)
+	}
+}
+
+func TestRunWithMissingAppPathFails(t *testing.T) {
+	fakeLaunchingSimulator(t)
+	cfg := Config{
+		Project:           "App.xcodeproj",
+		Scheme:            "App",
+		Configuration:     "Debug",
+		ResultBundlesPath: t.TempDir(),
+		Destination:       Destination{Kind: DestSimulator, UDID: "SIM-1", Platform: "iOS Simulator", PlatformFamily: PlatformIOS},
+		Xcodebuild:        XcodebuildConfig{SkipDestinationCheck: true},
+	}
+	rec := &recordingEmitter{}
+	_, _, err := RunWithOptions(context.Background(), t.TempDir(), cfg, RunOptions{AppPath: "/missing/App.app"}, rec)
+	if err == nil {
+		t.Fatal("expected a missing app bundle to fail")
+	}
+	var phaseErr *RunPhaseCanceledError
+	if errors.As(err, &phaseErr) {
+		t.Fatalf("err = %v, not a cancel", err)
+	}
+	for _, ev := range rec.events {
+		if ev.Err != nil && ev.Err.Code == "APP_BUNDLE_MISSING" {
+			return
+		}
+	}
+	t.Fatalf("no APP_BUNDLE_MISSING in %+v", rec.events)
+}
+
+type emitterFunc func(Event)
+
+func (f emitterFunc) Emit(ev Event) { f(ev) }
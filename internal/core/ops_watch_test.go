@@ -107,7 +107,7 @@ func TestResolveWatchDeviceDeploymentFromCompanionBuild(t *testing.T) {
 	writeTestAppBundle(t, watchPath, watchBundleID, true, companionBundleID)
 
 	oldResolver := resolveCompanionDeviceIDForWatch
-	resolveCompanionDeviceIDForWatch = func(ctx context.Context, target string, emit Emitter) (string, error) {
+	resolveCompanionDeviceIDForWatch = func(ctx context.Context, target string, emit Emitter, preserveLocale bool) (string, error) {
 		if target != "my-phone" {
 			t.Fatalf("target = %q", target)
 		}
@@ -116,7 +116,7 @@ func TestResolveWatchDeviceDeploymentFromCompanionBuild(t *testing.T) {
 	defer func() { resolveCompanionDeviceIDForWatch = oldResolver }()
 
 	dst := Destination{PlatformFamily: PlatformWatchOS, TargetType: TargetDevice, CompanionTargetID: "my-phone"}
-	dep, err := resolveWatchDeviceDeployment(context.Background(), dst, companionPath, companionInfo, nil)
+	dep, err := resolveWatchDeviceDeployment(context.Background(), dst, companionPath, companionInfo, nil, false)
 	if err != nil {
 		t.Fatalf("resolveWatchDeviceDeployment: %v", err)
 	}
@@ -141,13 +141,13 @@ func TestResolveWatchDeviceDeploymentFromWatchBuild(t *testing.T) {
 	writeTestAppBundle(t, companionPath, companionBundleID, false, "")
 
 	oldResolver := resolveCompanionDeviceIDForWatch
-	resolveCompanionDeviceIDForWatch = func(ctx context.Context, target string, emit Emitter) (string, error) {
+	resolveCompanionDeviceIDForWatch = func(ctx context.Context, target string, emit Emitter, preserveLocale bool) (string, error) {
 		return "iphone-udid", nil
 	}
 	defer func() { resolveCompanionDeviceIDForWatch = oldResolver }()
 
 	dst := Destination{PlatformFamily: PlatformWatchOS, TargetType: TargetDevice, CompanionTargetID: "my-phone"}
-	dep, err := resolveWatchDeviceDeployment(context.Background(), dst, watchPath, watchInfo, nil)
+	dep, err := resolveWatchDeviceDeployment(context.Background(), dst, watchPath, watchInfo, nil, false)
 	if err != nil {
 		t.Fatalf("resolveWatchDeviceDeployment: %v", err)
 	}
@@ -168,13 +168,13 @@ func TestResolveWatchDeviceDeploymentFailsOnMismatch(t *testing.T) {
 	writeTestAppBundle(t, companionPath, "com.other.phone", false, "")
 
 	oldResolver := resolveCompanionDeviceIDForWatch
-	resolveCompanionDeviceIDForWatch = func(ctx context.Context, target string, emit Emitter) (string, error) {
+	resolveCompanionDeviceIDForWatch = func(ctx context.Context, target string, emit Emitter, preserveLocale bool) (string, error) {
 		return "iphone-udid", nil
 	}
 	defer func() { resolveCompanionDeviceIDForWatch = oldResolver }()
 
 	dst := Destination{PlatformFamily: PlatformWatchOS, TargetType: TargetDevice, CompanionTargetID: "my-phone"}
-	_, err := resolveWatchDeviceDeployment(context.Background(), dst, watchPath, watchInfo, nil)
+	_, err := resolveWatchDeviceDeployment(context.Background(), dst, watchPath, watchInfo, nil, false)
 	if err == nil {
 		t.Fatalf("expected mismatch error")
 	}
@@ -186,7 +186,7 @@ func TestResolveWatchDeviceDeploymentRequiresCompanionTarget(t *testing.T) {
 	companionInfo := writeTestAppBundle(t, companionPath, "com.example.phone", false, "")
 
 	dst := Destination{PlatformFamily: PlatformWatchOS, TargetType: TargetDevice}
-	_, err := resolveWatchDeviceDeployment(context.Background(), dst, companionPath, companionInfo, nil)
+	_, err := resolveWatchDeviceDeployment(context.Background(), dst, companionPath, companionInfo, nil, false)
 	if err == nil {
 		t.Fatalf("expected missing companion target error")
 	}
@@ -0,0 +1,144 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	ProductApp       = "app"
+	ProductExtension = "appex"
+)
+
+// ProductInfo is an app or app extension built by the scheme.
+type ProductInfo struct {
+	Path     string `json:"path"`
+	BundleID string `json:"bundleId"`
+	Kind     string `json:"kind"` // ProductApp or ProductExtension
+	// HostApp is the app embedding an extension (installing it installs the
+	// extension). Empty for apps and for extensions that aren't embedded.
+	HostApp string `json:"hostApp,omitempty"`
+	// ExtensionPoint is the extension's NSExtensionPointIdentifier.
+	ExtensionPoint string `json:"extensionPoint,omitempty"`
+}
+
+// Name is the product's bundle file name, e.g. "Widgets.appex"
+func (p ProductInfo) Name() string {
+	return filepath.Base(p.Path)
+}
+
+// productPlugInDirs are where bundles embed extensions (iOS, then macOS layout)
+var productPlugInDirs = []string{"PlugIns", "Extensions", "Contents/PlugIns", "Contents/Extensions"}
+
+// DiscoverProducts lists the .app and .appex bundles in buildDir (the
+// TARGET_BUILD_DIR of a build): apps first, then extensions, each sorted by
+// path. Extensions embedded in an app are listed once, under their host.
+func DiscoverProducts(buildDir string) []ProductInfo {
+	entries, err := os.ReadDir(buildDir)
+	if err != nil {
+		return nil
+	}
+	var apps, exts []ProductInfo
+	embedded := map[string]bool{}
+	for _, e := range entries {
+		if !e.IsDir() || !strings.EqualFold(filepath.Ext(e.Name()), ".app") {
+			continue
+		}
+		appPath := filepath.Join(buildDir, e.Name())
+		info, err := readProductInfo(appPath)
+		if err != nil {
+			continue
+		}
+		apps = append(apps, ProductInfo{Path: appPath, BundleID: info.BundleID, Kind: ProductApp})
+		for _, dir := range productPlugInDirs {
+			matches, _ := filepath.Glob(filepath.Join(appPath, dir, "*.appex"))
+			for _, extPath := range matches {
+				ext, err := readProductInfo(extPath)
+				if err != nil {
+					continue
+				}
+				embedded[ext.BundleID] = true
+				exts = append(exts, ProductInfo{Path: extPath, BundleID: ext.BundleID, Kind: ProductExtension, HostApp: appPath, ExtensionPoint: ext.ExtensionPoint})
+			}
+		}
+	}
+	for _, e := range entries {
+		if !e.IsDir() || !strings.EqualFold(filepath.Ext(e.Name()), ".appex") {
+			continue
+		}
+		extPath := filepath.Join(buildDir, e.Name())
+		ext, err := readProductInfo(extPath)
+		if err != nil || embedded[ext.BundleID] {
+			continue
+		}
+		exts = append(exts, ProductInfo{Path: extPath, BundleID: ext.BundleID, Kind: ProductExtension, ExtensionPoint: ext.ExtensionPoint})
+	}
+	sort.Slice(apps, func(i, j int) bool { return apps[i].Path < apps[j].Path })
+	sort.Slice(exts, func(i, j int) bool { return exts[i].Path < exts[j].Path })
+	return append(apps, exts...)
+}
+
+// readProductInfo reads a bundle's Info.plist (flat iOS or macOS Contents/ layout)
+func readProductInfo(path string) (AppBundleInfo, error) {
+	info, err := ReadAppBundleInfo(path)
+	if err != nil {
+		info, err = ReadAppBundleInfo(filepath.Join(path, "Contents"))
+	}
+	if err == nil && info.BundleID == "" {
+		err = errors.New("bundle id missing")
+	}
+	return info, err
+}
+
+// ListProducts reads the build settings and lists the products already built
+// into TARGET_BUILD_DIR.
+func ListProducts(ctx context.Context, projectRoot string, cfg Config) ([]ProductInfo, error) {
+	settings, err := ShowBuildSettings(ctx, projectRoot, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return DiscoverProducts(productsDir(settings)), nil
+}
+
+func productsDir(settings BuildSettings) string {
+	if dir := settings["TARGET_BUILD_DIR"]; dir != "" {
+		return dir
+	}
+	return settings["BUILT_PRODUCTS_DIR"]
+}
+
+// selectProduct finds the product with bundleID among a build's products
+func selectProduct(products []ProductInfo, bundleID string) (ProductInfo, error) {
+	var ids []string
+	for _, p := range products {
+		if p.BundleID == bundleID {
+			return p, nil
+		}
+		ids = append(ids, p.BundleID)
+	}
+	if len(ids) == 0 {
+		return ProductInfo{}, fmt.Errorf("product %s not found: no products discovered in the build directory", bundleID)
+	}
+	return ProductInfo{}, fmt.Errorf("product %s not found (built: %s)", bundleID, strings.Join(ids, ", "))
+}
+
+// extensionLaunchNote explains what running an extension does: extensions
+// can't be launched on their own, so their host app is installed and launched.
+func extensionLaunchNote(p ProductInfo) string {
+	host := filepath.Base(p.HostApp)
+	how := "trigger it from " + host + " or the system UI to debug it"
+	switch p.ExtensionPoint {
+	case "com.apple.widgetkit-extension":
+		how = "add the widget to the home screen to load it"
+	case "com.apple.usernotifications.content-extension", "com.apple.usernotifications.service":
+		how = "send a matching push notification to load it"
+	case "com.apple.share-services":
+		how = "share something from any app to load it"
+	}
+	return fmt.Sprintf("%s is an app extension and can't be launched directly; installed and launched %s instead — %s", p.Name(), host, how)
+}
@@ -0,0 +1,81 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"howett.net/plist"
+)
+
+func writeBundle(t *testing.T, dir string, info map[string]any) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	b, err := plist.Marshal(info, plist.XMLFormat)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "Info.plist"), b, 0o644); err != nil {
+		t.Fatalf("write plist: %v", err)
+	}
+}
+
+func TestDiscoverProducts(t *testing.T) {
+	dir := t.TempDir()
+	app := filepath.Join(dir, "App.app")
+	widget := filepath.Join(app, "PlugIns", "Widgets.appex")
+	writeBundle(t, app, map[string]any{"CFBundleIdentifier": "com.example.app"})
+	writeBundle(t, widget, map[string]any{
+		"CFBundleIdentifier": "com.example.app.widgets",
+		"NSExtension":        map[string]any{"NSExtensionPointIdentifier": "com.apple.widgetkit-extension"},
+	})
+	// The standalone copy of an embedded extension is listed once, under its host.
+	writeBundle(t, filepath.Join(dir, "Widgets.appex"), map[string]any{"CFBundleIdentifier": "com.example.app.widgets"})
+	writeBundle(t, filepath.Join(dir, "Loose.appex"), map[string]any{"CFBundleIdentifier": "com.example.loose"})
+	writeBundle(t, filepath.Join(dir, "Mac.app", "Contents"), map[string]any{"CFBundleIdentifier": "com.example.mac"})
+	if err := os.MkdirAll(filepath.Join(dir, "Broken.app"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []ProductInfo{
+		{Path: app, BundleID: "com.example.app", Kind: ProductApp},
+		{Path: filepath.Join(dir, "Mac.app"), BundleID: "com.example.mac", Kind: ProductApp},
+		{Path: widget, BundleID: "com.example.app.widgets", Kind: ProductExtension, HostApp: app, ExtensionPoint: "com.apple.widgetkit-extension"},
+		{Path: filepath.Join(dir, "Loose.appex"), BundleID: "com.example.loose", Kind: ProductExtension},
+	}
+	if got := DiscoverProducts(dir); !reflect.DeepEqual(got, want) {
+		t.Fatalf("DiscoverProducts =\n%+v\nwant\n%+v", got, want)
+	}
+	if got := DiscoverProducts(filepath.Join(dir, "missing")); got != nil {
+		t.Fatalf("missing dir should list nothing, got %+v", got)
+	}
+}
+
+func TestSelectProduct(t *testing.T) {
+	products := []ProductInfo{
+		{Path: "/b/App.app", BundleID: "com.example.app", Kind: ProductApp},
+		{Path: "/b/App.app/PlugIns/W.appex", BundleID: "com.example.w", Kind: ProductExtension, HostApp: "/b/App.app"},
+	}
+	p, err := selectProduct(products, "com.example.w")
+	if err != nil || p.HostApp != "/b/App.app" {
+		t.Fatalf("selectProduct = %+v, %v", p, err)
+	}
+	if _, err := selectProduct(products, "com.example.gone"); err == nil || !strings.Contains(err.Error(), "com.example.app, com.example.w") {
+		t.Fatalf("error should list the built products, got %v", err)
+	}
+}
+
+func TestExtensionLaunchNote(t *testing.T) {
+	note := extensionLaunchNote(ProductInfo{
+		Path:           "/b/App.app/PlugIns/Widgets.appex",
+		HostApp:        "/b/App.app",
+		ExtensionPoint: "com.apple.widgetkit-extension",
+	})
+	if !strings.Contains(note, "launched App.app instead") || !strings.Contains(note, "add the widget") {
+		t.Fatalf("unexpected note %q", note)
+	}
+}
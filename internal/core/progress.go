@@ -0,0 +1,153 @@
+package core
+
+import (
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ProgressInterval is the least time between two progress events of an
+// operation, unless the stage changes.
+const ProgressInterval = 250 * time.Millisecond
+
+// Progress reports where a build is (type "progress"): data.stage, e.g.
+// "Compile"; data.current and data.total, the steps done and expected (total
+// is 0 when unknown); and data.file, the file being worked on, if any. The
+// event carries no message so text output stays quiet.
+func Progress(cmd, stage string, current, total int, file string) Event {
+	data := map[string]any{"stage": stage, "current": current, "total": total}
+	if file != "" {
+		data["file"] = file
+	}
+	return Event{V: EventSchemaVersion, TS: NowTS(), Cmd: cmd, Type: "progress", Level: "info", Data: data}
+}
+
+// rawStepStages maps raw xcodebuild step names to progress stages. Step
+// names are never localized.
+var rawStepStages = []struct {
+	prefix string
+	stage  string
+}{
+	{"CompileSwift ", "Compile"},
+	{"CompileSwiftSources ", "Compile"},
+	{"SwiftCompile ", "Compile"},
+	{"CompileC ", "Compile"},
+	{"ProcessInfoPlistFile ", "Compile"},
+	{"CompileAssetCatalog ", "Compile"},
+	{"CompileStoryboard ", "Compile"},
+	{"Analyze ", "Analyzing"},
+	{"Ld ", "Link"},
+	{"CpResource ", "Link"},
+	{"CodeSign ", "Sign"},
+	{"PhaseScriptExecution ", "Running"},
+	{"Test Suite ", "Testing"},
+	{"Testing started", "Testing"},
+	// swift build, once normalizeSwiftBuildLine removed the [N/M] prefix
+	{"Compiling ", "Compile"},
+	{"Linking ", "Link"},
+	// SwiftPM package resolution
+	{"Resolve Package Graph", "Packages"},
+	{"Resolved source packages:", "Packages"},
+	{"Fetching from ", "Packages"},
+	{"Computing version for ", "Packages"},
+}
+
+// RawStepStage returns the progress stage of a raw xcodebuild step line, or
+// "".
+func RawStepStage(line string) string {
+	for _, s := range rawStepStages {
+		if strings.HasPrefix(line, s.prefix) {
+			return s.stage
+		}
+	}
+	return ""
+}
+
+// "[12/40] Compiling App AppDelegate.swift"
+var swiftBuildCountRE = regexp.MustCompile(`^\[(\d+)/(\d+)\] `)
+
+// progressTracker turns log lines into throttled progress events. current
+// counts compile steps for xcodebuild, whose output has no total (like
+// CompiledFiles), and follows the [N/M] step counter for swift build.
+type progressTracker struct {
+	stage   string
+	current int
+	total   int
+	file    string
+
+	now     func() time.Time
+	last    time.Time
+	pending bool
+}
+
+// observe reads a raw line (before normalizeSwiftBuildLine) and returns a
+// progress event when one is due.
+func (p *progressTracker) observe(cmd, line string) (Event, bool) {
+	changed := false
+	if m := swiftBuildCountRE.FindStringSubmatch(line); m != nil {
+		cur, _ := strconv.Atoi(m[1])
+		total, _ := strconv.Atoi(m[2])
+		if cur > p.current {
+			p.current = cur
+		}
+		p.total = max(p.total, total)
+		line = line[len(m[0]):]
+		changed = true
+	}
+	stage := RawStepStage(line)
+	if stage == "" {
+		if !changed {
+			return Event{}, false
+		}
+		stage = p.stage
+	}
+	if stage == "Compile" {
+		if file := stepSourceFile(line); file != "" {
+			p.file = file
+		}
+	}
+	if isCompileStep(line) && p.total == 0 {
+		p.current++
+	}
+	stageChanged := stage != p.stage
+	p.stage = stage
+	return p.due(cmd, stageChanged)
+}
+
+// due emits now if the stage changed or enough time passed, and otherwise
+// remembers that an update is pending
+func (p *progressTracker) due(cmd string, force bool) (Event, bool) {
+	now := time.Now
+	if p.now != nil {
+		now = p.now
+	}
+	t := now()
+	if !force && !p.last.IsZero() && t.Sub(p.last) < ProgressInterval {
+		p.pending = true
+		return Event{}, false
+	}
+	p.last, p.pending = t, false
+	return Progress(cmd, p.stage, p.current, p.total, p.file), true
+}
+
+// flush returns the last update held back by throttling
+func (p *progressTracker) flush(cmd string) (Event, bool) {
+	if !p.pending {
+		return Event{}, false
+	}
+	p.pending = false
+	return Progress(cmd, p.stage, p.current, p.total, p.file), true
+}
+
+// stepSourceFile returns the source file a compile step works on
+func stepSourceFile(line string) string {
+	for _, word := range strings.Fields(line) {
+		switch filepath.Ext(word) {
+		case ".swift", ".m", ".mm", ".c", ".cc", ".cpp":
+			return word
+		}
+	}
+	return ""
+}
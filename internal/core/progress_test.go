@@ -0,0 +1,129 @@
+package core
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func progressEvents(events []Event) []map[string]any {
+	var out []map[string]any
+	for _, ev := range events {
+		if ev.Type == "progress" {
+			out = append(out, ev.Data.(map[string]any))
+		}
+	}
+	return out
+}
+
+func TestLogSinkEmitsMonotonicProgress(t *testing.T) {
+	b, err := os.ReadFile(filepath.Join("testdata", "xcodebuild-build.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := &recordingEmitter{}
+	sink := newXcodebuildLogSink(context.Background(), "build", Config{Xcodebuild: XcodebuildConfig{LogFormat: "raw"}}, rec)
+	clock := time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC)
+	sink.progress.now = func() time.Time { return clock }
+	for _, line := range strings.Split(string(b), "\n") {
+		clock = clock.Add(50 * time.Millisecond)
+		sink.HandleLine(line)
+	}
+	sink.Finalize(nil, 0)
+
+	progress := progressEvents(rec.events)
+	if len(progress) == 0 {
+		t.Fatal("no progress events")
+	}
+	prev, stages := -1, []string{}
+	for _, p := range progress {
+		cur := p["current"].(int)
+		if cur < prev {
+			t.Fatalf("current went back from %d to %d: %v", prev, cur, progress)
+		}
+		prev = cur
+		if stage := p["stage"].(string); len(stages) == 0 || stages[len(stages)-1] != stage {
+			stages = append(stages, stage)
+		}
+	}
+	if got, want := strings.Join(stages, ","), "Packages,Compile,Link,Sign,Compile,Link,Sign"; got != want {
+		t.Fatalf("stages = %s, want %s", got, want)
+	}
+	last := progress[len(progress)-1]
+	if last["current"] != sink.CompiledFiles() || last["total"] != 0 {
+		t.Fatalf("last progress = %v, want current %d of an unknown total", last, sink.CompiledFiles())
+	}
+	if sink.CompiledFiles() != 8 {
+		t.Fatalf("CompiledFiles = %d", sink.CompiledFiles())
+	}
+	var files []string
+	for _, p := range progress {
+		f, _ := p["file"].(string)
+		if f != "" && (len(files) == 0 || files[len(files)-1] != filepath.Base(f)) {
+			files = append(files, filepath.Base(f))
+		}
+	}
+	if len(files) == 0 || files[len(files)-1] != "ProductList.swift" {
+		t.Fatalf("files = %v", files)
+	}
+}
+
+func TestProgressIsThrottled(t *testing.T) {
+	clock := time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC)
+	p := progressTracker{now: func() time.Time { return clock }}
+	emitted := 0
+	for i := range 40 {
+		clock = clock.Add(30 * time.Millisecond)
+		if _, ok := p.observe("build", "CompileSwift normal arm64 /src/F"+string(rune('a'+i%26))+".swift"); ok {
+			emitted++
+		}
+	}
+	// 1.2s of compiling: the first step, then one per ProgressInterval
+	if emitted != 5 {
+		t.Fatalf("emitted %d progress events in 1.2s", emitted)
+	}
+	ev, ok := p.flush("build")
+	if !ok || ev.Data.(map[string]any)["current"] != 40 {
+		t.Fatalf("flush = %+v, %v", ev, ok)
+	}
+	if _, ok := p.flush("build"); ok {
+		t.Fatal("flushed twice")
+	}
+
+	// A stage change is reported right away
+	if _, ok := p.observe("build", "Ld /build/App normal"); !ok {
+		t.Fatal("stage change was throttled")
+	}
+}
+
+func TestProgressFollowsSwiftBuildCounter(t *testing.T) {
+	p := progressTracker{now: func() time.Time { return time.Time{} }}
+	ev, ok := p.observe("build", "[3/12] Compiling ShopKit Cart.swift")
+	if !ok {
+		t.Fatal("no progress")
+	}
+	data := ev.Data.(map[string]any)
+	if data["stage"] != "Compile" || data["current"] != 3 || data["total"] != 12 || data["file"] != "Cart.swift" {
+		t.Fatalf("progress = %v", data)
+	}
+	p.observe("build", "[12/12] Linking shop")
+	if p.stage != "Link" || p.current != 12 {
+		t.Fatalf("tracker = %+v", p)
+	}
+}
+
+func TestProgressStageFromPackageResolution(t *testing.T) {
+	p := progressTracker{now: func() time.Time { return time.Time{} }}
+	for _, line := range []string{"Resolve Package Graph", "Fetching from https://github.com/apple/swift-log.git", "Resolved source packages:"} {
+		ev, ok := p.observe("build", line)
+		if !ok && p.stage != "Packages" {
+			t.Fatalf("%q: no Packages progress", line)
+		}
+		if ok && ev.Data.(map[string]any)["stage"] != "Packages" {
+			t.Fatalf("%q: progress = %v", line, ev.Data)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// MaxBuildRecords caps the build history kept per project.
+const MaxBuildRecords = 20
+
+const (
+	// fullRebuildRatio is the share of the last full build's compiled files
+	// above which an incremental build counts as a full rebuild.
+	fullRebuildRatio = 0.8
+	// minFullBuildFiles keeps tiny projects, where every build recompiles
+	// everything, from warning on each build.
+	minFullBuildFiles = 10
+)
+
+// Raw xcodebuild compile steps, one per compiled file (or Swift batch)
+var compileStepRE = regexp.MustCompile(`^(?:CompileC|CompileSwift|SwiftCompile) `)
+
+// isCompileStep reports whether a raw xcodebuild line starts compiling a file
+func isCompileStep(line string) bool {
+	return compileStepRE.MatchString(line)
+}
+
+// BuildRecord is one successful build in the per-project build history
+type BuildRecord struct {
+	Scheme        string `json:"scheme,omitempty"`
+	Configuration string `json:"configuration,omitempty"`
+	Destination   string `json:"destination,omitempty"` // Display name
+	DestinationID string `json:"destinationId,omitempty"`
+	CompiledFiles int    `json:"compiledFiles"`
+	// Cleaned is set when DerivedData was cleaned since the previous build.
+	Cleaned   bool   `json:"cleaned,omitempty"`
+	Timestamp string `json:"timestamp"`
+}
+
+// NewBuildRecord describes a finished build of cfg
+func NewBuildRecord(cfg Config, compiledFiles int, at time.Time) BuildRecord {
+	id := cfg.Destination.ID
+	if id == "" {
+		id = cfg.Destination.UDID
+	}
+	if id == "" {
+		id = string(cfg.Destination.Kind)
+	}
+	return BuildRecord{
+		Scheme:        cfg.Scheme,
+		Configuration: cfg.Configuration,
+		Destination:   cfg.Destination.Name,
+		DestinationID: id,
+		CompiledFiles: compiledFiles,
+		Timestamp:     at.Format(time.RFC3339),
+	}
+}
+
+// RebuildCheck compares a build's compiled files with its history
+type RebuildCheck struct {
+	Compiled int
+	// FullBuild is the most files an earlier build of the same scheme and
+	// configuration compiled.
+	FullBuild int
+	// Delta is the change from the previous build of the same scheme and
+	// configuration.
+	Delta int
+}
+
+// Message is the warning shown for an unexpected full rebuild
+func (c RebuildCheck) Message() string {
+	return fmt.Sprintf("looks like a full rebuild — derived data may have been invalidated (%d files compiled, %+d vs the previous build)", c.Compiled, c.Delta)
+}
+
+// RecordClean notes that DerivedData was cleaned, so the next build is
+// expected to compile everything.
+func (st *State) RecordClean(projectRoot string, at time.Time) {
+	if st.Cleans == nil {
+		st.Cleans = make(map[string]string)
+	}
+	st.Cleans[projectRoot] = at.Format(time.RFC3339)
+}
+
+// RecordBuild appends r to the project's build history and reports whether
+// it looks like an unexpected full rebuild: it compiled more than 80% of the
+// last full build although no clean ran and the destination didn't change.
+func (st *State) RecordBuild(projectRoot string, r BuildRecord) (RebuildCheck, bool) {
+	if st.Builds == nil {
+		st.Builds = make(map[string][]BuildRecord)
+	}
+	if _, ok := st.Cleans[projectRoot]; ok {
+		r.Cleaned = true
+		delete(st.Cleans, projectRoot)
+	}
+	history := st.Builds[projectRoot]
+	check, rebuilt := checkRebuild(history, r)
+
+	history = append(history, r)
+	if len(history) > MaxBuildRecords {
+		history = history[len(history)-MaxBuildRecords:]
+	}
+	st.Builds[projectRoot] = history
+	return check, rebuilt
+}
+
+// BuildRecords returns the project's build history, oldest first
+func (st *State) BuildRecords(projectRoot string) []BuildRecord {
+	if st.Builds == nil {
+		return nil
+	}
+	return st.Builds[projectRoot]
+}
+
+func checkRebuild(history []BuildRecord, r BuildRecord) (RebuildCheck, bool) {
+	check := RebuildCheck{Compiled: r.CompiledFiles}
+	var prev *BuildRecord
+	for i := range history {
+		h := &history[i]
+		if h.Scheme != r.Scheme || h.Configuration != r.Configuration {
+			continue
+		}
+		prev = h
+		check.FullBuild = max(check.FullBuild, h.CompiledFiles)
+	}
+	if prev == nil {
+		return check, false
+	}
+	check.Delta = r.CompiledFiles - prev.CompiledFiles
+	// A new destination builds for another SDK/architecture from scratch.
+	if r.Cleaned || r.DestinationID != prev.DestinationID || check.FullBuild < minFullBuildFiles {
+		return check, false
+	}
+	return check, float64(r.CompiledFiles) > fullRebuildRatio*float64(check.FullBuild)
+}
@@ -0,0 +1,88 @@
+package core
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIsCompileStep(t *testing.T) {
+	for _, line := range []string{
+		"CompileSwift normal arm64 /p/App/ContentView.swift (in target 'App' from project 'App')",
+		"SwiftCompile normal arm64 Compiling\\ ContentView.swift /p/App/ContentView.swift (in target 'App' from project 'App')",
+		"CompileC /p/DerivedData/Build/Intermediates.noindex/App.build/Foo.o /p/App/Foo.m normal arm64 objective-c com.apple.compilers.llvm.clang.1_0.compiler",
+	} {
+		if !isCompileStep(line) {
+			t.Fatalf("expected a compile step: %q", line)
+		}
+	}
+	for _, line := range []string{
+		"CompileSwiftSources normal arm64 com.apple.xcode.tools.swift.compiler (in target 'App' from project 'App')",
+		"CompileAssetCatalog /p/Build/Products/Debug-iphonesimulator/App.app /p/App/Assets.xcassets",
+		"    cd /p",
+	} {
+		if isCompileStep(line) {
+			t.Fatalf("not a compiled file: %q", line)
+		}
+	}
+}
+
+func testBuildRecord(compiled int, dest string) BuildRecord {
+	cfg := Config{Scheme: "App", Configuration: "Debug", Destination: Destination{ID: dest, Name: dest}}
+	return NewBuildRecord(cfg, compiled, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+}
+
+func TestRecordBuildFlagsUnexpectedFullRebuild(t *testing.T) {
+	var st State
+	if _, rebuilt := st.RecordBuild("/p", testBuildRecord(400, "SIM-1")); rebuilt {
+		t.Fatalf("the first build has nothing to compare with")
+	}
+	if _, rebuilt := st.RecordBuild("/p", testBuildRecord(3, "SIM-1")); rebuilt {
+		t.Fatalf("an incremental build is not a rebuild")
+	}
+	check, rebuilt := st.RecordBuild("/p", testBuildRecord(390, "SIM-1"))
+	if !rebuilt {
+		t.Fatalf("expected a full rebuild warning, got %+v", check)
+	}
+	if check.FullBuild != 400 || check.Delta != 387 {
+		t.Fatalf("unexpected check %+v", check)
+	}
+	if !strings.Contains(check.Message(), "looks like a full rebuild") || !strings.Contains(check.Message(), "+387") {
+		t.Fatalf("unexpected message %q", check.Message())
+	}
+	if got := len(st.BuildRecords("/p")); got != 3 {
+		t.Fatalf("expected 3 records, got %d", got)
+	}
+}
+
+func TestRecordBuildExpectsRebuildAfterCleanOrNewDestination(t *testing.T) {
+	var st State
+	st.RecordBuild("/p", testBuildRecord(400, "SIM-1"))
+
+	st.RecordClean("/p", time.Now())
+	if _, rebuilt := st.RecordBuild("/p", testBuildRecord(400, "SIM-1")); rebuilt {
+		t.Fatalf("a build after a clean is expected to compile everything")
+	}
+	records := st.BuildRecords("/p")
+	if !records[len(records)-1].Cleaned {
+		t.Fatalf("the build after a clean should be marked")
+	}
+	if _, ok := st.Cleans["/p"]; ok {
+		t.Fatalf("the clean should be consumed by the next build")
+	}
+
+	if _, rebuilt := st.RecordBuild("/p", testBuildRecord(400, "DEVICE-1")); rebuilt {
+		t.Fatalf("a new destination is expected to compile everything")
+	}
+}
+
+func TestRecordBuildCapsHistory(t *testing.T) {
+	var st State
+	for i := 0; i < MaxBuildRecords+5; i++ {
+		st.RecordBuild("/p", testBuildRecord(i, "SIM-1"))
+	}
+	records := st.BuildRecords("/p")
+	if len(records) != MaxBuildRecords || records[0].CompiledFiles != 5 {
+		t.Fatalf("expected the newest %d records, got %d starting at %d", MaxBuildRecords, len(records), records[0].CompiledFiles)
+	}
+}
@@ -0,0 +1,203 @@
+package core
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+)
+
+// RedactedText replaces secrets in messages.
+const RedactedText = "•••redacted•••"
+
+// defaultRedactPatterns catch common secrets: AWS access key IDs, bearer
+// tokens, and TOKEN/SECRET/PASSWORD/API_KEY assignments. A "secret" group
+// limits redaction to the value, keeping the name readable.
+var defaultRedactPatterns = []string{
+	`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`,
+	`(?i)\baws_?secret_?access_?key\s*[=:]\s*"?(?P<secret>[A-Za-z0-9/+]{40})`,
+	`(?i)\bbearer\s+(?P<secret>[A-Za-z0-9\-._~+/]{8,}=*)`,
+	`(?i)\b[a-z0-9_]*(?:token|secret|password|passwd|api_?key|access_?key)[a-z0-9_]*=(?P<secret>[^\s&"']+)`,
+}
+
+// RedactConfig holds secret redaction options.
+type RedactConfig struct {
+	// Patterns are regexes for secrets to redact, in addition to the
+	// built-in ones. A named group "secret" redacts only that part of the
+	// match, e.g. `license=(?P<secret>\w+)`.
+	Patterns []string `json:"patterns,omitempty"`
+}
+
+type redactRule struct {
+	re     *regexp.Regexp
+	secret int // Index of the "secret" group, or 0 for the whole match
+}
+
+// Redactor replaces secrets in messages with RedactedText.
+type Redactor struct {
+	rules []redactRule
+	// minLen is the shortest text any rule can match; shorter messages
+	// are passed through without running the regexes
+	minLen int
+}
+
+// NewRedactor builds a redactor from the built-in patterns and
+// cfg.Patterns. Invalid patterns are skipped and returned as errors.
+func NewRedactor(cfg RedactConfig) (*Redactor, []error) {
+	r := &Redactor{minLen: -1}
+	var errs []error
+	for _, p := range cfg.Patterns {
+		if err := r.add(p); err != nil {
+			errs = append(errs, fmt.Errorf("ignoring invalid redact.patterns pattern %q: %v", p, err))
+		}
+	}
+	for _, p := range defaultRedactPatterns {
+		if err := r.add(p); err != nil {
+			panic(err)
+		}
+	}
+	return r, errs
+}
+
+func (r *Redactor) add(pattern string) error {
+	parsed, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return err
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return err
+	}
+	secret := re.SubexpIndex("secret")
+	if secret < 0 {
+		secret = 0
+	}
+	r.rules = append(r.rules, redactRule{re: re, secret: secret})
+	if n := minMatchLen(parsed.Simplify()); r.minLen < 0 || n < r.minLen {
+		r.minLen = n
+	}
+	return nil
+}
+
+// minMatchLen is a lower bound on the bytes a match of re spans
+func minMatchLen(re *syntax.Regexp) int {
+	switch re.Op {
+	case syntax.OpLiteral:
+		return len(re.Rune)
+	case syntax.OpCharClass, syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		return 1
+	case syntax.OpCapture, syntax.OpPlus:
+		return minMatchLen(re.Sub[0])
+	case syntax.OpRepeat:
+		return re.Min * minMatchLen(re.Sub[0])
+	case syntax.OpConcat:
+		n := 0
+		for _, sub := range re.Sub {
+			n += minMatchLen(sub)
+		}
+		return n
+	case syntax.OpAlternate:
+		n := -1
+		for _, sub := range re.Sub {
+			if m := minMatchLen(sub); n < 0 || m < n {
+				n = m
+			}
+		}
+		return max(n, 0)
+	}
+	return 0
+}
+
+// Redact returns s with every secret replaced by RedactedText. A secret
+// spanning lines is redacted per line, so the line structure of console
+// entries ("meta\nmessage") survives.
+func (r *Redactor) Redact(s string) string {
+	if r == nil || len(s) < r.minLen {
+		return s
+	}
+	for _, rule := range r.rules {
+		matches := rule.re.FindAllStringSubmatchIndex(s, -1)
+		if matches == nil {
+			continue
+		}
+		var b strings.Builder
+		last := 0
+		for _, m := range matches {
+			start, end := m[2*rule.secret], m[2*rule.secret+1]
+			if start < last || start == end {
+				continue
+			}
+			b.WriteString(s[last:start])
+			b.WriteString(redactLines(s[start:end]))
+			last = end
+		}
+		b.WriteString(s[last:])
+		s = b.String()
+	}
+	return s
+}
+
+// redactLines replaces each non-empty line of secret with RedactedText
+func redactLines(secret string) string {
+	lines := strings.Split(secret, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = RedactedText
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Event returns ev with secrets redacted from its message, error, and
+// string data values. Data is copied rather than changed in place.
+func (r *Redactor) Event(ev Event) Event {
+	if r == nil {
+		return ev
+	}
+	ev.Msg = r.Redact(ev.Msg)
+	if ev.Err != nil {
+		e := *ev.Err
+		e.Message = r.Redact(e.Message)
+		e.Detail = r.Redact(e.Detail)
+		e.Suggestion = r.Redact(e.Suggestion)
+		ev.Err = &e
+	}
+	if data, ok := ev.Data.(map[string]any); ok {
+		var copied map[string]any
+		for k, v := range data {
+			s, ok := v.(string)
+			if !ok {
+				continue
+			}
+			if red := r.Redact(s); red != s {
+				if copied == nil {
+					copied = maps.Clone(data)
+				}
+				copied[k] = red
+			}
+		}
+		if copied != nil {
+			ev.Data = copied
+		}
+	}
+	return ev
+}
+
+// RedactEmitter scrubs secrets from every event before passing it to emit,
+// so no sink (terminal, JSON, persisted logs) sees them.
+func RedactEmitter(emit Emitter, r *Redactor) Emitter {
+	if emit == nil || r == nil {
+		return emit
+	}
+	return redactingEmitter{next: emit, redactor: r}
+}
+
+type redactingEmitter struct {
+	next     Emitter
+	redactor *Redactor
+}
+
+func (e redactingEmitter) Emit(ev Event) {
+	e.next.Emit(e.redactor.Event(ev))
+}
@@ -0,0 +1,107 @@
+package core
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ReproResultBundle is the result bundle name used in reproduction commands.
+const ReproResultBundle = "repro.xcresult"
+
+// ReproCommand renders a standalone xcodebuild command equivalent to cfg,
+// for pasting into a bug report. It is meant to be run from the project
+// root: paths under projectRoot are relative, arguments are shell-quoted,
+// and the result bundle is a fixed placeholder. Xcodebuild env values are
+// left out since they often carry secrets.
+func ReproCommand(projectRoot string, cfg Config, action string) string {
+	if action == "" {
+		action = "build"
+	}
+	cfg.Workspace = reproPath(projectRoot, cfg.Workspace)
+	cfg.Project = reproPath(projectRoot, cfg.Project)
+	args := baseXcodebuildArgs("", cfg)
+	if dir := DerivedDataDir(cfg); dir != "" {
+		args = append(args, "-derivedDataPath", reproPath(projectRoot, dir))
+	}
+	bundle := ReproResultBundle
+	if cfg.ResultBundlesPath != "" {
+		bundle = filepath.Join(reproPath(projectRoot, cfg.ResultBundlesPath), ReproResultBundle)
+	}
+	args = append(args, "-resultBundlePath", bundle, action)
+	args = append(args, cfg.Xcodebuild.Options...)
+	return shellJoin("xcodebuild", xcodebuildArgs(cfg, args))
+}
+
+// XcboltCommand renders the `xcbolt <action>` invocation that selects the
+// same scheme, configuration, and destination as cfg. The destination is
+// named rather than given by ID so the command works on other machines.
+func XcboltCommand(cfg Config, action string) string {
+	if action == "" {
+		action = "build"
+	}
+	args := []string{action}
+	if cfg.Scheme != "" {
+		args = append(args, "--scheme", cfg.Scheme)
+	}
+	if cfg.Configuration != "" {
+		args = append(args, "--configuration", cfg.Configuration)
+	}
+	dst := normalizeDestination(cfg.Destination)
+	if dst.Kind != DestAuto {
+		if dst.PlatformFamily != PlatformUnknown {
+			args = append(args, "--platform", string(dst.PlatformFamily))
+		}
+		if dst.TargetType != "" && dst.TargetType != TargetAuto {
+			args = append(args, "--target-type", string(dst.TargetType))
+		}
+		if dst.TargetType != TargetLocal {
+			target := strings.TrimSpace(dst.Name)
+			if target == "" {
+				target = strings.TrimSpace(dst.ID)
+			}
+			if target != "" {
+				args = append(args, "--target", target)
+			}
+		}
+		if dst.CompanionTargetID != "" {
+			args = append(args, "--companion-target", dst.CompanionTargetID)
+		}
+	}
+	return shellJoin("xcbolt", args)
+}
+
+// reproPath makes p relative to projectRoot when it lies inside it.
+func reproPath(projectRoot, p string) string {
+	if p == "" || projectRoot == "" || !filepath.IsAbs(p) {
+		return p
+	}
+	rel, err := filepath.Rel(projectRoot, p)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return p
+	}
+	return rel
+}
+
+// shellSafeRE matches arguments that need no quoting in a POSIX shell.
+var shellSafeRE = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+// shellQuote quotes s for a POSIX shell, using single quotes when needed.
+func shellQuote(s string) string {
+	if s == "" {
+		return "''"
+	}
+	if shellSafeRE.MatchString(s) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func shellJoin(name string, args []string) string {
+	parts := make([]string, 0, len(args)+1)
+	parts = append(parts, shellQuote(name))
+	for _, a := range args {
+		parts = append(parts, shellQuote(a))
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,110 @@
+package core
+
+import "testing"
+
+func TestReproCommand(t *testing.T) {
+	root := "/Users/me/My App"
+	base := DefaultConfig(root)
+	base.Scheme = "MyApp"
+
+	cases := []struct {
+		name   string
+		cfg    func(Config) Config
+		action string
+		want   string
+	}{
+		{
+			name: "workspace with spaces and simulator",
+			cfg: func(c Config) Config {
+				c.Workspace = "My App.xcworkspace"
+				c.Destination = Destination{Kind: DestSimulator, PlatformFamily: PlatformIOS, TargetType: TargetSimulator, Platform: "iOS Simulator", ID: "ABC-123", Name: "iPhone 15 Pro"}
+				return c
+			},
+			want: `xcodebuild -workspace 'My App.xcworkspace' -scheme MyApp -configuration Debug -destination 'platform=iOS Simulator,id=ABC-123' -derivedDataPath .xcbolt/DerivedData -resultBundlePath .xcbolt/Results/repro.xcresult build '-AppleLanguages=(en)' -AppleLocale=en_US`,
+		},
+		{
+			name: "project on mac with options",
+			cfg: func(c Config) Config {
+				c.Project = "App.xcodeproj"
+				c.Configuration = "Release"
+				c.Destination = Destination{Kind: DestMacOS, PlatformFamily: PlatformMacOS, TargetType: TargetLocal}
+				c.Xcodebuild.Options = []string{"-quiet", "OTHER_SWIFT_FLAGS=-D DEBUG_MENU"}
+				return c
+			},
+			action: "test",
+			want:   `xcodebuild -project App.xcodeproj -scheme MyApp -configuration Release -destination platform=macOS -derivedDataPath .xcbolt/DerivedData -resultBundlePath .xcbolt/Results/repro.xcresult test -quiet 'OTHER_SWIFT_FLAGS=-D DEBUG_MENU' '-AppleLanguages=(en)' -AppleLocale=en_US`,
+		},
+		{
+			name: "paths outside the root stay absolute",
+			cfg: func(c Config) Config {
+				c.Workspace = "/Volumes/Work/Shared.xcworkspace"
+				c.DerivedDataPath = "/tmp/DD"
+				c.Scheme = "Bob's App"
+				return c
+			},
+			want: `xcodebuild -workspace /Volumes/Work/Shared.xcworkspace -scheme 'Bob'\''s App' -configuration Debug -derivedDataPath /tmp/DD -resultBundlePath .xcbolt/Results/repro.xcresult build '-AppleLanguages=(en)' -AppleLocale=en_US`,
+		},
+		{
+			name: "preserved locale",
+			cfg: func(c Config) Config {
+				c.Project = "App.xcodeproj"
+				c.Xcodebuild.PreserveLocale = true
+				return c
+			},
+			want: `xcodebuild -project App.xcodeproj -scheme MyApp -configuration Debug -derivedDataPath .xcbolt/DerivedData -resultBundlePath .xcbolt/Results/repro.xcresult build`,
+		},
+		{
+			name: "split derived data",
+			cfg: func(c Config) Config {
+				c.Project = "App.xcodeproj"
+				c.Xcodebuild.SplitDerivedDataByDestination = true
+				c.Destination = Destination{Kind: DestCatalyst, PlatformFamily: PlatformCatalyst, TargetType: TargetLocal}
+				return c
+			},
+			want: `xcodebuild -project App.xcodeproj -scheme MyApp -configuration Debug -destination 'platform=macOS,variant=Mac Catalyst' -derivedDataPath .xcbolt/DerivedData/catalyst-local -resultBundlePath .xcbolt/Results/repro.xcresult build '-AppleLanguages=(en)' -AppleLocale=en_US`,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := ReproCommand(root, c.cfg(base), c.action); got != c.want {
+				t.Fatalf("got  %s\nwant %s", got, c.want)
+			}
+		})
+	}
+}
+
+func TestXcboltCommand(t *testing.T) {
+	cases := []struct {
+		name string
+		cfg  Config
+		want string
+	}{
+		{
+			name: "simulator by name",
+			cfg:  Config{Scheme: "MyApp", Configuration: "Debug", Destination: Destination{Kind: DestSimulator, PlatformFamily: PlatformIOS, TargetType: TargetSimulator, ID: "ABC-123", Name: "iPhone 15 Pro"}},
+			want: `xcbolt build --scheme MyApp --configuration Debug --platform ios --target-type simulator --target 'iPhone 15 Pro'`,
+		},
+		{
+			name: "local mac",
+			cfg:  Config{Scheme: "My App", Configuration: "Release", Destination: Destination{Kind: DestMacOS, PlatformFamily: PlatformMacOS, TargetType: TargetLocal, Name: "My Mac"}},
+			want: `xcbolt build --scheme 'My App' --configuration Release --platform macos --target-type local`,
+		},
+		{
+			name: "watch device with companion",
+			cfg:  Config{Scheme: "Watch", Destination: Destination{Kind: DestDevice, PlatformFamily: PlatformWatchOS, TargetType: TargetDevice, ID: "00008301-AA", CompanionTargetID: "00008110-BB"}},
+			want: `xcbolt build --scheme Watch --platform watchos --target-type device --target 00008301-AA --companion-target 00008110-BB`,
+		},
+		{
+			name: "auto destination",
+			cfg:  Config{Scheme: "MyApp", Destination: Destination{Kind: DestAuto, TargetType: TargetAuto}},
+			want: `xcbolt build --scheme MyApp`,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := XcboltCommand(c.cfg, "build"); got != c.want {
+				t.Fatalf("got  %s\nwant %s", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,369 @@
+package core
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultReproMaxResultBundleMB is used when repro.maxResultBundleMB is
+// unset.
+const DefaultReproMaxResultBundleMB = 200
+
+// ReproConfig holds options for the TUI's Export Repro Bundle.
+type ReproConfig struct {
+	// ZipResultBundle adds the result bundle to the repro bundle as a zip.
+	ZipResultBundle bool `json:"zipResultBundle,omitempty"`
+	// MaxResultBundleMB skips zipping result bundles larger than this, in
+	// MB (0 means DefaultReproMaxResultBundleMB).
+	MaxResultBundleMB int `json:"maxResultBundleMB,omitempty"`
+}
+
+// MaxResultBundleBytes returns the largest result bundle that is zipped.
+func (c ReproConfig) MaxResultBundleBytes() int64 {
+	mb := c.MaxResultBundleMB
+	if mb <= 0 {
+		mb = DefaultReproMaxResultBundleMB
+	}
+	return int64(mb) << 20
+}
+
+// ReproBundle is what WriteReproBundle puts together for a teammate to look
+// into a failed operation.
+type ReproBundle struct {
+	Op      string // "build", "test", or "run"
+	Outcome string // "failed", "succeeded", "canceled"
+	// Log is an excerpt of the op's log: lines LogStart+1.. of LogTotal.
+	Log      []string
+	LogStart int
+	LogTotal int
+	// Errors are the error messages of the Issues tab, first one first.
+	Errors   []string
+	Warnings int
+	// IssuesJSON is every issue as JSON.
+	IssuesJSON string
+	// Command is the exact xcodebuild invocation (env values hidden).
+	Command string
+	Config  Config
+	// Meta is the environment recorded for the result bundle (nil when
+	// there is none); Xcode is used without it.
+	Meta         *ResultMeta
+	Xcode        XcodeVersion
+	ResultBundle string
+	CreatedAt    time.Time
+}
+
+// ReproBundleResult describes a written repro bundle.
+type ReproBundleResult struct {
+	Dir   string
+	Files []string
+	// ResultBundleNote says why the result bundle wasn't zipped ("" when
+	// it was, or wasn't asked for).
+	ResultBundleNote string
+}
+
+// ReproDir returns where a repro bundle created at t is written.
+func ReproDir(projectRoot string, t time.Time) string {
+	return filepath.Join(projectRoot, ".xcbolt", "repro", t.Format("20060102-150405"))
+}
+
+// WriteReproBundle writes b to ReproDir: the log excerpt, the issues, the
+// commands, the config, the environment, optionally the zipped result
+// bundle, and a README.md summarizing the failure. Every text file goes
+// through r. progress, if set, is called before each slow step.
+func WriteReproBundle(ctx context.Context, projectRoot string, b ReproBundle, r *Redactor, progress func(string)) (ReproBundleResult, error) {
+	if b.CreatedAt.IsZero() {
+		b.CreatedAt = time.Now()
+	}
+	report := func(s string) {
+		if progress != nil {
+			progress(s)
+		}
+	}
+	res := ReproBundleResult{Dir: ReproDir(projectRoot, b.CreatedAt)}
+	if err := os.MkdirAll(res.Dir, 0o755); err != nil {
+		return res, err
+	}
+	write := func(name, text string) error {
+		if err := os.WriteFile(filepath.Join(res.Dir, name), []byte(r.Redact(text)), 0o644); err != nil {
+			return err
+		}
+		res.Files = append(res.Files, name)
+		return nil
+	}
+
+	report("Writing log and issues…")
+	if err := write("build.log", strings.Join(b.Log, "\n")+"\n"); err != nil {
+		return res, err
+	}
+	if b.IssuesJSON != "" {
+		if err := write("issues.json", b.IssuesJSON+"\n"); err != nil {
+			return res, err
+		}
+	}
+	if err := write("command.txt", reproCommands(projectRoot, b)); err != nil {
+		return res, err
+	}
+	cfgJSON, err := json.MarshalIndent(reproConfigSnapshot(b.Config), "", "  ")
+	if err != nil {
+		return res, err
+	}
+	if err := write("config.json", string(cfgJSON)+"\n"); err != nil {
+		return res, err
+	}
+	if b.Meta != nil {
+		metaJSON, err := json.MarshalIndent(b.Meta, "", "  ")
+		if err != nil {
+			return res, err
+		}
+		if err := write("environment.json", string(metaJSON)+"\n"); err != nil {
+			return res, err
+		}
+	}
+
+	if b.Config.Repro.ZipResultBundle && b.ResultBundle != "" {
+		name := filepath.Base(b.ResultBundle) + ".zip"
+		size, err := dirSize(b.ResultBundle)
+		switch {
+		case err != nil:
+			res.ResultBundleNote = "The result bundle could not be read: " + err.Error()
+		case size > b.Config.Repro.MaxResultBundleBytes():
+			res.ResultBundleNote = fmt.Sprintf("The result bundle (%s) is over repro.maxResultBundleMB (%s) and was not included.",
+				FormatBytes(size), FormatBytes(b.Config.Repro.MaxResultBundleBytes()))
+		default:
+			report(fmt.Sprintf("Zipping result bundle (%s)…", FormatBytes(size)))
+			if err := zipDir(ctx, b.ResultBundle, filepath.Join(res.Dir, name)); err != nil {
+				_ = os.Remove(filepath.Join(res.Dir, name))
+				if ctx.Err() != nil {
+					return res, ctx.Err()
+				}
+				res.ResultBundleNote = "Zipping the result bundle failed: " + err.Error()
+			} else {
+				res.Files = append(res.Files, name)
+			}
+		}
+	}
+
+	report("Writing README…")
+	if err := write("README.md", reproReadme(projectRoot, b, res)); err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+// reproCommands is command.txt: the exact command, then standalone ones
+func reproCommands(projectRoot string, b ReproBundle) string {
+	var s strings.Builder
+	if b.Command != "" {
+		s.WriteString("# Exact xcodebuild invocation (env values hidden)\n")
+		s.WriteString(b.Command + "\n\n")
+	}
+	s.WriteString("# Standalone, from the project root\n")
+	s.WriteString(ReproCommand(projectRoot, b.Config, reproAction(b.Op)) + "\n\n")
+	s.WriteString("# With xcbolt\n")
+	s.WriteString(XcboltCommand(b.Config, b.Op) + "\n")
+	return s.String()
+}
+
+// reproAction is the xcodebuild action reproducing op (a run builds)
+func reproAction(op string) string {
+	if op == "test" {
+		return "test"
+	}
+	return "build"
+}
+
+// reproConfigSnapshot is the config with env values hidden, like the
+// XCODEBUILD_COMMAND status does
+func reproConfigSnapshot(cfg Config) Config {
+	cfg.Xcodebuild.Env = hideEnvValues(cfg.Xcodebuild.Env)
+	cfg.Launch.Env = hideEnvValues(cfg.Launch.Env)
+	presets := make([]LaunchPreset, len(cfg.Launch.Presets))
+	for i, p := range cfg.Launch.Presets {
+		p.Env = hideEnvValues(p.Env)
+		presets[i] = p
+	}
+	if len(presets) > 0 {
+		cfg.Launch.Presets = presets
+	}
+	return cfg
+}
+
+func hideEnvValues(env map[string]string) map[string]string {
+	if len(env) == 0 {
+		return env
+	}
+	out := make(map[string]string, len(env))
+	for k := range env {
+		out[k] = "***"
+	}
+	return out
+}
+
+func reproReadme(projectRoot string, b ReproBundle, res ReproBundleResult) string {
+	var s strings.Builder
+	fmt.Fprintf(&s, "# xcbolt %s %s\n\n", b.Op, b.Outcome)
+	fmt.Fprintf(&s, "- Created: %s\n", b.CreatedAt.Format("2006-01-02 15:04:05"))
+	scheme := b.Config.Scheme
+	if b.Config.Configuration != "" {
+		scheme += " (" + b.Config.Configuration + ")"
+	}
+	if scheme != "" {
+		fmt.Fprintf(&s, "- Scheme: %s\n", scheme)
+	}
+	if dst := b.Config.Destination; dst.Name != "" {
+		name := dst.Name
+		if dst.OS != "" {
+			name += " (" + dst.OS + ")"
+		}
+		fmt.Fprintf(&s, "- Destination: %s\n", name)
+	}
+	if env := reproEnvironment(b); env != "" {
+		fmt.Fprintf(&s, "- Environment: %s\n", env)
+	}
+	fmt.Fprintf(&s, "- Issues: %d errors, %d warnings\n", len(b.Errors), b.Warnings)
+
+	if len(b.Errors) > 0 {
+		s.WriteString("\n## First error\n\n")
+		s.WriteString(indentBlock(b.Errors[0]))
+		if len(b.Errors) > 1 {
+			fmt.Fprintf(&s, "\n%d more in issues.json.\n", len(b.Errors)-1)
+		}
+	}
+
+	s.WriteString("\n## Reproduce\n\nFrom the project root:\n\n")
+	s.WriteString(indentBlock(XcboltCommand(b.Config, b.Op)))
+	s.WriteString("\nor without xcbolt:\n\n")
+	s.WriteString(indentBlock(ReproCommand(projectRoot, b.Config, reproAction(b.Op))))
+
+	s.WriteString("\n## Files\n\n")
+	for _, name := range res.Files {
+		fmt.Fprintf(&s, "- `%s`: %s\n", name, reproFileDescription(name, b))
+	}
+	if b.ResultBundle != "" {
+		fmt.Fprintf(&s, "\nResult bundle on the reporter's machine: `%s`\n", b.ResultBundle)
+	}
+	if res.ResultBundleNote != "" {
+		s.WriteString(res.ResultBundleNote + "\n")
+	}
+	return s.String()
+}
+
+func reproFileDescription(name string, b ReproBundle) string {
+	switch name {
+	case "build.log":
+		if b.LogTotal > len(b.Log) {
+			return fmt.Sprintf("log lines %d–%d of %d, around the first error", b.LogStart+1, b.LogStart+len(b.Log), b.LogTotal)
+		}
+		return "the whole log"
+	case "issues.json":
+		return "every error and warning with its location"
+	case "command.txt":
+		return "the exact xcodebuild invocation and standalone commands"
+	case "config.json":
+		return "the effective xcbolt config (env values hidden)"
+	case "environment.json":
+		return "Xcode, macOS, and git state when the op ran"
+	}
+	if strings.HasSuffix(name, ".zip") {
+		return "the result bundle, open it with Xcode after unzipping"
+	}
+	return ""
+}
+
+// reproEnvironment is a one-line banner, e.g. "Xcode 15.3 (15E204a) · macOS
+// 14.5 (23F79) · git main @ 1a2b3c4d5e6f (dirty)"
+func reproEnvironment(b ReproBundle) string {
+	xcode := b.Xcode
+	var parts []string
+	if b.Meta != nil {
+		xcode = b.Meta.Xcode
+	}
+	if label := xcode.Label(); label != "" {
+		parts = append(parts, label)
+	}
+	if b.Meta != nil {
+		if b.Meta.MacOS != "" {
+			parts = append(parts, "macOS "+b.Meta.MacOS)
+		}
+		if git := b.Meta.Git; git != nil {
+			commit := git.Commit
+			if len(commit) > 12 {
+				commit = commit[:12]
+			}
+			s := "git " + commit
+			if git.Branch != "" {
+				s = "git " + git.Branch + " @ " + commit
+			}
+			if git.Dirty {
+				s += " (dirty)"
+			}
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, " · ")
+}
+
+func indentBlock(text string) string {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = "    " + line
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// zipDir writes root, a directory such as a result bundle, to the zip file
+// dst with root's name as the top-level folder
+func zipDir(ctx context.Context, root, dst string) error {
+	f, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	zw := zip.NewWriter(f)
+	parent := filepath.Dir(root)
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(parent, path)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		if d.IsDir() {
+			_, err := zw.Create(name + "/")
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		src, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate})
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(w, src)
+		return err
+	})
+	if cerr := zw.Close(); err == nil {
+		err = cerr
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
@@ -0,0 +1,126 @@
+package core
+
+import (
+	"archive/zip"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteReproBundle(t *testing.T) {
+	root := t.TempDir()
+	bundle := filepath.Join(root, ".xcbolt", "Results", "20261015-101500.xcresult")
+	if err := os.MkdirAll(filepath.Join(bundle, "Data"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(bundle, "Info.plist"), []byte("<plist/>"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := Config{Scheme: "App", Configuration: "Debug", Destination: Destination{Kind: DestSimulator, Name: "iPhone 15", OS: "17.5"}}
+	cfg.Xcodebuild.Env = map[string]string{"SIGNING_TOKEN": "hunter2"}
+	cfg.Repro.ZipResultBundle = true
+	b := ReproBundle{
+		Op:           "build",
+		Outcome:      "failed",
+		Log:          []string{"CompileSwift App.swift", "export API_KEY=abc123", "/src/App.swift:3:5: error: cannot find 'x' in scope"},
+		LogStart:     120,
+		LogTotal:     900,
+		Errors:       []string{"cannot find 'x' in scope", "missing return"},
+		Warnings:     4,
+		IssuesJSON:   `[{"type":"error"}]`,
+		Command:      "xcodebuild -scheme App build",
+		Config:       cfg,
+		Meta:         &ResultMeta{Op: "build", Xcode: XcodeVersion{Version: "15.3", Build: "15E204a"}, MacOS: "14.5 (23F79)", Git: &GitState{Commit: "1a2b3c4d5e6f7a8b", Branch: "main", Dirty: true}},
+		ResultBundle: bundle,
+		CreatedAt:    time.Date(2026, 10, 15, 10, 16, 0, 0, time.Local),
+	}
+	r, _ := NewRedactor(RedactConfig{})
+	var steps []string
+	res, err := WriteReproBundle(context.Background(), root, b, r, func(s string) { steps = append(steps, s) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Dir != filepath.Join(root, ".xcbolt", "repro", "20261015-101600") {
+		t.Fatalf("Dir = %s", res.Dir)
+	}
+	want := []string{"build.log", "issues.json", "command.txt", "config.json", "environment.json", "20261015-101500.xcresult.zip", "README.md"}
+	if strings.Join(res.Files, ",") != strings.Join(want, ",") {
+		t.Fatalf("Files = %v", res.Files)
+	}
+	if len(steps) != 3 || !strings.HasPrefix(steps[1], "Zipping result bundle") {
+		t.Fatalf("progress = %v", steps)
+	}
+
+	read := func(name string) string {
+		data, err := os.ReadFile(filepath.Join(res.Dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+	if log := read("build.log"); strings.Contains(log, "abc123") || !strings.Contains(log, RedactedText) {
+		t.Fatalf("log not redacted:\n%s", log)
+	}
+	if c := read("config.json"); strings.Contains(c, "hunter2") || !strings.Contains(c, `"SIGNING_TOKEN": "***"`) {
+		t.Fatalf("config env not hidden:\n%s", c)
+	}
+	readme := read("README.md")
+	for _, s := range []string{
+		"# xcbolt build failed",
+		"- Scheme: App (Debug)",
+		"- Environment: Xcode 15.3 (15E204a) · macOS 14.5 (23F79) · git main @ 1a2b3c4d5e6f (dirty)",
+		"- Issues: 2 errors, 4 warnings",
+		"    cannot find 'x' in scope",
+		"`build.log`: log lines 121–123 of 900",
+		"xcbolt build --scheme App",
+	} {
+		if !strings.Contains(readme, s) {
+			t.Fatalf("README missing %q:\n%s", s, readme)
+		}
+	}
+
+	zr, err := zip.OpenReader(filepath.Join(res.Dir, "20261015-101500.xcresult.zip"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer zr.Close()
+	names := map[string]bool{}
+	for _, f := range zr.File {
+		names[f.Name] = true
+	}
+	if !names["20261015-101500.xcresult/Info.plist"] || !names["20261015-101500.xcresult/Data/"] {
+		t.Fatalf("zip entries = %v", names)
+	}
+}
+
+func TestWriteReproBundleSkipsLargeResultBundle(t *testing.T) {
+	root := t.TempDir()
+	bundle := filepath.Join(root, "big.xcresult")
+	if err := os.MkdirAll(bundle, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(bundle, "data"), make([]byte, 2<<20), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := Config{Scheme: "App", Repro: ReproConfig{ZipResultBundle: true, MaxResultBundleMB: 1}}
+	res, err := WriteReproBundle(context.Background(), root, ReproBundle{Op: "test", Outcome: "failed", Config: cfg, ResultBundle: bundle}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range res.Files {
+		if strings.HasSuffix(name, ".zip") {
+			t.Fatalf("zipped an oversized bundle: %v", res.Files)
+		}
+	}
+	if !strings.Contains(res.ResultBundleNote, "is over repro.maxResultBundleMB (1.0 MB)") {
+		t.Fatalf("note = %q", res.ResultBundleNote)
+	}
+	readme, _ := os.ReadFile(filepath.Join(res.Dir, "README.md"))
+	if !strings.Contains(string(readme), res.ResultBundleNote) || !strings.Contains(string(readme), "-resultBundlePath repro.xcresult test") {
+		t.Fatalf("README:\n%s", readme)
+	}
+}
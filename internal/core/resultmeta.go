@@ -0,0 +1,163 @@
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// resultMetaTimeout bounds collecting the environment for meta.json, which
+// also happens after a canceled op
+const resultMetaTimeout = 5 * time.Second
+
+// ResultMeta is the environment of a build or test, written next to its
+// result bundle as <timestamp>.meta.json.
+type ResultMeta struct {
+	Op           string `json:"op"`
+	Success      bool   `json:"success"`
+	ResultBundle string `json:"resultBundle"`
+	StartedAt    string `json:"startedAt"`
+	FinishedAt   string `json:"finishedAt"`
+	// DurationMs is the time spent in xcodebuild; ElapsedMs the whole op.
+	DurationMs    int64        `json:"durationMs"`
+	ElapsedMs     int64        `json:"elapsedMs"`
+	Xcode         XcodeVersion `json:"xcode"`
+	MacOS         string       `json:"macos,omitempty"` // "14.5 (23F79)"
+	Git           *GitState    `json:"git,omitempty"`
+	Scheme        string       `json:"scheme,omitempty"`
+	Configuration string       `json:"configuration,omitempty"`
+	Destination   Destination  `json:"destination"`
+	// ConfigHash identifies the effective config, so two results can be
+	// checked for the same settings.
+	ConfigHash string `json:"configHash"`
+}
+
+// GitState is the project's git checkout.
+type GitState struct {
+	Commit string `json:"commit"`
+	Branch string `json:"branch,omitempty"` // "" on a detached HEAD
+	Dirty  bool   `json:"dirty"`
+}
+
+// ResultMetaPath returns where the metadata of a result bundle is written.
+func ResultMetaPath(bundlePath string) string {
+	return strings.TrimSuffix(bundlePath, ".xcresult") + ".meta.json"
+}
+
+// ReadResultMeta reads a meta.json file.
+func ReadResultMeta(path string) (ResultMeta, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ResultMeta{}, err
+	}
+	var meta ResultMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return ResultMeta{}, err
+	}
+	return meta, nil
+}
+
+// LatestResultMeta returns the newest meta.json in the results directory.
+func LatestResultMeta(resultsDir string) (string, error) {
+	paths, err := filepath.Glob(filepath.Join(resultsDir, "*.meta.json"))
+	if err != nil {
+		return "", err
+	}
+	if len(paths) == 0 {
+		return "", os.ErrNotExist
+	}
+	// Names start with a sortable timestamp
+	sort.Strings(paths)
+	return paths[len(paths)-1], nil
+}
+
+// resultRun is a finished build or test for writeResultMeta
+type resultRun struct {
+	op       string
+	bundle   string
+	started  time.Time
+	duration time.Duration
+	success  bool
+}
+
+// writeResultMeta records the environment of a finished build or test next
+// to its result bundle and returns the file's path. It never fails the op:
+// errors are a Warn and return "".
+func writeResultMeta(projectRoot string, cfg Config, run resultRun, emit Emitter) string {
+	ctx, cancel := context.WithTimeout(context.Background(), resultMetaTimeout)
+	defer cancel()
+
+	finished := time.Now()
+	meta := ResultMeta{
+		Op:            run.op,
+		Success:       run.success,
+		ResultBundle:  run.bundle,
+		StartedAt:     run.started.Format(time.RFC3339),
+		FinishedAt:    finished.Format(time.RFC3339),
+		DurationMs:    run.duration.Milliseconds(),
+		ElapsedMs:     finished.Sub(run.started).Milliseconds(),
+		MacOS:         macOSVersion(ctx),
+		Git:           gitState(ctx, projectRoot),
+		Scheme:        cfg.Scheme,
+		Configuration: cfg.Configuration,
+		Destination:   cfg.Destination,
+		ConfigHash:    configHash(cfg),
+	}
+	// Unknown versions are left empty
+	meta.Xcode, _ = XcodeInfo(ctx)
+
+	path := ResultMetaPath(run.bundle)
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err == nil {
+		err = os.WriteFile(path, append(data, '\n'), 0o644)
+	}
+	if err != nil {
+		emitMaybe(emit, Warn(run.op, "Could not write result metadata: "+err.Error()))
+		return ""
+	}
+	return path
+}
+
+// macOSVersion returns e.g. "14.5 (23F79)", or "" when sw_vers isn't there
+func macOSVersion(ctx context.Context) string {
+	version, err := captureCmd(ctx, "sw_vers", "-productVersion")
+	if err != nil || version == "" {
+		return ""
+	}
+	if build, err := captureCmd(ctx, "sw_vers", "-buildVersion"); err == nil && build != "" {
+		return version + " (" + build + ")"
+	}
+	return version
+}
+
+// gitState describes the checkout containing projectRoot (nil outside git)
+func gitState(ctx context.Context, projectRoot string) *GitState {
+	commit, err := captureCmd(ctx, "git", "-C", projectRoot, "rev-parse", "HEAD")
+	if err != nil || commit == "" {
+		return nil
+	}
+	st := &GitState{Commit: commit}
+	if branch, err := captureCmd(ctx, "git", "-C", projectRoot, "rev-parse", "--abbrev-ref", "HEAD"); err == nil && branch != "HEAD" {
+		st.Branch = branch
+	}
+	if status, err := captureCmd(ctx, "git", "-C", projectRoot, "status", "--porcelain"); err == nil {
+		st.Dirty = status != ""
+	}
+	return st
+}
+
+// configHash is a short SHA-256 of the effective config
+func configHash(cfg Config) string {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:16]
+}
@@ -0,0 +1,96 @@
+package core
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteResultMeta(t *testing.T) {
+	dir := t.TempDir()
+	bundle := filepath.Join(dir, "20240102-030405.xcresult")
+	cfg := Config{Scheme: "App", Configuration: "Debug", Destination: Destination{Kind: DestSimulator, Name: "iPhone 15", UDID: "SIM-1"}}
+	rec := &recordingEmitter{}
+	started := time.Now().Add(-3 * time.Second)
+
+	path := writeResultMeta(dir, cfg, resultRun{op: "build", bundle: bundle, started: started, duration: 2 * time.Second, success: true}, rec)
+	if path != filepath.Join(dir, "20240102-030405.meta.json") {
+		t.Fatalf("path = %q", path)
+	}
+	if len(rec.events) != 0 {
+		t.Fatalf("unexpected events: %+v", rec.events)
+	}
+	meta, err := ReadResultMeta(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.Op != "build" || !meta.Success || meta.ResultBundle != bundle || meta.Scheme != "App" || meta.Destination.UDID != "SIM-1" {
+		t.Fatalf("meta = %+v", meta)
+	}
+	if meta.DurationMs != 2000 || meta.ElapsedMs < 3000 {
+		t.Fatalf("durations = %d / %d", meta.DurationMs, meta.ElapsedMs)
+	}
+	if meta.ConfigHash == "" || meta.ConfigHash != configHash(cfg) {
+		t.Fatalf("config hash = %q", meta.ConfigHash)
+	}
+	cfg.Configuration = "Release"
+	if configHash(cfg) == meta.ConfigHash {
+		t.Fatalf("config hash ignores the configuration")
+	}
+	if meta.Git != nil {
+		t.Fatalf("git state outside a repository: %+v", meta.Git)
+	}
+
+	latest, err := LatestResultMeta(dir)
+	if err != nil || latest != path {
+		t.Fatalf("latest = %q, %v", latest, err)
+	}
+}
+
+func TestWriteResultMetaOnlyWarns(t *testing.T) {
+	rec := &recordingEmitter{}
+	bundle := filepath.Join(t.TempDir(), "missing", "20240102-030405.xcresult")
+	if path := writeResultMeta(t.TempDir(), Config{}, resultRun{op: "test", bundle: bundle, started: time.Now()}, rec); path != "" {
+		t.Fatalf("path = %q for an unwritable directory", path)
+	}
+	if len(rec.events) != 1 || rec.events[0].Type != "warning" || !strings.Contains(rec.events[0].Msg, "result metadata") {
+		t.Fatalf("events = %+v, want one warning", rec.events)
+	}
+	if _, err := LatestResultMeta(t.TempDir()); !os.IsNotExist(err) {
+		t.Fatalf("LatestResultMeta in an empty directory: %v", err)
+	}
+}
+
+func TestGitState(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=t", "-c", "user.email=t@example.com"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "-q", "-b", "main")
+	if err := os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	git("add", "a.txt")
+	git("commit", "-q", "-m", "a")
+
+	st := gitState(t.Context(), dir)
+	if st == nil || len(st.Commit) != 40 || st.Branch != "main" || st.Dirty {
+		t.Fatalf("git state = %+v", st)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "a.txt"), []byte("b"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if st := gitState(t.Context(), dir); st == nil || !st.Dirty {
+		t.Fatalf("modified checkout not dirty: %+v", st)
+	}
+}
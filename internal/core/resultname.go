@@ -0,0 +1,190 @@
+package core
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultResultNameTemplate names result bundles after the time their op
+// started, e.g. 20260102-150405.xcresult.
+const DefaultResultNameTemplate = "{timestamp}"
+
+// resultNamePlaceholders are the placeholders of results.nameTemplate
+var resultNamePlaceholders = []string{"op", "scheme", "configuration", "timestamp", "destination"}
+
+// reservedResultSuffixes end the names of a bundle's companions (the retry
+// bundle and the analyzer report), which retention files under the bundle
+var reservedResultSuffixes = []string{"-retry", "-analyzer"}
+
+// issuedResultBundles are the bundle paths this process handed out; an op's
+// bundle doesn't exist until xcodebuild writes it, so a second op started
+// in the same second can't see the first one's on disk yet
+var issuedResultBundles struct {
+	sync.Mutex
+	paths map[string]bool
+}
+
+// ValidateResultNameTemplate checks results.nameTemplate: placeholders
+// must be known and closed, the name must stay in resultBundlesPath, and it
+// must not end like a companion of another bundle (reservedResultSuffixes).
+func ValidateResultNameTemplate(tmpl string) error {
+	if tmpl == "" {
+		return nil
+	}
+	if _, err := parseResultNameTemplate(tmpl); err != nil {
+		return err
+	}
+	for _, suffix := range reservedResultSuffixes {
+		if strings.HasSuffix(tmpl, suffix) {
+			return fmt.Errorf("results.nameTemplate %q: must not end in %q, which marks a bundle's companions", tmpl, suffix)
+		}
+	}
+	return nil
+}
+
+// resultNamePart is literal text or, with placeholder set, a placeholder
+type resultNamePart struct {
+	text        string
+	placeholder bool
+}
+
+func parseResultNameTemplate(tmpl string) ([]resultNamePart, error) {
+	if strings.ContainsAny(tmpl, `/\`) {
+		return nil, fmt.Errorf("results.nameTemplate %q: must not contain path separators", tmpl)
+	}
+	var parts []resultNamePart
+	rest := tmpl
+	for rest != "" {
+		open := strings.IndexAny(rest, "{}")
+		if open < 0 {
+			parts = append(parts, resultNamePart{text: rest})
+			break
+		}
+		if rest[open] == '}' {
+			return nil, fmt.Errorf("results.nameTemplate %q: \"}\" without \"{\"", tmpl)
+		}
+		if open > 0 {
+			parts = append(parts, resultNamePart{text: rest[:open]})
+		}
+		closing := strings.IndexAny(rest[open+1:], "{}")
+		if closing < 0 || rest[open+1+closing] != '}' {
+			return nil, fmt.Errorf("results.nameTemplate %q: unclosed \"{\"", tmpl)
+		}
+		name := rest[open+1 : open+1+closing]
+		if !slices.Contains(resultNamePlaceholders, name) {
+			return nil, fmt.Errorf("results.nameTemplate %q: unknown placeholder {%s} (use {%s})", tmpl, name, strings.Join(resultNamePlaceholders, "}, {"))
+		}
+		parts = append(parts, resultNamePart{text: name, placeholder: true})
+		rest = rest[open+1+closing+1:]
+	}
+	return parts, nil
+}
+
+// ResultBundleName expands results.nameTemplate (DefaultResultNameTemplate
+// when unset) for op started at now, without the .xcresult extension.
+// Placeholder values are made safe for file names.
+func ResultBundleName(cfg Config, op string, now time.Time) (string, error) {
+	tmpl := cfg.Results.NameTemplate
+	if tmpl == "" {
+		tmpl = DefaultResultNameTemplate
+	}
+	parts, err := parseResultNameTemplate(tmpl)
+	if err != nil {
+		return "", err
+	}
+	destination := cfg.Destination.Name
+	if destination == "" {
+		destination = string(cfg.Destination.Kind)
+	}
+	values := map[string]string{
+		"op":            op,
+		"scheme":        cfg.Scheme,
+		"configuration": cfg.Configuration,
+		"timestamp":     now.Format("20060102-150405"),
+		"destination":   destination,
+	}
+	var b strings.Builder
+	for _, p := range parts {
+		if p.placeholder {
+			b.WriteString(sanitizeResultName(values[p.text]))
+		} else {
+			b.WriteString(p.text)
+		}
+	}
+	name := strings.Trim(b.String(), " .")
+	if name == "" {
+		name = now.Format("20060102-150405")
+	}
+	return name, nil
+}
+
+// sanitizeResultName replaces what isn't a letter, digit, ".", "_", or "-"
+// with "-", e.g. "My App/Beta" becomes "My-App-Beta"; empty values become
+// "none"
+func sanitizeResultName(v string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range v {
+		ok := r == '.' || r == '_' || r == '-' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+		if !ok {
+			if !dash {
+				b.WriteByte('-')
+			}
+			dash = true
+			continue
+		}
+		b.WriteRune(r)
+		dash = false
+	}
+	s := strings.Trim(b.String(), "-.")
+	if s == "" {
+		return "none"
+	}
+	return s
+}
+
+// resultBundlePath returns a result bundle path in resultBundlesPath for
+// op started at now that no other op uses. When the name is taken (two ops
+// in the same second), milliseconds are appended, then a random suffix,
+// with a warning.
+func resultBundlePath(cfg Config, op string, now time.Time, emit Emitter) string {
+	name, err := ResultBundleName(cfg, op, now)
+	if err != nil {
+		// LoadConfig rejects invalid templates; a config built in code
+		// falls back to the default name
+		emitMaybe(emit, Warn(op, err.Error()))
+		name = now.Format("20060102-150405")
+	}
+	issuedResultBundles.Lock()
+	defer issuedResultBundles.Unlock()
+	if issuedResultBundles.paths == nil {
+		issuedResultBundles.paths = map[string]bool{}
+	}
+	taken := func(path string) bool {
+		if issuedResultBundles.paths[path] {
+			return true
+		}
+		_, err := os.Lstat(path)
+		return err == nil
+	}
+	path := filepath.Join(cfg.ResultBundlesPath, name+".xcresult")
+	if taken(path) {
+		alt := filepath.Join(cfg.ResultBundlesPath, fmt.Sprintf("%s-%03d.xcresult", name, now.Nanosecond()/int(time.Millisecond)))
+		for taken(alt) {
+			buf := make([]byte, 3)
+			_, _ = rand.Read(buf)
+			alt = filepath.Join(cfg.ResultBundlesPath, name+"-"+hex.EncodeToString(buf)+".xcresult")
+		}
+		emitMaybe(emit, Warn(op, fmt.Sprintf("Result bundle %s already exists; writing %s instead", filepath.Base(path), filepath.Base(alt))))
+		path = alt
+	}
+	issuedResultBundles.paths[path] = true
+	return path
+}
@@ -0,0 +1,114 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestResultBundleNameExpandsTemplate(t *testing.T) {
+	now := time.Date(2026, 1, 2, 15, 4, 5, 0, time.Local)
+	cfg := Config{
+		Scheme:        "My App/Beta",
+		Configuration: "Debug",
+		Destination:   Destination{Kind: DestSimulator, Name: "iPhone 15 Pro (17.2)"},
+	}
+	tests := []struct {
+		tmpl string
+		want string
+	}{
+		{"", "20260102-150405"},
+		{"test-{scheme}-{timestamp}", "test-My-App-Beta-20260102-150405"},
+		{"{op}_{configuration}_{destination}", "build_Debug_iPhone-15-Pro-17.2"},
+		{"{scheme}", "My-App-Beta"},
+		{"..{op}", "build"},
+	}
+	for _, tc := range tests {
+		cfg.Results.NameTemplate = tc.tmpl
+		got, err := ResultBundleName(cfg, "build", now)
+		if err != nil || got != tc.want {
+			t.Errorf("ResultBundleName(%q) = %q, %v; want %q", tc.tmpl, got, err, tc.want)
+		}
+	}
+
+	cfg.Scheme = "../.."
+	cfg.Results.NameTemplate = "{scheme}-{timestamp}"
+	if got, _ := ResultBundleName(cfg, "build", now); got != "none-20260102-150405" {
+		t.Fatalf("unsafe scheme expanded to %q", got)
+	}
+}
+
+func TestValidateResultNameTemplate(t *testing.T) {
+	for _, tmpl := range []string{"", "{timestamp}", "ci-{op}-{scheme}-{timestamp}"} {
+		if err := ValidateResultNameTemplate(tmpl); err != nil {
+			t.Errorf("ValidateResultNameTemplate(%q) = %v", tmpl, err)
+		}
+	}
+	for tmpl, want := range map[string]string{
+		"{sheme}-{timestamp}": "unknown placeholder {sheme}",
+		"{op":                 "unclosed",
+		"{op{timestamp}}":     "unclosed",
+		"op}":                 "without",
+		"ci/{timestamp}":      "path separators",
+		"{timestamp}-retry":   "must not end in \"-retry\"",
+		"{op}-analyzer":       "must not end in \"-analyzer\"",
+	} {
+		if err := ValidateResultNameTemplate(tmpl); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ValidateResultNameTemplate(%q) = %v, want %q", tmpl, err, want)
+		}
+	}
+}
+
+func TestResultBundlePathAvoidsCollisions(t *testing.T) {
+	dir := t.TempDir()
+	cfg := Config{ResultBundlesPath: dir, Scheme: "App", Results: ResultsConfig{NameTemplate: "{op}-{timestamp}"}}
+	now := time.Date(2026, 1, 2, 15, 4, 5, 250*int(time.Millisecond), time.Local)
+
+	rec := &recordingEmitter{}
+	first := resultBundlePath(cfg, "build", now, rec)
+	if first != filepath.Join(dir, "build-20260102-150405.xcresult") || len(rec.events) != 0 {
+		t.Fatalf("first = %s, events = %+v", first, rec.events)
+	}
+	// Issued but not yet written by xcodebuild
+	second := resultBundlePath(cfg, "build", now, rec)
+	if second != filepath.Join(dir, "build-20260102-150405-250.xcresult") {
+		t.Fatalf("second = %s", second)
+	}
+	if len(rec.events) != 1 || rec.events[0].Level != "warn" || !strings.Contains(rec.events[0].Msg, "already exists") {
+		t.Fatalf("expected a collision warning, got %+v", rec.events)
+	}
+	third := resultBundlePath(cfg, "build", now, rec)
+	if third == first || third == second || !strings.HasPrefix(filepath.Base(third), "build-20260102-150405-") {
+		t.Fatalf("third = %s", third)
+	}
+
+	// A bundle already on disk, e.g. from another xcbolt process
+	if err := os.MkdirAll(filepath.Join(dir, "test-20260102-150405.xcresult"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if got := resultBundlePath(cfg, "test", now, nil); got != filepath.Join(dir, "test-20260102-150405-250.xcresult") {
+		t.Fatalf("existing bundle not avoided: %s", got)
+	}
+}
+
+func TestInvalidResultNameTemplateFailsValidation(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	root := t.TempDir()
+	writeTestConfig(t, root, fmt.Sprintf(`{
+  "version": %d,
+  "results": {"nameTemplate": "{scheme}-{time}"}
+}`, ConfigVersion))
+	if _, err := LoadConfig(root, ""); err == nil || !strings.Contains(err.Error(), "unknown placeholder {time}") {
+		t.Fatalf("expected an invalid template error, got %v", err)
+	}
+	problems, err := CheckConfig(root, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(problems) != 1 || problems[0].Kind != ConfigProblemInvalid || problems[0].Line != 3 || !strings.Contains(problems[0].Message(), "{time}") {
+		t.Fatalf("unexpected problems %+v", problems)
+	}
+}
@@ -0,0 +1,275 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// ResultsConfig holds options for the result bundles under
+// resultBundlesPath.
+type ResultsConfig struct {
+	Retention ResultsRetention `json:"retention,omitempty"`
+	// NameTemplate names result bundles, with {op}, {scheme},
+	// {configuration}, {timestamp}, and {destination} placeholders, e.g.
+	// "test-{scheme}-{timestamp}" (default DefaultResultNameTemplate).
+	NameTemplate string `json:"nameTemplate,omitempty"`
+}
+
+// ResultsRetention limits how many result bundles are kept. Zero values
+// keep everything.
+type ResultsRetention struct {
+	// MaxCount keeps at most this many bundles, newest first.
+	MaxCount int `json:"maxCount,omitempty"`
+	// MaxAgeDays removes bundles older than this many days.
+	MaxAgeDays int `json:"maxAgeDays,omitempty"`
+}
+
+// Enabled reports whether any limit is set.
+func (r ResultsRetention) Enabled() bool {
+	return r.MaxCount > 0 || r.MaxAgeDays > 0
+}
+
+// resultsLockName is the lock file in resultBundlesPath. Operations hold it
+// shared while they write result bundles; pruning needs it exclusively.
+const resultsLockName = ".xcbolt-results.lock"
+
+// resultsOps counts this process's operations writing result bundles. The
+// first one takes the shared lock and the last one releases it, so nested
+// operations (run's build) don't lock against each other.
+var resultsOps struct {
+	sync.Mutex
+	n int
+	f *os.File
+}
+
+// beginResultsOp marks an operation writing result bundles until the
+// returned func is called. Other xcbolt instances don't prune meanwhile.
+func beginResultsOp(cfg Config) func() {
+	resultsOps.Lock()
+	defer resultsOps.Unlock()
+	resultsOps.n++
+	if resultsOps.n == 1 && cfg.ResultBundlesPath != "" {
+		if err := os.MkdirAll(cfg.ResultBundlesPath, 0o755); err == nil {
+			if f, err := os.OpenFile(filepath.Join(cfg.ResultBundlesPath, resultsLockName), os.O_CREATE|os.O_RDWR, 0o644); err == nil {
+				// Best effort: a failed shared lock only means a pruner may
+				// not see this op
+				_ = syscall.Flock(int(f.Fd()), syscall.LOCK_SH)
+				resultsOps.f = f
+			}
+		}
+	}
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			resultsOps.Lock()
+			defer resultsOps.Unlock()
+			resultsOps.n--
+			if resultsOps.n == 0 && resultsOps.f != nil {
+				_ = syscall.Flock(int(resultsOps.f.Fd()), syscall.LOCK_UN)
+				resultsOps.f.Close()
+				resultsOps.f = nil
+			}
+		})
+	}
+}
+
+// PruneResult is what PruneResultBundles removed.
+type PruneResult struct {
+	Removed []string `json:"removed,omitempty"`
+	Bytes   int64    `json:"bytes"`
+	// Skipped says why nothing was pruned ("" when pruning ran).
+	Skipped string `json:"skipped,omitempty"`
+}
+
+// ErrResultsBusy means another operation is writing result bundles, so
+// pruning was skipped.
+var ErrResultsBusy = errors.New("result bundles are in use by another operation")
+
+// resultGroup is a result bundle with the files named after it: its
+// .meta.json, the -retry bundle of a re-run, and an analyzer report. A
+// compact output log is a group of its own, with outputLog set.
+type resultGroup struct {
+	bundle    string
+	paths     []string
+	modTime   time.Time
+	outputLog bool
+}
+
+// PruneResultBundles removes the result bundles beyond results.retention,
+// oldest first, and likewise the compact output logs (counted apart from
+// the bundles). It never removes cfg.LastResultBundle or bundles modified
+// at or after since (the current operation's), and skips with
+// ErrResultsBusy while another operation holds the results lock. The
+// summary is emitted as a log event for cmd.
+func PruneResultBundles(ctx context.Context, cfg Config, since time.Time, cmd string, emit Emitter) (PruneResult, error) {
+	policy := cfg.Results.Retention
+	if !policy.Enabled() || cfg.ResultBundlesPath == "" {
+		return PruneResult{Skipped: "no retention policy"}, nil
+	}
+
+	resultsOps.Lock()
+	defer resultsOps.Unlock()
+	if resultsOps.n > 0 {
+		// The outer operation prunes when it's done
+		return PruneResult{Skipped: "an operation is running"}, nil
+	}
+	f, err := os.OpenFile(filepath.Join(cfg.ResultBundlesPath, resultsLockName), os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return PruneResult{}, nil
+		}
+		return PruneResult{}, err
+	}
+	defer f.Close()
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		emitMaybe(emit, Warn(cmd, "Skipped pruning result bundles: another xcbolt operation is using them"))
+		return PruneResult{Skipped: ErrResultsBusy.Error()}, ErrResultsBusy
+	}
+	defer syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+
+	groups, err := resultGroups(cfg.ResultBundlesPath)
+	if err != nil {
+		return PruneResult{}, err
+	}
+	var res PruneResult
+	now := time.Now()
+	newer := map[bool]int{}
+	logs := 0
+	for _, g := range groups {
+		if ctx.Err() != nil {
+			break
+		}
+		i := newer[g.outputLog]
+		newer[g.outputLog]++
+		if g.bundle == cfg.LastResultBundle || !g.modTime.Before(since) {
+			continue
+		}
+		tooMany := policy.MaxCount > 0 && i >= policy.MaxCount
+		tooOld := policy.MaxAgeDays > 0 && now.Sub(g.modTime) > time.Duration(policy.MaxAgeDays)*24*time.Hour
+		if !tooMany && !tooOld {
+			continue
+		}
+		for _, p := range g.paths {
+			size, _ := pathSize(ctx, p)
+			if err := os.RemoveAll(p); err != nil {
+				emitMaybe(emit, Warn(cmd, fmt.Sprintf("Could not remove %s: %v", filepath.Base(p), err)))
+				continue
+			}
+			res.Bytes += size
+		}
+		res.Removed = append(res.Removed, g.bundle)
+		if g.outputLog {
+			logs++
+		}
+	}
+	if len(res.Removed) > 0 {
+		InvalidateStorage()
+		what := countNoun(len(res.Removed)-logs, "result bundle")
+		if logs > 0 {
+			what += " and " + countNoun(logs, "output log")
+		}
+		emitMaybe(emit, Log(cmd, fmt.Sprintf("Pruned %s (%s reclaimed, results.retention)", what, FormatBytes(res.Bytes))))
+	}
+	return res, ctx.Err()
+}
+
+// resultGroups lists the result bundles and output logs in dir, newest
+// first
+func resultGroups(dir string) ([]resultGroup, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	byStem := map[string]*resultGroup{}
+	var groups []resultGroup
+	for _, e := range entries {
+		name := e.Name()
+		if strings.HasSuffix(name, OutputLogSuffix) && e.Type().IsRegular() {
+			g := resultGroup{bundle: filepath.Join(dir, name), outputLog: true}
+			g.paths = []string{g.bundle}
+			if info, err := e.Info(); err == nil {
+				g.modTime = info.ModTime()
+			}
+			groups = append(groups, g)
+			continue
+		}
+		stem, primary := resultStem(name)
+		if stem == "" {
+			continue
+		}
+		g := byStem[stem]
+		if g == nil {
+			g = &resultGroup{}
+			byStem[stem] = g
+		}
+		path := filepath.Join(dir, name)
+		g.paths = append(g.paths, path)
+		if primary {
+			g.bundle = path
+			if info, err := e.Info(); err == nil {
+				g.modTime = info.ModTime()
+			}
+		}
+	}
+	for _, g := range byStem {
+		// Files without their bundle are left alone
+		if g.bundle != "" {
+			groups = append(groups, *g)
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if !groups[i].modTime.Equal(groups[j].modTime) {
+			return groups[i].modTime.After(groups[j].modTime)
+		}
+		return groups[i].bundle > groups[j].bundle
+	})
+	return groups, nil
+}
+
+// resultStem returns the bundle a results entry belongs to, e.g.
+// "20260102-150405" for "20260102-150405-retry.xcresult", and whether the
+// entry is that bundle itself
+func resultStem(name string) (string, bool) {
+	if stem, ok := strings.CutSuffix(name, ".meta.json"); ok {
+		return strings.TrimSuffix(stem, "-retry"), false
+	}
+	if stem, ok := strings.CutSuffix(name, "-analyzer"); ok {
+		return stem, false
+	}
+	if stem, ok := strings.CutSuffix(name, ".xcresult"); ok {
+		if base, retry := strings.CutSuffix(stem, "-retry"); retry {
+			return base, false
+		}
+		return stem, true
+	}
+	return "", false
+}
+
+// resultsOp marks an operation writing result bundles. Call the returned
+// func with the operation's final config when it's done: it releases the
+// results lock and prunes by results.retention, keeping the bundles the
+// operation wrote.
+func resultsOp(ctx context.Context, cfg Config, cmd string, emit Emitter) func(Config) {
+	started := time.Now()
+	done := beginResultsOp(cfg)
+	return func(cfg Config) {
+		done()
+		if ctx.Err() != nil {
+			return
+		}
+		if _, err := PruneResultBundles(ctx, cfg, started, cmd, emit); err != nil && !errors.Is(err, ErrResultsBusy) {
+			emitMaybe(emit, Warn(cmd, "Pruning result bundles failed: "+err.Error()))
+		}
+	}
+}
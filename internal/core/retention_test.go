@@ -0,0 +1,227 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// writeResultBundle makes a synthetic result bundle of size bytes modified at mtime
+func writeResultBundle(t *testing.T, dir, name string, size int, mtime time.Time) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(path, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(path, "Info.plist"), make([]byte, size), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func resultEntries(t *testing.T, dir string) string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range entries {
+		if e.Name() != resultsLockName {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+func TestPruneResultBundlesByCount(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	for i, name := range []string{"a", "b", "c", "d"} {
+		writeResultBundle(t, dir, name+".xcresult", 1000, now.Add(-time.Duration(4-i)*time.Hour))
+	}
+	// The oldest one's siblings go with it
+	if err := os.WriteFile(filepath.Join(dir, "a.meta.json"), []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	writeResultBundle(t, dir, "a-retry.xcresult", 500, now.Add(-4*time.Hour))
+	if err := os.MkdirAll(filepath.Join(dir, "a-analyzer"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := Config{ResultBundlesPath: dir, Results: ResultsConfig{Retention: ResultsRetention{MaxCount: 2}}}
+	rec := &recordingEmitter{}
+	res, err := PruneResultBundles(context.Background(), cfg, now, "build", rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := resultEntries(t, dir), "c.xcresult,d.xcresult"; got != want {
+		t.Fatalf("left %s, want %s", got, want)
+	}
+	if len(res.Removed) != 2 || res.Bytes < 2500 {
+		t.Fatalf("res = %+v", res)
+	}
+	var logged string
+	for _, ev := range rec.events {
+		if ev.Type == "log" {
+			logged = ev.Msg
+		}
+	}
+	if !strings.Contains(logged, "Pruned 2 result bundles") || !strings.Contains(logged, "reclaimed") {
+		t.Fatalf("log = %q", logged)
+	}
+}
+
+func TestPruneResultBundlesPrunesOutputLogs(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	for i, name := range []string{"a", "b", "c"} {
+		mtime := now.Add(-time.Duration(3-i) * time.Hour)
+		writeResultBundle(t, dir, name+".xcresult", 10, mtime)
+		log := filepath.Join(dir, name+OutputLogSuffix)
+		if err := os.WriteFile(log, []byte("raw"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(log, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The running command's log is newer than since
+	if err := os.WriteFile(filepath.Join(dir, "d"+OutputLogSuffix), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := Config{ResultBundlesPath: dir, Results: ResultsConfig{Retention: ResultsRetention{MaxCount: 2}}}
+	rec := &recordingEmitter{}
+	if _, err := PruneResultBundles(context.Background(), cfg, now.Add(-time.Minute), "build", rec); err != nil {
+		t.Fatal(err)
+	}
+	want := "b.xcresult,c-output.log,c.xcresult,d-output.log"
+	if got := resultEntries(t, dir); got != want {
+		t.Fatalf("left %s, want %s", got, want)
+	}
+	if n := len(rec.events); n == 0 || !strings.Contains(rec.events[n-1].Msg, "Pruned 1 result bundle and 2 output logs") {
+		t.Fatalf("events = %+v", rec.events)
+	}
+}
+
+func TestPruneResultBundlesByAge(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	writeResultBundle(t, dir, "old.xcresult", 10, now.Add(-10*24*time.Hour))
+	writeResultBundle(t, dir, "new.xcresult", 10, now.Add(-2*24*time.Hour))
+
+	cfg := Config{ResultBundlesPath: dir, Results: ResultsConfig{Retention: ResultsRetention{MaxAgeDays: 7}}}
+	if _, err := PruneResultBundles(context.Background(), cfg, now, "build", nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := resultEntries(t, dir); got != "new.xcresult" {
+		t.Fatalf("left %s", got)
+	}
+}
+
+func TestPruneResultBundlesKeepsLastAndCurrent(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	last := writeResultBundle(t, dir, "last.xcresult", 10, now.Add(-30*24*time.Hour))
+	writeResultBundle(t, dir, "older.xcresult", 10, now.Add(-20*24*time.Hour))
+	started := now.Add(-time.Minute)
+	writeResultBundle(t, dir, "current-1.xcresult", 10, now.Add(-30*time.Second))
+	writeResultBundle(t, dir, "current-2.xcresult", 10, now)
+
+	cfg := Config{
+		ResultBundlesPath: dir,
+		LastResultBundle:  last,
+		Results:           ResultsConfig{Retention: ResultsRetention{MaxCount: 1, MaxAgeDays: 1}},
+	}
+	res, err := PruneResultBundles(context.Background(), cfg, started, "test", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := resultEntries(t, dir), "current-1.xcresult,current-2.xcresult,last.xcresult"; got != want {
+		t.Fatalf("left %s, want %s", got, want)
+	}
+	if len(res.Removed) != 1 {
+		t.Fatalf("removed %v", res.Removed)
+	}
+}
+
+func TestPruneResultBundlesWithoutPolicy(t *testing.T) {
+	dir := t.TempDir()
+	writeResultBundle(t, dir, "a.xcresult", 10, time.Now().Add(-365*24*time.Hour))
+	res, err := PruneResultBundles(context.Background(), Config{ResultBundlesPath: dir}, time.Now(), "build", nil)
+	if err != nil || res.Skipped == "" {
+		t.Fatalf("res = %+v, err = %v", res, err)
+	}
+	if got := resultEntries(t, dir); got != "a.xcresult" {
+		t.Fatalf("left %s", got)
+	}
+}
+
+func TestPruneResultBundlesSkipsWhileLocked(t *testing.T) {
+	dir := t.TempDir()
+	writeResultBundle(t, dir, "a.xcresult", 10, time.Now().Add(-time.Hour))
+	writeResultBundle(t, dir, "b.xcresult", 10, time.Now().Add(-2*time.Hour))
+
+	// Another xcbolt process writing results holds the lock shared
+	f, err := os.OpenFile(filepath.Join(dir, resultsLockName), os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_SH); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := Config{ResultBundlesPath: dir, Results: ResultsConfig{Retention: ResultsRetention{MaxCount: 1}}}
+	rec := &recordingEmitter{}
+	_, err = PruneResultBundles(context.Background(), cfg, time.Now(), "build", rec)
+	if !errors.Is(err, ErrResultsBusy) {
+		t.Fatalf("err = %v, want ErrResultsBusy", err)
+	}
+	if got := resultEntries(t, dir); got != "a.xcresult,b.xcresult" {
+		t.Fatalf("left %s", got)
+	}
+	if len(rec.events) != 1 || rec.events[0].Type != "warning" {
+		t.Fatalf("events = %+v", rec.events)
+	}
+
+	// Once released, the next prune goes ahead
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_UN); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := PruneResultBundles(context.Background(), cfg, time.Now(), "build", nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := resultEntries(t, dir); got != "a.xcresult" {
+		t.Fatalf("left %s", got)
+	}
+}
+
+func TestResultsOpPrunesWhenOutermostOpEnds(t *testing.T) {
+	dir := t.TempDir()
+	writeResultBundle(t, dir, "a.xcresult", 10, time.Now().Add(-time.Hour))
+	writeResultBundle(t, dir, "b.xcresult", 10, time.Now().Add(-2*time.Hour))
+	cfg := Config{ResultBundlesPath: dir, Results: ResultsConfig{Retention: ResultsRetention{MaxCount: 1}}}
+
+	outer := resultsOp(context.Background(), cfg, "run", nil)
+	inner := resultsOp(context.Background(), cfg, "build", nil)
+	inner(cfg)
+	if got := resultEntries(t, dir); got != "a.xcresult,b.xcresult" {
+		t.Fatalf("pruned during the outer op: left %s", got)
+	}
+	outer(cfg)
+	if got := resultEntries(t, dir); got != "a.xcresult" {
+		t.Fatalf("left %s", got)
+	}
+}
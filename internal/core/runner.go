@@ -20,34 +20,43 @@ type CmdSpec struct {
 
 	StdoutLine func(string)
 	StderrLine func(string)
+
+	// PreserveLocale keeps the user's locale for xcodebuild, simctl, and
+	// devicectl, which otherwise run in English unless the loaded config
+	// preserves it (see commandEnv).
+	PreserveLocale bool
 }
 
 type CmdResult struct {
 	ExitCode int
 	PID      int
 	Duration time.Duration
+	// Signal names the signal that terminated the process, e.g. "SIGKILL"
+	// ("" when it exited).
+	Signal string
 }
 
 func RunStreaming(ctx context.Context, spec CmdSpec) (CmdResult, error) {
 	start := time.Now()
 
-	cmd := exec.Command(spec.Path, spec.Args...)
+	cmd := exec.Command(spec.Path, commandArgs(spec)...)
 	if spec.Dir != "" {
 		cmd.Dir = spec.Dir
 	}
-	cmd.Env = mergeEnv(os.Environ(), spec.Env)
+	cmd.Env = mergeEnv(os.Environ(), commandEnv(spec))
 
 	// Ensure we can signal the whole process group on cancel (macOS/Linux).
 	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
 
-	stdout, err := cmd.StdoutPipe()
-	if err != nil {
-		return CmdResult{}, err
-	}
-	stderr, err := cmd.StderrPipe()
-	if err != nil {
-		return CmdResult{}, err
-	}
+	// Route output through io.Pipes rather than StdoutPipe: Wait then waits
+	// for exec's copy goroutines, so the last lines of short-lived processes
+	// are never lost. WaitDelay bounds that wait if a grandchild keeps the
+	// pipes open.
+	stdout, stdoutW := io.Pipe()
+	stderr, stderrW := io.Pipe()
+	cmd.Stdout = stdoutW
+	cmd.Stderr = stderrW
+	cmd.WaitDelay = 2 * time.Second
 
 	if err := cmd.Start(); err != nil {
 		return CmdResult{}, err
@@ -61,28 +70,32 @@ func RunStreaming(ctx context.Context, spec CmdSpec) (CmdResult, error) {
 	go streamLines(stderr, spec.StderrLine, stderrDone)
 
 	waitDone := make(chan error, 1)
-	go func() { waitDone <- cmd.Wait() }()
+	go func() {
+		err := cmd.Wait()
+		if errors.Is(err, exec.ErrWaitDelay) {
+			// The process succeeded; only a leftover child held the pipes.
+			err = nil
+		}
+		stdoutW.Close()
+		stderrW.Close()
+		waitDone <- err
+	}()
 
 	// Cancel handling
-	cancelled := false
 	select {
 	case err := <-waitDone:
 		<-stdoutDone
 		<-stderrDone
 		return finalizeResult(err, pid, time.Since(start))
 	case <-ctx.Done():
-		cancelled = true
 		_ = syscall.Kill(-pid, syscall.SIGINT)
 
 		select {
 		case err := <-waitDone:
 			<-stdoutDone
 			<-stderrDone
-			// Surface cancellation as context error if possible.
-			if errors.Is(ctx.Err(), context.Canceled) {
-				return CmdResult{ExitCode: exitCodeFromErr(err), PID: pid, Duration: time.Since(start)}, ctx.Err()
-			}
-			return finalizeResult(err, pid, time.Since(start))
+			// Surface cancellation (and timeouts) as the context error.
+			return CmdResult{ExitCode: exitCodeFromErr(err), PID: pid, Duration: time.Since(start)}, ctx.Err()
 		case <-time.After(3 * time.Second):
 			_ = syscall.Kill(-pid, syscall.SIGTERM)
 		}
@@ -91,19 +104,13 @@ func RunStreaming(ctx context.Context, spec CmdSpec) (CmdResult, error) {
 		case err := <-waitDone:
 			<-stdoutDone
 			<-stderrDone
-			if errors.Is(ctx.Err(), context.Canceled) {
-				return CmdResult{ExitCode: exitCodeFromErr(err), PID: pid, Duration: time.Since(start)}, ctx.Err()
-			}
-			return finalizeResult(err, pid, time.Since(start))
+			return CmdResult{ExitCode: exitCodeFromErr(err), PID: pid, Duration: time.Since(start)}, ctx.Err()
 		case <-time.After(3 * time.Second):
 			_ = syscall.Kill(-pid, syscall.SIGKILL)
 			err := <-waitDone
 			<-stdoutDone
 			<-stderrDone
-			if cancelled {
-				return CmdResult{ExitCode: exitCodeFromErr(err), PID: pid, Duration: time.Since(start)}, ctx.Err()
-			}
-			return finalizeResult(err, pid, time.Since(start))
+			return CmdResult{ExitCode: exitCodeFromErr(err), PID: pid, Duration: time.Since(start)}, ctx.Err()
 		}
 	}
 }
@@ -118,11 +125,13 @@ func streamLines(r io.Reader, onLine func(string), done chan<- struct{}) {
 			onLine(scanner.Text())
 		}
 	}
+	// Keep draining after a scanner error so the writer never blocks.
+	_, _ = io.Copy(io.Discard, r)
 }
 
 func finalizeResult(waitErr error, pid int, dur time.Duration) (CmdResult, error) {
 	code := exitCodeFromErr(waitErr)
-	res := CmdResult{ExitCode: code, PID: pid, Duration: dur}
+	res := CmdResult{ExitCode: code, PID: pid, Duration: dur, Signal: signalFromErr(waitErr)}
 	if waitErr == nil {
 		return res, nil
 	}
@@ -143,6 +152,17 @@ func exitCodeFromErr(err error) int {
 	return 1
 }
 
+// signalFromErr names the signal that terminated a process, or ""
+func signalFromErr(err error) string {
+	var ee *exec.ExitError
+	if errors.As(err, &ee) {
+		if ws, ok := ee.Sys().(syscall.WaitStatus); ok && ws.Signaled() {
+			return SignalName(ws.Signal())
+		}
+	}
+	return ""
+}
+
 func mergeEnv(base []string, extra map[string]string) []string {
 	if len(extra) == 0 {
 		return base
@@ -0,0 +1,92 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// processAlive treats zombies as dead: containers may not reap orphans.
+func processAlive(pid int) bool {
+	if err := syscall.Kill(pid, 0); err != nil {
+		return false
+	}
+	if stat, err := os.ReadFile("/proc/" + strconv.Itoa(pid) + "/stat"); err == nil {
+		if i := strings.LastIndexByte(string(stat), ')'); i >= 0 && i+2 < len(stat) && stat[i+2] == 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+func TestRunStreamingCancelKillsProcessGroup(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The outer shell waits on a nested worker, like xcodebuild and its
+	// build services; the worker reports its pid before it starts sleeping.
+	pidCh := make(chan int, 1)
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := RunStreaming(ctx, CmdSpec{
+			Path: "/bin/sh",
+			Args: []string{"-c", `sh -c 'echo $$; exec sleep 30'`},
+			StdoutLine: func(s string) {
+				if pid, err := strconv.Atoi(strings.TrimSpace(s)); err == nil {
+					pidCh <- pid
+				}
+			},
+		})
+		errCh <- err
+	}()
+
+	var worker int
+	select {
+	case worker = <-pidCh:
+	case <-time.After(5 * time.Second):
+		t.Fatal("worker did not start")
+	}
+	cancel()
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("RunStreaming did not return after cancel")
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for processAlive(worker) {
+		if time.Now().After(deadline) {
+			_ = syscall.Kill(worker, syscall.SIGKILL)
+			t.Fatalf("nested worker %d outlived cancellation", worker)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestRunStreamingTimeoutReportsDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	_, err := RunStreaming(ctx, CmdSpec{Path: "/bin/sh", Args: []string{"-c", "exec sleep 30"}})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if got := CancelStatus(err); got != CancelStatusTimedOut {
+		t.Fatalf("CancelStatus = %q, want %q", got, CancelStatusTimedOut)
+	}
+	if got := CancelStatus(context.Canceled); got != CancelStatusCanceled {
+		t.Fatalf("CancelStatus(Canceled) = %q", got)
+	}
+	if got := CancelStatus(errors.New("exit status 65")); got != "" {
+		t.Fatalf("CancelStatus(exit) = %q, want empty", got)
+	}
+}
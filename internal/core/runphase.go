@@ -0,0 +1,189 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RunPhase is a step of Run: building, installing, launching, and staying
+// attached to the app's console.
+type RunPhase string
+
+const (
+	RunPhaseBuild   RunPhase = "build"
+	RunPhaseInstall RunPhase = "install"
+	RunPhaseLaunch  RunPhase = "launch"
+	RunPhaseConsole RunPhase = "console"
+)
+
+// RunPhaseCode marks the status events Run emits as it enters each phase
+// (Data["phase"] is the RunPhase).
+const RunPhaseCode = "RUN_PHASE"
+
+// RunTimingCode marks the status event Run emits once the app is launched,
+// with how long each step took (Data["phases"] is a []RunStepTiming).
+const RunTimingCode = "RUN_TIMING"
+
+// RunStepTiming is how long one step of a run took: "build", "boot" (boot
+// and bootstatus), "install", "logs" (log stream setup), or "launch". Steps
+// a run skipped, such as booting an already booted simulator, are left out.
+type RunStepTiming struct {
+	Name string `json:"name"`
+	MS   int64  `json:"ms"`
+}
+
+// FormatRunTimings renders timings as one line, e.g.
+// "build 42s · boot 3s · install 2.1s · launch 0.8s".
+func FormatRunTimings(timings []RunStepTiming) string {
+	parts := make([]string, 0, len(timings))
+	for _, t := range timings {
+		parts = append(parts, t.Name+" "+formatStepDuration(time.Duration(t.MS)*time.Millisecond))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// formatStepDuration keeps a decimal below 10s, where it matters
+func formatStepDuration(d time.Duration) string {
+	switch {
+	case d < 100*time.Millisecond:
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	case d < 10*time.Second:
+		return strconv.FormatFloat(math.Round(d.Seconds()*10)/10, 'f', -1, 64) + "s"
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Round(time.Second).Seconds()))
+	}
+	return d.Round(time.Second).String()
+}
+
+// RunPhaseCanceledError is returned when a run is canceled after its build
+// succeeded. The app is built (RunResult.AppPath and
+// Config.LastBuiltAppBundle are set), so it can be launched again without
+// rebuilding; Phase is the step that was interrupted.
+type RunPhaseCanceledError struct {
+	Phase RunPhase
+	Err   error
+}
+
+func (e *RunPhaseCanceledError) Error() string {
+	return string(e.Phase) + " canceled"
+}
+
+func (e *RunPhaseCanceledError) Unwrap() error { return e.Err }
+
+// runPhases tracks the phase a run is in. Console output arrives on other
+// goroutines, so entering a phase is locked.
+type runPhases struct {
+	emit  Emitter
+	mu    sync.Mutex
+	phase RunPhase
+
+	timings  []RunStepTiming
+	reported bool
+}
+
+// enter moves to phase and reports it, unless the run is already there
+func (p *runPhases) enter(phase RunPhase) {
+	p.mu.Lock()
+	if p.phase == phase {
+		p.mu.Unlock()
+		return
+	}
+	p.phase = phase
+	p.mu.Unlock()
+	ev := Status("run", "Run phase: "+string(phase), map[string]any{"phase": string(phase)})
+	ev.Code = RunPhaseCode
+	emitMaybe(p.emit, ev)
+}
+
+func (p *runPhases) current() RunPhase {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.phase
+}
+
+// time starts timing step name. The returned func records it; calls after
+// the first do nothing, so a step may be ended from several places.
+func (p *runPhases) time(name string) func() {
+	start := time.Now()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			p.mu.Lock()
+			p.timings = append(p.timings, RunStepTiming{Name: name, MS: time.Since(start).Milliseconds()})
+			p.mu.Unlock()
+		})
+	}
+}
+
+// stepTimings returns the steps timed so far
+func (p *runPhases) stepTimings() []RunStepTiming {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]RunStepTiming(nil), p.timings...)
+}
+
+// reportTimings emits the RUN_TIMING status once, when the app is launched
+func (p *runPhases) reportTimings() {
+	p.mu.Lock()
+	if p.reported || len(p.timings) == 0 {
+		p.mu.Unlock()
+		return
+	}
+	p.reported = true
+	timings := append([]RunStepTiming(nil), p.timings...)
+	p.mu.Unlock()
+	ev := Status("run", "Run timing: "+FormatRunTimings(timings), map[string]any{"phases": timings})
+	ev.Code = RunTimingCode
+	emitMaybe(p.emit, ev)
+}
+
+// runPhaseLabel capitalizes a phase for messages, e.g. "Launch"
+func runPhaseLabel(phase RunPhase) string {
+	s := string(phase)
+	if s == "" {
+		return ""
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// logStreamSetupTimeout bounds how long a simulator run waits for its log
+// stream's first line before launching anyway.
+const logStreamSetupTimeout = 3 * time.Second
+
+// startedEmitter wraps a log stream's emitter so its first line (simctl's
+// "Filtering the log data" header) marks the stream as started.
+type startedEmitter struct {
+	next    Emitter
+	started func()
+}
+
+func (e startedEmitter) Emit(ev Event) {
+	e.started()
+	emitMaybe(e.next, ev)
+}
+
+// launchedEmitter wraps next so the app's first console line ends the
+// launch step and reports the timings: with --console, devicectl stays
+// attached until the app exits.
+func (p *runPhases) launchedEmitter(next Emitter, launchDone func()) Emitter {
+	return launchedEmitter{next: next, launched: func() {
+		launchDone()
+		p.reportTimings()
+	}}
+}
+
+type launchedEmitter struct {
+	next     Emitter
+	launched func()
+}
+
+func (e launchedEmitter) Emit(ev Event) {
+	if mm, ok := ev.Data.(map[string]any); ok && ev.Type == "log" && mm["stream"] == "app" {
+		e.launched()
+	}
+	emitMaybe(e.next, ev)
+}
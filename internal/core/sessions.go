@@ -1,11 +1,15 @@
 package core
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"syscall"
 	"time"
 )
 
@@ -34,7 +38,13 @@ func sessionsPath(projectRoot string) string {
 	return filepath.Join(projectRoot, ".xcbolt", "sessions.json")
 }
 
+// LoadSessions reads the project's sessions. A corrupt file (e.g. truncated
+// by a crash) is moved aside and an empty list returned.
 func LoadSessions(projectRoot string) (Sessions, error) {
+	return loadSessions(projectRoot, nil)
+}
+
+func loadSessions(projectRoot string, emit Emitter) (Sessions, error) {
 	path := sessionsPath(projectRoot)
 	b, err := os.ReadFile(path)
 	if err != nil {
@@ -45,7 +55,12 @@ func LoadSessions(projectRoot string) (Sessions, error) {
 	}
 	var s Sessions
 	if err := json.Unmarshal(b, &s); err != nil {
-		return Sessions{}, err
+		backup := path + ".corrupt"
+		if renameErr := os.Rename(path, backup); renameErr != nil {
+			return Sessions{}, err
+		}
+		emitMaybe(emit, Warn("sessions", fmt.Sprintf("%s was unreadable (%v); moved it to %s and started a new session list", path, err, backup)))
+		return Sessions{Version: SessionsVersion, Items: []Session{}}, nil
 	}
 	if s.Version != SessionsVersion {
 		// Hard-cutover for session schema: reset to empty state.
@@ -54,18 +69,42 @@ func LoadSessions(projectRoot string) (Sessions, error) {
 	return s, nil
 }
 
+// SaveSessions replaces the project's sessions. Prefer AddSession and
+// RemoveSessions, which update the file under its lock.
 func SaveSessions(projectRoot string, s Sessions) error {
 	if err := EnsureProjectDirs(projectRoot); err != nil {
 		return err
 	}
-	path := sessionsPath(projectRoot)
+	return withFileLock(sessionsPath(projectRoot), func() error {
+		return saveSessions(projectRoot, s)
+	})
+}
+
+func saveSessions(projectRoot string, s Sessions) error {
 	s.Version = SessionsVersion
 	b, err := json.MarshalIndent(s, "", "  ")
 	if err != nil {
 		return err
 	}
 	b = append(b, '\n')
-	return os.WriteFile(path, b, 0o644)
+	return writeFileAtomic(sessionsPath(projectRoot), b, 0o644)
+}
+
+// updateSessions applies update to the project's sessions while holding the
+// sessions lock, so concurrent xcbolt instances don't lose each other's
+// changes.
+func updateSessions(projectRoot string, emit Emitter, update func(*Sessions)) error {
+	if err := EnsureProjectDirs(projectRoot); err != nil {
+		return err
+	}
+	return withFileLock(sessionsPath(projectRoot), func() error {
+		s, err := loadSessions(projectRoot, emit)
+		if err != nil {
+			return err
+		}
+		update(&s)
+		return saveSessions(projectRoot, s)
+	})
 }
 
 func AddSession(projectRoot string, bundleID string, pid int, target string, udid string) (Session, error) {
@@ -92,10 +131,12 @@ func AddSession(projectRoot string, bundleID string, pid int, target string, udi
 }
 
 func AddSessionWithDestination(projectRoot string, bundleID string, pid int, dst Destination) (Session, error) {
-	s, err := LoadSessions(projectRoot)
-	if err != nil {
-		return Session{}, err
-	}
+	return addSession(projectRoot, bundleID, pid, dst, nil)
+}
+
+// addSession records a launched app; emit receives a warning when a corrupt
+// sessions file had to be reset.
+func addSession(projectRoot string, bundleID string, pid int, dst Destination, emit Emitter) (Session, error) {
 	dst = normalizeDestination(dst)
 	target := string(dst.Kind)
 	if target == "" || dst.Kind == DestAuto {
@@ -122,28 +163,107 @@ func AddSessionWithDestination(projectRoot string, bundleID string, pid int, dst
 		CompanionBundleID: dst.CompanionBundleID,
 		StartedAt:         time.Now().UTC().Format(time.RFC3339Nano),
 	}
-	// Remove any existing with same ID
-	filtered := make([]Session, 0, len(s.Items))
-	for _, it := range s.Items {
-		if it.ID != id {
-			filtered = append(filtered, it)
+	err := updateSessions(projectRoot, emit, func(s *Sessions) {
+		// Remove any existing with same ID
+		filtered := make([]Session, 0, len(s.Items))
+		for _, it := range s.Items {
+			if it.ID != id {
+				filtered = append(filtered, it)
+			}
 		}
-	}
-	s.Items = append(filtered, sess)
-	return sess, SaveSessions(projectRoot, s)
+		s.Items = append(filtered, sess)
+	})
+	return sess, err
 }
 
 func RemoveSession(projectRoot string, id string) error {
-	s, err := LoadSessions(projectRoot)
-	if err != nil {
-		return err
+	return updateSessions(projectRoot, nil, func(s *Sessions) {
+		filtered := make([]Session, 0, len(s.Items))
+		for _, it := range s.Items {
+			if it.ID != id && it.BundleID != id {
+				filtered = append(filtered, it)
+			}
+		}
+		s.Items = filtered
+	})
+}
+
+// RemoveSessions drops the sessions with exactly these IDs. Unlike
+// RemoveSession it never matches bundle ids, so pruning one stale entry
+// keeps the app's sessions on other destinations.
+func RemoveSessions(projectRoot string, ids ...string) error {
+	drop := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		drop[id] = true
 	}
-	filtered := make([]Session, 0, len(s.Items))
-	for _, it := range s.Items {
-		if it.ID != id && it.BundleID != id {
-			filtered = append(filtered, it)
+	return updateSessions(projectRoot, nil, func(s *Sessions) {
+		filtered := make([]Session, 0, len(s.Items))
+		for _, it := range s.Items {
+			if !drop[it.ID] {
+				filtered = append(filtered, it)
+			}
+		}
+		s.Items = filtered
+	})
+}
+
+// SessionAlive probes whether the session's app is still running: Mac apps
+// by PID, simulator apps through launchctl inside the simulator, and device
+// apps through devicectl's process list. A failed probe reports dead.
+func SessionAlive(ctx context.Context, sess Session, preserveLocale bool) bool {
+	switch sess.Target {
+	case string(DestMacOS), string(DestCatalyst):
+		if sess.PID <= 0 {
+			return false
+		}
+		err := syscall.Kill(sess.PID, 0)
+		return err == nil || errors.Is(err, syscall.EPERM)
+	case string(DestSimulator):
+		if sess.UDID == "" {
+			return false
+		}
+		out, err := simctlOutput(ctx, preserveLocale, "spawn", sess.UDID, "launchctl", "list")
+		return err == nil && launchctlListHasApp(out, sess.BundleID)
+	case string(DestDevice):
+		if sess.UDID == "" || sess.PID <= 0 {
+			return false
+		}
+		var out strings.Builder
+		_, err := RunStreaming(ctx, CmdSpec{
+			Path:           "xcrun",
+			Args:           []string{"devicectl", "device", "info", "processes", "--device", sess.UDID},
+			PreserveLocale: preserveLocale,
+			StdoutLine:     func(s string) { out.WriteString(s + "\n") },
+		})
+		return err == nil && processListHasPID(out.String(), sess.PID)
+	}
+	return false
+}
+
+// launchctlListHasApp reports whether `launchctl list` output (PID, status,
+// label columns) has a running UIKitApplication job for bundleID.
+func launchctlListHasApp(out, bundleID string) bool {
+	if bundleID == "" {
+		return false
+	}
+	label := "UIKitApplication:" + bundleID + "["
+	for _, line := range strings.Split(normalizeLines(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 3 && fields[0] != "-" && strings.HasPrefix(fields[2], label) {
+			return true
+		}
+	}
+	return false
+}
+
+// processListHasPID reports whether a devicectl process table lists pid in
+// its first column.
+func processListHasPID(out string, pid int) bool {
+	want := strconv.Itoa(pid)
+	for _, line := range strings.Split(normalizeLines(out), "\n") {
+		if fields := strings.Fields(line); len(fields) > 0 && fields[0] == want {
+			return true
 		}
 	}
-	s.Items = filtered
-	return SaveSessions(projectRoot, s)
+	return false
 }
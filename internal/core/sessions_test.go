@@ -2,7 +2,10 @@ package core
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -88,3 +91,119 @@ func TestRemoveSessionByBundleID(t *testing.T) {
 		t.Fatalf("items len = %d, want 0", len(loaded.Items))
 	}
 }
+
+func TestRemoveSessionsMatchesExactIDs(t *testing.T) {
+	root := t.TempDir()
+	for _, udid := range []string{"SIM-1", "SIM-2"} {
+		if _, err := AddSession(root, "com.example.app", 0, "simulator", udid); err != nil {
+			t.Fatalf("AddSession: %v", err)
+		}
+	}
+	if err := RemoveSessions(root, "com.example.app@SIM-1", "com.example.app"); err != nil {
+		t.Fatalf("RemoveSessions: %v", err)
+	}
+	loaded, err := LoadSessions(root)
+	if err != nil {
+		t.Fatalf("LoadSessions: %v", err)
+	}
+	if len(loaded.Items) != 1 || loaded.Items[0].ID != "com.example.app@SIM-2" {
+		t.Fatalf("unexpected items %+v", loaded.Items)
+	}
+}
+
+func TestCorruptSessionsFileIsBackedUp(t *testing.T) {
+	root := t.TempDir()
+	if err := EnsureProjectDirs(root); err != nil {
+		t.Fatalf("EnsureProjectDirs: %v", err)
+	}
+	truncated := `{"version": 2, "items": [{"id": "com.example.app"`
+	if err := os.WriteFile(sessionsPath(root), []byte(truncated), 0o644); err != nil {
+		t.Fatalf("write sessions: %v", err)
+	}
+
+	rec := &recordingEmitter{}
+	if _, err := addSession(root, "com.example.other", 0, Destination{Kind: DestMacOS}, rec); err != nil {
+		t.Fatalf("addSession: %v", err)
+	}
+	if len(rec.events) != 1 || rec.events[0].Type != "warning" || !strings.Contains(rec.events[0].Msg, "sessions.json.corrupt") {
+		t.Fatalf("expected a warning naming the backup, got %+v", rec.events)
+	}
+	backup, err := os.ReadFile(sessionsPath(root) + ".corrupt")
+	if err != nil || string(backup) != truncated {
+		t.Fatalf("backup = %q, %v", backup, err)
+	}
+	loaded, err := LoadSessions(root)
+	if err != nil {
+		t.Fatalf("LoadSessions: %v", err)
+	}
+	if len(loaded.Items) != 1 || loaded.Items[0].BundleID != "com.example.other" {
+		t.Fatalf("unexpected items %+v", loaded.Items)
+	}
+}
+
+func TestConcurrentSessionUpdates(t *testing.T) {
+	root := t.TempDir()
+	const n = 40
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			udid := fmt.Sprintf("SIM-%d", i)
+			if _, err := AddSession(root, "com.example.app", 0, "simulator", udid); err != nil {
+				errs <- err
+				return
+			}
+			if i%2 == 1 {
+				errs <- RemoveSessions(root, "com.example.app@"+udid)
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("session update failed: %v", err)
+		}
+	}
+
+	b, err := os.ReadFile(sessionsPath(root))
+	if err != nil {
+		t.Fatalf("read sessions: %v", err)
+	}
+	var s Sessions
+	if err := json.Unmarshal(b, &s); err != nil {
+		t.Fatalf("sessions file doesn't parse: %v\n%s", err, b)
+	}
+	got := map[string]bool{}
+	for _, it := range s.Items {
+		got[it.ID] = true
+	}
+	for i := 0; i < n; i++ {
+		id := fmt.Sprintf("com.example.app@SIM-%d", i)
+		if want := i%2 == 0; got[id] != want {
+			t.Errorf("%s present = %v, want %v", id, got[id], want)
+		}
+	}
+	if len(s.Items) != n/2 {
+		t.Errorf("items = %d, want %d", len(s.Items), n/2)
+	}
+}
+
+func TestSessionAliveParsers(t *testing.T) {
+	launchctl := "PID\tStatus\tLabel\n" +
+		"-\t0\tUIKitApplication:com.example.old[1a2b][rb-legacy]\n" +
+		"4242\t0\tUIKitApplication:com.example.app[3c4d][rb-legacy]\n"
+	if !launchctlListHasApp(launchctl, "com.example.app") {
+		t.Fatalf("running app should be found")
+	}
+	if launchctlListHasApp(launchctl, "com.example.old") || launchctlListHasApp(launchctl, "com.example") {
+		t.Fatalf("exited or prefix-only apps should not match")
+	}
+
+	procs := "PID    Executable URL\n 1    file:///sbin/launchd\n 812  file:///private/var/containers/App.app/App\n"
+	if !processListHasPID(procs, 812) || processListHasPID(procs, 81) {
+		t.Fatalf("pid matching should be exact")
+	}
+}
@@ -0,0 +1,182 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// SettingsVariant is what DiffBuildSettings changes in the config to get the
+// other side of the diff. Set exactly one field.
+type SettingsVariant struct {
+	Configuration string       `json:"configuration,omitempty"`
+	Destination   *Destination `json:"destination,omitempty"`
+}
+
+// BuildSettingChange is one key that differs between two sets of build
+// settings. From is empty for added keys, To for removed ones.
+type BuildSettingChange struct {
+	Key  string `json:"key"`
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+}
+
+// BuildSettingsDiff compares the build settings of the config (Base) with a
+// variant of it (Other). Each list is sorted by key.
+type BuildSettingsDiff struct {
+	Base    string               `json:"base"`
+	Other   string               `json:"other"`
+	Added   []BuildSettingChange `json:"added,omitempty"`
+	Removed []BuildSettingChange `json:"removed,omitempty"`
+	Changed []BuildSettingChange `json:"changed,omitempty"`
+	// PathOnly counts keys whose values only differ in the DerivedData
+	// location; they're left out of Changed.
+	PathOnly int `json:"pathOnly,omitempty"`
+}
+
+// Empty reports whether both sides have the same settings
+func (d BuildSettingsDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// DiffBuildSettings runs -showBuildSettings for cfg and for cfg changed by
+// other, and returns the keys that differ.
+func DiffBuildSettings(ctx context.Context, projectRoot string, cfg Config, other SettingsVariant) (BuildSettingsDiff, error) {
+	otherCfg := cfg
+	switch {
+	case other.Configuration != "":
+		otherCfg.Configuration = other.Configuration
+	case other.Destination != nil:
+		otherCfg.Destination = *other.Destination
+	default:
+		return BuildSettingsDiff{}, fmt.Errorf("nothing to compare: set a configuration or destination")
+	}
+	base, err := ShowBuildSettings(ctx, projectRoot, cfg)
+	if err != nil {
+		return BuildSettingsDiff{}, fmt.Errorf("build settings for %s: %w", settingsLabel(cfg, other), err)
+	}
+	theirs, err := ShowBuildSettings(ctx, projectRoot, otherCfg)
+	if err != nil {
+		return BuildSettingsDiff{}, fmt.Errorf("build settings for %s: %w", settingsLabel(otherCfg, other), err)
+	}
+	dirs := []string{derivedDataRoot(projectRoot, cfg), derivedDataRoot(projectRoot, otherCfg)}
+	diff := diffBuildSettings(base, theirs, dirs)
+	diff.Base = settingsLabel(cfg, other)
+	diff.Other = settingsLabel(otherCfg, other)
+	return diff, nil
+}
+
+// settingsLabel names a side of the diff by the dimension that varies
+func settingsLabel(cfg Config, other SettingsVariant) string {
+	if other.Configuration != "" {
+		if cfg.Configuration == "" {
+			return "default configuration"
+		}
+		return cfg.Configuration
+	}
+	if cfg.Destination.Name != "" {
+		return cfg.Destination.Name
+	}
+	if dest := BuildDestinationString(cfg); dest != "" {
+		return dest
+	}
+	return "default destination"
+}
+
+// derivedDataRoot is the absolute -derivedDataPath passed for cfg ("" when
+// Xcode's default location is used)
+func derivedDataRoot(projectRoot string, cfg Config) string {
+	dir := DerivedDataDir(cfg)
+	if dir == "" || filepath.IsAbs(dir) {
+		return dir
+	}
+	return filepath.Join(projectRoot, dir)
+}
+
+// Xcode's default per-project DerivedData folder, e.g. ".../DerivedData/App-bxyz…"
+var xcodeDerivedDataRE = regexp.MustCompile(`/DerivedData/[^/\s]+-[a-z]{28}`)
+
+// derivedDataPlaceholder replaces DerivedData locations when comparing values
+const derivedDataPlaceholder = "$(DERIVED_DATA)"
+
+// normalizeDerivedData replaces the DerivedData locations in v (the given
+// dirs, then Xcode's default folders) with a placeholder
+func normalizeDerivedData(v string, dirs []string) string {
+	sorted := append([]string{}, dirs...)
+	sort.Slice(sorted, func(i, j int) bool { return len(sorted[i]) > len(sorted[j]) })
+	for _, dir := range sorted {
+		if dir != "" {
+			v = strings.ReplaceAll(v, dir, derivedDataPlaceholder)
+		}
+	}
+	return xcodeDerivedDataRE.ReplaceAllString(v, "/"+derivedDataPlaceholder)
+}
+
+// diffBuildSettings compares two settings maps, treating values that only
+// differ in a DerivedData location (dirs) as equal
+func diffBuildSettings(base, other BuildSettings, dirs []string) BuildSettingsDiff {
+	var diff BuildSettingsDiff
+	for key, from := range base {
+		to, ok := other[key]
+		switch {
+		case !ok:
+			diff.Removed = append(diff.Removed, BuildSettingChange{Key: key, From: from})
+		case from == to:
+		case normalizeDerivedData(from, dirs) == normalizeDerivedData(to, dirs):
+			diff.PathOnly++
+		default:
+			diff.Changed = append(diff.Changed, BuildSettingChange{Key: key, From: from, To: to})
+		}
+	}
+	for key, to := range other {
+		if _, ok := base[key]; !ok {
+			diff.Added = append(diff.Added, BuildSettingChange{Key: key, To: to})
+		}
+	}
+	for _, list := range [][]BuildSettingChange{diff.Added, diff.Removed, diff.Changed} {
+		sort.Slice(list, func(i, j int) bool { return list[i].Key < list[j].Key })
+	}
+	return diff
+}
+
+// FormatBuildSettingsDiff renders the diff as plain text, one key per line
+// with the two values in aligned columns.
+func FormatBuildSettingsDiff(d BuildSettingsDiff) string {
+	keyWidth := 0
+	for _, list := range [][]BuildSettingChange{d.Changed, d.Added, d.Removed} {
+		for _, c := range list {
+			keyWidth = max(keyWidth, len(c.Key))
+		}
+	}
+	valueWidth := len(d.Base)
+	for _, c := range append(append([]BuildSettingChange{}, d.Changed...), d.Removed...) {
+		valueWidth = max(valueWidth, len(c.From))
+	}
+	valueWidth = min(valueWidth, 60)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Build settings: %s vs %s\n", d.Base, d.Other)
+	if d.Empty() {
+		b.WriteString("\nNo differences\n")
+	}
+	section := func(title string, list []BuildSettingChange) {
+		if len(list) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\n%s (%d)\n", title, len(list))
+		fmt.Fprintf(&b, "  %-*s  %-*s  %s\n", keyWidth, "", valueWidth, d.Base, d.Other)
+		for _, c := range list {
+			fmt.Fprintf(&b, "  %-*s  %-*s  %s\n", keyWidth, c.Key, valueWidth, c.From, c.To)
+		}
+	}
+	section("Changed", d.Changed)
+	section("Added", d.Added)
+	section("Removed", d.Removed)
+	if d.PathOnly > 0 {
+		fmt.Fprintf(&b, "\n%d more differ only in the DerivedData location\n", d.PathOnly)
+	}
+	return b.String()
+}
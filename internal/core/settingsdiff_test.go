@@ -0,0 +1,87 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestDiffBuildSettingsAddedRemovedChanged(t *testing.T) {
+	base := BuildSettings{
+		"SWIFT_OPTIMIZATION_LEVEL": "-Onone",
+		"DEBUG_INFORMATION_FORMAT": "dwarf",
+		"ENABLE_TESTABILITY":       "YES",
+		"PRODUCT_NAME":             "App",
+	}
+	other := BuildSettings{
+		"SWIFT_OPTIMIZATION_LEVEL": "-O",
+		"DEBUG_INFORMATION_FORMAT": "dwarf-with-dsym",
+		"PRODUCT_NAME":             "App",
+		"VALIDATE_PRODUCT":         "YES",
+	}
+	diff := diffBuildSettings(base, other, nil)
+	if len(diff.Changed) != 2 || diff.Changed[0].Key != "DEBUG_INFORMATION_FORMAT" || diff.Changed[1].To != "-O" {
+		t.Fatalf("unexpected changed: %+v", diff.Changed)
+	}
+	if len(diff.Added) != 1 || diff.Added[0] != (BuildSettingChange{Key: "VALIDATE_PRODUCT", To: "YES"}) {
+		t.Fatalf("unexpected added: %+v", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0] != (BuildSettingChange{Key: "ENABLE_TESTABILITY", From: "YES"}) {
+		t.Fatalf("unexpected removed: %+v", diff.Removed)
+	}
+}
+
+func TestDiffBuildSettingsCollapsesDerivedDataPaths(t *testing.T) {
+	base := BuildSettings{
+		"OBJROOT":          "/p/.xcbolt/DerivedData/ios-simulator/Build/Intermediates.noindex",
+		"BUILD_DIR":        "/Users/me/Library/Developer/Xcode/DerivedData/App-abcdefghijklmnopqrstuvwxyzab/Build/Products",
+		"TARGET_BUILD_DIR": "/p/.xcbolt/DerivedData/ios-simulator/Build/Products/Debug-iphonesimulator",
+	}
+	other := BuildSettings{
+		"OBJROOT":          "/p/.xcbolt/DerivedData/ios-device/Build/Intermediates.noindex",
+		"BUILD_DIR":        "/Users/me/Library/Developer/Xcode/DerivedData/App-zyxwvutsrqponmlkjihgfedcbazy/Build/Products",
+		"TARGET_BUILD_DIR": "/p/.xcbolt/DerivedData/ios-device/Build/Products/Debug-iphoneos",
+	}
+	dirs := []string{"/p/.xcbolt/DerivedData/ios-simulator", "/p/.xcbolt/DerivedData/ios-device"}
+	diff := diffBuildSettings(base, other, dirs)
+	if diff.PathOnly != 2 {
+		t.Fatalf("expected 2 path-only differences, got %d (%+v)", diff.PathOnly, diff.Changed)
+	}
+	if len(diff.Changed) != 1 || diff.Changed[0].Key != "TARGET_BUILD_DIR" {
+		t.Fatalf("expected the real TARGET_BUILD_DIR difference, got %+v", diff.Changed)
+	}
+	if !strings.HasSuffix(diff.Changed[0].To, "Debug-iphoneos") {
+		t.Fatalf("changed values should stay unnormalized: %+v", diff.Changed[0])
+	}
+}
+
+func TestFormatBuildSettingsDiffAlignsColumns(t *testing.T) {
+	diff := BuildSettingsDiff{
+		Base:     "Debug",
+		Other:    "Release",
+		Changed:  []BuildSettingChange{{Key: "SWIFT_OPTIMIZATION_LEVEL", From: "-Onone", To: "-O"}, {Key: "ONLY_ACTIVE_ARCH", From: "YES", To: "NO"}},
+		PathOnly: 3,
+	}
+	out := FormatBuildSettingsDiff(diff)
+	if !strings.HasPrefix(out, "Build settings: Debug vs Release\n") {
+		t.Fatalf("missing header:\n%s", out)
+	}
+	var cols []int
+	for _, ln := range strings.Split(out, "\n") {
+		if strings.Contains(ln, "_LEVEL") || strings.Contains(ln, "_ARCH") {
+			cols = append(cols, strings.LastIndex(ln, " ")+1)
+		}
+	}
+	if len(cols) != 2 || cols[0] != cols[1] {
+		t.Fatalf("values not aligned (%v):\n%s", cols, out)
+	}
+	if !strings.Contains(out, "3 more differ only in the DerivedData location") {
+		t.Fatalf("missing path-only note:\n%s", out)
+	}
+}
+
+func TestDiffBuildSettingsRequiresVariant(t *testing.T) {
+	if _, err := DiffBuildSettings(context.Background(), t.TempDir(), Config{}, SettingsVariant{}); err == nil {
+		t.Fatalf("expected an error without a configuration or destination")
+	}
+}
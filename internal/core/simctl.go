@@ -50,11 +50,12 @@ type Simulator struct {
 	Available      bool           `json:"available"`
 }
 
-func SimctlList(ctx context.Context, emit Emitter) (simctlListJSON, error) {
+func SimctlList(ctx context.Context, emit Emitter, preserveLocale bool) (simctlListJSON, error) {
 	var out strings.Builder
 	_, err := RunStreaming(ctx, CmdSpec{
-		Path: "xcrun",
-		Args: []string{"simctl", "list", "--json"},
+		Path:           "xcrun",
+		Args:           []string{"simctl", "list", "--json"},
+		PreserveLocale: preserveLocale,
 		StdoutLine: func(s string) {
 			out.WriteString(s)
 			out.WriteString("\n")
@@ -102,19 +103,36 @@ func FlattenSimulators(list simctlListJSON) []Simulator {
 			})
 		}
 	}
+	// By platform, then OS version (numerically: iOS 17.10 after 17.5)
 	sort.Slice(out, func(i, j int) bool {
 		if out[i].RuntimeName == out[j].RuntimeName {
 			return out[i].Name < out[j].Name
 		}
+		pi, pj := runtimePlatform(out[i].RuntimeName), runtimePlatform(out[j].RuntimeName)
+		if pi != pj {
+			return pi < pj
+		}
+		if c := CompareOSVersions(out[i].OSVersion, out[j].OSVersion); c != 0 {
+			return c < 0
+		}
 		return out[i].RuntimeName < out[j].RuntimeName
 	})
 	return out
 }
 
-func SimctlBoot(ctx context.Context, udid string) error {
+// runtimePlatform is a runtime name without its version ("iOS 17.5" → "iOS")
+func runtimePlatform(runtimeName string) string {
+	if i := osVersionRE.FindStringIndex(runtimeName); i != nil {
+		return strings.TrimSpace(runtimeName[:i[0]])
+	}
+	return runtimeName
+}
+
+func SimctlBoot(ctx context.Context, udid string, preserveLocale bool) error {
 	_, err := RunStreaming(ctx, CmdSpec{
-		Path: "xcrun",
-		Args: []string{"simctl", "boot", udid},
+		Path:           "xcrun",
+		Args:           []string{"simctl", "boot", udid},
+		PreserveLocale: preserveLocale,
 	})
 	// boot returns error if already booted; ignore common cases
 	if err != nil && strings.Contains(err.Error(), "Unable") {
@@ -123,26 +141,29 @@ func SimctlBoot(ctx context.Context, udid string) error {
 	return err
 }
 
-func SimctlBootStatus(ctx context.Context, udid string) error {
+func SimctlBootStatus(ctx context.Context, udid string, preserveLocale bool) error {
 	_, err := RunStreaming(ctx, CmdSpec{
-		Path: "xcrun",
-		Args: []string{"simctl", "bootstatus", udid, "-b"},
+		Path:           "xcrun",
+		Args:           []string{"simctl", "bootstatus", udid, "-b"},
+		PreserveLocale: preserveLocale,
 	})
 	return err
 }
 
-func SimctlShutdown(ctx context.Context, udid string) error {
+func SimctlShutdown(ctx context.Context, udid string, preserveLocale bool) error {
 	_, err := RunStreaming(ctx, CmdSpec{
-		Path: "xcrun",
-		Args: []string{"simctl", "shutdown", udid},
+		Path:           "xcrun",
+		Args:           []string{"simctl", "shutdown", udid},
+		PreserveLocale: preserveLocale,
 	})
 	return err
 }
 
-func SimctlErase(ctx context.Context, udid string) error {
+func SimctlErase(ctx context.Context, udid string, preserveLocale bool) error {
 	_, err := RunStreaming(ctx, CmdSpec{
-		Path: "xcrun",
-		Args: []string{"simctl", "erase", udid},
+		Path:           "xcrun",
+		Args:           []string{"simctl", "erase", udid},
+		PreserveLocale: preserveLocale,
 	})
 	return err
 }
@@ -153,16 +174,17 @@ func SimctlOpenSimulatorApp(ctx context.Context) error {
 	return err
 }
 
-func SimctlOpenURL(ctx context.Context, udid string, url string) error {
+func SimctlOpenURL(ctx context.Context, udid string, url string, preserveLocale bool) error {
 	_, err := RunStreaming(ctx, CmdSpec{
-		Path: "xcrun",
-		Args: []string{"simctl", "openurl", udid, url},
+		Path:           "xcrun",
+		Args:           []string{"simctl", "openurl", udid, url},
+		PreserveLocale: preserveLocale,
 	})
 	return err
 }
 
 // SimctlLogStream streams unified logs for a simulator.
-func SimctlLogStream(ctx context.Context, udid string, predicate string, emit Emitter) error {
+func SimctlLogStream(ctx context.Context, udid string, predicate string, emit Emitter, preserveLocale bool) error {
 	if udid == "" {
 		return errors.New("missing simulator udid")
 	}
@@ -171,8 +193,9 @@ func SimctlLogStream(ctx context.Context, udid string, predicate string, emit Em
 		args = append(args, "--predicate", predicate)
 	}
 	_, err := RunStreaming(ctx, CmdSpec{
-		Path: "xcrun",
-		Args: args,
+		Path:           "xcrun",
+		Args:           args,
+		PreserveLocale: preserveLocale,
 		StdoutLine: func(s string) {
 			if emit != nil {
 				if isSimctlLogHeader(s) {
@@ -212,7 +235,7 @@ func isSimctlLogHeader(line string) bool {
 	return strings.Contains(l, "filtering the log data using")
 }
 
-func SimctlScreenshot(ctx context.Context, udid string, outPath string) error {
+func SimctlScreenshot(ctx context.Context, udid string, outPath string, preserveLocale bool) error {
 	if outPath == "" {
 		return errors.New("missing output path")
 	}
@@ -220,37 +243,100 @@ func SimctlScreenshot(ctx context.Context, udid string, outPath string) error {
 		return err
 	}
 	_, err := RunStreaming(ctx, CmdSpec{
-		Path: "xcrun",
-		Args: []string{"simctl", "io", udid, "screenshot", outPath},
+		Path:           "xcrun",
+		Args:           []string{"simctl", "io", udid, "screenshot", outPath},
+		PreserveLocale: preserveLocale,
 	})
 	return err
 }
 
-func SimctlCreate(ctx context.Context, name, deviceTypeID, runtimeID string) (string, error) {
+func SimctlCreate(ctx context.Context, name, deviceTypeID, runtimeID string, preserveLocale bool) (string, error) {
 	if name == "" || deviceTypeID == "" || runtimeID == "" {
 		return "", fmt.Errorf("name, deviceTypeId, runtimeId are required")
 	}
 	var out strings.Builder
 	_, err := RunStreaming(ctx, CmdSpec{
-		Path:       "xcrun",
-		Args:       []string{"simctl", "create", name, deviceTypeID, runtimeID},
-		StdoutLine: func(s string) { out.WriteString(s) },
+		Path:           "xcrun",
+		Args:           []string{"simctl", "create", name, deviceTypeID, runtimeID},
+		PreserveLocale: preserveLocale,
+		StdoutLine:     func(s string) { out.WriteString(s) },
 	})
 	return strings.TrimSpace(out.String()), err
 }
 
-func SimctlDelete(ctx context.Context, udid string) error {
+func SimctlDelete(ctx context.Context, udid string, preserveLocale bool) error {
 	_, err := RunStreaming(ctx, CmdSpec{
-		Path: "xcrun",
-		Args: []string{"simctl", "delete", udid},
+		Path:           "xcrun",
+		Args:           []string{"simctl", "delete", udid},
+		PreserveLocale: preserveLocale,
 	})
 	return err
 }
 
-func SimctlPrune(ctx context.Context) error {
+func SimctlPrune(ctx context.Context, preserveLocale bool) error {
 	_, err := RunStreaming(ctx, CmdSpec{
-		Path: "xcrun",
-		Args: []string{"simctl", "delete", "unavailable"},
+		Path:           "xcrun",
+		Args:           []string{"simctl", "delete", "unavailable"},
+		PreserveLocale: preserveLocale,
 	})
 	return err
 }
+
+// simctlOutput runs a simctl subcommand and returns its stdout. On failure the
+// error carries simctl's stderr, which says why (e.g. app not installed).
+func simctlOutput(ctx context.Context, preserveLocale bool, args ...string) (string, error) {
+	var out, errOut strings.Builder
+	_, err := RunStreaming(ctx, CmdSpec{
+		Path:           "xcrun",
+		Args:           append([]string{"simctl"}, args...),
+		PreserveLocale: preserveLocale,
+		StdoutLine:     func(s string) { out.WriteString(s + "\n") },
+		StderrLine:     func(s string) { errOut.WriteString(s + "\n") },
+	})
+	if err != nil {
+		if detail := strings.TrimSpace(errOut.String()); detail != "" {
+			return "", fmt.Errorf("%w: %s", err, detail)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(out.String()), nil
+}
+
+// SimctlAppContainer returns the path of an installed app's container
+// ("app", "data", "groups", or an app group identifier).
+func SimctlAppContainer(ctx context.Context, udid, bundleID, container string, preserveLocale bool) (string, error) {
+	if udid == "" || bundleID == "" {
+		return "", errors.New("simulator udid and bundle id are required")
+	}
+	if container == "" {
+		container = "data"
+	}
+	return simctlOutput(ctx, preserveLocale, "get_app_container", udid, bundleID, container)
+}
+
+// SimctlResetAppData wipes an app's data on a simulator. With a built app it
+// uninstalls and reinstalls (clearing the data container); without one it
+// can only reset privacy permissions. It returns a short description of what
+// was reset.
+func SimctlResetAppData(ctx context.Context, udid, bundleID, appPath string, preserveLocale bool) (string, error) {
+	if udid == "" || bundleID == "" {
+		return "", errors.New("simulator udid and bundle id are required")
+	}
+	_, _ = simctlOutput(ctx, preserveLocale, "terminate", udid, bundleID)
+	if appPath == "" {
+		if _, err := simctlOutput(ctx, preserveLocale, "privacy", udid, "reset", "all", bundleID); err != nil {
+			return "", err
+		}
+		return "reset privacy permissions (no built app to reinstall)", nil
+	}
+	if _, err := os.Stat(appPath); err != nil {
+		return "", fmt.Errorf("built app not found: %w", err)
+	}
+	if _, err := simctlOutput(ctx, preserveLocale, "uninstall", udid, bundleID); err != nil {
+		return "", err
+	}
+	if _, err := simctlOutput(ctx, preserveLocale, "install", udid, appPath); err != nil {
+		return "", fmt.Errorf("uninstalled but reinstall failed: %w", err)
+	}
+	return "reinstalled with empty data", nil
+}
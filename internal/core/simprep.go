@@ -0,0 +1,171 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// simulatorPrepRestoreTimeout bounds restoring the simulator after tests,
+// which runs even when the tests were canceled.
+const simulatorPrepRestoreTimeout = 15 * time.Second
+
+// statusBarOverrideArgs is the clean status bar of App Store screenshots.
+var statusBarOverrideArgs = []string{
+	"--time", "9:41",
+	"--dataNetwork", "wifi", "--wifiMode", "active", "--wifiBars", "3",
+	"--cellularMode", "active", "--cellularBars", "4",
+	"--batteryState", "charged", "--batteryLevel", "100",
+}
+
+// simulatorPrepTestArgs are the xcodebuild test arguments for the prep's
+// language and region. xcodebuild launches the app under test with
+// -AppleLanguages and -AppleLocale from them.
+func simulatorPrepTestArgs(prep SimulatorPrepConfig) []string {
+	var args []string
+	if lang := prepLanguage(prep); lang != "" {
+		args = append(args, "-testLanguage", lang)
+	}
+	if region := prepRegion(prep.Locale); region != "" {
+		args = append(args, "-testRegion", region)
+	}
+	return args
+}
+
+// simulatorPrepEnv passes the language and locale to the test runner
+// (TEST_RUNNER_ variables), for UI tests that launch the app with their own
+// arguments.
+func simulatorPrepEnv(prep SimulatorPrepConfig) map[string]string {
+	env := map[string]string{}
+	if lang := prepLanguage(prep); lang != "" {
+		env["TEST_RUNNER_AppleLanguages"] = "(" + lang + ")"
+	}
+	if locale := strings.ReplaceAll(strings.TrimSpace(prep.Locale), "-", "_"); locale != "" {
+		env["TEST_RUNNER_AppleLocale"] = locale
+	}
+	return env
+}
+
+// prepLanguage is the configured language, else the locale's language
+// ("de_DE" → "de")
+func prepLanguage(prep SimulatorPrepConfig) string {
+	if lang := strings.TrimSpace(prep.Language); lang != "" {
+		return lang
+	}
+	lang, _, _ := strings.Cut(strings.ReplaceAll(strings.TrimSpace(prep.Locale), "-", "_"), "_")
+	return lang
+}
+
+// prepRegion is a locale's region ("de_DE" → "DE")
+func prepRegion(locale string) string {
+	_, region, ok := strings.Cut(strings.ReplaceAll(strings.TrimSpace(locale), "-", "_"), "_")
+	if !ok {
+		return ""
+	}
+	return strings.ToUpper(region)
+}
+
+// withEnv returns base with extra added (base is not modified)
+func withEnv(base, extra map[string]string) map[string]string {
+	if len(extra) == 0 {
+		return base
+	}
+	out := make(map[string]string, len(base)+len(extra))
+	for k, v := range base {
+		out[k] = v
+	}
+	for k, v := range extra {
+		out[k] = v
+	}
+	return out
+}
+
+// prepareSimulator boots the test simulator and applies test.simulatorPrep,
+// emitting a Status per step. The returned func restores the appearance and
+// status bar. A failed step warns, or with prep.Required fails the prep.
+func prepareSimulator(ctx context.Context, cfg Config, emit Emitter) (func(), error) {
+	prep := cfg.Test.SimulatorPrep
+	if !prep.Enabled() {
+		return func() {}, nil
+	}
+	if cfg.Destination.Kind != DestSimulator || cfg.Destination.UDID == "" {
+		emitMaybe(emit, Warn("test", "test.simulatorPrep applies only to simulator destinations; skipped"))
+		return func() {}, nil
+	}
+	udid := cfg.Destination.UDID
+	var restores []func(context.Context) error
+	restore := func() {
+		if len(restores) == 0 {
+			return
+		}
+		// The test context may be canceled; restoring still has to happen.
+		rctx, cancel := context.WithTimeout(context.Background(), simulatorPrepRestoreTimeout)
+		defer cancel()
+		emitMaybe(emit, Status("test", "Restoring simulator", nil))
+		for i := len(restores) - 1; i >= 0; i-- {
+			if err := restores[i](rctx); err != nil {
+				emitMaybe(emit, Warn("test", "Could not restore simulator: "+err.Error()))
+			}
+		}
+	}
+	stepFailed := func(step string, err error) error {
+		if prep.Required {
+			restore()
+			return fmt.Errorf("simulator prep (%s) failed: %w", step, err)
+		}
+		emitMaybe(emit, Warn("test", fmt.Sprintf("Simulator prep (%s) failed: %v", step, err)))
+		return nil
+	}
+
+	emitMaybe(emit, Status("test", "Booting simulator", map[string]any{"udid": udid}))
+	if err := BootSimulator(ctx, cfg, "test", udid, emit); err != nil {
+		if errors.Is(err, ErrSimRuntimeBroken) {
+			// Tests can't run on this simulator either
+			return func() {}, err
+		}
+		if err := stepFailed("boot", err); err != nil {
+			return func() {}, err
+		}
+	}
+
+	if appearance := strings.ToLower(strings.TrimSpace(prep.Appearance)); appearance != "" {
+		emitMaybe(emit, Status("test", "Simulator appearance: "+appearance, nil))
+		previous, _ := simctlOutput(ctx, cfg.Xcodebuild.PreserveLocale, "ui", udid, "appearance")
+		if _, err := simctlOutput(ctx, cfg.Xcodebuild.PreserveLocale, "ui", udid, "appearance", appearance); err != nil {
+			if err := stepFailed("appearance", err); err != nil {
+				return func() {}, err
+			}
+		} else if previous != "" && previous != appearance && previous != "unsupported" {
+			restores = append(restores, func(ctx context.Context) error {
+				_, err := simctlOutput(ctx, cfg.Xcodebuild.PreserveLocale, "ui", udid, "appearance", previous)
+				return err
+			})
+		}
+	}
+
+	if lang := prepLanguage(prep); lang != "" {
+		msg := "Test language: " + lang
+		if region := prepRegion(prep.Locale); region != "" {
+			msg += ", region: " + region
+		}
+		emitMaybe(emit, Status("test", msg, nil))
+	}
+
+	if prep.StatusBarOverrides {
+		emitMaybe(emit, Status("test", "Overriding simulator status bar", nil))
+		args := append([]string{"status_bar", udid, "override"}, statusBarOverrideArgs...)
+		if _, err := simctlOutput(ctx, cfg.Xcodebuild.PreserveLocale, args...); err != nil {
+			if err := stepFailed("status bar", err); err != nil {
+				return func() {}, err
+			}
+		} else {
+			restores = append(restores, func(ctx context.Context) error {
+				_, err := simctlOutput(ctx, cfg.Xcodebuild.PreserveLocale, "status_bar", udid, "clear")
+				return err
+			})
+		}
+	}
+	return restore, nil
+}
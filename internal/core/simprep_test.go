@@ -0,0 +1,137 @@
+package core
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fakeSimctl puts an xcrun on PATH that logs its arguments, reports a light
+// appearance, and fails the commands matching failPattern
+func fakeSimctl(t *testing.T, failPattern string) string {
+	t.Helper()
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "calls.log")
+	script := `#!/bin/sh
+echo "$*" >> "` + logPath + `"
+case "$*" in
+` + failPattern + `) echo "failed" >&2; exit 1 ;;
+"simctl ui SIM-1 appearance") echo light ;;
+esac
+`
+	if err := os.WriteFile(filepath.Join(dir, "xcrun"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir)
+	return logPath
+}
+
+func simctlCalls(t *testing.T, logPath string) []string {
+	t.Helper()
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Split(strings.TrimSpace(string(data)), "\n")
+}
+
+func prepConfig(prep SimulatorPrepConfig) Config {
+	return Config{
+		Destination: Destination{Kind: DestSimulator, UDID: "SIM-1"},
+		Test:        TestConfig{SimulatorPrep: prep},
+	}
+}
+
+func TestPrepareSimulatorAppliesAndRestores(t *testing.T) {
+	logPath := fakeSimctl(t, "no-match")
+	rec := &recordingEmitter{}
+	cfg := prepConfig(SimulatorPrepConfig{Appearance: "Dark", Locale: "de_DE", StatusBarOverrides: true})
+
+	restore, err := prepareSimulator(context.Background(), cfg, rec)
+	if err != nil {
+		t.Fatalf("prepareSimulator: %v", err)
+	}
+	restore()
+
+	calls := simctlCalls(t, logPath)
+	want := []string{
+		"simctl boot SIM-1",
+		"simctl bootstatus SIM-1 -b",
+		"simctl ui SIM-1 appearance",
+		"simctl ui SIM-1 appearance dark",
+		"simctl status_bar SIM-1 override " + strings.Join(statusBarOverrideArgs, " "),
+		"simctl status_bar SIM-1 clear",
+		"simctl ui SIM-1 appearance light",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Fatalf("calls:\n%s\nwant:\n%s", strings.Join(calls, "\n"), strings.Join(want, "\n"))
+	}
+	var statuses []string
+	for _, ev := range rec.events {
+		if ev.Type == "warning" {
+			t.Fatalf("unexpected warning: %s", ev.Msg)
+		}
+		statuses = append(statuses, ev.Msg)
+	}
+	if got := strings.Join(statuses, "|"); !strings.Contains(got, "Simulator appearance: dark|Test language: de, region: DE|Overriding simulator status bar|Restoring simulator") {
+		t.Fatalf("statuses = %s", got)
+	}
+}
+
+func TestPrepareSimulatorWarnsOrFails(t *testing.T) {
+	fakeSimctl(t, "*appearance\\ dark")
+	rec := &recordingEmitter{}
+	cfg := prepConfig(SimulatorPrepConfig{Appearance: "dark", StatusBarOverrides: true})
+	restore, err := prepareSimulator(context.Background(), cfg, rec)
+	if err != nil {
+		t.Fatalf("optional prep failed the tests: %v", err)
+	}
+	restore()
+	warned := false
+	for _, ev := range rec.events {
+		warned = warned || (ev.Type == "warning" && strings.Contains(ev.Msg, "appearance"))
+	}
+	if !warned {
+		t.Fatalf("no warning for the failed appearance step: %+v", rec.events)
+	}
+
+	logPath := fakeSimctl(t, "*appearance\\ dark")
+	cfg.Test.SimulatorPrep = SimulatorPrepConfig{StatusBarOverrides: true, Appearance: "dark", Required: true}
+	if _, err := prepareSimulator(context.Background(), cfg, &recordingEmitter{}); err == nil {
+		t.Fatalf("required prep did not fail")
+	}
+	for _, call := range simctlCalls(t, logPath) {
+		if strings.Contains(call, "status_bar") {
+			t.Fatalf("prep went on after a required step failed: %s", call)
+		}
+	}
+}
+
+func TestPrepareSimulatorSkipsOtherDestinations(t *testing.T) {
+	rec := &recordingEmitter{}
+	cfg := prepConfig(SimulatorPrepConfig{Appearance: "dark"})
+	cfg.Destination = Destination{Kind: DestDevice, UDID: "PHONE-1"}
+	if _, err := prepareSimulator(context.Background(), cfg, rec); err != nil {
+		t.Fatal(err)
+	}
+	if len(rec.events) != 1 || rec.events[0].Type != "warning" {
+		t.Fatalf("events = %+v, want one warning", rec.events)
+	}
+}
+
+func TestSimulatorPrepLanguageArgs(t *testing.T) {
+	prep := SimulatorPrepConfig{Locale: "de-AT"}
+	if got, want := simulatorPrepTestArgs(prep), []string{"-testLanguage", "de", "-testRegion", "AT"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("args = %v, want %v", got, want)
+	}
+	env := simulatorPrepEnv(SimulatorPrepConfig{Language: "en", Locale: "de_DE"})
+	if env["TEST_RUNNER_AppleLanguages"] != "(en)" || env["TEST_RUNNER_AppleLocale"] != "de_DE" {
+		t.Fatalf("env = %v", env)
+	}
+	if args := simulatorPrepTestArgs(SimulatorPrepConfig{Appearance: "dark"}); args != nil {
+		t.Fatalf("args without a language = %v", args)
+	}
+}
@@ -0,0 +1,124 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrSimRuntimeBroken means simulators can't boot until CoreSimulator is
+// restarted (or the Mac rebooted).
+var ErrSimRuntimeBroken = errors.New("simulator runtime is broken")
+
+// simRecoveryWait is how long CoreSimulator gets to come back after it was
+// killed
+var simRecoveryWait = 5 * time.Second
+
+// Boot errors from a wedged CoreSimulator, lowercased. They persist until
+// CoreSimulatorService restarts.
+var simRuntimeBrokenPatterns = []string{
+	"unable to boot device",
+	"invalid device state",
+	"unable to boot the simulator",
+	"failed to start launchd_sim",
+	"coresimulatorservice connection became invalid",
+	"connection to coresimulatorservice",
+	"coresimulator.framework was changed while the process was running",
+}
+
+// isSimRuntimeBroken reports whether simctl output says the simulator
+// runtime is broken. "Unable to boot device in current state: Booted" only
+// means the simulator is already running.
+func isSimRuntimeBroken(output string) bool {
+	l := strings.ToLower(output)
+	if strings.Contains(l, "current state: booted") {
+		return false
+	}
+	for _, p := range simRuntimeBrokenPatterns {
+		if strings.Contains(l, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// bootAndWait boots a simulator and waits until it's ready. The error
+// carries simctl's stderr. booted is false when the simulator was already
+// running.
+func bootAndWait(ctx context.Context, udid string, preserveLocale bool) (booted bool, err error) {
+	booted = true
+	if _, err := simctlOutput(ctx, preserveLocale, "boot", udid); err != nil {
+		if isSimRuntimeBroken(err.Error()) {
+			return false, err
+		}
+		booted = !strings.Contains(strings.ToLower(err.Error()), "current state: booted")
+	}
+	// Other boot errors show up in bootstatus
+	_, err = simctlOutput(ctx, preserveLocale, "bootstatus", udid, "-b")
+	return booted && err == nil, err
+}
+
+// BootSimulator boots a simulator for cmd and waits until it's ready. When
+// the boot fails because the simulator runtime is broken and
+// simulator.autoRecover is on, it restarts CoreSimulator and retries once,
+// emitting a Status per step. A broken runtime emits a SIM_RUNTIME_BROKEN
+// error and returns an error wrapping ErrSimRuntimeBroken.
+func BootSimulator(ctx context.Context, cfg Config, cmd, udid string, emit Emitter) error {
+	_, err := bootSimulator(ctx, cfg, cmd, udid, emit)
+	return err
+}
+
+// bootSimulator is BootSimulator, also reporting whether the simulator had
+// to be booted (false when it was already running).
+func bootSimulator(ctx context.Context, cfg Config, cmd, udid string, emit Emitter) (bool, error) {
+	booted, err := bootAndWait(ctx, udid, cfg.Xcodebuild.PreserveLocale)
+	if err == nil || ctx.Err() != nil || !isSimRuntimeBroken(err.Error()) {
+		return booted, err
+	}
+	if cfg.Simulator.ShouldAutoRecover() {
+		emitMaybe(emit, Warn(cmd, "Simulator failed to boot: "+err.Error()))
+		err = recoverSimRuntime(ctx, cmd, udid, cfg.Xcodebuild.PreserveLocale, emit)
+		if err == nil {
+			emitMaybe(emit, Status(cmd, "Simulator recovered", map[string]any{"udid": udid}))
+			return true, nil
+		}
+		if ctx.Err() != nil {
+			return false, err
+		}
+	}
+	suggestion := "Quit Simulator and reboot the Mac. If it keeps happening, erase or recreate the simulator."
+	if !cfg.Simulator.ShouldAutoRecover() {
+		suggestion = "Run `xcrun simctl shutdown all` and `killall -9 com.apple.CoreSimulator.CoreSimulatorService`, or set simulator.autoRecover to true. If that fails, reboot the Mac."
+	}
+	emitMaybe(emit, Err(cmd, ErrorObject{
+		Code:       "SIM_RUNTIME_BROKEN",
+		Message:    "Simulator runtime is broken",
+		Detail:     err.Error(),
+		Suggestion: suggestion,
+	}))
+	return false, fmt.Errorf("%w: %v", ErrSimRuntimeBroken, err)
+}
+
+// recoverSimRuntime shuts down all simulators, restarts CoreSimulatorService,
+// and boots udid again
+func recoverSimRuntime(ctx context.Context, cmd, udid string, preserveLocale bool, emit Emitter) error {
+	emitMaybe(emit, Status(cmd, "Recovering simulator: shutting down all simulators", nil))
+	_, _ = simctlOutput(ctx, preserveLocale, "shutdown", "all")
+
+	emitMaybe(emit, Status(cmd, "Recovering simulator: restarting CoreSimulatorService", nil))
+	// Fails when the service isn't running, which is fine
+	_, _ = RunStreaming(ctx, CmdSpec{Path: "killall", Args: []string{"-9", "com.apple.CoreSimulator.CoreSimulatorService"}})
+
+	emitMaybe(emit, Status(cmd, fmt.Sprintf("Recovering simulator: waiting %s for CoreSimulator", simRecoveryWait), nil))
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(simRecoveryWait):
+	}
+
+	emitMaybe(emit, Status(cmd, "Recovering simulator: booting again", map[string]any{"udid": udid}))
+	_, err := bootAndWait(ctx, udid, preserveLocale)
+	return err
+}
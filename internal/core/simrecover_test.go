@@ -0,0 +1,126 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIsSimRuntimeBroken(t *testing.T) {
+	broken := []string{
+		"exit status 149: An error was encountered processing the command (domain=com.apple.CoreSimulator.SimError, code=405):\nUnable to boot device because we cannot determine the runtime bundle.",
+		"Unable to boot device in current state: Creating",
+		"Invalid device state",
+		"exit status 1: Unable to boot the Simulator.",
+		"launchd failed to respond. Failed to start launchd_sim: could not bind to session",
+		"CoreSimulatorService connection became invalid. Simulator services will no longer be available.",
+		"CoreSimulator.framework was changed while the process was running.",
+	}
+	for _, s := range broken {
+		if !isSimRuntimeBroken(s) {
+			t.Fatalf("not detected as broken: %q", s)
+		}
+	}
+	fine := []string{
+		"",
+		"exit status 149: Unable to boot device in current state: Booted",
+		"Invalid device: SIM-UNKNOWN",
+		"exit status 60: timed out",
+	}
+	for _, s := range fine {
+		if isSimRuntimeBroken(s) {
+			t.Fatalf("detected as broken: %q", s)
+		}
+	}
+}
+
+// fakeBrokenRuntime puts xcrun and killall on PATH. Boots fail with a
+// broken-runtime error until killall runs, or always with stuck.
+func fakeBrokenRuntime(t *testing.T, stuck bool) string {
+	t.Helper()
+	wait := simRecoveryWait
+	simRecoveryWait = 0
+	t.Cleanup(func() { simRecoveryWait = wait })
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "calls.log")
+	restarted := filepath.Join(dir, "restarted")
+	if stuck {
+		restarted = filepath.Join(dir, "never")
+	}
+	xcrun := `#!/bin/sh
+echo "$*" >> "` + logPath + `"
+case "$1 $2" in
+"simctl boot"|"simctl bootstatus")
+	if [ ! -f "` + restarted + `" ]; then echo "Unable to boot device because it cannot be located on disk." >&2; exit 149; fi ;;
+esac
+`
+	killall := `#!/bin/sh
+echo "killall $*" >> "` + logPath + `"
+: > "` + filepath.Join(dir, "restarted") + `"
+`
+	for name, script := range map[string]string{"xcrun": xcrun, "killall": killall} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(script), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("PATH", dir)
+	return logPath
+}
+
+func TestBootSimulatorRecoversBrokenRuntime(t *testing.T) {
+	logPath := fakeBrokenRuntime(t, false)
+	rec := &recordingEmitter{}
+	if err := BootSimulator(context.Background(), Config{}, "run", "SIM-1", rec); err != nil {
+		t.Fatalf("BootSimulator: %v", err)
+	}
+	calls := simctlCalls(t, logPath)
+	want := []string{
+		"simctl boot SIM-1",
+		"simctl shutdown all",
+		"killall -9 com.apple.CoreSimulator.CoreSimulatorService",
+		"simctl boot SIM-1",
+		"simctl bootstatus SIM-1 -b",
+	}
+	if strings.Join(calls, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("calls:\n%s\nwant:\n%s", strings.Join(calls, "\n"), strings.Join(want, "\n"))
+	}
+	var statuses int
+	for _, ev := range rec.events {
+		if ev.Type == "status" && strings.HasPrefix(ev.Msg, "Recovering simulator") {
+			statuses++
+		}
+	}
+	if statuses != 4 {
+		t.Fatalf("recovery statuses = %d, want 4: %+v", statuses, rec.events)
+	}
+}
+
+func TestBootSimulatorReportsBrokenRuntime(t *testing.T) {
+	logPath := fakeBrokenRuntime(t, true)
+	rec := &recordingEmitter{}
+	err := BootSimulator(context.Background(), Config{}, "run", "SIM-1", rec)
+	if !errors.Is(err, ErrSimRuntimeBroken) {
+		t.Fatalf("err = %v, want ErrSimRuntimeBroken", err)
+	}
+	last := rec.events[len(rec.events)-1]
+	if obj := last.Err; obj == nil || obj.Code != "SIM_RUNTIME_BROKEN" || !strings.Contains(obj.Suggestion, "reboot") {
+		t.Fatalf("last event = %+v", last)
+	}
+	if n := strings.Count(strings.Join(simctlCalls(t, logPath), "\n"), "killall"); n != 1 {
+		t.Fatalf("recovery ran %d times, want once", n)
+	}
+
+	// simulator.autoRecover off skips the recovery
+	logPath = fakeBrokenRuntime(t, true)
+	off := false
+	cfg := Config{Simulator: SimulatorConfig{AutoRecover: &off}}
+	if err := BootSimulator(context.Background(), cfg, "run", "SIM-1", &recordingEmitter{}); !errors.Is(err, ErrSimRuntimeBroken) {
+		t.Fatalf("err = %v, want ErrSimRuntimeBroken", err)
+	}
+	if calls := simctlCalls(t, logPath); len(calls) != 1 {
+		t.Fatalf("calls with autoRecover off = %v", calls)
+	}
+}
@@ -7,7 +7,7 @@ import (
 	"path/filepath"
 )
 
-const StateVersion = 2
+const StateVersion = 3
 
 // RecentProject tracks a recently used project
 type RecentProject struct {
@@ -27,6 +27,27 @@ type RecentCombo struct {
 	UsedAt      string `json:"usedAt"`
 }
 
+// LastOp records an operation started from the TUI so it can be replayed
+type LastOp struct {
+	Name        string      `json:"name"`
+	OnlyTesting []string    `json:"onlyTesting,omitempty"`
+	SkipTesting []string    `json:"skipTesting,omitempty"`
+	Destination Destination `json:"destination"`
+	StartedAt   string      `json:"startedAt"`
+	// LaunchOnly runs the last built app without rebuilding
+	LaunchOnly bool `json:"launchOnly,omitempty"`
+	// SkipInstall (with LaunchOnly) only relaunches the app on a device
+	SkipInstall bool `json:"skipInstall,omitempty"`
+	// Repeat runs the tests this many times (stress mode)
+	Repeat int `json:"repeat,omitempty"`
+	// Preset is the launch preset picked for this run only
+	// (LaunchPresetNone for none)
+	Preset string `json:"preset,omitempty"`
+	// Target builds this target instead of the scheme (Config.TargetOverride)
+	Target        string `json:"target,omitempty"`
+	TargetProject string `json:"targetProject,omitempty"`
+}
+
 // State persists user preferences across sessions
 type State struct {
 	Version int             `json:"version"`
@@ -34,10 +55,35 @@ type State struct {
 
 	// Per-project recent combos, keyed by project root
 	Combos map[string][]RecentCombo `json:"combos,omitempty"`
+
+	// Per-project last operation, keyed by project root
+	LastOps map[string]LastOp `json:"lastOps,omitempty"`
+
+	// Duration history, keyed by HistoryKey (added in v3)
+	History map[string][]DurationEntry `json:"history,omitempty"`
+
+	// Per-project build history with compiled file counts, keyed by project root
+	Builds map[string][]BuildRecord `json:"builds,omitempty"`
+
+	// Per-project time of a DerivedData clean not yet followed by a build
+	Cleans map[string]string `json:"cleans,omitempty"`
+
+	// Per-project warning category shown on the Issues tab ("" = all)
+	IssueFilters map[string]string `json:"issueFilters,omitempty"`
+
+	// Destination last used with each scheme, keyed by SchemeKey
+	SchemeDestinations map[string]Destination `json:"schemeDestinations,omitempty"`
+
+	// Failing test keys (see TestKey) of the last test run of each scheme,
+	// keyed by SchemeKey
+	TestFailures map[string][]string `json:"testFailures,omitempty"`
 }
 
 const MaxRecentCombos = 5
 
+// MaxRecentProjects caps the project roots kept in State.Recent
+const MaxRecentProjects = 15
+
 func defaultState() State { return State{Version: StateVersion, Recent: []RecentProject{}} }
 
 func UserStatePath() (string, error) {
@@ -64,10 +110,22 @@ func LoadState() (State, error) {
 	if err := json.Unmarshal(b, &st); err != nil {
 		return st, err
 	}
+	migrateState(&st)
+	return st, nil
+}
+
+// migrateState upgrades older state files in place.
+func migrateState(st *State) {
 	if st.Version == 0 {
 		st.Version = StateVersion
 	}
-	return st, nil
+	if st.Version < 3 {
+		// v2 -> v3: duration history added.
+		if st.History == nil {
+			st.History = map[string][]DurationEntry{}
+		}
+		st.Version = 3
+	}
 }
 
 func SaveState(st State) error {
@@ -114,6 +172,22 @@ func (st *State) AddRecentCombo(projectRoot string, combo RecentCombo) {
 	st.Combos[projectRoot] = filtered
 }
 
+// AddRecentProject moves a project root to the front of the recent
+// projects, replacing its previous entry
+func (st *State) AddRecentProject(p RecentProject) {
+	recent := make([]RecentProject, 0, len(st.Recent)+1)
+	recent = append(recent, p)
+	for _, r := range st.Recent {
+		if r.Root != p.Root {
+			recent = append(recent, r)
+		}
+	}
+	if len(recent) > MaxRecentProjects {
+		recent = recent[:MaxRecentProjects]
+	}
+	st.Recent = recent
+}
+
 // GetRecentCombos returns recent combos for a project
 func (st *State) GetRecentCombos(projectRoot string) []RecentCombo {
 	if st.Combos == nil {
@@ -121,3 +195,62 @@ func (st *State) GetRecentCombos(projectRoot string) []RecentCombo {
 	}
 	return st.Combos[projectRoot]
 }
+
+// SetLastOp records the last operation for a project
+func (st *State) SetLastOp(projectRoot string, op LastOp) {
+	if st.LastOps == nil {
+		st.LastOps = make(map[string]LastOp)
+	}
+	st.LastOps[projectRoot] = op
+}
+
+// SetIssueFilter records the Issues tab warning category of a project
+// ("" clears it)
+func (st *State) SetIssueFilter(projectRoot, category string) {
+	if category == "" {
+		delete(st.IssueFilters, projectRoot)
+		return
+	}
+	if st.IssueFilters == nil {
+		st.IssueFilters = make(map[string]string)
+	}
+	st.IssueFilters[projectRoot] = category
+}
+
+// IssueFilter returns the Issues tab warning category of a project
+func (st *State) IssueFilter(projectRoot string) string {
+	return st.IssueFilters[projectRoot]
+}
+
+// SchemeKey keys per-scheme state: the project root and the scheme name
+func SchemeKey(projectRoot, scheme string) string {
+	return projectRoot + "#" + scheme
+}
+
+// SetSchemeDestination remembers dst as the destination last used with a
+// project's scheme. Unresolved (auto) destinations are not remembered.
+func (st *State) SetSchemeDestination(projectRoot, scheme string, dst Destination) {
+	if scheme == "" || !dst.IsResolved() {
+		return
+	}
+	if st.SchemeDestinations == nil {
+		st.SchemeDestinations = make(map[string]Destination)
+	}
+	st.SchemeDestinations[SchemeKey(projectRoot, scheme)] = dst
+}
+
+// SchemeDestination returns the destination last used with a project's
+// scheme
+func (st *State) SchemeDestination(projectRoot, scheme string) (Destination, bool) {
+	dst, ok := st.SchemeDestinations[SchemeKey(projectRoot, scheme)]
+	return dst, ok
+}
+
+// GetLastOp returns the last operation for a project
+func (st *State) GetLastOp(projectRoot string) (LastOp, bool) {
+	if st.LastOps == nil {
+		return LastOp{}, false
+	}
+	op, ok := st.LastOps[projectRoot]
+	return op, ok && op.Name != ""
+}
@@ -0,0 +1,75 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestStateLastOpRoundTrip(t *testing.T) {
+	var st State
+	if _, ok := st.GetLastOp("/proj"); ok {
+		t.Fatalf("expected no last op on empty state")
+	}
+
+	st.SetLastOp("/proj", LastOp{
+		Name:        "test",
+		OnlyTesting: []string{"AppTests/LoginTests"},
+		Destination: Destination{ID: "SIM-1", Name: "iPhone 16"},
+	})
+
+	b, err := json.Marshal(st)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var got State
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	op, ok := got.GetLastOp("/proj")
+	if !ok || op.Name != "test" || len(op.OnlyTesting) != 1 || op.Destination.ID != "SIM-1" {
+		t.Fatalf("unexpected last op: %+v", op)
+	}
+	if _, ok := got.GetLastOp("/other"); ok {
+		t.Fatalf("last op should be per project")
+	}
+}
+
+func TestStateSchemeDestinations(t *testing.T) {
+	var st State
+	st.SetSchemeDestination("/proj", "App", Destination{Kind: DestAuto, TargetType: TargetAuto})
+	if _, ok := st.SchemeDestination("/proj", "App"); ok {
+		t.Fatal("auto destination was remembered")
+	}
+	st.SetSchemeDestination("/proj", "App", Destination{Kind: DestSimulator, ID: "SIM-A"})
+	st.SetSchemeDestination("/proj", "Helper", Destination{Kind: DestMacOS})
+	if dst, ok := st.SchemeDestination("/proj", "App"); !ok || dst.ID != "SIM-A" {
+		t.Fatalf("App = %+v %v", dst, ok)
+	}
+	if dst, ok := st.SchemeDestination("/proj", "Helper"); !ok || dst.Kind != DestMacOS {
+		t.Fatalf("Helper = %+v %v", dst, ok)
+	}
+	if _, ok := st.SchemeDestination("/other", "App"); ok {
+		t.Fatal("destination leaked to another project")
+	}
+}
+
+func TestStateAddRecentProject(t *testing.T) {
+	var st State
+	for i := 0; i < MaxRecentProjects+3; i++ {
+		st.AddRecentProject(RecentProject{Root: fmt.Sprintf("/proj%d", i)})
+	}
+	st.AddRecentProject(RecentProject{Root: "/proj5", Scheme: "App"})
+	if len(st.Recent) != MaxRecentProjects {
+		t.Fatalf("kept %d projects", len(st.Recent))
+	}
+	if st.Recent[0].Root != "/proj5" || st.Recent[0].Scheme != "App" || st.Recent[1].Root != "/proj17" {
+		t.Fatalf("recent = %+v", st.Recent[:2])
+	}
+	for _, r := range st.Recent[1:] {
+		if r.Root == "/proj5" {
+			t.Fatal("project listed twice")
+		}
+	}
+}
@@ -0,0 +1,261 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// StatusSnapshot is what the status bar shows: served by the TUI's status
+// server and kept in .xcbolt/status.json for `xcbolt status`.
+type StatusSnapshot struct {
+	Project     string `json:"project"`
+	Scheme      string `json:"scheme"`
+	Destination string `json:"destination"`
+	Running     bool   `json:"running"`
+	Op          string `json:"op,omitempty"`
+	// Preset is the launch preset of a running run
+	Preset       string            `json:"preset,omitempty"`
+	Stage        string            `json:"stage,omitempty"`
+	Progress     StatusProgress    `json:"progress"`
+	LastResult   *StatusLastResult `json:"lastResult,omitempty"`
+	ErrorCount   int               `json:"errorCount"`
+	WarningCount int               `json:"warningCount"`
+	// PID is the xcbolt process that wrote the status file
+	PID       int    `json:"pid,omitempty"`
+	UpdatedAt string `json:"updatedAt,omitempty"`
+}
+
+// StatusProgress mirrors the progress bar
+type StatusProgress struct {
+	Current int `json:"current"`
+	Total   int `json:"total"`
+}
+
+// StatusLastResult describes the most recent finished operation
+type StatusLastResult struct {
+	Op       string `json:"op"`
+	Success  bool   `json:"success"`
+	Duration string `json:"duration,omitempty"`
+}
+
+// statusFileInterval throttles status file writes for progress updates;
+// an op starting or finishing is written at once.
+const statusFileInterval = time.Second
+
+func StatusFilePath(projectRoot string) string {
+	return filepath.Join(projectRoot, ".xcbolt", "status.json")
+}
+
+// ReadStatusFile reads the project's status file.
+func ReadStatusFile(projectRoot string) (StatusSnapshot, error) {
+	var snap StatusSnapshot
+	b, err := os.ReadFile(StatusFilePath(projectRoot))
+	if err != nil {
+		return snap, err
+	}
+	err = json.Unmarshal(b, &snap)
+	return snap, err
+}
+
+// StatusFileWriter keeps .xcbolt/status.json current from a background
+// goroutine. Publish never blocks, and write failures are ignored: the file
+// is a convenience for `xcbolt status` and must not affect operations.
+type StatusFileWriter struct {
+	projectRoot string
+	snapCh      chan StatusSnapshot
+	done        chan struct{}
+	stopped     chan struct{}
+}
+
+func NewStatusFileWriter(projectRoot string) *StatusFileWriter {
+	w := &StatusFileWriter{
+		projectRoot: projectRoot,
+		snapCh:      make(chan StatusSnapshot, 1),
+		done:        make(chan struct{}),
+		stopped:     make(chan struct{}),
+	}
+	go w.loop()
+	return w
+}
+
+// Publish replaces the pending snapshot; older pending snapshots are dropped
+func (w *StatusFileWriter) Publish(snap StatusSnapshot) {
+	if w == nil {
+		return
+	}
+	for {
+		select {
+		case w.snapCh <- snap:
+			return
+		default:
+		}
+		select {
+		case <-w.snapCh:
+		default:
+		}
+	}
+}
+
+// Close writes the last pending snapshot and stops the writer
+func (w *StatusFileWriter) Close() {
+	if w == nil {
+		return
+	}
+	close(w.done)
+	<-w.stopped
+}
+
+func (w *StatusFileWriter) loop() {
+	defer close(w.stopped)
+	var (
+		written   StatusSnapshot
+		hasWrite  bool
+		pending   *StatusSnapshot
+		lastWrite time.Time
+		timer     *time.Timer
+		timerC    <-chan time.Time
+	)
+	flush := func() {
+		if pending == nil {
+			return
+		}
+		w.write(*pending)
+		written, hasWrite, pending, lastWrite = *pending, true, nil, time.Now()
+	}
+	for {
+		select {
+		case <-w.done:
+			select {
+			case snap := <-w.snapCh:
+				pending = &snap
+			default:
+			}
+			flush()
+			return
+		case snap := <-w.snapCh:
+			switch {
+			case hasWrite && sameStatus(written, snap):
+				pending = nil
+			case !hasWrite || statusTransition(written, snap) || time.Since(lastWrite) >= statusFileInterval:
+				pending = &snap
+				flush()
+			default:
+				pending = &snap
+				if timerC == nil {
+					timer = time.NewTimer(time.Until(lastWrite.Add(statusFileInterval)))
+					timerC = timer.C
+				}
+			}
+		case <-timerC:
+			timerC = nil
+			flush()
+		}
+		if pending == nil && timerC != nil {
+			timer.Stop()
+			timerC = nil
+		}
+	}
+}
+
+func (w *StatusFileWriter) write(snap StatusSnapshot) {
+	snap.PID = os.Getpid()
+	snap.UpdatedAt = time.Now().UTC().Format(time.RFC3339Nano)
+	b, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(StatusFilePath(w.projectRoot)), 0o755); err != nil {
+		return
+	}
+	_ = writeFileAtomic(StatusFilePath(w.projectRoot), append(b, '\n'), 0o644)
+}
+
+// statusTransition reports an op starting or finishing
+func statusTransition(a, b StatusSnapshot) bool {
+	return a.Running != b.Running || a.Op != b.Op || (a.LastResult == nil) != (b.LastResult == nil) ||
+		(a.LastResult != nil && *a.LastResult != *b.LastResult)
+}
+
+func sameStatus(a, b StatusSnapshot) bool {
+	if statusTransition(a, b) {
+		return false
+	}
+	a.LastResult, b.LastResult = nil, nil
+	return a == b
+}
+
+// StatusWriterAlive reports whether the process that wrote snap is still
+// running; a dead writer's "running" status is stale.
+func StatusWriterAlive(snap StatusSnapshot) bool {
+	if snap.PID <= 0 {
+		return true
+	}
+	err := syscall.Kill(snap.PID, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
+// FormatStatusLine renders snap as one line like the TUI's status bar:
+// scheme, destination, the running stage or last result, and issue counts.
+// color adds ANSI colors.
+func FormatStatusLine(snap StatusSnapshot, color bool) string {
+	paint := func(code, s string) string {
+		if !color || s == "" {
+			return s
+		}
+		return "\x1b[" + code + "m" + s + "\x1b[0m"
+	}
+	scheme := snap.Scheme
+	if scheme == "" {
+		scheme = "?"
+	}
+	dest := snap.Destination
+	if dest == "" {
+		dest = "?"
+	}
+	parts := []string{paint("1;36", "⚡ xcbolt"), scheme, dest}
+
+	switch {
+	case snap.Running && !StatusWriterAlive(snap):
+		parts = append(parts, paint("33", "● "+snap.Op+" interrupted"))
+	case snap.Running:
+		state := "● " + snap.Op
+		if snap.Preset != "" {
+			state += " [" + snap.Preset + "]"
+		}
+		if snap.Stage != "" {
+			state += " · " + snap.Stage
+		}
+		if snap.Progress.Total > 0 {
+			state += fmt.Sprintf(" %d/%d", snap.Progress.Current, snap.Progress.Total)
+		}
+		parts = append(parts, paint("36", state))
+	case snap.LastResult != nil:
+		r := snap.LastResult
+		state := strings.TrimSpace(r.Op + " " + r.Duration)
+		if r.Success {
+			parts = append(parts, paint("32", "✓ "+state))
+		} else {
+			parts = append(parts, paint("31", "✗ "+state))
+		}
+	default:
+		parts = append(parts, "idle")
+	}
+
+	var counts []string
+	if snap.ErrorCount > 0 {
+		counts = append(counts, paint("31", fmt.Sprintf("✗ %d", snap.ErrorCount)))
+	}
+	if snap.WarningCount > 0 {
+		counts = append(counts, paint("33", fmt.Sprintf("⚠ %d", snap.WarningCount)))
+	}
+	if len(counts) > 0 {
+		parts = append(parts, strings.Join(counts, " "))
+	}
+	return strings.Join(parts, paint("2", " | "))
+}
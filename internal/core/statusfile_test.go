@@ -0,0 +1,82 @@
+package core
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStatusFileWriterThrottlesProgress(t *testing.T) {
+	root := t.TempDir()
+	w := NewStatusFileWriter(root)
+	running := StatusSnapshot{Scheme: "App", Running: true, Op: "build", Stage: "Compile"}
+	w.Publish(running)
+	waitForStatus(t, root, func(s StatusSnapshot) bool { return s.Stage == "Compile" })
+
+	running.Stage = "Link"
+	w.Publish(running)
+	time.Sleep(100 * time.Millisecond)
+	if snap, _ := ReadStatusFile(root); snap.Stage != "Compile" {
+		t.Fatalf("stage change within a second should wait, got %q", snap.Stage)
+	}
+
+	// Finishing is written at once and supersedes the pending stage
+	done := StatusSnapshot{Scheme: "App", Op: "build", LastResult: &StatusLastResult{Op: "build", Success: true, Duration: "3s"}}
+	w.Publish(done)
+	waitForStatus(t, root, func(s StatusSnapshot) bool { return !s.Running && s.LastResult != nil })
+	w.Close()
+
+	snap, err := ReadStatusFile(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snap.PID != os.Getpid() || snap.UpdatedAt == "" || snap.Stage != "" {
+		t.Fatalf("unexpected final status %+v", snap)
+	}
+}
+
+func TestStatusFileWriterFlushesOnClose(t *testing.T) {
+	root := t.TempDir()
+	w := NewStatusFileWriter(root)
+	w.Publish(StatusSnapshot{Running: true, Op: "test", Stage: "Compile"})
+	waitForStatus(t, root, func(s StatusSnapshot) bool { return s.Running })
+	w.Publish(StatusSnapshot{Running: true, Op: "test", Stage: "Testing"})
+	w.Close()
+	if snap, _ := ReadStatusFile(root); snap.Stage != "Testing" {
+		t.Fatalf("Close should write the pending status, got %+v", snap)
+	}
+}
+
+func waitForStatus(t *testing.T, root string, ok func(StatusSnapshot) bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if snap, err := ReadStatusFile(root); err == nil && ok(snap) {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("status file was not written")
+}
+
+func TestFormatStatusLine(t *testing.T) {
+	running := StatusSnapshot{Scheme: "App", Destination: "iPhone 16", Running: true, Op: "build", Stage: "Compile", Progress: StatusProgress{Current: 12, Total: 40}, WarningCount: 3}
+	if got, want := FormatStatusLine(running, false), "⚡ xcbolt | App | iPhone 16 | ● build · Compile 12/40 | ⚠ 3"; got != want {
+		t.Fatalf("running line = %q, want %q", got, want)
+	}
+
+	failed := StatusSnapshot{Scheme: "App", LastResult: &StatusLastResult{Op: "test", Duration: "8.2s"}, ErrorCount: 2}
+	if got, want := FormatStatusLine(failed, false), "⚡ xcbolt | App | ? | ✗ test 8.2s | ✗ 2"; got != want {
+		t.Fatalf("failed line = %q, want %q", got, want)
+	}
+	if colored := FormatStatusLine(failed, true); !strings.Contains(colored, "\x1b[31m✗ test 8.2s\x1b[0m") {
+		t.Fatalf("expected a red result, got %q", colored)
+	}
+
+	// A writer that exited mid-op left a stale "running" status
+	stale := StatusSnapshot{Running: true, Op: "run", PID: 1 << 30}
+	if got := FormatStatusLine(stale, false); !strings.Contains(got, "● run interrupted") {
+		t.Fatalf("stale line = %q", got)
+	}
+}
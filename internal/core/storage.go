@@ -0,0 +1,142 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	// DefaultDiskSpaceWarnGB is the free space below which xcbolt warns.
+	DefaultDiskSpaceWarnGB = 10
+	// StorageCacheTTL is how long MeasureStorage reuses directory sizes.
+	StorageCacheTTL = 3 * time.Minute
+	// StorageSizeTimeout bounds the size walk of each storage item.
+	StorageSizeTimeout = 30 * time.Second
+)
+
+// StorageItem is a directory xcbolt fills, with the clean op that empties it.
+type StorageItem struct {
+	Name    string `json:"name"`
+	Path    string `json:"path"`
+	Bytes   int64  `json:"bytes"`
+	Partial bool   `json:"partial,omitempty"`
+	// CleanOp is the clean operation that removes it (see CleanTargets).
+	CleanOp string `json:"cleanOp"`
+}
+
+// StorageReport is the disk usage of the xcbolt-managed directories and
+// the free space on the project's volume.
+type StorageReport struct {
+	Items []StorageItem `json:"items"`
+	// FreeBytes is the space available on the project's volume (-1 when
+	// unknown).
+	FreeBytes  int64     `json:"freeBytes"`
+	MeasuredAt time.Time `json:"measuredAt"`
+}
+
+// DiskSpaceWarnBytes is the free space below which xcbolt warns (0 when
+// warnings are off).
+func (c Config) DiskSpaceWarnBytes() int64 {
+	gb := c.DiskSpaceWarnGB
+	if gb == 0 {
+		gb = DefaultDiskSpaceWarnGB
+	}
+	if gb < 0 {
+		return 0
+	}
+	return int64(gb) << 30
+}
+
+// LowDiskSpaceWarning is the warning for r under cfg, or "" when there is
+// enough free space.
+func LowDiskSpaceWarning(r StorageReport, cfg Config) string {
+	limit := cfg.DiskSpaceWarnBytes()
+	if limit == 0 || r.FreeBytes < 0 || r.FreeBytes >= limit {
+		return ""
+	}
+	return fmt.Sprintf("Low disk space: %s free (builds may fail). Clean DerivedData or result bundles to reclaim space.", FormatBytes(r.FreeBytes))
+}
+
+var storageCache struct {
+	sync.Mutex
+	reports map[string]StorageReport
+}
+
+// MeasureStorage sizes cfg's DerivedData and result bundle directories and
+// the SwiftPM caches, reusing sizes measured less than StorageCacheTTL ago.
+// Free space is always read fresh. A canceled ctx returns its error and
+// caches nothing.
+func MeasureStorage(ctx context.Context, projectRoot string, cfg Config) (StorageReport, error) {
+	items := storageItems(projectRoot, cfg)
+	key := projectRoot + "\x00" + items[0].Path + "\x00" + items[1].Path
+	storageCache.Lock()
+	cached, ok := storageCache.reports[key]
+	storageCache.Unlock()
+
+	report := cached
+	if !ok || time.Since(cached.MeasuredAt) >= StorageCacheTTL {
+		report = StorageReport{MeasuredAt: time.Now()}
+		for _, item := range items {
+			walkCtx, cancel := context.WithTimeout(ctx, StorageSizeTimeout)
+			item.Bytes, item.Partial = pathSize(walkCtx, item.Path)
+			cancel()
+			if err := ctx.Err(); err != nil {
+				return StorageReport{}, err
+			}
+			report.Items = append(report.Items, item)
+		}
+		storageCache.Lock()
+		if storageCache.reports == nil {
+			storageCache.reports = map[string]StorageReport{}
+		}
+		storageCache.reports[key] = report
+		storageCache.Unlock()
+	}
+	report.FreeBytes = -1
+	if free, err := FreeDiskSpace(projectRoot); err == nil {
+		report.FreeBytes = free
+	}
+	return report, nil
+}
+
+// InvalidateStorage drops cached sizes, e.g. after a clean.
+func InvalidateStorage() {
+	storageCache.Lock()
+	storageCache.reports = nil
+	storageCache.Unlock()
+}
+
+// storageItems lists the directories the clean ops remove, DerivedData and
+// Results first. Missing ones measure as 0 bytes.
+func storageItems(projectRoot string, cfg Config) []StorageItem {
+	derived := filepath.Join(projectRoot, ".xcbolt", "DerivedData")
+	if cfg.DerivedDataPath != "" {
+		derived = absJoin(projectRoot, cfg.DerivedDataPath)
+	}
+	results := filepath.Join(projectRoot, ".xcbolt", "Results")
+	if cfg.ResultBundlesPath != "" {
+		results = absJoin(projectRoot, cfg.ResultBundlesPath)
+	}
+	items := []StorageItem{
+		{Name: "DerivedData", Path: derived, CleanOp: "clean-derived"},
+		{Name: "Results", Path: results, CleanOp: "clean-results"},
+	}
+	for _, p := range SwiftPMCachePaths() {
+		items = append(items, StorageItem{Name: "SwiftPM " + filepath.Base(p), Path: p, CleanOp: "clean-spm-cache"})
+	}
+	return items
+}
+
+// FreeDiskSpace returns the bytes available to unprivileged users on the
+// volume holding path.
+func FreeDiskSpace(path string) (int64, error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return 0, err
+	}
+	return int64(st.Bavail) * int64(st.Bsize), nil
+}
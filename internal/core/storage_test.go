@@ -0,0 +1,118 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMeasureStorageSizesAndCaches(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	root := t.TempDir()
+	InvalidateStorage()
+	t.Cleanup(InvalidateStorage)
+
+	derived := filepath.Join(root, ".xcbolt", "DerivedData", "Build")
+	if err := os.MkdirAll(derived, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(derived, "a.o"), make([]byte, 3000), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := MeasureStorage(context.Background(), root, Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sizes := map[string]int64{}
+	for _, item := range r.Items {
+		sizes[item.CleanOp] += item.Bytes
+	}
+	if sizes["clean-derived"] != 3000 || sizes["clean-results"] != 0 || sizes["clean-spm-cache"] != 0 {
+		t.Fatalf("sizes = %v", sizes)
+	}
+	if r.FreeBytes <= 0 {
+		t.Fatalf("FreeBytes = %d", r.FreeBytes)
+	}
+
+	// Within the TTL sizes come from the cache
+	if err := os.WriteFile(filepath.Join(derived, "b.o"), make([]byte, 1000), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	r2, _ := MeasureStorage(context.Background(), root, Config{})
+	if r2.Items[0].Bytes != 3000 || !r2.MeasuredAt.Equal(r.MeasuredAt) {
+		t.Fatalf("expected cached sizes, got %+v", r2.Items[0])
+	}
+	InvalidateStorage()
+	r3, _ := MeasureStorage(context.Background(), root, Config{})
+	if r3.Items[0].Bytes != 4000 {
+		t.Fatalf("after invalidate DerivedData = %d, want 4000", r3.Items[0].Bytes)
+	}
+}
+
+func TestMeasureStorageUsesConfiguredPaths(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	root := t.TempDir()
+	InvalidateStorage()
+	t.Cleanup(InvalidateStorage)
+
+	derived := filepath.Join(t.TempDir(), "DD")
+	if err := os.MkdirAll(derived, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(derived, "a.o"), make([]byte, 2000), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := Config{DerivedDataPath: derived, ResultBundlesPath: "out/Results"}
+	r, err := MeasureStorage(context.Background(), root, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Items[0].Path != derived || r.Items[0].Bytes != 2000 {
+		t.Fatalf("DerivedData item = %+v", r.Items[0])
+	}
+	if want := filepath.Join(root, "out", "Results"); r.Items[1].Path != want {
+		t.Fatalf("Results path = %s, want %s", r.Items[1].Path, want)
+	}
+}
+
+func TestMeasureStorageCanceled(t *testing.T) {
+	root := t.TempDir()
+	InvalidateStorage()
+	t.Cleanup(InvalidateStorage)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := MeasureStorage(ctx, root, Config{}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	storageCache.Lock()
+	defer storageCache.Unlock()
+	if len(storageCache.reports) != 0 {
+		t.Fatal("canceled measurement was cached")
+	}
+}
+
+func TestLowDiskSpaceWarning(t *testing.T) {
+	report := func(gb int64) StorageReport {
+		return StorageReport{FreeBytes: gb << 30, MeasuredAt: time.Now()}
+	}
+	if w := LowDiskSpaceWarning(report(4), Config{}); !strings.Contains(w, "4.0 GB free") {
+		t.Fatalf("default threshold: %q", w)
+	}
+	if w := LowDiskSpaceWarning(report(12), Config{}); w != "" {
+		t.Fatalf("12 GB free warned: %q", w)
+	}
+	if w := LowDiskSpaceWarning(report(12), Config{DiskSpaceWarnGB: 20}); w == "" {
+		t.Fatal("expected warning below 20 GB")
+	}
+	if w := LowDiskSpaceWarning(report(1), Config{DiskSpaceWarnGB: -1}); w != "" {
+		t.Fatalf("disabled warning fired: %q", w)
+	}
+	if w := LowDiskSpaceWarning(StorageReport{FreeBytes: -1}, Config{}); w != "" {
+		t.Fatalf("unknown free space warned: %q", w)
+	}
+}
@@ -0,0 +1,326 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StressIterationCode marks the status events of a stress run as each
+// iteration finishes (Data: iteration, iterations, failures).
+const StressIterationCode = "STRESS_ITERATION"
+
+// maxFailureLogLines caps StressResult.FirstFailureLog
+const maxFailureLogLines = 40
+
+// StressOptions tunes StressTest.
+type StressOptions struct {
+	// Iterations is how many times the tests run.
+	Iterations int
+	// UntilFailure stops at the first failing iteration.
+	UntilFailure bool
+}
+
+// StressResult is the outcome of running the same tests repeatedly.
+type StressResult struct {
+	// Iterations is how many iterations finished; fewer than Requested
+	// when canceled or stopped at the first failure.
+	Iterations int `json:"iterations"`
+	Requested  int `json:"requested"`
+	// Failures lists the failing iterations, 1-based.
+	Failures []int `json:"failures,omitempty"`
+	// FirstFailureLog has the failure lines of the first failing iteration.
+	FirstFailureLog string `json:"firstFailureLog,omitempty"`
+	// InPlace is set when xcodebuild repeated the tests itself
+	// (-test-iterations) instead of xcbolt running it once per iteration.
+	InPlace bool `json:"inPlace,omitempty"`
+	// ResultBundle is the bundle of the (last) xcodebuild run.
+	ResultBundle string        `json:"resultBundle,omitempty"`
+	Duration     time.Duration `json:"duration"`
+}
+
+// FailureRate is the percentage of finished iterations that failed.
+func (r StressResult) FailureRate() float64 {
+	if r.Iterations == 0 {
+		return 0
+	}
+	return float64(len(r.Failures)) * 100 / float64(r.Iterations)
+}
+
+// Line summarizes the run, e.g. "50/50 iterations · 2 failures (4.0%)".
+func (r StressResult) Line() string {
+	noun := "failures"
+	if len(r.Failures) == 1 {
+		noun = "failure"
+	}
+	return fmt.Sprintf("%d/%d iterations · %d %s (%.1f%%)", r.Iterations, r.Requested, len(r.Failures), noun, r.FailureRate())
+}
+
+// testIterations asks xcodebuild to repeat each test (Xcode 13+).
+type testIterations struct {
+	count        int
+	untilFailure bool
+}
+
+func (t testIterations) args() []string {
+	if t.count <= 0 {
+		return nil
+	}
+	args := []string{"-test-iterations", strconv.Itoa(t.count)}
+	if t.untilFailure {
+		args = append(args, "-run-tests-until-failure")
+	}
+	return args
+}
+
+// StressTest runs the tests opts.Iterations times to catch flaky ones,
+// usually narrowed to a few with onlyTesting. On Xcode 13+ xcodebuild
+// repeats them itself (-test-iterations); otherwise, and for Swift
+// packages, xcodebuild runs once per iteration with the same filters.
+// Retries (test.retryOnFailure) are off, so every failure counts.
+//
+// A canceled run returns the iterations that finished along with the
+// cancel error. Failing iterations make the error non-nil.
+func StressTest(ctx context.Context, projectRoot string, cfg Config, onlyTesting, skipTesting []string, opts StressOptions, emit Emitter) (res StressResult, out Config, err error) {
+	finish := resultsOp(ctx, cfg, "test", emit)
+	defer func() { finish(out) }()
+	res.Requested = opts.Iterations
+	if opts.Iterations < 1 {
+		return res, cfg, fmt.Errorf("stress runs need at least 1 iteration, got %d", opts.Iterations)
+	}
+	cfg, err = maybeGenerateProject(ctx, projectRoot, cfg, "test", emit)
+	if err != nil {
+		return res, cfg, err
+	}
+	if err := runPreHook(ctx, projectRoot, cfg, "test", emit); err != nil {
+		return res, cfg, err
+	}
+
+	// Retries would hide the flakiness we're looking for
+	retries := cfg.Test.RetryOnFailure
+	cfg.Test.RetryOnFailure = 0
+	defer func() { out.Test.RetryOnFailure = retries }()
+
+	_, isPackage, _ := swiftPackageFor(ctx, projectRoot, cfg)
+	res.InPlace = !isPackage && (cfg.Xcodebuild.DryRun || xcodeSupportsTestRetry(ctx))
+	tracker := &stressTracker{next: emit, inPlace: res.InPlace, requested: opts.Iterations}
+	started := time.Now()
+
+	if res.InPlace {
+		emitMaybe(emit, Status("test", fmt.Sprintf("Stress: running the tests %d times (-test-iterations)", opts.Iterations), nil))
+		var tr TestResult
+		tr, cfg, err = test(ctx, projectRoot, cfg, onlyTesting, skipTesting, testIterations{count: opts.Iterations, untilFailure: opts.UntilFailure}, tracker)
+		res.ResultBundle = tr.ResultBundle
+		tracker.finishInPlace(err)
+	} else {
+		emitMaybe(emit, Status("test", fmt.Sprintf("Stress: running xcodebuild test %d times", opts.Iterations), nil))
+		for i := 1; i <= opts.Iterations; i++ {
+			tracker.begin(i)
+			var tr TestResult
+			tr, cfg, err = test(ctx, projectRoot, cfg, onlyTesting, skipTesting, testIterations{}, tracker)
+			res.ResultBundle = tr.ResultBundle
+			if CancelStatus(err) != "" {
+				break
+			}
+			failed := tracker.end(i, err != nil)
+			if failed && opts.UntilFailure {
+				break
+			}
+		}
+	}
+	res.Duration = time.Since(started)
+	res.Iterations, res.Failures, res.FirstFailureLog = tracker.outcome()
+	runPostHook(ctx, projectRoot, cfg, "test", hookOutcome{success: err == nil && len(res.Failures) == 0, resultBundle: res.ResultBundle}, emit)
+
+	emitMaybe(emit, Status("test", "Stress: "+res.Line(), nil))
+	data := tracker.resultData()
+	data["stress"] = res
+	if status := CancelStatus(err); status != "" {
+		data["status"] = status
+		data["partial"] = true
+		emitMaybe(emit, Result("test", false, data))
+		return res, cfg, err
+	}
+	if len(res.Failures) > 0 {
+		err = fmt.Errorf("%d of %d iterations failed", len(res.Failures), res.Iterations)
+		emitMaybe(emit, Err("test", ErrorObject{
+			Code:       "TEST_STRESS_FAILED",
+			Message:    "Tests failed under stress: " + res.Line(),
+			Detail:     res.FirstFailureLog,
+			Suggestion: "The first failing iteration's log is in the detail; inspect its result bundle for more.",
+		}))
+		emitMaybe(emit, Result("test", false, data))
+		return res, cfg, err
+	}
+	if err != nil {
+		// xcodebuild failed without a test failure (e.g. the build)
+		emitMaybe(emit, Result("test", false, data))
+		return res, cfg, err
+	}
+	emitMaybe(emit, Result("test", true, data))
+	return res, cfg, nil
+}
+
+var (
+	// XCTest prints the iteration on each "started" line when repeating
+	stressIterationRE = regexp.MustCompile(`\(Iteration (\d+) of (\d+)\)`)
+	// "Test Case '-[AppTests.LoginTests testSSO]' failed (0.012 seconds)."
+	// and Swift Testing's "✘ Test login() failed after 0.01 seconds."
+	testCaseFailedRE = regexp.MustCompile(`^(?:Test Case '.+' failed|\S+ Test .+ failed)`)
+)
+
+// stressTracker forwards the events of a stress run, attributing test
+// failures to iterations from the raw xcodebuild output. The test result
+// events of single runs are held back for the stress result.
+type stressTracker struct {
+	next      Emitter
+	inPlace   bool
+	requested int
+
+	mu         sync.Mutex
+	current    int // Iteration being run
+	finished   int
+	failures   []int
+	failedNow  bool // The current iteration has a failure
+	log        []string
+	firstLog   []string
+	lastResult map[string]any
+}
+
+func (t *stressTracker) Emit(ev Event) {
+	if ev.Type == "result" {
+		wrapped, _ := ev.Data.(map[string]any)
+		t.mu.Lock()
+		t.lastResult, _ = wrapped["data"].(map[string]any)
+		t.mu.Unlock()
+		return
+	}
+	if ev.Type == "log_raw" || (ev.Type == "log" && !isPrettyLog(ev)) {
+		t.observe(ev.Msg)
+	}
+	emitMaybe(t.next, ev)
+}
+
+func isPrettyLog(ev Event) bool {
+	data, _ := ev.Data.(map[string]any)
+	pretty, _ := data["pretty"].(bool)
+	return pretty
+}
+
+// observe reads one raw output line
+func (t *stressTracker) observe(line string) {
+	var progress *Event
+	t.mu.Lock()
+	if m := stressIterationRE.FindStringSubmatch(line); m != nil && t.inPlace {
+		if n, _ := strconv.Atoi(m[1]); n > t.current {
+			if t.current > 0 {
+				ev := t.endLocked(t.current, false)
+				progress = &ev
+			}
+			t.current = n
+		}
+	}
+	if testCaseFailedRE.MatchString(line) || ClassifyDiagnostic(line).Severity == DiagnosticError {
+		if testCaseFailedRE.MatchString(line) {
+			t.failedNow = true
+		}
+		if len(t.log) < maxFailureLogLines && (len(t.log) == 0 || t.log[len(t.log)-1] != line) {
+			t.log = append(t.log, line)
+		}
+	}
+	t.mu.Unlock()
+	if progress != nil {
+		emitMaybe(t.next, *progress)
+	}
+}
+
+// begin starts iteration i of a looped run
+func (t *stressTracker) begin(i int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.current = i
+	t.failedNow = false
+	t.log = nil
+}
+
+// end finishes iteration i of a looped run, reporting whether it failed
+func (t *stressTracker) end(i int, failed bool) bool {
+	t.mu.Lock()
+	ev := t.endLocked(i, failed)
+	t.mu.Unlock()
+	emitMaybe(t.next, ev)
+	return failed || t.failedNow
+}
+
+// endLocked records iteration i as finished and returns its progress event
+func (t *stressTracker) endLocked(i int, failed bool) Event {
+	if failed || t.failedNow {
+		if !slices.Contains(t.failures, i) {
+			t.failures = append(t.failures, i)
+		}
+		if t.firstLog == nil {
+			t.firstLog = append([]string{}, t.log...)
+		}
+	}
+	t.finished = max(t.finished, i)
+	t.failedNow = false
+	t.log = nil
+	ev := Status("test", fmt.Sprintf("Iteration %d/%d, %d failures", i, t.requested, len(t.failures)), map[string]any{
+		"iteration":  i,
+		"iterations": t.requested,
+		"failures":   len(t.failures),
+	})
+	ev.Code = StressIterationCode
+	return ev
+}
+
+// finishInPlace closes the last iteration of an in-place run. Without
+// iteration markers (e.g. Swift Testing) a failure is put on the first
+// iteration and a success counts every iteration as passed.
+func (t *stressTracker) finishInPlace(err error) {
+	if CancelStatus(err) != "" {
+		// The interrupted iteration didn't finish
+		return
+	}
+	t.mu.Lock()
+	var ev Event
+	switch {
+	case t.current > 0:
+		ev = t.endLocked(t.current, false)
+		if err == nil {
+			t.finished = t.requested
+		}
+	case err != nil && t.failedNow:
+		ev = t.endLocked(1, true)
+	case err == nil:
+		ev = t.endLocked(t.requested, false)
+	default:
+		t.mu.Unlock()
+		return
+	}
+	t.mu.Unlock()
+	emitMaybe(t.next, ev)
+}
+
+func (t *stressTracker) outcome() (int, []int, string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.finished, slices.Clone(t.failures), strings.Join(t.firstLog, "\n")
+}
+
+// resultData is the payload of the last single-run result, to extend with
+// the stress result
+func (t *stressTracker) resultData() map[string]any {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	data := map[string]any{}
+	for k, v := range t.lastResult {
+		data[k] = v
+	}
+	return data
+}
@@ -0,0 +1,114 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTestIterationsArgs(t *testing.T) {
+	if got := (testIterations{}).args(); got != nil {
+		t.Fatalf("expected no args, got %v", got)
+	}
+	want := []string{"-test-iterations", "50", "-run-tests-until-failure"}
+	if got := (testIterations{count: 50, untilFailure: true}).args(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("args = %v, want %v", got, want)
+	}
+}
+
+func TestStressTrackerInPlace(t *testing.T) {
+	rec := &recordingEmitter{}
+	tr := &stressTracker{next: rec, inPlace: true, requested: 3}
+	lines := []string{
+		"Test Case '-[AppTests.LoginTests testSSO]' started (Iteration 1 of 3).",
+		"Test Case '-[AppTests.LoginTests testSSO]' passed (0.010 seconds).",
+		"Test Case '-[AppTests.LoginTests testSSO]' started (Iteration 2 of 3).",
+		"/src/LoginTests.swift:12: error: -[AppTests.LoginTests testSSO] : XCTAssertEqual failed",
+		"Test Case '-[AppTests.LoginTests testSSO]' failed (0.012 seconds).",
+		"Test Case '-[AppTests.LoginTests testSSO]' started (Iteration 3 of 3).",
+		"Test Case '-[AppTests.LoginTests testSSO]' passed (0.010 seconds).",
+	}
+	for _, line := range lines {
+		tr.Emit(LogRaw("test", line))
+	}
+	// Formatted copies are ignored
+	tr.Emit(LogPretty("test", "✖ testSSO, XCTAssertEqual failed"))
+	tr.Emit(Result("test", false, map[string]any{"resultBundle": "/tmp/r.xcresult"}))
+	tr.finishInPlace(errors.New("exit status 65"))
+
+	done, failures, log := tr.outcome()
+	if done != 3 || !reflect.DeepEqual(failures, []int{2}) {
+		t.Fatalf("done = %d, failures = %v", done, failures)
+	}
+	if !strings.Contains(log, "XCTAssertEqual failed") || !strings.Contains(log, "' failed (0.012") {
+		t.Fatalf("first failure log = %q", log)
+	}
+
+	var progress []string
+	for _, ev := range rec.events {
+		if ev.Type == "result" {
+			t.Fatalf("forwarded the inner result")
+		}
+		if ev.Code == StressIterationCode {
+			progress = append(progress, ev.Msg)
+		}
+	}
+	want := []string{"Iteration 1/3, 0 failures", "Iteration 2/3, 1 failures", "Iteration 3/3, 1 failures"}
+	if !reflect.DeepEqual(progress, want) {
+		t.Fatalf("progress = %v", progress)
+	}
+	if data := tr.resultData(); data["resultBundle"] != "/tmp/r.xcresult" {
+		t.Fatalf("resultData = %v", data)
+	}
+}
+
+func TestStressTrackerLoopStopsCountingOnCancel(t *testing.T) {
+	tr := &stressTracker{next: &recordingEmitter{}, requested: 5}
+	tr.begin(1)
+	tr.Emit(LogRaw("test", "Test Case '-[A.B c]' passed (0.001 seconds)."))
+	if tr.end(1, false) {
+		t.Fatal("passing iteration reported as failed")
+	}
+	tr.begin(2)
+	if !tr.end(2, true) {
+		t.Fatal("failing iteration reported as passed")
+	}
+	tr.begin(3)
+	// Canceled: iteration 3 never ends
+	done, failures, _ := tr.outcome()
+	if done != 2 || !reflect.DeepEqual(failures, []int{2}) {
+		t.Fatalf("done = %d, failures = %v", done, failures)
+	}
+}
+
+func TestStressTrackerInPlaceWithoutMarkers(t *testing.T) {
+	tr := &stressTracker{next: &recordingEmitter{}, inPlace: true, requested: 10}
+	tr.finishInPlace(nil)
+	if done, failures, _ := tr.outcome(); done != 10 || failures != nil {
+		t.Fatalf("done = %d, failures = %v", done, failures)
+	}
+
+	tr = &stressTracker{next: &recordingEmitter{}, inPlace: true, requested: 10}
+	tr.finishInPlace(context.Canceled)
+	if done, _, _ := tr.outcome(); done != 0 {
+		t.Fatalf("counted an interrupted run: %d", done)
+	}
+}
+
+func TestStressResultLine(t *testing.T) {
+	r := StressResult{Iterations: 12, Requested: 50, Failures: []int{7}}
+	if got := r.Line(); got != "12/50 iterations · 1 failure (8.3%)" {
+		t.Fatalf("Line = %q", got)
+	}
+	if (StressResult{}).FailureRate() != 0 {
+		t.Fatal("rate of no iterations")
+	}
+}
+
+func TestStressTestRejectsZeroIterations(t *testing.T) {
+	if _, _, err := StressTest(context.Background(), t.TempDir(), Config{}, nil, nil, StressOptions{}, nil); err == nil {
+		t.Fatal("expected an error")
+	}
+}
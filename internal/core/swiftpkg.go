@@ -0,0 +1,608 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Swift package targets and products, as reported by
+// `swift package describe --type json`.
+const (
+	SwiftProductExecutable = "executable"
+	SwiftProductLibrary    = "library"
+	SwiftTargetTest        = "test"
+)
+
+// SwiftPackageConfigurations are the build configurations of a Swift
+// package, named like Xcode's (swift build takes them lowercased).
+var SwiftPackageConfigurations = []string{"Debug", "Release"}
+
+// SwiftPackage describes a SwiftPM package opened without an Xcode
+// workspace or project.
+type SwiftPackage struct {
+	Name     string
+	Products []SwiftPackageItem
+	Targets  []SwiftPackageItem
+}
+
+// SwiftPackageItem is a product or target and its type ("executable",
+// "library", "test", ...).
+type SwiftPackageItem struct {
+	Name string
+	Type string
+}
+
+// PackageScheme is the scheme that builds and tests the whole package,
+// named like the one Xcode generates.
+func (p SwiftPackage) PackageScheme() string {
+	return p.Name + "-Package"
+}
+
+// Schemes lists the package's products, the targets no product covers
+// (test targets included) and the whole-package scheme.
+func (p SwiftPackage) Schemes() []string {
+	seen := map[string]struct{}{p.PackageScheme(): {}}
+	schemes := []string{}
+	add := func(name string) {
+		if _, ok := seen[name]; ok || name == "" {
+			return
+		}
+		seen[name] = struct{}{}
+		schemes = append(schemes, name)
+	}
+	for _, prod := range p.Products {
+		add(prod.Name)
+	}
+	for _, t := range p.Targets {
+		add(t.Name)
+	}
+	sort.Strings(schemes)
+	return append(schemes, p.PackageScheme())
+}
+
+// DefaultScheme is the first executable product, or the package scheme.
+func (p SwiftPackage) DefaultScheme() string {
+	if exes := p.Executables(); len(exes) > 0 {
+		return exes[0]
+	}
+	return p.PackageScheme()
+}
+
+// Executables lists the executable products, sorted.
+func (p SwiftPackage) Executables() []string {
+	out := []string{}
+	for _, prod := range p.Products {
+		if prod.Type == SwiftProductExecutable {
+			out = append(out, prod.Name)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func (p SwiftPackage) product(name string) (SwiftPackageItem, bool) {
+	for _, prod := range p.Products {
+		if prod.Name == name {
+			return prod, true
+		}
+	}
+	return SwiftPackageItem{}, false
+}
+
+func (p SwiftPackage) target(name string) (SwiftPackageItem, bool) {
+	for _, t := range p.Targets {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return SwiftPackageItem{}, false
+}
+
+// parseSwiftPackageDescribe reads `swift package describe --type json`.
+// Product types are objects keyed by kind ({"executable": null},
+// {"library": ["automatic"]}); target types are plain strings.
+func parseSwiftPackageDescribe(out string) (SwiftPackage, error) {
+	var doc struct {
+		Name     string `json:"name"`
+		Products []struct {
+			Name string                     `json:"name"`
+			Type map[string]json.RawMessage `json:"type"`
+		} `json:"products"`
+		Targets []struct {
+			Name string `json:"name"`
+			Type string `json:"type"`
+		} `json:"targets"`
+	}
+	if err := json.Unmarshal([]byte(extractJSONObject(out)), &doc); err != nil {
+		return SwiftPackage{}, fmt.Errorf("parse swift package describe: %w", err)
+	}
+	pkg := SwiftPackage{Name: doc.Name}
+	for _, p := range doc.Products {
+		kind := ""
+		for k := range p.Type {
+			kind = k
+		}
+		pkg.Products = append(pkg.Products, SwiftPackageItem{Name: p.Name, Type: kind})
+	}
+	for _, t := range doc.Targets {
+		pkg.Targets = append(pkg.Targets, SwiftPackageItem{Name: t.Name, Type: t.Type})
+	}
+	return pkg, nil
+}
+
+// isSwiftPackageRoot reports whether projectRoot is a Swift package that
+// has no Xcode workspace or project of its own.
+func isSwiftPackageRoot(projectRoot string, cfg Config) bool {
+	if cfg.Workspace != "" || cfg.Project != "" {
+		return false
+	}
+	if _, err := os.Stat(filepath.Join(projectRoot, "Package.swift")); err != nil {
+		return false
+	}
+	workspaces, projects, err := scanProjectEntries(projectRoot)
+	return err == nil && len(workspaces) == 0 && len(projects) == 0
+}
+
+// DescribeSwiftPackage runs `swift package describe` in projectRoot.
+func DescribeSwiftPackage(ctx context.Context, projectRoot string) (SwiftPackage, error) {
+	var stdout, stderr strings.Builder
+	_, err := RunStreaming(ctx, CmdSpec{
+		Path:       "xcrun",
+		Args:       []string{"swift", "package", "describe", "--type", "json"},
+		Dir:        projectRoot,
+		StdoutLine: func(s string) { stdout.WriteString(s + "\n") },
+		StderrLine: func(s string) { stderr.WriteString(s + "\n") },
+	})
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return SwiftPackage{}, fmt.Errorf("swift package describe: %w: %s", err, msg)
+		}
+		return SwiftPackage{}, fmt.Errorf("swift package describe: %w", err)
+	}
+	return parseSwiftPackageDescribe(stdout.String())
+}
+
+// swiftPackageFor describes the package at projectRoot when it is a pure
+// Swift package. ok is false for Xcode projects.
+func swiftPackageFor(ctx context.Context, projectRoot string, cfg Config) (pkg SwiftPackage, ok bool, err error) {
+	if !isSwiftPackageRoot(projectRoot, cfg) {
+		return SwiftPackage{}, false, nil
+	}
+	pkg, err = DescribeSwiftPackage(ctx, projectRoot)
+	return pkg, true, err
+}
+
+func swiftPackageErrorObject(err error) ErrorObject {
+	return ErrorObject{
+		Code:       "SWIFT_PACKAGE_INVALID",
+		Message:    "Could not read Package.swift",
+		Detail:     err.Error(),
+		Suggestion: "Run `swift package describe` in the package directory to see the manifest error.",
+	}
+}
+
+// withSwiftPackageDefaults picks a scheme and configuration the package
+// has, and collapses the destination to My Mac.
+func withSwiftPackageDefaults(pkg SwiftPackage, cfg Config, emit Emitter) Config {
+	if cfg.Scheme == "" || !stringInSlice(cfg.Scheme, pkg.Schemes()) {
+		cfg.Scheme = pkg.DefaultScheme()
+		emitMaybe(emit, Status("context", "Auto-selected scheme", map[string]any{"scheme": cfg.Scheme}))
+	}
+	if !stringInSlice(cfg.Configuration, SwiftPackageConfigurations) {
+		cfg.Configuration = SwiftPackageConfigurations[0]
+	}
+	cfg.Destination = myMacDestination()
+	return cfg
+}
+
+// myMacDestination is the local macOS destination.
+func myMacDestination() Destination {
+	cfg, _ := resolveDestination(Config{Destination: Destination{TargetType: TargetLocal, PlatformFamily: PlatformMacOS}}, nil)
+	return cfg.Destination
+}
+
+// swiftConfigurationArgs maps an Xcode-style configuration to -c.
+func swiftConfigurationArgs(cfg Config) []string {
+	conf := strings.ToLower(cfg.Configuration)
+	if conf == "" {
+		conf = "debug"
+	}
+	return []string{"-c", conf}
+}
+
+// swiftBuildArgs builds the scheme: a product, a single target, the test
+// targets, or (for the package scheme) everything.
+func swiftBuildArgs(pkg SwiftPackage, cfg Config) []string {
+	args := append([]string{"build"}, swiftConfigurationArgs(cfg)...)
+	if _, ok := pkg.product(cfg.Scheme); ok {
+		return append(args, "--product", cfg.Scheme)
+	}
+	if t, ok := pkg.target(cfg.Scheme); ok {
+		if t.Type == SwiftTargetTest {
+			return append(args, "--build-tests")
+		}
+		return append(args, "--target", cfg.Scheme)
+	}
+	return args
+}
+
+// swiftTestFilter turns an -only-testing identifier ("Target/Suite/test")
+// into a swift test --filter ("Target.Suite/test").
+func swiftTestFilter(id string) string {
+	return strings.Replace(id, "/", ".", 1)
+}
+
+// swiftTestArgs runs the scheme's tests. A test target scheme filters to
+// that target unless onlyTesting narrows the run further.
+func swiftTestArgs(pkg SwiftPackage, cfg Config, onlyTesting, skipTesting []string) []string {
+	args := append([]string{"test"}, swiftConfigurationArgs(cfg)...)
+	if t, ok := pkg.target(cfg.Scheme); ok && t.Type == SwiftTargetTest && len(onlyTesting) == 0 {
+		args = append(args, "--filter", t.Name)
+	}
+	for _, o := range onlyTesting {
+		args = append(args, "--filter", swiftTestFilter(o))
+	}
+	for _, s := range skipTesting {
+		args = append(args, "--skip", swiftTestFilter(s))
+	}
+	return args
+}
+
+// runProduct picks the executable product to run for the scheme.
+func (p SwiftPackage) runProduct(scheme string) (string, error) {
+	if prod, ok := p.product(scheme); ok {
+		if prod.Type != SwiftProductExecutable {
+			return "", fmt.Errorf("product %q is a %s, not an executable", scheme, prod.Type)
+		}
+		return scheme, nil
+	}
+	exes := p.Executables()
+	switch len(exes) {
+	case 0:
+		return "", fmt.Errorf("package %q has no executable products", p.Name)
+	case 1:
+		return exes[0], nil
+	}
+	return "", fmt.Errorf("scheme %q is not an executable; pick one of %s", scheme, strings.Join(exes, ", "))
+}
+
+// swift build prefixes each step with its position, e.g. "[12/40] ".
+var swiftBuildStepRE = regexp.MustCompile(`^\[\d+/\d+\] `)
+
+// normalizeSwiftBuildLine rewrites swift build output into the forms the
+// xcodebuild log handling understands: step counters are dropped
+// ("[3/9] Compiling App main.swift" -> "Compiling App main.swift") and
+// package fetches read like xcodebuild's ("Fetching from <url>").
+func normalizeSwiftBuildLine(line string) string {
+	line = swiftBuildStepRE.ReplaceAllString(line, "")
+	for _, verb := range []string{"Fetching", "Updating"} {
+		if rest, ok := strings.CutPrefix(line, verb+" https://"); ok {
+			return verb + " from https://" + rest
+		}
+	}
+	return line
+}
+
+// isSwiftBuildCompileStep reports whether a normalized swift build line
+// compiles a module's sources.
+func isSwiftBuildCompileStep(line string) bool {
+	return strings.HasPrefix(line, "Compiling ")
+}
+
+// newSwiftBuildLogSink is a log sink for swift build and swift test. Their
+// output is not xcodebuild's, so it is never piped through a formatter.
+func newSwiftBuildLogSink(ctx context.Context, cmd string, cfg Config, emit Emitter) *logSink {
+	cfg.Xcodebuild.LogFormat = string(LogFormatRaw)
+	sink := newXcodebuildLogSink(ctx, cmd, cfg, emit)
+	sink.swiftBuild = true
+	return sink
+}
+
+var (
+	// XCTest: "Test Case '-[AppTests.LoginTests testSSO]' passed (0.002 seconds)."
+	xctestCaseRE = regexp.MustCompile(`^Test Case '(?:-\[)?([^' \]]+)(?: ([^'\]]+))?\]?' (passed|failed|skipped)`)
+	// Swift Testing: "✔ Test login() passed after 0.001 seconds.", "➜ Test sso() skipped."
+	swiftTestingCaseRE = regexp.MustCompile(`^\S+ Test (.+?) (?:(passed|failed) after|(skipped)\b)`)
+)
+
+// swiftTestTally counts test results from swift test output, for both
+// XCTest and Swift Testing.
+type swiftTestTally struct {
+	passed, failed, skipped int
+	failedTests             []string
+}
+
+func (t *swiftTestTally) observe(line string) {
+	line = strings.TrimSpace(line)
+	var status, id string
+	if m := xctestCaseRE.FindStringSubmatch(line); m != nil {
+		status = m[3]
+		id = strings.Replace(m[1], ".", "/", 1)
+		if m[2] != "" {
+			id += "/" + m[2]
+		}
+	} else if m := swiftTestingCaseRE.FindStringSubmatch(line); m != nil && !strings.HasPrefix(m[1], "run with ") {
+		status, id = m[2]+m[3], strings.Trim(m[1], `"`)
+	} else {
+		return
+	}
+	switch status {
+	case "passed":
+		t.passed++
+	case "failed":
+		t.failed++
+		t.failedTests = append(t.failedTests, id)
+	case "skipped":
+		t.skipped++
+	}
+}
+
+func (t *swiftTestTally) Summary() TestSummary {
+	return TestSummary{
+		Total:       t.passed + t.failed + t.skipped,
+		Passed:      t.passed,
+		Failed:      t.failed,
+		Skipped:     t.skipped,
+		FailedTests: t.failedTests,
+	}
+}
+
+// swiftBinPath asks swift build where the configuration's products go.
+func swiftBinPath(ctx context.Context, projectRoot string, cfg Config) (string, error) {
+	var out strings.Builder
+	_, err := RunStreaming(ctx, CmdSpec{
+		Path:       "xcrun",
+		Args:       append(append([]string{"swift", "build"}, swiftConfigurationArgs(cfg)...), "--show-bin-path"),
+		Dir:        projectRoot,
+		StdoutLine: func(s string) { out.WriteString(s + "\n") },
+	})
+	if err != nil {
+		return "", fmt.Errorf("swift build --show-bin-path: %w", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	return strings.TrimSpace(lines[len(lines)-1]), nil
+}
+
+func swiftPackageBuild(ctx context.Context, projectRoot string, cfg Config, pkg SwiftPackage, emit Emitter) (BuildResult, Config, error) {
+	cfg = withSwiftPackageDefaults(pkg, cfg, emit)
+	args := swiftBuildArgs(pkg, cfg)
+	command := formatCmd("swift", args)
+	emitMaybe(emit, Status("build", "Build started", map[string]any{"package": pkg.Name, "scheme": cfg.Scheme}))
+	emitMaybe(emit, XcodebuildCommand("build", command))
+	if cfg.Xcodebuild.DryRun {
+		emitMaybe(emit, Log("build", "Dry run: "+command))
+		emitMaybe(emit, Result("build", true, map[string]any{"exitCode": 0, "dryRun": true}))
+		return BuildResult{Command: command}, cfg, nil
+	}
+
+	sink := newSwiftBuildLogSink(ctx, "build", cfg, emit)
+	res, err := RunStreaming(ctx, CmdSpec{
+		Path:       "xcrun",
+		Args:       append([]string{"swift"}, args...),
+		Dir:        projectRoot,
+		Env:        cfg.Xcodebuild.Env,
+		StdoutLine: sink.HandleLine,
+		StderrLine: sink.HandleStderrLine,
+	})
+	sink.Finalize(err, res.ExitCode)
+	packages := resolvedPackagesFor(projectRoot, cfg, sink)
+	if err != nil {
+		data := map[string]any{"exitCode": res.ExitCode, "compiledFiles": sink.CompiledFiles()}
+		if status := CancelStatus(err); status != "" {
+			emitMaybe(emit, Err("build", cancelErrorObject("Build", err, "")))
+			data["status"] = status
+		} else {
+			emitMaybe(emit, Err("build", ErrorObject{
+				Code:       "SWIFT_BUILD_FAILED",
+				Message:    "swift build failed",
+				Detail:     err.Error(),
+				Suggestion: "Check the compiler errors above, or run `swift build` in the package directory.",
+			}))
+		}
+		emitMaybe(emit, Result("build", false, withPackages(data, packages)))
+		return BuildResult{ExitCode: res.ExitCode, Duration: res.Duration, Command: command, Packages: packages, CompiledFiles: sink.CompiledFiles()}, cfg, err
+	}
+
+	var execPath string
+	if prod, ok := pkg.product(cfg.Scheme); ok && prod.Type == SwiftProductExecutable {
+		if bin, err := swiftBinPath(ctx, projectRoot, cfg); err == nil {
+			execPath = filepath.Join(bin, prod.Name)
+		} else {
+			emitMaybe(emit, Warn("build", err.Error()))
+		}
+	}
+	compiled := sink.CompiledFiles()
+	data := withPackages(map[string]any{
+		"exitCode":      0,
+		"durationMs":    res.Duration.Milliseconds(),
+		"appPath":       execPath,
+		"compiledFiles": compiled,
+	}, packages)
+	emitMaybe(emit, Result("build", true, data))
+	return BuildResult{ExitCode: 0, Duration: res.Duration, AppPath: execPath, Command: command, Packages: packages, CompiledFiles: compiled}, cfg, nil
+}
+
+func swiftPackageTest(ctx context.Context, projectRoot string, cfg Config, pkg SwiftPackage, onlyTesting, skipTesting []string, emit Emitter) (TestResult, Config, error) {
+	cfg = withSwiftPackageDefaults(pkg, cfg, emit)
+	args := swiftTestArgs(pkg, cfg, onlyTesting, skipTesting)
+	command := formatCmd("swift", args)
+	emitMaybe(emit, Status("test", "Tests started", map[string]any{"package": pkg.Name, "scheme": cfg.Scheme}))
+	emitMaybe(emit, XcodebuildCommand("test", command))
+	if cfg.Xcodebuild.DryRun {
+		emitMaybe(emit, Log("test", "Dry run: "+command))
+		emitMaybe(emit, Result("test", true, map[string]any{"exitCode": 0, "dryRun": true}))
+		return TestResult{Command: command}, cfg, nil
+	}
+
+	sink := newSwiftBuildLogSink(ctx, "test", cfg, emit)
+	var tally swiftTestTally
+	res, err := RunStreaming(ctx, CmdSpec{
+		Path: "xcrun",
+		Args: append([]string{"swift"}, args...),
+		Dir:  projectRoot,
+		Env:  cfg.Xcodebuild.Env,
+		StdoutLine: func(s string) {
+			tally.observe(s)
+			sink.HandleLine(s)
+		},
+		StderrLine: func(s string) {
+			tally.observe(s)
+			sink.HandleStderrLine(s)
+		},
+	})
+	sink.Finalize(err, res.ExitCode)
+	packages := resolvedPackagesFor(projectRoot, cfg, sink)
+
+	summary := tally.Summary()
+	summary.Partial = CancelStatus(err) != "" && summary.HasCounts()
+	tr := TestResult{ExitCode: res.ExitCode, Duration: res.Duration, Summary: summary, Command: command, Packages: packages}
+	tr.Changes = recordTestChanges(projectRoot, cfg, onlyTesting, skipTesting, summary, err)
+	if summary.HasCounts() {
+		emitMaybe(emit, Status("test", "Tests: "+summary.CountsLine(), nil))
+	}
+	if tr.Changes != nil {
+		emitMaybe(emit, Status("test", "Changes: "+tr.Changes.Line(), nil))
+	}
+
+	data := map[string]any{"exitCode": res.ExitCode, "durationMs": res.Duration.Milliseconds()}
+	if err != nil {
+		errObj := ErrorObject{
+			Code:       "SWIFT_TEST_FAILED",
+			Message:    "swift test failed",
+			Detail:     err.Error(),
+			Suggestion: "Check the failures above, or run `swift test` in the package directory.",
+		}
+		if status := CancelStatus(err); status != "" {
+			detail := "No tests finished"
+			if summary.HasCounts() {
+				detail = fmt.Sprintf("%d tests ran, %d failures so far", summary.Total, summary.Failed)
+			}
+			errObj = cancelErrorObject("Tests", err, detail)
+			data["status"] = status
+			data["partial"] = true
+		}
+		emitMaybe(emit, Err("test", errObj))
+		emitMaybe(emit, Result("test", false, withTestChanges(withPackages(withTestCounts(data, summary), packages), tr.Changes)))
+		return tr, cfg, err
+	}
+	emitMaybe(emit, Result("test", true, withTestChanges(withPackages(withTestCounts(data, summary), packages), tr.Changes)))
+	return tr, cfg, nil
+}
+
+func swiftPackageRun(ctx context.Context, projectRoot string, cfg Config, pkg SwiftPackage, opts RunOptions, emit Emitter) (RunResult, Config, error) {
+	console := opts.Console
+	cfg = withSwiftPackageDefaults(pkg, cfg, emit)
+	product, err := pkg.runProduct(cfg.Scheme)
+	if err != nil {
+		emitMaybe(emit, Err("run", ErrorObject{
+			Code:       "EXECUTABLE_PRODUCT_REQUIRED",
+			Message:    "No executable product to run",
+			Detail:     err.Error(),
+			Suggestion: "Pick an executable product as the scheme.",
+		}))
+		return RunResult{}, cfg, err
+	}
+	emitMaybe(emit, Status("run", "Resolved destination", destinationMetadata(cfg.Destination)))
+
+	// Build the product being run, then go back to the configured scheme.
+	scheme := cfg.Scheme
+	cfg.Scheme = product
+	buildRes, cfg, err := Build(ctx, projectRoot, cfg, emit)
+	cfg.Scheme = scheme
+	if err != nil || cfg.Xcodebuild.DryRun {
+		if cfg.Xcodebuild.DryRun {
+			emitMaybe(emit, Status("run", "Dry run enabled; skipping launch", nil))
+		}
+		return RunResult{Target: string(cfg.Destination.Kind)}, cfg, err
+	}
+	execPath := buildRes.AppPath
+	if execPath == "" {
+		err := fmt.Errorf("could not locate the built %s executable", product)
+		emitMaybe(emit, Err("run", ErrorObject{
+			Code:       "APP_EXECUTABLE_MISSING",
+			Message:    "Executable not found",
+			Detail:     err.Error(),
+			Suggestion: "Run `swift build --show-bin-path` in the package directory.",
+		}))
+		return RunResult{}, cfg, err
+	}
+	if _, statErr := os.Stat(execPath); statErr != nil {
+		emitMaybe(emit, Err("run", ErrorObject{
+			Code:       "APP_EXECUTABLE_MISSING",
+			Message:    "Executable not found",
+			Detail:     statErr.Error(),
+			Suggestion: "Clean and rebuild the package.",
+		}))
+		return RunResult{}, cfg, statErr
+	}
+
+	if err := runBeforeLaunch(ctx, opts, emit); err != nil {
+		return RunResult{}, cfg, err
+	}
+	launchEnv := consoleLaunchEnv(cfg, console)
+	emitMaybe(emit, Status("run", "Launching executable", map[string]any{"executable": execPath}))
+	if console {
+		// Stay attached and stream the tool's output until it exits.
+		res, err := RunStreaming(ctx, CmdSpec{
+			Path:       execPath,
+			Args:       cfg.Launch.Options,
+			Dir:        projectRoot,
+			Env:        launchEnv,
+			StdoutLine: func(s string) { emitMaybe(emit, LogStream("run", s, "app")) },
+			StderrLine: func(s string) { emitMaybe(emit, LogStream("run", s, "app")) },
+		})
+		if errors.Is(err, context.Canceled) {
+			emitMaybe(emit, Status("run", "Run canceled", map[string]any{"product": product}))
+			return RunResult{}, cfg, err
+		}
+		emitMaybe(emit, Status("run", "App exited", map[string]any{"pid": res.PID, "product": product, "exitCode": res.ExitCode}))
+		emitMaybe(emit, Result("run", true, map[string]any{"pid": res.PID, "product": product, "exitCode": res.ExitCode}))
+		return RunResult{AppPath: execPath, BundleID: product, PID: res.PID, Target: string(cfg.Destination.Kind)}, cfg, nil
+	}
+
+	cmd := exec.Command(execPath, cfg.Launch.Options...)
+	cmd.Dir = projectRoot
+	cmd.Env = mergeEnv(os.Environ(), launchEnv)
+	if err := cmd.Start(); err != nil {
+		emitMaybe(emit, Err("run", ErrorObject{
+			Code:       "MAC_LAUNCH_FAILED",
+			Message:    "Failed to launch executable",
+			Detail:     err.Error(),
+			Suggestion: "Check the executable and its permissions.",
+		}))
+		return RunResult{}, cfg, err
+	}
+	if ctx.Err() != nil {
+		_ = cmd.Process.Kill()
+		_, _ = cmd.Process.Wait()
+		return RunResult{}, cfg, ctx.Err()
+	}
+	pid := cmd.Process.Pid
+	_ = cmd.Process.Release()
+	_, _ = addSession(projectRoot, product, pid, cfg.Destination, emit)
+	emitMaybe(emit, Status("run", "Running", map[string]any{"pid": pid, "product": product}))
+	emitMaybe(emit, Result("run", true, map[string]any{"pid": pid, "product": product}))
+	return RunResult{AppPath: execPath, BundleID: product, PID: pid, Target: string(cfg.Destination.Kind)}, cfg, nil
+}
+
+// swiftPackageContext fills in the schemes and configurations of a pure
+// Swift package during context discovery.
+func swiftPackageContext(ctx context.Context, projectRoot string, cfg Config, emit Emitter) (SwiftPackage, Config, error) {
+	emitMaybe(emit, Status("context", "Reading Swift package", map[string]any{"path": filepath.Join(projectRoot, "Package.swift")}))
+	describeCtx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+	pkg, err := DescribeSwiftPackage(describeCtx, projectRoot)
+	if err != nil {
+		return SwiftPackage{}, cfg, err
+	}
+	return pkg, withSwiftPackageDefaults(pkg, cfg, emit), nil
+}
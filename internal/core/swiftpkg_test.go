@@ -0,0 +1,181 @@
+package core
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const describeFixture = `warning: 'tool': found 1 file(s) which are unhandled
+{
+  "name" : "Tool",
+  "path" : "/src/Tool",
+  "products" : [
+    {"name" : "tool", "targets" : ["tool"], "type" : {"executable" : null}},
+    {"name" : "ToolKit", "targets" : ["ToolKit"], "type" : {"library" : ["automatic"]}}
+  ],
+  "targets" : [
+    {"name" : "ToolKitTests", "type" : "test"},
+    {"name" : "ToolKit", "type" : "library"},
+    {"name" : "tool", "type" : "executable"}
+  ]
+}`
+
+func TestParseSwiftPackageDescribe(t *testing.T) {
+	pkg, err := parseSwiftPackageDescribe(describeFixture)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if pkg.Name != "Tool" {
+		t.Fatalf("name = %q", pkg.Name)
+	}
+	if got, want := pkg.Schemes(), []string{"ToolKit", "ToolKitTests", "tool", "Tool-Package"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("schemes = %v, want %v", got, want)
+	}
+	if got := pkg.DefaultScheme(); got != "tool" {
+		t.Fatalf("default scheme = %q", got)
+	}
+	if _, err := pkg.runProduct("ToolKit"); err == nil {
+		t.Fatal("running a library product should fail")
+	}
+	if got, err := pkg.runProduct("Tool-Package"); err != nil || got != "tool" {
+		t.Fatalf("runProduct(package) = %q, %v", got, err)
+	}
+}
+
+func TestSwiftPackageArgs(t *testing.T) {
+	pkg, _ := parseSwiftPackageDescribe(describeFixture)
+	cases := []struct {
+		scheme string
+		want   []string
+	}{
+		{"tool", []string{"build", "-c", "release", "--product", "tool"}},
+		{"ToolKitTests", []string{"build", "-c", "release", "--build-tests"}},
+		{"Tool-Package", []string{"build", "-c", "release"}},
+	}
+	for _, c := range cases {
+		cfg := Config{Scheme: c.scheme, Configuration: "Release"}
+		if got := swiftBuildArgs(pkg, cfg); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("swiftBuildArgs(%s) = %v, want %v", c.scheme, got, c.want)
+		}
+	}
+
+	cfg := Config{Scheme: "ToolKitTests"}
+	if got, want := swiftTestArgs(pkg, cfg, nil, nil), []string{"test", "-c", "debug", "--filter", "ToolKitTests"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("swiftTestArgs = %v, want %v", got, want)
+	}
+	got := swiftTestArgs(pkg, cfg, []string{"ToolKitTests/ParserTests/testEmpty"}, []string{"ToolKitTests/SlowTests"})
+	want := []string{"test", "-c", "debug", "--filter", "ToolKitTests.ParserTests/testEmpty", "--skip", "ToolKitTests.SlowTests"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("swiftTestArgs(only) = %v, want %v", got, want)
+	}
+}
+
+func TestWithSwiftPackageDefaultsUsesMyMac(t *testing.T) {
+	pkg, _ := parseSwiftPackageDescribe(describeFixture)
+	cfg := withSwiftPackageDefaults(pkg, Config{Scheme: "Missing", Configuration: "Staging", Destination: Destination{Kind: DestSimulator, UDID: "ABC"}}, nil)
+	if cfg.Scheme != "tool" || cfg.Configuration != "Debug" {
+		t.Fatalf("scheme/configuration = %q/%q", cfg.Scheme, cfg.Configuration)
+	}
+	if cfg.Destination.Kind != DestMacOS || cfg.Destination.Name != "My Mac" || cfg.Destination.UDID != "" {
+		t.Fatalf("destination = %+v", cfg.Destination)
+	}
+}
+
+func TestIsSwiftPackageRoot(t *testing.T) {
+	root := t.TempDir()
+	if isSwiftPackageRoot(root, Config{}) {
+		t.Fatal("empty dir is not a package")
+	}
+	if err := os.WriteFile(filepath.Join(root, "Package.swift"), []byte("// swift-tools-version:5.9\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if !isSwiftPackageRoot(root, Config{}) {
+		t.Fatal("Package.swift alone should be a package root")
+	}
+	if isSwiftPackageRoot(root, Config{Project: "App.xcodeproj"}) {
+		t.Fatal("a configured project takes precedence")
+	}
+	if err := os.Mkdir(filepath.Join(root, "App.xcodeproj"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if isSwiftPackageRoot(root, Config{}) {
+		t.Fatal("a package next to an Xcode project is built with xcodebuild")
+	}
+}
+
+func TestSwiftBuildLogSinkNormalizesOutput(t *testing.T) {
+	rec := &recordingEmitter{}
+	sink := newSwiftBuildLogSink(context.Background(), "build", Config{}, rec)
+	for _, line := range []string{
+		"Fetching https://github.com/apple/swift-argument-parser",
+		"Fetched https://github.com/apple/swift-argument-parser from cache (0.42s)",
+		"Building for debugging...",
+		"[1/6] Compiling ToolKit Parser.swift",
+		"[2/6] Compiling ToolKit Lexer.swift",
+		"[5/6] Linking tool",
+		"Build complete! (3.10s)",
+	} {
+		sink.HandleLine(line)
+	}
+	sink.Finalize(nil, 0)
+
+	if got := sink.CompiledFiles(); got != 2 {
+		t.Fatalf("compiled files = %d, want 2", got)
+	}
+	var logs []string
+	for _, ev := range rec.events {
+		if ev.Type == "log" {
+			logs = append(logs, ev.Msg)
+		}
+	}
+	want := []string{
+		"SwiftPM: Fetching 1 package(s) (swift-argument-parser)",
+		"Fetched https://github.com/apple/swift-argument-parser from cache (0.42s)",
+		"Building for debugging...",
+		"Compiling ToolKit Parser.swift",
+		"Compiling ToolKit Lexer.swift",
+		"Linking tool",
+		"Build complete! (3.10s)",
+	}
+	if !reflect.DeepEqual(logs, want) {
+		t.Fatalf("logs = %q, want %q", logs, want)
+	}
+}
+
+func TestSwiftTestTally(t *testing.T) {
+	var tally swiftTestTally
+	for _, line := range []string{
+		"Test Suite 'All tests' started at 2026-10-15 10:00:00.000.",
+		"Test Case '-[ToolKitTests.ParserTests testEmpty]' started.",
+		"Test Case '-[ToolKitTests.ParserTests testEmpty]' passed (0.001 seconds).",
+		"Test Case '-[ToolKitTests.ParserTests testNested]' failed (0.002 seconds).",
+		"Test Case '-[ToolKitTests.ParserTests testUnicode]' skipped (0.000 seconds).",
+		"Executed 3 tests, with 1 failure (0 unexpected) in 0.003 (0.004) seconds",
+		"◇ Test lexesNumbers() started.",
+		"✘ Test lexesNumbers() recorded an issue at LexerTests.swift:9:5: Expectation failed: 1 == 2",
+		"✘ Test lexesNumbers() failed after 0.001 seconds with 1 issue.",
+		"✔ Test lexesIdentifiers() passed after 0.001 seconds.",
+		"➜ Test lexesEmoji() skipped.",
+		"✘ Test run with 3 tests failed after 0.002 seconds with 1 issue.",
+	} {
+		tally.observe(line)
+	}
+	got := tally.Summary()
+	if got.Total != 6 || got.Passed != 2 || got.Failed != 2 || got.Skipped != 2 {
+		t.Fatalf("counts = %+v", got)
+	}
+	if want := []string{"ToolKitTests/ParserTests/testNested", "lexesNumbers()"}; !reflect.DeepEqual(got.FailedTests, want) {
+		t.Fatalf("failed tests = %v, want %v", got.FailedTests, want)
+	}
+}
+
+func TestValidateInitConfigAcceptsSwiftPackage(t *testing.T) {
+	info := ContextInfo{Package: "Tool", Schemes: []string{"tool", "Tool-Package"}, Configurations: SwiftPackageConfigurations}
+	cfg := Config{Scheme: "tool", Configuration: "Debug", Destination: myMacDestination()}
+	if _, err := ValidateInitConfig(info, cfg, localDestinationCandidates()); err != nil {
+		t.Fatalf("ValidateInitConfig: %v", err)
+	}
+}
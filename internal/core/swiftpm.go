@@ -0,0 +1,179 @@
+package core
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ResolvedPackage is a SwiftPM dependency pinned by package resolution.
+type ResolvedPackage struct {
+	Name     string `json:"name"`
+	Version  string `json:"version,omitempty"`
+	Branch   string `json:"branch,omitempty"`
+	Revision string `json:"revision,omitempty"`
+	URL      string `json:"url,omitempty"`
+}
+
+// Pin returns the version, or branch/revision for unversioned pins.
+func (p ResolvedPackage) Pin() string {
+	switch {
+	case p.Version != "":
+		return p.Version
+	case p.Branch != "":
+		return p.Branch
+	case len(p.Revision) > 7:
+		return p.Revision[:7]
+	}
+	return p.Revision
+}
+
+// SwiftPMProgressCode marks per-package resolution progress events.
+const SwiftPMProgressCode = "SWIFTPM_PROGRESS"
+
+// SwiftPMProgress reports package resolution progress. The event carries no
+// message so text output keeps the batched "SwiftPM: …" summaries.
+func SwiftPMProgress(cmd, action, pkg string, fetched, total int) Event {
+	ev := Status(cmd, "", map[string]any{"action": action, "package": pkg, "fetched": fetched, "total": total})
+	ev.Code = SwiftPMProgressCode
+	return ev
+}
+
+var (
+	// "Fetching from https://github.com/apple/swift-log.git" / "Cloning https://…"
+	spmStartRE = regexp.MustCompile(`^(?:Fetching|Cloning|Updating)(?: from)? (\S+)`)
+	// "Fetched https://github.com/apple/swift-log.git from cache (0.52s)" / "Cloned https://…"
+	spmDoneRE = regexp.MustCompile(`^(?:Fetched|Cloned|Updated) (\S+)`)
+	// "  swift-log: https://github.com/apple/swift-log.git @ 1.5.4"
+	spmResolvedRE = regexp.MustCompile(`^\s+([^\s:]+): (\S+) @ (\S+)\s*$`)
+)
+
+// packageTracker follows SwiftPM output in xcodebuild logs.
+type packageTracker struct {
+	started    map[string]bool
+	done       map[string]bool
+	inResolved bool
+	resolved   []ResolvedPackage
+}
+
+// observe updates the tracker from one log line. It returns a progress event
+// when a package started or finished fetching.
+func (t *packageTracker) observe(cmd, line string) (Event, bool) {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "Resolved source packages:" {
+		t.inResolved = true
+		t.resolved = nil
+		return Event{}, false
+	}
+	if t.inResolved {
+		if m := spmResolvedRE.FindStringSubmatch(line); m != nil {
+			t.resolved = append(t.resolved, ResolvedPackage{Name: m[1], URL: m[2], Version: m[3]})
+			return Event{}, false
+		}
+		t.inResolved = false
+	}
+
+	if t.started == nil {
+		t.started = map[string]bool{}
+		t.done = map[string]bool{}
+	}
+	if m := spmDoneRE.FindStringSubmatch(trimmed); m != nil {
+		name := repoNameFromURL(m[1])
+		t.started[name] = true
+		if t.done[name] {
+			return Event{}, false
+		}
+		t.done[name] = true
+		return SwiftPMProgress(cmd, "Fetched", name, len(t.done), len(t.started)), true
+	}
+	if m := spmStartRE.FindStringSubmatch(trimmed); m != nil {
+		name := repoNameFromURL(m[1])
+		if t.started[name] {
+			return Event{}, false
+		}
+		t.started[name] = true
+		return SwiftPMProgress(cmd, "Fetching", name, len(t.done), len(t.started)), true
+	}
+	return Event{}, false
+}
+
+// ResolvedPackages returns the package list from the last "Resolved source
+// packages:" block, if any.
+func (t *packageTracker) ResolvedPackages() []ResolvedPackage {
+	return t.resolved
+}
+
+// packageResolvedPaths lists where Xcode keeps Package.resolved for cfg.
+func packageResolvedPaths(projectRoot string, cfg Config) []string {
+	paths := []string{}
+	if cfg.Workspace != "" {
+		paths = append(paths, filepath.Join(absJoin(projectRoot, cfg.Workspace), "xcshareddata", "swiftpm", "Package.resolved"))
+	}
+	if cfg.Project != "" {
+		paths = append(paths, filepath.Join(absJoin(projectRoot, cfg.Project), "project.xcworkspace", "xcshareddata", "swiftpm", "Package.resolved"))
+	}
+	return append(paths, filepath.Join(projectRoot, "Package.resolved"))
+}
+
+// LoadResolvedPackages reads the Package.resolved that applies to cfg.
+// It returns nil when the project has no SwiftPM dependencies.
+func LoadResolvedPackages(projectRoot string, cfg Config) ([]ResolvedPackage, error) {
+	for _, p := range packageResolvedPaths(projectRoot, cfg) {
+		b, err := os.ReadFile(p)
+		if err != nil {
+			continue
+		}
+		return parsePackageResolved(b)
+	}
+	return nil, nil
+}
+
+type packageResolvedState struct {
+	Version  string `json:"version"`
+	Branch   string `json:"branch"`
+	Revision string `json:"revision"`
+}
+
+// parsePackageResolved handles v1 ({"object":{"pins":[…]}}) and v2/v3
+// ({"pins":[…]}) Package.resolved files.
+func parsePackageResolved(b []byte) ([]ResolvedPackage, error) {
+	var doc struct {
+		Pins []struct {
+			Identity string               `json:"identity"`
+			Location string               `json:"location"`
+			State    packageResolvedState `json:"state"`
+		} `json:"pins"`
+		Object struct {
+			Pins []struct {
+				Package       string               `json:"package"`
+				RepositoryURL string               `json:"repositoryURL"`
+				State         packageResolvedState `json:"state"`
+			} `json:"pins"`
+		} `json:"object"`
+	}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+	out := []ResolvedPackage{}
+	for _, p := range doc.Pins {
+		out = append(out, ResolvedPackage{Name: p.Identity, URL: p.Location, Version: p.State.Version, Branch: p.State.Branch, Revision: p.State.Revision})
+	}
+	for _, p := range doc.Object.Pins {
+		out = append(out, ResolvedPackage{Name: p.Package, URL: p.RepositoryURL, Version: p.State.Version, Branch: p.State.Branch, Revision: p.State.Revision})
+	}
+	sort.SliceStable(out, func(i, j int) bool { return strings.ToLower(out[i].Name) < strings.ToLower(out[j].Name) })
+	return out, nil
+}
+
+// resolvedPackagesFor prefers the list printed by xcodebuild and falls back
+// to Package.resolved.
+func resolvedPackagesFor(projectRoot string, cfg Config, sink *logSink) []ResolvedPackage {
+	if pkgs := sink.ResolvedPackages(); len(pkgs) > 0 {
+		return pkgs
+	}
+	pkgs, _ := LoadResolvedPackages(projectRoot, cfg)
+	return pkgs
+}
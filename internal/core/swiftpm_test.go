@@ -0,0 +1,102 @@
+package core
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLogSinkReportsSwiftPMProgress(t *testing.T) {
+	rec := &recordingEmitter{}
+	sink := newXcodebuildLogSink(context.Background(), "build", Config{Xcodebuild: XcodebuildConfig{LogFormat: "raw"}}, rec)
+
+	for _, line := range []string{
+		"Resolve Package Graph",
+		"Fetching from https://github.com/apple/swift-log.git",
+		"Fetching from https://github.com/apple/swift-argument-parser.git",
+		"Fetched https://github.com/apple/swift-log.git from cache (0.52s)",
+		"Fetched https://github.com/apple/swift-argument-parser.git (1.20s)",
+		"Resolved source packages:",
+		"  swift-log: https://github.com/apple/swift-log.git @ 1.5.4",
+		"  swift-argument-parser: https://github.com/apple/swift-argument-parser.git @ 1.3.0",
+		"",
+		"CompileSwift normal arm64 /src/App.swift",
+	} {
+		sink.HandleLine(line)
+	}
+	sink.Finalize(nil, 0)
+
+	type step struct {
+		action         string
+		pkg            string
+		fetched, total int
+	}
+	var got []step
+	for _, ev := range rec.events {
+		if ev.Code != SwiftPMProgressCode {
+			continue
+		}
+		if ev.Msg != "" {
+			t.Fatalf("progress events must not carry text output: %+v", ev)
+		}
+		d := ev.Data.(map[string]any)
+		got = append(got, step{d["action"].(string), d["package"].(string), d["fetched"].(int), d["total"].(int)})
+	}
+	want := []step{
+		{"Fetching", "swift-log", 0, 1},
+		{"Fetching", "swift-argument-parser", 0, 2},
+		{"Fetched", "swift-log", 1, 2},
+		{"Fetched", "swift-argument-parser", 2, 2},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("progress = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("progress[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	pkgs := sink.ResolvedPackages()
+	if len(pkgs) != 2 || pkgs[0].Name != "swift-log" || pkgs[0].Version != "1.5.4" || pkgs[1].URL != "https://github.com/apple/swift-argument-parser.git" {
+		t.Fatalf("unexpected resolved packages: %+v", pkgs)
+	}
+}
+
+func TestLoadResolvedPackagesFormats(t *testing.T) {
+	root := t.TempDir()
+	cfg := Config{Project: "App.xcodeproj"}
+
+	if pkgs, err := LoadResolvedPackages(root, cfg); err != nil || pkgs != nil {
+		t.Fatalf("expected no packages without Package.resolved, got %+v %v", pkgs, err)
+	}
+
+	dir := filepath.Join(root, "App.xcodeproj", "project.xcworkspace", "xcshareddata", "swiftpm")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	v2 := `{"pins":[
+		{"identity":"swift-log","location":"https://github.com/apple/swift-log.git","state":{"revision":"e97a6fcb1ab07462881ac165fdbb37f067e205d5","version":"1.5.4"}},
+		{"identity":"Alamofire","location":"https://github.com/Alamofire/Alamofire.git","state":{"branch":"main","revision":"0123456789abcdef"}}
+	],"version":2}`
+	if err := os.WriteFile(filepath.Join(dir, "Package.resolved"), []byte(v2), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	pkgs, err := LoadResolvedPackages(root, cfg)
+	if err != nil {
+		t.Fatalf("load v2: %v", err)
+	}
+	if len(pkgs) != 2 || pkgs[0].Name != "Alamofire" || pkgs[0].Pin() != "main" || pkgs[1].Pin() != "1.5.4" {
+		t.Fatalf("unexpected v2 packages: %+v", pkgs)
+	}
+
+	v1 := `{"object":{"pins":[{"package":"Nimble","repositoryURL":"https://github.com/Quick/Nimble","state":{"branch":null,"revision":"abcdef1234567","version":null}}]},"version":1}`
+	pkgs, err = parsePackageResolved([]byte(v1))
+	if err != nil {
+		t.Fatalf("parse v1: %v", err)
+	}
+	if len(pkgs) != 1 || pkgs[0].Name != "Nimble" || pkgs[0].Pin() != "abcdef1" {
+		t.Fatalf("unexpected v1 packages: %+v", pkgs)
+	}
+}
@@ -0,0 +1,164 @@
+package core
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TargetBuildInfo is what one target did in a build. A target that compiled
+// or linked nothing was up to date (cached).
+type TargetBuildInfo struct {
+	Name    string `json:"name"`
+	Project string `json:"project,omitempty"`
+	// Compiled is set when the target compiled sources or linked.
+	Compiled  bool `json:"compiled"`
+	FileCount int  `json:"fileCount"`
+	// Duration spans the target's first to last build step.
+	Duration time.Duration `json:"duration"`
+}
+
+var (
+	// Old build system: "=== BUILD TARGET App OF PROJECT App WITH CONFIGURATION Debug ==="
+	buildTargetMarkerRE = regexp.MustCompile(`^=== (?:BUILD|ANALYZE|CLEAN)(?: AGGREGATE)? TARGET (.+?) OF PROJECT (.+?) WITH`)
+	// xcbeautify's rendering of the same marker
+	prettyBuildTargetRE = regexp.MustCompile(`^(?:▸\s*)?(?:Build|Analyze|Clean)(?: aggregate)? target (.+?)(?: of project (.+?))?(?: with configuration .*)?$`)
+	// New build system dependency graph note, one line per target:
+	// "Target 'App' in project 'App'"
+	graphTargetRE = regexp.MustCompile(`^\s*Target '([^']+)' in project '([^']+)'`)
+	// New build system steps end with the target they belong to
+	stepTargetRE = regexp.MustCompile(`\(in target '([^']+)' from project '([^']+)'\)\s*$`)
+	// xcbeautify steps are prefixed with the target, e.g. "[App] Compiling A.swift"
+	prettyStepRE = regexp.MustCompile(`^\[([^\]]+)\] (\S+)`)
+	// SwiftPM's "[3/12] Compiling ..." progress, not a target
+	progressCounterRE = regexp.MustCompile(`^\d+/\d+$`)
+	// Raw link steps
+	linkStepRE = regexp.MustCompile(`^(?:Ld|Libtool) `)
+	// Raw steps of the old build system, attributed to the current target
+	rawStepRE = regexp.MustCompile(`^[A-Z][A-Za-z]+ \S`)
+)
+
+// targetTracker collects per-target work from xcodebuild output, raw or
+// formatted by xcbeautify. Targets are listed from the dependency graph
+// note (or the old build system's BUILD TARGET markers), and those that
+// never compile or link are reported as cached.
+type targetTracker struct {
+	now     func() time.Time
+	targets map[string]*trackedTarget
+	order   []string
+	current string // Old build system: the target being built
+}
+
+type trackedTarget struct {
+	info        TargetBuildInfo
+	first, last time.Time
+}
+
+func (t *targetTracker) observe(line string) {
+	if m := buildTargetMarkerRE.FindStringSubmatch(line); m != nil {
+		t.target(m[1], m[2])
+		t.current = m[1]
+		return
+	}
+	if m := prettyBuildTargetRE.FindStringSubmatch(line); m != nil {
+		t.target(m[1], m[2])
+		t.current = m[1]
+		return
+	}
+	if m := graphTargetRE.FindStringSubmatch(line); m != nil {
+		t.target(m[1], m[2])
+		return
+	}
+	if m := stepTargetRE.FindStringSubmatch(line); m != nil {
+		t.step(t.target(m[1], m[2]), isCompileStep(line), linkStepRE.MatchString(line))
+		return
+	}
+	if m := prettyStepRE.FindStringSubmatch(line); m != nil && !progressCounterRE.MatchString(m[1]) {
+		t.step(t.target(m[1], ""), m[2] == "Compiling", m[2] == "Linking")
+		return
+	}
+	if t.current != "" && rawStepRE.MatchString(line) && !strings.HasPrefix(line, "Check dependencies") {
+		t.step(t.targets[t.current], isCompileStep(line), linkStepRE.MatchString(line))
+	}
+}
+
+// target returns the tracked target name, adding it on first sight
+func (t *targetTracker) target(name, project string) *trackedTarget {
+	if tt, ok := t.targets[name]; ok {
+		if tt.info.Project == "" {
+			tt.info.Project = project
+		}
+		return tt
+	}
+	if t.targets == nil {
+		t.targets = make(map[string]*trackedTarget)
+	}
+	tt := &trackedTarget{info: TargetBuildInfo{Name: name, Project: project}}
+	t.targets[name] = tt
+	t.order = append(t.order, name)
+	return tt
+}
+
+func (t *targetTracker) step(tt *trackedTarget, compile, link bool) {
+	at := time.Now()
+	if t.now != nil {
+		at = t.now()
+	}
+	if tt.first.IsZero() {
+		tt.first = at
+	}
+	tt.last = at
+	if compile {
+		tt.info.FileCount++
+	}
+	if compile || link {
+		tt.info.Compiled = true
+	}
+}
+
+// Targets returns the targets seen: those that compiled first, slowest
+// first, then the cached ones in build order.
+func (t *targetTracker) Targets() []TargetBuildInfo {
+	if len(t.order) == 0 {
+		return nil
+	}
+	out := make([]TargetBuildInfo, 0, len(t.order))
+	for _, name := range t.order {
+		tt := t.targets[name]
+		info := tt.info
+		info.Duration = tt.last.Sub(tt.first)
+		out = append(out, info)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Compiled != out[j].Compiled {
+			return out[i].Compiled
+		}
+		if out[i].Compiled && out[i].Duration != out[j].Duration {
+			return out[i].Duration > out[j].Duration
+		}
+		return false
+	})
+	return out
+}
+
+// ParseTargetBuilds summarizes per-target work from a build log, raw
+// xcodebuild output or xcbeautify's. Durations need live output and are 0.
+func ParseTargetBuilds(lines []string) []TargetBuildInfo {
+	t := targetTracker{now: func() time.Time { return time.Unix(0, 0) }}
+	for _, line := range lines {
+		t.observe(line)
+	}
+	return t.Targets()
+}
+
+// CompiledTargets counts the targets that did work.
+func CompiledTargets(targets []TargetBuildInfo) int {
+	n := 0
+	for _, t := range targets {
+		if t.Compiled {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,101 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestParseTargetBuildsRaw(t *testing.T) {
+	lines := []string{
+		"note: Building targets in dependency order",
+		"note: Target dependency graph (3 targets)",
+		"    Target 'Core' in project 'Core'",
+		"    Target 'Feed' in project 'App'",
+		"    ➜ Explicit dependency on target 'Core' in project 'Core'",
+		"    Target 'App' in project 'App'",
+		"WriteAuxiliaryFile /dd/App.build/App.SwiftFileList (in target 'App' from project 'App')",
+		"SwiftCompile normal arm64 /src/App/AppView.swift (in target 'App' from project 'App')",
+		"SwiftCompile normal arm64 /src/App/Model.swift (in target 'App' from project 'App')",
+		"Ld /dd/App.app/App normal (in target 'App' from project 'App')",
+		"CodeSign /dd/Feed.framework (in target 'Feed' from project 'App')",
+	}
+	got := ParseTargetBuilds(lines)
+	want := []TargetBuildInfo{
+		{Name: "App", Project: "App", Compiled: true, FileCount: 2},
+		{Name: "Core", Project: "Core"},
+		{Name: "Feed", Project: "App"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %+v", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("target %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+	if n := CompiledTargets(got); n != 1 {
+		t.Fatalf("CompiledTargets = %d", n)
+	}
+}
+
+func TestParseTargetBuildsOldMarkersAndXcbeautify(t *testing.T) {
+	raw := ParseTargetBuilds([]string{
+		"=== BUILD TARGET Core OF PROJECT Core WITH CONFIGURATION Debug ===",
+		"Check dependencies",
+		"=== BUILD TARGET App OF PROJECT App WITH CONFIGURATION Debug ===",
+		"Check dependencies",
+		"CompileC /dd/main.o /src/main.m normal arm64 objective-c",
+		"    cd /src",
+	})
+	if len(raw) != 2 || raw[0].Name != "App" || !raw[0].Compiled || raw[0].FileCount != 1 || raw[1].Name != "Core" || raw[1].Compiled {
+		t.Fatalf("raw = %+v", raw)
+	}
+
+	pretty := ParseTargetBuilds([]string{
+		"Build target Core of project Core with configuration Debug",
+		"Build target App of project App with configuration Debug",
+		"[App] Compiling AppView.swift",
+		"[App] Compiling Model.swift",
+		"[App] Linking App",
+		"[3/12] Compiling Foo Foo.swift",
+	})
+	if len(pretty) != 2 || pretty[0].Name != "App" || pretty[0].FileCount != 2 || pretty[0].Project != "App" || pretty[1].Name != "Core" || pretty[1].Compiled {
+		t.Fatalf("pretty = %+v", pretty)
+	}
+}
+
+func TestTargetTrackerOrdersBuiltBySlowest(t *testing.T) {
+	clock := time.Unix(0, 0)
+	tr := targetTracker{now: func() time.Time { return clock }}
+	step := func(target, line string, after time.Duration) {
+		clock = clock.Add(after)
+		tr.observe(line + " (in target '" + target + "' from project 'P')")
+	}
+	tr.observe("    Target 'Cached' in project 'P'")
+	step("Fast", "SwiftCompile normal arm64 /src/A.swift", 0)
+	step("Slow", "SwiftCompile normal arm64 /src/B.swift", 0)
+	step("Fast", "Ld /dd/Fast normal", time.Second)
+	step("Slow", "Ld /dd/Slow normal", 3*time.Second)
+
+	got := tr.Targets()
+	if len(got) != 3 || got[0].Name != "Slow" || got[1].Name != "Fast" || got[2].Name != "Cached" {
+		t.Fatalf("order = %+v", got)
+	}
+	if got[0].Duration != 4*time.Second || got[1].Duration != time.Second || got[2].Duration != 0 {
+		t.Fatalf("durations = %+v", got)
+	}
+}
+
+func TestLogSinkCollectsTargets(t *testing.T) {
+	sink := newXcodebuildLogSink(context.Background(), "build", Config{Xcodebuild: XcodebuildConfig{LogFormat: "raw"}}, &recordingEmitter{})
+	sink.HandleLine("    Target 'Core' in project 'Core'")
+	sink.HandleLine("SwiftCompile normal arm64 /src/App.swift (in target 'App' from project 'App')")
+	targets := sink.Targets()
+	if len(targets) != 2 || targets[0].Name != "App" || targets[0].FileCount != 1 || targets[1].Compiled {
+		t.Fatalf("targets = %+v", targets)
+	}
+	if sink.CompiledFiles() != 1 {
+		t.Fatalf("compiled = %d", sink.CompiledFiles())
+	}
+}
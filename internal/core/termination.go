@@ -0,0 +1,145 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// AppTerminatedCode marks the status event sent when the app of a console
+// run ends. Data has pid, bundleId, exitCode, and reason (a Termination
+// constant), plus signal and detail (the log line the reason was read
+// from) when known.
+const AppTerminatedCode = "APP_TERMINATED"
+
+// Reasons a console run's app ended (RunResult.TerminationReason).
+const (
+	TerminationCleanExit         = "clean-exit"
+	TerminationUncaughtException = "uncaught-exception"
+	TerminationWatchdog          = "watchdog"
+	TerminationJetsam            = "jetsam"
+	TerminationCrash             = "crash"
+	TerminationUnknown           = "unknown"
+)
+
+// TerminationLabel describes a termination reason for humans, e.g.
+// "watchdog timeout".
+func TerminationLabel(reason string) string {
+	switch reason {
+	case TerminationCleanExit:
+		return "clean exit"
+	case TerminationUncaughtException:
+		return "uncaught exception"
+	case TerminationWatchdog:
+		return "watchdog timeout"
+	case TerminationJetsam:
+		return "jetsam (memory limit)"
+	case TerminationCrash:
+		return "crash"
+	}
+	return "unknown reason"
+}
+
+// TerminationSummary reports how an app ended, e.g. "App exited cleanly
+// (exit code 0)" or "App terminated: watchdog timeout (exit code 9)".
+func TerminationSummary(reason string, exitCode int) string {
+	if reason == TerminationCleanExit {
+		return fmt.Sprintf("App exited cleanly (exit code %d)", exitCode)
+	}
+	return fmt.Sprintf("App terminated: %s (exit code %d)", TerminationLabel(reason), exitCode)
+}
+
+// terminationPatterns classify the app's last words and the system's
+// termination messages; the specific reasons are checked before crash.
+// Watchdog and jetsam match system phrasing only, since apps log about
+// their own watchdogs and memory limits.
+var terminationPatterns = []struct {
+	reason string
+	re     *regexp.Regexp
+}{
+	{TerminationUncaughtException, regexp.MustCompile(`(?i)terminating (app )?due to uncaught exception`)},
+	{TerminationWatchdog, regexp.MustCompile(`(?i)8badf00d|\bwatchdog (timeout|transgression|event)|failed to (scene-)?(launch|resume|suspend|terminate) in time|exhausted real \(wall clock\) time allowance`)},
+	{TerminationJetsam, regexp.MustCompile(`(?i)\bjetsam (event|kill)|killed by jetsam|memorystatus: .*kill|per-process-limit|EXC_RESOURCE.*MEMORY`)},
+	{TerminationCrash, regexp.MustCompile(`(?i)\bFatal error:|EXC_BAD_ACCESS|EXC_BAD_INSTRUCTION|EXC_BREAKPOINT|EXC_CRASH|terminated due to signal|Thread \d+: signal SIG`)},
+}
+
+// crashSignals end a process because it faulted rather than being told to
+var crashSignals = map[string]bool{"SIGSEGV": true, "SIGBUS": true, "SIGILL": true, "SIGTRAP": true, "SIGABRT": true, "SIGFPE": true}
+
+// terminationWatcher reads a console run's output and unified logs for why
+// the app ended. It is safe for concurrent use.
+type terminationWatcher struct {
+	mu     sync.Mutex
+	reason string
+	detail string
+}
+
+// observe classifies line. The latest specific reason wins, since the
+// cause comes last; a crash only fills in when nothing specific was seen.
+func (w *terminationWatcher) observe(line string) {
+	for _, p := range terminationPatterns {
+		if !p.re.MatchString(line) {
+			continue
+		}
+		w.mu.Lock()
+		if w.reason == "" || p.reason != TerminationCrash {
+			w.reason, w.detail = p.reason, strings.TrimSpace(line)
+		}
+		w.mu.Unlock()
+		return
+	}
+}
+
+// emitter observes the log lines emitted through it
+func (w *terminationWatcher) emitter(next Emitter) Emitter {
+	return terminationEmitter{w: w, next: next}
+}
+
+type terminationEmitter struct {
+	w    *terminationWatcher
+	next Emitter
+}
+
+func (e terminationEmitter) Emit(ev Event) {
+	if ev.Type == "log" {
+		e.w.observe(ev.Msg)
+	}
+	emitMaybe(e.next, ev)
+}
+
+// classify names the reason the app ended with exitCode, or was killed by
+// signal. A zero exit is clean; otherwise what the logs said decides, else
+// a crash signal, else the reason is unknown.
+func (w *terminationWatcher) classify(exitCode int, signal string) (reason, detail string) {
+	if exitCode == 0 && signal == "" {
+		return TerminationCleanExit, ""
+	}
+	w.mu.Lock()
+	reason, detail = w.reason, w.detail
+	w.mu.Unlock()
+	switch {
+	case reason != "":
+		return reason, detail
+	case crashSignals[signal]:
+		return TerminationCrash, ""
+	}
+	return TerminationUnknown, ""
+}
+
+// emitAppTerminated reports how the app of a console run ended
+// (AppTerminatedCode) and returns the reason
+func emitAppTerminated(emit Emitter, w *terminationWatcher, res CmdResult, pid int, bundleID string) string {
+	reason, detail := w.classify(res.ExitCode, res.Signal)
+	data := map[string]any{"pid": pid, "bundleId": bundleID, "exitCode": res.ExitCode, "reason": reason}
+	if res.Signal != "" {
+		data["signal"] = res.Signal
+	}
+	if detail != "" {
+		data["detail"] = detail
+	}
+	ev := Status("run", TerminationSummary(reason, res.ExitCode), data)
+	ev.Code = AppTerminatedCode
+	emitMaybe(emit, ev)
+	return reason
+}
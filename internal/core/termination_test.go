@@ -0,0 +1,73 @@
+package core
+
+import "testing"
+
+func TestTerminationWatcherClassify(t *testing.T) {
+	cases := []struct {
+		name     string
+		lines    []string
+		exitCode int
+		signal   string
+		want     string
+	}{
+		{"clean exit", []string{"memory limit warning from the app"}, 0, "", TerminationCleanExit},
+		{"uncaught exception", []string{
+			"*** Terminating app due to uncaught exception 'NSInvalidArgumentException', reason: 'boom'",
+			"Thread 1: signal SIGABRT",
+		}, 134, "", TerminationUncaughtException},
+		{"exception after the abort line", []string{
+			"Thread 1: signal SIGABRT",
+			"libc++abi: terminating due to uncaught exception of type NSException",
+		}, 134, "", TerminationUncaughtException},
+		{"watchdog", []string{"runningboardd: Process exhausted real (wall clock) time allowance of 20.00 seconds"}, 9, "", TerminationWatchdog},
+		{"jetsam", []string{"kernel: memorystatus: killing_specific_process pid 4242 [App] (per-process-limit 2048MB)"}, 9, "", TerminationJetsam},
+		{"benign lines before the cause", []string{
+			"Cache memory limit set to 512MB",
+			"Watchdog: monitoring the main thread",
+			"scene-update watchdog transgression: app exhausted real (wall clock) time allowance of 10.00 seconds",
+		}, 9, "", TerminationWatchdog},
+		{"latest specific reason wins", []string{
+			"runningboardd: Process exhausted real (wall clock) time allowance of 20.00 seconds",
+			"*** Terminating app due to uncaught exception 'NSInternalInconsistencyException', reason: 'late'",
+			"Thread 1: signal SIGABRT",
+		}, 134, "", TerminationUncaughtException},
+		{"app logs are not jetsam", []string{"ImageCache: memory limit reached, evicting", "App/Model.swift:12: Fatal error: Index out of range"}, 133, "", TerminationCrash},
+		{"swift fatal error", []string{"App/Model.swift:12: Fatal error: Index out of range"}, 133, "", TerminationCrash},
+		{"crash signal", nil, 0, "SIGSEGV", TerminationCrash},
+		{"killed", nil, 137, "", TerminationUnknown},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			w := &terminationWatcher{}
+			for _, line := range c.lines {
+				w.observe(line)
+			}
+			if got, _ := w.classify(c.exitCode, c.signal); got != c.want {
+				t.Fatalf("reason = %q, want %q", got, c.want)
+			}
+		})
+	}
+}
+
+func TestEmitAppTerminated(t *testing.T) {
+	w := &terminationWatcher{}
+	w.emitter(nil).Emit(LogStream("run", "AppName[4242] *** Terminating app due to uncaught exception 'X'", "unified"))
+
+	rec := &recordingEmitter{}
+	reason := emitAppTerminated(rec, w, CmdResult{ExitCode: 1}, 4242, "com.example.App")
+	got := rec.events
+	if reason != TerminationUncaughtException || len(got) != 1 {
+		t.Fatalf("reason %q, events %+v", reason, got)
+	}
+	ev := got[0]
+	data := ev.Data.(map[string]any)
+	if ev.Code != AppTerminatedCode || data["exitCode"] != 1 || data["reason"] != reason || data["detail"] == nil {
+		t.Fatalf("event = %+v", ev)
+	}
+	if ev.Msg != "App terminated: uncaught exception (exit code 1)" {
+		t.Fatalf("msg = %q", ev.Msg)
+	}
+	if s := TerminationSummary(TerminationCleanExit, 0); s != "App exited cleanly (exit code 0)" {
+		t.Fatalf("summary = %q", s)
+	}
+}
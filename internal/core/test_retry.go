@@ -0,0 +1,150 @@
+package core
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// minRetryXcodeMajor is the first Xcode with -retry-tests-on-failure.
+const minRetryXcodeMajor = 13
+
+// xcodeSupportsTestRetry reports whether the selected Xcode accepts
+// -retry-tests-on-failure. An unreadable version counts as unsupported.
+func xcodeSupportsTestRetry(ctx context.Context) bool {
+	x, err := XcodeInfo(ctx)
+	if err != nil {
+		return false
+	}
+	return x.Major() >= minRetryXcodeMajor
+}
+
+// testRetryArgs returns the xcodebuild flags for in-place retries; the
+// iteration count includes the first attempt.
+func testRetryArgs(retries int) []string {
+	if retries <= 0 {
+		return nil
+	}
+	return []string{"-retry-tests-on-failure", "-test-iterations", strconv.Itoa(retries + 1)}
+}
+
+// flakyTestsFromTree returns test cases in a `test-results tests` tree that
+// passed overall after at least one failed repetition.
+func flakyTestsFromTree(raw any) []string {
+	var out []string
+	walkTestCases(raw, func(bundle, suite string, node map[string]any) {
+		if result, _ := node["result"].(string); !strings.EqualFold(result, "Passed") {
+			return
+		}
+		children, _ := node["children"].([]any)
+		for _, c := range children {
+			child, ok := c.(map[string]any)
+			if !ok {
+				continue
+			}
+			nodeType, _ := child["nodeType"].(string)
+			result, _ := child["result"].(string)
+			if nodeType == "Repetition" && strings.EqualFold(result, "Failed") {
+				out = append(out, testCaseLabel(suite, node))
+				return
+			}
+		}
+	})
+	return out
+}
+
+// failedTestsFromTree returns failing test cases as -only-testing
+// identifiers ("<Target>/<Suite>/<test>").
+func failedTestsFromTree(raw any) []string {
+	var out []string
+	walkTestCases(raw, func(bundle, suite string, node map[string]any) {
+		if result, _ := node["result"].(string); !strings.EqualFold(result, "Failed") {
+			return
+		}
+		name, _ := node["name"].(string)
+		parts := []string{}
+		for _, p := range []string{bundle, suite, strings.TrimSuffix(name, "()")} {
+			if p != "" {
+				parts = append(parts, p)
+			}
+		}
+		out = append(out, strings.Join(parts, "/"))
+	})
+	return out
+}
+
+// failedTestsFromLegacy reads issues.testFailureSummaries from a legacy
+// ActionsInvocationRecord as -only-testing identifiers.
+func failedTestsFromLegacy(raw any) []string {
+	obj, _ := raw.(map[string]any)
+	issues, _ := obj["issues"].(map[string]any)
+	summaries, _ := issues["testFailureSummaries"].(map[string]any)
+	values, _ := summaries["_values"].([]any)
+	seen := map[string]bool{}
+	var out []string
+	for _, v := range values {
+		f, _ := v.(map[string]any)
+		name := legacyString(f["testCaseName"]) // "LoginTests.testSSO()"
+		target := legacyString(f["producingTarget"])
+		if name == "" {
+			continue
+		}
+		name = strings.TrimSuffix(name, "()")
+		name = strings.Replace(name, ".", "/", 1)
+		if target != "" {
+			name = target + "/" + name
+		}
+		if !seen[name] {
+			seen[name] = true
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+func legacyString(v any) string {
+	if m, ok := v.(map[string]any); ok {
+		s, _ := m["_value"].(string)
+		return s
+	}
+	return ""
+}
+
+// walkTestCases calls fn for each "Test Case" node with its enclosing test
+// bundle and suite names.
+func walkTestCases(raw any, fn func(bundle, suite string, node map[string]any)) {
+	obj, ok := raw.(map[string]any)
+	if !ok {
+		return
+	}
+	var walk func(nodes any, bundle, suite string)
+	walk = func(nodes any, bundle, suite string) {
+		list, _ := nodes.([]any)
+		for _, n := range list {
+			node, ok := n.(map[string]any)
+			if !ok {
+				continue
+			}
+			name, _ := node["name"].(string)
+			switch nodeType, _ := node["nodeType"].(string); nodeType {
+			case "Test Case":
+				fn(bundle, suite, node)
+			case "Test Suite":
+				walk(node["children"], bundle, name)
+			case "Unit test bundle", "UI test bundle":
+				walk(node["children"], name, suite)
+			default:
+				walk(node["children"], bundle, suite)
+			}
+		}
+	}
+	walk(obj["testNodes"], "", "")
+}
+
+func testCaseLabel(suite string, node map[string]any) string {
+	name, _ := node["name"].(string)
+	if suite != "" {
+		return suite + "/" + name
+	}
+	return name
+}
@@ -0,0 +1,48 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestXcodeVersionMajor(t *testing.T) {
+	tests := map[string]int{
+		"Xcode 16.1\nBuild version 16B40":    16,
+		"Xcode 12.5.1\nBuild version 12E507": 12,
+		"xcode-select: error":                0,
+	}
+	for in, want := range tests {
+		version, _ := parseXcodeVersion(in)
+		if got := (XcodeVersion{Version: version}).Major(); got != want {
+			t.Fatalf("XcodeVersion{%q}.Major() = %d, want %d", version, got, want)
+		}
+	}
+}
+
+func TestTestRetryArgs(t *testing.T) {
+	if got := testRetryArgs(0); got != nil {
+		t.Fatalf("expected no args without retries, got %v", got)
+	}
+	want := []string{"-retry-tests-on-failure", "-test-iterations", "3"}
+	if got := testRetryArgs(2); !reflect.DeepEqual(got, want) {
+		t.Fatalf("testRetryArgs(2) = %v, want %v", got, want)
+	}
+}
+
+func TestFlakyAndFailedTestsFromTree(t *testing.T) {
+	tree := loadJSONFixture(t, "xcresult_tests_retry.json")
+	if got, want := flakyTestsFromTree(tree), []string{"OnboardingUITests/testSwipeThrough()"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("flakyTestsFromTree = %v, want %v", got, want)
+	}
+	if got, want := failedTestsFromTree(tree), []string{"MyAppUITests/OnboardingUITests/testSkipButton"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("failedTestsFromTree = %v, want %v", got, want)
+	}
+}
+
+func TestFailedTestsFromLegacy(t *testing.T) {
+	got := failedTestsFromLegacy(loadJSONFixture(t, "xcresult_legacy_skips.json"))
+	want := []string{"MyAppTests/CheckoutTests/testCheckoutTotals"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("failedTestsFromLegacy = %v, want %v", got, want)
+	}
+}
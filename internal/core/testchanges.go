@@ -0,0 +1,179 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// TestChanges compares a test run's failures with the previous run of the
+// same scheme. Entries are TestKey identifiers.
+type TestChanges struct {
+	// NewFailures passed (or didn't fail) in the previous run
+	NewFailures []string `json:"newFailures"`
+	// Fixed failed in the previous run and passed in this one
+	Fixed []string `json:"fixed"`
+	// StillFailing failed in both runs
+	StillFailing []string `json:"stillFailing"`
+}
+
+// Any reports whether a failure appeared or went away
+func (c TestChanges) Any() bool {
+	return len(c.NewFailures) > 0 || len(c.Fixed) > 0
+}
+
+// Line renders the changes as "2 new failures, 1 fixed, 3 still failing"
+func (c TestChanges) Line() string {
+	var parts []string
+	switch n := len(c.NewFailures); n {
+	case 0:
+	case 1:
+		parts = append(parts, "1 new failure")
+	default:
+		parts = append(parts, fmt.Sprintf("%d new failures", n))
+	}
+	if n := len(c.Fixed); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d fixed", n))
+	}
+	if n := len(c.StillFailing); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d still failing", n))
+	}
+	if len(parts) == 0 {
+		return "none since the last run"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// TestKey is the identifier a failing test is compared by across runs:
+// "<Target>/<Suite>/<test>" without the "()" or argument labels of the test
+// function and without the arguments of a parameterized case, so every
+// retry and argument set of a test maps to one key.
+func TestKey(id string) string {
+	id = strings.TrimSpace(id)
+	dir, name := "", id
+	if i := strings.LastIndex(id, "/"); i >= 0 {
+		// Arguments may contain slashes; the test name is the component
+		// before the first parenthesis.
+		if j := strings.Index(id, "("); j >= 0 && j < i {
+			i = strings.LastIndex(id[:j], "/")
+		}
+		dir, name = id[:i+1], id[i+1:]
+	}
+	if j := strings.Index(name, "("); j > 0 {
+		name = name[:j]
+	}
+	return dir + name
+}
+
+// failingTestKeys returns the run's failing tests as sorted, unique keys;
+// tests that passed on retry don't count.
+func failingTestKeys(summary TestSummary) []string {
+	flaky := map[string]bool{}
+	for _, t := range summary.FlakyTests {
+		flaky[TestKey(t)] = true
+	}
+	var keys []string
+	for _, t := range summary.FailedTests {
+		if k := TestKey(t); k != "" && !flaky[k] {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+	return slices.Compact(keys)
+}
+
+// testFilterRuns reports whether a test key was selected by -only-testing
+// and -skip-testing identifiers (a target, suite, or test each)
+func testFilterRuns(onlyTesting, skipTesting []string) func(key string) bool {
+	covers := func(ids []string, key string) bool {
+		for _, id := range ids {
+			id = TestKey(id)
+			if key == id || strings.HasPrefix(key, id+"/") {
+				return true
+			}
+		}
+		return false
+	}
+	return func(key string) bool {
+		if len(onlyTesting) > 0 && !covers(onlyTesting, key) {
+			return false
+		}
+		return !covers(skipTesting, key)
+	}
+}
+
+// compareTestFailures sorts failing into new and still-failing tests and
+// finds the previous failures that ran and passed. It returns the failures
+// to remember: failing plus the previous ones that didn't run.
+func compareTestFailures(previous, failing []string, ran func(key string) bool) (TestChanges, []string) {
+	var c TestChanges
+	for _, k := range failing {
+		if slices.Contains(previous, k) {
+			c.StillFailing = append(c.StillFailing, k)
+		} else {
+			c.NewFailures = append(c.NewFailures, k)
+		}
+	}
+	remembered := slices.Clone(failing)
+	for _, k := range previous {
+		switch {
+		case slices.Contains(failing, k):
+		case ran(k):
+			c.Fixed = append(c.Fixed, k)
+		default:
+			remembered = append(remembered, k)
+		}
+	}
+	slices.Sort(remembered)
+	return c, remembered
+}
+
+// RecordTestFailures stores the failing tests of a finished run of a
+// project's scheme and compares them with the previous run. ran reports
+// whether a test was selected in this run; unselected previous failures are
+// kept as they were. ok is false for the first recorded run.
+func (st *State) RecordTestFailures(projectRoot, scheme string, failing []string, ran func(key string) bool) (TestChanges, bool) {
+	key := SchemeKey(projectRoot, scheme)
+	previous, ok := st.TestFailures[key]
+	changes, remembered := compareTestFailures(previous, failing, ran)
+	if st.TestFailures == nil {
+		st.TestFailures = make(map[string][]string)
+	}
+	if remembered == nil {
+		remembered = []string{}
+	}
+	st.TestFailures[key] = remembered
+	return changes, ok
+}
+
+// recordTestChanges remembers a complete test run's failures in the user
+// state and returns how they changed since the previous run (nil for the
+// first run, or when the run can't be compared).
+func recordTestChanges(projectRoot string, cfg Config, onlyTesting, skipTesting []string, summary TestSummary, err error) *TestChanges {
+	if cfg.Scheme == "" || cfg.Xcodebuild.DryRun || CancelStatus(err) != "" || summary.Partial || !summary.HasCounts() {
+		return nil
+	}
+	if summary.Failed > 0 && len(summary.FailedTests) == 0 {
+		// Failures without names can't be compared
+		return nil
+	}
+	st, loadErr := LoadState()
+	if loadErr != nil {
+		return nil
+	}
+	changes, ok := st.RecordTestFailures(projectRoot, cfg.Scheme, failingTestKeys(summary), testFilterRuns(onlyTesting, skipTesting))
+	if saveErr := SaveState(st); saveErr != nil || !ok {
+		return nil
+	}
+	return &changes
+}
+
+// withTestChanges adds the comparison with the previous run to a test
+// result's data
+func withTestChanges(data map[string]any, changes *TestChanges) map[string]any {
+	if changes == nil {
+		return data
+	}
+	data["changes"] = changes
+	return data
+}
@@ -0,0 +1,106 @@
+package core
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestTestKeyIgnoresArgumentsAndParens(t *testing.T) {
+	cases := map[string]string{
+		"AppTests/LoginTests/testSSO":                     "AppTests/LoginTests/testSSO",
+		"AppTests/LoginTests/testSSO()":                   "AppTests/LoginTests/testSSO",
+		" AppTests/MathTests/addition(a:b:) ":             "AppTests/MathTests/addition",
+		"AppTests/MathTests/addition(a:b:) (1, 2)":        "AppTests/MathTests/addition",
+		`AppTests/PathTests/resolves(path:) ("/tmp/a/b")`: "AppTests/PathTests/resolves",
+		"checkout()": "checkout",
+	}
+	for in, want := range cases {
+		if got := TestKey(in); got != want {
+			t.Errorf("TestKey(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestFailingTestKeysDropsFlakyAndRetries(t *testing.T) {
+	summary := TestSummary{
+		Failed: 4,
+		FailedTests: []string{
+			"App/MathTests/addition(a:b:) (1, 2)",
+			"App/MathTests/addition(a:b:) (3, 4)",
+			"App/LoginTests/testSSO",
+			"App/SyncTests/testUpload",
+		},
+		FlakyTests: []string{"App/SyncTests/testUpload()"},
+	}
+	want := []string{"App/LoginTests/testSSO", "App/MathTests/addition"}
+	if got := failingTestKeys(summary); !reflect.DeepEqual(got, want) {
+		t.Fatalf("failingTestKeys = %q, want %q", got, want)
+	}
+}
+
+func TestRecordTestFailuresComparesWithPreviousRun(t *testing.T) {
+	var st State
+	all := testFilterRuns(nil, nil)
+	if _, ok := st.RecordTestFailures("/proj", "App", []string{"App/A/a", "App/B/b"}, all); ok {
+		t.Fatal("first run has nothing to compare with")
+	}
+
+	changes, ok := st.RecordTestFailures("/proj", "App", []string{"App/B/b", "App/C/c"}, all)
+	if !ok {
+		t.Fatal("second run should compare")
+	}
+	want := TestChanges{NewFailures: []string{"App/C/c"}, Fixed: []string{"App/A/a"}, StillFailing: []string{"App/B/b"}}
+	if !reflect.DeepEqual(changes, want) {
+		t.Fatalf("changes = %+v, want %+v", changes, want)
+	}
+	if got := changes.Line(); got != "1 new failure, 1 fixed, 1 still failing" {
+		t.Fatalf("Line() = %q", got)
+	}
+	if _, ok := st.RecordTestFailures("/proj", "Other", nil, all); ok {
+		t.Fatal("failures leaked to another scheme")
+	}
+}
+
+func TestRecordTestFailuresKeepsTestsThatDidNotRun(t *testing.T) {
+	var st State
+	all := testFilterRuns(nil, nil)
+	st.RecordTestFailures("/proj", "App", []string{"App/LoginTests/testSSO", "App/SyncTests/testUpload"}, all)
+
+	onlyLogin := testFilterRuns([]string{"App/LoginTests"}, nil)
+	changes, _ := st.RecordTestFailures("/proj", "App", nil, onlyLogin)
+	if !reflect.DeepEqual(changes.Fixed, []string{"App/LoginTests/testSSO"}) {
+		t.Fatalf("fixed = %q", changes.Fixed)
+	}
+
+	changes, _ = st.RecordTestFailures("/proj", "App", nil, all)
+	if !reflect.DeepEqual(changes.Fixed, []string{"App/SyncTests/testUpload"}) {
+		t.Fatalf("a test outside the filter should still count as failing, fixed = %q", changes.Fixed)
+	}
+}
+
+func TestRecordTestChangesPersistsPerScheme(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	cfg := Config{Scheme: "App"}
+	first := TestSummary{Total: 2, Passed: 1, Failed: 1, FailedTests: []string{"App/A/a()"}}
+	if changes := recordTestChanges("/proj", cfg, nil, nil, first, errors.New("tests failed")); changes != nil {
+		t.Fatalf("first run returned %+v", changes)
+	}
+	canceled := TestSummary{Total: 1, Passed: 1, Partial: true}
+	if changes := recordTestChanges("/proj", cfg, nil, nil, canceled, nil); changes != nil {
+		t.Fatalf("partial run returned %+v", changes)
+	}
+
+	second := TestSummary{Total: 2, Passed: 2}
+	changes := recordTestChanges("/proj", cfg, nil, nil, second, nil)
+	if changes == nil || !reflect.DeepEqual(changes.Fixed, []string{"App/A/a"}) || changes.Line() != "1 fixed" {
+		t.Fatalf("changes = %+v", changes)
+	}
+	st, err := LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := st.TestFailures[SchemeKey("/proj", "App")]; got == nil || len(got) != 0 {
+		t.Fatalf("stored failures = %#v", got)
+	}
+}
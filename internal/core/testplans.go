@@ -0,0 +1,88 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// XcodebuildTestPlans lists the scheme's test plans via
+// `xcodebuild -showTestPlans -json`. Schemes without test plans return nil.
+func XcodebuildTestPlans(ctx context.Context, projectRoot string, cfg Config) ([]string, error) {
+	if cfg.Scheme == "" {
+		return nil, errors.New("no scheme configured")
+	}
+	args := []string{"xcodebuild", "-showTestPlans", "-json", "-scheme", cfg.Scheme}
+	if cfg.Workspace != "" {
+		args = append(args, "-workspace", filepath.Join(projectRoot, cfg.Workspace))
+	} else if cfg.Project != "" {
+		args = append(args, "-project", filepath.Join(projectRoot, cfg.Project))
+	}
+
+	var out strings.Builder
+	_, err := RunStreaming(ctx, CmdSpec{
+		Path:           "xcrun",
+		Args:           args,
+		PreserveLocale: cfg.Xcodebuild.PreserveLocale,
+		Dir:            projectRoot,
+		StdoutLine: func(s string) {
+			out.WriteString(s)
+			out.WriteString("\n")
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return parseTestPlans(out.String())
+}
+
+// parseTestPlans reads `{"name": "<scheme>", "testPlans": [{"name": …}]}`.
+func parseTestPlans(out string) ([]string, error) {
+	trim := extractJSONObject(out)
+	if trim == "" {
+		trim = out
+	}
+	var parsed struct {
+		TestPlans []struct {
+			Name string `json:"name"`
+		} `json:"testPlans"`
+	}
+	if err := json.Unmarshal([]byte(trim), &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse xcodebuild -showTestPlans -json output: %w", err)
+	}
+	var plans []string
+	for _, p := range parsed.TestPlans {
+		if p.Name != "" {
+			plans = append(plans, p.Name)
+		}
+	}
+	return plans, nil
+}
+
+// missingTestPlanError checks whether a failed test run was caused by
+// cfg.Test.Plan no longer existing in the scheme. It returns nil when the
+// plan exists or the plans can't be listed.
+func missingTestPlanError(ctx context.Context, projectRoot string, cfg Config) *ErrorObject {
+	plans, err := XcodebuildTestPlans(ctx, projectRoot, cfg)
+	if err != nil {
+		return nil
+	}
+	for _, p := range plans {
+		if p == cfg.Test.Plan {
+			return nil
+		}
+	}
+	available := "none"
+	if len(plans) > 0 {
+		available = strings.Join(plans, ", ")
+	}
+	return &ErrorObject{
+		Code:       "TEST_PLAN_NOT_FOUND",
+		Message:    fmt.Sprintf("Test plan %q not found in scheme %q", cfg.Test.Plan, cfg.Scheme),
+		Detail:     "Available test plans: " + available,
+		Suggestion: "Re-select a plan (palette: Switch Test Plan, or `xcbolt test --test-plan <name>`), or clear test.plan in .xcbolt/config.json.",
+	}
+}
@@ -0,0 +1,50 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestParseTestPlans(t *testing.T) {
+	out := `2024-05-01 12:00:00.000 xcodebuild[123:456] Note: some preamble
+{
+  "name" : "App",
+  "testPlans" : [
+    { "name" : "Unit" },
+    { "name" : "UI" },
+    { "name" : "Smoke" }
+  ]
+}`
+	plans, err := parseTestPlans(out)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if strings.Join(plans, ",") != "Unit,UI,Smoke" {
+		t.Fatalf("plans = %v", plans)
+	}
+
+	if plans, err := parseTestPlans(`{"name":"App","testPlans":[]}`); err != nil || plans != nil {
+		t.Fatalf("expected no plans, got %v %v", plans, err)
+	}
+}
+
+func TestDryRunTestPassesPlan(t *testing.T) {
+	rec := &recordingEmitter{}
+	cfg := Config{
+		Project:           "App.xcodeproj",
+		Scheme:            "App",
+		Configuration:     "Debug",
+		ResultBundlesPath: t.TempDir(),
+		Destination:       Destination{Kind: DestSimulator, UDID: "SIM-1", Platform: "iOS Simulator"},
+		Xcodebuild:        XcodebuildConfig{DryRun: true},
+		Test:              TestConfig{Plan: "Smoke"},
+	}
+	res, _, err := Test(context.Background(), t.TempDir(), cfg, nil, nil, rec)
+	if err != nil {
+		t.Fatalf("dry-run test: %v", err)
+	}
+	if !strings.Contains(res.Command, "-testPlan Smoke") || !strings.HasSuffix(res.Command, " test \"-AppleLanguages=(en)\" -AppleLocale=en_US") {
+		t.Fatalf("unexpected command: %q", res.Command)
+	}
+}
@@ -0,0 +1,146 @@
+package core
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// maxTestSourceFiles bounds FindTestSource on large trees.
+const maxTestSourceFiles = 20000
+
+// testSourceSkipDirs are never searched for test sources, nor are hidden
+// directories and bundles with testSourceSkipExts.
+var (
+	testSourceSkipDirs = map[string]bool{
+		"DerivedData":  true,
+		"Pods":         true,
+		"Carthage":     true,
+		"node_modules": true,
+	}
+	testSourceSkipExts = map[string]bool{
+		".xcodeproj":   true,
+		".xcworkspace": true,
+		".xcassets":    true,
+		".xcresult":    true,
+		".framework":   true,
+		".xcframework": true,
+	}
+)
+
+// TestSourceMatch is where a test is declared. ClassOnly marks the test
+// class's declaration, found when the method itself wasn't.
+type TestSourceMatch struct {
+	File      string `json:"file"`
+	Line      int    `json:"line"`
+	ClassOnly bool   `json:"classOnly,omitempty"`
+}
+
+// FindTestSource finds the source of a test that was reported without a
+// file, by searching the Swift and Objective-C files under paths (relative
+// to the project root; default the root) for "func <method>(" or
+// "- (void)<method>". Matches in files declaring class come first and, when
+// there are any, replace the others. Without a method match, it returns the
+// class declaration. The search stops after maxTestSourceFiles files.
+func FindTestSource(ctx context.Context, projectRoot string, paths []string, class, method string) ([]TestSourceMatch, error) {
+	if method == "" && class == "" {
+		return nil, errors.New("no test name")
+	}
+	var methodRE, classRE *regexp.Regexp
+	if method != "" {
+		q := regexp.QuoteMeta(method)
+		methodRE = regexp.MustCompile(`\bfunc\s+` + q + `\s*[(<]|^\s*-\s*\(void\)\s*` + q + `\b`)
+	}
+	if class != "" {
+		q := regexp.QuoteMeta(class)
+		classRE = regexp.MustCompile(`\b(class|struct|actor|enum)\s+` + q + `\b|@implementation\s+` + q + `\b`)
+	}
+
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	var methods, classes []TestSourceMatch
+	inClass := map[string]bool{}
+	seen := 0
+	for _, p := range paths {
+		dir := absJoin(projectRoot, p)
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			name := d.Name()
+			if d.IsDir() {
+				if path != dir && (strings.HasPrefix(name, ".") || testSourceSkipDirs[name] || testSourceSkipExts[filepath.Ext(name)]) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			switch filepath.Ext(name) {
+			case ".swift", ".m", ".mm":
+			default:
+				return nil
+			}
+			if seen++; seen > maxTestSourceFiles {
+				return filepath.SkipAll
+			}
+			scanTestSource(path, methodRE, classRE, func(line int, isClass bool) {
+				if isClass {
+					inClass[path] = true
+					classes = append(classes, TestSourceMatch{File: path, Line: line, ClassOnly: true})
+				} else {
+					methods = append(methods, TestSourceMatch{File: path, Line: line})
+				}
+			})
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(methods) == 0 {
+		return classes, nil
+	}
+	if class != "" {
+		var own []TestSourceMatch
+		for _, m := range methods {
+			if inClass[m.File] {
+				own = append(own, m)
+			}
+		}
+		if len(own) > 0 {
+			methods = own
+		}
+	}
+	sort.SliceStable(methods, func(i, j int) bool { return methods[i].File < methods[j].File })
+	return methods, nil
+}
+
+// scanTestSource reports the lines of path matching methodRE or classRE
+func scanTestSource(path string, methodRE, classRE *regexp.Regexp, found func(line int, isClass bool)) {
+	f, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		text := scanner.Text()
+		if methodRE != nil && methodRE.MatchString(text) {
+			found(n, false)
+		}
+		if classRE != nil && classRE.MatchString(text) {
+			found(n, true)
+		}
+	}
+}
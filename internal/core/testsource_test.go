@@ -0,0 +1,53 @@
+package core
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTestSource(t *testing.T, root, rel, content string) string {
+	t.Helper()
+	path := filepath.Join(root, rel)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestFindTestSource(t *testing.T) {
+	root := t.TempDir()
+	login := writeTestSource(t, root, "AppTests/LoginTests.swift", "import XCTest\n\nfinal class LoginTests: XCTestCase {\n    func testSSO() throws {\n    }\n}\n")
+	other := writeTestSource(t, root, "AppTests/OtherTests.swift", "class OtherTests: XCTestCase {\n  func testSSO() {}\n}\n")
+	legacy := writeTestSource(t, root, "LegacyTests/CartTests.m", "@implementation CartTests\n- (void)testTotal {\n}\n@end\n")
+	writeTestSource(t, root, "DerivedData/Build/LoginTests.swift", "class LoginTests { func testMissing() {} }\n")
+	ctx := context.Background()
+
+	// The test's own class wins over other classes with the same method
+	got, err := FindTestSource(ctx, root, nil, "LoginTests", "testSSO")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0] != (TestSourceMatch{File: login, Line: 4}) {
+		t.Fatalf("unexpected matches %+v", got)
+	}
+
+	// Without a class (Swift Testing) every match is returned
+	if got, _ := FindTestSource(ctx, root, nil, "", "testSSO"); len(got) != 2 || got[0].File != login || got[1].File != other {
+		t.Fatalf("unexpected matches %+v", got)
+	}
+
+	if got, _ := FindTestSource(ctx, root, []string{"LegacyTests"}, "CartTests", "testTotal"); len(got) != 1 || got[0].File != legacy || got[0].Line != 2 {
+		t.Fatalf("unexpected Objective-C matches %+v", got)
+	}
+
+	// A missing method falls back to the class; DerivedData is skipped
+	got, _ = FindTestSource(ctx, root, nil, "LoginTests", "testMissing")
+	if len(got) != 1 || !got[0].ClassOnly || got[0].File != login || got[0].Line != 3 {
+		t.Fatalf("expected the class declaration, got %+v", got)
+	}
+}
@@ -0,0 +1,66 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// UnifiedLogPredicate limits a unified log stream to an app's subsystems
+// (bundleID and bundleID.*) and/or a custom predicate; both may be empty.
+func UnifiedLogPredicate(bundleID, predicate string) string {
+	bundleID = strings.TrimSpace(bundleID)
+	predicate = strings.TrimSpace(predicate)
+	app := ""
+	if bundleID != "" {
+		app = fmt.Sprintf("subsystem == \"%s\" OR subsystem BEGINSWITH \"%s.\"", bundleID, bundleID)
+	}
+	switch {
+	case app == "":
+		return predicate
+	case predicate == "":
+		return app
+	}
+	return "(" + app + ") AND (" + predicate + ")"
+}
+
+// StreamDestinationLogs streams unified logs from dst until ctx is done:
+// simctl for simulators, devicectl for devices, and log(1) for this Mac.
+// Lines are emitted like run's console output (stream "unified" or
+// "system").
+func StreamDestinationLogs(ctx context.Context, dst Destination, predicate string, emit Emitter, preserveLocale bool) error {
+	id := dst.ID
+	if id == "" {
+		id = dst.UDID
+	}
+	switch dst.Kind {
+	case DestSimulator:
+		return SimctlLogStream(ctx, id, predicate, emit, preserveLocale)
+	case DestDevice:
+		return DevicectlLogStream(ctx, id, predicate, emit, preserveLocale)
+	case DestMacOS, DestCatalyst:
+		return MacLogStream(ctx, predicate, emit)
+	}
+	return fmt.Errorf("log streaming is not supported for destination kind %q", dst.Kind)
+}
+
+// MacLogStream streams this Mac's unified logs with `log stream`.
+func MacLogStream(ctx context.Context, predicate string, emit Emitter) error {
+	args := []string{"stream", "--style", "compact", "--level", "debug"}
+	if predicate != "" {
+		args = append(args, "--predicate", predicate)
+	}
+	_, err := RunStreaming(ctx, CmdSpec{
+		Path: "/usr/bin/log",
+		Args: args,
+		StdoutLine: func(s string) {
+			if isSimctlLogHeader(s) {
+				emitMaybe(emit, LogStream("run", s, "system"))
+			} else {
+				emitMaybe(emit, LogStream("run", formatSimctlLogLine(s), "unified"))
+			}
+		},
+		StderrLine: func(s string) { emitMaybe(emit, Log("logs", s)) },
+	})
+	return err
+}
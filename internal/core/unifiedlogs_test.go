@@ -0,0 +1,26 @@
+package core
+
+import (
+	"context"
+	"testing"
+)
+
+func TestUnifiedLogPredicateCombinesBundleAndPredicate(t *testing.T) {
+	cases := []struct{ bundle, pred, want string }{
+		{"", "", ""},
+		{"com.example.Notes", "", `subsystem == "com.example.Notes" OR subsystem BEGINSWITH "com.example.Notes."`},
+		{"", ` category == "sync" `, `category == "sync"`},
+		{"com.example.Notes", `category == "sync"`, `(subsystem == "com.example.Notes" OR subsystem BEGINSWITH "com.example.Notes.") AND (category == "sync")`},
+	}
+	for _, c := range cases {
+		if got := UnifiedLogPredicate(c.bundle, c.pred); got != c.want {
+			t.Fatalf("UnifiedLogPredicate(%q, %q) = %q, want %q", c.bundle, c.pred, got, c.want)
+		}
+	}
+}
+
+func TestStreamDestinationLogsRejectsUnknownKind(t *testing.T) {
+	if err := StreamDestinationLogs(context.Background(), Destination{Kind: "watch"}, "", nil, false); err == nil {
+		t.Fatal("expected an error for an unsupported destination")
+	}
+}
@@ -0,0 +1,105 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// UserCommand is a project command offered in the TUI command palette.
+// Shell runs via /bin/sh -c in the project root.
+type UserCommand struct {
+	Name         string `json:"name"`
+	ID           string `json:"id"`
+	Shell        string `json:"shell"`
+	Description  string `json:"description,omitempty"`
+	NeedsConfirm bool   `json:"needsConfirm,omitempty"`
+}
+
+// Title is Name, or the ID when no name is set.
+func (c UserCommand) Title() string {
+	if c.Name != "" {
+		return c.Name
+	}
+	return c.ID
+}
+
+// UserCommandByID finds the configured command with id.
+func (c Config) UserCommandByID(id string) (UserCommand, bool) {
+	for _, uc := range c.Commands {
+		if uc.ID == id {
+			return uc, true
+		}
+	}
+	return UserCommand{}, false
+}
+
+// ValidateCommands checks that every command has an ID and a shell command,
+// and that IDs are unique and not among reserved (the built-in IDs).
+func ValidateCommands(cmds []UserCommand, reserved []string) error {
+	seen := map[string]bool{}
+	for _, id := range reserved {
+		seen[id] = true
+	}
+	for i, uc := range cmds {
+		switch {
+		case strings.TrimSpace(uc.ID) == "":
+			return fmt.Errorf("commands[%d] (%s): missing id", i, uc.Name)
+		case strings.TrimSpace(uc.Shell) == "":
+			return fmt.Errorf("commands[%d] (%s): missing shell", i, uc.ID)
+		case seen[uc.ID]:
+			for _, id := range reserved {
+				if id == uc.ID {
+					return fmt.Errorf("commands[%d]: id %q is taken by a built-in command", i, uc.ID)
+				}
+			}
+			return fmt.Errorf("commands[%d]: duplicate id %q", i, uc.ID)
+		}
+		seen[uc.ID] = true
+	}
+	return nil
+}
+
+// RunUserCommand runs a user command in the project root, streaming its
+// output as logs. A non-zero exit fails with COMMAND_FAILED.
+func RunUserCommand(ctx context.Context, projectRoot string, cfg Config, uc UserCommand, emit Emitter) error {
+	op := "command"
+	start := time.Now()
+	emitMaybe(emit, Status(op, "Running "+uc.Title(), map[string]any{"id": uc.ID}))
+	emitMaybe(emit, Log(op, fmt.Sprintf("Command %s: %s", uc.ID, uc.Shell)))
+	if cfg.Xcodebuild.DryRun {
+		emitMaybe(emit, Log(op, fmt.Sprintf("Dry run: command %s not executed", uc.ID)))
+		emitMaybe(emit, Result(op, true, map[string]any{"id": uc.ID, "dryRun": true}))
+		return nil
+	}
+	res, err := RunStreaming(ctx, CmdSpec{
+		Path:       "/bin/sh",
+		Args:       []string{"-c", uc.Shell},
+		Dir:        projectRoot,
+		StdoutLine: func(s string) { emitMaybe(emit, Log(op, s)) },
+		StderrLine: func(s string) { emitMaybe(emit, Log(op, s)) },
+	})
+	data := map[string]any{"id": uc.ID, "exitCode": res.ExitCode, "durationMs": time.Since(start).Milliseconds()}
+	if err != nil {
+		if status := CancelStatus(err); status != "" {
+			emitMaybe(emit, Err(op, cancelErrorObject(uc.Title(), err, "")))
+			emitMaybe(emit, Result(op, false, data))
+			return err
+		}
+		detail := err.Error()
+		if res.ExitCode != 0 {
+			detail = fmt.Sprintf("exit status %d", res.ExitCode)
+		}
+		emitMaybe(emit, Err(op, ErrorObject{
+			Code:       "COMMAND_FAILED",
+			Message:    uc.Title() + " failed",
+			Detail:     detail,
+			Suggestion: fmt.Sprintf("Check the output above, or fix commands.%s in .xcbolt/config.json.", uc.ID),
+		}))
+		emitMaybe(emit, Result(op, false, data))
+		return fmt.Errorf("%s failed: %s", uc.ID, detail)
+	}
+	emitMaybe(emit, Result(op, true, data))
+	return nil
+}
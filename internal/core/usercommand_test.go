@@ -0,0 +1,78 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateCommands(t *testing.T) {
+	ok := []UserCommand{{ID: "mocks", Shell: "make mocks"}, {ID: "bump", Shell: "agvtool next-version -all"}}
+	if err := ValidateCommands(ok, []string{"build"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cases := map[string][]UserCommand{
+		"missing id":               {{Name: "Mocks", Shell: "make"}},
+		"missing shell":            {{ID: "mocks"}},
+		`duplicate id "mocks"`:     {{ID: "mocks", Shell: "a"}, {ID: "mocks", Shell: "b"}},
+		`"build" is taken by a bu`: {{ID: "build", Shell: "make"}},
+	}
+	for want, cmds := range cases {
+		err := ValidateCommands(cmds, []string{"build"})
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q, got %v", want, err)
+		}
+	}
+}
+
+func TestLoadConfigRejectsDuplicateCommands(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Dir(ConfigPath(dir)), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	body := fmt.Sprintf(`{"version": %d, "commands": [{"id": "mocks", "shell": "a"}, {"id": "mocks", "shell": "b"}]}`, ConfigVersion)
+	if err := os.WriteFile(ConfigPath(dir), []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadConfig(dir, ""); err == nil || !strings.Contains(err.Error(), "duplicate id") {
+		t.Fatalf("expected a duplicate id error, got %v", err)
+	}
+}
+
+func TestRunUserCommandReportsExitCode(t *testing.T) {
+	rec := &recordingEmitter{}
+	uc := UserCommand{ID: "lint", Name: "Lint", Shell: "echo checking; exit 4"}
+
+	err := RunUserCommand(context.Background(), t.TempDir(), Config{}, uc, rec)
+	if err == nil || !strings.Contains(err.Error(), "exit status 4") {
+		t.Fatalf("expected exit status 4, got %v", err)
+	}
+	var sawHeader, sawOutput, sawErr bool
+	for _, ev := range rec.events {
+		switch {
+		case ev.Type == "log" && ev.Msg == "Command lint: echo checking; exit 4":
+			sawHeader = true
+		case ev.Type == "log" && ev.Msg == "checking":
+			sawOutput = true
+		case ev.Type == "error":
+			sawErr = ev.Err != nil && ev.Err.Code == "COMMAND_FAILED"
+		}
+	}
+	if !sawHeader || !sawOutput || !sawErr {
+		t.Fatalf("missing header, output, or error event: %+v", rec.events)
+	}
+}
+
+func TestRunUserCommandCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	rec := &recordingEmitter{}
+	err := RunUserCommand(ctx, t.TempDir(), Config{}, UserCommand{ID: "slow", Shell: "sleep 5"}, rec)
+	if CancelStatus(err) != CancelStatusCanceled {
+		t.Fatalf("expected a cancel, got %v", err)
+	}
+}
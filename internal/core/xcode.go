@@ -0,0 +1,238 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// XcodeVersion describes the active developer directory.
+type XcodeVersion struct {
+	Version      string `json:"version,omitempty"` // "15.4"
+	Build        string `json:"build,omitempty"`   // "15F31d"
+	DeveloperDir string `json:"developerDir,omitempty"`
+	// CommandLineTools is set when xcode-select points at the Command Line
+	// Tools rather than a full Xcode.app.
+	CommandLineTools bool `json:"commandLineTools,omitempty"`
+}
+
+// Label renders the version for display, e.g. "Xcode 15.4 (15F31d)".
+func (x XcodeVersion) Label() string {
+	switch {
+	case x.CommandLineTools:
+		return "No full Xcode selected"
+	case x.Version == "":
+		return ""
+	case x.Build != "":
+		return fmt.Sprintf("Xcode %s (%s)", x.Version, x.Build)
+	}
+	return "Xcode " + x.Version
+}
+
+// Major returns the major version, or 0 when unknown.
+func (x XcodeVersion) Major() int {
+	v := parseVersionParts(x.Version)
+	if len(v) == 0 {
+		return 0
+	}
+	return v[0]
+}
+
+var (
+	xcodeFullRE  = regexp.MustCompile(`(?m)^Xcode (\d+(?:\.\d+)*)`)
+	xcodeBuildRE = regexp.MustCompile(`(?m)^Build version (\S+)`)
+)
+
+// parseXcodeVersion reads `xcodebuild -version` output:
+//
+//	Xcode 15.4
+//	Build version 15F31d
+func parseXcodeVersion(out string) (version, build string) {
+	if m := xcodeFullRE.FindStringSubmatch(out); m != nil {
+		version = m[1]
+	}
+	if m := xcodeBuildRE.FindStringSubmatch(out); m != nil {
+		build = m[1]
+	}
+	return version, build
+}
+
+// xcodeVersions caches `xcodebuild -version` per developer directory:
+// every build and test checks the active Xcode, and xcodebuild is slow to
+// start. xcode-select -p, which is quick, picks the entry, so DEVELOPER_DIR
+// or xcode-select -s still switch Xcode while xcbolt runs.
+var xcodeVersions struct {
+	sync.Mutex
+	byDir map[string]XcodeVersion
+}
+
+// XcodeInfo reports the selected developer directory and Xcode version.
+// A Command Line Tools-only install is not an error; it sets
+// CommandLineTools instead.
+func XcodeInfo(ctx context.Context) (XcodeVersion, error) {
+	x := XcodeVersion{}
+	dir, err := captureCmd(ctx, "xcode-select", "-p")
+	if err != nil {
+		return x, fmt.Errorf("xcode-select -p: %w", err)
+	}
+	x.DeveloperDir = strings.TrimSpace(dir)
+	if strings.Contains(x.DeveloperDir, "CommandLineTools") {
+		x.CommandLineTools = true
+		return x, nil
+	}
+	xcodeVersions.Lock()
+	cached, ok := xcodeVersions.byDir[x.DeveloperDir]
+	xcodeVersions.Unlock()
+	if ok {
+		return cached, nil
+	}
+	out, err := captureCmd(ctx, "xcrun", "xcodebuild", "-version")
+	if err != nil {
+		if strings.Contains(out, "requires Xcode") || strings.Contains(out, "command line tools instance") {
+			x.CommandLineTools = true
+			return x, nil
+		}
+		return x, fmt.Errorf("xcodebuild -version: %w", err)
+	}
+	x.Version, x.Build = parseXcodeVersion(out)
+	if x.Version == "" {
+		return x, fmt.Errorf("unrecognized xcodebuild -version output: %q", out)
+	}
+	xcodeVersions.Lock()
+	if xcodeVersions.byDir == nil {
+		xcodeVersions.byDir = map[string]XcodeVersion{}
+	}
+	xcodeVersions.byDir[x.DeveloperDir] = x
+	xcodeVersions.Unlock()
+	return x, nil
+}
+
+// CheckRequiredXcode returns an error describing why x does not satisfy
+// the requiredXcode constraint, or nil when it does (or none is set).
+func CheckRequiredXcode(x XcodeVersion, constraint string) error {
+	constraint = strings.TrimSpace(constraint)
+	if constraint == "" {
+		return nil
+	}
+	if x.CommandLineTools {
+		return fmt.Errorf("project requires Xcode %s but no full Xcode is selected", constraint)
+	}
+	if x.Version == "" {
+		return nil // unknown version: nothing to compare
+	}
+	ok, err := versionSatisfies(x.Version, constraint)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("active Xcode %s does not satisfy requiredXcode %q", x.Version, constraint)
+	}
+	return nil
+}
+
+// versionSatisfies checks version against a space- or comma-separated list
+// of constraints, all of which must hold. Supported forms: "15.4" (same
+// version, missing parts match anything), ">=15", ">15.2", "<=16", "<16",
+// "=15.4", "~15.2" (>=15.2, <15.3) and "^15.2" (>=15.2, <16).
+func versionSatisfies(version, constraint string) (bool, error) {
+	v := parseVersionParts(version)
+	for _, term := range strings.FieldsFunc(constraint, func(r rune) bool { return r == ' ' || r == ',' }) {
+		rest := strings.TrimLeft(term, "<>=~^")
+		op := term[:len(term)-len(rest)]
+		want := parseVersionParts(rest)
+		if len(want) == 0 {
+			return false, fmt.Errorf("invalid requiredXcode term %q", term)
+		}
+		cmp := compareVersionParts(v, want)
+		var ok bool
+		switch op {
+		case "", "=", "==":
+			ok = versionPrefixMatch(v, want)
+		case ">=":
+			ok = cmp >= 0
+		case ">":
+			ok = cmp > 0
+		case "<=":
+			ok = cmp <= 0 || versionPrefixMatch(v, want)
+		case "<":
+			ok = cmp < 0
+		case "~":
+			ok = cmp >= 0 && versionPrefixMatch(v, want[:min(len(want), 2)])
+		case "^":
+			ok = cmp >= 0 && versionPrefixMatch(v, want[:1])
+		default:
+			return false, fmt.Errorf("invalid requiredXcode operator %q", op)
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func parseVersionParts(s string) []int {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	parts := []int{}
+	for _, p := range strings.Split(s, ".") {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return nil
+		}
+		parts = append(parts, n)
+	}
+	return parts
+}
+
+// compareVersionParts compares component-wise, treating missing parts as 0.
+func compareVersionParts(a, b []int) int {
+	for i := 0; i < max(len(a), len(b)); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+var osVersionRE = regexp.MustCompile(`\d+(?:\.\d+)*`)
+
+// CompareOSVersions compares OS versions such as "17.5" and "17.10"
+// numerically, so 17.10 sorts after 17.5. The first dotted number in each
+// string is compared (e.g. "iOS 18.0"); strings without one compare as text.
+func CompareOSVersions(a, b string) int {
+	pa := parseVersionParts(osVersionRE.FindString(a))
+	pb := parseVersionParts(osVersionRE.FindString(b))
+	if pa == nil || pb == nil {
+		return strings.Compare(a, b)
+	}
+	return compareVersionParts(pa, pb)
+}
+
+// versionPrefixMatch reports whether v starts with all components of want.
+func versionPrefixMatch(v, want []int) bool {
+	for i, w := range want {
+		x := 0
+		if i < len(v) {
+			x = v[i]
+		}
+		if x != w {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,131 @@
+package core
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseXcodeVersion(t *testing.T) {
+	version, build := parseXcodeVersion("Xcode 15.4\nBuild version 15F31d\n")
+	if version != "15.4" || build != "15F31d" {
+		t.Fatalf("got %q %q", version, build)
+	}
+	x := XcodeVersion{Version: version, Build: build}
+	if got := x.Label(); got != "Xcode 15.4 (15F31d)" {
+		t.Fatalf("unexpected label %q", got)
+	}
+	if x.Major() != 15 {
+		t.Fatalf("expected major 15, got %d", x.Major())
+	}
+	if got := (XcodeVersion{CommandLineTools: true}).Label(); got != "No full Xcode selected" {
+		t.Fatalf("unexpected CLT label %q", got)
+	}
+}
+
+func TestVersionSatisfies(t *testing.T) {
+	cases := []struct {
+		version    string
+		constraint string
+		want       bool
+	}{
+		{"15.4", ">=15.3", true},
+		{"15.2", ">=15.3", false},
+		{"15.4", "15", true},
+		{"15.4", "15.4", true},
+		{"15.4.1", "15.4", true},
+		{"16.0", "15", false},
+		{"15.4", "^15.2", true},
+		{"16.0", "^15.2", false},
+		{"15.2.1", "~15.2", true},
+		{"15.3", "~15.2", false},
+		{"15.4", ">=15, <16", true},
+		{"16.1", ">=15 <16", false},
+		{"16.1", "<=16", true},
+		{"17.0", "<=16", false},
+	}
+	for _, tc := range cases {
+		got, err := versionSatisfies(tc.version, tc.constraint)
+		if err != nil {
+			t.Fatalf("%s %s: unexpected error %v", tc.version, tc.constraint, err)
+		}
+		if got != tc.want {
+			t.Fatalf("%s %s: expected %v", tc.version, tc.constraint, tc.want)
+		}
+	}
+	if _, err := versionSatisfies("15.4", ">=fifteen"); err == nil {
+		t.Fatalf("expected error for invalid constraint")
+	}
+}
+
+func TestCheckRequiredXcode(t *testing.T) {
+	x := XcodeVersion{Version: "15.4", Build: "15F31d"}
+	if err := CheckRequiredXcode(x, ""); err != nil {
+		t.Fatalf("empty constraint should pass: %v", err)
+	}
+	if err := CheckRequiredXcode(x, ">=15"); err != nil {
+		t.Fatalf("expected pass: %v", err)
+	}
+	if err := CheckRequiredXcode(x, ">=16"); err == nil {
+		t.Fatalf("expected mismatch")
+	}
+	if err := CheckRequiredXcode(XcodeVersion{CommandLineTools: true}, ">=15"); err == nil {
+		t.Fatalf("expected CLT-only install to fail the constraint")
+	}
+	if err := CheckRequiredXcode(XcodeVersion{}, ">=15"); err != nil {
+		t.Fatalf("unknown version should not warn: %v", err)
+	}
+}
+
+func TestCompareOSVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"17.10", "17.5", 1},
+		{"17.5", "18.0", -1},
+		{"17", "17.0", 0},
+		{"iOS 18.0", "iOS 17.5", 1},
+		{"", "17.0", -1},
+	}
+	for _, tc := range tests {
+		if got := CompareOSVersions(tc.a, tc.b); got != tc.want {
+			t.Fatalf("CompareOSVersions(%q, %q) = %d, want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestXcodeInfoCachesVersionPerDeveloperDir(t *testing.T) {
+	bin := t.TempDir()
+	calls := filepath.Join(bin, "calls.log")
+	scripts := map[string]string{
+		"xcode-select": `echo "${DEVELOPER_DIR:-/Applications/Xcode.app/Contents/Developer}"`,
+		"xcrun":        "echo xcodebuild >> " + calls + "\necho 'Xcode 16.0'\necho 'Build version 16A242d'",
+	}
+	for name, script := range scripts {
+		if err := os.WriteFile(filepath.Join(bin, name), []byte("#!/bin/sh\n"+script+"\n"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	resetXcodeVersions(t)
+
+	for range 2 {
+		if x, err := XcodeInfo(context.Background()); err != nil || x.Build != "16A242d" {
+			t.Fatalf("XcodeInfo = %+v, %v", x, err)
+		}
+	}
+	t.Setenv("DEVELOPER_DIR", "/Applications/Xcode-beta.app/Contents/Developer")
+	if x, err := XcodeInfo(context.Background()); err != nil || x.DeveloperDir != "/Applications/Xcode-beta.app/Contents/Developer" {
+		t.Fatalf("XcodeInfo = %+v, %v", x, err)
+	}
+	b, err := os.ReadFile(calls)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(b), "xcodebuild"); n != 2 {
+		t.Fatalf("xcodebuild -version ran %d times, want once per developer dir", n)
+	}
+}
@@ -13,7 +13,9 @@ import (
 type XcodeListInfo struct {
 	Schemes        []string `json:"schemes"`
 	Configurations []string `json:"configurations"`
-	Name           string   `json:"name"`
+	// Targets are only listed for projects, not workspaces
+	Targets []string `json:"targets,omitempty"`
+	Name    string   `json:"name"`
 }
 
 type xcodebuildListJSON struct {
@@ -34,9 +36,10 @@ func XcodebuildList(ctx context.Context, projectRoot string, cfg Config, emit Em
 
 	var out strings.Builder
 	res, err := RunStreaming(ctx, CmdSpec{
-		Path: "xcrun",
-		Args: args,
-		Dir:  projectRoot,
+		Path:           "xcrun",
+		Args:           args,
+		PreserveLocale: cfg.Xcodebuild.PreserveLocale,
+		Dir:            projectRoot,
 		StdoutLine: func(s string) {
 			out.WriteString(s)
 			out.WriteString("\n")
@@ -78,6 +81,9 @@ func XcodebuildList(ctx context.Context, projectRoot string, cfg Config, emit Em
 		if len(info.Configurations) == 0 {
 			info.Configurations = parsed.Project.Configurations
 		}
+		if len(info.Targets) == 0 {
+			info.Targets = parsed.Project.Targets
+		}
 	}
 	return info, nil
 }
@@ -122,31 +128,18 @@ type BuildSettings map[string]string
 
 // ShowBuildSettings runs `xcodebuild -showBuildSettings` and returns a map.
 func ShowBuildSettings(ctx context.Context, projectRoot string, cfg Config) (BuildSettings, error) {
-	args := []string{"xcodebuild", "-showBuildSettings"}
-	if cfg.Workspace != "" {
-		args = append(args, "-workspace", filepath.Join(projectRoot, cfg.Workspace))
-	} else if cfg.Project != "" {
-		args = append(args, "-project", filepath.Join(projectRoot, cfg.Project))
-	}
-	if cfg.Scheme != "" {
-		args = append(args, "-scheme", cfg.Scheme)
-	}
-	if cfg.Configuration != "" {
-		args = append(args, "-configuration", cfg.Configuration)
-	}
-	if dest := BuildDestinationString(cfg); dest != "" {
-		args = append(args, "-destination", dest)
-	}
-	if cfg.DerivedDataPath != "" {
-		args = append(args, "-derivedDataPath", cfg.DerivedDataPath)
+	args := append([]string{"xcodebuild", "-showBuildSettings"}, baseXcodebuildArgs(projectRoot, cfg)...)
+	if dir := DerivedDataDir(cfg); dir != "" {
+		args = append(args, "-derivedDataPath", dir)
 	}
 
 	var lines []string
 	_, err := RunStreaming(ctx, CmdSpec{
-		Path:       "xcrun",
-		Args:       args,
-		Dir:        projectRoot,
-		StdoutLine: func(s string) { lines = append(lines, s) },
+		Path:           "xcrun",
+		Args:           args,
+		PreserveLocale: cfg.Xcodebuild.PreserveLocale,
+		Dir:            projectRoot,
+		StdoutLine:     func(s string) { lines = append(lines, s) },
 	})
 	if err != nil {
 		return nil, err
@@ -168,3 +161,20 @@ func ShowBuildSettings(ctx context.Context, projectRoot string, cfg Config) (Bui
 	}
 	return settings, nil
 }
+
+// SchemePlatformFamilies reads SUPPORTED_PLATFORMS for the configured scheme
+// and maps it to platform families (best first). The configured destination
+// is ignored so a stale destination can't make the lookup fail.
+func SchemePlatformFamilies(ctx context.Context, projectRoot string, cfg Config) ([]PlatformFamily, error) {
+	cfg.Destination = Destination{}
+	settings, err := ShowBuildSettings(ctx, projectRoot, cfg)
+	if err != nil {
+		return nil, err
+	}
+	families := PlatformFamiliesFromSupportedPlatforms(settings["SUPPORTED_PLATFORMS"])
+	// iPad-only apps report iphoneos but target device family 2.
+	if len(families) > 0 && families[0] == PlatformIOS && strings.TrimSpace(settings["TARGETED_DEVICE_FAMILY"]) == "2" {
+		families[0] = PlatformIPadOS
+	}
+	return families, nil
+}
@@ -5,11 +5,48 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 )
 
 type TestSummary struct {
 	Raw any `json:"raw"`
+
+	// Counts parsed from Raw; all zero when the format wasn't recognized.
+	Total            int `json:"total"`
+	Passed           int `json:"passed"`
+	Failed           int `json:"failed"`
+	Skipped          int `json:"skipped"`
+	ExpectedFailures int `json:"expectedFailures"`
+
+	// SkippedTests lists skipped test identifiers (e.g. "LoginTests/testSSO()")
+	// when the result bundle exposes the test tree.
+	SkippedTests []string `json:"skippedTests,omitempty"`
+	// FlakyTests passed only after a failed repetition (-retry-tests-on-failure).
+	FlakyTests []string `json:"flakyTests,omitempty"`
+	// FailedTests are -only-testing identifiers ("<Target>/<Suite>/<test>").
+	FailedTests []string `json:"failedTests,omitempty"`
+	// Partial is set when the run was interrupted; counts cover only the
+	// tests that finished.
+	Partial bool `json:"partial,omitempty"`
+}
+
+// HasCounts reports whether test counts were recognized in the summary.
+func (s TestSummary) HasCounts() bool {
+	return s.Total > 0 || s.Passed > 0 || s.Failed > 0 || s.Skipped > 0 || s.ExpectedFailures > 0
+}
+
+// CountsLine renders the counts as "30 passed, 1 failed, 4 skipped".
+func (s TestSummary) CountsLine() string {
+	parts := []string{
+		fmt.Sprintf("%d passed", s.Passed),
+		fmt.Sprintf("%d failed", s.Failed),
+		fmt.Sprintf("%d skipped", s.Skipped),
+	}
+	if s.ExpectedFailures > 0 {
+		parts = append(parts, fmt.Sprintf("%d expected failures", s.ExpectedFailures))
+	}
+	return strings.Join(parts, ", ")
 }
 
 // XcresultTestSummary attempts to extract a structured test summary from an .xcresult bundle.
@@ -18,7 +55,7 @@ func XcresultTestSummary(ctx context.Context, resultBundlePath string) (TestSumm
 		return TestSummary{}, errors.New("missing result bundle path")
 	}
 
-	candidates := [][]string{
+	raw, err := xcresultJSON(ctx, [][]string{
 		// Modern
 		{"xcresulttool", "get", "test-results", "summary", "--path", resultBundlePath, "--format", "json"},
 		// Some versions accept no --format
@@ -26,8 +63,30 @@ func XcresultTestSummary(ctx context.Context, resultBundlePath string) (TestSumm
 		// Fallback: dump top-level
 		{"xcresulttool", "get", "--path", resultBundlePath, "--format", "json"},
 		{"xcresulttool", "get", "--path", resultBundlePath},
+	})
+	if err != nil {
+		return TestSummary{}, err
+	}
+	summary := parseTestSummary(raw)
+	summary.FailedTests = failedTestsFromLegacy(raw)
+
+	// Only the modern test tree carries per-test results; older Xcodes just
+	// keep the counts and failure summaries.
+	if summary.HasCounts() && len(summary.FailedTests) == 0 {
+		if tree, err := xcresultJSON(ctx, [][]string{
+			{"xcresulttool", "get", "test-results", "tests", "--path", resultBundlePath, "--format", "json"},
+		}); err == nil {
+			summary.SkippedTests = skippedTestsFromTree(tree)
+			summary.FlakyTests = flakyTestsFromTree(tree)
+			summary.FailedTests = failedTestsFromTree(tree)
+		}
 	}
+	return summary, nil
+}
 
+// xcresultJSON runs each xcresulttool invocation in turn and returns the first
+// output that parses as JSON.
+func xcresultJSON(ctx context.Context, candidates [][]string) (any, error) {
 	var lastErr error
 	for _, args := range candidates {
 		var out strings.Builder
@@ -52,10 +111,66 @@ func XcresultTestSummary(ctx context.Context, resultBundlePath string) (TestSumm
 			lastErr = fmt.Errorf("xcresult json parse: %w", err)
 			continue
 		}
-		return TestSummary{Raw: anyJSON}, nil
+		return anyJSON, nil
 	}
 	if lastErr == nil {
 		lastErr = errors.New("failed to read xcresult")
 	}
-	return TestSummary{}, lastErr
+	return nil, lastErr
+}
+
+// parseTestSummary reads counts from either the modern `test-results summary`
+// shape (totalTestCount, passedTests, ...) or the legacy
+// ActionsInvocationRecord metrics (testsCount, testsFailedCount, ...).
+func parseTestSummary(raw any) TestSummary {
+	s := TestSummary{Raw: raw}
+	obj, ok := raw.(map[string]any)
+	if !ok {
+		return s
+	}
+	if _, ok := obj["totalTestCount"]; ok {
+		s.Total = jsonInt(obj["totalTestCount"])
+		s.Passed = jsonInt(obj["passedTests"])
+		s.Failed = jsonInt(obj["failedTests"])
+		s.Skipped = jsonInt(obj["skippedTests"])
+		s.ExpectedFailures = jsonInt(obj["expectedFailures"])
+		return s
+	}
+	if metrics, ok := obj["metrics"].(map[string]any); ok {
+		s.Total = jsonInt(metrics["testsCount"])
+		s.Failed = jsonInt(metrics["testsFailedCount"])
+		s.Skipped = jsonInt(metrics["testsSkippedCount"])
+		s.ExpectedFailures = jsonInt(metrics["testsExpectedFailureCount"])
+		s.Passed = s.Total - s.Failed - s.Skipped - s.ExpectedFailures
+		if s.Passed < 0 {
+			s.Passed = 0
+		}
+	}
+	return s
+}
+
+// jsonInt accepts plain numbers and the legacy {"_value": "12"} wrapper.
+func jsonInt(v any) int {
+	switch t := v.(type) {
+	case float64:
+		return int(t)
+	case string:
+		n, _ := strconv.Atoi(t)
+		return n
+	case map[string]any:
+		return jsonInt(t["_value"])
+	}
+	return 0
+}
+
+// skippedTestsFromTree walks a `test-results tests` tree and returns the
+// skipped test cases as "<Suite>/<test>".
+func skippedTestsFromTree(raw any) []string {
+	var out []string
+	walkTestCases(raw, func(_, suite string, node map[string]any) {
+		if result, _ := node["result"].(string); strings.EqualFold(result, "Skipped") {
+			out = append(out, testCaseLabel(suite, node))
+		}
+	})
+	return out
 }
@@ -0,0 +1,73 @@
+package core
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func loadJSONFixture(t *testing.T, name string) any {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	var v any
+	if err := json.Unmarshal(b, &v); err != nil {
+		t.Fatalf("parse fixture: %v", err)
+	}
+	return v
+}
+
+func TestParseTestSummaryModernCountsSkips(t *testing.T) {
+	s := parseTestSummary(loadJSONFixture(t, "xcresult_summary_skips.json"))
+	if s.Total != 37 || s.Passed != 30 || s.Failed != 1 || s.Skipped != 4 || s.ExpectedFailures != 2 {
+		t.Fatalf("unexpected counts: %+v", s)
+	}
+	if got := s.CountsLine(); got != "30 passed, 1 failed, 4 skipped, 2 expected failures" {
+		t.Fatalf("CountsLine = %q", got)
+	}
+}
+
+func TestParseTestSummaryLegacyMetrics(t *testing.T) {
+	s := parseTestSummary(loadJSONFixture(t, "xcresult_legacy_skips.json"))
+	if s.Total != 35 || s.Passed != 30 || s.Failed != 1 || s.Skipped != 4 || s.ExpectedFailures != 0 {
+		t.Fatalf("unexpected counts: %+v", s)
+	}
+}
+
+func TestParseTestSummaryUnknownShape(t *testing.T) {
+	s := parseTestSummary(map[string]any{"issues": map[string]any{}})
+	if s.HasCounts() {
+		t.Fatalf("expected no counts, got %+v", s)
+	}
+}
+
+func TestSkippedTestsFromTree(t *testing.T) {
+	got := skippedTestsFromTree(loadJSONFixture(t, "xcresult_tests_skips.json"))
+	want := []string{
+		"CheckoutTests/testApplePay()",
+		"LoginTests/testSSO()",
+		"LoginTests/testPasskeys()",
+		"ProfileTests/testOfflineBanner()",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("skippedTestsFromTree = %v, want %v", got, want)
+	}
+}
+
+func TestWithTestCountsPayload(t *testing.T) {
+	data := withTestCounts(map[string]any{"exitCode": 0}, TestSummary{Passed: 3, Skipped: 1, Total: 4, SkippedTests: []string{"A/b()"}})
+	if data["skipped"] != 1 || data["passed"] != 3 || data["expectedFailures"] != 0 {
+		t.Fatalf("unexpected payload: %v", data)
+	}
+	if names, _ := data["skippedTests"].([]string); strings.Join(names, ",") != "A/b()" {
+		t.Fatalf("unexpected skipped names: %v", data["skippedTests"])
+	}
+	if data := withTestCounts(map[string]any{}, TestSummary{}); len(data) != 0 {
+		t.Fatalf("expected payload untouched without counts: %v", data)
+	}
+}
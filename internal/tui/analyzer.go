@@ -0,0 +1,66 @@
+package tui
+
+import (
+	"os/exec"
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// =============================================================================
+// Static Analyzer
+// =============================================================================
+
+var (
+	// xcodebuild step header, e.g. "Analyze /p/Foo.m normal arm64 (in target 'App' from project 'App')"
+	stepHeaderRE = regexp.MustCompile(`^(\w+) .*\(in target '[^']*' from project '[^']*'\)\s*$`)
+
+	// Trailing checker name of an analyzer diagnostic ("[deadcode.DeadStores]")
+	analyzerCheckerRE = regexp.MustCompile(`\[(?:alpha|apiModeling|core|cplusplus|deadcode|nullability|optin|osx|security|unix|valist|webkit)\.[\w.]+\]\s*$`)
+)
+
+// analyzerStep reports whether line starts an xcodebuild step, and if so
+// whether that step runs the static analyzer
+func analyzerStep(line string) (analyzing bool, ok bool) {
+	m := stepHeaderRE.FindStringSubmatch(line)
+	if m == nil {
+		return false, false
+	}
+	return strings.HasPrefix(m[1], "Analyze"), true
+}
+
+// isAnalyzerFinding checks if a warning names a static analyzer checker
+func isAnalyzerFinding(line string) bool {
+	return analyzerCheckerRE.MatchString(line)
+}
+
+// analyzerReportPath is what "open report" opens: the only HTML report, or
+// the report directory when there are several ("" when nothing was found)
+func analyzerReportPath(res core.AnalyzeResult) string {
+	switch len(res.Reports) {
+	case 0:
+		return ""
+	case 1:
+		return res.Reports[0]
+	}
+	return res.ReportDir
+}
+
+// openAnalyzerReport opens the last analyzer report in the browser (or the
+// report directory in Finder)
+func (m *Model) openAnalyzerReport() tea.Cmd {
+	path := analyzerReportPath(m.lastAnalyze)
+	if path == "" {
+		m.setStatus("No analyzer report (run Analyze first)")
+		return nil
+	}
+	return func() tea.Msg {
+		if err := exec.Command("open", path).Start(); err != nil {
+			return statusMsg("Failed to open analyzer report")
+		}
+		return statusMsg("Opened analyzer report")
+	}
+}
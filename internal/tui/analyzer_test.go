@@ -0,0 +1,76 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func TestAnalyzerWarningsBecomeAnalyzerIssues(t *testing.T) {
+	tv := NewTabView()
+	tv.SummaryTab.SetRunning("analyze")
+	for _, line := range loadFixtureLines(t, "analyze_output.txt") {
+		tv.AddRawLine(line)
+	}
+	tv.SetBuildResult(BuildStatusSuccess, "4.2s", nil)
+
+	if tv.Counts.AnalyzerCount != 2 || tv.Counts.WarningCount != 1 {
+		t.Fatalf("want 2 analyzer findings and 1 compiler warning, got %+v", tv.Counts)
+	}
+	issues := tv.IssuesTab.Issues
+	if len(issues) != 3 || issues[0].Type != IssueTypeWarning {
+		t.Fatalf("compiler warnings should sort before analyzer findings: %+v", issues)
+	}
+	for _, issue := range issues[1:] {
+		if issue.Type != IssueTypeAnalyzer || !strings.HasSuffix(issue.File, "LegacyStore.m") {
+			t.Fatalf("unexpected analyzer issue %+v", issue)
+		}
+	}
+	if issues[1].Message != "Value stored to 'count' is never read [deadcode.DeadStores]" {
+		t.Fatalf("unexpected message %q", issues[1].Message)
+	}
+	if !strings.Contains(stripANSI(tv.IssuesTab.View(DefaultStyles())), "2 analyzer") {
+		t.Fatalf("Issues header should count analyzer findings")
+	}
+	if tv.SummaryTab.AnalyzerCount != 2 {
+		t.Fatalf("Dashboard analyzer count = %d", tv.SummaryTab.AnalyzerCount)
+	}
+}
+
+func TestAnalyzerFindingOutsideAnalyzeStep(t *testing.T) {
+	tv := NewTabView()
+	tv.AddRawLine("/p/Foo.m:3:1: warning: Null pointer argument in call to memcpy [core.NonNullParamChecker]")
+	tv.AddRawLine("/p/Foo.m:9:1: warning: unused variable 'x' [-Wunused-variable]")
+	if tv.Counts.AnalyzerCount != 1 || tv.Counts.WarningCount != 1 {
+		t.Fatalf("checker names mark analyzer findings, -W flags don't: %+v", tv.Counts)
+	}
+}
+
+func TestResultCardShowsAnalyzerReport(t *testing.T) {
+	st := NewSummaryTab()
+	st.SetSize(100, 40)
+	st.SetRunning("analyze")
+	st.SetResult(BuildStatusSuccess, "4.2s", nil, 0, 0)
+	st.AnalyzerCount = 2
+	st.SetAnalyzerReport(analyzerReportPath(core.AnalyzeResult{
+		ReportDir: "/r/x-analyzer",
+		Reports:   []string{"/r/x-analyzer/report-1.html", "/r/x-analyzer/report-2.html"},
+	}))
+
+	view := stripANSI(st.View(DefaultStyles()))
+	for _, want := range []string{"ANALYZE SUCCEEDED", "2 analyzer findings", "Report: /r/x-analyzer", "Open Analyzer Report"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("result card is missing %q:\n%s", want, view)
+		}
+	}
+}
+
+func TestAnalyzerReportPath(t *testing.T) {
+	if got := analyzerReportPath(core.AnalyzeResult{ReportDir: "/r"}); got != "" {
+		t.Fatalf("no reports should mean nothing to open, got %q", got)
+	}
+	if got := analyzerReportPath(core.AnalyzeResult{ReportDir: "/r", Reports: []string{"/r/a.html"}}); got != "/r/a.html" {
+		t.Fatalf("a single report should open directly, got %q", got)
+	}
+}
@@ -0,0 +1,135 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// =============================================================================
+// App Data Container
+// =============================================================================
+
+// appDataMsg reports the outcome of a container reveal/reset
+type appDataMsg string
+
+// appDataTarget is the installed app the container shortcuts act on
+type appDataTarget struct {
+	BundleID string
+	UDID     string
+	Name     string // Simulator name, for the confirm prompt
+	AppPath  string // Last built .app, reinstalled on reset
+}
+
+// resolveAppDataTarget picks the app from the last run/build (or the latest
+// session) and the simulator from the active destination.
+func (m *Model) resolveAppDataTarget() (appDataTarget, error) {
+	t := appDataTarget{BundleID: m.lastRun.BundleID, AppPath: m.lastRun.AppPath}
+	if t.BundleID == "" {
+		t.BundleID = m.lastBuild.BundleID
+	}
+	if t.AppPath == "" {
+		t.AppPath = m.lastBuild.AppPath
+	}
+
+	kind, udid := m.cfg.Destination.Kind, m.cfg.Destination.UDID
+	t.Name = m.cfg.Destination.Name
+	if kind == core.DestAuto || kind == "" {
+		kind, udid = core.DestinationKind(m.lastRun.Target), m.lastRun.UDID
+	}
+	if sess, err := latestSession(m.projectRoot); err == nil {
+		if t.BundleID == "" {
+			t.BundleID = sess.BundleID
+		}
+		if kind == core.DestAuto || kind == "" {
+			kind, udid = core.DestinationKind(sess.Target), sess.UDID
+		}
+	}
+
+	switch kind {
+	case core.DestSimulator:
+	case core.DestDevice:
+		return t, errors.New("App data shortcuts are not supported on physical devices (use Xcode's Devices window)")
+	case core.DestMacOS, core.DestCatalyst:
+		return t, errors.New("App data shortcuts are not supported for Mac apps")
+	default:
+		return t, errors.New("Select a simulator destination first")
+	}
+	if udid == "" {
+		return t, errors.New("Simulator destination has no UDID; pick one with d")
+	}
+	t.UDID = udid
+	if t.BundleID == "" {
+		return t, errors.New("No bundle id yet: build or run the app first")
+	}
+	if t.Name == "" {
+		t.Name = udid
+	}
+	return t, nil
+}
+
+// reportAppData shows a result in the status bar and, in run mode, as a
+// system line in the console pane
+func (m *Model) reportAppData(text string) {
+	m.setStatus(text)
+	if m.runMode.Active {
+		m.appendConsoleLog(consoleSystemPrefix+"[xcbolt] "+text, "")
+	}
+}
+
+func (m *Model) revealAppContainer() tea.Cmd {
+	t, err := m.resolveAppDataTarget()
+	if err != nil {
+		m.reportAppData(err.Error())
+		return nil
+	}
+	preserveLocale := m.cfg.Xcodebuild.PreserveLocale
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		path, err := core.SimctlAppContainer(ctx, t.UDID, t.BundleID, "data", preserveLocale)
+		if err != nil {
+			return appDataMsg("Container lookup failed for " + t.BundleID + ": " + err.Error())
+		}
+		if err := exec.Command("open", path).Start(); err != nil {
+			return appDataMsg("Failed to open " + path + ": " + err.Error())
+		}
+		return appDataMsg("Opened data container: " + path)
+	}
+}
+
+func (m *Model) confirmResetAppData() {
+	t, err := m.resolveAppDataTarget()
+	if err != nil {
+		m.reportAppData(err.Error())
+		return
+	}
+	body := []string{"App: " + t.BundleID, "Simulator: " + t.Name}
+	if t.AppPath != "" {
+		body = append(body, "Uninstalls and reinstalls "+relativeToRoot(m.projectRoot, t.AppPath)+"; all app data is erased.")
+	} else {
+		body = append(body, "No built app to reinstall: only privacy permissions are reset.")
+	}
+	preserveLocale := m.cfg.Xcodebuild.PreserveLocale
+	m.openConfirm(ConfirmPrompt{
+		Title: "Reset app data?",
+		Body:  body,
+		OnYes: func(m *Model) tea.Cmd {
+			m.setStatus("Resetting " + t.BundleID + "…")
+			return func() tea.Msg {
+				ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+				defer cancel()
+				what, err := core.SimctlResetAppData(ctx, t.UDID, t.BundleID, t.AppPath, preserveLocale)
+				if err != nil {
+					return appDataMsg("Reset failed for " + t.BundleID + ": " + err.Error())
+				}
+				return appDataMsg(t.BundleID + ": " + what)
+			}
+		},
+	})
+}
@@ -0,0 +1,51 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func TestAppDataTargetFromLastBuildAndDestination(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.cfg.Destination = core.Destination{Kind: core.DestSimulator, UDID: "SIM-1", Name: "iPhone 15"}
+
+	if _, err := m.resolveAppDataTarget(); err == nil || !strings.Contains(err.Error(), "build or run") {
+		t.Fatalf("expected missing bundle id error, got %v", err)
+	}
+
+	m.lastBuild = core.BuildResult{BundleID: "com.example.App", AppPath: "/dd/App.app"}
+	got, err := m.resolveAppDataTarget()
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if got.BundleID != "com.example.App" || got.UDID != "SIM-1" || got.AppPath != "/dd/App.app" || got.Name != "iPhone 15" {
+		t.Fatalf("unexpected target: %+v", got)
+	}
+
+	m.confirmResetAppData()
+	if m.mode != ModeConfirm || !strings.Contains(strings.Join(m.confirm.Body, "\n"), "all app data is erased") {
+		t.Fatalf("reset should ask for confirmation first: mode=%v body=%q", m.mode, m.confirm.Body)
+	}
+}
+
+func TestAppDataUnsupportedOnDevice(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.cfg.Destination = core.Destination{Kind: core.DestDevice, UDID: "DEV-1"}
+	m.lastRun = core.RunResult{BundleID: "com.example.App", Target: "device", UDID: "DEV-1"}
+	m.runMode.Active = true
+
+	if cmd := m.revealAppContainer(); cmd != nil {
+		t.Fatalf("devices should not spawn simctl")
+	}
+	if !strings.Contains(m.statusMsg, "not supported on physical devices") {
+		t.Fatalf("unexpected status %q", m.statusMsg)
+	}
+	last := m.runMode.Console.Lines[len(m.runMode.Console.Lines)-1]
+	if !strings.HasPrefix(last, consoleSystemPrefix) || !strings.Contains(last, "not supported") {
+		t.Fatalf("expected a system console line, got %q", last)
+	}
+}
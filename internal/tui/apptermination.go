@@ -0,0 +1,28 @@
+package tui
+
+import (
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// showAppTermination reports how the console run's app ended (an
+// AppTerminatedCode event): a banner in the console pane, red unless the
+// exit was clean, and a line on the run's result card
+func (m *Model) showAppTermination(ev core.Event) {
+	data, _ := ev.Data.(map[string]any)
+	reason, _ := data["reason"].(string)
+	exitCode, _ := data["exitCode"].(int)
+	summary := core.TerminationSummary(reason, exitCode)
+	clean := reason == core.TerminationCleanExit
+	m.tabView.SummaryTab.SetAppExit(summary, clean)
+	if !m.runMode.Active {
+		return
+	}
+	if clean {
+		m.appendConsoleLog(consoleSystemPrefix+"■ "+summary, "")
+		return
+	}
+	m.appendConsoleLog("■ "+summary, "E")
+	if detail, _ := data["detail"].(string); detail != "" {
+		m.appendConsoleLog(consoleSystemPrefix+"  "+detail, "")
+	}
+}
@@ -0,0 +1,32 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func TestShowAppTermination(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.width, m.height = 120, 40
+	m.runMode.Active = true
+
+	m.handleEvent(core.Event{Type: "status", Code: core.AppTerminatedCode, Msg: "App terminated", Data: map[string]any{
+		"exitCode": 134, "reason": core.TerminationUncaughtException, "detail": "*** Terminating app due to uncaught exception 'X'",
+	}})
+	c := m.runMode.Console
+	if len(c.Lines) != 2 || !strings.Contains(c.Lines[0], "App terminated: uncaught exception (exit code 134)") || c.Levels[0] != "E" {
+		t.Fatalf("console = %q %q", c.Lines, c.Levels)
+	}
+	if st := m.tabView.SummaryTab; st.AppExit != "App terminated: uncaught exception (exit code 134)" || st.AppExitClean {
+		t.Fatalf("result card = %q clean %v", st.AppExit, st.AppExitClean)
+	}
+
+	m.runMode.Console.Reset()
+	m.handleEvent(core.Event{Type: "status", Code: core.AppTerminatedCode, Data: map[string]any{"exitCode": 0, "reason": core.TerminationCleanExit}})
+	if c := m.runMode.Console; len(c.Lines) != 1 || !strings.HasPrefix(c.Lines[0], consoleSystemPrefix) || !m.tabView.SummaryTab.AppExitClean {
+		t.Fatalf("clean exit console = %q", c.Lines)
+	}
+}
@@ -0,0 +1,162 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+const (
+	// appUsageInterval is how often the launched app's CPU and memory are
+	// read; each read is one ps run
+	appUsageInterval = 2 * time.Second
+	// appUsageTimeout bounds a single read
+	appUsageTimeout = 5 * time.Second
+)
+
+// appUsageState follows the CPU and memory of the app launched by a run
+// while run mode is active
+type appUsageState struct {
+	gen int
+	pid int
+	// ctx is canceled when the loop stops, ending a read in flight
+	ctx    context.Context
+	cancel context.CancelFunc
+	usage  core.AppUsage
+	has    bool
+}
+
+// appUsageTickMsg starts the next read; gen drops ticks of a stopped loop
+type appUsageTickMsg struct {
+	gen int
+}
+
+// appUsageMsg delivers a finished read
+type appUsageMsg struct {
+	gen   int
+	usage core.AppUsage
+	err   error
+}
+
+// watchAppUsage starts reading the usage of the app process pid launched
+// on target, replacing any previous loop. Device apps aren't watched.
+func (m *Model) watchAppUsage(pid int, target string) tea.Cmd {
+	if pid == m.appUsage.pid && m.appUsage.cancel != nil {
+		return nil
+	}
+	m.stopAppUsage()
+	if pid <= 0 || !core.AppUsageSupported(target) {
+		return nil
+	}
+	m.appUsage.pid = pid
+	m.appUsage.ctx, m.appUsage.cancel = context.WithCancel(context.Background())
+	return sampleAppUsage(m.appUsage.ctx, m.appUsage.gen, pid)
+}
+
+// watchRunApp watches a Mac app after its run returned, and stops watching
+// once a simulator run ended, which means its app exited
+func (m *Model) watchRunApp(msg opDoneMsg) tea.Cmd {
+	if msg.cmd != "run" {
+		return nil
+	}
+	if msg.err == nil && msg.run != nil && m.runMode.Active {
+		switch msg.run.Target {
+		case string(core.DestMacOS), string(core.DestCatalyst):
+			return m.watchAppUsage(msg.run.PID, msg.run.Target)
+		}
+	}
+	m.stopAppUsage()
+	return nil
+}
+
+// watchLaunchedApp starts watching the app of an AppLaunchedCode event
+func (m *Model) watchLaunchedApp(ev core.Event) tea.Cmd {
+	data, _ := ev.Data.(map[string]any)
+	pid, _ := data["pid"].(int)
+	target, _ := data["target"].(string)
+	return m.watchAppUsage(pid, target)
+}
+
+// stopAppUsage ends the read loop, canceling a read in flight, and clears
+// the readout
+func (m *Model) stopAppUsage() {
+	if m.appUsage.cancel != nil {
+		m.appUsage.cancel()
+	}
+	m.appUsage = appUsageState{gen: m.appUsage.gen + 1}
+}
+
+// sampleAppUsage reads the usage of pid once, in the background
+func sampleAppUsage(ctx context.Context, gen, pid int) tea.Cmd {
+	return func() tea.Msg {
+		readCtx, cancel := context.WithTimeout(ctx, appUsageTimeout)
+		defer cancel()
+		usage, err := core.SampleAppUsage(readCtx, pid)
+		return appUsageMsg{gen: gen, usage: usage, err: err}
+	}
+}
+
+// appUsageActive reports whether the loop of gen should go on
+func (m *Model) appUsageActive(gen int) bool {
+	return gen == m.appUsage.gen && m.appUsage.cancel != nil && m.runMode.Active && !m.quitting
+}
+
+// handleAppUsage shows a read and schedules the next one, or stops when
+// the app exited or run mode ended
+func (m *Model) handleAppUsage(msg appUsageMsg) tea.Cmd {
+	if msg.gen != m.appUsage.gen {
+		return nil
+	}
+	if !m.appUsageActive(msg.gen) || errors.Is(msg.err, core.ErrAppExited) || errors.Is(msg.err, context.Canceled) {
+		m.stopAppUsage()
+		return nil
+	}
+	if msg.err == nil {
+		m.appUsage.usage, m.appUsage.has = msg.usage, true
+	}
+	gen := msg.gen
+	return tea.Tick(appUsageInterval, func(time.Time) tea.Msg { return appUsageTickMsg{gen: gen} })
+}
+
+// handleAppUsageTick starts the next read while the loop is current
+func (m *Model) handleAppUsageTick(msg appUsageTickMsg) tea.Cmd {
+	if msg.gen != m.appUsage.gen {
+		return nil
+	}
+	if !m.appUsageActive(msg.gen) {
+		m.stopAppUsage()
+		return nil
+	}
+	return sampleAppUsage(m.appUsage.ctx, msg.gen, m.appUsage.pid)
+}
+
+// appUsageLabel is the console header's readout, e.g. "CPU 12% · MEM 184
+// MB", with values over tui.appCpuWarn / tui.appMemoryWarnMb highlighted.
+// Device runs show "n/a".
+func (m Model) appUsageLabel() string {
+	s := m.styles
+	muted := lipgloss.NewStyle().Foreground(s.Colors.TextMuted)
+	if !m.appUsage.has {
+		if m.running && m.runningCmd == "run" && m.cfg.Destination.Kind == core.DestDevice {
+			return muted.Render("CPU/MEM n/a")
+		}
+		return ""
+	}
+	warn := lipgloss.NewStyle().Foreground(s.Colors.Warning)
+	u := m.appUsage.usage
+	cpu := muted.Render(fmt.Sprintf("CPU %.0f%%", u.CPU))
+	if u.CPU > float64(m.cfg.TUI.CPUWarn()) {
+		cpu = warn.Render(fmt.Sprintf("CPU %.0f%%", u.CPU))
+	}
+	mem := muted.Render(fmt.Sprintf("MEM %.0f MB", u.MemoryMB()))
+	if u.MemoryMB() > float64(m.cfg.TUI.MemoryWarnMB()) {
+		mem = warn.Render(fmt.Sprintf("MEM %.0f MB", u.MemoryMB()))
+	}
+	return cpu + muted.Render(" · ") + mem
+}
@@ -0,0 +1,66 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func TestAppUsageLoop(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.runMode.Active = true
+
+	if cmd := m.watchLaunchedApp(core.Event{Code: core.AppLaunchedCode, Data: map[string]any{"pid": 4242, "target": "device"}}); cmd != nil {
+		t.Fatal("device apps are not watched")
+	}
+	if cmd := m.watchLaunchedApp(core.Event{Code: core.AppLaunchedCode, Data: map[string]any{"pid": 4242, "target": "simulator"}}); cmd == nil {
+		t.Fatal("simulator app not watched")
+	}
+	gen := m.appUsage.gen
+
+	if cmd := m.handleAppUsage(appUsageMsg{gen: gen, usage: core.AppUsage{CPU: 95, RSSKB: 188416}}); cmd == nil {
+		t.Fatal("next read not scheduled")
+	}
+	if !m.appUsage.has || m.appUsage.usage.CPU != 95 {
+		t.Fatalf("usage = %+v", m.appUsage)
+	}
+	label := stripANSI(m.appUsageLabel())
+	if label != "CPU 95% · MEM 184 MB" {
+		t.Fatalf("label = %q", label)
+	}
+
+	// Reads of an older loop are dropped
+	if cmd := m.handleAppUsage(appUsageMsg{gen: gen - 1}); cmd != nil {
+		t.Fatal("stale read scheduled another")
+	}
+
+	// The loop ends when the app exits
+	if cmd := m.handleAppUsage(appUsageMsg{gen: gen, err: core.ErrAppExited}); cmd != nil {
+		t.Fatal("loop continued after the app exited")
+	}
+	if m.appUsage.has || m.appUsageLabel() != "" {
+		t.Fatal("readout kept after the app exited")
+	}
+	if cmd := m.handleAppUsageTick(appUsageTickMsg{gen: gen}); cmd != nil {
+		t.Fatal("tick of a stopped loop started a read")
+	}
+
+	// and when run mode is left
+	m.watchAppUsage(4343, "macos")
+	m.runMode.Active = false
+	if cmd := m.handleAppUsageTick(appUsageTickMsg{gen: m.appUsage.gen}); cmd != nil {
+		t.Fatal("loop continued after run mode ended")
+	}
+}
+
+func TestAppUsageLabelDevice(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.cfg.Destination.Kind = core.DestDevice
+	m.running, m.runningCmd = true, "run"
+	if label := stripANSI(m.appUsageLabel()); !strings.Contains(label, "n/a") {
+		t.Fatalf("label = %q", label)
+	}
+}
@@ -0,0 +1,46 @@
+package tui
+
+import (
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// autoSwitchOnFailure shows the Issues tab after a failed op with errors
+// (tui.autoSwitchTab "issues-on-failure"), unless the user picked a tab
+// during the op. It reports whether it switched.
+func (m *Model) autoSwitchOnFailure() bool {
+	if m.cfg.TUI.AutoSwitchMode() != core.AutoSwitchIssuesOnFailure || m.userTabOverride {
+		return false
+	}
+	if m.tabView.Counts.ErrorCount == 0 || m.tabView.ActiveTab == TabIssues {
+		return false
+	}
+	m.tabView.SetActiveTab(TabIssues)
+	if m.runMode.Active {
+		m.runMode.FocusPane = PaneBuild
+	}
+	return true
+}
+
+// cycleAutoSwitchTab switches tui.autoSwitchTab issues-on-failure →
+// logs-on-start → never → issues-on-failure
+func (m *Model) cycleAutoSwitchTab() {
+	next := core.AutoSwitchLogsOnStart
+	switch m.cfg.TUI.AutoSwitchMode() {
+	case core.AutoSwitchLogsOnStart:
+		next = core.AutoSwitchNever
+	case core.AutoSwitchNever:
+		next = core.AutoSwitchIssuesOnFailure
+	}
+	m.cfg.TUI.AutoSwitchTab = next
+	if err := core.SaveConfig(m.projectRoot, m.configPath, m.cfg); err != nil {
+		m.lastErr = err.Error()
+	}
+	switch next {
+	case core.AutoSwitchNever:
+		m.setStatus("Auto tab switch off")
+	case core.AutoSwitchLogsOnStart:
+		m.setStatus("Auto tab switch: Logs when an op starts")
+	default:
+		m.setStatus("Auto tab switch: Issues when an op fails")
+	}
+}
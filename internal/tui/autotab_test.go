@@ -0,0 +1,150 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// newFailedBuildModel returns a model with a build running that logged an
+// error
+func newFailedBuildModel(t *testing.T, mode string) Model {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.mode = ModeNormal
+	m.cfg.TUI.AutoSwitchTab = mode
+	m.running = true
+	m.runningCmd = "build"
+	m.tabView.SummaryTab.SetRunning("build")
+	m.tabView.AddRawLine("/src/App.swift:3:1: error: cannot find 'x' in scope")
+	return m
+}
+
+func TestFailedBuildSwitchesToIssues(t *testing.T) {
+	m := newFailedBuildModel(t, "")
+	m.handleOpDone(opDoneMsg{cmd: "build", err: errors.New("exit status 65"), build: &core.BuildResult{}})
+	if m.tabView.ActiveTab != TabIssues {
+		t.Fatalf("active tab = %v, want Issues", m.tabView.ActiveTab)
+	}
+	if !strings.Contains(m.statusMsg, "switched to Issues") {
+		t.Fatalf("status = %q", m.statusMsg)
+	}
+}
+
+func TestAutoSwitchKeepsUserTab(t *testing.T) {
+	m := newFailedBuildModel(t, core.AutoSwitchIssuesOnFailure)
+	m = updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2")})
+	if !m.userTabOverride {
+		t.Fatalf("tab key during the op did not set the override")
+	}
+	m.handleOpDone(opDoneMsg{cmd: "build", err: errors.New("exit status 65"), build: &core.BuildResult{}})
+	if m.tabView.ActiveTab != TabStream || strings.Contains(m.statusMsg, "switched") {
+		t.Fatalf("tab = %v status = %q after a manual switch", m.tabView.ActiveTab, m.statusMsg)
+	}
+
+	m = newFailedBuildModel(t, core.AutoSwitchNever)
+	m.handleOpDone(opDoneMsg{cmd: "build", err: errors.New("exit status 65"), build: &core.BuildResult{}})
+	if m.tabView.ActiveTab != TabDashboard {
+		t.Fatalf("never mode switched to %v", m.tabView.ActiveTab)
+	}
+}
+
+func TestFailureWithoutErrorsStaysOnTab(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.running = true
+	m.runningCmd = "build"
+	m.handleOpDone(opDoneMsg{cmd: "build", err: errors.New("no scheme"), build: &core.BuildResult{}})
+	if m.tabView.ActiveTab != TabDashboard {
+		t.Fatalf("failure without compiler errors switched to %v", m.tabView.ActiveTab)
+	}
+}
+
+func TestLogsOnStartSwitchesToLogs(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.cfg.TUI.AutoSwitchTab = core.AutoSwitchLogsOnStart
+	m.userTabOverride = true
+	m.startOp("logs") // Fails at once: no session selected
+	if m.tabView.ActiveTab != TabStream || m.userTabOverride {
+		t.Fatalf("tab = %v override = %v after start", m.tabView.ActiveTab, m.userTabOverride)
+	}
+	if !strings.Contains(m.statusMsg, "switched to Logs") {
+		t.Fatalf("status = %q", m.statusMsg)
+	}
+}
+
+func TestCycleAutoSwitchTab(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	for _, want := range []string{core.AutoSwitchLogsOnStart, core.AutoSwitchNever, core.AutoSwitchIssuesOnFailure} {
+		m.cycleAutoSwitchTab()
+		if m.cfg.TUI.AutoSwitchTab != want {
+			t.Fatalf("autoSwitchTab = %q, want %q", m.cfg.TUI.AutoSwitchTab, want)
+		}
+	}
+}
+
+func TestKilledBuildIsWordedByCause(t *testing.T) {
+	m := newFailedBuildModel(t, core.AutoSwitchNever)
+	m.tabView.AddRawLine("clang: error: unable to execute command: Killed: 9")
+	err := &core.XcodebuildExitError{Failure: core.FailureSignal, Signal: "SIGKILL", ExitCode: -1, Err: errors.New("signal: killed")}
+	m.handleOpDone(opDoneMsg{cmd: "build", err: err, build: &core.BuildResult{Failure: core.FailureSignal, Signal: "SIGKILL"}})
+
+	want := "Build terminated (SIGKILL — possible out-of-memory)"
+	if got := m.tabView.SummaryTab.FailureHeadline(); got != want {
+		t.Fatalf("headline = %q, want %q", got, want)
+	}
+	if m.statusMsg != want {
+		t.Fatalf("status = %q", m.statusMsg)
+	}
+	st := m.tabView.SummaryTab
+	st.SetSize(100, 40)
+	if view := stripANSI(st.View(DefaultStyles())); !strings.Contains(view, strings.ToUpper(want)) {
+		t.Fatalf("failed view without the headline:\n%s", view)
+	}
+	analysis := m.tabView.IssuesTab.generateAnalysis(m.tabView.IssuesTab.getByType(IssueTypeError))
+	if !strings.Contains(analysis, "killed (SIGKILL) while memory ran low") {
+		t.Fatalf("analysis without OOM guidance: %q", analysis)
+	}
+}
+
+func TestKilledBuildWithoutErrorsShowsAnalysis(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.running, m.runningCmd = true, "build"
+	m.tabView.SummaryTab.SetRunning("build")
+	err := &core.XcodebuildExitError{Failure: core.FailureSignal, Signal: "SIGKILL", ExitCode: -1, Err: errors.New("signal: killed")}
+	m.handleOpDone(opDoneMsg{cmd: "build", err: err, build: &core.BuildResult{Failure: core.FailureSignal, Signal: "SIGKILL"}})
+
+	it := m.tabView.IssuesTab
+	it.SetSize(100, 30)
+	view := stripANSI(it.View(DefaultStyles()))
+	if !strings.Contains(view, "Analysis") || !strings.Contains(view, "killed (SIGKILL) before it finished") {
+		t.Fatalf("killed build without errors shows no analysis:\n%s", view)
+	}
+}
+
+func TestCrashedXcodebuildHeadline(t *testing.T) {
+	m := newFailedBuildModel(t, core.AutoSwitchNever)
+	err := &core.XcodebuildExitError{Failure: core.FailureCrash, ExitCode: 70, Err: errors.New("exit status 70")}
+	m.handleOpDone(opDoneMsg{cmd: "build", err: err, build: &core.BuildResult{}})
+	if got := m.tabView.SummaryTab.FailureHeadline(); got != "xcodebuild crashed" {
+		t.Fatalf("headline = %q", got)
+	}
+	// No memory pressure in the log: no OOM guidance
+	if analysis := m.tabView.IssuesTab.generateAnalysis(m.tabView.IssuesTab.getByType(IssueTypeError)); strings.Contains(analysis, "memory") {
+		t.Fatalf("analysis = %q", analysis)
+	}
+
+	m = newFailedBuildModel(t, core.AutoSwitchNever)
+	m.handleOpDone(opDoneMsg{cmd: "build", err: &core.XcodebuildExitError{Failure: core.FailureBuild, ExitCode: 65, Err: errors.New("exit status 65")}, build: &core.BuildResult{}})
+	if got := m.tabView.SummaryTab.FailureHeadline(); got != "Build failed" || m.statusMsg != "BUILD failed" {
+		t.Fatalf("headline = %q status = %q", got, m.statusMsg)
+	}
+}
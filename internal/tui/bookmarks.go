@@ -0,0 +1,267 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// =============================================================================
+// Log Line Bookmarks
+// =============================================================================
+
+// bookmarkFlashDuration is how long a jumped-to line stays highlighted
+const bookmarkFlashDuration = 1500 * time.Millisecond
+
+// bookmarkPreviewLen caps the preview shown in the jump list
+const bookmarkPreviewLen = 80
+
+// Bookmark points at a log line by its stable ID, so trimming older lines
+// from the buffer never makes a jump land on different content
+type Bookmark struct {
+	Pane    Pane       // PaneBuild = Logs tab, PaneConsole = run console
+	Stream  *StreamTab // Logs tab holding the line (live or previous run)
+	LineID  uint64
+	LineNum int // 1-based position when bookmarked (display only)
+	Preview string
+	Op      string
+	Pinned  bool
+}
+
+// bookmarkFlashMsg clears the jump highlight unless a newer jump replaced it
+type bookmarkFlashMsg int
+
+// bookmarkTarget returns the line under the cursor in the focused pane: the
+// top visible line of the Logs tab or the console pane.
+func (m *Model) bookmarkTarget() (Bookmark, bool) {
+	if m.runMode.Active && m.runMode.FocusPane == PaneConsole {
+		visible := m.visibleConsoleIndices()
+		if len(visible) == 0 {
+			return Bookmark{}, false
+		}
+		pos := m.runMode.Console.Pos
+		if pos >= len(visible) {
+			pos = len(visible) - 1
+		}
+		if pos < 0 {
+			pos = 0
+		}
+		idx := visible[pos]
+		if idx >= len(m.runMode.Console.IDs) {
+			return Bookmark{}, false
+		}
+		line := consolePlainLine(m.runMode.Console.Lines[idx])
+		return Bookmark{
+			Pane:    PaneConsole,
+			LineID:  m.runMode.Console.IDs[idx],
+			LineNum: pos + 1,
+			Preview: bookmarkPreview(line),
+			Op:      m.tabView.Operation,
+		}, true
+	}
+
+	st := m.tabView.VisibleStreamTab()
+	line, idx, ok := st.TopLine()
+	if !ok {
+		return Bookmark{}, false
+	}
+	op := m.tabView.Operation
+	if m.tabView.ShowingPrevious && m.tabView.previous != nil {
+		op = m.tabView.previous.Operation
+	}
+	return Bookmark{
+		Pane:    PaneBuild,
+		Stream:  st,
+		LineID:  line.ID,
+		LineNum: idx + 1,
+		Preview: bookmarkPreview(line.Raw),
+		Op:      op,
+	}, true
+}
+
+func bookmarkPreview(line string) string {
+	line = strings.TrimSpace(line)
+	if len(line) > bookmarkPreviewLen {
+		line = line[:bookmarkPreviewLen-3] + "..."
+	}
+	if line == "" {
+		return "(blank line)"
+	}
+	return line
+}
+
+func (b Bookmark) same(o Bookmark) bool {
+	return b.Pane == o.Pane && b.Stream == o.Stream && b.LineID == o.LineID
+}
+
+// toggleBookmark cycles the line under the cursor through bookmarked →
+// pinned (kept across operations) → removed
+func (m *Model) toggleBookmark() {
+	if !(m.runMode.Active && m.runMode.FocusPane == PaneConsole) && m.tabView.ActiveTab != TabStream {
+		m.setStatus("Bookmarks work on the Logs tab and the console pane")
+		return
+	}
+	target, ok := m.bookmarkTarget()
+	if !ok {
+		m.setStatus("Nothing to bookmark yet")
+		return
+	}
+	for i, b := range m.bookmarks {
+		if !b.same(target) {
+			continue
+		}
+		if !b.Pinned {
+			m.bookmarks[i].Pinned = true
+			m.setStatus(fmt.Sprintf("Pinned bookmark at line %d (kept across runs)", b.LineNum))
+			return
+		}
+		m.bookmarks = append(m.bookmarks[:i], m.bookmarks[i+1:]...)
+		m.setStatus(fmt.Sprintf("Removed bookmark at line %d", b.LineNum))
+		return
+	}
+	m.bookmarks = append(m.bookmarks, target)
+	m.setStatus(fmt.Sprintf("Bookmarked line %d (' to jump, m again to pin)", target.LineNum))
+}
+
+// resetBookmarks runs when a new operation starts: unpinned bookmarks are
+// dropped, as are pinned ones whose content is no longer in memory
+func (m *Model) resetBookmarks() {
+	kept := m.bookmarks[:0]
+	for _, b := range m.bookmarks {
+		if b.Pinned && m.bookmarkLineIndex(b) >= 0 {
+			kept = append(kept, b)
+		}
+	}
+	m.bookmarks = kept
+	m.bookmarkFlashID++
+	m.clearBookmarkFlash()
+}
+
+// clearBookmarks removes every bookmark, pinned or not
+func (m *Model) clearBookmarks() {
+	n := len(m.bookmarks)
+	m.bookmarks = nil
+	m.setStatus(fmt.Sprintf("Cleared %d bookmark(s)", n))
+}
+
+// bookmarkStream resolves the Logs tab a bookmark points into, reporting
+// whether it is the previous-run snapshot
+func (m *Model) bookmarkStream(b Bookmark) (st *StreamTab, previous bool, ok bool) {
+	if b.Stream == m.tabView.StreamTab {
+		return b.Stream, false, true
+	}
+	if m.tabView.previous != nil && b.Stream == m.tabView.previous.StreamTab {
+		return b.Stream, true, true
+	}
+	return nil, false, false
+}
+
+// bookmarkLineIndex returns the bookmarked line's current index in its
+// buffer, or -1 once it has been trimmed or cleared
+func (m *Model) bookmarkLineIndex(b Bookmark) int {
+	if b.Pane == PaneConsole {
+		if !m.runMode.Active {
+			return -1
+		}
+		return m.runMode.Console.IndexOfID(b.LineID)
+	}
+	st, _, ok := m.bookmarkStream(b)
+	if !ok {
+		return -1
+	}
+	return st.IndexOfID(b.LineID)
+}
+
+// BookmarkItems creates selector items from bookmarks (ID = list index)
+func BookmarkItems(bookmarks []Bookmark) []SelectorItem {
+	items := make([]SelectorItem, len(bookmarks))
+	for i, b := range bookmarks {
+		where := "Logs"
+		if b.Pane == PaneConsole {
+			where = "Console"
+		}
+		if b.Op != "" {
+			where += " · " + b.Op
+		}
+		meta := fmt.Sprintf("L%d", b.LineNum)
+		if b.Pinned {
+			meta += " · pinned"
+		}
+		items[i] = SelectorItem{
+			ID:          strconv.Itoa(i),
+			Title:       b.Preview,
+			Description: where,
+			Meta:        meta,
+		}
+	}
+	return items
+}
+
+func (m *Model) openBookmarksSelector() {
+	if len(m.bookmarks) == 0 {
+		m.setStatus("No bookmarks (m marks the top line)")
+		return
+	}
+	m.selector = NewSelector("Bookmarks (enter jumps)", BookmarkItems(m.bookmarks), m.width, m.styles)
+	m.selectorType = SelectorBookmarks
+	m.mode = ModeSelector
+}
+
+// jumpToBookmark scrolls the bookmark's pane to its line and flashes it
+func (m *Model) jumpToBookmark(item *SelectorItem) tea.Cmd {
+	i, err := strconv.Atoi(item.ID)
+	if err != nil || i < 0 || i >= len(m.bookmarks) {
+		return nil
+	}
+	b := m.bookmarks[i]
+	idx := m.bookmarkLineIndex(b)
+	if idx < 0 {
+		m.setStatus("Bookmarked line is no longer in the log buffer")
+		return nil
+	}
+
+	m.clearBookmarkFlash()
+	if b.Pane == PaneConsole {
+		pos := -1
+		for vi, ci := range m.visibleConsoleIndices() {
+			if ci == idx {
+				pos = vi
+				break
+			}
+		}
+		if pos < 0 {
+			m.setStatus("Bookmarked line is hidden by the console filter (l)")
+			return nil
+		}
+		m.runMode.FocusPane = PaneConsole
+		m.runMode.Console.Pos = minInt(pos, m.consoleMaxPos())
+		m.runMode.Console.Follow = false
+		m.runMode.Console.Highlight = b.LineID
+	} else {
+		st, previous, _ := m.bookmarkStream(b)
+		m.tabView.ShowingPrevious = previous
+		m.selectTab(TabStream)
+		if m.runMode.Active {
+			m.runMode.FocusPane = PaneBuild
+		}
+		st.ScrollToIndex(idx)
+		st.HighlightID = b.LineID
+	}
+	m.setStatus(fmt.Sprintf("Jumped to line %d", idx+1))
+
+	m.bookmarkFlashID++
+	id := m.bookmarkFlashID
+	return tea.Tick(bookmarkFlashDuration, func(time.Time) tea.Msg { return bookmarkFlashMsg(id) })
+}
+
+// clearBookmarkFlash removes the jump highlight from every pane
+func (m *Model) clearBookmarkFlash() {
+	m.tabView.StreamTab.HighlightID = 0
+	if m.tabView.previous != nil {
+		m.tabView.previous.StreamTab.HighlightID = 0
+	}
+	m.runMode.Console.Highlight = 0
+}
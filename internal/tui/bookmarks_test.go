@@ -0,0 +1,121 @@
+package tui
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestBookmarkJumpSurvivesTrimming(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.tabView.SetActiveTab(TabStream)
+	st := m.tabView.StreamTab
+	st.SetSize(80, 10)
+
+	for i := 0; i < 100; i++ {
+		st.AddLine(fmt.Sprintf("line %d", i), TabLineTypeNormal)
+	}
+	st.ScrollToIndex(40)
+	m.toggleBookmark()
+	if len(m.bookmarks) != 1 || m.bookmarks[0].Preview != "line 40" {
+		t.Fatalf("unexpected bookmarks: %+v", m.bookmarks)
+	}
+
+	// Push enough output that the ring buffer drops the first 1000 lines.
+	st.GotoBottom()
+	for i := 100; i < maxStreamTabLines+1000; i++ {
+		st.AddLine(fmt.Sprintf("line %d", i), TabLineTypeNormal)
+	}
+	if idx := m.bookmarkLineIndex(m.bookmarks[0]); idx != -1 {
+		t.Fatalf("trimmed line should not resolve, got index %d", idx)
+	}
+
+	st.ScrollToIndex(500)
+	m.toggleBookmark()
+	want := st.Lines[500].Raw
+	for i := 0; i < 300; i++ {
+		st.AddLine("more", TabLineTypeNormal)
+	}
+	items := BookmarkItems(m.bookmarks)
+	cmd := m.jumpToBookmark(&items[1])
+	if cmd == nil {
+		t.Fatalf("expected a flash timer after jumping")
+	}
+	line, _, _ := st.TopLine()
+	if line.Raw != want || st.HighlightID != line.ID || st.AutoFollow {
+		t.Fatalf("jumped to %q (highlight %d, follow %v), want %q", line.Raw, st.HighlightID, st.AutoFollow, want)
+	}
+
+	m.Update(bookmarkFlashMsg(m.bookmarkFlashID))
+	if st.HighlightID != 0 {
+		t.Fatalf("highlight should clear after the flash")
+	}
+}
+
+func TestBookmarksClearOnNewOperationUnlessPinned(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.tabView.SetActiveTab(TabStream)
+	st := m.tabView.StreamTab
+	st.SetSize(80, 5)
+	for i := 0; i < 20; i++ {
+		st.AddLine(fmt.Sprintf("build line %d", i), TabLineTypeNormal)
+	}
+
+	st.ScrollToIndex(2)
+	m.toggleBookmark()
+	st.ScrollToIndex(7)
+	m.toggleBookmark()
+	m.toggleBookmark() // second press pins
+	if len(m.bookmarks) != 2 || m.bookmarks[0].Pinned || !m.bookmarks[1].Pinned {
+		t.Fatalf("unexpected bookmarks: %+v", m.bookmarks)
+	}
+
+	m.tabView.Clear()
+	m.resetBookmarks()
+	if len(m.bookmarks) != 1 || m.bookmarks[0].Preview != "build line 7" {
+		t.Fatalf("expected only the pinned bookmark to survive: %+v", m.bookmarks)
+	}
+
+	// The pinned line now lives in the previous-run snapshot.
+	items := BookmarkItems(m.bookmarks)
+	m.jumpToBookmark(&items[0])
+	if !m.tabView.ShowingPrevious {
+		t.Fatalf("expected jump to switch to the previous run")
+	}
+	if line, _, _ := m.tabView.VisibleStreamTab().TopLine(); line.Raw != "build line 7" {
+		t.Fatalf("jumped to %q", line.Raw)
+	}
+
+	st = m.tabView.VisibleStreamTab()
+	st.ScrollToIndex(7)
+	m.toggleBookmark() // third press removes
+	if len(m.bookmarks) != 0 {
+		t.Fatalf("expected bookmark removed, got %+v", m.bookmarks)
+	}
+}
+
+func TestConsoleBookmarkUsesStableIDs(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.runMode.Active = true
+	m.runMode.FocusPane = PaneConsole
+	m.runMode.Console.Follow = true
+	for i := 0; i < 50; i++ {
+		m.appendConsoleLog(fmt.Sprintf("app %d", i), "")
+	}
+	m.runMode.Console.Pos = 10
+	m.runMode.Console.Follow = false
+	m.toggleBookmark()
+	if len(m.bookmarks) != 1 || m.bookmarks[0].Pane != PaneConsole || m.bookmarks[0].Preview != "app 10" {
+		t.Fatalf("unexpected bookmarks: %+v", m.bookmarks)
+	}
+
+	m.runMode.Console.Pos = 40
+	items := BookmarkItems(m.bookmarks)
+	m.jumpToBookmark(&items[0])
+	idx := m.bookmarkLineIndex(m.bookmarks[0])
+	if m.runMode.Console.Lines[idx] != "app 10" || m.runMode.Console.Highlight != m.bookmarks[0].LineID {
+		t.Fatalf("console jump landed on %q", m.runMode.Console.Lines[idx])
+	}
+}
@@ -0,0 +1,102 @@
+package tui
+
+import (
+	"context"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// listTargetsTimeout bounds listing the targets of every project
+const listTargetsTimeout = 60 * time.Second
+
+// targetsListedMsg delivers the targets for "Build Target…"
+type targetsListedMsg struct {
+	targets []core.ProjectTarget
+	err     error
+}
+
+// opLabel names an op in status messages, e.g. "BUILD" or "BUILD target
+// Networking" for a target build
+func opLabel(name, target string) string {
+	if target == "" {
+		return strings.ToUpper(name)
+	}
+	return strings.ToUpper(name) + " target " + target
+}
+
+// requestBuildTarget lists the project's targets to build one instead of
+// the scheme, listing them with xcodebuild first when discovery didn't
+func (m *Model) requestBuildTarget() tea.Cmd {
+	if m.running {
+		m.setStatus("An operation is already running")
+		return nil
+	}
+	if len(m.info.Targets) > 0 {
+		m.openBuildTargetSelector()
+		return nil
+	}
+	m.setStatus("Listing targets…")
+	root, cfg := m.projectRoot, m.cfg
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), listTargetsTimeout)
+		defer cancel()
+		targets, err := core.ListProjectTargets(ctx, root, cfg, nil)
+		return targetsListedMsg{targets: targets, err: err}
+	}
+}
+
+func (m *Model) handleTargetsListed(msg targetsListedMsg) {
+	if msg.err != nil {
+		m.lastErr = msg.err.Error()
+		m.setStatus("Could not list targets")
+		return
+	}
+	m.info.Targets = msg.targets
+	if len(msg.targets) == 0 {
+		m.setStatus("No targets found")
+		return
+	}
+	if m.mode == ModeNormal && !m.running {
+		m.openBuildTargetSelector()
+	}
+}
+
+func (m *Model) openBuildTargetSelector() {
+	m.selector = NewSelector("Build Target", BuildTargetItems(m.info.Targets), m.width, m.styles)
+	m.selectorType = SelectorBuildTarget
+	m.mode = ModeSelector
+}
+
+// BuildTargetItems creates selector items (ID = index into targets). The
+// project is shown when the targets come from several projects.
+func BuildTargetItems(targets []core.ProjectTarget) []SelectorItem {
+	projects := map[string]bool{}
+	for _, t := range targets {
+		projects[t.Project] = true
+	}
+	items := make([]SelectorItem, 0, len(targets))
+	for i, t := range targets {
+		item := SelectorItem{ID: strconv.Itoa(i), Title: t.Name}
+		if len(projects) > 1 {
+			item.Description = strings.TrimSuffix(filepath.Base(t.Project), ".xcodeproj")
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// selectBuildTarget builds the picked target
+func (m *Model) selectBuildTarget(item *SelectorItem) tea.Cmd {
+	i, err := strconv.Atoi(item.ID)
+	if err != nil || i < 0 || i >= len(m.info.Targets) {
+		return nil
+	}
+	t := m.info.Targets[i]
+	return m.startOpRequest(core.LastOp{Name: "build", Target: t.Name, TargetProject: t.Project})
+}
@@ -0,0 +1,39 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func TestBuildTargetItemsNameProjects(t *testing.T) {
+	single := BuildTargetItems([]core.ProjectTarget{{Name: "App", Project: "App.xcodeproj"}, {Name: "Networking", Project: "App.xcodeproj"}})
+	if len(single) != 2 || single[1].Title != "Networking" || single[1].Description != "" {
+		t.Fatalf("unexpected items %+v", single)
+	}
+	multi := BuildTargetItems([]core.ProjectTarget{{Name: "App", Project: "App.xcodeproj"}, {Name: "Networking", Project: "Net/Net.xcodeproj"}})
+	if multi[1].ID != "1" || multi[1].Description != "Net" {
+		t.Fatalf("unexpected item %+v", multi[1])
+	}
+}
+
+func TestOpLabel(t *testing.T) {
+	if got := opLabel("build", "Networking"); got != "BUILD target Networking" {
+		t.Fatalf("opLabel = %q", got)
+	}
+	if got := opLabel("test", ""); got != "TEST" {
+		t.Fatalf("opLabel = %q", got)
+	}
+}
+
+func TestHandleTargetsListedOpensSelector(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.handleTargetsListed(targetsListedMsg{targets: []core.ProjectTarget{{Name: "Networking", Project: "App.xcodeproj"}}})
+	if m.mode != ModeSelector || m.selectorType != SelectorBuildTarget {
+		t.Fatalf("mode = %v, selector = %v", m.mode, m.selectorType)
+	}
+	if cmd := m.requestBuildTarget(); cmd != nil {
+		t.Fatal("listed targets again instead of reusing them")
+	}
+}
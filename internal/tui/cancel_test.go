@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func TestCanceledTestRunKeepsPartialResults(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.lastTest = core.TestResult{Summary: core.TestSummary{Total: 300, Passed: 300}}
+
+	m.running = true
+	m.runningCmd = "test"
+	m.tabView.SummaryTab.SetRunning("test")
+	m.tabView.AddRawLine("/src/LoginTests.swift:12: error: -[LoginTests testSSO] : XCTAssertTrue failed")
+	partial := core.TestSummary{Total: 120, Passed: 118, Failed: 2, Partial: true}
+	m.handleOpDone(opDoneMsg{cmd: "test", err: context.Canceled, test: &core.TestResult{Summary: partial}})
+
+	st := m.tabView.SummaryTab
+	if st.Status != BuildStatusCanceled || st.TimedOut {
+		t.Fatalf("status = %v timedOut = %v", st.Status, st.TimedOut)
+	}
+	if want := "Canceled — 120 of ~300 tests ran, 2 failures so far"; st.CanceledDetail != want {
+		t.Fatalf("detail = %q, want %q", st.CanceledDetail, want)
+	}
+	st.SetSize(100, 40)
+	if view := stripANSI(st.View(DefaultStyles())); !strings.Contains(view, "TEST CANCELED") || !strings.Contains(view, "120 of ~300 tests ran") {
+		t.Fatalf("canceled view missing partial results:\n%s", view)
+	}
+	if len(m.tabView.IssuesTab.Issues) == 0 {
+		t.Fatalf("issues from the partial run should be kept")
+	}
+	if m.statusBar.LastResultStatus != "canceled" {
+		t.Fatalf("last result status = %q", m.statusBar.LastResultStatus)
+	}
+}
+
+func TestTimedOutBuildReportsPhase(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.running = true
+	m.runningCmd = "build"
+	m.currentStage = "Linking"
+	m.tabView.SummaryTab.SetRunning("build")
+	err := fmt.Errorf("xcodebuild: %w", context.DeadlineExceeded)
+	m.handleOpDone(opDoneMsg{cmd: "build", err: err, build: &core.BuildResult{}})
+
+	st := m.tabView.SummaryTab
+	if !st.TimedOut || st.CanceledDetail != "Timed out during Linking" {
+		t.Fatalf("timedOut = %v detail = %q", st.TimedOut, st.CanceledDetail)
+	}
+	if m.statusBar.LastResultStatus != "timed_out" || !strings.Contains(m.statusMsg, "timed out") {
+		t.Fatalf("status = %q / %q", m.statusBar.LastResultStatus, m.statusMsg)
+	}
+}
+
+func TestLaunchCanceledReportsBuildSuccess(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.running = true
+	m.runningCmd = "run"
+	m.tabView.SummaryTab.SetRunning("run")
+	m.parseProgressFromEvent(core.Event{Type: "status", Code: core.RunPhaseCode, Msg: "Run phase: launch", Data: map[string]any{"phase": "launch"}})
+	if m.currentStage != "Launch" {
+		t.Fatalf("stage = %q, want Launch", m.currentStage)
+	}
+
+	cfg := m.cfg
+	cfg.Version = 1
+	cfg.LastBuiltAppBundle = "/dd/App.app"
+	err := &core.RunPhaseCanceledError{Phase: core.RunPhaseLaunch, Err: context.Canceled}
+	m.handleOpDone(opDoneMsg{cmd: "run", err: err, cfg: cfg, run: &core.RunResult{AppPath: "/dd/App.app", Phase: core.RunPhaseLaunch}, runPhase: err.Error()})
+
+	st := m.tabView.SummaryTab
+	if st.Status != BuildStatusSuccess || st.RunPhaseNote == "" {
+		t.Fatalf("status = %v note = %q", st.Status, st.RunPhaseNote)
+	}
+	st.SetSize(100, 40)
+	if view := stripANSI(st.View(DefaultStyles())); !strings.Contains(view, "BUILD SUCCEEDED") || !strings.Contains(view, "launch canceled") {
+		t.Fatalf("success view missing the canceled launch:\n%s", view)
+	}
+	if m.statusBar.LastResultStatus != "success" || m.statusBar.LastResultOp != "build" {
+		t.Fatalf("status bar = %q %q", m.statusBar.LastResultStatus, m.statusBar.LastResultOp)
+	}
+	if !strings.Contains(m.statusMsg, "launch canceled") {
+		t.Fatalf("status = %q", m.statusMsg)
+	}
+	if m.cfg.LastBuiltAppBundle != "/dd/App.app" {
+		t.Fatalf("LastBuiltAppBundle = %q", m.cfg.LastBuiltAppBundle)
+	}
+}
+
+func TestLaunchOnlyNeedsABuiltApp(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	if cmd := m.launchOnly(); cmd != nil || m.running || !strings.Contains(m.statusMsg, "No built app") {
+		t.Fatalf("launch only without an app: running = %v status = %q", m.running, m.statusMsg)
+	}
+}
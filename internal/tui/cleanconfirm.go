@@ -0,0 +1,78 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// =============================================================================
+// Clean Confirmation
+// =============================================================================
+
+// cleanPlanMsg carries the measured targets of a clean op awaiting confirm
+type cleanPlanMsg struct {
+	op      string
+	targets []core.CleanTarget
+}
+
+func isCleanOp(name string) bool {
+	switch name {
+	case "clean", "clean-derived", "clean-results", "clean-sessions", "clean-spm-cache":
+		return true
+	}
+	return false
+}
+
+// requestClean starts a clean op, first measuring what it would delete and
+// asking for confirmation unless tui.confirmClean is off
+func (m *Model) requestClean(op string) tea.Cmd {
+	if !m.cfg.TUI.ShouldConfirmClean() {
+		return m.startOrRestartOp(op)
+	}
+	m.setStatus("Measuring " + op + " targets…")
+	root, cfg := m.projectRoot, m.cfg
+	return func() tea.Msg {
+		targets := core.MeasureCleanTargets(context.Background(), core.CleanTargets(root, cfg, op))
+		return cleanPlanMsg{op: op, targets: targets}
+	}
+}
+
+func (m *Model) handleCleanPlan(msg cleanPlanMsg) {
+	if len(msg.targets) == 0 {
+		m.setStatus("Nothing to clean")
+		return
+	}
+	var body, warn []string
+	var total int64
+	for _, t := range msg.targets {
+		line := fmt.Sprintf("%s  %s", cleanSize(t), relativeToRoot(m.projectRoot, t.Path))
+		total += t.Bytes
+		if t.Global {
+			warn = append(warn, line+" (shared by all projects)")
+		} else {
+			body = append(body, line)
+		}
+	}
+	targets, op := msg.targets, msg.op
+	m.openConfirm(ConfirmPrompt{
+		Title: fmt.Sprintf("Delete %s?", core.FormatBytes(total)),
+		Body:  body,
+		Warn:  warn,
+		OnYes: func(m *Model) tea.Cmd {
+			m.cleanTargets = targets
+			return m.startOrRestartOp(op)
+		},
+	})
+}
+
+// cleanSize formats a target's size, marking timed-out walks as lower bounds
+func cleanSize(t core.CleanTarget) string {
+	if t.Partial {
+		return "≥" + core.FormatBytes(t.Bytes)
+	}
+	return core.FormatBytes(t.Bytes)
+}
@@ -0,0 +1,65 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func TestCleanAsksBeforeDeleting(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	root := t.TempDir()
+	results := filepath.Join(root, ".xcbolt", "Results")
+	if err := os.MkdirAll(results, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(results, "log"), make([]byte, 2048), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel(root, "", ConfigOverrides{})
+
+	cmd := m.requestClean("clean-results")
+	if cmd == nil || m.running {
+		t.Fatalf("clean should measure first, not start")
+	}
+	m.handleCleanPlan(cmd().(cleanPlanMsg))
+	if m.mode != ModeConfirm || !strings.Contains(m.confirm.Title, "2.0 KB") {
+		t.Fatalf("expected a confirm prompt with the size: mode=%v title=%q", m.mode, m.confirm.Title)
+	}
+
+	m.handleConfirmKey(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.running {
+		t.Fatalf("cancel should not start the clean")
+	}
+	if _, err := os.Stat(results); err != nil {
+		t.Fatalf("results should survive a cancel: %v", err)
+	}
+}
+
+func TestCleanSwiftPMCacheIsHighlighted(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.handleCleanPlan(cleanPlanMsg{op: "clean-spm-cache", targets: []core.CleanTarget{
+		{Path: filepath.Join(home, "Library", "Caches", "org.swift.swiftpm"), Bytes: 1 << 20, Global: true},
+	}})
+	if len(m.confirm.Body) != 0 || len(m.confirm.Warn) != 1 || !strings.Contains(m.confirm.Warn[0], "shared by all projects") {
+		t.Fatalf("global caches should be listed as warnings: body=%q warn=%q", m.confirm.Body, m.confirm.Warn)
+	}
+}
+
+func TestCleanWithoutConfirmStartsImmediately(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	off := false
+	m.cfg.TUI.ConfirmClean = &off
+	if cmd := m.requestClean("clean-sessions"); cmd == nil || !m.running {
+		t.Fatalf("confirmClean=false should start the op directly")
+	}
+	m.cancelRunningOp()
+}
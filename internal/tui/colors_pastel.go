@@ -19,6 +19,9 @@ func PastelColorsDark() Colors {
 		Error:   lipgloss.AdaptiveColor{Light: "#C74B5C", Dark: "#F7768E"}, // Soft coral
 		Running: lipgloss.AdaptiveColor{Light: "#8B6AB0", Dark: "#BB9AF7"}, // Soft purple
 
+		// Analyzer - soft cyan, apart from warnings
+		Analyzer: lipgloss.AdaptiveColor{Light: "#2A7E8F", Dark: "#7DCFFF"},
+
 		// Backgrounds - deep navy
 		Background: lipgloss.AdaptiveColor{Light: "#FFFBF5", Dark: "#1A1B26"},
 		Surface:    lipgloss.AdaptiveColor{Light: "#F5F0E8", Dark: "#24283B"},
@@ -48,6 +51,9 @@ func PastelColorsLight() Colors {
 		Error:   lipgloss.AdaptiveColor{Light: "#C74B5C", Dark: "#F7768E"},
 		Running: lipgloss.AdaptiveColor{Light: "#8B6AB0", Dark: "#BB9AF7"},
 
+		// Analyzer - soft cyan, apart from warnings
+		Analyzer: lipgloss.AdaptiveColor{Light: "#2A7E8F", Dark: "#7DCFFF"},
+
 		// Backgrounds - warm cream
 		Background: lipgloss.AdaptiveColor{Light: "#FFFBF5", Dark: "#1A1B26"},
 		Surface:    lipgloss.AdaptiveColor{Light: "#F5F0E8", Dark: "#24283B"},
@@ -116,6 +122,9 @@ func PastelColors() Colors {
 		Error:   lipgloss.AdaptiveColor{Light: "#C74B5C", Dark: "#F7768E"},
 		Running: lipgloss.AdaptiveColor{Light: "#8B6AB0", Dark: "#BB9AF7"},
 
+		// Analyzer - soft cyan, apart from warnings
+		Analyzer: lipgloss.AdaptiveColor{Light: "#2A7E8F", Dark: "#7DCFFF"},
+
 		// Backgrounds
 		Background: lipgloss.AdaptiveColor{Light: "#FFFBF5", Dark: "#1A1B26"},
 		Surface:    lipgloss.AdaptiveColor{Light: "#F5F0E8", Dark: "#24283B"},
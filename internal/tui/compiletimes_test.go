@@ -0,0 +1,59 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func TestSlowestFilesCard(t *testing.T) {
+	st := NewSummaryTab()
+	st.SetSize(100, 50)
+	st.SetContextLoaded(true)
+	st.SetResult(BuildStatusSuccess, "12s", nil, 0, 0)
+	if view := stripANSI(st.View(DefaultStyles())); strings.Contains(view, "Slowest files") {
+		t.Fatalf("card shown without timing data:\n%s", view)
+	}
+
+	st.SetSlowestFiles([]core.CompileTime{
+		{File: "/src/App/FeedView.swift", Duration: 4210 * time.Millisecond},
+		{File: "App", Files: 12, Duration: 2 * time.Second},
+	})
+	view := stripANSI(st.View(DefaultStyles()))
+	for _, want := range []string{"Slowest files", "4.21s  FeedView.swift", "2.00s  App (12 files)"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("card missing %q:\n%s", want, view)
+		}
+	}
+
+	st.Clear()
+	if st.SlowestFiles != nil {
+		t.Fatalf("Clear kept the slowest files")
+	}
+}
+
+func TestAnnotateCompileTimes(t *testing.T) {
+	stream := NewStreamTab()
+	stream.SetSize(120, 20)
+	stream.AddLine("Compiling FeedView.swift", TabLineTypeNormal)
+	stream.AddLine("Compiling Model.swift", TabLineTypeNormal)
+	stream.AddLine("Linking App", TabLineTypeNormal)
+
+	n := stream.AnnotateCompileTimes([]core.CompileTime{
+		{File: "/src/App/FeedView.swift", Duration: 1500 * time.Millisecond},
+		{File: "/src/App/Other.swift", Duration: time.Second},
+		{File: "App", Files: 3, Duration: 5 * time.Second},
+	})
+	if n != 1 {
+		t.Fatalf("annotated %d lines, want 1", n)
+	}
+	view := stripANSI(stream.View(DefaultStyles()))
+	if !strings.Contains(view, "Compiling FeedView.swift  (1.50s)") {
+		t.Fatalf("compile time missing:\n%s", view)
+	}
+	if strings.Contains(view, "Model.swift  (") || strings.Contains(view, "Linking App  (") {
+		t.Fatalf("unexpected annotation:\n%s", view)
+	}
+}
@@ -0,0 +1,71 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// =============================================================================
+// Config Validation
+// =============================================================================
+
+// configProblemsStatus summarizes config problems for the status bar,
+// spelling out the first one ("" when there are none)
+func configProblemsStatus(problems []core.ConfigProblem) string {
+	switch len(problems) {
+	case 0:
+		return ""
+	case 1:
+		return "Config: " + problems[0].String()
+	}
+	return fmt.Sprintf("Config: %s (+%d more, see `xcbolt config validate`)", problems[0].String(), len(problems)-1)
+}
+
+// validateConfig checks the config file for unknown and deprecated keys
+func (m *Model) validateConfig() {
+	problems, err := core.CheckConfig(m.projectRoot, m.configPath)
+	if err != nil {
+		m.lastErr = err.Error()
+		m.setStatus("Config is not valid JSON")
+		return
+	}
+	if len(problems) == 0 {
+		m.setStatus("Config OK: no unknown or deprecated keys")
+		return
+	}
+	m.setStatus(configProblemsStatus(problems))
+}
+
+// =============================================================================
+// Effective Config
+// =============================================================================
+
+// ConfigOriginItems creates selector items from effective config values
+// (ID = key, Meta = origin)
+func ConfigOriginItems(settings []core.ConfigSetting) []SelectorItem {
+	items := make([]SelectorItem, len(settings))
+	for i, s := range settings {
+		items[i] = SelectorItem{
+			ID:          s.Key,
+			Title:       s.Key,
+			Description: s.Value,
+			Meta:        s.Origin,
+		}
+	}
+	return items
+}
+
+// openConfigOrigins lists the effective config with where each value came
+// from (default, global, project, or flag)
+func (m *Model) openConfigOrigins() {
+	settings, err := core.ConfigOrigins(m.cfg)
+	if err != nil {
+		m.lastErr = err.Error()
+		m.setStatus("Could not read the effective config")
+		return
+	}
+	m.selector = NewSelector("Effective Config (enter copies value)", ConfigOriginItems(settings), m.width, m.styles)
+	m.selectorType = SelectorConfigOrigins
+	m.mode = ModeSelector
+}
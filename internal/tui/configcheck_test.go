@@ -0,0 +1,54 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func TestConfigProblemsStatus(t *testing.T) {
+	if got := configProblemsStatus(nil); got != "" {
+		t.Fatalf("expected no status without problems, got %q", got)
+	}
+	problems := []core.ConfigProblem{
+		{Key: "configuratoin", Line: 4, Kind: core.ConfigProblemUnknown, Suggestion: "configuration"},
+		{Key: "tui.notfy", Line: 9, Kind: core.ConfigProblemUnknown, Suggestion: "tui.notify"},
+	}
+	if got := configProblemsStatus(problems[:1]); got != `Config: line 4: unknown key "configuratoin" (did you mean "configuration"?)` {
+		t.Fatalf("unexpected status %q", got)
+	}
+	if got := configProblemsStatus(problems); !strings.Contains(got, "+1 more") {
+		t.Fatalf("expected the remaining count, got %q", got)
+	}
+}
+
+func TestOpenConfigOriginsListsEffectiveValues(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	cfg, err := core.LoadConfig(m.projectRoot, "")
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	m.cfg = cfg
+	m.cfg.Xcodebuild.LogFormat = "raw"
+	m.cfg.SetFlagOrigin("xcodebuild.logFormat")
+
+	m.openConfigOrigins()
+	if m.mode != ModeSelector || m.selectorType != SelectorConfigOrigins {
+		t.Fatalf("mode = %v, selector = %v", m.mode, m.selectorType)
+	}
+	found := false
+	for _, item := range m.selector.items {
+		if item.ID == "xcodebuild.logFormat" {
+			found = true
+			if item.Description != `"raw"` || item.Meta != core.ConfigOriginFlag {
+				t.Fatalf("logFormat item = %+v", item)
+			}
+		}
+	}
+	if !found {
+		t.Fatal("missing xcodebuild.logFormat")
+	}
+}
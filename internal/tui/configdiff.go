@@ -0,0 +1,117 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// configChangesDuration is how long the Dashboard shows what a config
+// change did
+const configChangesDuration = 8 * time.Second
+
+// configChangesClearMsg hides the Dashboard's config changes; gen drops
+// clears of changes that were replaced in the meantime
+type configChangesClearMsg struct{ gen int }
+
+// configChange is one config value that changed, e.g. scheme "" → "App"
+type configChange struct {
+	Key  string
+	From string
+	To   string
+}
+
+// String renders the change as "scheme: — → App"
+func (c configChange) String() string {
+	return c.Key + ": " + orDash(c.From) + " → " + orDash(c.To)
+}
+
+// configToggles are the boolean settings diffConfig reports
+var configToggles = []struct {
+	key string
+	get func(core.Config) bool
+}{
+	{"dry run", func(c core.Config) bool { return c.Xcodebuild.DryRun }},
+	{"timing report", func(c core.Config) bool { return c.Xcodebuild.TimingReport }},
+	{"code coverage", func(c core.Config) bool { return c.Test.CodeCoverage }},
+	{"destination check", func(c core.Config) bool { return !c.Xcodebuild.SkipDestinationCheck }},
+}
+
+// diffConfig lists the values that differ between before and after: the
+// project, scheme, configuration, test plan, destination, and toggles
+func diffConfig(before, after core.Config) []configChange {
+	var changes []configChange
+	add := func(key, from, to string) {
+		if from != to {
+			changes = append(changes, configChange{Key: key, From: from, To: to})
+		}
+	}
+	add("workspace", before.Workspace, after.Workspace)
+	add("project", before.Project, after.Project)
+	add("scheme", before.Scheme, after.Scheme)
+	add("configuration", before.Configuration, after.Configuration)
+	add("test plan", before.Test.Plan, after.Test.Plan)
+	add("destination", destinationSummary(before.Destination), destinationSummary(after.Destination))
+	for _, t := range configToggles {
+		add(t.key, onOff(t.get(before)), onOff(t.get(after)))
+	}
+	return changes
+}
+
+// destinationSummary names a destination with its OS, e.g. "iPhone 15
+// (17.5)"; auto destinations show their platform
+func destinationSummary(d core.Destination) string {
+	switch {
+	case d.Name != "" && d.OS != "" && d.OS != "macOS":
+		return d.Name + " (" + d.OS + ")"
+	case d.Name != "":
+		return d.Name
+	case d.Kind == core.DestAuto && d.PlatformFamily != core.PlatformUnknown:
+		return "auto (" + core.PlatformFamilyLabel(d.PlatformFamily) + ")"
+	}
+	return destinationID(d)
+}
+
+func onOff(on bool) string {
+	if on {
+		return "on"
+	}
+	return "off"
+}
+
+// formatConfigChanges joins changes into one line
+func formatConfigChanges(changes []configChange) string {
+	parts := make([]string, len(changes))
+	for i, c := range changes {
+		parts[i] = c.String()
+	}
+	return strings.Join(parts, ", ")
+}
+
+// noteConfigChanges shows how the config differs from before on the
+// Dashboard for a few seconds and logs it to the Logs tab
+func (m *Model) noteConfigChanges(before core.Config) tea.Cmd {
+	changes := diffConfig(before, m.cfg)
+	if len(changes) == 0 {
+		return nil
+	}
+	lines := make([]string, len(changes))
+	for i, c := range changes {
+		lines[i] = c.String()
+	}
+	m.tabView.SummaryTab.SetConfigChanges(lines)
+	m.tabView.AddLine("[xcbolt] Config: "+formatConfigChanges(changes), TabLineTypeNormal)
+
+	m.configChangesGen++
+	gen := m.configChangesGen
+	return tea.Tick(configChangesDuration, func(time.Time) tea.Msg { return configChangesClearMsg{gen: gen} })
+}
+
+func (m *Model) clearConfigChanges(msg configChangesClearMsg) {
+	if msg.gen == m.configChangesGen {
+		m.tabView.SummaryTab.SetConfigChanges(nil)
+	}
+}
@@ -0,0 +1,56 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func TestDiffConfig(t *testing.T) {
+	before := core.Config{Destination: core.Destination{Kind: core.DestAuto}}
+	after := core.Config{
+		Project:       "App.xcodeproj",
+		Scheme:        "MyApp",
+		Configuration: "Debug",
+		Destination:   core.Destination{Kind: core.DestSimulator, UDID: "SIM-A", Name: "iPhone 15", OS: "17.5"},
+		Xcodebuild:    core.XcodebuildConfig{DryRun: true},
+	}
+	got := formatConfigChanges(diffConfig(before, after))
+	want := "project: — → App.xcodeproj, scheme: — → MyApp, configuration: — → Debug, destination: — → iPhone 15 (17.5), dry run: off → on"
+	if got != want {
+		t.Fatalf("diff =\n%s\nwant\n%s", got, want)
+	}
+	if changes := diffConfig(after, after); len(changes) != 0 {
+		t.Fatalf("no change reported %v", changes)
+	}
+}
+
+func TestSelectorChangeShowsConfigDiff(t *testing.T) {
+	m := schemeDestModel(t)
+	m.cfg.Destination.OS = "17.5"
+	m.selectorType = SelectorDestination
+	cmd := m.handleSelectorResult(&SelectorItem{ID: "macos", Title: "My Mac"})
+	if cmd == nil {
+		t.Fatal("expected a command clearing the Dashboard diff")
+	}
+	want := "destination: iPhone 15 (17.5) → My Mac"
+	if got := m.tabView.SummaryTab.ConfigChanges; len(got) != 1 || got[0] != want {
+		t.Fatalf("Dashboard changes = %q, want %q", got, want)
+	}
+	lines := m.tabView.StreamTab.Lines
+	if len(lines) == 0 || !strings.Contains(lines[len(lines)-1].Text, "[xcbolt] Config: "+want) {
+		t.Fatalf("Logs tab lines = %+v", lines)
+	}
+
+	// A newer change outlives the clear of the older one
+	m.handleSelectorResult(&SelectorItem{ID: "catalyst", Title: "My Mac (Catalyst)"})
+	m.clearConfigChanges(configChangesClearMsg{gen: m.configChangesGen - 1})
+	if len(m.tabView.SummaryTab.ConfigChanges) == 0 {
+		t.Fatal("stale clear hid the newer changes")
+	}
+	m.clearConfigChanges(configChangesClearMsg{gen: m.configChangesGen})
+	if len(m.tabView.SummaryTab.ConfigChanges) != 0 {
+		t.Fatal("changes not cleared")
+	}
+}
@@ -0,0 +1,119 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// =============================================================================
+// Confirm Prompt
+// =============================================================================
+
+// ConfirmPrompt is a yes/no overlay guarding actions that change files
+type ConfirmPrompt struct {
+	Title string
+	Body  []string
+	Warn  []string // Rendered after Body in the warning color
+	OnYes func(m *Model) tea.Cmd
+	// OnNo, when set, makes n a second choice (esc still cancels);
+	// YesHint and NoHint label the keys
+	OnNo    func(m *Model) tea.Cmd
+	YesHint string
+	NoHint  string
+}
+
+// View renders the prompt content (without centering)
+func (c ConfirmPrompt) View(width int, s Styles) string {
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(s.Colors.Text)
+	b.WriteString(titleStyle.Render(c.Title))
+	b.WriteString("\n")
+
+	dividerStyle := lipgloss.NewStyle().Foreground(s.Colors.BorderMuted)
+	b.WriteString(dividerStyle.Render(strings.Repeat("─", width-6)))
+	b.WriteString("\n")
+
+	bodyStyle := lipgloss.NewStyle().Foreground(s.Colors.TextMuted)
+	for _, line := range c.Body {
+		b.WriteString(bodyStyle.Render(truncateString(line, width-6)))
+		b.WriteString("\n")
+	}
+	warnStyle := lipgloss.NewStyle().Foreground(s.Colors.Warning)
+	for _, line := range c.Warn {
+		b.WriteString(warnStyle.Render(truncateString(line, width-6)))
+		b.WriteString("\n")
+	}
+	if len(c.Body)+len(c.Warn) > 0 {
+		b.WriteString("\n")
+	}
+
+	hintKeyStyle := lipgloss.NewStyle().Foreground(s.Colors.Accent)
+	hintDescStyle := lipgloss.NewStyle().Foreground(s.Colors.TextSubtle)
+	yes := c.YesHint
+	if yes == "" {
+		yes = "confirm"
+	}
+	if c.OnNo != nil {
+		no := c.NoHint
+		if no == "" {
+			no = "no"
+		}
+		b.WriteString(hintKeyStyle.Render("y") + hintDescStyle.Render(" "+yes+"  ") +
+			hintKeyStyle.Render("n") + hintDescStyle.Render(" "+no+"  ") +
+			hintKeyStyle.Render("esc") + hintDescStyle.Render(" cancel"))
+	} else {
+		b.WriteString(hintKeyStyle.Render("y") + hintDescStyle.Render(" "+yes+"  ") +
+			hintKeyStyle.Render("n/esc") + hintDescStyle.Render(" cancel"))
+	}
+
+	containerStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(s.Colors.Border).
+		Padding(1, 2)
+
+	return containerStyle.Width(width).Render(b.String())
+}
+
+func (m *Model) openConfirm(p ConfirmPrompt) {
+	m.confirm = p
+	m.mode = ModeConfirm
+}
+
+func (m *Model) handleConfirmKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "y", "Y", "enter":
+		p := m.confirm
+		m.confirm = ConfirmPrompt{}
+		m.mode = ModeNormal
+		if p.OnYes != nil {
+			return p.OnYes(m)
+		}
+	case "n", "N":
+		p := m.confirm
+		m.confirm = ConfirmPrompt{}
+		m.mode = ModeNormal
+		if p.OnNo != nil {
+			return p.OnNo(m)
+		}
+		m.setStatus("Cancelled")
+	case "esc", "q":
+		m.confirm = ConfirmPrompt{}
+		m.mode = ModeNormal
+		m.setStatus("Cancelled")
+	}
+	return nil
+}
+
+func (m Model) confirmOverlayView() string {
+	width := m.width * 50 / 100
+	if width < 50 {
+		width = 50
+	}
+	if width > 80 {
+		width = 80
+	}
+	return RenderCenteredPopup(m.confirm.View(width, m.styles), m.width, m.height)
+}
@@ -0,0 +1,282 @@
+package tui
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// =============================================================================
+// Console Pane
+// =============================================================================
+
+// ConsolePane holds console lines and their scroll state: the app's output
+// in run mode, or a unified log stream in the log browser
+type ConsolePane struct {
+	Lines     []string    // Wrapped lines; meta and system lines carry a prefix
+	Levels    []string    // Level letter per line ("" = always shown)
+	Times     []time.Time // Emit time per line
+	IDs       []uint64    // Monotonic line ID per line
+	Pos       int         // Scroll position among the visible lines
+	Follow    bool        // Stick to the newest line
+	NewLines  int         // Lines added while scrolled up
+	Highlight uint64      // Line flashed after a bookmark jump or search
+
+	nextID uint64 // Never reset, so IDs stay unique across runs
+}
+
+// Reset drops every line and follows new output
+func (c *ConsolePane) Reset() {
+	c.Lines, c.Levels, c.Times, c.IDs = nil, nil, nil, nil
+	c.Pos = 0
+	c.Follow = true
+	c.NewLines = 0
+	c.Highlight = 0
+}
+
+// Append stores a console entry ("meta\nmessage" adds a meta line above the
+// message) split into display lines by wrap
+func (c *ConsolePane) Append(entry, level string, at time.Time, wrap func(string) []string) {
+	meta, msg := splitConsoleEntry(entry)
+	if meta != "" {
+		c.add(consoleMetaPrefix+meta, level, at)
+	}
+	for _, wrapped := range wrap(msg) {
+		c.add(wrapped, level, at)
+	}
+}
+
+func (c *ConsolePane) add(line, level string, at time.Time) {
+	c.nextID++
+	c.Lines = append(c.Lines, line)
+	c.Levels = append(c.Levels, level)
+	c.Times = append(c.Times, at)
+	c.IDs = append(c.IDs, c.nextID)
+	if !c.Follow {
+		c.NewLines++
+	}
+}
+
+// Trim drops the oldest lines beyond limit, keeping a scrolled-back view on
+// the same lines
+func (c *ConsolePane) Trim(limit int, show func(level string) bool) {
+	if limit <= 0 || len(c.Lines) <= limit {
+		return
+	}
+	drop := len(c.Lines) - limit
+	visibleDrop := 0
+	for _, lvl := range c.Levels[:drop] {
+		if show(lvl) {
+			visibleDrop++
+		}
+	}
+	c.Lines = c.Lines[drop:]
+	c.Levels = c.Levels[drop:]
+	c.Times = c.Times[drop:]
+	c.IDs = c.IDs[drop:]
+	if !c.Follow && c.Pos > 0 {
+		c.Pos = maxInt(0, c.Pos-visibleDrop)
+	}
+}
+
+// VisibleIndices returns the indices of the lines show lets through
+func (c *ConsolePane) VisibleIndices(show func(level string) bool) []int {
+	idx := make([]int, 0, len(c.Lines))
+	for i := range c.Lines {
+		if i < len(c.Levels) && !show(c.Levels[i]) {
+			continue
+		}
+		idx = append(idx, i)
+	}
+	return idx
+}
+
+// Scroll moves the view by delta lines, clamped to [0, maxPos]; scrolling
+// to the end follows new output again
+func (c *ConsolePane) Scroll(delta, maxPos int) {
+	maxPos = maxInt(0, maxPos)
+	c.Pos = minInt(maxInt(0, c.Pos+delta), maxPos)
+	c.Follow = c.Pos == maxPos
+	if c.Follow {
+		c.NewLines = 0
+	}
+}
+
+// ScrollToEnd shows the newest lines and follows new output
+func (c *ConsolePane) ScrollToEnd(maxPos int) {
+	c.Pos = maxInt(0, maxPos)
+	c.Follow = true
+	c.NewLines = 0
+}
+
+// Settle keeps a following view at the newest line after lines were added
+func (c *ConsolePane) Settle(maxPos int) {
+	maxPos = maxInt(0, maxPos)
+	if c.Follow || c.Pos >= maxPos-1 {
+		c.ScrollToEnd(maxPos)
+	}
+}
+
+// IndexOfID returns the index of the line with id, or -1 once it has been
+// trimmed or cleared
+func (c *ConsolePane) IndexOfID(id uint64) int {
+	i := sort.Search(len(c.IDs), func(i int) bool { return c.IDs[i] >= id })
+	if i < len(c.IDs) && c.IDs[i] == id {
+		return i
+	}
+	return -1
+}
+
+// Search returns the positions in visible of the lines containing query
+// (case-insensitive)
+func (c *ConsolePane) Search(query string, visible []int) []int {
+	query = strings.ToLower(query)
+	if query == "" {
+		return nil
+	}
+	var matches []int
+	for pos, idx := range visible {
+		if strings.Contains(strings.ToLower(consolePlainLine(c.Lines[idx])), query) {
+			matches = append(matches, pos)
+		}
+	}
+	return matches
+}
+
+// Text returns every line as plain text, e.g. for saving the captured
+// window to a file
+func (c *ConsolePane) Text() string {
+	var b strings.Builder
+	for _, line := range c.Lines {
+		b.WriteString(consolePlainLine(line))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// consolePlainLine strips the meta/system marker of a console line
+func consolePlainLine(line string) string {
+	line = strings.TrimPrefix(line, consoleMetaPrefix)
+	return strings.TrimPrefix(line, consoleSystemPrefix)
+}
+
+// consoleViewOptions describe how a ConsolePane is rendered
+type consoleViewOptions struct {
+	Width, Height int
+	Header        string // Optional first row
+	Visible       []int  // Line indices passing the level filter
+	Timestamps    bool
+	Styles        Styles
+}
+
+// View renders the visible lines with a scrollbar, padded to the height
+func (c *ConsolePane) View(o consoleViewOptions) string {
+	if o.Height <= 0 {
+		return ""
+	}
+	s := o.Styles
+	contentHeight := o.Height
+	if o.Header != "" {
+		contentHeight--
+	}
+	barWidth := scrollbarWidth
+	if o.Width-barWidth < 1 {
+		barWidth = 0
+	}
+	contentWidth := o.Width - barWidth
+	if contentWidth < 1 {
+		contentWidth = o.Width
+	}
+	pad := lipgloss.NewStyle().Width(contentWidth)
+	emptyBar := strings.Repeat(" ", barWidth)
+	start := maxInt(0, c.Pos)
+	end := minInt(start+contentHeight, len(o.Visible))
+
+	// Follow indicator on the top row, once the lines overflow the pane
+	badge := ""
+	if len(o.Visible) > contentHeight || !c.Follow {
+		badge = followIndicator(c.Follow, c.NewLines, s)
+	}
+	var lines []string
+	row := func(text string) string {
+		if badge != "" && len(lines) == 0 {
+			return overlayTopRight(text, badge, contentWidth)
+		}
+		return pad.Render(text)
+	}
+
+	if o.Header != "" {
+		lines = append(lines, row(o.Header)+emptyBar)
+	}
+	metaStyle := lipgloss.NewStyle().Foreground(s.Colors.TextMuted)
+	systemStyle := lipgloss.NewStyle().Foreground(s.Colors.TextMuted)
+	barLines := renderScrollbarLines(contentHeight, len(o.Visible), c.Pos, s)
+	if len(barLines) != contentHeight {
+		barLines = make([]string, contentHeight)
+		for i := range barLines {
+			barLines[i] = emptyBar
+		}
+	}
+	for i := start; i < end; i++ {
+		idx := o.Visible[i]
+		line := c.Lines[idx]
+		level := ""
+		if idx < len(c.Levels) {
+			level = c.Levels[idx]
+		}
+		style := consoleLevelStyle(s, level)
+		prefix := ""
+		if strings.HasPrefix(line, consoleMetaPrefix) {
+			line = strings.TrimPrefix(line, consoleMetaPrefix)
+			style = metaStyle
+			if level != "" {
+				prefix = consoleLevelStyle(s, level).Bold(true).Render(level) + " "
+			}
+		} else if strings.HasPrefix(line, consoleSystemPrefix) {
+			line = strings.TrimPrefix(line, consoleSystemPrefix)
+			style = systemStyle
+		}
+		lineWidth := contentWidth
+		if o.Timestamps && idx < len(c.Times) {
+			ts := c.Times[idx].Local().Format(streamTimestampLayout)
+			prefix = lipgloss.NewStyle().Foreground(s.Syntax.Timestamp).Render(ts) + " " + prefix
+			lineWidth -= len(ts) + 1
+		}
+		// Truncate long lines
+		if lineWidth > 7 && len(line) > lineWidth-4 {
+			line = line[:lineWidth-7] + "..."
+		}
+		if c.Highlight != 0 && idx < len(c.IDs) && c.IDs[idx] == c.Highlight {
+			style = flashStyle(s)
+		}
+		barLine := emptyBar
+		if barWidth > 0 {
+			barLine = barLines[i-start]
+		}
+		lines = append(lines, row(prefix+style.Render(line))+barLine)
+	}
+
+	// Pad to fill height
+	for len(lines) < o.Height {
+		lines = append(lines, row("")+emptyBar)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// consoleLevelStyle colors console text by level: D dim, I default,
+// W yellow, E/F red bold.
+func consoleLevelStyle(s Styles, level string) lipgloss.Style {
+	c := s.Colors
+	switch level {
+	case "D":
+		return lipgloss.NewStyle().Foreground(c.TextSubtle)
+	case "W":
+		return lipgloss.NewStyle().Foreground(c.Warning)
+	case "E", "F":
+		return lipgloss.NewStyle().Foreground(c.Error).Bold(true)
+	default:
+		return lipgloss.NewStyle().Foreground(c.Text)
+	}
+}
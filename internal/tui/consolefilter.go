@@ -0,0 +1,150 @@
+package tui
+
+import (
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// =============================================================================
+// Console Level Filter
+// =============================================================================
+
+// ConsoleFilter is a preset over Launch.ConsoleLogLevels cycled with l
+type ConsoleFilter int
+
+const (
+	ConsoleFilterAll ConsoleFilter = iota
+	ConsoleFilterWarnings
+	ConsoleFilterErrors
+	ConsoleFilterFaults
+	consoleFilterCount
+)
+
+var consoleLevelOrder = []string{"D", "I", "W", "E", "F"}
+
+func (f ConsoleFilter) String() string {
+	switch f {
+	case ConsoleFilterWarnings:
+		return "Warnings+"
+	case ConsoleFilterErrors:
+		return "Errors+"
+	case ConsoleFilterFaults:
+		return "Faults only"
+	default:
+		return "All"
+	}
+}
+
+// Levels returns the ConsoleLogLevels map equivalent to the preset
+func (f ConsoleFilter) Levels() map[string]bool {
+	floor := map[ConsoleFilter]int{
+		ConsoleFilterAll:      0,
+		ConsoleFilterWarnings: 2,
+		ConsoleFilterErrors:   3,
+		ConsoleFilterFaults:   4,
+	}[f]
+	levels := make(map[string]bool, len(consoleLevelOrder))
+	for i, level := range consoleLevelOrder {
+		levels[level] = i >= floor
+	}
+	return levels
+}
+
+// consoleFilterFromLevels maps a ConsoleLogLevels map back to a preset.
+// Returns false for hand-edited combinations that match no preset.
+func consoleFilterFromLevels(levels map[string]bool) (ConsoleFilter, bool) {
+	for f := ConsoleFilterAll; f < consoleFilterCount; f++ {
+		want := f.Levels()
+		match := true
+		for _, level := range consoleLevelOrder {
+			on, ok := levels[level]
+			if !ok {
+				on = true
+			}
+			if on != want[level] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return f, true
+		}
+	}
+	return ConsoleFilterAll, false
+}
+
+// consoleEventLevel returns the level letter of a console line; system lines
+// have none and are always shown.
+func consoleEventLevel(line string, ev core.Event) string {
+	if mm, ok := ev.Data.(map[string]any); ok {
+		if s, ok := mm["stream"].(string); ok && s == "system" {
+			return ""
+		}
+	}
+	return core.ConsoleLineLevel(line)
+}
+
+// consoleLevelVisible applies cfg.Launch.ConsoleLogLevels at render time
+func (m Model) consoleLevelVisible(level string) bool {
+	return core.ConsoleLevelEnabled(m.cfg.Launch.ConsoleLogLevels, level)
+}
+
+// console is the console pane being shown: the log browser's when it is
+// open, else run mode's
+func (m *Model) console() *ConsolePane {
+	if m.logBrowser.Active {
+		return &m.logBrowser.Console
+	}
+	return &m.runMode.Console
+}
+
+// visibleConsoleIndices returns the console line indices passing the filter
+func (m Model) visibleConsoleIndices() []int {
+	return m.console().VisibleIndices(m.consoleLevelVisible)
+}
+
+// consoleLineCount is the number of console lines shown with the filter applied
+func (m Model) consoleLineCount() int {
+	if f, ok := consoleFilterFromLevels(m.cfg.Launch.ConsoleLogLevels); ok && f == ConsoleFilterAll {
+		return len(m.console().Lines)
+	}
+	return len(m.visibleConsoleIndices())
+}
+
+// consoleMaxPos is the scroll position showing the newest console lines
+func (m Model) consoleMaxPos() int {
+	return maxInt(0, m.consoleLineCount()-m.consolePaneHeight())
+}
+
+// cycleConsoleFilter rotates All → Warnings+ → Errors+ → Faults only and
+// persists the result as ConsoleLogLevels.
+func (m *Model) cycleConsoleFilter() {
+	cur, ok := consoleFilterFromLevels(m.cfg.Launch.ConsoleLogLevels)
+	next := ConsoleFilterAll
+	if ok {
+		next = (cur + 1) % consoleFilterCount
+	}
+	m.cfg.Launch.ConsoleLogLevels = next.Levels()
+	if err := core.SaveConfig(m.projectRoot, m.configPath, m.cfg); err != nil {
+		m.lastErr = err.Error()
+	}
+
+	m.followConsole()
+	m.setStatus("Console: " + next.String())
+}
+
+// followConsole jumps to the newest line after the visible set changed
+func (m *Model) followConsole() {
+	m.console().ScrollToEnd(m.consoleMaxPos())
+}
+
+// consoleFilterLabel is shown in the console header when lines are hidden
+func (m Model) consoleFilterLabel() string {
+	f, ok := consoleFilterFromLevels(m.cfg.Launch.ConsoleLogLevels)
+	switch {
+	case !ok:
+		return "Filter: custom levels"
+	case f != ConsoleFilterAll:
+		return "Filter: " + f.String()
+	}
+	return ""
+}
@@ -0,0 +1,62 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func TestConsoleFilterPresetsRoundTrip(t *testing.T) {
+	for f := ConsoleFilterAll; f < consoleFilterCount; f++ {
+		got, ok := consoleFilterFromLevels(f.Levels())
+		if !ok || got != f {
+			t.Fatalf("%s: round trip gave %s (ok=%v)", f, got, ok)
+		}
+	}
+	if f, ok := consoleFilterFromLevels(nil); !ok || f != ConsoleFilterAll {
+		t.Fatalf("nil levels should mean All")
+	}
+	if _, ok := consoleFilterFromLevels(map[string]bool{"I": false, "W": true}); ok {
+		t.Fatalf("custom combination should not match a preset")
+	}
+}
+
+func TestConsoleFilterAppliesAtRenderTime(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.runMode.Active = true
+
+	for _, l := range []struct{ line, level string }{
+		{"10:00:00.000 I App[1:0]\nhello", "I"},
+		{"10:00:01.000 W App[1:0]\nlow memory", "W"},
+		{"10:00:02.000 F App[1:0]\nfatal", "F"},
+	} {
+		m.appendConsoleLog(l.line, l.level)
+	}
+	total := len(m.runMode.Console.Lines)
+
+	m.cycleConsoleFilter() // Warnings+
+	if got := m.consoleLineCount(); got != 4 {
+		t.Fatalf("Warnings+ should show 4 lines, got %d", got)
+	}
+	m.cycleConsoleFilter() // Errors+
+	m.cycleConsoleFilter() // Faults only
+	if got := m.consoleLineCount(); got != 2 {
+		t.Fatalf("Faults only should show 2 lines, got %d", got)
+	}
+	if m.consoleFilterLabel() != "Filter: Faults only" {
+		t.Fatalf("unexpected header label %q", m.consoleFilterLabel())
+	}
+	m.cycleConsoleFilter() // All
+	if len(m.runMode.Console.Lines) != total || m.consoleLineCount() != total {
+		t.Fatalf("filtering lost data: %d of %d", m.consoleLineCount(), total)
+	}
+
+	saved, err := core.LoadConfig(m.projectRoot, "")
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if f, ok := consoleFilterFromLevels(saved.Launch.ConsoleLogLevels); !ok || f != ConsoleFilterAll {
+		t.Fatalf("filter not persisted in consoleLogLevels: %v", saved.Launch.ConsoleLogLevels)
+	}
+}
@@ -0,0 +1,60 @@
+package tui
+
+import (
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// ContainerItems creates selector items from ranked workspaces and
+// projects; those under Pods/, Carthage/, .build/, or Examples/ are dimmed.
+func ContainerItems(ranked []core.ContainerCandidate) []SelectorItem {
+	items := make([]SelectorItem, len(ranked))
+	for i, c := range ranked {
+		items[i] = SelectorItem{
+			ID:          c.Kind() + ":" + c.Path,
+			Title:       filepath.Base(c.Path),
+			Description: c.Kind() + " in " + c.Dir(),
+			Meta:        groupedCount(c.Schemes, "scheme"),
+			Dim:         c.Deprioritized(),
+		}
+	}
+	return items
+}
+
+// openContainerChooser asks which workspace or project to use when the
+// config names none and discovery found no single obvious one. Nothing is
+// saved until one is picked.
+func (m *Model) openContainerChooser() {
+	if m.cfg.Workspace != "" || m.cfg.Project != "" || m.info.Package != "" {
+		return
+	}
+	ranked := core.RankContainers(m.info)
+	if len(ranked) == 0 {
+		return
+	}
+	m.selector = NewSelector("Select Workspace or Project", ContainerItems(ranked), m.width, m.styles)
+	m.selectorType = SelectorContainer
+	m.mode = ModeSelector
+	m.setStatus("Several workspaces and projects found · pick the one to build")
+}
+
+// selectContainer saves the workspace or project picked in the chooser and
+// reloads the context for its schemes and configurations
+func (m *Model) selectContainer(item *SelectorItem) tea.Cmd {
+	kind, path, ok := strings.Cut(item.ID, ":")
+	if !ok {
+		return nil
+	}
+	before := m.cfg
+	core.ContainerCandidate{Path: path, Workspace: kind == "workspace"}.Apply(&m.cfg)
+	if err := core.SaveConfig(m.projectRoot, m.configPath, m.cfg); err != nil {
+		m.lastErr = err.Error()
+		return nil
+	}
+	m.setStatus("Using " + path + " · loading schemes…")
+	return tea.Batch(m.noteConfigChanges(before), loadContextCmd(m.projectRoot, m.configPath, m.cfgOverride))
+}
@@ -0,0 +1,52 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func TestContainerChooser(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	root := t.TempDir()
+	for _, dir := range []string{"App.xcodeproj", "Tool.xcodeproj", "Pods/Pods.xcodeproj"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	m := NewModel(root, "", ConfigOverrides{})
+	m.width, m.height = 120, 40
+	info := core.ContextInfo{
+		ProjectRoot: root,
+		Workspaces:  []string{},
+		Projects:    []string{"App.xcodeproj", "Tool.xcodeproj", filepath.Join("Pods", "Pods.xcodeproj")},
+		Schemes:     []string{"App", "Tool"},
+	}
+	next, _ := m.Update(contextLoadedMsg{info: info, cfg: core.DefaultConfig(root)})
+	m = next.(Model)
+
+	if m.mode != ModeSelector || m.selectorType != SelectorContainer {
+		t.Fatalf("chooser not opened: mode %v, selector %v", m.mode, m.selectorType)
+	}
+	if _, err := os.Stat(core.ConfigPath(root)); err == nil {
+		t.Fatal("config saved before a container was picked")
+	}
+	items := ContainerItems(core.RankContainers(info))
+	if len(items) != 3 || items[2].Title != "Pods.xcodeproj" || !items[2].Dim || items[0].Description != "project in ." {
+		t.Fatalf("items = %+v", items)
+	}
+
+	// Enter picks the first (best ranked) one
+	m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.mode != ModeNormal || m.cfg.Project != "App.xcodeproj" {
+		t.Fatalf("picked %q, mode %v", m.cfg.Project, m.mode)
+	}
+	saved, err := core.LoadConfig(root, "")
+	if err != nil || saved.Project != "App.xcodeproj" {
+		t.Fatalf("saved project %q, %v", saved.Project, err)
+	}
+}
@@ -0,0 +1,59 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ansiRE matches CSI and OSC escape sequences.
+var ansiRE = regexp.MustCompile(`\x1b\[[0-?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)`)
+
+// stripANSI removes terminal escape sequences so copied text is plain.
+func stripANSI(s string) string {
+	if !strings.Contains(s, "\x1b") {
+		return s
+	}
+	return ansiRE.ReplaceAllString(s, "")
+}
+
+// issueLocation formats an issue as path:line:col (empty if it has no file).
+func issueLocation(issue Issue) string {
+	if issue.File == "" {
+		return ""
+	}
+	loc := issue.File
+	if issue.Line > 0 {
+		loc += fmt.Sprintf(":%d", issue.Line)
+		if issue.Column > 0 {
+			loc += fmt.Sprintf(":%d", issue.Column)
+		}
+	}
+	return loc
+}
+
+// issueGrepLine formats an issue grep-style with a project-relative path,
+// e.g. "Sources/App.swift:42:7: error: message".
+func issueGrepLine(projectRoot string, issue Issue) string {
+	msg := strings.TrimSpace(stripANSI(issue.Message))
+	if issue.File == "" {
+		return msg
+	}
+	rel := issue
+	rel.File = relativeToRoot(projectRoot, issue.File)
+	return fmt.Sprintf("%s: %s: %s", issueLocation(rel), issue.Type.String(), msg)
+}
+
+// relativeToRoot returns path relative to root when it lives inside root;
+// paths outside the project (SDK headers, packages) are returned unchanged.
+func relativeToRoot(root, path string) string {
+	if root == "" || !filepath.IsAbs(path) {
+		return path
+	}
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return path
+	}
+	return rel
+}
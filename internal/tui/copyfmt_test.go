@@ -0,0 +1,51 @@
+package tui
+
+import "testing"
+
+func TestStripANSI(t *testing.T) {
+	tests := map[string]string{
+		"plain":                                 "plain",
+		"\x1b[31merror:\x1b[0m boom":            "error: boom",
+		"\x1b[1;38;5;196mx\x1b[m":               "x",
+		"\x1b]8;;file:///a\x07link\x1b]8;;\x07": "link",
+	}
+	for in, want := range tests {
+		if got := stripANSI(in); got != want {
+			t.Fatalf("stripANSI(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestRelativeToRoot(t *testing.T) {
+	tests := []struct {
+		root, path, want string
+	}{
+		{"/work/App", "/work/App/Sources/A.swift", "Sources/A.swift"},
+		{"/work/App", "/work/AppKit/B.swift", "/work/AppKit/B.swift"},
+		{"/work/App", "/Applications/Xcode.app/SDK/h.h", "/Applications/Xcode.app/SDK/h.h"},
+		{"/work/App", "Sources/A.swift", "Sources/A.swift"},
+		{"", "/work/App/A.swift", "/work/App/A.swift"},
+	}
+	for _, tc := range tests {
+		if got := relativeToRoot(tc.root, tc.path); got != tc.want {
+			t.Fatalf("relativeToRoot(%q, %q) = %q, want %q", tc.root, tc.path, got, tc.want)
+		}
+	}
+}
+
+func TestIssueCopyFormats(t *testing.T) {
+	it := NewIssuesTab()
+	it.AddIssue(IssueTypeError, "/work/App/Sources/A.swift:42:7: error: cannot find 'x' in scope")
+	issue := it.Issues[0]
+
+	if got := issueLocation(issue); got != "/work/App/Sources/A.swift:42:7" {
+		t.Fatalf("issueLocation = %q", got)
+	}
+	want := "Sources/A.swift:42:7: error: cannot find 'x' in scope"
+	if got := issueGrepLine("/work/App", issue); got != want {
+		t.Fatalf("issueGrepLine = %q, want %q", got, want)
+	}
+	if got := issueLocation(Issue{Message: "no file"}); got != "" {
+		t.Fatalf("issueLocation without file = %q", got)
+	}
+}
@@ -0,0 +1,71 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// =============================================================================
+// Code Coverage
+// =============================================================================
+
+// SetCoverage sets the coverage of the finished test run (nil when it
+// wasn't collected) and the percent levels below which it shows as a
+// warning or a failure
+func (st *SummaryTab) SetCoverage(cov *core.CoverageSummary, warn, fail float64) {
+	st.Coverage = cov
+	st.CoverageWarn, st.CoverageFail = warn, fail
+}
+
+// coverageView renders the overall coverage colored by the thresholds
+func (st *SummaryTab) coverageView(styles Styles) string {
+	pct := st.Coverage.Percent()
+	return lipgloss.NewStyle().Foreground(coverageColor(pct, st.CoverageWarn, st.CoverageFail, styles)).Render(fmt.Sprintf("%.1f%%", pct))
+}
+
+// coverageColor is green at or above warn, yellow at or above fail, red
+// below
+func coverageColor(pct, warn, fail float64, styles Styles) lipgloss.AdaptiveColor {
+	switch {
+	case pct >= warn:
+		return styles.Colors.Success
+	case pct >= fail:
+		return styles.Colors.Warning
+	}
+	return styles.Colors.Error
+}
+
+// openCoverageTargets lists the last test run's targets by coverage,
+// lowest first
+func (m *Model) openCoverageTargets() {
+	cov := m.lastTest.Coverage
+	if cov == nil {
+		m.setStatus("No coverage yet · enable test.codeCoverage and run tests")
+		return
+	}
+	items := CoverageItems(*cov)
+	if len(items) == 0 {
+		m.setStatus(fmt.Sprintf("Coverage: %.1f%% (no targets reported)", cov.Percent()))
+		return
+	}
+	m.selector = NewSelector(fmt.Sprintf("Coverage by target (%.1f%% overall)", cov.Percent()), items, m.width, m.styles)
+	m.selectorType = SelectorCoverage
+	m.mode = ModeSelector
+}
+
+// CoverageItems creates selector items for the targets of a coverage
+// report, in its order (lowest coverage first)
+func CoverageItems(cov core.CoverageSummary) []SelectorItem {
+	items := make([]SelectorItem, 0, len(cov.Targets))
+	for _, t := range cov.Targets {
+		desc := fmt.Sprintf("%.1f%%", t.LineCoverage*100)
+		if t.ExecutableLines > 0 {
+			desc += fmt.Sprintf(" · %d/%d lines", t.CoveredLines, t.ExecutableLines)
+		}
+		items = append(items, SelectorItem{ID: t.Name, Title: t.Name, Description: desc})
+	}
+	return items
+}
@@ -0,0 +1,70 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func TestCoverageColorThresholds(t *testing.T) {
+	styles := DefaultStyles()
+	cases := []struct {
+		pct  float64
+		want string
+	}{
+		{85, "success"},
+		{80, "success"},
+		{60, "warning"},
+		{49.9, "error"},
+	}
+	for _, c := range cases {
+		got := coverageColor(c.pct, 80, 50, styles)
+		want := map[string]any{"success": styles.Colors.Success, "warning": styles.Colors.Warning, "error": styles.Colors.Error}[c.want]
+		if got != want {
+			t.Errorf("coverageColor(%v) = %v, want %s", c.pct, got, c.want)
+		}
+	}
+}
+
+func TestCoverageShownAfterTests(t *testing.T) {
+	st := NewSummaryTab()
+	st.SetSize(100, 40)
+	st.ActionType = "test"
+	st.SetResult(BuildStatusSuccess, "3s", nil, 0, 0)
+	st.SetCoverage(&core.CoverageSummary{LineCoverage: 0.724}, 80, 50)
+	if view := st.View(DefaultStyles()); !strings.Contains(view, "Coverage: 72.4%") {
+		t.Fatalf("coverage missing from the success card:\n%s", view)
+	}
+	st.Clear()
+	if st.Coverage != nil {
+		t.Fatal("Clear kept the coverage")
+	}
+}
+
+func TestCoverageItems(t *testing.T) {
+	items := CoverageItems(core.CoverageSummary{LineCoverage: 0.62, Targets: []core.TargetCoverage{
+		{Name: "Networking.framework", LineCoverage: 0.2, CoveredLines: 60, ExecutableLines: 300},
+		{Name: "App.app", LineCoverage: 0.8},
+	}})
+	if len(items) != 2 || items[0].Title != "Networking.framework" || items[0].Description != "20.0% · 60/300 lines" {
+		t.Fatalf("items = %+v", items)
+	}
+	if items[1].Description != "80.0%" {
+		t.Fatalf("description without line counts = %q", items[1].Description)
+	}
+}
+
+func TestOpenCoverageTargetsWithoutCoverage(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.openCoverageTargets()
+	if m.mode == ModeSelector || !strings.Contains(m.statusMsg, "test.codeCoverage") {
+		t.Fatalf("mode = %v, status = %q", m.mode, m.statusMsg)
+	}
+	m.lastTest.Coverage = &core.CoverageSummary{LineCoverage: 0.5, Targets: []core.TargetCoverage{{Name: "App.app", LineCoverage: 0.5}}}
+	m.openCoverageTargets()
+	if m.mode != ModeSelector || m.selectorType != SelectorCoverage {
+		t.Fatalf("mode = %v, selector = %v", m.mode, m.selectorType)
+	}
+}
@@ -0,0 +1,101 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func proSimulators() []core.Simulator {
+	sim := func(udid, version string) core.Simulator {
+		return core.Simulator{
+			Name:           "iPhone 15 Pro",
+			UDID:           udid,
+			State:          "Shutdown",
+			RuntimeName:    "iOS " + version,
+			RuntimeID:      "com.apple.CoreSimulator.SimRuntime.iOS-" + strings.ReplaceAll(version, ".", "-"),
+			OSVersion:      version,
+			PlatformFamily: core.PlatformIOS,
+			Available:      true,
+		}
+	}
+	return []core.Simulator{
+		sim("SIM-17-5", "17.5"),
+		sim("SIM-17-10", "17.10"),
+		sim("SIM-17-0", "17.0"),
+		{Name: "iPad Air", UDID: "SIM-IPAD", RuntimeName: "iOS 18.0", OSVersion: "18.0", PlatformFamily: core.PlatformIPadOS, Available: true},
+	}
+}
+
+func selectorCursorItem(s SelectorModel) *SelectorItem {
+	if s.cursor >= len(s.filtered) {
+		return nil
+	}
+	return &s.filtered[s.cursor]
+}
+
+func TestDestinationNameItemsGroupsSameNames(t *testing.T) {
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.info.Simulators = proSimulators()
+	sims, devs, _ := m.destinationInfos()
+
+	items := DestinationNameItems(sims, devs)
+	if len(items) != 4 {
+		t.Fatalf("items = %+v, want Mac, Catalyst, one iPhone 15 Pro and iPad Air", items)
+	}
+	group := items[2]
+	if group.ID != destinationNamePrefix+"iPhone 15 Pro" {
+		t.Fatalf("group id = %q", group.ID)
+	}
+	if group.Description != "3 OS versions: iOS 17.10, iOS 17.5, iOS 17.0" {
+		t.Fatalf("group description = %q", group.Description)
+	}
+	if items[3].ID != "SIM-IPAD" || items[3].Description != "iOS 18.0 • ipados" {
+		t.Fatalf("single simulator item = %+v", items[3])
+	}
+
+	osItems := DestinationOSItems("iPhone 15 Pro", sims)
+	var titles []string
+	for _, it := range osItems {
+		titles = append(titles, it.Title)
+	}
+	if strings.Join(titles, ",") != "iOS 17.10,iOS 17.5,iOS 17.0" {
+		t.Fatalf("OS items = %v", titles)
+	}
+}
+
+func TestDestinationSelectorTwoStages(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.mode = ModeNormal
+	m.info.Simulators = proSimulators()
+	m.cfg.Destination = core.Destination{ID: "SIM-17-5", UDID: "SIM-17-5", Kind: core.DestSimulator}
+
+	m.openDestinationSelector()
+	if sel := selectorCursorItem(m.selector); sel == nil || sel.ID != destinationNamePrefix+"iPhone 15 Pro" {
+		t.Fatalf("first stage selected %+v, want the iPhone 15 Pro group", sel)
+	}
+
+	m = updateModel(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.mode != ModeSelector || m.selectorType != SelectorDestinationOS {
+		t.Fatalf("mode = %v selector = %v, want the OS stage", m.mode, m.selectorType)
+	}
+	if sel := selectorCursorItem(m.selector); sel == nil || sel.ID != "SIM-17-10" {
+		t.Fatalf("OS stage selected %+v, want the newest runtime", sel)
+	}
+
+	m = updateModel(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.mode != ModeNormal {
+		t.Fatalf("mode = %v after picking the OS", m.mode)
+	}
+	dst := m.cfg.Destination
+	if dst.UDID != "SIM-17-10" || dst.RuntimeID != "com.apple.CoreSimulator.SimRuntime.iOS-17-10" {
+		t.Fatalf("destination = %+v", dst)
+	}
+	if m.statusMsg != "Destination: iPhone 15 Pro (iOS 17.10)" {
+		t.Fatalf("status = %q", m.statusMsg)
+	}
+}
@@ -0,0 +1,112 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// =============================================================================
+// Device Help
+// =============================================================================
+
+// DeviceHelpOverlay walks through fixing a device install or launch that
+// devicectl refused (locked device, Developer Mode off, ...)
+type DeviceHelpOverlay struct {
+	Err  *core.DevicectlError
+	Info core.ErrorObject
+}
+
+// View renders the overlay content (without centering)
+func (o DeviceHelpOverlay) View(width int, s Styles) string {
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(s.Colors.Error)
+	b.WriteString(titleStyle.Render(s.Icons.Error + " " + o.Info.Message))
+	b.WriteString("\n")
+
+	dividerStyle := lipgloss.NewStyle().Foreground(s.Colors.BorderMuted)
+	b.WriteString(dividerStyle.Render(strings.Repeat("─", width-6)))
+	b.WriteString("\n")
+
+	textStyle := lipgloss.NewStyle().Foreground(s.Colors.Text).Width(width - 9)
+	numberStyle := lipgloss.NewStyle().Foreground(s.Colors.Accent).Bold(true)
+	for i, step := range o.Err.Steps() {
+		b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, numberStyle.Render(fmt.Sprintf("%d. ", i+1)), textStyle.Render(step)))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	detailStyle := lipgloss.NewStyle().Foreground(s.Colors.TextSubtle)
+	b.WriteString(detailStyle.Render(truncateString(o.Err.Error(), width-6)))
+	b.WriteString("\n\n")
+
+	hintKeyStyle := lipgloss.NewStyle().Foreground(s.Colors.Accent)
+	hintDescStyle := lipgloss.NewStyle().Foreground(s.Colors.TextSubtle)
+	b.WriteString(hintKeyStyle.Render("r") + hintDescStyle.Render(" retry the "+o.Err.Step+"   "))
+	b.WriteString(hintKeyStyle.Render("esc") + hintDescStyle.Render(" close"))
+
+	containerStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(s.Colors.Error).
+		Padding(1, 2)
+
+	return containerStyle.Width(width).Render(b.String())
+}
+
+// openDeviceHelp shows how to fix a device run that failed for a known
+// reason; other failures are left to the Issues tab
+func (m *Model) openDeviceHelp(err error) {
+	var derr *core.DevicectlError
+	if !errors.As(err, &derr) || len(derr.Steps()) == 0 {
+		return
+	}
+	m.deviceHelp = DeviceHelpOverlay{Err: derr, Info: derr.ErrorObject(core.ErrorObject{})}
+	m.mode = ModeDeviceHelp
+}
+
+// retryDeviceStep re-attempts only the step that failed: the install and
+// launch of the built app, or just the launch
+func (m *Model) retryDeviceStep() tea.Cmd {
+	derr := m.deviceHelp.Err
+	m.deviceHelp = DeviceHelpOverlay{}
+	m.mode = ModeNormal
+	if derr == nil {
+		return nil
+	}
+	if m.running {
+		m.setStatus("An operation is already running")
+		return nil
+	}
+	if m.cfg.LastBuiltAppBundle == "" {
+		m.setStatus("No built app yet · build or run first")
+		return nil
+	}
+	return m.startOpRequest(core.LastOp{Name: "run", LaunchOnly: true, SkipInstall: derr.Step == "launch"})
+}
+
+func (m *Model) handleDeviceHelpKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "r", "enter":
+		return m.retryDeviceStep()
+	case "esc", "q":
+		m.deviceHelp = DeviceHelpOverlay{}
+		m.mode = ModeNormal
+	}
+	return nil
+}
+
+func (m Model) deviceHelpOverlayView() string {
+	width := m.width * 60 / 100
+	if width < 60 {
+		width = 60
+	}
+	if width > 90 {
+		width = 90
+	}
+	return RenderCenteredPopup(m.deviceHelp.View(width, m.styles), m.width, m.height)
+}
@@ -0,0 +1,68 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func newFailedRunModel(t *testing.T) Model {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.mode = ModeNormal
+	m.width, m.height = 120, 40
+	m.running = true
+	m.runningCmd = "run"
+	m.tabView.SummaryTab.SetRunning("run")
+	return m
+}
+
+func TestDeviceHelpOpensForKnownFailures(t *testing.T) {
+	m := newFailedRunModel(t)
+	err := &core.DevicectlError{Step: "launch", Category: core.DeviceFailureLocked, Description: "The device is locked.", Err: errors.New("exit status 1")}
+	m.handleOpDone(opDoneMsg{cmd: "run", err: err})
+	if m.mode != ModeDeviceHelp {
+		t.Fatalf("mode = %v", m.mode)
+	}
+	view := stripANSI(m.deviceHelpOverlayView())
+	for _, want := range []string{"The device is locked", "1. Unlock the device.", "r retry the launch", "devicectl launch failed: The device is locked."} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("overlay missing %q:\n%s", want, view)
+		}
+	}
+
+	// Retrying needs the built app
+	m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	if m.mode != ModeNormal || m.running || !strings.Contains(m.statusMsg, "No built app") {
+		t.Fatalf("mode = %v running = %v status = %q", m.mode, m.running, m.statusMsg)
+	}
+}
+
+func TestDeviceHelpSkipsUnknownFailures(t *testing.T) {
+	m := newFailedRunModel(t)
+	err := &core.DevicectlError{Step: "install", Raw: `{"code": 3}`, Err: errors.New("exit status 1")}
+	m.handleOpDone(opDoneMsg{cmd: "run", err: err})
+	if m.mode == ModeDeviceHelp {
+		t.Fatal("opened for an unknown failure")
+	}
+
+	m = newFailedRunModel(t)
+	m.handleOpDone(opDoneMsg{cmd: "run", err: errors.New("exit status 65")})
+	if m.mode == ModeDeviceHelp {
+		t.Fatal("opened for a build failure")
+	}
+}
+
+func TestDeviceHelpEscCloses(t *testing.T) {
+	m := newFailedRunModel(t)
+	m.handleOpDone(opDoneMsg{cmd: "run", err: &core.DevicectlError{Step: "install", Category: core.DeviceFailureStorage, Err: errors.New("exit status 1")}})
+	m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.mode != ModeNormal || m.deviceHelp.Err != nil {
+		t.Fatalf("esc left mode = %v", m.mode)
+	}
+}
@@ -0,0 +1,73 @@
+package tui
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// devicePollTickMsg starts a destination poll; gen drops ticks of a poll
+// loop replaced by a context reload
+type devicePollTickMsg struct {
+	gen int
+}
+
+// devicePollMsg delivers a finished destination poll
+type devicePollMsg struct {
+	gen   int
+	dests core.Destinations
+}
+
+// scheduleDevicePoll (re)starts the poll loop for devices and simulators
+// that come and go, e.g. an iPhone plugged in after launch
+func (m *Model) scheduleDevicePoll() tea.Cmd {
+	every := m.cfg.TUI.DevicePollEvery()
+	if every <= 0 {
+		return nil
+	}
+	m.devicePollGen++
+	gen := m.devicePollGen
+	return tea.Tick(every, func(time.Time) tea.Msg { return devicePollTickMsg{gen: gen} })
+}
+
+// handleDevicePollTick lists destinations in the background; while an
+// operation runs, polling waits for the next interval
+func (m *Model) handleDevicePollTick(msg devicePollTickMsg) tea.Cmd {
+	if msg.gen != m.devicePollGen || m.quitting {
+		return nil
+	}
+	if m.running {
+		return m.scheduleDevicePoll()
+	}
+	gen := msg.gen
+	preserveLocale := m.cfg.Xcodebuild.PreserveLocale
+	return func() tea.Msg {
+		return devicePollMsg{gen: gen, dests: core.ListDestinations(context.Background(), nil, preserveLocale)}
+	}
+}
+
+// handleDevicePoll updates the device and simulator lists and says what
+// came or went
+func (m *Model) handleDevicePoll(msg devicePollMsg) tea.Cmd {
+	if msg.gen != m.devicePollGen {
+		return nil
+	}
+	wasAvailable := core.DestinationAvailable(m.cfg.Destination, m.info)
+	changes := core.DiffDestinations(m.info, msg.dests)
+	msg.dests.Apply(&m.info)
+	available := core.DestinationAvailable(m.cfg.Destination, m.info)
+
+	switch {
+	case changes.Any():
+		m.setStatus(changes.Line())
+	case wasAvailable && !available:
+		m.setStatus(m.cfg.Destination.Name + " is no longer available")
+	}
+	if changes.Any() || wasAvailable != available {
+		m.publishStatus()
+	}
+	return m.scheduleDevicePoll()
+}
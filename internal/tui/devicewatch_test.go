@@ -0,0 +1,65 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func TestDevicePollReportsChanges(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.mode = ModeNormal
+	m = updateModel(m, tea.WindowSizeMsg{Width: 160, Height: 40})
+	m.tabView.SummaryTab.SetContextLoaded(true)
+	m.cfg.Destination = core.Destination{Kind: core.DestDevice, UDID: "PHONE", Name: "iPhone von Max"}
+
+	if m.scheduleDevicePoll() == nil {
+		t.Fatal("polling should be on by default")
+	}
+	gen := m.devicePollGen
+
+	// Plugged in
+	m = updateModel(m, devicePollMsg{gen: gen, dests: core.Destinations{Devices: []core.Device{{Name: "iPhone von Max", Identifier: "PHONE"}}}})
+	if m.statusMsg != "iPhone von Max connected" || len(m.info.Devices) != 1 {
+		t.Fatalf("status = %q devices = %d", m.statusMsg, len(m.info.Devices))
+	}
+	if strings.Contains(stripANSI(m.View()), "unavailable") {
+		t.Fatal("connected destination marked unavailable")
+	}
+
+	// Unplugged
+	gen = m.devicePollGen
+	m = updateModel(m, devicePollMsg{gen: gen, dests: core.Destinations{Devices: []core.Device{}}})
+	if m.statusMsg != "iPhone von Max disconnected" {
+		t.Fatalf("status = %q", m.statusMsg)
+	}
+	if !strings.Contains(stripANSI(m.View()), "unavailable") {
+		t.Fatal("expected an unavailable badge in the status bar")
+	}
+
+	// Results of a replaced poll loop are dropped
+	m = updateModel(m, devicePollMsg{gen: gen - 1, dests: core.Destinations{Devices: []core.Device{{Name: "iPad", Identifier: "IPAD"}}}})
+	if len(m.info.Devices) != 0 {
+		t.Fatal("stale poll applied")
+	}
+}
+
+func TestDevicePollPausesDuringOps(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.scheduleDevicePoll()
+	m.running = true
+	gen := m.devicePollGen
+	if cmd := m.handleDevicePollTick(devicePollTickMsg{gen: gen}); cmd == nil || m.devicePollGen != gen+1 {
+		t.Fatal("a tick during an op should only reschedule")
+	}
+
+	m.cfg.TUI.DevicePollInterval = "0"
+	if m.scheduleDevicePoll() != nil {
+		t.Fatal("devicePollInterval 0 should turn polling off")
+	}
+}
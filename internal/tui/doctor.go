@@ -0,0 +1,206 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// =============================================================================
+// Doctor Overlay
+// =============================================================================
+
+// doctorReportMsg delivers a full doctor run.
+type doctorReportMsg struct {
+	report core.DoctorReport
+	err    error
+}
+
+// doctorCheckMsg delivers a single re-run check (after a fix).
+type doctorCheckMsg struct {
+	check core.DoctorCheck
+	err   error
+}
+
+// DoctorOverlay shows doctor checks with pass/warn/fail icons
+type DoctorOverlay struct {
+	Checks  []core.DoctorCheck
+	Cursor  int
+	Loading bool
+	Fixing  string // ID of the check being fixed
+	Message string
+}
+
+func runDoctorCmd(projectRoot string, cfg core.Config) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 45*time.Second)
+		defer cancel()
+		rep, err := core.Doctor(ctx, projectRoot, cfg, nil)
+		return doctorReportMsg{report: rep, err: err}
+	}
+}
+
+func fixDoctorCheckCmd(projectRoot string, cfg core.Config, id string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+		check, err := core.FixDoctorCheck(ctx, projectRoot, cfg, id, nil)
+		return doctorCheckMsg{check: check, err: err}
+	}
+}
+
+// SetReport replaces all checks
+func (d *DoctorOverlay) SetReport(rep core.DoctorReport) {
+	d.Checks = rep.Checks
+	d.Loading = false
+	if d.Cursor >= len(d.Checks) {
+		d.Cursor = maxInt(0, len(d.Checks)-1)
+	}
+}
+
+// UpdateCheck replaces a single check in place
+func (d *DoctorOverlay) UpdateCheck(c core.DoctorCheck) {
+	for i := range d.Checks {
+		if d.Checks[i].ID == c.ID {
+			d.Checks[i] = c
+			return
+		}
+	}
+	d.Checks = append(d.Checks, c)
+}
+
+// Selected returns the check under the cursor
+func (d *DoctorOverlay) Selected() (core.DoctorCheck, bool) {
+	if d.Cursor < 0 || d.Cursor >= len(d.Checks) {
+		return core.DoctorCheck{}, false
+	}
+	return d.Checks[d.Cursor], true
+}
+
+// MoveCursor moves the selection by delta, clamped
+func (d *DoctorOverlay) MoveCursor(delta int) {
+	d.Cursor += delta
+	if d.Cursor >= len(d.Checks) {
+		d.Cursor = len(d.Checks) - 1
+	}
+	if d.Cursor < 0 {
+		d.Cursor = 0
+	}
+}
+
+// Counts returns pass/warn/fail totals
+func (d *DoctorOverlay) Counts() (pass, warn, fail int) {
+	for _, c := range d.Checks {
+		switch c.Status {
+		case core.DoctorFail:
+			fail++
+		case core.DoctorWarn:
+			warn++
+		default:
+			pass++
+		}
+	}
+	return pass, warn, fail
+}
+
+// View renders the overlay content (without centering)
+func (d *DoctorOverlay) View(width int, s Styles) string {
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(s.Colors.Text)
+	b.WriteString(titleStyle.Render("Doctor"))
+	b.WriteString("\n")
+
+	dividerStyle := lipgloss.NewStyle().Foreground(s.Colors.BorderMuted)
+	b.WriteString(dividerStyle.Render(strings.Repeat("─", width-6)))
+	b.WriteString("\n")
+
+	mutedStyle := lipgloss.NewStyle().Foreground(s.Colors.TextMuted)
+	hintStyle := lipgloss.NewStyle().Foreground(s.Colors.TextSubtle).Italic(true)
+	nameStyle := lipgloss.NewStyle().Foreground(s.Colors.Text)
+	selectedStyle := lipgloss.NewStyle().Foreground(s.Colors.Text).Bold(true)
+
+	if d.Loading && len(d.Checks) == 0 {
+		b.WriteString(mutedStyle.Render("  Running checks…"))
+		b.WriteString("\n")
+	}
+
+	for i, c := range d.Checks {
+		var icon string
+		switch c.Status {
+		case core.DoctorFail:
+			icon = lipgloss.NewStyle().Foreground(s.Colors.Error).Render(s.Icons.Error)
+		case core.DoctorWarn:
+			icon = lipgloss.NewStyle().Foreground(s.Colors.Warning).Render(s.Icons.Warning)
+		default:
+			icon = lipgloss.NewStyle().Foreground(s.Colors.Success).Render(s.Icons.Success)
+		}
+
+		prefix := "  "
+		name := nameStyle.Render(c.Name)
+		if i == d.Cursor {
+			prefix = s.StatusStyle("running").Render(s.Icons.ChevronRight) + " "
+			name = selectedStyle.Render(c.Name)
+		}
+		line := prefix + icon + " " + name
+		if d.Fixing == c.ID {
+			line += mutedStyle.Render("  fixing…")
+		} else if c.Fixable {
+			line += " " + lipgloss.NewStyle().Foreground(s.Colors.Accent).Render("[fix]")
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+
+		if c.Detail != "" {
+			b.WriteString(mutedStyle.Render("    " + truncateString(c.Detail, width-10)))
+			b.WriteString("\n")
+		}
+		if c.Status != core.DoctorPass && c.FixHint != "" {
+			b.WriteString(hintStyle.Render("    " + truncateString(c.FixHint, width-10)))
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString(dividerStyle.Render(strings.Repeat("─", width-6)))
+	b.WriteString("\n")
+
+	if d.Message != "" {
+		b.WriteString(mutedStyle.Render(d.Message))
+		b.WriteString("\n")
+	} else if !d.Loading {
+		pass, warn, fail := d.Counts()
+		b.WriteString(mutedStyle.Render(fmt.Sprintf("%d passed · %d warnings · %d failed", pass, warn, fail)))
+		b.WriteString("\n")
+	}
+
+	hintKeyStyle := lipgloss.NewStyle().Foreground(s.Colors.Accent)
+	hintDescStyle := lipgloss.NewStyle().Foreground(s.Colors.TextSubtle)
+	b.WriteString(hintKeyStyle.Render("↑↓") + hintDescStyle.Render(" select  ") +
+		hintKeyStyle.Render("⏎") + hintDescStyle.Render(" apply fix  ") +
+		hintKeyStyle.Render("r") + hintDescStyle.Render(" re-run  ") +
+		hintKeyStyle.Render("esc") + hintDescStyle.Render(" close"))
+
+	containerStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(s.Colors.Border).
+		Padding(1, 2)
+
+	return containerStyle.Width(width).Render(b.String())
+}
+
+func truncateString(s string, max int) string {
+	if max <= 1 || lipgloss.Width(s) <= max {
+		return s
+	}
+	r := []rune(s)
+	if len(r) > max-1 {
+		r = r[:max-1]
+	}
+	return string(r) + "…"
+}
@@ -0,0 +1,217 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// eventsMsg carries every event that was waiting when the UI asked for the
+// next one, so a burst of output costs one Update/View cycle instead of one
+// per line.
+type eventsMsg struct {
+	events []core.Event
+	// dropped counts events the emitter discarded since the last batch
+	// because the channel was full.
+	dropped int64
+	// from is the emitter the batch was read from
+	from *chanEmitter
+}
+
+// chanEmitter hands core events to the UI. Emit never blocks: when the
+// buffer is full the event is counted as dropped (it is still written to
+// the op log on disk).
+type chanEmitter struct {
+	ch      chan core.Event
+	stop    <-chan struct{}
+	dropped atomic.Int64
+	log     *opLog
+	// redact scrubs secrets before the event reaches the op log or the UI
+	redact *core.Redactor
+	// lastRun records the .xcbolt/last-run.json summary of a build, run, or
+	// test; it sees every event, even those dropped from the UI
+	lastRun *core.LastRunRecorder
+}
+
+func (e *chanEmitter) Emit(ev core.Event) {
+	// Never block core operations on UI rendering.
+	// Also, stop accepting events once the op is done/canceled.
+	select {
+	case <-e.stop:
+		return
+	default:
+	}
+	ev = e.redact.Event(ev)
+	e.log.Write(ev)
+	e.lastRun.Observe(ev)
+	select {
+	case e.ch <- ev:
+	default:
+		e.dropped.Add(1)
+	}
+}
+
+// waitForEvent blocks for the next event, then drains whatever else is
+// already buffered (up to maxEventBatch) into one eventsMsg.
+func waitForEvent(e *chanEmitter) tea.Cmd {
+	return func() tea.Msg {
+		var first core.Event
+		select {
+		case <-e.stop:
+			return nil
+		case first = <-e.ch:
+		}
+		batch := eventsMsg{events: []core.Event{first}, from: e}
+	drain:
+		for len(batch.events) < maxEventBatch {
+			select {
+			case ev := <-e.ch:
+				batch.events = append(batch.events, ev)
+			default:
+				break drain
+			}
+		}
+		batch.dropped = e.dropped.Swap(0)
+		return batch
+	}
+}
+
+// logPath is the op log of e, or "" when there is none.
+func (e *chanEmitter) logPath() string {
+	if e == nil {
+		return ""
+	}
+	return e.log.Path()
+}
+
+// droppedEventsNotice is shown in the logs when events were dropped.
+func droppedEventsNotice(n int64, logPath string) string {
+	if logPath == "" {
+		return fmt.Sprintf("%d lines dropped from display", n)
+	}
+	return fmt.Sprintf("%d lines dropped from display (full log preserved on disk: %s)", n, logPath)
+}
+
+// opLogsDir holds the full text log of recent TUI operations.
+func opLogsDir(projectRoot string) string {
+	return filepath.Join(projectRoot, ".xcbolt", "Logs")
+}
+
+// opLog writes every event of an operation to a text file, so the full
+// output survives even when the display drops lines.
+type opLog struct {
+	mu     sync.Mutex
+	path   string
+	f      *os.File
+	w      *bufio.Writer
+	last   string
+	closed bool
+}
+
+// openOpLog creates .xcbolt/Logs/<op>-<timestamp>.log and prunes the
+// oldest logs beyond maxOpLogs. Failing to create it is not fatal: a nil
+// *opLog discards writes.
+func openOpLog(projectRoot, name string, now time.Time) *opLog {
+	dir := opLogsDir(projectRoot)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil
+	}
+	pruneOpLogs(dir, maxOpLogs-1)
+	path := filepath.Join(dir, name+"-"+now.Format("20060102-150405")+".log")
+	f, err := os.Create(path)
+	if err != nil {
+		return nil
+	}
+	return &opLog{path: path, f: f, w: bufio.NewWriter(f)}
+}
+
+// pruneOpLogs removes the oldest *.log files in dir so at most keep remain.
+func pruneOpLogs(dir string, keep int) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	logs := []string{}
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".log") {
+			logs = append(logs, e.Name())
+		}
+	}
+	if len(logs) <= keep {
+		return
+	}
+	// Names end in a sortable timestamp; order by it, not by op name.
+	sort.Slice(logs, func(i, j int) bool {
+		return opLogStamp(logs[i]) < opLogStamp(logs[j])
+	})
+	for _, name := range logs[:len(logs)-keep] {
+		_ = os.Remove(filepath.Join(dir, name))
+	}
+}
+
+func opLogStamp(name string) string {
+	name = strings.TrimSuffix(name, ".log")
+	if len(name) < len("20060102-150405") {
+		return name
+	}
+	return name[len(name)-len("20060102-150405"):]
+}
+
+// Path is the log file, or "" for a nil log.
+func (l *opLog) Path() string {
+	if l == nil {
+		return ""
+	}
+	return l.path
+}
+
+// Write appends the event's text. Pretty lines are skipped (the raw line
+// is logged), and so is an error line xcodebuild output repeated right
+// after its raw copy.
+func (l *opLog) Write(ev core.Event) {
+	if l == nil || isPrettyEvent(ev) {
+		return
+	}
+	line := ev.Msg
+	if ev.Err != nil && ev.Err.Detail != "" {
+		line += ": " + ev.Err.Detail
+	}
+	if line == "" {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed || line == l.last {
+		return
+	}
+	l.last = line
+	if ev.Type == "warning" || ev.Type == "error" {
+		line = "[" + ev.Type + "] " + line
+	}
+	_, _ = l.w.WriteString(line + "\n")
+}
+
+// Close flushes and closes the file. Later writes are ignored.
+func (l *opLog) Close() {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return
+	}
+	l.closed = true
+	_ = l.w.Flush()
+	_ = l.f.Close()
+}
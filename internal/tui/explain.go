@@ -0,0 +1,292 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// =============================================================================
+// Explain Issue Overlay
+// =============================================================================
+
+// explainContextLines is how many log lines around an issue are sent to
+// issues.explainCommand on each side
+const explainContextLines = 5
+
+// explainDoneMsg delivers a finished explain command; gen drops results of
+// an overlay that was closed in the meantime
+type explainDoneMsg struct {
+	gen int
+	res core.ExplainResult
+	err error
+}
+
+// ExplainOverlay shows the output of issues.explainCommand for one issue
+type ExplainOverlay struct {
+	Title   string // The issue, as "file:line: message"
+	Running bool
+	Result  core.ExplainResult
+	Err     error
+	Offset  int
+	Message string
+
+	gen    int
+	cancel context.CancelFunc
+}
+
+// Output is the text shown: stdout on success, stderr (or the error) when
+// the command failed or timed out
+func (e *ExplainOverlay) Output() string {
+	if e.Err == nil {
+		return strings.TrimRight(e.Result.Stdout, "\n")
+	}
+	out := strings.TrimRight(e.Result.Stderr, "\n")
+	if out == "" {
+		return e.Err.Error()
+	}
+	return e.Err.Error() + "\n\n" + out
+}
+
+// Lines is the output wrapped to width
+func (e *ExplainOverlay) Lines(width int) []string {
+	if e.Running {
+		return nil
+	}
+	var lines []string
+	for _, line := range strings.Split(e.Output(), "\n") {
+		lines = append(lines, wrapLine(line, maxInt(8, width), "")...)
+	}
+	return lines
+}
+
+// Scroll moves the first visible line by delta, clamped to the lines that fit
+func (e *ExplainOverlay) Scroll(delta, width, visible int) {
+	e.Offset += delta
+	if maxOffset := len(e.Lines(width)) - visible; e.Offset > maxOffset {
+		e.Offset = maxOffset
+	}
+	if e.Offset < 0 {
+		e.Offset = 0
+	}
+}
+
+// View renders the overlay content (without centering); height is the
+// number of output lines shown at once
+func (e *ExplainOverlay) View(width, height int, s Styles, spinner string) string {
+	var b strings.Builder
+	inner := width - 6
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(s.Colors.Text)
+	mutedStyle := lipgloss.NewStyle().Foreground(s.Colors.TextMuted)
+	dividerStyle := lipgloss.NewStyle().Foreground(s.Colors.BorderMuted)
+	textStyle := lipgloss.NewStyle().Foreground(s.Colors.Text)
+	if e.Err != nil {
+		textStyle = textStyle.Foreground(s.Colors.Error)
+	}
+
+	b.WriteString(titleStyle.Render("Explain"))
+	b.WriteString("\n")
+	b.WriteString(mutedStyle.Render(truncateString(e.Title, inner)))
+	b.WriteString("\n")
+	b.WriteString(dividerStyle.Render(strings.Repeat("─", inner)))
+	b.WriteString("\n")
+
+	lines := e.Lines(inner)
+	switch {
+	case e.Running:
+		b.WriteString(spinner + mutedStyle.Render(" Running "+truncateString(e.Result.Command, inner-10)+"…") + "\n")
+	case len(lines) == 0 || (len(lines) == 1 && lines[0] == ""):
+		b.WriteString(mutedStyle.Render("  No output") + "\n")
+	default:
+		end := minInt(len(lines), e.Offset+height)
+		for _, line := range lines[e.Offset:end] {
+			b.WriteString(textStyle.Render(line) + "\n")
+		}
+		if len(lines) > height {
+			b.WriteString(lipgloss.NewStyle().Foreground(s.Colors.TextSubtle).
+				Render(fmt.Sprintf("  %d–%d of %d", e.Offset+1, end, len(lines))) + "\n")
+		}
+	}
+
+	b.WriteString(dividerStyle.Render(strings.Repeat("─", inner)))
+	b.WriteString("\n")
+	if e.Message != "" {
+		b.WriteString(mutedStyle.Render(truncateString(e.Message, inner)))
+	} else if !e.Running {
+		b.WriteString(mutedStyle.Render(explainSummary(e.Result, e.Err)))
+	}
+	b.WriteString("\n")
+
+	hintKeyStyle := lipgloss.NewStyle().Foreground(s.Colors.Accent)
+	hintDescStyle := lipgloss.NewStyle().Foreground(s.Colors.TextSubtle)
+	b.WriteString(hintKeyStyle.Render("↑↓") + hintDescStyle.Render(" scroll  ") +
+		hintKeyStyle.Render("c") + hintDescStyle.Render(" copy  ") +
+		hintKeyStyle.Render("esc") + hintDescStyle.Render(" close"))
+
+	containerStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(s.Colors.Border).
+		Padding(1, 2)
+
+	return containerStyle.Width(width).Render(b.String())
+}
+
+// explainSummary describes how the command finished, e.g. "1.2s, exit 1"
+func explainSummary(res core.ExplainResult, err error) string {
+	summary := res.Duration.Round(100 * time.Millisecond).String()
+	switch {
+	case res.TimedOut:
+		summary += ", timed out"
+	case err != nil && res.ExitCode > 0:
+		summary += fmt.Sprintf(", exit %d", res.ExitCode)
+	case err != nil:
+		summary += ", failed"
+	}
+	return summary
+}
+
+// =============================================================================
+// Model wiring
+// =============================================================================
+
+// explainIssue runs issues.explainCommand for the selected issue
+func (m *Model) explainIssue() tea.Cmd {
+	issue := m.tabView.VisibleIssuesTab().GetSelectedIssue()
+	if issue == nil || len(issue.TestList) > 0 {
+		return func() tea.Msg { return statusMsg("Select an issue to explain") }
+	}
+	req := core.ExplainRequest{
+		Type:    issue.Type.String(),
+		Message: issue.Message,
+		File:    issue.File,
+		Line:    issue.Line,
+		Column:  issue.Column,
+		Context: issueLogContext(m.tabView.VisibleStreamTab(), *issue),
+	}
+	if issue.FullText != "" {
+		req.Message = issue.FullText
+	}
+
+	if m.explain.cancel != nil {
+		m.explain.cancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	title := issue.Message
+	if loc := issueLocation(*issue); loc != "" {
+		title = loc + ": " + title
+	}
+	m.explain = ExplainOverlay{
+		Title:   title,
+		Running: true,
+		Result:  core.ExplainResult{Command: core.ExpandExplainCommand(m.cfg.Issues.ExplainCommand, req)},
+		gen:     m.explain.gen + 1,
+		cancel:  cancel,
+	}
+	m.mode = ModeExplain
+
+	gen, root, cfg := m.explain.gen, m.projectRoot, m.cfg.Issues
+	return func() tea.Msg {
+		res, err := core.RunExplainCommand(ctx, root, cfg, req)
+		return explainDoneMsg{gen: gen, res: res, err: err}
+	}
+}
+
+// issueLogContext returns the log lines around the first line mentioning
+// the issue
+func issueLogContext(tab *StreamTab, issue Issue) []string {
+	if tab == nil {
+		return nil
+	}
+	needle, _, _ := strings.Cut(strings.TrimSpace(issue.Message), "\n")
+	if needle == "" {
+		return nil
+	}
+	for i, line := range tab.Lines {
+		if !strings.Contains(streamLineText(line), needle) {
+			continue
+		}
+		start, end := maxInt(0, i-explainContextLines), minInt(len(tab.Lines), i+explainContextLines+1)
+		lines := make([]string, 0, end-start)
+		for _, l := range tab.Lines[start:end] {
+			lines = append(lines, streamLineText(l))
+		}
+		return lines
+	}
+	return nil
+}
+
+func streamLineText(line StreamLine) string {
+	if line.Raw != "" {
+		return line.Raw
+	}
+	return line.Text
+}
+
+// handleExplainDone shows the command's output and logs the invocation
+func (m *Model) handleExplainDone(msg explainDoneMsg) {
+	line := fmt.Sprintf("[xcbolt] Explain: %s (%s)", msg.res.Command, explainSummary(msg.res, msg.err))
+	m.tabView.AddLine(line, TabLineTypeNormal)
+	if msg.gen != m.explain.gen || m.mode != ModeExplain {
+		return
+	}
+	m.explain.Running = false
+	m.explain.cancel = nil
+	m.explain.Result = msg.res
+	m.explain.Err = msg.err
+}
+
+// closeExplain closes the overlay, stopping a command still running
+func (m *Model) closeExplain() {
+	if m.explain.cancel != nil {
+		m.explain.cancel()
+	}
+	m.explain = ExplainOverlay{gen: m.explain.gen}
+	m.mode = ModeNormal
+}
+
+// explainWidth and explainHeight size the overlay and its output area
+func (m Model) explainWidth() int {
+	return minInt(maxInt(60, m.width*70/100), maxInt(20, m.width-4))
+}
+
+func (m Model) explainHeight() int {
+	return maxInt(5, m.height-16)
+}
+
+func (m *Model) handleExplainKey(msg tea.KeyMsg) tea.Cmd {
+	e := &m.explain
+	width, height := m.explainWidth()-6, m.explainHeight()
+	switch msg.String() {
+	case "esc", "q":
+		m.closeExplain()
+	case "c":
+		if e.Running {
+			return nil
+		}
+		return m.copyToClipboard(e.Output(), "Copied explanation")
+	case "up", "k":
+		e.Scroll(-1, width, height)
+	case "down", "j":
+		e.Scroll(1, width, height)
+	case "pgup", "ctrl+u":
+		e.Scroll(-height/2, width, height)
+	case "pgdown", "ctrl+d":
+		e.Scroll(height/2, width, height)
+	case "home", "g":
+		e.Scroll(-len(e.Lines(width)), width, height)
+	case "end", "G":
+		e.Scroll(len(e.Lines(width)), width, height)
+	}
+	return nil
+}
+
+func (m Model) explainOverlayView() string {
+	return RenderCenteredPopup(m.explain.View(m.explainWidth(), m.explainHeight(), m.styles, m.spinner.View()), m.width, m.height)
+}
@@ -0,0 +1,84 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func TestExplainIssueShowsOutput(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.mode = ModeNormal
+	m = updateModel(m, tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	errLine := "/src/App.swift:12:5: error: cannot find 'foo' in scope"
+	for _, line := range []string{"CompileSwift normal arm64 /src/App.swift", errLine, "** BUILD FAILED **"} {
+		m.tabView.StreamTab.AddLine(line, TabLineTypeNormal)
+	}
+	m.tabView.IssuesTab.AddIssue(IssueTypeError, errLine)
+	m.selectTab(TabIssues)
+
+	// Unconfigured, x is just stop
+	m = updateModel(m, keyMsgFor("x"))
+	if m.mode != ModeNormal {
+		t.Fatalf("explain ran without issues.explainCommand")
+	}
+
+	m.cfg.Issues.ExplainCommand = "echo {line}; cat"
+	cmd := m.explainIssue()
+	if m.mode != ModeExplain || !m.explain.Running || cmd == nil {
+		t.Fatalf("overlay not opened: mode=%v", m.mode)
+	}
+	if !strings.Contains(stripANSI(m.View()), "Running echo 12; cat") {
+		t.Fatalf("expected a running line:\n%s", stripANSI(m.View()))
+	}
+	m = updateModel(m, cmd())
+
+	view := stripANSI(m.View())
+	for _, want := range []string{"12", "/src/App.swift:12:5: error: cannot find 'foo' in scope", "Log context:", "** BUILD FAILED **"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("overlay missing %q:\n%s", want, view)
+		}
+	}
+	lines := m.tabView.StreamTab.Lines
+	if len(lines) == 0 || !strings.Contains(lines[len(lines)-1].Text, "[xcbolt] Explain: echo 12; cat (") {
+		t.Fatalf("expected an Explain line in the Logs tab, got %+v", lines)
+	}
+
+	m = updateModel(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.mode != ModeNormal {
+		t.Fatalf("esc did not close the overlay")
+	}
+}
+
+func TestExplainIssueFailureShowsStderr(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.mode = ModeNormal
+	m = updateModel(m, tea.WindowSizeMsg{Width: 120, Height: 40})
+	m.tabView.IssuesTab.AddIssue(IssueTypeWarning, "/src/App.swift:3:1: warning: unused variable 'x'")
+	m.selectTab(TabIssues)
+	m.cfg.Issues.ExplainCommand = "echo ignored; echo 'no API key' >&2; exit 3"
+
+	m = updateModel(m, m.explainIssue()())
+	view := stripANSI(m.View())
+	if strings.Contains(view, "ignored") || !strings.Contains(view, "no API key") || !strings.Contains(view, "exit 3") {
+		t.Fatalf("expected stderr and the exit code:\n%s", view)
+	}
+}
+
+func TestExplainOverlayScrollClamps(t *testing.T) {
+	e := ExplainOverlay{Result: core.ExplainResult{Stdout: strings.Repeat("line\n", 20)}}
+	e.Scroll(100, 40, 5)
+	if e.Offset != 15 {
+		t.Fatalf("offset = %d, want 15", e.Offset)
+	}
+	e.Scroll(-100, 40, 5)
+	if e.Offset != 0 {
+		t.Fatalf("offset = %d, want 0", e.Offset)
+	}
+}
@@ -0,0 +1,268 @@
+package tui
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// Fix-its
+// =============================================================================
+
+// FixIt is a textual replacement suggested by the compiler.
+// Lines and columns are 1-based; the end position is exclusive.
+type FixIt struct {
+	File      string
+	StartLine int
+	StartCol  int
+	EndLine   int
+	EndCol    int
+	Text      string
+}
+
+// parseableFixItRE matches -fdiagnostics-parseable-fixits output:
+// fix-it:"/path/File.swift":{12:5-12:8}:"replacement"
+var parseableFixItRE = regexp.MustCompile(`^\s*fix-it:"(.+)":\{(\d+):(\d+)-(\d+):(\d+)\}:(".*")\s*$`)
+
+// caretRE matches the caret line under a quoted source line ("    ^~~~").
+var caretRE = regexp.MustCompile(`^( *)\^(~*)\s*$`)
+
+func parseParseableFixIt(line string) (FixIt, bool) {
+	m := parseableFixItRE.FindStringSubmatch(line)
+	if m == nil {
+		return FixIt{}, false
+	}
+	fix := FixIt{File: m[1]}
+	fix.StartLine, _ = strconv.Atoi(m[2])
+	fix.StartCol, _ = strconv.Atoi(m[3])
+	fix.EndLine, _ = strconv.Atoi(m[4])
+	fix.EndCol, _ = strconv.Atoi(m[5])
+	text, err := strconv.Unquote(m[6])
+	if err != nil {
+		text = strings.Trim(m[6], `"`)
+	}
+	fix.Text = text
+	return fix, true
+}
+
+// caretState follows the "source / caret / replacement" lines Swift prints
+// after a diagnostic, e.g.
+//
+//	let total = coutn + 1
+//	            ^~~~~
+//	            count
+type caretState struct {
+	stage int // 0 idle, 1 expect source, 2 expect caret, 3 expect replacement
+	file  string
+	line  int
+	col   int
+	width int
+}
+
+func (c *caretState) start(file string, line int) {
+	if file == "" || line <= 0 {
+		*c = caretState{}
+		return
+	}
+	*c = caretState{stage: 1, file: file, line: line}
+}
+
+// observe advances the state machine and returns a fix-it once complete.
+func (c *caretState) observe(text string) (FixIt, bool) {
+	switch c.stage {
+	case 1:
+		c.stage = 2
+	case 2:
+		m := caretRE.FindStringSubmatch(text)
+		if m == nil {
+			c.stage = 0
+			return FixIt{}, false
+		}
+		c.col = len(m[1]) + 1
+		if m[2] != "" {
+			c.width = 1 + len(m[2])
+		}
+		c.stage = 3
+	case 3:
+		c.stage = 0
+		// Unindented lines are build output (CompileSwift ...), never a replacement.
+		indent := c.col - 1
+		if indent == 0 || len(text) <= indent || strings.TrimLeft(text[:indent], " ") != "" || text[indent] == ' ' {
+			return FixIt{}, false
+		}
+		return FixIt{
+			File:      c.file,
+			StartLine: c.line,
+			StartCol:  c.col,
+			EndLine:   c.line,
+			EndCol:    c.col + c.width,
+			Text:      strings.TrimRight(text[indent:], " \t"),
+		}, true
+	}
+	return FixIt{}, false
+}
+
+// Describe returns a one-line human summary of the fix-it.
+func (f FixIt) Describe() string {
+	switch {
+	case f.StartLine == f.EndLine && f.StartCol == f.EndCol:
+		return fmt.Sprintf("insert %q at %d:%d", f.Text, f.StartLine, f.StartCol)
+	case f.Text == "":
+		return fmt.Sprintf("remove %d:%d-%d:%d", f.StartLine, f.StartCol, f.EndLine, f.EndCol)
+	default:
+		return fmt.Sprintf("replace %d:%d-%d:%d with %q", f.StartLine, f.StartCol, f.EndLine, f.EndCol, f.Text)
+	}
+}
+
+// fixItFile is the fix-its of an issue that edit one file
+type fixItFile struct {
+	Path  string
+	Fixes []FixIt
+}
+
+// groupFixIts groups fixes by the file each one edits, in the order the
+// files first appear. A fix without a file edits fallback; relative paths
+// are resolved against root.
+func groupFixIts(root, fallback string, fixes []FixIt) []fixItFile {
+	var files []fixItFile
+	index := map[string]int{}
+	for _, f := range fixes {
+		path := f.File
+		if path == "" {
+			path = fallback
+		}
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(root, path)
+		}
+		i, ok := index[path]
+		if !ok {
+			i = len(files)
+			index[path] = i
+			files = append(files, fixItFile{Path: path})
+		}
+		files[i].Fixes = append(files[i].Fixes, f)
+	}
+	return files
+}
+
+// applyFixIts rewrites each file with its fixes. Nothing is written when a
+// file was modified after builtAt or a fix does not fit it. Each original
+// is copied into backupDir first, under its path relative to root.
+// Returns the backup paths.
+func applyFixIts(root string, files []fixItFile, builtAt time.Time, backupDir string) ([]string, error) {
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no fix-its to apply")
+	}
+	type rewrite struct {
+		path         string
+		mode         os.FileMode
+		content, out []byte
+	}
+	rewrites := make([]rewrite, 0, len(files))
+	for _, file := range files {
+		info, err := os.Stat(file.Path)
+		if err != nil {
+			return nil, err
+		}
+		if !builtAt.IsZero() && info.ModTime().After(builtAt) {
+			return nil, fmt.Errorf("%s changed since the build; rebuild to refresh fix-its", filepath.Base(file.Path))
+		}
+		content, err := os.ReadFile(file.Path)
+		if err != nil {
+			return nil, err
+		}
+		out, err := editFixIts(content, file.Fixes)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Base(file.Path), err)
+		}
+		rewrites = append(rewrites, rewrite{path: file.Path, mode: info.Mode().Perm(), content: content, out: out})
+	}
+
+	backups := make([]string, 0, len(rewrites))
+	for _, r := range rewrites {
+		backup := filepath.Join(backupDir, relativeToRoot(root, r.path)+".bak")
+		if err := os.MkdirAll(filepath.Dir(backup), 0o755); err != nil {
+			return backups, err
+		}
+		if err := os.WriteFile(backup, r.content, r.mode); err != nil {
+			return backups, err
+		}
+		backups = append(backups, backup)
+	}
+	for _, r := range rewrites {
+		if err := os.WriteFile(r.path, r.out, r.mode); err != nil {
+			return backups, err
+		}
+	}
+	return backups, nil
+}
+
+// editFixIts returns content with fixes applied
+func editFixIts(content []byte, fixes []FixIt) ([]byte, error) {
+	type edit struct {
+		start, end int
+		text       string
+	}
+	edits := make([]edit, 0, len(fixes))
+	for _, f := range fixes {
+		start, err := lineColOffset(content, f.StartLine, f.StartCol)
+		if err != nil {
+			return nil, err
+		}
+		end, err := lineColOffset(content, f.EndLine, f.EndCol)
+		if err != nil {
+			return nil, err
+		}
+		if end < start {
+			return nil, fmt.Errorf("invalid fix-it range %d:%d-%d:%d", f.StartLine, f.StartCol, f.EndLine, f.EndCol)
+		}
+		edits = append(edits, edit{start: start, end: end, text: f.Text})
+	}
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start > edits[j].start })
+	for i := 1; i < len(edits); i++ {
+		if edits[i].end > edits[i-1].start {
+			return nil, fmt.Errorf("overlapping fix-its")
+		}
+	}
+
+	out := content
+	for _, e := range edits {
+		next := make([]byte, 0, len(out)-(e.end-e.start)+len(e.text))
+		next = append(next, out[:e.start]...)
+		next = append(next, e.text...)
+		next = append(next, out[e.end:]...)
+		out = next
+	}
+	return out, nil
+}
+
+// lineColOffset converts a 1-based line and byte column into an offset.
+// A column one past the end of the line addresses the line break.
+func lineColOffset(content []byte, line, col int) (int, error) {
+	if line < 1 || col < 1 {
+		return 0, fmt.Errorf("invalid position %d:%d", line, col)
+	}
+	offset := 0
+	for l := 1; l < line; l++ {
+		i := bytes.IndexByte(content[offset:], '\n')
+		if i < 0 {
+			return 0, fmt.Errorf("line %d is past the end of the file", line)
+		}
+		offset += i + 1
+	}
+	lineLen := len(content) - offset
+	if i := bytes.IndexByte(content[offset:], '\n'); i >= 0 {
+		lineLen = i
+	}
+	if col-1 > lineLen {
+		return 0, fmt.Errorf("column %d is past the end of line %d", col, line)
+	}
+	return offset + col - 1, nil
+}
@@ -0,0 +1,126 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIssuesTabAttachesParseableFixIt(t *testing.T) {
+	it := NewIssuesTab()
+	it.AddIssue(IssueTypeError, "/src/App.swift:10:5: error: switch must be exhaustive")
+	it.ObserveLine("/src/App.swift:10:5: note: add missing case: '.b'")
+	it.ObserveLine(`fix-it:"/src/App.swift":{12:1-12:1}:"case .b:\n"`)
+
+	issue := it.GetSelectedIssue()
+	if issue == nil || len(issue.FixIts) != 1 {
+		t.Fatalf("expected one fix-it, got %+v", issue)
+	}
+	fix := issue.FixIts[0]
+	if fix.File != "/src/App.swift" || fix.StartLine != 12 || fix.EndCol != 1 || fix.Text != "case .b:\n" {
+		t.Fatalf("unexpected fix-it: %+v", fix)
+	}
+}
+
+func TestIssuesTabAttachesCaretFixIt(t *testing.T) {
+	it := NewIssuesTab()
+	it.AddIssue(IssueTypeError, "/src/App.swift:3:13: error: cannot find 'coutn' in scope")
+	it.ObserveLine("let total = coutn + 1")
+	it.ObserveLine("            ^~~~~")
+	it.ObserveLine("            count")
+
+	issue := it.GetSelectedIssue()
+	if issue == nil || len(issue.FixIts) != 1 {
+		t.Fatalf("expected one fix-it, got %+v", issue)
+	}
+	fix := issue.FixIts[0]
+	if fix.StartLine != 3 || fix.StartCol != 13 || fix.EndCol != 18 || fix.Text != "count" {
+		t.Fatalf("unexpected fix-it: %+v", fix)
+	}
+
+	// A caret block whose next line is unrelated output yields nothing.
+	it.AddIssue(IssueTypeWarning, "/src/App.swift:5:1: warning: unused")
+	it.ObserveLine("foo()")
+	it.ObserveLine("^")
+	it.ObserveLine("CompileSwift normal arm64")
+	for _, issue := range it.Issues {
+		if issue.Type == IssueTypeWarning && len(issue.FixIts) != 0 {
+			t.Fatalf("unexpected fix-it on warning: %+v", issue.FixIts)
+		}
+	}
+}
+
+func TestApplyFixIts(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "App.swift")
+	src := "let a = 1\nlet total = coutn + 1\n"
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	fixes := []FixIt{
+		{File: path, StartLine: 2, StartCol: 13, EndLine: 2, EndCol: 18, Text: "count"},
+		{File: path, StartLine: 1, StartCol: 10, EndLine: 1, EndCol: 10, Text: " // one"},
+	}
+
+	backupDir := filepath.Join(dir, "backups")
+	backups, err := applyFixIts(dir, groupFixIts(dir, "", fixes), time.Now().Add(time.Minute), backupDir)
+	if err != nil || len(backups) != 1 {
+		t.Fatalf("applyFixIts: %v %v", backups, err)
+	}
+	got, _ := os.ReadFile(path)
+	if string(got) != "let a = 1 // one\nlet total = count + 1\n" {
+		t.Fatalf("unexpected result:\n%s", got)
+	}
+	orig, _ := os.ReadFile(backups[0])
+	if string(orig) != src {
+		t.Fatalf("backup does not hold the original: %q", orig)
+	}
+
+	// The file was just written, so it is newer than a build from an hour ago.
+	_, err = applyFixIts(dir, groupFixIts(dir, "", fixes[:1]), time.Now().Add(-time.Hour), backupDir)
+	if err == nil || !strings.Contains(err.Error(), "changed since the build") {
+		t.Fatalf("expected mtime refusal, got %v", err)
+	}
+}
+
+func TestApplyFixItsAcrossFiles(t *testing.T) {
+	dir := t.TempDir()
+	app := filepath.Join(dir, "App", "Model.swift")
+	kit := filepath.Join(dir, "Kit", "Model.swift")
+	for path, src := range map[string]string{app: "let a = coutn\n", kit: "var b = 1\n"} {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fixes := []FixIt{
+		{File: app, StartLine: 1, StartCol: 9, EndLine: 1, EndCol: 14, Text: "count"},
+		{File: "Kit/Model.swift", StartLine: 1, StartCol: 1, EndLine: 1, EndCol: 4, Text: "let"},
+	}
+	files := groupFixIts(dir, app, fixes)
+	if len(files) != 2 || files[1].Path != kit {
+		t.Fatalf("groups = %+v", files)
+	}
+
+	backupDir := filepath.Join(dir, "backups")
+	backups, err := applyFixIts(dir, files, time.Now().Add(time.Minute), backupDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for path, want := range map[string]string{app: "let a = count\n", kit: "let b = 1\n"} {
+		if got, _ := os.ReadFile(path); string(got) != want {
+			t.Fatalf("%s = %q, want %q", path, got, want)
+		}
+	}
+	want := []string{filepath.Join(backupDir, "App", "Model.swift.bak"), filepath.Join(backupDir, "Kit", "Model.swift.bak")}
+	if len(backups) != 2 || backups[0] != want[0] || backups[1] != want[1] {
+		t.Fatalf("backups = %v, want %v", backups, want)
+	}
+	if orig, _ := os.ReadFile(backups[1]); string(orig) != "var b = 1\n" {
+		t.Fatalf("Kit backup = %q", orig)
+	}
+}
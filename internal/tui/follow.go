@@ -0,0 +1,62 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// =============================================================================
+// Follow Indicator
+// =============================================================================
+
+// followIndicator is the badge scrollable views show in their top-right
+// corner: FOLLOW while they stick to new output, PAUSED with the number of
+// lines that arrived since while scrolled up. G resumes following.
+func followIndicator(following bool, newLines int, styles Styles) string {
+	if following {
+		return lipgloss.NewStyle().Foreground(styles.Colors.TextSubtle).Render("FOLLOW")
+	}
+	text := "PAUSED"
+	if newLines > 0 {
+		noun := "lines"
+		if newLines == 1 {
+			noun = "line"
+		}
+		text = fmt.Sprintf("PAUSED ↓ %d new %s", newLines, noun)
+	}
+	return lipgloss.NewStyle().Foreground(styles.Colors.Warning).Bold(true).Render(text)
+}
+
+// overlayTopRight draws badge over the right end of block's first row,
+// which comes out exactly width columns wide. Rows too narrow for the badge
+// are only padded; unsized views (width 0) are left alone.
+func overlayTopRight(block, badge string, width int) string {
+	if width <= 0 {
+		return block
+	}
+	row, rest, multiline := strings.Cut(block, "\n")
+	keep := width - lipgloss.Width(badge) - 1
+	if keep < 0 {
+		row = fitRow(row, width)
+	} else {
+		row = fitRow(row, keep) + " " + badge
+	}
+	if multiline {
+		return row + "\n" + rest
+	}
+	return row
+}
+
+// fitRow truncates or pads row to width columns
+func fitRow(row string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	row = lipgloss.NewStyle().MaxWidth(width).Render(row)
+	if pad := width - lipgloss.Width(row); pad > 0 {
+		row += strings.Repeat(" ", pad)
+	}
+	return row
+}
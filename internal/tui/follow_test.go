@@ -0,0 +1,155 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// assertRowWidths fails when a row of view is wider than width, or the
+// first row (carrying the indicator) is not exactly width wide
+func assertRowWidths(t *testing.T, view string, width int) {
+	t.Helper()
+	rows := strings.Split(view, "\n")
+	if w := lipgloss.Width(rows[0]); w != width {
+		t.Fatalf("top row is %d wide, want %d: %q", w, width, stripANSI(rows[0]))
+	}
+	for i, row := range rows {
+		if w := lipgloss.Width(row); w > width {
+			t.Fatalf("row %d is %d wide, want at most %d", i, w, width)
+		}
+	}
+}
+
+func TestStreamTabFollowIndicator(t *testing.T) {
+	st := NewStreamTab()
+	st.SetSize(60, 5)
+	for i := range 10 {
+		st.AddLine(fmt.Sprintf("line %d", i), TabLineTypeNormal)
+	}
+	view := st.View(DefaultStyles())
+	if !strings.Contains(stripANSI(view), "FOLLOW") {
+		t.Fatalf("missing FOLLOW:\n%s", stripANSI(view))
+	}
+	assertRowWidths(t, view, 60)
+
+	st.ScrollUp(3)
+	for i := range 312 {
+		st.AddLine(fmt.Sprintf("new %d", i), TabLineTypeNormal)
+	}
+	if st.NewLines != 312 {
+		t.Fatalf("NewLines = %d", st.NewLines)
+	}
+	view = st.View(DefaultStyles())
+	if !strings.Contains(stripANSI(view), "PAUSED ↓ 312 new lines") {
+		t.Fatalf("missing paused indicator:\n%s", stripANSI(view))
+	}
+	assertRowWidths(t, view, 60)
+
+	st.GotoBottom()
+	if st.NewLines != 0 || !st.AutoFollow {
+		t.Fatalf("GotoBottom kept NewLines = %d, follow = %v", st.NewLines, st.AutoFollow)
+	}
+	st.AddLine("after", TabLineTypeNormal)
+	if st.NewLines != 0 {
+		t.Fatalf("counted a line while following")
+	}
+
+	// Scrolling back down to the end follows again too
+	st.ScrollUp(1)
+	st.AddLine("one more", TabLineTypeNormal)
+	if !strings.Contains(stripANSI(st.View(DefaultStyles())), "PAUSED ↓ 1 new line") {
+		t.Fatalf("missing singular indicator:\n%s", stripANSI(st.View(DefaultStyles())))
+	}
+	st.ScrollDown(10)
+	if st.NewLines != 0 || !st.AutoFollow {
+		t.Fatalf("ScrollDown to the end kept NewLines = %d", st.NewLines)
+	}
+}
+
+func TestStreamTabHidesIndicatorWhenLinesFit(t *testing.T) {
+	st := NewStreamTab()
+	st.SetSize(60, 5)
+	st.AddLine("only line", TabLineTypeNormal)
+	if view := stripANSI(st.View(DefaultStyles())); strings.Contains(view, "FOLLOW") {
+		t.Fatalf("indicator shown without overflow:\n%s", view)
+	}
+}
+
+func TestConsolePaneFollowIndicator(t *testing.T) {
+	var c ConsolePane
+	c.Reset()
+	wrap := func(s string) []string { return []string{s} }
+	for i := range 10 {
+		c.Append(fmt.Sprintf("log %d", i), "", time.Now(), wrap)
+	}
+	opts := func() consoleViewOptions {
+		return consoleViewOptions{Width: 50, Height: 4, Header: "Console", Visible: c.VisibleIndices(func(string) bool { return true }), Styles: DefaultStyles()}
+	}
+	maxPos := len(opts().Visible) - 3
+
+	c.Settle(maxPos)
+	view := c.View(opts())
+	if !strings.Contains(stripANSI(strings.Split(view, "\n")[0]), "Console") || !strings.Contains(stripANSI(view), "FOLLOW") {
+		t.Fatalf("missing header or FOLLOW:\n%s", stripANSI(view))
+	}
+	assertRowWidths(t, view, 50)
+
+	c.Scroll(-2, maxPos)
+	for i := range 5 {
+		c.Append(fmt.Sprintf("more %d", i), "", time.Now(), wrap)
+	}
+	if c.NewLines != 5 {
+		t.Fatalf("NewLines = %d", c.NewLines)
+	}
+	view = c.View(opts())
+	if !strings.Contains(stripANSI(view), "PAUSED ↓ 5 new lines") {
+		t.Fatalf("missing paused indicator:\n%s", stripANSI(view))
+	}
+	assertRowWidths(t, view, 50)
+
+	c.ScrollToEnd(len(opts().Visible) - 3)
+	if c.NewLines != 0 || !c.Follow {
+		t.Fatalf("ScrollToEnd kept NewLines = %d", c.NewLines)
+	}
+}
+
+func TestIssuesTabFollowIndicator(t *testing.T) {
+	it := NewIssuesTab()
+	it.SetSize(70, 8)
+	for i := range 10 {
+		it.AddIssue(IssueTypeWarning, fmt.Sprintf("/src/File%d.swift:1:1: warning: unused %d", i, i))
+	}
+	if it.NewLines != 0 {
+		t.Fatalf("counted issues while following")
+	}
+	view := it.View(DefaultStyles())
+	if !strings.Contains(stripANSI(view), "FOLLOW") {
+		t.Fatalf("missing FOLLOW:\n%s", stripANSI(view))
+	}
+	assertRowWidths(t, view, 70)
+
+	it.ScrollDown(1)
+	if it.Follow {
+		t.Fatalf("moving through an overflowing list kept following")
+	}
+	it.AddIssue(IssueTypeError, "/src/Main.swift:3:1: error: boom")
+	it.AddIssue(IssueTypeWarning, "/src/Other.swift:3:1: warning: meh")
+	view = it.View(DefaultStyles())
+	if !strings.Contains(stripANSI(view), "PAUSED ↓ 2 new lines") {
+		t.Fatalf("missing paused indicator:\n%s", stripANSI(view))
+	}
+	assertRowWidths(t, view, 70)
+
+	it.GotoBottom()
+	if !it.Follow || it.NewLines != 0 {
+		t.Fatalf("GotoBottom kept NewLines = %d", it.NewLines)
+	}
+	it.Clear()
+	if !it.Follow || it.NewLines != 0 {
+		t.Fatalf("Clear kept the follow state")
+	}
+}
@@ -0,0 +1,66 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// =============================================================================
+// Project .gitignore
+// =============================================================================
+
+// openGitignoreSelector asks what Git: Add Ignore Entry should add to the
+// project's .gitignore
+func (m *Model) openGitignoreSelector() {
+	plan, err := core.PlanProjectGitignore(m.projectRoot, core.GitignoreDir)
+	if err != nil {
+		m.lastErr = err.Error()
+		m.setStatus("Could not read .gitignore")
+		return
+	}
+	if len(plan.Add) == 0 {
+		m.setStatus(".gitignore already ignores .xcbolt")
+		return
+	}
+	items := []SelectorItem{
+		{ID: string(core.GitignoreDir), Title: "Ignore .xcbolt/", Description: "Keep all of xcbolt's files out of git"},
+		{ID: string(core.GitignoreSelective), Title: "Ignore generated files", Description: "Commit .xcbolt/config.json to share it with the team"},
+	}
+	m.selector = NewSelector("Add to .gitignore", items, m.width, m.styles)
+	m.selectorType = SelectorGitignore
+	m.mode = ModeSelector
+}
+
+// confirmGitignore previews what mode adds to the project's .gitignore and
+// writes it only once confirmed
+func (m *Model) confirmGitignore(mode core.GitignoreMode) tea.Cmd {
+	plan, err := core.PlanProjectGitignore(m.projectRoot, mode)
+	if err != nil {
+		m.lastErr = err.Error()
+		m.setStatus("Could not read .gitignore")
+		return nil
+	}
+	if len(plan.Add) == 0 {
+		m.setStatus(".gitignore already ignores .xcbolt")
+		return nil
+	}
+	root := m.projectRoot
+	m.openConfirm(ConfirmPrompt{
+		Title: "Update .gitignore?",
+		Body:  plan.Preview(),
+		OnYes: func(m *Model) tea.Cmd {
+			added, err := core.AddProjectGitignore(root, mode)
+			if err != nil {
+				m.lastErr = err.Error()
+				m.setStatus("Could not write .gitignore")
+				return nil
+			}
+			m.setStatus(fmt.Sprintf("Added %d lines to .gitignore", len(added)))
+			return nil
+		},
+	})
+	return nil
+}
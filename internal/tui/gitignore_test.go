@@ -0,0 +1,67 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func TestGitignoreEntryIsPreviewedAndConfirmed(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	root := t.TempDir()
+	path := core.ProjectGitignorePath(root)
+	if err := os.WriteFile(path, []byte("*.xcuserstate"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel(root, "", ConfigOverrides{})
+
+	m.executePaletteCommand(&Command{ID: "git-ignore"})
+	if m.mode != ModeSelector || m.selectorType != SelectorGitignore {
+		t.Fatalf("expected the mode selector, mode=%v", m.mode)
+	}
+	m.mode = ModeNormal
+	m.confirmGitignore(core.GitignoreDir)
+	if m.mode != ModeConfirm || !strings.Contains(strings.Join(m.confirm.Body, "\n"), "+ .xcbolt/") {
+		t.Fatalf("expected a preview: mode=%v body=%q", m.mode, m.confirm.Body)
+	}
+
+	m.handleConfirmKey(tea.KeyMsg{Type: tea.KeyEsc})
+	if b, _ := os.ReadFile(path); string(b) != "*.xcuserstate" {
+		t.Fatalf("cancel changed .gitignore: %q", b)
+	}
+
+	m.confirmGitignore(core.GitignoreDir)
+	m.handleConfirmKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if b, _ := os.ReadFile(path); string(b) != "*.xcuserstate\n# xcbolt\n.xcbolt/\n" {
+		t.Fatalf(".gitignore = %q", b)
+	}
+
+	m.executePaletteCommand(&Command{ID: "git-ignore"})
+	if m.mode == ModeSelector || !strings.Contains(m.statusMsg, "already ignores") {
+		t.Fatalf("expected nothing to do: mode=%v status=%q", m.mode, m.statusMsg)
+	}
+}
+
+func TestWizardOffersGitignoreOnlyInGitCheckouts(t *testing.T) {
+	root := t.TempDir()
+	if wizardOffersGitignore(root) {
+		t.Fatal("offered outside a git checkout")
+	}
+	if err := os.Mkdir(filepath.Join(root, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if !wizardOffersGitignore(root) {
+		t.Fatal("not offered in a git checkout")
+	}
+	if err := os.WriteFile(core.ProjectGitignorePath(root), []byte(".xcbolt/\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if wizardOffersGitignore(root) {
+		t.Fatal("offered although .xcbolt is ignored")
+	}
+}
@@ -0,0 +1,293 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// =============================================================================
+// Help Overlay
+// =============================================================================
+
+var helpGroupNames = []string{"ACTIONS", "CONFIGURATION", "TABS", "VIEW", "SCROLLING", "NAVIGATION"}
+
+// helpEntry is one binding listed in the help overlay
+type helpEntry struct {
+	Group   string
+	Binding key.Binding
+	// Reason is set when the binding does nothing in the current state
+	Reason string
+}
+
+// helpEntries returns the FullHelp bindings matching the help filter
+func (m Model) helpEntries() []helpEntry {
+	query := strings.TrimSpace(m.helpFilter)
+	var entries []helpEntry
+	for i, group := range m.keys.FullHelp() {
+		name := ""
+		if i < len(helpGroupNames) {
+			name = helpGroupNames[i]
+		}
+		for _, b := range group {
+			h := b.Help()
+			if query != "" && !fuzzyMatch(h.Key, query) && !fuzzyMatch(h.Desc, query) {
+				continue
+			}
+			entries = append(entries, helpEntry{Group: name, Binding: b, Reason: m.helpDisabledReason(b)})
+		}
+	}
+	return entries
+}
+
+// helpDisabledReason explains why a binding is inert right now, or "" if it
+// would do something.
+func (m Model) helpDisabledReason(b key.Binding) string {
+	k := m.keys
+	switch {
+	case sameBinding(b, k.Help):
+		return "already open"
+	case sameBinding(b, k.Stop), sameBinding(b, k.Cancel):
+		if !m.running {
+			return "nothing running"
+		}
+	case sameBinding(b, k.Rerun):
+		if m.running {
+			return "operation running"
+		}
+		if m.lastOp == nil {
+			return "nothing run yet"
+		}
+	case sameBinding(b, k.PrevRun):
+		if !m.tabView.HasPrevious() {
+			return "no previous run"
+		}
+	case sameBinding(b, k.SwitchPane), sameBinding(b, k.ConsoleFilter),
+		sameBinding(b, k.SplitGrow), sameBinding(b, k.SplitShrink), sameBinding(b, k.SplitSwap):
+		if !m.runMode.Active {
+			return "run mode only"
+		}
+	case sameBinding(b, k.TabNext):
+		if m.runMode.Active {
+			return "switches panes in run mode"
+		}
+	case sameBinding(b, k.Bookmarks):
+		if len(m.bookmarks) == 0 {
+			return "no bookmarks"
+		}
+	case sameBinding(b, k.ApplyFixIt), sameBinding(b, k.CopyLoc), sameBinding(b, k.IssueCategory):
+		if m.tabView.ActiveTab != TabIssues {
+			return "Issues tab only"
+		}
+	case sameBinding(b, k.MutePhase):
+		if !m.onPhaseHeader() {
+			return "phase header at top of Logs"
+		}
+	case sameBinding(b, k.ExplainIssue):
+		if m.cfg.Issues.ExplainCommand == "" {
+			return "set issues.explainCommand"
+		}
+		if m.tabView.ActiveTab != TabIssues {
+			return "Issues tab only"
+		}
+	}
+	return ""
+}
+
+func sameBinding(a, b key.Binding) bool {
+	return a.Help() == b.Help() && strings.Join(a.Keys(), ",") == strings.Join(b.Keys(), ",")
+}
+
+// openHelp shows the help overlay with an empty filter
+func (m *Model) openHelp() {
+	m.mode = ModeHelp
+	m.helpFilter = ""
+	m.helpCursor = 0
+	m.setupHelpViewport()
+	m.helpViewport.GotoTop()
+}
+
+func (m *Model) handleHelpKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		if m.helpFilter != "" {
+			m.setHelpFilter("")
+			return nil
+		}
+		m.mode = ModeNormal
+	case "?":
+		m.mode = ModeNormal
+	case "enter":
+		return m.executeHelpEntry()
+	case "up", "ctrl+p":
+		m.moveHelpCursor(-1)
+	case "down", "ctrl+n":
+		m.moveHelpCursor(1)
+	case "pgup", "ctrl+u":
+		m.helpViewport.HalfViewUp()
+	case "pgdown", "ctrl+d":
+		m.helpViewport.HalfViewDown()
+	case "home":
+		m.helpViewport.GotoTop()
+	case "end":
+		m.helpViewport.GotoBottom()
+	case "backspace":
+		if r := []rune(m.helpFilter); len(r) > 0 {
+			m.setHelpFilter(string(r[:len(r)-1]))
+		}
+	default:
+		if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+			m.setHelpFilter(m.helpFilter + string(msg.Runes))
+		}
+	}
+	return nil
+}
+
+func (m *Model) setHelpFilter(filter string) {
+	m.helpFilter = filter
+	m.helpCursor = 0
+	m.setupHelpViewport()
+}
+
+func (m *Model) moveHelpCursor(delta int) {
+	n := len(m.helpEntries())
+	if n == 0 {
+		return
+	}
+	m.helpCursor = (m.helpCursor + delta + n) % n
+	m.setupHelpViewport()
+}
+
+// executeHelpEntry closes help and replays the highlighted binding as a key
+// press in normal mode.
+func (m *Model) executeHelpEntry() tea.Cmd {
+	entries := m.helpEntries()
+	if m.helpCursor < 0 || m.helpCursor >= len(entries) {
+		return nil
+	}
+	e := entries[m.helpCursor]
+	if e.Reason != "" {
+		m.setStatus("Not available: " + e.Reason)
+		return nil
+	}
+	keys := e.Binding.Keys()
+	if len(keys) == 0 {
+		return nil
+	}
+	m.mode = ModeNormal
+	return m.handleKeyPress(keyMsgFor(keys[0]))
+}
+
+// keyMsgFor builds the KeyMsg whose String() is k
+func keyMsgFor(k string) tea.KeyMsg {
+	alt := false
+	name := k
+	if strings.HasPrefix(name, "alt+") && len(name) > len("alt+") {
+		alt = true
+		name = strings.TrimPrefix(name, "alt+")
+	}
+	for t := tea.KeyType(-128); t < 128; t++ {
+		if t == tea.KeyRunes {
+			continue
+		}
+		if (tea.Key{Type: t}).String() == name {
+			return tea.KeyMsg{Type: t, Alt: alt}
+		}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(name), Alt: alt}
+}
+
+// setupHelpViewport sizes the help viewport and renders the filtered entries.
+// The scroll offset is kept while the filter changes, then adjusted so the
+// highlighted entry stays visible.
+func (m *Model) setupHelpViewport() {
+	s := m.styles
+
+	// Calculate width: 50-60% of screen, clamped
+	width := m.width * 55 / 100
+	if width < 50 {
+		width = 50
+	}
+	if width > 80 {
+		width = 80
+	}
+
+	// Calculate max height for content (leave room for border, padding, title, filter, footer)
+	maxHeight := m.height - 12
+	if maxHeight < 10 {
+		maxHeight = 10
+	}
+
+	sectionStyle := lipgloss.NewStyle().
+		Foreground(s.Colors.TextSubtle).
+		Bold(true)
+
+	keyStyle := lipgloss.NewStyle().
+		Foreground(s.Colors.Accent).
+		Width(12)
+
+	descStyle := lipgloss.NewStyle().
+		Foreground(s.Colors.Text)
+
+	dimStyle := lipgloss.NewStyle().
+		Foreground(s.Colors.TextSubtle)
+
+	selectedStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(s.Colors.Accent)
+
+	entries := m.helpEntries()
+	if m.helpCursor >= len(entries) {
+		m.helpCursor = maxInt(0, len(entries)-1)
+	}
+
+	var b strings.Builder
+	lineNo, cursorLine := 0, 0
+	group := ""
+	for i, e := range entries {
+		if e.Group != group {
+			if i > 0 {
+				b.WriteString("\n")
+				lineNo++
+			}
+			group = e.Group
+			b.WriteString(sectionStyle.Render("  " + group))
+			b.WriteString("\n")
+			lineNo++
+		}
+
+		h := e.Binding.Help()
+		marker := "  "
+		if i == m.helpCursor {
+			marker = "▸ "
+			cursorLine = lineNo
+		}
+		var line string
+		switch {
+		case e.Reason != "":
+			line = dimStyle.Render(marker) + dimStyle.Width(12).Render(h.Key) + dimStyle.Render(h.Desc+" ("+e.Reason+")")
+		case i == m.helpCursor:
+			line = selectedStyle.Render(marker) + keyStyle.Render(h.Key) + selectedStyle.Render(h.Desc)
+		default:
+			line = marker + keyStyle.Render(h.Key) + descStyle.Render(h.Desc)
+		}
+		b.WriteString(line + "\n")
+		lineNo++
+	}
+	if len(entries) == 0 {
+		b.WriteString(dimStyle.Render("  No matching shortcuts") + "\n")
+	}
+
+	offset := m.helpViewport.YOffset
+	m.helpViewport.Width = width - 6 // Account for border and padding
+	m.helpViewport.Height = maxHeight
+	m.helpViewport.SetContent(b.String())
+	m.helpViewport.SetYOffset(offset)
+	if cursorLine < m.helpViewport.YOffset {
+		m.helpViewport.SetYOffset(cursorLine)
+	} else if cursorLine >= m.helpViewport.YOffset+m.helpViewport.Height {
+		m.helpViewport.SetYOffset(cursorLine - m.helpViewport.Height + 1)
+	}
+}
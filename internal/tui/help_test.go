@@ -0,0 +1,82 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func newHelpTestModel(t *testing.T) *Model {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.width, m.height = 120, 40
+	m.openHelp()
+	return &m
+}
+
+func typeHelp(m *Model, text string) {
+	for _, r := range text {
+		m.handleHelpKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func TestHelpFilterNarrowsEntries(t *testing.T) {
+	m := newHelpTestModel(t)
+	all := len(m.helpEntries())
+
+	typeHelp(m, "fixit")
+	entries := m.helpEntries()
+	if len(entries) == 0 || len(entries) >= all {
+		t.Fatalf("filter did not narrow entries: %d of %d", len(entries), all)
+	}
+	if !sameBinding(entries[0].Binding, m.keys.ApplyFixIt) {
+		t.Fatalf("expected apply fix-it first, got %+v", entries[0].Binding.Help())
+	}
+	if entries[0].Reason != "Issues tab only" {
+		t.Fatalf("expected apply fix-it dimmed outside Issues, got %q", entries[0].Reason)
+	}
+
+	m.handleHelpKey(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.mode != ModeHelp || m.helpFilter != "" {
+		t.Fatalf("esc should clear the filter first")
+	}
+	m.handleHelpKey(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.mode != ModeNormal {
+		t.Fatalf("second esc should close help")
+	}
+}
+
+func TestHelpEnterExecutesBinding(t *testing.T) {
+	m := newHelpTestModel(t)
+	typeHelp(m, "issues")
+	entries := m.helpEntries()
+	if len(entries) == 0 || !sameBinding(entries[m.helpCursor].Binding, m.keys.Tab3) {
+		t.Fatalf("expected the Issues tab binding highlighted, got %+v", entries)
+	}
+
+	m.handleHelpKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.mode != ModeNormal || m.tabView.ActiveTab != TabIssues {
+		t.Fatalf("expected help closed on the Issues tab, mode=%v tab=%v", m.mode, m.tabView.ActiveTab)
+	}
+}
+
+func TestHelpEnterRefusesDisabledBinding(t *testing.T) {
+	m := newHelpTestModel(t)
+	typeHelp(m, "stop")
+	m.handleHelpKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.mode != ModeHelp {
+		t.Fatalf("disabled binding should keep help open")
+	}
+	if m.statusMsg != "Not available: nothing running" {
+		t.Fatalf("unexpected status %q", m.statusMsg)
+	}
+}
+
+func TestKeyMsgForRoundTrips(t *testing.T) {
+	for _, k := range []string{"b", "?", "tab", "shift+tab", "ctrl+k", "enter", "pgdown", " "} {
+		if got := keyMsgFor(k).String(); got != k {
+			t.Errorf("keyMsgFor(%q).String() = %q", k, got)
+		}
+	}
+}
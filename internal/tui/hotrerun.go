@@ -0,0 +1,268 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// hotRerunErrorLines is how many errors a failed hot rerun lists in the
+// console
+const hotRerunErrorLines = 3
+
+// hotRerunTarget finds the app a hot rerun replaces: the last run, or else
+// the newest session, when it ran on the current destination
+func (m *Model) hotRerunTarget() (stopTarget, bool) {
+	dst := m.cfg.Destination
+	if m.lastRun.BundleID != "" {
+		target := stopTarget{BundleID: m.lastRun.BundleID, PID: m.lastRun.PID, Target: m.lastRun.Target, UDID: m.lastRun.UDID}
+		if runsOn(target, dst) {
+			return target, true
+		}
+	}
+	sessions, err := core.LoadSessions(m.projectRoot)
+	if err != nil {
+		return stopTarget{}, false
+	}
+	var best *core.Session
+	for i, sess := range sessions.Items {
+		if !runsOn(sessionStopTarget(sess), dst) {
+			continue
+		}
+		if best == nil || parseSessionTime(sess.StartedAt).After(parseSessionTime(best.StartedAt)) {
+			best = &sessions.Items[i]
+		}
+	}
+	if best == nil {
+		return stopTarget{}, false
+	}
+	return sessionStopTarget(*best), true
+}
+
+// runsOn reports whether target is an app on destination dst
+func runsOn(target stopTarget, dst core.Destination) bool {
+	if target.BundleID == "" || target.Target != string(dst.Kind) {
+		return false
+	}
+	switch dst.Kind {
+	case core.DestSimulator, core.DestDevice:
+		return target.UDID != "" && (target.UDID == dst.UDID || target.UDID == dst.ID)
+	case core.DestMacOS, core.DestCatalyst:
+		return target.PID != 0
+	}
+	return false
+}
+
+// hotRerun rebuilds and relaunches the app running on the current
+// destination, keeping the console. The old app is stopped only once the
+// build succeeded; a run still attached to it keeps streaming its console
+// until then. Without one it is a plain run.
+func (m *Model) hotRerun() tea.Cmd {
+	if m.replacedRun != nil {
+		m.setStatus("Hot rerun already in progress")
+		return nil
+	}
+	if target, ok := m.attachedRunTarget(); ok {
+		m.detachRun(target)
+		m.hotStop = &target
+		return m.startOp("run")
+	}
+	target, ok := m.hotRerunTarget()
+	if !ok {
+		m.hotStop = nil
+		m.setStatus("No app running on this destination · starting Run")
+		return m.startOrRestartOp("run")
+	}
+	m.hotStop = &target
+	return m.startOrRestartOp("run")
+}
+
+// replacedRun is an attached run a hot rerun replaces. It keeps streaming
+// the previous app's console while the new run builds, ends at the new
+// run's BeforeLaunch, and becomes current again when the new run stops
+// before that.
+type replacedRun struct {
+	seq     int
+	app     stopTarget
+	started time.Time
+	cancel  context.CancelFunc
+	events  *chanEmitter
+	stop    chan struct{}
+	done    <-chan opDoneMsg
+	exited  <-chan struct{}
+}
+
+// end cancels the replaced run and waits for it to return, so the old
+// console is closed before the new app launches
+func (r *replacedRun) end(ctx context.Context) error {
+	if r == nil {
+		return nil
+	}
+	r.cancel()
+	select {
+	case <-r.exited:
+	case <-time.After(shutdownGrace):
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return nil
+}
+
+// noteRunApp records the app the run in flight launched (AppLaunchedCode)
+func (m *Model) noteRunApp(ev core.Event) {
+	if m.runningCmd != "run" {
+		return
+	}
+	data, _ := ev.Data.(map[string]any)
+	var app stopTarget
+	app.BundleID, _ = data["bundleId"].(string)
+	app.PID, _ = data["pid"].(int)
+	app.Target, _ = data["target"].(string)
+	app.UDID, _ = data["udid"].(string)
+	m.runApp = &app
+}
+
+// attachedRunTarget is the app of the run in flight, when that run is
+// attached to it on the current destination
+func (m *Model) attachedRunTarget() (stopTarget, bool) {
+	if !m.running || m.runningCmd != "run" || m.runApp == nil {
+		return stopTarget{}, false
+	}
+	return *m.runApp, runsOn(*m.runApp, m.cfg.Destination)
+}
+
+// detachRun sets the run in flight aside as the replaced run of a hot
+// rerun, without canceling it
+func (m *Model) detachRun(app stopTarget) {
+	m.replacedRun = &replacedRun{
+		seq:     m.opSeq,
+		app:     app,
+		started: m.opStart,
+		cancel:  m.cancelFn,
+		events:  m.events,
+		stop:    m.eventStopCh,
+		done:    m.doneCh,
+		exited:  m.opExited,
+	}
+	m.running = false
+	m.cancelFn = nil
+	m.events = nil
+	m.eventStopCh = nil
+	m.doneCh = nil
+	m.opExited = nil
+	m.runApp = nil
+}
+
+// handleReplacedEvents shows the replaced app's output in the console;
+// the rest of the replaced run's events are dropped
+func (m *Model) handleReplacedEvents(events []core.Event) {
+	now := time.Now()
+	for _, ev := range events {
+		if !isConsoleEvent(ev) {
+			continue
+		}
+		line := m.formatConsoleEvent(ev)
+		m.appendConsoleLogAt(line, consoleEventLevel(line, ev), eventTime(ev, now))
+	}
+}
+
+// endReplacedRun closes out the replaced run once it returned
+func (m *Model) endReplacedRun() {
+	r := m.replacedRun
+	m.replacedRun = nil
+	if r.stop != nil {
+		close(r.stop)
+	}
+	if r.events != nil {
+		r.events.log.Close()
+	}
+}
+
+// resumeReplacedRun makes the replaced run current again once the hot
+// rerun finished without launching. When another op or quitting waits, it
+// is canceled instead.
+func (m *Model) resumeReplacedRun() tea.Cmd {
+	r := m.replacedRun
+	if r == nil {
+		return nil
+	}
+	if m.pendingOp != "" || m.quitting {
+		r.cancel()
+		return nil
+	}
+	m.replacedRun = nil
+	m.opSeq = r.seq
+	m.running = true
+	m.runningCmd = "run"
+	m.opStart = r.started
+	m.cancelFn = r.cancel
+	m.events = r.events
+	m.eventStopCh = r.stop
+	m.doneCh = r.done
+	m.opExited = r.exited
+	app := r.app
+	m.runApp = &app
+	m.currentStage = "Running"
+	m.runMode.Active = true
+	return tea.Batch(tickCmd(), m.watchAppUsage(app.PID, app.Target))
+}
+
+// hotRerunDivider separates sessions in the console pane
+func hotRerunDivider(at time.Time) string {
+	return consoleSystemPrefix + "──────── hot rerun " + at.Format("15:04:05") + " ────────"
+}
+
+// stopBeforeRelaunch stops the replaced app from core.Run's BeforeLaunch.
+// An app that is already gone is only a warning: the launch replaces it
+// anyway.
+func stopBeforeRelaunch(ctx context.Context, projectRoot string, target stopTarget, emit core.Emitter, preserveLocale bool) error {
+	emit.Emit(core.Status("run", "Stopping previous app", map[string]any{"bundleId": target.BundleID, "pid": target.PID}))
+	stopCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if err := stopTargetApp(stopCtx, target, preserveLocale); err != nil {
+		emit.Emit(core.Warn("run", "Previous app not stopped: "+err.Error()))
+	}
+	forgetSession(projectRoot, target)
+	return ctx.Err()
+}
+
+// appendHotRerunFailure summarizes a failed hot rerun in the console. When
+// it failed before replacing the app (e.g. the build), the old app is still
+// running.
+func (m *Model) appendHotRerunFailure(err error, replaced bool) {
+	n := m.tabView.IssuesTab.countByType(IssueTypeError)
+	summary := "[xcbolt] Rebuild failed"
+	if replaced {
+		summary = "[xcbolt] Relaunch failed"
+	}
+	switch {
+	case n == 1:
+		summary += " (1 error)"
+	case n > 1:
+		summary += fmt.Sprintf(" (%d errors)", n)
+	}
+	if !replaced {
+		summary += " · the previous app is still running"
+	}
+	m.appendConsoleLog(consoleSystemPrefix+summary, "")
+	shown := 0
+	for _, issue := range m.tabView.IssuesTab.Issues {
+		if issue.Type != IssueTypeError || shown == hotRerunErrorLines {
+			continue
+		}
+		shown++
+		loc := ""
+		if issue.File != "" {
+			loc = fmt.Sprintf("%s:%d: ", filepath.Base(issue.File), issue.Line)
+		}
+		m.appendConsoleLog(consoleSystemPrefix+"  "+loc+issue.Message, "")
+	}
+	if n == 0 && err != nil {
+		m.appendConsoleLog(consoleSystemPrefix+"  "+err.Error(), "")
+	}
+}
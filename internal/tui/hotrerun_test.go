@@ -0,0 +1,140 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func TestHotRerunTargetMatchesDestination(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.cfg.Destination = core.Destination{Kind: core.DestSimulator, UDID: "SIM-A"}
+	m.lastRun = core.RunResult{BundleID: "com.example.App", PID: 42, Target: "simulator", UDID: "SIM-A"}
+	if target, ok := m.hotRerunTarget(); !ok || target.PID != 42 {
+		t.Fatalf("last run on this simulator: %+v %v", target, ok)
+	}
+
+	m.cfg.Destination = core.Destination{Kind: core.DestSimulator, UDID: "SIM-B"}
+	if _, ok := m.hotRerunTarget(); ok {
+		t.Fatal("last run on another simulator matched")
+	}
+	sess, err := core.AddSessionWithDestination(m.projectRoot, "com.example.App", 7, core.Destination{Kind: core.DestSimulator, UDID: "SIM-B"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if target, ok := m.hotRerunTarget(); !ok || target.SessionID != sess.ID || target.PID != 7 {
+		t.Fatalf("session on this simulator: %+v %v", target, ok)
+	}
+}
+
+func TestHotRerunKeepsConsoleAndReportsFailedBuild(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.runMode.Active = true
+	m.appendConsoleLog("old session output", "")
+	m.hotStop = &stopTarget{BundleID: "com.example.App", PID: 42, Target: "simulator", UDID: "SIM-A"}
+
+	m.startOp("run")
+	console := strings.Join(m.runMode.Console.Lines, "\n")
+	if !strings.Contains(console, "old session output") || !strings.Contains(console, "hot rerun") {
+		t.Fatalf("console after hot rerun start:\n%s", console)
+	}
+	if m.hotStop != nil || !m.hotRunning {
+		t.Fatalf("hotStop = %v, hotRunning = %v", m.hotStop, m.hotRunning)
+	}
+
+	m.tabView.IssuesTab.AddParsedIssue(Issue{Type: IssueTypeError, Message: "cannot find 'foo' in scope", File: "/p/App.swift", Line: 12})
+	m.handleOpDone(opDoneMsg{cmd: "run", err: errors.New("exit status 65"), run: &core.RunResult{}})
+	console = strings.Join(m.runMode.Console.Lines, "\n")
+	if !strings.Contains(console, "Rebuild failed (1 error) · the previous app is still running") {
+		t.Fatalf("missing failure summary:\n%s", console)
+	}
+	if !strings.Contains(console, "App.swift:12: cannot find 'foo' in scope") {
+		t.Fatalf("missing error line:\n%s", console)
+	}
+	if !strings.Contains(console, "old session output") {
+		t.Fatal("old console output was cleared")
+	}
+}
+
+func TestPlainRunClearsConsole(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.appendConsoleLog("old session output", "")
+	m.startOp("run")
+	if len(m.runMode.Console.Lines) != 0 || m.hotRunning {
+		t.Fatalf("console = %q, hotRunning = %v", m.runMode.Console.Lines, m.hotRunning)
+	}
+}
+
+func TestHotRerunKeepsAttachedRunUntilLaunch(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.cfg.Destination = core.Destination{Kind: core.DestSimulator, UDID: "SIM-A"}
+	// An attached run whose app is up
+	canceled := 0
+	events := &chanEmitter{ch: make(chan core.Event, 8), stop: make(chan struct{})}
+	m.running, m.runningCmd, m.opSeq = true, "run", 1
+	m.cancelFn = func() { canceled++ }
+	m.events, m.eventStopCh = events, make(chan struct{})
+	m.runMode.Active = true
+	launched := core.Status("run", "App launched", map[string]any{"pid": 42, "bundleId": "com.example.App", "target": "simulator", "udid": "SIM-A"})
+	launched.Code = core.AppLaunchedCode
+	next, _ := m.Update(eventsMsg{events: []core.Event{launched}, from: events})
+	m = next.(Model)
+	if target, ok := m.attachedRunTarget(); !ok || target.PID != 42 {
+		t.Fatalf("attached app = %+v %v", target, ok)
+	}
+
+	m.hotRerun()
+	if canceled != 0 || m.replacedRun == nil || !m.hotRunning || m.hotStop != nil {
+		t.Fatalf("canceled = %d, replaced = %v, hotRunning = %v", canceled, m.replacedRun, m.hotRunning)
+	}
+	output := core.Log("run", "old app still talking")
+	output.Data = map[string]any{"stream": "app"}
+	next, _ = m.Update(eventsMsg{events: []core.Event{output}, from: events})
+	m = next.(Model)
+	if !strings.Contains(strings.Join(m.runMode.Console.Lines, "\n"), "old app still talking") {
+		t.Fatalf("replaced app output not shown:\n%s", strings.Join(m.runMode.Console.Lines, "\n"))
+	}
+
+	// The rebuild fails: the old run is current again, app and all
+	next, _ = m.Update(opDoneMsg{cmd: "run", err: errors.New("exit status 65"), run: &core.RunResult{}, seq: m.opSeq})
+	m = next.(Model)
+	if m.replacedRun != nil || !m.running || m.runningCmd != "run" || m.runApp == nil || m.runApp.PID != 42 {
+		t.Fatalf("replaced run not resumed: running = %v, runApp = %+v", m.running, m.runApp)
+	}
+	if !strings.Contains(strings.Join(m.runMode.Console.Lines, "\n"), "the previous app is still running") {
+		t.Fatal("missing failure summary")
+	}
+	m.stopOrCancelOp()
+	if canceled != 1 {
+		t.Fatalf("resumed run not canceled by x: %d", canceled)
+	}
+}
+
+func TestReplacedRunEndsBeforeLaunch(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	exited := make(chan struct{})
+	m.running, m.runningCmd, m.opSeq = true, "run", 1
+	m.cancelFn = func() { close(exited) }
+	m.eventStopCh, m.opExited = make(chan struct{}), exited
+	m.detachRun(stopTarget{BundleID: "com.example.App", PID: 42, Target: "simulator", UDID: "SIM-A"})
+	r := m.replacedRun
+	if err := r.end(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Its result only closes it out; the new run stays current
+	m.running, m.runningCmd, m.opSeq = true, "run", 2
+	next, _ := m.Update(opDoneMsg{cmd: "run", err: context.Canceled, seq: r.seq})
+	m = next.(Model)
+	if m.replacedRun != nil || !m.running {
+		t.Fatalf("replaced = %v, running = %v", m.replacedRun, m.running)
+	}
+}
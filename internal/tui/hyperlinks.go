@@ -0,0 +1,142 @@
+package tui
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// =============================================================================
+// OSC 8 Hyperlinks
+// =============================================================================
+
+// locationRE matches an absolute or relative file path with a line number
+// (path:line or path:line:col). The path needs an alphabetic extension so
+// times and versions ("12:30", "1.2:3") don't match.
+var locationRE = regexp.MustCompile(`((?:\.{1,2}/|/)?[\w.+@-]+(?:/[\w.+@-]+)*\.[A-Za-z][A-Za-z0-9]*):(\d+)(?::(\d+))?`)
+
+// Linker wraps file locations in OSC 8 hyperlinks. A nil *Linker leaves
+// text unchanged.
+type Linker struct {
+	Template string // URL with {path}, {line}, {col}
+	Root     string // Resolves project-relative paths
+}
+
+// newLinker returns the linker for the TUI config, or nil when hyperlinks
+// are off (tui.hyperlinks false, or unset on an unsupported terminal)
+func newLinker(cfg core.TUIConfig, projectRoot string) *Linker {
+	enabled := DetectHyperlinks()
+	if cfg.Hyperlinks != nil {
+		enabled = *cfg.Hyperlinks
+	}
+	if !enabled {
+		return nil
+	}
+	tmpl := cfg.HyperlinkTemplate
+	if tmpl == "" {
+		tmpl = core.DefaultHyperlinkTemplate
+	}
+	return &Linker{Template: tmpl, Root: projectRoot}
+}
+
+// DetectHyperlinks checks if the terminal likely renders OSC 8 links
+func DetectHyperlinks() bool {
+	switch os.Getenv("XCBOLT_HYPERLINKS") {
+	case "1":
+		return true
+	case "0":
+		return false
+	}
+
+	term := os.Getenv("TERM")
+	termProgram := os.Getenv("TERM_PROGRAM")
+
+	hyperlinkTerminals := []string{
+		"iTerm",
+		"WezTerm",
+		"ghostty",
+		"kitty",
+		"vscode",
+	}
+	for _, t := range hyperlinkTerminals {
+		if strings.Contains(strings.ToLower(termProgram), strings.ToLower(t)) || strings.Contains(term, t) {
+			return true
+		}
+	}
+
+	// GNOME Terminal and other VTE terminals since 0.50
+	if v, err := strconv.Atoi(os.Getenv("VTE_VERSION")); err == nil && v >= 5000 {
+		return true
+	}
+
+	// Unknown terminals may print the escapes literally
+	return false
+}
+
+// URL fills the template for a location
+func (l *Linker) URL(path string, line, col int) string {
+	if !filepath.IsAbs(path) && l.Root != "" {
+		path = filepath.Join(l.Root, path)
+	}
+	u := strings.ReplaceAll(l.Template, "{path}", (&url.URL{Path: filepath.ToSlash(path)}).EscapedPath())
+	u = strings.ReplaceAll(u, "{line}", strconv.Itoa(line))
+	return strings.ReplaceAll(u, "{col}", strconv.Itoa(max(col, 1)))
+}
+
+// Wrap links display to the location
+func (l *Linker) Wrap(display, path string, line, col int) string {
+	if l == nil || path == "" {
+		return display
+	}
+	return osc8(l.URL(path, line, col), display)
+}
+
+// WrapLocation links display when loc is a path:line[:col] location
+func (l *Linker) WrapLocation(display, loc string) string {
+	if l == nil {
+		return display
+	}
+	m := locationRE.FindStringSubmatch(loc)
+	if m == nil || m[0] != loc {
+		return display
+	}
+	line, _ := strconv.Atoi(m[2])
+	col, _ := strconv.Atoi(m[3])
+	return l.Wrap(display, m[1], line, col)
+}
+
+// Render styles text, linking every file location in it
+func (l *Linker) Render(text string, style lipgloss.Style) string {
+	if l == nil {
+		return style.Render(text)
+	}
+	matches := locationRE.FindAllStringIndex(text, -1)
+	if len(matches) == 0 {
+		return style.Render(text)
+	}
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		if m[0] > last {
+			b.WriteString(style.Render(text[last:m[0]]))
+		}
+		b.WriteString(l.WrapLocation(style.Render(text[m[0]:m[1]]), text[m[0]:m[1]]))
+		last = m[1]
+	}
+	if last < len(text) {
+		b.WriteString(style.Render(text[last:]))
+	}
+	return b.String()
+}
+
+// osc8 wraps text in an OSC 8 hyperlink to target
+func osc8(target, text string) string {
+	return "\x1b]8;;" + target + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func TestLinkerRenderWrapsLocations(t *testing.T) {
+	l := &Linker{Template: "vscode://file{path}:{line}:{col}", Root: "/proj"}
+	got := l.Render("Sources/App.swift:42:7: error: boom", lipgloss.NewStyle())
+	want := "\x1b]8;;vscode://file/proj/Sources/App.swift:42:7\x1b\\Sources/App.swift:42:7\x1b]8;;\x1b\\: error: boom"
+	if got != want {
+		t.Fatalf("Render = %q, want %q", got, want)
+	}
+	if got := l.Render("took 12:30 on v1.2:3", lipgloss.NewStyle()); strings.Contains(got, "\x1b]8") {
+		t.Fatalf("times and versions should not be linked: %q", got)
+	}
+
+	file := &Linker{Template: core.DefaultHyperlinkTemplate}
+	if got := file.URL("/a b/App.swift", 3, 0); got != "file:///a%20b/App.swift" {
+		t.Fatalf("URL = %q", got)
+	}
+}
+
+func TestLinkerDisabledFallsBackToPlainText(t *testing.T) {
+	t.Setenv("XCBOLT_HYPERLINKS", "1")
+	off := false
+	if l := newLinker(core.TUIConfig{Hyperlinks: &off}, "/proj"); l != nil {
+		t.Fatalf("tui.hyperlinks=false should disable links")
+	}
+	var l *Linker
+	if got := l.Render("/p/App.swift:1: error: x", lipgloss.NewStyle()); got != "/p/App.swift:1: error: x" {
+		t.Fatalf("nil linker should not add escapes: %q", got)
+	}
+	t.Setenv("XCBOLT_HYPERLINKS", "0")
+	if l := newLinker(core.TUIConfig{}, "/proj"); l != nil {
+		t.Fatalf("XCBOLT_HYPERLINKS=0 should disable detection")
+	}
+}
+
+func TestStreamTabLinksButCopiesPlainText(t *testing.T) {
+	st := NewStreamTab()
+	st.SetSize(200, 10)
+	st.ShowLineNumbers = false
+	st.Linker = &Linker{Template: core.DefaultHyperlinkTemplate}
+	st.AddLine("/p/Sources/App.swift:42:7: error: boom", TabLineTypeError)
+	st.AddLine("Compiling /p/Sources/View.swift:10", TabLineTypeNormal)
+
+	view := st.View(DefaultStyles())
+	for _, want := range []string{
+		"\x1b]8;;file:///p/Sources/App.swift\x1b\\",
+		"\x1b]8;;file:///p/Sources/View.swift\x1b\\",
+	} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("view missing link %q:\n%q", want, view)
+		}
+	}
+	if content := st.GetVisibleContent(); strings.Contains(content, "\x1b") {
+		t.Fatalf("copied text should be plain: %q", content)
+	}
+
+	st.Linker = nil
+	if view := st.View(DefaultStyles()); strings.Contains(view, "\x1b]8") {
+		t.Fatalf("links should be off without a linker: %q", view)
+	}
+}
+
+func TestIssuesTabLinksLocation(t *testing.T) {
+	it := NewIssuesTab()
+	it.SetSize(200, 20)
+	it.Linker = &Linker{Template: "xcode://{path}?line={line}"}
+	it.AddIssue(IssueTypeError, "/p/Sources/App.swift:42:7: error: boom")
+	if view := it.View(DefaultStyles()); !strings.Contains(view, "\x1b]8;;xcode:///p/Sources/App.swift?line=42\x1b\\") {
+		t.Fatalf("issue location should be linked:\n%q", view)
+	}
+}
@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// =============================================================================
+// Per-Architecture Duplicates
+// =============================================================================
+
+// archCommandRE finds the architecture of an xcodebuild command header
+// ("SwiftCompile normal arm64 /path/File.swift (in target ...)")
+var archCommandRE = regexp.MustCompile(`^[A-Z]\w+ .*\bnormal (arm64e?|arm64_32|x86_64|i386|armv7k)\b`)
+
+// archNoteRE finds the architecture of a "note: building for ..." line
+var archNoteRE = regexp.MustCompile(`(?i)^note: building for .*?\b(arm64e?|arm64_32|x86_64|i386|armv7k)\b`)
+
+// lineArchitecture is the architecture a log line starts compiling or
+// linking for ("" if it doesn't)
+func lineArchitecture(line string) string {
+	if m := archCommandRE.FindStringSubmatch(line); m != nil {
+		return m[1]
+	}
+	if m := archNoteRE.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// observeArchitecture tracks the architecture of the command whose output
+// is being read
+func (it *IssuesTab) observeArchitecture(line string) {
+	if arch := lineArchitecture(line); arch != "" {
+		it.arch = arch
+	}
+}
+
+// mergeArchDuplicate folds issue into an identical one (same type,
+// location, and message) reported for another architecture. Its line and
+// continuation are appended to the existing FullText, prefixed with the
+// architecture. Reports whether it was merged.
+func (it *IssuesTab) mergeArchDuplicate(issue Issue) bool {
+	if it.arch == "" || issue.File == "" {
+		return false
+	}
+	dup := it.archDuplicate(issue)
+	if dup == nil {
+		return false
+	}
+	dup.Architectures = append(dup.Architectures, it.arch)
+	dup.FullText += "\n[" + it.arch + "] " + issue.FullText
+	it.lastSeq = dup.seq
+	it.caret.start(dup.File, dup.Line)
+	it.cont.Start(dup.File, dup.Line)
+	return true
+}
+
+func (it *IssuesTab) archDuplicate(issue Issue) *Issue {
+	for _, list := range [][]Issue{it.Issues, it.hidden, it.muted} {
+		for i := range list {
+			d := &list[i]
+			if d.Type == issue.Type && d.File == issue.File && d.Line == issue.Line && d.Column == issue.Column &&
+				d.Message == issue.Message && len(d.Architectures) > 0 && !slices.Contains(d.Architectures, it.arch) {
+				return d
+			}
+		}
+	}
+	return nil
+}
+
+// archSuffix is the list suffix of an issue reported for several
+// architectures ("(arm64, x86_64)")
+func archSuffix(issue Issue) string {
+	if len(issue.Architectures) < 2 {
+		return ""
+	}
+	return "(" + strings.Join(issue.Architectures, ", ") + ")"
+}
@@ -0,0 +1,92 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func TestUniversalBuildWarningsCollapsePerArchitecture(t *testing.T) {
+	tv := NewTabView()
+	for _, line := range loadFixtureLines(t, "universal_macos_warnings.txt") {
+		tv.AddRawLine(line)
+	}
+
+	if tv.Counts.WarningCount != 3 {
+		t.Fatalf("expected 3 collapsed warnings, got %d", tv.Counts.WarningCount)
+	}
+	issues := tv.IssuesTab.Issues
+	if len(issues) != 3 {
+		t.Fatalf("expected 3 issues, got %d: %+v", len(issues), issues)
+	}
+
+	unused := issues[0]
+	if !strings.Contains(unused.Message, "'draft' was never used") {
+		t.Fatalf("unexpected first issue: %+v", unused)
+	}
+	if got := strings.Join(unused.Architectures, ","); got != "arm64,x86_64" {
+		t.Fatalf("expected arm64,x86_64, got %q", got)
+	}
+	if !strings.Contains(unused.FullText, "\n[x86_64] /Users/dev/Notes/Notes/NoteStore.swift:42:13: warning:") ||
+		strings.Count(unused.FullText, "42 |         let draft = note.title") != 2 {
+		t.Fatalf("per-architecture detail lost:\n%s", unused.FullText)
+	}
+	if strings.Contains(unused.FullText, "synchronize") {
+		t.Fatalf("x86_64 excerpt of another warning was folded in:\n%s", unused.FullText)
+	}
+
+	intel := issues[2]
+	if len(intel.Architectures) != 1 || intel.Architectures[0] != "x86_64" || archSuffix(intel) != "" {
+		t.Fatalf("x86_64-only warning should stay single-arch: %+v", intel)
+	}
+
+	tv.IssuesTab.SetSize(200, 20)
+	view := stripANSI(tv.IssuesTab.View(DefaultStyles()))
+	if !strings.Contains(view, "(arm64, x86_64)") {
+		t.Fatalf("expected the architecture suffix in the list:\n%s", view)
+	}
+}
+
+func TestUniversalBuildCountsOnDashboard(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	for _, line := range loadFixtureLines(t, "universal_macos_warnings.txt") {
+		m.handleEvent(core.LogRaw("build", line))
+	}
+	m.tabView.SetBuildResult(BuildStatusSuccess, "1s", nil)
+	if m.tabView.SummaryTab.WarningCount != 3 {
+		t.Fatalf("Dashboard should count collapsed warnings, got %d", m.tabView.SummaryTab.WarningCount)
+	}
+}
+
+func TestSameArchitectureRepeatsStaySeparate(t *testing.T) {
+	tv := NewTabView()
+	warning := "/p/Shared.h:3:1: warning: declaration is not a prototype"
+	for _, line := range []string{
+		"CompileC /d/A.o /p/A.m normal arm64 objective-c com.apple.compilers.llvm.clang.1_0.compiler (in target 'App' from project 'App')",
+		warning,
+		"CompileC /d/B.o /p/B.m normal arm64 objective-c com.apple.compilers.llvm.clang.1_0.compiler (in target 'App' from project 'App')",
+		warning,
+	} {
+		tv.AddRawLine(line)
+	}
+	if tv.Counts.WarningCount != 2 || len(tv.IssuesTab.Issues) != 2 {
+		t.Fatalf("a header warning from two files of one slice is two warnings: %d/%d", tv.Counts.WarningCount, len(tv.IssuesTab.Issues))
+	}
+}
+
+func TestLineArchitecture(t *testing.T) {
+	cases := map[string]string{
+		"SwiftCompile normal arm64 Compiling\\ A.swift /p/A.swift (in target 'A' from project 'A')":    "arm64",
+		"CompileC /d/A.o /p/A.m normal x86_64 objective-c com.apple.compilers.llvm.clang.1_0.compiler": "x86_64",
+		"note: Building for macOS-arm64e":    "arm64e",
+		"Ld /d/App normal (in target 'App')": "",
+		"    cd /Users/dev/normal arm64":     "",
+	}
+	for line, want := range cases {
+		if got := lineArchitecture(line); got != want {
+			t.Fatalf("lineArchitecture(%q) = %q, want %q", line, got, want)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// =============================================================================
+// Warning Categories
+// =============================================================================
+
+// builtinWarningClassifier classifies warnings when no config is applied
+var builtinWarningClassifier, _ = core.NewWarningClassifier(core.IssuesConfig{})
+
+func (it *IssuesTab) classifier() *core.WarningClassifier {
+	if it.Classifier == nil {
+		return builtinWarningClassifier
+	}
+	return it.Classifier
+}
+
+// shows reports whether issue passes the category filter. Errors always do.
+func (it *IssuesTab) shows(issue Issue) bool {
+	if it.CategoryFilter == "" || issue.Type == IssueTypeError {
+		return true
+	}
+	return issue.Type == IssueTypeWarning && issue.Category == it.CategoryFilter
+}
+
+// AllIssues returns the listed and filtered-out issues, errors first
+func (it *IssuesTab) AllIssues() []Issue {
+	all := make([]Issue, 0, len(it.Issues)+len(it.hidden))
+	all = append(all, it.Issues...)
+	all = append(all, it.hidden...)
+	sortBySeverity(all)
+	return all
+}
+
+// SetCategoryFilter lists only errors and warnings of category ("" lists
+// everything) and moves the selection to the top
+func (it *IssuesTab) SetCategoryFilter(category string) {
+	it.CategoryFilter = category
+	it.refilter()
+	it.GotoTop()
+}
+
+// refilter re-partitions the issues after the filter, categories, or
+// muted phases changed
+func (it *IssuesTab) refilter() {
+	all := append(it.AllIssues(), it.muted...)
+	sortBySeverity(all)
+	it.Issues = it.Issues[:0]
+	it.hidden = nil
+	it.muted = nil
+	for _, issue := range all {
+		switch {
+		case it.muter.hides(issue.Phase):
+			it.muted = append(it.muted, issue)
+		case it.shows(issue):
+			it.Issues = append(it.Issues, issue)
+		default:
+			it.hidden = append(it.hidden, issue)
+		}
+	}
+	if it.Selected >= len(it.Issues) {
+		it.Selected = max(0, len(it.Issues)-1)
+	}
+	it.ScrollPos = min(it.ScrollPos, it.Selected)
+}
+
+// SetClassifier re-classifies the warnings with c (e.g. after the config
+// changed)
+func (it *IssuesTab) SetClassifier(c *core.WarningClassifier) {
+	it.Classifier = c
+	for _, list := range [][]Issue{it.Issues, it.hidden, it.muted} {
+		for i := range list {
+			if list[i].Type == IssueTypeWarning {
+				list[i].Category = c.Classify(list[i].Message)
+			}
+		}
+	}
+	it.refilter()
+}
+
+// CategoryCounts counts warnings per category, listed or filtered out
+func (it *IssuesTab) CategoryCounts() map[string]int {
+	counts := map[string]int{}
+	for _, list := range [][]Issue{it.Issues, it.hidden} {
+		for _, issue := range list {
+			if issue.Type == IssueTypeWarning {
+				counts[issue.Category]++
+			}
+		}
+	}
+	return counts
+}
+
+// NextCategory is the filter after the current one: the next category with
+// warnings, then "" (all) after the last
+func (it *IssuesTab) NextCategory() string {
+	counts := it.CategoryCounts()
+	categories := it.classifier().Categories()
+	start := 0
+	for i, c := range categories {
+		if c == it.CategoryFilter {
+			start = i + 1
+		}
+	}
+	for _, c := range categories[start:] {
+		if counts[c] > 0 {
+			return c
+		}
+	}
+	return ""
+}
+
+// categoryBreakdown is the header's per-category count, largest first
+// ("deprecated 800 · unused 7 · concurrency 5")
+func (it *IssuesTab) categoryBreakdown() string {
+	counts := it.CategoryCounts()
+	if len(counts) < 2 && counts[core.WarningCategoryOther] > 0 {
+		return "" // Nothing classified: the total says it all
+	}
+	categories := append([]string(nil), it.classifier().Categories()...)
+	sort.SliceStable(categories, func(i, j int) bool { return counts[categories[i]] > counts[categories[j]] })
+	var parts []string
+	for _, c := range categories {
+		if counts[c] > 0 {
+			parts = append(parts, fmt.Sprintf("%s %d", core.WarningCategoryLabel(c), counts[c]))
+		}
+	}
+	return strings.Join(parts, " · ")
+}
+
+// sortBySeverity orders issues like sortIssues, keeping insertion order
+// within a severity
+func sortBySeverity(issues []Issue) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Type != issues[j].Type {
+			return issues[i].Type < issues[j].Type
+		}
+		return issues[i].seq < issues[j].seq
+	})
+}
+
+// cycleIssueCategory switches the Issues tab to the next warning category
+// and remembers it for the project
+func (m *Model) cycleIssueCategory() {
+	it := m.tabView.VisibleIssuesTab()
+	if it.countByType(IssueTypeWarning) == 0 && it.CategoryFilter == "" {
+		m.setStatus("No warnings to filter")
+		return
+	}
+	next := it.NextCategory()
+	m.tabView.SetCategoryFilter(next)
+	m.state.SetIssueFilter(m.projectRoot, next)
+	m.saveStateAsync()
+	if next == "" {
+		m.setStatus("Issues: all warnings")
+		return
+	}
+	m.setStatus(fmt.Sprintf("Issues: %s warnings (%d)", core.WarningCategoryLabel(next), it.CategoryCounts()[next]))
+}
+
+// applyIssueCategories builds the warning classifier from the config
+func (m *Model) applyIssueCategories() {
+	c, errs := core.NewWarningClassifier(m.cfg.Issues)
+	m.tabView.SetClassifier(c)
+	if len(errs) > 0 {
+		m.setStatus(errs[0].Error())
+	}
+}
+
+// issueJSON is one issue of the Issues tab as copied by copyIssuesJSON
+type issueJSON struct {
+	Type          string   `json:"type"`
+	Category      string   `json:"category,omitempty"`
+	Message       string   `json:"message"`
+	File          string   `json:"file,omitempty"`
+	Line          int      `json:"line,omitempty"`
+	Column        int      `json:"column,omitempty"`
+	Architectures []string `json:"architectures,omitempty"`
+}
+
+// issuesJSON encodes every issue (including filtered-out ones)
+func issuesJSON(issues []Issue) (string, error) {
+	out := make([]issueJSON, 0, len(issues))
+	for _, issue := range issues {
+		if issue.TestList != "" {
+			continue
+		}
+		out = append(out, issueJSON{
+			Type:          issue.Type.String(),
+			Category:      issue.Category,
+			Message:       issue.Message,
+			File:          issue.File,
+			Line:          issue.Line,
+			Column:        issue.Column,
+			Architectures: issue.Architectures,
+		})
+	}
+	b, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func (m *Model) copyIssuesJSON() tea.Cmd {
+	issues := m.tabView.VisibleIssuesTab().AllIssues()
+	if len(issues) == 0 {
+		m.setStatus("No issues to copy")
+		return nil
+	}
+	text, err := issuesJSON(issues)
+	if err != nil {
+		m.setStatus("Copy failed: " + err.Error())
+		return nil
+	}
+	return m.copyToClipboard(text, "Copied issues as JSON")
+}
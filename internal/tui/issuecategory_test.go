@@ -0,0 +1,132 @@
+package tui
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func addCategoryIssues(it *IssuesTab) {
+	it.AddIssue(IssueTypeWarning, "/src/A.swift:1:1: warning: 'keyWindow' was deprecated in iOS 13.0")
+	it.AddIssue(IssueTypeWarning, "/src/A.swift:2:1: warning: 'foo()' is deprecated: use bar()")
+	it.AddIssue(IssueTypeError, "/src/B.swift:3:1: error: cannot find 'x' in scope")
+	it.AddIssue(IssueTypeWarning, "/src/C.swift:4:1: warning: initialization of immutable value 'y' was never used")
+	it.AddIssue(IssueTypeWarning, "/src/C.swift:5:1: warning: capture of 'self' with non-sendable type 'M'")
+}
+
+func TestIssuesTabClassifiesWarnings(t *testing.T) {
+	it := NewIssuesTab()
+	addCategoryIssues(it)
+
+	counts := it.CategoryCounts()
+	if counts[core.WarningCategoryDeprecation] != 2 || counts[core.WarningCategoryUnused] != 1 || counts[core.WarningCategoryConcurrency] != 1 {
+		t.Fatalf("counts = %v", counts)
+	}
+	if got, want := it.categoryBreakdown(), "deprecated 2 · unused 1 · concurrency 1"; got != want {
+		t.Fatalf("breakdown = %q, want %q", got, want)
+	}
+	for _, issue := range it.Issues {
+		if issue.Type == IssueTypeError && issue.Category != "" {
+			t.Fatalf("error got category %q", issue.Category)
+		}
+	}
+}
+
+func TestIssuesTabCategoryFilter(t *testing.T) {
+	it := NewIssuesTab()
+	addCategoryIssues(it)
+
+	var seen []string
+	for {
+		next := it.NextCategory()
+		it.SetCategoryFilter(next)
+		seen = append(seen, next)
+		if next == "" || len(seen) > 10 {
+			break
+		}
+	}
+	want := []string{core.WarningCategoryDeprecation, core.WarningCategoryUnused, core.WarningCategoryConcurrency, ""}
+	if len(seen) != len(want) {
+		t.Fatalf("cycle = %v, want %v", seen, want)
+	}
+	for i := range want {
+		if seen[i] != want[i] {
+			t.Fatalf("cycle = %v, want %v", seen, want)
+		}
+	}
+
+	it.SetCategoryFilter(core.WarningCategoryUnused)
+	if len(it.Issues) != 2 || it.Issues[0].Type != IssueTypeError || it.Issues[1].Category != core.WarningCategoryUnused {
+		t.Fatalf("filtered issues = %+v", it.Issues)
+	}
+	if it.countByType(IssueTypeWarning) != 4 {
+		t.Fatalf("header count should include filtered-out warnings, got %d", it.countByType(IssueTypeWarning))
+	}
+
+	// Issues arriving while filtered still classify, and continuation
+	// lines attach to hidden issues rather than the visible ones.
+	it.AddIssue(IssueTypeWarning, "/src/D.swift:6:1: warning: 'old' is deprecated")
+	it.ObserveLine(`fix-it:"/src/D.swift":{6:1-6:4}:"new"`)
+	if len(it.Issues) != 2 {
+		t.Fatalf("deprecation warning should stay hidden, got %+v", it.Issues)
+	}
+	it.SetCategoryFilter(core.WarningCategoryDeprecation)
+	var fixed bool
+	for _, issue := range it.Issues {
+		if issue.File == "/src/D.swift" {
+			fixed = len(issue.FixIts) == 1
+		}
+	}
+	if !fixed {
+		t.Fatalf("fix-it not attached to the hidden warning: %+v", it.Issues)
+	}
+
+	it.SetCategoryFilter("")
+	if len(it.Issues) != 6 || it.Issues[0].Type != IssueTypeError {
+		t.Fatalf("unfiltered issues = %+v", it.Issues)
+	}
+}
+
+func TestIssuesTabCustomCategories(t *testing.T) {
+	it := NewIssuesTab()
+	addCategoryIssues(it)
+	it.AddIssue(IssueTypeWarning, "/src/E.swift:1:1: warning: Line too long (line_length_rule)")
+
+	c, errs := core.NewWarningClassifier(core.IssuesConfig{CategoryPatterns: map[string][]string{"swiftlint": {`_rule\)`}}})
+	if len(errs) != 0 {
+		t.Fatal(errs)
+	}
+	it.SetCategoryFilter(core.WarningCategoryOther)
+	it.SetClassifier(c)
+	if len(it.Issues) != 1 {
+		t.Fatalf("reclassified warning should leave 'other', got %+v", it.Issues)
+	}
+	if it.CategoryCounts()["swiftlint"] != 1 {
+		t.Fatalf("counts = %v", it.CategoryCounts())
+	}
+}
+
+func TestIssuesJSONIncludesCategory(t *testing.T) {
+	it := NewIssuesTab()
+	addCategoryIssues(it)
+	it.SetCategoryFilter(core.WarningCategoryUnused)
+
+	text, err := issuesJSON(it.AllIssues())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out []issueJSON
+	if err := json.Unmarshal([]byte(text), &out); err != nil {
+		t.Fatal(err)
+	}
+	if len(out) != 5 {
+		t.Fatalf("expected filtered-out issues too, got %d", len(out))
+	}
+	if out[0].Type != "error" || out[0].Category != "" {
+		t.Fatalf("first = %+v", out[0])
+	}
+	if out[1].Category != core.WarningCategoryDeprecation || out[1].File != "/src/A.swift" || out[1].Line != 1 {
+		t.Fatalf("second = %+v", out[1])
+	}
+}
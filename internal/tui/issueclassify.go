@@ -3,92 +3,102 @@ package tui
 import (
 	"regexp"
 	"strings"
+
+	"github.com/xcbolt/xcbolt/internal/core"
 )
 
+// Diagnostic continuation patterns (swiftc/clang)
 var (
-	fileErrorRE = regexp.MustCompile(`(?i)^[^:\s].*:\d+(?::\d+)?:\s*(fatal error|error):`)
+	diagGutterRE = regexp.MustCompile(`^\s*\d*\s*\|`)
+	diagCaretRE  = regexp.MustCompile(`^\s*[\^~][\s\^~]*$`)
 )
 
-func issueSeverity(line string) TabLineType {
-	lower := strings.ToLower(line)
+// diagContinuation tracks whether lines following a file:line:col diagnostic
+// belong to it: source excerpts, caret lines, fix-it replacements and
+// same-location (or unlocated) notes.
+type diagContinuation struct {
+	open         bool
+	file         string
+	line         int
+	expectSource bool   // next plain line may be an old-style source excerpt
+	pending      string // excerpt waiting for its caret line
+	afterCaret   bool
+}
 
-	if strings.Contains(lower, "warning:") || strings.Contains(line, "⚠") {
-		return TabLineTypeWarning
-	}
-	if strings.Contains(lower, "note:") || strings.Contains(lower, "remark:") {
-		return TabLineTypeNote
+// Start opens a block for a diagnostic at file:line (closes it when unlocated).
+func (c *diagContinuation) Start(file string, line int) {
+	if file == "" || line <= 0 {
+		*c = diagContinuation{}
+		return
 	}
+	*c = diagContinuation{open: true, file: file, line: line, expectSource: true}
+}
 
-	if isFatalBuildErrorLine(lower, line) {
-		return TabLineTypeError
+// StartAt opens a block when text is a file:line:col diagnostic.
+func (c *diagContinuation) StartAt(text string) {
+	if d := core.ClassifyDiagnostic(text); d.Located() && d.Severity != core.DiagnosticNone {
+		c.Start(d.File, d.Line)
 	}
+}
 
-	if !isErrorishLine(lower, line) {
-		return TabLineTypeNormal
+// Continues reports whether text belongs to the open diagnostic. Old-style
+// excerpts are only known once their caret arrives, so a buffered excerpt
+// line is returned alongside the caret.
+func (c *diagContinuation) Continues(text string) (bool, string) {
+	if !c.open || strings.TrimSpace(text) == "" {
+		return false, ""
+	}
+	d := core.ClassifyDiagnostic(text)
+	if d.IsPretty {
+		// Formatter output arrives interleaved with the raw block
+		return false, ""
+	}
+	if d.Located() && d.Severity != core.DiagnosticNone {
+		if d.Severity == core.DiagnosticNote && d.File == c.file && d.Line == c.line {
+			c.expectSource, c.pending, c.afterCaret = true, "", false
+			return true, ""
+		}
+		*c = diagContinuation{}
+		return false, ""
 	}
 
-	if fileErrorRE.MatchString(line) {
-		return TabLineTypeError
+	trimmed := strings.TrimSpace(text)
+	switch {
+	case diagGutterRE.MatchString(text):
+		c.expectSource, c.pending, c.afterCaret = false, "", false
+		return true, ""
+	case diagCaretRE.MatchString(text):
+		pending := c.pending
+		c.expectSource, c.pending, c.afterCaret = false, "", true
+		return true, pending
+	case strings.HasPrefix(trimmed, "note:") || strings.HasPrefix(trimmed, "fix-it:"):
+		c.expectSource, c.pending, c.afterCaret = true, "", false
+		return true, ""
+	case c.afterCaret && strings.HasPrefix(text, " "):
+		c.afterCaret = false
+		return true, ""
+	case c.expectSource:
+		c.expectSource, c.pending, c.afterCaret = false, text, false
+		return false, ""
 	}
-	return TabLineTypeWarning
+	*c = diagContinuation{}
+	return false, ""
 }
 
-func isErrorishLine(lower, line string) bool {
-	return strings.Contains(lower, "error:") ||
-		strings.Contains(lower, "fatal error") ||
-		strings.Contains(lower, "❌") ||
-		strings.Contains(line, "✗")
+// issueSeverity is the Logs/Issues line type of a log line
+func issueSeverity(line string) TabLineType {
+	return diagnosticLineType(core.ClassifyDiagnostic(line))
 }
 
-func isFatalBuildErrorLine(lower, line string) bool {
-	switch {
-	case strings.Contains(lower, "fatal error"):
-		return true
-	case strings.Contains(lower, "clang: error"):
-		return true
-	case strings.Contains(lower, "swiftc: error"):
-		return true
-	case strings.Contains(lower, "swift-frontend: error"):
-		return true
-	case strings.Contains(lower, "ld: error"):
-		return true
-	case strings.Contains(lower, "linker command failed"):
-		return true
-	case strings.Contains(lower, "command swiftcompile failed"):
-		return true
-	case strings.Contains(lower, "command compilec failed"):
-		return true
-	case strings.Contains(lower, "command link failed"):
-		return true
-	case strings.Contains(lower, "codesign error"):
-		return true
-	case strings.Contains(lower, "code signing") && strings.Contains(lower, "error"):
-		return true
-	case strings.Contains(lower, "provisioning profile") && strings.Contains(lower, "error"):
-		return true
-	case strings.Contains(lower, "no such module"):
-		return true
-	case strings.Contains(lower, "undefined symbols"):
-		return true
-	case strings.Contains(lower, "symbol(s) not found"):
-		return true
-	case strings.Contains(lower, "framework not found"):
-		return true
-	case strings.Contains(lower, "library not found"):
-		return true
-	case strings.Contains(lower, "failed with exit code"):
-		return true
-	case strings.Contains(lower, "build failed"):
-		return true
-	case strings.Contains(lower, "xcodebuild: error"):
-		return true
-	case strings.Contains(lower, "error: unable to find a destination"):
-		return true
-	case strings.Contains(lower, "error: no profiles"):
-		return true
-	case strings.Contains(lower, "error: exit status"):
-		return true
+// diagnosticLineType maps a classified line to its Logs/Issues line type
+func diagnosticLineType(d core.Diagnostic) TabLineType {
+	switch d.Severity {
+	case core.DiagnosticError:
+		return TabLineTypeError
+	case core.DiagnosticWarning:
+		return TabLineTypeWarning
+	case core.DiagnosticNote:
+		return TabLineTypeNote
 	}
-
-	return false
+	return TabLineTypeNormal
 }
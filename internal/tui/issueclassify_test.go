@@ -1,6 +1,13 @@
 package tui
 
-import "testing"
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
 
 func TestIssueSeverity(t *testing.T) {
 	tests := []struct {
@@ -12,7 +19,9 @@ func TestIssueSeverity(t *testing.T) {
 		{"fatal error: boom", TabLineTypeError},
 		{"clang: error: link failed", TabLineTypeError},
 		{"Sources/App.swift:12:5: error: no such module", TabLineTypeError},
-		{"error: something weird", TabLineTypeWarning},
+		{"error: something weird", TabLineTypeError},
+		{"/src/warning: dir/App.swift:3:1: error: boom", TabLineTypeError},
+		{"Test Case '-[AppTests testErrorHandling]' passed (0.001 seconds).", TabLineTypeNormal},
 		{"normal output", TabLineTypeNormal},
 	}
 	for _, tc := range tests {
@@ -22,3 +31,113 @@ func TestIssueSeverity(t *testing.T) {
 		}
 	}
 }
+
+func loadFixtureLines(t *testing.T, name string) []string {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	return strings.Split(strings.TrimRight(string(b), "\n"), "\n")
+}
+
+func TestMultiLineSwiftErrorsBecomeSingleIssues(t *testing.T) {
+	for _, fixture := range []string{"swiftc_generics.txt", "swiftc_generics_legacy.txt"} {
+		t.Run(fixture, func(t *testing.T) {
+			lines := loadFixtureLines(t, fixture)
+			tv := NewTabView()
+			for _, line := range lines {
+				tv.AddRawLine(line)
+			}
+
+			if tv.Counts.ErrorCount != 1 || tv.Counts.WarningCount != 0 {
+				t.Fatalf("expected 1 error and 0 warnings, got %d/%d", tv.Counts.ErrorCount, tv.Counts.WarningCount)
+			}
+			if len(tv.IssuesTab.Issues) != 2 {
+				t.Fatalf("expected error + unrelated note, got %d issues: %+v", len(tv.IssuesTab.Issues), tv.IssuesTab.Issues)
+			}
+
+			errIssue := tv.IssuesTab.Issues[0]
+			if errIssue.Type != IssueTypeError || !strings.Contains(errIssue.Message, "generic parameter 'Model'") {
+				t.Fatalf("unexpected first issue: %+v", errIssue)
+			}
+			if n := len(strings.Split(errIssue.FullText, "\n")); n < 6 {
+				t.Fatalf("expected the excerpt folded into the error (>= 6 lines), got %d:\n%s", n, errIssue.FullText)
+			}
+			if strings.Contains(errIssue.FullText, "SwiftCompile") {
+				t.Fatalf("unrelated output folded into the error:\n%s", errIssue.FullText)
+			}
+
+			note := tv.IssuesTab.Issues[1]
+			if note.Type != IssueTypeNote || note.File != "/Users/dev/Shop/Sources/Store/APIClient.swift" {
+				t.Fatalf("expected unrelated note issue, got %+v", note)
+			}
+		})
+	}
+}
+
+func TestPhaseViewTagsContinuationLinesAsInfo(t *testing.T) {
+	pv := NewPhaseView()
+	for _, line := range loadFixtureLines(t, "swiftc_generics.txt") {
+		pv.AddLine(line)
+	}
+
+	errors := 0
+	for _, phase := range pv.Phases {
+		for _, l := range phase.Lines {
+			if l.Type == LogLineError {
+				errors++
+			}
+			if strings.HasPrefix(strings.TrimSpace(l.Text), "|") && l.Type != LogLineInfo {
+				t.Fatalf("continuation line not tagged as info: %q (%v)", l.Text, l.Type)
+			}
+		}
+	}
+	if errors != 1 {
+		t.Fatalf("expected a single error line, got %d", errors)
+	}
+}
+
+func TestIssueCountsAgreeEverywhere(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.running = true
+	m.runningCmd = "build"
+	for _, ev := range []core.Event{
+		core.LogRaw("build", "/src/App.swift:12:9: error: cannot find 'foo' in scope"),
+		core.LogPretty("build", "❌ /src/App.swift:12:9: cannot find 'foo' in scope"),
+		core.LogRaw("build", "/src/warning: dir/Parser.swift:3:1: error: boom"),
+		core.LogRaw("build", "/src/App.swift:20:13: warning: variable 'x' was never used"),
+		core.LogPretty("build", "⚠️  /src/App.swift:20:13: variable 'x' was never used"),
+		core.LogRaw("build", "Test Case '-[AppTests testErrorHandling]' passed (0.001 seconds)."),
+		core.LogRaw("build", "error: Signing for \"App\" requires a development team."),
+		core.Warn("build", "log formatter stopped; falling back to raw output"),
+		core.Err("build", core.ErrorObject{Code: "XCODEBUILD_FAILED", Message: "xcodebuild failed"}),
+	} {
+		m.handleEvent(ev)
+	}
+	m.syncStatusBarState()
+
+	errors, warnings := 0, 0
+	for _, issue := range m.tabView.IssuesTab.AllIssues() {
+		switch issue.Type {
+		case IssueTypeError:
+			errors++
+		case IssueTypeWarning:
+			warnings++
+		}
+	}
+	counts := m.tabView.Counts
+	if counts.ErrorCount != 3 || counts.WarningCount != 1 {
+		t.Fatalf("badges = %d errors, %d warnings; want 3, 1", counts.ErrorCount, counts.WarningCount)
+	}
+	if errors != counts.ErrorCount || warnings != counts.WarningCount {
+		t.Fatalf("Issues list = %d/%d, badges = %d/%d", errors, warnings, counts.ErrorCount, counts.WarningCount)
+	}
+	if st := m.tabView.SummaryTab; st.ErrorCount != counts.ErrorCount || st.WarningCount != counts.WarningCount {
+		t.Fatalf("Dashboard = %d/%d, badges = %d/%d", st.ErrorCount, st.WarningCount, counts.ErrorCount, counts.WarningCount)
+	}
+	if sb := m.statusBar; sb.ErrorCount != counts.ErrorCount || sb.WarningCount != counts.WarningCount {
+		t.Fatalf("status bar = %d/%d, badges = %d/%d", sb.ErrorCount, sb.WarningCount, counts.ErrorCount, counts.WarningCount)
+	}
+}
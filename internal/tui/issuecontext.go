@@ -0,0 +1,53 @@
+package tui
+
+// =============================================================================
+// Issue Context Windows
+// =============================================================================
+
+// issueClusterSeparator is drawn (dimmed) between non-adjacent clusters
+const issueClusterSeparator = "···"
+
+// issueCluster is a window of lines [Start, End) around one or more issue
+// lines; First is the first issue line inside it
+type issueCluster struct {
+	Start, End int
+	First      int
+}
+
+// issueClusters returns the context windows around every issue line among n
+// lines. Overlapping or touching windows are merged, and windows are clipped
+// to the buffer.
+func issueClusters(n, context int, isIssue func(i int) bool) []issueCluster {
+	if context < 0 {
+		context = 0
+	}
+	var clusters []issueCluster
+	for i := 0; i < n; i++ {
+		if !isIssue(i) {
+			continue
+		}
+		start := maxInt(0, i-context)
+		end := minInt(n, i+context+1)
+		if k := len(clusters) - 1; k >= 0 && start <= clusters[k].End {
+			clusters[k].End = maxInt(clusters[k].End, end)
+			continue
+		}
+		clusters = append(clusters, issueCluster{Start: start, End: end, First: i})
+	}
+	return clusters
+}
+
+// issueClusterRows flattens clusters into display rows: line indices, with
+// -1 marking the separator between clusters
+func issueClusterRows(clusters []issueCluster) []int {
+	var rows []int
+	for ci, c := range clusters {
+		if ci > 0 {
+			rows = append(rows, -1)
+		}
+		for i := c.Start; i < c.End; i++ {
+			rows = append(rows, i)
+		}
+	}
+	return rows
+}
@@ -0,0 +1,118 @@
+package tui
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestIssueClustersMergeAndClip(t *testing.T) {
+	issues := map[int]bool{0: true, 4: true, 6: true, 15: true, 19: true}
+	got := issueClusters(20, 2, func(i int) bool { return issues[i] })
+	want := []issueCluster{
+		{Start: 0, End: 9, First: 0},    // 0 clipped at the top, touches 4's window, overlaps 6's
+		{Start: 13, End: 20, First: 15}, // 19 clipped at the bottom, overlaps 15's
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("clusters = %+v, want %+v", got, want)
+	}
+
+	rows := issueClusterRows(got)
+	if len(rows) != 9+1+7 || rows[9] != -1 || rows[10] != 13 || rows[len(rows)-1] != 19 {
+		t.Fatalf("unexpected rows: %v", rows)
+	}
+
+	// Windows one line apart stay separate clusters.
+	got = issueClusters(20, 1, func(i int) bool { return i == 3 || i == 7 })
+	if len(got) != 2 || got[0].End != 5 || got[1].Start != 6 {
+		t.Fatalf("gap of one line should not merge: %+v", got)
+	}
+	if got := issueClusters(3, 0, func(i int) bool { return true }); len(got) != 1 || got[0].End != 3 {
+		t.Fatalf("adjacent issues without context should merge: %+v", got)
+	}
+}
+
+func newIssueStream(t *testing.T) *StreamTab {
+	t.Helper()
+	st := NewStreamTab()
+	st.SetSize(80, 6)
+	for i := 0; i < 40; i++ {
+		switch i {
+		case 1, 20, 22, 39:
+			st.AddLine(fmt.Sprintf("/src/F%d.swift:1:1: error: bad %d", i, i), TabLineTypeError)
+		default:
+			st.AddLine(fmt.Sprintf("line %d", i), TabLineTypeNormal)
+		}
+	}
+	return st
+}
+
+func TestStreamTabErrorsOnlyContext(t *testing.T) {
+	st := newIssueStream(t)
+	st.SetErrorsOnly(true, 2)
+	st.GotoTop()
+
+	// [0..3] ··· [18..24] ··· [37..39]
+	if n := st.rowCount(); n != 4+1+7+1+3 {
+		t.Fatalf("row count = %d", n)
+	}
+	view := stripANSI(st.GetVisibleContent())
+	lines := strings.Split(view, "\n")
+	if lines[0] != "line 0" || lines[4] != issueClusterSeparator || lines[5] != "line 18" {
+		t.Fatalf("unexpected errors-only view:\n%s", view)
+	}
+
+	// n/N cycle through the three clusters and wrap; the cluster already at
+	// the top of the view is skipped.
+	for _, want := range []int{2, 3, 1} {
+		n, total, ok := st.JumpToCluster(1)
+		if !ok || n != want || total != 3 {
+			t.Fatalf("next cluster = %d/%d, want %d/3", n, total, want)
+		}
+	}
+	if n, _, _ := st.JumpToCluster(-1); n != 3 {
+		t.Fatalf("prev should wrap to the last cluster, got %d", n)
+	}
+	if view := st.GetVisibleContent(); !strings.Contains(view, "line 37") || !strings.Contains(view, "error: bad 39") {
+		t.Fatalf("last cluster should be on screen (clamped at the bottom):\n%s", view)
+	}
+
+	// Turning the filter off keeps the position.
+	st.JumpToCluster(-1)
+	st.SetErrorsOnly(false, 2)
+	if _, idx, _ := st.TopLine(); idx != 18 {
+		t.Fatalf("expected line 18 at the top after leaving errors-only, got %d", idx)
+	}
+}
+
+func TestPhaseViewErrorsOnlyContext(t *testing.T) {
+	v := NewPhaseView()
+	v.ContextLines = 1
+	v.SetSize(80, 50)
+	v.StickyHeader = false
+	v.Phases = []BuildPhase{{Name: "Compiling"}}
+	for i := 0; i < 10; i++ {
+		lt := LogLineNormal
+		if i == 0 || i == 5 {
+			lt = LogLineError
+		}
+		v.Phases[0].Lines = append(v.Phases[0].Lines, LogLine{Text: fmt.Sprintf("l%d", i), Type: lt})
+	}
+	v.ShowErrorsOnly = true
+
+	// header, [0,1], ···, [4,5,6]
+	if n := v.totalLines(); n != 1+2+1+3 {
+		t.Fatalf("totalLines = %d", n)
+	}
+	out := stripANSI(v.View(DefaultStyles()))
+	if !strings.Contains(out, issueClusterSeparator) || strings.Contains(out, "l3") || !strings.Contains(out, "l6") {
+		t.Fatalf("unexpected errors-only view:\n%s", out)
+	}
+	if n, total, _ := v.JumpToCluster(1); n != 1 || total != 2 || v.ScrollPos != 1 {
+		t.Fatalf("first cluster: %d/%d at row %d", n, total, v.ScrollPos)
+	}
+	if n, _, _ := v.JumpToCluster(1); n != 2 || v.ScrollPos != 4 {
+		t.Fatalf("second cluster: %d at row %d", n, v.ScrollPos)
+	}
+}
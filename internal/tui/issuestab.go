@@ -2,11 +2,13 @@ package tui
 
 import (
 	"fmt"
-	"regexp"
+	"slices"
 	"sort"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/xcbolt/xcbolt/internal/core"
 )
 
 // =============================================================================
@@ -19,6 +21,7 @@ type IssueType int
 const (
 	IssueTypeError IssueType = iota
 	IssueTypeWarning
+	IssueTypeAnalyzer // Static analyzer finding (xcodebuild analyze)
 	IssueTypeNote
 )
 
@@ -29,6 +32,8 @@ func (t IssueType) String() string {
 		return "error"
 	case IssueTypeWarning:
 		return "warning"
+	case IssueTypeAnalyzer:
+		return "analyzer"
 	case IssueTypeNote:
 		return "note"
 	default:
@@ -49,6 +54,28 @@ type Issue struct {
 	Column   int
 	FullText string // Complete multi-line message
 	Expanded bool   // Whether to show full message
+	FixIts   []FixIt
+	Applied  bool   // Fix-its were written to disk
+	TestList string // "skipped" or "flaky" for synthetic test-list entries
+	// Linker issues: the missing or duplicate symbol, and which ("undefined"
+	// or "duplicate")
+	Symbol     string
+	LinkerKind string
+	// Category is the warning category (see core.WarningClassifier)
+	Category string
+	// Architectures lists the slices that reported the issue; universal
+	// builds report each diagnostic once per architecture
+	Architectures []string
+	// New marks a test failure that didn't fail in the previous run
+	New bool
+	// SourceFile and SourceLine are where a test failure reported without
+	// a file was found when opened in Xcode
+	SourceFile string
+	SourceLine int
+	// Phase is the header line of the Logs phase the issue was reported in
+	Phase string
+
+	seq int // Insertion order, stable across sorting
 }
 
 // =============================================================================
@@ -65,20 +92,61 @@ type IssuesTab struct {
 	// Scroll state
 	ScrollPos   int
 	VisibleRows int
+	// Follow is cleared while the user scrolls through the list; NewLines
+	// counts the issues added meanwhile. Issues are sorted by severity, so
+	// following keeps the view in place rather than jumping to the newest.
+	Follow   bool
+	NewLines int
 
 	// Dimensions
 	Width  int
 	Height int
 
-	// Regex for parsing error locations
-	locationRegex *regexp.Regexp
+	// MinimalMode renders one hard-truncated line per issue
+	MinimalMode bool
+
+	// Linker turns issue locations into OSC 8 links (nil = off)
+	Linker *Linker
+
+	// Classifier assigns warning categories (nil = built-in patterns)
+	Classifier *core.WarningClassifier
+	// CategoryFilter lists only errors and warnings of this category (""
+	// lists everything). Filtered-out issues wait in hidden.
+	CategoryFilter string
+	hidden         []Issue
+	// Issues of muted phases wait in muted (see phaseMuter); phase is the
+	// header line of the phase being read
+	muter *phaseMuter
+	muted []Issue
+	phase string
+
+	// Termination is the signal that killed xcodebuild ("" when it exited);
+	// MemoryPressure is set once the log hinted at memory running out
+	Termination    string
+	MemoryPressure bool
+
+	// Fix-it and continuation tracking for the most recently added issue
+	lastSeq int
+	caret   caretState
+	cont    diagContinuation
+	// arch is the architecture of the command whose output is being read
+	arch string
+
+	// Rows each issue occupied in the last View pass, for mouse clicks
+	rowSpans []issueRowSpan
+}
+
+// issueRowSpan is the row range [Top, Bottom) an issue was rendered at
+type issueRowSpan struct {
+	Index       int
+	Top, Bottom int
 }
 
 // NewIssuesTab creates a new IssuesTab
 func NewIssuesTab() *IssuesTab {
 	return &IssuesTab{
-		Issues:        make([]Issue, 0, 100),
-		locationRegex: regexp.MustCompile(`([^\s:]+):(\d+):(\d+):`),
+		Issues: make([]Issue, 0, 100),
+		Follow: true,
 	}
 }
 
@@ -98,6 +166,9 @@ func (it *IssuesTab) SetSize(width, height int) {
 	it.Height = height
 	// Reserve lines for header and analysis section
 	it.VisibleRows = height - 4
+	if it.MinimalMode {
+		it.VisibleRows = height - 1
+	}
 	if it.VisibleRows < 1 {
 		it.VisibleRows = 1
 	}
@@ -106,15 +177,58 @@ func (it *IssuesTab) SetSize(width, height int) {
 // Clear resets the issues list
 func (it *IssuesTab) Clear() {
 	it.Issues = it.Issues[:0]
+	it.hidden = it.hidden[:0]
+	it.muted = it.muted[:0]
+	it.phase = ""
 	it.Selected = 0
 	it.ScrollPos = 0
+	it.Follow, it.NewLines = true, 0
+	it.lastSeq = 0
+	it.caret = caretState{}
+	it.cont = diagContinuation{}
+	it.arch = ""
+	it.Termination, it.MemoryPressure = "", false
 }
 
-// AddIssue adds a new issue from a log line
-func (it *IssuesTab) AddIssue(issueType IssueType, line string) {
+// AddIssue adds a new issue from a log line. It reports false when the
+// issue was merged into the same one from another architecture.
+func (it *IssuesTab) AddIssue(issueType IssueType, line string) bool {
 	issue := it.parseIssue(issueType, line)
+	if it.mergeArchDuplicate(issue) {
+		return false
+	}
+	if it.arch != "" {
+		issue.Architectures = []string{it.arch}
+	}
+	it.AddParsedIssue(issue)
+	return true
+}
+
+// AddParsedIssue adds an issue built by an aggregator (e.g. linker errors)
+func (it *IssuesTab) AddParsedIssue(issue Issue) {
+	it.lastSeq++
+	issue.seq = it.lastSeq
+	it.caret.start(issue.File, issue.Line)
+	it.cont.Start(issue.File, issue.Line)
+	if issue.Type == IssueTypeWarning && issue.Category == "" {
+		issue.Category = it.classifier().Classify(issue.Message)
+	}
+	if issue.Phase == "" {
+		issue.Phase = it.phase
+	}
+	if it.muter.hides(issue.Phase) {
+		it.muted = append(it.muted, issue)
+		return
+	}
+	if !it.shows(issue) {
+		it.hidden = append(it.hidden, issue)
+		return
+	}
 	it.Issues = append(it.Issues, issue)
 	it.sortIssues()
+	if !it.Follow {
+		it.NewLines++
+	}
 	if maxIssues > 0 && len(it.Issues) > maxIssues {
 		it.Issues = it.Issues[:maxIssues]
 		if it.Selected >= len(it.Issues) {
@@ -129,6 +243,136 @@ func (it *IssuesTab) AddIssue(issueType IssueType, line string) {
 	}
 }
 
+// SetSkippedTests replaces the collapsible "N skipped tests" entry; enter
+// expands it to the test names. count may exceed len(names) when the result
+// bundle only reports totals.
+func (it *IssuesTab) SetSkippedTests(count int, names []string) {
+	it.setTestList("skipped", count, names)
+}
+
+// SetFlakyTests replaces the "N flaky tests" entry listing tests that passed
+// only after a retry.
+func (it *IssuesTab) SetFlakyTests(names []string) {
+	it.setTestList("flaky", len(names), names)
+}
+
+func (it *IssuesTab) setTestList(kind string, count int, names []string) {
+	it.Issues = withoutTestList(it.Issues, kind)
+	it.hidden = withoutTestList(it.hidden, kind)
+	if count <= 0 {
+		return
+	}
+
+	noun := "tests"
+	if count == 1 {
+		noun = "test"
+	}
+	message := fmt.Sprintf("%d %s %s", count, kind, noun)
+	full := message
+	if len(names) > 0 {
+		full += "\n" + strings.Join(names, "\n")
+	} else {
+		full += " (open the .xcresult bundle for names)"
+	}
+	// seq -1 keeps fix-its and continuations from attaching to this entry.
+	entry := Issue{Type: IssueTypeNote, Message: message, FullText: full, TestList: kind, seq: -1}
+	if !it.shows(entry) {
+		it.hidden = append(it.hidden, entry)
+		return
+	}
+	it.Issues = append(it.Issues, entry)
+	it.sortIssues()
+}
+
+func withoutTestList(issues []Issue, kind string) []Issue {
+	kept := issues[:0]
+	for _, issue := range issues {
+		if issue.TestList != kind {
+			kept = append(kept, issue)
+		}
+	}
+	return kept
+}
+
+// ObserveLine inspects a non-issue line for fix-its belonging to the most
+// recently added issue (parseable fix-it lines or a caret/replacement block).
+func (it *IssuesTab) ObserveLine(line string) {
+	it.observeArchitecture(line)
+	if it.lastSeq == 0 {
+		return
+	}
+	if fix, ok := parseParseableFixIt(line); ok {
+		it.caret = caretState{}
+		it.attachFixIt(fix)
+		return
+	}
+	// Notes restart the caret block at their own location.
+	if d := core.ClassifyDiagnostic(line); d.Located() {
+		it.caret.start(d.File, d.Line)
+		return
+	}
+	if fix, ok := it.caret.observe(line); ok {
+		it.attachFixIt(fix)
+	}
+}
+
+// Continue appends line to the previous issue's FullText when it is a
+// continuation (excerpt, caret, same-location note). Reports whether it was.
+func (it *IssuesTab) Continue(line string) bool {
+	ok, pending := it.cont.Continues(line)
+	if !ok {
+		return false
+	}
+	if issue := it.bySeq(it.lastSeq); issue != nil {
+		if pending != "" {
+			issue.FullText += "\n" + pending
+		}
+		issue.FullText += "\n" + line
+	}
+	return true
+}
+
+func (it *IssuesTab) attachFixIt(fix FixIt) {
+	issue := it.bySeq(it.lastSeq)
+	if issue == nil {
+		return
+	}
+	if fix.File == "" {
+		fix.File = issue.File
+	}
+	if slices.Contains(issue.FixIts, fix) {
+		return // Repeated by another architecture
+	}
+	issue.FixIts = append(issue.FixIts, fix)
+}
+
+// MarkApplied flags the issue with the given sequence as applied
+func (it *IssuesTab) MarkApplied(seq int) {
+	if issue := it.bySeq(seq); issue != nil {
+		issue.Applied = true
+	}
+}
+
+// bySeq finds a listed, filtered-out, or muted issue by sequence
+func (it *IssuesTab) bySeq(seq int) *Issue {
+	for i := range it.Issues {
+		if it.Issues[i].seq == seq {
+			return &it.Issues[i]
+		}
+	}
+	for i := range it.hidden {
+		if it.hidden[i].seq == seq {
+			return &it.hidden[i]
+		}
+	}
+	for i := range it.muted {
+		if it.muted[i].seq == seq {
+			return &it.muted[i]
+		}
+	}
+	return nil
+}
+
 // parseIssue extracts issue details from a log line
 func (it *IssuesTab) parseIssue(issueType IssueType, line string) Issue {
 	issue := Issue{
@@ -136,36 +380,24 @@ func (it *IssuesTab) parseIssue(issueType IssueType, line string) Issue {
 		Message:  line,
 		FullText: line,
 	}
-
-	// Try to extract file:line:column
-	matches := it.locationRegex.FindStringSubmatch(line)
-	if len(matches) >= 4 {
-		issue.File = matches[1]
-		fmt.Sscanf(matches[2], "%d", &issue.Line)
-		fmt.Sscanf(matches[3], "%d", &issue.Column)
-
-		// Extract just the message part (after the location)
-		idx := strings.Index(line, matches[0])
-		if idx >= 0 {
-			rest := line[idx+len(matches[0]):]
-			// Remove "error:" or "warning:" prefix
-			rest = strings.TrimPrefix(rest, " error: ")
-			rest = strings.TrimPrefix(rest, " warning: ")
-			rest = strings.TrimPrefix(rest, " note: ")
-			rest = strings.TrimSpace(rest)
-			if rest != "" {
-				issue.Message = rest
-			}
+	if d := core.ClassifyDiagnostic(line); d.File != "" {
+		issue.File, issue.Line, issue.Column = d.File, d.Line, d.Col
+		if d.Message != "" {
+			issue.Message = d.Message
 		}
 	}
-
 	return issue
 }
 
-// sortIssues sorts issues by severity (errors first, then warnings, then notes)
+// sortIssues sorts issues by severity (errors first, then warnings, analyzer
+// findings, and notes), new test failures first within their severity
 func (it *IssuesTab) sortIssues() {
 	sort.SliceStable(it.Issues, func(i, j int) bool {
-		return it.Issues[i].Type < it.Issues[j].Type
+		a, b := it.Issues[i], it.Issues[j]
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.New && !b.New
 	})
 }
 
@@ -191,6 +423,7 @@ func (it *IssuesTab) ScrollUp(n int) {
 	if it.Selected < it.ScrollPos {
 		it.ScrollPos = it.Selected
 	}
+	it.updateFollow()
 }
 
 // ScrollDown scrolls down by n lines
@@ -206,20 +439,32 @@ func (it *IssuesTab) ScrollDown(n int) {
 	if it.Selected >= it.ScrollPos+it.VisibleRows {
 		it.ScrollPos = it.Selected - it.VisibleRows + 1
 	}
+	it.updateFollow()
 }
 
 // GotoTop goes to the first issue
 func (it *IssuesTab) GotoTop() {
 	it.Selected = 0
 	it.ScrollPos = 0
+	it.updateFollow()
 }
 
-// GotoBottom goes to the last issue
+// GotoBottom goes to the last issue and follows again
 func (it *IssuesTab) GotoBottom() {
 	if len(it.Issues) > 0 {
 		it.Selected = len(it.Issues) - 1
 		it.ScrollPos = it.maxScrollPos()
 	}
+	it.Follow, it.NewLines = true, 0
+}
+
+// updateFollow pauses following after the user scrolled the list, unless
+// the view shows its end
+func (it *IssuesTab) updateFollow() {
+	it.Follow = it.ScrollPos >= it.maxScrollPos()
+	if it.Follow {
+		it.NewLines = 0
+	}
 }
 
 // ToggleExpand toggles the expanded state of the selected issue
@@ -235,9 +480,17 @@ func (it *IssuesTab) ToggleExpand() {
 
 // View renders the issues tab content
 func (it *IssuesTab) View(styles Styles) string {
-	if len(it.Issues) == 0 {
+	it.rowSpans = it.rowSpans[:0]
+	if len(it.Issues) == 0 && len(it.hidden) == 0 {
+		if it.Termination != "" && !it.Running {
+			// Killed without a diagnostic: the analysis is all there is
+			return it.renderAnalysis(styles)
+		}
 		return it.emptyView(styles)
 	}
+	if it.MinimalMode {
+		return it.minimalView(styles)
+	}
 
 	barWidth := scrollbarWidth
 	if it.Width-barWidth < 1 {
@@ -253,11 +506,21 @@ func (it *IssuesTab) View(styles Styles) string {
 
 	var lines []string
 
+	// Follow indicator on the top row, once the issues overflow the list
+	badge := ""
+	if len(it.Issues) > it.VisibleRows || !it.Follow {
+		badge = followIndicator(it.Follow, it.NewLines, styles)
+	}
+
 	// Header with counts
 	header := it.renderHeader(styles)
 	if header != "" {
 		for _, line := range strings.Split(header, "\n") {
-			lines = append(lines, pad.Render(line)+emptyBar)
+			rendered := pad.Render(line)
+			if badge != "" && len(lines) == 0 {
+				rendered = overlayTopRight(rendered, badge, contentWidth)
+			}
+			lines = append(lines, rendered+emptyBar)
 		}
 	}
 
@@ -270,13 +533,20 @@ func (it *IssuesTab) View(styles Styles) string {
 			barLines[i] = emptyBar
 		}
 	}
+	y := len(lines)
 	for i, line := range listLines {
-		lines = append(lines, pad.Render(line)+barLines[i])
+		rendered := pad.Render(line)
+		if badge != "" && len(lines) == 0 {
+			rendered = overlayTopRight(rendered, badge, contentWidth)
+		}
+		lines = append(lines, rendered+barLines[i])
+		it.recordRowSpan(it.ScrollPos+i, y, lipgloss.Height(rendered))
+		y += lipgloss.Height(rendered)
 	}
 
-	// Analysis section (if there are errors)
+	// Analysis section (if there are errors or xcodebuild was killed)
 	errorCount := it.countByType(IssueTypeError)
-	if errorCount > 0 {
+	if errorCount > 0 || it.Termination != "" {
 		analysis := it.renderAnalysis(styles)
 		for _, line := range strings.Split(analysis, "\n") {
 			lines = append(lines, pad.Render(line)+emptyBar)
@@ -286,10 +556,64 @@ func (it *IssuesTab) View(styles Styles) string {
 	return strings.Join(lines, "\n")
 }
 
+// minimalView renders a one-line header and one truncated line per issue,
+// without the scrollbar or analysis section
+func (it *IssuesTab) minimalView(styles Styles) string {
+	clip := lipgloss.NewStyle().MaxWidth(it.Width)
+	var lines []string
+	if header := it.renderHeader(styles); header != "" {
+		lines = append(lines, clip.Render(strings.Split(header, "\n")[0]))
+	}
+
+	end := minInt(it.ScrollPos+it.VisibleRows, len(it.Issues))
+	for i := it.ScrollPos; i < end; i++ {
+		issue := it.Issues[i]
+		issue.Expanded = false
+		line := it.renderIssue(issue, i == it.Selected, styles, it.Width)
+		it.recordRowSpan(i, len(lines), 1)
+		lines = append(lines, clip.Render(strings.Split(line, "\n")[0]))
+	}
+	return clampBlock(strings.Join(lines, "\n"), it.Width, it.Height)
+}
+
+// recordRowSpan notes that issue idx was rendered at rows [top, top+height);
+// padding rows past the last issue are skipped
+func (it *IssuesTab) recordRowSpan(idx, top, height int) {
+	if idx < len(it.Issues) {
+		it.rowSpans = append(it.rowSpans, issueRowSpan{Index: idx, Top: top, Bottom: top + height})
+	}
+}
+
+// IssueAt returns the index of the issue rendered at row y of the last view
+func (it *IssuesTab) IssueAt(y int) (int, bool) {
+	for _, span := range it.rowSpans {
+		if y >= span.Top && y < span.Bottom {
+			return span.Index, true
+		}
+	}
+	return 0, false
+}
+
+// Select makes issue idx the selected one, keeping it in view
+func (it *IssuesTab) Select(idx int) {
+	if idx < 0 || idx >= len(it.Issues) {
+		return
+	}
+	it.Selected = idx
+	if it.Selected < it.ScrollPos {
+		it.ScrollPos = it.Selected
+	}
+	if it.Selected >= it.ScrollPos+it.VisibleRows {
+		it.ScrollPos = it.Selected - it.VisibleRows + 1
+	}
+	it.updateFollow()
+}
+
 // renderHeader renders the issue count header
 func (it *IssuesTab) renderHeader(styles Styles) string {
 	errorCount := it.countByType(IssueTypeError)
 	warnCount := it.countByType(IssueTypeWarning)
+	analyzerCount := it.countByType(IssueTypeAnalyzer)
 
 	icons := styles.Icons
 
@@ -302,7 +626,21 @@ func (it *IssuesTab) renderHeader(styles Styles) string {
 
 	if warnCount > 0 {
 		warnStyle := lipgloss.NewStyle().Foreground(styles.Colors.Warning)
-		parts = append(parts, warnStyle.Render(fmt.Sprintf("%s %d warnings", icons.Warning, warnCount)))
+		warnings := warnStyle.Render(fmt.Sprintf("%s %d warnings", icons.Warning, warnCount))
+		if breakdown := it.categoryBreakdown(); breakdown != "" {
+			warnings += " " + lipgloss.NewStyle().Foreground(styles.Colors.TextMuted).Render("("+breakdown+")")
+		}
+		parts = append(parts, warnings)
+	}
+
+	if analyzerCount > 0 {
+		analyzerStyle := lipgloss.NewStyle().Foreground(styles.Colors.Analyzer)
+		parts = append(parts, analyzerStyle.Render(fmt.Sprintf("%s %d analyzer", icons.Analyzer, analyzerCount)))
+	}
+
+	if it.CategoryFilter != "" {
+		filterStyle := lipgloss.NewStyle().Foreground(styles.Colors.Accent)
+		parts = append(parts, filterStyle.Render("filter: "+core.WarningCategoryLabel(it.CategoryFilter)+" (f)"))
 	}
 
 	if len(parts) == 0 {
@@ -358,9 +696,20 @@ func (it *IssuesTab) renderIssue(issue Issue, selected bool, styles Styles, maxW
 	case IssueTypeWarning:
 		icon = icons.Warning
 		iconStyle = lipgloss.NewStyle().Foreground(styles.Colors.Warning)
+	case IssueTypeAnalyzer:
+		icon = icons.Analyzer
+		iconStyle = lipgloss.NewStyle().Foreground(styles.Colors.Analyzer)
 	case IssueTypeNote:
 		icon = icons.Note
 		iconStyle = lipgloss.NewStyle().Foreground(styles.Colors.TextMuted)
+		switch issue.TestList {
+		case "skipped":
+			icon = icons.Skipped
+			iconStyle = lipgloss.NewStyle().Foreground(styles.Colors.Warning)
+		case "flaky":
+			icon = icons.Flaky
+			iconStyle = lipgloss.NewStyle().Foreground(styles.Colors.Warning).Bold(true)
+		}
 	}
 
 	// Message (truncate if needed)
@@ -400,11 +749,22 @@ func (it *IssuesTab) renderIssue(issue Issue, selected bool, styles Styles, maxW
 		messageStyle = messageStyle.Bold(true).Foreground(styles.Colors.Accent)
 	}
 	messageRendered := messageStyle.Render(message)
+	if issue.New {
+		messageRendered = lipgloss.NewStyle().Foreground(styles.Colors.Error).Bold(true).Render("NEW") + " " + messageRendered
+	}
 
 	locationStyle := lipgloss.NewStyle().Foreground(styles.Colors.TextSubtle)
 	locationRendered := ""
 	if location != "" {
-		locationRendered = "  " + locationStyle.Render(location)
+		locationRendered = "  " + it.Linker.Wrap(locationStyle.Render(location), issue.File, issue.Line, issue.Column)
+	}
+	if suffix := archSuffix(issue); suffix != "" {
+		locationRendered += "  " + locationStyle.Render(suffix)
+	}
+	if issue.Applied {
+		locationRendered += "  " + lipgloss.NewStyle().Foreground(styles.Colors.Success).Render("[applied]")
+	} else if len(issue.FixIts) > 0 {
+		locationRendered += "  " + lipgloss.NewStyle().Foreground(styles.Colors.Accent).Render("[fix-it]")
 	}
 
 	// Selection indicator
@@ -422,6 +782,17 @@ func (it *IssuesTab) renderIssue(issue Issue, selected bool, styles Styles, maxW
 			PaddingLeft(4)
 		line += "\n" + fullStyle.Render(issue.FullText)
 	}
+	if issue.Expanded && len(issue.FixIts) > 0 {
+		fixStyle := lipgloss.NewStyle().
+			Foreground(styles.Colors.Accent).
+			PaddingLeft(4)
+		for _, fix := range issue.FixIts {
+			line += "\n" + fixStyle.Render("fix-it: "+fix.Describe())
+		}
+		if !issue.Applied {
+			line += "\n" + fixStyle.Render("press a to apply")
+		}
+	}
 
 	return line
 }
@@ -429,7 +800,7 @@ func (it *IssuesTab) renderIssue(issue Issue, selected bool, styles Styles, maxW
 // renderAnalysis renders the AI-style analysis section
 func (it *IssuesTab) renderAnalysis(styles Styles) string {
 	errors := it.getByType(IssueTypeError)
-	if len(errors) == 0 {
+	if len(errors) == 0 && it.Termination == "" {
 		return ""
 	}
 
@@ -461,13 +832,23 @@ func (it *IssuesTab) renderAnalysis(styles Styles) string {
 
 // generateAnalysis creates heuristic-based analysis for common errors
 func (it *IssuesTab) generateAnalysis(errors []Issue) string {
-	if len(errors) == 0 {
-		return ""
-	}
-
 	var suggestions []string
+	switch {
+	case it.Termination != "" && it.MemoryPressure:
+		suggestions = append(suggestions, "xcodebuild was killed ("+it.Termination+") while memory ran low - quit memory-hungry apps and simulators, build with fewer jobs (-jobs in xcodebuild.options), and retry")
+	case it.Termination != "":
+		suggestions = append(suggestions, "xcodebuild was killed ("+it.Termination+") before it finished - if memory was low, quit memory-hungry apps and simulators or build with fewer jobs (-jobs in xcodebuild.options), then retry")
+	}
 
 	for _, err := range errors {
+		if hint := linkerSuggestion(err); hint != "" {
+			suggestions = append(suggestions, hint)
+			continue
+		}
+		if core.StaleModuleCacheError(err.Message) {
+			suggestions = append(suggestions, "Module cache built by another compiler (e.g. after an Xcode update) - run Clean DerivedData from the palette and rebuild")
+			continue
+		}
 		msg := strings.ToLower(err.Message)
 
 		// Module not found
@@ -507,6 +888,9 @@ func (it *IssuesTab) generateAnalysis(errors []Issue) string {
 	}
 
 	if len(unique) == 0 {
+		if len(errors) == 0 {
+			return ""
+		}
 		return fmt.Sprintf("Found %d error(s). Review the messages above for details.", len(errors))
 	}
 
@@ -517,7 +901,7 @@ func (it *IssuesTab) generateAnalysis(errors []Issue) string {
 // Helper Methods
 // =============================================================================
 
-// countByType counts issues of a specific type
+// countByType counts issues of a specific type, listed or filtered out
 func (it *IssuesTab) countByType(issueType IssueType) int {
 	count := 0
 	for _, issue := range it.Issues {
@@ -525,6 +909,11 @@ func (it *IssuesTab) countByType(issueType IssueType) int {
 			count++
 		}
 	}
+	for _, issue := range it.hidden {
+		if issue.Type == issueType {
+			count++
+		}
+	}
 	return count
 }
 
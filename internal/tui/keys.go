@@ -14,6 +14,15 @@ const (
 	ModeHelp
 	ModeWizard
 	ModeSearch // New search mode
+	ModeDoctor
+	ModeConfirm
+	ModeSettingsDiff
+	ModeResultInfo
+	ModeLogBrowser  // Standalone unified log browser
+	ModeLogForm     // Log browser bundle id / predicate form
+	ModeExplain     // issues.explainCommand output
+	ModeDeviceHelp  // Remediation for a failed device install/launch
+	ModeProjectPath // Launcher path input (outside a project)
 )
 
 // SelectorType represents what the selector is selecting
@@ -23,6 +32,32 @@ const (
 	SelectorScheme SelectorType = iota
 	SelectorConfiguration
 	SelectorDestination
+	SelectorPackages
+	SelectorBookmarks
+	SelectorTestPlan
+	SelectorSessions
+	SelectorSessionAction
+	SelectorProduct
+	SelectorSettingsDiff
+	SelectorSettingsDiffConfiguration
+	SelectorSettingsDiffDestination
+	SelectorBuildHistory
+	SelectorCoverage
+	SelectorDestinationOS
+	SelectorConfigOrigins
+	SelectorPhases
+	SelectorStorage
+	SelectorLogDestination
+	SelectorTestSource
+	SelectorTargets
+	SelectorStressCount
+	SelectorGitignore
+	SelectorLaunchPreset
+	SelectorLaunchPresetScope
+	SelectorContainer
+	SelectorRecentProject
+	SelectorMetrics
+	SelectorBuildTarget
 )
 
 // keyMap defines all keybindings for the TUI
@@ -38,6 +73,9 @@ type keyMap struct {
 	Test  key.Binding
 	Clean key.Binding
 	Stop  key.Binding
+	Rerun key.Binding
+	// HotRerun rebuilds and relaunches the running app, keeping the console
+	HotRerun key.Binding
 
 	// Selectors
 	Scheme        key.Binding
@@ -54,10 +92,18 @@ type keyMap struct {
 	Tab2    key.Binding // Issues tab
 	Tab3    key.Binding // Summary tab
 	TabNext key.Binding // Cycle to next tab
+	PrevRun key.Binding // Toggle previous run snapshot
 
 	// Copy
 	CopyLine    key.Binding // Copy current line
 	CopyVisible key.Binding // Copy all visible content
+	CopyLoc     key.Binding // Issues tab: copy file:line:col
+	CopyGrep    key.Binding // Issues tab: copy relative path + message
+
+	// IssueCategory cycles the Issues tab warning category filter
+	IssueCategory key.Binding
+	// ExplainIssue runs issues.explainCommand on the selected issue
+	ExplainIssue key.Binding
 
 	// Display toggles
 	ToggleLineNumbers key.Binding
@@ -67,8 +113,14 @@ type keyMap struct {
 	Search           key.Binding
 	NextError        key.Binding
 	PrevError        key.Binding
+	NextMarker       key.Binding
+	PrevMarker       key.Binding
+	NextPhase        key.Binding
+	PrevPhase        key.Binding
+	MutePhase        key.Binding
 	OpenXcode        key.Binding
 	OpenEditor       key.Binding
+	ApplyFixIt       key.Binding
 	ToggleRawView    key.Binding
 	ToggleCollapse   key.Binding
 	ExpandAll        key.Binding
@@ -94,8 +146,16 @@ type keyMap struct {
 	SelectEnter key.Binding
 
 	// Run mode split view
-	SwitchPane  key.Binding
-	ToggleMouse key.Binding
+	SwitchPane    key.Binding
+	ConsoleFilter key.Binding // Cycle console level filter
+	ToggleMouse   key.Binding
+	SplitGrow     key.Binding // Grow the focused pane
+	SplitShrink   key.Binding
+	SplitSwap     key.Binding // Give the other pane the larger share
+
+	// Bookmarks
+	Bookmark  key.Binding
+	Bookmarks key.Binding
 }
 
 func defaultKeyMap() keyMap {
@@ -135,6 +195,14 @@ func defaultKeyMap() keyMap {
 			key.WithKeys("x"),
 			key.WithHelp("x", "stop"),
 		),
+		Rerun: key.NewBinding(
+			key.WithKeys("."),
+			key.WithHelp(".", "rerun last"),
+		),
+		HotRerun: key.NewBinding(
+			key.WithKeys("R"),
+			key.WithHelp("R", "hot rerun"),
+		),
 
 		// Selectors
 		Scheme: key.NewBinding(
@@ -167,20 +235,24 @@ func defaultKeyMap() keyMap {
 		// Tab navigation
 		Tab1: key.NewBinding(
 			key.WithKeys("1"),
-			key.WithHelp("1", "logs tab"),
+			key.WithHelp("1", "dashboard tab"),
 		),
 		Tab2: key.NewBinding(
 			key.WithKeys("2"),
-			key.WithHelp("2", "issues tab"),
+			key.WithHelp("2", "logs tab"),
 		),
 		Tab3: key.NewBinding(
 			key.WithKeys("3"),
-			key.WithHelp("3", "summary tab"),
+			key.WithHelp("3", "issues tab"),
 		),
 		TabNext: key.NewBinding(
 			key.WithKeys("tab"),
 			key.WithHelp("tab", "next tab"),
 		),
+		PrevRun: key.NewBinding(
+			key.WithKeys("shift+tab"),
+			key.WithHelp("⇧tab", "previous run"),
+		),
 
 		// Copy
 		CopyLine: key.NewBinding(
@@ -191,6 +263,14 @@ func defaultKeyMap() keyMap {
 			key.WithKeys("Y"),
 			key.WithHelp("Y", "copy visible"),
 		),
+		CopyLoc: key.NewBinding(
+			key.WithKeys("Y"),
+			key.WithHelp("Y", "copy location (issues)"),
+		),
+		CopyGrep: key.NewBinding(
+			key.WithKeys("ctrl+y"),
+			key.WithHelp("^Y", "copy as grep (issues)"),
+		),
 
 		// Display toggles
 		ToggleLineNumbers: key.NewBinding(
@@ -215,6 +295,26 @@ func defaultKeyMap() keyMap {
 			key.WithKeys("N"),
 			key.WithHelp("N", "prev error"),
 		),
+		NextMarker: key.NewBinding(
+			key.WithKeys("]"),
+			key.WithHelp("]", "next marker below view"),
+		),
+		PrevMarker: key.NewBinding(
+			key.WithKeys("["),
+			key.WithHelp("[", "prev marker above view"),
+		),
+		NextPhase: key.NewBinding(
+			key.WithKeys("}"),
+			key.WithHelp("}", "next phase"),
+		),
+		MutePhase: key.NewBinding(
+			key.WithKeys("u"),
+			key.WithHelp("u", "mute phase at top of Logs"),
+		),
+		PrevPhase: key.NewBinding(
+			key.WithKeys("{"),
+			key.WithHelp("{", "prev phase"),
+		),
 		OpenXcode: key.NewBinding(
 			key.WithKeys("o"),
 			key.WithHelp("o", "open in Xcode"),
@@ -223,13 +323,17 @@ func defaultKeyMap() keyMap {
 			key.WithKeys("O"),
 			key.WithHelp("O", "open in $EDITOR"),
 		),
+		ApplyFixIt: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "apply fix-it"),
+		),
 		ToggleRawView: key.NewBinding(
 			key.WithKeys("v"),
 			key.WithHelp("v", "toggle logs view"),
 		),
 		ToggleCollapse: key.NewBinding(
 			key.WithKeys("enter", " "),
-			key.WithHelp("enter", "toggle phase collapse"),
+			key.WithHelp("enter", "toggle phase / expand issue"),
 		),
 		ExpandAll: key.NewBinding(
 			key.WithKeys("e"),
@@ -259,7 +363,7 @@ func defaultKeyMap() keyMap {
 		),
 		ScrollBottom: key.NewBinding(
 			key.WithKeys("end", "G"),
-			key.WithHelp("end/G", "bottom"),
+			key.WithHelp("end/G", "bottom · resume follow"),
 		),
 		PageUp: key.NewBinding(
 			key.WithKeys("pgup"),
@@ -278,6 +382,14 @@ func defaultKeyMap() keyMap {
 			key.WithHelp("^D", "half page down"),
 		),
 		// Repurposed: now toggles stream view
+		IssueCategory: key.NewBinding(
+			key.WithKeys("f"),
+			key.WithHelp("f", "cycle warning category (Issues)"),
+		),
+		ExplainIssue: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "explain issue (Issues)"),
+		),
 		ToggleAutoFollow: key.NewBinding(
 			key.WithKeys("f"),
 			key.WithHelp("f", "toggle logs view"),
@@ -302,9 +414,34 @@ func defaultKeyMap() keyMap {
 			key.WithKeys("tab"),
 			key.WithHelp("tab", "switch pane"),
 		),
+		ConsoleFilter: key.NewBinding(
+			key.WithKeys("l"),
+			key.WithHelp("l", "console level filter"),
+		),
 		ToggleMouse: key.NewBinding(
+			key.WithKeys("M"),
+			key.WithHelp("M", "toggle mouse"),
+		),
+		SplitGrow: key.NewBinding(
+			key.WithKeys("+", "="),
+			key.WithHelp("+", "grow focused pane"),
+		),
+		SplitShrink: key.NewBinding(
+			key.WithKeys("-"),
+			key.WithHelp("-", "shrink focused pane"),
+		),
+		SplitSwap: key.NewBinding(
+			key.WithKeys("\\"),
+			key.WithHelp("\\", "swap pane sizes"),
+		),
+
+		Bookmark: key.NewBinding(
 			key.WithKeys("m"),
-			key.WithHelp("m", "toggle mouse"),
+			key.WithHelp("m", "bookmark/pin line"),
+		),
+		Bookmarks: key.NewBinding(
+			key.WithKeys("'"),
+			key.WithHelp("'", "jump to bookmark"),
 		),
 	}
 }
@@ -318,17 +455,18 @@ func (k keyMap) ShortHelp() []key.Binding {
 func (k keyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		// Actions
-		{k.Build, k.Run, k.Test, k.Clean, k.Stop},
+		{k.Build, k.Run, k.Test, k.Clean, k.Stop, k.Rerun, k.HotRerun},
 		// Configuration
 		{k.Scheme, k.Configuration, k.Destination, k.Palette, k.Init, k.Refresh},
 		// Tabs
-		{k.Tab1, k.Tab2, k.Tab3, k.TabNext},
+		{k.Tab1, k.Tab2, k.Tab3, k.TabNext, k.SwitchPane, k.ConsoleFilter, k.PrevRun},
+		{k.SplitGrow, k.SplitShrink, k.SplitSwap},
 		// View controls
 		{k.ToggleRawView, k.ToggleLineNumbers, k.ToggleTimestamps, k.ToggleErrorsOnly, k.ToggleMouse, k.ExpandAll, k.CollapseAll},
 		// Scrolling
 		{k.ScrollUp, k.ScrollDown, k.PageUp, k.PageDown, k.ScrollTop, k.ScrollBottom},
 		// Navigation & Other
-		{k.Search, k.NextError, k.PrevError, k.CopyLine, k.CopyVisible, k.OpenXcode, k.OpenEditor, k.Cancel, k.Help, k.Quit},
+		{k.Search, k.NextError, k.PrevError, k.NextMarker, k.PrevMarker, k.NextPhase, k.PrevPhase, k.MutePhase, k.Bookmark, k.Bookmarks, k.CopyLine, k.CopyVisible, k.CopyLoc, k.CopyGrep, k.IssueCategory, k.ExplainIssue, k.OpenXcode, k.OpenEditor, k.ApplyFixIt, k.Cancel, k.Help, k.Quit},
 	}
 }
 
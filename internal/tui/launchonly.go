@@ -0,0 +1,36 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// launchOnly installs and launches the last built app without rebuilding,
+// e.g. after a run was canceled while launching
+func (m *Model) launchOnly() tea.Cmd {
+	if m.running {
+		m.setStatus("An operation is already running")
+		return nil
+	}
+	if m.cfg.LastBuiltAppBundle == "" {
+		m.setStatus("No built app yet · build or run first")
+		return nil
+	}
+	return m.startOpRequest(core.LastOp{Name: "run", LaunchOnly: true})
+}
+
+// runPhaseStage is the progress stage of a run phase event ("" for the
+// build, whose stages come from xcodebuild's output)
+func runPhaseStage(ev core.Event) string {
+	data, _ := ev.Data.(map[string]any)
+	switch phase, _ := data["phase"].(string); core.RunPhase(phase) {
+	case core.RunPhaseInstall:
+		return "Install"
+	case core.RunPhaseLaunch:
+		return "Launch"
+	case core.RunPhaseConsole:
+		return "Console"
+	}
+	return ""
+}
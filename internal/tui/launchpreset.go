@@ -0,0 +1,111 @@
+package tui
+
+import (
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// =============================================================================
+// Launch Presets
+// =============================================================================
+
+// noPresetID is the selector item that runs without a preset
+const noPresetID = "\x00none"
+
+// Scope items after a preset is picked
+const (
+	presetOnceID    = "once"
+	presetDefaultID = "default"
+)
+
+// openLaunchPresets lists launch.presets to pick one for the next run
+func (m *Model) openLaunchPresets() {
+	if len(m.cfg.Launch.Presets) == 0 {
+		m.setStatus("No launch presets (add launch.presets to .xcbolt/config.json)")
+		return
+	}
+	m.selector = NewSelector("Launch preset", LaunchPresetItems(m.cfg.Launch), m.width, m.styles)
+	m.selectorType = SelectorLaunchPreset
+	m.mode = ModeSelector
+}
+
+// LaunchPresetItems creates selector items (ID = preset name) after a "None"
+// item, marking the default preset
+func LaunchPresetItems(launch core.LaunchConfig) []SelectorItem {
+	items := make([]SelectorItem, 0, len(launch.Presets)+1)
+	none := SelectorItem{ID: noPresetID, Title: "None", Description: "Only launch.options and launch.env"}
+	if launch.Preset == "" {
+		none.Meta = "[default]"
+	}
+	items = append(items, none)
+	for _, p := range launch.Presets {
+		item := SelectorItem{ID: p.Name, Title: p.Name, Description: presetSummary(p)}
+		if p.Name == launch.Preset {
+			item.Meta = "[default]"
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// presetSummary lists a preset's arguments and environment keys
+func presetSummary(p core.LaunchPreset) string {
+	parts := append([]string{}, p.Args...)
+	keys := make([]string, 0, len(p.Env))
+	for k := range p.Env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		parts = append(parts, k+"=…")
+	}
+	if len(parts) == 0 {
+		return "No arguments"
+	}
+	return strings.Join(parts, " ")
+}
+
+// pickLaunchPreset asks whether the picked preset is for one run or the
+// default
+func (m *Model) pickLaunchPreset(item *SelectorItem) {
+	m.pendingPreset = item.ID
+	name := item.Title
+	m.selector = NewSelector("Run with "+name, []SelectorItem{
+		{ID: presetOnceID, Title: "This run only", Description: "launch.preset stays as it is"},
+		{ID: presetDefaultID, Title: "Run and make default", Description: "Save as launch.preset for every run"},
+	}, m.width, m.styles)
+	m.selectorType = SelectorLaunchPresetScope
+	m.mode = ModeSelector
+}
+
+// runLaunchPreset runs with the pending preset, saving it as launch.preset
+// when chosen as the default. Neither scope replaces a running operation.
+func (m *Model) runLaunchPreset(item *SelectorItem) tea.Cmd {
+	preset := m.pendingPreset
+	m.pendingPreset = ""
+	if preset == "" {
+		return nil
+	}
+	if m.running {
+		m.setStatus("An operation is already running")
+		return nil
+	}
+	if item.ID == presetDefaultID {
+		if preset == noPresetID {
+			preset = ""
+		}
+		m.cfg.Launch.Preset = preset
+		if err := core.SaveConfig(m.projectRoot, m.configPath, m.cfg); err != nil {
+			m.lastErr = err.Error()
+		}
+		return m.startOp("run")
+	}
+	if preset == noPresetID {
+		preset = core.LaunchPresetNone
+	}
+	return m.startOpRequest(core.LastOp{Name: "run", Preset: preset})
+}
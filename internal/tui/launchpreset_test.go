@@ -0,0 +1,92 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func TestLaunchPresetItemsMarkDefault(t *testing.T) {
+	launch := core.LaunchConfig{
+		Presets: []core.LaunchPreset{
+			{Name: "Onboarding", Args: []string{"-resetOnboarding"}},
+			{Name: "Staging", Args: []string{"-useStagingAPI"}, Env: map[string]string{"API_TOKEN": "secret"}},
+		},
+		Preset: "Staging",
+	}
+	items := LaunchPresetItems(launch)
+	if len(items) != 3 || items[0].ID != noPresetID || items[0].Meta != "" {
+		t.Fatalf("expected None first and not the default, got %+v", items)
+	}
+	if items[2].Meta != "[default]" || items[2].Description != "-useStagingAPI API_TOKEN=…" {
+		t.Fatalf("unexpected Staging item %+v", items[2])
+	}
+	launch.Preset = ""
+	if got := LaunchPresetItems(launch); got[0].Meta != "[default]" {
+		t.Fatalf("None should be the default when launch.preset is unset")
+	}
+}
+
+func TestLaunchPresetPickAsksForScope(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+
+	m.executePaletteCommand(&Command{ID: "run-launch-preset"})
+	if m.mode == ModeSelector || !strings.Contains(m.statusMsg, "launch.presets") {
+		t.Fatalf("expected a hint without presets: mode=%v status=%q", m.mode, m.statusMsg)
+	}
+
+	m.cfg.Launch.Presets = []core.LaunchPreset{{Name: "UITest", Args: []string{"-uiTestMode"}}}
+	m.executePaletteCommand(&Command{ID: "run-launch-preset"})
+	if m.mode != ModeSelector || m.selectorType != SelectorLaunchPreset {
+		t.Fatalf("expected the preset selector, mode=%v", m.mode)
+	}
+	m.pickLaunchPreset(&SelectorItem{ID: "UITest", Title: "UITest"})
+	if m.selectorType != SelectorLaunchPresetScope || m.pendingPreset != "UITest" {
+		t.Fatalf("expected the scope selector, type=%v pending=%q", m.selectorType, m.pendingPreset)
+	}
+}
+
+func TestLaunchPresetRefusedWhileRunning(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.cfg.Launch.Presets = []core.LaunchPreset{{Name: "UITest", Args: []string{"-uiTestMode"}}}
+	m.running = true
+	m.runningCmd = "build"
+	for _, scope := range []string{presetOnceID, presetDefaultID} {
+		m.pendingPreset = "UITest"
+		if cmd := m.runLaunchPreset(&SelectorItem{ID: scope}); cmd != nil || m.pendingOp != "" {
+			t.Fatalf("%s: running op replaced (pending %q)", scope, m.pendingOp)
+		}
+		if !strings.Contains(m.statusMsg, "already running") || m.cfg.Launch.Preset != "" {
+			t.Fatalf("%s: status %q, launch.preset %q", scope, m.statusMsg, m.cfg.Launch.Preset)
+		}
+	}
+}
+
+func TestLaunchPresetShownWhileRunning(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.running = true
+	m.runningCmd = "run"
+	m.runMode.Active = true
+	m.parseProgressFromEvent(core.Event{Type: "status", Code: core.LaunchPresetCode, Msg: "Launch preset: Staging", Data: map[string]any{"preset": "Staging"}})
+
+	m.syncStatusBarState()
+	if m.statusBar.Preset != "Staging" {
+		t.Fatalf("status bar preset = %q", m.statusBar.Preset)
+	}
+	if snap := m.statusSnapshot(); snap.Preset != "Staging" {
+		t.Fatalf("status snapshot preset = %q", snap.Preset)
+	}
+	if header := stripANSI(m.consoleHeader()); !strings.Contains(header, "preset Staging") {
+		t.Fatalf("console header = %q", header)
+	}
+
+	m.running = false
+	m.syncStatusBarState()
+	if m.statusBar.Preset != "" || m.statusSnapshot().Preset != "" {
+		t.Fatal("preset still shown after the run")
+	}
+}
@@ -29,8 +29,17 @@ type Layout struct {
 
 	// Minimal mode for small terminals
 	MinimalMode bool
+
+	// Run mode split: the build pane's share in percent (0 = 60), and
+	// whether the panes sit side by side on wide terminals
+	SplitRatio    int
+	SplitVertical bool
 }
 
+// sideBySideMinWidth is the narrowest terminal that gets a side-by-side
+// run mode split
+const sideBySideMinWidth = 160
+
 // NewLayout creates a new layout with default settings
 func NewLayout() Layout {
 	return Layout{
@@ -66,8 +75,12 @@ func (l Layout) ContentWidth() int {
 // Note: This is a rough estimate - actual height is calculated dynamically in RenderFullLayout
 func (l Layout) ContentHeight() int {
 	if l.MinimalMode {
-		// In minimal mode: 1 line status, rest for content
-		return maxInt(0, l.Height-1)
+		// In minimal mode: 1 line status, 1 line hints, rest for content
+		h := l.Height - 1
+		if l.ShowHintsBar {
+			h--
+		}
+		return maxInt(0, h)
 	}
 
 	h := l.Height - l.StatusBarHeight
@@ -82,19 +95,45 @@ func (l Layout) ContentHeight() int {
 	return maxInt(0, h)
 }
 
-// SplitTopHeight returns the height for the top pane (60%) in split view
+// SideBySide reports whether the run mode split puts the console next to
+// the build pane (SplitVertical on a terminal at least 160 columns wide)
+func (l Layout) SideBySide() bool {
+	return l.SplitVertical && !l.MinimalMode && l.Width >= sideBySideMinWidth
+}
+
+// SplitPanes returns the build and console pane sizes for totalHeight rows
+// of split content (the divider row already taken off). Side by side, a
+// divider column separates the panes.
+func (l Layout) SplitPanes(totalHeight int) (buildWidth, buildHeight, consoleWidth, consoleHeight int) {
+	ratio := l.SplitRatio
+	if ratio <= 0 {
+		ratio = 60
+	}
+	if l.SideBySide() {
+		buildWidth = (l.Width - 1) * ratio / 100
+		return buildWidth, totalHeight, l.Width - 1 - buildWidth, totalHeight
+	}
+	buildHeight = totalHeight * ratio / 100
+	return l.Width, buildHeight, l.Width, totalHeight - buildHeight
+}
+
+// SplitTopHeight returns the height of the build pane in split view
 func (l Layout) SplitTopHeight() int {
-	total := l.ContentHeight()
 	// Reserve 1 line for the divider
-	return maxInt(0, (total-1)*60/100)
+	_, h, _, _ := l.SplitPanes(maxInt(0, l.ContentHeight()-1))
+	return h
 }
 
-// SplitBottomHeight returns the height for the bottom pane (40%) in split view
+// SplitBottomHeight returns the height of the console pane in split view
 func (l Layout) SplitBottomHeight() int {
-	total := l.ContentHeight()
-	topHeight := l.SplitTopHeight()
-	// Reserve 1 line for the divider
-	return maxInt(0, total-topHeight-1)
+	_, _, _, h := l.SplitPanes(maxInt(0, l.ContentHeight()-1))
+	return h
+}
+
+// SplitConsoleWidth returns the width of the console pane in split view
+func (l Layout) SplitConsoleWidth() int {
+	_, _, w, _ := l.SplitPanes(0)
+	return w
 }
 
 // =============================================================================
@@ -272,13 +311,11 @@ func (l Layout) RenderSplitLayout(statusBar, progressBar, topContent, bottomCont
 
 	totalContentHeight = maxInt(0, totalContentHeight)
 
-	// Split 60/40
-	topHeight := totalContentHeight * 60 / 100
-	bottomHeight := totalContentHeight - topHeight
+	topWidth, topHeight, bottomWidth, bottomHeight := l.SplitPanes(totalContentHeight)
 
 	// Render panes
-	topStyle := lipgloss.NewStyle().Width(l.Width).Height(topHeight).MaxHeight(topHeight)
-	bottomStyle := lipgloss.NewStyle().Width(l.Width).Height(bottomHeight).MaxHeight(bottomHeight)
+	topStyle := lipgloss.NewStyle().Width(topWidth).MaxWidth(topWidth).Height(topHeight).MaxHeight(topHeight)
+	bottomStyle := lipgloss.NewStyle().Width(bottomWidth).MaxWidth(bottomWidth).Height(bottomHeight).MaxHeight(bottomHeight)
 
 	renderedTop := topStyle.Render(topContent)
 	renderedBottom := bottomStyle.Render(bottomContent)
@@ -299,6 +336,19 @@ func (l Layout) RenderSplitLayout(statusBar, progressBar, topContent, bottomCont
 	leftLabel := labelStyle.Render(topLabel)
 	rightLabel := labelStyle.Render(bottomLabel)
 
+	if l.SideBySide() {
+		// Labels above each pane, a divider column between them
+		labelRow := paneLabel(leftLabel, topWidth, dividerStyle) + dividerStyle.Render("┬") + paneLabel(rightLabel, bottomWidth, dividerStyle)
+		column := dividerStyle.Render(strings.TrimSuffix(strings.Repeat("│\n", topHeight), "\n"))
+		panes := lipgloss.JoinHorizontal(lipgloss.Top, renderedTop, column, renderedBottom)
+		parts := []string{header}
+		if progress != "" {
+			parts = append(parts, progress)
+		}
+		parts = append(parts, labelRow, panes, hints)
+		return lipgloss.JoinVertical(lipgloss.Left, parts...)
+	}
+
 	leftWidth := lipgloss.Width(leftLabel)
 	rightWidth := lipgloss.Width(rightLabel)
 	middleWidth := l.Width - leftWidth - rightWidth - 4
@@ -331,3 +381,21 @@ func (l Layout) RenderSplitLayout(statusBar, progressBar, topContent, bottomCont
 		hints,
 	)
 }
+
+// paneLabel fills width columns with label followed by a divider line
+func paneLabel(label string, width int, dividerStyle lipgloss.Style) string {
+	rest := width - lipgloss.Width(label) - 1
+	if rest < 0 {
+		return dividerStyle.Render(strings.Repeat("─", maxInt(0, width)))
+	}
+	return dividerStyle.Render("─") + label + dividerStyle.Render(strings.Repeat("─", rest))
+}
+
+// clampBlock hard-truncates rendered content to width columns and height
+// rows (ANSI-aware); minimal mode uses it instead of wrapping
+func clampBlock(content string, width, height int) string {
+	if width <= 0 || height <= 0 {
+		return ""
+	}
+	return lipgloss.NewStyle().MaxWidth(width).MaxHeight(height).Render(content)
+}
@@ -7,4 +7,8 @@ const (
 	maxStreamTabLines = 20000
 	// maxIssues caps the in-memory issues list.
 	maxIssues = 2000
+	// maxEventBatch caps how many buffered events one Update handles.
+	maxEventBatch = 500
+	// maxOpLogs is how many operation logs .xcbolt/Logs keeps.
+	maxOpLogs = 20
 )
@@ -0,0 +1,193 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// Linker Issues
+// =============================================================================
+
+var (
+	ldUndefinedHeaderRE = regexp.MustCompile(`^Undefined symbols? for architecture (\S+):\s*$`)
+	ldUndefinedSymbolRE = regexp.MustCompile(`^\s+"(.+)", referenced from:\s*$`)
+	ldDuplicateRE       = regexp.MustCompile(`^(?:ld: )?duplicate symbol '(.+)' in:\s*$`)
+	ldSummaryRE         = regexp.MustCompile(`^ld: (?:symbol\(s\) not found|\d+ duplicate symbols?)`)
+
+	// Swift module of a mangled ($s9Analytics...) or demangled
+	// (Analytics.Tracker...) symbol
+	swiftMangledModuleRE   = regexp.MustCompile(`^_?\$s(\d+)([A-Za-z_]\w*)`)
+	swiftDemangledModuleRE = regexp.MustCompile(`\b([A-Za-z_]\w*)\.[A-Za-z_]`)
+)
+
+const (
+	linkerUndefined = "undefined"
+	linkerDuplicate = "duplicate"
+)
+
+// linkerSymbol is one missing or duplicate symbol of an ld block
+type linkerSymbol struct {
+	kind string
+	name string
+	arch string
+	refs []string // Referencing objects (undefined) or defining objects (duplicate)
+}
+
+// linkerAggregator groups ld's "Undefined symbols" and "duplicate symbol"
+// blocks into one issue per symbol instead of one per output line.
+type linkerAggregator struct {
+	kind    string // Block being read ("" when none)
+	arch    string
+	symbols []linkerSymbol
+	trailer bool // A block just ended; clang's "linker command failed" follows
+}
+
+// Observe consumes line when it belongs to a linker block. Issues of a block
+// that ended (at this line or just before it) are returned.
+func (a *linkerAggregator) Observe(line string) (bool, []Issue) {
+	trailer := a.trailer
+	a.trailer = false
+
+	if m := ldUndefinedHeaderRE.FindStringSubmatch(line); m != nil {
+		done := a.Flush("")
+		a.kind, a.arch = linkerUndefined, m[1]
+		return true, done
+	}
+	if m := ldDuplicateRE.FindStringSubmatch(line); m != nil {
+		if a.kind != linkerDuplicate {
+			done := a.Flush("")
+			a.kind = linkerDuplicate
+			a.symbols = append(a.symbols, linkerSymbol{kind: linkerDuplicate, name: m[1]})
+			return true, done
+		}
+		a.symbols = append(a.symbols, linkerSymbol{kind: linkerDuplicate, name: m[1]})
+		return true, nil
+	}
+	if a.kind != "" {
+		if m := ldUndefinedSymbolRE.FindStringSubmatch(line); m != nil && a.kind == linkerUndefined {
+			a.symbols = append(a.symbols, linkerSymbol{kind: linkerUndefined, name: m[1], arch: a.arch})
+			return true, nil
+		}
+		if strings.HasPrefix(line, " ") && strings.TrimSpace(line) != "" && len(a.symbols) > 0 {
+			last := &a.symbols[len(a.symbols)-1]
+			last.refs = append(last.refs, strings.TrimSpace(line))
+			return true, nil
+		}
+		if ldSummaryRE.MatchString(line) {
+			a.trailer = true
+			return true, a.Flush(line)
+		}
+		return false, a.Flush("")
+	}
+	if trailer && strings.Contains(strings.ToLower(line), "linker command failed") {
+		return true, nil
+	}
+	return false, nil
+}
+
+// Flush ends the open block, returning an issue per symbol. summary is ld's
+// closing line, appended to each issue's full text.
+func (a *linkerAggregator) Flush(summary string) []Issue {
+	symbols := a.symbols
+	a.kind, a.arch, a.symbols = "", "", nil
+	if len(symbols) == 0 {
+		return nil
+	}
+	issues := make([]Issue, 0, len(symbols))
+	for _, sym := range symbols {
+		issues = append(issues, sym.issue(summary))
+	}
+	return issues
+}
+
+func (s linkerSymbol) issue(summary string) Issue {
+	var b strings.Builder
+	var message string
+	if s.kind == linkerUndefined {
+		message = "Undefined symbol: " + displaySymbol(s.name)
+		fmt.Fprintf(&b, "Undefined symbol for architecture %s: %s", s.arch, s.name)
+		if len(s.refs) > 0 {
+			b.WriteString("\nReferenced from:")
+		}
+	} else {
+		message = "Duplicate symbol: " + displaySymbol(s.name)
+		fmt.Fprintf(&b, "Duplicate symbol %s, defined in:", s.name)
+	}
+	for _, ref := range s.refs {
+		b.WriteString("\n  " + ref)
+	}
+	if summary != "" {
+		b.WriteString("\n" + summary)
+	}
+	return Issue{
+		Type:       IssueTypeError,
+		Message:    message,
+		FullText:   b.String(),
+		Symbol:     s.name,
+		LinkerKind: s.kind,
+	}
+}
+
+// displaySymbol names Objective-C runtime symbols by their class
+func displaySymbol(name string) string {
+	for _, prefix := range []string{"_OBJC_CLASS_$_", "_OBJC_METACLASS_$_"} {
+		if cls, ok := strings.CutPrefix(name, prefix); ok {
+			return cls + " (Objective-C class)"
+		}
+	}
+	return name
+}
+
+// symbolModule guesses the Swift module defining name ("" when unknown,
+// e.g. for C and Objective-C symbols)
+func symbolModule(name string) string {
+	if m := swiftMangledModuleRE.FindStringSubmatch(name); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		if n > 0 && n <= len(m[2]) {
+			return m[2][:n]
+		}
+	}
+	if strings.HasPrefix(name, "_") {
+		return ""
+	}
+	for _, m := range swiftDemangledModuleRE.FindAllStringSubmatch(name, -1) {
+		if m[1] != "__C" && m[1] != "Swift" {
+			return m[1]
+		}
+	}
+	return ""
+}
+
+// linkerSuggestion is the analysis hint for a linker issue
+func linkerSuggestion(issue Issue) string {
+	switch issue.LinkerKind {
+	case linkerUndefined:
+		if module := symbolModule(issue.Symbol); module != "" {
+			return fmt.Sprintf("Symbol defined in target %s not linked - check target membership or add the framework", module)
+		}
+		return fmt.Sprintf("%s not linked - check target membership or add the framework that defines it", displaySymbol(issue.Symbol))
+	case linkerDuplicate:
+		return fmt.Sprintf("%s defined more than once - remove one definition or stop linking the library twice", displaySymbol(issue.Symbol))
+	}
+	return ""
+}
+
+// addLinkerIssues records issues of a finished linker block
+func (tv *TabView) addLinkerIssues(issues []Issue) {
+	for _, issue := range issues {
+		tv.IssuesTab.AddParsedIssue(issue)
+		tv.countIssue(IssueTypeError)
+	}
+	tv.syncIssueCounts()
+}
+
+// addLinkerLine shows a line folded into a linker block in Logs only
+func (tv *TabView) addLinkerLine(line string, at time.Time) {
+	tv.StreamTab.AddLineAt(line, classifyTabLogLine(line), at)
+	tv.Counts.StreamLines++
+	tv.enforceSnapshotCaps()
+}
@@ -0,0 +1,92 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+)
+
+func addFixture(t *testing.T, name string) *TabView {
+	t.Helper()
+	tv := NewTabView()
+	for _, line := range loadFixtureLines(t, name) {
+		tv.AddRawLine(line)
+	}
+	tv.SetBuildResult(BuildStatusFailed, "", nil)
+	return tv
+}
+
+func TestUndefinedSymbolsBecomeOneIssuePerSymbol(t *testing.T) {
+	for _, fixture := range []string{"ld64_undefined.txt", "ld_prime_undefined.txt"} {
+		t.Run(fixture, func(t *testing.T) {
+			tv := addFixture(t, fixture)
+			issues := tv.IssuesTab.Issues
+			if len(issues) != 2 || tv.Counts.ErrorCount != 2 {
+				t.Fatalf("expected 2 symbol issues, got %d (errors %d): %+v", len(issues), tv.Counts.ErrorCount, issues)
+			}
+			objc := issues[0]
+			if objc.Type != IssueTypeError || objc.Message != "Undefined symbol: FIRApp (Objective-C class)" {
+				t.Fatalf("unexpected ObjC issue %+v", objc)
+			}
+			if !strings.Contains(objc.FullText, "AppDelegate.o") || !strings.Contains(objc.FullText, "for architecture arm64") {
+				t.Fatalf("referencing objects missing from full text:\n%s", objc.FullText)
+			}
+			swift := issues[1]
+			if !strings.Contains(swift.FullText, "SettingsView.o") || !strings.Contains(swift.FullText, "AppDelegate.o") {
+				t.Fatalf("every reference should be listed:\n%s", swift.FullText)
+			}
+			if got := linkerSuggestion(swift); !strings.Contains(got, "target Analytics not linked") {
+				t.Fatalf("unexpected suggestion %q", got)
+			}
+			if len(tv.StreamTab.Lines) != len(loadFixtureLines(t, fixture)) {
+				t.Fatalf("folded lines should still show in Logs")
+			}
+		})
+	}
+}
+
+func TestDuplicateSymbolsBecomeOneIssuePerSymbol(t *testing.T) {
+	tv := addFixture(t, "ld64_duplicate.txt")
+	issues := tv.IssuesTab.Issues
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 duplicate issues, got %+v", issues)
+	}
+	if issues[0].Message != "Duplicate symbol: _kAPIBaseURL" || !strings.Contains(issues[0].FullText, "Config.o") || !strings.Contains(issues[0].FullText, "Networking.o") {
+		t.Fatalf("unexpected first issue %+v", issues[0])
+	}
+	if issues[1].Message != "Duplicate symbol: SHPLogger (Objective-C class)" || !strings.Contains(issues[1].FullText, "libLogging.a(SHPLogger.o)") {
+		t.Fatalf("unexpected second issue %+v", issues[1])
+	}
+	if !strings.Contains(tv.IssuesTab.generateAnalysis(issues), "defined more than once") {
+		t.Fatalf("analysis should explain duplicates")
+	}
+}
+
+func TestLinkerBlockWithoutSummaryFlushesOnNextLine(t *testing.T) {
+	tv := NewTabView()
+	tv.AddRawLine("Undefined symbols for architecture x86_64:")
+	tv.AddRawLine(`  "_sqlite3_open", referenced from:`)
+	tv.AddRawLine("      _openDB in Store.o")
+	if len(tv.IssuesTab.Issues) != 0 {
+		t.Fatalf("issues should wait for the block to end")
+	}
+	tv.AddRawLine("** BUILD FAILED **")
+	if len(tv.IssuesTab.Issues) == 0 || tv.IssuesTab.Issues[0].Symbol != "_sqlite3_open" {
+		t.Fatalf("block should flush on the next unrelated line: %+v", tv.IssuesTab.Issues)
+	}
+	if got := linkerSuggestion(tv.IssuesTab.Issues[0]); !strings.HasPrefix(got, "_sqlite3_open not linked") {
+		t.Fatalf("C symbols have no module: %q", got)
+	}
+}
+
+func TestSymbolModule(t *testing.T) {
+	for name, want := range map[string]string{
+		"_$s9Analytics7TrackerC6sharedACvau":         "Analytics",
+		"type metadata accessor for Analytics.Track": "Analytics",
+		"Swift.String.init() -> Swift.String":        "",
+		"_OBJC_CLASS_$_FIRApp":                       "",
+	} {
+		if got := symbolModule(name); got != want {
+			t.Errorf("symbolModule(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
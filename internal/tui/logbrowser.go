@@ -0,0 +1,511 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// =============================================================================
+// Log Browser
+// =============================================================================
+
+// LogBrowserState is the standalone unified log browser: a full-screen
+// console streaming a destination's logs without building or launching
+type LogBrowserState struct {
+	Active      bool
+	Console     ConsolePane
+	Destination core.Destination
+	BundleID    string // Limits the stream to the app's subsystems
+	Predicate   string // Extra NSPredicate
+	Paused      bool
+	Streaming   bool
+
+	pending []logBrowserLine // Lines received while paused
+	matches []int            // Search matches (visible console positions)
+	cancel  context.CancelFunc
+	stop    chan struct{}
+	events  *chanEmitter
+	gen     int // Bumped on every (re)start; stale stream messages are ignored
+}
+
+type logBrowserLine struct {
+	entry, level string
+	at           time.Time
+}
+
+// logBrowserEventsMsg is a batch of events from stream gen
+type logBrowserEventsMsg struct {
+	gen   int
+	batch eventsMsg
+}
+
+// logBrowserDoneMsg reports that stream gen ended
+type logBrowserDoneMsg struct {
+	gen int
+	err error
+}
+
+// logCapturesDir holds console windows saved from the log browser
+func logCapturesDir(projectRoot string) string {
+	return filepath.Join(projectRoot, ".xcbolt", "Captures")
+}
+
+// openLogDestinationSelector starts the log browser: pick a destination,
+// then the bundle id / predicate form
+func (m *Model) openLogDestinationSelector() {
+	if m.running {
+		m.setStatus("An operation is already running")
+		return
+	}
+	items, selectedID := m.destinationSelectorItems()
+	m.selector = NewSelectorWithSelected("Stream Logs From", items, selectedID, m.width, m.styles)
+	m.selectorType = SelectorLogDestination
+	m.mode = ModeSelector
+}
+
+func (m *Model) pickLogDestination(item *SelectorItem) tea.Cmd {
+	dst, ok := m.destinationForItem(item)
+	if !ok {
+		return nil
+	}
+	bundleID := m.logBrowser.BundleID
+	if bundleID == "" {
+		bundleID = m.lastRun.BundleID
+	}
+	m.openLogForm(dst, bundleID, m.logBrowser.Predicate, false)
+	return nil
+}
+
+// =============================================================================
+// Form
+// =============================================================================
+
+// logForm asks for the bundle id and predicate of a log stream
+type logForm struct {
+	dst     core.Destination
+	inputs  []textinput.Model
+	focus   int
+	restart bool // Changing the predicate of the open browser
+}
+
+func (m *Model) openLogForm(dst core.Destination, bundleID, predicate string, restart bool) {
+	bundle := textinput.New()
+	bundle.Placeholder = "com.example.App (optional)"
+	bundle.CharLimit = 200
+	bundle.SetValue(bundleID)
+	bundle.Focus()
+
+	pred := textinput.New()
+	pred.Placeholder = `e.g. category == "network" (optional)`
+	pred.CharLimit = 500
+	pred.SetValue(predicate)
+
+	m.logForm = logForm{dst: dst, inputs: []textinput.Model{bundle, pred}, restart: restart}
+	m.mode = ModeLogForm
+}
+
+func (m *Model) handleLogFormKey(msg tea.KeyMsg) tea.Cmd {
+	f := &m.logForm
+	switch msg.String() {
+	case "esc":
+		if f.restart {
+			m.mode = ModeLogBrowser
+		} else {
+			m.mode = ModeNormal
+			m.setStatus("Cancelled")
+		}
+		return nil
+	case "tab", "shift+tab", "up", "down":
+		f.inputs[f.focus].Blur()
+		f.focus = (f.focus + 1) % len(f.inputs)
+		return f.inputs[f.focus].Focus()
+	case "enter":
+		bundleID := strings.TrimSpace(f.inputs[0].Value())
+		predicate := strings.TrimSpace(f.inputs[1].Value())
+		if f.restart {
+			m.mode = ModeLogBrowser
+			return m.changeLogPredicate(bundleID, predicate)
+		}
+		return m.startLogBrowser(f.dst, bundleID, predicate)
+	}
+	var cmd tea.Cmd
+	f.inputs[f.focus], cmd = f.inputs[f.focus].Update(msg)
+	return cmd
+}
+
+func (m Model) logFormOverlayView() string {
+	s := m.styles
+	width := minInt(maxInt(m.width*60/100, 50), 90)
+	f := m.logForm
+
+	var b strings.Builder
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(s.Colors.Text)
+	title := "Stream logs: " + f.dst.Name
+	if f.restart {
+		title = "Change log filter: " + f.dst.Name
+	}
+	b.WriteString(titleStyle.Render(truncateString(title, width-6)))
+	b.WriteString("\n")
+	dividerStyle := lipgloss.NewStyle().Foreground(s.Colors.BorderMuted)
+	b.WriteString(dividerStyle.Render(strings.Repeat("─", width-6)))
+	b.WriteString("\n")
+
+	labelStyle := lipgloss.NewStyle().Foreground(s.Colors.TextMuted)
+	focusStyle := lipgloss.NewStyle().Foreground(s.Colors.Accent).Bold(true)
+	for i, label := range []string{"Bundle id", "Predicate"} {
+		style := labelStyle
+		if i == f.focus {
+			style = focusStyle
+		}
+		input := f.inputs[i]
+		input.Width = width - 10
+		b.WriteString(style.Render(label))
+		b.WriteString("\n")
+		b.WriteString(input.View())
+		b.WriteString("\n\n")
+	}
+
+	hintKeyStyle := lipgloss.NewStyle().Foreground(s.Colors.Accent)
+	hintDescStyle := lipgloss.NewStyle().Foreground(s.Colors.TextSubtle)
+	b.WriteString(hintKeyStyle.Render("enter") + hintDescStyle.Render(" stream  ") +
+		hintKeyStyle.Render("tab") + hintDescStyle.Render(" next field  ") +
+		hintKeyStyle.Render("esc") + hintDescStyle.Render(" cancel"))
+
+	containerStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(s.Colors.Border).
+		Padding(1, 2)
+	return RenderCenteredPopup(containerStyle.Width(width).Render(b.String()), m.width, m.height)
+}
+
+// =============================================================================
+// Streaming
+// =============================================================================
+
+func (m *Model) startLogBrowser(dst core.Destination, bundleID, predicate string) tea.Cmd {
+	lb := &m.logBrowser
+	lb.Active = true
+	lb.Destination = dst
+	lb.BundleID = bundleID
+	lb.Predicate = predicate
+	lb.Paused = false
+	lb.pending = nil
+	lb.matches = nil
+	lb.Console.Reset()
+	m.mode = ModeLogBrowser
+	m.setStatus("Streaming logs from " + dst.Name)
+	return m.startLogStream()
+}
+
+// changeLogPredicate restarts the stream with a new filter, keeping the
+// lines captured so far
+func (m *Model) changeLogPredicate(bundleID, predicate string) tea.Cmd {
+	lb := &m.logBrowser
+	if bundleID == lb.BundleID && predicate == lb.Predicate && lb.Streaming {
+		return nil
+	}
+	lb.BundleID = bundleID
+	lb.Predicate = predicate
+	m.appendLogBrowserLine(logBrowserLine{entry: consoleSystemPrefix + "[xcbolt] Filter: " + m.logBrowserFilterLabel(), at: time.Now()})
+	m.setStatus("Log filter applied")
+	return m.startLogStream()
+}
+
+// startLogStream (re)starts streaming with the current filter
+func (m *Model) startLogStream() tea.Cmd {
+	lb := &m.logBrowser
+	m.stopLogStream()
+	lb.gen++
+	gen := lb.gen
+	ctx, cancel := context.WithCancel(context.Background())
+	stop := make(chan struct{})
+	lb.cancel = cancel
+	lb.stop = stop
+	lb.Streaming = true
+
+	emitter := &chanEmitter{ch: make(chan core.Event, 8192), stop: stop, redact: m.redactor}
+	lb.events = emitter
+	dst := lb.Destination
+	predicate := core.UnifiedLogPredicate(lb.BundleID, lb.Predicate)
+	preserveLocale := m.cfg.Xcodebuild.PreserveLocale
+	done := make(chan error, 1)
+	go func() {
+		done <- core.StreamDestinationLogs(ctx, dst, predicate, emitter, preserveLocale)
+	}()
+	return tea.Batch(waitForLogBrowserEvents(emitter, gen), func() tea.Msg {
+		return logBrowserDoneMsg{gen: gen, err: <-done}
+	})
+}
+
+func waitForLogBrowserEvents(e *chanEmitter, gen int) tea.Cmd {
+	wait := waitForEvent(e)
+	return func() tea.Msg {
+		batch, ok := wait().(eventsMsg)
+		if !ok {
+			return nil
+		}
+		return logBrowserEventsMsg{gen: gen, batch: batch}
+	}
+}
+
+// stopLogStream cancels the running stream, if any
+func (m *Model) stopLogStream() {
+	lb := &m.logBrowser
+	if lb.cancel != nil {
+		lb.cancel()
+		lb.cancel = nil
+	}
+	if lb.stop != nil {
+		close(lb.stop)
+		lb.stop = nil
+	}
+	lb.events = nil
+	lb.Streaming = false
+}
+
+// closeLogBrowser stops streaming and returns to the dashboard
+func (m *Model) closeLogBrowser() {
+	m.stopLogStream()
+	m.logBrowser.Active = false
+	m.logBrowser.pending = nil
+	m.logBrowser.matches = nil
+	m.mode = ModeNormal
+	m.selectTab(TabDashboard)
+	m.setStatus("Log stream stopped")
+}
+
+func (m *Model) handleLogBrowserEvents(msg logBrowserEventsMsg) tea.Cmd {
+	lb := &m.logBrowser
+	if !lb.Active || msg.gen != lb.gen || lb.stop == nil {
+		return nil
+	}
+	for _, ev := range msg.batch.events {
+		at := time.Now()
+		if t, ok := ev.Time(); ok {
+			at = t
+		}
+		line := logBrowserLine{at: at}
+		if isConsoleEvent(ev) {
+			line.entry = m.formatConsoleEvent(ev)
+			line.level = consoleEventLevel(line.entry, ev)
+		} else {
+			// log(1)/simctl/devicectl stderr
+			line.entry = consoleSystemPrefix + "[xcbolt] " + ev.Msg
+		}
+		m.appendLogBrowserLine(line)
+	}
+	if msg.batch.dropped > 0 {
+		m.appendLogBrowserLine(logBrowserLine{entry: consoleSystemPrefix + "[xcbolt] " + droppedEventsNotice(msg.batch.dropped, ""), at: time.Now()})
+	}
+	return waitForLogBrowserEvents(lb.events, lb.gen)
+}
+
+func (m *Model) handleLogBrowserDone(msg logBrowserDoneMsg) {
+	lb := &m.logBrowser
+	if msg.gen != lb.gen {
+		return
+	}
+	wasStreaming := lb.Streaming
+	m.stopLogStream()
+	if !lb.Active || !wasStreaming {
+		return
+	}
+	text := "Log stream ended"
+	if msg.err != nil && !isCanceledErr(msg.err) {
+		text += ": " + msg.err.Error()
+	}
+	m.appendLogBrowserLine(logBrowserLine{entry: consoleSystemPrefix + "[xcbolt] " + text, at: time.Now()})
+	m.setStatus(text)
+}
+
+// appendLogBrowserLine adds a line, or holds it back while paused
+func (m *Model) appendLogBrowserLine(line logBrowserLine) {
+	lb := &m.logBrowser
+	if lb.Paused {
+		lb.pending = append(lb.pending, line)
+		if maxConsoleLines > 0 && len(lb.pending) > maxConsoleLines {
+			lb.pending = lb.pending[len(lb.pending)-maxConsoleLines:]
+		}
+		return
+	}
+	c := &lb.Console
+	c.Append(line.entry, line.level, line.at, func(msg string) []string {
+		return wrapConsoleText(msg, m.layout.ContentWidth()-2)
+	})
+	c.Trim(maxConsoleLines, m.consoleLevelVisible)
+	c.Settle(m.consoleMaxPos())
+}
+
+// toggleLogBrowserPause freezes the view; held lines are added on resume
+func (m *Model) toggleLogBrowserPause() {
+	lb := &m.logBrowser
+	lb.Paused = !lb.Paused
+	if lb.Paused {
+		m.setStatus("Paused")
+		return
+	}
+	pending := lb.pending
+	lb.pending = nil
+	for _, line := range pending {
+		m.appendLogBrowserLine(line)
+	}
+	m.setStatus(fmt.Sprintf("Resumed (%d new lines)", len(pending)))
+}
+
+// saveLogBrowser writes the captured lines to .xcbolt/Captures
+func (m *Model) saveLogBrowser() {
+	c := &m.logBrowser.Console
+	if len(c.Lines) == 0 {
+		m.setStatus("Nothing to save yet")
+		return
+	}
+	dir := logCapturesDir(m.projectRoot)
+	path := filepath.Join(dir, "unified-"+time.Now().Format("20060102-150405")+".log")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		m.setStatus("Could not save logs: " + err.Error())
+		return
+	}
+	if err := os.WriteFile(path, []byte(c.Text()), 0o644); err != nil {
+		m.setStatus("Could not save logs: " + err.Error())
+		return
+	}
+	rel, err := filepath.Rel(m.projectRoot, path)
+	if err != nil {
+		rel = path
+	}
+	m.setStatus(fmt.Sprintf("Saved %d lines to %s", len(c.Lines), rel))
+}
+
+// =============================================================================
+// Keys & View
+// =============================================================================
+
+func (m *Model) handleLogBrowserKey(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case keyMatches(msg, m.keys.Quit):
+		m.stopLogStream()
+		return m.quitGracefully()
+	case msg.String() == "esc", keyMatches(msg, m.keys.Cancel):
+		m.closeLogBrowser()
+	case msg.String() == " ", msg.String() == "p":
+		m.toggleLogBrowserPause()
+	case msg.String() == "w":
+		m.saveLogBrowser()
+	case msg.String() == "e":
+		lb := m.logBrowser
+		m.openLogForm(lb.Destination, lb.BundleID, lb.Predicate, true)
+	case keyMatches(msg, m.keys.ConsoleFilter):
+		m.cycleConsoleFilter()
+	case keyMatches(msg, m.keys.Search):
+		m.enterSearchMode()
+	case msg.String() == "n":
+		m.nextSearchMatch()
+	case msg.String() == "N":
+		m.prevSearchMatch()
+	case keyMatches(msg, m.keys.ScrollUp):
+		m.scrollConsole(-1)
+	case keyMatches(msg, m.keys.ScrollDown):
+		m.scrollConsole(1)
+	case keyMatches(msg, m.keys.ScrollTop):
+		m.scrollConsole(-m.logBrowser.Console.Pos)
+	case keyMatches(msg, m.keys.ScrollBottom):
+		m.console().ScrollToEnd(m.consoleMaxPos())
+	case keyMatches(msg, m.keys.PageUp):
+		m.scrollConsole(-m.consolePaneHeight())
+	case keyMatches(msg, m.keys.PageDown):
+		m.scrollConsole(m.consolePaneHeight())
+	case keyMatches(msg, m.keys.HalfPageUp):
+		m.scrollConsole(-m.consolePaneHeight() / 2)
+	case keyMatches(msg, m.keys.HalfPageDown):
+		m.scrollConsole(m.consolePaneHeight() / 2)
+	}
+	return nil
+}
+
+// logBrowserHeight is the height of the full-screen console
+func (m Model) logBrowserHeight() int {
+	return m.layout.ContentHeight()
+}
+
+// logBrowserFilterLabel describes what the stream is limited to
+func (m Model) logBrowserFilterLabel() string {
+	lb := m.logBrowser
+	var parts []string
+	if lb.BundleID != "" {
+		parts = append(parts, lb.BundleID)
+	}
+	if lb.Predicate != "" {
+		parts = append(parts, lb.Predicate)
+	}
+	if len(parts) == 0 {
+		return "all processes"
+	}
+	return strings.Join(parts, " · ")
+}
+
+func (m Model) logBrowserHeader() string {
+	s := m.styles
+	lb := m.logBrowser
+	labelStyle := lipgloss.NewStyle().Foreground(s.Colors.TextMuted)
+	status := "Streaming"
+	switch {
+	case lb.Paused:
+		status = fmt.Sprintf("Paused — %d new lines", len(lb.pending))
+	case !lb.Streaming:
+		status = "Stopped"
+	}
+	header := labelStyle.Render(lb.Destination.Name + " · " + m.logBrowserFilterLabel() + " · " + status)
+	if filter := m.consoleFilterLabel(); filter != "" {
+		header += labelStyle.Render(" · ") + lipgloss.NewStyle().Foreground(s.Colors.Warning).Render(filter)
+	}
+	return header
+}
+
+// logBrowserContent renders the full-screen console
+func (m Model) logBrowserContent() string {
+	return m.logBrowser.Console.View(consoleViewOptions{
+		Width:      m.layout.ContentWidth(),
+		Height:     m.logBrowserHeight(),
+		Header:     m.logBrowserHeader(),
+		Visible:    m.visibleConsoleIndices(),
+		Timestamps: m.tabView.StreamTab.ShowTimestamps,
+		Styles:     m.styles,
+	})
+}
+
+func (m Model) logBrowserView() string {
+	m.syncStatusBarState()
+	statusBarContent := m.statusBar.ViewWithMinimal(m.width, m.styles, m.layout.MinimalMode)
+	m.layout.ShowProgressBar = false
+
+	pause := "pause"
+	if m.logBrowser.Paused {
+		pause = "resume"
+	}
+	hints := m.hintsBar.renderHints([]HintItem{
+		{Key: "space", Desc: pause},
+		{Key: "/", Desc: "search"},
+		{Key: "l", Desc: "filter"},
+		{Key: "e", Desc: "predicate"},
+		{Key: "w", Desc: "save"},
+		{Key: "esc", Desc: "stop"},
+	}, m.styles)
+
+	return m.layout.RenderFullLayout(
+		statusBarContent,
+		"",
+		m.logBrowserContent(),
+		hints,
+		m.styles,
+	)
+}
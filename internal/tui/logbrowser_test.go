@@ -0,0 +1,181 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func newLogBrowserModel(t *testing.T) Model {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.width, m.height = 120, 30
+	m.layout.Width, m.layout.Height = 120, 30
+	// An unsupported kind ends the stream at once; tests feed events directly
+	m.startLogBrowser(core.Destination{Name: "Test Device", Kind: "test"}, "com.example.Notes", "")
+	return m
+}
+
+func logBrowserEvents(gen int, lines ...string) logBrowserEventsMsg {
+	msg := logBrowserEventsMsg{gen: gen}
+	for _, line := range lines {
+		msg.batch.events = append(msg.batch.events, core.LogStream("run", line, "unified"))
+	}
+	return msg
+}
+
+func TestLogBrowserStreamsIntoItsOwnConsole(t *testing.T) {
+	m := newLogBrowserModel(t)
+	if m.mode != ModeLogBrowser || !m.logBrowser.Active {
+		t.Fatalf("expected the log browser, mode = %v", m.mode)
+	}
+	m.handleLogBrowserEvents(logBrowserEvents(m.logBrowser.gen,
+		"12:00:01.000 I Notes[42]\nsynced 3 notes",
+		"12:00:02.000 E Notes[42]\nupload failed",
+	))
+	if got := len(m.logBrowser.Console.Lines); got != 4 {
+		t.Fatalf("expected 4 console lines, got %d: %q", got, m.logBrowser.Console.Lines)
+	}
+	if len(m.runMode.Console.Lines) != 0 {
+		t.Fatalf("run console should stay empty: %q", m.runMode.Console.Lines)
+	}
+
+	view := stripANSI(m.View())
+	for _, want := range []string{"Test Device · com.example.Notes · Streaming", "upload failed", "space:pause"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("view missing %q:\n%s", want, view)
+		}
+	}
+
+	m.cycleConsoleFilter() // Warnings+
+	if got := m.consoleLineCount(); got != 2 {
+		t.Fatalf("expected only the error entry with Warnings+, got %d lines", got)
+	}
+}
+
+func TestLogBrowserIgnoresStaleStreams(t *testing.T) {
+	m := newLogBrowserModel(t)
+	stale := m.logBrowser.gen
+	m.changeLogPredicate("com.example.Notes", `category == "sync"`)
+	if m.logBrowser.gen == stale {
+		t.Fatal("changing the predicate should restart the stream")
+	}
+	divider := len(m.logBrowser.Console.Lines)
+	if divider != 1 || !strings.Contains(m.logBrowser.Console.Lines[0], `Filter: com.example.Notes · category == "sync"`) {
+		t.Fatalf("expected a filter divider, got %q", m.logBrowser.Console.Lines)
+	}
+
+	m.handleLogBrowserEvents(logBrowserEvents(stale, "12:00:01.000 I Notes[42]\nold stream"))
+	m.handleLogBrowserDone(logBrowserDoneMsg{gen: stale})
+	if len(m.logBrowser.Console.Lines) != divider || !m.logBrowser.Streaming {
+		t.Fatalf("stale stream changed the browser: %q", m.logBrowser.Console.Lines)
+	}
+}
+
+func TestLogBrowserPauseHoldsLines(t *testing.T) {
+	m := newLogBrowserModel(t)
+	m.handleKeyPress(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	if !m.logBrowser.Paused {
+		t.Fatal("space should pause")
+	}
+	m.handleLogBrowserEvents(logBrowserEvents(m.logBrowser.gen, "12:00:01.000 I Notes[42]\nwhile paused"))
+	if len(m.logBrowser.Console.Lines) != 0 || len(m.logBrowser.pending) != 1 {
+		t.Fatalf("paused browser should hold lines: %d shown, %d pending", len(m.logBrowser.Console.Lines), len(m.logBrowser.pending))
+	}
+	if view := stripANSI(m.View()); !strings.Contains(view, "Paused — 1 new lines") {
+		t.Fatalf("header should count held lines:\n%s", view)
+	}
+
+	m.toggleLogBrowserPause()
+	if len(m.logBrowser.Console.Lines) != 2 || len(m.logBrowser.pending) != 0 {
+		t.Fatalf("resume should flush held lines: %q", m.logBrowser.Console.Lines)
+	}
+}
+
+func TestLogBrowserSavesCapturedWindow(t *testing.T) {
+	m := newLogBrowserModel(t)
+	m.handleLogBrowserEvents(logBrowserEvents(m.logBrowser.gen, "12:00:01.000 I Notes[42]\nsynced 3 notes"))
+	m.saveLogBrowser()
+
+	files, _ := filepath.Glob(filepath.Join(logCapturesDir(m.projectRoot), "unified-*.log"))
+	if len(files) != 1 {
+		t.Fatalf("expected one capture, got %v (status %q)", files, m.statusMsg)
+	}
+	data, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "12:00:01.000 I Notes[42]\nsynced 3 notes\n" {
+		t.Fatalf("unexpected capture:\n%s", data)
+	}
+	if !strings.HasPrefix(m.statusMsg, "Saved 2 lines to .xcbolt/Captures/unified-") {
+		t.Fatalf("unexpected status %q", m.statusMsg)
+	}
+}
+
+func TestLogBrowserSearch(t *testing.T) {
+	m := newLogBrowserModel(t)
+	var lines []string
+	for i := 0; i < 60; i++ {
+		lines = append(lines, "12:00:01.000 I Notes[42]\nheartbeat")
+	}
+	lines = append(lines, "12:00:02.000 E Notes[42]\nUpload Failed")
+	lines = append(lines, "12:00:03.000 I Notes[42]\nheartbeat")
+	m.handleLogBrowserEvents(logBrowserEvents(m.logBrowser.gen, lines...))
+
+	m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	if m.mode != ModeSearch {
+		t.Fatalf("expected search mode, got %v", m.mode)
+	}
+	m.searchInput.SetValue("upload")
+	m.executeSearch()
+	if len(m.logBrowser.matches) != 1 {
+		t.Fatalf("expected one match, got %v", m.logBrowser.matches)
+	}
+	c := m.logBrowser.Console
+	if c.Follow || c.Highlight == 0 || consolePlainLine(c.Lines[c.IndexOfID(c.Highlight)]) != "Upload Failed" {
+		t.Fatalf("search should jump to and flash the match: %+v", c.Highlight)
+	}
+
+	m.exitSearchMode(false)
+	if m.mode != ModeLogBrowser {
+		t.Fatalf("search should return to the log browser, got %v", m.mode)
+	}
+}
+
+func TestLogBrowserStopReturnsToDashboard(t *testing.T) {
+	m := newLogBrowserModel(t)
+	m.selectTab(TabIssues)
+	m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.logBrowser.Active || m.logBrowser.Streaming || m.mode != ModeNormal {
+		t.Fatalf("esc should stop the browser: active=%v mode=%v", m.logBrowser.Active, m.mode)
+	}
+	if m.tabView.ActiveTab != TabDashboard {
+		t.Fatalf("expected the Dashboard, got %v", m.tabView.ActiveTab)
+	}
+}
+
+func TestLogFormStartsBrowser(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.openLogForm(core.Destination{Name: "My Mac", Kind: "test"}, "", "", false)
+	for _, r := range "com.example.Notes" {
+		m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	m.handleKeyPress(tea.KeyMsg{Type: tea.KeyTab})
+	for _, r := range `category == "sync"` {
+		m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	lb := m.logBrowser
+	if !lb.Active || lb.BundleID != "com.example.Notes" || lb.Predicate != `category == "sync"` {
+		t.Fatalf("form did not start the browser: %+v", lb)
+	}
+	m.closeLogBrowser()
+}
@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/xcbolt/xcbolt/internal/core"
 )
 
 // =============================================================================
@@ -20,6 +22,8 @@ type BuildPhase struct {
 	Status    PhaseStatus // Current status
 	FileCount int         // Number of files processed (for display)
 	HasError  bool        // Quick flag for error presence
+
+	issues []int // Indexes of the error/warning lines, for scrollbar markers
 }
 
 // PhaseStatus represents the build phase status
@@ -69,6 +73,7 @@ type PhaseView struct {
 	AutoFollow      bool
 	StickyHeader    bool
 	ShowErrorsOnly  bool
+	ContextLines    int // Lines kept around each issue in errors-only mode
 	Width           int
 	RenderMode      PhaseRenderMode
 	LastRenderLines int
@@ -81,6 +86,12 @@ type PhaseView struct {
 	SmartCollapse bool // Auto-collapse clean phases, expand errors
 	ShowRawMode   bool // Show raw logs instead of grouped
 
+	// Continuation lines of the last diagnostic (excerpts, carets, notes)
+	cont diagContinuation
+
+	// Issue cluster last jumped to with n/N (-1 for none)
+	clusterCursor int
+
 	// Search state
 	SearchQuery   string          // Current search query
 	SearchMatches []SearchMatch   // Search match positions
@@ -111,6 +122,8 @@ func NewPhaseView() PhaseView {
 		AutoFollow:    true,
 		StickyHeader:  true,
 		RenderMode:    PhaseRenderGrouped,
+		ContextLines:  core.DefaultIssueContextLines,
+		clusterCursor: -1,
 	}
 }
 
@@ -122,6 +135,8 @@ func (v *PhaseView) Clear() {
 	v.SelectedPhase = -1
 	v.AutoFollow = true
 	v.LastRenderLines = 0
+	v.cont = diagContinuation{}
+	v.clusterCursor = -1
 }
 
 // SetSize sets the visible dimensions
@@ -154,12 +169,32 @@ func (v *PhaseView) AddLine(line string) {
 
 	// Add to current phase (or create default)
 	logLine := categorizeLogLine(line)
+	if continued, _ := v.cont.Continues(line); continued {
+		logLine = LogLine{Text: line, Type: LogLineInfo}
+	} else {
+		v.cont.StartAt(line)
+	}
 	v.addToCurrentPhase(logLine)
 
 	// Auto-scroll if at bottom
 	v.autoScroll()
 }
 
+// AddInfoLine adds a line that is always shown as info, never as an issue
+func (v *PhaseView) AddInfoLine(line string) {
+	if strings.TrimSpace(line) == "" {
+		return
+	}
+
+	v.FlatLines = append(v.FlatLines, line)
+	if len(v.FlatLines) > 2000 {
+		v.FlatLines = v.FlatLines[len(v.FlatLines)-2000:]
+	}
+
+	v.addToCurrentPhase(LogLine{Text: line, Type: LogLineInfo})
+	v.autoScroll()
+}
+
 // ensurePhase creates or activates a phase
 func (v *PhaseView) ensurePhase(name string) {
 	// Check if phase already exists
@@ -234,6 +269,9 @@ func (v *PhaseView) addToCurrentPhase(line LogLine) {
 	for i := range v.Phases {
 		if v.Phases[i].Status == PhaseRunning {
 			v.Phases[i].Lines = append(v.Phases[i].Lines, line)
+			if isIssueLogLine(line) {
+				v.Phases[i].issues = append(v.Phases[i].issues, len(v.Phases[i].Lines)-1)
+			}
 
 			// Update phase status based on line type
 			if line.Type == LogLineError || line.Type == LogLineTestFail {
@@ -253,11 +291,15 @@ func (v *PhaseView) addToCurrentPhase(line LogLine) {
 
 	// No running phase - create a default "Build" phase
 	if len(v.Phases) == 0 {
-		v.Phases = append(v.Phases, BuildPhase{
+		phase := BuildPhase{
 			Name:   "Build",
 			Lines:  []LogLine{line},
 			Status: PhaseRunning,
-		})
+		}
+		if isIssueLogLine(line) {
+			phase.issues = []int{0}
+		}
+		v.Phases = append(v.Phases, phase)
 	}
 }
 
@@ -342,12 +384,11 @@ func (v *PhaseView) totalLines() int {
 	count := 0
 	for _, phase := range v.Phases {
 		if v.ShowErrorsOnly {
-			matchCount := v.matchingLineCount(phase)
-			if matchCount == 0 {
+			if v.matchingLineCount(phase) == 0 {
 				continue
 			}
 			count++ // Phase header
-			count += matchCount
+			count += len(issueClusterRows(v.issueClusters(phase)))
 			continue
 		}
 
@@ -376,13 +417,139 @@ func (v *PhaseView) visibleRowsForContent() int {
 func (v *PhaseView) matchingLineCount(phase BuildPhase) int {
 	count := 0
 	for _, line := range phase.Lines {
-		if line.Type == LogLineError || line.Type == LogLineWarning || line.Type == LogLineTestFail {
+		if isIssueLogLine(line) {
 			count++
 		}
 	}
 	return count
 }
 
+func isIssueLogLine(line LogLine) bool {
+	return line.Type == LogLineError || line.Type == LogLineWarning || line.Type == LogLineTestFail
+}
+
+// issueClusters returns the phase's issue lines with ContextLines around each
+func (v *PhaseView) issueClusters(phase BuildPhase) []issueCluster {
+	return issueClusters(len(phase.Lines), v.ContextLines, func(i int) bool { return isIssueLogLine(phase.Lines[i]) })
+}
+
+// clusterStarts returns the position of every issue cluster in the grouped
+// layout, as (phase, first row of the cluster)
+func (v *PhaseView) clusterStarts() (phases []int, rows []int) {
+	row := 0
+	for i, phase := range v.Phases {
+		if v.ShowErrorsOnly && v.matchingLineCount(phase) == 0 {
+			continue
+		}
+		row++ // Phase header
+		clusters := v.issueClusters(phase)
+		if v.ShowErrorsOnly {
+			for ci := range clusters {
+				phases = append(phases, i)
+				rows = append(rows, row)
+				row += clusters[ci].End - clusters[ci].Start + 1 // + separator
+			}
+			if len(clusters) > 0 {
+				row--
+			}
+			continue
+		}
+		if phase.Collapsed {
+			continue
+		}
+		for _, c := range clusters {
+			phases = append(phases, i)
+			rows = append(rows, row+c.Start)
+		}
+		row += len(phase.Lines)
+	}
+	return phases, rows
+}
+
+// JumpToCluster scrolls to the next (dir > 0) or previous issue cluster,
+// wrapping around. Phases with issues are expanded first so every cluster is
+// reachable. It returns the 1-based cluster number and the total.
+func (v *PhaseView) JumpToCluster(dir int) (int, int, bool) {
+	if !v.ShowErrorsOnly {
+		for i := range v.Phases {
+			if v.matchingLineCount(v.Phases[i]) > 0 {
+				v.Phases[i].Collapsed = false
+			}
+		}
+	}
+	_, rows := v.clusterStarts()
+	if len(rows) == 0 {
+		return 0, 0, false
+	}
+	target := v.clusterCursor + dir
+	if v.clusterCursor < 0 || v.clusterCursor >= len(rows) {
+		target = 0
+		if dir < 0 {
+			target = len(rows) - 1
+		}
+	} else if target < 0 {
+		target = len(rows) - 1
+	} else if target >= len(rows) {
+		target = 0
+	}
+	v.clusterCursor = target
+	v.AutoFollow = false
+	v.ScrollPos = rows[target]
+	return target + 1, len(rows), true
+}
+
+// markers returns the grouped layout rows of errors and warnings; a collapsed
+// phase marks its header. Raw and card views have none.
+func (v *PhaseView) markers() []scrollMarker {
+	if v.ShowRawMode || v.RenderMode == PhaseRenderCards {
+		return nil
+	}
+	var markers []scrollMarker
+	row := 0
+	for _, phase := range v.Phases {
+		if v.ShowErrorsOnly {
+			if v.matchingLineCount(phase) == 0 {
+				continue
+			}
+			row++ // Phase header
+			for _, j := range issueClusterRows(v.issueClusters(phase)) {
+				if j >= 0 && isIssueLogLine(phase.Lines[j]) {
+					markers = append(markers, scrollMarker{Row: row, Error: phase.Lines[j].Type != LogLineWarning})
+				}
+				row++
+			}
+			continue
+		}
+
+		header := row
+		row++
+		if phase.Collapsed {
+			if len(phase.issues) > 0 {
+				markers = append(markers, scrollMarker{Row: header, Error: phase.HasError})
+			}
+			continue
+		}
+		for _, j := range phase.issues {
+			markers = append(markers, scrollMarker{Row: row + j, Error: phase.Lines[j].Type != LogLineWarning})
+		}
+		row += len(phase.Lines)
+	}
+	return markers
+}
+
+// JumpToMarker scrolls to the nearest error or warning below (dir > 0) or
+// above the view. It reports false when there's none that way.
+func (v *PhaseView) JumpToMarker(dir int) bool {
+	row, ok := nearestMarker(v.markers(), v.ScrollPos, v.ScrollPos+v.visibleRowsForContent(), dir)
+	if !ok {
+		return false
+	}
+	v.AutoFollow = false
+	v.clusterCursor = -1
+	v.ScrollPos = centeredScrollPos(row, v.visibleRowsForContent(), v.totalLines()-v.visibleRowsForContent())
+	return true
+}
+
 // SelectNextPhase moves to next phase
 func (v *PhaseView) SelectNextPhase() {
 	if len(v.Phases) == 0 {
@@ -437,109 +604,6 @@ func (v *PhaseView) ToggleRawMode() {
 // Error/Match Navigation
 // =============================================================================
 
-// FindNextError finds the next error line and returns its phase and line index
-func (v *PhaseView) FindNextError(currentPhase, currentLine int) (int, int) {
-	if len(v.Phases) == 0 {
-		return -1, -1
-	}
-	if currentPhase < 0 {
-		currentPhase = 0
-		currentLine = -1
-	} else if currentPhase >= len(v.Phases) {
-		currentPhase = len(v.Phases) - 1
-		currentLine = len(v.Phases[currentPhase].Lines) - 1
-	}
-	if currentLine < -1 {
-		currentLine = -1
-	}
-	if currentLine >= len(v.Phases[currentPhase].Lines) {
-		currentLine = len(v.Phases[currentPhase].Lines) - 1
-	}
-	// Start searching from current position
-	for p := currentPhase; p < len(v.Phases); p++ {
-		startLine := 0
-		if p == currentPhase {
-			startLine = currentLine + 1
-		}
-		for l := startLine; l < len(v.Phases[p].Lines); l++ {
-			if v.Phases[p].Lines[l].Type == LogLineError ||
-				v.Phases[p].Lines[l].Type == LogLineTestFail {
-				// Expand this phase
-				v.Phases[p].Collapsed = false
-				return p, l
-			}
-		}
-	}
-
-	// Wrap around to beginning
-	for p := 0; p <= currentPhase; p++ {
-		endLine := len(v.Phases[p].Lines)
-		if p == currentPhase {
-			endLine = currentLine
-		}
-		for l := 0; l < endLine; l++ {
-			if v.Phases[p].Lines[l].Type == LogLineError ||
-				v.Phases[p].Lines[l].Type == LogLineTestFail {
-				v.Phases[p].Collapsed = false
-				return p, l
-			}
-		}
-	}
-
-	return -1, -1
-}
-
-// FindPrevError finds the previous error line
-func (v *PhaseView) FindPrevError(currentPhase, currentLine int) (int, int) {
-	if len(v.Phases) == 0 {
-		return -1, -1
-	}
-	if currentPhase < 0 {
-		currentPhase = 0
-	}
-	if currentPhase >= len(v.Phases) {
-		currentPhase = len(v.Phases) - 1
-		currentLine = len(v.Phases[currentPhase].Lines)
-	}
-	if currentLine < 0 {
-		currentLine = 0
-	}
-	if currentLine > len(v.Phases[currentPhase].Lines) {
-		currentLine = len(v.Phases[currentPhase].Lines)
-	}
-	// Search backwards from current position
-	for p := currentPhase; p >= 0; p-- {
-		endLine := len(v.Phases[p].Lines) - 1
-		if p == currentPhase {
-			endLine = currentLine - 1
-		}
-		for l := endLine; l >= 0; l-- {
-			if v.Phases[p].Lines[l].Type == LogLineError ||
-				v.Phases[p].Lines[l].Type == LogLineTestFail {
-				v.Phases[p].Collapsed = false
-				return p, l
-			}
-		}
-	}
-
-	// Wrap around to end
-	for p := len(v.Phases) - 1; p >= currentPhase; p-- {
-		startLine := 0
-		if p == currentPhase {
-			startLine = currentLine + 1
-		}
-		for l := len(v.Phases[p].Lines) - 1; l >= startLine; l-- {
-			if v.Phases[p].Lines[l].Type == LogLineError ||
-				v.Phases[p].Lines[l].Type == LogLineTestFail {
-				v.Phases[p].Collapsed = false
-				return p, l
-			}
-		}
-	}
-
-	return -1, -1
-}
-
 // ErrorCount returns total error count
 func (v *PhaseView) ErrorCount() int {
 	count := 0
@@ -677,7 +741,7 @@ func (v *PhaseView) View(styles Styles) string {
 	if contentWidth < 1 {
 		return content
 	}
-	return withScrollbar(content, v.VisibleRows, contentWidth, v.totalLines(), v.ScrollPos, styles)
+	return withScrollbar(content, v.VisibleRows, contentWidth, v.totalLines(), v.ScrollPos, styles, v.markers()...)
 }
 
 // renderRaw renders flat log lines
@@ -717,7 +781,7 @@ func (v PhaseView) renderGrouped(styles Styles) string {
 	icons := styles.Icons
 	var allLines []string
 	var linePhases []int
-	headerIndex := map[int]int{}
+	headerIndex := v.HeaderRows()
 
 	for i, phase := range v.Phases {
 		isSelected := v.PhaseMode && i == v.SelectedPhase
@@ -730,17 +794,22 @@ func (v PhaseView) renderGrouped(styles Styles) string {
 		header := v.renderPhaseHeader(phase, isSelected, styles, icons)
 		allLines = append(allLines, header)
 		linePhases = append(linePhases, i)
-		headerIndex[i] = len(allLines) - 1
+
+		if v.ShowErrorsOnly {
+			for _, j := range issueClusterRows(v.issueClusters(phase)) {
+				if j < 0 {
+					allLines = append(allLines, "  "+v.renderClusterSeparator(styles))
+				} else {
+					allLines = append(allLines, "  "+v.renderLogLine(phase.Lines[j], v.IsHighlighted(i, j), styles, icons))
+				}
+				linePhases = append(linePhases, i)
+			}
+			continue
+		}
 
 		// Render phase content if expanded
 		for j, line := range phase.Lines {
-			if v.ShowErrorsOnly &&
-				line.Type != LogLineError &&
-				line.Type != LogLineWarning &&
-				line.Type != LogLineTestFail {
-				continue
-			}
-			if !v.ShowErrorsOnly && phase.Collapsed {
+			if phase.Collapsed {
 				continue
 			}
 			highlighted := v.IsHighlighted(i, j)
@@ -880,11 +949,12 @@ func (v PhaseView) renderPhaseCard(phaseIndex int, phase BuildPhase, styles Styl
 
 	var body []string
 	if v.ShowErrorsOnly {
-		for i, line := range phase.Lines {
-			if line.Type != LogLineError && line.Type != LogLineWarning && line.Type != LogLineTestFail {
+		for _, i := range issueClusterRows(v.issueClusters(phase)) {
+			if i < 0 {
+				body = append(body, "  "+v.renderClusterSeparator(styles))
 				continue
 			}
-			body = append(body, "  "+v.renderLogLine(line, v.IsHighlighted(phaseIndex, i), styles, icons))
+			body = append(body, "  "+v.renderLogLine(phase.Lines[i], v.IsHighlighted(phaseIndex, i), styles, icons))
 		}
 	} else if phase.Collapsed {
 		muted := lipgloss.NewStyle().Foreground(styles.Colors.TextMuted)
@@ -908,6 +978,10 @@ func (v PhaseView) renderPhaseCard(phaseIndex int, phase BuildPhase, styles Styl
 	return cardStyle.Render(content)
 }
 
+func (v PhaseView) renderClusterSeparator(styles Styles) string {
+	return lipgloss.NewStyle().Foreground(styles.Colors.TextMuted).Render(issueClusterSeparator)
+}
+
 func phaseStatusStyle(phase BuildPhase, styles Styles, icons Icons) (string, lipgloss.Style) {
 	switch phase.Status {
 	case PhaseRunning:
@@ -1042,6 +1116,7 @@ var phasePatterns = []struct {
 	{regexp.MustCompile(`^▸ Processing`), "Processing"},
 	{regexp.MustCompile(`^▸ Copying`), "Copying"},
 	{regexp.MustCompile(`^▸ Building`), "Building"},
+	{regexp.MustCompile(`^(▸ )?Running script`), "Running Script"},
 	{regexp.MustCompile(`^▸ Running`), "Running"},
 	{regexp.MustCompile(`^▸ Testing`), "Testing"},
 	{regexp.MustCompile(`^▸ Analyzing`), "Analyzing"},
@@ -1068,6 +1143,15 @@ var phasePatterns = []struct {
 	{regexp.MustCompile(`^Test Suite '.*' started`), "Running Tests"},
 	{regexp.MustCompile(`^Test Case '.*' started`), "Running Tests"},
 
+	// SwiftPM package resolution (raw lines and core's batched summaries)
+	{regexp.MustCompile(`^(Resolve Package Graph|Resolved source packages:|SwiftPM: |Fetching from |Computing version for )`), "Packages"},
+
+	// Pre/post action hooks (core/hooks.go)
+	{regexp.MustCompile(`^Hook (pre|post)(Build|Run|Test): `), "Hooks"},
+
+	// Project commands (core/usercommand.go)
+	{regexp.MustCompile(`^Command [^:\s]+: `), "Command"},
+
 	// Archive/Export
 	{regexp.MustCompile(`^Archive`), "Archiving"},
 	{regexp.MustCompile(`^Export`), "Exporting"},
@@ -1104,31 +1188,25 @@ func categorizeLogLine(line string) LogLine {
 	logLine := LogLine{Text: line, Type: LogLineNormal}
 	lower := strings.ToLower(line)
 
-	// Test results
-	if strings.Contains(line, "Test Case") || strings.Contains(line, "test case") {
-		if strings.Contains(lower, "passed") {
-			logLine.Type = LogLineTestPass
-			logLine.TestName = extractTestName(line)
-			return logLine
-		}
-		if strings.Contains(lower, "failed") {
-			logLine.Type = LogLineTestFail
-			logLine.TestName = extractTestName(line)
-			return logLine
-		}
-	}
-
-	// Errors/warnings/notes (shared heuristics)
-	switch issueSeverity(line) {
-	case TabLineTypeError:
+	d := core.ClassifyDiagnostic(line)
+	switch {
+	case d.TestOutcome == "passed":
+		logLine.Type = LogLineTestPass
+		logLine.TestName = d.Test
+		return logLine
+	case d.TestOutcome == "failed":
+		logLine.Type = LogLineTestFail
+		logLine.TestName = d.Test
+		return logLine
+	case d.Severity == core.DiagnosticError:
 		logLine.Type = LogLineError
-		logLine.FilePath, logLine.LineNum = extractFileLocation(line)
+		logLine.FilePath, logLine.LineNum = d.File, d.Line
 		return logLine
-	case TabLineTypeWarning:
+	case d.Severity == core.DiagnosticWarning:
 		logLine.Type = LogLineWarning
-		logLine.FilePath, logLine.LineNum = extractFileLocation(line)
+		logLine.FilePath, logLine.LineNum = d.File, d.Line
 		return logLine
-	case TabLineTypeNote:
+	case d.Severity == core.DiagnosticNote:
 		logLine.Type = LogLineInfo
 		return logLine
 	}
@@ -1152,41 +1230,3 @@ func categorizeLogLine(line string) LogLine {
 
 	return logLine
 }
-
-// extractTestName extracts test name from test output line
-func extractTestName(line string) string {
-	// Pattern: Test Case '-[ClassName testMethod]' started/passed/failed
-	start := strings.Index(line, "'")
-	end := strings.LastIndex(line, "'")
-	if start != -1 && end != -1 && end > start {
-		return line[start+1 : end]
-	}
-	return ""
-}
-
-// extractFileLocation tries to extract file:line from error/warning
-func extractFileLocation(line string) (string, int) {
-	// Common pattern: /path/to/file.swift:42:10: error: message
-	// Also: /path/to/file.swift:42: error: message
-	parts := strings.Split(line, ":")
-	if len(parts) >= 3 {
-		// Find path-like part followed by numbers
-		for i := 0; i < len(parts)-1; i++ {
-			part := strings.TrimSpace(parts[i])
-			if strings.HasPrefix(part, "/") ||
-				strings.HasSuffix(part, ".swift") ||
-				strings.HasSuffix(part, ".m") ||
-				strings.HasSuffix(part, ".mm") ||
-				strings.HasSuffix(part, ".c") ||
-				strings.HasSuffix(part, ".cpp") ||
-				strings.HasSuffix(part, ".h") {
-				var lineNum int
-				if i+1 < len(parts) {
-					fmt.Sscanf(strings.TrimSpace(parts[i+1]), "%d", &lineNum)
-				}
-				return part, lineNum
-			}
-		}
-	}
-	return "", 0
-}
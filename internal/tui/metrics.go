@@ -0,0 +1,62 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// metricsShowLimit is how many entries "Metrics: Show" lists
+const metricsShowLimit = 20
+
+// openMetrics lists the newest entries of the metrics log
+func (m *Model) openMetrics() {
+	if !m.cfg.Metrics.Enabled {
+		m.setStatus("Metrics are off (set metrics.enabled in the config)")
+		return
+	}
+	entries, err := core.ReadMetrics(core.MetricsPath(m.projectRoot, m.cfg), time.Time{})
+	if err != nil {
+		m.lastErr = err.Error()
+		m.setStatus("Could not read the metrics log")
+		return
+	}
+	items := MetricsItems(entries, metricsShowLimit)
+	if len(items) == 0 {
+		m.setStatus("No metrics recorded yet")
+		return
+	}
+	m.selector = NewSelector("Metrics (last "+fmt.Sprint(len(items))+")", items, m.width, m.styles)
+	m.selectorType = SelectorMetrics
+	m.mode = ModeSelector
+}
+
+// MetricsItems creates selector items for the newest limit entries, newest
+// first
+func MetricsItems(entries []core.MetricsEntry, limit int) []SelectorItem {
+	var items []SelectorItem
+	for i := len(entries) - 1; i >= 0 && len(items) < limit; i-- {
+		e := entries[i]
+		result := "ok"
+		if !e.Success {
+			result = fmt.Sprintf("failed, %d errors", e.Errors)
+		}
+		desc := []string{orDash(e.Configuration), orDash(strings.TrimSpace(e.PlatformFamily + " " + e.DestinationKind))}
+		if e.Op == "build" {
+			desc = append(desc, fmt.Sprintf("%d compiled", e.CompiledFiles))
+		}
+		item := SelectorItem{
+			ID:          fmt.Sprint(i),
+			Title:       e.Op + " · " + metaDuration(e.DurationMs),
+			Description: strings.Join(desc, " · "),
+			Meta:        result,
+		}
+		if t, err := time.Parse(time.RFC3339, e.Timestamp); err == nil {
+			item.Note = t.Local().Format("Jan 2 15:04")
+		}
+		items = append(items, item)
+	}
+	return items
+}
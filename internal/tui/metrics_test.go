@@ -0,0 +1,33 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func TestMetricsItemsNewestFirst(t *testing.T) {
+	entries := []core.MetricsEntry{
+		{Op: "build", Configuration: "Debug", DestinationKind: "simulator", PlatformFamily: "ios", DurationMs: 12300, Success: true, CompiledFiles: 40},
+		{Op: "test", Configuration: "Debug", DestinationKind: "simulator", PlatformFamily: "ios", DurationMs: 42000, Errors: 2},
+		{Op: "build", DurationMs: 800, Success: true, CompiledFiles: 3},
+	}
+	items := MetricsItems(entries, 2)
+	if len(items) != 2 {
+		t.Fatalf("expected the 2 newest entries, got %d", len(items))
+	}
+	if items[0].Title != "build · 800ms" || items[0].Description != "— · — · 3 compiled" || items[0].Meta != "ok" {
+		t.Fatalf("unexpected newest item %+v", items[0])
+	}
+	if items[1].Title != "test · 42s" || items[1].Description != "Debug · ios simulator" || items[1].Meta != "failed, 2 errors" {
+		t.Fatalf("unexpected item %+v", items[1])
+	}
+}
+
+func TestOpenMetricsDisabled(t *testing.T) {
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.openMetrics()
+	if m.mode == ModeSelector {
+		t.Fatal("metrics opened while disabled")
+	}
+}
@@ -0,0 +1,121 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func newMinimalModel(t *testing.T) Model {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.mode = ModeNormal
+	m = updateModel(m, tea.WindowSizeMsg{Width: 60, Height: 15})
+	if !m.layout.MinimalMode || !m.tabView.MinimalMode || !m.tabView.SummaryTab.MinimalMode {
+		t.Fatalf("60x15 should enable the minimal layout")
+	}
+	return m
+}
+
+func updateModel(m Model, msg tea.Msg) Model {
+	next, _ := m.Update(msg)
+	return next.(Model)
+}
+
+func assertFits(t *testing.T, name, view string, width, height int) {
+	t.Helper()
+	lines := strings.Split(view, "\n")
+	if len(lines) > height {
+		t.Fatalf("%s: %d lines, want <= %d:\n%s", name, len(lines), height, stripANSI(view))
+	}
+	for i, line := range lines {
+		if w := lipgloss.Width(line); w > width {
+			t.Fatalf("%s: line %d is %d wide, want <= %d: %q", name, i, w, width, stripANSI(line))
+		}
+	}
+}
+
+func TestMinimalLayoutFitsSmallTerminal(t *testing.T) {
+	m := newMinimalModel(t)
+	long := strings.Repeat("very-long-identifier-", 10)
+	m.tabView.SummaryTab.SetContextLoaded(true)
+	m.tabView.SummaryTab.SetProjectInfo("App"+long, "App", "iPhone 16 Pro", "Debug")
+	m.tabView.SummaryTab.SetSystemInfo("16.0", "Booted", false)
+	for i := 0; i < 40; i++ {
+		m.tabView.StreamTab.AddLine("CompileSwift normal arm64 /src/"+long+".swift", TabLineTypeNormal)
+	}
+	m.tabView.IssuesTab.AddIssue(IssueTypeError, "/src/Some/Deep/Path/View.swift:12:4: error: cannot find '"+long+"' in scope")
+	m.tabView.IssuesTab.AddIssue(IssueTypeWarning, "/src/App.swift:3:1: warning: variable 'x' was never used")
+	m.tabView.IssuesTab.Issues[0].Expanded = true
+
+	summary := m.tabView.SummaryTab
+	states := []struct {
+		name  string
+		apply func()
+	}{
+		{"idle", func() {}},
+		{"building", func() {
+			summary.SetRunning("build")
+			summary.UpdateProgress("/src/"+long+".swift", 3, 10, "Compiling")
+		}},
+		{"success", func() {
+			summary.SetResult(BuildStatusSuccess, "12.3s", []PhaseResult{{Name: "Compiling", Duration: "10s", Count: 40}}, 0, 1)
+		}},
+		{"failed", func() { summary.SetResult(BuildStatusFailed, "4.0s", nil, 1, 1) }},
+		{"canceled", func() { summary.SetResult(BuildStatusCanceled, "1.0s", nil, 0, 0) }},
+	}
+	for _, s := range states {
+		s.apply()
+		for _, tab := range []Tab{TabDashboard, TabStream, TabIssues} {
+			m.tabView.SetActiveTab(tab)
+			assertFits(t, s.name+"/"+tab.String(), m.View(), 60, 15)
+		}
+	}
+
+	// Run mode collapses to the build pane and tab keeps cycling tabs.
+	m.runMode.Active = true
+	m.tabView.SetActiveTab(TabDashboard)
+	m = updateModel(m, tea.KeyMsg{Type: tea.KeyTab})
+	if m.tabView.ActiveTab != TabStream {
+		t.Fatalf("tab should cycle tabs in the minimal run layout, got %s", m.tabView.ActiveTab)
+	}
+	assertFits(t, "run/Logs", m.View(), 60, 15)
+}
+
+func TestMinimalTabsGolden(t *testing.T) {
+	m := newMinimalModel(t)
+	it := m.tabView.IssuesTab
+	it.AddIssue(IssueTypeError, "/src/Feature/Deep/View.swift:12:4: error: cannot find 'someVeryLongIdentifierName' in scope")
+	it.AddIssue(IssueTypeWarning, "/src/App.swift:3:1: warning: variable 'x' was never used")
+
+	m.tabView.SetActiveTab(TabIssues)
+	got := strings.Split(stripANSI(m.tabView.View(m.styles)), "\n")
+	if strings.Contains(stripANSI(m.tabView.View(m.styles)), "Dashboard") {
+		t.Fatalf("minimal layout should not render the tab bar")
+	}
+	if len(got) != 3 {
+		t.Fatalf("want header + one line per issue, got %d lines:\n%s", len(got), strings.Join(got, "\n"))
+	}
+	for i, line := range got {
+		if lipgloss.Width(line) > 60 {
+			t.Fatalf("issue line %d exceeds 60 columns: %q", i, line)
+		}
+	}
+	if !strings.Contains(got[1], "cannot find") || !strings.Contains(got[2], "variable 'x' was never used") {
+		t.Fatalf("unexpected issue lines:\n%s", strings.Join(got, "\n"))
+	}
+
+	st := m.tabView.SummaryTab
+	st.SetContextLoaded(true)
+	st.SetProjectInfo("App", "App", "iPhone 16", "Debug")
+	st.SetResult(BuildStatusSuccess, "12.3s", nil, 0, 0)
+	view := stripANSI(st.View(m.styles))
+	for _, border := range []string{"╭", "│", "╰"} {
+		if strings.Contains(view, border) {
+			t.Fatalf("minimal dashboard should not draw card borders:\n%s", view)
+		}
+	}
+}
@@ -35,15 +35,13 @@ const (
 
 // RunModeState tracks the state for run mode split view
 type RunModeState struct {
-	Active        bool     // Whether run mode split view is active
-	ConsoleLogs   []string // App console output (separate from build logs)
-	FocusPane     Pane     // Which pane has focus
-	ConsolePos    int      // Scroll position for console pane
-	TopHeight     int      // Cached top pane height
-	BottomHeight  int      // Cached bottom pane height
-	Status        string   // Last run status message
-	StatusAt      time.Time
-	ConsoleFollow bool
+	Active       bool        // Whether run mode split view is active
+	Console      ConsolePane // App console output (separate from build logs)
+	FocusPane    Pane        // Which pane has focus
+	TopHeight    int         // Cached top pane height
+	BottomHeight int         // Cached bottom pane height
+	Status       string      // Last run status message
+	StatusAt     time.Time
 }
 
 // LogViewMode controls the main log presentation.
@@ -58,12 +56,15 @@ const (
 // Messages
 // =============================================================================
 
-type eventMsg core.Event
-
 type contextLoadedMsg struct {
 	info core.ContextInfo
 	cfg  core.Config
 	err  error
+	// noProject is set when the root has no workspace, project, or
+	// Package.swift; discovery is skipped and the launcher opens
+	noProject bool
+	// configProblems are unknown or deprecated config keys, shown as a warning
+	configProblems []core.ConfigProblem
 }
 
 type ConfigOverrides struct {
@@ -72,15 +73,31 @@ type ConfigOverrides struct {
 	HasLogFormat      bool
 	HasLogFormatArgs  bool
 	UseXcodebuildList bool
+	ListTestPlans     bool
+	StatusPort        int    // 0 disables the HTTP status server
+	StatusBind        string // Defaults to 127.0.0.1
 }
 
 type opDoneMsg struct {
-	cmd   string
-	err   error
-	cfg   core.Config
-	build *core.BuildResult
-	run   *core.RunResult
-	test  *core.TestResult
+	cmd     string
+	err     error
+	cfg     core.Config
+	build   *core.BuildResult
+	run     *core.RunResult
+	test    *core.TestResult
+	stress  *core.StressResult
+	analyze *core.AnalyzeResult
+	// replaced is set when a hot rerun stopped the previous app
+	replaced bool
+	// target is the target a target build built instead of the scheme
+	target string
+	// runPhase is set when a run was canceled after its build succeeded,
+	// e.g. "launch canceled"
+	runPhase string
+	// info is the context a generate op rediscovered
+	info *core.ContextInfo
+	// seq identifies the op that finished (Model.opSeq)
+	seq int
 }
 
 const (
@@ -113,8 +130,12 @@ type Model struct {
 	cfg         core.Config
 	cfgOverride ConfigOverrides
 	info        core.ContextInfo
-	state       core.State // User state (recents, favorites)
-	gitBranch   string     // Current git branch
+	state       core.State             // User state (recents, favorites)
+	gitBranch   string                 // Current git branch
+	status      *statusServer          // Optional HTTP status endpoint
+	statusFile  *core.StatusFileWriter // .xcbolt/status.json for `xcbolt status`
+	appUsage    appUsageState          // CPU and memory of the app in run mode
+	projectPath projectPathForm        // Launcher path input
 
 	// Window dimensions
 	width  int
@@ -129,6 +150,10 @@ type Model struct {
 	mode         Mode
 	selectorType SelectorType
 
+	// Sessions selector: probed sessions and the one whose actions are shown
+	sessions    []sessionEntry
+	sessionPick core.Session
+
 	// Layout components
 	layout      Layout
 	statusBar   StatusBar
@@ -139,9 +164,20 @@ type Model struct {
 	spinner      spinner.Model
 	viewport     viewport.Model
 	helpViewport viewport.Model // For scrollable help overlay
+	helpFilter   string         // Typed filter narrowing the help entries
+	helpCursor   int            // Highlighted help entry (index into helpEntries)
 	wizard       wizardModel
+	wizardFamily core.PlatformFamily // Inferred from the scheme's SUPPORTED_PLATFORMS
+	wizardScheme string              // Scheme wizardFamily was inferred for
 	selector     SelectorModel
 	palette      PaletteModel
+	doctor       DoctorOverlay
+	settingsDiff SettingsDiffOverlay
+	confirm      ConfirmPrompt
+	resultInfo   ResultInfoOverlay
+	explain      ExplainOverlay
+	deviceHelp   DeviceHelpOverlay
+	logForm      logForm
 
 	// Tab-based log view (replaces phaseView and streamView)
 	tabView *TabView
@@ -164,15 +200,37 @@ type Model struct {
 	// Operation state
 	running    bool
 	runningCmd string
-	pendingOp  string
-	cancelFn   context.CancelFunc
-	eventCh    <-chan core.Event
-	// eventStopCh is closed to stop the event waiter cmd without closing eventCh.
+	// runningTarget is the target of a running target build
+	runningTarget string
+	pendingOp     string
+	// hotStop is the app the next run replaces (hot rerun); hotRunning is
+	// set while that run is in progress
+	hotStop    *stopTarget
+	hotRunning bool
+	// runApp is the app the run in flight launched and is attached to
+	runApp *stopTarget
+	// replacedRun is the attached run a hot rerun replaces, kept alive
+	// while the new run builds
+	replacedRun *replacedRun
+	// opSeq numbers started ops, so a replaced run's result is told apart
+	opSeq  int
+	lastOp *core.LastOp // Last started op, replayed by "."
+	// logSession is the session the "logs" op streams
+	logSession core.Session
+	// cleanTargets are the confirmed (already measured) paths of the next
+	// clean op; nil means measure when it starts.
+	cleanTargets []core.CleanTarget
+	cancelFn     context.CancelFunc
+	events       *chanEmitter
+	// eventStopCh is closed to stop the event waiter cmd without closing the
+	// event channel.
 	// This avoids "send on closed channel" panics if core continues emitting briefly
 	// after an op completes/cancels.
 	eventStopCh chan struct{}
 	doneCh      <-chan opDoneMsg
-	tickCount   int // For spinner animation timing
+	opExited    <-chan struct{} // Closed when the op goroutine returns
+	quitting    bool            // Quit requested; waiting for the op to wind down
+	tickCount   int             // For spinner animation timing
 	opStart     time.Time
 	lastEvent   time.Time
 	lastLog     time.Time
@@ -184,17 +242,64 @@ type Model struct {
 	stageProgress string // e.g., "23/47"
 	progressCur   int
 	progressTotal int
+	testCounts    TestCounts // live test outcomes for the current test op
 
 	// Results
 	lastResult *Result
 	lastBuild  core.BuildResult
 	lastRun    core.RunResult
 	lastTest   core.TestResult
-	lastErr    string
+	// lastAnalyze is the last static analyzer run (for "open report")
+	lastAnalyze core.AnalyzeResult
+	lastErr     string
+
+	// lastCommand is the most recent xcodebuild invocation (env redacted)
+	lastCommand string
+	// redactor scrubs secrets from op events (redact.patterns)
+	redactor *core.Redactor
 
 	// Run mode split view
 	runMode      RunModeState
+	logBrowser   LogBrowserState
 	mouseEnabled bool
+	lastClick    issueClick // For double-clicking issues
+	// userTabOverride is set when the user switches tabs during an op, so
+	// tui.autoSwitchTab leaves their choice alone when it ends
+	userTabOverride bool
+
+	// Log line bookmarks (cleared on the next operation unless pinned)
+	bookmarks       []Bookmark
+	bookmarkFlashID int
+
+	// Storage check (see storage.go)
+	storage        *core.StorageReport
+	storageCancel  context.CancelFunc
+	storagePending bool // Open the storage selector when the check lands
+	lowDiskWarned  bool
+
+	// Destination polling (see devicewatch.go)
+	devicePollGen int
+
+	// Identifies the config changes shown on the Dashboard (see configdiff.go)
+	configChangesGen int
+
+	// Test sources found for test failures without a file, by test name
+	// (see testsource.go)
+	testSources    map[string][]core.TestSourceMatch
+	testSourcePick *testSourcePick
+
+	// Tests waiting for a stress iteration count (see stress.go)
+	stressOnly []string
+
+	// launchPreset is the launch preset of the current or last run;
+	// pendingPreset waits for the once/default choice (see launchpreset.go)
+	launchPreset  string
+	pendingPreset string
+
+	// staleAsked is the Xcode the user was asked about cleaning DerivedData
+	// for; cleanStale cleans it in the next op (see staledd.go)
+	staleAsked string
+	cleanStale bool
 }
 
 // NewModel creates a new TUI model
@@ -213,6 +318,10 @@ func NewModel(projectRoot string, configPath string, overrides ConfigOverrides)
 
 	// Load user state (ignore errors - use defaults if not found)
 	state, _ := core.LoadState()
+	var lastOp *core.LastOp
+	if op, ok := state.GetLastOp(projectRoot); ok {
+		lastOp = &op
+	}
 
 	// Initialize layout components
 	layout := NewLayout()
@@ -226,6 +335,9 @@ func NewModel(projectRoot string, configPath string, overrides ConfigOverrides)
 	si.CharLimit = 100
 	si.Width = 40
 
+	tabView := NewTabView()
+	tabView.SetCategoryFilter(state.IssueFilter(projectRoot))
+
 	return Model{
 		projectRoot:  projectRoot,
 		configPath:   configPath,
@@ -236,7 +348,7 @@ func NewModel(projectRoot string, configPath string, overrides ConfigOverrides)
 		spinner:      sp,
 		viewport:     vp,
 		helpViewport: helpVp,
-		tabView:      NewTabView(),
+		tabView:      tabView,
 		phaseView:    NewPhaseView(),
 		streamView:   NewStreamView(),
 		searchInput:  si,
@@ -249,6 +361,9 @@ func NewModel(projectRoot string, configPath string, overrides ConfigOverrides)
 		statusBar:   statusBar,
 		progressBar: progressBar,
 		hintsBar:    hintsBar,
+
+		lastOp:   lastOp,
+		redactor: builtinRedactor,
 	}
 }
 
@@ -277,29 +392,39 @@ func loadContextCmd(projectRoot, configPath string, overrides ConfigOverrides) t
 		if err != nil {
 			return contextLoadedMsg{err: err}
 		}
+		if err := core.ValidateCommands(cfg.Commands, builtinCommandIDs()); err != nil {
+			return contextLoadedMsg{err: fmt.Errorf("invalid config: %w", err)}
+		}
 		applyConfigOverrides(&cfg, overrides)
+		if !core.HasProject(projectRoot, cfg) {
+			return contextLoadedMsg{cfg: cfg, noProject: true}
+		}
+		problems, _ := core.CheckConfig(projectRoot, configPath)
 		ctx, cancel := context.WithTimeout(context.Background(), 45*time.Second)
 		defer cancel()
 
 		emit := core.NewTextEmitter(ioDiscard{})
 		info, cfg2, err := core.DiscoverContext(ctx, projectRoot, cfg, emit, core.ContextOptions{
 			UseXcodebuildList:     overrides.UseXcodebuildList,
+			ListTestPlans:         overrides.ListTestPlans,
 			AllowXcodebuildList:   true,
 			XcodebuildListTimeout: 5 * time.Second,
 		})
 		if err != nil {
 			return contextLoadedMsg{err: err}
 		}
-		return contextLoadedMsg{info: info, cfg: cfg2}
+		return contextLoadedMsg{info: info, cfg: cfg2, configProblems: problems}
 	}
 }
 
 func applyConfigOverrides(cfg *core.Config, overrides ConfigOverrides) {
 	if overrides.HasLogFormat {
 		cfg.Xcodebuild.LogFormat = overrides.LogFormat
+		cfg.SetFlagOrigin("xcodebuild.logFormat")
 	}
 	if overrides.HasLogFormatArgs {
 		cfg.Xcodebuild.LogFormatArgs = overrides.LogFormatArgs
+		cfg.SetFlagOrigin("xcodebuild.logFormatArgs")
 	}
 }
 
@@ -323,11 +448,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.layout.SetSize(m.width, m.height)
 		m.updateViewportSize()
 		if m.mode == ModeWizard {
-			m.wizard = newWizard(m.info, m.cfg, m.width)
+			m.wizard = newWizard(m.info, m.cfg, m.width, m.wizardFamily)
 		}
 		// Responsive: warn if terminal is too small
 		if m.width < 80 || m.height < 20 {
-			m.setStatus("Terminal too small (min 80x20)")
+			m.setStatus("Terminal too small (min 80x20): minimal layout, tab cycles tabs")
 		}
 		// When minimal mode toggles (1-line vs 2-line header/hints), we can leave
 		// stale lines on screen unless we clear once.
@@ -343,6 +468,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.tabView.SummaryTab.SetContextLoaded(true)
 			break
 		}
+		if msg.noProject {
+			m.cfg = msg.cfg
+			m.applyTUIConfig()
+			m.tabView.SummaryTab.SetContextLoaded(true)
+			m.noProjectStatus()
+			m.openProjectLauncher()
+			break
+		}
 		m.info = msg.info
 		m.cfg = msg.cfg
 		m.applyTUIConfig()
@@ -355,16 +488,52 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Auto-detect: if not configured but context found, auto-select defaults
 		needsConfig := m.cfg.Scheme == "" || (m.cfg.Workspace == "" && m.cfg.Project == "")
+		before := m.cfg
 		if needsConfig && m.tryAutoDetect() {
-			// Auto-config applied - save and show toast
+			// Auto-config applied - save and show what it picked
 			if err := core.SaveConfig(m.projectRoot, m.configPath, m.cfg); err == nil {
 				m.setStatus("Ready")
 			} else {
 				m.setStatus("Context ready")
 			}
+			cmds = append(cmds, m.noteConfigChanges(before))
 		} else {
 			m.setStatus("Context ready")
 		}
+		if status := configProblemsStatus(msg.configProblems); status != "" {
+			m.setStatus(status)
+		}
+		if needsConfig && m.mode == ModeNormal {
+			m.openContainerChooser()
+		}
+		m.rememberProject()
+		m.syncHistory()
+		m.publishStatus()
+		cmds = append(cmds, m.checkStorage(), m.scheduleDevicePoll())
+
+	case schemeSupportMsg:
+		cmds = append(cmds, m.handleSchemeSupport(msg))
+
+	case configChangesClearMsg:
+		m.clearConfigChanges(msg)
+
+	case storageMsg:
+		m.handleStorage(msg)
+
+	case devicePollTickMsg:
+		cmds = append(cmds, m.handleDevicePollTick(msg))
+
+	case devicePollMsg:
+		cmds = append(cmds, m.handleDevicePoll(msg))
+
+	case appUsageTickMsg:
+		cmds = append(cmds, m.handleAppUsageTick(msg))
+
+	case appUsageMsg:
+		cmds = append(cmds, m.handleAppUsage(msg))
+
+	case targetsListedMsg:
+		m.handleTargetsListed(msg)
 
 	case tickMsg:
 		// Continue ticking if we need animation (spinner while running or loading)
@@ -391,6 +560,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.MouseMsg:
 		m.handleMouse(msg)
 
+	case wizardPlatformsMsg:
+		m.wizardScheme = msg.scheme
+		m.wizardFamily = ""
+		if len(msg.families) > 0 {
+			m.wizardFamily = msg.families[0]
+		}
+		if m.mode == ModeNormal {
+			cmds = append(cmds, m.showWizard())
+		}
+
 	case wizardDoneMsg:
 		m.mode = ModeNormal
 		if msg.aborted {
@@ -402,7 +581,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.setStatus("Init failed")
 			break
 		}
-		m.cfg = msg.cfg
+		cfg, err := core.ValidateInitConfig(m.info, msg.cfg, core.InitDestinationCandidates(m.info))
+		if err != nil {
+			m.lastErr = err.Error()
+			m.setStatus("Init failed")
+			break
+		}
+		before := m.cfg
+		m.cfg = cfg
 		m.applyTUIConfig()
 		if err := core.SaveConfig(m.projectRoot, m.configPath, m.cfg); err != nil {
 			m.lastErr = err.Error()
@@ -410,24 +596,67 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			break
 		}
 		m.setStatus("Saved config")
-		cmds = append(cmds, loadContextCmd(m.projectRoot, m.configPath, m.cfgOverride))
+		cmds = append(cmds, m.noteConfigChanges(before), loadContextCmd(m.projectRoot, m.configPath, m.cfgOverride))
+		if msg.gitignore != "" {
+			cmds = append(cmds, m.confirmGitignore(msg.gitignore))
+		}
 
-	case eventMsg:
-		ev := core.Event(msg)
-		m.handleEvent(ev)
+	case eventsMsg:
+		if r := m.replacedRun; r != nil && msg.from == r.events {
+			m.handleReplacedEvents(msg.events)
+			cmds = append(cmds, waitForEvent(r.events))
+			break
+		}
+		for _, ev := range msg.events {
+			if ev.Code == core.AppLaunchedCode {
+				m.noteRunApp(ev)
+				cmds = append(cmds, m.watchLaunchedApp(ev))
+			}
+			m.handleEvent(ev)
+			m.status.Relay(ev)
+		}
+		if msg.dropped > 0 {
+			m.handleEvent(core.Warn(m.runningCmd, droppedEventsNotice(msg.dropped, m.events.logPath())))
+		}
+		m.publishStatus()
 		// PhaseView handles its own auto-scroll
-		if m.eventCh != nil && m.eventStopCh != nil {
-			cmds = append(cmds, waitForEvent(m.eventCh, m.eventStopCh))
+		if m.events != nil && m.eventStopCh != nil && (msg.from == nil || msg.from == m.events) {
+			cmds = append(cmds, waitForEvent(m.events))
+		}
+
+	case logBrowserEventsMsg:
+		if cmd := m.handleLogBrowserEvents(msg); cmd != nil {
+			cmds = append(cmds, cmd)
 		}
 
+	case logBrowserDoneMsg:
+		m.handleLogBrowserDone(msg)
+
 	case opDoneMsg:
+		if r := m.replacedRun; r != nil && msg.seq == r.seq {
+			m.endReplacedRun()
+			break
+		}
 		prevSplit := m.runMode.Active
-		m.handleOpDone(msg)
+		if cmd := m.handleOpDone(msg); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+		cmds = append(cmds, m.watchRunApp(msg))
+		if !msg.replaced {
+			// A hot rerun that failed before the launch leaves the previous
+			// app attached
+			cmds = append(cmds, m.resumeReplacedRun())
+		}
+		if strings.HasPrefix(msg.cmd, "clean") {
+			core.InvalidateStorage()
+		}
+		cmds = append(cmds, m.checkStorage())
+		m.publishStatus()
 		if m.runMode.Active != prevSplit {
 			cmds = append(cmds, tea.ClearScreen)
 		}
 		cmds = append(cmds, loadContextCmd(m.projectRoot, m.configPath, m.cfgOverride))
-		if m.pendingOp != "" {
+		if m.pendingOp != "" && !m.quitting && !m.running {
 			next := m.pendingOp
 			m.pendingOp = ""
 			cmds = append(cmds, m.startOp(next))
@@ -440,8 +669,61 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.statusBar.Spinner = m.spinner
 		cmds = append(cmds, cmd)
 
+	case appDataMsg:
+		m.reportAppData(string(msg))
+
+	case cleanPlanMsg:
+		m.handleCleanPlan(msg)
+
+	case sessionsMsg:
+		m.handleSessions(msg)
+
+	case productsMsg:
+		m.handleProducts(msg)
+
+	case settingsDiffMsg:
+		m.handleSettingsDiff(msg)
+
+	case testSourceMsg:
+		cmds = append(cmds, m.handleTestSource(msg))
+
+	case explainDoneMsg:
+		m.handleExplainDone(msg)
+
+	case testPlansMsg:
+		cmds = append(cmds, m.handleTestPlans(msg))
+
+	case bookmarkFlashMsg:
+		if int(msg) == m.bookmarkFlashID {
+			m.clearBookmarkFlash()
+		}
+
 	case statusMsg:
 		m.setStatus(string(msg))
+
+	case reproProgressMsg:
+		m.setStatus(msg.text)
+		cmds = append(cmds, waitForRepro(msg.ch))
+
+	case reproDoneMsg:
+		cmds = append(cmds, m.handleReproDone(msg))
+
+	case doctorReportMsg:
+		m.doctor.SetReport(msg.report)
+		if msg.err != nil {
+			m.doctor.Message = msg.err.Error()
+		}
+
+	case doctorCheckMsg:
+		m.doctor.Fixing = ""
+		if msg.check.ID != "" {
+			m.doctor.UpdateCheck(msg.check)
+		}
+		if msg.err != nil {
+			m.doctor.Message = "Fix failed: " + msg.err.Error()
+		} else {
+			m.doctor.Message = "Fixed " + msg.check.Name
+		}
 	}
 
 	// PhaseView scrolling is handled through key bindings in handleNormalModeKey
@@ -474,7 +756,62 @@ func (m *Model) openConfigurationSelector() {
 	m.mode = ModeSelector
 }
 
+// openDestinationSelector lists destinations by name; a name shared by
+// simulators on several OS versions opens a second selector for the version
 func (m *Model) openDestinationSelector() {
+	m.openDestinationSelectorFor("Select Destination", nil)
+}
+
+// openDestinationSelectorFor lists the destinations on families (all when
+// nil) under title
+func (m *Model) openDestinationSelectorFor(title string, families []core.PlatformFamily) {
+	sims, devices, selectedID := m.destinationInfos()
+	items := DestinationNameItems(sims, devices)
+	if families != nil {
+		sims, devices, items = compatibleDestinations(families, sims, devices)
+	}
+	if len(items) == 0 {
+		m.setStatus("No destinations found")
+		return
+	}
+	markLastUsed(items, m.lastUsedDestinationID(), sims)
+	for _, sim := range sims {
+		if sim.UDID == selectedID && len(DestinationOSItems(sim.Name, sims)) > 1 {
+			selectedID = destinationNamePrefix + sim.Name
+		}
+	}
+
+	// Pass screen width - selector calculates its own width (50-60%)
+	m.selector = NewSelectorWithSelected(title, items, selectedID, m.width, m.styles)
+	m.selectorType = SelectorDestination
+	m.mode = ModeSelector
+}
+
+// openDestinationOSSelector lists the OS versions of the simulators called
+// name; the cursor starts on the newest
+func (m *Model) openDestinationOSSelector(name string) {
+	sims, _, _ := m.destinationInfos()
+	items := DestinationOSItems(name, sims)
+	if len(items) == 0 {
+		m.setStatus("No simulators named " + name)
+		return
+	}
+	markLastUsed(items, m.lastUsedDestinationID(), nil)
+	m.selector = NewSelector("Select "+name+" OS", items, m.width, m.styles)
+	m.selectorType = SelectorDestinationOS
+	m.mode = ModeSelector
+}
+
+// destinationSelectorItems lists the known destinations and the ID of the
+// configured one
+func (m *Model) destinationSelectorItems() ([]SelectorItem, string) {
+	sims, devices, selectedID := m.destinationInfos()
+	return DestinationItems(sims, devices), selectedID
+}
+
+// destinationInfos converts the discovered simulators and devices for the
+// selector and returns the configured destination's item ID
+func (m *Model) destinationInfos() ([]SimulatorInfo, []DeviceInfo, string) {
 	// Convert core types to selector types
 	sims := make([]SimulatorInfo, len(m.info.Simulators))
 	for i, s := range m.info.Simulators {
@@ -502,12 +839,6 @@ func (m *Model) openDestinationSelector() {
 		}
 	}
 
-	items := DestinationItems(sims, devices)
-	if len(items) == 0 {
-		m.setStatus("No destinations found")
-		return
-	}
-
 	selectedID := m.cfg.Destination.ID
 	if selectedID == "" {
 		selectedID = m.cfg.Destination.UDID
@@ -518,22 +849,14 @@ func (m *Model) openDestinationSelector() {
 	case core.DestCatalyst:
 		selectedID = "catalyst"
 	}
-
-	// Pass screen width - selector calculates its own width (50-60%)
-	m.selector = NewSelectorWithSelected("Select Destination", items, selectedID, m.width, m.styles)
-	m.selectorType = SelectorDestination
-	m.mode = ModeSelector
+	return sims, devices, selectedID
 }
 
-func (m *Model) handleSelectorResult(item *SelectorItem) {
+func (m *Model) handleSelectorResult(item *SelectorItem) tea.Cmd {
+	before := m.cfg
 	switch m.selectorType {
 	case SelectorScheme:
-		m.cfg.Scheme = item.ID
-		m.setStatus("Scheme: " + item.Title)
-		// Save config
-		if err := core.SaveConfig(m.projectRoot, m.configPath, m.cfg); err != nil {
-			m.lastErr = err.Error()
-		}
+		return m.selectScheme(item)
 
 	case SelectorConfiguration:
 		m.cfg.Configuration = item.ID
@@ -542,98 +865,107 @@ func (m *Model) handleSelectorResult(item *SelectorItem) {
 			m.lastErr = err.Error()
 		}
 
-	case SelectorDestination:
-		if item.ID == "macos" {
-			m.cfg.Destination = core.Destination{
-				Kind:           core.DestMacOS,
-				TargetType:     core.TargetLocal,
-				PlatformFamily: core.PlatformMacOS,
-				UDID:           "",
-				ID:             "",
-				Name:           "My Mac",
-				Platform:       "macOS",
-				OS:             "macOS",
-			}
+	case SelectorTestPlan:
+		m.cfg.Test.Plan = item.ID
+		m.setStatus("Test plan: " + item.Title)
+		if err := core.SaveConfig(m.projectRoot, m.configPath, m.cfg); err != nil {
+			m.lastErr = err.Error()
+		}
+
+	case SelectorDestination, SelectorDestinationOS:
+		dst, ok := m.destinationForItem(item)
+		if !ok {
+			return nil
+		}
+		m.cfg.Destination = dst
+		if m.selectorType == SelectorDestinationOS {
+			m.setStatus("Destination: " + dst.Name + " (" + item.Title + ")")
+		} else {
 			m.setStatus("Destination: " + item.Title)
-			if err := core.SaveConfig(m.projectRoot, m.configPath, m.cfg); err != nil {
-				m.lastErr = err.Error()
-			}
-			return
 		}
-		if item.ID == "catalyst" {
-			m.cfg.Destination = core.Destination{
-				Kind:           core.DestCatalyst,
-				TargetType:     core.TargetLocal,
-				PlatformFamily: core.PlatformCatalyst,
-				UDID:           "",
-				ID:             "",
-				Name:           "My Mac (Catalyst)",
-				Platform:       "macOS",
-				OS:             "macOS",
+		if err := core.SaveConfig(m.projectRoot, m.configPath, m.cfg); err != nil {
+			m.lastErr = err.Error()
+		}
+		m.rememberSchemeDestination()
+		m.saveStateAsync()
+	}
+	return m.noteConfigChanges(before)
+}
+
+// destinationForItem builds the config destination for a destination
+// selector item
+func (m *Model) destinationForItem(item *SelectorItem) (core.Destination, bool) {
+	switch item.ID {
+	case "macos":
+		return core.Destination{
+			Kind:           core.DestMacOS,
+			TargetType:     core.TargetLocal,
+			PlatformFamily: core.PlatformMacOS,
+			UDID:           "",
+			ID:             "",
+			Name:           "My Mac",
+			Platform:       "macOS",
+			OS:             "macOS",
+		}, true
+	case "catalyst":
+		return core.Destination{
+			Kind:           core.DestCatalyst,
+			TargetType:     core.TargetLocal,
+			PlatformFamily: core.PlatformCatalyst,
+			UDID:           "",
+			ID:             "",
+			Name:           "My Mac (Catalyst)",
+			Platform:       "macOS",
+			OS:             "macOS",
+		}, true
+	}
+	// Find the destination in our lists
+	for _, sim := range m.info.Simulators {
+		if sim.UDID == item.ID {
+			family := sim.PlatformFamily
+			if family == "" {
+				family = core.InferPlatformFamilyFromRuntime(sim.RuntimeID, sim.RuntimeName, sim.Name)
 			}
-			m.setStatus("Destination: " + item.Title)
-			if err := core.SaveConfig(m.projectRoot, m.configPath, m.cfg); err != nil {
-				m.lastErr = err.Error()
+			platform := core.PlatformStringForDestination(family, core.TargetSimulator)
+			if platform == "" {
+				platform = "iOS Simulator"
 			}
-			return
+			return core.Destination{
+				Kind:           core.DestSimulator,
+				TargetType:     core.TargetSimulator,
+				PlatformFamily: family,
+				UDID:           sim.UDID,
+				ID:             sim.UDID,
+				Name:           sim.Name,
+				Platform:       platform,
+				OS:             sim.OSVersion,
+				RuntimeID:      sim.RuntimeID,
+			}, true
 		}
-		// Find the destination in our lists
-		for _, sim := range m.info.Simulators {
-			if sim.UDID == item.ID {
-				family := sim.PlatformFamily
-				if family == "" {
-					family = core.InferPlatformFamilyFromRuntime(sim.RuntimeID, sim.RuntimeName, sim.Name)
-				}
-				platform := core.PlatformStringForDestination(family, core.TargetSimulator)
-				if platform == "" {
-					platform = "iOS Simulator"
-				}
-				m.cfg.Destination = core.Destination{
-					Kind:           core.DestSimulator,
-					TargetType:     core.TargetSimulator,
-					PlatformFamily: family,
-					UDID:           sim.UDID,
-					ID:             sim.UDID,
-					Name:           sim.Name,
-					Platform:       platform,
-					OS:             sim.OSVersion,
-					RuntimeID:      sim.RuntimeID,
-				}
-				m.setStatus("Destination: " + item.Title)
-				if err := core.SaveConfig(m.projectRoot, m.configPath, m.cfg); err != nil {
-					m.lastErr = err.Error()
-				}
-				return
+	}
+	for _, dev := range m.info.Devices {
+		if dev.Identifier == item.ID {
+			family := dev.PlatformFamily
+			if family == "" {
+				family = core.InferPlatformFamilyFromDevice(dev.Platform, dev.Model, dev.Name)
 			}
-		}
-		for _, dev := range m.info.Devices {
-			if dev.Identifier == item.ID {
-				family := dev.PlatformFamily
-				if family == "" {
-					family = core.InferPlatformFamilyFromDevice(dev.Platform, dev.Model, dev.Name)
-				}
-				platform := core.PlatformStringForDestination(family, core.TargetDevice)
-				if platform == "" {
-					platform = dev.Platform
-				}
-				m.cfg.Destination = core.Destination{
-					Kind:           core.DestDevice,
-					TargetType:     core.TargetDevice,
-					PlatformFamily: family,
-					UDID:           dev.Identifier,
-					ID:             dev.Identifier,
-					Name:           dev.Name,
-					Platform:       platform,
-					OS:             dev.OSVersion,
-				}
-				m.setStatus("Destination: " + item.Title)
-				if err := core.SaveConfig(m.projectRoot, m.configPath, m.cfg); err != nil {
-					m.lastErr = err.Error()
-				}
-				return
+			platform := core.PlatformStringForDestination(family, core.TargetDevice)
+			if platform == "" {
+				platform = dev.Platform
 			}
+			return core.Destination{
+				Kind:           core.DestDevice,
+				TargetType:     core.TargetDevice,
+				PlatformFamily: family,
+				UDID:           dev.Identifier,
+				ID:             dev.Identifier,
+				Name:           dev.Name,
+				Platform:       platform,
+				OS:             dev.OSVersion,
+			}, true
 		}
 	}
+	return core.Destination{}, false
 }
 
 func minInt(a, b int) int {
@@ -656,9 +988,11 @@ func formatShortDuration(d time.Duration) string {
 	return fmt.Sprintf("%dm%02ds", minutes, secs)
 }
 
-func (m Model) splitHeights(statusBarContent, progressBarContent, hintsBarContent string) (int, int) {
+// splitPanes returns the build and console pane sizes for the run mode
+// split view
+func (m Model) splitPanes(statusBarContent, progressBarContent, hintsBarContent string) (buildWidth, buildHeight, consoleWidth, consoleHeight int) {
 	if m.layout.MinimalMode {
-		return m.layout.ContentHeight(), 0
+		return m.layout.ContentWidth(), m.layout.ContentHeight(), m.layout.ContentWidth(), 0
 	}
 
 	header := m.layout.RenderHeader(statusBarContent, m.styles)
@@ -673,12 +1007,27 @@ func (m Model) splitHeights(statusBarContent, progressBarContent, hintsBarConten
 	}
 	totalContentHeight = maxInt(0, totalContentHeight)
 
-	topHeight := totalContentHeight * 60 / 100
-	bottomHeight := totalContentHeight - topHeight
-	return topHeight, bottomHeight
+	return m.layout.SplitPanes(totalContentHeight)
 }
 
+// consolePaneHeight is the height of the console being shown: the log
+// browser's when it is open, else run mode's pane
 func (m Model) consolePaneHeight() int {
+	if m.logBrowser.Active {
+		return m.logBrowserHeight()
+	}
+	return m.runConsoleHeight()
+}
+
+// consolePaneWidth is the width of the console being shown
+func (m Model) consolePaneWidth() int {
+	if m.logBrowser.Active {
+		return m.layout.ContentWidth()
+	}
+	return m.runConsoleWidth()
+}
+
+func (m Model) runConsoleHeight() int {
 	if m.runMode.Active {
 		if m.runMode.BottomHeight > 0 {
 			return m.runMode.BottomHeight
@@ -690,6 +1039,15 @@ func (m Model) consolePaneHeight() int {
 	return m.layout.SplitBottomHeight()
 }
 
+// runConsoleWidth is the width of the run console pane: the full content
+// width, or its share of it when the panes sit side by side
+func (m Model) runConsoleWidth() int {
+	if m.runMode.Active && !m.layout.MinimalMode {
+		return m.layout.SplitConsoleWidth()
+	}
+	return m.layout.ContentWidth()
+}
+
 func (m *Model) logIdleDuration(now time.Time) time.Duration {
 	if !m.running {
 		return 0
@@ -728,7 +1086,7 @@ func (m Model) activityLine() string {
 
 	stage := m.currentStage
 	if stage == "" {
-		stage = strings.ToUpper(m.runningCmd)
+		stage = opLabel(m.runningCmd, m.runningTarget)
 	}
 	status := m.lastStatus
 	if status == "" {
@@ -762,7 +1120,7 @@ func (m Model) activityLine() string {
 
 func (m *Model) openPalette() {
 	// Pass screen width - palette calculates its own width (50-60%)
-	m.palette = NewPalette(m.width, m.styles)
+	m.palette = NewPalette(m.width, m.styles, m.cfg.Commands)
 	m.mode = ModePalette
 }
 
@@ -775,6 +1133,15 @@ func (m *Model) startOrRestartOp(name string) tea.Cmd {
 	return m.startOp(name)
 }
 
+// generateProject forces the configured project generator to run
+func (m *Model) generateProject() tea.Cmd {
+	if m.cfg.Generate.Tool == "" {
+		m.setStatus("No generator configured (set generate.tool in .xcbolt/config.json)")
+		return nil
+	}
+	return m.startOrRestartOp("generate")
+}
+
 func (m *Model) stopOrCancelOp() tea.Cmd {
 	if m.running {
 		m.cancelRunningOp()
@@ -795,38 +1162,84 @@ func (m *Model) executePaletteCommand(cmd *Command) tea.Cmd {
 		return m.startOrRestartOp("run")
 	case "test":
 		return m.startOrRestartOp("test")
+	case "hot-rerun":
+		return m.hotRerun()
+	case "run-launch-only":
+		return m.launchOnly()
+	case "run-choose-product":
+		return m.chooseProduct()
+	case "run-launch-preset":
+		m.openLaunchPresets()
 	case "clean":
-		return m.startOrRestartOp("clean")
-	case "clean-derived":
+		return m.requestClean("clean")
+	case "clean-derived", "clean-results", "clean-sessions":
 		if !m.running {
-			return m.startOp("clean-derived")
+			return m.requestClean(cmd.ID)
 		}
-	case "clean-results":
+	case "generate-project":
+		return m.generateProject()
+	case "clean-spm-cache":
 		if !m.running {
-			return m.startOp("clean-results")
+			return m.requestClean("clean-spm-cache")
 		}
-	case "clean-sessions":
-		if !m.running {
-			return m.startOp("clean-sessions")
+	case "results-prune":
+		if !m.cfg.Results.Retention.Enabled() {
+			m.setStatus("No retention policy (set results.retention in config)")
+			return nil
 		}
-	case "clean-spm-cache":
 		if !m.running {
-			return m.startOp("clean-spm-cache")
+			return m.startOp("results-prune")
 		}
+	case "rerun-last":
+		return m.rerunLastOp()
+	case "history-clear":
+		m.clearHistory()
 	case "stop":
 		return m.stopOrCancelOp()
 
 	// Archive/Profile (not implemented yet)
-	case "archive", "archive-appstore", "archive-adhoc", "profile", "analyze":
+	case "archive", "archive-appstore", "archive-adhoc", "profile":
 		m.setStatus(cmd.Name + " coming soon")
+	case "analyze":
+		return m.startOrRestartOp("analyze")
+	case "open-analyzer-report":
+		return m.openAnalyzerReport()
+	case "settings-diff":
+		m.openSettingsDiff()
+	case "why-rebuild":
+		m.openWhyRebuild()
+	case "results-info":
+		m.openResultInfo()
+	case "coverage-targets":
+		m.openCoverageTargets()
+	case "targets":
+		m.openTargets()
+	case "test-stress":
+		m.openStressCount()
 
 	// Configuration
 	case "scheme":
 		m.openSchemeSelector()
 	case "configuration":
 		m.openConfigurationSelector()
+	case "test-plan":
+		return m.openTestPlanSelector()
 	case "destination":
 		m.openDestinationSelector()
+	case "config-validate":
+		m.validateConfig()
+	case "build-target":
+		return m.requestBuildTarget()
+	case "config-show":
+		m.openConfigOrigins()
+	case "metrics-show":
+		m.openMetrics()
+	case "recent-projects":
+		m.requestProjectLauncher()
+	case "copy-issues-json":
+		return m.copyIssuesJSON()
+	case "storage":
+		return m.openStorage()
 	case "toggle-dry-run":
 		m.cfg.Xcodebuild.DryRun = !m.cfg.Xcodebuild.DryRun
 		if err := core.SaveConfig(m.projectRoot, m.configPath, m.cfg); err != nil {
@@ -837,6 +1250,14 @@ func (m *Model) executePaletteCommand(cmd *Command) tea.Cmd {
 		} else {
 			m.setStatus("Dry run disabled")
 		}
+	case "toggle-notify":
+		m.cycleNotifyMode()
+	case "toggle-auto-tab":
+		m.cycleAutoSwitchTab()
+	case "toggle-run-split":
+		m.toggleRunSplitVertical()
+	case "toggle-show-muted":
+		m.toggleShowMuted()
 	case "toggle-unified-logs":
 		cur := true
 		if m.cfg.Launch.StreamUnifiedLogs != nil {
@@ -877,32 +1298,68 @@ func (m *Model) executePaletteCommand(cmd *Command) tea.Cmd {
 		m.toggleConsoleLevel("E", "Error")
 	case "toggle-log-fault":
 		m.toggleConsoleLevel("F", "Fault")
+	case "console-filter":
+		m.cycleConsoleFilter()
 	case "init":
-		m.mode = ModeWizard
-		m.wizard = newWizard(m.info, m.cfg, m.width)
-		return m.wizard.Init()
+		return m.openWizard()
 	case "refresh":
 		m.setStatus("Refreshing…")
 		return loadContextCmd(m.projectRoot, m.configPath, m.cfgOverride)
 
 	// Utilities
 	case "doctor":
-		m.setStatus("Doctor not implemented in TUI")
+		return m.openDoctor()
 	case "logs":
-		m.setStatus("Use CLI: xcbolt logs")
+		m.openLogDestinationSelector()
 	case "simulator-boot", "simulator-shutdown":
 		m.setStatus("Use CLI: xcbolt simulator")
 	case "open-xcode":
 		return m.openInXcode()
 	case "open-project":
 		return m.openProject()
+	case "copy-last-command":
+		if m.lastCommand == "" {
+			m.setStatus("No xcodebuild command yet: build, run, or test first")
+			return nil
+		}
+		return m.copyToClipboard(m.lastCommand, "Copied xcodebuild command")
+	case "export-repro":
+		return m.exportRepro()
+	case "git-ignore":
+		m.openGitignoreSelector()
+		return nil
+	case "copy-repro-command":
+		return m.copyToClipboard(core.ReproCommand(m.projectRoot, m.cfg, "build"), "Copied repro command")
+	case "copy-xcbolt-command":
+		return m.copyToClipboard(core.XcboltCommand(m.cfg, "build"), "Copied xcbolt command")
+	case "packages":
+		m.openPackagesSelector()
+	case "sessions":
+		return m.openSessions()
+	case "app-reveal-container":
+		return m.revealAppContainer()
+	case "app-reset-data":
+		m.confirmResetAppData()
+	case "bookmarks":
+		m.openBookmarksSelector()
+	case "go-to-phase":
+		m.openPhaseSelector()
+	case "bookmarks-clear":
+		m.clearBookmarks()
 
 	// Navigation
+	case "show-previous-run":
+		m.togglePreviousRun()
 	case "help":
-		m.mode = ModeHelp
-		m.setupHelpViewport()
+		m.openHelp()
 	case "quit":
-		return tea.Quit
+		return m.quitGracefully()
+
+	// Project commands (config commands)
+	default:
+		if uc, ok := m.cfg.UserCommandByID(cmd.ID); ok {
+			return m.requestUserCommand(uc)
+		}
 	}
 
 	return nil
@@ -913,75 +1370,110 @@ func (m *Model) updateViewportSize() {
 	m.viewport.Width = maxInt(0, m.layout.ContentWidth()-2)
 	m.viewport.Height = maxInt(0, m.layout.ContentHeight()-2)
 	// Update TabView dimensions (includes tab bar height internally)
+	m.tabView.SetMinimal(m.layout.MinimalMode)
 	m.tabView.SetSize(m.layout.ContentWidth(), m.layout.ContentHeight())
 	// Update legacy views
 	m.phaseView.SetSize(m.layout.ContentWidth(), m.layout.ContentHeight())
 	m.streamView.SetSize(m.layout.ContentWidth(), m.layout.ContentHeight())
 }
 
-func (m *Model) setupHelpViewport() {
-	s := m.styles
-
-	// Calculate width: 50-60% of screen, clamped
-	width := m.width * 55 / 100
-	if width < 50 {
-		width = 50
-	}
-	if width > 80 {
-		width = 80
+// selectTab switches tabs for the user. During an op this also stops
+// tui.autoSwitchTab from switching when the op ends.
+func (m *Model) selectTab(tab Tab) {
+	if m.running && tab != m.tabView.ActiveTab {
+		m.userTabOverride = true
 	}
+	m.tabView.SetActiveTab(tab)
+}
 
-	// Calculate max height for content (leave room for border, padding, title, footer)
-	maxHeight := m.height - 10
-	if maxHeight < 10 {
-		maxHeight = 10
+func (m *Model) togglePreviousRun() {
+	if !m.tabView.TogglePrevious() {
+		m.setStatus("No previous run")
+		return
 	}
-
-	var b strings.Builder
-
-	groups := m.keys.FullHelp()
-	groupNames := []string{"ACTIONS", "CONFIGURATION", "TABS", "VIEW", "SCROLLING", "NAVIGATION"}
-
-	sectionStyle := lipgloss.NewStyle().
-		Foreground(s.Colors.TextSubtle).
-		Bold(true)
-
-	keyStyle := lipgloss.NewStyle().
-		Foreground(s.Colors.Accent).
-		Width(12)
-
-	descStyle := lipgloss.NewStyle().
-		Foreground(s.Colors.Text)
-
-	for i, group := range groups {
-		if i < len(groupNames) {
-			b.WriteString(sectionStyle.Render("  " + groupNames[i]))
-			b.WriteString("\n")
-		}
-		for _, binding := range group {
-			keys := binding.Help().Key
-			desc := binding.Help().Desc
-			keyPart := keyStyle.Render("  " + keys)
-			descPart := descStyle.Render(desc)
-			b.WriteString(keyPart + descPart + "\n")
+	if m.tabView.ShowingPrevious {
+		if m.tabView.ActiveTab == TabDashboard {
+			m.selectTab(TabIssues)
 		}
-		b.WriteString("\n")
+		m.setStatus("Showing previous run")
+	} else {
+		m.setStatus("Showing current run")
 	}
+}
 
-	content := b.String()
+// openWizard opens the init wizard, first inferring the default platform
+// family from the scheme when the config doesn't pin one.
+func (m *Model) openWizard() tea.Cmd {
+	if m.cfg.Destination.PlatformFamily != "" || m.cfg.Scheme == "" || m.wizardScheme == m.cfg.Scheme {
+		return m.showWizard()
+	}
+	m.setStatus("Reading scheme platforms…")
+	return wizardPlatformsCmd(m.projectRoot, m.cfg)
+}
 
-	// Set up viewport
-	m.helpViewport.Width = width - 6 // Account for border and padding
-	m.helpViewport.Height = maxHeight
-	m.helpViewport.SetContent(content)
-	m.helpViewport.GotoTop()
+func (m *Model) showWizard() tea.Cmd {
+	m.mode = ModeWizard
+	m.wizard = newWizard(m.info, m.cfg, m.width, m.wizardFamily)
+	return m.wizard.Init()
 }
 
 func (m *Model) applyTUIConfig() {
+	m.phaseView.ContextLines = m.cfg.TUI.IssueContext()
+	m.tabView.SetErrorsOnly(m.phaseView.ShowErrorsOnly, m.phaseView.ContextLines)
+	m.tabView.SetLinker(newLinker(m.cfg.TUI, m.projectRoot))
+	m.applyIssueCategories()
+	m.tabView.SetMutedPhases(m.cfg.TUI.MutedPhases)
+	m.applyRedaction()
 	m.phaseView.SmartCollapse = !m.cfg.TUI.ShowAllLogs
 	if m.cfg.TUI.ShowAllLogs {
 		m.phaseView.ExpandAll()
 	}
+	m.syncRunSplit()
+}
+
+// builtinRedactor redacts with the built-in patterns until the config loads
+var builtinRedactor, _ = core.NewRedactor(core.RedactConfig{})
+
+// applyRedaction builds the redactor op events pass through from the config
+func (m *Model) applyRedaction() {
+	r, errs := core.NewRedactor(m.cfg.Redact)
+	m.redactor = r
+	if len(errs) > 0 {
+		m.setStatus(errs[0].Error())
+	}
+}
+
+// jumpToIssueCluster moves the Logs tab to the next/previous group of
+// errors/warnings (with their context lines)
+func (m *Model) jumpToIssueCluster(dir int) {
+	m.phaseView.JumpToCluster(dir)
+	m.selectTab(TabStream)
+	if m.runMode.Active {
+		m.runMode.FocusPane = PaneBuild
+	}
+	n, total, ok := m.tabView.VisibleStreamTab().JumpToCluster(dir)
+	if !ok {
+		m.setStatus("No errors or warnings")
+		return
+	}
+	m.setStatus(fmt.Sprintf("Issue %d/%d", n, total))
+}
+
+// jumpToMarker scrolls the Logs tab to the nearest error/warning marked on
+// the scrollbar below or above the view
+func (m *Model) jumpToMarker(dir int) {
+	m.phaseView.JumpToMarker(dir)
+	m.selectTab(TabStream)
+	if m.runMode.Active {
+		m.runMode.FocusPane = PaneBuild
+	}
+	if !m.tabView.VisibleStreamTab().JumpToMarker(dir) {
+		if dir > 0 {
+			m.setStatus("No errors or warnings below")
+		} else {
+			m.setStatus("No errors or warnings above")
+		}
+	}
 }
 
 func (m *Model) toggleLogView() {
@@ -1013,13 +1505,28 @@ func (m *Model) syncStatusBarState() {
 	m.statusBar.GitBranch = m.gitBranch
 	m.statusBar.Scheme = m.cfg.Scheme
 	m.statusBar.Configuration = m.cfg.Configuration
+	m.statusBar.TestPlan = m.cfg.Test.Plan
 	m.statusBar.Destination = m.cfg.Destination.Name
 	m.statusBar.DestOS = m.cfg.Destination.OS
+	m.statusBar.DestUnavailable = m.tabView.SummaryTab.ContextLoaded && !core.DestinationAvailable(m.cfg.Destination, m.info)
 	m.statusBar.DryRun = m.cfg.Xcodebuild.DryRun
+	m.statusBar.XcodeMismatch = ""
+	if err := core.CheckRequiredXcode(m.info.Xcode, m.cfg.RequiredXcode); err != nil {
+		m.statusBar.XcodeMismatch = m.info.Xcode.Label()
+		if m.statusBar.XcodeMismatch == "" {
+			m.statusBar.XcodeMismatch = "Xcode"
+		}
+	}
 	m.statusBar.Running = m.running
 	m.statusBar.RunningCmd = m.runningCmd
 	m.statusBar.Stage = m.currentStage
+	m.statusBar.Stress, m.statusBar.Preset = "", ""
+	if m.running {
+		m.statusBar.Stress = m.tabView.SummaryTab.StressProgress
+		m.statusBar.Preset = m.launchPreset
+	}
 	m.statusBar.Progress = m.stageProgress
+	m.statusBar.Tests = m.testCounts
 
 	// Error/warning counts from TabView
 	m.statusBar.ErrorCount = m.tabView.Counts.ErrorCount
@@ -1050,48 +1557,88 @@ func (m *Model) syncStatusBarState() {
 		simulatorStatus = "Available"
 	}
 	deviceConnected := len(m.info.Devices) > 0
-	m.tabView.SummaryTab.SetSystemInfo("Xcode", simulatorStatus, deviceConnected)
+	m.tabView.SummaryTab.SetSystemInfo(m.info.Xcode.Label(), simulatorStatus, deviceConnected)
+	xcodeWarning := ""
+	if err := core.CheckRequiredXcode(m.info.Xcode, m.cfg.RequiredXcode); err != nil {
+		xcodeWarning = "requires " + m.cfg.RequiredXcode
+	}
+	m.tabView.SummaryTab.SetXcodeWarning(xcodeWarning)
+
+	derivedData := ""
+	if m.cfg.Xcodebuild.SplitDerivedDataByDestination {
+		derivedData = core.DerivedDataDir(m.cfg)
+		if rel, err := filepath.Rel(m.projectRoot, derivedData); err == nil && !strings.HasPrefix(rel, "..") {
+			derivedData = rel
+		}
+	}
+	m.tabView.SummaryTab.SetDerivedData(derivedData)
 }
 
 func (m *Model) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
+	// Shutting down - only a second quit (force) is accepted
+	if m.quitting {
+		if keyMatches(msg, m.keys.Quit) {
+			return tea.Quit
+		}
+		return nil
+	}
+
 	// Wizard mode - delegate to wizard
 	if m.mode == ModeWizard {
 		if keyMatches(msg, m.keys.Quit) {
-			return tea.Quit
+			return m.quitGracefully()
 		}
 		var cmd tea.Cmd
 		m.wizard, cmd = m.wizard.Update(msg)
 		return cmd
 	}
 
-	// Help mode - handle scrolling or close
+	// Help mode - filter, scroll, execute, or close
 	if m.mode == ModeHelp {
-		switch msg.String() {
-		case "esc", "?", "q":
-			m.mode = ModeNormal
-			return nil
-		case "up", "k":
-			m.helpViewport.LineUp(1)
-			return nil
-		case "down", "j":
-			m.helpViewport.LineDown(1)
-			return nil
-		case "pgup", "ctrl+u":
-			m.helpViewport.HalfViewUp()
-			return nil
-		case "pgdown", "ctrl+d":
-			m.helpViewport.HalfViewDown()
-			return nil
-		case "home", "g":
-			m.helpViewport.GotoTop()
-			return nil
-		case "end", "G":
-			m.helpViewport.GotoBottom()
-			return nil
-		default:
-			// Ignore other keys
-			return nil
-		}
+		return m.handleHelpKey(msg)
+	}
+
+	// Doctor mode
+	if m.mode == ModeDoctor {
+		return m.handleDoctorKey(msg)
+	}
+
+	// Build settings diff
+	if m.mode == ModeSettingsDiff {
+		return m.handleSettingsDiffKey(msg)
+	}
+
+	// Confirm prompt
+	if m.mode == ModeConfirm {
+		return m.handleConfirmKey(msg)
+	}
+
+	// Result bundle metadata
+	if m.mode == ModeResultInfo {
+		return m.handleResultInfoKey(msg)
+	}
+
+	// Explain issue output
+	if m.mode == ModeExplain {
+		return m.handleExplainKey(msg)
+	}
+
+	// Device install/launch remediation
+	if m.mode == ModeDeviceHelp {
+		return m.handleDeviceHelpKey(msg)
+	}
+
+	// Project launcher path input
+	if m.mode == ModeProjectPath {
+		return m.handleProjectPathKey(msg)
+	}
+
+	// Log browser
+	if m.mode == ModeLogForm {
+		return m.handleLogFormKey(msg)
+	}
+	if m.mode == ModeLogBrowser {
+		return m.handleLogBrowserKey(msg)
 	}
 
 	// Selector mode
@@ -1102,7 +1649,58 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
 		if result != nil {
 			m.mode = ModeNormal
 			if !result.Aborted && result.Selected != nil {
-				m.handleSelectorResult(result.Selected)
+				switch m.selectorType {
+				case SelectorScheme:
+					return m.selectScheme(result.Selected)
+				case SelectorPackages:
+					return m.copyPackageVersion(result.Selected)
+				case SelectorConfigOrigins:
+					return m.copyToClipboard(result.Selected.Description, "Copied "+result.Selected.Title)
+				case SelectorBookmarks:
+					return m.jumpToBookmark(result.Selected)
+				case SelectorPhases:
+					return m.goToPhase(result.Selected)
+				case SelectorStorage:
+					return m.requestClean(result.Selected.ID)
+				case SelectorLogDestination:
+					return m.pickLogDestination(result.Selected)
+				case SelectorSessions:
+					return m.selectSession(result.Selected)
+				case SelectorSessionAction:
+					return m.runSessionAction(result.Selected.ID)
+				case SelectorProduct:
+					return m.pickProduct(result.Selected)
+				case SelectorLaunchPreset:
+					m.pickLaunchPreset(result.Selected)
+					return nil
+				case SelectorLaunchPresetScope:
+					return m.runLaunchPreset(result.Selected)
+				case SelectorSettingsDiff:
+					m.chooseSettingsDiffTarget(result.Selected.ID)
+					return nil
+				case SelectorSettingsDiffConfiguration, SelectorSettingsDiffDestination:
+					return m.pickSettingsDiffTarget(result.Selected)
+				case SelectorBuildHistory, SelectorCoverage, SelectorTargets, SelectorMetrics:
+					return nil
+				case SelectorTestSource:
+					return m.pickTestSource(result.Selected)
+				case SelectorStressCount:
+					return m.pickStressCount(result.Selected)
+				case SelectorGitignore:
+					return m.confirmGitignore(core.GitignoreMode(result.Selected.ID))
+				case SelectorContainer:
+					return m.selectContainer(result.Selected)
+				case SelectorRecentProject:
+					return m.selectRecentProject(result.Selected)
+				case SelectorBuildTarget:
+					return m.selectBuildTarget(result.Selected)
+				case SelectorDestination:
+					if name, ok := strings.CutPrefix(result.Selected.ID, destinationNamePrefix); ok {
+						m.openDestinationOSSelector(name)
+						return nil
+					}
+				}
+				return m.handleSelectorResult(result.Selected)
 			}
 		}
 		return nil
@@ -1147,14 +1745,10 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
 	// Normal mode
 	switch {
 	case keyMatches(msg, m.keys.Quit):
-		if m.running {
-			m.cancelRunningOp()
-		}
-		return tea.Quit
+		return m.quitGracefully()
 
 	case keyMatches(msg, m.keys.Help):
-		m.mode = ModeHelp
-		m.setupHelpViewport()
+		m.openHelp()
 
 	case keyMatches(msg, m.keys.Cancel):
 		return m.stopOrCancelOp()
@@ -1169,11 +1763,22 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
 		return m.startOrRestartOp("test")
 
 	case keyMatches(msg, m.keys.Clean):
-		return m.startOrRestartOp("clean")
+		return m.requestClean("clean")
+
+	// x stops a running op or app; otherwise, on the Issues tab, it explains
+	case m.tabView.ActiveTab == TabIssues && !m.running && !m.runMode.Active &&
+		m.cfg.Issues.ExplainCommand != "" && keyMatches(msg, m.keys.ExplainIssue):
+		return m.explainIssue()
 
 	case keyMatches(msg, m.keys.Stop):
 		return m.stopOrCancelOp()
 
+	case keyMatches(msg, m.keys.Rerun):
+		return m.rerunLastOp()
+
+	case keyMatches(msg, m.keys.HotRerun):
+		return m.hotRerun()
+
 	case keyMatches(msg, m.keys.Scheme):
 		m.openSchemeSelector()
 
@@ -1187,9 +1792,7 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
 		m.openPalette()
 
 	case keyMatches(msg, m.keys.Init):
-		m.mode = ModeWizard
-		m.wizard = newWizard(m.info, m.cfg, m.width)
-		return m.wizard.Init()
+		return m.openWizard()
 
 	case keyMatches(msg, m.keys.Refresh):
 		m.setStatus("Refreshing…")
@@ -1197,22 +1800,28 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
 
 	// Tab navigation
 	case keyMatches(msg, m.keys.Tab1):
-		m.tabView.SetActiveTab(TabDashboard)
+		m.selectTab(TabDashboard)
 		m.setStatus("Dashboard")
 
 	case keyMatches(msg, m.keys.Tab2):
-		m.tabView.SetActiveTab(TabStream)
+		m.selectTab(TabStream)
 		m.setStatus("Logs")
 
 	case keyMatches(msg, m.keys.Tab3):
-		m.tabView.SetActiveTab(TabIssues)
+		m.selectTab(TabIssues)
 		m.setStatus("Issues")
 
-	case keyMatches(msg, m.keys.TabNext):
-		if !m.runMode.Active { // Don't conflict with SwitchPane in run mode
-			m.tabView.NextTab()
-			m.setStatus(m.tabView.ActiveTab.String())
+	// In run mode tab switches panes, except in the minimal layout where
+	// only the build pane is shown and tab is the only way to change tabs.
+	case keyMatches(msg, m.keys.TabNext) && (!m.runMode.Active || m.layout.MinimalMode):
+		if m.running {
+			m.userTabOverride = true
 		}
+		m.tabView.NextTab()
+		m.setStatus(m.tabView.ActiveTab.String())
+
+	case keyMatches(msg, m.keys.PrevRun):
+		m.togglePreviousRun()
 
 	// Display toggles
 	case keyMatches(msg, m.keys.ToggleLineNumbers):
@@ -1235,14 +1844,27 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
 	case keyMatches(msg, m.keys.CopyLine):
 		return m.copyCurrentLine()
 
+	case m.tabView.ActiveTab == TabIssues && keyMatches(msg, m.keys.CopyLoc):
+		return m.copyIssueLocation()
+
+	case m.tabView.ActiveTab == TabIssues && keyMatches(msg, m.keys.ApplyFixIt):
+		m.promptApplyFixIt()
+
+	case keyMatches(msg, m.keys.CopyGrep):
+		return m.copyIssueGrep()
+
 	case keyMatches(msg, m.keys.CopyVisible):
 		return m.copyVisibleContent()
 
+	case m.tabView.ActiveTab == TabIssues && keyMatches(msg, m.keys.IssueCategory):
+		m.cycleIssueCategory()
+
 	case keyMatches(msg, m.keys.ToggleAutoFollow), keyMatches(msg, m.keys.ToggleRawView):
 		m.toggleLogView()
 
 	case keyMatches(msg, m.keys.ToggleErrorsOnly):
 		m.phaseView.ShowErrorsOnly = !m.phaseView.ShowErrorsOnly
+		m.tabView.SetErrorsOnly(m.phaseView.ShowErrorsOnly, m.phaseView.ContextLines)
 		if m.phaseView.ShowErrorsOnly {
 			m.logViewMode = LogViewCards
 			m.phaseView.ShowRawMode = false
@@ -1269,19 +1891,14 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
 
 	case keyMatches(msg, m.keys.ScrollTop):
 		if m.runMode.Active && m.runMode.FocusPane == PaneConsole {
-			m.runMode.ConsolePos = 0
+			m.console().Scroll(-m.console().Pos, m.consoleMaxPos())
 		} else {
 			m.tabView.GotoTop()
 		}
 
 	case keyMatches(msg, m.keys.ScrollBottom):
 		if m.runMode.Active && m.runMode.FocusPane == PaneConsole {
-			maxPos := len(m.runMode.ConsoleLogs) - m.consolePaneHeight()
-			if maxPos < 0 {
-				maxPos = 0
-			}
-			m.runMode.ConsolePos = maxPos
-			m.runMode.ConsoleFollow = true
+			m.console().ScrollToEnd(m.consoleMaxPos())
 		} else {
 			m.tabView.GotoBottom()
 		}
@@ -1314,6 +1931,31 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
 			m.tabView.ScrollDown(5)
 		}
 
+	case keyMatches(msg, m.keys.ConsoleFilter):
+		if m.runMode.Active {
+			m.cycleConsoleFilter()
+		} else {
+			m.setStatus("Console filter is available in run mode")
+		}
+
+	case keyMatches(msg, m.keys.SplitGrow), keyMatches(msg, m.keys.SplitShrink):
+		if !m.runMode.Active || m.layout.MinimalMode {
+			m.setStatus("Split resizing is available in run mode")
+			break
+		}
+		if keyMatches(msg, m.keys.SplitGrow) {
+			m.resizeRunSplit(runSplitStep)
+		} else {
+			m.resizeRunSplit(-runSplitStep)
+		}
+
+	case keyMatches(msg, m.keys.SplitSwap):
+		if !m.runMode.Active || m.layout.MinimalMode {
+			m.setStatus("Split resizing is available in run mode")
+			break
+		}
+		m.swapRunSplit()
+
 	// Run mode pane switching
 	case keyMatches(msg, m.keys.SwitchPane):
 		if m.runMode.Active {
@@ -1326,7 +1968,13 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
 			}
 		}
 
-	// Phase controls
+	// Phase controls (on the Issues tab: expand the selected issue)
+	case m.tabView.ActiveTab == TabIssues && keyMatches(msg, m.keys.ToggleCollapse):
+		m.tabView.VisibleIssuesTab().ToggleExpand()
+
+	case m.tabView.ActiveTab == TabDashboard && len(m.tabView.SummaryTab.Targets) > 0 && keyMatches(msg, m.keys.ToggleCollapse):
+		m.tabView.SummaryTab.ToggleTargets()
+
 	case keyMatches(msg, m.keys.ToggleCollapse):
 		m.phaseView.ToggleSelectedPhase()
 
@@ -1340,28 +1988,42 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
 
 	// Error navigation
 	case keyMatches(msg, m.keys.NextError):
-		if p, l := m.phaseView.FindNextError(-1, -1); p >= 0 {
-			m.setStatus("Next error")
-			_ = l // Line index available for future use
-		} else {
-			m.setStatus("No errors found")
-		}
+		m.jumpToIssueCluster(1)
 
 	case keyMatches(msg, m.keys.PrevError):
-		if p, l := m.phaseView.FindPrevError(len(m.phaseView.Phases), 0); p >= 0 {
-			m.setStatus("Previous error")
-			_ = l // Line index available for future use
-		} else {
-			m.setStatus("No errors found")
-		}
+		m.jumpToIssueCluster(-1)
+
+	case keyMatches(msg, m.keys.NextMarker):
+		m.jumpToMarker(1)
+
+	case keyMatches(msg, m.keys.PrevMarker):
+		m.jumpToMarker(-1)
+
+	case keyMatches(msg, m.keys.NextPhase):
+		return m.jumpToPhase(1)
+
+	case keyMatches(msg, m.keys.PrevPhase):
+		return m.jumpToPhase(-1)
 
 	// Open in editor
+	case m.tabView.ActiveTab == TabIssues && keyMatches(msg, m.keys.OpenXcode):
+		return m.openIssueInXcode()
+
 	case keyMatches(msg, m.keys.OpenXcode):
 		return m.openInXcode()
 
 	case keyMatches(msg, m.keys.OpenEditor):
 		return m.openInEditor()
 
+	case keyMatches(msg, m.keys.Bookmark):
+		m.toggleBookmark()
+
+	case keyMatches(msg, m.keys.Bookmarks):
+		m.openBookmarksSelector()
+
+	case keyMatches(msg, m.keys.MutePhase):
+		m.togglePhaseMute()
+
 	case keyMatches(msg, m.keys.ToggleMouse):
 		m.mouseEnabled = !m.mouseEnabled
 		if m.mouseEnabled {
@@ -1385,8 +2047,12 @@ func (m *Model) handleMouse(msg tea.MouseMsg) {
 		if msg.Type == tea.MouseWheelUp {
 			delta = -3
 		}
+		if m.logBrowser.Active {
+			m.scrollConsole(delta)
+			return
+		}
 		if m.runMode.Active {
-			if m.mouseInConsolePane(msg.Y) {
+			if m.mouseInConsolePane(msg.X, msg.Y) {
 				m.runMode.FocusPane = PaneConsole
 				m.scrollConsole(delta)
 			} else {
@@ -1404,27 +2070,31 @@ func (m *Model) handleMouse(msg tea.MouseMsg) {
 		} else {
 			m.tabView.ScrollDown(delta)
 		}
+
+	case tea.MouseLeft:
+		if msg.Action == tea.MouseActionPress {
+			m.handleClick(msg, time.Now())
+		}
 	}
 }
 
-func (m *Model) mouseInConsolePane(y int) bool {
+func (m *Model) mouseInConsolePane(x, y int) bool {
 	if !m.runMode.Active {
 		return false
 	}
 	if m.layout.MinimalMode {
 		return true
 	}
-	headerHeight := 2
 	hintsHeight := 2
-	if m.layout.MinimalMode {
-		headerHeight = 1
-		hintsHeight = 0
-	}
-	contentStart := headerHeight
+	contentStart := m.contentTop()
 	contentEnd := m.height - hintsHeight
 	if y < contentStart || y >= contentEnd {
 		return false
 	}
+	if m.layout.SideBySide() {
+		buildWidth, _, _, _ := m.layout.SplitPanes(0)
+		return x > buildWidth
+	}
 	topHeight := m.runMode.TopHeight
 	if topHeight == 0 {
 		topHeight = m.layout.SplitTopHeight()
@@ -1506,6 +2176,9 @@ func (m *Model) cancelRunningOp() {
 	if m.cancelFn != nil {
 		m.cancelFn()
 	}
+	if m.replacedRun != nil {
+		m.replacedRun.cancel()
+	}
 	m.setStatus("Canceling…")
 	if m.runMode.Active {
 		// Exit split view immediately so the bottom bar returns to normal hints.
@@ -1523,53 +2196,84 @@ func (m *Model) stopApp() tea.Cmd {
 			return statusMsg(err.Error())
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		defer cancel()
+		return stopAndForget(m.projectRoot, target, m.cfg.Xcodebuild.PreserveLocale)
+	}
+}
 
-		switch target.Target {
-		case string(core.DestSimulator):
-			if target.UDID == "" {
-				return statusMsg("Missing simulator UDID")
-			}
-			if target.BundleID == "" {
-				return statusMsg("Missing bundle id")
-			}
-			if _, err := core.RunStreaming(ctx, core.CmdSpec{
-				Path: "xcrun",
-				Args: []string{"simctl", "terminate", target.UDID, target.BundleID},
-			}); err != nil {
-				return statusMsg("Stop failed: " + err.Error())
-			}
-		case string(core.DestDevice):
-			if target.UDID == "" {
-				return statusMsg("Missing device UDID")
-			}
-			if err := core.DevicectlStop(ctx, target.UDID, target.PID, target.BundleID, nil); err != nil {
-				return statusMsg("Stop failed: " + err.Error())
-			}
-			if target.CompanionTargetID != "" && target.CompanionBundleID != "" {
-				_ = core.DevicectlStop(ctx, target.CompanionTargetID, 0, target.CompanionBundleID, nil)
-			}
-		case string(core.DestMacOS), string(core.DestCatalyst):
-			if target.PID == 0 {
-				return statusMsg("Missing PID for stop")
-			}
-			if err := syscall.Kill(target.PID, syscall.SIGTERM); err != nil {
-				return statusMsg("Stop failed: " + err.Error())
-			}
-		default:
-			return statusMsg("Stop not supported for target: " + target.Target)
-		}
+// stopAndForget terminates target and drops its session
+func stopAndForget(projectRoot string, target stopTarget, preserveLocale bool) statusMsg {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
 
-		removeID := target.SessionID
-		if removeID == "" {
-			removeID = target.BundleID
+	if err := stopTargetApp(ctx, target, preserveLocale); err != nil {
+		return statusMsg(err.Error())
+	}
+	forgetSession(projectRoot, target)
+	return statusMsg("App stopped")
+}
+
+// forgetSession drops the session of target
+func forgetSession(projectRoot string, target stopTarget) {
+	removeID := target.SessionID
+	if removeID == "" {
+		removeID = target.BundleID
+	}
+	if removeID != "" {
+		_ = core.RemoveSession(projectRoot, removeID)
+	}
+}
+
+// sessionStopTarget describes how to stop the app of a tracked session
+func sessionStopTarget(sess core.Session) stopTarget {
+	return stopTarget{
+		BundleID:          sess.BundleID,
+		PID:               sess.PID,
+		Target:            sess.Target,
+		UDID:              sess.UDID,
+		SessionID:         sess.ID,
+		CompanionTargetID: sess.CompanionTargetID,
+		CompanionBundleID: sess.CompanionBundleID,
+	}
+}
+
+// stopTargetApp terminates the app described by target
+func stopTargetApp(ctx context.Context, target stopTarget, preserveLocale bool) error {
+	switch target.Target {
+	case string(core.DestSimulator):
+		if target.UDID == "" {
+			return errors.New("Missing simulator UDID")
+		}
+		if target.BundleID == "" {
+			return errors.New("Missing bundle id")
 		}
-		if removeID != "" {
-			_ = core.RemoveSession(m.projectRoot, removeID)
+		if _, err := core.RunStreaming(ctx, core.CmdSpec{
+			Path:           "xcrun",
+			Args:           []string{"simctl", "terminate", target.UDID, target.BundleID},
+			PreserveLocale: preserveLocale,
+		}); err != nil {
+			return errors.New("Stop failed: " + err.Error())
 		}
-		return statusMsg("App stopped")
+	case string(core.DestDevice):
+		if target.UDID == "" {
+			return errors.New("Missing device UDID")
+		}
+		if err := core.DevicectlStop(ctx, target.UDID, target.PID, target.BundleID, nil, preserveLocale); err != nil {
+			return errors.New("Stop failed: " + err.Error())
+		}
+		if target.CompanionTargetID != "" && target.CompanionBundleID != "" {
+			_ = core.DevicectlStop(ctx, target.CompanionTargetID, 0, target.CompanionBundleID, nil, preserveLocale)
+		}
+	case string(core.DestMacOS), string(core.DestCatalyst):
+		if target.PID == 0 {
+			return errors.New("Missing PID for stop")
+		}
+		if err := syscall.Kill(target.PID, syscall.SIGTERM); err != nil {
+			return errors.New("Stop failed: " + err.Error())
+		}
+	default:
+		return errors.New("Stop not supported for target: " + target.Target)
 	}
+	return nil
 }
 
 func (m *Model) resolveStopTarget() (stopTarget, error) {
@@ -1585,13 +2289,7 @@ func (m *Model) resolveStopTarget() (stopTarget, error) {
 		if err != nil {
 			return stopTarget{}, fmt.Errorf("no running app found")
 		}
-		target.BundleID = sess.BundleID
-		target.PID = sess.PID
-		target.Target = sess.Target
-		target.UDID = sess.UDID
-		target.SessionID = sess.ID
-		target.CompanionTargetID = sess.CompanionTargetID
-		target.CompanionBundleID = sess.CompanionBundleID
+		target = sessionStopTarget(sess)
 	}
 
 	if target.Target == "" {
@@ -1658,6 +2356,9 @@ func (m *Model) enterSearchMode() {
 // exitSearchMode exits search mode and optionally clears search
 func (m *Model) exitSearchMode(clearSearch bool) {
 	m.mode = ModeNormal
+	if m.logBrowser.Active {
+		m.mode = ModeLogBrowser
+	}
 	m.searchInput.Blur()
 	m.searchActive = false
 	if clearSearch {
@@ -1665,6 +2366,8 @@ func (m *Model) exitSearchMode(clearSearch bool) {
 		m.searchMatches = nil
 		m.searchCursor = 0
 		m.phaseView.ClearSearch()
+		m.logBrowser.matches = nil
+		m.logBrowser.Console.Highlight = 0
 	}
 }
 
@@ -1675,15 +2378,20 @@ func (m *Model) executeSearch() {
 		m.searchMatches = nil
 		m.searchCursor = 0
 		m.phaseView.ClearSearch()
+		m.logBrowser.matches = nil
 		return
 	}
 
 	m.searchQuery = query
-	m.searchMatches = m.phaseView.Search(query)
+	if m.logBrowser.Active {
+		m.logBrowser.matches = m.logBrowser.Console.Search(query, m.visibleConsoleIndices())
+	} else {
+		m.searchMatches = m.phaseView.Search(query)
+	}
 	m.searchCursor = 0
 
-	if len(m.searchMatches) > 0 {
-		m.setStatus(fmt.Sprintf("%d matches", len(m.searchMatches)))
+	if m.searchMatchCount() > 0 {
+		m.setStatus(fmt.Sprintf("%d matches", m.searchMatchCount()))
 		m.jumpToSearchMatch(0)
 	} else {
 		m.setStatus("No matches found")
@@ -1692,35 +2400,56 @@ func (m *Model) executeSearch() {
 
 // jumpToSearchMatch jumps to a specific search match
 func (m *Model) jumpToSearchMatch(idx int) {
-	if idx < 0 || idx >= len(m.searchMatches) {
+	if idx < 0 || idx >= m.searchMatchCount() {
 		return
 	}
 	m.searchCursor = idx
+	if m.logBrowser.Active {
+		c := &m.logBrowser.Console
+		pos := m.logBrowser.matches[idx]
+		visible := m.visibleConsoleIndices()
+		if pos >= len(visible) {
+			return
+		}
+		c.Pos = minInt(pos, m.consoleMaxPos())
+		c.Follow = false
+		c.Highlight = c.IDs[visible[pos]]
+		return
+	}
 	match := m.searchMatches[idx]
 	m.phaseView.JumpToMatch(match.Phase, match.Line)
 }
 
+// searchMatchCount is the number of matches of the current search: in the
+// log browser's console when it is open, else in the logs
+func (m Model) searchMatchCount() int {
+	if m.logBrowser.Active {
+		return len(m.logBrowser.matches)
+	}
+	return len(m.searchMatches)
+}
+
 // nextSearchMatch moves to the next search match
 func (m *Model) nextSearchMatch() {
-	if len(m.searchMatches) == 0 {
+	if m.searchMatchCount() == 0 {
 		return
 	}
-	m.searchCursor = (m.searchCursor + 1) % len(m.searchMatches)
+	m.searchCursor = (m.searchCursor + 1) % m.searchMatchCount()
 	m.jumpToSearchMatch(m.searchCursor)
-	m.setStatus(fmt.Sprintf("%d/%d", m.searchCursor+1, len(m.searchMatches)))
+	m.setStatus(fmt.Sprintf("%d/%d", m.searchCursor+1, m.searchMatchCount()))
 }
 
 // prevSearchMatch moves to the previous search match
 func (m *Model) prevSearchMatch() {
-	if len(m.searchMatches) == 0 {
+	if m.searchMatchCount() == 0 {
 		return
 	}
 	m.searchCursor--
 	if m.searchCursor < 0 {
-		m.searchCursor = len(m.searchMatches) - 1
+		m.searchCursor = m.searchMatchCount() - 1
 	}
 	m.jumpToSearchMatch(m.searchCursor)
-	m.setStatus(fmt.Sprintf("%d/%d", m.searchCursor+1, len(m.searchMatches)))
+	m.setStatus(fmt.Sprintf("%d/%d", m.searchCursor+1, m.searchMatchCount()))
 }
 
 // statusMsg is a message for setting status
@@ -1740,17 +2469,10 @@ func (m *Model) tryAutoDetect() bool {
 		return false
 	}
 
-	// Auto-select project: prefer workspace, then project
+	// Discovery picked the workspace or project unless it was ambiguous;
+	// then openContainerChooser asks
 	if m.cfg.Workspace == "" && m.cfg.Project == "" {
-		if len(m.info.Workspaces) == 1 {
-			m.cfg.Workspace = m.info.Workspaces[0]
-		} else if len(m.info.Projects) == 1 {
-			m.cfg.Project = m.info.Projects[0]
-		} else if len(m.info.Workspaces) > 0 {
-			m.cfg.Workspace = m.info.Workspaces[0]
-		} else if len(m.info.Projects) > 0 {
-			m.cfg.Project = m.info.Projects[0]
-		}
+		return false
 	}
 
 	// Auto-select scheme: prefer first one
@@ -1810,9 +2532,15 @@ func (m *Model) tryAutoDetect() bool {
 	return m.cfg.Scheme != "" && (m.cfg.Workspace != "" || m.cfg.Project != "")
 }
 
-// saveRecentCombo saves the current scheme+destination combo to recents
+// saveRecentCombo saves the current scheme+destination combo to recents and
+// remembers the destination for the scheme
 func (m *Model) saveRecentCombo() {
-	if m.cfg.Scheme == "" || m.cfg.Destination.UDID == "" {
+	if m.cfg.Scheme == "" {
+		return
+	}
+	m.rememberSchemeDestination()
+	if m.cfg.Destination.UDID == "" {
+		m.saveStateAsync()
 		return
 	}
 
@@ -1825,16 +2553,37 @@ func (m *Model) saveRecentCombo() {
 	}
 
 	m.state.AddRecentCombo(m.projectRoot, combo)
-	// Save state in background (ignore errors)
-	go func() {
-		_ = core.SaveState(m.state)
-	}()
+	m.saveStateAsync()
+}
+
+// eventTime returns when ev was emitted, falling back to the receive time for
+// events without a timestamp.
+func eventTime(ev core.Event, received time.Time) time.Time {
+	if t, ok := ev.Time(); ok {
+		return t
+	}
+	return received
 }
 
 func (m *Model) handleEvent(ev core.Event) {
 	line := m.formatEventLine(ev)
 	now := time.Now()
+	at := eventTime(ev, now)
 	m.lastEvent = now
+	if ev.Code == core.SwiftPMProgressCode {
+		m.observePackageProgress(ev)
+		return
+	}
+	if ev.Code == core.XcodebuildCommandCode {
+		m.observeXcodebuildCommand(ev, at)
+		return
+	}
+	if ev.Type == "progress" {
+		if !m.cfg.TUI.LegacyProgress {
+			m.observeProgress(ev)
+		}
+		return
+	}
 	if ev.Type == "log" || ev.Type == "log_raw" {
 		m.lastLog = now
 	}
@@ -1865,20 +2614,36 @@ func (m *Model) handleEvent(ev core.Event) {
 			m.tabView.SummaryTab.UpdateProgress("", 0, 0, "Running")
 		}
 		consoleLine := m.formatConsoleEvent(ev)
-		if m.consoleLevelEnabled(consoleLine, ev) {
-			m.appendConsoleLog(consoleLine)
-		}
+		m.appendConsoleLogAt(consoleLine, consoleEventLevel(consoleLine, ev), at)
+		return
+	}
+
+	// Stderr tool chatter is informational: show it, but never count it.
+	if isToolNoiseEvent(ev) {
+		m.tabView.AddLineAt(ev.Msg, TabLineTypeVerbose, at)
+		m.streamView.AddRawLine(ev.Msg)
+		m.phaseView.AddInfoLine(ev.Msg)
 		return
 	}
 
+	if m.runningCmd == "test" && (ev.Type == "log_raw" || (ev.Type == "log" && !isPrettyEvent(ev))) {
+		if m.testCounts.observeTestLine(ev.Msg) {
+			m.tabView.SummaryTab.SetTestCounts(m.testCounts)
+		}
+	}
+
 	// Route to TabView (new tab-based system)
 	switch {
 	case ev.Type == "log_raw":
-		m.tabView.AddRawLine(ev.Msg)
+		m.tabView.AddRawLineAt(ev.Msg, at)
 	case ev.Type == "log":
-		m.tabView.AddRawLine(ev.Msg)
+		m.tabView.AddRawLineAt(ev.Msg, at)
+	case ev.Type == "error":
+		m.tabView.AddEventLineAt(line, TabLineTypeError, at)
+	case ev.Type == "warning":
+		m.tabView.AddEventLineAt(line, TabLineTypeWarning, at)
 	default:
-		m.tabView.AddRawLine(line)
+		m.tabView.AddRawLineAt(line, at)
 	}
 
 	// Stream view always tracks raw or pretty output (legacy).
@@ -1912,9 +2677,7 @@ func (m *Model) handleEvent(ev core.Event) {
 		// In run mode, route app/unified logs to console pane.
 		if m.runMode.Active && m.runningCmd == "run" && isConsoleEvent(ev) {
 			line := m.formatConsoleEvent(ev)
-			if m.consoleLevelEnabled(line, ev) {
-				m.appendConsoleLog(line)
-			}
+			m.appendConsoleLogAt(line, consoleEventLevel(line, ev), at)
 		} else {
 			m.appendLog(phaseLine)
 		}
@@ -1922,22 +2685,50 @@ func (m *Model) handleEvent(ev core.Event) {
 
 	// Track progress for stage indicators
 	m.parseProgressFromEvent(ev)
-	// Error/warning counts are tracked by PhaseView through categorizeLogLine
+}
 
-	// Track errors/warnings count for Dashboard
-	if isConsoleEvent(ev) {
-		return
-	}
-	if ev.Type == "error" {
-		m.tabView.SummaryTab.IncrementErrors()
-		return
+// observePackageProgress updates the Packages stage from a SwiftPM progress event
+func (m *Model) observePackageProgress(ev core.Event) {
+	data, _ := ev.Data.(map[string]any)
+	fetched, _ := data["fetched"].(int)
+	total, _ := data["total"].(int)
+	pkg, _ := data["package"].(string)
+	m.currentStage = "Packages"
+	m.stageProgress = fmt.Sprintf("%d/%d", fetched, total)
+	m.progressBar.SetProgress(fetched, total, m.currentStage)
+	m.tabView.SummaryTab.UpdateProgress("", 0, 0, m.currentStage)
+	m.tabView.SummaryTab.SetPackageProgress(fetched, total, pkg)
+}
+
+// observeProgress shows a progress event in the progress bar, status bar,
+// and Dashboard
+func (m *Model) observeProgress(ev core.Event) {
+	data, _ := ev.Data.(map[string]any)
+	stage, _ := data["stage"].(string)
+	current, _ := data["current"].(int)
+	total, _ := data["total"].(int)
+	file, _ := data["file"].(string)
+	if stage != "" {
+		m.currentStage = stage
+	}
+	m.progressCur, m.progressTotal = current, total
+	m.stageProgress = ""
+	if total > 0 {
+		m.stageProgress = fmt.Sprintf("%d/%d", current, total)
 	}
-	switch issueSeverity(ev.Msg) {
-	case TabLineTypeError:
-		m.tabView.SummaryTab.IncrementErrors()
-	case TabLineTypeWarning:
-		m.tabView.SummaryTab.IncrementWarnings()
+	m.progressBar.SetProgress(current, total, m.currentStage)
+	m.tabView.SummaryTab.UpdateProgress(file, current, total, m.currentStage)
+}
+
+// observeXcodebuildCommand remembers the invocation for "copy last command"
+// and shows it muted in the Logs tab
+func (m *Model) observeXcodebuildCommand(ev core.Event, at time.Time) {
+	data, _ := ev.Data.(map[string]any)
+	if command, _ := data["command"].(string); command != "" {
+		m.lastCommand = command
 	}
+	m.tabView.AddLineAt(ev.Msg, TabLineTypeVerbose, at)
+	m.streamView.AddRawLine(ev.Msg)
 }
 
 func (m *Model) parseProgressFromEvent(ev core.Event) {
@@ -1952,8 +2743,10 @@ func (m *Model) parseProgressFromEvent(ev core.Event) {
 		return
 	}
 
-	// Clear build stage once build completes successfully.
-	if strings.Contains(msg, "Build Succeeded") || strings.Contains(msg, "BUILD SUCCEEDED") {
+	// Clear build stage once the operation reports its result. The result
+	// event is emitted by xcbolt itself, so this doesn't depend on the
+	// (possibly localized) "** BUILD SUCCEEDED **" banner.
+	if ev.Type == "result" || strings.Contains(msg, "Build Succeeded") || strings.Contains(msg, "BUILD SUCCEEDED") {
 		m.currentStage = ""
 		m.stageProgress = ""
 		m.progressCur = 0
@@ -1966,8 +2759,46 @@ func (m *Model) parseProgressFromEvent(ev core.Event) {
 		return
 	}
 
-	// Extract stage from common xcodebuild output patterns
+	if ev.Code == core.ProjectGenerateCode {
+		m.currentStage = "Generate"
+		return
+	}
+	if ev.Code == core.RunPhaseCode {
+		if stage := runPhaseStage(ev); stage != "" {
+			m.currentStage = stage
+		}
+		return
+	}
+	if ev.Code == core.LaunchPresetCode {
+		if data, ok := ev.Data.(map[string]any); ok {
+			m.launchPreset, _ = data["preset"].(string)
+		}
+		return
+	}
+	if ev.Code == core.AppTerminatedCode {
+		m.showAppTermination(ev)
+	}
+	if ev.Code == core.RunTimingCode {
+		if data, ok := ev.Data.(map[string]any); ok {
+			timings, _ := data["phases"].([]core.RunStepTiming)
+			m.tabView.SummaryTab.SetRunTimings(timings)
+		}
+		return
+	}
+	if ev.Code == core.StressIterationCode {
+		m.setStressProgress(ev)
+		return
+	}
+	if !m.cfg.TUI.LegacyProgress {
+		// Stage and progress come from core's progress events
+		return
+	}
+
+	// Extract stage from common xcodebuild output patterns. Raw step names
+	// (CompileSwift, Ld, ...) are never localized, so check them first.
 	switch {
+	case core.RawStepStage(msg) != "":
+		m.currentStage = core.RawStepStage(msg)
 	case strings.Contains(msg, "Compiling"):
 		m.currentStage = "Compile"
 	case strings.Contains(msg, "Linking"):
@@ -1984,8 +2815,8 @@ func (m *Model) parseProgressFromEvent(ev core.Event) {
 		m.currentStage = "Testing"
 	case strings.Contains(msg, "Analyzing"):
 		m.currentStage = "Analyzing"
-	case strings.Contains(msg, "Resolving") || strings.Contains(msg, "Resolved"):
-		m.currentStage = "Resolve"
+	case strings.HasPrefix(msg, "SwiftPM: ") || strings.Contains(msg, "Resolving") || strings.Contains(msg, "Resolved"):
+		m.currentStage = "Packages"
 	}
 
 	// Extract current file being processed
@@ -2044,6 +2875,15 @@ func isPrettyEvent(ev core.Event) bool {
 	return ok && pretty
 }
 
+func isToolNoiseEvent(ev core.Event) bool {
+	m, ok := ev.Data.(map[string]any)
+	if !ok {
+		return false
+	}
+	noise, ok := m["toolNoise"].(bool)
+	return ok && noise
+}
+
 func isConsoleEvent(ev core.Event) bool {
 	m, ok := ev.Data.(map[string]any)
 	if !ok {
@@ -2072,43 +2912,6 @@ func (m *Model) formatConsoleEvent(ev core.Event) string {
 	return ev.Msg
 }
 
-func (m *Model) consoleLevelEnabled(line string, ev core.Event) bool {
-	mm, ok := ev.Data.(map[string]any)
-	if ok {
-		if s, ok := mm["stream"].(string); ok && s == "system" {
-			return true
-		}
-	}
-	levels := m.cfg.Launch.ConsoleLogLevels
-	if len(levels) == 0 {
-		return true
-	}
-	level := extractConsoleLevel(line)
-	if level == "" {
-		return true
-	}
-	if v, ok := levels[level]; ok {
-		return v
-	}
-	return true
-}
-
-func extractConsoleLevel(line string) string {
-	fields := strings.Fields(line)
-	if len(fields) < 2 {
-		return ""
-	}
-	level := strings.TrimSpace(fields[1])
-	if level == "" {
-		return ""
-	}
-	r := []rune(level)
-	if len(r) == 0 {
-		return ""
-	}
-	return strings.ToUpper(string(r[0]))
-}
-
 func isCanceledErr(err error) bool {
 	if err == nil {
 		return false
@@ -2116,6 +2919,11 @@ func isCanceledErr(err error) bool {
 	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
 		return true
 	}
+	// core tells cancellations apart: a killed xcodebuild is a failure
+	var exitErr *core.XcodebuildExitError
+	if errors.As(err, &exitErr) {
+		return false
+	}
 	msg := strings.ToLower(err.Error())
 	if strings.Contains(msg, "canceled") || strings.Contains(msg, "cancelled") {
 		return true
@@ -2126,15 +2934,54 @@ func isCanceledErr(err error) bool {
 	return false
 }
 
-func (m *Model) handleOpDone(msg opDoneMsg) {
+// isTimeoutErr distinguishes a deadline from a user cancel
+func isTimeoutErr(err error) bool {
+	return errors.Is(err, context.DeadlineExceeded)
+}
+
+// canceledDetail describes how far an interrupted op got: the phase a build
+// was in, or the tests that ran (against the last full run's total when known)
+func canceledDetail(reason, stage string, counts TestCounts, expected int) string {
+	label := strings.ToUpper(reason[:1]) + reason[1:]
+	if counts.Any() {
+		ran := counts.Passed + counts.Failed + counts.Skipped + counts.ExpectedFailures
+		of := ""
+		if expected > ran {
+			of = fmt.Sprintf(" of ~%d", expected)
+		}
+		return fmt.Sprintf("%s — %d%s tests ran, %d failures so far", label, ran, of, counts.Failed)
+	}
+	if stage != "" {
+		return label + " during " + stage
+	}
+	return label
+}
+
+func (m *Model) handleOpDone(msg opDoneMsg) tea.Cmd {
+	stage := m.currentStage
 	m.running = false
 	m.runningCmd = ""
+	m.runningTarget = ""
 	m.cancelFn = nil
-	m.eventCh = nil
 	if m.eventStopCh != nil {
 		close(m.eventStopCh)
 		m.eventStopCh = nil
 	}
+	if m.events != nil {
+		m.events.log.Close()
+		if rec := m.events.lastRun; rec != nil {
+			run := rec.Finish(msg.err)
+			if err := core.WriteLastRun(core.LastRunPath(m.projectRoot), run); err != nil {
+				m.setStatus("Could not write the run summary: " + err.Error())
+			}
+			cfg := m.cfg
+			if msg.cfg.Scheme != "" {
+				cfg = msg.cfg
+			}
+			_ = core.RecordMetrics(m.projectRoot, cfg, run)
+		}
+		m.events = nil
+	}
 	m.doneCh = nil
 	m.currentStage = ""
 	m.stageProgress = ""
@@ -2145,10 +2992,8 @@ func (m *Model) handleOpDone(msg opDoneMsg) {
 	// If a run failed or was canceled before launch, exit split view.
 	if msg.cmd == "run" && msg.run == nil {
 		m.runMode.Active = false
-		m.runMode.ConsoleLogs = nil
+		m.runMode.Console.Reset()
 		m.runMode.FocusPane = PaneBuild
-		m.runMode.ConsolePos = 0
-		m.runMode.ConsoleFollow = true
 		m.runMode.Status = ""
 		m.runMode.StatusAt = time.Time{}
 	}
@@ -2157,6 +3002,7 @@ func (m *Model) handleOpDone(msg opDoneMsg) {
 	m.progressBar.Hide()
 	m.layout.ShowProgressBar = false
 
+	var configCmd tea.Cmd
 	if msg.cfg.Version != 0 {
 		prev := m.cfg
 		changed := prev.Scheme != msg.cfg.Scheme ||
@@ -2171,19 +3017,38 @@ func (m *Model) handleOpDone(msg opDoneMsg) {
 			}
 		}
 		m.cfg = msg.cfg
+		if changed {
+			configCmd = m.noteConfigChanges(prev)
+		}
+	}
+	if msg.info != nil {
+		m.info = *msg.info
 	}
 
 	success := msg.err == nil
 	canceled := isCanceledErr(msg.err)
+	cancelReason := ""
+	if canceled {
+		cancelReason = "canceled"
+		if isTimeoutErr(msg.err) {
+			cancelReason = "timed out"
+		}
+	}
 	status := BuildStatusFailed
 	if canceled {
 		status = BuildStatusCanceled
 	} else if success {
 		status = BuildStatusSuccess
 	}
+	// A run canceled after its build succeeded reports the build's success;
+	// only the interrupted phase was canceled
+	launchCanceled := msg.runPhase != ""
+	if launchCanceled {
+		status = BuildStatusSuccess
+	}
 
 	// Mark build complete in PhaseView (triggers smart collapse)
-	m.phaseView.MarkBuildComplete(success)
+	m.phaseView.MarkBuildComplete(success || launchCanceled)
 
 	// Track result duration for status bar
 	var duration time.Duration
@@ -2202,6 +3067,24 @@ func (m *Model) handleOpDone(msg opDoneMsg) {
 		if msg.build.BundleID != "" {
 			m.tabView.SummaryTab.SetAppInfo(msg.build.BundleID)
 		}
+		if success && !m.cfg.Xcodebuild.DryRun && msg.target == "" {
+			m.recordBuildCompiles(*msg.build)
+		}
+		if len(msg.build.CompileTimes) > 0 {
+			m.tabView.StreamTab.AnnotateCompileTimes(msg.build.CompileTimes)
+			m.tabView.SummaryTab.SetSlowestFiles(core.SlowestFiles(msg.build.CompileTimes, core.SlowestFilesLimit))
+		}
+		m.tabView.SummaryTab.SetTargets(msg.build.Targets)
+	}
+	if success && cleansDerivedData(msg.cmd) {
+		m.recordClean()
+	}
+	m.runApp = nil
+	if m.hotRunning {
+		m.hotRunning = false
+		if !success && !canceled && m.runMode.Active {
+			m.appendHotRerunFailure(msg.err, msg.replaced)
+		}
 	}
 	if msg.run != nil {
 		m.lastRun = *msg.run
@@ -2215,8 +3098,32 @@ func (m *Model) handleOpDone(msg opDoneMsg) {
 			m.tabView.SummaryTab.SetAppInfo(msg.run.BundleID)
 		}
 	}
+	expectedTests := 0
+	if !m.lastTest.Summary.Partial {
+		expectedTests = m.lastTest.Summary.Total
+	}
 	if msg.test != nil {
 		m.lastTest = *msg.test
+		// xcresult counts are authoritative (and include expected failures).
+		if sum := msg.test.Summary; sum.HasCounts() {
+			m.testCounts = testCountsFromSummary(sum)
+			m.tabView.SummaryTab.SetTestCounts(m.testCounts)
+			m.tabView.IssuesTab.SetSkippedTests(sum.Skipped, sum.SkippedTests)
+		}
+		if len(msg.test.Flaky) > 0 {
+			m.tabView.IssuesTab.SetFlakyTests(msg.test.Flaky)
+		}
+		warn, fail := m.cfg.Test.CoverageThresholds()
+		m.tabView.SummaryTab.SetCoverage(msg.test.Coverage, warn, fail)
+		m.tabView.SummaryTab.SetTestChanges(msg.test.Changes)
+		if msg.test.Changes != nil {
+			m.tabView.IssuesTab.MarkNewTestFailures(msg.test.Changes.NewFailures)
+		}
+		// The test op recorded its failures in the user state; don't let
+		// the next save of the in-memory copy drop them
+		if st, err := core.LoadState(); err == nil {
+			m.state.TestFailures = st.TestFailures
+		}
 		m.lastResult = &Result{
 			Operation: "Test",
 			Success:   success,
@@ -2226,16 +3133,72 @@ func (m *Model) handleOpDone(msg opDoneMsg) {
 		duration = msg.test.Duration
 		durationStr = duration.Round(100 * time.Millisecond).String()
 	}
+	if msg.stress != nil {
+		m.tabView.SummaryTab.SetStress(msg.stress)
+		m.lastResult = &Result{
+			Operation: "Test",
+			Success:   success,
+			Message:   msg.stress.Line(),
+			Duration:  msg.stress.Duration,
+			Timestamp: time.Now(),
+		}
+		duration = msg.stress.Duration
+		durationStr = duration.Round(100 * time.Millisecond).String()
+	}
+	if msg.analyze != nil {
+		m.lastAnalyze = *msg.analyze
+		m.lastResult = &Result{
+			Operation: "Analyze",
+			Success:   success,
+			Duration:  msg.analyze.Duration,
+			Timestamp: time.Now(),
+		}
+		duration = msg.analyze.Duration
+		durationStr = duration.Round(100 * time.Millisecond).String()
+		m.tabView.SummaryTab.SetAnalyzerReport(analyzerReportPath(*msg.analyze))
+	}
 
 	// Update TabView summary with build results
+	var exitErr *core.XcodebuildExitError
+	if launchCanceled {
+		m.tabView.SummaryTab.SetRunPhaseNote(msg.runPhase + " · the app is built (Run: Install & Launch Only)")
+	} else if canceled {
+		counts := TestCounts{}
+		if msg.cmd == "test" {
+			counts = m.testCounts
+		}
+		m.tabView.SummaryTab.SetCanceled(cancelReason == "timed out", canceledDetail(cancelReason, stage, counts, expectedTests))
+	} else if errors.As(msg.err, &exitErr) {
+		m.tabView.SummaryTab.SetFailure(exitErr.Failure, exitErr.Signal)
+		m.tabView.IssuesTab.Termination = exitErr.Signal
+	}
 	m.tabView.SetBuildResult(status, durationStr, nil)
+	if msg.cmd == "run" && !success && !canceled && m.mode == ModeNormal {
+		m.openDeviceHelp(msg.err)
+	}
+
+	// Record duration history (canceled ops say nothing about speed, and
+	// target builds about the scheme's)
+	if !canceled && msg.target == "" {
+		elapsed := duration
+		if elapsed <= 0 {
+			elapsed = time.Since(m.opStart)
+		}
+		m.recordDuration(msg.cmd, elapsed, success)
+		m.syncHistory()
+	}
 
 	// Update status bar with last result
 	m.statusBar.HasLastResult = true
 	if !canceled {
 		m.statusBar.LastResultSuccess = success
 	}
-	if canceled {
+	if launchCanceled {
+		m.statusBar.LastResultSuccess = true
+		m.statusBar.LastResultStatus = "success"
+	} else if cancelReason == "timed out" {
+		m.statusBar.LastResultStatus = "timed_out"
+	} else if canceled {
 		m.statusBar.LastResultStatus = "canceled"
 	} else if success {
 		m.statusBar.LastResultStatus = "success"
@@ -2243,6 +3206,9 @@ func (m *Model) handleOpDone(msg opDoneMsg) {
 		m.statusBar.LastResultStatus = "error"
 	}
 	m.statusBar.LastResultOp = msg.cmd
+	if launchCanceled {
+		m.statusBar.LastResultOp = "build"
+	}
 	if duration > 0 {
 		m.statusBar.LastResultTime = duration.Round(100 * time.Millisecond).String()
 	} else {
@@ -2250,35 +3216,176 @@ func (m *Model) handleOpDone(msg opDoneMsg) {
 	}
 
 	// Append result line to logs
-	resultLine := m.formatResultLine(msg.cmd, success, canceled, duration)
+	resultLine := m.formatResultLine(msg.cmd, success, cancelReason, duration)
+	if launchCanceled {
+		resultLine = m.formatResultLine("build", true, "", duration) + " · " + msg.runPhase
+	}
 	m.appendLog(resultLine)
 	m.appendStreamLine(resultLine)
 	if msg.err != nil && !canceled {
 		errLine := "error: " + msg.err.Error()
 		m.appendLog(errLine)
 		m.appendStreamLine(errLine)
-		m.tabView.AddRawLine(errLine)
+		m.tabView.AddEventLineAt(errLine, TabLineTypeError, time.Now())
 	}
 
 	if msg.err != nil {
-		if canceled {
+		if cancelReason == "timed out" {
+			m.setStatus(opLabel(msg.cmd, msg.target) + " timed out")
+		} else if launchCanceled {
+			m.setStatus("Build succeeded · " + msg.runPhase + " · Run: Install & Launch Only reuses the app")
+		} else if canceled {
 			if strings.EqualFold(msg.cmd, "run") {
 				m.setStatus("Run canceled by user")
 			} else {
-				m.setStatus(strings.ToUpper(msg.cmd) + " canceled by user")
+				m.setStatus(opLabel(msg.cmd, msg.target) + " canceled by user")
 			}
 		} else {
 			m.lastErr = msg.err.Error()
-			m.setStatus(strings.ToUpper(msg.cmd) + " failed")
+			status := opLabel(msg.cmd, msg.target) + " failed"
+			if f := m.tabView.SummaryTab.Failure; f != "" && f != core.FailureBuild {
+				status = m.tabView.SummaryTab.FailureHeadline()
+			}
+			if m.autoSwitchOnFailure() {
+				status += " · switched to Issues"
+			}
+			m.setStatus(status)
+			var incompatible *core.DestinationIncompatibleError
+			if errors.As(msg.err, &incompatible) {
+				m.openCompatibleDestinationSelector(incompatible)
+			}
 		}
 	} else {
-		m.setStatus(strings.ToUpper(msg.cmd) + " done")
+		m.setStatus(opLabel(msg.cmd, msg.target) + " done")
+	}
+
+	// User cancels need no announcement; timeouts do
+	if canceled && cancelReason != "timed out" {
+		return configCmd
 	}
+	return tea.Batch(configCmd, notifyCmd(m.cfg.TUI, opNotice{
+		Op:      msg.cmd,
+		Success: success,
+		Elapsed: time.Since(m.opStart),
+		Errors:  m.tabView.Counts.ErrorCount,
+	}))
 }
 
 func (m *Model) startOp(name string) tea.Cmd {
+	return m.startOpRequest(core.LastOp{Name: name})
+}
+
+// rerunLastOp replays the last started op with the same parameters
+func (m *Model) rerunLastOp() tea.Cmd {
+	if m.running {
+		m.setStatus("An operation is already running")
+		return nil
+	}
+	if m.lastOp == nil {
+		m.setStatus("Nothing to rerun yet")
+		return nil
+	}
+	if isCleanOp(m.lastOp.Name) {
+		return m.requestClean(m.lastOp.Name)
+	}
+	if isUserCommandOp(m.lastOp.Name) {
+		return m.rerunUserCommand(m.lastOp.Name)
+	}
+	return m.startOpRequest(*m.lastOp)
+}
+
+// recordLastOp remembers req in the model and persists it to user state
+func (m *Model) recordLastOp(req core.LastOp) {
+	op := req
+	m.lastOp = &op
+	m.state.SetLastOp(m.projectRoot, op)
+	m.saveStateAsync()
+}
+
+// saveStateAsync writes user state in the background (errors are ignored)
+func (m *Model) saveStateAsync() {
+	st := m.state
+	go func() {
+		_ = core.SaveState(st)
+	}()
+}
+
+// historyQuery describes the current scheme/config/destination for op
+func (m *Model) historyQuery(op string) core.DurationEntry {
+	return core.DurationEntry{
+		Op:              op,
+		Scheme:          m.cfg.Scheme,
+		Configuration:   m.cfg.Configuration,
+		DestinationKind: string(m.cfg.Destination.Kind),
+	}
+}
+
+// recordDuration stores a finished op in the duration history
+func (m *Model) recordDuration(op string, d time.Duration, success bool) {
+	e := m.historyQuery(op)
+	e.DurationMs = d.Milliseconds()
+	e.Success = success
+	e.Timestamp = time.Now().Format(time.RFC3339)
+	m.state.AddDuration(m.projectRoot, e)
+	m.saveStateAsync()
+}
+
+// syncHistory shows the last build durations on the Dashboard
+func (m *Model) syncHistory() {
+	entries := m.state.GetDurations(m.projectRoot, m.historyQuery("build"))
+	if len(entries) > 10 {
+		entries = entries[len(entries)-10:]
+	}
+	durations := make([]time.Duration, 0, len(entries))
+	for _, e := range entries {
+		durations = append(durations, e.Duration())
+	}
+	m.tabView.SummaryTab.SetHistory(durations)
+}
+
+func (m *Model) clearHistory() {
+	m.state.ClearHistory(m.projectRoot)
+	m.saveStateAsync()
+	m.syncHistory()
+	m.setStatus("Duration history cleared")
+}
+
+func (m *Model) startOpRequest(req core.LastOp) tea.Cmd {
+	if built, stale := m.staleDerivedData(req); stale {
+		m.confirmStaleDerivedData(req, built)
+		return nil
+	}
+	name := req.Name
+	cfg := m.cfg
+	if req.Destination.ID != "" || req.Destination.UDID != "" {
+		cfg.Destination = req.Destination
+	} else {
+		req.Destination = cfg.Destination
+	}
+	if m.cleanStale {
+		cfg.Xcodebuild.AutoCleanOnXcodeChange = true
+		m.cleanStale = false
+	}
+	if name == "build" {
+		cfg.TargetOverride, cfg.TargetProject = req.Target, req.TargetProject
+	}
+	req.StartedAt = time.Now().Format(time.RFC3339)
+	m.recordLastOp(req)
+	hot := m.hotStop
+	m.hotStop = nil
+	if name != "run" {
+		hot = nil
+	}
+	m.hotRunning = hot != nil
+	replacing := m.replacedRun
+	if name != "run" || hot == nil {
+		replacing = nil
+	}
+	m.runApp = nil
+
 	m.running = true
 	m.runningCmd = name
+	m.runningTarget = cfg.TargetOverride
 	now := time.Now()
 	m.opStart = now
 	m.lastEvent = now
@@ -2287,6 +3394,7 @@ func (m *Model) startOp(name string) tea.Cmd {
 	m.lastStatus = ""
 	m.runMode.Status = ""
 	m.runMode.StatusAt = time.Time{}
+	m.launchPreset = ""
 
 	// Update progress bar
 	m.progressBar.Visible = true
@@ -2298,142 +3406,162 @@ func (m *Model) startOp(name string) tea.Cmd {
 	// Activate run mode split view for "run" command
 	if name == "run" {
 		m.runMode.Active = true
-		m.runMode.ConsoleLogs = nil
+		if hot != nil {
+			// Hot rerun: keep the console, divided from the new session
+			m.appendConsoleLog(hotRerunDivider(now), "")
+			m.runMode.Console.Pos = 0
+			m.runMode.Console.Follow = true
+		} else {
+			m.runMode.Console.Reset()
+		}
 		m.runMode.FocusPane = PaneBuild
-		m.runMode.ConsolePos = 0
-		m.runMode.ConsoleFollow = true
 	} else {
 		m.runMode.Active = false
 	}
 
-	// Clear logs for new operation
+	// Clear logs for new operation (keeps the outgoing run as a snapshot;
+	// the active tab is left as-is)
 	m.tabView.Clear()
+	m.tabView.Operation = name
+	m.testCounts = TestCounts{}
 	m.phaseView.Clear()
 	m.streamView.Clear()
+	m.resetBookmarks()
 
 	// Initialize Dashboard for live activity
 	m.tabView.SummaryTab.SetRunning(name)
+	m.publishStatus()
+
+	m.userTabOverride = false
+	if m.cfg.TUI.AutoSwitchMode() == core.AutoSwitchLogsOnStart && m.tabView.ActiveTab != TabStream {
+		m.tabView.SetActiveTab(TabStream)
+		m.setStatus(opLabel(name, m.runningTarget) + " started · switched to Logs")
+	} else if m.runningTarget != "" {
+		m.setStatus(opLabel(name, m.runningTarget))
+	}
 
 	m.appendLog("─────────────────────────────────────────")
-	m.appendLog(fmt.Sprintf("%s  %s", time.Now().Format("15:04:05"), strings.ToUpper(name)))
+	m.appendLog(fmt.Sprintf("%s  %s", time.Now().Format("15:04:05"), opLabel(name, m.runningTarget)))
 	m.appendStreamLine("─────────────────────────────────────────")
-	m.appendStreamLine(fmt.Sprintf("%s  %s", time.Now().Format("15:04:05"), strings.ToUpper(name)))
+	m.appendStreamLine(fmt.Sprintf("%s  %s", time.Now().Format("15:04:05"), opLabel(name, m.runningTarget)))
 
 	// Save this scheme+destination combo to recents
 	m.saveRecentCombo()
 
-	events := make(chan core.Event, 8192)
 	stopEvents := make(chan struct{})
+	emitter := &chanEmitter{ch: make(chan core.Event, 8192), stop: stopEvents, log: openOpLog(m.projectRoot, name, time.Now()), redact: m.redactor}
+	if name == "build" || name == "run" || name == "test" {
+		emitter.lastRun = core.NewLastRunRecorder(name)
+	}
 	done := make(chan opDoneMsg, 1)
+	exited := make(chan struct{})
 	ctx, cancel := context.WithCancel(context.Background())
+	m.opSeq++
+	seq := m.opSeq
 	m.cancelFn = cancel
-	m.eventCh = events
+	m.opExited = exited
+	m.events = emitter
 	m.eventStopCh = stopEvents
 	m.doneCh = done
 
-	emitter := &chanEmitter{ch: events, stop: stopEvents}
-
-	cfg := m.cfg
 	root := m.projectRoot
+	cleanTargets := m.cleanTargets
+	m.cleanTargets = nil
+	logSession := m.logSession
 
 	go func() {
+		defer close(exited)
 		switch name {
 		case "build":
 			res, cfg2, err := core.Build(ctx, root, cfg, emitter)
-			done <- opDoneMsg{cmd: name, err: err, cfg: cfg2, build: &res}
+			done <- opDoneMsg{cmd: name, err: err, cfg: cfg2, build: &res, target: cfg.TargetOverride}
 		case "run":
 			// Use console mode in TUI so run stays attached to app output.
-			res, cfg2, err := core.Run(ctx, root, cfg, true, emitter)
-			done <- opDoneMsg{cmd: name, err: err, cfg: cfg2, run: &res}
-		case "test":
-			res, cfg2, err := core.Test(ctx, root, cfg, nil, nil, emitter)
-			done <- opDoneMsg{cmd: name, err: err, cfg: cfg2, test: &res}
-		case "clean":
-			// Clean derived data and results
-			paths := []string{
-				filepath.Join(root, ".xcbolt", "DerivedData"),
-				filepath.Join(root, ".xcbolt", "Results"),
+			opts := core.RunOptions{Console: true, Preset: req.Preset}
+			if req.LaunchOnly {
+				opts.AppPath = cfg.LastBuiltAppBundle
+				opts.SkipInstall = req.SkipInstall
 			}
-			var cleanErr error
-			for _, p := range paths {
-				if err := os.RemoveAll(p); err != nil && !os.IsNotExist(err) {
-					cleanErr = err
+			replaced := false
+			if hot != nil {
+				target := *hot
+				opts.BeforeLaunch = func(ctx context.Context) error {
+					replaced = true
+					if err := replacing.end(ctx); err != nil {
+						return err
+					}
+					return stopBeforeRelaunch(ctx, root, target, emitter, cfg.Xcodebuild.PreserveLocale)
 				}
 			}
-			done <- opDoneMsg{cmd: name, err: cleanErr}
-		case "clean-derived":
-			p := filepath.Join(root, ".xcbolt", "DerivedData")
-			err := os.RemoveAll(p)
+			res, cfg2, err := core.RunWithOptions(ctx, root, cfg, opts, emitter)
+			var phaseErr *core.RunPhaseCanceledError
+			runPhase := ""
+			if errors.As(err, &phaseErr) {
+				runPhase = phaseErr.Error()
+			}
+			done <- opDoneMsg{cmd: name, err: err, cfg: cfg2, run: &res, replaced: replaced, runPhase: runPhase}
+		case "test":
+			if req.Repeat > 0 {
+				res, cfg2, err := core.StressTest(ctx, root, cfg, req.OnlyTesting, req.SkipTesting, core.StressOptions{Iterations: req.Repeat}, emitter)
+				done <- opDoneMsg{cmd: name, err: err, cfg: cfg2, stress: &res}
+				break
+			}
+			res, cfg2, err := core.Test(ctx, root, cfg, req.OnlyTesting, req.SkipTesting, emitter)
+			done <- opDoneMsg{cmd: name, err: err, cfg: cfg2, test: &res}
+		case "analyze":
+			res, cfg2, err := core.Analyze(ctx, root, cfg, emitter)
+			done <- opDoneMsg{cmd: name, err: err, cfg: cfg2, analyze: &res}
+		case "clean", "clean-derived", "clean-results", "clean-sessions", "clean-spm-cache":
+			targets := cleanTargets
+			if targets == nil {
+				targets = core.MeasureCleanTargets(ctx, core.CleanTargets(root, cfg, name))
+			}
+			_, err := core.RemoveCleanTargets(name, targets, emitter)
 			done <- opDoneMsg{cmd: name, err: err}
-		case "clean-results":
-			p := filepath.Join(root, ".xcbolt", "Results")
-			err := os.RemoveAll(p)
+		case "results-prune":
+			res, err := core.PruneResultBundles(ctx, cfg, time.Now(), name, emitter)
+			if errors.Is(err, core.ErrResultsBusy) {
+				// Already warned; nothing failed
+				err = nil
+			} else if err == nil && len(res.Removed) == 0 {
+				emitter.Emit(core.Log(name, "No result bundles beyond results.retention"))
+			}
 			done <- opDoneMsg{cmd: name, err: err}
-		case "clean-sessions":
-			p := filepath.Join(root, ".xcbolt", "sessions.json")
-			err := os.RemoveAll(p)
+		case "logs":
+			if logSession.UDID == "" {
+				done <- opDoneMsg{cmd: name, err: errors.New("no session selected (open Sessions from the palette)")}
+				break
+			}
+			err := core.SimctlLogStream(ctx, logSession.UDID, sessionLogPredicate(logSession.BundleID), emitter, cfg.Xcodebuild.PreserveLocale)
 			done <- opDoneMsg{cmd: name, err: err}
-		case "clean-spm-cache":
-			var cleanErr error
-			if home, err := os.UserHomeDir(); err == nil {
-				paths := []string{
-					filepath.Join(home, "Library", "Caches", "org.swift.swiftpm"),
-					filepath.Join(home, "Library", "Developer", "Xcode", "SourcePackages"),
-				}
-				for _, p := range paths {
-					if err := os.RemoveAll(p); err != nil && !os.IsNotExist(err) {
-						cleanErr = err
-					}
-				}
-			} else {
-				cleanErr = err
+		case "generate":
+			info, cfg2, err := core.GenerateProject(ctx, root, cfg, name, "", emitter)
+			out := opDoneMsg{cmd: name, err: err, cfg: cfg2}
+			if err == nil && info.ProjectRoot != "" {
+				out.info = &info
 			}
-			done <- opDoneMsg{cmd: name, err: cleanErr}
+			done <- out
 		default:
+			if isUserCommandOp(name) {
+				done <- runUserCommandOp(ctx, root, cfg, name, emitter)
+				break
+			}
 			done <- opDoneMsg{cmd: name, err: fmt.Errorf("unknown op %s", name)}
 		}
 		close(done)
 	}()
 	// Clear once at op start to avoid stale layout artifacts when switching modes.
-	return tea.Batch(waitForEvent(events, stopEvents), waitForDone(done), tickCmd(), tea.ClearScreen)
-}
-
-type chanEmitter struct {
-	ch   chan<- core.Event
-	stop <-chan struct{}
+	return tea.Batch(waitForEvent(emitter), waitForDone(done, seq), tickCmd(), tea.ClearScreen)
 }
 
-func (e *chanEmitter) Emit(ev core.Event) {
-	// Never block core operations on UI rendering.
-	// Also, stop accepting events once the op is done/canceled.
-	select {
-	case <-e.stop:
-		return
-	default:
-	}
-	select {
-	case e.ch <- ev:
-	default:
-	}
-}
-
-func waitForEvent(ch <-chan core.Event, stop <-chan struct{}) tea.Cmd {
-	return func() tea.Msg {
-		select {
-		case <-stop:
-			return nil
-		case ev := <-ch:
-			return eventMsg(ev)
-		}
-	}
-}
-
-func waitForDone(ch <-chan opDoneMsg) tea.Cmd {
+// waitForDone delivers the result of op seq
+func waitForDone(ch <-chan opDoneMsg, seq int) tea.Cmd {
 	return func() tea.Msg {
 		m, ok := <-ch
 		if !ok {
 			return nil
 		}
+		m.seq = seq
 		return m
 	}
 }
@@ -2446,37 +3574,30 @@ func (m *Model) appendStreamLine(line string) {
 	m.streamView.AddRawLine(line)
 }
 
-func (m *Model) appendConsoleLog(line string) {
-	meta, msg := splitConsoleEntry(line)
-	if meta != "" {
-		m.runMode.ConsoleLogs = append(m.runMode.ConsoleLogs, consoleMetaPrefix+meta)
-	}
-	for _, wrapped := range m.wrapConsoleLines(msg) {
-		m.runMode.ConsoleLogs = append(m.runMode.ConsoleLogs, wrapped)
-	}
-	if maxConsoleLines > 0 && len(m.runMode.ConsoleLogs) > maxConsoleLines {
-		drop := len(m.runMode.ConsoleLogs) - maxConsoleLines
-		m.runMode.ConsoleLogs = m.runMode.ConsoleLogs[drop:]
-		if !m.runMode.ConsoleFollow && m.runMode.ConsolePos > 0 {
-			m.runMode.ConsolePos -= drop
-			if m.runMode.ConsolePos < 0 {
-				m.runMode.ConsolePos = 0
-			}
-		}
-	}
+// appendConsoleLog stores every console line with its level; the level filter
+// is applied when rendering so changing it never loses output.
+func (m *Model) appendConsoleLog(line string, level string) {
+	m.appendConsoleLogAt(line, level, time.Now())
+}
+
+// appendConsoleLogAt appends a console entry emitted at the given time
+func (m *Model) appendConsoleLogAt(line string, level string, at time.Time) {
+	c := &m.runMode.Console
+	c.Append(line, level, at, func(msg string) []string {
+		return wrapConsoleText(msg, m.runConsoleWidth()-2)
+	})
+	c.Trim(maxConsoleLines, m.consoleLevelVisible)
 	// Auto-scroll if at bottom
-	maxPos := len(m.runMode.ConsoleLogs) - m.consolePaneHeight()
-	if maxPos < 0 {
-		maxPos = 0
-	}
-	if m.runMode.ConsoleFollow || m.runMode.ConsolePos >= maxPos-1 {
-		m.runMode.ConsolePos = maxPos
-		m.runMode.ConsoleFollow = true
+	visible := len(c.Lines)
+	if f, ok := consoleFilterFromLevels(m.cfg.Launch.ConsoleLogLevels); !ok || f != ConsoleFilterAll {
+		visible = len(c.VisibleIndices(m.consoleLevelVisible))
 	}
+	c.Settle(visible - m.runConsoleHeight())
 }
 
-func (m *Model) wrapConsoleLines(line string) []string {
-	width := maxInt(0, m.layout.ContentWidth()-2)
+// wrapConsoleText splits a console message into lines of at most width
+func wrapConsoleText(line string, width int) []string {
+	width = maxInt(0, width)
 	if width <= 0 || line == "" {
 		return []string{line}
 	}
@@ -2590,6 +3711,7 @@ func (m *Model) toggleConsoleLevel(level string, label string) {
 	if err := core.SaveConfig(m.projectRoot, m.configPath, m.cfg); err != nil {
 		m.lastErr = err.Error()
 	}
+	m.followConsole()
 	if next {
 		m.setStatus(label + " logs enabled")
 	} else {
@@ -2599,20 +3721,7 @@ func (m *Model) toggleConsoleLevel(level string, label string) {
 
 // scrollConsole scrolls the console pane by delta lines
 func (m *Model) scrollConsole(delta int) {
-	m.runMode.ConsolePos += delta
-
-	// Clamp to valid range
-	maxPos := len(m.runMode.ConsoleLogs) - m.consolePaneHeight()
-	if maxPos < 0 {
-		maxPos = 0
-	}
-	if m.runMode.ConsolePos < 0 {
-		m.runMode.ConsolePos = 0
-	}
-	if m.runMode.ConsolePos > maxPos {
-		m.runMode.ConsolePos = maxPos
-	}
-	m.runMode.ConsoleFollow = m.runMode.ConsolePos == maxPos
+	m.console().Scroll(delta, m.consoleMaxPos())
 }
 
 func (m *Model) formatEventLine(ev core.Event) string {
@@ -2640,29 +3749,26 @@ func (m *Model) formatEventLine(ev core.Event) string {
 	return prefix + msg
 }
 
-func (m *Model) formatResultLine(op string, success bool, canceled bool, duration time.Duration) string {
+// formatResultLine renders the op's final log line; cancelReason is
+// "canceled", "timed out", or "" when the op ran to completion
+func (m *Model) formatResultLine(op string, success bool, cancelReason string, duration time.Duration) string {
 	icons := m.styles.Icons
 
 	icon := icons.Success
 	status := "success"
-	verb := "Succeeded"
-	if canceled {
+	if cancelReason == "timed out" {
+		icon = icons.Warning
+		status = "warning"
+	} else if cancelReason != "" {
 		icon = icons.Paused
 		status = "canceled"
-		verb = "Canceled"
 	} else if !success {
 		icon = icons.Error
 		status = "error"
-		verb = "Failed"
 	}
 
 	iconStyled := m.styles.StatusStyle(status).Render(icon)
-	text := strings.ToUpper(op[:1]) + op[1:] + " " + verb
-	if duration > 0 {
-		text += " · " + duration.Round(100*time.Millisecond).String()
-	}
-
-	return iconStyled + " " + text
+	return iconStyled + " " + core.ResultHeadline(op, success, cancelReason, duration)
 }
 
 // =============================================================================
@@ -2684,6 +3790,49 @@ func (m Model) View() string {
 		return m.wizardView()
 	}
 
+	// Doctor overlay
+	if m.mode == ModeDoctor {
+		return m.doctorOverlayView()
+	}
+
+	// Build settings diff overlay
+	if m.mode == ModeSettingsDiff {
+		return m.settingsDiffOverlayView()
+	}
+
+	// Confirm prompt overlay
+	if m.mode == ModeConfirm {
+		return m.confirmOverlayView()
+	}
+
+	// Result bundle metadata overlay
+	if m.mode == ModeResultInfo {
+		return m.resultInfoOverlayView()
+	}
+
+	// Explain issue overlay
+	if m.mode == ModeExplain {
+		return m.explainOverlayView()
+	}
+
+	// Device install/launch remediation overlay
+	if m.mode == ModeDeviceHelp {
+		return m.deviceHelpOverlayView()
+	}
+
+	// Project launcher path input
+	if m.mode == ModeProjectPath {
+		return m.projectPathOverlayView()
+	}
+
+	// Log browser and its form
+	if m.mode == ModeLogForm {
+		return m.logFormOverlayView()
+	}
+	if m.mode == ModeLogBrowser {
+		return m.logBrowserView()
+	}
+
 	// Selector mode - show selector overlay on top of main view
 	if m.mode == ModeSelector {
 		return m.selectorOverlayView()
@@ -2716,6 +3865,13 @@ func (m Model) mainView() string {
 
 	// Build hints bar
 	hints := DefaultHints()
+	if m.layout.MinimalMode {
+		// No tab bar in the minimal layout: name the active tab instead
+		hints = append([]HintItem{{Key: "tab", Desc: m.tabView.ActiveTab.String()}}, hints...)
+	}
+	if m.lastOp != nil {
+		hints = append(hints, HintItem{Key: ".", Desc: "rerun"})
+	}
 	if m.running {
 		hints = append(hints, HintItem{Key: "x", Desc: "stop"})
 		if key := actionKeyForCmd(m.runningCmd); key != "" {
@@ -2723,24 +3879,30 @@ func (m Model) mainView() string {
 		}
 	}
 	if m.mouseEnabled {
-		hints = append(hints, HintItem{Key: "m", Desc: "mouse:on"})
+		hints = append(hints, HintItem{Key: "M", Desc: "mouse:on"})
 	} else {
-		hints = append(hints, HintItem{Key: "m", Desc: "mouse:off"})
+		hints = append(hints, HintItem{Key: "M", Desc: "mouse:off"})
 	}
 	hintsBarContent := m.hintsBar.renderHints(hints, m.styles)
 
 	// Use split view for run mode
 	if m.runMode.Active {
-		topHeight, bottomHeight := m.splitHeights(statusBarContent, progressBarContent, hintsBarContent)
+		buildWidth, topHeight, _, bottomHeight := m.splitPanes(statusBarContent, progressBarContent, hintsBarContent)
 		m.runMode.TopHeight = topHeight
 		m.runMode.BottomHeight = bottomHeight
-		m.tabView.SetSize(m.layout.ContentWidth(), topHeight)
+		m.tabView.SetSize(buildWidth, topHeight)
+		if m.layout.MinimalMode {
+			// Only the build pane is shown; tab keeps cycling tabs
+			m.tabView.SetSize(m.layout.ContentWidth(), m.layout.ContentHeight())
+		}
 		topContent := m.contentView()
 		bottomContent := m.consoleView()
 		topFocused := m.runMode.FocusPane == PaneBuild
 
 		// Add tab hint for run mode
-		hintsBarContent = m.runModeHintsBar()
+		if !m.layout.MinimalMode {
+			hintsBarContent = m.runModeHintsBar()
+		}
 
 		return m.layout.RenderSplitLayout(
 			statusBarContent,
@@ -2813,8 +3975,8 @@ func (m Model) searchBarView() string {
 	var matchInfo string
 	if m.searchQuery != "" {
 		countStyle := lipgloss.NewStyle().Foreground(s.Colors.TextMuted)
-		if len(m.searchMatches) > 0 {
-			matchInfo = countStyle.Render(fmt.Sprintf(" %d/%d", m.searchCursor+1, len(m.searchMatches)))
+		if m.searchMatchCount() > 0 {
+			matchInfo = countStyle.Render(fmt.Sprintf(" %d/%d", m.searchCursor+1, m.searchMatchCount()))
 		} else {
 			matchInfo = countStyle.Render(" No matches")
 		}
@@ -2829,86 +3991,23 @@ func (m Model) searchBarView() string {
 
 // contentView renders the main content area (logs)
 func (m Model) contentView() string {
+	if m.logBrowser.Active {
+		return m.logBrowserContent()
+	}
 	// Always render TabView - it handles empty state internally
 	return m.tabView.View(m.styles)
 }
 
 // consoleView renders the console output pane for run mode
 func (m Model) consoleView() string {
-	s := m.styles
-
-	// Calculate visible range
-	height := m.consolePaneHeight()
-	if height <= 0 {
-		return ""
-	}
-
-	header := m.consoleHeader()
-	contentHeight := height
-	if header != "" {
-		contentHeight--
-	}
-	barWidth := scrollbarWidth
-	if m.layout.ContentWidth()-barWidth < 1 {
-		barWidth = 0
-	}
-	contentWidth := m.layout.ContentWidth() - barWidth
-	if contentWidth < 1 {
-		contentWidth = m.layout.ContentWidth()
-	}
-	pad := lipgloss.NewStyle().Width(contentWidth)
-	emptyBar := strings.Repeat(" ", barWidth)
-	start := m.runMode.ConsolePos
-	if start < 0 {
-		start = 0
-	}
-	end := start + contentHeight
-	if end > len(m.runMode.ConsoleLogs) {
-		end = len(m.runMode.ConsoleLogs)
-	}
-
-	// Build visible lines
-	var lines []string
-	if header != "" {
-		lines = append(lines, pad.Render(header)+emptyBar)
-	}
-	textStyle := lipgloss.NewStyle().Foreground(s.Colors.Text)
-	metaStyle := lipgloss.NewStyle().Foreground(s.Colors.TextMuted)
-	systemStyle := lipgloss.NewStyle().Foreground(s.Colors.TextMuted)
-	barLines := renderScrollbarLines(contentHeight, len(m.runMode.ConsoleLogs), m.runMode.ConsolePos, s)
-	if len(barLines) != contentHeight {
-		barLines = make([]string, contentHeight)
-		for i := range barLines {
-			barLines[i] = emptyBar
-		}
-	}
-	for i := start; i < end; i++ {
-		line := m.runMode.ConsoleLogs[i]
-		style := textStyle
-		if strings.HasPrefix(line, consoleMetaPrefix) {
-			line = strings.TrimPrefix(line, consoleMetaPrefix)
-			style = metaStyle
-		} else if strings.HasPrefix(line, consoleSystemPrefix) {
-			line = strings.TrimPrefix(line, consoleSystemPrefix)
-			style = systemStyle
-		}
-		// Truncate long lines
-		if contentWidth > 0 && len(line) > contentWidth-4 {
-			line = line[:contentWidth-7] + "..."
-		}
-		barLine := emptyBar
-		if barWidth > 0 {
-			barLine = barLines[i-start]
-		}
-		lines = append(lines, pad.Render(style.Render(line))+barLine)
-	}
-
-	// Pad to fill height
-	for len(lines) < height {
-		lines = append(lines, pad.Render("")+emptyBar)
-	}
-
-	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	return m.runMode.Console.View(consoleViewOptions{
+		Width:      m.runConsoleWidth(),
+		Height:     m.runConsoleHeight(),
+		Header:     m.consoleHeader(),
+		Visible:    m.visibleConsoleIndices(),
+		Timestamps: m.tabView.StreamTab.ShowTimestamps,
+		Styles:     m.styles,
+	})
 }
 
 func (m Model) consoleHeader() string {
@@ -2918,7 +4017,7 @@ func (m Model) consoleHeader() string {
 	status := ""
 	if m.running {
 		status = "Running"
-		if len(m.runMode.ConsoleLogs) == 0 {
+		if len(m.runMode.Console.Lines) == 0 {
 			status = "Running — waiting for app output…"
 		} else {
 			status = "Running — streaming logs"
@@ -2929,7 +4028,18 @@ func (m Model) consoleHeader() string {
 	if status == "" {
 		return ""
 	}
-	return labelStyle.Render(status)
+	if m.launchPreset != "" {
+		status += " · preset " + m.launchPreset
+	}
+	header := labelStyle.Render(status)
+	if filter := m.consoleFilterLabel(); filter != "" {
+		filterStyle := lipgloss.NewStyle().Foreground(s.Colors.Warning)
+		header = labelStyle.Render(status+" · ") + filterStyle.Render(filter)
+	}
+	if usage := m.appUsageLabel(); usage != "" {
+		header += labelStyle.Render(" · ") + usage
+	}
+	return header
 }
 
 func actionKeyForCmd(cmd string) string {
@@ -2962,6 +4072,8 @@ func (m Model) runModeHintsBar() string {
 		Desc string
 	}{
 		{"tab", "switch pane"},
+		{"+/-", "resize"},
+		{"l", "filter"},
 	}
 	if m.running {
 		hints = append(hints, struct {
@@ -2984,12 +4096,12 @@ func (m Model) runModeHintsBar() string {
 		hints = append(hints, struct {
 			Key  string
 			Desc string
-		}{"m", "mouse:on"})
+		}{"M", "mouse:on"})
 	} else {
 		hints = append(hints, struct {
 			Key  string
 			Desc string
-		}{"m", "mouse:off"})
+		}{"M", "mouse:off"})
 	}
 	hints = append(hints,
 		struct {
@@ -3090,6 +4202,14 @@ func (m Model) helpOverlayView() string {
 	b.WriteString(titleStyle.Render("Keyboard Shortcuts"))
 	b.WriteString("\n")
 
+	filterStyle := lipgloss.NewStyle().Foreground(s.Colors.TextMuted)
+	if m.helpFilter != "" {
+		b.WriteString(filterStyle.Render("Filter: ") + m.helpFilter)
+	} else {
+		b.WriteString(filterStyle.Render("Type to filter"))
+	}
+	b.WriteString("\n")
+
 	dividerStyle := lipgloss.NewStyle().Foreground(s.Colors.BorderMuted)
 	b.WriteString(dividerStyle.Render(strings.Repeat("─", width-6)))
 	b.WriteString("\n")
@@ -3112,9 +4232,9 @@ func (m Model) helpOverlayView() string {
 
 	hintKeyStyle := lipgloss.NewStyle().Foreground(s.Colors.Accent)
 	hintDescStyle := lipgloss.NewStyle().Foreground(s.Colors.TextSubtle)
-	hints := "Press " + hintKeyStyle.Render("?") + hintDescStyle.Render(" or ") +
-		hintKeyStyle.Render("esc") + hintDescStyle.Render(" to close") +
-		hintDescStyle.Render("  ↑↓ scroll") + scrollInfo
+	hints := hintKeyStyle.Render("enter") + hintDescStyle.Render(" run  ") +
+		hintKeyStyle.Render("↑↓") + hintDescStyle.Render(" select  ") +
+		hintKeyStyle.Render("esc") + hintDescStyle.Render(" clear/close") + scrollInfo
 	b.WriteString(hints)
 
 	// Container with border
@@ -3152,12 +4272,64 @@ func (m Model) selectorOverlayView() string {
 	return RenderCenteredPopup(selectorContent, m.width, m.height)
 }
 
+func (m Model) doctorOverlayView() string {
+	width := m.width * 60 / 100
+	if width < 60 {
+		width = 60
+	}
+	if width > 90 {
+		width = 90
+	}
+	return RenderCenteredPopup(m.doctor.View(width, m.styles), m.width, m.height)
+}
+
 func (m Model) paletteOverlayView() string {
 	// Render palette popup centered on screen
 	paletteContent := m.palette.View()
 	return RenderPaletteCentered(paletteContent, m.width, m.height)
 }
 
+// =============================================================================
+// Doctor
+// =============================================================================
+
+func (m *Model) openDoctor() tea.Cmd {
+	m.mode = ModeDoctor
+	m.doctor = DoctorOverlay{Loading: true}
+	return runDoctorCmd(m.projectRoot, m.cfg)
+}
+
+func (m *Model) handleDoctorKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc", "q":
+		m.mode = ModeNormal
+	case "up", "k":
+		m.doctor.MoveCursor(-1)
+	case "down", "j":
+		m.doctor.MoveCursor(1)
+	case "r":
+		if m.doctor.Loading || m.doctor.Fixing != "" {
+			return nil
+		}
+		m.doctor.Loading = true
+		m.doctor.Message = ""
+		return runDoctorCmd(m.projectRoot, m.cfg)
+	case "enter":
+		c, ok := m.doctor.Selected()
+		if !ok || m.doctor.Fixing != "" {
+			return nil
+		}
+		if !c.Fixable {
+			m.doctor.Message = "No automatic fix for " + c.Name
+			return nil
+		}
+		m.doctor.Fixing = c.ID
+		m.doctor.Message = ""
+		return fixDoctorCheckCmd(m.projectRoot, m.cfg, c.ID)
+	}
+	return nil
+}
+
 // =============================================================================
 // Git Helpers
 // =============================================================================
@@ -3182,9 +4354,9 @@ func (m *Model) copyCurrentLine() tea.Cmd {
 
 	switch m.tabView.ActiveTab {
 	case TabStream:
-		content = m.tabView.StreamTab.GetCurrentLine()
+		content = m.tabView.VisibleStreamTab().GetCurrentLine()
 	case TabIssues:
-		if issue := m.tabView.IssuesTab.GetSelectedIssue(); issue != nil {
+		if issue := m.tabView.VisibleIssuesTab().GetSelectedIssue(); issue != nil {
 			content = issue.FullText
 		}
 	case TabDashboard:
@@ -3206,11 +4378,11 @@ func (m *Model) copyVisibleContent() tea.Cmd {
 
 	switch m.tabView.ActiveTab {
 	case TabStream:
-		content = m.tabView.StreamTab.GetVisibleContent()
+		content = m.tabView.VisibleStreamTab().GetVisibleContent()
 	case TabIssues:
 		// Copy all visible issues
 		var lines []string
-		for _, issue := range m.tabView.IssuesTab.Issues {
+		for _, issue := range m.tabView.VisibleIssuesTab().Issues {
 			lines = append(lines, issue.FullText)
 		}
 		content = strings.Join(lines, "\n")
@@ -3227,8 +4399,87 @@ func (m *Model) copyVisibleContent() tea.Cmd {
 	return m.copyToClipboard(content, "Copied visible content")
 }
 
-// copyToClipboard copies content to system clipboard using pbcopy (macOS)
+// copyIssueLocation copies the selected issue as path:line:col
+func (m *Model) copyIssueLocation() tea.Cmd {
+	issue := m.tabView.VisibleIssuesTab().GetSelectedIssue()
+	if issue == nil {
+		return func() tea.Msg { return statusMsg("Nothing to copy") }
+	}
+	loc := issueLocation(*issue)
+	if loc == "" {
+		return func() tea.Msg { return statusMsg("Issue has no file location") }
+	}
+	return m.copyToClipboard(loc, "Copied location "+loc)
+}
+
+// copyIssueGrep copies the selected issue as "rel/path:line:col: type: message"
+func (m *Model) copyIssueGrep() tea.Cmd {
+	if m.tabView.ActiveTab != TabIssues {
+		return func() tea.Msg { return statusMsg("Copy as grep works on the Issues tab") }
+	}
+	issue := m.tabView.VisibleIssuesTab().GetSelectedIssue()
+	if issue == nil {
+		return func() tea.Msg { return statusMsg("Nothing to copy") }
+	}
+	return m.copyToClipboard(issueGrepLine(m.projectRoot, *issue), "Copied as grep line")
+}
+
+// promptApplyFixIt asks before writing the selected issue's fix-its to disk
+func (m *Model) promptApplyFixIt() {
+	if m.tabView.ShowingPrevious {
+		m.setStatus("Fix-its can only be applied from the current run")
+		return
+	}
+	issue := m.tabView.IssuesTab.GetSelectedIssue()
+	if issue == nil || len(issue.FixIts) == 0 {
+		m.setStatus("No fix-it for this issue")
+		return
+	}
+	if issue.Applied {
+		m.setStatus("Fix-it already applied")
+		return
+	}
+	if m.running {
+		m.setStatus("Wait for the current operation to finish")
+		return
+	}
+
+	files := groupFixIts(m.projectRoot, issue.File, issue.FixIts)
+	seq := issue.seq
+	builtAt := m.opStart
+
+	var body []string
+	for _, file := range files {
+		body = append(body, relativeToRoot(m.projectRoot, file.Path))
+		for _, fix := range file.Fixes {
+			body = append(body, "  "+fix.Describe())
+		}
+	}
+	m.openConfirm(ConfirmPrompt{
+		Title: "Apply fix-it?",
+		Body:  body,
+		OnYes: func(m *Model) tea.Cmd {
+			backupDir := filepath.Join(m.projectRoot, ".xcbolt", "backups", time.Now().Format("20060102-150405"))
+			backups, err := applyFixIts(m.projectRoot, files, builtAt, backupDir)
+			if err != nil {
+				m.setStatus("Fix-it not applied: " + err.Error())
+				return nil
+			}
+			m.tabView.IssuesTab.MarkApplied(seq)
+			if len(backups) == 1 {
+				m.setStatus("Applied fix-it (backup: " + relativeToRoot(m.projectRoot, backups[0]) + ")")
+			} else {
+				m.setStatus(fmt.Sprintf("Applied fix-its to %d files (backups: %s)", len(backups), relativeToRoot(m.projectRoot, backupDir)))
+			}
+			return nil
+		},
+	})
+}
+
+// copyToClipboard copies content to system clipboard using pbcopy (macOS).
+// Escape sequences are stripped so styled lines paste as plain text.
 func (m *Model) copyToClipboard(content, successMsg string) tea.Cmd {
+	content = stripANSI(content)
 	return func() tea.Msg {
 		cmd := exec.Command("pbcopy")
 		cmd.Stdin = strings.NewReader(content)
@@ -1,6 +1,10 @@
 package tui
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -49,7 +53,7 @@ func TestWaitForEvent_Stops(t *testing.T) {
 	events := make(chan core.Event)
 	stop := make(chan struct{})
 
-	cmd := waitForEvent(events, stop)
+	cmd := waitForEvent(&chanEmitter{ch: events, stop: stop})
 	done := make(chan tea.Msg, 1)
 	go func() { done <- cmd() }()
 
@@ -64,3 +68,111 @@ func TestWaitForEvent_Stops(t *testing.T) {
 		t.Fatal("waitForEvent did not stop")
 	}
 }
+
+func TestChanEmitter_CountsDroppedEvents(t *testing.T) {
+	e := &chanEmitter{ch: make(chan core.Event, 2), stop: make(chan struct{})}
+	for i := 0; i < 5; i++ {
+		e.Emit(core.Log("build", fmt.Sprintf("line %d", i)))
+	}
+	msg := waitForEvent(e)().(eventsMsg)
+	if len(msg.events) != 2 || msg.dropped != 3 {
+		t.Fatalf("got %d events, %d dropped; want 2, 3", len(msg.events), msg.dropped)
+	}
+	if got := e.dropped.Load(); got != 0 {
+		t.Fatalf("dropped counter not reset: %d", got)
+	}
+}
+
+func TestWaitForEvent_BatchesBufferedEvents(t *testing.T) {
+	e := &chanEmitter{ch: make(chan core.Event, 2*maxEventBatch), stop: make(chan struct{})}
+	for i := 0; i < maxEventBatch+10; i++ {
+		e.Emit(core.Log("build", "line"))
+	}
+	if got := len(waitForEvent(e)().(eventsMsg).events); got != maxEventBatch {
+		t.Fatalf("first batch = %d events, want %d", got, maxEventBatch)
+	}
+	if got := len(waitForEvent(e)().(eventsMsg).events); got != 10 {
+		t.Fatalf("second batch = %d events, want 10", got)
+	}
+}
+
+// A 50k-line burst must cost far fewer Update cycles than lines.
+func TestEventBurstBatchesUpdates(t *testing.T) {
+	const lines = 50000
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	stop := make(chan struct{})
+	e := &chanEmitter{ch: make(chan core.Event, lines), stop: stop}
+	m.events = e
+	m.eventStopCh = stop
+	m.running = true
+	m.runningCmd = "build"
+	for i := 0; i < lines; i++ {
+		e.Emit(core.Log("build", fmt.Sprintf("CompileSwift normal arm64 /src/File%d.swift", i)))
+	}
+
+	updates := 0
+	for len(e.ch) > 0 {
+		msg := waitForEvent(e)()
+		next, _ := m.Update(msg)
+		m = next.(Model)
+		updates++
+	}
+	if updates > lines/10/10 {
+		t.Fatalf("%d Update calls for %d lines, want at most %d", updates, lines, lines/100)
+	}
+	t.Logf("%d lines in %d updates", lines, updates)
+}
+
+func TestEventsMsgReportsDroppedLines(t *testing.T) {
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	stop := make(chan struct{})
+	m.events = &chanEmitter{ch: make(chan core.Event, 1), stop: stop, log: openOpLog(t.TempDir(), "build", time.Now())}
+	m.eventStopCh = stop
+	m = updateModel(m, eventsMsg{events: []core.Event{core.Log("build", "hello")}, dropped: 42})
+	found := false
+	for _, l := range m.tabView.StreamTab.Lines {
+		if strings.Contains(l.Text, "42 lines dropped from display (full log preserved on disk: ") {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatal("dropped-lines notice not shown in the logs")
+	}
+}
+
+func TestOpLogWritesAndPrunes(t *testing.T) {
+	root := t.TempDir()
+	dir := opLogsDir(root)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < maxOpLogs+3; i++ {
+		name := fmt.Sprintf("test-20260101-0000%02d.log", i)
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	l := openOpLog(root, "build", time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC))
+	l.Write(core.LogRaw("build", "/src/A.swift:1:1: error: boom"))
+	l.Write(core.Log("build", "/src/A.swift:1:1: error: boom"))
+	l.Write(core.LogPretty("build", "❌ boom"))
+	l.Write(core.Warn("build", "careful"))
+	l.Close()
+	l.Write(core.Log("build", "after close"))
+
+	b, err := os.ReadFile(l.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(b), "/src/A.swift:1:1: error: boom\n[warning] careful\n"; got != want {
+		t.Fatalf("log = %q, want %q", got, want)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != maxOpLogs {
+		t.Fatalf("%d logs kept, want %d", len(entries), maxOpLogs)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "test-20260101-000000.log")); !os.IsNotExist(err) {
+		t.Fatal("oldest log should be pruned")
+	}
+}
@@ -0,0 +1,82 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// =============================================================================
+// Mouse Clicks
+// =============================================================================
+
+// doubleClickInterval is the most time between two clicks on the same issue
+// that still counts as a double-click
+const doubleClickInterval = 400 * time.Millisecond
+
+// issueClick is the last click on an issue row
+type issueClick struct {
+	tab   *IssuesTab
+	index int
+	at    time.Time
+}
+
+// contentTop is the screen row the content pane starts at (below the header)
+func (m Model) contentTop() int {
+	if m.layout.MinimalMode {
+		return 1
+	}
+	return 2
+}
+
+// handleClick maps a left click to the tab bar, issue rows, phase headers and
+// scrollbar markers rendered in the last View pass. In run mode it also moves the pane focus.
+func (m *Model) handleClick(msg tea.MouseMsg, now time.Time) {
+	if m.mode != ModeNormal {
+		return
+	}
+	if m.runMode.Active {
+		if !m.layout.MinimalMode && m.mouseInConsolePane(msg.X, msg.Y) {
+			m.runMode.FocusPane = PaneConsole
+			return
+		}
+		m.runMode.FocusPane = PaneBuild
+	}
+
+	y := msg.Y - m.contentTop()
+	if tab, ok := m.tabView.TabAt(msg.X, y); ok {
+		m.selectTab(tab)
+		return
+	}
+	y -= m.tabView.ContentTop()
+	if y < 0 || y >= m.tabView.Height {
+		return
+	}
+
+	switch m.tabView.ActiveTab {
+	case TabIssues:
+		issues := m.tabView.VisibleIssuesTab()
+		idx, ok := issues.IssueAt(y)
+		if !ok {
+			return
+		}
+		last := m.lastClick
+		issues.Select(idx)
+		if last.tab == issues && last.index == idx && now.Sub(last.at) <= doubleClickInterval {
+			issues.ToggleExpand()
+			m.lastClick = issueClick{}
+			return
+		}
+		m.lastClick = issueClick{tab: issues, index: idx, at: now}
+
+	case TabStream:
+		stream := m.tabView.VisibleStreamTab()
+		if msg.X == stream.Width-scrollbarWidth {
+			stream.JumpToTrackCell(y)
+			return
+		}
+		if idx, ok := stream.LineAt(y); ok {
+			stream.TogglePhase(idx)
+		}
+	}
+}
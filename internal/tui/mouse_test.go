@@ -0,0 +1,177 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newClickModel returns a 120x40 model; the tab bar is on rows 2-3 and tab
+// content starts on row 4
+func newClickModel(t *testing.T) Model {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.mode = ModeNormal
+	m = updateModel(m, tea.WindowSizeMsg{Width: 120, Height: 40})
+	if m.layout.MinimalMode {
+		t.Fatalf("120x40 should use the full layout")
+	}
+	return m
+}
+
+func leftClick(x, y int) tea.MouseMsg {
+	return tea.MouseMsg{X: x, Y: y, Type: tea.MouseLeft, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress}
+}
+
+func TestClickTabBarSwitchesTabs(t *testing.T) {
+	m := newClickModel(t)
+	cases := []struct {
+		x, y int
+		want Tab
+	}{
+		{60, 2, TabStream},
+		{110, 2, TabIssues},
+		{5, 3, TabDashboard}, // Underline row
+	}
+	for _, c := range cases {
+		m.View()
+		m = updateModel(m, leftClick(c.x, c.y))
+		if m.tabView.ActiveTab != c.want {
+			t.Fatalf("click at (%d,%d): active tab = %v, want %v", c.x, c.y, m.tabView.ActiveTab, c.want)
+		}
+	}
+
+	// Below the tab bar nothing switches.
+	m.View()
+	m = updateModel(m, leftClick(60, 10))
+	if m.tabView.ActiveTab != TabDashboard {
+		t.Fatalf("content click switched tabs to %v", m.tabView.ActiveTab)
+	}
+}
+
+func TestClickIgnoredOutsideNormalMode(t *testing.T) {
+	m := newClickModel(t)
+	m.View()
+	m.mode = ModeHelp
+	m = updateModel(m, leftClick(60, 2))
+	if m.tabView.ActiveTab != TabDashboard {
+		t.Fatalf("click behind the help overlay switched tabs")
+	}
+}
+
+func TestClickSelectsAndDoubleClickExpandsIssue(t *testing.T) {
+	m := newClickModel(t)
+	issues := m.tabView.IssuesTab
+	issues.AddIssue(IssueTypeError, "/src/A.swift:1:1: error: first")
+	issues.AddIssue(IssueTypeError, "/src/B.swift:2:1: error: second")
+	issues.AddIssue(IssueTypeWarning, "/src/C.swift:3:1: warning: third")
+	m.tabView.SetActiveTab(TabIssues)
+	m.View()
+
+	// Row 4 is the counts header; issues follow one per row.
+	m = updateModel(m, leftClick(10, 6))
+	if issues.Selected != 1 || issues.Issues[1].Expanded {
+		t.Fatalf("single click: selected = %d, expanded = %v", issues.Selected, issues.Issues[1].Expanded)
+	}
+
+	now := time.Now()
+	m.View()
+	m.handleClick(leftClick(10, 7), now)
+	m.handleClick(leftClick(10, 7), now.Add(time.Second))
+	if issues.Selected != 2 || issues.Issues[2].Expanded {
+		t.Fatalf("slow clicks expanded the issue")
+	}
+	m.handleClick(leftClick(10, 7), now.Add(time.Second+100*time.Millisecond))
+	if !issues.Issues[2].Expanded {
+		t.Fatalf("double click did not expand the issue")
+	}
+
+	// The header row and the empty rows below the list select nothing.
+	m.View()
+	m.handleClick(leftClick(10, 4), now)
+	m.handleClick(leftClick(10, 30), now)
+	if issues.Selected != 2 {
+		t.Fatalf("selected = %d after clicks outside the list", issues.Selected)
+	}
+}
+
+func TestClickPhaseHeaderTogglesCollapse(t *testing.T) {
+	m := newClickModel(t)
+	stream := m.tabView.StreamTab
+	stream.AddLine("=== BUILD TARGET App ===", TabLineTypePhaseHeader)
+	stream.AddLine("first step", TabLineTypeNormal)
+	stream.AddLine("second step", TabLineTypeNormal)
+	stream.AddLine("third step", TabLineTypeNormal)
+	stream.AddLine("=== BUILD TARGET Tests ===", TabLineTypePhaseHeader)
+	stream.AddLine("tests step", TabLineTypeNormal)
+	m.tabView.SetActiveTab(TabStream)
+	m.View()
+
+	// Clicking a normal line does nothing.
+	m = updateModel(m, leftClick(20, 5))
+	if stream.rowCount() != 6 {
+		t.Fatalf("clicking a log line collapsed something")
+	}
+
+	m = updateModel(m, leftClick(20, 4))
+	view := stripANSI(m.View())
+	if strings.Contains(view, "second step") || !strings.Contains(view, "[+3 hidden]") {
+		t.Fatalf("phase not collapsed:\n%s", view)
+	}
+	if !strings.Contains(view, "tests step") {
+		t.Fatalf("collapse hid the next phase:\n%s", view)
+	}
+	if idx, ok := stream.LineAt(1); !ok || idx != 4 {
+		t.Fatalf("row 1 shows line %d, want the second header", idx)
+	}
+
+	m = updateModel(m, leftClick(20, 4))
+	if view := stripANSI(m.View()); !strings.Contains(view, "second step") || strings.Contains(view, "hidden]") {
+		t.Fatalf("phase not expanded:\n%s", view)
+	}
+}
+
+func TestClickMovesRunModeFocus(t *testing.T) {
+	m := newClickModel(t)
+	m.runMode.Active = true
+	m.runMode.FocusPane = PaneBuild
+	m.View()
+
+	m = updateModel(m, leftClick(10, 35))
+	if m.runMode.FocusPane != PaneConsole {
+		t.Fatalf("click in console pane: focus = %v", m.runMode.FocusPane)
+	}
+	m = updateModel(m, leftClick(10, 10))
+	if m.runMode.FocusPane != PaneBuild {
+		t.Fatalf("click in build pane: focus = %v", m.runMode.FocusPane)
+	}
+}
+
+func TestClickScrollbarMarkerJumps(t *testing.T) {
+	m := newClickModel(t)
+	stream := m.tabView.StreamTab
+	for i := 0; i < 2000; i++ {
+		if i == 1500 {
+			stream.AddLine("/src/A.swift:1:1: error: expected expression", TabLineTypeError)
+			continue
+		}
+		stream.AddLine("compiling", TabLineTypeNormal)
+	}
+	m.tabView.SetActiveTab(TabStream)
+	stream.GotoTop()
+	m.View()
+
+	cell := markerCell(1500, stream.VisibleRows, stream.rowCount())
+	x := stream.Width - scrollbarWidth
+	m = updateModel(m, leftClick(x, 4+cell+1))
+	if stream.ScrollPos != 0 {
+		t.Fatalf("clicking an unmarked cell scrolled to %d", stream.ScrollPos)
+	}
+	m = updateModel(m, leftClick(x, 4+cell))
+	if top, bottom := stream.ScrollPos, stream.ScrollPos+stream.VisibleRows; 1500 < top || 1500 >= bottom {
+		t.Fatalf("view %d-%d misses the error", top, bottom)
+	}
+}
@@ -0,0 +1,275 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// =============================================================================
+// Muted Phases
+// =============================================================================
+
+// phaseMuter decides which phases are muted: tui.mutedPhases plus the
+// headers muted with M this session. The live and previous tabs share one.
+type phaseMuter struct {
+	patterns []string // tui.mutedPhases, lowercased
+	session  []string // Header lines muted with M, lowercased
+	// Show counts and lists the issues of muted phases anyway
+	Show bool
+}
+
+// Muted reports whether the phase under header is muted: a pattern equals
+// its detected phase name or is part of the header line.
+func (pm *phaseMuter) Muted(header string) bool {
+	if pm == nil || header == "" || len(pm.patterns)+len(pm.session) == 0 {
+		return false
+	}
+	lower := strings.ToLower(header)
+	if slices.Contains(pm.session, lower) {
+		return true
+	}
+	name := strings.ToLower(detectBuildPhase(header))
+	for _, p := range pm.patterns {
+		if p == name || strings.Contains(lower, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// hides reports whether the issues of the phase under header are left out
+// of the counts and the Issues list
+func (pm *phaseMuter) hides(header string) bool {
+	return pm != nil && !pm.Show && pm.Muted(header)
+}
+
+// setPatterns replaces the tui.mutedPhases patterns
+func (pm *phaseMuter) setPatterns(patterns []string) {
+	pm.patterns = pm.patterns[:0]
+	for _, p := range patterns {
+		if p = strings.ToLower(strings.TrimSpace(p)); p != "" {
+			pm.patterns = append(pm.patterns, p)
+		}
+	}
+}
+
+// mutedForSession reports whether header was muted with M
+func (pm *phaseMuter) mutedForSession(header string) bool {
+	return slices.Contains(pm.session, strings.ToLower(header))
+}
+
+// toggleSession mutes or unmutes header for the session, reporting whether
+// it is muted now
+func (pm *phaseMuter) toggleSession(header string) bool {
+	lower := strings.ToLower(header)
+	if i := slices.Index(pm.session, lower); i >= 0 {
+		pm.session = slices.Delete(pm.session, i, i+1)
+		return false
+	}
+	pm.session = append(pm.session, lower)
+	return true
+}
+
+// phaseIssueCount is the errors and warnings counted under one phase header
+type phaseIssueCount struct {
+	Errors, Warnings int
+}
+
+// countIssue counts an error or warning in the current phase
+func (tv *TabView) countIssue(t IssueType) {
+	c := &tv.Counts
+	if c.byPhase == nil {
+		c.byPhase = map[string]phaseIssueCount{}
+	}
+	pc := c.byPhase[tv.phase]
+	if t == IssueTypeError {
+		c.errors++
+		pc.Errors++
+		if tv.phaseMuted {
+			c.MutedErrors++
+		}
+	} else {
+		c.warnings++
+		pc.Warnings++
+		if tv.phaseMuted {
+			c.MutedWarnings++
+		}
+	}
+	c.byPhase[tv.phase] = pc
+	c.apply(tv.muter.Show)
+}
+
+// recount totals the issues of muted phases after the muted set changed
+func (c *TabCounts) recount(pm *phaseMuter) {
+	c.MutedErrors, c.MutedWarnings = 0, 0
+	for header, pc := range c.byPhase {
+		if pm.Muted(header) {
+			c.MutedErrors += pc.Errors
+			c.MutedWarnings += pc.Warnings
+		}
+	}
+	c.apply(pm.Show)
+}
+
+// apply sets the badge counts, leaving out muted issues unless show is set
+func (c *TabCounts) apply(show bool) {
+	c.ErrorCount, c.WarningCount = c.errors, c.warnings
+	if !show {
+		c.ErrorCount -= c.MutedErrors
+		c.WarningCount -= c.MutedWarnings
+	}
+}
+
+// SetMutedPhases applies tui.mutedPhases
+func (tv *TabView) SetMutedPhases(patterns []string) {
+	tv.muter.setPatterns(patterns)
+	tv.remute()
+}
+
+// SetShowMuted counts and lists the issues of muted phases (or stops)
+func (tv *TabView) SetShowMuted(show bool) {
+	tv.muter.Show = show
+	tv.remute()
+}
+
+// TogglePhaseMute mutes or unmutes the phase under header for the session,
+// collapsing or expanding its headers in Logs, and reports whether it is
+// muted now. ok is false for a phase muted by tui.mutedPhases, which stays
+// muted.
+func (tv *TabView) TogglePhaseMute(header string) (muted, ok bool) {
+	if tv.muter.Muted(header) && !tv.muter.mutedForSession(header) {
+		return true, false
+	}
+	muted = tv.muter.toggleSession(header)
+	for _, st := range tv.streamTabs() {
+		for i, line := range st.Lines {
+			if line.Type == TabLineTypePhaseHeader && strings.EqualFold(line.Text, header) {
+				st.setPhaseCollapsed(i, muted)
+			}
+		}
+	}
+	tv.remute()
+	return muted, true
+}
+
+// remute applies a changed muted set to the counts, Issues, and the current
+// phase
+func (tv *TabView) remute() {
+	tv.phaseMuted = tv.muter.Muted(tv.phase)
+	tv.Counts.recount(tv.muter)
+	tv.IssuesTab.refilter()
+	if tv.previous != nil {
+		tv.previous.Counts.recount(tv.muter)
+		tv.previous.IssuesTab.refilter()
+	}
+	tv.syncIssueCounts()
+}
+
+// streamTabs returns the live and previous Logs
+func (tv *TabView) streamTabs() []*StreamTab {
+	if tv.previous == nil {
+		return []*StreamTab{tv.StreamTab}
+	}
+	return []*StreamTab{tv.StreamTab, tv.previous.StreamTab}
+}
+
+// mutedLabel is the note after a muted phase header, e.g. "(muted, 2,013
+// warnings hidden)"; issues aren't hidden while muted ones are shown
+func (st *StreamTab) mutedLabel(idx int) string {
+	if !st.muter.Muted(st.Lines[idx].Text) {
+		return ""
+	}
+	errors, warnings := 0, 0
+	for i := idx + 1; i < len(st.Lines) && st.Lines[i].Type != TabLineTypePhaseHeader; i++ {
+		switch st.Lines[i].Type {
+		case TabLineTypeError:
+			errors++
+		case TabLineTypeWarning:
+			warnings++
+		}
+	}
+	if st.muter.Show || errors+warnings == 0 {
+		return "(muted)"
+	}
+	var parts []string
+	if errors > 0 {
+		parts = append(parts, groupedCount(errors, "error"))
+	}
+	if warnings > 0 {
+		parts = append(parts, groupedCount(warnings, "warning"))
+	}
+	return "(muted, " + strings.Join(parts, ", ") + " hidden)"
+}
+
+// groupedCount is e.g. "1 warning" or "2,013 warnings"
+func groupedCount(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%s %ss", groupDigits(n), noun)
+}
+
+// groupDigits formats n with thousands separators ("2,013")
+func groupDigits(n int) string {
+	if n < 0 {
+		return "-" + groupDigits(-n)
+	}
+	s := strconv.Itoa(n)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
+
+// selectedPhaseHeader returns the phase header at the top of Logs (where
+// { } and Go to Phase put it), if that's where one is
+func (m *Model) selectedPhaseHeader() (string, bool) {
+	if m.tabView.ActiveTab != TabStream || (m.runMode.Active && m.runMode.FocusPane != PaneBuild) {
+		return "", false
+	}
+	line, _, ok := m.tabView.VisibleStreamTab().TopLine()
+	if !ok || line.Type != TabLineTypePhaseHeader {
+		return "", false
+	}
+	return line.Text, true
+}
+
+// onPhaseHeader reports whether a phase header is at the top of Logs
+func (m *Model) onPhaseHeader() bool {
+	_, ok := m.selectedPhaseHeader()
+	return ok
+}
+
+// togglePhaseMute mutes or unmutes the phase at the top of Logs for the
+// session
+func (m *Model) togglePhaseMute() {
+	header, ok := m.selectedPhaseHeader()
+	if !ok {
+		m.setStatus("No phase header at the top of Logs (use { or } to jump to one)")
+		return
+	}
+	muted, ok := m.tabView.TogglePhaseMute(header)
+	name := truncateStreamText(header, 40)
+	switch {
+	case !ok:
+		m.setStatus(name + " is muted by tui.mutedPhases")
+	case !muted:
+		m.setStatus("Unmuted " + name)
+	default:
+		m.setStatus("Muted " + name + " for this session (u again to unmute)")
+	}
+}
+
+// toggleShowMuted counts and lists the issues of muted phases, or stops
+func (m *Model) toggleShowMuted() {
+	show := !m.tabView.muter.Show
+	m.tabView.SetShowMuted(show)
+	c := m.tabView.Counts
+	if show {
+		m.setStatus(fmt.Sprintf("Showing muted issues (%d errors, %d warnings)", c.MutedErrors, c.MutedWarnings))
+		return
+	}
+	m.setStatus(fmt.Sprintf("Hiding muted issues (%d errors, %d warnings)", c.MutedErrors, c.MutedWarnings))
+}
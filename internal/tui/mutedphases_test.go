@@ -0,0 +1,150 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	lintHeader    = "PhaseScriptExecution SwiftLint /tmp/Script-1.sh (in target 'App' from project 'App')"
+	compileHeader = "SwiftCompile normal arm64 Compiling\\ A.swift /src/A.swift (in target 'App' from project 'App')"
+)
+
+// addLintedBuild feeds a build whose SwiftLint phase warns three times
+// before the compiler reports an error and a warning
+func addLintedBuild(tv *TabView) {
+	tv.AddRawLine("=== BUILD TARGET App OF PROJECT App WITH CONFIGURATION Debug ===")
+	tv.AddRawLine(lintHeader)
+	for i := 0; i < 3; i++ {
+		tv.AddRawLine("/src/B.swift:1:1: warning: Line Length Violation")
+	}
+	tv.AddRawLine(compileHeader)
+	tv.AddRawLine("/src/A.swift:2:1: error: cannot find 'x' in scope")
+	tv.AddRawLine("/src/A.swift:3:1: warning: var never mutated")
+}
+
+func TestMutedPhaseCounts(t *testing.T) {
+	tv := NewTabView()
+	tv.SetSize(120, 30)
+	tv.SetMutedPhases([]string{"running script"})
+	addLintedBuild(tv)
+
+	c := tv.Counts
+	if c.ErrorCount != 1 || c.WarningCount != 1 || c.MutedWarnings != 3 {
+		t.Fatalf("counts = %+v", c)
+	}
+	if len(tv.IssuesTab.Issues) != 2 || tv.IssuesTab.countByType(IssueTypeWarning) != 1 {
+		t.Fatalf("issues = %+v", tv.IssuesTab.Issues)
+	}
+	if s := tv.SummaryTab; s.ErrorCount != 1 || s.WarningCount != 1 {
+		t.Fatalf("dashboard counts = %d errors, %d warnings", s.ErrorCount, s.WarningCount)
+	}
+
+	st := tv.StreamTab
+	lint := st.IndexOfID(2)
+	if st.Lines[lint].Type != TabLineTypePhaseHeader || st.hiddenAfter(lint) != 3 {
+		t.Fatalf("lint phase not collapsed: %+v", st.Lines[lint])
+	}
+	if got := st.mutedLabel(lint); got != "(muted, 3 warnings hidden)" {
+		t.Fatalf("label = %q", got)
+	}
+	// Expanding the phase shows its lines; they stay uncounted
+	st.TogglePhase(lint)
+	if st.hiddenAfter(lint) != 0 || tv.Counts.WarningCount != 1 {
+		t.Fatalf("expanded: hidden %d, warnings %d", st.hiddenAfter(lint), tv.Counts.WarningCount)
+	}
+
+	tv.SetShowMuted(true)
+	if c := tv.Counts; c.ErrorCount != 1 || c.WarningCount != 4 {
+		t.Fatalf("shown counts = %+v", c)
+	}
+	if len(tv.IssuesTab.Issues) != 5 || tv.SummaryTab.WarningCount != 4 {
+		t.Fatalf("shown issues = %d, dashboard warnings %d", len(tv.IssuesTab.Issues), tv.SummaryTab.WarningCount)
+	}
+	if got := st.mutedLabel(lint); got != "(muted)" {
+		t.Fatalf("label while shown = %q", got)
+	}
+
+	tv.SetShowMuted(false)
+	if c := tv.Counts; c.WarningCount != 1 || len(tv.IssuesTab.Issues) != 2 {
+		t.Fatalf("hidden again: counts %+v, %d issues", c, len(tv.IssuesTab.Issues))
+	}
+}
+
+func TestTogglePhaseMute(t *testing.T) {
+	tv := NewTabView()
+	tv.SetSize(120, 30)
+	addLintedBuild(tv)
+	if tv.Counts.WarningCount != 4 {
+		t.Fatalf("warnings = %d before muting", tv.Counts.WarningCount)
+	}
+
+	muted, ok := tv.TogglePhaseMute(compileHeader)
+	if !muted || !ok {
+		t.Fatalf("TogglePhaseMute = %v, %v", muted, ok)
+	}
+	if c := tv.Counts; c.ErrorCount != 0 || c.WarningCount != 3 || c.MutedErrors != 1 {
+		t.Fatalf("counts after mute = %+v", c)
+	}
+	compile := tv.StreamTab.IndexOfID(6)
+	if tv.StreamTab.hiddenAfter(compile) != 2 {
+		t.Fatalf("muted phase not collapsed")
+	}
+	// Later runs of the session mute it too
+	tv.Clear()
+	addLintedBuild(tv)
+	if tv.Counts.ErrorCount != 0 {
+		t.Fatalf("errors = %d in the next run", tv.Counts.ErrorCount)
+	}
+
+	if muted, _ := tv.TogglePhaseMute(compileHeader); muted {
+		t.Fatal("second toggle should unmute")
+	}
+	if c := tv.Counts; c.ErrorCount != 1 || c.WarningCount != 4 {
+		t.Fatalf("counts after unmute = %+v", c)
+	}
+
+	tv.SetMutedPhases([]string{"SwiftLint"})
+	if _, ok := tv.TogglePhaseMute(lintHeader); ok {
+		t.Fatal("a phase muted by config can't be unmuted")
+	}
+}
+
+func TestMutePhaseKey(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.width, m.height = 120, 40
+	addLintedBuild(m.tabView)
+	m.selectTab(TabStream)
+
+	st := m.tabView.StreamTab
+	st.ScrollToPhase(st.IndexOfID(2))
+	mouse := m.mouseEnabled
+	m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+	if m.tabView.Counts.WarningCount != 1 || m.mouseEnabled != mouse {
+		t.Fatalf("u didn't mute the lint phase: %+v", m.tabView.Counts)
+	}
+
+	// Away from a phase header u only says why nothing happened, and M
+	// still toggles the mouse
+	st.ScrollToIndex(st.IndexOfID(7))
+	m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+	if m.tabView.Counts.WarningCount != 1 || !strings.Contains(m.statusMsg, "No phase header") {
+		t.Fatalf("u away from a phase header: status %q, counts %+v", m.statusMsg, m.tabView.Counts)
+	}
+	st.ScrollToPhase(st.IndexOfID(2))
+	m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("M")})
+	if m.mouseEnabled == mouse || m.tabView.Counts.WarningCount != 1 {
+		t.Fatal("M on a phase header should only toggle the mouse")
+	}
+}
+
+func TestGroupDigits(t *testing.T) {
+	for n, want := range map[int]string{0: "0", 999: "999", 2013: "2,013", 1234567: "1,234,567", -4200: "-4,200"} {
+		if got := groupDigits(n); got != want {
+			t.Errorf("groupDigits(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
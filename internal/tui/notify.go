@@ -0,0 +1,108 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// =============================================================================
+// Completion Notifications
+// =============================================================================
+
+// notifyTimeout bounds the notification helper so a hung osascript can't pile up
+const notifyTimeout = 5 * time.Second
+
+// bellWriter receives the terminal bell (swapped in tests)
+var bellWriter io.Writer = os.Stdout
+
+// runNotifier runs a notification helper (swapped in tests)
+var runNotifier = func(ctx context.Context, name string, args ...string) error {
+	return exec.CommandContext(ctx, name, args...).Run()
+}
+
+// opNotice describes a finished op for a notification
+type opNotice struct {
+	Op      string
+	Success bool
+	Elapsed time.Duration
+	Errors  int
+}
+
+// Text is the notification body, e.g. "BUILD failed in 1m2s (3 errors)"
+func (n opNotice) Text() string {
+	outcome := "succeeded"
+	if !n.Success {
+		outcome = "failed"
+	}
+	text := fmt.Sprintf("%s %s in %s", strings.ToUpper(n.Op), outcome, n.Elapsed.Round(time.Second))
+	if n.Errors > 0 {
+		text += fmt.Sprintf(" (%d errors)", n.Errors)
+	}
+	return text
+}
+
+// notifyCmd returns the notification for a finished op, or nil when
+// tui.notify is off or the op finished under tui.notifyMinDuration.
+// Notification errors are ignored: they must never affect the op result.
+func notifyCmd(cfg core.TUIConfig, n opNotice) tea.Cmd {
+	mode := cfg.NotifyMode()
+	if mode == core.NotifyNone || n.Elapsed < cfg.NotifyThreshold() {
+		return nil
+	}
+	return func() tea.Msg {
+		switch mode {
+		case core.NotifyBell:
+			_, _ = io.WriteString(bellWriter, "\a")
+		case core.NotifySystem:
+			ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+			defer cancel()
+			name, args := systemNotifier(n.Text())
+			_ = runNotifier(ctx, name, args...)
+		}
+		return nil
+	}
+}
+
+// systemNotifier picks terminal-notifier when installed, else osascript
+func systemNotifier(text string) (string, []string) {
+	if _, err := exec.LookPath("terminal-notifier"); err == nil {
+		return "terminal-notifier", []string{"-title", "xcbolt", "-message", text}
+	}
+	script := fmt.Sprintf("display notification %s with title \"xcbolt\"", appleScriptString(text))
+	return "osascript", []string{"-e", script}
+}
+
+// appleScriptString quotes s as an AppleScript string literal
+func appleScriptString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+// cycleNotifyMode switches tui.notify none → bell → system → none
+func (m *Model) cycleNotifyMode() {
+	next := core.NotifyBell
+	switch m.cfg.TUI.NotifyMode() {
+	case core.NotifyBell:
+		next = core.NotifySystem
+	case core.NotifySystem:
+		next = core.NotifyNone
+	}
+	m.cfg.TUI.Notify = next
+	if err := core.SaveConfig(m.projectRoot, m.configPath, m.cfg); err != nil {
+		m.lastErr = err.Error()
+	}
+	if next == core.NotifyNone {
+		m.setStatus("Notifications off")
+		return
+	}
+	m.setStatus(fmt.Sprintf("Notifications: %s (ops over %s)", next, m.cfg.TUI.NotifyThreshold()))
+}
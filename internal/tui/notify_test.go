@@ -0,0 +1,60 @@
+package tui
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func TestNotifyCmdRespectsModeAndThreshold(t *testing.T) {
+	long := opNotice{Op: "build", Success: false, Elapsed: 65 * time.Second, Errors: 3}
+	if cmd := notifyCmd(core.TUIConfig{}, long); cmd != nil {
+		t.Fatalf("notify defaults to none")
+	}
+	bell := core.TUIConfig{Notify: "bell"}
+	if cmd := notifyCmd(bell, opNotice{Op: "build", Elapsed: 2 * time.Second}); cmd != nil {
+		t.Fatalf("ops under the threshold should stay silent")
+	}
+
+	var buf bytes.Buffer
+	prev := bellWriter
+	bellWriter = &buf
+	defer func() { bellWriter = prev }()
+	notifyCmd(bell, long)()
+	if buf.String() != "\a" {
+		t.Fatalf("bell mode should ring, got %q", buf.String())
+	}
+
+	if got := long.Text(); got != "BUILD failed in 1m5s (3 errors)" {
+		t.Fatalf("Text = %q", got)
+	}
+}
+
+func TestSystemNotifyFailureIsIgnored(t *testing.T) {
+	var ran []string
+	prev := runNotifier
+	runNotifier = func(_ context.Context, name string, args ...string) error {
+		ran = append(append(ran, name), args...)
+		return errors.New("no notification center")
+	}
+	defer func() { runNotifier = prev }()
+
+	cfg := core.TUIConfig{Notify: "system", NotifyMinDuration: "0s"}
+	if msg := notifyCmd(cfg, opNotice{Op: "test", Success: true, Elapsed: time.Second})(); msg != nil {
+		t.Fatalf("notification should not produce a message, got %v", msg)
+	}
+	if len(ran) == 0 || !strings.Contains(strings.Join(ran, " "), "TEST succeeded") {
+		t.Fatalf("expected a notifier call, got %q", ran)
+	}
+}
+
+func TestAppleScriptStringEscapes(t *testing.T) {
+	if got := appleScriptString(`say "hi" \ bye`); got != `"say \"hi\" \\ bye"` {
+		t.Fatalf("appleScriptString = %q", got)
+	}
+}
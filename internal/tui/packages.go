@@ -0,0 +1,60 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// =============================================================================
+// Resolved Packages
+// =============================================================================
+
+// resolvedPackages returns the packages from the last build/test, falling
+// back to the project's Package.resolved
+func (m *Model) resolvedPackages() []core.ResolvedPackage {
+	if len(m.lastBuild.Packages) > 0 {
+		return m.lastBuild.Packages
+	}
+	if len(m.lastTest.Packages) > 0 {
+		return m.lastTest.Packages
+	}
+	pkgs, err := core.LoadResolvedPackages(m.projectRoot, m.cfg)
+	if err != nil {
+		m.lastErr = "Package.resolved: " + err.Error()
+	}
+	return pkgs
+}
+
+// PackageItems creates selector items from resolved packages (ID = name)
+func PackageItems(pkgs []core.ResolvedPackage) []SelectorItem {
+	items := make([]SelectorItem, len(pkgs))
+	for i, p := range pkgs {
+		items[i] = SelectorItem{
+			ID:          p.Name,
+			Title:       p.Name,
+			Description: p.URL,
+			Meta:        p.Pin(),
+		}
+	}
+	return items
+}
+
+func (m *Model) openPackagesSelector() {
+	pkgs := m.resolvedPackages()
+	if len(pkgs) == 0 {
+		m.setStatus("No resolved packages")
+		return
+	}
+	m.selector = NewSelector("Resolved Packages (enter copies version)", PackageItems(pkgs), m.width, m.styles)
+	m.selectorType = SelectorPackages
+	m.mode = ModeSelector
+}
+
+// copyPackageVersion copies the pinned version of the selected package
+func (m *Model) copyPackageVersion(item *SelectorItem) tea.Cmd {
+	if item.Meta == "" {
+		return func() tea.Msg { return statusMsg("No version pinned for " + item.Title) }
+	}
+	return m.copyToClipboard(item.Meta, "Copied "+item.Title+" "+item.Meta)
+}
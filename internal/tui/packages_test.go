@@ -0,0 +1,54 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func TestPackagesPhaseAndProgress(t *testing.T) {
+	for _, line := range []string{
+		"SwiftPM: Resolving package graph",
+		"SwiftPM: Fetching 2 package(s) (swift-log, swift-argument-parser)",
+		"Resolved source packages:",
+	} {
+		if got := detectBuildPhase(line); got != "Packages" {
+			t.Fatalf("detectBuildPhase(%q) = %q, want Packages", line, got)
+		}
+	}
+
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.runningCmd = "build"
+	m.tabView.SummaryTab.SetRunning("build")
+	m.handleEvent(core.SwiftPMProgress("build", "Fetched", "swift-log", 1, 3))
+
+	if m.currentStage != "Packages" || m.stageProgress != "1/3" {
+		t.Fatalf("stage = %q %q", m.currentStage, m.stageProgress)
+	}
+	if len(m.tabView.StreamTab.Lines) != 0 {
+		t.Fatalf("progress events should not add log lines")
+	}
+	st := m.tabView.SummaryTab
+	if st.PackagesFetched != 1 || st.PackagesTotal != 3 || st.PackageCurrent != "swift-log" {
+		t.Fatalf("unexpected package progress: %d/%d %q", st.PackagesFetched, st.PackagesTotal, st.PackageCurrent)
+	}
+	st.SetSize(100, 40)
+	if view := stripANSI(st.View(DefaultStyles())); !strings.Contains(view, "Packages: 1/3 fetched · swift-log") {
+		t.Fatalf("building view missing package progress:\n%s", view)
+	}
+}
+
+func TestPackageItemsUsePinnedVersion(t *testing.T) {
+	items := PackageItems([]core.ResolvedPackage{
+		{Name: "swift-log", Version: "1.5.4", URL: "https://github.com/apple/swift-log.git"},
+		{Name: "Nimble", Branch: "main"},
+	})
+	if items[0].ID != "swift-log" || items[0].Meta != "1.5.4" || items[0].Description != "https://github.com/apple/swift-log.git" {
+		t.Fatalf("unexpected item: %+v", items[0])
+	}
+	if items[1].Meta != "main" {
+		t.Fatalf("expected branch pin, got %+v", items[1])
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/xcbolt/xcbolt/internal/core"
 )
 
 // =============================================================================
@@ -90,13 +92,23 @@ func DefaultCommands() []Command {
 	return []Command{
 		// Build/Run/Test
 		{ID: "build", Name: "Build", Description: "Build the project", Shortcut: "b", Category: "Actions"},
+		{ID: "build-target", Name: "Build Target…", Description: "Build one target instead of the scheme, e.g. to typecheck a framework", Category: "Actions"},
 		{ID: "run", Name: "Run", Description: "Build and run the app", Shortcut: "r", Category: "Actions"},
+		{ID: "run-launch-only", Name: "Run: Install & Launch Only", Description: "Install and launch the last built app without rebuilding", Category: "Actions"},
+		{ID: "run-launch-preset", Name: "Run: Launch Preset", Description: "Run with a preset from launch.presets, once or as the default", Category: "Actions"},
+		{ID: "run-choose-product", Name: "Run: Choose Product", Description: "Pick the app or app extension to install and launch", Category: "Actions"},
 		{ID: "test", Name: "Test", Description: "Run tests", Shortcut: "t", Category: "Actions"},
+		{ID: "test-stress", Name: "Test: Stress Selected", Description: "Run the test failure selected in Issues many times to catch flakiness", Category: "Actions"},
 		{ID: "clean", Name: "Clean", Description: "Clean build artifacts", Shortcut: "c", Category: "Actions"},
-		{ID: "clean-derived", Name: "Clean DerivedData", Description: "Remove .xcbolt/DerivedData", Category: "Actions"},
+		{ID: "clean-derived", Name: "Clean DerivedData", Description: "Remove .xcbolt/DerivedData (all destinations)", Category: "Actions"},
 		{ID: "clean-results", Name: "Clean Results", Description: "Remove .xcbolt/Results", Category: "Actions"},
 		{ID: "clean-sessions", Name: "Clean Sessions", Description: "Remove .xcbolt/sessions.json", Category: "Actions"},
 		{ID: "clean-spm-cache", Name: "Clean SwiftPM Cache", Description: "Remove SwiftPM caches", Category: "Actions"},
+		{ID: "storage", Name: "Storage", Description: "Disk used by DerivedData, results, and SwiftPM caches; Enter cleans one", Category: "Actions"},
+		{ID: "generate-project", Name: "Generate Project", Description: "Run the tuist/xcodegen generator now", Category: "Actions"},
+		{ID: "hot-rerun", Name: "Hot Rerun", Description: "Rebuild and relaunch the running app, keeping the console (old app stays if the build fails)", Shortcut: "R", Category: "Actions"},
+		{ID: "rerun-last", Name: "Rerun Last", Description: "Repeat the last operation with the same parameters", Shortcut: ".", Category: "Actions"},
+		{ID: "history-clear", Name: "History: Clear", Description: "Reset build and test duration history", Category: "Actions"},
 		{ID: "stop", Name: "Stop App", Description: "Stop running application", Shortcut: "x", Category: "Actions"},
 
 		// Archive/Profile
@@ -104,13 +116,25 @@ func DefaultCommands() []Command {
 		{ID: "archive-appstore", Name: "Archive for App Store", Description: "Archive with App Store profile", Category: "Build"},
 		{ID: "archive-adhoc", Name: "Archive for Ad Hoc", Description: "Archive with Ad Hoc profile", Category: "Build"},
 		{ID: "profile", Name: "Profile", Description: "Profile with Instruments", Category: "Build"},
-		{ID: "analyze", Name: "Analyze", Description: "Run static analyzer", Category: "Build"},
+		{ID: "analyze", Name: "Analyze", Description: "Run the clang static analyzer (xcodebuild analyze)", Category: "Build"},
+		{ID: "open-analyzer-report", Name: "Open Analyzer Report", Description: "Open the HTML report of the last analyze", Category: "Build"},
+		{ID: "why-rebuild", Name: "Why Rebuild?", Description: "Compiled file counts of recent builds and what changed between them", Category: "Build"},
+		{ID: "targets", Name: "Build: Targets", Description: "Which targets the last build compiled and which were cached", Category: "Build"},
+		{ID: "coverage-targets", Name: "Coverage: By Target", Description: "Line coverage per target from the last test run, lowest first (test.codeCoverage)", Category: "Build"},
+		{ID: "results-prune", Name: "Results: Prune", Description: "Remove result bundles beyond results.retention", Category: "Build"},
+		{ID: "results-info", Name: "Results: Info", Description: "Toolchain, git, and settings recorded for the last result bundle", Category: "Build"},
+		{ID: "settings-diff", Name: "Settings: Diff", Description: "Compare build settings across configurations or destinations", Category: "Build"},
 
 		// Configuration
 		{ID: "scheme", Name: "Switch Scheme", Description: "Change the active scheme", Shortcut: "s", Category: "Config"},
 		{ID: "configuration", Name: "Switch Configuration", Description: "Change the active build configuration", Shortcut: "~", Category: "Config"},
+		{ID: "test-plan", Name: "Switch Test Plan", Description: "Choose the test plan passed to xcodebuild test", Category: "Config"},
 		{ID: "destination", Name: "Switch Destination", Description: "Change the target device/simulator", Shortcut: "d", Category: "Config"},
 		{ID: "toggle-dry-run", Name: "Toggle Dry Run", Description: "Print xcodebuild commands without running them", Category: "Config"},
+		{ID: "toggle-notify", Name: "Cycle Notifications", Description: "Notify when long ops finish: off → bell → system", Category: "Config"},
+		{ID: "toggle-auto-tab", Name: "Cycle Auto Tab Switch", Description: "Switch tabs for you: Issues on failure → Logs on start → never", Category: "Config"},
+		{ID: "toggle-show-muted", Name: "Toggle Muted Issues", Description: "Count and list the errors and warnings of muted phases (tui.mutedPhases, M)", Category: "Config"},
+		{ID: "toggle-run-split", Name: "Toggle Side-by-Side Split", Description: "Run mode: console next to the build pane on wide terminals", Category: "Config"},
 		{ID: "toggle-unified-logs", Name: "Toggle Unified Logs", Description: "Stream unified logs during Run", Category: "Config"},
 		{ID: "toggle-system-logs", Name: "Toggle System Logs", Description: "Include Apple/system subsystems in unified logs", Category: "Config"},
 		{ID: "toggle-log-debug", Name: "Toggle Debug Logs", Description: "Show/hide debug logs in console", Category: "Config"},
@@ -118,25 +142,45 @@ func DefaultCommands() []Command {
 		{ID: "toggle-log-warn", Name: "Toggle Warning Logs", Description: "Show/hide warning logs in console", Category: "Config"},
 		{ID: "toggle-log-error", Name: "Toggle Error Logs", Description: "Show/hide error logs in console", Category: "Config"},
 		{ID: "toggle-log-fault", Name: "Toggle Fault Logs", Description: "Show/hide fault logs in console", Category: "Config"},
+		{ID: "console-filter", Name: "Cycle Console Filter", Description: "All → Warnings+ → Errors+ → Faults only", Shortcut: "l", Category: "Config"},
+		{ID: "copy-issues-json", Name: "Issues: Copy as JSON", Description: "Copy every issue with its type, warning category, and location", Category: "Navigation"},
+		{ID: "config-show", Name: "Config: Show Effective", Description: "List every setting with where it comes from (default, global, project, flag)", Category: "Config"},
+		{ID: "metrics-show", Name: "Metrics: Show", Description: "The last 20 entries of the build metrics log (metrics.enabled)", Category: "Config"},
+		{ID: "config-validate", Name: "Validate Config", Description: "Check the config file for unknown and deprecated keys", Category: "Config"},
 		{ID: "init", Name: "Initialize Config", Description: "Run the configuration wizard", Shortcut: "i", Category: "Config"},
 		{ID: "refresh", Name: "Refresh Context", Description: "Rescan projects, schemes, and devices", Shortcut: "^R", Category: "Config"},
 
 		// Utilities
 		{ID: "doctor", Name: "Run Doctor", Description: "Check environment and dependencies", Category: "Utilities"},
-		{ID: "logs", Name: "Logs", Description: "Stream device/simulator logs", Category: "Utilities"},
+		{ID: "logs", Name: "Logs", Description: "Browse a destination's unified logs", Category: "Utilities"},
 		{ID: "simulator-boot", Name: "Boot Simulator", Description: "Boot the selected simulator", Category: "Utilities"},
 		{ID: "simulator-shutdown", Name: "Shutdown Simulator", Description: "Shutdown all simulators", Category: "Utilities"},
 		{ID: "open-xcode", Name: "Open in Xcode", Description: "Open workspace/project in Xcode", Category: "Utilities"},
 		{ID: "open-project", Name: "Open Project", Description: "Reveal workspace/project in Finder", Category: "Utilities"},
+		{ID: "recent-projects", Name: "Open Recent Project", Description: "Switch to a recently used project, or enter a path", Category: "Utilities"},
+		{ID: "app-reveal-container", Name: "App: Reveal Data Container", Description: "Open the app's simulator data container in Finder", Category: "Utilities"},
+		{ID: "app-reset-data", Name: "App: Reset Data", Description: "Reinstall the app on the simulator with empty data", Category: "Utilities"},
+		{ID: "copy-last-command", Name: "Copy Last xcodebuild Command", Description: "Copy the exact xcodebuild invocation of the last operation", Category: "Utilities"},
+		{ID: "export-repro", Name: "Export Repro Bundle", Description: "Save the log around the first error, issues, command, config, and environment to .xcbolt/repro for a teammate", Category: "Utilities"},
+		{ID: "git-ignore", Name: "Git: Add Ignore Entry", Description: "Add .xcbolt/ or xcbolt's generated files to the project's .gitignore, after a preview", Category: "Utilities"},
+		{ID: "copy-repro-command", Name: "Copy Repro Command", Description: "Copy a standalone xcodebuild build command for the current config", Category: "Utilities"},
+		{ID: "copy-xcbolt-command", Name: "Copy xcbolt Command", Description: "Copy the xcbolt build command selecting the current scheme and destination", Category: "Utilities"},
+		{ID: "packages", Name: "Packages: Show Resolved", Description: "List resolved SwiftPM packages and copy a version", Category: "Utilities"},
+		{ID: "sessions", Name: "Sessions", Description: "List launched apps to stop, stream logs, or forget", Category: "Utilities"},
 
 		// Navigation
+		{ID: "go-to-phase", Name: "Go to Phase", Description: "List build phases with their error/warning counts and jump to one", Shortcut: "{ }", Category: "Navigation"},
+		{ID: "bookmarks", Name: "Bookmarks: Jump", Description: "List bookmarked log lines and jump to one", Shortcut: "'", Category: "Navigation"},
+		{ID: "bookmarks-clear", Name: "Bookmarks: Clear", Description: "Remove all bookmarks, including pinned ones", Category: "Navigation"},
+		{ID: "show-previous-run", Name: "Show Previous Run", Description: "Toggle Logs/Issues from the previous operation", Shortcut: "⇧tab", Category: "Navigation"},
 		{ID: "help", Name: "Show Help", Description: "Display keyboard shortcuts", Shortcut: "?", Category: "Navigation"},
 		{ID: "quit", Name: "Quit", Description: "Exit xcbolt", Shortcut: "q", Category: "Navigation"},
 	}
 }
 
-// NewPalette creates a new command palette
-func NewPalette(screenWidth int, styles Styles) PaletteModel {
+// NewPalette creates a new command palette listing the built-in commands
+// and the project's (config commands, in the "Project" group)
+func NewPalette(screenWidth int, styles Styles, project []core.UserCommand) PaletteModel {
 	// Calculate width: 50-60% of screen, clamped to reasonable bounds
 	width := screenWidth * 55 / 100
 	if width < 40 {
@@ -152,7 +196,7 @@ func NewPalette(screenWidth int, styles Styles) PaletteModel {
 	ti.CharLimit = 50
 	ti.Width = width - 6
 
-	commands := DefaultCommands()
+	commands := append(DefaultCommands(), UserCommands(project)...)
 	maxVisible := 12
 	if len(commands) < maxVisible {
 		maxVisible = len(commands)
@@ -0,0 +1,184 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// =============================================================================
+// Phase Navigation
+// =============================================================================
+
+// PhaseHeader is a phase header line of the Logs tab with the issues in its
+// phase (the lines up to the next header)
+type PhaseHeader struct {
+	Index    int // Line index in StreamTab.Lines
+	Row      int // Display row (hidden headers map to the next visible row)
+	Text     string
+	Errors   int
+	Warnings int
+}
+
+// PhaseHeaders lists the phase headers in buffer order
+func (st *StreamTab) PhaseHeaders() []PhaseHeader {
+	var headers []PhaseHeader
+	for i, line := range st.Lines {
+		switch {
+		case line.Type == TabLineTypePhaseHeader:
+			headers = append(headers, PhaseHeader{Index: i, Row: st.rowForLine(i), Text: line.Text})
+		case len(headers) == 0:
+		case line.Type == TabLineTypeError:
+			headers[len(headers)-1].Errors++
+		case line.Type == TabLineTypeWarning:
+			headers[len(headers)-1].Warnings++
+		}
+	}
+	return headers
+}
+
+// JumpToPhase scrolls to the next (dir > 0) or previous phase header after
+// or before the top of the view, wrapping around, and highlights it. It
+// returns the 1-based phase number and the total.
+func (st *StreamTab) JumpToPhase(dir int) (int, int, bool) {
+	headers := st.PhaseHeaders()
+	if len(headers) == 0 {
+		return 0, 0, false
+	}
+	// Go relative to the header last jumped to (the view may not have been
+	// able to scroll it to the top), else to the top of the view.
+	_, top, _ := st.TopLine()
+	if idx := st.IndexOfID(st.phaseID); st.phaseID != 0 && idx >= 0 {
+		top = idx
+	}
+	target := -1
+	for i, h := range headers {
+		if (dir > 0 && h.Index > top && target < 0) || (dir < 0 && h.Index < top) {
+			target = i
+		}
+	}
+	if target < 0 {
+		target = len(headers) - 1
+		if dir > 0 {
+			target = 0
+		}
+	}
+	st.ScrollToPhase(headers[target].Index)
+	return target + 1, len(headers), true
+}
+
+// ScrollToPhase puts the header at line idx at the top of the view and
+// highlights it
+func (st *StreamTab) ScrollToPhase(idx int) {
+	if idx < 0 || idx >= len(st.Lines) {
+		return
+	}
+	st.ScrollToIndex(idx)
+	st.HighlightID = st.Lines[idx].ID
+	st.phaseID = st.Lines[idx].ID
+}
+
+// HeaderRows maps each rendered phase to the row of its header in the
+// grouped layout (before the sticky header is applied)
+func (v *PhaseView) HeaderRows() map[int]int {
+	rows := map[int]int{}
+	row := 0
+	for i, phase := range v.Phases {
+		if v.ShowErrorsOnly && v.matchingLineCount(phase) == 0 {
+			continue
+		}
+		rows[i] = row
+		row++
+		switch {
+		case v.ShowErrorsOnly:
+			row += len(issueClusterRows(v.issueClusters(phase)))
+		case !phase.Collapsed:
+			row += len(phase.Lines)
+		}
+	}
+	return rows
+}
+
+// PhaseItems creates selector items from phase headers (ID = line index)
+func PhaseItems(headers []PhaseHeader) []SelectorItem {
+	items := make([]SelectorItem, len(headers))
+	for i, h := range headers {
+		items[i] = SelectorItem{
+			ID:          strconv.Itoa(h.Index),
+			Title:       h.Text,
+			Description: issueCountLabel(h.Errors, h.Warnings),
+			Dim:         h.Errors == 0 && h.Warnings == 0,
+		}
+	}
+	return items
+}
+
+// issueCountLabel is "2 errors, 1 warning" ("no issues" for none)
+func issueCountLabel(errors, warnings int) string {
+	plural := func(n int, word string) string {
+		if n == 1 {
+			return "1 " + word
+		}
+		return fmt.Sprintf("%d %ss", n, word)
+	}
+	switch {
+	case errors > 0 && warnings > 0:
+		return plural(errors, "error") + ", " + plural(warnings, "warning")
+	case errors > 0:
+		return plural(errors, "error")
+	case warnings > 0:
+		return plural(warnings, "warning")
+	}
+	return "no issues"
+}
+
+// jumpToPhase moves the Logs tab to the next/previous phase header
+func (m *Model) jumpToPhase(dir int) tea.Cmd {
+	m.selectTab(TabStream)
+	if m.runMode.Active {
+		m.runMode.FocusPane = PaneBuild
+	}
+	st := m.tabView.VisibleStreamTab()
+	n, total, ok := st.JumpToPhase(dir)
+	if !ok {
+		m.setStatus("No phases yet")
+		return nil
+	}
+	m.setStatus(fmt.Sprintf("Phase %d/%d", n, total))
+	return m.flashJumpHighlight()
+}
+
+func (m *Model) openPhaseSelector() {
+	headers := m.tabView.VisibleStreamTab().PhaseHeaders()
+	if len(headers) == 0 {
+		m.setStatus("No phases yet")
+		return
+	}
+	m.selector = NewSelector("Go to Phase", PhaseItems(headers), m.width, m.styles)
+	m.selectorType = SelectorPhases
+	m.mode = ModeSelector
+}
+
+// goToPhase jumps to the phase picked in the selector
+func (m *Model) goToPhase(item *SelectorItem) tea.Cmd {
+	idx, err := strconv.Atoi(item.ID)
+	if err != nil {
+		return nil
+	}
+	m.selectTab(TabStream)
+	if m.runMode.Active {
+		m.runMode.FocusPane = PaneBuild
+	}
+	m.tabView.VisibleStreamTab().ScrollToPhase(idx)
+	m.setStatus("Jumped to " + item.Title)
+	return m.flashJumpHighlight()
+}
+
+// flashJumpHighlight clears the jump highlight after bookmarkFlashDuration
+func (m *Model) flashJumpHighlight() tea.Cmd {
+	m.bookmarkFlashID++
+	id := m.bookmarkFlashID
+	return tea.Tick(bookmarkFlashDuration, func(time.Time) tea.Msg { return bookmarkFlashMsg(id) })
+}
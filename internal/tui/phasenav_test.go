@@ -0,0 +1,119 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func addPhaseFixture(st *StreamTab) {
+	st.AddLine("=== BUILD TARGET Core ===", TabLineTypePhaseHeader)
+	st.AddLine("/src/Core/A.swift:1:1: error: boom", TabLineTypeError)
+	st.AddLine("/src/Core/B.swift:2:1: warning: hmm", TabLineTypeWarning)
+	for i := 0; i < 20; i++ {
+		st.AddLine(fmt.Sprintf("core %d", i), TabLineTypeNormal)
+	}
+	st.AddLine("=== BUILD TARGET App ===", TabLineTypePhaseHeader)
+	for i := 0; i < 20; i++ {
+		st.AddLine(fmt.Sprintf("app %d", i), TabLineTypeNormal)
+	}
+	st.AddLine("=== BUILD TARGET Widget ===", TabLineTypePhaseHeader)
+	st.AddLine("/src/Widget/W.swift:3:1: warning: unused", TabLineTypeWarning)
+	st.AddLine("widget done", TabLineTypeNormal)
+}
+
+func TestStreamTabPhaseHeaders(t *testing.T) {
+	st := NewStreamTab()
+	st.SetSize(80, 10)
+	addPhaseFixture(st)
+
+	headers := st.PhaseHeaders()
+	if len(headers) != 3 {
+		t.Fatalf("headers = %+v", headers)
+	}
+	if h := headers[0]; h.Index != 0 || h.Errors != 1 || h.Warnings != 1 {
+		t.Fatalf("first header = %+v", h)
+	}
+	if h := headers[2]; h.Index != 44 || h.Errors != 0 || h.Warnings != 1 {
+		t.Fatalf("last header = %+v", h)
+	}
+	if got := PhaseItems(headers)[0].Description; got != "1 error, 1 warning" {
+		t.Fatalf("description = %q", got)
+	}
+
+	// Collapsing a phase shifts the display rows of the later headers.
+	st.TogglePhase(0)
+	if h := st.PhaseHeaders()[1]; h.Row != 1 {
+		t.Fatalf("row after collapse = %d, want 1", h.Row)
+	}
+}
+
+func TestStreamTabJumpToPhaseWraps(t *testing.T) {
+	st := NewStreamTab()
+	st.SetSize(80, 10)
+	addPhaseFixture(st)
+	st.GotoTop()
+
+	want := []int{23, 44, 0, 23}
+	for i, idx := range want {
+		n, total, ok := st.JumpToPhase(1)
+		if !ok || total != 3 {
+			t.Fatalf("jump %d: ok=%v total=%d", i, ok, total)
+		}
+		// The last phase can't scroll to the top; the jump still counts.
+		if got := st.PhaseHeaders()[n-1].Index; got != idx {
+			t.Fatalf("jump %d landed on line %d, want %d", i, got, idx)
+		}
+		if st.HighlightID != st.Lines[idx].ID || st.AutoFollow {
+			t.Fatalf("jump %d: highlight %d, follow %v", i, st.HighlightID, st.AutoFollow)
+		}
+	}
+	if n, _, _ := st.JumpToPhase(-1); n != 1 {
+		t.Fatalf("previous phase = %d, want 1", n)
+	}
+}
+
+func TestPhaseViewHeaderRowsMatchRenderedHeaders(t *testing.T) {
+	v := NewPhaseView()
+	v.StickyHeader = false
+	v.SmartCollapse = false
+	for _, line := range []string{"CompileSwiftSources normal arm64", "compile a", "compile b", "Ld /App normal", "link a", "CodeSign /App.app", "sign a"} {
+		v.AddLine(line)
+	}
+	v.Phases[1].Collapsed = true
+	v.SetSize(80, 20)
+	v.GotoTop()
+	rendered := strings.Split(stripANSI(v.renderGrouped(DefaultStyles())), "\n")
+	rows := v.HeaderRows()
+	for phase, row := range rows {
+		if !strings.Contains(rendered[row], v.Phases[phase].Name) {
+			t.Fatalf("row %d = %q, want header of %q", row, rendered[row], v.Phases[phase].Name)
+		}
+	}
+	if rows[2] != 5 {
+		t.Fatalf("header rows = %v", rows)
+	}
+}
+
+func TestGoToPhasePalette(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	st := m.tabView.StreamTab
+	st.SetSize(80, 10)
+	addPhaseFixture(st)
+
+	m.openPhaseSelector()
+	if m.mode != ModeSelector || m.selectorType != SelectorPhases {
+		t.Fatalf("mode = %v, selector = %v", m.mode, m.selectorType)
+	}
+	items := PhaseItems(st.PhaseHeaders())
+	if cmd := m.goToPhase(&items[1]); cmd == nil {
+		t.Fatal("expected a flash timer after jumping")
+	}
+	if line, _, _ := st.TopLine(); line.Text != "=== BUILD TARGET App ===" {
+		t.Fatalf("top line = %q", line.Text)
+	}
+	if m.tabView.ActiveTab != TabStream {
+		t.Fatalf("active tab = %v", m.tabView.ActiveTab)
+	}
+}
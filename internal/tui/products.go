@@ -0,0 +1,97 @@
+package tui
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// =============================================================================
+// Run Product Selection
+// =============================================================================
+
+// defaultProductID is the selector item that runs the scheme's main app
+const defaultProductID = "\x00default"
+
+// productsMsg carries the built products for the product selector
+type productsMsg struct {
+	products []core.ProductInfo
+	err      error
+}
+
+// chooseProduct lists the apps and extensions the scheme builds, from the
+// last build or by reading the build settings
+func (m *Model) chooseProduct() tea.Cmd {
+	if products := m.lastBuild.Products; len(products) > 0 {
+		return func() tea.Msg { return productsMsg{products: products} }
+	}
+	m.setStatus("Looking up built products…")
+	root, cfg := m.projectRoot, m.cfg
+	return func() tea.Msg {
+		products, err := core.ListProducts(context.Background(), root, cfg)
+		return productsMsg{products: products, err: err}
+	}
+}
+
+func (m *Model) handleProducts(msg productsMsg) {
+	if msg.err != nil {
+		m.setStatus("Could not read build settings: " + msg.err.Error())
+		return
+	}
+	switch len(msg.products) {
+	case 0:
+		m.setStatus("No built products found (build first)")
+		return
+	case 1:
+		m.setStatus("The scheme builds a single product: " + msg.products[0].Name())
+		return
+	}
+	m.selector = NewSelector("Run product", ProductItems(msg.products, m.cfg.Launch.Product), m.width, m.styles)
+	m.selectorType = SelectorProduct
+	m.mode = ModeSelector
+	m.setStatus("")
+}
+
+// ProductItems creates selector items (ID = bundle id) after a "scheme
+// default" item, marking the configured product
+func ProductItems(products []core.ProductInfo, current string) []SelectorItem {
+	items := make([]SelectorItem, 0, len(products)+1)
+	def := SelectorItem{ID: defaultProductID, Title: "Scheme default", Description: "The scheme's main app"}
+	if current == "" {
+		def.Meta = "[selected]"
+	}
+	items = append(items, def)
+	for _, p := range products {
+		parts := []string{p.BundleID}
+		if p.Kind == core.ProductExtension {
+			if p.HostApp != "" {
+				parts = append(parts, "extension in "+filepath.Base(p.HostApp))
+			} else {
+				parts = append(parts, "extension (not embedded)")
+			}
+		}
+		item := SelectorItem{ID: p.BundleID, Title: p.Name(), Description: strings.Join(parts, " · ")}
+		if p.BundleID == current {
+			item.Meta = "[selected]"
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// pickProduct saves the chosen product as launch.product and runs it
+func (m *Model) pickProduct(item *SelectorItem) tea.Cmd {
+	product := item.ID
+	if product == defaultProductID {
+		product = ""
+	}
+	m.cfg.Launch.Product = product
+	if err := core.SaveConfig(m.projectRoot, m.configPath, m.cfg); err != nil {
+		m.lastErr = err.Error()
+	}
+	return m.startOrRestartOp("run")
+}
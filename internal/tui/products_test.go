@@ -0,0 +1,41 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+var testProducts = []core.ProductInfo{
+	{Path: "/b/App.app", BundleID: "com.example.app", Kind: core.ProductApp},
+	{Path: "/b/App.app/PlugIns/Widgets.appex", BundleID: "com.example.app.widgets", Kind: core.ProductExtension, HostApp: "/b/App.app"},
+}
+
+func TestProductItemsMarkSelection(t *testing.T) {
+	items := ProductItems(testProducts, "com.example.app.widgets")
+	if len(items) != 3 || items[0].ID != defaultProductID || items[0].Meta != "" {
+		t.Fatalf("expected scheme default first and unselected, got %+v", items)
+	}
+	if items[2].Title != "Widgets.appex" || items[2].Meta != "[selected]" || !strings.Contains(items[2].Description, "extension in App.app") {
+		t.Fatalf("unexpected extension item %+v", items[2])
+	}
+	if got := ProductItems(testProducts, ""); got[0].Meta != "[selected]" {
+		t.Fatalf("scheme default should be selected when launch.product is unset")
+	}
+}
+
+func TestHandleProductsSkipsSelectorForSingleProduct(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+
+	m.handleProducts(productsMsg{products: testProducts[:1]})
+	if m.mode == ModeSelector || !strings.Contains(m.statusMsg, "single product") {
+		t.Fatalf("a single product needs no choice (mode %v, status %q)", m.mode, m.statusMsg)
+	}
+
+	m.handleProducts(productsMsg{products: testProducts})
+	if m.mode != ModeSelector || m.selectorType != SelectorProduct {
+		t.Fatalf("several products should open the selector")
+	}
+}
@@ -0,0 +1,69 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func TestProgressStageFromLocalizedRawLog(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.cfg.TUI.LegacyProgress = true
+	m.runningCmd = "build"
+	m.tabView.SummaryTab.SetRunning("build")
+
+	steps := []struct {
+		line  string
+		stage string
+	}{
+		{"CompileSwift normal arm64 /src/App/ContentView.swift (in target 'App' from project 'App')", "Compile"},
+		{"/src/App/ContentView.swift:20:13: Warnung: Variable 'x' wurde nie verwendet", "Compile"},
+		{"Ld /DD/Build/Products/Debug-iphonesimulator/App.app/App normal (in target 'App' from project 'App')", "Link"},
+		{"CodeSign /DD/Build/Products/Debug-iphonesimulator/App.app (in target 'App' from project 'App')", "Sign"},
+	}
+	for _, s := range steps {
+		m.handleEvent(core.LogRaw("build", s.line))
+		if m.currentStage != s.stage {
+			t.Fatalf("after %q stage = %q, want %q", s.line, m.currentStage, s.stage)
+		}
+	}
+
+	m.handleEvent(core.LogRaw("build", "** BUILD ERFOLGREICH **"))
+	if m.currentStage != "Sign" {
+		t.Fatalf("localized banner changed stage to %q", m.currentStage)
+	}
+	m.handleEvent(core.Result("build", true, map[string]any{"exitCode": 0}))
+	if m.currentStage != "" {
+		t.Fatalf("result should clear stage, got %q", m.currentStage)
+	}
+}
+
+func TestProgressEventsDriveStage(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.runningCmd = "build"
+	m.tabView.SummaryTab.SetRunning("build")
+
+	// Log text alone no longer moves the stage
+	m.handleEvent(core.LogRaw("build", "Ld /DD/App.app/App normal (in target 'App' from project 'App')"))
+	if m.currentStage != "" {
+		t.Fatalf("raw log set stage %q", m.currentStage)
+	}
+
+	m.handleEvent(core.Progress("build", "Compile", 3, 12, "/src/App/ContentView.swift"))
+	if m.currentStage != "Compile" || m.stageProgress != "3/12" || m.progressBar.Current != 3 {
+		t.Fatalf("stage = %q %q bar = %d", m.currentStage, m.stageProgress, m.progressBar.Current)
+	}
+	if st := m.tabView.SummaryTab; st.CurrentFile != "ContentView.swift" || st.FilesTotal != 12 {
+		t.Fatalf("Dashboard = %q %d/%d", st.CurrentFile, st.FileProgress, st.FilesTotal)
+	}
+	if n := len(m.tabView.StreamTab.Lines); n != 1 {
+		t.Fatalf("progress event added to Logs: %d lines", n)
+	}
+
+	m.handleEvent(core.Progress("build", "Link", 8, 0, ""))
+	if m.currentStage != "Link" || m.stageProgress != "" {
+		t.Fatalf("stage = %q %q", m.currentStage, m.stageProgress)
+	}
+}
@@ -0,0 +1,297 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+	"github.com/xcbolt/xcbolt/internal/util"
+)
+
+// enterProjectPathID is the launcher item that asks for a path
+const enterProjectPathID = "\x00path"
+
+// projectPathForm asks for the directory of a project to open
+type projectPathForm struct {
+	input textinput.Model
+	// matches are the directories the last tab completed among
+	matches []string
+}
+
+// RecentProjectItems creates launcher items (ID = project root) from the
+// recent projects, newest first, followed by the "Enter a path" item.
+// Roots that no longer exist are dimmed.
+func RecentProjectItems(recent []core.RecentProject) []SelectorItem {
+	items := make([]SelectorItem, 0, len(recent)+1)
+	for _, r := range recent {
+		item := SelectorItem{
+			ID:          r.Root,
+			Title:       filepath.Base(r.Root),
+			Description: shortenHome(r.Root),
+			Meta:        r.Scheme,
+		}
+		if t, err := time.Parse(time.RFC3339, r.UpdatedAt); err == nil {
+			item.Note = t.Local().Format("Jan 2 15:04")
+		}
+		if info, err := os.Stat(r.Root); err != nil || !info.IsDir() {
+			item.Dim = true
+			item.Note = "missing"
+		}
+		items = append(items, item)
+	}
+	return append(items, SelectorItem{
+		ID:          enterProjectPathID,
+		Title:       "Enter a path…",
+		Description: "Open a project directory by path (tab completes)",
+	})
+}
+
+// rememberProject moves the loaded project to the front of the launcher's
+// recent projects
+func (m *Model) rememberProject() {
+	m.state.AddRecentProject(core.RecentProject{
+		Root:      m.projectRoot,
+		Workspace: m.cfg.Workspace,
+		Project:   m.cfg.Project,
+		Scheme:    m.cfg.Scheme,
+		UpdatedAt: time.Now().Format(time.RFC3339),
+	})
+	_ = core.SaveState(m.state)
+}
+
+// openProjectLauncher lists the recent projects to switch to; without any,
+// it asks for a path right away
+func (m *Model) openProjectLauncher() {
+	var recent []core.RecentProject
+	for _, r := range m.state.Recent {
+		if r.Root != m.projectRoot {
+			recent = append(recent, r)
+		}
+	}
+	if len(recent) == 0 {
+		m.openProjectPathInput()
+		return
+	}
+	m.selector = NewSelector("Open Recent Project", RecentProjectItems(recent), m.width, m.styles)
+	m.selectorType = SelectorRecentProject
+	m.mode = ModeSelector
+}
+
+// requestProjectLauncher opens the launcher from the palette, unless
+// something is running in the current project
+func (m *Model) requestProjectLauncher() {
+	if m.running || m.runMode.Active {
+		m.setStatus("Stop the running operation before switching projects")
+		return
+	}
+	m.openProjectLauncher()
+}
+
+// selectRecentProject switches to the picked project, or asks for a path
+func (m *Model) selectRecentProject(item *SelectorItem) tea.Cmd {
+	if item.ID == enterProjectPathID {
+		m.openProjectPathInput()
+		return nil
+	}
+	return m.switchProject(item.ID)
+}
+
+// noProjectStatus explains why the launcher is shown
+func (m *Model) noProjectStatus() {
+	m.setStatus("No workspace, project, or Package.swift in " + shortenHome(m.projectRoot))
+}
+
+func (m *Model) openProjectPathInput() {
+	in := textinput.New()
+	in.Placeholder = "~/Developer/MyApp"
+	in.CharLimit = 500
+	in.Focus()
+	m.projectPath = projectPathForm{input: in}
+	m.mode = ModeProjectPath
+}
+
+func (m *Model) handleProjectPathKey(msg tea.KeyMsg) tea.Cmd {
+	f := &m.projectPath
+	switch msg.String() {
+	case "esc":
+		m.mode = ModeNormal
+		m.noProjectStatus()
+		return nil
+	case "tab":
+		value, matches := completeDir(f.input.Value(), m.projectRoot)
+		f.input.SetValue(value)
+		f.input.CursorEnd()
+		f.matches = matches
+		return nil
+	case "enter":
+		path := strings.TrimSpace(f.input.Value())
+		if path == "" {
+			return nil
+		}
+		return m.switchProject(path)
+	}
+	f.matches = nil
+	var cmd tea.Cmd
+	f.input, cmd = f.input.Update(msg)
+	return cmd
+}
+
+// switchProject re-roots xcbolt at the project containing dir (relative
+// paths are taken from the current root) as if it had been started there:
+// it changes the working directory and rebuilds the model, which reloads
+// the context. A config path given with --config is kept.
+func (m *Model) switchProject(dir string) tea.Cmd {
+	dir = expandHome(dir)
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(m.projectRoot, dir)
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		m.lastErr = "Not a directory: " + shortenHome(dir)
+		m.setStatus("Project not opened")
+		return nil
+	}
+	root, err := util.FindProjectRoot(dir)
+	if err == nil {
+		err = os.Chdir(root)
+	}
+	if err != nil {
+		m.lastErr = err.Error()
+		m.setStatus("Project not opened")
+		return nil
+	}
+
+	configPath := m.configPath
+	if configPath == "" || configPath == core.ConfigPath(m.projectRoot) {
+		configPath = core.ConfigPath(root)
+	}
+	next := NewModel(root, configPath, m.cfgOverride)
+	next.width, next.height = m.width, m.height
+	next.mouseEnabled = m.mouseEnabled
+	next.status = m.status
+	if m.statusFile != nil {
+		m.statusFile.Close()
+		next.statusFile = core.NewStatusFileWriter(root)
+	}
+	*m = next
+	width, height := m.width, m.height
+	return tea.Batch(m.Init(), func() tea.Msg { return tea.WindowSizeMsg{Width: width, Height: height} })
+}
+
+// completeDir completes the last element of a directory path typed in the
+// launcher. A single match is completed with a trailing separator;
+// several are completed to their common prefix and returned for display.
+// Hidden directories only match a prefix starting with ".".
+func completeDir(value, base string) (string, []string) {
+	dirPart, prefix := filepath.Split(value)
+	dir := expandHome(dirPart)
+	if dir == "" {
+		dir = "."
+	}
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(base, dir)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return value, nil
+	}
+	var matches []string
+	for _, e := range entries {
+		name := e.Name()
+		if !strings.HasPrefix(name, prefix) || (strings.HasPrefix(name, ".") && !strings.HasPrefix(prefix, ".")) {
+			continue
+		}
+		if e.IsDir() {
+			matches = append(matches, name)
+		} else if e.Type()&os.ModeSymlink != 0 {
+			if info, err := os.Stat(filepath.Join(dir, name)); err == nil && info.IsDir() {
+				matches = append(matches, name)
+			}
+		}
+	}
+	sort.Strings(matches)
+	switch len(matches) {
+	case 0:
+		return value, nil
+	case 1:
+		return dirPart + matches[0] + string(filepath.Separator), nil
+	}
+	common := matches[0]
+	for _, name := range matches[1:] {
+		for !strings.HasPrefix(name, common) {
+			common = common[:len(common)-1]
+		}
+	}
+	return dirPart + common, matches
+}
+
+// expandHome replaces a leading ~ with the home directory
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
+
+// shortenHome writes paths under the home directory with a leading ~
+func shortenHome(path string) string {
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return path
+	}
+	if path == home {
+		return "~"
+	}
+	if rest, ok := strings.CutPrefix(path, home+string(filepath.Separator)); ok {
+		return "~" + string(filepath.Separator) + rest
+	}
+	return path
+}
+
+func (m Model) projectPathOverlayView() string {
+	s := m.styles
+	width := minInt(maxInt(m.width*60/100, 50), 90)
+	f := m.projectPath
+
+	var b strings.Builder
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(s.Colors.Text)
+	b.WriteString(titleStyle.Render("Open Project"))
+	b.WriteString("\n")
+	dividerStyle := lipgloss.NewStyle().Foreground(s.Colors.BorderMuted)
+	b.WriteString(dividerStyle.Render(strings.Repeat("─", width-6)))
+	b.WriteString("\n")
+
+	labelStyle := lipgloss.NewStyle().Foreground(s.Colors.TextMuted)
+	b.WriteString(labelStyle.Render("Directory (relative to " + truncateString(shortenHome(m.projectRoot), width-30) + ")"))
+	b.WriteString("\n")
+	input := f.input
+	input.Width = width - 10
+	b.WriteString(input.View())
+	b.WriteString("\n\n")
+	if len(f.matches) > 0 {
+		b.WriteString(labelStyle.Render(truncateString(strings.Join(f.matches, "  "), width-6)))
+		b.WriteString("\n\n")
+	}
+
+	hintKeyStyle := lipgloss.NewStyle().Foreground(s.Colors.Accent)
+	hintDescStyle := lipgloss.NewStyle().Foreground(s.Colors.TextSubtle)
+	b.WriteString(hintKeyStyle.Render("enter") + hintDescStyle.Render(" open  ") +
+		hintKeyStyle.Render("tab") + hintDescStyle.Render(" complete  ") +
+		hintKeyStyle.Render("esc") + hintDescStyle.Render(" cancel"))
+
+	containerStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(s.Colors.Border).
+		Padding(1, 2)
+	return RenderCenteredPopup(containerStyle.Width(width).Render(b.String()), m.width, m.height)
+}
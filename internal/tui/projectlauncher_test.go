@@ -0,0 +1,105 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func TestProjectLauncherOutsideProject(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+	home := t.TempDir()
+	app := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(app, "App.xcodeproj"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	msg := loadContextCmd(home, "", ConfigOverrides{})().(contextLoadedMsg)
+	if !msg.noProject || msg.err != nil {
+		t.Fatalf("msg = %+v", msg)
+	}
+
+	m := NewModel(home, "", ConfigOverrides{})
+	m.width, m.height = 120, 40
+	m.state.AddRecentProject(core.RecentProject{Root: app, Scheme: "App", UpdatedAt: "2026-10-01T09:30:00Z"})
+	m.state.AddRecentProject(core.RecentProject{Root: filepath.Join(home, "Gone")})
+	next, _ := m.Update(msg)
+	m = next.(Model)
+	if m.mode != ModeSelector || m.selectorType != SelectorRecentProject {
+		t.Fatalf("launcher not opened: mode %v, selector %v", m.mode, m.selectorType)
+	}
+
+	items := RecentProjectItems(m.state.Recent)
+	if len(items) != 3 || !items[0].Dim || items[0].Note != "missing" || items[1].ID != app || items[1].Meta != "App" || items[1].Note == "" || items[2].ID != enterProjectPathID {
+		t.Fatalf("items = %+v", items)
+	}
+
+	if cmd := m.selectRecentProject(&items[1]); cmd == nil {
+		t.Fatal("no context reload after switching")
+	}
+	if m.projectRoot != app || m.configPath != core.ConfigPath(app) || m.width != 120 {
+		t.Fatalf("root %q, config %q, width %d", m.projectRoot, m.configPath, m.width)
+	}
+	if wd, _ := os.Getwd(); wd != app {
+		t.Fatalf("working directory %q", wd)
+	}
+
+	// A successful load moves the project to the front of the recents
+	next, _ = m.Update(contextLoadedMsg{info: core.ContextInfo{ProjectRoot: app}, cfg: core.Config{Project: "App.xcodeproj", Scheme: "App"}})
+	m = next.(Model)
+	st, err := core.LoadState()
+	if err != nil || len(st.Recent) == 0 || st.Recent[0].Root != app || st.Recent[0].Scheme != "App" {
+		t.Fatalf("recent = %+v, %v", st.Recent, err)
+	}
+}
+
+func TestProjectLauncherPathInput(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.openProjectLauncher()
+	if m.mode != ModeProjectPath {
+		t.Fatalf("without recents the path input opens, mode %v", m.mode)
+	}
+	if cmd := m.switchProject("does-not-exist"); cmd != nil || m.lastErr == "" {
+		t.Fatalf("missing directory opened, err %q", m.lastErr)
+	}
+}
+
+func TestCompleteDir(t *testing.T) {
+	base := t.TempDir()
+	for _, dir := range []string{"Alpha", "Apple", "Beta/Inner", ".hidden"} {
+		if err := os.MkdirAll(filepath.Join(base, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(base, "Afile"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	sep := string(filepath.Separator)
+
+	cases := []struct {
+		value   string
+		want    string
+		matches []string
+	}{
+		{"A", "A", []string{"Alpha", "Apple"}},
+		{"Al", "Alpha" + sep, nil},
+		{"B", "Beta" + sep, nil},
+		{"Beta" + sep, "Beta" + sep + "Inner" + sep, nil},
+		{"", "", []string{"Alpha", "Apple", "Beta"}},
+		{".", ".hidden" + sep, nil},
+		{"Z", "Z", nil},
+		{base + sep + "Ap", base + sep + "Apple" + sep, nil},
+	}
+	for _, c := range cases {
+		got, matches := completeDir(c.value, base)
+		if got != c.want || !reflect.DeepEqual(matches, c.matches) {
+			t.Errorf("completeDir(%q) = %q %v, want %q %v", c.value, got, matches, c.want, c.matches)
+		}
+	}
+}
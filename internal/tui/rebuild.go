@@ -0,0 +1,102 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// =============================================================================
+// Rebuild Tracking
+// =============================================================================
+
+// whyRebuildLimit is how many recent builds "Why Rebuild?" lists
+const whyRebuildLimit = 8
+
+// recordBuildCompiles adds a finished build to the build history and flags
+// an unexpected full rebuild in the logs and the success card
+func (m *Model) recordBuildCompiles(res core.BuildResult) {
+	check, rebuilt := m.state.RecordBuild(m.projectRoot, core.NewBuildRecord(m.cfg, res.CompiledFiles, time.Now()))
+	m.saveStateAsync()
+	if !rebuilt {
+		return
+	}
+	ev := core.Warn("build", check.Message())
+	m.handleEvent(ev)
+	m.status.Relay(ev)
+	m.tabView.SummaryTab.SetRebuildNote(fmt.Sprintf("Full rebuild? %d files compiled (%+d vs previous build) — see Why Rebuild?", check.Compiled, check.Delta))
+}
+
+// recordClean notes a DerivedData clean so the next full build is expected
+func (m *Model) recordClean() {
+	m.state.RecordClean(m.projectRoot, time.Now())
+	m.saveStateAsync()
+}
+
+// cleansDerivedData reports whether a clean op removes DerivedData
+func cleansDerivedData(op string) bool {
+	return op == "clean" || op == "clean-derived"
+}
+
+// openWhyRebuild lists the last builds, newest first, with what changed
+// since the build before each
+func (m *Model) openWhyRebuild() {
+	items := WhyRebuildItems(m.state.BuildRecords(m.projectRoot), whyRebuildLimit)
+	if len(items) == 0 {
+		m.setStatus("No builds recorded yet")
+		return
+	}
+	m.selector = NewSelector("Recent builds (compiled files)", items, m.width, m.styles)
+	m.selectorType = SelectorBuildHistory
+	m.mode = ModeSelector
+}
+
+// WhyRebuildItems creates selector items for the newest limit builds. Each
+// description names what changed since the previous build.
+func WhyRebuildItems(records []core.BuildRecord, limit int) []SelectorItem {
+	var items []SelectorItem
+	for i := len(records) - 1; i >= 0 && len(items) < limit; i-- {
+		r := records[i]
+		var changes []string
+		if r.Cleaned {
+			changes = append(changes, "cleaned before")
+		}
+		if i > 0 {
+			prev := records[i-1]
+			if prev.Scheme != r.Scheme {
+				changes = append(changes, "scheme changed from "+prev.Scheme)
+			}
+			if prev.Configuration != r.Configuration {
+				changes = append(changes, "configuration changed from "+prev.Configuration)
+			}
+			if prev.DestinationID != r.DestinationID {
+				changes = append(changes, "destination changed from "+orDash(prev.Destination))
+			}
+		} else {
+			changes = append(changes, "first recorded build")
+		}
+		if len(changes) == 0 {
+			changes = append(changes, "nothing changed")
+		}
+		meta := r.Timestamp
+		if t, err := time.Parse(time.RFC3339, r.Timestamp); err == nil {
+			meta = t.Local().Format("Jan 2 15:04")
+		}
+		items = append(items, SelectorItem{
+			ID:          fmt.Sprintf("%d", i),
+			Title:       fmt.Sprintf("%d files · %s · %s", r.CompiledFiles, orDash(r.Configuration), orDash(r.Destination)),
+			Description: strings.Join(changes, " · "),
+			Meta:        meta,
+		})
+	}
+	return items
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "—"
+	}
+	return s
+}
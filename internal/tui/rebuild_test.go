@@ -0,0 +1,48 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func TestWhyRebuildItemsDescribeChanges(t *testing.T) {
+	records := []core.BuildRecord{
+		{Scheme: "App", Configuration: "Debug", Destination: "iPhone 15", DestinationID: "SIM-1", CompiledFiles: 400},
+		{Scheme: "App", Configuration: "Debug", Destination: "iPhone 15", DestinationID: "SIM-1", CompiledFiles: 2},
+		{Scheme: "App", Configuration: "Release", Destination: "iPad", DestinationID: "SIM-2", CompiledFiles: 410},
+		{Scheme: "App", Configuration: "Release", Destination: "iPad", DestinationID: "SIM-2", CompiledFiles: 405, Cleaned: true},
+	}
+	items := WhyRebuildItems(records, 3)
+	if len(items) != 3 {
+		t.Fatalf("expected the 3 newest builds, got %d", len(items))
+	}
+	if !strings.HasPrefix(items[0].Title, "405 files · Release · iPad") || items[0].Description != "cleaned before" {
+		t.Fatalf("unexpected newest item %+v", items[0])
+	}
+	if !strings.Contains(items[1].Description, "configuration changed from Debug") || !strings.Contains(items[1].Description, "destination changed from iPhone 15") {
+		t.Fatalf("expected configuration and destination changes, got %q", items[1].Description)
+	}
+	if items[2].Description != "nothing changed" {
+		t.Fatalf("unexpected description %q", items[2].Description)
+	}
+}
+
+func TestRecordBuildCompilesSetsRebuildNote(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	// State saves run in the background; keep them from writing at all
+	t.Setenv("XDG_CONFIG_HOME", "/dev/null/xcbolt-test")
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.cfg.Scheme, m.cfg.Configuration = "App", "Debug"
+
+	m.recordBuildCompiles(core.BuildResult{CompiledFiles: 300})
+	m.recordBuildCompiles(core.BuildResult{CompiledFiles: 4})
+	if m.tabView.SummaryTab.RebuildNote != "" {
+		t.Fatalf("incremental builds need no note")
+	}
+	m.recordBuildCompiles(core.BuildResult{CompiledFiles: 290})
+	if note := m.tabView.SummaryTab.RebuildNote; !strings.Contains(note, "290 files compiled (+286") {
+		t.Fatalf("unexpected note %q", note)
+	}
+}
@@ -0,0 +1,158 @@
+package tui
+
+import (
+	"context"
+	"os"
+	"os/exec"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// =============================================================================
+// Repro Bundle Export
+// =============================================================================
+
+// reproLogLines is how many log lines around the first error a repro bundle
+// keeps
+const reproLogLines = 500
+
+// reproProgressMsg is a step of a running export; ch delivers the next one
+type reproProgressMsg struct {
+	text string
+	ch   <-chan tea.Msg
+}
+
+// reproDoneMsg ends an export
+type reproDoneMsg struct {
+	res core.ReproBundleResult
+	err error
+}
+
+func waitForRepro(ch <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return <-ch
+	}
+}
+
+// reproLogWindow returns the lines [start, end) of a total-line log to keep:
+// size lines centered on firstError, or the last size lines without one
+func reproLogWindow(total, firstError, size int) (int, int) {
+	if total <= size {
+		return 0, total
+	}
+	if firstError < 0 {
+		return total - size, total
+	}
+	start := min(max(0, firstError-size/2), total-size)
+	return start, start + size
+}
+
+// reproBundle gathers the last operation's log, issues, command, and
+// environment for core.WriteReproBundle
+func (m *Model) reproBundle() (core.ReproBundle, bool) {
+	st := m.tabView.VisibleStreamTab()
+	if len(st.Lines) == 0 {
+		return core.ReproBundle{}, false
+	}
+	firstError := -1
+	for i, line := range st.Lines {
+		if line.Type == TabLineTypeError {
+			firstError = i
+			break
+		}
+	}
+	start, end := reproLogWindow(len(st.Lines), firstError, reproLogLines)
+	b := core.ReproBundle{
+		Op:           "build",
+		Outcome:      "failed",
+		LogStart:     start,
+		LogTotal:     len(st.Lines),
+		Command:      m.lastCommand,
+		Config:       m.cfg,
+		Xcode:        m.info.Xcode,
+		ResultBundle: m.cfg.LastResultBundle,
+	}
+	for _, line := range st.Lines[start:end] {
+		b.Log = append(b.Log, line.Raw)
+	}
+	if m.lastOp != nil && m.lastOp.Name != "" {
+		b.Op = m.lastOp.Name
+	}
+	switch m.tabView.SummaryTab.Status {
+	case BuildStatusSuccess:
+		b.Outcome = "succeeded"
+	case BuildStatusCanceled:
+		b.Outcome = "canceled"
+	}
+
+	issues := m.tabView.VisibleIssuesTab().AllIssues()
+	for _, issue := range issues {
+		switch {
+		case issue.TestList != "":
+		case issue.Type == IssueTypeError:
+			b.Errors = append(b.Errors, issueGrepLine(m.projectRoot, issue))
+		case issue.Type == IssueTypeWarning:
+			b.Warnings++
+		}
+	}
+	if text, err := issuesJSON(issues); err == nil && len(issues) > 0 {
+		b.IssuesJSON = text
+	}
+	if b.ResultBundle != "" {
+		if meta, err := core.ReadResultMeta(core.ResultMetaPath(b.ResultBundle)); err == nil {
+			b.Meta = &meta
+		}
+		if _, err := os.Stat(b.ResultBundle); err != nil {
+			b.ResultBundle = ""
+		}
+	}
+	return b, true
+}
+
+// exportRepro writes a repro bundle of the last operation in the
+// background, reporting each step in the status line
+func (m *Model) exportRepro() tea.Cmd {
+	if m.running {
+		m.setStatus("Wait for the operation to finish before exporting")
+		return nil
+	}
+	b, ok := m.reproBundle()
+	if !ok {
+		m.setStatus("Nothing to export yet: run a build or test first")
+		return nil
+	}
+	root, redactor := m.projectRoot, m.redactor
+	ch := make(chan tea.Msg, 1)
+	go func() {
+		res, err := core.WriteReproBundle(context.Background(), root, b, redactor, func(step string) {
+			ch <- reproProgressMsg{text: step, ch: ch}
+		})
+		ch <- reproDoneMsg{res: res, err: err}
+	}()
+	m.setStatus("Exporting repro bundle…")
+	return waitForRepro(ch)
+}
+
+// handleReproDone reveals the bundle in Finder and copies its path
+func (m *Model) handleReproDone(msg reproDoneMsg) tea.Cmd {
+	if msg.err != nil {
+		m.lastErr = msg.err.Error()
+		m.setStatus("Repro export failed: " + msg.err.Error())
+		return nil
+	}
+	done := "Exported repro bundle to " + relativeToRoot(m.projectRoot, msg.res.Dir) + " (path copied)"
+	if msg.res.ResultBundleNote != "" {
+		done += " · result bundle not included, see README.md"
+	}
+	m.setStatus(done)
+	dir := msg.res.Dir
+	reveal := func() tea.Msg {
+		if err := exec.Command("open", "-R", dir).Start(); err != nil {
+			return statusMsg("Failed to reveal " + dir + ": " + err.Error())
+		}
+		return nil
+	}
+	return tea.Batch(reveal, m.copyToClipboard(dir, done))
+}
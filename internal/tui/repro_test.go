@@ -0,0 +1,70 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func TestReproLogWindow(t *testing.T) {
+	tests := []struct {
+		total, firstError, start, end int
+	}{
+		{100, 40, 0, 100},
+		{2000, 1000, 750, 1250},
+		{2000, 100, 0, 500},
+		{2000, 1900, 1500, 2000},
+		{2000, -1, 1500, 2000},
+	}
+	for _, tc := range tests {
+		start, end := reproLogWindow(tc.total, tc.firstError, reproLogLines)
+		if start != tc.start || end != tc.end {
+			t.Fatalf("reproLogWindow(%d, %d) = %d, %d, want %d, %d", tc.total, tc.firstError, start, end, tc.start, tc.end)
+		}
+	}
+}
+
+func TestExportReproWritesBundle(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	if cmd := m.exportRepro(); cmd != nil || !strings.Contains(m.statusMsg, "Nothing to export") {
+		t.Fatalf("exported an empty log: %q", m.statusMsg)
+	}
+
+	m.lastOp = &core.LastOp{Name: "build"}
+	m.lastCommand = "xcodebuild -scheme App build"
+	m.tabView.StreamTab.AddLine("CompileSwift App.swift", TabLineTypeNormal)
+	m.tabView.StreamTab.AddLine("/src/App.swift:3:5: error: cannot find 'x' in scope", TabLineTypeError)
+	m.tabView.IssuesTab.AddIssue(IssueTypeError, "/src/App.swift:3:5: error: cannot find 'x' in scope")
+	m.tabView.SummaryTab.SetResult(BuildStatusFailed, "3s", nil, 1, 0)
+
+	msg := m.exportRepro()()
+	for {
+		p, ok := msg.(reproProgressMsg)
+		if !ok {
+			break
+		}
+		msg = waitForRepro(p.ch)()
+	}
+	done, ok := msg.(reproDoneMsg)
+	if !ok || done.err != nil {
+		t.Fatalf("export ended with %#v", msg)
+	}
+	if filepath.Dir(done.res.Dir) != filepath.Join(m.projectRoot, ".xcbolt", "repro") {
+		t.Fatalf("Dir = %s", done.res.Dir)
+	}
+	readme, err := os.ReadFile(filepath.Join(done.res.Dir, "README.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(readme), "# xcbolt build failed") || !strings.Contains(string(readme), "/src/App.swift:3:5: error: cannot find 'x' in scope") {
+		t.Fatalf("README:\n%s", readme)
+	}
+	m.handleReproDone(done)
+	if !strings.HasPrefix(m.statusMsg, "Exported repro bundle to .xcbolt/repro/") {
+		t.Fatalf("status = %q", m.statusMsg)
+	}
+}
@@ -0,0 +1,168 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// =============================================================================
+// Result Info
+// =============================================================================
+
+// ResultInfoOverlay shows the environment recorded for a result bundle
+type ResultInfoOverlay struct {
+	Path string
+	Meta core.ResultMeta
+}
+
+// Rows returns the metadata as label/value pairs
+func (o ResultInfoOverlay) Rows() [][2]string {
+	meta := o.Meta
+	outcome := "failed"
+	if meta.Success {
+		outcome = "succeeded"
+	}
+	rows := [][2]string{
+		{"Operation", meta.Op + " " + outcome},
+		{"Result bundle", meta.ResultBundle},
+		{"Started", formatMetaTime(meta.StartedAt)},
+		{"Duration", fmt.Sprintf("%s (xcodebuild %s)", metaDuration(meta.ElapsedMs), metaDuration(meta.DurationMs))},
+		{"Xcode", orDash(meta.Xcode.Label())},
+		{"macOS", orDash(meta.MacOS)},
+	}
+	if meta.Git != nil {
+		git := shortCommit(meta.Git.Commit)
+		if meta.Git.Branch != "" {
+			git = meta.Git.Branch + " @ " + git
+		}
+		if meta.Git.Dirty {
+			git += " (dirty)"
+		}
+		rows = append(rows, [2]string{"Git", git})
+	} else {
+		rows = append(rows, [2]string{"Git", "—"})
+	}
+	dest := meta.Destination.Name
+	if meta.Destination.OS != "" {
+		dest += " (" + meta.Destination.OS + ")"
+	}
+	rows = append(rows,
+		[2]string{"Scheme", orDash(meta.Scheme)},
+		[2]string{"Configuration", orDash(meta.Configuration)},
+		[2]string{"Destination", orDash(strings.TrimSpace(dest))},
+		[2]string{"Config hash", orDash(meta.ConfigHash)},
+	)
+	return rows
+}
+
+// View renders the overlay content (without centering)
+func (o ResultInfoOverlay) View(width int, s Styles) string {
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(s.Colors.Text)
+	b.WriteString(titleStyle.Render("Result info"))
+	b.WriteString("\n")
+
+	dividerStyle := lipgloss.NewStyle().Foreground(s.Colors.BorderMuted)
+	b.WriteString(dividerStyle.Render(strings.Repeat("─", width-6)))
+	b.WriteString("\n")
+
+	labelStyle := lipgloss.NewStyle().Foreground(s.Colors.TextMuted)
+	valueStyle := lipgloss.NewStyle().Foreground(s.Colors.Text)
+	for _, row := range o.Rows() {
+		label := fmt.Sprintf("%-14s", row[0])
+		b.WriteString(labelStyle.Render(label))
+		b.WriteString(valueStyle.Render(truncateString(row[1], width-6-len(label))))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	pathStyle := lipgloss.NewStyle().Foreground(s.Colors.TextSubtle)
+	b.WriteString(pathStyle.Render(truncateString(o.Path, width-6)))
+	b.WriteString("\n\n")
+
+	hintKeyStyle := lipgloss.NewStyle().Foreground(s.Colors.Accent)
+	hintDescStyle := lipgloss.NewStyle().Foreground(s.Colors.TextSubtle)
+	b.WriteString(hintKeyStyle.Render("esc") + hintDescStyle.Render(" close"))
+
+	containerStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(s.Colors.Border).
+		Padding(1, 2)
+
+	return containerStyle.Width(width).Render(b.String())
+}
+
+// formatMetaTime shows an RFC 3339 time in local time
+func formatMetaTime(ts string) string {
+	t, err := time.Parse(time.RFC3339, ts)
+	if err != nil {
+		return orDash(ts)
+	}
+	return t.Local().Format("Jan 2 15:04:05")
+}
+
+func metaDuration(ms int64) string {
+	return (time.Duration(ms) * time.Millisecond).Round(100 * time.Millisecond).String()
+}
+
+func shortCommit(commit string) string {
+	if len(commit) > 12 {
+		return commit[:12]
+	}
+	return commit
+}
+
+// openResultInfo shows the metadata of the last result bundle, or of the
+// newest one on disk after a restart
+func (m *Model) openResultInfo() {
+	path := ""
+	if m.cfg.LastResultBundle != "" {
+		path = core.ResultMetaPath(m.cfg.LastResultBundle)
+		if _, err := os.Stat(path); err != nil {
+			path = ""
+		}
+	}
+	if path == "" {
+		latest, err := core.LatestResultMeta(m.cfg.ResultBundlesPath)
+		if err != nil {
+			m.setStatus("No result metadata yet: run a build or test")
+			return
+		}
+		path = latest
+	}
+	meta, err := core.ReadResultMeta(path)
+	if err != nil {
+		m.lastErr = err.Error()
+		m.setStatus("Could not read result metadata")
+		return
+	}
+	m.resultInfo = ResultInfoOverlay{Path: path, Meta: meta}
+	m.mode = ModeResultInfo
+}
+
+func (m *Model) handleResultInfoKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc", "q", "enter":
+		m.resultInfo = ResultInfoOverlay{}
+		m.mode = ModeNormal
+	}
+	return nil
+}
+
+func (m Model) resultInfoOverlayView() string {
+	width := m.width * 60 / 100
+	if width < 60 {
+		width = 60
+	}
+	if width > 100 {
+		width = 100
+	}
+	return RenderCenteredPopup(m.resultInfo.View(width, m.styles), m.width, m.height)
+}
@@ -0,0 +1,63 @@
+package tui
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func TestResultInfoOverlay(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.mode = ModeNormal
+	m = updateModel(m, tea.WindowSizeMsg{Width: 120, Height: 40})
+	m.cfg.ResultBundlesPath = t.TempDir()
+
+	m.openResultInfo()
+	if m.mode != ModeNormal || !strings.Contains(m.statusMsg, "No result metadata") {
+		t.Fatalf("mode = %v status = %q without metadata", m.mode, m.statusMsg)
+	}
+
+	bundle := filepath.Join(m.cfg.ResultBundlesPath, "20240102-030405.xcresult")
+	meta := core.ResultMeta{
+		Op:            "test",
+		ResultBundle:  bundle,
+		StartedAt:     "2024-01-02T03:04:05Z",
+		DurationMs:    61000,
+		ElapsedMs:     65000,
+		Xcode:         core.XcodeVersion{Version: "15.4", Build: "15F31d"},
+		MacOS:         "14.5 (23F79)",
+		Git:           &core.GitState{Commit: "0123456789abcdef0123", Branch: "main", Dirty: true},
+		Scheme:        "App",
+		Configuration: "Debug",
+		Destination:   core.Destination{Name: "iPhone 15", OS: "17.5"},
+		ConfigHash:    "abc123",
+	}
+	data, _ := json.Marshal(meta)
+	if err := os.WriteFile(core.ResultMetaPath(bundle), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// After a restart there is no last bundle; the newest file is used.
+	m.openResultInfo()
+	if m.mode != ModeResultInfo {
+		t.Fatalf("overlay not opened: %q", m.statusMsg)
+	}
+	view := stripANSI(m.View())
+	for _, want := range []string{"test failed", "Xcode 15.4 (15F31d)", "14.5 (23F79)", "main @ 0123456789ab (dirty)", "iPhone 15 (17.5)", "1m5s (xcodebuild 1m1s)", "abc123"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("overlay missing %q:\n%s", want, view)
+		}
+	}
+
+	m = updateModel(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.mode != ModeNormal {
+		t.Fatalf("esc did not close the overlay")
+	}
+}
@@ -2,16 +2,32 @@ package tui
 
 import (
 	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/xcbolt/xcbolt/internal/core"
 )
 
 func Run(projectRoot string, configPath string, overrides ConfigOverrides) error {
 	m := NewModel(projectRoot, configPath, overrides)
+	m.statusFile = core.NewStatusFileWriter(projectRoot)
+	// Switching projects replaces the writer; close the final model's
+	defer func() { m.statusFile.Close() }()
+	if overrides.StatusPort > 0 {
+		srv, err := startStatusServer(overrides.StatusBind, overrides.StatusPort)
+		if err != nil {
+			return err
+		}
+		defer srv.Close()
+		m.status = srv
+	}
 	p := tea.NewProgram(
 		m,
 		tea.WithAltScreen(),
 		tea.WithReportFocus(),      // required for huh focus support in larger programs
 		tea.WithMouseCellMotion(),   // mouse wheel scroll (Shift+drag to select text)
 	)
-	_, err := p.Run()
+	final, err := p.Run()
+	if fm, ok := final.(Model); ok {
+		m = fm
+	}
 	return err
 }
@@ -0,0 +1,64 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// runSplitStep is how far + / - move the run mode split, in percent
+const runSplitStep = 5
+
+// resizeRunSplit grows (delta > 0) or shrinks the focused run mode pane by
+// delta percent and saves the new ratio to tui.runSplitRatio
+func (m *Model) resizeRunSplit(delta int) {
+	if m.runMode.FocusPane == PaneConsole {
+		delta = -delta
+	}
+	ratio := m.cfg.TUI.SplitRatio() + delta
+	ratio = maxInt(core.MinRunSplitRatio, minInt(core.MaxRunSplitRatio, ratio))
+	m.setRunSplitRatio(ratio)
+}
+
+// swapRunSplit gives the other pane the larger share
+func (m *Model) swapRunSplit() {
+	m.setRunSplitRatio(100 - m.cfg.TUI.SplitRatio())
+}
+
+func (m *Model) setRunSplitRatio(ratio int) {
+	m.cfg.TUI.RunSplitRatio = ratio
+	m.saveRunSplit()
+	m.setStatus(fmt.Sprintf("Split: build %d%% / console %d%%", ratio, 100-ratio))
+}
+
+// toggleRunSplitVertical switches the run mode split between stacked and
+// side-by-side panes. Side by side only applies to terminals at least 160
+// columns wide; narrower ones keep stacking.
+func (m *Model) toggleRunSplitVertical() {
+	m.cfg.TUI.RunSplitVertical = !m.cfg.TUI.RunSplitVertical
+	m.saveRunSplit()
+	switch {
+	case !m.cfg.TUI.RunSplitVertical:
+		m.setStatus("Run split: stacked")
+	case m.layout.SideBySide():
+		m.setStatus("Run split: side by side")
+	default:
+		m.setStatus(fmt.Sprintf("Run split: side by side (needs %d+ columns, terminal is %d)", sideBySideMinWidth, m.width))
+	}
+}
+
+func (m *Model) saveRunSplit() {
+	m.syncRunSplit()
+	if err := core.SaveConfig(m.projectRoot, m.configPath, m.cfg); err != nil {
+		m.lastErr = err.Error()
+	}
+}
+
+// syncRunSplit copies the split settings from the config to the layout and
+// resizes the panes
+func (m *Model) syncRunSplit() {
+	m.layout.SplitRatio = m.cfg.TUI.SplitRatio()
+	m.layout.SplitVertical = m.cfg.TUI.RunSplitVertical
+	m.runMode.TopHeight = 0
+	m.runMode.BottomHeight = 0
+}
@@ -0,0 +1,154 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// newSplitModel returns a model in run mode on a width x 40 terminal
+func newSplitModel(t *testing.T, width int) Model {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.mode = ModeNormal
+	m.runMode.Active = true
+	m = updateModel(m, tea.WindowSizeMsg{Width: width, Height: 40})
+	return m
+}
+
+func TestSplitResizeKeys(t *testing.T) {
+	m := newSplitModel(t, 120)
+	press := func(k string) {
+		m = updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+	}
+
+	press("+")
+	if got := m.cfg.TUI.SplitRatio(); got != 65 {
+		t.Fatalf("ratio after + = %d, want 65", got)
+	}
+	if m.statusMsg != "Split: build 65% / console 35%" {
+		t.Fatalf("status = %q", m.statusMsg)
+	}
+
+	// With the console focused, + grows the console
+	m.runMode.FocusPane = PaneConsole
+	press("+")
+	press("=")
+	if got := m.cfg.TUI.SplitRatio(); got != 55 {
+		t.Fatalf("ratio after growing the console = %d, want 55", got)
+	}
+
+	m.runMode.FocusPane = PaneBuild
+	for i := 0; i < 10; i++ {
+		press("-")
+	}
+	if got := m.cfg.TUI.SplitRatio(); got != core.MinRunSplitRatio {
+		t.Fatalf("ratio = %d, want clamped to %d", got, core.MinRunSplitRatio)
+	}
+
+	press("\\")
+	if got := m.cfg.TUI.SplitRatio(); got != 80 {
+		t.Fatalf("ratio after swap = %d, want 80", got)
+	}
+	if m.layout.SplitTopHeight() <= m.layout.SplitBottomHeight() {
+		t.Fatalf("build pane %d rows should be larger than console %d", m.layout.SplitTopHeight(), m.layout.SplitBottomHeight())
+	}
+
+	cfg, err := core.LoadConfig(m.projectRoot, m.configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if cfg.TUI.RunSplitRatio != 80 {
+		t.Fatalf("saved ratio = %d, want 80", cfg.TUI.RunSplitRatio)
+	}
+}
+
+func TestSplitResizeNeedsRunMode(t *testing.T) {
+	m := newSplitModel(t, 120)
+	m.runMode.Active = false
+	m = updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("+")})
+	if m.cfg.TUI.RunSplitRatio != 0 {
+		t.Fatalf("ratio changed outside run mode: %d", m.cfg.TUI.RunSplitRatio)
+	}
+}
+
+func TestSplitPanes(t *testing.T) {
+	l := NewLayout()
+	l.SetSize(200, 40)
+	if bw, bh, cw, ch := l.SplitPanes(30); bw != 200 || cw != 200 || bh != 18 || ch != 12 {
+		t.Fatalf("stacked panes = %dx%d / %dx%d", bw, bh, cw, ch)
+	}
+
+	l.SplitVertical = true
+	if bw, bh, cw, ch := l.SplitPanes(30); bw != 119 || cw != 80 || bh != 30 || ch != 30 {
+		t.Fatalf("side-by-side panes = %dx%d / %dx%d", bw, bh, cw, ch)
+	}
+
+	// Too narrow for side by side
+	l.SetSize(150, 40)
+	if l.SideBySide() {
+		t.Fatalf("150 columns should stack")
+	}
+}
+
+func TestSideBySideSplitView(t *testing.T) {
+	m := newSplitModel(t, 200)
+	m.cfg.TUI.RunSplitVertical = true
+	m.syncRunSplit()
+	m.appendConsoleLog("console says hi", "")
+	m.appendConsoleLog(strings.Repeat("x", 150), "")
+
+	view := m.View()
+	lines := strings.Split(stripANSI(view), "\n")
+	if len(lines) != 40 {
+		t.Fatalf("view has %d lines, want 40", len(lines))
+	}
+	buildWidth, _, consoleWidth, _ := m.layout.SplitPanes(0)
+	found := false
+	for _, line := range lines {
+		if w := lipgloss.Width(line); w > 200 {
+			t.Fatalf("line is %d columns wide: %q", w, line)
+		}
+		if i := strings.Index(line, "console says hi"); i >= 0 {
+			found = true
+			if col := lipgloss.Width(line[:i]); col <= buildWidth {
+				t.Fatalf("console line at column %d, want right of %d", col, buildWidth)
+			}
+		}
+	}
+	if !found {
+		t.Fatalf("console line missing:\n%s", stripANSI(view))
+	}
+
+	// Long console lines wrap at the console pane width
+	for _, line := range m.runMode.Console.Lines {
+		if w := lipgloss.Width(line); w > consoleWidth-2 {
+			t.Fatalf("console line is %d columns, pane is %d", w, consoleWidth)
+		}
+	}
+
+	// Clicks right of the divider focus the console
+	if !m.mouseInConsolePane(buildWidth+5, 10) || m.mouseInConsolePane(buildWidth-5, 10) {
+		t.Fatalf("mouseInConsolePane does not follow the vertical divider")
+	}
+}
+
+func TestToggleRunSplitNarrowTerminal(t *testing.T) {
+	m := newSplitModel(t, 120)
+	m.toggleRunSplitVertical()
+	if !m.cfg.TUI.RunSplitVertical || m.layout.SideBySide() {
+		t.Fatalf("vertical = %v sideBySide = %v", m.cfg.TUI.RunSplitVertical, m.layout.SideBySide())
+	}
+	if !strings.Contains(m.statusMsg, "needs 160+ columns") {
+		t.Fatalf("status = %q", m.statusMsg)
+	}
+	m.toggleRunSplitVertical()
+	if m.cfg.TUI.RunSplitVertical || m.statusMsg != "Run split: stacked" {
+		t.Fatalf("vertical = %v status = %q", m.cfg.TUI.RunSplitVertical, m.statusMsg)
+	}
+}
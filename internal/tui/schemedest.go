@@ -0,0 +1,224 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// schemeSupportTimeout bounds the build settings lookup behind
+// checkSchemeSupport
+const schemeSupportTimeout = 30 * time.Second
+
+// lastUsedNote marks the destination last used with the current scheme in
+// the destination selector
+const lastUsedNote = "(last used with this scheme)"
+
+// schemeSupportMsg carries the platforms a scheme supports, for restoring
+// its destination when the current one doesn't fit
+type schemeSupportMsg struct {
+	scheme   string
+	families []core.PlatformFamily
+	err      error
+}
+
+// selectScheme switches to the scheme picked in the selector and, when it
+// changed, to the destination last used with it
+func (m *Model) selectScheme(item *SelectorItem) tea.Cmd {
+	before := m.cfg
+	changed := item.ID != m.cfg.Scheme
+	m.cfg.Scheme = item.ID
+	m.info.TestPlans = nil // Listed per scheme
+	m.setStatus("Scheme: " + item.Title)
+	var cmd tea.Cmd
+	if changed {
+		if m.cfg.TUI.AlwaysRestoreSchemeDestination() {
+			m.restoreSchemeDestination(nil)
+		} else {
+			cmd = m.checkSchemeSupport()
+		}
+	}
+	if err := core.SaveConfig(m.projectRoot, m.configPath, m.cfg); err != nil {
+		m.lastErr = err.Error()
+	}
+	return tea.Batch(cmd, m.noteConfigChanges(before))
+}
+
+// checkSchemeSupport reads the platforms the current scheme supports in the
+// background
+func (m *Model) checkSchemeSupport() tea.Cmd {
+	root, cfg := m.projectRoot, m.cfg
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), schemeSupportTimeout)
+		defer cancel()
+		families, err := core.CachedSchemePlatformFamilies(ctx, root, cfg)
+		return schemeSupportMsg{scheme: cfg.Scheme, families: families, err: err}
+	}
+}
+
+// handleSchemeSupport restores the scheme's destination when the current
+// one is on a platform the scheme doesn't support
+func (m *Model) handleSchemeSupport(msg schemeSupportMsg) tea.Cmd {
+	if msg.scheme != m.cfg.Scheme || msg.err != nil || len(msg.families) == 0 {
+		return nil
+	}
+	if core.FamilySupported(msg.families, m.cfg.Destination.PlatformFamily) {
+		return nil
+	}
+	before := m.cfg
+	m.restoreSchemeDestination(msg.families)
+	if err := core.SaveConfig(m.projectRoot, m.configPath, m.cfg); err != nil {
+		m.lastErr = err.Error()
+	}
+	return m.noteConfigChanges(before)
+}
+
+// compatibleDestinations narrows the destination selector's simulators,
+// devices, and items to those on one of families
+func compatibleDestinations(families []core.PlatformFamily, sims []SimulatorInfo, devices []DeviceInfo) ([]SimulatorInfo, []DeviceInfo, []SelectorItem) {
+	var keptSims []SimulatorInfo
+	for _, sim := range sims {
+		if core.FamilySupported(families, core.PlatformFamily(sim.PlatformFamily)) {
+			keptSims = append(keptSims, sim)
+		}
+	}
+	var keptDevices []DeviceInfo
+	for _, dev := range devices {
+		if core.FamilySupported(families, core.PlatformFamily(dev.PlatformFamily)) {
+			keptDevices = append(keptDevices, dev)
+		}
+	}
+	items := slices.DeleteFunc(DestinationNameItems(keptSims, keptDevices), func(item SelectorItem) bool {
+		switch item.ID {
+		case "macos":
+			return !core.FamilySupported(families, core.PlatformMacOS)
+		case "catalyst":
+			return !core.FamilySupported(families, core.PlatformCatalyst)
+		}
+		return false
+	})
+	return keptSims, keptDevices, items
+}
+
+// openCompatibleDestinationSelector lets the user pick a destination the
+// scheme supports after a build was refused for an incompatible one
+func (m *Model) openCompatibleDestinationSelector(e *core.DestinationIncompatibleError) {
+	labels := make([]string, len(e.Supported))
+	for i, f := range e.Supported {
+		labels[i] = core.PlatformFamilyLabel(f)
+	}
+	m.openDestinationSelectorFor(fmt.Sprintf("Destinations for %s (%s)", e.Scheme, strings.Join(labels, ", ")), e.Supported)
+	if m.mode == ModeSelector {
+		m.setStatus(fmt.Sprintf("%s doesn't support %s · pick a compatible destination", e.Scheme, core.PlatformFamilyLabel(e.Destination.PlatformFamily)))
+	}
+}
+
+// restoreSchemeDestination switches to the destination last used with the
+// current scheme. When that one is gone, the destination is auto-picked at
+// build time on its platform. With nothing remembered, the current
+// destination stays unless families (the scheme's platforms, when it was
+// found not to support it) is given.
+func (m *Model) restoreSchemeDestination(families []core.PlatformFamily) {
+	scheme := m.cfg.Scheme
+	remembered, ok := m.state.SchemeDestination(m.projectRoot, scheme)
+	switch {
+	case ok && sameDestination(remembered, m.cfg.Destination):
+	case ok:
+		if dst, found := m.availableDestination(remembered); found {
+			m.cfg.Destination = dst
+			m.setStatus(fmt.Sprintf("Scheme: %s · Destination: %s %s", scheme, dst.Name, lastUsedNote))
+			return
+		}
+		m.cfg.Destination = core.AutoDestination(remembered.PlatformFamily)
+		m.setStatus(fmt.Sprintf("Scheme: %s · %s (last used) is unavailable, auto-picking a destination", scheme, remembered.Name))
+	case len(families) > 0:
+		current := m.cfg.Destination.Name
+		m.cfg.Destination = core.AutoDestination(families[0])
+		m.setStatus(fmt.Sprintf("Scheme: %s · %s doesn't support it, auto-picking a destination", scheme, current))
+	}
+}
+
+// availableDestination finds remembered among the discovered destinations
+func (m *Model) availableDestination(remembered core.Destination) (core.Destination, bool) {
+	switch remembered.Kind {
+	case core.DestMacOS, core.DestCatalyst:
+		return remembered, true
+	}
+	id := remembered.ID
+	if id == "" {
+		id = remembered.UDID
+	}
+	dst, ok := m.destinationForItem(&SelectorItem{ID: id})
+	if !ok {
+		return core.Destination{}, false
+	}
+	dst.CompanionTargetID = remembered.CompanionTargetID
+	dst.CompanionBundleID = remembered.CompanionBundleID
+	return dst, true
+}
+
+// sameDestination reports whether a and b are the same target
+func sameDestination(a, b core.Destination) bool {
+	if a.Kind != b.Kind {
+		return false
+	}
+	switch a.Kind {
+	case core.DestMacOS, core.DestCatalyst:
+		return true
+	}
+	return destinationID(a) != "" && destinationID(a) == destinationID(b)
+}
+
+func destinationID(d core.Destination) string {
+	if d.ID != "" {
+		return d.ID
+	}
+	return d.UDID
+}
+
+// rememberSchemeDestination records the current destination for the
+// current scheme; the caller saves the state
+func (m *Model) rememberSchemeDestination() {
+	m.state.SetSchemeDestination(m.projectRoot, m.cfg.Scheme, m.cfg.Destination)
+}
+
+// lastUsedDestinationID is the destination selector item ID of the
+// destination last used with the current scheme ("" if none)
+func (m *Model) lastUsedDestinationID() string {
+	remembered, ok := m.state.SchemeDestination(m.projectRoot, m.cfg.Scheme)
+	if !ok {
+		return ""
+	}
+	switch remembered.Kind {
+	case core.DestMacOS:
+		return "macos"
+	case core.DestCatalyst:
+		return "catalyst"
+	}
+	return destinationID(remembered)
+}
+
+// markLastUsed notes the item for the destination last used with the scheme.
+// A simulator in a name group marks the group.
+func markLastUsed(items []SelectorItem, id string, sims []SimulatorInfo) {
+	if id == "" {
+		return
+	}
+	ids := []string{id}
+	for _, sim := range sims {
+		if sim.UDID == id {
+			ids = append(ids, destinationNamePrefix+sim.Name)
+		}
+	}
+	for i := range items {
+		if slices.Contains(ids, items[i].ID) {
+			items[i].Note = lastUsedNote
+		}
+	}
+}
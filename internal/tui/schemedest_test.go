@@ -0,0 +1,120 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func schemeDestModel(t *testing.T) Model {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.info.Simulators = []core.Simulator{
+		{Name: "iPhone 15", UDID: "SIM-A", OSVersion: "17.5", PlatformFamily: core.PlatformIOS, Available: true},
+	}
+	m.cfg.Scheme = "App"
+	m.cfg.Destination = core.Destination{Kind: core.DestSimulator, TargetType: core.TargetSimulator, UDID: "SIM-A", ID: "SIM-A", Name: "iPhone 15", PlatformFamily: core.PlatformIOS}
+	return m
+}
+
+func TestSelectSchemeRestoresLastDestination(t *testing.T) {
+	m := schemeDestModel(t)
+	m.saveRecentCombo() // As an op starts: remembers iPhone 15 for App
+
+	m.selectScheme(&SelectorItem{ID: "Helper", Title: "Helper"})
+	if m.cfg.Destination.UDID != "SIM-A" {
+		t.Fatalf("scheme without a remembered destination changed it: %+v", m.cfg.Destination)
+	}
+	m.selectorType = SelectorDestination
+	m.handleSelectorResult(&SelectorItem{ID: "macos", Title: "My Mac"})
+
+	m.selectScheme(&SelectorItem{ID: "App", Title: "App"})
+	if m.cfg.Destination.UDID != "SIM-A" || !strings.Contains(m.statusMsg, lastUsedNote) {
+		t.Fatalf("App destination not restored: %+v (%q)", m.cfg.Destination, m.statusMsg)
+	}
+	m.selectScheme(&SelectorItem{ID: "Helper", Title: "Helper"})
+	if m.cfg.Destination.Kind != core.DestMacOS {
+		t.Fatalf("Helper destination not restored: %+v", m.cfg.Destination)
+	}
+
+	// The simulator is gone: auto-pick on its platform instead
+	m.info.Simulators = nil
+	m.selectScheme(&SelectorItem{ID: "App", Title: "App"})
+	if m.cfg.Destination.Kind != core.DestAuto || m.cfg.Destination.PlatformFamily != core.PlatformIOS {
+		t.Fatalf("unavailable destination: %+v", m.cfg.Destination)
+	}
+	if !strings.Contains(m.statusMsg, "unavailable") {
+		t.Fatalf("status = %q", m.statusMsg)
+	}
+}
+
+func TestSchemeSupportRestoresOnlyWhenUnsupported(t *testing.T) {
+	m := schemeDestModel(t)
+	off := false
+	m.cfg.TUI.PerSchemeDestinations = &off
+	m.state.SetSchemeDestination(m.projectRoot, "Helper", core.Destination{Kind: core.DestMacOS, PlatformFamily: core.PlatformMacOS, Name: "My Mac"})
+
+	if cmd := m.selectScheme(&SelectorItem{ID: "Helper", Title: "Helper"}); cmd == nil {
+		t.Fatal("expected a scheme support check")
+	}
+	if m.cfg.Destination.UDID != "SIM-A" {
+		t.Fatal("destination changed before the support check")
+	}
+	m.handleSchemeSupport(schemeSupportMsg{scheme: "Helper", families: []core.PlatformFamily{core.PlatformIOS, core.PlatformMacOS}})
+	if m.cfg.Destination.UDID != "SIM-A" {
+		t.Fatalf("supported destination replaced: %+v", m.cfg.Destination)
+	}
+	m.handleSchemeSupport(schemeSupportMsg{scheme: "Helper", families: []core.PlatformFamily{core.PlatformMacOS}})
+	if m.cfg.Destination.Kind != core.DestMacOS {
+		t.Fatalf("unsupported destination kept: %+v", m.cfg.Destination)
+	}
+
+	// Nothing remembered: fall back to auto-picking on the scheme's platform
+	m.cfg.Scheme = "Watch"
+	m.handleSchemeSupport(schemeSupportMsg{scheme: "Watch", families: []core.PlatformFamily{core.PlatformWatchOS}})
+	if m.cfg.Destination.Kind != core.DestAuto || m.cfg.Destination.PlatformFamily != core.PlatformWatchOS {
+		t.Fatalf("auto-pick fallback: %+v", m.cfg.Destination)
+	}
+}
+
+func TestMarkLastUsedMarksNameGroup(t *testing.T) {
+	sims := []SimulatorInfo{
+		{Name: "iPhone 15", UDID: "SIM-A", OSVersion: "17.5"},
+		{Name: "iPhone 15", UDID: "SIM-B", OSVersion: "18.0"},
+	}
+	items := DestinationNameItems(sims, nil)
+	markLastUsed(items, "SIM-A", sims)
+	var marked []string
+	for _, item := range items {
+		if item.Note != "" {
+			marked = append(marked, item.ID)
+		}
+	}
+	if len(marked) != 1 || marked[0] != destinationNamePrefix+"iPhone 15" {
+		t.Fatalf("marked = %v", marked)
+	}
+}
+
+func TestIncompatibleDestinationOpensCompatibleSelector(t *testing.T) {
+	m := schemeDestModel(t)
+	m.info.Simulators = append(m.info.Simulators, core.Simulator{Name: "Apple TV", UDID: "TV-A", OSVersion: "17.5", PlatformFamily: core.PlatformTvOS, Available: true})
+	m.cfg.Destination = core.Destination{Kind: core.DestSimulator, UDID: "TV-A", ID: "TV-A", Name: "Apple TV", PlatformFamily: core.PlatformTvOS}
+
+	err := &core.DestinationIncompatibleError{Scheme: "App", Destination: m.cfg.Destination, Supported: []core.PlatformFamily{core.PlatformIOS}}
+	m.handleOpDone(opDoneMsg{cmd: "build", err: err, build: &core.BuildResult{}})
+	if m.mode != ModeSelector || m.selectorType != SelectorDestination {
+		t.Fatalf("mode = %v, selector = %v", m.mode, m.selectorType)
+	}
+	var ids []string
+	for _, item := range m.selector.items {
+		ids = append(ids, item.ID)
+	}
+	if strings.Join(ids, ",") != "catalyst,SIM-A" {
+		t.Fatalf("items = %q, want Catalyst and the iPhone", ids)
+	}
+	if !strings.Contains(m.selector.title, "App (iOS)") || !strings.Contains(m.statusMsg, "doesn't support tvOS") {
+		t.Fatalf("title %q, status %q", m.selector.title, m.statusMsg)
+	}
+}
@@ -9,7 +9,90 @@ import (
 
 const scrollbarWidth = 1
 
-func renderScrollbarLines(height, content, scrollPos int, styles Styles) []string {
+// scrollMarker flags an error (red) or warning (yellow) at a display row on
+// the scrollbar track
+type scrollMarker struct {
+	Row   int
+	Error bool
+}
+
+// markerCell is the track cell a row falls in
+func markerCell(row, height, content int) int {
+	return row * height / content
+}
+
+// markerCells buckets markers into track cells (0 none, 1 warning, 2
+// error); an error outranks warnings sharing its cell
+func markerCells(height, content int, markers []scrollMarker) []int8 {
+	if len(markers) == 0 || content <= height || height <= 0 {
+		return nil
+	}
+	cells := make([]int8, height)
+	for _, mk := range markers {
+		if mk.Row < 0 || mk.Row >= content {
+			continue
+		}
+		level := int8(1)
+		if mk.Error {
+			level = 2
+		}
+		c := markerCell(mk.Row, height, content)
+		if level > cells[c] {
+			cells[c] = level
+		}
+	}
+	return cells
+}
+
+// scrollMarkerAt returns the row of the first error marker in track cell, or
+// of the first warning when the cell has no error
+func scrollMarkerAt(cell, height, content int, markers []scrollMarker) (int, bool) {
+	if content <= height || cell < 0 || cell >= height {
+		return 0, false
+	}
+	row, found := 0, false
+	for _, mk := range markers {
+		if mk.Row < 0 || mk.Row >= content || markerCell(mk.Row, height, content) != cell {
+			continue
+		}
+		if mk.Error {
+			return mk.Row, true
+		}
+		if !found {
+			row, found = mk.Row, true
+		}
+	}
+	return row, found
+}
+
+// nearestMarker returns the row of the first marker below the view rows
+// [top, bottom) when dir > 0, else the last one above it. Markers are in row
+// order.
+func nearestMarker(markers []scrollMarker, top, bottom, dir int) (int, bool) {
+	if dir > 0 {
+		for _, mk := range markers {
+			if mk.Row >= bottom {
+				return mk.Row, true
+			}
+		}
+		return 0, false
+	}
+	for i := len(markers) - 1; i >= 0; i-- {
+		if markers[i].Row < top {
+			return markers[i].Row, true
+		}
+	}
+	return 0, false
+}
+
+// centeredScrollPos is the scroll position showing row mid-view
+func centeredScrollPos(row, visible, maxScroll int) int {
+	return min(max(0, row-visible/2), max(0, maxScroll))
+}
+
+// renderScrollbarLines draws the track and thumb, with markers colored on
+// the cells they scale to
+func renderScrollbarLines(height, content, scrollPos int, styles Styles, markers ...scrollMarker) []string {
 	if height <= 0 {
 		return nil
 	}
@@ -56,10 +139,26 @@ func renderScrollbarLines(height, content, scrollPos int, styles Styles) []strin
 			lines[i] = thumb
 		}
 	}
+
+	cells := markerCells(height, content, markers)
+	if cells == nil {
+		return lines
+	}
+	markerStyles := [3]lipgloss.Style{{}, lipgloss.NewStyle().Foreground(styles.Colors.Warning), lipgloss.NewStyle().Foreground(styles.Colors.Error)}
+	for i, level := range cells {
+		if level == 0 {
+			continue
+		}
+		glyph := "━"
+		if i >= thumbTop && i < thumbTop+thumbHeight {
+			glyph = "█"
+		}
+		lines[i] = markerStyles[level].Render(glyph)
+	}
 	return lines
 }
 
-func withScrollbar(content string, height, width, totalLines, scrollPos int, styles Styles) string {
+func withScrollbar(content string, height, width, totalLines, scrollPos int, styles Styles, markers ...scrollMarker) string {
 	if height <= 0 || scrollbarWidth <= 0 {
 		return content
 	}
@@ -77,7 +176,7 @@ func withScrollbar(content string, height, width, totalLines, scrollPos int, sty
 		}
 	}
 
-	bar := renderScrollbarLines(height, totalLines, scrollPos, styles)
+	bar := renderScrollbarLines(height, totalLines, scrollPos, styles, markers...)
 	if len(bar) != height {
 		return strings.Join(lines, "\n")
 	}
@@ -0,0 +1,138 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestMarkerCellsScaleToTrack(t *testing.T) {
+	markers := []scrollMarker{{Row: 0}, {Row: 5, Error: true}, {Row: 9}, {Row: 9999, Error: true}, {Row: 10000}}
+	cells := markerCells(10, 10000, markers)
+	if cells[0] != 2 || cells[9] != 2 {
+		t.Fatalf("cells = %v, want errors on the first and last cell", cells)
+	}
+	for i := 1; i < 9; i++ {
+		if cells[i] != 0 {
+			t.Fatalf("cell %d = %d", i, cells[i])
+		}
+	}
+	if markerCells(10, 10, markers) != nil {
+		t.Fatal("marked a track that isn't drawn")
+	}
+
+	bar := renderScrollbarLines(10, 10000, 0, DefaultStyles(), markers...)
+	if got := stripANSI(bar[9]); got != "━" {
+		t.Fatalf("last cell = %q", got)
+	}
+	if got := stripANSI(bar[0]); got != "█" {
+		t.Fatalf("marker under the thumb = %q", got)
+	}
+}
+
+func TestScrollMarkerAtPrefersErrors(t *testing.T) {
+	markers := []scrollMarker{{Row: 100}, {Row: 150, Error: true}, {Row: 900}}
+	if row, ok := scrollMarkerAt(1, 10, 1000, markers); !ok || row != 150 {
+		t.Fatalf("cell 1 = %d, %v", row, ok)
+	}
+	if row, ok := scrollMarkerAt(9, 10, 1000, markers); !ok || row != 900 {
+		t.Fatalf("cell 9 = %d, %v", row, ok)
+	}
+	if _, ok := scrollMarkerAt(5, 10, 1000, markers); ok {
+		t.Fatal("found a marker in an empty cell")
+	}
+}
+
+func newMarkedStream() *StreamTab {
+	st := NewStreamTab()
+	st.SetSize(80, 10)
+	for i := 0; i < 500; i++ {
+		switch i {
+		case 50:
+			st.AddLine("/src/A.swift:1:1: warning: unused", TabLineTypeWarning)
+		case 300:
+			st.AddLine("/src/B.swift:2:1: error: no such module", TabLineTypeError)
+		default:
+			st.AddLine(fmt.Sprintf("line %d", i), TabLineTypeNormal)
+		}
+	}
+	return st
+}
+
+func TestStreamJumpToMarker(t *testing.T) {
+	st := newMarkedStream()
+	st.GotoTop()
+	if !st.JumpToMarker(1) || st.ScrollPos != 45 {
+		t.Fatalf("first jump ScrollPos = %d", st.ScrollPos)
+	}
+	if !st.JumpToMarker(1) || st.ScrollPos != 295 || st.AutoFollow {
+		t.Fatalf("second jump ScrollPos = %d", st.ScrollPos)
+	}
+	if st.JumpToMarker(1) {
+		t.Fatal("jumped past the last marker")
+	}
+	if !st.JumpToMarker(-1) || st.ScrollPos != 45 {
+		t.Fatalf("back jump ScrollPos = %d", st.ScrollPos)
+	}
+
+	view := stripANSI(st.View(DefaultStyles()))
+	if !strings.Contains(view, "━") {
+		t.Fatalf("no marker on the scrollbar:\n%s", view)
+	}
+}
+
+func TestStreamMarkersFollowFilterAndTrim(t *testing.T) {
+	st := newMarkedStream()
+	st.SetErrorsOnly(true, 2)
+	markers := st.markers()
+	if len(markers) != 2 || markers[0].Row != 2 || markers[0].Error || markers[1].Row != 8 || !markers[1].Error {
+		t.Fatalf("errors-only markers = %+v", markers)
+	}
+	st.SetErrorsOnly(false, 2)
+
+	for i := 0; i < maxStreamTabLines; i++ {
+		st.AddLine("filler", TabLineTypeNormal)
+	}
+	if len(st.markers()) != 0 {
+		t.Fatalf("trimmed lines still marked: %+v", st.markers())
+	}
+	st.AddLine("error: late", TabLineTypeError)
+	if m := st.markers(); len(m) != 1 || m[0].Row != len(st.Lines)-1 {
+		t.Fatalf("markers after trim = %+v", m)
+	}
+}
+
+func TestPhaseViewMarkers(t *testing.T) {
+	v := NewPhaseView()
+	v.SetSize(80, 5)
+	v.AddLine("=== BUILD TARGET App OF PROJECT App WITH CONFIGURATION Debug ===")
+	for i := 0; i < 20; i++ {
+		v.AddLine(fmt.Sprintf("step %d", i))
+	}
+	v.AddLine("/src/A.swift:3:1: warning: unused variable")
+	v.AddLine("/src/A.swift:4:1: error: cannot find 'x' in scope")
+
+	markers := v.markers()
+	if len(markers) != 2 || markers[0].Error || !markers[1].Error || markers[1].Row != markers[0].Row+1 {
+		t.Fatalf("markers = %+v", markers)
+	}
+	v.GotoTop()
+	if !v.JumpToMarker(1) || v.ScrollPos == 0 {
+		t.Fatalf("JumpToMarker left ScrollPos = %d", v.ScrollPos)
+	}
+}
+
+func TestStreamMarksCollapsedPhaseHeader(t *testing.T) {
+	st := NewStreamTab()
+	st.SetSize(80, 3)
+	st.AddLine("=== BUILD TARGET App ===", TabLineTypePhaseHeader)
+	st.AddLine("step", TabLineTypeNormal)
+	st.AddLine("warning: unused", TabLineTypeWarning)
+	st.AddLine("=== BUILD TARGET Tests ===", TabLineTypePhaseHeader)
+	st.AddLine("error: missing", TabLineTypeError)
+	st.TogglePhase(0)
+	markers := st.markers()
+	if len(markers) != 2 || markers[0].Row != 0 || markers[1].Row != 2 || !markers[1].Error {
+		t.Fatalf("markers = %+v", markers)
+	}
+}
@@ -1,11 +1,15 @@
 package tui
 
 import (
+	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/xcbolt/xcbolt/internal/core"
 )
 
 // =============================================================================
@@ -18,6 +22,8 @@ type SelectorItem struct {
 	Title       string // Display title
 	Description string // Secondary info (e.g., OS version, state)
 	Meta        string // Additional metadata (e.g., "[booted]")
+	Note        string // Accent badge (e.g., "(last used with this scheme)")
+	Dim         bool   // Render muted (e.g., dead sessions)
 }
 
 // MatchScore returns how well this item matches the query (higher = better)
@@ -419,6 +425,11 @@ func (m SelectorModel) View() string {
 // renderItem renders a single selector item
 func (m SelectorModel) renderItem(item SelectorItem, isSelected bool, icons Icons, itemStyle, selectedStyle, descStyle lipgloss.Style, s Styles) string {
 	var line string
+	if item.Dim {
+		itemStyle = itemStyle.Foreground(s.Colors.TextSubtle)
+		selectedStyle = selectedStyle.Foreground(s.Colors.TextMuted)
+		descStyle = descStyle.Foreground(s.Colors.TextSubtle)
+	}
 	if isSelected {
 		line = s.StatusStyle("running").Render(icons.ChevronRight) + " "
 		line += selectedStyle.Render(item.Title)
@@ -436,15 +447,18 @@ func (m SelectorModel) renderItem(item SelectorItem, isSelected bool, icons Icon
 	if item.Meta != "" {
 		metaStyle := descStyle
 		// Color-code device state
-		if item.Meta == "[booted]" {
+		if item.Meta == "[booted]" || item.Meta == "[alive]" {
 			metaStyle = s.StatusStyle("success")
-		} else if item.Meta == "[shutdown]" {
+		} else if item.Meta == "[shutdown]" || item.Meta == "[dead]" {
 			metaStyle = s.StatusStyle("idle")
 		} else if item.Meta == "[device]" {
 			metaStyle = s.StatusStyle("running")
 		}
 		line += " " + metaStyle.Render(item.Meta)
 	}
+	if item.Note != "" {
+		line += " " + lipgloss.NewStyle().Foreground(s.Colors.Accent).Render(item.Note)
+	}
 	if m.showSelectedBadge && m.selectedID != "" && item.ID == m.selectedID && !strings.Contains(line, "[current]") {
 		badgeStyle := s.StatusStyle("warning")
 		line += " " + badgeStyle.Render("[current]")
@@ -511,9 +525,98 @@ func ConfigurationItems(configs []string, current string) []SelectorItem {
 
 // DestinationItems creates selector items from simulators and devices
 func DestinationItems(sims []SimulatorInfo, devices []DeviceInfo) []SelectorItem {
-	items := make([]SelectorItem, 0, 1+len(sims)+len(devices))
+	items := make([]SelectorItem, 0, 2+len(sims)+len(devices))
+	items = append(items, localDestinationItems()...)
+	for _, sim := range sims {
+		items = append(items, simulatorItem(sim))
+	}
+	return append(items, deviceItems(devices)...)
+}
+
+// destinationNamePrefix marks a DestinationNameItems entry that stands for
+// several simulators with the same name
+const destinationNamePrefix = "name:"
+
+// DestinationNameItems is the first stage of the destination selector: like
+// DestinationItems, but simulators sharing a name (e.g. "iPhone 15 Pro" on
+// iOS 17.5 and 18.0) are one entry listing their OS versions. Picking it
+// opens DestinationOSItems.
+func DestinationNameItems(sims []SimulatorInfo, devices []DeviceInfo) []SelectorItem {
+	items := make([]SelectorItem, 0, 2+len(sims)+len(devices))
+	items = append(items, localDestinationItems()...)
+	groups := map[string][]SimulatorInfo{}
+	var names []string
+	for _, sim := range sims {
+		if _, ok := groups[sim.Name]; !ok {
+			names = append(names, sim.Name)
+		}
+		groups[sim.Name] = append(groups[sim.Name], sim)
+	}
+	for _, name := range names {
+		group := groups[name]
+		if len(group) == 1 {
+			items = append(items, simulatorItem(group[0]))
+			continue
+		}
+		sortSimulatorsByOS(group)
+		versions := make([]string, len(group))
+		meta := ""
+		for i, sim := range group {
+			versions[i] = simulatorOSLabel(sim)
+			if sim.State == "Booted" {
+				meta = "[booted]"
+			}
+		}
+		items = append(items, SelectorItem{
+			ID:          destinationNamePrefix + name,
+			Title:       name,
+			Description: fmt.Sprintf("%d OS versions: %s", len(group), strings.Join(versions, ", ")),
+			Meta:        meta,
+		})
+	}
+	return append(items, deviceItems(devices)...)
+}
 
-	// Local Mac destination
+// DestinationOSItems is the second stage of the destination selector: the
+// simulators called name, newest OS first
+func DestinationOSItems(name string, sims []SimulatorInfo) []SelectorItem {
+	var group []SimulatorInfo
+	for _, sim := range sims {
+		if sim.Name == name {
+			group = append(group, sim)
+		}
+	}
+	sortSimulatorsByOS(group)
+	items := make([]SelectorItem, len(group))
+	for i, sim := range group {
+		item := simulatorItem(sim)
+		item.Title = simulatorOSLabel(sim)
+		item.Description = sim.Name
+		if sim.PlatformFamily != "" {
+			item.Description += " • " + sim.PlatformFamily
+		}
+		items[i] = item
+	}
+	return items
+}
+
+// sortSimulatorsByOS orders simulators newest OS first (17.10 before 17.5)
+func sortSimulatorsByOS(sims []SimulatorInfo) {
+	sort.SliceStable(sims, func(i, j int) bool {
+		return core.CompareOSVersions(simulatorOSLabel(sims[i]), simulatorOSLabel(sims[j])) > 0
+	})
+}
+
+// simulatorOSLabel names a simulator's OS, e.g. "iOS 17.5"
+func simulatorOSLabel(sim SimulatorInfo) string {
+	if sim.RuntimeName != "" {
+		return sim.RuntimeName
+	}
+	return sim.OSVersion
+}
+
+func localDestinationItems() []SelectorItem {
+	var items []SelectorItem
 	items = append(items, SelectorItem{
 		ID:          "macos",
 		Title:       "My Mac",
@@ -526,31 +629,38 @@ func DestinationItems(sims []SimulatorInfo, devices []DeviceInfo) []SelectorItem
 		Description: "macOS",
 		Meta:        "[catalyst]",
 	})
+	return items
+}
 
-	// Add simulators
-	for _, sim := range sims {
-		meta := ""
-		if sim.State == "Booted" {
-			meta = "[booted]"
-		}
-		desc := sim.RuntimeName
-		if sim.PlatformFamily != "" {
-			desc = sim.PlatformFamily + " • " + desc
+// simulatorItem lists a simulator with its OS version first
+func simulatorItem(sim SimulatorInfo) SelectorItem {
+	meta := ""
+	if sim.State == "Booted" {
+		meta = "[booted]"
+	}
+	desc := simulatorOSLabel(sim)
+	if sim.PlatformFamily != "" {
+		if desc != "" {
+			desc += " • " + sim.PlatformFamily
+		} else {
+			desc = sim.PlatformFamily
 		}
-		items = append(items, SelectorItem{
-			ID:          sim.UDID,
-			Title:       sim.Name,
-			Description: desc,
-			Meta:        meta,
-		})
 	}
+	return SelectorItem{
+		ID:          sim.UDID,
+		Title:       sim.Name,
+		Description: desc,
+		Meta:        meta,
+	}
+}
 
-	// Add devices
+func deviceItems(devices []DeviceInfo) []SelectorItem {
+	var items []SelectorItem
 	for _, dev := range devices {
 		desc := dev.OSVersion
 		if dev.PlatformFamily != "" {
 			if desc != "" {
-				desc = dev.PlatformFamily + " • " + desc
+				desc += " • " + dev.PlatformFamily
 			} else {
 				desc = dev.PlatformFamily
 			}
@@ -562,7 +672,6 @@ func DestinationItems(sims []SimulatorInfo, devices []DeviceInfo) []SelectorItem
 			Meta:        "[device]",
 		})
 	}
-
 	return items
 }
 
@@ -0,0 +1,219 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// =============================================================================
+// Run Sessions
+// =============================================================================
+
+// sessionProbeTimeout bounds the liveness probes run when listing sessions
+const sessionProbeTimeout = 10 * time.Second
+
+// cleanDeadSessionsID is the selector item that prunes dead sessions
+const cleanDeadSessionsID = "\x00clean-dead"
+
+// sessionEntry is a tracked session with the result of its liveness probe
+type sessionEntry struct {
+	core.Session
+	Alive bool
+}
+
+// sessionsMsg carries the probed sessions for the sessions selector
+type sessionsMsg struct {
+	entries []sessionEntry
+	err     error
+}
+
+// openSessions loads every tracked session and probes whether its app is
+// still running before listing them
+func (m *Model) openSessions() tea.Cmd {
+	m.setStatus("Probing sessions…")
+	root := m.projectRoot
+	preserveLocale := m.cfg.Xcodebuild.PreserveLocale
+	return func() tea.Msg {
+		s, err := core.LoadSessions(root)
+		if err != nil {
+			return sessionsMsg{err: err}
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), sessionProbeTimeout)
+		defer cancel()
+		entries := make([]sessionEntry, len(s.Items))
+		var wg sync.WaitGroup
+		for i, sess := range s.Items {
+			entries[i].Session = sess
+			wg.Add(1)
+			go func(e *sessionEntry) {
+				defer wg.Done()
+				e.Alive = core.SessionAlive(ctx, e.Session, preserveLocale)
+			}(&entries[i])
+		}
+		wg.Wait()
+		// Newest first
+		sort.SliceStable(entries, func(i, j int) bool {
+			return parseSessionTime(entries[i].StartedAt).After(parseSessionTime(entries[j].StartedAt))
+		})
+		return sessionsMsg{entries: entries}
+	}
+}
+
+func (m *Model) handleSessions(msg sessionsMsg) {
+	if msg.err != nil {
+		m.setStatus("Could not load sessions: " + msg.err.Error())
+		return
+	}
+	if len(msg.entries) == 0 {
+		m.setStatus("No tracked sessions")
+		return
+	}
+	m.sessions = msg.entries
+	m.selector = NewSelector("Sessions", SessionItems(msg.entries, m.destinationName), m.width, m.styles)
+	m.selectorType = SelectorSessions
+	m.mode = ModeSelector
+	m.setStatus("")
+}
+
+// SessionItems creates selector items (ID = session ID), dimming dead ones.
+// A "clean dead sessions" item is appended when any are dead.
+func SessionItems(entries []sessionEntry, destName func(udid string) string) []SelectorItem {
+	items := make([]SelectorItem, 0, len(entries)+1)
+	dead := 0
+	for _, e := range entries {
+		parts := []string{e.Target}
+		if name := destName(e.UDID); name != "" {
+			parts = append(parts, name)
+		}
+		if e.PID > 0 {
+			parts = append(parts, fmt.Sprintf("pid %d", e.PID))
+		}
+		if t := parseSessionTime(e.StartedAt); !t.IsZero() {
+			parts = append(parts, t.Local().Format("Jan 2 15:04"))
+		}
+		meta := "[alive]"
+		if !e.Alive {
+			meta = "[dead]"
+			dead++
+		}
+		items = append(items, SelectorItem{
+			ID:          e.ID,
+			Title:       e.BundleID,
+			Description: strings.Join(parts, " · "),
+			Meta:        meta,
+			Dim:         !e.Alive,
+		})
+	}
+	if dead > 0 {
+		items = append(items, SelectorItem{
+			ID:          cleanDeadSessionsID,
+			Title:       "Clean dead sessions",
+			Description: fmt.Sprintf("Forget %d session(s) whose app is no longer running", dead),
+		})
+	}
+	return items
+}
+
+// destinationName resolves a simulator/device UDID to its display name
+func (m *Model) destinationName(udid string) string {
+	if udid == "" {
+		return ""
+	}
+	for _, sim := range m.info.Simulators {
+		if sim.UDID == udid {
+			return sim.Name
+		}
+	}
+	for _, dev := range m.info.Devices {
+		if dev.Identifier == udid {
+			return dev.Name
+		}
+	}
+	return ""
+}
+
+// selectSession opens the action menu for the picked session
+func (m *Model) selectSession(item *SelectorItem) tea.Cmd {
+	if item.ID == cleanDeadSessionsID {
+		m.cleanDeadSessions()
+		return nil
+	}
+	for _, e := range m.sessions {
+		if e.ID != item.ID {
+			continue
+		}
+		m.sessionPick = e.Session
+		title := e.BundleID
+		if !e.Alive {
+			title += " (not running)"
+		}
+		m.selector = NewSelector(title, []SelectorItem{
+			{ID: "stop", Title: "Stop", Description: "Terminate the app and forget the session"},
+			{ID: "logs", Title: "Stream logs", Description: "Follow the app's unified logs (simulator)"},
+			{ID: "copy", Title: "Copy bundle id", Description: e.BundleID},
+			{ID: "remove", Title: "Remove entry", Description: "Forget the session without stopping the app"},
+		}, m.width, m.styles)
+		m.selectorType = SelectorSessionAction
+		m.mode = ModeSelector
+		return nil
+	}
+	return nil
+}
+
+// runSessionAction applies an action from the session menu
+func (m *Model) runSessionAction(action string) tea.Cmd {
+	sess := m.sessionPick
+	root := m.projectRoot
+	preserveLocale := m.cfg.Xcodebuild.PreserveLocale
+	switch action {
+	case "stop":
+		m.setStatus("Stopping " + sess.BundleID + "…")
+		return func() tea.Msg {
+			return stopAndForget(root, sessionStopTarget(sess), preserveLocale)
+		}
+	case "logs":
+		if sess.Target != string(core.DestSimulator) || sess.UDID == "" {
+			m.setStatus("Log streaming needs a simulator session (use Console.app for devices and Macs)")
+			return nil
+		}
+		m.logSession = sess
+		return m.startOrRestartOp("logs")
+	case "copy":
+		return m.copyToClipboard(sess.BundleID, "Copied "+sess.BundleID)
+	case "remove":
+		if err := core.RemoveSessions(root, sess.ID); err != nil {
+			m.setStatus("Could not remove session: " + err.Error())
+			return nil
+		}
+		m.setStatus("Removed session " + sess.ID)
+	}
+	return nil
+}
+
+// cleanDeadSessions forgets every session whose probe found no running app
+func (m *Model) cleanDeadSessions() {
+	var ids []string
+	for _, e := range m.sessions {
+		if !e.Alive {
+			ids = append(ids, e.ID)
+		}
+	}
+	if err := core.RemoveSessions(m.projectRoot, ids...); err != nil {
+		m.setStatus("Could not clean sessions: " + err.Error())
+		return
+	}
+	m.setStatus(fmt.Sprintf("Removed %d dead session(s)", len(ids)))
+}
+
+// sessionLogPredicate matches the unified logs of a session's app
+func sessionLogPredicate(bundleID string) string {
+	return core.UnifiedLogPredicate(bundleID, "")
+}
@@ -0,0 +1,73 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func TestSessionItemsDimDeadAndOfferCleanup(t *testing.T) {
+	entries := []sessionEntry{
+		{Session: core.Session{ID: "com.example.app@SIM-1", BundleID: "com.example.app", Target: "simulator", UDID: "SIM-1", StartedAt: "2026-10-14T12:00:00Z"}, Alive: true},
+		{Session: core.Session{ID: "com.example.mac", BundleID: "com.example.mac", Target: "macos", PID: 812}},
+	}
+	names := func(udid string) string {
+		if udid == "SIM-1" {
+			return "iPhone 15"
+		}
+		return ""
+	}
+	items := SessionItems(entries, names)
+	if len(items) != 3 {
+		t.Fatalf("expected two sessions plus cleanup, got %+v", items)
+	}
+	if items[0].Dim || items[0].Meta != "[alive]" || !strings.Contains(items[0].Description, "iPhone 15") {
+		t.Fatalf("unexpected alive item %+v", items[0])
+	}
+	if !items[1].Dim || items[1].Meta != "[dead]" || !strings.Contains(items[1].Description, "pid 812") {
+		t.Fatalf("unexpected dead item %+v", items[1])
+	}
+	if items[2].ID != cleanDeadSessionsID {
+		t.Fatalf("expected cleanup item last, got %+v", items[2])
+	}
+}
+
+func TestCleanDeadSessionsKeepsAlive(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	root := t.TempDir()
+	for _, udid := range []string{"SIM-1", "SIM-2"} {
+		if _, err := core.AddSession(root, "com.example.app", 0, "simulator", udid); err != nil {
+			t.Fatal(err)
+		}
+	}
+	m := NewModel(root, "", ConfigOverrides{})
+	m.handleSessions(sessionsMsg{entries: []sessionEntry{
+		{Session: core.Session{ID: "com.example.app@SIM-1", BundleID: "com.example.app"}, Alive: true},
+		{Session: core.Session{ID: "com.example.app@SIM-2", BundleID: "com.example.app"}},
+	}})
+	if m.mode != ModeSelector || m.selectorType != SelectorSessions {
+		t.Fatalf("sessions should open a selector: mode=%v type=%v", m.mode, m.selectorType)
+	}
+
+	m.selectSession(&SelectorItem{ID: cleanDeadSessionsID})
+	s, err := core.LoadSessions(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Items) != 1 || s.Items[0].ID != "com.example.app@SIM-1" {
+		t.Fatalf("only the dead session should be pruned, got %+v", s.Items)
+	}
+}
+
+func TestSessionLogsNeedSimulator(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.sessionPick = core.Session{ID: "com.example.app@DEV-1", BundleID: "com.example.app", Target: "device", UDID: "DEV-1"}
+	if cmd := m.runSessionAction("logs"); cmd != nil || m.running {
+		t.Fatalf("device sessions should not start a log stream")
+	}
+	if !strings.Contains(m.statusMsg, "simulator") {
+		t.Fatalf("unexpected status %q", m.statusMsg)
+	}
+}
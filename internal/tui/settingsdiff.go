@@ -0,0 +1,358 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// =============================================================================
+// Build Settings Diff Overlay
+// =============================================================================
+
+// settingsDiffMsg delivers a finished build settings comparison
+type settingsDiffMsg struct {
+	diff core.BuildSettingsDiff
+	err  error
+}
+
+// settingsDiffRow is one differing key, tagged "changed", "added" or "removed"
+type settingsDiffRow struct {
+	Kind   string
+	Change core.BuildSettingChange
+}
+
+// SettingsDiffOverlay shows two build settings side by side, one differing
+// key per row
+type SettingsDiffOverlay struct {
+	Diff      core.BuildSettingsDiff
+	Loading   bool
+	Query     string // Search filter on keys and values
+	Searching bool   // Typing into Query
+	Offset    int
+	Message   string
+}
+
+func diffBuildSettingsCmd(projectRoot string, cfg core.Config, other core.SettingsVariant) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 90*time.Second)
+		defer cancel()
+		diff, err := core.DiffBuildSettings(ctx, projectRoot, cfg, other)
+		return settingsDiffMsg{diff: diff, err: err}
+	}
+}
+
+// Rows lists the differences matching the search, changed keys first
+func (d *SettingsDiffOverlay) Rows() []settingsDiffRow {
+	query := strings.ToLower(strings.TrimSpace(d.Query))
+	var rows []settingsDiffRow
+	add := func(kind string, list []core.BuildSettingChange) {
+		for _, c := range list {
+			if query != "" && !strings.Contains(strings.ToLower(c.Key+"\x00"+c.From+"\x00"+c.To), query) {
+				continue
+			}
+			rows = append(rows, settingsDiffRow{Kind: kind, Change: c})
+		}
+	}
+	add("changed", d.Diff.Changed)
+	add("added", d.Diff.Added)
+	add("removed", d.Diff.Removed)
+	return rows
+}
+
+// SetQuery filters the rows and scrolls back to the top
+func (d *SettingsDiffOverlay) SetQuery(q string) {
+	d.Query = q
+	d.Offset = 0
+}
+
+// Scroll moves the first visible row by delta, clamped to the rows that fit
+func (d *SettingsDiffOverlay) Scroll(delta, visible int) {
+	d.Offset += delta
+	if maxOffset := len(d.Rows()) - visible; d.Offset > maxOffset {
+		d.Offset = maxOffset
+	}
+	if d.Offset < 0 {
+		d.Offset = 0
+	}
+}
+
+// View renders the overlay content (without centering); height is the
+// number of rows shown at once
+func (d *SettingsDiffOverlay) View(width, height int, s Styles) string {
+	var b strings.Builder
+	inner := width - 6
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(s.Colors.Text)
+	mutedStyle := lipgloss.NewStyle().Foreground(s.Colors.TextMuted)
+	dividerStyle := lipgloss.NewStyle().Foreground(s.Colors.BorderMuted)
+
+	title := "Build Settings Diff"
+	if d.Diff.Base != "" {
+		title += ": " + d.Diff.Base + " ↔ " + d.Diff.Other
+	}
+	b.WriteString(titleStyle.Render(truncateString(title, inner)))
+	b.WriteString("\n")
+	switch {
+	case d.Searching:
+		b.WriteString(mutedStyle.Render("Search: ") + d.Query + "▏")
+	case d.Query != "":
+		b.WriteString(mutedStyle.Render("Search: ") + d.Query)
+	default:
+		b.WriteString(mutedStyle.Render("Press / to search"))
+	}
+	b.WriteString("\n")
+	b.WriteString(dividerStyle.Render(strings.Repeat("─", inner)))
+	b.WriteString("\n")
+
+	rows := d.Rows()
+	switch {
+	case d.Loading:
+		b.WriteString(mutedStyle.Render("  Reading build settings…") + "\n")
+	case d.Diff.Empty():
+		b.WriteString(mutedStyle.Render("  No differences") + "\n")
+	case len(rows) == 0:
+		b.WriteString(mutedStyle.Render("  No matching settings") + "\n")
+	default:
+		b.WriteString(d.renderRows(rows, inner, height, s))
+	}
+
+	b.WriteString(dividerStyle.Render(strings.Repeat("─", inner)))
+	b.WriteString("\n")
+	if d.Message != "" {
+		b.WriteString(mutedStyle.Render(truncateString(d.Message, inner)))
+	} else if !d.Loading {
+		summary := fmt.Sprintf("%d changed · %d added · %d removed", len(d.Diff.Changed), len(d.Diff.Added), len(d.Diff.Removed))
+		if d.Diff.PathOnly > 0 {
+			summary += fmt.Sprintf(" · %d DerivedData-only", d.Diff.PathOnly)
+		}
+		b.WriteString(mutedStyle.Render(summary))
+	}
+	b.WriteString("\n")
+
+	hintKeyStyle := lipgloss.NewStyle().Foreground(s.Colors.Accent)
+	hintDescStyle := lipgloss.NewStyle().Foreground(s.Colors.TextSubtle)
+	b.WriteString(hintKeyStyle.Render("↑↓") + hintDescStyle.Render(" scroll  ") +
+		hintKeyStyle.Render("/") + hintDescStyle.Render(" search  ") +
+		hintKeyStyle.Render("e") + hintDescStyle.Render(" export  ") +
+		hintKeyStyle.Render("esc") + hintDescStyle.Render(" close"))
+
+	containerStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(s.Colors.Border).
+		Padding(1, 2)
+
+	return containerStyle.Width(width).Render(b.String())
+}
+
+// renderRows renders the visible rows with the key and both values in
+// aligned columns
+func (d *SettingsDiffOverlay) renderRows(rows []settingsDiffRow, inner, height int, s Styles) string {
+	keyWidth := 0
+	for _, r := range rows {
+		keyWidth = maxInt(keyWidth, len(r.Change.Key))
+	}
+	keyWidth = minInt(keyWidth, inner/3)
+	valueWidth := maxInt(8, (inner-keyWidth-6)/2)
+
+	headerStyle := lipgloss.NewStyle().Foreground(s.Colors.TextSubtle).Bold(true)
+	keyStyle := lipgloss.NewStyle().Foreground(s.Colors.Text)
+	valueStyle := lipgloss.NewStyle().Foreground(s.Colors.TextMuted)
+	column := func(text string, w int) string {
+		return lipgloss.NewStyle().Width(w).Render(truncateString(text, w))
+	}
+
+	var b strings.Builder
+	b.WriteString("  " + headerStyle.Render(column("", keyWidth)) + "  " +
+		headerStyle.Render(column(d.Diff.Base, valueWidth)) + "  " +
+		headerStyle.Render(column(d.Diff.Other, valueWidth)) + "\n")
+
+	end := minInt(len(rows), d.Offset+height)
+	for _, r := range rows[d.Offset:end] {
+		marker, color := "~", s.Colors.Warning
+		switch r.Kind {
+		case "added":
+			marker, color = "+", s.Colors.Success
+		case "removed":
+			marker, color = "-", s.Colors.Error
+		}
+		markStyle := lipgloss.NewStyle().Foreground(color)
+		b.WriteString(markStyle.Render(marker) + " " +
+			keyStyle.Render(column(r.Change.Key, keyWidth)) + "  " +
+			valueStyle.Render(column(r.Change.From, valueWidth)) + "  " +
+			markStyle.Render(column(r.Change.To, valueWidth)) + "\n")
+	}
+	if len(rows) > height {
+		b.WriteString(lipgloss.NewStyle().Foreground(s.Colors.TextSubtle).
+			Render(fmt.Sprintf("  %d–%d of %d", d.Offset+1, end, len(rows))) + "\n")
+	}
+	return b.String()
+}
+
+// =============================================================================
+// Model wiring
+// =============================================================================
+
+// openSettingsDiff asks which dimension to vary for the comparison
+func (m *Model) openSettingsDiff() {
+	items := []SelectorItem{
+		{ID: "configuration", Title: "Configuration", Description: "Compare " + m.cfg.Configuration + " with another configuration"},
+		{ID: "destination", Title: "Destination", Description: "Compare " + m.cfg.Destination.Name + " with another destination"},
+	}
+	m.selector = NewSelector("Diff build settings by", items, m.width, m.styles)
+	m.selectorType = SelectorSettingsDiff
+	m.mode = ModeSelector
+}
+
+// chooseSettingsDiffTarget opens the selector for the other side of the diff
+func (m *Model) chooseSettingsDiffTarget(dimension string) {
+	var items []SelectorItem
+	if dimension == "configuration" {
+		for _, item := range ConfigurationItems(m.info.Configurations, m.cfg.Configuration) {
+			if item.ID != m.cfg.Configuration {
+				items = append(items, item)
+			}
+		}
+		if len(items) == 0 {
+			m.setStatus("No other configuration to compare with")
+			return
+		}
+		m.selector = NewSelector("Compare "+m.cfg.Configuration+" with", items, m.width, m.styles)
+		m.selectorType = SelectorSettingsDiffConfiguration
+		m.mode = ModeSelector
+		return
+	}
+	all, current := m.destinationSelectorItems()
+	for _, item := range all {
+		if item.ID != current {
+			items = append(items, item)
+		}
+	}
+	if len(items) == 0 {
+		m.setStatus("No other destination to compare with")
+		return
+	}
+	m.selector = NewSelector("Compare "+m.cfg.Destination.Name+" with", items, m.width, m.styles)
+	m.selectorType = SelectorSettingsDiffDestination
+	m.mode = ModeSelector
+}
+
+// startSettingsDiff opens the overlay and compares the build settings
+func (m *Model) startSettingsDiff(other core.SettingsVariant) tea.Cmd {
+	m.settingsDiff = SettingsDiffOverlay{Loading: true}
+	m.mode = ModeSettingsDiff
+	return diffBuildSettingsCmd(m.projectRoot, m.cfg, other)
+}
+
+func (m *Model) pickSettingsDiffTarget(item *SelectorItem) tea.Cmd {
+	if m.selectorType == SelectorSettingsDiffConfiguration {
+		return m.startSettingsDiff(core.SettingsVariant{Configuration: item.ID})
+	}
+	dst, ok := m.destinationForItem(item)
+	if !ok {
+		m.setStatus("Unknown destination: " + item.Title)
+		return nil
+	}
+	return m.startSettingsDiff(core.SettingsVariant{Destination: &dst})
+}
+
+func (m *Model) handleSettingsDiff(msg settingsDiffMsg) {
+	m.settingsDiff.Loading = false
+	if msg.err != nil {
+		m.settingsDiff.Message = "Diff failed: " + msg.err.Error()
+		return
+	}
+	m.settingsDiff.Diff = msg.diff
+}
+
+// settingsDiffHeight is how many rows fit in the overlay
+func (m Model) settingsDiffHeight() int {
+	return maxInt(5, m.height-16)
+}
+
+func (m *Model) handleSettingsDiffKey(msg tea.KeyMsg) tea.Cmd {
+	d := &m.settingsDiff
+	height := m.settingsDiffHeight()
+	if d.Searching {
+		switch msg.String() {
+		case "esc":
+			d.Searching = false
+			d.SetQuery("")
+		case "enter":
+			d.Searching = false
+		case "backspace":
+			if r := []rune(d.Query); len(r) > 0 {
+				d.SetQuery(string(r[:len(r)-1]))
+			}
+		default:
+			if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+				d.SetQuery(d.Query + string(msg.Runes))
+			}
+		}
+		return nil
+	}
+	switch msg.String() {
+	case "esc", "q":
+		if d.Query != "" {
+			d.SetQuery("")
+			return nil
+		}
+		m.mode = ModeNormal
+	case "/":
+		d.Searching = true
+	case "up", "k":
+		d.Scroll(-1, height)
+	case "down", "j":
+		d.Scroll(1, height)
+	case "pgup", "ctrl+u":
+		d.Scroll(-height/2, height)
+	case "pgdown", "ctrl+d":
+		d.Scroll(height/2, height)
+	case "home", "g":
+		d.Scroll(-len(d.Rows()), height)
+	case "end", "G":
+		d.Scroll(len(d.Rows()), height)
+	case "e":
+		if d.Loading || d.Diff.Base == "" {
+			return nil
+		}
+		path, err := exportSettingsDiff(m.cfg.ResultBundlesPath, d.Diff)
+		if err != nil {
+			d.Message = "Export failed: " + err.Error()
+		} else {
+			d.Message = "Exported to " + path
+		}
+	}
+	return nil
+}
+
+// exportSettingsDiff writes the full diff as text next to the result bundles
+func exportSettingsDiff(dir string, diff core.BuildSettingsDiff) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, time.Now().Format("20060102-150405")+"-settings-diff.txt")
+	if err := os.WriteFile(path, []byte(core.FormatBuildSettingsDiff(diff)), 0o644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+func (m Model) settingsDiffOverlayView() string {
+	width := m.width * 80 / 100
+	if width < 70 {
+		width = 70
+	}
+	if width > 160 {
+		width = 160
+	}
+	return RenderCenteredPopup(m.settingsDiff.View(width, m.settingsDiffHeight(), m.styles), m.width, m.height)
+}
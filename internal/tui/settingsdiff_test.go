@@ -0,0 +1,100 @@
+package tui
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+var testSettingsDiff = core.BuildSettingsDiff{
+	Base:     "Debug",
+	Other:    "Release",
+	Changed:  []core.BuildSettingChange{{Key: "SWIFT_OPTIMIZATION_LEVEL", From: "-Onone", To: "-O"}},
+	Added:    []core.BuildSettingChange{{Key: "VALIDATE_PRODUCT", To: "YES"}},
+	Removed:  []core.BuildSettingChange{{Key: "ENABLE_TESTABILITY", From: "YES"}},
+	PathOnly: 4,
+}
+
+func TestSettingsDiffOverlaySearchAndColumns(t *testing.T) {
+	d := SettingsDiffOverlay{Diff: testSettingsDiff}
+	if rows := d.Rows(); len(rows) != 3 || rows[0].Kind != "changed" || rows[2].Kind != "removed" {
+		t.Fatalf("unexpected rows %+v", rows)
+	}
+
+	d.SetQuery("yes")
+	if rows := d.Rows(); len(rows) != 2 {
+		t.Fatalf("search should match values too, got %+v", rows)
+	}
+
+	d.SetQuery("")
+	out := stripANSI(d.View(100, 10, DefaultStyles()))
+	for _, want := range []string{"Debug ↔ Release", "SWIFT_OPTIMIZATION_LEVEL", "4 DerivedData-only"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("overlay missing %q:\n%s", want, out)
+		}
+	}
+	var fromCol, toCol []int
+	for _, ln := range strings.Split(out, "\n") {
+		if strings.Contains(ln, "Debug") && strings.Contains(ln, "Release") && !strings.Contains(ln, "↔") {
+			fromCol, toCol = append(fromCol, strings.Index(ln, "Debug")), append(toCol, strings.Index(ln, "Release"))
+		}
+		if strings.Contains(ln, "-Onone") {
+			fromCol, toCol = append(fromCol, strings.Index(ln, "-Onone")), append(toCol, strings.LastIndex(ln, "-O "))
+		}
+	}
+	if len(fromCol) != 2 || fromCol[0] != fromCol[1] || toCol[0] != toCol[1] {
+		t.Fatalf("columns not aligned (%v, %v):\n%s", fromCol, toCol, out)
+	}
+}
+
+func TestSettingsDiffOverlayScrollClamps(t *testing.T) {
+	d := SettingsDiffOverlay{Diff: testSettingsDiff}
+	d.Scroll(5, 2)
+	if d.Offset != 1 {
+		t.Fatalf("offset = %d, want 1", d.Offset)
+	}
+	d.Scroll(-5, 2)
+	if d.Offset != 0 {
+		t.Fatalf("offset = %d, want 0", d.Offset)
+	}
+}
+
+func TestSettingsDiffKeysSearchAndExport(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.cfg.ResultBundlesPath = t.TempDir()
+	m.mode = ModeSettingsDiff
+	m.handleSettingsDiff(settingsDiffMsg{diff: testSettingsDiff})
+
+	m.handleSettingsDiffKey(keyMsgFor("/"))
+	for _, r := range "valid" {
+		m.handleSettingsDiffKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if rows := m.settingsDiff.Rows(); len(rows) != 1 || rows[0].Change.Key != "VALIDATE_PRODUCT" {
+		t.Fatalf("search not applied: %+v", rows)
+	}
+	m.handleSettingsDiffKey(keyMsgFor("enter"))
+
+	m.handleSettingsDiffKey(keyMsgFor("e"))
+	path := strings.TrimPrefix(m.settingsDiff.Message, "Exported to ")
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("export not written (%q): %v", m.settingsDiff.Message, err)
+	}
+	if !strings.Contains(string(b), "ENABLE_TESTABILITY") {
+		t.Fatalf("export should hold the full diff, not the search results:\n%s", b)
+	}
+
+	m.handleSettingsDiffKey(keyMsgFor("esc"))
+	if m.mode != ModeSettingsDiff || m.settingsDiff.Query != "" {
+		t.Fatalf("first esc should clear the search")
+	}
+	m.handleSettingsDiffKey(keyMsgFor("esc"))
+	if m.mode != ModeNormal {
+		t.Fatalf("second esc should close the overlay")
+	}
+}
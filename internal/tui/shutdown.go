@@ -0,0 +1,87 @@
+package tui
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// =============================================================================
+// Graceful Shutdown
+// =============================================================================
+
+// shutdownGrace bounds how long quitting waits for a canceled op to exit
+const shutdownGrace = 3 * time.Second
+
+// quitGracefully cancels an in-flight op and quits once its goroutine has
+// returned (or shutdownGrace elapses), so xcodebuild/simctl children do not
+// outlive the TUI. Apps launched by an interrupted run are terminated too.
+func (m *Model) quitGracefully() tea.Cmd {
+	m.cancelStorageCheck()
+	if !m.running {
+		return tea.Quit
+	}
+	exited := m.opExited
+	wasRun := m.runningCmd == "run"
+	since := m.opStart
+	root := m.projectRoot
+	preserveLocale := m.cfg.Xcodebuild.PreserveLocale
+	// A hot rerun's replaced run is still attached to the previous app
+	var replacedExited <-chan struct{}
+	if r := m.replacedRun; r != nil {
+		r.cancel()
+		replacedExited = r.exited
+		since = r.started
+	}
+
+	m.quitting = true
+	m.pendingOp = ""
+	m.cancelRunningOp()
+	m.setStatus("Stopping… (q again to force quit)")
+
+	return func() tea.Msg {
+		waitOpExit(exited, shutdownGrace)
+		waitOpExit(replacedExited, shutdownGrace)
+		if wasRun {
+			stopSessionsSince(root, since, preserveLocale)
+		}
+		return tea.Quit()
+	}
+}
+
+// waitOpExit blocks until exited is closed or timeout passes. Reports
+// whether the op exited in time.
+func waitOpExit(exited <-chan struct{}, timeout time.Duration) bool {
+	if exited == nil {
+		return true
+	}
+	select {
+	case <-exited:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// stopSessionsSince terminates apps whose sessions were recorded at or after
+// since, i.e. launched by the op being shut down.
+func stopSessionsSince(projectRoot string, since time.Time, preserveLocale bool) {
+	sessions, err := core.LoadSessions(projectRoot)
+	if err != nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for _, sess := range sessions.Items {
+		started := parseSessionTime(sess.StartedAt)
+		if started.IsZero() || started.Before(since) {
+			continue
+		}
+		if err := stopTargetApp(ctx, sessionStopTarget(sess), preserveLocale); err == nil {
+			_ = core.RemoveSession(projectRoot, sess.ID)
+		}
+	}
+}
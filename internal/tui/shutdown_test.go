@@ -0,0 +1,63 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestQuitGracefullyWaitsForOp(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+
+	exited := make(chan struct{})
+	canceled := make(chan struct{})
+	m.running = true
+	m.runningCmd = "build"
+	m.opExited = exited
+	m.cancelFn = func() { close(canceled) }
+	m.pendingOp = "test"
+
+	cmd := m.quitGracefully()
+	if !m.quitting || m.pendingOp != "" {
+		t.Fatalf("expected quitting with pending op dropped")
+	}
+	select {
+	case <-canceled:
+	default:
+		t.Fatal("op was not canceled")
+	}
+
+	// Keys other than quit are ignored while shutting down.
+	if m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")}) != nil {
+		t.Fatal("expected keys to be ignored while quitting")
+	}
+
+	got := make(chan tea.Msg, 1)
+	go func() { got <- cmd() }()
+	select {
+	case <-got:
+		t.Fatal("quit did not wait for the op to exit")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(exited)
+	select {
+	case msg := <-got:
+		if _, ok := msg.(tea.QuitMsg); !ok {
+			t.Fatalf("expected tea.QuitMsg, got %T", msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("quit did not complete after the op exited")
+	}
+}
+
+func TestWaitOpExitTimesOut(t *testing.T) {
+	if waitOpExit(make(chan struct{}), 10*time.Millisecond) {
+		t.Fatal("expected timeout")
+	}
+	if !waitOpExit(nil, time.Second) {
+		t.Fatal("nil channel means nothing to wait for")
+	}
+}
@@ -0,0 +1,56 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// =============================================================================
+// Stale DerivedData
+// =============================================================================
+
+// staleDerivedData returns the Xcode that built the DerivedData req would
+// use, when it isn't the active Xcode and the user wasn't asked about it yet
+func (m *Model) staleDerivedData(req core.LastOp) (core.XcodeVersion, bool) {
+	switch {
+	case req.Name != "build" && req.Name != "run" && req.Name != "test",
+		req.LaunchOnly,
+		m.cfg.Xcodebuild.DryRun,
+		m.cfg.Xcodebuild.AutoCleanOnXcodeChange, // core cleans it
+		m.info.Xcode.Label() == "",
+		m.staleAsked == m.info.Xcode.Label():
+		return core.XcodeVersion{}, false
+	}
+	cfg := m.cfg
+	if req.Destination.ID != "" || req.Destination.UDID != "" {
+		cfg.Destination = req.Destination
+	}
+	return core.StaleDerivedData(core.DerivedDataDir(cfg), m.info.Xcode)
+}
+
+// confirmStaleDerivedData offers to clean DerivedData built by another
+// Xcode before starting req
+func (m *Model) confirmStaleDerivedData(req core.LastOp, built core.XcodeVersion) {
+	active := m.info.Xcode.Label()
+	start := func(clean bool) func(m *Model) tea.Cmd {
+		return func(m *Model) tea.Cmd {
+			m.staleAsked = active
+			m.cleanStale = clean
+			return m.startOpRequest(req)
+		}
+	}
+	m.openConfirm(ConfirmPrompt{
+		Title: "Clean DerivedData?",
+		Body: []string{
+			"DerivedData was built with " + built.Label() + ",",
+			"but " + active + " is active.",
+			"Stale module caches from another Xcode cause odd build errors.",
+		},
+		Warn:    []string{"Cleaning means a full rebuild."},
+		OnYes:   start(true),
+		OnNo:    start(false),
+		YesHint: "clean and " + req.Name,
+		NoHint:  req.Name + " anyway",
+	})
+}
@@ -0,0 +1,76 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func newStaleDerivedDataModel(t *testing.T) Model {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.cfg.DerivedDataPath = t.TempDir()
+	m.info.Xcode = core.XcodeVersion{Version: "16.0", Build: "16A242d"}
+	marker := `{"version":"15.4","build":"15F31d"}`
+	if err := os.WriteFile(filepath.Join(m.cfg.DerivedDataPath, ".xcbolt-xcode.json"), []byte(marker), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestStaleDerivedDataAsksBeforeBuilding(t *testing.T) {
+	m := newStaleDerivedDataModel(t)
+	if cmd := m.startOpRequest(core.LastOp{Name: "build"}); cmd != nil || m.running {
+		t.Fatal("build started without asking")
+	}
+	if m.mode != ModeConfirm || m.confirm.OnNo == nil {
+		t.Fatalf("mode = %v, want the clean prompt", m.mode)
+	}
+	body := strings.Join(m.confirm.Body, " ")
+	if !strings.Contains(body, "Xcode 15.4 (15F31d)") || !strings.Contains(body, "Xcode 16.0 (16A242d)") {
+		t.Fatalf("body = %q", body)
+	}
+
+	// esc cancels: nothing runs, and the next build asks again
+	m.handleConfirmKey(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.mode != ModeNormal || m.running {
+		t.Fatalf("esc: mode = %v, running = %v", m.mode, m.running)
+	}
+	if _, stale := m.staleDerivedData(core.LastOp{Name: "build"}); !stale {
+		t.Fatal("canceling should not dismiss the prompt")
+	}
+
+	// Asked once per active Xcode
+	m.staleAsked = m.info.Xcode.Label()
+	if _, stale := m.staleDerivedData(core.LastOp{Name: "build"}); stale {
+		t.Fatal("asked again for the same Xcode")
+	}
+}
+
+func TestStaleDerivedDataSkipped(t *testing.T) {
+	m := newStaleDerivedDataModel(t)
+	for _, req := range []core.LastOp{{Name: "clean"}, {Name: "run", LaunchOnly: true}} {
+		if _, stale := m.staleDerivedData(req); stale {
+			t.Errorf("%+v should not ask", req)
+		}
+	}
+	m.cfg.Xcodebuild.AutoCleanOnXcodeChange = true
+	if _, stale := m.staleDerivedData(core.LastOp{Name: "test"}); stale {
+		t.Error("autoCleanOnXcodeChange cleans without asking")
+	}
+}
+
+func TestStaleModuleCacheAnalysis(t *testing.T) {
+	m := newFailedBuildModel(t, core.AutoSwitchNever)
+	m.tabView.AddRawLine("/src/App.swift:1:8: error: module compiled with Swift 5.10 cannot be imported by the Swift 6.0 compiler: /dd/Foo.swiftmodule")
+	analysis := m.tabView.IssuesTab.generateAnalysis(m.tabView.IssuesTab.getByType(IssueTypeError))
+	if !strings.Contains(analysis, "Clean DerivedData") {
+		t.Fatalf("analysis = %q", analysis)
+	}
+}
@@ -19,15 +19,23 @@ type StatusBar struct {
 	GitBranch     string
 	Scheme        string
 	Configuration string
+	TestPlan      string // Active test plan ("" = scheme default)
 	Destination   string
 	DestOS        string
-	DryRun        bool
+	// DestUnavailable marks a destination that isn't connected or listed
+	DestUnavailable bool
+	DryRun          bool
+	XcodeMismatch   string // Active Xcode label when it misses requiredXcode
 
 	// Running state
 	Running    bool
 	RunningCmd string
 	Stage      string
 	Progress   string
+	// Stress is the iteration progress of a stress test run
+	Stress string
+	// Preset is the launch preset of a running run
+	Preset string
 
 	// Last result (shown when not running)
 	HasLastResult     bool
@@ -40,6 +48,9 @@ type StatusBar struct {
 	ErrorCount   int
 	WarningCount int
 
+	// Live test outcomes while a test op runs
+	Tests TestCounts
+
 	// Spinner for animation
 	Spinner spinner.Model
 }
@@ -146,6 +157,10 @@ func (s StatusBar) renderMinimalView(width int, styles Styles, icons Icons) stri
 		device = device[:12] + "..."
 	}
 	deviceStyle := lipgloss.NewStyle().Foreground(styles.Colors.Text)
+	if s.DestUnavailable && s.Destination != "" {
+		device = icons.Warning + " " + device
+		deviceStyle = styles.StatusStyle("warning")
+	}
 
 	// Status indicator
 	var status string
@@ -155,6 +170,8 @@ func (s StatusBar) renderMinimalView(width int, styles Styles, icons Icons) stri
 		switch s.LastResultStatus {
 		case "canceled":
 			status = icons.Paused
+		case "timed_out":
+			status = icons.Warning
 		case "success":
 			status = icons.Success
 		case "error":
@@ -236,6 +253,12 @@ func (s StatusBar) renderCenterSection(styles Styles) string {
 		parts = append(parts, sep, confStyle.Render(s.Configuration))
 	}
 
+	// Test plan
+	if s.TestPlan != "" {
+		planStyle := lipgloss.NewStyle().Foreground(styles.Colors.TextMuted)
+		parts = append(parts, sep, planStyle.Render("plan:"+s.TestPlan))
+	}
+
 	// Destination
 	destText := "No destination"
 	destStyle := lipgloss.NewStyle().Foreground(styles.Colors.TextMuted)
@@ -247,20 +270,36 @@ func (s StatusBar) renderCenterSection(styles Styles) string {
 		destStyle = lipgloss.NewStyle().Foreground(styles.Colors.Text)
 	}
 	parts = append(parts, sep, destStyle.Render(destText))
+	if s.DestUnavailable && s.Destination != "" {
+		parts = append(parts, " ", styles.StatusStyle("warning").Render(styles.Icons.Warning+" unavailable"))
+	}
 
 	if s.DryRun {
 		dryStyle := styles.StatusStyle("warning")
 		parts = append(parts, sep, dryStyle.Render("DRY RUN"))
 	}
 
+	if s.XcodeMismatch != "" {
+		parts = append(parts, sep, styles.StatusStyle("warning").Render(styles.Icons.Warning+" "+s.XcodeMismatch))
+	}
+
 	return lipgloss.JoinHorizontal(lipgloss.Center, parts...)
 }
 
 // renderRightSection renders status indicator (spinner or result)
 func (s StatusBar) renderRightSection(styles Styles, icons Icons) string {
 	if s.Running {
-		// Running: show icon only
+		// Running: show icon only (plus the test counter while testing)
 		icon := styles.StatusStyle("running").Render(icons.Run)
+		if s.Stress != "" {
+			icon += " " + lipgloss.NewStyle().Foreground(styles.Colors.TextMuted).Render(s.Stress)
+		}
+		if s.Preset != "" {
+			icon += " " + lipgloss.NewStyle().Foreground(styles.Colors.TextMuted).Render("preset:"+s.Preset)
+		}
+		if s.RunningCmd == "test" && s.Tests.Any() {
+			return icon + " " + s.Tests.View(styles)
+		}
 		return icon
 	}
 
@@ -287,6 +326,9 @@ func (s StatusBar) renderRightSection(styles Styles, icons Icons) string {
 		case "canceled":
 			resultIcon = icons.Paused
 			resultStatus = "canceled"
+		case "timed_out":
+			resultIcon = icons.Warning
+			resultStatus = "warning"
 		case "error":
 			resultIcon = icons.Error
 			resultStatus = "error"
@@ -0,0 +1,219 @@
+package tui
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// =============================================================================
+// Status Server
+// =============================================================================
+
+// StatusSnapshot is the JSON body served at GET /status
+type StatusSnapshot = core.StatusSnapshot
+
+// StatusProgress mirrors the progress bar
+type StatusProgress = core.StatusProgress
+
+// StatusLastResult describes the most recent finished operation
+type StatusLastResult = core.StatusLastResult
+
+// statusServer serves a snapshot of the Model over HTTP. The Model only ever
+// does non-blocking channel sends; a dedicated goroutine owns the updates.
+type statusServer struct {
+	mu   sync.RWMutex
+	snap StatusSnapshot
+
+	snapCh  chan StatusSnapshot
+	eventCh chan core.Event
+	done    chan struct{}
+
+	subsMu sync.Mutex
+	subs   map[chan core.Event]struct{}
+
+	srv  *http.Server
+	addr string
+}
+
+// startStatusServer listens on bind:port and serves /status and /events
+func startStatusServer(bind string, port int) (*statusServer, error) {
+	if bind == "" {
+		bind = "127.0.0.1"
+	}
+	ln, err := net.Listen("tcp", net.JoinHostPort(bind, strconv.Itoa(port)))
+	if err != nil {
+		return nil, fmt.Errorf("status server: %w", err)
+	}
+
+	s := &statusServer{
+		snapCh:  make(chan StatusSnapshot, 1),
+		eventCh: make(chan core.Event, 256),
+		done:    make(chan struct{}),
+		subs:    map[chan core.Event]struct{}{},
+		addr:    ln.Addr().String(),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /status", s.handleStatus)
+	mux.HandleFunc("GET /events", s.handleEvents)
+	s.srv = &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+
+	go s.loop()
+	go func() { _ = s.srv.Serve(ln) }()
+	return s, nil
+}
+
+// Publish replaces the snapshot; older pending snapshots are dropped
+func (s *statusServer) Publish(snap StatusSnapshot) {
+	if s == nil {
+		return
+	}
+	for {
+		select {
+		case s.snapCh <- snap:
+			return
+		default:
+		}
+		select {
+		case <-s.snapCh:
+		default:
+		}
+	}
+}
+
+// Relay forwards an event to /events subscribers, dropping it when backed up
+func (s *statusServer) Relay(ev core.Event) {
+	if s == nil {
+		return
+	}
+	select {
+	case s.eventCh <- ev:
+	default:
+	}
+}
+
+// Close stops the server and disconnects subscribers
+func (s *statusServer) Close() {
+	if s == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	close(s.done)
+	_ = s.srv.Shutdown(ctx)
+}
+
+func (s *statusServer) loop() {
+	for {
+		select {
+		case <-s.done:
+			return
+		case snap := <-s.snapCh:
+			s.mu.Lock()
+			s.snap = snap
+			s.mu.Unlock()
+		case ev := <-s.eventCh:
+			s.subsMu.Lock()
+			for ch := range s.subs {
+				select {
+				case ch <- ev:
+				default:
+				}
+			}
+			s.subsMu.Unlock()
+		}
+	}
+}
+
+func (s *statusServer) snapshot() StatusSnapshot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.snap
+}
+
+func (s *statusServer) handleStatus(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(s.snapshot())
+}
+
+func (s *statusServer) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	flusher.Flush()
+
+	ch := make(chan core.Event, 64)
+	s.subsMu.Lock()
+	s.subs[ch] = struct{}{}
+	s.subsMu.Unlock()
+	defer func() {
+		s.subsMu.Lock()
+		delete(s.subs, ch)
+		s.subsMu.Unlock()
+	}()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-s.done:
+			return
+		case ev := <-ch:
+			b, err := json.Marshal(ev)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", ev.Type, b)
+			flusher.Flush()
+		}
+	}
+}
+
+// statusSnapshot captures the Model fields exposed by the status server
+func (m *Model) statusSnapshot() StatusSnapshot {
+	snap := StatusSnapshot{
+		Project:      m.projectRoot,
+		Scheme:       m.cfg.Scheme,
+		Destination:  m.cfg.Destination.Name,
+		Running:      m.running,
+		Op:           m.runningCmd,
+		Stage:        m.currentStage,
+		Progress:     StatusProgress{Current: m.progressCur, Total: m.progressTotal},
+		ErrorCount:   m.tabView.Counts.ErrorCount,
+		WarningCount: m.tabView.Counts.WarningCount,
+	}
+	if m.running {
+		snap.Preset = m.launchPreset
+	}
+	if m.statusBar.HasLastResult {
+		snap.LastResult = &StatusLastResult{
+			Op:       m.statusBar.LastResultOp,
+			Success:  m.statusBar.LastResultStatus == "success",
+			Duration: m.statusBar.LastResultTime,
+		}
+	}
+	return snap
+}
+
+// publishStatus pushes the current snapshot to the status server and the
+// status file when they are enabled
+func (m *Model) publishStatus() {
+	if m.status == nil && m.statusFile == nil {
+		return
+	}
+	snap := m.statusSnapshot()
+	m.status.Publish(snap)
+	m.statusFile.Publish(snap)
+}
@@ -0,0 +1,92 @@
+package tui
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func TestStatusServerServesSnapshot(t *testing.T) {
+	s, err := startStatusServer("", 0)
+	if err != nil {
+		t.Fatalf("startStatusServer: %v", err)
+	}
+	defer s.Close()
+	if !strings.HasPrefix(s.addr, "127.0.0.1:") {
+		t.Fatalf("expected localhost bind, got %s", s.addr)
+	}
+
+	s.Publish(StatusSnapshot{Scheme: "App", Running: true, Op: "build", ErrorCount: 2})
+
+	var got StatusSnapshot
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		resp, err := http.Get("http://" + s.addr + "/status")
+		if err != nil {
+			t.Fatalf("GET /status: %v", err)
+		}
+		err = json.NewDecoder(resp.Body).Decode(&got)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if got.Running {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !got.Running || got.Op != "build" || got.Scheme != "App" || got.ErrorCount != 2 {
+		t.Fatalf("unexpected snapshot: %+v", got)
+	}
+}
+
+func TestStatusServerRelaysEvents(t *testing.T) {
+	s, err := startStatusServer("127.0.0.1", 0)
+	if err != nil {
+		t.Fatalf("startStatusServer: %v", err)
+	}
+	defer s.Close()
+
+	resp, err := http.Get("http://" + s.addr + "/events")
+	if err != nil {
+		t.Fatalf("GET /events: %v", err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("unexpected content type %q", ct)
+	}
+
+	lines := make(chan string, 8)
+	go func() {
+		sc := bufio.NewScanner(resp.Body)
+		for sc.Scan() {
+			lines <- sc.Text()
+		}
+		close(lines)
+	}()
+
+	// The subscription is registered asynchronously; keep relaying until seen.
+	tick := time.NewTicker(20 * time.Millisecond)
+	defer tick.Stop()
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case <-tick.C:
+			s.Relay(core.Log("build", "Compiling App.swift"))
+		case line, ok := <-lines:
+			if !ok {
+				t.Fatalf("stream closed early")
+			}
+			if strings.HasPrefix(line, "data: ") && strings.Contains(line, "Compiling App.swift") {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("no event received")
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// storageMsg carries the result of a background storage check
+type storageMsg struct {
+	report core.StorageReport
+	err    error
+}
+
+// checkStorage measures xcbolt's directories and free space in the
+// background, canceling a check still in flight
+func (m *Model) checkStorage() tea.Cmd {
+	m.cancelStorageCheck()
+	ctx, cancel := context.WithCancel(context.Background())
+	m.storageCancel = cancel
+	root, cfg := m.projectRoot, m.cfg
+	return func() tea.Msg {
+		report, err := core.MeasureStorage(ctx, root, cfg)
+		return storageMsg{report: report, err: err}
+	}
+}
+
+func (m *Model) cancelStorageCheck() {
+	if m.storageCancel != nil {
+		m.storageCancel()
+		m.storageCancel = nil
+	}
+}
+
+// handleStorage shows the report and warns when free space drops below
+// diskSpaceWarnGB (once, until it recovers)
+func (m *Model) handleStorage(msg storageMsg) {
+	if msg.err != nil {
+		return // Canceled by a newer check
+	}
+	m.storageCancel = nil
+	m.storage = &msg.report
+	m.tabView.SummaryTab.SetStorage(storageSummary(msg.report))
+	warning := core.LowDiskSpaceWarning(msg.report, m.cfg)
+	if warning != "" && !m.lowDiskWarned {
+		m.handleEvent(core.Warn("storage", warning))
+	}
+	m.lowDiskWarned = warning != ""
+	if m.storagePending {
+		m.storagePending = false
+		m.openStorageSelector()
+	}
+}
+
+// storageSummary is the System card line, e.g.
+// "DerivedData 4.2 GB · Results 900.0 MB · SwiftPM 1.1 GB · 12.0 GB free"
+func storageSummary(r core.StorageReport) string {
+	var parts []string
+	var spm int64
+	spmPartial := false
+	for _, item := range r.Items {
+		if item.CleanOp == "clean-spm-cache" {
+			spm += item.Bytes
+			spmPartial = spmPartial || item.Partial
+			continue
+		}
+		parts = append(parts, item.Name+" "+storageSize(item.Bytes, item.Partial))
+	}
+	if spm > 0 {
+		parts = append(parts, "SwiftPM "+storageSize(spm, spmPartial))
+	}
+	if r.FreeBytes >= 0 {
+		parts = append(parts, core.FormatBytes(r.FreeBytes)+" free")
+	}
+	return strings.Join(parts, " · ")
+}
+
+// storageSize is FormatBytes with a "+" when the walk was cut short
+func storageSize(n int64, partial bool) string {
+	if partial {
+		return core.FormatBytes(n) + "+"
+	}
+	return core.FormatBytes(n)
+}
+
+// StorageItems creates selector items from a storage report (ID = clean op)
+func StorageItems(r core.StorageReport, projectRoot string) []SelectorItem {
+	items := make([]SelectorItem, 0, len(r.Items))
+	for _, item := range r.Items {
+		items = append(items, SelectorItem{
+			ID:          item.CleanOp,
+			Title:       fmt.Sprintf("%-22s %s", item.Name, storageSize(item.Bytes, item.Partial)),
+			Description: relativeToRoot(projectRoot, item.Path),
+			Meta:        "[" + item.CleanOp + "]",
+			Dim:         item.Bytes == 0,
+		})
+	}
+	return items
+}
+
+// openStorage refreshes the storage report, then lists the sizes of
+// xcbolt's directories; Enter cleans the selected one (through the usual
+// confirmation)
+func (m *Model) openStorage() tea.Cmd {
+	m.storagePending = true
+	m.setStatus("Measuring storage…")
+	return m.checkStorage()
+}
+
+func (m *Model) openStorageSelector() {
+	if m.mode != ModeNormal || m.storage == nil {
+		return
+	}
+	m.setStatus("Storage measured")
+	title := "Storage"
+	if m.storage.FreeBytes >= 0 {
+		title += " · " + core.FormatBytes(m.storage.FreeBytes) + " free"
+	}
+	m.selector = NewSelector(title, StorageItems(*m.storage, m.projectRoot), m.width, m.styles)
+	m.selectorType = SelectorStorage
+	m.mode = ModeSelector
+}
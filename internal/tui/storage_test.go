@@ -0,0 +1,59 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func TestStorageSummary(t *testing.T) {
+	r := core.StorageReport{
+		Items: []core.StorageItem{
+			{Name: "DerivedData", Bytes: 4509715660, CleanOp: "clean-derived"},
+			{Name: "Results", Bytes: 943718400, CleanOp: "clean-results"},
+			{Name: "SwiftPM org.swift.swiftpm", Bytes: 1 << 30, CleanOp: "clean-spm-cache"},
+			{Name: "SwiftPM SourcePackages", Bytes: 1 << 29, Partial: true, CleanOp: "clean-spm-cache"},
+		},
+		FreeBytes: 12 << 30,
+	}
+	want := "DerivedData 4.2 GB · Results 900.0 MB · SwiftPM 1.5 GB+ · 12.0 GB free"
+	if got := storageSummary(r); got != want {
+		t.Fatalf("got  %q\nwant %q", got, want)
+	}
+}
+
+func TestHandleStorageWarnsOnceAndOpensSelector(t *testing.T) {
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	low := core.StorageReport{
+		Items:     []core.StorageItem{{Name: "DerivedData", Bytes: 1 << 20, CleanOp: "clean-derived"}},
+		FreeBytes: 2 << 30,
+	}
+	warnings := func() int {
+		n := 0
+		for _, line := range m.tabView.StreamTab.Lines {
+			if strings.Contains(line.Text, "Low disk space") {
+				n++
+			}
+		}
+		return n
+	}
+
+	m.handleStorage(storageMsg{report: low})
+	m.handleStorage(storageMsg{report: low})
+	if got := warnings(); got != 1 {
+		t.Fatalf("low disk warnings = %d, want 1", got)
+	}
+	if !strings.Contains(m.tabView.SummaryTab.Storage, "2.0 GB free") {
+		t.Fatalf("System card storage = %q", m.tabView.SummaryTab.Storage)
+	}
+
+	m.storagePending = true
+	m.handleStorage(storageMsg{report: low})
+	if m.mode != ModeSelector || m.selectorType != SelectorStorage {
+		t.Fatalf("mode = %v, selector = %v", m.mode, m.selectorType)
+	}
+	if len(m.selector.items) != 1 || m.selector.items[0].ID != "clean-derived" {
+		t.Fatalf("items = %+v", m.selector.items)
+	}
+}
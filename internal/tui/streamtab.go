@@ -2,11 +2,15 @@ package tui
 
 import (
 	"fmt"
+	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/xcbolt/xcbolt/internal/core"
 )
 
 // =============================================================================
@@ -19,6 +23,10 @@ type StreamLine struct {
 	Timestamp time.Time
 	Type      TabLineType
 	Raw       string // Original unformatted text
+	ID        uint64 // Monotonic per tab; survives ring-buffer trimming
+	// CompileTime is how long the file this line compiles took
+	// (xcodebuild.timingReport), shown after the text
+	CompileTime time.Duration
 }
 
 // StreamTab displays the live log stream with enhancements
@@ -29,12 +37,44 @@ type StreamTab struct {
 	ScrollPos   int
 	AutoFollow  bool
 	VisibleRows int
+	// NewLines counts the lines added while scrolled up (not following)
+	NewLines int
+
+	// Line identity and bookmark jump highlight
+	nextID      uint64
+	HighlightID uint64
 
 	// Display settings
 	ShowLineNumbers bool
 	ShowTimestamps  bool
 	PathStyle       string // "full", "short", "filename"
 
+	// Errors-only filter: issue lines plus ContextLines around each
+	ErrorsOnly   bool
+	ContextLines int
+	rowsCache    []int
+	rowsKey      streamRowsKey
+	clusterID    uint64 // First line of the last cluster jumped to
+	phaseID      uint64 // Phase header last jumped to
+
+	// Error and warning line IDs in order, for the scrollbar markers
+	issueIDs []uint64
+	// Markers of the filtered rows, cached with rowsKey
+	markerCache []scrollMarker
+	markerKey   streamRowsKey
+
+	// Phase headers whose lines are hidden, by line ID
+	collapsed    map[uint64]bool
+	collapsedGen int
+	// muter marks muted phase headers (nil = none)
+	muter *phaseMuter
+
+	// Line index shown on each row in the last View pass (-1 for none)
+	shownRows []int
+
+	// Linker turns file locations into OSC 8 links (nil = off)
+	Linker *Linker
+
 	// Dimensions
 	Width  int
 	Height int
@@ -54,6 +94,7 @@ func NewStreamTab() *StreamTab {
 		ShowLineNumbers: true,
 		ShowTimestamps:  false,
 		PathStyle:       "full", // Don't shorten paths - show full for clarity
+		ContextLines:    core.DefaultIssueContextLines,
 		// Match file paths: /path/to/file.ext or /path/to/dir (with optional :line:col)
 		filePathRegex: regexp.MustCompile(`(/[^\s:]+(?:\.[a-zA-Z0-9]+)?)(?::(\d+)(?::(\d+))?)?`),
 		// Match URLs: https://... or http://...
@@ -63,6 +104,14 @@ func NewStreamTab() *StreamTab {
 	}
 }
 
+// streamRowsKey identifies the buffer state rowsCache was computed for
+type streamRowsKey struct {
+	firstID, lastID uint64
+	context         int
+	errorsOnly      bool
+	collapsedGen    int
+}
+
 // SetSize updates dimensions
 func (st *StreamTab) SetSize(width, height int) {
 	st.Width = width
@@ -75,20 +124,45 @@ func (st *StreamTab) Clear() {
 	st.Lines = st.Lines[:0]
 	st.ScrollPos = 0
 	st.AutoFollow = true
+	st.NewLines = 0
+	st.rowsCache = nil
+	st.rowsKey = streamRowsKey{}
+	st.issueIDs = st.issueIDs[:0]
+	st.markerCache = nil
+	st.clusterID = 0
+	st.phaseID = 0
+	st.collapsed = nil
+	st.shownRows = nil
 }
 
-// AddLine adds a new line to the stream
+// streamTimestampLayout formats line timestamps in the gutter and in copies
+const streamTimestampLayout = "15:04:05"
+
+// AddLine adds a new line to the stream, stamped with the current time
 func (st *StreamTab) AddLine(text string, lineType TabLineType) {
+	st.AddLineAt(text, lineType, time.Now())
+}
+
+// AddLineAt adds a new line stamped with the time it was emitted
+func (st *StreamTab) AddLineAt(text string, lineType TabLineType, at time.Time) {
+	st.nextID++
 	line := StreamLine{
 		Text:      text,
-		Timestamp: time.Now(),
+		Timestamp: at,
 		Type:      lineType,
 		Raw:       text,
+		ID:        st.nextID,
 	}
 	st.Lines = append(st.Lines, line)
+	if lineType == TabLineTypeError || lineType == TabLineTypeWarning {
+		st.issueIDs = append(st.issueIDs, line.ID)
+	}
 	if maxStreamTabLines > 0 && len(st.Lines) > maxStreamTabLines {
 		drop := len(st.Lines) - maxStreamTabLines
 		st.Lines = st.Lines[drop:]
+		for len(st.issueIDs) > 0 && st.issueIDs[0] < st.Lines[0].ID {
+			st.issueIDs = st.issueIDs[1:]
+		}
 		if !st.AutoFollow {
 			st.ScrollPos -= drop
 			if st.ScrollPos < 0 {
@@ -100,6 +174,8 @@ func (st *StreamTab) AddLine(text string, lineType TabLineType) {
 	// Auto-scroll if following
 	if st.AutoFollow {
 		st.ScrollPos = st.maxScrollPos()
+	} else {
+		st.NewLines++
 	}
 }
 
@@ -108,7 +184,7 @@ func (st *StreamTab) AddLine(text string, lineType TabLineType) {
 // =============================================================================
 
 func (st *StreamTab) maxScrollPos() int {
-	max := len(st.Lines) - st.VisibleRows
+	max := st.rowCount() - st.VisibleRows
 	if max < 0 {
 		return 0
 	}
@@ -118,6 +194,8 @@ func (st *StreamTab) maxScrollPos() int {
 // ScrollUp scrolls up by n lines
 func (st *StreamTab) ScrollUp(n int) {
 	st.AutoFollow = false
+	st.clusterID = 0
+	st.phaseID = 0
 	st.ScrollPos -= n
 	if st.ScrollPos < 0 {
 		st.ScrollPos = 0
@@ -126,24 +204,77 @@ func (st *StreamTab) ScrollUp(n int) {
 
 // ScrollDown scrolls down by n lines
 func (st *StreamTab) ScrollDown(n int) {
+	st.clusterID = 0
+	st.phaseID = 0
 	st.ScrollPos += n
 	max := st.maxScrollPos()
 	if st.ScrollPos >= max {
 		st.ScrollPos = max
 		st.AutoFollow = true
+		st.NewLines = 0
 	}
 }
 
 // GotoTop scrolls to the top
 func (st *StreamTab) GotoTop() {
 	st.AutoFollow = false
+	st.clusterID = 0
+	st.phaseID = 0
 	st.ScrollPos = 0
 }
 
 // GotoBottom scrolls to the bottom
 func (st *StreamTab) GotoBottom() {
+	st.clusterID = 0
+	st.phaseID = 0
 	st.ScrollPos = st.maxScrollPos()
 	st.AutoFollow = true
+	st.NewLines = 0
+}
+
+// TopLine returns the line at the top of the view (the one copied with y)
+func (st *StreamTab) TopLine() (StreamLine, int, bool) {
+	if len(st.Lines) == 0 {
+		return StreamLine{}, 0, false
+	}
+	if st.filtered() {
+		rows := st.rows()
+		for pos := maxInt(0, st.ScrollPos); pos < len(rows); pos++ {
+			if idx := rows[pos]; idx >= 0 {
+				return st.Lines[idx], idx, true
+			}
+		}
+		return StreamLine{}, 0, false
+	}
+	idx := st.ScrollPos
+	if idx >= len(st.Lines) {
+		idx = len(st.Lines) - 1
+	}
+	return st.Lines[idx], idx, true
+}
+
+// IndexOfID returns the current index of the line with the given ID, or -1
+// once it has been trimmed
+func (st *StreamTab) IndexOfID(id uint64) int {
+	i := sort.Search(len(st.Lines), func(i int) bool { return st.Lines[i].ID >= id })
+	if i < len(st.Lines) && st.Lines[i].ID == id {
+		return i
+	}
+	return -1
+}
+
+// ScrollToIndex stops following and puts line idx at the top of the view
+func (st *StreamTab) ScrollToIndex(idx int) {
+	st.AutoFollow = false
+	st.clusterID = 0
+	st.phaseID = 0
+	st.ScrollPos = st.rowForLine(idx)
+	if max := st.maxScrollPos(); st.ScrollPos > max {
+		st.ScrollPos = max
+	}
+	if st.ScrollPos < 0 {
+		st.ScrollPos = 0
+	}
 }
 
 // =============================================================================
@@ -152,15 +283,21 @@ func (st *StreamTab) GotoBottom() {
 
 // View renders the stream tab content
 func (st *StreamTab) View(styles Styles) string {
+	st.shownRows = st.shownRows[:0]
 	if len(st.Lines) == 0 {
 		return st.emptyView(styles)
 	}
 
+	if st.ErrorsOnly && len(st.rows()) == 0 {
+		return st.emptyView(styles)
+	}
+
 	// Calculate visible range
+	total := st.rowCount()
 	start := st.ScrollPos
 	end := start + st.VisibleRows
-	if end > len(st.Lines) {
-		end = len(st.Lines)
+	if end > total {
+		end = total
 	}
 
 	// Render visible lines
@@ -182,8 +319,27 @@ func (st *StreamTab) View(styles Styles) string {
 		contentWidth = 1
 	}
 
-	for i := start; i < end; i++ {
+	separator := lipgloss.NewStyle().Foreground(styles.Colors.TextMuted).
+		Width(gutterWidth).Align(lipgloss.Right).Render(issueClusterSeparator)
+	for pos := start; pos < end; pos++ {
+		i := st.lineAtRow(pos)
+		st.shownRows = append(st.shownRows, i)
+		if i < 0 {
+			lines = append(lines, separator)
+			continue
+		}
 		line := st.Lines[i]
+		if line.CompileTime > 0 {
+			line.Text += "  (" + formatCompileTime(line.CompileTime) + ")"
+		}
+		if line.Type == TabLineTypePhaseHeader {
+			if label := st.mutedLabel(i); label != "" {
+				line.Text += "  " + label
+			}
+		}
+		if n := st.hiddenAfter(i); n > 0 {
+			line.Text += fmt.Sprintf("  [+%d hidden]", n)
+		}
 		rendered := st.renderLine(i+1, line, gutterWidth, contentWidth, styles)
 		lines = append(lines, rendered)
 	}
@@ -193,25 +349,21 @@ func (st *StreamTab) View(styles Styles) string {
 		lines = append(lines, "")
 	}
 
-	content := lipgloss.JoinVertical(lipgloss.Left, lines...)
-
-	// Add auto-follow indicator
-	if st.AutoFollow {
-		indicator := lipgloss.NewStyle().
-			Foreground(styles.Colors.Accent).
-			Render(" AUTO")
-		// Position in bottom-right (simplified - just append to last line)
-		_ = indicator // TODO: position properly
+	// Follow indicator, once the lines overflow the view
+	contentWidthTotal := st.Width - barWidth
+	if len(lines) > 0 && (total > st.VisibleRows || !st.AutoFollow) {
+		lines[0] = overlayTopRight(lines[0], followIndicator(st.AutoFollow, st.NewLines, styles), contentWidthTotal)
 	}
 
+	content := lipgloss.JoinVertical(lipgloss.Left, lines...)
+
 	if barWidth == 0 {
 		return content
 	}
-	contentWidthTotal := st.Width - barWidth
 	if contentWidthTotal < 1 {
 		return content
 	}
-	return withScrollbar(content, st.VisibleRows, contentWidthTotal, len(st.Lines), st.ScrollPos, styles)
+	return withScrollbar(content, st.VisibleRows, contentWidthTotal, total, st.ScrollPos, styles, st.markers()...)
 }
 
 // renderLine renders a single line with syntax highlighting
@@ -225,8 +377,13 @@ func (st *StreamTab) renderLine(lineNum int, line StreamLine, gutterWidth, conte
 		parts = append(parts, gutter)
 	}
 
-	// Content with syntax highlighting
-	content := st.highlightLine(line, contentWidth, styles)
+	// Content with syntax highlighting (reversed while flashing a bookmark jump)
+	var content string
+	if line.ID != 0 && line.ID == st.HighlightID {
+		content = flashStyle(styles).Render(truncateStreamText(line.Text, contentWidth))
+	} else {
+		content = st.highlightLine(line, contentWidth, styles)
+	}
 	parts = append(parts, content)
 
 	return lipgloss.JoinHorizontal(lipgloss.Top, parts...)
@@ -246,7 +403,7 @@ func (st *StreamTab) renderGutter(lineNum int, line StreamLine, width int, synta
 
 	if st.ShowTimestamps {
 		tsStyle := lipgloss.NewStyle().Foreground(syntax.Timestamp)
-		ts := line.Timestamp.Format("15:04:05")
+		ts := line.Timestamp.Local().Format(streamTimestampLayout)
 		if gutter != "" {
 			gutter += " "
 		}
@@ -262,15 +419,10 @@ func (st *StreamTab) renderGutter(lineNum int, line StreamLine, width int, synta
 
 // highlightLine applies syntax highlighting to a line
 func (st *StreamTab) highlightLine(line StreamLine, maxWidth int, styles Styles) string {
-	text := line.Text
+	text := truncateStreamText(line.Text, maxWidth)
 	syntax := styles.Syntax
 	colors := styles.Colors
 
-	// Truncate if needed
-	if len(text) > maxWidth && maxWidth > 3 {
-		text = text[:maxWidth-3] + "..."
-	}
-
 	// Apply base style based on line type
 	var style lipgloss.Style
 	switch line.Type {
@@ -298,7 +450,20 @@ func (st *StreamTab) highlightLine(line StreamLine, maxWidth int, styles Styles)
 		}
 	}
 
-	return style.Render(text)
+	return st.Linker.Render(text, style)
+}
+
+// truncateStreamText shortens text to maxWidth with an ellipsis
+func truncateStreamText(text string, maxWidth int) string {
+	if len(text) > maxWidth && maxWidth > 3 {
+		return text[:maxWidth-3] + "..."
+	}
+	return text
+}
+
+// flashStyle marks the line a bookmark jump landed on
+func flashStyle(styles Styles) lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(styles.Colors.Accent).Reverse(true)
 }
 
 // highlightFilePaths highlights file paths and URLs in a line
@@ -350,7 +515,7 @@ func (st *StreamTab) highlightFilePaths(text string, syntax SyntaxColors) string
 	// Apply highlighting in reverse order to preserve indices
 	result := text
 	for _, m := range allMatches {
-		highlighted := pathStyle.Render(m.text)
+		highlighted := st.Linker.WrapLocation(pathStyle.Render(m.text), m.text)
 		result = result[:m.start] + highlighted + result[m.end:]
 	}
 
@@ -378,13 +543,17 @@ func (st *StreamTab) emptyView(styles Styles) string {
 		Padding(1, 0)
 	bigIcon := iconStyle.Render(icons.Idle)
 
+	text, hintText := "Waiting for build output...", "Press b to build, r to run, t to test"
+	if st.ErrorsOnly && len(st.Lines) > 0 {
+		text, hintText = "No errors or warnings", "Press F to show all logs"
+	}
 	msg := lipgloss.NewStyle().
 		Foreground(styles.Colors.TextSubtle).
-		Render("Waiting for build output...")
+		Render(text)
 
 	hint := lipgloss.NewStyle().
 		Foreground(styles.Colors.TextSubtle).
-		Render("Press b to build, r to run, t to test")
+		Render(hintText)
 
 	content := lipgloss.JoinVertical(lipgloss.Center, "", bigIcon, "", msg, "", hint)
 
@@ -403,14 +572,19 @@ func (st *StreamTab) emptyView(styles Styles) string {
 
 // GetCurrentLine returns the currently selected/visible line for copying
 func (st *StreamTab) GetCurrentLine() string {
-	if len(st.Lines) == 0 {
+	line, _, ok := st.TopLine()
+	if !ok {
 		return ""
 	}
-	idx := st.ScrollPos
-	if idx >= len(st.Lines) {
-		idx = len(st.Lines) - 1
+	return st.copyLine(line)
+}
+
+// copyLine returns the raw text, prefixed with its timestamp when shown
+func (st *StreamTab) copyLine(line StreamLine) string {
+	if st.ShowTimestamps {
+		return line.Timestamp.Local().Format(streamTimestampLayout) + " " + line.Raw
 	}
-	return st.Lines[idx].Raw
+	return line.Raw
 }
 
 // GetVisibleContent returns all visible content for copying
@@ -421,14 +595,335 @@ func (st *StreamTab) GetVisibleContent() string {
 
 	start := st.ScrollPos
 	end := start + st.VisibleRows
-	if end > len(st.Lines) {
-		end = len(st.Lines)
+	if total := st.rowCount(); end > total {
+		end = total
 	}
 
 	var lines []string
-	for i := start; i < end; i++ {
-		lines = append(lines, st.Lines[i].Raw)
+	for pos := start; pos < end; pos++ {
+		if i := st.lineAtRow(pos); i >= 0 {
+			lines = append(lines, st.copyLine(st.Lines[i]))
+		} else {
+			lines = append(lines, issueClusterSeparator)
+		}
 	}
 
 	return strings.Join(lines, "\n")
 }
+
+// =============================================================================
+// Errors-Only Filter
+// =============================================================================
+
+// SetErrorsOnly shows only errors/warnings with contextLines of surrounding
+// output, keeping the top line in view where possible
+func (st *StreamTab) SetErrorsOnly(on bool, contextLines int) {
+	_, top, ok := st.TopLine()
+	st.ErrorsOnly = on
+	st.ContextLines = contextLines
+	st.clusterID = 0
+	st.phaseID = 0
+	if st.AutoFollow || !ok {
+		st.ScrollPos = st.maxScrollPos()
+		return
+	}
+	st.ScrollToIndex(top)
+}
+
+func (st *StreamTab) isIssueLine(i int) bool {
+	t := st.Lines[i].Type
+	return t == TabLineTypeError || t == TabLineTypeWarning
+}
+
+func (st *StreamTab) clusters() []issueCluster {
+	return issueClusters(len(st.Lines), st.ContextLines, st.isIssueLine)
+}
+
+// filtered reports whether display rows differ from buffer lines (errors-only
+// or collapsed phases)
+func (st *StreamTab) filtered() bool {
+	return st.ErrorsOnly || len(st.collapsed) > 0
+}
+
+// rows returns the display rows: the errors-only rows (see
+// issueClusterRows), or every line outside collapsed phases. It's cached
+// until the buffer or the filter changes.
+func (st *StreamTab) rows() []int {
+	key := streamRowsKey{context: st.ContextLines, errorsOnly: st.ErrorsOnly, collapsedGen: st.collapsedGen}
+	if len(st.Lines) > 0 {
+		key.firstID = st.Lines[0].ID
+		key.lastID = st.Lines[len(st.Lines)-1].ID
+	}
+	if st.rowsCache == nil || key != st.rowsKey {
+		if st.ErrorsOnly {
+			st.rowsCache = issueClusterRows(st.clusters())
+		} else {
+			st.rowsCache = st.expandedRows()
+		}
+		if st.rowsCache == nil {
+			st.rowsCache = []int{}
+		}
+		st.rowsKey = key
+	}
+	return st.rowsCache
+}
+
+// rowCount is the number of display rows in the current view
+func (st *StreamTab) rowCount() int {
+	if st.filtered() {
+		return len(st.rows())
+	}
+	return len(st.Lines)
+}
+
+// lineAtRow maps a display row to a line index (-1 for a separator)
+func (st *StreamTab) lineAtRow(pos int) int {
+	if st.filtered() {
+		return st.rows()[pos]
+	}
+	return pos
+}
+
+// rowForLine maps a line index to its display row; hidden lines map to the
+// next visible row
+func (st *StreamTab) rowForLine(idx int) int {
+	if !st.filtered() {
+		return idx
+	}
+	rows := st.rows()
+	for pos, i := range rows {
+		if i >= idx {
+			return pos
+		}
+	}
+	return len(rows) - 1
+}
+
+// JumpToCluster scrolls to the next (dir > 0) or previous issue cluster,
+// wrapping around. It returns the 1-based cluster number and the total.
+func (st *StreamTab) JumpToCluster(dir int) (int, int, bool) {
+	clusters := st.clusters()
+	if len(clusters) == 0 {
+		return 0, 0, false
+	}
+	current := -1
+	if idx := st.IndexOfID(st.clusterID); st.clusterID != 0 && idx >= 0 {
+		for ci, c := range clusters {
+			if c.Start <= idx && idx < c.End {
+				current = ci
+			}
+		}
+	}
+	target := current + dir
+	if current < 0 {
+		// No jump yet: go relative to the top of the view.
+		_, top, _ := st.TopLine()
+		target = -1
+		for ci, c := range clusters {
+			if (dir > 0 && c.Start > top && target < 0) || (dir < 0 && c.Start < top) {
+				target = ci
+			}
+		}
+	}
+	if target < 0 {
+		target = len(clusters) - 1
+		if dir > 0 {
+			target = 0
+		}
+	} else if target >= len(clusters) {
+		target = 0
+	}
+	c := clusters[target]
+	st.ScrollToIndex(c.Start)
+	st.clusterID = st.Lines[c.First].ID
+	return target + 1, len(clusters), true
+}
+
+// =============================================================================
+// Scrollbar Markers
+// =============================================================================
+
+// markers returns the display rows of errors and warnings. Unfiltered it's
+// built from issueIDs each frame; filtered markers are cached until the rows
+// change.
+func (st *StreamTab) markers() []scrollMarker {
+	if len(st.issueIDs) == 0 {
+		return nil
+	}
+	if st.filtered() {
+		rows := st.rows()
+		if st.markerCache == nil || st.markerKey != st.rowsKey {
+			st.markerCache = st.markerCache[:0]
+			if st.ErrorsOnly {
+				for pos, i := range rows {
+					if i >= 0 && st.isIssueLine(i) {
+						st.markerCache = append(st.markerCache, scrollMarker{Row: pos, Error: st.Lines[i].Type == TabLineTypeError})
+					}
+				}
+			} else {
+				// Collapsed lines are marked on their phase header, the
+				// last row shown before them
+				first := st.Lines[0].ID
+				for _, id := range st.issueIDs {
+					i := int(id - first)
+					pos := sort.Search(len(rows), func(p int) bool { return rows[p] > i }) - 1
+					st.markerCache = append(st.markerCache, scrollMarker{Row: max(pos, 0), Error: st.Lines[i].Type == TabLineTypeError})
+				}
+			}
+			if st.markerCache == nil {
+				st.markerCache = []scrollMarker{}
+			}
+			st.markerKey = st.rowsKey
+		}
+		return st.markerCache
+	}
+	first := st.Lines[0].ID
+	markers := make([]scrollMarker, len(st.issueIDs))
+	for n, id := range st.issueIDs {
+		i := int(id - first)
+		markers[n] = scrollMarker{Row: i, Error: st.Lines[i].Type == TabLineTypeError}
+	}
+	return markers
+}
+
+// JumpToMarker scrolls to the nearest error or warning below (dir > 0) or
+// above the view. It reports false when there's none that way.
+func (st *StreamTab) JumpToMarker(dir int) bool {
+	row, ok := nearestMarker(st.markers(), st.ScrollPos, st.ScrollPos+st.VisibleRows, dir)
+	if !ok {
+		return false
+	}
+	st.scrollToMarker(row)
+	return true
+}
+
+// JumpToTrackCell scrolls to the marker drawn on scrollbar cell y, reporting
+// false when the cell has none
+func (st *StreamTab) JumpToTrackCell(y int) bool {
+	row, ok := scrollMarkerAt(y, st.VisibleRows, st.rowCount(), st.markers())
+	if !ok {
+		return false
+	}
+	st.scrollToMarker(row)
+	return true
+}
+
+func (st *StreamTab) scrollToMarker(row int) {
+	st.AutoFollow = false
+	st.clusterID = 0
+	st.phaseID = 0
+	st.ScrollPos = centeredScrollPos(row, st.VisibleRows, st.maxScrollPos())
+}
+
+// =============================================================================
+// Phase Collapse
+// =============================================================================
+
+// expandedRows lists the lines outside collapsed phases; a phase runs from
+// its header to the next one
+func (st *StreamTab) expandedRows() []int {
+	rows := make([]int, 0, len(st.Lines))
+	hiding := false
+	for i, line := range st.Lines {
+		if line.Type == TabLineTypePhaseHeader {
+			hiding = st.collapsed[line.ID]
+		} else if hiding {
+			continue
+		}
+		rows = append(rows, i)
+	}
+	return rows
+}
+
+// hiddenAfter is the number of lines hidden under header idx (0 when it's
+// not a collapsed phase header, or in errors-only mode)
+func (st *StreamTab) hiddenAfter(idx int) int {
+	if st.ErrorsOnly || !st.collapsed[st.Lines[idx].ID] {
+		return 0
+	}
+	n := 0
+	for i := idx + 1; i < len(st.Lines) && st.Lines[i].Type != TabLineTypePhaseHeader; i++ {
+		n++
+	}
+	return n
+}
+
+// LineAt returns the index of the line rendered at row y of the last view
+func (st *StreamTab) LineAt(y int) (int, bool) {
+	if y < 0 || y >= len(st.shownRows) || st.shownRows[y] < 0 {
+		return 0, false
+	}
+	return st.shownRows[y], true
+}
+
+// TogglePhase collapses or expands the phase headed by line idx, keeping the
+// header on the same row. It reports false when idx isn't a phase header or
+// errors-only mode is on.
+func (st *StreamTab) TogglePhase(idx int) bool {
+	if st.ErrorsOnly || idx < 0 || idx >= len(st.Lines) || st.Lines[idx].Type != TabLineTypePhaseHeader {
+		return false
+	}
+	st.setPhaseCollapsed(idx, !st.collapsed[st.Lines[idx].ID])
+	return true
+}
+
+// setPhaseCollapsed collapses or expands the phase headed by line idx,
+// keeping the header on the same row
+func (st *StreamTab) setPhaseCollapsed(idx int, collapsed bool) {
+	id := st.Lines[idx].ID
+	if collapsed == st.collapsed[id] {
+		return
+	}
+	offset := st.rowForLine(idx) - st.ScrollPos
+	if collapsed {
+		if st.collapsed == nil {
+			st.collapsed = make(map[uint64]bool)
+		}
+		st.collapsed[id] = true
+	} else {
+		delete(st.collapsed, id)
+	}
+	st.collapsedGen++
+	if st.AutoFollow {
+		st.ScrollPos = st.maxScrollPos()
+		return
+	}
+	st.ScrollPos = min(max(0, st.rowForLine(idx)-offset), st.maxScrollPos())
+}
+
+// =============================================================================
+// Compile Times
+// =============================================================================
+
+// AnnotateCompileTimes attaches each file's compile time to the last line
+// that compiles it ("Compiling App.swift", "SwiftCompile … /path/App.swift").
+// Multi-file units have no single line and are skipped. It returns the
+// number of lines annotated.
+func (st *StreamTab) AnnotateCompileTimes(times []core.CompileTime) int {
+	if len(times) == 0 {
+		return 0
+	}
+	lastLine := map[string]int{}
+	for i, line := range st.Lines {
+		if !strings.Contains(strings.ToLower(line.Raw), "compil") {
+			continue
+		}
+		for _, field := range strings.Fields(line.Raw) {
+			field = strings.Trim(field, "'\"`()[]")
+			if strings.HasSuffix(field, ".swift") {
+				lastLine[filepath.Base(field)] = i
+			}
+		}
+	}
+	annotated := 0
+	for _, t := range times {
+		if t.Files > 0 {
+			continue
+		}
+		if i, ok := lastLine[filepath.Base(t.File)]; ok && st.Lines[i].CompileTime == 0 {
+			st.Lines[i].CompileTime = t.Duration
+			annotated++
+		}
+	}
+	return annotated
+}
@@ -0,0 +1,102 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// =============================================================================
+// Stress Test
+// =============================================================================
+
+// stressCounts are the iteration counts offered by Test: Stress Selected
+var stressCounts = []int{10, 25, 50, 100}
+
+// SetStress sets the outcome of a stress test run
+func (st *SummaryTab) SetStress(r *core.StressResult) {
+	st.Stress = r
+}
+
+// stressView renders e.g. "50/50 iterations · 2 failures (4.0%)", in red
+// when any iteration failed
+func (st *SummaryTab) stressView(styles Styles) string {
+	color := styles.Colors.Success
+	if len(st.Stress.Failures) > 0 {
+		color = styles.Colors.Error
+	}
+	return lipgloss.NewStyle().Foreground(color).Render(st.Stress.Line())
+}
+
+// setStressProgress shows a stress run's iteration progress on the
+// Dashboard and the status bar
+func (m *Model) setStressProgress(ev core.Event) {
+	data, _ := ev.Data.(map[string]any)
+	iteration, _ := data["iteration"].(int)
+	iterations, _ := data["iterations"].(int)
+	failures, _ := data["failures"].(int)
+	noun := "failures"
+	if failures == 1 {
+		noun = "failure"
+	}
+	m.tabView.SummaryTab.StressProgress = fmt.Sprintf("iteration %d/%d, %d %s", iteration, iterations, failures, noun)
+}
+
+// stressTests returns the -only-testing identifiers to stress: the test of
+// the failure selected in Issues, else the last test run's filter
+func (m *Model) stressTests() []string {
+	if issue := m.tabView.VisibleIssuesTab().GetSelectedIssue(); issue != nil {
+		if name := issueTestName(*issue); name != "" {
+			for _, id := range m.lastTest.Summary.FailedTests {
+				if id == name || strings.HasSuffix(id, "/"+name) {
+					return []string{id}
+				}
+			}
+		}
+	}
+	if m.lastOp != nil && m.lastOp.Name == "test" && len(m.lastOp.OnlyTesting) > 0 {
+		return m.lastOp.OnlyTesting
+	}
+	return nil
+}
+
+// openStressCount asks how many times to run the selected test
+func (m *Model) openStressCount() {
+	if m.running {
+		m.setStatus("An operation is already running")
+		return
+	}
+	only := m.stressTests()
+	if len(only) == 0 {
+		m.setStatus("Select a failing test in Issues to stress")
+		return
+	}
+	m.stressOnly = only
+	items := make([]SelectorItem, len(stressCounts))
+	for i, n := range stressCounts {
+		items[i] = SelectorItem{ID: strconv.Itoa(n), Title: fmt.Sprintf("%d iterations", n)}
+	}
+	title := "Stress " + only[0]
+	if len(only) > 1 {
+		title = fmt.Sprintf("Stress %d tests", len(only))
+	}
+	m.selector = NewSelector(title, items, m.width, m.styles)
+	m.selectorType = SelectorStressCount
+	m.mode = ModeSelector
+}
+
+// pickStressCount starts the stress run with the chosen iteration count
+func (m *Model) pickStressCount(item *SelectorItem) tea.Cmd {
+	only := m.stressOnly
+	m.stressOnly = nil
+	n, err := strconv.Atoi(item.ID)
+	if err != nil || n < 1 || len(only) == 0 {
+		return nil
+	}
+	return m.startOpRequest(core.LastOp{Name: "test", OnlyTesting: only, Repeat: n})
+}
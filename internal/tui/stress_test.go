@@ -0,0 +1,67 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func TestOpenStressCountUsesSelectedFailure(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.openStressCount()
+	if m.mode == ModeSelector {
+		t.Fatal("opened without a selected test")
+	}
+
+	m.lastTest.Summary.FailedTests = []string{"AppTests/CartTests/testTotal", "AppTests/LoginTests/testSSO"}
+	m.tabView.IssuesTab.AddIssue(IssueTypeError, "/src/LoginTests.swift:12: error: -[AppTests.LoginTests testSSO] : XCTAssertTrue failed")
+	m.openStressCount()
+	if m.mode != ModeSelector || m.selectorType != SelectorStressCount {
+		t.Fatalf("mode = %v, selector = %v", m.mode, m.selectorType)
+	}
+	if len(m.stressOnly) != 1 || m.stressOnly[0] != "AppTests/LoginTests/testSSO" {
+		t.Fatalf("stressOnly = %v", m.stressOnly)
+	}
+}
+
+func TestStressTestsFallsBackToLastFilter(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.lastOp = &core.LastOp{Name: "test", OnlyTesting: []string{"AppTests/CartTests"}}
+	if got := m.stressTests(); len(got) != 1 || got[0] != "AppTests/CartTests" {
+		t.Fatalf("stressTests = %v", got)
+	}
+}
+
+func TestStressProgressAndSummary(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	ev := core.Status("test", "Iteration 12/50, 0 failures", map[string]any{"iteration": 12, "iterations": 50, "failures": 0})
+	ev.Code = core.StressIterationCode
+	m.parseProgressFromEvent(ev)
+	if got := m.tabView.SummaryTab.StressProgress; got != "iteration 12/50, 0 failures" {
+		t.Fatalf("StressProgress = %q", got)
+	}
+
+	st := NewSummaryTab()
+	st.SetSize(100, 60)
+	st.SetContextLoaded(true)
+	st.SetStress(&core.StressResult{Iterations: 12, Requested: 50})
+	st.SetCanceled(false, "")
+	st.SetResult(BuildStatusCanceled, "40s", nil, 0, 0)
+	if view := stripANSI(st.View(DefaultStyles())); !strings.Contains(view, "Stress: 12/50 iterations · 0 failures (0.0%)") {
+		t.Fatalf("canceled view misses the stress line:\n%s", view)
+	}
+
+	st.SetStress(&core.StressResult{Iterations: 50, Requested: 50, Failures: []int{3, 40}})
+	st.SetResult(BuildStatusFailed, "2m", nil, 2, 0)
+	if view := stripANSI(st.View(DefaultStyles())); !strings.Contains(view, "2 failures (4.0%)") {
+		t.Fatalf("failed view misses the failure rate:\n%s", view)
+	}
+	st.Clear()
+	if st.Stress != nil || st.StressProgress != "" {
+		t.Fatal("Clear kept the stress result")
+	}
+}
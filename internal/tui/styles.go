@@ -23,6 +23,9 @@ type Colors struct {
 	Error   lipgloss.AdaptiveColor
 	Running lipgloss.AdaptiveColor
 
+	// Static analyzer findings
+	Analyzer lipgloss.AdaptiveColor
+
 	// Background colors
 	Background lipgloss.AdaptiveColor
 	Surface    lipgloss.AdaptiveColor
@@ -53,12 +56,15 @@ type Icons struct {
 	Bolt string
 
 	// Status icons
-	Success string
-	Error   string
-	Warning string
-	Running string
-	Idle    string
-	Paused  string
+	Success  string
+	Error    string
+	Warning  string
+	Running  string
+	Idle     string
+	Paused   string
+	Skipped  string
+	Flaky    string
+	Analyzer string
 
 	// Action icons
 	Build   string
@@ -141,12 +147,15 @@ func NerdFontIcons() Icons {
 		Bolt: "\uf0e7", //  (nf-fa-bolt)
 
 		// Status
-		Success: "\uf00c", //  (nf-fa-check)
-		Error:   "\uf00d", //  (nf-fa-times)
-		Warning: "\uf071", //  (nf-fa-exclamation_triangle)
-		Running: "\uf110", //  (nf-fa-spinner) - use with animation
-		Idle:    "\uf111", //  (nf-fa-circle)
-		Paused:  "\uf28b", //  (nf-fa-pause_circle)
+		Success:  "\uf00c", //  (nf-fa-check)
+		Error:    "\uf00d", //  (nf-fa-times)
+		Warning:  "\uf071", //  (nf-fa-exclamation_triangle)
+		Running:  "\uf110", //  (nf-fa-spinner) - use with animation
+		Idle:     "\uf111", //  (nf-fa-circle)
+		Paused:   "\uf28b", //  (nf-fa-pause_circle)
+		Skipped:  "\uf051", //  (nf-fa-step_forward)
+		Flaky:    "\uf021", //  (nf-fa-refresh)
+		Analyzer: "\uf188", //  (nf-fa-bug)
 
 		// Actions
 		Build:   "\uf0ad", //  (nf-fa-wrench)
@@ -230,12 +239,15 @@ func UnicodeIcons() Icons {
 		Bolt: "⚡",
 
 		// Status
-		Success: "✓",
-		Error:   "✗",
-		Warning: "⚠",
-		Running: "●",
-		Idle:    "○",
-		Paused:  "◉",
+		Success:  "✓",
+		Error:    "✗",
+		Warning:  "⚠",
+		Running:  "●",
+		Idle:     "○",
+		Paused:   "◉",
+		Skipped:  "↷",
+		Flaky:    "⟳",
+		Analyzer: "◈",
 
 		// Actions
 		Build:   "⚒",
@@ -259,7 +271,7 @@ func UnicodeIcons() Icons {
 		Command:  "⌘",
 
 		// Git
-		Branch: "",
+		Branch: "⎇",
 
 		// Misc
 		Dot:       "●",
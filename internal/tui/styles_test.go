@@ -0,0 +1,17 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIconSetsFillEveryIcon(t *testing.T) {
+	for name, icons := range map[string]Icons{"nerd font": NerdFontIcons(), "unicode": UnicodeIcons()} {
+		v := reflect.ValueOf(icons)
+		for i := 0; i < v.NumField(); i++ {
+			if v.Field(i).String() == "" {
+				t.Errorf("%s icons: %s is empty", name, v.Type().Field(i).Name)
+			}
+		}
+	}
+}
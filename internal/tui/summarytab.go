@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/xcbolt/xcbolt/internal/core"
 )
 
 // =============================================================================
@@ -43,8 +45,11 @@ type SummaryTab struct {
 
 	// System Info (idle state)
 	XcodeVersion    string
+	XcodeWarning    string // Set when the active Xcode misses cfg.RequiredXcode
 	SimulatorStatus string
 	DeviceConnected bool
+	DerivedData     string // Active per-destination DerivedData dir ("" when not split)
+	Storage         string // Disk usage line ("" until measured)
 
 	// Build Progress
 	CurrentFile  string // Filename only
@@ -55,10 +60,63 @@ type SummaryTab struct {
 	StartTime    time.Time // For elapsed timer
 	SpinnerFrame int       // 0-3 for animation
 
+	// SwiftPM resolution progress (Packages stage)
+	PackagesFetched int
+	PackagesTotal   int
+	PackageCurrent  string
+
 	// Results
 	Duration     string
 	ErrorCount   int
 	WarningCount int
+	Tests        TestCounts
+
+	// Static analyzer findings and the report to open ("" when none)
+	AnalyzerCount  int
+	AnalyzerReport string
+
+	// RebuildNote flags a build that recompiled nearly everything unexpectedly
+	RebuildNote string
+	// RunPhaseNote names the run phase canceled after the build succeeded
+	RunPhaseNote string
+	// RunTimings is the last run's step breakdown, e.g.
+	// "build 42s · boot 3s · install 2.1s · launch 0.8s"
+	RunTimings string
+	// AppExit says how the console run's app ended, e.g. "App exited
+	// cleanly (exit code 0)"; AppExitClean colors it
+	AppExit      string
+	AppExitClean bool
+
+	// SlowestFiles are the slowest compile units (xcodebuild.timingReport)
+	SlowestFiles []core.CompileTime
+
+	// Targets of the last build, built ones first; the card lists them all
+	// when TargetsExpanded
+	Targets         []core.TargetBuildInfo
+	TargetsExpanded bool
+
+	// Coverage of the last test run (test.codeCoverage) and its warn/fail
+	// levels in percent
+	Coverage     *core.CoverageSummary
+	CoverageWarn float64
+	CoverageFail float64
+
+	// TestChanges compares the last test run's failures with the run before
+	TestChanges *core.TestChanges
+
+	// Stress is the outcome of a repeated test run; StressProgress is its
+	// live "iteration 12/50, 0 failures"
+	Stress         *core.StressResult
+	StressProgress string
+
+	// Interrupted ops: timeout vs user cancel, and how far the op got
+	TimedOut       bool
+	CanceledDetail string
+
+	// Failure is how xcodebuild failed ("" or core.FailureBuild for an
+	// ordinary failure), with the terminating signal
+	Failure       core.XcodebuildFailure
+	FailureSignal string
 
 	// Last Build (for idle state)
 	LastBuildSuccess  bool
@@ -67,6 +125,12 @@ type SummaryTab struct {
 	LastBuildWarnings int
 	HasLastBuild      bool
 
+	// Recent build durations, oldest first (idle History card)
+	History []time.Duration
+
+	// ConfigChanges describes a config change just made, one value per line
+	ConfigChanges []string
+
 	// Legacy fields (kept for compatibility)
 	Phases    []PhaseResult
 	FileCount int
@@ -81,6 +145,9 @@ type SummaryTab struct {
 	// Dimensions
 	Width  int
 	Height int
+
+	// MinimalMode drops card borders for terminals under 80x20
+	MinimalMode bool
 }
 
 // NewSummaryTab creates a new SummaryTab
@@ -113,6 +180,20 @@ func (st *SummaryTab) Clear() {
 	st.Duration = ""
 	st.ErrorCount = 0
 	st.WarningCount = 0
+	st.Tests = TestCounts{}
+	st.AnalyzerCount, st.AnalyzerReport = 0, ""
+	st.RebuildNote = ""
+	st.RunPhaseNote = ""
+	st.RunTimings = ""
+	st.AppExit, st.AppExitClean = "", false
+	st.SlowestFiles = nil
+	st.Targets, st.TargetsExpanded = nil, false
+	st.Coverage = nil
+	st.TestChanges = nil
+	st.Stress, st.StressProgress = nil, ""
+	st.TimedOut, st.CanceledDetail = false, ""
+	st.Failure, st.FailureSignal = "", ""
+	st.PackagesFetched, st.PackagesTotal, st.PackageCurrent = 0, 0, ""
 	st.FileCount = 0
 	st.ScrollPos = 0
 
@@ -148,6 +229,31 @@ func (st *SummaryTab) SetSystemInfo(xcode, simulator string, deviceConnected boo
 	st.DeviceConnected = deviceConnected
 }
 
+// SetXcodeWarning sets the requiredXcode mismatch badge ("" clears it)
+func (st *SummaryTab) SetXcodeWarning(warning string) {
+	st.XcodeWarning = warning
+}
+
+// SetDerivedData sets the active DerivedData directory shown in the System card
+func (st *SummaryTab) SetDerivedData(dir string) {
+	st.DerivedData = dir
+}
+
+// SetStorage sets the disk usage line shown in the System card
+func (st *SummaryTab) SetStorage(line string) {
+	st.Storage = line
+}
+
+// SetConfigChanges sets the Config Changed card (nil hides it)
+func (st *SummaryTab) SetConfigChanges(lines []string) {
+	st.ConfigChanges = lines
+}
+
+// SetHistory updates the durations shown in the History card
+func (st *SummaryTab) SetHistory(durations []time.Duration) {
+	st.History = durations
+}
+
 // SetContextLoaded marks context discovery as complete
 func (st *SummaryTab) SetContextLoaded(loaded bool) {
 	st.ContextLoaded = loaded
@@ -161,6 +267,7 @@ func (st *SummaryTab) SetRunning(actionType string) {
 	st.SpinnerFrame = 0
 	st.ErrorCount = 0
 	st.WarningCount = 0
+	st.AnalyzerCount = 0
 }
 
 // UpdateProgress updates live build progress
@@ -185,14 +292,82 @@ func (st *SummaryTab) SetLogIdle(d time.Duration) {
 	st.LogIdle = d
 }
 
-// IncrementErrors increments the error count
-func (st *SummaryTab) IncrementErrors() {
-	st.ErrorCount++
+// SetIssueCounts sets the error, warning, and analyzer finding counts
+func (st *SummaryTab) SetIssueCounts(errors, warnings, analyzer int) {
+	st.ErrorCount = errors
+	st.WarningCount = warnings
+	st.AnalyzerCount = analyzer
+}
+
+// SetAnalyzerReport sets the analyzer report shown in the result card
+func (st *SummaryTab) SetAnalyzerReport(path string) {
+	st.AnalyzerReport = path
+}
+
+// SetRunPhaseNote marks a run canceled after its build succeeded; the
+// success card then reports the build with the note
+func (st *SummaryTab) SetRunPhaseNote(note string) {
+	st.RunPhaseNote = note
+}
+
+// SetRunTimings sets the step breakdown shown under a run's result
+func (st *SummaryTab) SetRunTimings(timings []core.RunStepTiming) {
+	st.RunTimings = core.FormatRunTimings(timings)
+}
+
+// SetAppExit sets the line saying how the console run's app ended
+func (st *SummaryTab) SetAppExit(summary string, clean bool) {
+	st.AppExit, st.AppExitClean = summary, clean
+}
+
+// SetRebuildNote sets the unexpected full rebuild note of the success card
+func (st *SummaryTab) SetRebuildNote(note string) {
+	st.RebuildNote = note
+}
+
+// SetSlowestFiles sets the compile units of the Slowest files card
+func (st *SummaryTab) SetSlowestFiles(times []core.CompileTime) {
+	st.SlowestFiles = times
+}
+
+// SetTestCounts updates the test outcome counter
+func (st *SummaryTab) SetTestCounts(c TestCounts) {
+	st.Tests = c
+}
+
+// SetPackageProgress updates the SwiftPM fetch readout
+func (st *SummaryTab) SetPackageProgress(fetched, total int, current string) {
+	st.PackagesFetched = fetched
+	st.PackagesTotal = total
+	st.PackageCurrent = current
+}
+
+// SetFailure records how xcodebuild failed, for the failed view's headline
+func (st *SummaryTab) SetFailure(failure core.XcodebuildFailure, signal string) {
+	st.Failure = failure
+	st.FailureSignal = signal
+}
+
+// FailureHeadline words a failure by its cause: "Build failed", "xcodebuild
+// crashed", or "Build terminated (SIGKILL — possible out-of-memory)"
+func (st *SummaryTab) FailureHeadline() string {
+	switch st.Failure {
+	case core.FailureCrash:
+		return "xcodebuild crashed"
+	case core.FailureSignal:
+		signal := st.FailureSignal
+		if signal == "SIGKILL" {
+			signal += " — possible out-of-memory"
+		}
+		return st.actionNoun() + " terminated (" + signal + ")"
+	}
+	return st.actionNoun() + " failed"
 }
 
-// IncrementWarnings increments the warning count
-func (st *SummaryTab) IncrementWarnings() {
-	st.WarningCount++
+// SetCanceled records why the op stopped early, e.g. "Canceled during Linking"
+func (st *SummaryTab) SetCanceled(timedOut bool, detail string) {
+	st.TimedOut = timedOut
+	st.CanceledDetail = detail
 }
 
 // SetResult updates with final build results
@@ -277,8 +452,35 @@ func (st *SummaryTab) GotoBottom() {
 // Card Rendering
 // =============================================================================
 
-// renderCard draws a bordered card with title
+// cardWidth is the card width for the current size (the full width when minimal)
+func (st *SummaryTab) cardWidth() int {
+	if st.MinimalMode {
+		return st.Width
+	}
+	width := st.Width - 8
+	if width > 70 {
+		width = 70
+	}
+	if width < 40 {
+		width = 40
+	}
+	return width
+}
+
+// renderCard draws a bordered card with title; in minimal mode it is a title
+// line followed by plain lines
 func (st *SummaryTab) renderCard(title string, content []string, width int, styles Styles) string {
+	if st.MinimalMode {
+		titleStyle := lipgloss.NewStyle().Foreground(styles.Colors.TextMuted).Bold(true)
+		lines := []string{titleStyle.Render(title)}
+		for _, line := range content {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			lines = append(lines, " "+strings.TrimSpace(line))
+		}
+		return strings.Join(lines, "\n")
+	}
 	if width < 20 {
 		width = 60
 	}
@@ -332,6 +534,28 @@ func (st *SummaryTab) renderCard(title string, content []string, width int, styl
 
 // View renders the dashboard content
 func (st *SummaryTab) View(styles Styles) string {
+	if st.MinimalMode {
+		// Top-left aligned with blank spacer lines dropped
+		var lines []string
+		for _, line := range strings.Split(st.statusView(styles), "\n") {
+			if strings.TrimSpace(stripANSI(line)) != "" {
+				lines = append(lines, line)
+			}
+		}
+		return clampBlock(strings.Join(lines, "\n"), st.Width, st.Height)
+	}
+	return st.statusView(styles)
+}
+
+// align is the card placement: centered, or top-left in minimal mode
+func (st *SummaryTab) align() lipgloss.Position {
+	if st.MinimalMode {
+		return lipgloss.Left
+	}
+	return lipgloss.Center
+}
+
+func (st *SummaryTab) statusView(styles Styles) string {
 	switch st.Status {
 	case BuildStatusPending:
 		return st.idleView(styles)
@@ -348,6 +572,15 @@ func (st *SummaryTab) View(styles Styles) string {
 	}
 }
 
+// configChangesCards is the Config Changed card, shown briefly after a
+// config change on top of the other cards
+func (st *SummaryTab) configChangesCards(cardWidth int, styles Styles) []string {
+	if len(st.ConfigChanges) == 0 {
+		return nil
+	}
+	return []string{st.renderCard("Config Changed", st.ConfigChanges, cardWidth, styles)}
+}
+
 // idleView shows project info, system status, last build, and quick actions
 func (st *SummaryTab) idleView(styles Styles) string {
 	// Show loading state if context hasn't loaded yet
@@ -355,15 +588,9 @@ func (st *SummaryTab) idleView(styles Styles) string {
 		return st.loadingView(styles)
 	}
 
-	cardWidth := st.Width - 8
-	if cardWidth > 70 {
-		cardWidth = 70
-	}
-	if cardWidth < 40 {
-		cardWidth = 40
-	}
+	cardWidth := st.cardWidth()
 
-	var cards []string
+	cards := st.configChangesCards(cardWidth, styles)
 
 	// Project Card
 	projectContent := []string{}
@@ -397,6 +624,10 @@ func (st *SummaryTab) idleView(styles Styles) string {
 	} else {
 		line1 += "Xcode: Unknown"
 	}
+	if st.XcodeWarning != "" {
+		badge := lipgloss.NewStyle().Foreground(styles.Colors.Warning).Bold(true)
+		line1 += " " + badge.Render(styles.Icons.Warning+" "+st.XcodeWarning)
+	}
 	if st.SimulatorStatus != "" {
 		line1 += "   Simulator: " + st.SimulatorStatus
 	}
@@ -409,6 +640,12 @@ func (st *SummaryTab) idleView(styles Styles) string {
 		deviceStatus += "Not connected"
 	}
 	systemContent = append(systemContent, deviceStatus)
+	if st.DerivedData != "" {
+		systemContent = append(systemContent, "DerivedData: "+st.DerivedData)
+	}
+	if st.Storage != "" {
+		systemContent = append(systemContent, "Storage: "+st.Storage)
+	}
 	cards = append(cards, st.renderCard("System", systemContent, cardWidth, styles))
 
 	// Last Build Card (if available)
@@ -429,6 +666,17 @@ func (st *SummaryTab) idleView(styles Styles) string {
 		cards = append(cards, st.renderCard("Last Build", lastBuildContent, cardWidth, styles))
 	}
 
+	// History Card (build durations)
+	if len(st.History) > 0 {
+		minD, avgD, maxD := durationStats(st.History)
+		sparkStyle := lipgloss.NewStyle().Foreground(styles.Colors.Accent)
+		historyContent := []string{
+			sparkStyle.Render(sparkline(st.History)),
+			fmt.Sprintf("min %s · avg %s · max %s", formatHistoryDuration(minD), formatHistoryDuration(avgD), formatHistoryDuration(maxD)),
+		}
+		cards = append(cards, st.renderCard("History", historyContent, cardWidth, styles))
+	}
+
 	// Quick Actions
 	actionStyle := lipgloss.NewStyle().
 		Foreground(styles.Colors.Accent).
@@ -444,7 +692,7 @@ func (st *SummaryTab) idleView(styles Styles) string {
 	)
 
 	// Combine all cards
-	content := lipgloss.JoinVertical(lipgloss.Center,
+	content := lipgloss.JoinVertical(st.align(),
 		"",
 		strings.Join(cards, "\n\n"),
 		"",
@@ -454,8 +702,8 @@ func (st *SummaryTab) idleView(styles Styles) string {
 	return lipgloss.Place(
 		st.Width,
 		st.Height,
-		lipgloss.Center,
-		lipgloss.Center,
+		st.align(),
+		st.align(),
 		content,
 	)
 }
@@ -466,7 +714,7 @@ func (st *SummaryTab) loadingView(styles Styles) string {
 	spinnerStyle := lipgloss.NewStyle().Foreground(styles.Colors.Accent).Bold(true)
 	textStyle := lipgloss.NewStyle().Foreground(styles.Colors.TextMuted)
 
-	content := lipgloss.JoinVertical(lipgloss.Center,
+	content := lipgloss.JoinVertical(st.align(),
 		"",
 		spinnerStyle.Render(spinner),
 		"",
@@ -477,21 +725,35 @@ func (st *SummaryTab) loadingView(styles Styles) string {
 	return lipgloss.Place(
 		st.Width,
 		st.Height,
-		lipgloss.Center,
-		lipgloss.Center,
+		st.align(),
+		st.align(),
 		content,
 	)
 }
 
-// buildingView shows live progress with spinner
-func (st *SummaryTab) buildingView(styles Styles) string {
-	cardWidth := st.Width - 8
-	if cardWidth > 70 {
-		cardWidth = 70
+// actionNoun names the op for result cards ("Build", "Test", …)
+func (st *SummaryTab) actionNoun() string {
+	switch st.ActionType {
+	case "clean":
+		return "Clean"
+	case "generate":
+		return "Generate"
+	case "test":
+		return "Test"
+	case "run":
+		return "Run"
+	case "analyze":
+		return "Analyze"
 	}
-	if cardWidth < 40 {
-		cardWidth = 40
+	if isUserCommandOp(st.ActionType) {
+		return "Command"
 	}
+	return "Build"
+}
+
+// buildingView shows live progress with spinner
+func (st *SummaryTab) buildingView(styles Styles) string {
+	cardWidth := st.cardWidth()
 
 	var cards []string
 
@@ -502,12 +764,20 @@ func (st *SummaryTab) buildingView(styles Styles) string {
 	case "clean":
 		actionLabel = "CLEANING"
 		cardTitle = "Cleaning"
+	case "generate":
+		actionLabel = "GENERATING"
+		cardTitle = "Generating"
 	case "test":
 		actionLabel = "TESTING"
 		cardTitle = "Testing"
 	case "run":
 		actionLabel = "RUNNING"
 		cardTitle = "Running"
+	default:
+		if isUserCommandOp(st.ActionType) {
+			actionLabel = "RUNNING COMMAND"
+			cardTitle = "Command"
+		}
 	}
 
 	// Main Progress Card
@@ -554,8 +824,16 @@ func (st *SummaryTab) buildingView(styles Styles) string {
 		// We have a stage but no specific file
 		stageText := st.CurrentStage
 		switch st.CurrentStage {
-		case "Resolve":
-			stageText = "Resolving dependencies..."
+		case "Packages":
+			stageText = "Resolving packages..."
+			if st.PackagesTotal > 0 {
+				stageText = fmt.Sprintf("Packages: %d/%d fetched", st.PackagesFetched, st.PackagesTotal)
+				if st.PackageCurrent != "" {
+					stageText += " · " + st.PackageCurrent
+				}
+			}
+		case "Generate":
+			stageText = "Generating project..."
 		case "Compile":
 			stageText = "Preparing to compile..."
 		case "Link":
@@ -571,6 +849,8 @@ func (st *SummaryTab) buildingView(styles Styles) string {
 		switch st.ActionType {
 		case "clean":
 			activityLine = fileStyle.Render("Cleaning derived data...")
+		case "generate":
+			activityLine = fileStyle.Render("Generating project...")
 		case "test":
 			activityLine = fileStyle.Render("Preparing tests...")
 		case "run":
@@ -580,6 +860,12 @@ func (st *SummaryTab) buildingView(styles Styles) string {
 		}
 	}
 	buildContent = append(buildContent, activityLine)
+	if st.StressProgress != "" {
+		buildContent = append(buildContent, labelStyle.Render("Stress: "+st.StressProgress))
+	}
+	if st.ActionType == "test" && st.Tests.Any() {
+		buildContent = append(buildContent, "", st.Tests.View(styles))
+	}
 
 	cards = append(cards, st.renderCard(cardTitle, buildContent, cardWidth, styles))
 
@@ -599,7 +885,7 @@ func (st *SummaryTab) buildingView(styles Styles) string {
 		cards = append(cards, st.renderCard("Issues", issuesContent, cardWidth, styles))
 	}
 
-	content := lipgloss.JoinVertical(lipgloss.Center,
+	content := lipgloss.JoinVertical(st.align(),
 		"",
 		strings.Join(cards, "\n\n"),
 	)
@@ -607,8 +893,8 @@ func (st *SummaryTab) buildingView(styles Styles) string {
 	return lipgloss.Place(
 		st.Width,
 		st.Height,
-		lipgloss.Center,
-		lipgloss.Center,
+		st.align(),
+		st.align(),
 		content,
 	)
 }
@@ -639,20 +925,18 @@ func (st *SummaryTab) renderDotProgress(width int, styles Styles) string {
 
 // successView shows build success
 func (st *SummaryTab) successView(styles Styles) string {
-	cardWidth := st.Width - 8
-	if cardWidth > 70 {
-		cardWidth = 70
-	}
-	if cardWidth < 40 {
-		cardWidth = 40
-	}
+	cardWidth := st.cardWidth()
 
-	var cards []string
+	cards := st.configChangesCards(cardWidth, styles)
+	noun := st.actionNoun()
+	if st.RunPhaseNote != "" {
+		noun = "Build"
+	}
 
 	// Success Card
 	successContent := []string{""}
 	successIcon := lipgloss.NewStyle().Foreground(styles.Colors.Success).Bold(true)
-	successText := successIcon.Render(styles.Icons.Success + " BUILD SUCCEEDED")
+	successText := successIcon.Render(styles.Icons.Success + " " + strings.ToUpper(noun) + " SUCCEEDED")
 	durationStyle := lipgloss.NewStyle().Foreground(styles.Colors.TextMuted)
 	durationText := durationStyle.Render(st.Duration)
 
@@ -660,9 +944,27 @@ func (st *SummaryTab) successView(styles Styles) string {
 	innerWidth := cardWidth - 4 // Account for card borders
 	successContent = append(successContent, lipgloss.PlaceHorizontal(innerWidth, lipgloss.Center, successText))
 	successContent = append(successContent, lipgloss.PlaceHorizontal(innerWidth, lipgloss.Center, durationText))
+	if st.RunTimings != "" && st.RunPhaseNote == "" {
+		successContent = append(successContent, lipgloss.PlaceHorizontal(innerWidth, lipgloss.Center, durationStyle.Render(truncateString(st.RunTimings, innerWidth))))
+	}
+	if st.AppExit != "" && st.RunPhaseNote == "" {
+		exitStyle := lipgloss.NewStyle().Foreground(styles.Colors.Error).Bold(true)
+		if st.AppExitClean {
+			exitStyle = lipgloss.NewStyle().Foreground(styles.Colors.Success)
+		}
+		successContent = append(successContent, lipgloss.PlaceHorizontal(innerWidth, lipgloss.Center, exitStyle.Render(truncateString(st.AppExit, innerWidth))))
+	}
+	if st.RebuildNote != "" {
+		noteStyle := lipgloss.NewStyle().Foreground(styles.Colors.Warning)
+		successContent = append(successContent, "", lipgloss.PlaceHorizontal(innerWidth, lipgloss.Center, noteStyle.Render(styles.Icons.Warning+" "+st.RebuildNote)))
+	}
+	if st.RunPhaseNote != "" {
+		noteStyle := lipgloss.NewStyle().Foreground(styles.Colors.TextMuted)
+		successContent = append(successContent, "", lipgloss.PlaceHorizontal(innerWidth, lipgloss.Center, noteStyle.Render(styles.Icons.Paused+" "+st.RunPhaseNote)))
+	}
 	successContent = append(successContent, "")
 
-	cards = append(cards, st.renderCard("Build Succeeded", successContent, cardWidth, styles))
+	cards = append(cards, st.renderCard(noun+" Succeeded", successContent, cardWidth, styles))
 
 	// Summary Card
 	summaryContent := []string{}
@@ -682,8 +984,28 @@ func (st *SummaryTab) successView(styles Styles) string {
 		parts = append(parts, textStyle.Render("0 warnings"))
 	}
 	summaryContent = append(summaryContent, strings.Join(parts, "   "))
+	if st.Tests.Any() {
+		summaryContent = append(summaryContent, "Tests: "+st.Tests.View(styles))
+	}
+	if st.Coverage != nil {
+		summaryContent = append(summaryContent, "Coverage: "+st.coverageView(styles))
+	}
+	if st.TestChanges != nil {
+		summaryContent = append(summaryContent, "Changes: "+st.testChangesView(styles))
+	}
+	if st.Stress != nil {
+		summaryContent = append(summaryContent, "Stress: "+st.stressView(styles))
+	}
+	summaryContent = append(summaryContent, st.analyzerLines(styles)...)
 	cards = append(cards, st.renderCard("Summary", summaryContent, cardWidth, styles))
 
+	if len(st.SlowestFiles) > 0 {
+		cards = append(cards, st.renderCard("Slowest files", st.slowestFileLines(cardWidth-4, styles), cardWidth, styles))
+	}
+	if len(st.Targets) > 0 {
+		cards = append(cards, st.renderCard("Targets", st.targetLines(cardWidth-4, styles), cardWidth, styles))
+	}
+
 	// Quick Actions
 	actionStyle := lipgloss.NewStyle().
 		Foreground(styles.Colors.Accent).
@@ -697,7 +1019,7 @@ func (st *SummaryTab) successView(styles Styles) string {
 		actionStyle.Render("[C]"), keyStyle.Render(" Clean"),
 	)
 
-	content := lipgloss.JoinVertical(lipgloss.Center,
+	content := lipgloss.JoinVertical(st.align(),
 		"",
 		strings.Join(cards, "\n\n"),
 		"",
@@ -707,28 +1029,61 @@ func (st *SummaryTab) successView(styles Styles) string {
 	return lipgloss.Place(
 		st.Width,
 		st.Height,
-		lipgloss.Center,
-		lipgloss.Center,
+		st.align(),
+		st.align(),
 		content,
 	)
 }
 
-// failedView shows build failure
-func (st *SummaryTab) failedView(styles Styles) string {
-	cardWidth := st.Width - 8
-	if cardWidth > 70 {
-		cardWidth = 70
+// analyzerLines shows analyzer findings and the report path in the Summary card
+func (st *SummaryTab) analyzerLines(styles Styles) []string {
+	if st.AnalyzerCount == 0 && st.AnalyzerReport == "" {
+		return nil
 	}
-	if cardWidth < 40 {
-		cardWidth = 40
+	analyzerStyle := lipgloss.NewStyle().Foreground(styles.Colors.Analyzer)
+	lines := []string{analyzerStyle.Render(fmt.Sprintf("%s %d analyzer findings", styles.Icons.Analyzer, st.AnalyzerCount))}
+	if st.AnalyzerReport != "" {
+		hintStyle := lipgloss.NewStyle().Foreground(styles.Colors.TextSubtle)
+		lines = append(lines,
+			"Report: "+st.AnalyzerReport,
+			hintStyle.Render("Open it with Open Analyzer Report (ctrl+k)"),
+		)
 	}
+	return lines
+}
 
-	var cards []string
+// slowestFileLines lists the slowest compile units, one per line with the
+// duration first
+func (st *SummaryTab) slowestFileLines(width int, styles Styles) []string {
+	durationStyle := lipgloss.NewStyle().Foreground(styles.Colors.Warning)
+	lines := make([]string, 0, len(st.SlowestFiles))
+	for _, t := range st.SlowestFiles {
+		name := filepath.Base(t.File)
+		if t.Files > 0 {
+			name = fmt.Sprintf("%s (%d files)", t.File, t.Files)
+		}
+		duration := fmt.Sprintf("%7s", formatCompileTime(t.Duration))
+		name = truncateStreamText(name, maxInt(1, width-lipgloss.Width(duration)-2))
+		lines = append(lines, durationStyle.Render(duration)+"  "+name)
+	}
+	return lines
+}
+
+// formatCompileTime shows compile times in seconds, e.g. "12.34s"
+func formatCompileTime(d time.Duration) string {
+	return fmt.Sprintf("%.2fs", d.Seconds())
+}
+
+// failedView shows build failure
+func (st *SummaryTab) failedView(styles Styles) string {
+	cardWidth := st.cardWidth()
+
+	cards := st.configChangesCards(cardWidth, styles)
 
 	// Failed Card
 	failedContent := []string{""}
 	failedIcon := lipgloss.NewStyle().Foreground(styles.Colors.Error).Bold(true)
-	failedText := failedIcon.Render(styles.Icons.Error + " BUILD FAILED")
+	failedText := failedIcon.Render(styles.Icons.Error + " " + strings.ToUpper(st.FailureHeadline()))
 	durationStyle := lipgloss.NewStyle().Foreground(styles.Colors.TextMuted)
 	durationText := durationStyle.Render(st.Duration)
 
@@ -738,7 +1093,7 @@ func (st *SummaryTab) failedView(styles Styles) string {
 	failedContent = append(failedContent, lipgloss.PlaceHorizontal(innerWidth, lipgloss.Center, durationText))
 	failedContent = append(failedContent, "")
 
-	cards = append(cards, st.renderCard("Build Failed", failedContent, cardWidth, styles))
+	cards = append(cards, st.renderCard(st.actionNoun()+" Failed", failedContent, cardWidth, styles))
 
 	// Summary Card
 	summaryContent := []string{}
@@ -752,6 +1107,19 @@ func (st *SummaryTab) failedView(styles Styles) string {
 		parts = append(parts, warnStyle.Render(fmt.Sprintf("%s %d warnings", styles.Icons.Warning, st.WarningCount)))
 	}
 	summaryContent = append(summaryContent, strings.Join(parts, "   "))
+	if st.Tests.Any() {
+		summaryContent = append(summaryContent, "Tests: "+st.Tests.View(styles))
+	}
+	if st.Coverage != nil {
+		summaryContent = append(summaryContent, "Coverage: "+st.coverageView(styles))
+	}
+	if st.TestChanges != nil {
+		summaryContent = append(summaryContent, "Changes: "+st.testChangesView(styles))
+	}
+	if st.Stress != nil {
+		summaryContent = append(summaryContent, "Stress: "+st.stressView(styles))
+	}
+	summaryContent = append(summaryContent, st.analyzerLines(styles)...)
 
 	hintStyle := lipgloss.NewStyle().Foreground(styles.Colors.TextSubtle)
 	summaryContent = append(summaryContent, hintStyle.Render("Press 2 to view Issues"))
@@ -771,7 +1139,7 @@ func (st *SummaryTab) failedView(styles Styles) string {
 		actionStyle.Render("[C]"), keyStyle.Render(" Clean"),
 	)
 
-	content := lipgloss.JoinVertical(lipgloss.Center,
+	content := lipgloss.JoinVertical(st.align(),
 		"",
 		strings.Join(cards, "\n\n"),
 		"",
@@ -781,28 +1149,27 @@ func (st *SummaryTab) failedView(styles Styles) string {
 	return lipgloss.Place(
 		st.Width,
 		st.Height,
-		lipgloss.Center,
-		lipgloss.Center,
+		st.align(),
+		st.align(),
 		content,
 	)
 }
 
 // canceledView shows a canceled build (user-initiated)
 func (st *SummaryTab) canceledView(styles Styles) string {
-	cardWidth := st.Width - 8
-	if cardWidth > 70 {
-		cardWidth = 70
-	}
-	if cardWidth < 40 {
-		cardWidth = 40
-	}
+	cardWidth := st.cardWidth()
 
-	var cards []string
+	cards := st.configChangesCards(cardWidth, styles)
 
 	// Canceled Card
 	canceledContent := []string{""}
 	canceledIcon := lipgloss.NewStyle().Foreground(styles.Colors.TextMuted).Bold(true)
-	canceledText := canceledIcon.Render(styles.Icons.Paused + " BUILD CANCELED")
+	canceledText := canceledIcon.Render(styles.Icons.Paused + " " + strings.ToUpper(st.actionNoun()) + " CANCELED")
+	cardTitle := st.actionNoun() + " Canceled"
+	if st.TimedOut {
+		canceledText = lipgloss.NewStyle().Foreground(styles.Colors.Warning).Bold(true).Render(styles.Icons.Warning + " " + strings.ToUpper(st.actionNoun()) + " TIMED OUT")
+		cardTitle = st.actionNoun() + " Timed Out"
+	}
 	durationStyle := lipgloss.NewStyle().Foreground(styles.Colors.TextMuted)
 	durationText := durationStyle.Render(st.Duration)
 
@@ -812,9 +1179,13 @@ func (st *SummaryTab) canceledView(styles Styles) string {
 	if st.Duration != "" {
 		canceledContent = append(canceledContent, lipgloss.PlaceHorizontal(innerWidth, lipgloss.Center, durationText))
 	}
+	if st.CanceledDetail != "" {
+		detail := lipgloss.NewStyle().Foreground(styles.Colors.Text).Render(st.CanceledDetail)
+		canceledContent = append(canceledContent, lipgloss.PlaceHorizontal(innerWidth, lipgloss.Center, detail))
+	}
 	canceledContent = append(canceledContent, "")
 
-	cards = append(cards, st.renderCard("Build Canceled", canceledContent, cardWidth, styles))
+	cards = append(cards, st.renderCard(cardTitle, canceledContent, cardWidth, styles))
 
 	// Summary Card
 	summaryContent := []string{}
@@ -830,6 +1201,9 @@ func (st *SummaryTab) canceledView(styles Styles) string {
 	if len(parts) > 0 {
 		summaryContent = append(summaryContent, strings.Join(parts, "   "))
 	}
+	if st.Stress != nil {
+		summaryContent = append(summaryContent, "Stress: "+st.stressView(styles))
+	}
 
 	hintStyle := lipgloss.NewStyle().Foreground(styles.Colors.TextSubtle)
 	if st.ErrorCount > 0 || st.WarningCount > 0 {
@@ -856,7 +1230,7 @@ func (st *SummaryTab) canceledView(styles Styles) string {
 		actionStyle.Render("[C]"), keyStyle.Render(" Clean"),
 	)
 
-	content := lipgloss.JoinVertical(lipgloss.Center,
+	content := lipgloss.JoinVertical(st.align(),
 		"",
 		strings.Join(cards, "\n\n"),
 		"",
@@ -866,8 +1240,53 @@ func (st *SummaryTab) canceledView(styles Styles) string {
 	return lipgloss.Place(
 		st.Width,
 		st.Height,
-		lipgloss.Center,
-		lipgloss.Center,
+		st.align(),
+		st.align(),
 		content,
 	)
 }
+
+// =============================================================================
+// History Helpers
+// =============================================================================
+
+var sparkRunes = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline renders durations as unicode bars scaled between min and max
+func sparkline(values []time.Duration) string {
+	if len(values) == 0 {
+		return ""
+	}
+	minD, _, maxD := durationStats(values)
+	out := make([]rune, 0, len(values))
+	for _, v := range values {
+		idx := 0
+		if maxD > minD {
+			idx = int(float64(v-minD) / float64(maxD-minD) * float64(len(sparkRunes)-1))
+		}
+		out = append(out, sparkRunes[idx])
+	}
+	return string(out)
+}
+
+func durationStats(values []time.Duration) (minD, avgD, maxD time.Duration) {
+	if len(values) == 0 {
+		return 0, 0, 0
+	}
+	minD, maxD = values[0], values[0]
+	var total time.Duration
+	for _, v := range values {
+		if v < minD {
+			minD = v
+		}
+		if v > maxD {
+			maxD = v
+		}
+		total += v
+	}
+	return minD, total / time.Duration(len(values)), maxD
+}
+
+func formatHistoryDuration(d time.Duration) string {
+	return d.Round(100 * time.Millisecond).String()
+}
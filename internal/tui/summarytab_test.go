@@ -1,8 +1,11 @@
 package tui
 
 import (
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/xcbolt/xcbolt/internal/core"
 )
 
 func TestSummaryTabRunningAndResult(t *testing.T) {
@@ -23,8 +26,7 @@ func TestSummaryTabRunningAndResult(t *testing.T) {
 	if st.FileProgress != 3 || st.FilesTotal != 10 {
 		t.Fatalf("progress = %d/%d", st.FileProgress, st.FilesTotal)
 	}
-	st.IncrementErrors()
-	st.IncrementWarnings()
+	st.SetIssueCounts(1, 1, 0)
 	st.SetResult(BuildStatusSuccess, "12s", []PhaseResult{{Name: "Compile", Count: 10}}, st.ErrorCount, st.WarningCount)
 	if st.Status != BuildStatusSuccess {
 		t.Fatalf("status = %v", st.Status)
@@ -52,3 +54,44 @@ func TestSummaryTabElapsedTimeAndSpinner(t *testing.T) {
 		t.Fatalf("spinner did not advance")
 	}
 }
+
+func TestSparklineAndHistoryCard(t *testing.T) {
+	values := []time.Duration{10 * time.Second, 20 * time.Second, 15 * time.Second}
+	if got := sparkline(values); got != "▁█▄" {
+		t.Fatalf("unexpected sparkline %q", got)
+	}
+	if got := sparkline([]time.Duration{time.Second, time.Second}); got != "▁▁" {
+		t.Fatalf("flat sparkline should use the lowest bar, got %q", got)
+	}
+
+	st := NewSummaryTab()
+	st.SetSize(80, 40)
+	st.SetContextLoaded(true)
+	st.SetHistory(values)
+	view := stripANSI(st.View(DefaultStyles()))
+	if !strings.Contains(view, "History") || !strings.Contains(view, "min 10s · avg 15s · max 20s") {
+		t.Fatalf("history card missing:\n%s", view)
+	}
+}
+
+func TestRunTimingsShowUnderResult(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.running = true
+	m.runningCmd = "run"
+	m.tabView.SummaryTab.SetRunning("run")
+	timings := []core.RunStepTiming{{Name: "build", MS: 42000}, {Name: "install", MS: 2100}, {Name: "launch", MS: 800}}
+	m.parseProgressFromEvent(core.Event{Type: "status", Code: core.RunTimingCode, Msg: "Run timing: …", Data: map[string]any{"phases": timings}})
+	m.handleOpDone(opDoneMsg{cmd: "run", cfg: m.cfg, run: &core.RunResult{PID: 42, Timings: timings}})
+
+	st := m.tabView.SummaryTab
+	st.SetSize(100, 40)
+	if view := stripANSI(st.View(DefaultStyles())); !strings.Contains(view, "build 42s · install 2.1s · launch 0.8s") {
+		t.Fatalf("success view missing the run timings:\n%s", view)
+	}
+
+	m.tabView.SummaryTab.Clear()
+	if m.tabView.SummaryTab.RunTimings != "" {
+		t.Fatal("timings kept for the next op")
+	}
+}
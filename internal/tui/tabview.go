@@ -3,8 +3,11 @@ package tui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/xcbolt/xcbolt/internal/core"
 )
 
 // =============================================================================
@@ -40,14 +43,22 @@ func (t Tab) String() string {
 
 // TabCounts holds the badge counts for each tab
 type TabCounts struct {
-	StreamLines  int // Total lines in logs
-	ErrorCount   int // Number of errors
-	WarningCount int // Number of warnings
+	StreamLines   int // Total lines in logs
+	ErrorCount    int // Number of errors
+	WarningCount  int // Number of warnings
+	AnalyzerCount int // Number of static analyzer findings
+	// Errors and warnings of muted phases, left out of ErrorCount and
+	// WarningCount unless muted issues are shown
+	MutedErrors   int
+	MutedWarnings int
+
+	errors, warnings int                        // Including muted ones
+	byPhase          map[string]phaseIssueCount // By phase header line
 }
 
-// IssueTotal returns total issues (errors + warnings)
+// IssueTotal returns total issues (errors + warnings + analyzer findings)
 func (c TabCounts) IssueTotal() int {
-	return c.ErrorCount + c.WarningCount
+	return c.ErrorCount + c.WarningCount + c.AnalyzerCount
 }
 
 // =============================================================================
@@ -71,18 +82,61 @@ type TabView struct {
 	// Settings
 	ShowLineNumbers bool
 	ShowTimestamps  bool
+
+	// Operation is the name of the op whose output the live tabs hold
+	Operation string
+
+	// MinimalMode hides the tab bar on terminals under 80x20 (tab cycles)
+	MinimalMode bool
+
+	// Previous run snapshot (Logs/Issues only); shown instead of the live
+	// tabs while ShowingPrevious is set.
+	previous        *tabSnapshot
+	ShowingPrevious bool
+
+	// linker groups ld symbol blocks into one issue per symbol
+	linker linkerAggregator
+	// analyzing is set while the current xcodebuild step is an Analyze step
+	analyzing bool
+	// muter decides which phases are muted; phase is the header line of the
+	// phase being read and phaseMuted whether it is muted
+	muter      *phaseMuter
+	phase      string
+	phaseMuted bool
+
+	// Hit regions of the last View pass, for mouse clicks
+	tabRegions []tabRegion
+	contentTop int // Row the active tab's content starts at
+}
+
+// tabRegion is the column range [X0, X1) a tab label occupied in the tab bar
+type tabRegion struct {
+	Tab    Tab
+	X0, X1 int
+}
+
+// tabSnapshot keeps the previous operation's Logs/Issues content
+type tabSnapshot struct {
+	StreamTab *StreamTab
+	IssuesTab *IssuesTab
+	Counts    TabCounts
+	Operation string
 }
 
 // NewTabView creates a new TabView with all tabs initialized
 func NewTabView() *TabView {
-	return &TabView{
+	tv := &TabView{
 		ActiveTab:       TabDashboard,
 		StreamTab:       NewStreamTab(),
 		IssuesTab:       NewIssuesTab(),
 		SummaryTab:      NewSummaryTab(),
 		ShowLineNumbers: true,
 		ShowTimestamps:  false,
+		muter:           &phaseMuter{},
 	}
+	tv.StreamTab.muter = tv.muter
+	tv.IssuesTab.muter = tv.muter
+	return tv
 }
 
 // SetSize updates dimensions for all tabs
@@ -90,6 +144,9 @@ func (tv *TabView) SetSize(width, height int) {
 	tv.Width = width
 	// Subtract 3 lines for tab bar (2 content lines + 1 border line from Container style)
 	contentHeight := height - 3
+	if tv.MinimalMode {
+		contentHeight = height
+	}
 	if contentHeight < 0 {
 		contentHeight = 0
 	}
@@ -98,14 +155,172 @@ func (tv *TabView) SetSize(width, height int) {
 	tv.StreamTab.SetSize(width, contentHeight)
 	tv.IssuesTab.SetSize(width, contentHeight)
 	tv.SummaryTab.SetSize(width, contentHeight)
+	if tv.previous != nil {
+		tv.previous.StreamTab.SetSize(width, contentHeight)
+		tv.previous.IssuesTab.SetSize(width, contentHeight)
+	}
+}
+
+// SetErrorsOnly applies the errors-only filter to the live and previous Logs
+func (tv *TabView) SetErrorsOnly(on bool, contextLines int) {
+	tv.StreamTab.SetErrorsOnly(on, contextLines)
+	if tv.previous != nil {
+		tv.previous.StreamTab.SetErrorsOnly(on, contextLines)
+	}
+}
+
+// SetLinker sets the hyperlinker of the live and previous Logs/Issues
+func (tv *TabView) SetLinker(l *Linker) {
+	tv.StreamTab.Linker = l
+	tv.IssuesTab.Linker = l
+	if tv.previous != nil {
+		tv.previous.StreamTab.Linker = l
+		tv.previous.IssuesTab.Linker = l
+	}
+}
+
+// SetClassifier sets the warning classifier of the live and previous Issues
+func (tv *TabView) SetClassifier(c *core.WarningClassifier) {
+	tv.IssuesTab.SetClassifier(c)
+	if tv.previous != nil {
+		tv.previous.IssuesTab.SetClassifier(c)
+	}
+}
+
+// SetCategoryFilter sets the warning category filter of the live and
+// previous Issues
+func (tv *TabView) SetCategoryFilter(category string) {
+	tv.IssuesTab.SetCategoryFilter(category)
+	if tv.previous != nil {
+		tv.previous.IssuesTab.SetCategoryFilter(category)
+	}
+}
+
+// SetMinimal switches every tab between the full and minimal layouts
+func (tv *TabView) SetMinimal(minimal bool) {
+	tv.MinimalMode = minimal
+	tv.SummaryTab.MinimalMode = minimal
+	tv.IssuesTab.MinimalMode = minimal
+	if tv.previous != nil {
+		tv.previous.IssuesTab.MinimalMode = minimal
+	}
 }
 
-// Clear resets all tabs for a new build
+// Clear resets all tabs for a new build. The outgoing Logs/Issues content
+// (with its scroll positions) is kept as the previous-run snapshot.
 func (tv *TabView) Clear() {
-	tv.StreamTab.Clear()
-	tv.IssuesTab.Clear()
+	tv.snapshotLive()
+	tv.linker = linkerAggregator{}
+	tv.analyzing = false
+	tv.phase, tv.phaseMuted = "", false
 	tv.SummaryTab.Clear()
 	tv.Counts = TabCounts{}
+	tv.ShowingPrevious = false
+}
+
+// snapshotLive moves the live Logs/Issues tabs into the snapshot slot and
+// starts fresh ones. Empty output doesn't replace an existing snapshot.
+func (tv *TabView) snapshotLive() {
+	if len(tv.StreamTab.Lines) == 0 && len(tv.IssuesTab.Issues) == 0 && len(tv.IssuesTab.hidden) == 0 && len(tv.IssuesTab.muted) == 0 {
+		tv.StreamTab.Clear()
+		tv.IssuesTab.Clear()
+		return
+	}
+	tv.previous = &tabSnapshot{
+		StreamTab: tv.StreamTab,
+		IssuesTab: tv.IssuesTab,
+		Counts:    tv.Counts,
+		Operation: tv.Operation,
+	}
+	tv.previous.IssuesTab.SetRunning(false)
+
+	stream := NewStreamTab()
+	stream.ShowLineNumbers = tv.StreamTab.ShowLineNumbers
+	stream.ShowTimestamps = tv.StreamTab.ShowTimestamps
+	stream.ErrorsOnly = tv.StreamTab.ErrorsOnly
+	stream.ContextLines = tv.StreamTab.ContextLines
+	stream.Linker = tv.StreamTab.Linker
+	stream.muter = tv.muter
+	stream.SetSize(tv.Width, tv.Height)
+	issues := NewIssuesTab()
+	issues.MinimalMode = tv.MinimalMode
+	issues.Linker = tv.IssuesTab.Linker
+	issues.Classifier = tv.IssuesTab.Classifier
+	issues.CategoryFilter = tv.IssuesTab.CategoryFilter
+	issues.muter = tv.muter
+	issues.SetSize(tv.Width, tv.Height)
+	tv.StreamTab = stream
+	tv.IssuesTab = issues
+}
+
+// HasPrevious reports whether a previous-run snapshot is available
+func (tv *TabView) HasPrevious() bool {
+	return tv.previous != nil
+}
+
+// TogglePrevious swaps the displayed Logs/Issues between the live run and
+// the previous-run snapshot. Returns false if there is no snapshot.
+func (tv *TabView) TogglePrevious() bool {
+	if tv.previous == nil {
+		tv.ShowingPrevious = false
+		return false
+	}
+	tv.ShowingPrevious = !tv.ShowingPrevious
+	return true
+}
+
+// dropPrevious discards the snapshot (e.g. when memory caps are exceeded)
+func (tv *TabView) dropPrevious() {
+	tv.previous = nil
+	tv.ShowingPrevious = false
+}
+
+// enforceSnapshotCaps drops the snapshot once live + snapshot content would
+// exceed the in-memory caps for a single run.
+func (tv *TabView) enforceSnapshotCaps() {
+	if tv.previous == nil {
+		return
+	}
+	lines := len(tv.previous.StreamTab.Lines) + len(tv.StreamTab.Lines)
+	issues := len(tv.previous.IssuesTab.Issues) + len(tv.IssuesTab.Issues)
+	if (maxStreamTabLines > 0 && lines > maxStreamTabLines) || (maxIssues > 0 && issues > maxIssues) {
+		tv.dropPrevious()
+	}
+}
+
+// VisibleStreamTab returns the Logs tab currently on screen (live or previous)
+func (tv *TabView) VisibleStreamTab() *StreamTab {
+	if tv.ShowingPrevious && tv.previous != nil {
+		return tv.previous.StreamTab
+	}
+	return tv.StreamTab
+}
+
+// VisibleIssuesTab returns the Issues tab currently on screen (live or previous)
+func (tv *TabView) VisibleIssuesTab() *IssuesTab {
+	if tv.ShowingPrevious && tv.previous != nil {
+		return tv.previous.IssuesTab
+	}
+	return tv.IssuesTab
+}
+
+// VisibleCounts returns the badge counts for the displayed content
+func (tv *TabView) VisibleCounts() TabCounts {
+	if tv.ShowingPrevious && tv.previous != nil {
+		return tv.previous.Counts
+	}
+	return tv.Counts
+}
+
+// previousLabel returns the tab bar label suffix for the snapshot
+func (tv *TabView) previousLabel() string {
+	if !tv.ShowingPrevious || tv.previous == nil {
+		return ""
+	}
+	if tv.previous.Operation != "" {
+		return " · prev " + tv.previous.Operation
+	}
+	return " · prev run"
 }
 
 // SetActiveTab changes the active tab
@@ -129,29 +344,113 @@ func (tv *TabView) PrevTab() {
 
 // AddLine routes a log line to appropriate tabs
 func (tv *TabView) AddLine(line string, lineType TabLineType) {
-	tv.StreamTab.AddLine(line, lineType)
+	tv.AddLineAt(line, lineType, time.Now())
+}
+
+// AddLineAt routes a log line emitted at the given time
+func (tv *TabView) AddLineAt(line string, lineType TabLineType, at time.Time) {
+	tv.addLineAt(line, lineType, at)
+}
+
+// addLineAt is AddLineAt, reporting whether an issue line was merged into
+// the same issue from another architecture (and so not counted)
+func (tv *TabView) addLineAt(line string, lineType TabLineType, at time.Time) bool {
+	merged := false
+	tv.StreamTab.AddLineAt(line, lineType, at)
 	tv.Counts.StreamLines++
+	if lineType == TabLineTypePhaseHeader {
+		tv.phase, tv.phaseMuted = line, tv.muter.Muted(line)
+		tv.IssuesTab.phase = line
+		if tv.phaseMuted {
+			tv.StreamTab.setPhaseCollapsed(len(tv.StreamTab.Lines)-1, true)
+		}
+	}
+	if analyzing, ok := analyzerStep(line); ok {
+		tv.analyzing = analyzing
+	}
 
 	// Route to issues tab if it's an error/warning (notes stay in stream only)
 	switch lineType {
 	case TabLineTypeError:
-		tv.IssuesTab.AddIssue(IssueTypeError, line)
-		tv.Counts.ErrorCount++
+		if merged = !tv.IssuesTab.AddIssue(IssueTypeError, line); !merged {
+			tv.countIssue(IssueTypeError)
+		}
 	case TabLineTypeWarning:
-		tv.IssuesTab.AddIssue(IssueTypeWarning, line)
-		tv.Counts.WarningCount++
+		if tv.analyzing || isAnalyzerFinding(line) {
+			if merged = !tv.IssuesTab.AddIssue(IssueTypeAnalyzer, line); !merged {
+				tv.Counts.AnalyzerCount++
+			}
+			break
+		}
+		if merged = !tv.IssuesTab.AddIssue(IssueTypeWarning, line); !merged {
+			tv.countIssue(IssueTypeWarning)
+		}
+	case TabLineTypeNote:
+		if core.ClassifyDiagnostic(line).Located() {
+			merged = !tv.IssuesTab.AddIssue(IssueTypeNote, line)
+		} else {
+			tv.IssuesTab.ObserveLine(line)
+		}
+	default:
+		tv.IssuesTab.ObserveLine(line)
 	}
+	tv.syncIssueCounts()
+	tv.enforceSnapshotCaps()
+	return merged
+}
+
+// AddEventLineAt shows one of the op's own status, warning, or error lines
+// in Logs. They describe the op rather than the code, so they are not issues
+// and are not counted.
+func (tv *TabView) AddEventLineAt(line string, lineType TabLineType, at time.Time) {
+	tv.StreamTab.AddLineAt(line, lineType, at)
+	tv.Counts.StreamLines++
+	tv.enforceSnapshotCaps()
+}
+
+// syncIssueCounts shows the badge counts on the Dashboard, so that the
+// status bar, tabs, Issues list, and Dashboard never disagree
+func (tv *TabView) syncIssueCounts() {
+	tv.SummaryTab.SetIssueCounts(tv.Counts.ErrorCount, tv.Counts.WarningCount, tv.Counts.AnalyzerCount)
 }
 
-// AddRawLine adds a raw line to the stream tab
-func (tv *TabView) AddRawLine(line string) {
+// AddRawLine adds a raw line to the stream tab. Lines continuing the previous
+// diagnostic, part of a linker symbol block, or repeating a diagnostic for
+// another architecture are folded into issues and never counted; the return
+// value reports whether that happened.
+func (tv *TabView) AddRawLine(line string) bool {
+	return tv.AddRawLineAt(line, time.Now())
+}
+
+// AddRawLineAt is AddRawLine for a line emitted at the given time
+func (tv *TabView) AddRawLineAt(line string, at time.Time) bool {
+	if core.MemoryPressureLine(line) {
+		tv.IssuesTab.MemoryPressure = true
+	}
+	consumed, done := tv.linker.Observe(line)
+	tv.addLinkerIssues(done)
+	if consumed {
+		tv.addLinkerLine(line, at)
+		return true
+	}
+	if tv.IssuesTab.Continue(line) {
+		tv.AddLineAt(line, TabLineTypeVerbose, at)
+		return true
+	}
+	if d := core.ClassifyDiagnostic(line); d.IsPretty {
+		// A log formatter's rendering of a raw line that is counted already
+		tv.AddEventLineAt(line, diagnosticLineType(d), at)
+		return false
+	}
 	lineType := classifyTabLogLine(line)
-	tv.AddLine(line, lineType)
+	return tv.addLineAt(line, lineType, at)
 }
 
 // SetBuildResult updates the summary tab with build results
 func (tv *TabView) SetBuildResult(status BuildStatus, duration string, phases []PhaseResult) {
+	tv.addLinkerIssues(tv.linker.Flush(""))
 	tv.SummaryTab.SetResult(status, duration, phases, tv.Counts.ErrorCount, tv.Counts.WarningCount)
+	tv.SummaryTab.AnalyzerCount = tv.Counts.AnalyzerCount
 }
 
 // =============================================================================
@@ -162,9 +461,9 @@ func (tv *TabView) SetBuildResult(status BuildStatus, duration string, phases []
 func (tv *TabView) ScrollUp(n int) {
 	switch tv.ActiveTab {
 	case TabStream:
-		tv.StreamTab.ScrollUp(n)
+		tv.VisibleStreamTab().ScrollUp(n)
 	case TabIssues:
-		tv.IssuesTab.ScrollUp(n)
+		tv.VisibleIssuesTab().ScrollUp(n)
 	case TabDashboard:
 		tv.SummaryTab.ScrollUp(n)
 	}
@@ -174,9 +473,9 @@ func (tv *TabView) ScrollUp(n int) {
 func (tv *TabView) ScrollDown(n int) {
 	switch tv.ActiveTab {
 	case TabStream:
-		tv.StreamTab.ScrollDown(n)
+		tv.VisibleStreamTab().ScrollDown(n)
 	case TabIssues:
-		tv.IssuesTab.ScrollDown(n)
+		tv.VisibleIssuesTab().ScrollDown(n)
 	case TabDashboard:
 		tv.SummaryTab.ScrollDown(n)
 	}
@@ -186,9 +485,9 @@ func (tv *TabView) ScrollDown(n int) {
 func (tv *TabView) GotoTop() {
 	switch tv.ActiveTab {
 	case TabStream:
-		tv.StreamTab.GotoTop()
+		tv.VisibleStreamTab().GotoTop()
 	case TabIssues:
-		tv.IssuesTab.GotoTop()
+		tv.VisibleIssuesTab().GotoTop()
 	case TabDashboard:
 		tv.SummaryTab.GotoTop()
 	}
@@ -198,9 +497,9 @@ func (tv *TabView) GotoTop() {
 func (tv *TabView) GotoBottom() {
 	switch tv.ActiveTab {
 	case TabStream:
-		tv.StreamTab.GotoBottom()
+		tv.VisibleStreamTab().GotoBottom()
 	case TabIssues:
-		tv.IssuesTab.GotoBottom()
+		tv.VisibleIssuesTab().GotoBottom()
 	case TabDashboard:
 		tv.SummaryTab.GotoBottom()
 	}
@@ -212,7 +511,13 @@ func (tv *TabView) GotoBottom() {
 
 // View renders the complete tab view (tab bar + content)
 func (tv *TabView) View(styles Styles) string {
+	if tv.MinimalMode {
+		tv.tabRegions = nil
+		tv.contentTop = 0
+		return clampBlock(tv.renderContent(styles), tv.Width, tv.Height)
+	}
 	tabBar := tv.renderTabBar(styles)
+	tv.contentTop = lipgloss.Height(tabBar)
 	content := tv.renderContent(styles)
 
 	// Guard against accidental double-render of the tab bar.
@@ -244,13 +549,13 @@ func (tv *TabView) renderTabBar(styles Styles) string {
 		{
 			tab:   TabStream,
 			icon:  icons.TabStream,
-			label: "Logs",
+			label: "Logs" + tv.previousLabel(),
 			badge: "",
 		},
 		{
 			tab:   TabIssues,
 			icon:  icons.TabIssues,
-			label: "Issues",
+			label: "Issues" + tv.previousLabel(),
 			badge: tv.issuesBadge(),
 		},
 	}
@@ -265,6 +570,8 @@ func (tv *TabView) renderTabBar(styles Styles) string {
 
 	var lineParts []string
 	var underlineParts []string
+	tv.tabRegions = tv.tabRegions[:0]
+	x := 0
 
 	for i, t := range tabs {
 		isActive := tv.ActiveTab == t.tab
@@ -272,6 +579,8 @@ func (tv *TabView) renderTabBar(styles Styles) string {
 		if i == 2 {
 			cellWidth = lastTabWidth
 		}
+		tv.tabRegions = append(tv.tabRegions, tabRegion{Tab: t.tab, X0: x, X1: min(x+cellWidth, tv.Width)})
+		x += cellWidth
 
 		// Alignment: Dashboard left, Logs center, Issues right
 		var align lipgloss.Position
@@ -298,7 +607,7 @@ func (tv *TabView) renderTabBar(styles Styles) string {
 		lineContent := iconStr + "  " + labelStr
 		if t.badge != "" {
 			badgeStyle := s.TabBadge
-			if tv.Counts.ErrorCount > 0 && t.tab == TabIssues {
+			if tv.VisibleCounts().ErrorCount > 0 && t.tab == TabIssues {
 				badgeStyle = s.TabBadgeError
 			}
 			lineContent += " " + badgeStyle.Render(t.badge)
@@ -358,6 +667,25 @@ func (tv *TabView) renderTabBar(styles Styles) string {
 	return lipgloss.JoinVertical(lipgloss.Left, lineContainer, underlineContainer)
 }
 
+// TabAt returns the tab whose label was at (x, y) in the last rendered view
+func (tv *TabView) TabAt(x, y int) (Tab, bool) {
+	if y < 0 || y >= tv.contentTop {
+		return 0, false
+	}
+	for _, r := range tv.tabRegions {
+		if x >= r.X0 && x < r.X1 {
+			return r.Tab, true
+		}
+	}
+	return 0, false
+}
+
+// ContentTop is the row the active tab's content started at in the last
+// rendered view (below the tab bar)
+func (tv *TabView) ContentTop() int {
+	return tv.contentTop
+}
+
 // issuesSubtitle returns the subtitle for the Issues tab
 func (tv *TabView) issuesSubtitle() string {
 	if tv.Counts.ErrorCount > 0 {
@@ -366,12 +694,15 @@ func (tv *TabView) issuesSubtitle() string {
 	if tv.Counts.WarningCount > 0 {
 		return fmt.Sprintf("%d warnings", tv.Counts.WarningCount)
 	}
+	if tv.Counts.AnalyzerCount > 0 {
+		return fmt.Sprintf("%d analyzer findings", tv.Counts.AnalyzerCount)
+	}
 	return "No issues"
 }
 
 // issuesBadge returns the badge text for the Issues tab
 func (tv *TabView) issuesBadge() string {
-	total := tv.Counts.IssueTotal()
+	total := tv.VisibleCounts().IssueTotal()
 	if total == 0 {
 		return ""
 	}
@@ -382,9 +713,9 @@ func (tv *TabView) issuesBadge() string {
 func (tv *TabView) renderContent(styles Styles) string {
 	switch tv.ActiveTab {
 	case TabStream:
-		return tv.StreamTab.View(styles)
+		return tv.VisibleStreamTab().View(styles)
 	case TabIssues:
-		return tv.IssuesTab.View(styles)
+		return tv.VisibleIssuesTab().View(styles)
 	case TabDashboard:
 		return tv.SummaryTab.View(styles)
 	default:
@@ -423,8 +754,11 @@ func classifyTabLogLine(line string) TabLineType {
 		return TabLineTypeProgress
 	}
 
-	// Phase header patterns
+	// Phase header patterns (run script phases can be muted, so they get
+	// their own header)
 	if strings.HasPrefix(line, "===") ||
+		strings.HasPrefix(line, "PhaseScriptExecution ") ||
+		strings.HasPrefix(strings.TrimPrefix(line, "▸ "), "Running script") ||
 		strings.Contains(lower, "compiling") ||
 		strings.Contains(lower, "linking") ||
 		strings.Contains(lower, "signing") {
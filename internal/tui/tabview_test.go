@@ -0,0 +1,70 @@
+package tui
+
+import "testing"
+
+func TestTabViewClearKeepsPreviousRun(t *testing.T) {
+	tv := NewTabView()
+	tv.SetSize(100, 30)
+	tv.Operation = "build"
+	tv.AddRawLine("Sources/App.swift:1:1: error: boom")
+	tv.SetActiveTab(TabIssues)
+
+	tv.Clear()
+	tv.Operation = "test"
+
+	if tv.ActiveTab != TabIssues {
+		t.Fatalf("active tab changed on clear: %v", tv.ActiveTab)
+	}
+	if !tv.HasPrevious() {
+		t.Fatalf("expected previous-run snapshot")
+	}
+	if len(tv.IssuesTab.Issues) != 0 || tv.Counts.ErrorCount != 0 {
+		t.Fatalf("live tabs not cleared")
+	}
+
+	if !tv.TogglePrevious() || !tv.ShowingPrevious {
+		t.Fatalf("expected to show previous run")
+	}
+	if got := len(tv.VisibleIssuesTab().Issues); got != 1 {
+		t.Fatalf("previous issues = %d, want 1", got)
+	}
+	if got := tv.VisibleCounts().ErrorCount; got != 1 {
+		t.Fatalf("previous error count = %d, want 1", got)
+	}
+	if got := tv.previousLabel(); got != " · prev build" {
+		t.Fatalf("previousLabel = %q", got)
+	}
+
+	// Live output keeps flowing into the live tabs while the snapshot is shown.
+	tv.AddRawLine("warning: live")
+	if len(tv.VisibleIssuesTab().Issues) != 1 || len(tv.IssuesTab.Issues) != 1 {
+		t.Fatalf("snapshot mutated by live output")
+	}
+}
+
+func TestTabViewClearWithoutOutputKeepsSnapshot(t *testing.T) {
+	tv := NewTabView()
+	tv.AddRawLine("hello")
+	tv.Clear()
+	prev := tv.previous
+	tv.Clear()
+	if tv.previous != prev {
+		t.Fatalf("empty run replaced the snapshot")
+	}
+}
+
+func TestTabViewDropsSnapshotOverCap(t *testing.T) {
+	tv := NewTabView()
+	for i := 0; i < maxStreamTabLines; i++ {
+		tv.StreamTab.Lines = append(tv.StreamTab.Lines, StreamLine{Text: "x"})
+	}
+	tv.Clear()
+	tv.TogglePrevious()
+	tv.AddRawLine("one more")
+	if tv.HasPrevious() || tv.ShowingPrevious {
+		t.Fatalf("expected snapshot to be dropped over cap")
+	}
+	if tv.TogglePrevious() {
+		t.Fatalf("toggle should fail without snapshot")
+	}
+}
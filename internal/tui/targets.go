@@ -0,0 +1,93 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// =============================================================================
+// Per-Target Build Summary
+// =============================================================================
+
+// targetsCardCollapsed is how many targets the collapsed Targets card lists
+const targetsCardCollapsed = 5
+
+// SetTargets sets the targets of the Targets card, collapsed
+func (st *SummaryTab) SetTargets(targets []core.TargetBuildInfo) {
+	st.Targets = targets
+	st.TargetsExpanded = false
+}
+
+// ToggleTargets expands or collapses the Targets card
+func (st *SummaryTab) ToggleTargets() {
+	st.TargetsExpanded = !st.TargetsExpanded
+}
+
+// targetLines renders the Targets card: a built/cached count, then the
+// targets that did work and, dimmed, the cached ones. Collapsed, it lists
+// the first few.
+func (st *SummaryTab) targetLines(width int, styles Styles) []string {
+	built := core.CompiledTargets(st.Targets)
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.Colors.TextMuted)
+	subtleStyle := lipgloss.NewStyle().Foreground(styles.Colors.TextSubtle)
+	durationStyle := lipgloss.NewStyle().Foreground(styles.Colors.Warning)
+
+	lines := []string{mutedStyle.Render(fmt.Sprintf("%d built · %d cached", built, len(st.Targets)-built))}
+	shown := st.Targets
+	if !st.TargetsExpanded && len(shown) > targetsCardCollapsed {
+		shown = shown[:targetsCardCollapsed]
+	}
+	for _, t := range shown {
+		if !t.Compiled {
+			lines = append(lines, subtleStyle.Render(fmt.Sprintf("%7s  %s", "cached", truncateStreamText(t.Name, maxInt(1, width-9)))))
+			continue
+		}
+		duration := fmt.Sprintf("%7s", formatCompileTime(t.Duration))
+		name := t.Name
+		if t.FileCount > 0 {
+			name = fmt.Sprintf("%s (%d files)", t.Name, t.FileCount)
+		}
+		name = truncateStreamText(name, maxInt(1, width-lipgloss.Width(duration)-2))
+		lines = append(lines, durationStyle.Render(duration)+"  "+name)
+	}
+	switch {
+	case len(shown) < len(st.Targets):
+		lines = append(lines, subtleStyle.Render(fmt.Sprintf("… %d more · enter to expand", len(st.Targets)-len(shown))))
+	case st.TargetsExpanded:
+		lines = append(lines, subtleStyle.Render("enter to collapse"))
+	}
+	return lines
+}
+
+// openTargets lists the last build's targets in a selector for searching
+func (m *Model) openTargets() {
+	targets := m.lastBuild.Targets
+	if len(targets) == 0 {
+		m.setStatus("No targets yet · build first")
+		return
+	}
+	built := core.CompiledTargets(targets)
+	m.selector = NewSelector(fmt.Sprintf("Targets (%d built, %d cached)", built, len(targets)-built), TargetItems(targets), m.width, m.styles)
+	m.selectorType = SelectorTargets
+	m.mode = ModeSelector
+}
+
+// TargetItems creates selector items for a build's targets, in its order
+// (built first)
+func TargetItems(targets []core.TargetBuildInfo) []SelectorItem {
+	items := make([]SelectorItem, 0, len(targets))
+	for _, t := range targets {
+		desc := "cached"
+		if t.Compiled {
+			desc = fmt.Sprintf("built · %d files · %s", t.FileCount, formatCompileTime(t.Duration))
+		}
+		if t.Project != "" && t.Project != t.Name {
+			desc += " · " + t.Project
+		}
+		items = append(items, SelectorItem{ID: t.Name, Title: t.Name, Description: desc})
+	}
+	return items
+}
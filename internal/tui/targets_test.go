@@ -0,0 +1,67 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func TestTargetsCard(t *testing.T) {
+	st := NewSummaryTab()
+	st.SetSize(100, 80)
+	st.SetContextLoaded(true)
+	st.SetResult(BuildStatusSuccess, "12s", nil, 0, 0)
+	if view := stripANSI(st.View(DefaultStyles())); strings.Contains(view, "Targets") {
+		t.Fatalf("card shown without targets:\n%s", view)
+	}
+
+	targets := []core.TargetBuildInfo{{Name: "App", Compiled: true, FileCount: 3, Duration: 2500 * time.Millisecond}}
+	for i := range 7 {
+		targets = append(targets, core.TargetBuildInfo{Name: fmt.Sprintf("Cached%d", i)})
+	}
+	st.SetTargets(targets)
+	view := stripANSI(st.View(DefaultStyles()))
+	for _, want := range []string{"Targets", "1 built · 7 cached", "2.50s  App (3 files)", " cached  Cached0", "… 3 more · enter to expand"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("card missing %q:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "Cached6") {
+		t.Fatalf("collapsed card lists every target:\n%s", view)
+	}
+
+	st.ToggleTargets()
+	if view := stripANSI(st.View(DefaultStyles())); !strings.Contains(view, "Cached6") || !strings.Contains(view, "enter to collapse") {
+		t.Fatalf("expanded card misses targets:\n%s", view)
+	}
+
+	st.Clear()
+	if st.Targets != nil || st.TargetsExpanded {
+		t.Fatalf("Clear kept the targets")
+	}
+}
+
+func TestOpenTargets(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.openTargets()
+	if m.mode == ModeSelector {
+		t.Fatal("opened without a build")
+	}
+
+	m.lastBuild.Targets = []core.TargetBuildInfo{
+		{Name: "App", Project: "App", Compiled: true, FileCount: 2, Duration: time.Second},
+		{Name: "Core", Project: "Packages"},
+	}
+	m.openTargets()
+	if m.mode != ModeSelector || m.selectorType != SelectorTargets {
+		t.Fatalf("mode = %v, selector = %v", m.mode, m.selectorType)
+	}
+	items := TargetItems(m.lastBuild.Targets)
+	if items[0].Description != "built · 2 files · 1.00s" || items[1].Description != "cached · Packages" {
+		t.Fatalf("items = %+v", items)
+	}
+}
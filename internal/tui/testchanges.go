@@ -0,0 +1,93 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// =============================================================================
+// Test Run Changes
+// =============================================================================
+
+// SetTestChanges sets how the finished test run's failures compare with the
+// scheme's previous run (nil for the first run)
+func (st *SummaryTab) SetTestChanges(c *core.TestChanges) {
+	st.TestChanges = c
+}
+
+// testChangesView renders e.g. "2 new failures, 1 fixed" with new failures
+// in red and fixed tests in green
+func (st *SummaryTab) testChangesView(styles Styles) string {
+	c := *st.TestChanges
+	var parts []string
+	if n := len(c.NewFailures); n > 0 {
+		noun := "new failures"
+		if n == 1 {
+			noun = "new failure"
+		}
+		parts = append(parts, lipgloss.NewStyle().Foreground(styles.Colors.Error).Bold(true).Render(fmt.Sprintf("%d %s", n, noun)))
+	}
+	if n := len(c.Fixed); n > 0 {
+		parts = append(parts, lipgloss.NewStyle().Foreground(styles.Colors.Success).Render(fmt.Sprintf("%d fixed", n)))
+	}
+	muted := lipgloss.NewStyle().Foreground(styles.Colors.TextMuted)
+	if n := len(c.StillFailing); n > 0 {
+		parts = append(parts, muted.Render(fmt.Sprintf("%d still failing", n)))
+	}
+	if len(parts) == 0 {
+		return muted.Render("none since the last run")
+	}
+	return strings.Join(parts, ", ")
+}
+
+var (
+	// XCTest: "-[AppTests.LoginTests testSSO] : XCTAssertTrue failed"
+	xctestIssueRE = regexp.MustCompile(`-\[(?:[\w]+\.)?(\w+) (\w+)\]`)
+	// Swift Testing: `Test checkout() recorded an issue at ...`
+	swiftTestingIssueRE = regexp.MustCompile(`Test ("[^"]+"|\S+) recorded an issue`)
+)
+
+// issueTestName returns the "Suite/test" (XCTest) or "test" (Swift Testing)
+// a test failure issue names, or "" for other issues
+func issueTestName(issue Issue) string {
+	// The message may be trimmed to the failure; the full text keeps the
+	// test's name
+	text := issue.Message + "\n" + issue.FullText
+	if m := xctestIssueRE.FindStringSubmatch(text); m != nil {
+		return m[1] + "/" + m[2]
+	}
+	if m := swiftTestingIssueRE.FindStringSubmatch(text); m != nil {
+		return core.TestKey(strings.Trim(m[1], `"`))
+	}
+	return ""
+}
+
+// MarkNewTestFailures flags the test failures whose test is in keys (see
+// core.TestKey) as new since the previous run; they are listed first among
+// the errors with a NEW badge.
+func (it *IssuesTab) MarkNewTestFailures(keys []string) {
+	isNew := func(issue Issue) bool {
+		name := issueTestName(issue)
+		if issue.Type != IssueTypeError || name == "" {
+			return false
+		}
+		for _, key := range keys {
+			if key == name || strings.HasSuffix(key, "/"+name) {
+				return true
+			}
+		}
+		return false
+	}
+	for i := range it.Issues {
+		it.Issues[i].New = isNew(it.Issues[i])
+	}
+	for i := range it.hidden {
+		it.hidden[i].New = isNew(it.hidden[i])
+	}
+	it.sortIssues()
+}
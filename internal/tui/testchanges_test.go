@@ -0,0 +1,65 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func TestTestChangesShownOnSummaryCard(t *testing.T) {
+	st := NewSummaryTab()
+	st.SetSize(100, 40)
+	st.ActionType = "test"
+	st.SetResult(BuildStatusFailed, "3s", nil, 2, 0)
+	st.SetTestChanges(&core.TestChanges{NewFailures: []string{"App/A/a", "App/B/b"}, Fixed: []string{"App/C/c"}})
+	if view := stripANSI(st.View(DefaultStyles())); !strings.Contains(view, "Changes: 2 new failures, 1 fixed") {
+		t.Fatalf("changes missing from the failure card:\n%s", view)
+	}
+	st.Clear()
+	if st.TestChanges != nil {
+		t.Fatal("Clear should drop the changes")
+	}
+}
+
+func TestNewTestFailuresListedFirst(t *testing.T) {
+	tv := NewTabView()
+	tv.AddRawLine("/src/LoginTests.swift:12: error: -[AppTests.LoginTests testSSO] : XCTAssertTrue failed")
+	tv.AddRawLine("/src/SyncTests.swift:30: error: -[AppTests.SyncTests testUpload] : XCTAssertEqual failed")
+	tv.IssuesTab.AddIssue(IssueTypeError, "✘ Test addition(a:b:) recorded an issue at MathTests.swift:9:5: Expectation failed")
+
+	tv.IssuesTab.MarkNewTestFailures([]string{"AppTests/SyncTests/testUpload", "AppTests/MathTests/addition"})
+	issues := tv.IssuesTab.Issues
+	if len(issues) != 3 {
+		t.Fatalf("expected 3 issues, got %d", len(issues))
+	}
+	if !issues[0].New || !issues[1].New || issues[2].New {
+		t.Fatalf("new failures should come first: %+v", issues)
+	}
+	if !strings.Contains(issues[2].Message, "testSSO") {
+		t.Fatalf("still-failing test should come last, got %q", issues[2].Message)
+	}
+
+	tv.IssuesTab.SetSize(100, 20)
+	if view := stripANSI(tv.IssuesTab.View(DefaultStyles())); strings.Count(view, "NEW ") != 2 {
+		t.Fatalf("expected two NEW badges:\n%s", view)
+	}
+}
+
+func TestTestRunChangesReachTabs(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.tabView.AddRawLine("/src/SyncTests.swift:30: error: -[AppTests.SyncTests testUpload] : XCTAssertEqual failed")
+	changes := &core.TestChanges{NewFailures: []string{"AppTests/SyncTests/testUpload"}}
+	m.handleOpDone(opDoneMsg{cmd: "test", err: errors.New("tests failed"), test: &core.TestResult{
+		Summary: core.TestSummary{Total: 2, Passed: 1, Failed: 1},
+		Changes: changes,
+	}})
+	if m.tabView.SummaryTab.TestChanges != changes {
+		t.Fatal("summary card should show the changes")
+	}
+	if issues := m.tabView.IssuesTab.Issues; len(issues) == 0 || !issues[0].New {
+		t.Fatalf("new failure not marked: %+v", issues)
+	}
+}
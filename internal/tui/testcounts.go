@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// =============================================================================
+// Test Counts
+// =============================================================================
+
+// TestCounts tallies test outcomes for the live counter and completion view
+type TestCounts struct {
+	Passed           int
+	Failed           int
+	Skipped          int
+	ExpectedFailures int
+	Flaky            int // passed only after a retry (also counted in Passed)
+}
+
+// Any reports whether at least one test outcome was seen
+func (c TestCounts) Any() bool {
+	return c.Passed+c.Failed+c.Skipped+c.ExpectedFailures > 0
+}
+
+// testCountsFromSummary converts the xcresult summary counts
+func testCountsFromSummary(s core.TestSummary) TestCounts {
+	return TestCounts{Passed: s.Passed, Failed: s.Failed, Skipped: s.Skipped, ExpectedFailures: s.ExpectedFailures, Flaky: len(s.FlakyTests)}
+}
+
+// View renders the counter, e.g. "✓ 30 ✗ 1 ↷ 4"
+func (c TestCounts) View(styles Styles) string {
+	icons := styles.Icons
+	parts := []string{styles.StatusStyle("success").Render(fmt.Sprintf("%s %d", icons.Success, c.Passed))}
+	if c.Failed > 0 {
+		parts = append(parts, styles.StatusStyle("error").Render(fmt.Sprintf("%s %d", icons.Error, c.Failed)))
+	}
+	if c.Skipped > 0 {
+		parts = append(parts, lipgloss.NewStyle().Foreground(styles.Colors.Warning).Render(fmt.Sprintf("%s %d", icons.Skipped, c.Skipped)))
+	}
+	if c.Flaky > 0 {
+		parts = append(parts, lipgloss.NewStyle().Foreground(styles.Colors.Warning).Bold(true).Render(fmt.Sprintf("%s %d flaky", icons.Flaky, c.Flaky)))
+	}
+	if c.ExpectedFailures > 0 {
+		parts = append(parts, lipgloss.NewStyle().Foreground(styles.Colors.TextMuted).Render(fmt.Sprintf("(%d expected failures)", c.ExpectedFailures)))
+	}
+	return strings.Join(parts, " ")
+}
+
+var (
+	// XCTest: Test Case '-[App.LoginTests testSSO]' skipped (0.001 seconds).
+	//         Test case 'LoginTests.testSSO()' passed on 'iPhone 15' (0.1 seconds)
+	xctestOutcomeRE = regexp.MustCompile(`^\s*Test [Cc]ase '[^']+' (passed|failed|skipped)\b`)
+	// Swift Testing: ✔ Test checkout() passed after 0.002 seconds.
+	//                ➜ Test sso() skipped.
+	swiftTestingOutcomeRE = regexp.MustCompile(`^\s*\S+ Test (?:"[^"]*"|\S+) (passed|failed|skipped)(?: after|[.:]|$)`)
+)
+
+// testCaseOutcome returns "passed", "failed" or "skipped" for a line
+// reporting a finished test case, or "".
+func testCaseOutcome(line string) string {
+	if m := xctestOutcomeRE.FindStringSubmatch(line); m != nil {
+		return m[1]
+	}
+	if m := swiftTestingOutcomeRE.FindStringSubmatch(line); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// observeTestLine updates the live counter from raw xcodebuild output
+func (c *TestCounts) observeTestLine(line string) bool {
+	switch testCaseOutcome(line) {
+	case "passed":
+		c.Passed++
+	case "failed":
+		c.Failed++
+	case "skipped":
+		c.Skipped++
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,83 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func TestTestCaseOutcome(t *testing.T) {
+	tests := map[string]string{
+		"Test Case '-[AppTests.LoginTests testSSO]' skipped (0.001 seconds).":            "skipped",
+		"Test Case '-[AppTests.LoginTests testLogin]' passed (0.120 seconds).":           "passed",
+		"Test Case '-[AppTests.LoginTests testLogout]' failed (0.050 seconds).":          "failed",
+		"Test case 'LoginTests.testSSO()' passed on 'iPhone 15 Pro' (0.001 seconds)":     "passed",
+		"Test Case '-[AppTests.LoginTests testSSO]' started.":                            "",
+		"✔ Test checkout() passed after 0.002 seconds.":                                  "passed",
+		"✘ Test checkout() failed after 0.010 seconds with 1 issue.":                     "failed",
+		"➜ Test applePay() skipped.":                                                     "skipped",
+		"➜ Test \"Apple Pay sheet\" skipped: \"needs hardware\"":                         "skipped",
+		"✘ Test checkout() recorded an issue at Checkout.swift:12:5: Expectation failed": "",
+		"✔ Suite CheckoutTests passed after 0.012 seconds.":                              "",
+		"Test Suite 'LoginTests' passed at 2024-10-14 10:00:00.000.":                     "",
+	}
+	for line, want := range tests {
+		if got := testCaseOutcome(line); got != want {
+			t.Errorf("testCaseOutcome(%q) = %q, want %q", line, got, want)
+		}
+	}
+}
+
+func TestTestCountsView(t *testing.T) {
+	styles := DefaultStyles()
+	got := TestCounts{Passed: 30, Failed: 1, Skipped: 4}.View(styles)
+	for _, want := range []string{"30", "1", "4", styles.Icons.Skipped} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("View() = %q, missing %q", got, want)
+		}
+	}
+}
+
+func TestIssuesTabSkippedTestsEntry(t *testing.T) {
+	it := NewIssuesTab()
+	it.AddIssue(IssueTypeWarning, "/tmp/A.swift:1:1: warning: unused")
+	it.SetSkippedTests(2, []string{"LoginTests/testSSO()", "LoginTests/testPasskeys()"})
+	it.SetSkippedTests(2, []string{"LoginTests/testSSO()", "LoginTests/testPasskeys()"})
+
+	if len(it.Issues) != 2 {
+		t.Fatalf("expected warning + one skipped entry, got %d", len(it.Issues))
+	}
+	skipped := it.Issues[1]
+	if skipped.TestList != "skipped" || skipped.Message != "2 skipped tests" || !strings.Contains(skipped.FullText, "LoginTests/testPasskeys()") {
+		t.Fatalf("unexpected skipped entry: %+v", skipped)
+	}
+
+	it.SetFlakyTests([]string{"OnboardingUITests/testSwipeThrough()"})
+	if len(it.Issues) != 3 || it.Issues[2].TestList != "flaky" || it.Issues[2].Message != "1 flaky test" {
+		t.Fatalf("expected flaky entry alongside skipped: %+v", it.Issues)
+	}
+	it.SetFlakyTests(nil)
+
+	it.SetSkippedTests(0, nil)
+	if len(it.Issues) != 1 || it.Issues[0].TestList != "" {
+		t.Fatalf("expected skipped entry removed: %+v", it.Issues)
+	}
+}
+
+func TestModelCountsTestOutcomes(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.running = true
+	m.runningCmd = "test"
+
+	m.handleEvent(core.Log("test", "Test Case '-[AppTests.A testOne]' passed (0.1 seconds)."))
+	m.handleEvent(core.Log("test", "Test Case '-[AppTests.A testTwo]' skipped (0.0 seconds)."))
+	m.handleEvent(core.LogPretty("test", "✓ testOne (0.1 seconds)"))
+	if m.testCounts != (TestCounts{Passed: 1, Skipped: 1}) {
+		t.Fatalf("unexpected live counts: %+v", m.testCounts)
+	}
+	if m.tabView.SummaryTab.Tests != m.testCounts {
+		t.Fatalf("dashboard counter not updated: %+v", m.tabView.SummaryTab.Tests)
+	}
+}
@@ -0,0 +1,69 @@
+package tui
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// =============================================================================
+// Test Plans
+// =============================================================================
+
+// testPlansMsg carries test plans listed on demand for the selector
+type testPlansMsg struct {
+	scheme string
+	plans  []string
+	err    error
+}
+
+// TestPlanItems creates selector items; the empty ID runs the scheme default
+func TestPlanItems(plans []string) []SelectorItem {
+	items := make([]SelectorItem, 0, len(plans)+1)
+	items = append(items, SelectorItem{ID: "", Title: "Scheme default", Description: "Don't pass -testPlan"})
+	for _, p := range plans {
+		items = append(items, SelectorItem{ID: p, Title: p})
+	}
+	return items
+}
+
+// openTestPlanSelector shows the scheme's test plans, listing them first
+// when context loading skipped them (they need a slow xcodebuild call)
+func (m *Model) openTestPlanSelector() tea.Cmd {
+	if m.cfg.Scheme == "" {
+		m.setStatus("Select a scheme first")
+		return nil
+	}
+	if len(m.info.TestPlans) == 0 {
+		m.setStatus("Listing test plans for " + m.cfg.Scheme + "…")
+		root, cfg := m.projectRoot, m.cfg
+		return func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			plans, err := core.XcodebuildTestPlans(ctx, root, cfg)
+			return testPlansMsg{scheme: cfg.Scheme, plans: plans, err: err}
+		}
+	}
+	m.selector = NewSelectorWithSelected("Select Test Plan", TestPlanItems(m.info.TestPlans), m.cfg.Test.Plan, m.width, m.styles)
+	m.selectorType = SelectorTestPlan
+	m.mode = ModeSelector
+	return nil
+}
+
+func (m *Model) handleTestPlans(msg testPlansMsg) tea.Cmd {
+	switch {
+	case msg.err != nil:
+		m.setStatus("Could not list test plans: " + msg.err.Error())
+	case msg.scheme != m.cfg.Scheme:
+		// Scheme changed while listing; the result is stale.
+	case len(msg.plans) == 0:
+		m.setStatus("Scheme " + msg.scheme + " has no test plans")
+	default:
+		m.info.TestPlans = msg.plans
+		return m.openTestPlanSelector()
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// testSourceTimeout bounds the search for a test's source
+const testSourceTimeout = 10 * time.Second
+
+// testSourceMsg delivers the search for the source of a test failure
+// reported without a file
+type testSourceMsg struct {
+	tab     *IssuesTab
+	seq     int
+	name    string
+	matches []core.TestSourceMatch
+	err     error
+}
+
+// testSourcePick is the issue waiting for the user to pick among several
+// matching test sources
+type testSourcePick struct {
+	tab     *IssuesTab
+	seq     int
+	name    string
+	matches []core.TestSourceMatch
+}
+
+// SetSource records where the issue's test was found, so the next open is
+// instant
+func (it *IssuesTab) SetSource(seq int, file string, line int) {
+	if issue := it.bySeq(seq); issue != nil {
+		issue.SourceFile, issue.SourceLine = file, line
+	}
+}
+
+// openIssueInXcode opens the selected issue's file at its line. Test
+// failures without a file open at the test method, found by searching the
+// test sources; other issues open the project.
+func (m *Model) openIssueInXcode() tea.Cmd {
+	tab := m.tabView.VisibleIssuesTab()
+	issue := tab.GetSelectedIssue()
+	switch {
+	case issue == nil:
+		return m.openInXcode()
+	case issue.File != "":
+		path := issue.File
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(m.projectRoot, path)
+		}
+		return m.openFileInXcode(path, issue.Line, "")
+	case issue.SourceFile != "":
+		return m.openFileInXcode(issue.SourceFile, issue.SourceLine, "")
+	}
+	name := issueTestName(*issue)
+	if name == "" {
+		return m.openInXcode()
+	}
+	if matches, ok := m.testSources[name]; ok {
+		return m.openTestSource(tab, issue.seq, name, matches)
+	}
+
+	m.setStatus("Looking for " + name + "…")
+	class, method := splitTestName(name)
+	root, paths, seq := m.projectRoot, m.cfg.Test.SourcePaths, issue.seq
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), testSourceTimeout)
+		defer cancel()
+		matches, err := core.FindTestSource(ctx, root, paths, class, method)
+		return testSourceMsg{tab: tab, seq: seq, name: name, matches: matches, err: err}
+	}
+}
+
+// splitTestName splits "Suite/test" into the class and method; Swift
+// Testing names may have no suite
+func splitTestName(name string) (class, method string) {
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		return name[:i], name[i+1:]
+	}
+	return "", name
+}
+
+func (m *Model) handleTestSource(msg testSourceMsg) tea.Cmd {
+	if msg.err != nil {
+		m.setStatus("Could not search for " + msg.name + ": " + msg.err.Error())
+		return nil
+	}
+	if m.testSources == nil {
+		m.testSources = map[string][]core.TestSourceMatch{}
+	}
+	m.testSources[msg.name] = msg.matches
+	return m.openTestSource(msg.tab, msg.seq, msg.name, msg.matches)
+}
+
+// openTestSource opens the only match, or asks which one to open
+func (m *Model) openTestSource(tab *IssuesTab, seq int, name string, matches []core.TestSourceMatch) tea.Cmd {
+	switch len(matches) {
+	case 0:
+		m.setStatus("No source found for " + name)
+		return nil
+	case 1:
+		return m.openTestMatch(tab, seq, name, matches[0])
+	}
+	items := make([]SelectorItem, len(matches))
+	for i, match := range matches {
+		items[i] = SelectorItem{
+			ID:    strconv.Itoa(i),
+			Title: fmt.Sprintf("%s:%d", relativeToRoot(m.projectRoot, match.File), match.Line),
+		}
+		if match.ClassOnly {
+			items[i].Description = "test class"
+		}
+	}
+	m.testSourcePick = &testSourcePick{tab: tab, seq: seq, name: name, matches: matches}
+	m.selector = NewSelector("Open "+name+" in", items, m.width, m.styles)
+	m.selectorType = SelectorTestSource
+	m.mode = ModeSelector
+	return nil
+}
+
+func (m *Model) pickTestSource(item *SelectorItem) tea.Cmd {
+	pick := m.testSourcePick
+	m.testSourcePick = nil
+	i, err := strconv.Atoi(item.ID)
+	if pick == nil || err != nil || i < 0 || i >= len(pick.matches) {
+		return nil
+	}
+	return m.openTestMatch(pick.tab, pick.seq, pick.name, pick.matches[i])
+}
+
+// openTestMatch remembers the match on the issue and opens it
+func (m *Model) openTestMatch(tab *IssuesTab, seq int, name string, match core.TestSourceMatch) tea.Cmd {
+	tab.SetSource(seq, match.File, match.Line)
+	note := ""
+	if match.ClassOnly {
+		_, method := splitTestName(name)
+		note = " (" + method + " not found, opened its class)"
+	}
+	return m.openFileInXcode(match.File, match.Line, note)
+}
+
+// openFileInXcode opens path at line with xed
+func (m *Model) openFileInXcode(path string, line int, note string) tea.Cmd {
+	args := []string{path}
+	loc := relativeToRoot(m.projectRoot, path)
+	if line > 0 {
+		args = []string{"-l", strconv.Itoa(line), path}
+		loc += ":" + strconv.Itoa(line)
+	}
+	return func() tea.Msg {
+		if err := exec.Command("xed", args...).Start(); err != nil {
+			return statusMsg("Failed to open Xcode: " + err.Error())
+		}
+		return statusMsg("Opened " + loc + " in Xcode" + note)
+	}
+}
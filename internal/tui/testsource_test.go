@@ -0,0 +1,59 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOpenTestFailureResolvesSource(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	root := t.TempDir()
+	for name, content := range map[string]string{
+		"AppTests/LoginTests.swift": "final class LoginTests: XCTestCase {\n    func testSSO() {}\n}\n",
+		"AppTests/CartTests.swift":  "@Suite struct CartTests {\n  @Test func total() {}\n}\n",
+		"AppTests/OrderTests.swift": "@Suite struct OrderTests {\n  @Test func total() {}\n}\n",
+	} {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	m := NewModel(root, "", ConfigOverrides{})
+	m.mode = ModeNormal
+	m.tabView.IssuesTab.AddIssue(IssueTypeError, "-[AppTests.LoginTests testSSO] : XCTAssertTrue failed")
+	m.selectTab(TabIssues)
+
+	cmd := m.openIssueInXcode()
+	if cmd == nil {
+		t.Fatal("expected a source search")
+	}
+	m = updateModel(m, cmd())
+	issue := m.tabView.IssuesTab.GetSelectedIssue()
+	want := filepath.Join(root, "AppTests/LoginTests.swift")
+	if issue.SourceFile != want || issue.SourceLine != 2 {
+		t.Fatalf("source not stored on the issue: %q:%d", issue.SourceFile, issue.SourceLine)
+	}
+	if len(m.testSources["LoginTests/testSSO"]) != 1 {
+		t.Fatalf("mapping not cached: %+v", m.testSources)
+	}
+
+	// Two Swift Testing suites declare total(): ask which one
+	m.tabView.IssuesTab.AddIssue(IssueTypeError, "Test total() recorded an issue at CartTests.swift:2:3: Expectation failed")
+	m.tabView.IssuesTab.Selected = 1
+	if got := issueTestName(*m.tabView.IssuesTab.GetSelectedIssue()); got != "total" {
+		t.Fatalf("test name = %q", got)
+	}
+	m.tabView.IssuesTab.GetSelectedIssue().File = ""
+	m = updateModel(m, m.openIssueInXcode()())
+	if m.mode != ModeSelector || m.selectorType != SelectorTestSource || len(m.testSourcePick.matches) != 2 {
+		t.Fatalf("expected a selector, mode=%v", m.mode)
+	}
+	m.pickTestSource(&SelectorItem{ID: "1"})
+	if issue := m.tabView.IssuesTab.GetSelectedIssue(); issue.SourceFile != filepath.Join(root, "AppTests/OrderTests.swift") {
+		t.Fatalf("picked source not stored: %q", issue.SourceFile)
+	}
+}
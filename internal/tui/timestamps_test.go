@@ -0,0 +1,74 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func TestStreamKeepsEmitTimestampsAcrossBurst(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.runningCmd = "build"
+
+	// 5k lines emitted 1ms apart, delivered in one burst well after the fact.
+	base := time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+	const n = 5000
+	for i := 0; i < n; i++ {
+		ev := core.LogRaw("build", fmt.Sprintf("CompileSwift normal arm64 File%d.swift", i))
+		ev.TS = base.Add(time.Duration(i) * time.Millisecond).Format(time.RFC3339Nano)
+		m.handleEvent(ev)
+	}
+
+	lines := m.tabView.StreamTab.Lines
+	if len(lines) != n {
+		t.Fatalf("expected %d lines, got %d", n, len(lines))
+	}
+	for i, line := range lines {
+		want := base.Add(time.Duration(i) * time.Millisecond)
+		if !line.Timestamp.Equal(want) {
+			t.Fatalf("line %d stamped %v, want emit time %v", i, line.Timestamp, want)
+		}
+		if i > 0 && line.Timestamp.Before(lines[i-1].Timestamp) {
+			t.Fatalf("line %d out of order", i)
+		}
+	}
+
+	st := m.tabView.StreamTab
+	st.ShowTimestamps = true
+	st.ShowLineNumbers = false
+	st.VisibleRows = n
+	st.ScrollPos = 0
+	stampRE := regexp.MustCompile(`^\d{2}:\d{2}:\d{2} CompileSwift`)
+	for i, line := range strings.Split(st.GetVisibleContent(), "\n") {
+		if !stampRE.MatchString(line) {
+			t.Fatalf("copied line %d has unstable format: %q", i, line)
+		}
+	}
+	first := base.Local().Format(streamTimestampLayout)
+	if got := st.GetCurrentLine(); !strings.HasPrefix(got, first+" ") {
+		t.Fatalf("expected copy to start with %s, got %q", first, got)
+	}
+}
+
+func TestStreamFallsBackToReceiveTime(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModel(t.TempDir(), "", ConfigOverrides{})
+	m.runningCmd = "build"
+
+	before := time.Now()
+	m.handleEvent(core.Event{Cmd: "build", Type: "log_raw", Msg: "legacy line"})
+	after := time.Now()
+
+	lines := m.tabView.StreamTab.Lines
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 line, got %d", len(lines))
+	}
+	if ts := lines[0].Timestamp; ts.Before(before) || ts.After(after) {
+		t.Fatalf("expected receive-time fallback, got %v", ts)
+	}
+}
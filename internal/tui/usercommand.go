@@ -0,0 +1,95 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// =============================================================================
+// Project Commands
+// =============================================================================
+
+// userCommandOpPrefix marks the op of a project command ("command:mocks")
+const userCommandOpPrefix = "command:"
+
+func userCommandOp(id string) string {
+	return userCommandOpPrefix + id
+}
+
+func isUserCommandOp(name string) bool {
+	return strings.HasPrefix(name, userCommandOpPrefix)
+}
+
+// UserCommands creates palette commands for the configured project commands
+func UserCommands(cmds []core.UserCommand) []Command {
+	out := make([]Command, 0, len(cmds))
+	for _, uc := range cmds {
+		desc := uc.Description
+		if desc == "" {
+			desc = uc.Shell
+		}
+		out = append(out, Command{ID: uc.ID, Name: uc.Title(), Description: desc, Category: "Project"})
+	}
+	return out
+}
+
+// builtinCommandIDs are the palette IDs project commands may not reuse
+func builtinCommandIDs() []string {
+	cmds := DefaultCommands()
+	ids := make([]string, 0, len(cmds))
+	for _, c := range cmds {
+		ids = append(ids, c.ID)
+	}
+	return ids
+}
+
+// requestUserCommand runs a project command, asking first when it has
+// needsConfirm set. Unlike build/run/test it never cancels a running op.
+func (m *Model) requestUserCommand(uc core.UserCommand) tea.Cmd {
+	if m.running {
+		m.setStatus("An operation is already running")
+		return nil
+	}
+	if !uc.NeedsConfirm {
+		return m.startOp(userCommandOp(uc.ID))
+	}
+	m.openConfirm(ConfirmPrompt{
+		Title: fmt.Sprintf("Run %s?", uc.Title()),
+		Body:  []string{"$ " + uc.Shell},
+		OnYes: func(m *Model) tea.Cmd {
+			if m.running {
+				m.setStatus("An operation is already running")
+				return nil
+			}
+			return m.startOp(userCommandOp(uc.ID))
+		},
+	})
+	return nil
+}
+
+// rerunUserCommand looks up the command of a recorded op again, so a
+// command removed from the config since is reported rather than run
+func (m *Model) rerunUserCommand(name string) tea.Cmd {
+	id := strings.TrimPrefix(name, userCommandOpPrefix)
+	uc, ok := m.cfg.UserCommandByID(id)
+	if !ok {
+		m.setStatus(fmt.Sprintf("Command %s is no longer configured", id))
+		return nil
+	}
+	return m.requestUserCommand(uc)
+}
+
+// runUserCommandOp runs the project command of op name (in the op goroutine)
+func runUserCommandOp(ctx context.Context, root string, cfg core.Config, name string, emit core.Emitter) opDoneMsg {
+	id := strings.TrimPrefix(name, userCommandOpPrefix)
+	uc, ok := cfg.UserCommandByID(id)
+	if !ok {
+		return opDoneMsg{cmd: name, err: fmt.Errorf("unknown command %s", id)}
+	}
+	return opDoneMsg{cmd: name, err: core.RunUserCommand(ctx, root, cfg, uc, emit)}
+}
@@ -0,0 +1,97 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func TestUserCommandsAppearInPaletteFilter(t *testing.T) {
+	p := NewPalette(120, DefaultStyles(), []core.UserCommand{
+		{ID: "mocks", Name: "Generate Mocks", Shell: "make mocks"},
+		{ID: "bump", Shell: "agvtool next-version -all", Description: "Bump the build number"},
+	})
+	p.input.SetValue("mocks")
+	p.filterCommands()
+	if len(p.filtered) == 0 || p.filtered[0].ID != "mocks" || p.filtered[0].Category != "Project" {
+		t.Fatalf("expected the project command first, got %+v", p.filtered)
+	}
+
+	p.input.SetValue("bump")
+	p.filterCommands()
+	if len(p.filtered) == 0 || p.filtered[0].Name != "bump" || p.filtered[0].Description != "Bump the build number" {
+		t.Fatalf("unnamed command should be listed by ID: %+v", p.filtered)
+	}
+}
+
+func TestUserCommandRunsInProjectRoot(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	root := t.TempDir()
+	m := NewModel(root, "", ConfigOverrides{})
+	m.cfg.Commands = []core.UserCommand{{ID: "where", Name: "Where", Shell: "pwd > where.txt; echo done"}}
+
+	if cmd := m.executePaletteCommand(&Command{ID: "where"}); cmd == nil || !m.running {
+		t.Fatalf("palette should start the project command")
+	}
+	if m.runningCmd != "command:where" {
+		t.Fatalf("unexpected op %q", m.runningCmd)
+	}
+	if cmd := m.executePaletteCommand(&Command{ID: "where"}); cmd != nil || !strings.Contains(m.statusMsg, "already running") {
+		t.Fatalf("a second command should be refused while one runs: %q", m.statusMsg)
+	}
+	done := <-m.doneCh
+	if done.err != nil {
+		t.Fatalf("command failed: %v", done.err)
+	}
+	m.handleOpDone(done)
+	if !strings.Contains(m.statusMsg, "COMMAND:WHERE done") {
+		t.Fatalf("unexpected status %q", m.statusMsg)
+	}
+
+	got, err := os.ReadFile(filepath.Join(root, "where.txt"))
+	if err != nil {
+		t.Fatalf("command did not run in the project root: %v", err)
+	}
+	want, _ := filepath.EvalSymlinks(root)
+	if dir, _ := filepath.EvalSymlinks(strings.TrimSpace(string(got))); dir != want {
+		t.Fatalf("ran in %q, want %q", got, want)
+	}
+}
+
+func TestUserCommandNeedsConfirm(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	root := t.TempDir()
+	m := NewModel(root, "", ConfigOverrides{})
+	m.cfg.Commands = []core.UserCommand{{ID: "wipe", Shell: "touch wiped.txt", NeedsConfirm: true}}
+
+	m.executePaletteCommand(&Command{ID: "wipe"})
+	if m.mode != ModeConfirm || m.running {
+		t.Fatalf("needsConfirm should ask first: mode=%v running=%v", m.mode, m.running)
+	}
+	m.handleConfirmKey(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.running {
+		t.Fatalf("declining should not run the command")
+	}
+	if _, err := os.Stat(filepath.Join(root, "wiped.txt")); err == nil {
+		t.Fatalf("declined command ran")
+	}
+}
+
+func TestUserCommandCollidingWithBuiltinIsRejected(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	root := t.TempDir()
+	cfg := core.DefaultConfig(root)
+	cfg.Commands = []core.UserCommand{{ID: "build", Shell: "make"}}
+	if err := core.SaveConfig(root, "", cfg); err != nil {
+		t.Fatal(err)
+	}
+	msg := loadContextCmd(root, "", ConfigOverrides{})().(contextLoadedMsg)
+	if msg.err == nil || !strings.Contains(msg.err.Error(), `"build" is taken by a built-in command`) {
+		t.Fatalf("expected a collision error, got %v", msg.err)
+	}
+}
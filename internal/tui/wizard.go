@@ -1,8 +1,12 @@
 package tui
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/huh"
@@ -13,47 +17,95 @@ type wizardDoneMsg struct {
 	cfg     core.Config
 	aborted bool
 	err     error
+	// gitignore is what to add to the project's .gitignore after the
+	// change is previewed and confirmed ("" to leave it alone).
+	gitignore core.GitignoreMode
+}
+
+// wizardPlatformsMsg carries the scheme's platform families for the wizard default.
+type wizardPlatformsMsg struct {
+	scheme   string
+	families []core.PlatformFamily
+}
+
+func wizardPlatformsCmd(projectRoot string, cfg core.Config) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		// Best-effort: on failure the wizard falls back to iOS.
+		families, _ := core.SchemePlatformFamilies(ctx, projectRoot, cfg)
+		return wizardPlatformsMsg{scheme: cfg.Scheme, families: families}
+	}
 }
 
 type wizardModel struct {
 	info core.ContextInfo
 	cfg  core.Config
 
-	projectChoice string
-	scheme        string
-	configuration string
-	destKind      string
-	targetUDID    string
+	// Form values live behind a pointer: huh writes through the pointers it
+	// was given, and wizardModel is copied by value on every Update.
+	vals *wizardValues
 
 	form *huh.Form
 }
 
-func newWizard(info core.ContextInfo, cfg core.Config, width int) wizardModel {
+type wizardValues struct {
+	ProjectChoice string
+	Scheme        string
+	Configuration string
+	Filter        wizardDestFilter
+	TargetUDID    string
+	Gitignore     string
+}
+
+// wizardDestFilter drives the dynamic destination options (platform family
+// first, then destination kind).
+type wizardDestFilter struct {
+	Family string
+	Kind   string
+}
+
+// wizardFamilies are the platform families offered by the pre-filter step.
+var wizardFamilies = []core.PlatformFamily{
+	core.PlatformIOS,
+	core.PlatformIPadOS,
+	core.PlatformTvOS,
+	core.PlatformWatchOS,
+	core.PlatformVisionOS,
+	core.PlatformMacOS,
+}
+
+func newWizard(info core.ContextInfo, cfg core.Config, width int, defaultFamily core.PlatformFamily) wizardModel {
+	v := &wizardValues{}
 	w := wizardModel{
 		info: info,
 		cfg:  cfg,
+		vals: v,
 	}
 
 	// Defaults
 	if cfg.Workspace != "" {
-		w.projectChoice = "workspace:" + cfg.Workspace
+		v.ProjectChoice = "workspace:" + cfg.Workspace
 	} else if cfg.Project != "" {
-		w.projectChoice = "project:" + cfg.Project
+		v.ProjectChoice = "project:" + cfg.Project
 	}
-	w.scheme = cfg.Scheme
-	w.configuration = cfg.Configuration
-	w.destKind = string(cfg.Destination.Kind)
-	if w.destKind == "" || w.destKind == string(core.DestAuto) {
-		w.destKind = string(core.DestSimulator)
+	v.Scheme = cfg.Scheme
+	v.Configuration = cfg.Configuration
+	v.Filter.Kind = string(cfg.Destination.Kind)
+	if v.Filter.Kind == "" || v.Filter.Kind == string(core.DestAuto) {
+		v.Filter.Kind = string(core.DestSimulator)
 	}
-	w.targetUDID = cfg.Destination.UDID
+	v.Filter.Family = string(wizardDefaultFamily(cfg.Destination, defaultFamily))
+	v.TargetUDID = cfg.Destination.UDID
 
 	projOpts := []huh.Option[string]{}
-	for _, ws := range info.Workspaces {
-		projOpts = append(projOpts, huh.NewOption("Workspace: "+ws, "workspace:"+ws))
-	}
-	for _, pr := range info.Projects {
-		projOpts = append(projOpts, huh.NewOption("Project: "+pr, "project:"+pr))
+	// Best first, so Pods and sample apps end up at the bottom
+	for _, c := range core.RankContainers(info) {
+		label := "Project: "
+		if c.Workspace {
+			label = "Workspace: "
+		}
+		projOpts = append(projOpts, huh.NewOption(label+c.Path, c.Kind()+":"+c.Path))
 	}
 	if len(projOpts) == 0 {
 		projOpts = append(projOpts, huh.NewOption("(No workspace/project detected)", ""))
@@ -67,91 +119,85 @@ func newWizard(info core.ContextInfo, cfg core.Config, width int) wizardModel {
 		schemeOpts = append(schemeOpts, huh.NewOption("(No schemes detected)", ""))
 	}
 
-	confList := normalizeConfigurations(info.Configurations, w.configuration)
+	confList := normalizeConfigurations(info.Configurations, v.Configuration)
 	confOpts := make([]huh.Option[string], 0, len(confList))
 	for _, c := range confList {
 		confOpts = append(confOpts, huh.NewOption(c, c))
 	}
 
-	kindOpts := []huh.Option[string]{
-		huh.NewOption("Simulator", string(core.DestSimulator)),
-		huh.NewOption("Device", string(core.DestDevice)),
-		huh.NewOption("macOS", string(core.DestMacOS)),
-		huh.NewOption("Mac Catalyst", string(core.DestCatalyst)),
+	candidates := core.DestinationCandidatesFromContext(info)
+	core.SortDestinationCandidates(candidates)
+
+	familyOpts := make([]huh.Option[string], 0, len(wizardFamilies))
+	for _, f := range wizardFamilies {
+		familyOpts = append(familyOpts, huh.NewOption(familyOptionLabel(f, candidates), string(f)))
 	}
 
-	// Dynamic target select based on destination kind.
+	kindOptions := func() []huh.Option[string] {
+		return wizardKindOptions(core.PlatformFamily(v.Filter.Family))
+	}
+
+	// Dynamic target select based on family + destination kind.
 	targetTitle := func() string {
-		switch core.DestinationKind(w.destKind) {
+		switch core.DestinationKind(v.Filter.Kind) {
 		case core.DestDevice:
 			return "Device"
 		case core.DestSimulator:
 			return "Simulator"
-		case core.DestMacOS, core.DestCatalyst:
-			return "Target"
 		default:
 			return "Target"
 		}
 	}
 	targetOptions := func() []huh.Option[string] {
-		switch core.DestinationKind(w.destKind) {
-		case core.DestDevice:
-			opts := []huh.Option[string]{}
-			for _, d := range info.Devices {
-				label := d.Name
-				if d.OSVersion != "" {
-					label = fmt.Sprintf("%s (iOS %s)", label, d.OSVersion)
-				}
-				opts = append(opts, huh.NewOption(label, d.Identifier))
-			}
-			if len(opts) == 0 {
-				opts = append(opts, huh.NewOption("(No devices found)", ""))
-			}
-			return opts
-		case core.DestSimulator:
-			opts := []huh.Option[string]{}
-			for _, s := range info.Simulators {
-				if !s.Available {
-					continue
-				}
-				label := fmt.Sprintf("%s (%s) [%s]", s.Name, s.RuntimeName, strings.ToLower(s.State))
-				opts = append(opts, huh.NewOption(label, s.UDID))
-			}
-			if len(opts) == 0 {
-				opts = append(opts, huh.NewOption("(No simulators found)", ""))
-			}
-			return opts
-		default:
-			return []huh.Option[string]{huh.NewOption("(No target required)", "")}
-		}
+		return wizardTargetOptions(candidates, v.Filter)
 	}
 
-	form := huh.NewForm(
+	groups := []*huh.Group{
 		huh.NewGroup(
 			huh.NewSelect[string]().
 				Title("Workspace / Project").
 				Options(projOpts...).
-				Value(&w.projectChoice),
+				Value(&v.ProjectChoice),
 			huh.NewSelect[string]().
 				Title("Scheme").
 				Options(schemeOpts...).
-				Value(&w.scheme),
+				Value(&v.Scheme),
 			huh.NewSelect[string]().
 				Title("Configuration").
 				Options(confOpts...).
-				Value(&w.configuration),
+				Value(&v.Configuration),
+		),
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title("Platform").
+				Options(familyOpts...).
+				Value(&v.Filter.Family),
 		),
 		huh.NewGroup(
 			huh.NewSelect[string]().
 				Title("Destination").
-				Options(kindOpts...).
-				Value(&w.destKind),
+				OptionsFunc(kindOptions, &v.Filter.Family).
+				Value(&v.Filter.Kind),
 			huh.NewSelect[string]().
-				TitleFunc(targetTitle, &w.destKind).
-				OptionsFunc(targetOptions, &w.destKind).
-				Value(&w.targetUDID),
+				TitleFunc(targetTitle, &v.Filter).
+				OptionsFunc(targetOptions, &v.Filter).
+				Value(&v.TargetUDID),
 		),
-	).WithShowHelp(true)
+	}
+	if wizardOffersGitignore(info.ProjectRoot) {
+		groups = append(groups, huh.NewGroup(
+			huh.NewSelect[string]().
+				Title("Project .gitignore").
+				Description("Previewed before anything is written").
+				Options(
+					huh.NewOption("Leave unchanged", ""),
+					huh.NewOption("Ignore .xcbolt/", string(core.GitignoreDir)),
+					huh.NewOption("Ignore generated files, commit config.json", string(core.GitignoreSelective)),
+				).
+				Value(&v.Gitignore),
+		))
+	}
+	form := huh.NewForm(groups...).WithShowHelp(true)
 
 	if width > 0 {
 		form.WithWidth(width - 6)
@@ -160,6 +206,120 @@ func newWizard(info core.ContextInfo, cfg core.Config, width int) wizardModel {
 	return w
 }
 
+// wizardOffersGitignore reports whether the wizard asks about the project's
+// .gitignore: only in a git checkout whose .gitignore misses .xcbolt.
+func wizardOffersGitignore(projectRoot string) bool {
+	if projectRoot == "" {
+		return false
+	}
+	if _, err := os.Stat(filepath.Join(projectRoot, ".git")); err != nil {
+		return false
+	}
+	plan, err := core.PlanProjectGitignore(projectRoot, core.GitignoreDir)
+	return err == nil && len(plan.Add) > 0
+}
+
+// wizardDefaultFamily picks the pre-selected platform: the configured family,
+// then the one inferred from the scheme, then iOS.
+func wizardDefaultFamily(dst core.Destination, inferred core.PlatformFamily) core.PlatformFamily {
+	family := dst.PlatformFamily
+	if family == core.PlatformCatalyst {
+		family = core.PlatformIOS
+	}
+	if family == "" || family == core.PlatformUnknown {
+		family = inferred
+	}
+	if family == core.PlatformCatalyst {
+		family = core.PlatformIOS
+	}
+	for _, f := range wizardFamilies {
+		if f == family {
+			return family
+		}
+	}
+	return core.PlatformIOS
+}
+
+func familyOptionLabel(f core.PlatformFamily, candidates []core.DestinationCandidate) string {
+	label := core.PlatformFamilyLabel(f)
+	if f == core.PlatformMacOS {
+		return label
+	}
+	sims, devices := 0, 0
+	for _, c := range candidates {
+		if c.PlatformFamily != f || !c.Available {
+			continue
+		}
+		switch c.TargetType {
+		case core.TargetSimulator:
+			sims++
+		case core.TargetDevice:
+			devices++
+		}
+	}
+	return fmt.Sprintf("%s (%d simulators, %d devices)", label, sims, devices)
+}
+
+func wizardKindOptions(family core.PlatformFamily) []huh.Option[string] {
+	switch family {
+	case core.PlatformMacOS:
+		return []huh.Option[string]{huh.NewOption("My Mac", string(core.DestMacOS))}
+	case core.PlatformIOS, core.PlatformIPadOS:
+		return []huh.Option[string]{
+			huh.NewOption("Simulator", string(core.DestSimulator)),
+			huh.NewOption("Device", string(core.DestDevice)),
+			huh.NewOption("Mac Catalyst", string(core.DestCatalyst)),
+		}
+	default:
+		return []huh.Option[string]{
+			huh.NewOption("Simulator", string(core.DestSimulator)),
+			huh.NewOption("Device", string(core.DestDevice)),
+		}
+	}
+}
+
+// wizardTargetOptions lists candidates for the chosen family and kind,
+// best first (see core.SortDestinationCandidates).
+func wizardTargetOptions(candidates []core.DestinationCandidate, filter wizardDestFilter) []huh.Option[string] {
+	family := core.PlatformFamily(filter.Family)
+	var want core.TargetType
+	switch core.DestinationKind(filter.Kind) {
+	case core.DestSimulator:
+		want = core.TargetSimulator
+	case core.DestDevice:
+		want = core.TargetDevice
+	default:
+		return []huh.Option[string]{huh.NewOption("(No target required)", "")}
+	}
+
+	opts := []huh.Option[string]{}
+	for _, c := range candidates {
+		if c.TargetType != want || c.PlatformFamily != family {
+			continue
+		}
+		if want == core.TargetSimulator && !c.Available {
+			continue
+		}
+		var label string
+		if want == core.TargetSimulator {
+			label = fmt.Sprintf("%s (%s) [%s]", c.Name, c.RuntimeName, strings.ToLower(c.State))
+		} else {
+			label = c.Name
+			if c.OSVersion != "" {
+				label = fmt.Sprintf("%s (%s %s)", label, core.PlatformFamilyLabel(family), c.OSVersion)
+			}
+		}
+		opts = append(opts, huh.NewOption(label, c.ID))
+	}
+	if len(opts) == 0 {
+		if want == core.TargetDevice {
+			return []huh.Option[string]{huh.NewOption("(No devices found)", "")}
+		}
+		return []huh.Option[string]{huh.NewOption("(No simulators found)", "")}
+	}
+	return opts
+}
+
 func (w wizardModel) Init() tea.Cmd {
 	return w.form.Init()
 }
@@ -172,18 +332,28 @@ func (w wizardModel) Update(msg tea.Msg) (wizardModel, tea.Cmd) {
 
 	switch w.form.State {
 	case huh.StateCompleted:
+		v := w.vals
 		cfg := w.cfg
-		cfg.Scheme = strings.TrimSpace(w.scheme)
-		cfg.Configuration = strings.TrimSpace(w.configuration)
-		cfg.Destination.Kind = core.DestinationKind(w.destKind)
-		cfg.Destination.UDID = strings.TrimSpace(w.targetUDID)
-		cfg.Destination.ID = strings.TrimSpace(w.targetUDID)
-
-		if strings.HasPrefix(w.projectChoice, "workspace:") {
-			cfg.Workspace = strings.TrimPrefix(w.projectChoice, "workspace:")
+		family := core.PlatformFamily(v.Filter.Family)
+		kind := core.DestinationKind(v.Filter.Kind)
+		// Family changed after the kind was picked: keep the kind valid.
+		if family == core.PlatformMacOS {
+			kind = core.DestMacOS
+		} else if kind == core.DestMacOS {
+			kind = core.DestSimulator
+		}
+		cfg.Scheme = strings.TrimSpace(v.Scheme)
+		cfg.Configuration = strings.TrimSpace(v.Configuration)
+		cfg.Destination.Kind = kind
+		cfg.Destination.UDID = strings.TrimSpace(v.TargetUDID)
+		cfg.Destination.ID = strings.TrimSpace(v.TargetUDID)
+		cfg.Destination.PlatformFamily = family
+
+		if strings.HasPrefix(v.ProjectChoice, "workspace:") {
+			cfg.Workspace = strings.TrimPrefix(v.ProjectChoice, "workspace:")
 			cfg.Project = ""
-		} else if strings.HasPrefix(w.projectChoice, "project:") {
-			cfg.Project = strings.TrimPrefix(w.projectChoice, "project:")
+		} else if strings.HasPrefix(v.ProjectChoice, "project:") {
+			cfg.Project = strings.TrimPrefix(v.ProjectChoice, "project:")
 			cfg.Workspace = ""
 		}
 
@@ -191,33 +361,31 @@ func (w wizardModel) Update(msg tea.Msg) (wizardModel, tea.Cmd) {
 		switch cfg.Destination.Kind {
 		case core.DestSimulator:
 			cfg.Destination.TargetType = core.TargetSimulator
+			cfg.Destination.Platform = core.PlatformStringForDestination(family, core.TargetSimulator)
 			for _, s := range w.info.Simulators {
 				if s.UDID == cfg.Destination.UDID {
 					cfg.Destination.Name = s.Name
-					cfg.Destination.PlatformFamily = s.PlatformFamily
-					cfg.Destination.Platform = core.PlatformStringForDestination(s.PlatformFamily, core.TargetSimulator)
-					if cfg.Destination.Platform == "" {
-						cfg.Destination.Platform = "iOS Simulator"
-					}
 					cfg.Destination.OS = s.OSVersion
 					cfg.Destination.RuntimeID = s.RuntimeID
 					break
 				}
 			}
+			if cfg.Destination.Platform == "" {
+				cfg.Destination.Platform = "iOS Simulator"
+			}
 		case core.DestDevice:
 			cfg.Destination.TargetType = core.TargetDevice
+			cfg.Destination.Platform = core.PlatformStringForDestination(family, core.TargetDevice)
 			for _, d := range w.info.Devices {
 				if d.Identifier == cfg.Destination.UDID {
 					cfg.Destination.Name = d.Name
-					cfg.Destination.PlatformFamily = d.PlatformFamily
-					cfg.Destination.Platform = core.PlatformStringForDestination(d.PlatformFamily, core.TargetDevice)
-					if cfg.Destination.Platform == "" {
-						cfg.Destination.Platform = "iOS"
-					}
 					cfg.Destination.OS = d.OSVersion
 					break
 				}
 			}
+			if cfg.Destination.Platform == "" {
+				cfg.Destination.Platform = "iOS"
+			}
 		case core.DestMacOS:
 			cfg.Destination.TargetType = core.TargetLocal
 			cfg.Destination.PlatformFamily = core.PlatformMacOS
@@ -236,7 +404,8 @@ func (w wizardModel) Update(msg tea.Msg) (wizardModel, tea.Cmd) {
 			cfg.Destination.UDID = ""
 		}
 
-		return w, tea.Batch(cmd, func() tea.Msg { return wizardDoneMsg{cfg: cfg} })
+		gitignore := core.GitignoreMode(v.Gitignore)
+		return w, tea.Batch(cmd, func() tea.Msg { return wizardDoneMsg{cfg: cfg, gitignore: gitignore} })
 	case huh.StateAborted:
 		return w, tea.Batch(cmd, func() tea.Msg { return wizardDoneMsg{aborted: true} })
 	default:
@@ -0,0 +1,39 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+func TestWizardDefaultFamily(t *testing.T) {
+	if got := wizardDefaultFamily(core.Destination{PlatformFamily: core.PlatformTvOS}, core.PlatformIOS); got != core.PlatformTvOS {
+		t.Fatalf("configured family not kept: %q", got)
+	}
+	if got := wizardDefaultFamily(core.Destination{}, core.PlatformVisionOS); got != core.PlatformVisionOS {
+		t.Fatalf("inferred family not used: %q", got)
+	}
+	if got := wizardDefaultFamily(core.Destination{}, ""); got != core.PlatformIOS {
+		t.Fatalf("fallback = %q, want ios", got)
+	}
+	if got := wizardDefaultFamily(core.Destination{PlatformFamily: core.PlatformCatalyst}, ""); got != core.PlatformIOS {
+		t.Fatalf("catalyst should map to ios, got %q", got)
+	}
+}
+
+func TestWizardTargetOptionsFiltersByFamily(t *testing.T) {
+	candidates := []core.DestinationCandidate{
+		{ID: "tv", Name: "Apple TV", PlatformFamily: core.PlatformTvOS, TargetType: core.TargetSimulator, Available: true},
+		{ID: "phone", Name: "iPhone 16", PlatformFamily: core.PlatformIOS, TargetType: core.TargetSimulator, Available: true},
+		{ID: "gone", Name: "iPhone 8", PlatformFamily: core.PlatformIOS, TargetType: core.TargetSimulator, Available: false},
+		{ID: "dev", Name: "My iPhone", PlatformFamily: core.PlatformIOS, TargetType: core.TargetDevice, Available: true},
+	}
+	opts := wizardTargetOptions(candidates, wizardDestFilter{Family: string(core.PlatformIOS), Kind: string(core.DestSimulator)})
+	if len(opts) != 1 || opts[0].Value != "phone" {
+		t.Fatalf("unexpected simulator options: %+v", opts)
+	}
+	opts = wizardTargetOptions(candidates, wizardDestFilter{Family: string(core.PlatformTvOS), Kind: string(core.DestDevice)})
+	if len(opts) != 1 || opts[0].Value != "" {
+		t.Fatalf("expected placeholder option, got %+v", opts)
+	}
+}
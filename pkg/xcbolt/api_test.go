@@ -0,0 +1,116 @@
+package xcbolt
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"strings"
+	"testing"
+)
+
+// forbiddenAPIPackages must never be reachable from the public API.
+var forbiddenAPIPackages = []string{
+	"github.com/xcbolt/xcbolt/internal/tui",
+	"github.com/xcbolt/xcbolt/internal/cli",
+	"github.com/charmbracelet/",
+	"github.com/spf13/cobra",
+}
+
+// TestPublicAPIExcludesUITypes type-checks this package and walks every
+// exported declaration (fields, methods, and signatures included) to make sure
+// no UI or CLI type leaks into the public surface.
+func TestPublicAPIExcludesUITypes(t *testing.T) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var files []*ast.File
+	for _, f := range pkgs["xcbolt"].Files {
+		files = append(files, f)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	pkg, err := conf.Check("github.com/xcbolt/xcbolt/pkg/xcbolt", fset, files, nil)
+	if err != nil {
+		t.Fatalf("type-check: %v", err)
+	}
+
+	w := apiWalker{seen: map[types.Type]bool{}}
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		obj := scope.Lookup(name)
+		if !obj.Exported() {
+			continue
+		}
+		w.walk(name, obj.Type())
+	}
+	for _, leak := range w.leaks {
+		t.Errorf("public API exposes %s", leak)
+	}
+	if len(w.seen) < 10 {
+		t.Fatalf("walked only %d types; the check is not looking at the API", len(w.seen))
+	}
+}
+
+type apiWalker struct {
+	seen  map[types.Type]bool
+	leaks []string
+}
+
+func (w *apiWalker) walk(path string, typ types.Type) {
+	if w.seen[typ] {
+		return
+	}
+	w.seen[typ] = true
+	switch t := typ.(type) {
+	case *types.Named:
+		if pkg := t.Obj().Pkg(); pkg != nil {
+			for _, bad := range forbiddenAPIPackages {
+				if strings.HasPrefix(pkg.Path(), bad) {
+					w.leaks = append(w.leaks, path+" ("+pkg.Path()+"."+t.Obj().Name()+")")
+				}
+			}
+		}
+		for i := 0; i < t.NumMethods(); i++ {
+			if m := t.Method(i); m.Exported() {
+				w.walk(path+"."+m.Name(), m.Type())
+			}
+		}
+		w.walk(path, t.Underlying())
+	case *types.Alias:
+		w.walk(path, types.Unalias(t))
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			if f := t.Field(i); f.Exported() {
+				w.walk(path+"."+f.Name(), f.Type())
+			}
+		}
+	case *types.Pointer:
+		w.walk(path, t.Elem())
+	case *types.Slice:
+		w.walk(path, t.Elem())
+	case *types.Array:
+		w.walk(path, t.Elem())
+	case *types.Map:
+		w.walk(path, t.Key())
+		w.walk(path, t.Elem())
+	case *types.Chan:
+		w.walk(path, t.Elem())
+	case *types.Signature:
+		for i := 0; i < t.Params().Len(); i++ {
+			w.walk(path+"()", t.Params().At(i).Type())
+		}
+		for i := 0; i < t.Results().Len(); i++ {
+			w.walk(path+"()", t.Results().At(i).Type())
+		}
+	case *types.Interface:
+		for i := 0; i < t.NumMethods(); i++ {
+			w.walk(path+"."+t.Method(i).Name(), t.Method(i).Type())
+		}
+	}
+}
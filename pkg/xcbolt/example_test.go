@@ -0,0 +1,75 @@
+package xcbolt_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/xcbolt/xcbolt/pkg/xcbolt"
+)
+
+// Build the project in the current directory and report compiler errors as
+// they stream in.
+func ExampleBuild() {
+	root, _ := os.Getwd()
+	cfg, err := xcbolt.LoadConfig(root, "")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	errors := 0
+	emit := xcbolt.EmitterFunc(func(ev xcbolt.Event) {
+		switch ev.Type {
+		case xcbolt.EventError:
+			errors++
+			if ev.Err != nil {
+				log.Printf("%s: %s", ev.Err.Code, ev.Err.Message)
+			}
+		case xcbolt.EventStatus:
+			log.Print(ev.Msg)
+		}
+	})
+
+	res, cfg, err := xcbolt.Build(context.Background(), root, cfg, emit)
+	if err != nil {
+		log.Fatalf("build failed with %d error(s): %v", errors, err)
+	}
+	fmt.Println("built", res.AppPath, "in", res.Duration)
+
+	// Keep the resolved scheme/destination for the next run.
+	_ = xcbolt.SaveConfig(root, "", cfg)
+}
+
+// Run a subset of tests and print the xcresult counts.
+func ExampleTest() {
+	root, _ := os.Getwd()
+	cfg, err := xcbolt.LoadConfig(root, "")
+	if err != nil {
+		log.Fatal(err)
+	}
+	cfg.Destination = xcbolt.Destination{Kind: xcbolt.DestSimulator, Name: "iPhone 16"}
+
+	res, _, err := xcbolt.Test(context.Background(), root, cfg, xcbolt.TestOptions{
+		OnlyTesting: []string{"AppTests/LoginTests"},
+	}, xcbolt.NewNDJSONEmitter(os.Stderr))
+	s := res.Summary
+	fmt.Printf("%d passed, %d failed, %d skipped\n", s.Passed, s.Failed, s.Skipped)
+	if err != nil {
+		os.Exit(1)
+	}
+}
+
+// List schemes and simulators without building.
+func ExampleDiscoverContext() {
+	root, _ := os.Getwd()
+	cfg, _ := xcbolt.LoadConfig(root, "")
+	info, _, err := xcbolt.DiscoverContext(context.Background(), root, cfg, nil, xcbolt.ContextOptions{AllowXcodebuildList: true})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("schemes:", info.Schemes)
+	for _, sim := range info.Simulators {
+		fmt.Println(sim.Name, sim.OSVersion)
+	}
+}
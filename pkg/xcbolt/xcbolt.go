@@ -0,0 +1,222 @@
+// Package xcbolt runs xcbolt's build, run, and test operations from other Go
+// programs without shelling out to the xcbolt binary.
+//
+// Operations report progress as a stream of Events delivered to an Emitter.
+// They are the same events `xcbolt --json` prints, so a CI helper can react
+// to errors, warnings, and results as they happen:
+//
+//	cfg, err := xcbolt.LoadConfig(root, "")
+//	if err != nil {
+//		return err
+//	}
+//	res, _, err := xcbolt.Build(ctx, root, cfg, xcbolt.EmitterFunc(func(ev xcbolt.Event) {
+//		if ev.Type == xcbolt.EventError {
+//			log.Printf("%s: %s", ev.Err.Code, ev.Err.Message)
+//		}
+//	}))
+//
+// The types are aliases of xcbolt's internal ones, so values move between
+// this package and the CLI's config files unchanged. Operations return the
+// updated Config (resolved scheme, destination, last result bundle); pass it
+// to SaveConfig to persist it like the CLI does.
+package xcbolt
+
+import (
+	"context"
+	"io"
+
+	"github.com/xcbolt/xcbolt/internal/core"
+)
+
+// Configuration (.xcbolt/config.json).
+type (
+	Config           = core.Config
+	Destination      = core.Destination
+	DestinationKind  = core.DestinationKind
+	XcodebuildConfig = core.XcodebuildConfig
+	LaunchConfig     = core.LaunchConfig
+	LaunchPreset     = core.LaunchPreset
+	TestConfig       = core.TestConfig
+	HooksConfig      = core.HooksConfig
+	GenerateConfig   = core.GenerateConfig
+	RedactConfig     = core.RedactConfig
+	// ConfigProblem is an unknown or deprecated key found by CheckConfig.
+	ConfigProblem = core.ConfigProblem
+)
+
+// Destination kinds.
+const (
+	DestAuto      = core.DestAuto
+	DestSimulator = core.DestSimulator
+	DestDevice    = core.DestDevice
+	DestMacOS     = core.DestMacOS
+	DestCatalyst  = core.DestCatalyst
+)
+
+// Project context and operation results.
+type (
+	ContextInfo    = core.ContextInfo
+	ContextOptions = core.ContextOptions
+	BuildResult    = core.BuildResult
+	CompileTime    = core.CompileTime
+	// TargetBuildInfo is an entry of BuildResult.Targets.
+	TargetBuildInfo = core.TargetBuildInfo
+	ProductInfo     = core.ProductInfo
+	RunResult       = core.RunResult
+	TestResult      = core.TestResult
+	TestSummary     = core.TestSummary
+	// CoverageSummary is TestResult.Coverage (test.codeCoverage).
+	CoverageSummary = core.CoverageSummary
+	TargetCoverage  = core.TargetCoverage
+	// TestChanges is TestResult.Changes (failures compared with the last run).
+	TestChanges = core.TestChanges
+	// StressResult is the outcome of StressTest.
+	StressResult = core.StressResult
+	// RunPhase is RunResult.Phase: "build", "install", "launch", or
+	// "console".
+	RunPhase = core.RunPhase
+	// RunPhaseCanceledError is Run's error when it was canceled after the
+	// build succeeded; RunResult.AppPath names the built app.
+	RunPhaseCanceledError = core.RunPhaseCanceledError
+	// DevicectlError is Run's error when installing or launching on a
+	// device failed; Category is one of the DeviceFailure constants, or ""
+	// when unknown.
+	DevicectlError = core.DevicectlError
+	// XcodebuildExitError is Build's and Test's error when xcodebuild
+	// failed rather than being canceled; Failure is one of the Failure
+	// constants and Signal names the terminating signal, if any.
+	XcodebuildExitError = core.XcodebuildExitError
+	XcodebuildFailure   = core.XcodebuildFailure
+)
+
+// How xcodebuild failed (XcodebuildExitError.Failure, BuildResult.Failure,
+// TestResult.Failure).
+const (
+	FailureBuild  = core.FailureBuild
+	FailureCrash  = core.FailureCrash
+	FailureSignal = core.FailureSignal
+)
+
+// Known device install/launch failures (DevicectlError.Category).
+const (
+	DeviceFailureLocked        = core.DeviceFailureLocked
+	DeviceFailurePasscode      = core.DeviceFailurePasscode
+	DeviceFailureDeveloperMode = core.DeviceFailureDeveloperMode
+	DeviceFailureUntrusted     = core.DeviceFailureUntrusted
+	DeviceFailureStorage       = core.DeviceFailureStorage
+)
+
+// Events.
+type (
+	// Event is one progress, log, or result record (see the README's Event
+	// schema).
+	Event = core.Event
+	// ErrorObject is the structured error carried by "error" events.
+	ErrorObject = core.ErrorObject
+	// Emitter receives events. Operations call Emit from their own
+	// goroutines, so implementations must not block for long.
+	Emitter = core.Emitter
+)
+
+// EventSchemaVersion is the version stamped on emitted events.
+const EventSchemaVersion = core.EventSchemaVersion
+
+// Event types.
+const (
+	EventStatus   = "status"
+	EventProgress = "progress"
+	EventLog      = "log"
+	EventLogRaw   = "log_raw"
+	EventWarning  = "warning"
+	EventError    = "error"
+	EventResult   = "result"
+)
+
+// EmitterFunc adapts a function to the Emitter interface.
+type EmitterFunc func(ev Event)
+
+// Emit calls f(ev).
+func (f EmitterFunc) Emit(ev Event) { f(ev) }
+
+// NewNDJSONEmitter writes one JSON event per line, like `xcbolt --json`.
+func NewNDJSONEmitter(w io.Writer) Emitter {
+	return core.NewNDJSONEmitter(w, EventSchemaVersion)
+}
+
+// NewTextEmitter writes human-readable messages, like plain `xcbolt` output.
+func NewTextEmitter(w io.Writer) Emitter {
+	return core.NewTextEmitter(w)
+}
+
+// RedactEmitter replaces secrets in events with "•••redacted•••" before
+// passing them to emit, as the CLI does: the built-in patterns (AWS keys,
+// bearer tokens, TOKEN=... assignments) plus cfg.Patterns. Invalid patterns
+// are skipped.
+func RedactEmitter(emit Emitter, cfg RedactConfig) Emitter {
+	r, _ := core.NewRedactor(cfg)
+	return core.RedactEmitter(emit, r)
+}
+
+// DefaultConfig returns the configuration used when projectRoot has no
+// .xcbolt/config.json.
+func DefaultConfig(projectRoot string) Config {
+	return core.DefaultConfig(projectRoot)
+}
+
+// LoadConfig reads .xcbolt/config.json under projectRoot, or configPath when
+// set. A missing file yields DefaultConfig.
+func LoadConfig(projectRoot, configPath string) (Config, error) {
+	return core.LoadConfig(projectRoot, configPath)
+}
+
+// SaveConfig writes cfg to .xcbolt/config.json under projectRoot, or
+// configPath when set.
+func SaveConfig(projectRoot, configPath string, cfg Config) error {
+	return core.SaveConfig(projectRoot, configPath, cfg)
+}
+
+// CheckConfig reports the unknown and deprecated keys of the config file
+// (nil when it doesn't exist).
+func CheckConfig(projectRoot, configPath string) ([]ConfigProblem, error) {
+	return core.CheckConfig(projectRoot, configPath)
+}
+
+// DiscoverContext lists the workspaces, projects, schemes, configurations,
+// simulators, and devices available for projectRoot, filling in cfg defaults
+// where the choice is unambiguous.
+func DiscoverContext(ctx context.Context, projectRoot string, cfg Config, emit Emitter, opts ContextOptions) (ContextInfo, Config, error) {
+	return core.DiscoverContext(ctx, projectRoot, cfg, emit, opts)
+}
+
+// Build runs xcodebuild build for cfg, including the configured hooks.
+func Build(ctx context.Context, projectRoot string, cfg Config, emit Emitter) (BuildResult, Config, error) {
+	return core.Build(ctx, projectRoot, cfg, emit)
+}
+
+// Run builds, installs, and launches the app. With console set, Run stays
+// attached to the app's output until ctx is canceled or the app exits.
+func Run(ctx context.Context, projectRoot string, cfg Config, console bool, emit Emitter) (RunResult, Config, error) {
+	return core.Run(ctx, projectRoot, cfg, console, emit)
+}
+
+// TestOptions narrows a test run.
+type TestOptions struct {
+	// OnlyTesting and SkipTesting take xcodebuild identifiers
+	// (Target[/Class[/method]]).
+	OnlyTesting []string
+	SkipTesting []string
+}
+
+// Test runs xcodebuild test and summarizes the result bundle.
+func Test(ctx context.Context, projectRoot string, cfg Config, opts TestOptions, emit Emitter) (TestResult, Config, error) {
+	return core.Test(ctx, projectRoot, cfg, opts.OnlyTesting, opts.SkipTesting, emit)
+}
+
+// StressOptions sets how many times StressTest runs the tests.
+type StressOptions = core.StressOptions
+
+// StressTest runs the tests opts.Iterations times with retries off to catch
+// flaky ones. A canceled run still returns the iterations that finished.
+func StressTest(ctx context.Context, projectRoot string, cfg Config, opts TestOptions, stress StressOptions, emit Emitter) (StressResult, Config, error) {
+	return core.StressTest(ctx, projectRoot, cfg, opts.OnlyTesting, opts.SkipTesting, stress, emit)
+}